@@ -0,0 +1,303 @@
+package accounting
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Permission is an action a role allows its users to take
+type Permission string
+
+const (
+	PermissionPostTransactions Permission = "POST_TRANSACTIONS"
+	PermissionClosePeriods     Permission = "CLOSE_PERIODS"    // close and reopen periods
+	PermissionManageAMLCases   Permission = "MANAGE_AML_CASES" // work alerts and investigations
+	PermissionApproveBudgets   Permission = "APPROVE_BUDGETS"
+	PermissionManageAccess     Permission = "MANAGE_ACCESS"  // create roles and users and assign roles
+	PermissionManageScripts    Permission = "MANAGE_SCRIPTS" // register, change and switch scripting hooks
+	PermissionViewPII          Permission = "VIEW_PII"       // see customer names, tax IDs and SAR numbers unmasked in exports
+)
+
+// knownPermissions lists the permissions a role may grant
+var knownPermissions = []Permission{
+	PermissionPostTransactions,
+	PermissionClosePeriods,
+	PermissionManageAMLCases,
+	PermissionApproveBudgets,
+	PermissionManageAccess,
+	PermissionManageScripts,
+	PermissionViewPII,
+}
+
+// Role is a named set of permissions
+type Role struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Permissions []Permission `json:"permissions"`
+	CreatedBy   string       `json:"created_by"`
+	CreatedAt   time.Time    `json:"created_at"`
+}
+
+// User is a person or system identified by the userID passed to engine methods
+type User struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	RoleIDs   []string  `json:"role_ids"`
+	Active    bool      `json:"active"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AccessDenial records an attempt refused for lack of permission
+type AccessDenial struct {
+	UserID     string     `json:"user_id"`
+	Permission Permission `json:"permission"`
+	Action     string     `json:"action"`
+	Reason     string     `json:"reason"`
+	DeniedAt   time.Time  `json:"denied_at"`
+}
+
+// roleAssignment is the payload of role assignment and revocation events
+type roleAssignment struct {
+	UserID string `json:"user_id"`
+	RoleID string `json:"role_id"`
+}
+
+// AccessControlService keeps users and their roles and authorizes engine actions.
+// Authorization starts once the first user is registered; until then every userID
+// may do anything, as before access control existed.
+type AccessControlService struct {
+	storage    *Storage
+	eventStore *EventStore
+}
+
+// NewAccessControlService creates a new access control service
+func NewAccessControlService(storage *Storage, eventStore *EventStore) *AccessControlService {
+	return &AccessControlService{
+		storage:    storage,
+		eventStore: eventStore,
+	}
+}
+
+// CreateRole defines a role granting a set of permissions
+func (acs *AccessControlService) CreateRole(role *Role, userID string) error {
+	if role.Name == "" {
+		return fmt.Errorf("role name is required")
+	}
+	if len(role.Permissions) == 0 {
+		return fmt.Errorf("role %s grants no permissions", role.Name)
+	}
+	for _, permission := range role.Permissions {
+		if !slices.Contains(knownPermissions, permission) {
+			return fmt.Errorf("unknown permission %s", permission)
+		}
+	}
+	if err := acs.Authorize(userID, PermissionManageAccess, "create role "+role.Name); err != nil {
+		return err
+	}
+
+	if err := acs.storage.assignID(&role.ID, "role", BucketRoles); err != nil {
+		return err
+	}
+	role.CreatedBy = userID
+	role.CreatedAt = time.Now()
+
+	_, err := acs.eventStore.CreateEvent(EventCreateRole, role, role.CreatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create role event: %w", err)
+	}
+	if err := acs.storage.SaveRole(role); err != nil {
+		return fmt.Errorf("failed to save role: %w", err)
+	}
+	return nil
+}
+
+// CreateUser registers a user under the ID they pass to engine methods. Registering
+// the first user turns authorization on, so that user must be able to manage access.
+func (acs *AccessControlService) CreateUser(user *User, userID string) error {
+	if user.ID == "" {
+		return fmt.Errorf("user ID is required")
+	}
+	if _, err := acs.storage.GetUser(user.ID); err == nil {
+		return fmt.Errorf("user %s already exists", user.ID)
+	}
+	for _, roleID := range user.RoleIDs {
+		if _, err := acs.storage.GetRole(roleID); err != nil {
+			return fmt.Errorf("invalid role: %w", err)
+		}
+	}
+
+	users, err := acs.storage.GetAllUsers()
+	if err != nil {
+		return fmt.Errorf("failed to get users: %w", err)
+	}
+	if len(users) == 0 {
+		permissions, err := acs.rolePermissions(user.RoleIDs)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(permissions, PermissionManageAccess) {
+			return fmt.Errorf("the first user must be able to manage access")
+		}
+	} else if err := acs.Authorize(userID, PermissionManageAccess, "create user "+user.ID); err != nil {
+		return err
+	}
+
+	user.Active = true
+	user.CreatedBy = userID
+	user.CreatedAt = time.Now()
+
+	_, err = acs.eventStore.CreateEvent(EventCreateUser, user, user.CreatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create user event: %w", err)
+	}
+	if err := acs.storage.SaveUser(user); err != nil {
+		return fmt.Errorf("failed to save user: %w", err)
+	}
+	return nil
+}
+
+// AssignRole grants a role to a user
+func (acs *AccessControlService) AssignRole(targetUserID, roleID, userID string) error {
+	if err := acs.Authorize(userID, PermissionManageAccess, "assign role "+roleID+" to "+targetUserID); err != nil {
+		return err
+	}
+	user, err := acs.storage.GetUser(targetUserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if _, err := acs.storage.GetRole(roleID); err != nil {
+		return fmt.Errorf("invalid role: %w", err)
+	}
+	if slices.Contains(user.RoleIDs, roleID) {
+		return nil
+	}
+	user.RoleIDs = append(user.RoleIDs, roleID)
+
+	_, err = acs.eventStore.CreateEvent(EventAssignRole, roleAssignment{UserID: targetUserID, RoleID: roleID}, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create role assignment event: %w", err)
+	}
+	return acs.storage.SaveUser(user)
+}
+
+// RevokeRole takes a role away from a user
+func (acs *AccessControlService) RevokeRole(targetUserID, roleID, userID string) error {
+	if err := acs.Authorize(userID, PermissionManageAccess, "revoke role "+roleID+" from "+targetUserID); err != nil {
+		return err
+	}
+	user, err := acs.storage.GetUser(targetUserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	i := slices.Index(user.RoleIDs, roleID)
+	if i < 0 {
+		return fmt.Errorf("user %s does not hold role %s", targetUserID, roleID)
+	}
+	user.RoleIDs = slices.Delete(user.RoleIDs, i, i+1)
+
+	_, err = acs.eventStore.CreateEvent(EventRevokeRole, roleAssignment{UserID: targetUserID, RoleID: roleID}, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create role revocation event: %w", err)
+	}
+	return acs.storage.SaveUser(user)
+}
+
+// HasPermission reports whether userID holds a permission through any of their
+// roles. Everyone holds every permission until the first user is registered.
+func (acs *AccessControlService) HasPermission(userID string, permission Permission) (bool, error) {
+	reason, err := acs.denialReason(userID, permission)
+	return reason == "", err
+}
+
+// Authorize checks that userID may take an action needing permission. Refused
+// attempts are recorded as ACCESS_DENIED events.
+func (acs *AccessControlService) Authorize(userID string, permission Permission, action string) error {
+	reason, err := acs.denialReason(userID, permission)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		return nil
+	}
+
+	denial := &AccessDenial{
+		UserID:     userID,
+		Permission: permission,
+		Action:     action,
+		Reason:     reason,
+		DeniedAt:   time.Now(),
+	}
+	if _, err := acs.eventStore.CreateEvent(EventAccessDenied, denial, denial.DeniedAt, userID); err != nil {
+		return fmt.Errorf("failed to create access denied event: %w", err)
+	}
+	return &AccessDeniedError{Denial: denial}
+}
+
+// GetAccessDenials returns the refused attempts recorded between from and to,
+// oldest first
+func (acs *AccessControlService) GetAccessDenials(from, to time.Time) ([]*AccessDenial, error) {
+	events, err := acs.eventStore.GetEvents(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	var denials []*AccessDenial
+	for _, event := range events {
+		if event.EventType != EventAccessDenied {
+			continue
+		}
+		var denial AccessDenial
+		if err := json.Unmarshal(event.Payload, &denial); err != nil {
+			return nil, fmt.Errorf("failed to decode access denied event %s: %w", event.ID, err)
+		}
+		denials = append(denials, &denial)
+	}
+	sort.SliceStable(denials, func(i, j int) bool {
+		return denials[i].DeniedAt.Before(denials[j].DeniedAt)
+	})
+	return denials, nil
+}
+
+// denialReason explains why userID lacks permission, or returns "" when they have it
+func (acs *AccessControlService) denialReason(userID string, permission Permission) (string, error) {
+	users, err := acs.storage.GetAllUsers()
+	if err != nil {
+		return "", fmt.Errorf("failed to get users: %w", err)
+	}
+	if len(users) == 0 {
+		return "", nil
+	}
+
+	i := slices.IndexFunc(users, func(user *User) bool { return user.ID == userID })
+	if i < 0 {
+		return "unknown user", nil
+	}
+	if !users[i].Active {
+		return "user is inactive", nil
+	}
+	permissions, err := acs.rolePermissions(users[i].RoleIDs)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains(permissions, permission) {
+		return fmt.Sprintf("missing permission %s", permission), nil
+	}
+	return "", nil
+}
+
+// rolePermissions collects the permissions granted by a set of roles
+func (acs *AccessControlService) rolePermissions(roleIDs []string) ([]Permission, error) {
+	var permissions []Permission
+	for _, roleID := range roleIDs {
+		role, err := acs.storage.GetRole(roleID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get role: %w", err)
+		}
+		permissions = append(permissions, role.Permissions...)
+	}
+	return permissions, nil
+}
@@ -0,0 +1,96 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessControl(t *testing.T) {
+	dbFile := "test_access_control.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	admin, clerk, analyst := "admin", "clerk", "analyst"
+	require.NoError(t, engine.CreateStandardAccounts(admin))
+
+	sale := func() *Transaction {
+		return &Transaction{
+			Description: "Sale",
+			ValidTime:   time.Now(),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: 700, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 700, Currency: "USD"}},
+			},
+		}
+	}
+	open := sale()
+	require.NoError(t, engine.CreateTransaction(open, clerk))
+	require.NoError(t, engine.PostTransaction(open.ID, clerk), "anyone may post before users are registered")
+
+	administrators := &Role{Name: "Administrators", Permissions: []Permission{PermissionManageAccess}}
+	bookkeepers := &Role{Name: "Bookkeepers", Permissions: []Permission{PermissionPostTransactions}}
+	compliance := &Role{Name: "Compliance", Permissions: []Permission{PermissionManageAMLCases}}
+
+	t.Run("bootstraps roles and users", func(t *testing.T) {
+		assert.ErrorContains(t, engine.CreateRole(&Role{Name: "Auditors", Permissions: []Permission{"READ_EVERYTHING"}}, admin), "unknown permission")
+		for _, role := range []*Role{administrators, bookkeepers, compliance} {
+			require.NoError(t, engine.CreateRole(role, admin))
+		}
+
+		assert.ErrorContains(t, engine.CreateUser(&User{ID: clerk, RoleIDs: []string{bookkeepers.ID}}, admin), "first user must be able to manage access")
+		require.NoError(t, engine.CreateUser(&User{ID: admin, Name: "Admin", RoleIDs: []string{administrators.ID}}, admin))
+
+		assert.ErrorContains(t, engine.CreateUser(&User{ID: analyst}, clerk), "unknown user")
+		require.NoError(t, engine.CreateUser(&User{ID: clerk, Name: "Clerk", RoleIDs: []string{bookkeepers.ID}}, admin))
+		require.NoError(t, engine.CreateUser(&User{ID: analyst, Name: "Analyst"}, admin))
+		assert.ErrorContains(t, engine.CreateUser(&User{ID: analyst}, admin), "already exists")
+	})
+
+	t.Run("enforces permissions", func(t *testing.T) {
+		txn := sale()
+		require.NoError(t, engine.CreateTransaction(txn, clerk))
+		assert.ErrorContains(t, engine.PostTransaction(txn.ID, analyst), "missing permission POST_TRANSACTIONS")
+		assert.ErrorContains(t, engine.PostTransaction(txn.ID, "stranger"), "unknown user")
+		require.NoError(t, engine.PostTransaction(txn.ID, clerk))
+
+		period := &Period{Name: "Now", Start: time.Now().AddDate(0, -1, 0), End: time.Now().AddDate(0, 1, 0)}
+		require.NoError(t, engine.CreatePeriod(period, admin))
+		assert.ErrorContains(t, engine.ClosePeriod(period.ID, true, clerk), "may not close period")
+		assert.ErrorContains(t, engine.ApproveBudgetRequest("request", clerk, &Amount{Value: 100, Currency: "USD"}, ""), "missing permission APPROVE_BUDGETS")
+
+		alert := &AMLAlert{Title: "Structuring", RiskLevel: RiskHigh}
+		require.NoError(t, engine.GetAMLService().RaiseAlert(alert))
+		assert.ErrorContains(t, engine.UpdateAMLAlertStatus(alert.ID, "UNDER_REVIEW", analyst), "MANAGE_AML_CASES")
+
+		require.NoError(t, engine.AssignRole(analyst, compliance.ID, admin))
+		require.NoError(t, engine.UpdateAMLAlertStatus(alert.ID, "UNDER_REVIEW", analyst))
+		_, err := engine.CreateAMLInvestigation(alert.ID, analyst)
+		require.NoError(t, err)
+
+		require.NoError(t, engine.RevokeRole(analyst, compliance.ID, admin))
+		assert.ErrorContains(t, engine.AddAMLInvestigationNote(alert.ID, "Called the customer", analyst), "MANAGE_AML_CASES")
+		assert.ErrorContains(t, engine.AssignRole(analyst, compliance.ID, clerk), "MANAGE_ACCESS")
+	})
+
+	t.Run("audits denied attempts", func(t *testing.T) {
+		denials, err := engine.GetAccessDenials(time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Len(t, denials, 8)
+		assert.Equal(t, clerk, denials[0].UserID)
+		assert.Equal(t, PermissionManageAccess, denials[0].Permission)
+		assert.Equal(t, "create user analyst", denials[0].Action)
+		assert.Equal(t, "unknown user", denials[0].Reason)
+		assert.Equal(t, PermissionPostTransactions, denials[1].Permission)
+
+		ok, err := engine.GetAccessControlService().HasPermission(clerk, PermissionPostTransactions)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
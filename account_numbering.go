@@ -0,0 +1,242 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Account Numbering
+// ----------------------------------------------------------------------------
+
+// AccountCodeRange is the block of codes reserved for one account type
+type AccountCodeRange struct {
+	AccountType AccountType `json:"account_type"`
+	From        int64       `json:"from"`
+	To          int64       `json:"to"` // inclusive
+}
+
+// AccountNumberingScheme sets how account codes are numbered: how many digits a code
+// has, which block of codes each account type draws from, and how far apart a
+// parent's children are numbered. Account IDs are unaffected; codes are a second,
+// human-facing key.
+type AccountNumberingScheme struct {
+	Name       string             `json:"name"`
+	CodeLength int                `json:"code_length"` // digits per code, 0 for any
+	Ranges     []AccountCodeRange `json:"ranges"`
+	ChildStep  int64              `json:"child_step"` // gap between child codes, 1 when unset
+}
+
+// AccountCodeViolation is an existing account whose code does not fit a scheme
+type AccountCodeViolation struct {
+	AccountID string `json:"account_id"`
+	Code      string `json:"code"`
+	Reason    string `json:"reason"`
+}
+
+// StandardAccountNumbering is the classic four-digit scheme with a thousand codes
+// per account type. New accounts are numbered from it when no scheme is configured.
+func StandardAccountNumbering() *AccountNumberingScheme {
+	return &AccountNumberingScheme{
+		Name:       "STANDARD",
+		CodeLength: 4,
+		Ranges: []AccountCodeRange{
+			{AccountType: Asset, From: 1000, To: 1999},
+			{AccountType: Liability, From: 2000, To: 2999},
+			{AccountType: Equity, From: 3000, To: 3999},
+			{AccountType: Income, From: 4000, To: 4999},
+			{AccountType: Expense, From: 5000, To: 5999},
+		},
+		ChildStep: 1,
+	}
+}
+
+// Validate checks that the scheme's ranges are well formed, fit the code length and
+// do not overlap
+func (s *AccountNumberingScheme) Validate() error {
+	if s.CodeLength < 0 {
+		return fmt.Errorf("code length cannot be negative")
+	}
+	if s.ChildStep < 0 {
+		return fmt.Errorf("child step cannot be negative")
+	}
+	seen := make(map[AccountType]bool, len(s.Ranges))
+	for i, r := range s.Ranges {
+		if seen[r.AccountType] {
+			return fmt.Errorf("account type %s has more than one code range", r.AccountType)
+		}
+		seen[r.AccountType] = true
+		if r.From < 0 || r.From > r.To {
+			return fmt.Errorf("code range %d-%d for %s is invalid", r.From, r.To, r.AccountType)
+		}
+		if s.CodeLength > 0 && (len(strconv.FormatInt(r.From, 10)) != s.CodeLength || len(strconv.FormatInt(r.To, 10)) != s.CodeLength) {
+			return fmt.Errorf("code range %d-%d for %s does not have %d digits", r.From, r.To, r.AccountType, s.CodeLength)
+		}
+		for _, other := range s.Ranges[:i] {
+			if r.From <= other.To && other.From <= r.To {
+				return fmt.Errorf("code ranges for %s and %s overlap", other.AccountType, r.AccountType)
+			}
+		}
+	}
+	return nil
+}
+
+// codeRange returns the block of codes for an account type
+func (s *AccountNumberingScheme) codeRange(accountType AccountType) (AccountCodeRange, bool) {
+	for _, r := range s.Ranges {
+		if r.AccountType == accountType {
+			return r, true
+		}
+	}
+	return AccountCodeRange{}, false
+}
+
+// childStep returns the gap between child codes
+func (s *AccountNumberingScheme) childStep() int64 {
+	if s.ChildStep <= 0 {
+		return 1
+	}
+	return s.ChildStep
+}
+
+// CheckCode reports why a code does not fit the scheme for an account type, or nil
+func (s *AccountNumberingScheme) CheckCode(code string, accountType AccountType) error {
+	if s.CodeLength > 0 && len(code) != s.CodeLength {
+		return fmt.Errorf("account code %q must have %d digits", code, s.CodeLength)
+	}
+	value, err := strconv.ParseInt(code, 10, 64)
+	if err != nil || strings.Trim(code, "0123456789") != "" {
+		return fmt.Errorf("account code %q must be numeric", code)
+	}
+	r, ok := s.codeRange(accountType)
+	if !ok {
+		return fmt.Errorf("numbering scheme %s has no code range for %s accounts", s.Name, accountType)
+	}
+	if value < r.From || value > r.To {
+		return fmt.Errorf("account code %s is outside the %d-%d range for %s accounts", code, r.From, r.To, accountType)
+	}
+	return nil
+}
+
+// ApplyCompanySettings sets the numbering scheme enforced on new account codes. With
+// no scheme configured, codes are free-form and generated codes follow the standard
+// scheme.
+func (cs *ChartOfAccountsService) ApplyCompanySettings(settings *CompanySettings) error {
+	var scheme *AccountNumberingScheme
+	if settings != nil && settings.AccountNumbering != nil {
+		if err := settings.AccountNumbering.Validate(); err != nil {
+			return fmt.Errorf("invalid account numbering scheme: %w", err)
+		}
+		scheme = settings.AccountNumbering
+	}
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.numbering = scheme
+	return nil
+}
+
+// numberingScheme returns the configured scheme and whether codes are checked
+// against it, falling back to the standard scheme for numbering only
+func (cs *ChartOfAccountsService) numberingScheme() (*AccountNumberingScheme, bool) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	if cs.numbering == nil {
+		return StandardAccountNumbering(), false
+	}
+	return cs.numbering, true
+}
+
+// CheckAccountCodes lists the existing accounts whose codes do not fit the configured
+// numbering scheme, for example before adopting a new scheme
+func (cs *ChartOfAccountsService) CheckAccountCodes() ([]AccountCodeViolation, error) {
+	scheme, _ := cs.numberingScheme()
+	accounts, err := cs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	var violations []AccountCodeViolation
+	for _, account := range accounts {
+		if err := scheme.CheckCode(account.Code, account.Type); err != nil {
+			violations = append(violations, AccountCodeViolation{AccountID: account.ID, Code: account.Code, Reason: err.Error()})
+		}
+	}
+	return violations, nil
+}
+
+// GetAccountByCode finds the account with a code
+func (cs *ChartOfAccountsService) GetAccountByCode(code string) (*Account, error) {
+	accounts, err := cs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	for _, account := range accounts {
+		if account.Code == code {
+			return account, nil
+		}
+	}
+	return nil, classify(ErrNotFound, "no account with code %s", code)
+}
+
+// FindAccountsByName lists the accounts whose names contain the text, ignoring case,
+// in code order
+func (cs *ChartOfAccountsService) FindAccountsByName(text string) ([]*Account, error) {
+	accounts, err := cs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	text = strings.ToLower(text)
+	var matches []*Account
+	for _, account := range accounts {
+		if strings.Contains(strings.ToLower(account.Name), text) {
+			matches = append(matches, account)
+		}
+	}
+	sortAccountsByCode(matches)
+	return matches, nil
+}
+
+// LookupAccount resolves a reference to an account by ID, then code, then exact name
+// ignoring case. A name shared by several accounts is ambiguous and rejected.
+func (cs *ChartOfAccountsService) LookupAccount(ref string) (*Account, error) {
+	accounts, err := cs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	for _, account := range accounts {
+		if account.ID == ref {
+			return account, nil
+		}
+	}
+	for _, account := range accounts {
+		if account.Code == ref {
+			return account, nil
+		}
+	}
+	var named []*Account
+	for _, account := range accounts {
+		if strings.EqualFold(account.Name, ref) {
+			named = append(named, account)
+		}
+	}
+	switch len(named) {
+	case 0:
+		return nil, classify(ErrNotFound, "no account with ID, code or name %q", ref)
+	case 1:
+		return named[0], nil
+	default:
+		sortAccountsByCode(named)
+		return nil, fmt.Errorf("account name %q is ambiguous: used by %s and %s", ref, named[0].ID, named[1].ID)
+	}
+}
+
+// sortAccountsByCode orders accounts by code, then ID
+func sortAccountsByCode(accounts []*Account) {
+	sort.Slice(accounts, func(i, j int) bool {
+		if accounts[i].Code != accounts[j].Code {
+			return accounts[i].Code < accounts[j].Code
+		}
+		return accounts[i].ID < accounts[j].ID
+	})
+}
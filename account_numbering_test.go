@@ -0,0 +1,108 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountNumbering(t *testing.T) {
+	dbFile := "test_account_numbering.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	t.Run("codes are free-form without a scheme", func(t *testing.T) {
+		require.NoError(t, engine.CreateAccount(&Account{ID: "suspense", Code: "S-1", Name: "Suspense", Type: Asset}, userID))
+		violations, err := engine.CheckAccountCodes()
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "suspense", violations[0].AccountID)
+	})
+
+	t.Run("rejects invalid schemes", func(t *testing.T) {
+		overlapping := StandardAccountNumbering()
+		overlapping.Ranges[1].From = 1500
+		assert.ErrorContains(t, engine.ApplyCompanySettings(&CompanySettings{AccountNumbering: overlapping}), "overlap")
+
+		short := StandardAccountNumbering()
+		short.Ranges[0].From = 100
+		assert.ErrorContains(t, engine.ApplyCompanySettings(&CompanySettings{AccountNumbering: short}), "does not have 4 digits")
+	})
+
+	scheme := &AccountNumberingScheme{
+		Name:       "SIX_DIGIT",
+		CodeLength: 6,
+		Ranges: []AccountCodeRange{
+			{AccountType: Asset, From: 100000, To: 199999},
+			{AccountType: Expense, From: 500000, To: 500200},
+		},
+		ChildStep: 100,
+	}
+	require.NoError(t, engine.ApplyCompanySettings(&CompanySettings{AccountNumbering: scheme}))
+	defer engine.ApplyCompanySettings(nil)
+
+	t.Run("validates codes against the type's range", func(t *testing.T) {
+		err := engine.CreateAccount(&Account{Code: "100100", Name: "Marketing", Type: Expense}, userID)
+		assert.ErrorContains(t, err, "outside the 500000-500200 range")
+
+		err = engine.CreateAccount(&Account{Code: "5001", Name: "Marketing", Type: Expense}, userID)
+		assert.ErrorContains(t, err, "must have 6 digits")
+
+		err = engine.CreateAccount(&Account{Code: "50O100", Name: "Marketing", Type: Expense}, userID)
+		assert.ErrorContains(t, err, "must be numeric")
+
+		err = engine.CreateAccount(&Account{Code: "300000", Name: "Retained Earnings", Type: Equity}, userID)
+		assert.ErrorContains(t, err, "no code range for EQUITY")
+	})
+
+	t.Run("numbers new accounts from the scheme", func(t *testing.T) {
+		marketing := &Account{ID: "marketing", Name: "Marketing", Type: Expense}
+		require.NoError(t, engine.CreateAccount(marketing, userID))
+		assert.Equal(t, "500000", marketing.Code)
+
+		ads := &Account{ID: "ads", ParentID: "marketing", Name: "Online Ads", Type: Expense}
+		require.NoError(t, engine.CreateAccount(ads, userID))
+		assert.Equal(t, "500100", ads.Code)
+		events := &Account{ID: "events", ParentID: "marketing", Name: "Events", Type: Expense}
+		require.NoError(t, engine.CreateAccount(events, userID))
+		assert.Equal(t, "500200", events.Code)
+
+		err := engine.CreateAccount(&Account{ParentID: "marketing", Name: "Print", Type: Expense}, userID)
+		assert.ErrorContains(t, err, "no free account code left")
+	})
+
+	t.Run("looks accounts up by code or name", func(t *testing.T) {
+		account, err := engine.GetAccountByCode("500100")
+		require.NoError(t, err)
+		assert.Equal(t, "ads", account.ID)
+
+		matches, err := engine.FindAccountsByName("ACCOUNTS")
+		require.NoError(t, err)
+		require.Len(t, matches, 2)
+		assert.Equal(t, []string{"accounts_receivable", "accounts_payable"}, []string{matches[0].ID, matches[1].ID})
+
+		for _, ref := range []string{"cash", "1001", "CASH"} {
+			account, err := engine.LookupAccount(ref)
+			require.NoError(t, err)
+			assert.Equal(t, "cash", account.ID)
+		}
+		account, err = engine.LookupAccount("online ads")
+		require.NoError(t, err)
+		assert.Equal(t, "ads", account.ID)
+
+		require.NoError(t, engine.CreateAccount(&Account{ID: "events_2", Name: "Events", Type: Asset}, userID))
+		_, err = engine.LookupAccount("Events")
+		assert.ErrorContains(t, err, "ambiguous")
+
+		_, err = engine.LookupAccount("nothing")
+		assert.Error(t, err)
+	})
+}
@@ -26,6 +26,16 @@ const (
     DimProject    DimensionKey = "project"
     DimRegion     DimensionKey = "region"
     DimCostCenter DimensionKey = "cost_center"
+    // Customer or vendor on receivable and payable entries.
+    DimCounterparty DimensionKey = "counterparty"
+    // On-chain transaction hash on crypto wallet entries.
+    DimChainTxHash DimensionKey = "chain_tx_hash"
+    // Sales channel (e.g. web, mobile, in-store) on revenue entries.
+    DimChannel DimensionKey = "channel"
+    // Client whose money an entry on a client money account moves.
+    DimClient DimensionKey = "client"
+    // Counterparty company on intercompany entries; consolidation eliminates them.
+    DimIntercompany DimensionKey = "intercompany"
 )
 
 // Dimension is an arbitrary key/value tag that can be attached to any business fact
@@ -80,6 +90,9 @@ type Account struct {
     Currency   Currency   `json:"currency,omitempty"`
     CreatedAt  time.Time  `json:"created_at"`
     ClosedAt   *time.Time `json:"closed_at,omitempty"`
+
+    // Ledger the account is kept in; empty for the general ledger.
+    LedgerID   string     `json:"ledger_id,omitempty"`
 }
 
 // ----------------------------------------------------------------------------
@@ -101,6 +114,9 @@ type Entry struct {
     Type          EntryType   `json:"type"`
     Amount        Amount      `json:"amount"`
     Dimensions    []Dimension `json:"dimensions,omitempty"`
+    Memo          string      `json:"memo,omitempty"`      // line-level note
+    Reference     string      `json:"reference,omitempty"` // external document, e.g. an invoice number
+    Tags          []string    `json:"tags,omitempty"`      // free-form labels, stored lowercase
 }
 
 // ----------------------------------------------------------------------------
@@ -110,10 +126,12 @@ type Entry struct {
 type TransactionStatus string
 
 const (
-    Pending  TransactionStatus = "PENDING"  // not yet posted
-    Posted   TransactionStatus = "POSTED"   // posted to ledger
-    Reversed TransactionStatus = "REVERSED" // reversed by a contra txn
-    InBatch  TransactionStatus = "IN_BATCH" // waiting for nightly batch
+    Pending         TransactionStatus = "PENDING"          // not yet posted
+    Posted          TransactionStatus = "POSTED"           // posted to ledger
+    Reversed        TransactionStatus = "REVERSED"         // reversed by a contra txn
+    InBatch         TransactionStatus = "IN_BATCH"         // waiting for nightly batch
+    PendingApproval TransactionStatus = "PENDING_APPROVAL" // held for journal approval
+    Rejected        TransactionStatus = "REJECTED"         // returned by an approver
 )
 
 type Transaction struct {
@@ -127,10 +145,22 @@ type Transaction struct {
     Status          TransactionStatus `json:"status"`
     Entries         []Entry           `json:"entries"`
 
+    // Ledger the transaction is recorded in; empty for the ledger of its entries.
+    LedgerID        string            `json:"ledger_id,omitempty"`
+
     // Metadata -------------------------------------------------------------------
     SourceRef string    `json:"source_ref,omitempty"` // e.g., invoice‑ID, external UUID
     UserID    string    `json:"user_id,omitempty"`   // who created/modified
 
+    // Client-chosen key making creation safe to retry: a repeat within the key's
+    // lifetime returns the transaction first created with it.
+    IdempotencyKey string `json:"idempotency_key,omitempty"`
+
+    // Maker-checker identities
+    CreatedBy  string `json:"created_by,omitempty"`  // who entered the transaction
+    PostedBy   string `json:"posted_by,omitempty"`   // who put it on the ledger
+    ApprovedBy string `json:"approved_by,omitempty"` // who released it from approval, if it was held
+
     CreatedAt time.Time `json:"created_at"`
     UpdatedAt time.Time `json:"updated_at"`
 }
@@ -209,6 +239,15 @@ type RecognitionSchedule struct {
     Occurrences   int               `json:"occurrences"` // e.g., 12 months
     StartTime     time.Time         `json:"start_time"`
     CreatedAt     time.Time         `json:"created_at"`
+    Breakdown     *CalculationBreakdown `json:"breakdown,omitempty"` // how the per-period amounts were derived
+
+    // Accounts each recognition debits and credits, e.g. unearned revenue and
+    // revenue; empty on schedules created before they were recorded
+    DebitAccountID  string `json:"debit_account_id,omitempty"`
+    CreditAccountID string `json:"credit_account_id,omitempty"`
+
+    TemplateID string `json:"template_id,omitempty"` // catalog template the schedule was created from
+    Reverse    bool   `json:"reverse,omitempty"`     // each recognition is reversed the next day
 }
 
 // ----------------------------------------------------------------------------
@@ -1,10 +1,13 @@
 package accounting
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 // AccrualService handles accrual and deferral recognition
@@ -12,6 +15,8 @@ type AccrualService struct {
 	storage       *Storage
 	postingEngine *PostingEngine
 	eventStore    *EventStore
+	logger        *slog.Logger
+	mutex         sync.Mutex // serializes recognition runs
 }
 
 // NewAccrualService creates a new accrual service
@@ -20,9 +25,15 @@ func NewAccrualService(storage *Storage, postingEngine *PostingEngine, eventStor
 		storage:       storage,
 		postingEngine: postingEngine,
 		eventStore:    eventStore,
+		logger:        discardLogger,
 	}
 }
 
+// SetLogger sends the service's logs to a host logger; nil discards them
+func (as *AccrualService) SetLogger(logger *slog.Logger) {
+	as.logger = componentLogger(logger, "accruals")
+}
+
 // AccrualType represents the type of accrual
 type AccrualType string
 
@@ -43,8 +54,19 @@ type RecognitionEntry struct {
 	Status          string     `json:"status"` // "PENDING", "PROCESSED", "FAILED"
 	TransactionID   string     `json:"transaction_id,omitempty"`
 	ProcessedAt     *time.Time `json:"processed_at,omitempty"`
+	Error           string     `json:"error,omitempty"` // why the last attempt failed
+
+	// Set on schedules that reverse each recognition the next day
+	ReversalTransactionID string `json:"reversal_transaction_id,omitempty"`
 }
 
+// Recognition entry statuses
+const (
+	RecognitionPending   = "PENDING"
+	RecognitionProcessed = "PROCESSED"
+	RecognitionFailed    = "FAILED"
+)
+
 // AccrualTemplate defines the accounts and rules for accrual recognition
 type AccrualTemplate struct {
 	ID                string      `json:"id"`
@@ -55,6 +77,10 @@ type AccrualTemplate struct {
 	ExpenseAccountID  string      `json:"expense_account_id,omitempty"`
 	DeferralAccountID string      `json:"deferral_account_id,omitempty"`
 	Dimensions        []Dimension `json:"dimensions,omitempty"`
+
+	// Reverse posts the opposite entry the day after each recognition, the
+	// accrue-and-reverse pattern for accruals settled by a later invoice
+	Reverse bool `json:"reverse,omitempty"`
 }
 
 // CreateRecognitionSchedule creates a new recognition schedule
@@ -68,69 +94,90 @@ func (as *AccrualService) CreateRecognitionSchedule(
 	userID string,
 ) (*RecognitionSchedule, error) {
 
+	debitAccountID, creditAccountID, err := recognitionAccounts(template)
+	if err != nil {
+		return nil, err
+	}
 	schedule := &RecognitionSchedule{
-		ID:            uuid.New().String(),
-		TransactionID: txnID,
-		Frequency:     frequency,
-		Occurrences:   occurrences,
-		StartTime:     startDate,
-		CreatedAt:     time.Now(),
+		ID:              newID(),
+		TransactionID:   txnID,
+		Frequency:       frequency,
+		Occurrences:     occurrences,
+		StartTime:       startDate,
+		CreatedAt:       time.Now(),
+		DebitAccountID:  debitAccountID,
+		CreditAccountID: creditAccountID,
 	}
+	if template != nil {
+		schedule.TemplateID = template.ID
+		schedule.Reverse = template.Reverse
+	}
+
+	// Keep the split behind the schedule so it can be checked later
+	amounts, err := totalAmount.Allocate(occurrences)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split schedule amount: %w", err)
+	}
+	dates := make([]time.Time, occurrences)
+	for i, date := 0, startDate; i < occurrences; i, date = i+1, as.addPeriod(date, frequency) {
+		dates[i] = date
+	}
+	schedule.Breakdown = recognitionBreakdown(schedule, totalAmount, amounts, dates, userID)
 
 	// Save the schedule
 	if err := as.storage.SaveSchedule(schedule); err != nil {
 		return nil, fmt.Errorf("failed to save schedule: %w", err)
 	}
 
-	// Generate recognition entries
-	if err := as.generateRecognitionEntries(schedule, totalAmount, template); err != nil {
-		return nil, fmt.Errorf("failed to generate recognition entries: %w", err)
+	// Store the occurrences RunRecognition posts
+	if err := as.storage.SaveRecognitionEntries(recognitionEntries(schedule, amounts, dates)...); err != nil {
+		return nil, fmt.Errorf("failed to save recognition entries: %w", err)
 	}
 
 	return schedule, nil
 }
 
-// generateRecognitionEntries generates all recognition entries for a schedule
-func (as *AccrualService) generateRecognitionEntries(
-	schedule *RecognitionSchedule,
-	totalAmount *Amount,
-	template *AccrualTemplate,
-) error {
-
-	// Calculate amount per period
-	amountPerPeriod := totalAmount.Value / int64(schedule.Occurrences)
-	remainder := totalAmount.Value % int64(schedule.Occurrences)
-
-	currentDate := schedule.StartTime
-
-	for i := 0; i < schedule.Occurrences; i++ {
-		// Add remainder to last entry to handle rounding
-		amount := amountPerPeriod
-		if i == schedule.Occurrences-1 {
-			amount += remainder
-		}
-
-		// Create recognition entry (in a real system, you'd have a separate storage method)
-		_ = &RecognitionEntry{
-			ID:              uuid.New().String(),
+// recognitionEntries lays out a schedule's occurrences, all pending
+func recognitionEntries(schedule *RecognitionSchedule, amounts []*Amount, dates []time.Time) []*RecognitionEntry {
+	entries := make([]*RecognitionEntry, len(amounts))
+	for i, amount := range amounts {
+		entries[i] = &RecognitionEntry{
+			ID:              newID(),
 			ScheduleID:      schedule.ID,
 			PeriodNumber:    i + 1,
-			RecognitionDate: currentDate,
-			Amount: &Amount{
-				Value:    amount,
-				Currency: totalAmount.Currency,
-			},
-			Status: "PENDING",
+			RecognitionDate: dates[i],
+			Amount:          amount,
+			Status:          RecognitionPending,
 		}
-
-		// For now, we'll just store in memory or use a simple approach
-		// In a real implementation, you'd save this entry to storage
-
-		// Advance to next period
-		currentDate = as.addPeriod(currentDate, schedule.Frequency)
 	}
+	return entries
+}
 
-	return nil
+// recognitionAccounts picks the accounts a schedule's recognitions debit and credit.
+// Revenue schedules release deferred or accrued revenue into revenue; expense
+// schedules charge expense against the prepaid or accrued balance. Without a
+// template, unearned revenue is recognized as revenue.
+func recognitionAccounts(template *AccrualTemplate) (debit, credit string, err error) {
+	if template == nil {
+		return "unearned_revenue", "revenue", nil
+	}
+	switch template.AccrualType {
+	case AccrualExpense, DeferralExpense:
+		debit = cmp.Or(template.ExpenseAccountID, "expenses")
+		credit = cmp.Or(template.DeferralAccountID, template.AccrualAccountID)
+		if credit == "" {
+			if template.AccrualType == DeferralExpense {
+				return "", "", fmt.Errorf("deferred expense template %s needs a deferral account", template.ID)
+			}
+			credit = "accounts_payable"
+		}
+	case AccrualRevenue, DeferralRevenue, "":
+		debit = cmp.Or(template.DeferralAccountID, template.AccrualAccountID, "unearned_revenue")
+		credit = cmp.Or(template.RevenueAccountID, "revenue")
+	default:
+		return "", "", fmt.Errorf("unknown accrual type %s", template.AccrualType)
+	}
+	return debit, credit, nil
 }
 
 // addPeriod adds a period to a date based on frequency
@@ -147,128 +194,232 @@ func (as *AccrualService) addPeriod(date time.Time, frequency ScheduleFrequency)
 	}
 }
 
-// ProcessPendingRecognitions processes all pending recognition entries up to a given date
-func (as *AccrualService) ProcessPendingRecognitions(upToDate time.Time, userID string) error {
+// RecognitionFailure is a recognition a run could not post
+type RecognitionFailure struct {
+	ScheduleID   string `json:"schedule_id"`
+	PeriodNumber int    `json:"period_number,omitempty"` // 0 when the schedule itself could not be read
+	Error        string `json:"error"`
+}
+
+// RecognitionRun reports what a recognition run posted
+type RecognitionRun struct {
+	AsOf             time.Time             `json:"as_of"`
+	RunAt            time.Time             `json:"run_at"`
+	Posted           []*RecognitionEntry   `json:"posted"`
+	Failed           []*RecognitionFailure `json:"failed,omitempty"`
+	AlreadyProcessed int                   `json:"already_processed"` // due entries posted by earlier runs
+	Recognized       map[Currency]int64    `json:"recognized"`        // total posted, by currency
+}
+
+// RunRecognition posts every recognition entry due by asOf that has not been posted
+// yet, across all schedules. Entries are posted in period order; a failure is
+// recorded on its entry and holds back the schedule's later periods until a run
+// succeeds. Running again is safe: processed entries are skipped, and a recognition
+// whose transaction was created before an interruption is finished rather than
+// repeated.
+func (as *AccrualService) RunRecognition(asOf time.Time, userID string) (*RecognitionRun, error) {
+	as.mutex.Lock()
+	defer as.mutex.Unlock()
+
 	schedules, err := as.storage.GetAllSchedules()
 	if err != nil {
-		return fmt.Errorf("failed to get schedules: %w", err)
+		return nil, fmt.Errorf("failed to get schedules: %w", err)
 	}
+	sort.Slice(schedules, func(i, j int) bool {
+		if !schedules[i].StartTime.Equal(schedules[j].StartTime) {
+			return schedules[i].StartTime.Before(schedules[j].StartTime)
+		}
+		return schedules[i].ID < schedules[j].ID
+	})
 
+	run := &RecognitionRun{AsOf: asOf, RunAt: time.Now(), Recognized: make(map[Currency]int64)}
 	for _, schedule := range schedules {
-		if err := as.processSchedule(schedule, upToDate, userID); err != nil {
-			// Log error but continue processing other schedules
-			fmt.Printf("Error processing schedule %s: %v\n", schedule.ID, err)
+		entries, err := as.scheduleEntries(schedule)
+		if err != nil {
+			run.Failed = append(run.Failed, &RecognitionFailure{ScheduleID: schedule.ID, Error: err.Error()})
+			continue
+		}
+		for _, entry := range entries {
+			if entry.Status == RecognitionProcessed {
+				if !entry.RecognitionDate.After(asOf) {
+					run.AlreadyProcessed++
+				}
+				continue
+			}
+			if entry.RecognitionDate.After(asOf) {
+				break
+			}
+
+			if err := as.recognize(schedule, entry, userID); err != nil {
+				entry.Status = RecognitionFailed
+				entry.Error = err.Error()
+				run.Failed = append(run.Failed, &RecognitionFailure{ScheduleID: schedule.ID, PeriodNumber: entry.PeriodNumber, Error: entry.Error})
+			} else {
+				processedAt := time.Now()
+				entry.Status = RecognitionProcessed
+				entry.ProcessedAt = &processedAt
+				entry.Error = ""
+			}
+			if err := as.storage.SaveRecognitionEntries(entry); err != nil {
+				return run, fmt.Errorf("failed to save recognition entry: %w", err)
+			}
+			if entry.Status == RecognitionFailed {
+				break
+			}
+			run.Posted = append(run.Posted, entry)
+			run.Recognized[entry.Amount.Currency] += entry.Amount.Value
 		}
 	}
 
-	return nil
+	for _, failure := range run.Failed {
+		as.logger.Error("failed to recognize schedule period",
+			LogKeyScheduleID, failure.ScheduleID, "period", failure.PeriodNumber, LogKeyError, failure.Error)
+	}
+	return run, nil
 }
 
-// processSchedule processes a single recognition schedule
-func (as *AccrualService) processSchedule(schedule *RecognitionSchedule, upToDate time.Time, userID string) error {
-	// Get all pending recognition entries for this schedule
-	// In a real implementation, you'd have proper storage methods for recognition entries
-
-	// For this example, we'll generate and process entries on the fly
-	currentDate := schedule.StartTime
+// ProcessPendingRecognitions posts the recognitions due up to a given date. Failures
+// are logged and left for a later run.
+func (as *AccrualService) ProcessPendingRecognitions(upToDate time.Time, userID string) error {
+	_, err := as.RunRecognition(upToDate, userID)
+	return err
+}
 
-	for i := 0; i < schedule.Occurrences; i++ {
-		if currentDate.After(upToDate) {
-			break // Don't process future recognitions
-		}
+// scheduleEntries returns a schedule's recognition entries. Schedules created before
+// entries were stored get them now, splitting the billing transaction's total as
+// processing always did; periods already posted under the schedule's source
+// reference count as processed.
+func (as *AccrualService) scheduleEntries(schedule *RecognitionSchedule) ([]*RecognitionEntry, error) {
+	entries, err := as.storage.GetRecognitionEntries(schedule.ID)
+	if err != nil || len(entries) > 0 {
+		return entries, err
+	}
 
-		// Check if this period has already been processed
-		// In a real system, you'd check the database
+	original, err := as.storage.GetTransaction(schedule.TransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get original transaction: %w", err)
+	}
+	amounts, err := recognitionScheduleTotal(original).Allocate(schedule.Occurrences)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split recognition amount: %w", err)
+	}
+	dates := make([]time.Time, schedule.Occurrences)
+	for i, date := 0, schedule.StartTime; i < schedule.Occurrences; i, date = i+1, as.addPeriod(date, schedule.Frequency) {
+		dates[i] = date
+	}
+	entries = recognitionEntries(schedule, amounts, dates)
 
-		// Create recognition transaction
-		if err := as.createRecognitionTransaction(schedule, currentDate, userID); err != nil {
-			return fmt.Errorf("failed to create recognition transaction for period %d: %w", i+1, err)
+	txns, err := as.storage.GetAllTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	for _, txn := range txns {
+		if txn.SourceRef != recognitionSourcePrefix+schedule.ID || txn.Status != Posted {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.Status == RecognitionPending && entry.RecognitionDate.Equal(txn.ValidTime) {
+				entry.Status = RecognitionProcessed
+				entry.TransactionID = txn.ID
+				entry.ProcessedAt = &txn.UpdatedAt
+				break
+			}
 		}
-
-		currentDate = as.addPeriod(currentDate, schedule.Frequency)
 	}
 
-	return nil
+	if err := as.storage.SaveRecognitionEntries(entries...); err != nil {
+		return nil, fmt.Errorf("failed to save recognition entries: %w", err)
+	}
+	return entries, nil
 }
 
-// createRecognitionTransaction creates a journal entry for accrual/deferral recognition
-func (as *AccrualService) createRecognitionTransaction(
-	schedule *RecognitionSchedule,
-	recognitionDate time.Time,
-	userID string,
-) error {
+// recognitionTransactionID names the transaction recognizing one period of a
+// schedule, so an interrupted run finds the transaction it already created
+func recognitionTransactionID(scheduleID string, periodNumber int) string {
+	return fmt.Sprintf("%s%s-%d", recognitionSourcePrefix, scheduleID, periodNumber)
+}
 
-	// Get the original transaction to understand the context
-	originalTxn, err := as.storage.GetTransaction(schedule.TransactionID)
-	if err != nil {
-		return fmt.Errorf("failed to get original transaction: %w", err)
+// recognize posts the transaction recognizing an entry, and its reversal on
+// schedules that reverse, recording their IDs on the entry
+func (as *AccrualService) recognize(schedule *RecognitionSchedule, entry *RecognitionEntry, userID string) error {
+	description := fmt.Sprintf("Accrual recognition for schedule %s", schedule.ID)
+	if original, err := as.storage.GetTransaction(schedule.TransactionID); err == nil {
+		description = fmt.Sprintf("Accrual recognition for %s", original.Description)
 	}
-
-	// Create recognition transaction
-	recognitionTxn := &Transaction{
-		ID:              uuid.New().String(),
-		Description:     fmt.Sprintf("Accrual recognition for %s", originalTxn.Description),
-		ValidTime:       recognitionDate,
-		TransactionTime: time.Now(),
-		Status:          Pending,
-		SourceRef:       fmt.Sprintf("ACCRUAL_%s", schedule.ID),
-		UserID:          userID,
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+	description = fmt.Sprintf("%s (%d of %d)", description, entry.PeriodNumber, schedule.Occurrences)
+	debitAccountID, creditAccountID := schedule.DebitAccountID, schedule.CreditAccountID
+	if debitAccountID == "" {
+		debitAccountID, creditAccountID, _ = recognitionAccounts(nil)
 	}
 
-	// Calculate recognition amount (simplified - in practice this would be more complex)
-	totalAmount := int64(0)
-	for _, entry := range originalTxn.Entries {
-		totalAmount += entry.Amount.Value
+	txnID := recognitionTransactionID(schedule.ID, entry.PeriodNumber)
+	recognition := recognitionTransaction(txnID, description, entry.RecognitionDate, debitAccountID, creditAccountID, entry.Amount, schedule, userID)
+	if err := as.postOnce(recognition, userID); err != nil {
+		return fmt.Errorf("failed to post recognition transaction: %w", err)
+	}
+	entry.TransactionID = txnID
+	if !schedule.Reverse {
+		return nil
 	}
-	recognitionAmount := totalAmount / int64(schedule.Occurrences)
-
-	// Create recognition entries based on accrual type
-	// This is a simplified example - real implementation would be more sophisticated
 
-	// Example: Revenue recognition
-	debitEntry := Entry{
-		ID:            uuid.New().String(),
-		TransactionID: recognitionTxn.ID,
-		AccountID:     "unearned_revenue", // Deferred revenue account
-		Type:          Debit,
-		Amount: Amount{
-			Value:    recognitionAmount,
-			Currency: originalTxn.Entries[0].Amount.Currency,
-		},
+	reversalID := txnID + "-R"
+	reversal := recognitionTransaction(reversalID, "Reversal of "+description, entry.RecognitionDate.AddDate(0, 0, 1), creditAccountID, debitAccountID, entry.Amount, schedule, userID)
+	if err := as.postOnce(reversal, userID); err != nil {
+		return fmt.Errorf("failed to post recognition reversal: %w", err)
 	}
+	entry.ReversalTransactionID = reversalID
+	return nil
+}
 
-	creditEntry := Entry{
-		ID:            uuid.New().String(),
-		TransactionID: recognitionTxn.ID,
-		AccountID:     "revenue", // Revenue account
-		Type:          Credit,
-		Amount: Amount{
-			Value:    recognitionAmount,
-			Currency: originalTxn.Entries[0].Amount.Currency,
+// recognitionTransaction builds a pending two-line transaction moving amount from
+// the credit account to the debit account
+func recognitionTransaction(id, description string, validTime time.Time, debitAccountID, creditAccountID string, amount *Amount, schedule *RecognitionSchedule, userID string) *Transaction {
+	now := time.Now()
+	return &Transaction{
+		ID:              id,
+		Description:     description,
+		ValidTime:       validTime,
+		TransactionTime: now,
+		Status:          Pending,
+		SourceRef:       recognitionSourcePrefix + schedule.ID,
+		UserID:          userID,
+		CreatedBy:       userID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		Entries: []Entry{
+			{ID: newID(), TransactionID: id, AccountID: debitAccountID, Type: Debit, Amount: *amount},
+			{ID: newID(), TransactionID: id, AccountID: creditAccountID, Type: Credit, Amount: *amount},
 		},
 	}
+}
 
-	recognitionTxn.Entries = []Entry{debitEntry, creditEntry}
+// postOnce records and posts a transaction with a deterministic ID. A transaction
+// already stored under the ID, left by an interrupted run, is posted if it is still
+// pending instead of being created again.
+func (as *AccrualService) postOnce(txn *Transaction, userID string) error {
+	existing, err := as.storage.GetTransaction(txn.ID)
+	switch {
+	case err == nil && existing.Status == Posted:
+		return nil
+	case err == nil:
+		return as.postingEngine.PostTransaction(existing, userID)
+	case !errors.Is(err, ErrNotFound):
+		return fmt.Errorf("failed to get transaction %s: %w", txn.ID, err)
+	}
 
-	// Create transaction creation event
 	_, err = as.eventStore.CreateEvent(
 		EventCreateTransaction,
-		TransactionCreatedEvent{Transaction: recognitionTxn},
-		recognitionTxn.ValidTime,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
 		userID,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create transaction event: %w", err)
 	}
-
-	// Post the transaction
-	if err := as.postingEngine.PostTransaction(recognitionTxn, userID); err != nil {
-		return fmt.Errorf("failed to post recognition transaction: %w", err)
+	if err := as.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
 	}
-
-	return nil
+	return as.postingEngine.PostTransaction(txn, userID)
 }
 
 // GetScheduleStatus returns the status of a recognition schedule
@@ -277,15 +428,32 @@ func (as *AccrualService) GetScheduleStatus(scheduleID string) (*ScheduleStatus,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get schedule: %w", err)
 	}
+	entries, err := as.storage.GetRecognitionEntries(scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recognition entries: %w", err)
+	}
 
 	status := &ScheduleStatus{
 		ScheduleID:          scheduleID,
 		TotalOccurrences:    schedule.Occurrences,
-		ProcessedCount:      0,                    // Would be calculated from stored recognition entries
-		RemainingCount:      schedule.Occurrences, // Would be calculated
-		NextRecognitionDate: schedule.StartTime,   // Would be calculated based on processed entries
+		RemainingCount:      schedule.Occurrences,
+		NextRecognitionDate: schedule.StartTime,
+	}
+	for _, entry := range entries {
+		if entry.Status == RecognitionProcessed {
+			status.ProcessedCount++
+		}
+	}
+	for _, entry := range entries {
+		if entry.Status != RecognitionProcessed {
+			status.NextRecognitionDate = entry.RecognitionDate
+			break
+		}
+	}
+	status.RemainingCount = schedule.Occurrences - status.ProcessedCount
+	if schedule.Occurrences > 0 {
+		status.CompletionRate = float64(status.ProcessedCount) / float64(schedule.Occurrences)
 	}
-
 	return status, nil
 }
 
@@ -303,7 +471,24 @@ func (as *AccrualService) getScheduleByID(scheduleID string) (*RecognitionSchedu
 		}
 	}
 
-	return nil, fmt.Errorf("schedule not found: %s", scheduleID)
+	return nil, notFound("schedule", scheduleID)
+}
+
+// AttachScheduleBreakdown replaces the supporting calculation stored with a schedule,
+// for example with the working behind an estimate the schedule recognizes
+func (as *AccrualService) AttachScheduleBreakdown(scheduleID string, breakdown *CalculationBreakdown, userID string) error {
+	if err := breakdown.Validate(); err != nil {
+		return fmt.Errorf("invalid calculation breakdown: %w", err)
+	}
+	schedule, err := as.getScheduleByID(scheduleID)
+	if err != nil {
+		return fmt.Errorf("failed to get schedule: %w", err)
+	}
+	if breakdown.ComputedBy == "" {
+		breakdown.ComputedBy = userID
+	}
+	schedule.Breakdown = breakdown
+	return as.storage.SaveSchedule(schedule)
 }
 
 // ScheduleStatus represents the current status of a recognition schedule
@@ -0,0 +1,137 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunRecognition(t *testing.T) {
+	// Setup
+	dbFile := "test_accrual_recognition.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "accountant"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	date := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC) }
+
+	// A year of support billed up front, recognized over three months
+	billing := &Transaction{
+		Description: "Support contract",
+		ValidTime:   date(1, 10),
+		Entries: []Entry{
+			{AccountID: "cash", Type: Debit, Amount: Amount{Value: 1200, Currency: "USD"}},
+			{AccountID: "unearned_revenue", Type: Credit, Amount: Amount{Value: 1200, Currency: "USD"}},
+		},
+	}
+	require.NoError(t, engine.CreateTransaction(billing, userID))
+	require.NoError(t, engine.PostTransaction(billing.ID, userID))
+	schedule, err := engine.CreateAccrualSchedule(billing.ID, &Amount{Value: 1200, Currency: "USD"}, Monthly, 3, date(1, 15), nil, userID)
+	require.NoError(t, err)
+
+	balance := func(accountID string) int64 {
+		result, err := engine.GetAccountBalance(accountID, date(12, 31))
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+	transactions := func() int {
+		txns, err := engine.GetStorage().GetAllTransactions()
+		require.NoError(t, err)
+		return len(txns)
+	}
+
+	t.Run("Posts Due Occurrences", func(t *testing.T) {
+		run, err := engine.RunRecognition(date(2, 20), userID)
+		require.NoError(t, err)
+		require.Len(t, run.Posted, 2)
+		assert.Empty(t, run.Failed)
+		assert.Equal(t, int64(800), run.Recognized["USD"])
+		for i, entry := range run.Posted {
+			assert.Equal(t, i+1, entry.PeriodNumber)
+			assert.Equal(t, RecognitionProcessed, entry.Status)
+			txn, err := engine.GetStorage().GetTransaction(entry.TransactionID)
+			require.NoError(t, err)
+			assert.Equal(t, Posted, txn.Status)
+			assert.Equal(t, entry.RecognitionDate, txn.ValidTime)
+		}
+		assert.Equal(t, int64(800), balance("revenue"))
+		assert.Equal(t, int64(400), balance("unearned_revenue"))
+
+		status, err := engine.GetAccrualScheduleStatus(schedule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, status.ProcessedCount)
+		assert.Equal(t, 1, status.RemainingCount)
+		assert.Equal(t, date(3, 15), status.NextRecognitionDate)
+	})
+
+	t.Run("Idempotent", func(t *testing.T) {
+		before := transactions()
+		run, err := engine.RunRecognition(date(2, 20), userID)
+		require.NoError(t, err)
+		assert.Empty(t, run.Posted)
+		assert.Equal(t, 2, run.AlreadyProcessed)
+		assert.Equal(t, before, transactions())
+		assert.Equal(t, int64(800), balance("revenue"))
+	})
+
+	t.Run("Resumes Interrupted Run", func(t *testing.T) {
+		// The second period posted but the run stopped before recording it
+		entries, err := engine.GetStorage().GetRecognitionEntries(schedule.ID)
+		require.NoError(t, err)
+		second := entries[1]
+		postedAs := second.TransactionID
+		second.Status, second.TransactionID, second.ProcessedAt = RecognitionPending, "", nil
+		require.NoError(t, engine.GetStorage().SaveRecognitionEntries(second))
+
+		before := transactions()
+		run, err := engine.RunRecognition(date(2, 20), userID)
+		require.NoError(t, err)
+		require.Len(t, run.Posted, 1)
+		assert.Equal(t, postedAs, run.Posted[0].TransactionID)
+		assert.Equal(t, before, transactions())
+		assert.Equal(t, int64(800), balance("revenue"))
+	})
+
+	t.Run("Failure Holds Back Later Periods", func(t *testing.T) {
+		template := &AccrualTemplate{ID: "subscriptions", AccrualType: DeferralRevenue, DeferralAccountID: "deferred_subscriptions"}
+		deferred, err := engine.CreateAccrualSchedule(billing.ID, &Amount{Value: 300, Currency: "USD"}, Monthly, 2, date(1, 20), template, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "deferred_subscriptions", deferred.DebitAccountID)
+		assert.Equal(t, "revenue", deferred.CreditAccountID)
+
+		run, err := engine.RunRecognition(date(3, 31), userID)
+		require.NoError(t, err)
+		require.Len(t, run.Failed, 1)
+		assert.Equal(t, deferred.ID, run.Failed[0].ScheduleID)
+		assert.Equal(t, 1, run.Failed[0].PeriodNumber)
+		assert.Contains(t, run.Failed[0].Error, "deferred_subscriptions")
+		require.Len(t, run.Posted, 1, "the other schedule carries on")
+		assert.Equal(t, schedule.ID, run.Posted[0].ScheduleID)
+
+		entries, err := engine.GetStorage().GetRecognitionEntries(deferred.ID)
+		require.NoError(t, err)
+		assert.Equal(t, RecognitionFailed, entries[0].Status)
+		assert.Equal(t, RecognitionPending, entries[1].Status)
+
+		// Once the account exists a later run posts both periods
+		require.NoError(t, engine.CreateAccount(&Account{ID: "deferred_subscriptions", Code: "2150", Name: "Deferred Subscriptions", Type: Liability}, userID))
+		run, err = engine.RunRecognition(date(3, 31), userID)
+		require.NoError(t, err)
+		assert.Empty(t, run.Failed)
+		assert.Len(t, run.Posted, 2)
+		assert.Equal(t, int64(300), run.Recognized["USD"])
+	})
+
+	t.Run("Expense Deferral Needs An Account", func(t *testing.T) {
+		_, err := engine.CreateAccrualSchedule(billing.ID, &Amount{Value: 300, Currency: "USD"}, Monthly, 2, date(1, 20),
+			&AccrualTemplate{ID: "prepaid", AccrualType: DeferralExpense}, userID)
+		assert.ErrorContains(t, err, "needs a deferral account")
+	})
+}
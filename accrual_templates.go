@@ -0,0 +1,153 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Built-in accrual template IDs
+const (
+	TemplateDeferredRevenue = "deferred_revenue"
+	TemplatePrepaidExpense  = "prepaid_expense"
+	TemplateAccruedExpense  = "accrued_expense"
+)
+
+// StandardAccrualTemplates returns the built-in recognition patterns. Deferred
+// revenue releases unearned revenue; prepaid expense amortizes a prepayment into
+// expense; accrued expense books an expense not yet invoiced and reverses it the
+// next day so the invoice can be posted in full.
+func StandardAccrualTemplates() []*AccrualTemplate {
+	return []*AccrualTemplate{
+		{
+			ID:               TemplateAccruedExpense,
+			Name:             "Accrued Expense",
+			AccrualType:      AccrualExpense,
+			AccrualAccountID: "accrued_liabilities",
+			ExpenseAccountID: "expenses",
+			Reverse:          true,
+		},
+		{
+			ID:                TemplateDeferredRevenue,
+			Name:              "Deferred Revenue",
+			AccrualType:       DeferralRevenue,
+			DeferralAccountID: "unearned_revenue",
+			RevenueAccountID:  "revenue",
+		},
+		{
+			ID:                TemplatePrepaidExpense,
+			Name:              "Prepaid Expense",
+			AccrualType:       DeferralExpense,
+			DeferralAccountID: "prepaid_expenses",
+			ExpenseAccountID:  "expenses",
+		},
+	}
+}
+
+// Validate checks that a template can drive a schedule
+func (t *AccrualTemplate) Validate() error {
+	if t.ID == "" {
+		return fmt.Errorf("accrual template ID is required")
+	}
+	if _, _, err := recognitionAccounts(t); err != nil {
+		return err
+	}
+	if t.Reverse && t.AccrualType != AccrualRevenue && t.AccrualType != AccrualExpense {
+		return fmt.Errorf("only accrual templates can reverse, not %s", t.AccrualType)
+	}
+	return nil
+}
+
+// SaveTemplate adds a template to the catalog, or replaces one with the same ID.
+// Saving a built-in ID overrides its account mapping.
+func (as *AccrualService) SaveTemplate(template *AccrualTemplate, userID string) error {
+	if err := template.Validate(); err != nil {
+		return classify(ErrValidation, "invalid accrual template: %v", err)
+	}
+	if err := as.checkTemplateAccounts(template); err != nil {
+		return err
+	}
+
+	_, err := as.eventStore.CreateEvent(EventSaveAccrualTemplate, template, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create template event: %w", err)
+	}
+	if err := as.storage.SaveAccrualTemplate(template); err != nil {
+		return fmt.Errorf("failed to save accrual template: %w", err)
+	}
+	return nil
+}
+
+// ListTemplates returns the template catalog: the built-in templates, overridden
+// by any saved template with the same ID, followed by custom ones, sorted by ID
+func (as *AccrualService) ListTemplates() ([]*AccrualTemplate, error) {
+	saved, err := as.storage.GetAllAccrualTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accrual templates: %w", err)
+	}
+	catalog := make(map[string]*AccrualTemplate)
+	for _, template := range StandardAccrualTemplates() {
+		catalog[template.ID] = template
+	}
+	for _, template := range saved {
+		catalog[template.ID] = template
+	}
+
+	templates := make([]*AccrualTemplate, 0, len(catalog))
+	for _, template := range catalog {
+		templates = append(templates, template)
+	}
+	slices.SortFunc(templates, func(a, b *AccrualTemplate) int { return strings.Compare(a.ID, b.ID) })
+	return templates, nil
+}
+
+// GetTemplate looks a template up in the catalog
+func (as *AccrualService) GetTemplate(id string) (*AccrualTemplate, error) {
+	template, err := as.storage.GetAccrualTemplate(id)
+	if err == nil {
+		return template, nil
+	}
+	for _, builtin := range StandardAccrualTemplates() {
+		if builtin.ID == id {
+			return builtin, nil
+		}
+	}
+	return nil, err
+}
+
+// CreateScheduleFromTemplate creates a recognition schedule using a catalog
+// template's account mapping. The accounts must exist, so a missing prepaid or
+// accrual account is reported here rather than on the first recognition run.
+func (as *AccrualService) CreateScheduleFromTemplate(
+	templateID string,
+	txnID string,
+	totalAmount *Amount,
+	frequency ScheduleFrequency,
+	occurrences int,
+	startDate time.Time,
+	userID string,
+) (*RecognitionSchedule, error) {
+	template, err := as.GetTemplate(templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accrual template: %w", err)
+	}
+	if err := as.checkTemplateAccounts(template); err != nil {
+		return nil, err
+	}
+	return as.CreateRecognitionSchedule(txnID, totalAmount, frequency, occurrences, startDate, template, userID)
+}
+
+// checkTemplateAccounts reports a template account that does not exist
+func (as *AccrualService) checkTemplateAccounts(template *AccrualTemplate) error {
+	debitAccountID, creditAccountID, err := recognitionAccounts(template)
+	if err != nil {
+		return err
+	}
+	for _, accountID := range []string{debitAccountID, creditAccountID} {
+		if _, err := as.storage.GetAccount(accountID); err != nil {
+			return fmt.Errorf("accrual template %s: %w", template.ID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccrualTemplates(t *testing.T) {
+	// Setup
+	dbFile := "test_accrual_templates.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "accountant"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	date := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC) }
+	balance := func(accountID string, asOf time.Time) int64 {
+		result, err := engine.GetAccountBalance(accountID, asOf)
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+
+	t.Run("Missing Accounts", func(t *testing.T) {
+		_, err := engine.CreateAccrualScheduleFromTemplate(TemplatePrepaidExpense, "", &Amount{Value: 1200, Currency: "USD"}, Monthly, 12, date(1, 31), userID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.ErrorContains(t, err, "prepaid_expenses")
+
+		_, err = engine.CreateAccrualScheduleFromTemplate("no-such-template", "", &Amount{Value: 1200, Currency: "USD"}, Monthly, 12, date(1, 31), userID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	require.NoError(t, engine.CreateAccount(&Account{ID: "prepaid_expenses", Code: "1400", Name: "Prepaid Expenses", Type: Asset}, userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "accrued_liabilities", Code: "2300", Name: "Accrued Liabilities", Type: Liability}, userID))
+
+	t.Run("Prepaid Expense Amortization", func(t *testing.T) {
+		// An annual insurance premium paid in January
+		premium := &Transaction{
+			Description: "Insurance premium",
+			ValidTime:   date(1, 5),
+			Entries: []Entry{
+				{AccountID: "prepaid_expenses", Type: Debit, Amount: Amount{Value: 1200, Currency: "USD"}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: 1200, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(premium, userID))
+		require.NoError(t, engine.PostTransaction(premium.ID, userID))
+
+		schedule, err := engine.CreateAccrualScheduleFromTemplate(TemplatePrepaidExpense, premium.ID, &Amount{Value: 1200, Currency: "USD"}, Monthly, 12, date(1, 15), userID)
+		require.NoError(t, err)
+		assert.Equal(t, TemplatePrepaidExpense, schedule.TemplateID)
+		assert.Equal(t, "expenses", schedule.DebitAccountID)
+		assert.Equal(t, "prepaid_expenses", schedule.CreditAccountID)
+		assert.False(t, schedule.Reverse)
+
+		run, err := engine.RunRecognition(date(3, 31), userID)
+		require.NoError(t, err)
+		assert.Len(t, run.Posted, 3)
+		assert.Equal(t, int64(300), balance("expenses", date(3, 31)))
+		assert.Equal(t, int64(900), balance("prepaid_expenses", date(3, 31)))
+	})
+
+	t.Run("Accrued Expense Reversal", func(t *testing.T) {
+		// Audit fees incurred each month, invoiced later
+		schedule, err := engine.CreateAccrualScheduleFromTemplate(TemplateAccruedExpense, "", &Amount{Value: 1000, Currency: "USD"}, Monthly, 2, date(4, 30), userID)
+		require.NoError(t, err)
+		assert.True(t, schedule.Reverse)
+		assert.Equal(t, "accrued_liabilities", schedule.CreditAccountID)
+
+		run, err := engine.RunRecognition(date(5, 31), userID)
+		require.NoError(t, err)
+		var accrued []*RecognitionEntry
+		for _, entry := range run.Posted {
+			if entry.ScheduleID == schedule.ID {
+				accrued = append(accrued, entry)
+			}
+		}
+		require.Len(t, accrued, 2)
+		for _, entry := range accrued {
+			reversal, err := engine.GetStorage().GetTransaction(entry.ReversalTransactionID)
+			require.NoError(t, err)
+			assert.Equal(t, Posted, reversal.Status)
+			assert.Equal(t, entry.RecognitionDate.AddDate(0, 0, 1), reversal.ValidTime)
+		}
+
+		// The accrual stands at month end and is gone the next day
+		assert.Equal(t, int64(500), balance("accrued_liabilities", date(4, 30)))
+		assert.Equal(t, int64(0), balance("accrued_liabilities", date(5, 1)))
+		assert.Equal(t, balance("expenses", date(4, 30))-500, balance("expenses", date(5, 1)))
+
+		// Running again posts neither the accruals nor the reversals twice
+		txns, err := engine.GetStorage().GetAllTransactions()
+		require.NoError(t, err)
+		run, err = engine.RunRecognition(date(5, 31), userID)
+		require.NoError(t, err)
+		assert.Empty(t, run.Posted)
+		after, err := engine.GetStorage().GetAllTransactions()
+		require.NoError(t, err)
+		assert.Len(t, after, len(txns))
+	})
+
+	t.Run("Catalog", func(t *testing.T) {
+		templates, err := engine.ListAccrualTemplates()
+		require.NoError(t, err)
+		var ids []string
+		for _, template := range templates {
+			ids = append(ids, template.ID)
+		}
+		assert.Equal(t, []string{TemplateAccruedExpense, TemplateDeferredRevenue, TemplatePrepaidExpense}, ids)
+
+		// A saved template overrides the built-in mapping and custom ones join the catalog
+		require.NoError(t, engine.SaveAccrualTemplate(&AccrualTemplate{
+			ID: TemplatePrepaidExpense, Name: "Prepaid Expense", AccrualType: DeferralExpense,
+			DeferralAccountID: "prepaid_expenses", ExpenseAccountID: "expenses",
+			Dimensions: []Dimension{{Key: DimDepartment, Value: "finance"}},
+		}, userID))
+		require.NoError(t, engine.SaveAccrualTemplate(&AccrualTemplate{
+			ID: "accrued_revenue", Name: "Accrued Revenue", AccrualType: AccrualRevenue,
+			AccrualAccountID: "accounts_receivable", RevenueAccountID: "revenue", Reverse: true,
+		}, userID))
+		templates, err = engine.ListAccrualTemplates()
+		require.NoError(t, err)
+		require.Len(t, templates, 4)
+		assert.Equal(t, "accrued_revenue", templates[1].ID)
+		assert.Len(t, templates[3].Dimensions, 1)
+
+		// Deferrals cannot reverse and every account must exist
+		err = engine.SaveAccrualTemplate(&AccrualTemplate{ID: "bad", AccrualType: DeferralRevenue, Reverse: true}, userID)
+		assert.ErrorIs(t, err, ErrValidation)
+		err = engine.SaveAccrualTemplate(&AccrualTemplate{ID: "bad", AccrualType: DeferralExpense, DeferralAccountID: "nowhere"}, userID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
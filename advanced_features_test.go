@@ -236,7 +236,7 @@ func TestReportingServiceIntegration(t *testing.T) {
 
 	// Create a minimal query API for testing
 	queryAPI := &QueryAPI{storage: storage}
-	reportingService := NewReportingService(storage, queryAPI)
+	reportingService := NewReportingService(storage, queryAPI, nil)
 
 	t.Run("Reporting Service Creation", func(t *testing.T) {
 		assert.NotNil(t, reportingService)
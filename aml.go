@@ -1,7 +1,10 @@
 package accounting
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"log/slog"
 	"sort"
 	"strings"
 	"time"
@@ -79,6 +82,8 @@ const (
 	RuleCryptocurrency      AMLRuleType = "CRYPTOCURRENCY"        // Cryptocurrency transactions
 	RuleJustUnderThreshold  AMLRuleType = "JUST_UNDER_THRESHOLD"  // Amounts just under thresholds
 	RuleUnexpectedGeography AMLRuleType = "UNEXPECTED_GEOGRAPHY"  // Unexpected geographical activity
+	RuleChargebackRate      AMLRuleType = "CHARGEBACK_RATE"       // Chargeback rate above card scheme thresholds
+	RuleClientShortfall     AMLRuleType = "CLIENT_SHORTFALL"      // Client money held below client balances
 )
 
 // AMLAlert represents an AML compliance alert
@@ -96,13 +101,14 @@ type AMLAlert struct {
 	Amount         *Amount           `json:"amount,omitempty"`
 	Currency       string            `json:"currency"`
 	DetectedAt     time.Time         `json:"detected_at"`
-	Status         string            `json:"status"` // "OPEN", "INVESTIGATING", "CLOSED", "ESCALATED"
+	Status         string            `json:"status"` // "OPEN", "UNDER_REVIEW", "INVESTIGATING", "CLOSED", "ESCALATED"
 	AssignedTo     string            `json:"assigned_to"`
 	Investigation  *AMLInvestigation `json:"investigation,omitempty"`
 	Evidence       []AMLEvidence     `json:"evidence"`
 	Dispositions   []AMLDisposition  `json:"dispositions"`
 	CreatedAt      time.Time         `json:"created_at"`
 	UpdatedAt      time.Time         `json:"updated_at"`
+	Version        int64             `json:"version"` // incremented on each save
 }
 
 // AMLInvestigation represents an investigation into an AML alert
@@ -204,8 +210,15 @@ type AMLCustomer struct {
 	ExpectedActivity string    `json:"expected_activity"`
 	BusinessPurpose  string    `json:"business_purpose"`
 
+	// Set when the relationship ends; records are kept for the retention period
+	// after it, then the personal data may be pseudonymized or erased
+	RelationshipEndedAt *time.Time     `json:"relationship_ended_at,omitempty"`
+	ErasedAt            *time.Time     `json:"erased_at,omitempty"`
+	ErasureMode         PIIErasureMode `json:"erasure_mode,omitempty"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Version   int64     `json:"version"` // for optimistic locking, see ConflictError
 }
 
 // AMLTransaction represents transaction data enriched for AML analysis
@@ -238,17 +251,89 @@ type AMLService struct {
 	rules       map[string]*AMLRule
 	customers   map[string]*AMLCustomer
 	alertsCache map[string]*AMLAlert
+	workflows   *WorkflowService
+	enrichers   []AMLEnricher
+	onAlert     []AMLAlertHandler
+	metrics     *EngineMetrics // nil until instrumented
+	logger      *slog.Logger
+	tracer      *engineTracer
 }
 
+// AMLEnricher fills in or adjusts an AML transaction before the rules evaluate it
+type AMLEnricher func(txn *AMLTransaction) error
+
+// AMLAlertHandler is called with each new alert once it is saved
+type AMLAlertHandler func(alert *AMLAlert)
+
 // NewAMLService creates a new AML service
 func NewAMLService(storage *Storage, compliance *ComplianceService, forensic *ForensicService) *AMLService {
-	return &AMLService{
+	aml := &AMLService{
 		storage:     storage,
 		compliance:  compliance,
 		forensic:    forensic,
 		rules:       make(map[string]*AMLRule),
 		customers:   make(map[string]*AMLCustomer),
 		alertsCache: make(map[string]*AMLAlert),
+		logger:      discardLogger,
+		tracer:      noopTracer,
+	}
+	aml.UseWorkflows(NewWorkflowService(storage))
+	return aml
+}
+
+// UseWorkflows runs alert handling on a shared workflow service. Call it while the
+// engine is being assembled.
+func (aml *AMLService) UseWorkflows(workflows *WorkflowService) {
+	workflows.mustRegister(aml.workflow())
+	aml.workflows = workflows
+}
+
+// SetLogger sends the service's logs to a host logger; nil discards them. Set it
+// while the engine is being assembled.
+func (aml *AMLService) SetLogger(logger *slog.Logger) {
+	aml.logger = componentLogger(logger, "aml")
+}
+
+// setTracer traces monitoring through the engine's tracer. Call it while the engine
+// is being assembled.
+func (aml *AMLService) setTracer(tracer *engineTracer) {
+	aml.tracer = tracer
+}
+
+// AddEnricher registers a step that runs on every monitored transaction before the
+// rules. Register enrichers while the engine is being assembled.
+func (aml *AMLService) AddEnricher(enricher AMLEnricher) {
+	aml.enrichers = append(aml.enrichers, enricher)
+}
+
+// AddAlertHandler registers a handler for new alerts, whether raised by monitoring
+// or directly. Register handlers while the engine is being assembled.
+func (aml *AMLService) AddAlertHandler(handler AMLAlertHandler) {
+	aml.onAlert = append(aml.onAlert, handler)
+}
+
+// Instrument records monitoring times and raised alerts in the engine metrics. Call
+// it while the engine is being assembled.
+func (aml *AMLService) Instrument(metrics *EngineMetrics) {
+	aml.metrics = metrics
+	aml.AddAlertHandler(metrics.alertRaised)
+}
+
+// alertRaised logs new alerts and passes them to the handlers
+func (aml *AMLService) alertRaised(alerts ...*AMLAlert) {
+	for _, alert := range alerts {
+		aml.logger.Warn("AML alert raised",
+			LogKeyAlertID, alert.ID,
+			LogKeyRuleType, alert.RuleType,
+			"risk_level", alert.RiskLevel,
+			"entity_id", alert.EntityID,
+			"transaction_ids", alert.TransactionIDs)
+		if _, err := aml.workflows.Start(WorkflowAMLAlert, alert.ID); err != nil {
+			aml.logger.Error("failed to start alert workflow", LogKeyAlertID, alert.ID, LogKeyError, err)
+		}
+		for _, handler := range aml.onAlert {
+			handler(alert)
+		}
 	}
 }
 
@@ -278,7 +363,7 @@ func (aml *AMLService) SetupStandardAMLRules(framework AMLFramework) error {
 func (aml *AMLService) setupBSARules() error {
 	rules := []*AMLRule{
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "CTR - Currency Transaction Report",
 			Type:        RuleCTR,
 			Framework:   BSA_Framework,
@@ -296,7 +381,7 @@ func (aml *AMLService) setupBSARules() error {
 			RiskMultiple: 1.5,
 		},
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "SAR - Suspicious Activity Threshold",
 			Type:        RuleSAR,
 			Framework:   BSA_Framework,
@@ -310,7 +395,7 @@ func (aml *AMLService) setupBSARules() error {
 			RiskMultiple: 2.0,
 		},
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "Structuring Detection",
 			Type:        RuleStructuring,
 			Framework:   BSA_Framework,
@@ -347,7 +432,7 @@ func (aml *AMLService) setupBSARules() error {
 func (aml *AMLService) setupAMLDRules() error {
 	rules := []*AMLRule{
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "EU Suspicious Transaction Threshold",
 			Type:        RuleSAR,
 			Framework:   AMLD_Framework,
@@ -361,7 +446,7 @@ func (aml *AMLService) setupAMLDRules() error {
 			RiskMultiple: 1.8,
 		},
 		{
-			ID:           generateUUID(),
+			ID:           newID(),
 			Name:         "High-Risk Third Countries",
 			Type:         RuleHighRiskJuris,
 			Framework:    AMLD_Framework,
@@ -390,7 +475,7 @@ func (aml *AMLService) setupAMLDRules() error {
 func (aml *AMLService) setupFATFRules() error {
 	rules := []*AMLRule{
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "FATF Velocity Monitoring",
 			Type:        RuleVelocity,
 			Framework:   FATF_Framework,
@@ -407,7 +492,7 @@ func (aml *AMLService) setupFATFRules() error {
 			RiskMultiple: 1.5,
 		},
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "Rapid Movement Pattern",
 			Type:        RuleRapidMovement,
 			Framework:   FATF_Framework,
@@ -439,7 +524,7 @@ func (aml *AMLService) setupFATFRules() error {
 func (aml *AMLService) setupFinCENRules() error {
 	rules := []*AMLRule{
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "FinCEN Beneficial Ownership",
 			Type:        RuleCDD,
 			Framework:   FINCEN_Framework,
@@ -470,7 +555,7 @@ func (aml *AMLService) setupFinCENRules() error {
 func (aml *AMLService) setupOFACRules() error {
 	rules := []*AMLRule{
 		{
-			ID:           generateUUID(),
+			ID:           newID(),
 			Name:         "OFAC Sanctions Screening",
 			Type:         RuleSanctions,
 			Framework:    OFAC_Framework,
@@ -503,7 +588,7 @@ func (aml *AMLService) setupCommonAMLRules() error {
 	rules := []*AMLRule{
 		// 1. Cash Intensive Activity Detection
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "Cash Intensive Activity",
 			Type:        RuleCashIntensive,
 			Framework:   BSA_Framework,
@@ -520,7 +605,7 @@ func (aml *AMLService) setupCommonAMLRules() error {
 
 		// 2. Just Under Threshold Detection
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "Just Under Threshold",
 			Type:        RuleJustUnderThreshold,
 			Framework:   BSA_Framework,
@@ -538,7 +623,7 @@ func (aml *AMLService) setupCommonAMLRules() error {
 
 		// 3. Unusual Timing Detection
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "Unusual Timing",
 			Type:        RuleUnusualTiming,
 			Framework:   BSA_Framework,
@@ -555,7 +640,7 @@ func (aml *AMLService) setupCommonAMLRules() error {
 
 		// 4. Account Dormancy Reactivation
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "Dormant Account Reactivation",
 			Type:        RuleAccountDormancy,
 			Framework:   BSA_Framework,
@@ -571,7 +656,7 @@ func (aml *AMLService) setupCommonAMLRules() error {
 
 		// 5. Wire Stripping Detection
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "Wire Stripping",
 			Type:        RuleWireStripping,
 			Framework:   BSA_Framework,
@@ -586,7 +671,7 @@ func (aml *AMLService) setupCommonAMLRules() error {
 
 		// 6. High-Risk Geography
 		{
-			ID:           generateUUID(),
+			ID:           newID(),
 			Name:         "Unexpected Geography",
 			Type:         RuleUnexpectedGeography,
 			Framework:    FATF_Framework,
@@ -599,7 +684,7 @@ func (aml *AMLService) setupCommonAMLRules() error {
 
 		// 7. Cryptocurrency Transactions
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "Cryptocurrency Activity",
 			Type:        RuleCryptocurrency,
 			Framework:   BSA_Framework,
@@ -614,7 +699,7 @@ func (aml *AMLService) setupCommonAMLRules() error {
 
 		// 8. Shell Company Indicators
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "Shell Company Indicators",
 			Type:        RuleShellCompany,
 			Framework:   FATF_Framework,
@@ -630,7 +715,7 @@ func (aml *AMLService) setupCommonAMLRules() error {
 
 		// 9. Trade-Based Money Laundering
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "Trade-Based Money Laundering",
 			Type:        RuleTradeBasedML,
 			Framework:   FATF_Framework,
@@ -646,7 +731,7 @@ func (aml *AMLService) setupCommonAMLRules() error {
 
 		// 10. Third-Party Check Deposits
 		{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Name:        "Third-Party Check Deposits",
 			Type:        RuleThirdPartyCheck,
 			Framework:   BSA_Framework,
@@ -704,10 +789,44 @@ func (aml *AMLService) SetupAllStandardAMLRules() error {
 
 // MonitorTransaction analyzes a transaction against AML rules
 func (aml *AMLService) MonitorTransaction(txn *Transaction, customerInfo map[string]*AMLCustomer) ([]*AMLAlert, error) {
+	return aml.MonitorTransactionContext(context.Background(), txn, customerInfo)
+}
+
+// MonitorTransactionContext analyzes a transaction against AML rules, tracing the
+// check as a child of any span in ctx
+func (aml *AMLService) MonitorTransactionContext(ctx context.Context, txn *Transaction, customerInfo map[string]*AMLCustomer) (_ []*AMLAlert, err error) {
+	enabled := 0
+	for _, rule := range aml.rules {
+		if rule.Enabled {
+			enabled++
+		}
+	}
+	_, span := aml.tracer.start(ctx, "aml.MonitorTransaction", append(transactionAttributes(txn), TraceKeyRuleCount.Int(enabled))...)
+	defer func() { endSpan(span, err) }()
+
+	alerts, err := aml.monitorTransaction(txn, customerInfo)
+	span.SetAttributes(TraceKeyAlertCount.Int(len(alerts)))
+	return alerts, err
+}
+
+// monitorTransaction runs the rules and checks against a transaction
+func (aml *AMLService) monitorTransaction(txn *Transaction, customerInfo map[string]*AMLCustomer) ([]*AMLAlert, error) {
+	started := time.Now()
+	defer func() { aml.metrics.observeMonitoring(time.Since(started)) }()
 	var alerts []*AMLAlert
 
 	// Convert transaction to AML format
 	amlTxn := aml.convertToAMLTransaction(txn, customerInfo)
+	for _, enrich := range aml.enrichers {
+		if err := enrich(amlTxn); err != nil {
+			return nil, fmt.Errorf("failed to enrich AML transaction: %w", err)
+		}
+	}
+
+	// Count it towards the dashboard volumes
+	if err := aml.storage.RecordAMLTransaction(amlTxn); err != nil {
+		return nil, fmt.Errorf("failed to record AML transaction: %w", err)
+	}
 
 	// Run traditional rule-based checks
 	for _, rule := range aml.rules {
@@ -717,6 +836,8 @@ func (aml *AMLService) MonitorTransaction(txn *Transaction, customerInfo map[str
 
 		alert := aml.evaluateRule(rule, amlTxn, customerInfo)
 		if alert != nil {
+			aml.logger.Debug("AML rule matched",
+				LogKeyRuleID, rule.ID, LogKeyRuleType, rule.Type, LogKeyAlertID, alert.ID, LogKeyTransactionID, txn.ID)
 			alerts = append(alerts, alert)
 
 			// Save alert
@@ -739,7 +860,8 @@ func (aml *AMLService) MonitorTransaction(txn *Transaction, customerInfo map[str
 	for _, check := range advancedChecks {
 		alert, err := check(txn)
 		if err != nil {
-			// Log error but continue with other checks
+			// Log the error but continue with the other checks
+			aml.logger.Warn("AML check failed", LogKeyTransactionID, txn.ID, LogKeyError, err)
 			continue
 		}
 		if alert != nil {
@@ -779,6 +901,8 @@ func (aml *AMLService) MonitorTransaction(txn *Transaction, customerInfo map[str
 		}
 	}
 
+	aml.logger.Debug("transaction monitored", LogKeyTransactionID, txn.ID, "alerts", len(alerts))
+	aml.alertRaised(alerts...)
 	return alerts, nil
 }
 
@@ -876,7 +1000,7 @@ func (aml *AMLService) evaluateCTRRule(rule *AMLRule, txn *AMLTransaction) *AMLA
 
 	if txn.Amount.Value >= int64(threshold) && txn.Channel == "CASH" {
 		return &AMLAlert{
-			ID:             generateUUID(),
+			ID:             newID(),
 			RuleType:       rule.Type,
 			Framework:      rule.Framework,
 			RiskLevel:      RiskHigh,
@@ -927,7 +1051,7 @@ func (aml *AMLService) evaluateSARRule(rule *AMLRule, txn *AMLTransaction) *AMLA
 			}
 
 			return &AMLAlert{
-				ID:             generateUUID(),
+				ID:             newID(),
 				RuleType:       rule.Type,
 				Framework:      rule.Framework,
 				RiskLevel:      riskLevel,
@@ -965,7 +1089,7 @@ func (aml *AMLService) evaluateStructuringRule(rule *AMLRule, txn *AMLTransactio
 	// For now, implement basic round amount detection
 	if aml.isRoundAmount(txn.Amount.Value) {
 		return &AMLAlert{
-			ID:             generateUUID(),
+			ID:             newID(),
 			RuleType:       rule.Type,
 			Framework:      rule.Framework,
 			RiskLevel:      RiskMedium,
@@ -1017,7 +1141,7 @@ func (aml *AMLService) evaluateHighRiskJurisdictionRule(rule *AMLRule, txn *AMLT
 	for _, country := range rule.Countries {
 		if txn.FromCountry == country || txn.ToCountry == country {
 			return &AMLAlert{
-				ID:             generateUUID(),
+				ID:             newID(),
 				RuleType:       rule.Type,
 				Framework:      rule.Framework,
 				RiskLevel:      RiskHigh,
@@ -1055,7 +1179,7 @@ func (aml *AMLService) evaluateSanctionsRule(rule *AMLRule, txn *AMLTransaction,
 	for customerID, customer := range customerInfo {
 		if (customerID == txn.FromCustomerID || customerID == txn.ToCustomerID) && customer.SanctionsMatch {
 			return &AMLAlert{
-				ID:             generateUUID(),
+				ID:             newID(),
 				RuleType:       rule.Type,
 				Framework:      rule.Framework,
 				RiskLevel:      RiskCritical,
@@ -1191,36 +1315,50 @@ func (aml *AMLService) GetAMLAlerts(status string, riskLevel AMLRiskLevel, limit
 	return filtered, nil
 }
 
-// UpdateAlertStatus updates the status of an AML alert
+// RaiseAlert records an alert raised outside rule-based monitoring, such as a
+// reconciliation exception, so it joins the review queue
+func (aml *AMLService) RaiseAlert(alert *AMLAlert) error {
+	if alert.ID == "" {
+		alert.ID = newID()
+	}
+	if alert.Status == "" {
+		alert.Status = "OPEN"
+	}
+	if err := aml.storage.SaveAMLAlert(alert); err != nil {
+		return fmt.Errorf("failed to save AML alert: %w", err)
+	}
+	aml.alertsCache[alert.ID] = alert
+	aml.alertRaised(alert)
+	return nil
+}
+
+// UpdateAlertStatus moves an AML alert to OPEN, UNDER_REVIEW, INVESTIGATING, ESCALATED
+// or CLOSED.
+// Setting the status it already has does nothing.
 func (aml *AMLService) UpdateAlertStatus(alertID, status, userID string) error {
 	alert, err := aml.storage.GetAMLAlert(alertID)
 	if err != nil {
 		return err
 	}
-
-	alert.Status = status
-	alert.UpdatedAt = time.Now()
-
-	// Add disposition if closing
+	if alert.Status == status || (alert.Status == "" && status == "OPEN") {
+		return nil
+	}
+	action, ok := amlAlertActions[status]
+	if !ok {
+		return fmt.Errorf("unknown AML alert status: %s", status)
+	}
+	request := &WorkflowRequest{Action: action, Actor: userID}
 	if status == "CLOSED" {
-		disposition := AMLDisposition{
-			ID:          generateUUID(),
-			Type:        "NO_ACTION",
-			Description: "Alert reviewed and closed",
-			DecidedBy:   userID,
-			DecidedAt:   time.Now(),
-			Rationale:   "No suspicious activity found upon review",
-		}
-		alert.Dispositions = append(alert.Dispositions, disposition)
+		request.Assignees = []string{}
 	}
-
-	return aml.storage.SaveAMLAlert(alert)
+	_, err = aml.workflows.Fire(WorkflowAMLAlert, alertID, request)
+	return err
 }
 
 // CreateInvestigation creates a new investigation for an alert
 func (aml *AMLService) CreateInvestigation(alertID, investigatorID string) (*AMLInvestigation, error) {
 	investigation := &AMLInvestigation{
-		ID:           generateUUID(),
+		ID:           newID(),
 		AlertID:      alertID,
 		Investigator: investigatorID,
 		StartedAt:    time.Now(),
@@ -1231,22 +1369,100 @@ func (aml *AMLService) CreateInvestigation(alertID, investigatorID string) (*AML
 		Notes:        []InvestigationNote{},
 	}
 
-	// Update alert
-	alert, err := aml.storage.GetAMLAlert(alertID)
+	_, err := aml.workflows.Fire(WorkflowAMLAlert, alertID, &WorkflowRequest{
+		Action: "investigate", Actor: investigatorID, Assignees: []string{investigatorID}, Data: investigation,
+	})
 	if err != nil {
 		return nil, err
 	}
+	return investigation, nil
+}
 
-	alert.Status = "INVESTIGATING"
-	alert.AssignedTo = investigatorID
-	alert.Investigation = investigation
-	alert.UpdatedAt = time.Now()
+// ----------------------------------------------------------------------------
+// Alert Workflow
+// ----------------------------------------------------------------------------
 
-	if err := aml.storage.SaveAMLAlert(alert); err != nil {
-		return nil, err
+// WorkflowAMLAlert is the handling of AML alerts; its subjects are alert IDs
+const WorkflowAMLAlert = "aml_alert"
+
+// AMLAlertEscalationAge is how long an alert may stay OPEN or UNDER_REVIEW before it
+// is escalated
+const AMLAlertEscalationAge = 30 * 24 * time.Hour
+
+// amlAlertActions maps each alert status to the workflow action that reaches it
+var amlAlertActions = map[string]string{
+	"OPEN":          "reopen",
+	"UNDER_REVIEW":  "review",
+	"INVESTIGATING": "investigate",
+	"ESCALATED":     "escalate",
+	"CLOSED":        "close",
+}
+
+// workflow defines how alerts move from raised to closed
+func (aml *AMLService) workflow() *WorkflowDefinition {
+	return &WorkflowDefinition{
+		Name: WorkflowAMLAlert,
+		Transitions: []WorkflowTransition{
+			{Action: "review", From: []string{"OPEN"}, To: "UNDER_REVIEW"},
+			{Action: "investigate", From: []string{"OPEN", "UNDER_REVIEW", "INVESTIGATING", "ESCALATED"}, To: "INVESTIGATING"},
+			{Action: "escalate", From: []string{"OPEN", "UNDER_REVIEW", "INVESTIGATING"}, To: "ESCALATED"},
+			{Action: "close", From: []string{"OPEN", "UNDER_REVIEW", "INVESTIGATING", "ESCALATED"}, To: "CLOSED"},
+			{Action: "reopen", From: []string{"UNDER_REVIEW", "CLOSED", "ESCALATED"}, To: "OPEN"},
+		},
+		Timers: []WorkflowTimer{
+			{State: "OPEN", After: AMLAlertEscalationAge, Action: "escalate"},
+			{State: "UNDER_REVIEW", After: AMLAlertEscalationAge, Action: "escalate"},
+		},
+		State: func(alertID string) (string, error) {
+			alert, err := aml.storage.GetAMLAlert(alertID)
+			if err != nil {
+				return "", err
+			}
+			if alert.Status == "" {
+				return "OPEN", nil
+			}
+			return alert.Status, nil
+		},
+		NotAllowed: func(instance *WorkflowInstance, request *WorkflowRequest) error {
+			return fmt.Errorf("cannot %s AML alert %s: alert is %s", request.Action, instance.SubjectID, instance.State)
+		},
+		OnTransition: aml.applyTransition,
 	}
+}
 
-	return investigation, nil
+// applyTransition records an alert's new status
+func (aml *AMLService) applyTransition(instance *WorkflowInstance, request *WorkflowRequest, _ string) error {
+	alert, err := aml.storage.GetAMLAlert(instance.SubjectID)
+	if err != nil {
+		return err
+	}
+	alert.Status = instance.State
+	alert.UpdatedAt = instance.EnteredAt
+
+	switch request.Action {
+	case "investigate":
+		alert.AssignedTo = request.Actor
+		if investigation, ok := request.Data.(*AMLInvestigation); ok {
+			alert.Investigation = investigation
+		}
+	case "close":
+		alert.Dispositions = append(alert.Dispositions, AMLDisposition{
+			ID:          newID(),
+			Type:        "NO_ACTION",
+			Description: "Alert reviewed and closed",
+			DecidedBy:   request.Actor,
+			DecidedAt:   instance.EnteredAt,
+			Rationale:   "No suspicious activity found upon review",
+		})
+	}
+
+	if err := aml.storage.SaveAMLAlert(alert); err != nil {
+		return err
+	}
+	if _, ok := aml.alertsCache[alert.ID]; ok {
+		aml.alertsCache[alert.ID] = alert
+	}
+	return nil
 }
 
 // AddInvestigationNote adds a note to an investigation
@@ -1261,7 +1477,7 @@ func (aml *AMLService) AddInvestigationNote(alertID, content, userID string) err
 	}
 
 	note := InvestigationNote{
-		ID:        generateUUID(),
+		ID:        newID(),
 		Content:   content,
 		CreatedBy: userID,
 		CreatedAt: time.Now(),
@@ -1282,8 +1498,11 @@ func (aml *AMLService) RegisterCustomer(customer *AMLCustomer) error {
 	customer.CreatedAt = time.Now()
 	customer.UpdatedAt = time.Now()
 
+	if err := aml.storage.SaveAMLCustomer(customer); err != nil {
+		return err
+	}
 	aml.customers[customer.ID] = customer
-	return aml.storage.SaveAMLCustomer(customer)
+	return nil
 }
 
 // UpdateCustomerRisk updates a customer's risk level
@@ -1299,6 +1518,23 @@ func (aml *AMLService) UpdateCustomerRisk(customerID string, riskLevel AMLRiskLe
 	return aml.storage.SaveAMLCustomer(customer)
 }
 
+// EndRelationship records the end of the business relationship with a customer,
+// which starts the record retention period
+func (aml *AMLService) EndRelationship(customerID string, endedAt time.Time) error {
+	customer, err := aml.storage.GetAMLCustomer(customerID)
+	if err != nil {
+		return err
+	}
+
+	customer.RelationshipEndedAt = &endedAt
+	customer.UpdatedAt = time.Now()
+
+	if _, ok := aml.customers[customer.ID]; ok {
+		aml.customers[customer.ID] = customer
+	}
+	return aml.storage.SaveAMLCustomer(customer)
+}
+
 // PerformKYC performs Know Your Customer check
 func (aml *AMLService) PerformKYC(customerID string, userID string) error {
 	customer, err := aml.storage.GetAMLCustomer(customerID)
@@ -1538,7 +1774,9 @@ type AMLRecommendation struct {
 	DueDate     time.Time `json:"due_date"`
 }
 
-// GenerateAMLDashboard creates a comprehensive AML monitoring dashboard
+// GenerateAMLDashboard creates a comprehensive AML monitoring dashboard. It reads
+// the daily aggregates kept as alerts and transactions are saved, so it covers whole
+// UTC days and costs the same however many alerts the period holds.
 func (aml *AMLService) GenerateAMLDashboard(startDate, endDate time.Time) (*AMLDashboard, error) {
 	dashboard := &AMLDashboard{
 		PeriodStart:       startDate,
@@ -1547,137 +1785,57 @@ func (aml *AMLService) GenerateAMLDashboard(startDate, endDate time.Time) (*AMLD
 		AlertsByType:      make(map[AMLRuleType]int),
 	}
 
-	// Get all alerts for the period
-	alerts, err := aml.getAlertsForPeriod(startDate, endDate)
+	// Get the counters for every day of the period
+	days, err := aml.storage.GetAMLDailyAggregates(amlAggregateDay(startDate), amlAggregateDay(endDate))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get alerts: %w", err)
+		return nil, fmt.Errorf("failed to get AML aggregates: %w", err)
 	}
+	activity := mergeAMLAggregates(days)
 
-	dashboard.TotalAlerts = len(alerts)
-
-	// Analyze alerts by risk level and type
-	for _, alert := range alerts {
-		dashboard.AlertsByRiskLevel[alert.RiskLevel]++
-		dashboard.AlertsByType[alert.RuleType]++
+	dashboard.TotalAlerts = activity.TotalAlerts
+	for level, count := range activity.AlertsByRiskLevel {
+		dashboard.AlertsByRiskLevel[level] = count
+	}
+	for ruleType, count := range activity.AlertsByType {
+		dashboard.AlertsByType[ruleType] = count
 	}
 
 	// Generate customer risk summaries
-	dashboard.TopRiskyCustomers = aml.generateCustomerRiskSummaries(alerts, 10)
+	dashboard.TopRiskyCustomers = aml.customerRiskSummaries(activity, 10)
 
 	// Calculate compliance metrics
-	dashboard.ComplianceMetrics = aml.calculateComplianceMetrics(alerts)
+	dashboard.ComplianceMetrics = aml.calculateComplianceMetrics(activity)
 
 	// Perform trend analysis
-	dashboard.TrendAnalysis = aml.performTrendAnalysis(startDate, endDate)
+	dashboard.TrendAnalysis = aml.performTrendAnalysis(days, endDate)
 
 	// Generate recommendations
-	dashboard.RecommendedActions = aml.generateRecommendations(alerts, dashboard.ComplianceMetrics)
+	dashboard.RecommendedActions = aml.generateRecommendations(activity, dashboard.ComplianceMetrics)
 
 	return dashboard, nil
 }
 
-// getAlertsForPeriod retrieves alerts for a specific time period
-func (aml *AMLService) getAlertsForPeriod(startDate, endDate time.Time) ([]*AMLAlert, error) {
-	var alerts []*AMLAlert
-
-	// In a real implementation, this would query the database
-	// For now, return cached alerts that fall within the period
-	for _, alert := range aml.alertsCache {
-		if alert.DetectedAt.After(startDate) && alert.DetectedAt.Before(endDate) {
-			alerts = append(alerts, alert)
-		}
-	}
-
-	return alerts, nil
-}
-
-// generateCustomerRiskSummaries creates risk summaries for top risky customers
-func (aml *AMLService) generateCustomerRiskSummaries(alerts []*AMLAlert, limit int) []CustomerRiskSummary {
-	customerRisks := make(map[string]*CustomerRiskSummary)
-
-	for _, alert := range alerts {
-		if alert.EntityType != "CUSTOMER" {
-			continue
-		}
-
-		if summary, exists := customerRisks[alert.EntityID]; exists {
-			summary.AlertCount++
-			if alert.Amount != nil {
-				summary.TotalVolume += alert.Amount.Value
-			}
-			summary.RiskFactors = append(summary.RiskFactors, string(alert.RuleType))
-		} else {
-			volume := int64(0)
-			if alert.Amount != nil {
-				volume = alert.Amount.Value
-			}
-
-			customerRisks[alert.EntityID] = &CustomerRiskSummary{
-				CustomerID:   alert.EntityID,
-				CustomerName: fmt.Sprintf("Customer %s", alert.EntityID), // In real system, lookup actual name
-				RiskScore:    70,                                         // Calculate based on alert types and frequencies
-				AlertCount:   1,
-				TotalVolume:  volume,
-				LastActivity: alert.DetectedAt,
-				RiskFactors:  []string{string(alert.RuleType)},
-			}
-		}
-	}
-
-	// Convert to slice and sort by risk score
-	var summaries []CustomerRiskSummary
-	for _, summary := range customerRisks {
-		summaries = append(summaries, *summary)
-	}
-
-	sort.Slice(summaries, func(i, j int) bool {
-		return summaries[i].RiskScore > summaries[j].RiskScore
-	})
-
-	if len(summaries) > limit {
-		summaries = summaries[:limit]
-	}
-
-	return summaries
-}
-
 // calculateComplianceMetrics computes various compliance metrics
-func (aml *AMLService) calculateComplianceMetrics(alerts []*AMLAlert) AMLComplianceMetrics {
-	var ctrCount, sarCount, totalTransactions int
-	var resolvedAlerts, falsePositives int
-	var totalResolutionTime int
+func (aml *AMLService) calculateComplianceMetrics(activity *AMLDailyAggregate) AMLComplianceMetrics {
+	ctrCount := activity.AlertsByType[RuleCTR]
+	sarCount := activity.AlertsByType[RuleSAR]
 
-	for _, alert := range alerts {
-		switch alert.RuleType {
-		case RuleCTR:
-			ctrCount++
-		case RuleSAR:
-			sarCount++
-		}
-
-		if alert.Status == "CLOSED" {
-			resolvedAlerts++
-			// Simulate resolution time (in a real system, calculate from timestamps)
-			totalResolutionTime += 24 // Average 24 hours
-		}
-
-		// Simulate false positive identification
-		if strings.Contains(alert.Description, "normal") {
-			falsePositives++
-		}
+	// Rates are taken over the monitored transactions, or a rough estimate when
+	// alerts were raised without monitoring
+	totalTransactions := int(activity.TransactionCount)
+	if totalTransactions == 0 {
+		totalTransactions = activity.TotalAlerts * 10
 	}
 
-	// Simulate total transactions for rate calculations
-	totalTransactions = len(alerts) * 10 // Rough estimate
-
+	// Simulate resolution time (in a real system, calculate from timestamps)
 	avgResolutionTime := 0
-	if resolvedAlerts > 0 {
-		avgResolutionTime = totalResolutionTime / resolvedAlerts
+	if activity.ClosedAlerts > 0 {
+		avgResolutionTime = 24 // Average 24 hours
 	}
 
 	falsePositiveRate := 0.0
-	if len(alerts) > 0 {
-		falsePositiveRate = float64(falsePositives) / float64(len(alerts)) * 100
+	if activity.TotalAlerts > 0 {
+		falsePositiveRate = float64(activity.FalsePositives) / float64(activity.TotalAlerts) * 100
 	}
 
 	// Calculate compliance score (0-100)
@@ -1698,23 +1856,36 @@ func (aml *AMLService) calculateComplianceMetrics(alerts []*AMLAlert) AMLComplia
 	}
 }
 
-// performTrendAnalysis analyzes trends over the specified period
-func (aml *AMLService) performTrendAnalysis(startDate, endDate time.Time) AMLTrendAnalysis {
-	// Simulate trend data (in a real system, query historical data)
-	return AMLTrendAnalysis{
-		AlertTrend30Days:  []int{5, 7, 3, 8, 12, 6, 9, 4, 11, 8},
-		VolumeTrend30Days: []int64{1000000, 1200000, 800000, 1500000, 2000000, 1100000, 1300000, 900000, 1600000, 1400000},
-		RiskScoreTrend:    []float64{65.5, 67.2, 63.8, 69.4, 72.1, 66.9, 68.5, 64.2, 70.8, 69.3},
+// performTrendAnalysis reports daily alerts and monitored volume for the last 30
+// days of the period, oldest first
+func (aml *AMLService) performTrendAnalysis(days []*AMLDailyAggregate, endDate time.Time) AMLTrendAnalysis {
+	byDay := make(map[string]*AMLDailyAggregate, len(days))
+	for _, day := range days {
+		byDay[day.Day] = day
+	}
+
+	trend := AMLTrendAnalysis{
+		AlertTrend30Days:  make([]int, 30),
+		VolumeTrend30Days: make([]int64, 30),
+		// Simulate risk scores and patterns (in a real system, derive from scored alerts)
+		RiskScoreTrend: []float64{65.5, 67.2, 63.8, 69.4, 72.1, 66.9, 68.5, 64.2, 70.8, 69.3},
 		EmergingPatterns: []string{
 			"Increased cash transactions in hospitality sector",
 			"Rise in round-amount wire transfers",
 			"Growing cryptocurrency-related activity",
 		},
 	}
+	for i := 0; i < 30; i++ {
+		if day, ok := byDay[amlAggregateDay(endDate.AddDate(0, 0, i-29))]; ok {
+			trend.AlertTrend30Days[i] = day.TotalAlerts
+			trend.VolumeTrend30Days[i] = day.TransactionVolume
+		}
+	}
+	return trend
 }
 
 // generateRecommendations creates actionable recommendations based on analysis
-func (aml *AMLService) generateRecommendations(alerts []*AMLAlert, metrics AMLComplianceMetrics) []AMLRecommendation {
+func (aml *AMLService) generateRecommendations(activity *AMLDailyAggregate, metrics AMLComplianceMetrics) []AMLRecommendation {
 	var recommendations []AMLRecommendation
 
 	// High false positive rate recommendation
@@ -1750,14 +1921,9 @@ func (aml *AMLService) generateRecommendations(alerts []*AMLAlert, metrics AMLCo
 	}
 
 	// High-risk patterns recommendation
-	highRiskCount := 0
-	for _, alert := range alerts {
-		if alert.RiskLevel == RiskHigh || alert.RiskLevel == RiskCritical {
-			highRiskCount++
-		}
-	}
+	highRiskCount := activity.AlertsByRiskLevel[RiskHigh] + activity.AlertsByRiskLevel[RiskCritical]
 
-	if highRiskCount > len(alerts)/4 {
+	if highRiskCount > activity.TotalAlerts/4 {
 		recommendations = append(recommendations, AMLRecommendation{
 			Priority:    "HIGH",
 			Category:    "INVESTIGATION",
@@ -1782,6 +1948,12 @@ func (aml *AMLService) ExportAMLReport(dashboard *AMLDashboard, format string) (
 		return aml.exportJSONReport(dashboard)
 	case "CSV":
 		return aml.exportCSVReport(dashboard)
+	case string(ExportFormatXLSX), string(ExportFormatPDF):
+		var out bytes.Buffer
+		if err := ExportReport(&out, ExportFormat(format), AMLDashboardDocument(dashboard)); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
 	default:
 		return nil, fmt.Errorf("unsupported export format: %s", format)
 	}
@@ -1871,7 +2043,7 @@ func (aml *AMLService) CheckCashIntensiveActivity(customerID string, timeWindow
 
 	if cashPercentage >= minPercentage && totalVolume >= minVolume {
 		return &AMLAlert{
-			ID:             generateUUID(),
+			ID:             newID(),
 			RuleType:       RuleCashIntensive,
 			Framework:      rule.Framework,
 			RiskLevel:      RiskHigh,
@@ -1905,7 +2077,7 @@ func (aml *AMLService) CheckJustUnderThreshold(txn *Transaction) (*AMLAlert, err
 
 		if entry.Amount.Value >= lowerBound && entry.Amount.Value < threshold {
 			return &AMLAlert{
-				ID:             generateUUID(),
+				ID:             newID(),
 				RuleType:       RuleJustUnderThreshold,
 				Framework:      rule.Framework,
 				RiskLevel:      RiskHigh,
@@ -1955,7 +2127,7 @@ func (aml *AMLService) CheckUnusualTiming(txn *Transaction) (*AMLAlert, error) {
 		}
 
 		return &AMLAlert{
-			ID:             generateUUID(),
+			ID:             newID(),
 			RuleType:       RuleUnusualTiming,
 			Framework:      rule.Framework,
 			RiskLevel:      RiskMedium,
@@ -2012,7 +2184,7 @@ func (aml *AMLService) CheckDormantAccountReactivation(txn *Transaction) (*AMLAl
 
 		if recentActivity == 0 { // Account was dormant
 			return &AMLAlert{
-				ID:             generateUUID(),
+				ID:             newID(),
 				RuleType:       RuleAccountDormancy,
 				Framework:      rule.Framework,
 				RiskLevel:      RiskMedium,
@@ -2063,7 +2235,7 @@ func (aml *AMLService) CheckHighRiskGeography(txn *Transaction, customerInfo *AM
 		totalAmount /= 2
 
 		return &AMLAlert{
-			ID:             generateUUID(),
+			ID:             newID(),
 			RuleType:       RuleUnexpectedGeography,
 			Framework:      rule.Framework,
 			RiskLevel:      RiskHigh,
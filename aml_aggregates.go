@@ -0,0 +1,223 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// AML Dashboard Aggregates
+// ----------------------------------------------------------------------------
+
+// amlAggregateDayLayout is the UTC calendar day AML activity is counted under
+const amlAggregateDayLayout = "2006-01-02"
+
+// amlAggregateDay returns the UTC calendar day an alert or transaction counts under
+func amlAggregateDay(t time.Time) string {
+	return t.UTC().Format(amlAggregateDayLayout)
+}
+
+// AMLCustomerActivity counts one customer's alerts and monitored transactions on a day
+type AMLCustomerActivity struct {
+	CustomerID        string              `json:"customer_id"`
+	AlertCount        int                 `json:"alert_count"`
+	AlertVolume       int64               `json:"alert_volume"`
+	AlertsByType      map[AMLRuleType]int `json:"alerts_by_type"`
+	LastActivity      time.Time           `json:"last_activity"`
+	TransactionCount  int64               `json:"transaction_count"`
+	TransactionVolume int64               `json:"transaction_volume"`
+}
+
+// AMLDailyAggregate holds the dashboard counters for one day. The counters are kept
+// up to date as alerts and monitored transactions are saved, so a dashboard reads one
+// record per day instead of every alert.
+type AMLDailyAggregate struct {
+	Day               string                          `json:"day"` // e.g. "2025-03-14"
+	TotalAlerts       int                             `json:"total_alerts"`
+	AlertsByRiskLevel map[AMLRiskLevel]int            `json:"alerts_by_risk_level"`
+	AlertsByType      map[AMLRuleType]int             `json:"alerts_by_type"`
+	ClosedAlerts      int                             `json:"closed_alerts"`
+	FalsePositives    int                             `json:"false_positives"`
+	TransactionCount  int64                           `json:"transaction_count"`
+	TransactionVolume int64                           `json:"transaction_volume"`
+	Customers         map[string]*AMLCustomerActivity `json:"customers"`
+}
+
+// newAMLDailyAggregate returns empty counters for a day
+func newAMLDailyAggregate(day string) *AMLDailyAggregate {
+	return &AMLDailyAggregate{
+		Day:               day,
+		AlertsByRiskLevel: make(map[AMLRiskLevel]int),
+		AlertsByType:      make(map[AMLRuleType]int),
+		Customers:         make(map[string]*AMLCustomerActivity),
+	}
+}
+
+// customer returns the activity counters for a customer, adding them if needed
+func (a *AMLDailyAggregate) customer(customerID string) *AMLCustomerActivity {
+	activity, ok := a.Customers[customerID]
+	if !ok {
+		activity = &AMLCustomerActivity{CustomerID: customerID, AlertsByType: make(map[AMLRuleType]int)}
+		a.Customers[customerID] = activity
+	}
+	return activity
+}
+
+// applyAlert adds an alert to the counters, or takes it back out when sign is -1
+// so that saving a changed alert replaces what it counted before
+func (a *AMLDailyAggregate) applyAlert(alert *AMLAlert, sign int) {
+	a.TotalAlerts += sign
+	addCount(a.AlertsByRiskLevel, alert.RiskLevel, sign)
+	addCount(a.AlertsByType, alert.RuleType, sign)
+	if alert.Status == "CLOSED" {
+		a.ClosedAlerts += sign
+	}
+	if strings.Contains(alert.Description, "normal") {
+		a.FalsePositives += sign
+	}
+
+	if alert.EntityType != "CUSTOMER" {
+		return
+	}
+	activity := a.customer(alert.EntityID)
+	activity.AlertCount += sign
+	if alert.Amount != nil {
+		activity.AlertVolume += int64(sign) * alert.Amount.Value
+	}
+	addCount(activity.AlertsByType, alert.RuleType, sign)
+	if sign > 0 && alert.DetectedAt.After(activity.LastActivity) {
+		activity.LastActivity = alert.DetectedAt
+	}
+	a.pruneCustomer(activity)
+}
+
+// applyTransaction counts a monitored transaction for the day and its customers
+func (a *AMLDailyAggregate) applyTransaction(txn *AMLTransaction) {
+	var volume int64
+	if txn.Amount != nil {
+		volume = txn.Amount.Value
+	}
+	a.TransactionCount++
+	a.TransactionVolume += volume
+
+	for _, customerID := range []string{txn.FromCustomerID, txn.ToCustomerID} {
+		if customerID == "" {
+			continue
+		}
+		activity := a.customer(customerID)
+		activity.TransactionCount++
+		activity.TransactionVolume += volume
+		if txn.Date.After(activity.LastActivity) {
+			activity.LastActivity = txn.Date
+		}
+	}
+}
+
+// merge adds another day's counters into these
+func (a *AMLDailyAggregate) merge(other *AMLDailyAggregate) {
+	a.TotalAlerts += other.TotalAlerts
+	for level, count := range other.AlertsByRiskLevel {
+		addCount(a.AlertsByRiskLevel, level, count)
+	}
+	for ruleType, count := range other.AlertsByType {
+		addCount(a.AlertsByType, ruleType, count)
+	}
+	a.ClosedAlerts += other.ClosedAlerts
+	a.FalsePositives += other.FalsePositives
+	a.TransactionCount += other.TransactionCount
+	a.TransactionVolume += other.TransactionVolume
+
+	for customerID, other := range other.Customers {
+		activity := a.customer(customerID)
+		activity.AlertCount += other.AlertCount
+		activity.AlertVolume += other.AlertVolume
+		for ruleType, count := range other.AlertsByType {
+			addCount(activity.AlertsByType, ruleType, count)
+		}
+		activity.TransactionCount += other.TransactionCount
+		activity.TransactionVolume += other.TransactionVolume
+		if other.LastActivity.After(activity.LastActivity) {
+			activity.LastActivity = other.LastActivity
+		}
+	}
+}
+
+// pruneCustomer drops a customer left with nothing counted
+func (a *AMLDailyAggregate) pruneCustomer(activity *AMLCustomerActivity) {
+	if activity.AlertCount == 0 && activity.TransactionCount == 0 {
+		delete(a.Customers, activity.CustomerID)
+	}
+}
+
+// addCount adjusts a counter, dropping it when it reaches zero
+func addCount[K comparable](counts map[K]int, key K, delta int) {
+	counts[key] += delta
+	if counts[key] == 0 {
+		delete(counts, key)
+	}
+}
+
+// GetAMLActivity totals the AML counters, including alert and transaction volume by
+// customer, for every UTC day from startDate to endDate
+func (aml *AMLService) GetAMLActivity(startDate, endDate time.Time) (*AMLDailyAggregate, error) {
+	days, err := aml.storage.GetAMLDailyAggregates(amlAggregateDay(startDate), amlAggregateDay(endDate))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AML aggregates: %w", err)
+	}
+	return mergeAMLAggregates(days), nil
+}
+
+// mergeAMLAggregates totals the counters of several days
+func mergeAMLAggregates(days []*AMLDailyAggregate) *AMLDailyAggregate {
+	total := newAMLDailyAggregate("")
+	for _, day := range days {
+		total.merge(day)
+	}
+	return total
+}
+
+// customerRiskSummaries ranks customers by alerts and then alerted volume
+func (aml *AMLService) customerRiskSummaries(activity *AMLDailyAggregate, limit int) []CustomerRiskSummary {
+	var summaries []CustomerRiskSummary
+	for _, customer := range activity.Customers {
+		if customer.AlertCount == 0 {
+			continue
+		}
+		var riskFactors []string
+		for ruleType := range customer.AlertsByType {
+			riskFactors = append(riskFactors, string(ruleType))
+		}
+		sort.Strings(riskFactors)
+
+		summaries = append(summaries, CustomerRiskSummary{
+			CustomerID:   customer.CustomerID,
+			CustomerName: fmt.Sprintf("Customer %s", customer.CustomerID), // In real system, lookup actual name
+			RiskScore:    70,                                              // Calculate based on alert types and frequencies
+			AlertCount:   customer.AlertCount,
+			TotalVolume:  customer.AlertVolume,
+			LastActivity: customer.LastActivity,
+			RiskFactors:  riskFactors,
+		})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].RiskScore != summaries[j].RiskScore {
+			return summaries[i].RiskScore > summaries[j].RiskScore
+		}
+		if summaries[i].AlertCount != summaries[j].AlertCount {
+			return summaries[i].AlertCount > summaries[j].AlertCount
+		}
+		if summaries[i].TotalVolume != summaries[j].TotalVolume {
+			return summaries[i].TotalVolume > summaries[j].TotalVolume
+		}
+		return summaries[i].CustomerID < summaries[j].CustomerID
+	})
+
+	if len(summaries) > limit {
+		summaries = summaries[:limit]
+	}
+
+	return summaries
+}
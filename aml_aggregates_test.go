@@ -0,0 +1,116 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAMLDashboardAggregates(t *testing.T) {
+	dbFile := "test_aml_aggregates.db"
+	defer os.Remove(dbFile)
+
+	storage, err := NewStorage(dbFile)
+	require.NoError(t, err)
+	defer storage.Close()
+
+	aml := NewAMLService(storage, nil, nil)
+	userID := "aml_officer"
+
+	day := func(d, hour int) time.Time { return time.Date(2025, 3, d, hour, 0, 0, 0, time.UTC) }
+	raise := func(ruleType AMLRuleType, level AMLRiskLevel, customerID string, amount int64, detected time.Time) *AMLAlert {
+		alert := &AMLAlert{
+			RuleType:    ruleType,
+			RiskLevel:   level,
+			Title:       string(ruleType),
+			Description: "Flagged activity",
+			EntityID:    customerID,
+			EntityType:  "CUSTOMER",
+			Amount:      &Amount{Value: amount, Currency: "USD"},
+			DetectedAt:  detected,
+		}
+		require.NoError(t, aml.RaiseAlert(alert))
+		return alert
+	}
+
+	first := raise(RuleCTR, RiskMedium, "customer_001", 1500000, day(3, 10))
+	raise(RuleSAR, RiskHigh, "customer_002", 900000, day(3, 23))
+	raise(RuleStructuring, RiskCritical, "customer_002", 950000, day(10, 8))
+	raise(RuleCTR, RiskMedium, "customer_001", 1200000, day(20, 8))
+
+	_, err = aml.MonitorTransaction(&Transaction{
+		ID:        "txn-wire",
+		SourceRef: "WIRE-001",
+		ValidTime: day(10, 12),
+		Entries: []Entry{
+			{AccountID: "cash", Type: Debit, Amount: Amount{Value: 250000, Currency: "USD"}},
+			{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 250000, Currency: "USD"}},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	t.Run("counts alerts by day", func(t *testing.T) {
+		days, err := storage.GetAMLDailyAggregates("2025-03-01", "2025-03-10")
+		require.NoError(t, err)
+		require.Len(t, days, 2)
+		assert.Equal(t, "2025-03-03", days[0].Day)
+		assert.Equal(t, 2, days[0].TotalAlerts)
+		assert.Equal(t, map[AMLRiskLevel]int{RiskMedium: 1, RiskHigh: 1}, days[0].AlertsByRiskLevel)
+		assert.Equal(t, int64(1), days[1].TransactionCount)
+		assert.Equal(t, int64(250000), days[1].TransactionVolume)
+	})
+
+	t.Run("tracks volume by customer", func(t *testing.T) {
+		activity, err := aml.GetAMLActivity(day(1, 0), day(31, 0))
+		require.NoError(t, err)
+		require.Contains(t, activity.Customers, "customer_002")
+		customer := activity.Customers["customer_002"]
+		assert.Equal(t, 2, customer.AlertCount)
+		assert.Equal(t, int64(1850000), customer.AlertVolume)
+		assert.Equal(t, int64(1), customer.TransactionCount)
+		assert.Equal(t, int64(250000), customer.TransactionVolume)
+		assert.Equal(t, day(10, 12), customer.LastActivity)
+	})
+
+	t.Run("status changes move alerts between counters", func(t *testing.T) {
+		require.NoError(t, aml.UpdateAlertStatus(first.ID, "CLOSED", userID))
+		require.NoError(t, aml.UpdateAlertStatus(first.ID, "CLOSED", userID))
+
+		activity, err := aml.GetAMLActivity(day(3, 0), day(3, 0))
+		require.NoError(t, err)
+		assert.Equal(t, 2, activity.TotalAlerts)
+		assert.Equal(t, 1, activity.ClosedAlerts)
+	})
+
+	t.Run("dashboard renders from aggregates", func(t *testing.T) {
+		dashboard, err := aml.GenerateAMLDashboard(day(1, 0), day(15, 0))
+		require.NoError(t, err)
+		assert.Equal(t, 3, dashboard.TotalAlerts)
+		assert.Equal(t, map[AMLRuleType]int{RuleCTR: 1, RuleSAR: 1, RuleStructuring: 1}, dashboard.AlertsByType)
+		assert.Equal(t, 24, dashboard.ComplianceMetrics.AverageResolutionTime)
+		assert.InDelta(t, 100.0, dashboard.ComplianceMetrics.CTRFilingRate, 0.001, "one CTR over one monitored transaction")
+
+		require.Len(t, dashboard.TopRiskyCustomers, 2)
+		assert.Equal(t, "customer_002", dashboard.TopRiskyCustomers[0].CustomerID)
+		assert.Equal(t, []string{string(RuleSAR), string(RuleStructuring)}, dashboard.TopRiskyCustomers[0].RiskFactors)
+
+		trend := dashboard.TrendAnalysis
+		require.Len(t, trend.AlertTrend30Days, 30)
+		assert.Equal(t, 2, trend.AlertTrend30Days[17], "March 3rd is 12 days before the 15th")
+		assert.Equal(t, int64(250000), trend.VolumeTrend30Days[24])
+	})
+
+	t.Run("rebuilds alert counts from saved alerts", func(t *testing.T) {
+		before, err := aml.GetAMLActivity(day(1, 0), day(31, 0))
+		require.NoError(t, err)
+
+		require.NoError(t, storage.RebuildAMLAggregates())
+
+		after, err := aml.GetAMLActivity(day(1, 0), day(31, 0))
+		require.NoError(t, err)
+		assert.Equal(t, before, after)
+	})
+}
@@ -0,0 +1,274 @@
+package accounting
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PIIErasureMode is how a customer's personal data is removed
+type PIIErasureMode string
+
+const (
+	// PIIPseudonymize replaces the name with a stable pseudonym and drops free-text
+	// details, keeping the country, type and risk flags for statistics
+	PIIPseudonymize PIIErasureMode = "PSEUDONYMIZE"
+	// PIIErase removes every personal attribute, including the country
+	PIIErase PIIErasureMode = "ERASE"
+)
+
+// DefaultAMLRecordRetention is how long customer records are kept after the
+// relationship ends, the five years AML regulations commonly require
+const DefaultAMLRecordRetention = 5 * 365 * 24 * time.Hour
+
+// erasedName stands in for an erased customer's name
+const erasedName = "[ERASED]"
+
+// PIIErasureCertificate records that a customer's personal data was removed. It
+// names the fields, not their values.
+type PIIErasureCertificate struct {
+	ID                  string         `json:"id"`
+	AMLCustomerID       string         `json:"aml_customer_id"`
+	CustomerID          string         `json:"customer_id"` // kept, so alerts and the ledger still link up
+	Mode                PIIErasureMode `json:"mode"`
+	Pseudonym           string         `json:"pseudonym,omitempty"`
+	Fields              []string       `json:"fields"`
+	AlertsRetained      int            `json:"alerts_retained"`
+	AlertsScrubbed      int            `json:"alerts_scrubbed"` // alerts whose text named the customer
+	RelationshipEndedAt time.Time      `json:"relationship_ended_at"`
+	RetentionEndedAt    time.Time      `json:"retention_ended_at"`
+	ErasedAt            time.Time      `json:"erased_at"`
+	ErasedBy            string         `json:"erased_by"`
+}
+
+// PIIErasureService pseudonymizes or erases AML customers' personal data once
+// their records are past retention
+type PIIErasureService struct {
+	storage    *Storage
+	eventStore *EventStore
+	aml        *AMLService
+	retention  time.Duration
+}
+
+// NewPIIErasureService creates a new PII erasure service
+func NewPIIErasureService(storage *Storage, eventStore *EventStore, aml *AMLService) *PIIErasureService {
+	return &PIIErasureService{
+		storage:    storage,
+		eventStore: eventStore,
+		aml:        aml,
+		retention:  DefaultAMLRecordRetention,
+	}
+}
+
+// SetRetention sets how long records are kept after a relationship ends; zero
+// restores the default
+func (ps *PIIErasureService) SetRetention(retention time.Duration) {
+	if retention <= 0 {
+		retention = DefaultAMLRecordRetention
+	}
+	ps.retention = retention
+}
+
+// Pseudonymize replaces a customer's personal data with a pseudonym
+func (ps *PIIErasureService) Pseudonymize(customerID, userID string) (*PIIErasureCertificate, error) {
+	return ps.erase(customerID, PIIPseudonymize, userID)
+}
+
+// Erase removes a customer's personal data
+func (ps *PIIErasureService) Erase(customerID, userID string) (*PIIErasureCertificate, error) {
+	return ps.erase(customerID, PIIErase, userID)
+}
+
+// erase removes the personal data from the customer record and from the text of the
+// alerts that name the customer. Alerts, their statistics and the ledger are kept.
+func (ps *PIIErasureService) erase(customerID string, mode PIIErasureMode, userID string) (*PIIErasureCertificate, error) {
+	customer, err := ps.storage.GetAMLCustomer(customerID)
+	if err != nil {
+		return nil, err
+	}
+	if customer.ErasedAt != nil && (customer.ErasureMode == PIIErase || mode == PIIPseudonymize) {
+		return nil, fmt.Errorf("customer %s was already %s on %s", customerID, strings.ToLower(string(customer.ErasureMode))+"d", customer.ErasedAt.Format("2006-01-02"))
+	}
+
+	now := time.Now()
+	if customer.RelationshipEndedAt == nil {
+		return nil, fmt.Errorf("customer %s still has an active relationship", customerID)
+	}
+	retentionEnds := customer.RelationshipEndedAt.Add(ps.retention)
+	if now.Before(retentionEnds) {
+		return nil, fmt.Errorf("customer %s records must be retained until %s", customerID, retentionEnds.Format("2006-01-02"))
+	}
+
+	alerts, err := ps.storage.GetAMLAlerts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AML alerts: %w", err)
+	}
+	var related []*AMLAlert
+	for _, alert := range alerts {
+		if !alertConcerns(alert, customer) {
+			continue
+		}
+		if alertActive(alert) {
+			return nil, fmt.Errorf("customer %s is under active investigation in AML alert %s", customerID, alert.ID)
+		}
+		related = append(related, alert)
+	}
+
+	certificate := &PIIErasureCertificate{
+		ID:                  newID(),
+		AMLCustomerID:       customer.ID,
+		CustomerID:          customer.CustomerID,
+		Mode:                mode,
+		AlertsRetained:      len(related),
+		RelationshipEndedAt: *customer.RelationshipEndedAt,
+		RetentionEndedAt:    retentionEnds,
+		ErasedAt:            now,
+		ErasedBy:            userID,
+	}
+
+	replacement := erasedName
+	if mode == PIIPseudonymize {
+		certificate.Pseudonym = pseudonym(customer)
+		replacement = certificate.Pseudonym
+	}
+	name := customer.Name
+	if name != "" && name != replacement {
+		certificate.Fields = append(certificate.Fields, "name")
+	}
+	customer.Name = replacement
+	if customer.ExpectedActivity != "" {
+		certificate.Fields = append(certificate.Fields, "expected_activity")
+		customer.ExpectedActivity = ""
+	}
+	if customer.BusinessPurpose != "" {
+		certificate.Fields = append(certificate.Fields, "business_purpose")
+		customer.BusinessPurpose = ""
+	}
+	if mode == PIIErase && customer.Country != "" {
+		certificate.Fields = append(certificate.Fields, "country")
+		customer.Country = ""
+	}
+	customer.ErasedAt = &now
+	customer.ErasureMode = mode
+	customer.UpdatedAt = now
+
+	if name != "" && name != replacement {
+		for _, alert := range related {
+			if scrubAlert(alert, name, replacement) {
+				alert.UpdatedAt = now
+				if err := ps.storage.SaveAMLAlert(alert); err != nil {
+					return nil, fmt.Errorf("failed to save AML alert %s: %w", alert.ID, err)
+				}
+				certificate.AlertsScrubbed++
+			}
+		}
+	}
+
+	if err := ps.storage.SaveAMLCustomer(customer); err != nil {
+		return nil, fmt.Errorf("failed to save AML customer: %w", err)
+	}
+	if _, ok := ps.aml.customers[customer.ID]; ok {
+		ps.aml.customers[customer.ID] = customer
+	}
+	if _, err := ps.eventStore.CreateEvent(EventEraseAMLCustomer, certificate, now, userID); err != nil {
+		return nil, fmt.Errorf("failed to record erasure certificate: %w", err)
+	}
+	return certificate, nil
+}
+
+// GetCertificates returns the erasure certificates issued between from and to
+func (ps *PIIErasureService) GetCertificates(from, to time.Time) ([]*PIIErasureCertificate, error) {
+	events, err := ps.eventStore.GetEvents(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+	var certificates []*PIIErasureCertificate
+	for _, event := range events {
+		if event.EventType != EventEraseAMLCustomer {
+			continue
+		}
+		var certificate PIIErasureCertificate
+		if err := json.Unmarshal(event.Payload, &certificate); err != nil {
+			return nil, fmt.Errorf("failed to decode erasure event %s: %w", event.ID, err)
+		}
+		certificates = append(certificates, &certificate)
+	}
+	sort.SliceStable(certificates, func(i, j int) bool {
+		return certificates[i].ErasedAt.Before(certificates[j].ErasedAt)
+	})
+	return certificates, nil
+}
+
+// GetDueForErasure lists the customers whose retention period has ended and whose
+// personal data has not been erased
+func (ps *PIIErasureService) GetDueForErasure(asOf time.Time) ([]*AMLCustomer, error) {
+	customers, err := ps.storage.GetAllAMLCustomers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AML customers: %w", err)
+	}
+	var due []*AMLCustomer
+	for _, customer := range customers {
+		if customer.ErasedAt != nil || customer.RelationshipEndedAt == nil {
+			continue
+		}
+		if !asOf.Before(customer.RelationshipEndedAt.Add(ps.retention)) {
+			due = append(due, customer)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].RelationshipEndedAt.Before(*due[j].RelationshipEndedAt)
+	})
+	return due, nil
+}
+
+// pseudonym derives a stable stand-in for a customer's name from its IDs
+func pseudonym(customer *AMLCustomer) string {
+	sum := sha256.Sum256([]byte(customer.ID + "\x00" + customer.CustomerID))
+	return "SUBJECT-" + strings.ToUpper(hex.EncodeToString(sum[:6]))
+}
+
+// alertConcerns reports whether an alert is about the customer, by entity or by name
+func alertConcerns(alert *AMLAlert, customer *AMLCustomer) bool {
+	if alert.EntityType == "CUSTOMER" && (alert.EntityID == customer.ID || alert.EntityID == customer.CustomerID) {
+		return true
+	}
+	return customer.Name != "" && strings.Contains(alert.Description, customer.Name)
+}
+
+// alertActive reports whether an alert is still being worked
+func alertActive(alert *AMLAlert) bool {
+	if alert.Investigation != nil && alert.Investigation.Status == "ACTIVE" {
+		return true
+	}
+	return alert.Status != "CLOSED"
+}
+
+// scrubAlert replaces name in the alert's free text, reporting whether it changed
+func scrubAlert(alert *AMLAlert, name, replacement string) bool {
+	changed := false
+	scrub := func(text *string) {
+		if strings.Contains(*text, name) {
+			*text = strings.ReplaceAll(*text, name, replacement)
+			changed = true
+		}
+	}
+	scrub(&alert.Title)
+	scrub(&alert.Description)
+	if investigation := alert.Investigation; investigation != nil {
+		for i := range investigation.Findings {
+			scrub(&investigation.Findings[i])
+		}
+		for i := range investigation.Notes {
+			scrub(&investigation.Notes[i].Content)
+		}
+		for i := range investigation.Actions {
+			scrub(&investigation.Actions[i].Description)
+			scrub(&investigation.Actions[i].Result)
+		}
+	}
+	return changed
+}
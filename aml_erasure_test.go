@@ -0,0 +1,136 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPIIErasure(t *testing.T) {
+	// Setup
+	dbFile := "test_pii_erasure.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "mlro"
+	started := time.Now()
+	aml := engine.GetAMLService()
+	register := func(id, name string) {
+		require.NoError(t, aml.RegisterCustomer(&AMLCustomer{
+			ID:               id,
+			CustomerID:       "cust-" + id,
+			Name:             name,
+			Type:             "INDIVIDUAL",
+			RiskLevel:        RiskHigh,
+			Country:          "GB",
+			IsPEP:            true,
+			ExpectedActivity: "Salary deposits",
+			BusinessPurpose:  "Personal banking",
+		}))
+	}
+	raise := func(customerID, name string) *AMLAlert {
+		alert := &AMLAlert{
+			ID:          newID(),
+			RuleType:    RuleSanctions,
+			RiskLevel:   RiskCritical,
+			Title:       "Sanctions Match Detected",
+			Description: "Customer " + name + " matches sanctions list",
+			EntityID:    customerID,
+			EntityType:  "CUSTOMER",
+			DetectedAt:  time.Now(),
+			Status:      "OPEN",
+		}
+		require.NoError(t, aml.RaiseAlert(alert))
+		return alert
+	}
+
+	register("aml-1", "Jane Doe")
+	register("aml-2", "John Roe")
+	alert := raise("cust-aml-1", "Jane Doe")
+
+	t.Run("Retention", func(t *testing.T) {
+		_, err := engine.PseudonymizeAMLCustomer("aml-1", userID)
+		assert.ErrorContains(t, err, "active relationship")
+
+		require.NoError(t, engine.EndAMLCustomerRelationship("aml-1", time.Now().AddDate(-1, 0, 0), userID))
+		_, err = engine.PseudonymizeAMLCustomer("aml-1", userID)
+		assert.ErrorContains(t, err, "must be retained until")
+
+		require.NoError(t, engine.EndAMLCustomerRelationship("aml-1", time.Now().AddDate(-6, 0, 0), userID))
+		due, err := engine.GetAMLCustomersDueForErasure(time.Now())
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		assert.Equal(t, "aml-1", due[0].ID)
+	})
+
+	t.Run("Active Investigation", func(t *testing.T) {
+		_, err := engine.PseudonymizeAMLCustomer("aml-1", userID)
+		assert.ErrorContains(t, err, "under active investigation")
+
+		require.NoError(t, engine.UpdateAMLAlertStatus(alert.ID, "CLOSED", userID))
+	})
+
+	t.Run("Pseudonymize", func(t *testing.T) {
+		certificate, err := engine.PseudonymizeAMLCustomer("aml-1", userID)
+		require.NoError(t, err)
+		assert.Equal(t, PIIPseudonymize, certificate.Mode)
+		assert.Equal(t, []string{"name", "expected_activity", "business_purpose"}, certificate.Fields)
+		assert.Equal(t, 1, certificate.AlertsRetained)
+		assert.Equal(t, 1, certificate.AlertsScrubbed)
+
+		customer, err := engine.GetStorage().GetAMLCustomer("aml-1")
+		require.NoError(t, err)
+		assert.Equal(t, certificate.Pseudonym, customer.Name)
+		assert.Equal(t, pseudonym(customer), customer.Name, "pseudonyms are stable")
+		assert.Equal(t, "GB", customer.Country, "statistical attributes are kept")
+		assert.True(t, customer.IsPEP)
+		assert.Empty(t, customer.ExpectedActivity)
+		require.NotNil(t, customer.ErasedAt)
+
+		saved, err := engine.GetStorage().GetAMLAlert(alert.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "CLOSED", saved.Status)
+		assert.Equal(t, "cust-aml-1", saved.EntityID, "the alert still links to the customer")
+		assert.NotContains(t, saved.Description, "Jane Doe")
+		assert.Contains(t, saved.Description, certificate.Pseudonym)
+
+		_, err = engine.PseudonymizeAMLCustomer("aml-1", userID)
+		assert.ErrorContains(t, err, "already pseudonymized")
+	})
+
+	t.Run("Erase", func(t *testing.T) {
+		certificate, err := engine.EraseAMLCustomer("aml-1", userID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"name", "country"}, certificate.Fields)
+
+		customer, err := engine.GetStorage().GetAMLCustomer("aml-1")
+		require.NoError(t, err)
+		assert.Equal(t, erasedName, customer.Name)
+		assert.Empty(t, customer.Country)
+		assert.Equal(t, PIIErase, customer.ErasureMode)
+		assert.Equal(t, RiskHigh, customer.RiskLevel)
+
+		_, err = engine.EraseAMLCustomer("aml-1", userID)
+		assert.ErrorContains(t, err, "already erased")
+
+		untouched, err := engine.GetStorage().GetAMLCustomer("aml-2")
+		require.NoError(t, err)
+		assert.Equal(t, "John Roe", untouched.Name)
+	})
+
+	t.Run("Certificates", func(t *testing.T) {
+		certificates, err := engine.GetErasureCertificates(started, time.Now())
+		require.NoError(t, err)
+		require.Len(t, certificates, 2)
+		assert.Equal(t, PIIPseudonymize, certificates[0].Mode)
+		assert.Equal(t, PIIErase, certificates[1].Mode)
+		assert.Equal(t, "cust-aml-1", certificates[1].CustomerID)
+		assert.Equal(t, userID, certificates[1].ErasedBy)
+	})
+}
@@ -0,0 +1,469 @@
+package accounting
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Alert and SAR Narratives
+// ----------------------------------------------------------------------------
+
+// NarrativeKind is what a narrative is written for
+type NarrativeKind string
+
+const (
+	NarrativeAlert NarrativeKind = "ALERT" // analyst summary of an alert
+	NarrativeSAR   NarrativeKind = "SAR"   // draft narrative for a suspicious activity report
+)
+
+// NarrativeSectionTemplate is one section of a narrative layout. Text is a Go
+// text/template executed over NarrativeFacts, with the functions money, date,
+// dateRange, percent, join and lower.
+type NarrativeSectionTemplate struct {
+	Key     string `json:"key"` // e.g. "WHO"
+	Heading string `json:"heading"`
+	Text    string `json:"text"`
+}
+
+// NarrativeTemplate is a configurable narrative layout
+type NarrativeTemplate struct {
+	ID        string                     `json:"id"`
+	Name      string                     `json:"name"`
+	Kind      NarrativeKind              `json:"kind"`
+	RuleTypes []AMLRuleType              `json:"rule_types,omitempty"` // alerts it is for; empty for any
+	Sections  []NarrativeSectionTemplate `json:"sections"`
+	CreatedBy string                     `json:"created_by"`
+	CreatedAt time.Time                  `json:"created_at"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+}
+
+// NarrativeSection is a generated section. Analysts edit Text; Generated keeps
+// what the template produced.
+type NarrativeSection struct {
+	Key       string     `json:"key"`
+	Heading   string     `json:"heading"`
+	Text      string     `json:"text"`
+	Generated string     `json:"generated"`
+	EditedBy  string     `json:"edited_by,omitempty"`
+	EditedAt  *time.Time `json:"edited_at,omitempty"`
+}
+
+// Narrative is a structured, editable write-up of an alert
+type Narrative struct {
+	ID          string             `json:"id"`
+	AlertID     string             `json:"alert_id"`
+	TemplateID  string             `json:"template_id,omitempty"` // empty for the built-in layout
+	Kind        NarrativeKind      `json:"kind"`
+	Sections    []NarrativeSection `json:"sections"`
+	GeneratedBy string             `json:"generated_by"`
+	GeneratedAt time.Time          `json:"generated_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// NarrativeTransaction is one alerted transaction as narratives describe it
+type NarrativeTransaction struct {
+	ID             string
+	Description    string
+	Date           time.Time
+	Amount         int64 // minor units
+	Currency       string
+	Channel        string
+	Purpose        string
+	FromCustomerID string
+	ToCustomerID   string
+	FromCountry    string
+	ToCountry      string
+}
+
+// NarrativeFacts is what narrative templates are executed over
+type NarrativeFacts struct {
+	Alert        *AMLAlert
+	Customer     *AMLCustomer // nil when the subject is not a registered customer
+	Subject      string       // the customer's name, or the alert's entity
+	Transactions []NarrativeTransaction
+	TotalAmount  int64 // minor units of Currency
+	Currency     string
+	FirstDate    time.Time // of the transactions, or detection when there are none
+	LastDate     time.Time
+	Channels     []string // distinct, in order of appearance
+	Countries    []string
+	Evidence     []AMLEvidence
+	Findings     []string // from the investigation, if one is open
+}
+
+// narrativeFuncs are the functions narrative templates may call
+var narrativeFuncs = template.FuncMap{
+	"money": func(value int64, currency string) string {
+		return FormatMinorUnits(value, Currency(currency)) + " " + currency
+	},
+	"date": func(t time.Time) string { return t.Format("2 January 2006") },
+	"dateRange": func(first, last time.Time) string {
+		if first.Format(time.DateOnly) == last.Format(time.DateOnly) {
+			return "on " + first.Format("2 January 2006")
+		}
+		return "between " + first.Format("2 January 2006") + " and " + last.Format("2 January 2006")
+	},
+	"percent": func(f float64) string { return fmt.Sprintf("%.0f%%", f*100) },
+	"join":    func(values []string, sep string) string { return strings.Join(values, sep) },
+	"lower":   strings.ToLower,
+}
+
+// DefaultNarrativeTemplate is the built-in layout used when no saved template fits
+func DefaultNarrativeTemplate(kind NarrativeKind) *NarrativeTemplate {
+	sections := []NarrativeSectionTemplate{
+		{Key: "WHO", Heading: "Subject", Text: `{{with .Customer}}{{.Name}} ({{lower .Type}} customer {{.CustomerID}}{{with .Country}}, {{.}}{{end}}), rated {{lower (print .RiskLevel)}} risk{{if .IsPEP}}, a politically exposed person{{end}}{{if .SanctionsMatch}}, with a sanctions match{{end}}.{{else}}{{.Subject}}.{{end}}`},
+		{Key: "WHAT", Heading: "Activity", Text: `{{len .Transactions}} transaction(s) totalling {{money .TotalAmount .Currency}} raised a {{lower (print .Alert.RiskLevel)}} risk {{.Alert.RuleType}} alert: {{.Alert.Description}}.`},
+		{Key: "WHEN", Heading: "Timing", Text: `The activity took place {{dateRange .FirstDate .LastDate}} and was detected on {{date .Alert.DetectedAt}}.`},
+		{Key: "WHERE", Heading: "Channels and locations", Text: `{{if .Channels}}Conducted by {{lower (join .Channels ", ")}}{{else}}The channel was not recorded{{end}}{{with .Countries}}, involving {{join . ", "}}{{end}}.`},
+		{Key: "WHY", Heading: "Grounds for suspicion", Text: `{{range .Evidence}}- {{.Description}} (source {{.Source}}, confidence {{percent .Confidence}})
+{{end}}{{range .Findings}}- {{.}}
+{{end}}{{with .Customer}}{{with .ExpectedActivity}}Expected activity on file: {{.}}.{{end}}{{end}}`},
+	}
+	name := "Alert summary"
+	if kind == NarrativeSAR {
+		name = "SAR narrative"
+		sections = append(sections, NarrativeSectionTemplate{Key: "HOW", Heading: "Transactions", Text: `{{range .Transactions}}- {{date .Date}}: {{money .Amount .Currency}}{{with .Channel}} by {{lower .}}{{end}}{{with .Purpose}} for "{{.}}"{{end}} (transaction {{.ID}})
+{{end}}`})
+	}
+	return &NarrativeTemplate{Name: name, Kind: kind, Sections: sections}
+}
+
+// Validate checks a narrative template's sections parse and run
+func (t *NarrativeTemplate) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("narrative template name is required")
+	}
+	if t.Kind != NarrativeAlert && t.Kind != NarrativeSAR {
+		return fmt.Errorf("unknown narrative kind %s", t.Kind)
+	}
+	if len(t.Sections) == 0 {
+		return fmt.Errorf("narrative template %s has no sections", t.Name)
+	}
+	keys := make(map[string]bool)
+	for _, section := range t.Sections {
+		if section.Key == "" {
+			return fmt.Errorf("narrative template %s has a section without a key", t.Name)
+		}
+		if keys[section.Key] {
+			return fmt.Errorf("narrative template %s repeats section %s", t.Name, section.Key)
+		}
+		keys[section.Key] = true
+		// Running over empty facts catches misspelt fields as well as syntax errors
+		if _, err := renderNarrativeSection(section, &NarrativeFacts{Alert: &AMLAlert{}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderNarrativeSection executes one section's template
+func renderNarrativeSection(section NarrativeSectionTemplate, facts *NarrativeFacts) (string, error) {
+	tmpl, err := template.New(section.Key).Funcs(narrativeFuncs).Option("missingkey=error").Parse(section.Text)
+	if err != nil {
+		return "", fmt.Errorf("section %s: %w", section.Key, err)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, facts); err != nil {
+		return "", fmt.Errorf("section %s: %w", section.Key, err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// NarrativeService drafts alert and SAR narratives from templates
+type NarrativeService struct {
+	storage    *Storage
+	eventStore *EventStore
+	aml        *AMLService
+}
+
+// NewNarrativeService creates a new narrative service
+func NewNarrativeService(storage *Storage, eventStore *EventStore, aml *AMLService) *NarrativeService {
+	return &NarrativeService{
+		storage:    storage,
+		eventStore: eventStore,
+		aml:        aml,
+	}
+}
+
+// SaveTemplate creates a narrative template, or replaces the one with the same ID
+func (ns *NarrativeService) SaveTemplate(tmpl *NarrativeTemplate, userID string) error {
+	if err := tmpl.Validate(); err != nil {
+		return err
+	}
+	now := time.Now()
+	if tmpl.ID != "" {
+		existing, err := ns.storage.GetNarrativeTemplate(tmpl.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get narrative template: %w", err)
+		}
+		tmpl.CreatedBy = existing.CreatedBy
+		tmpl.CreatedAt = existing.CreatedAt
+	} else {
+		if err := ns.storage.assignID(&tmpl.ID, "narrative_template", BucketNarrativeTemplates); err != nil {
+			return err
+		}
+		tmpl.CreatedBy = userID
+		tmpl.CreatedAt = now
+	}
+	tmpl.UpdatedAt = now
+
+	_, err := ns.eventStore.CreateEvent(EventSaveNarrativeTemplate, tmpl, now, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create narrative template event: %w", err)
+	}
+	return ns.storage.SaveNarrativeTemplate(tmpl)
+}
+
+// GenerateNarrative drafts a narrative for an alert. An empty templateID picks the
+// most recently updated saved template of the kind for the alert's rule type,
+// preferring ones written for that rule type, or else the built-in layout.
+func (ns *NarrativeService) GenerateNarrative(alertID string, kind NarrativeKind, templateID, userID string) (*Narrative, error) {
+	alert, err := ns.storage.GetAMLAlert(alertID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AML alert: %w", err)
+	}
+	tmpl, err := ns.chooseTemplate(alert, kind, templateID)
+	if err != nil {
+		return nil, err
+	}
+	facts, err := ns.gatherFacts(alert)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	narrative := &Narrative{
+		AlertID:     alert.ID,
+		TemplateID:  tmpl.ID,
+		Kind:        tmpl.Kind,
+		GeneratedBy: userID,
+		GeneratedAt: now,
+		UpdatedAt:   now,
+	}
+	for _, section := range tmpl.Sections {
+		text, err := renderNarrativeSection(section, facts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render narrative: %w", err)
+		}
+		narrative.Sections = append(narrative.Sections, NarrativeSection{
+			Key:       section.Key,
+			Heading:   section.Heading,
+			Text:      text,
+			Generated: text,
+		})
+	}
+	if err := ns.storage.assignID(&narrative.ID, "narrative", BucketNarratives); err != nil {
+		return nil, err
+	}
+
+	_, err = ns.eventStore.CreateEvent(EventGenerateNarrative, narrative, now, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create narrative event: %w", err)
+	}
+	if err := ns.storage.SaveNarrative(narrative); err != nil {
+		return nil, fmt.Errorf("failed to save narrative: %w", err)
+	}
+	return narrative, nil
+}
+
+// EditNarrativeSection replaces the text of one section of a narrative
+func (ns *NarrativeService) EditNarrativeSection(narrativeID, key, text, userID string) (*Narrative, error) {
+	narrative, err := ns.storage.GetNarrative(narrativeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get narrative: %w", err)
+	}
+	i := slices.IndexFunc(narrative.Sections, func(section NarrativeSection) bool { return section.Key == key })
+	if i < 0 {
+		return nil, fmt.Errorf("narrative %s has no section %s", narrativeID, key)
+	}
+
+	now := time.Now()
+	narrative.Sections[i].Text = text
+	narrative.Sections[i].EditedBy = userID
+	narrative.Sections[i].EditedAt = &now
+	narrative.UpdatedAt = now
+
+	_, err = ns.eventStore.CreateEvent(EventEditNarrative, narrative, now, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create narrative edit event: %w", err)
+	}
+	if err := ns.storage.SaveNarrative(narrative); err != nil {
+		return nil, fmt.Errorf("failed to save narrative: %w", err)
+	}
+	return narrative, nil
+}
+
+// GetNarratives returns an alert's narratives, oldest first
+func (ns *NarrativeService) GetNarratives(alertID string) ([]*Narrative, error) {
+	all, err := ns.storage.GetAllNarratives()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get narratives: %w", err)
+	}
+	var narratives []*Narrative
+	for _, narrative := range all {
+		if narrative.AlertID == alertID {
+			narratives = append(narratives, narrative)
+		}
+	}
+	sort.SliceStable(narratives, func(i, j int) bool {
+		return narratives[i].GeneratedAt.Before(narratives[j].GeneratedAt)
+	})
+	return narratives, nil
+}
+
+// FormatNarrative renders a narrative as text, one headed paragraph per section
+func FormatNarrative(narrative *Narrative) string {
+	var out strings.Builder
+	for i, section := range narrative.Sections {
+		if i > 0 {
+			out.WriteString("\n")
+		}
+		fmt.Fprintf(&out, "%s\n%s\n", strings.ToUpper(section.Heading), section.Text)
+	}
+	return out.String()
+}
+
+// chooseTemplate finds the template to draft an alert's narrative with
+func (ns *NarrativeService) chooseTemplate(alert *AMLAlert, kind NarrativeKind, templateID string) (*NarrativeTemplate, error) {
+	if templateID != "" {
+		tmpl, err := ns.storage.GetNarrativeTemplate(templateID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get narrative template: %w", err)
+		}
+		if tmpl.Kind != kind {
+			return nil, fmt.Errorf("narrative template %s is for %s narratives, not %s", tmpl.Name, tmpl.Kind, kind)
+		}
+		return tmpl, nil
+	}
+	if kind != NarrativeAlert && kind != NarrativeSAR {
+		return nil, fmt.Errorf("unknown narrative kind %s", kind)
+	}
+
+	templates, err := ns.storage.GetAllNarrativeTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get narrative templates: %w", err)
+	}
+	var best *NarrativeTemplate
+	for _, tmpl := range templates {
+		if tmpl.Kind != kind || (len(tmpl.RuleTypes) > 0 && !slices.Contains(tmpl.RuleTypes, alert.RuleType)) {
+			continue
+		}
+		switch {
+		case best == nil,
+			len(tmpl.RuleTypes) > 0 && len(best.RuleTypes) == 0,
+			(len(tmpl.RuleTypes) > 0) == (len(best.RuleTypes) > 0) && tmpl.UpdatedAt.After(best.UpdatedAt):
+			best = tmpl
+		}
+	}
+	if best == nil {
+		best = DefaultNarrativeTemplate(kind)
+	}
+	return best, nil
+}
+
+// gatherFacts collects what is known about an alert's subject and activity
+func (ns *NarrativeService) gatherFacts(alert *AMLAlert) (*NarrativeFacts, error) {
+	customers, err := ns.storage.GetAllAMLCustomers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AML customers: %w", err)
+	}
+	customerInfo := make(map[string]*AMLCustomer, len(customers))
+	for _, customer := range customers {
+		customerInfo[customer.CustomerID] = customer
+	}
+	findCustomer := func(id string) *AMLCustomer {
+		if customer, ok := customerInfo[id]; ok {
+			return customer
+		}
+		for _, customer := range customers {
+			if customer.ID == id {
+				return customer
+			}
+		}
+		return nil
+	}
+
+	facts := &NarrativeFacts{
+		Alert:    alert,
+		Subject:  strings.ToLower(alert.EntityType) + " " + alert.EntityID,
+		Currency: alert.Currency,
+		Evidence: alert.Evidence,
+	}
+	if alert.Investigation != nil {
+		facts.Findings = alert.Investigation.Findings
+	}
+	if alert.EntityType == "CUSTOMER" {
+		facts.Customer = findCustomer(alert.EntityID)
+	}
+
+	// Alerts may cite transactions that are not on the ledger, e.g. raised from an
+	// external feed, so those are left out
+	for _, id := range alert.TransactionIDs {
+		txn, err := ns.storage.GetTransaction(id)
+		if err != nil {
+			continue
+		}
+		amlTxn := ns.aml.convertToAMLTransaction(txn, customerInfo)
+		for _, enrich := range ns.aml.enrichers {
+			if err := enrich(amlTxn); err != nil {
+				return nil, fmt.Errorf("failed to enrich AML transaction %s: %w", txn.ID, err)
+			}
+		}
+		facts.Transactions = append(facts.Transactions, NarrativeTransaction{
+			ID:             txn.ID,
+			Description:    txn.Description,
+			Date:           txn.ValidTime,
+			Amount:         amlTxn.Amount.Value,
+			Currency:       amlTxn.Currency,
+			Channel:        amlTxn.Channel,
+			Purpose:        amlTxn.Purpose,
+			FromCustomerID: amlTxn.FromCustomerID,
+			ToCustomerID:   amlTxn.ToCustomerID,
+			FromCountry:    amlTxn.FromCountry,
+			ToCountry:      amlTxn.ToCountry,
+		})
+	}
+	sort.SliceStable(facts.Transactions, func(i, j int) bool {
+		return facts.Transactions[i].Date.Before(facts.Transactions[j].Date)
+	})
+
+	addDistinct := func(values []string, value string) []string {
+		if value == "" || slices.Contains(values, value) {
+			return values
+		}
+		return append(values, value)
+	}
+	for _, txn := range facts.Transactions {
+		facts.TotalAmount += txn.Amount
+		facts.Channels = addDistinct(facts.Channels, txn.Channel)
+		facts.Countries = addDistinct(facts.Countries, txn.FromCountry)
+		facts.Countries = addDistinct(facts.Countries, txn.ToCountry)
+		if facts.Customer == nil && txn.FromCustomerID != "" {
+			facts.Customer = findCustomer(txn.FromCustomerID)
+		}
+	}
+	if len(facts.Transactions) > 0 {
+		facts.FirstDate = facts.Transactions[0].Date
+		facts.LastDate = facts.Transactions[len(facts.Transactions)-1].Date
+		if facts.Currency == "" {
+			facts.Currency = facts.Transactions[0].Currency
+		}
+	} else {
+		facts.FirstDate, facts.LastDate = alert.DetectedAt, alert.DetectedAt
+		if alert.Amount != nil {
+			facts.TotalAmount = alert.Amount.Value
+			facts.Currency = string(alert.Amount.Currency)
+		}
+	}
+	if facts.Customer != nil {
+		facts.Subject = facts.Customer.Name
+		facts.Countries = addDistinct(facts.Countries, facts.Customer.Country)
+	}
+	return facts, nil
+}
@@ -0,0 +1,148 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAMLNarratives(t *testing.T) {
+	dbFile := "test_aml_narratives.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "aml_analyst"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	aml := engine.GetAMLService()
+	require.NoError(t, aml.RegisterCustomer(&AMLCustomer{
+		ID:               "cust-2",
+		CustomerID:       "customer_002",
+		Name:             "Harbour Traders Ltd",
+		Type:             "BUSINESS",
+		RiskLevel:        RiskHigh,
+		Country:          "GB",
+		ExpectedActivity: "Weekly card takings under $5,000",
+	}))
+
+	deposit := func(when time.Time, value int64) *Transaction {
+		txn := &Transaction{
+			Description: "Cash deposit",
+			SourceRef:   "CASH-001",
+			ValidTime:   when,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	second := deposit(time.Date(2026, 6, 9, 11, 0, 0, 0, time.UTC), 950000)
+	first := deposit(time.Date(2026, 6, 2, 10, 0, 0, 0, time.UTC), 900000)
+
+	alert := &AMLAlert{
+		RuleType:       RuleStructuring,
+		RiskLevel:      RiskHigh,
+		Title:          "Possible structuring",
+		Description:    "Cash deposits kept just below the reporting threshold",
+		EntityID:       "customer_002",
+		EntityType:     "CUSTOMER",
+		TransactionIDs: []string{second.ID, first.ID, "missing"},
+		Currency:       "USD",
+		DetectedAt:     time.Date(2026, 6, 10, 8, 0, 0, 0, time.UTC),
+		Evidence: []AMLEvidence{
+			{Type: "PATTERN", Description: "Two deposits within 95% of the CTR threshold", Source: "STRUCTURING_RULE", Confidence: 0.85},
+		},
+	}
+	require.NoError(t, aml.RaiseAlert(alert))
+
+	t.Run("alert narrative from the built-in template", func(t *testing.T) {
+		narrative, err := engine.GenerateNarrative(alert.ID, NarrativeAlert, "", userID)
+		require.NoError(t, err)
+		assert.Empty(t, narrative.TemplateID)
+
+		sections := make(map[string]string)
+		for _, section := range narrative.Sections {
+			sections[section.Key] = section.Text
+			assert.Equal(t, section.Text, section.Generated)
+		}
+		assert.Len(t, sections, 5)
+		assert.Equal(t, "Harbour Traders Ltd (business customer customer_002, GB), rated high risk.", sections["WHO"])
+		assert.Contains(t, sections["WHAT"], "2 transaction(s) totalling 18500.00 USD")
+		assert.Equal(t, "The activity took place between 2 June 2026 and 9 June 2026 and was detected on 10 June 2026.", sections["WHEN"])
+		assert.Equal(t, "Conducted by cash, involving GB.", sections["WHERE"])
+		assert.Contains(t, sections["WHY"], "- Two deposits within 95% of the CTR threshold (source STRUCTURING_RULE, confidence 85%)")
+		assert.Contains(t, sections["WHY"], "Expected activity on file: Weekly card takings under $5,000.")
+	})
+
+	t.Run("SAR draft lists the transactions in date order", func(t *testing.T) {
+		narrative, err := engine.GenerateNarrative(alert.ID, NarrativeSAR, "", userID)
+		require.NoError(t, err)
+		how := narrative.Sections[len(narrative.Sections)-1]
+		assert.Equal(t, "HOW", how.Key)
+		assert.Equal(t, "- 2 June 2026: 9000.00 USD by cash for \"Business cash deposit\" (transaction "+first.ID+")\n"+
+			"- 9 June 2026: 9500.00 USD by cash for \"Business cash deposit\" (transaction "+second.ID+")", how.Text)
+		assert.Contains(t, FormatNarrative(narrative), "TRANSACTIONS\n- 2 June 2026")
+	})
+
+	t.Run("sections are editable", func(t *testing.T) {
+		narratives, err := engine.GetNarratives(alert.ID)
+		require.NoError(t, err)
+		require.Len(t, narratives, 2)
+
+		edited, err := engine.EditNarrativeSection(narratives[0].ID, "WHY", "Deposits were split to avoid a CTR.", userID)
+		require.NoError(t, err)
+		stored, err := engine.GetStorage().GetNarrative(edited.ID)
+		require.NoError(t, err)
+		why := stored.Sections[4]
+		assert.Equal(t, "Deposits were split to avoid a CTR.", why.Text)
+		assert.Contains(t, why.Generated, "STRUCTURING_RULE")
+		assert.Equal(t, userID, why.EditedBy)
+		require.NotNil(t, why.EditedAt)
+
+		_, err = engine.EditNarrativeSection(narratives[0].ID, "HOW", "", userID)
+		assert.Error(t, err)
+	})
+
+	t.Run("saved templates are chosen by rule type", func(t *testing.T) {
+		generic := &NarrativeTemplate{Name: "Generic", Kind: NarrativeSAR, Sections: []NarrativeSectionTemplate{
+			{Key: "SUMMARY", Heading: "Summary", Text: "{{.Subject}}: {{.Alert.Title}}"},
+		}}
+		structuring := &NarrativeTemplate{Name: "Structuring", Kind: NarrativeSAR, RuleTypes: []AMLRuleType{RuleStructuring}, Sections: []NarrativeSectionTemplate{
+			{Key: "SUMMARY", Heading: "Summary", Text: "{{.Subject}} made {{len .Transactions}} deposits {{dateRange .FirstDate .LastDate}}"},
+		}}
+		require.NoError(t, engine.SaveNarrativeTemplate(structuring, userID))
+		require.NoError(t, engine.SaveNarrativeTemplate(generic, userID))
+
+		narrative, err := engine.GenerateNarrative(alert.ID, NarrativeSAR, "", userID)
+		require.NoError(t, err)
+		assert.Equal(t, structuring.ID, narrative.TemplateID)
+		assert.Equal(t, "Harbour Traders Ltd made 2 deposits between 2 June 2026 and 9 June 2026", narrative.Sections[0].Text)
+
+		narrative, err = engine.GenerateNarrative(alert.ID, NarrativeSAR, generic.ID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "Harbour Traders Ltd: Possible structuring", narrative.Sections[0].Text)
+
+		_, err = engine.GenerateNarrative(alert.ID, NarrativeAlert, generic.ID, userID)
+		assert.Error(t, err, "template kind must match")
+	})
+
+	t.Run("templates are validated", func(t *testing.T) {
+		for _, tmpl := range []*NarrativeTemplate{
+			{Name: "No sections", Kind: NarrativeAlert},
+			{Name: "Bad kind", Kind: "MEMO", Sections: []NarrativeSectionTemplate{{Key: "A", Text: "x"}}},
+			{Name: "Duplicate", Kind: NarrativeAlert, Sections: []NarrativeSectionTemplate{{Key: "A", Text: "x"}, {Key: "A", Text: "y"}}},
+			{Name: "Syntax", Kind: NarrativeAlert, Sections: []NarrativeSectionTemplate{{Key: "A", Text: "{{.Subject"}}},
+			{Name: "Unknown field", Kind: NarrativeAlert, Sections: []NarrativeSectionTemplate{{Key: "A", Text: "{{.Victim}}"}}},
+		} {
+			assert.Error(t, engine.SaveNarrativeTemplate(tmpl, userID), tmpl.Name)
+		}
+	})
+}
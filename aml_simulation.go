@@ -0,0 +1,239 @@
+package accounting
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Threshold What-If Simulation
+// ----------------------------------------------------------------------------
+
+// ThresholdChange overrides one threshold on the rules of a type, e.g. the CTR
+// rule's "single_transaction" amount
+type ThresholdChange struct {
+	RuleType  AMLRuleType  `json:"rule_type"`
+	Framework AMLFramework `json:"framework,omitempty"` // empty changes the rules of every framework
+	Threshold string       `json:"threshold"`           // key in the rule's thresholds
+	Value     interface{}  `json:"value"`               // amounts in minor units
+}
+
+// ThresholdScenario is a set of hypothetical threshold changes
+type ThresholdScenario struct {
+	Name    string            `json:"name"`
+	Changes []ThresholdChange `json:"changes"`
+}
+
+// AMLActivityCounts is the operational load a set of rules produces
+type AMLActivityCounts struct {
+	Alerts              int                  `json:"alerts"`
+	AlertVolume         int64                `json:"alert_volume"`
+	AlertsByType        map[AMLRuleType]int  `json:"alerts_by_type"`
+	AlertsByRiskLevel   map[AMLRiskLevel]int `json:"alerts_by_risk_level"`
+	FlaggedTransactions int                  `json:"flagged_transactions"` // transactions raising at least one alert
+	CTRFilings          int                  `json:"ctr_filings"`
+	SARFilings          int                  `json:"sar_filings"`
+}
+
+// AMLRuleImpact compares one rule's alerts under current and scenario thresholds
+type AMLRuleImpact struct {
+	RuleID          string       `json:"rule_id"`
+	RuleName        string       `json:"rule_name"`
+	RuleType        AMLRuleType  `json:"rule_type"`
+	Framework       AMLFramework `json:"framework"`
+	Changed         bool         `json:"changed"` // the scenario overrides one of its thresholds
+	BaselineAlerts  int          `json:"baseline_alerts"`
+	SimulatedAlerts int          `json:"simulated_alerts"`
+}
+
+// ThresholdSimulation is the impact of a scenario on historical activity
+type ThresholdSimulation struct {
+	Scenario              *ThresholdScenario `json:"scenario"`
+	FromDate              time.Time          `json:"from_date"`
+	ToDate                time.Time          `json:"to_date"`
+	TransactionsEvaluated int                `json:"transactions_evaluated"`
+	Baseline              AMLActivityCounts  `json:"baseline"`
+	Simulated             AMLActivityCounts  `json:"simulated"`
+	Rules                 []*AMLRuleImpact   `json:"rules"`
+	NewlyFlagged          []string           `json:"newly_flagged,omitempty"`     // transaction IDs flagged only under the scenario
+	NoLongerFlagged       []string           `json:"no_longer_flagged,omitempty"` // transaction IDs flagged only today
+}
+
+// newAMLActivityCounts returns empty counts
+func newAMLActivityCounts() AMLActivityCounts {
+	return AMLActivityCounts{
+		AlertsByType:      make(map[AMLRuleType]int),
+		AlertsByRiskLevel: make(map[AMLRiskLevel]int),
+	}
+}
+
+// add counts an alert
+func (c *AMLActivityCounts) add(alert *AMLAlert) {
+	c.Alerts++
+	if alert.Amount != nil {
+		c.AlertVolume += alert.Amount.Value
+	}
+	c.AlertsByType[alert.RuleType]++
+	c.AlertsByRiskLevel[alert.RiskLevel]++
+	switch alert.RuleType {
+	case RuleCTR:
+		c.CTRFilings++
+	case RuleSAR:
+		c.SARFilings++
+	}
+}
+
+// SimulateThresholds re-runs the enabled transaction rules over posted transactions
+// in the period, once with the current thresholds and once with the scenario's, and
+// reports the difference in alerts and filings. Nothing is saved; filings are
+// counted from CTR and SAR alerts as the dashboard does.
+func (aml *AMLService) SimulateThresholds(scenario *ThresholdScenario, fromDate, toDate time.Time) (*ThresholdSimulation, error) {
+	if scenario == nil || len(scenario.Changes) == 0 {
+		return nil, fmt.Errorf("scenario has no threshold changes")
+	}
+
+	rules := slices.Collect(maps.Values(aml.rules))
+	rules = slices.DeleteFunc(rules, func(rule *AMLRule) bool { return !rule.Enabled })
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+
+	simulated, changed, err := applyThresholdChanges(rules, scenario.Changes)
+	if err != nil {
+		return nil, err
+	}
+
+	customers, err := aml.storage.GetAllAMLCustomers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AML customers: %w", err)
+	}
+	customerInfo := make(map[string]*AMLCustomer, len(customers))
+	for _, customer := range customers {
+		customerInfo[customer.CustomerID] = customer
+	}
+
+	result := &ThresholdSimulation{
+		Scenario:  scenario,
+		FromDate:  fromDate,
+		ToDate:    toDate,
+		Baseline:  newAMLActivityCounts(),
+		Simulated: newAMLActivityCounts(),
+		Rules:     make([]*AMLRuleImpact, len(rules)),
+	}
+	for i, rule := range rules {
+		result.Rules[i] = &AMLRuleImpact{
+			RuleID:    rule.ID,
+			RuleName:  rule.Name,
+			RuleType:  rule.Type,
+			Framework: rule.Framework,
+			Changed:   changed[i],
+		}
+	}
+
+	err = aml.storage.ForEachPostedTransaction(fromDate, toDate, func(txn *Transaction) error {
+		amlTxn := aml.convertToAMLTransaction(txn, customerInfo)
+		for _, enrich := range aml.enrichers {
+			if err := enrich(amlTxn); err != nil {
+				return fmt.Errorf("failed to enrich AML transaction %s: %w", txn.ID, err)
+			}
+		}
+		result.TransactionsEvaluated++
+
+		var flaggedBefore, flaggedAfter bool
+		for i, rule := range rules {
+			if alert := aml.evaluateRule(rule, amlTxn, customerInfo); alert != nil {
+				result.Baseline.add(alert)
+				result.Rules[i].BaselineAlerts++
+				flaggedBefore = true
+			}
+			if alert := aml.evaluateRule(simulated[i], amlTxn, customerInfo); alert != nil {
+				result.Simulated.add(alert)
+				result.Rules[i].SimulatedAlerts++
+				flaggedAfter = true
+			}
+		}
+		if flaggedBefore {
+			result.Baseline.FlaggedTransactions++
+		}
+		if flaggedAfter {
+			result.Simulated.FlaggedTransactions++
+		}
+		switch {
+		case flaggedAfter && !flaggedBefore:
+			result.NewlyFlagged = append(result.NewlyFlagged, txn.ID)
+		case flaggedBefore && !flaggedAfter:
+			result.NoLongerFlagged = append(result.NoLongerFlagged, txn.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay transactions: %w", err)
+	}
+	return result, nil
+}
+
+// applyThresholdChanges copies the rules with the scenario's thresholds, reporting
+// which rules changed. Every change must match a rule and keep the threshold's
+// type, since the rules read thresholds by type.
+func applyThresholdChanges(rules []*AMLRule, changes []ThresholdChange) ([]*AMLRule, []bool, error) {
+	simulated := make([]*AMLRule, len(rules))
+	for i, rule := range rules {
+		clone := *rule
+		clone.Thresholds = maps.Clone(rule.Thresholds)
+		simulated[i] = &clone
+	}
+	changed := make([]bool, len(rules))
+
+	for _, change := range changes {
+		matched := false
+		for i, rule := range simulated {
+			if rule.Type != change.RuleType || (change.Framework != "" && rule.Framework != change.Framework) {
+				continue
+			}
+			current, ok := rule.Thresholds[change.Threshold]
+			if !ok {
+				continue
+			}
+			value, err := coerceThreshold(current, change.Value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("rule %s threshold %s: %w", rule.Name, change.Threshold, err)
+			}
+			rule.Thresholds[change.Threshold] = value
+			changed[i] = true
+			matched = true
+		}
+		if !matched {
+			return nil, nil, fmt.Errorf("no enabled %s rule has a %s threshold", change.RuleType, change.Threshold)
+		}
+	}
+	return simulated, changed, nil
+}
+
+// coerceThreshold converts a new threshold value to the type of the current one.
+// Whole numbers decoded from JSON as float64 are accepted for integer thresholds.
+func coerceThreshold(current, value interface{}) (interface{}, error) {
+	var number float64
+	switch v := value.(type) {
+	case int:
+		number = float64(v)
+	case int64:
+		number = float64(v)
+	case float64:
+		number = v
+	default:
+		return nil, fmt.Errorf("value %v is not a number", value)
+	}
+
+	switch current.(type) {
+	case int:
+		if number != math.Trunc(number) {
+			return nil, fmt.Errorf("value %v must be a whole number", value)
+		}
+		return int(number), nil
+	case float64:
+		return number, nil
+	}
+	return nil, fmt.Errorf("threshold of type %T cannot be simulated", current)
+}
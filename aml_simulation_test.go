@@ -0,0 +1,111 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateAMLThresholds(t *testing.T) {
+	dbFile := "test_aml_simulation.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "aml_officer"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.GetAMLService().SetupStandardAMLRules(BSA_Framework))
+	require.NoError(t, engine.GetAMLService().SetupStandardAMLRules(AMLD_Framework))
+
+	deposit := func(when time.Time, value int64) *Transaction {
+		txn := &Transaction{
+			Description: "Cash deposit",
+			SourceRef:   "CASH-001",
+			ValidTime:   when,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	day := func(d int) time.Time { return time.Date(2026, 6, d, 10, 0, 0, 0, time.UTC) }
+
+	small := deposit(day(2), 650000)
+	deposit(day(3), 800000)
+	deposit(day(4), 1200000)
+	deposit(day(6), 1200000) // a Saturday, suspicious enough for a SAR
+
+	scenario := &ThresholdScenario{
+		Name: "CTR at $5k, EU threshold at €10k",
+		Changes: []ThresholdChange{
+			{RuleType: RuleCTR, Threshold: "single_transaction", Value: 500000.0}, // as decoded from JSON
+			{RuleType: RuleSAR, Framework: AMLD_Framework, Threshold: "minimum_amount", Value: 1000000},
+		},
+	}
+	simulation, err := engine.SimulateAMLThresholds(scenario, day(1), day(30))
+	require.NoError(t, err)
+
+	t.Run("filings under current and hypothetical thresholds", func(t *testing.T) {
+		assert.Equal(t, 4, simulation.TransactionsEvaluated)
+		assert.Equal(t, 2, simulation.Baseline.CTRFilings)
+		assert.Equal(t, 4, simulation.Simulated.CTRFilings)
+		assert.Equal(t, 1, simulation.Baseline.SARFilings)
+		assert.Equal(t, 2, simulation.Simulated.SARFilings)
+		assert.Equal(t, simulation.Baseline.Alerts+3, simulation.Simulated.Alerts)
+		assert.Equal(t, simulation.Baseline.AlertVolume+650000+800000+1200000, simulation.Simulated.AlertVolume)
+		assert.Equal(t, []string{small.ID}, simulation.NewlyFlagged)
+		assert.Empty(t, simulation.NoLongerFlagged)
+	})
+
+	t.Run("impact by rule", func(t *testing.T) {
+		impacts := make(map[string]*AMLRuleImpact)
+		for _, impact := range simulation.Rules {
+			impacts[impact.RuleName] = impact
+		}
+		ctr := impacts["CTR - Currency Transaction Report"]
+		require.NotNil(t, ctr)
+		assert.True(t, ctr.Changed)
+		assert.Equal(t, 2, ctr.BaselineAlerts)
+		assert.Equal(t, 4, ctr.SimulatedAlerts)
+
+		eu := impacts["EU Suspicious Transaction Threshold"]
+		require.NotNil(t, eu)
+		assert.True(t, eu.Changed)
+		assert.Equal(t, 0, eu.BaselineAlerts)
+		assert.Equal(t, 1, eu.SimulatedAlerts)
+
+		bsaSAR := impacts["SAR - Suspicious Activity Threshold"]
+		require.NotNil(t, bsaSAR)
+		assert.False(t, bsaSAR.Changed, "the EU change is limited to its framework")
+	})
+
+	t.Run("nothing is saved and live rules are untouched", func(t *testing.T) {
+		alerts, err := engine.GetStorage().GetAMLAlerts()
+		require.NoError(t, err)
+		assert.Empty(t, alerts)
+		assert.Equal(t, 1000000, engine.GetAMLService().findRuleByType(RuleCTR).Thresholds["single_transaction"])
+	})
+
+	t.Run("changes are validated", func(t *testing.T) {
+		_, err := engine.SimulateAMLThresholds(&ThresholdScenario{Name: "Empty"}, day(1), day(30))
+		assert.Error(t, err)
+
+		invalid := []ThresholdChange{
+			{RuleType: RuleCTR, Threshold: "weekly_aggregate", Value: 500000},
+			{RuleType: RuleCTR, Threshold: "single_transaction", Value: "5000"},
+			{RuleType: RuleCTR, Threshold: "single_transaction", Value: 500000.5},
+		}
+		for _, change := range invalid {
+			_, err := engine.SimulateAMLThresholds(&ThresholdScenario{Name: "Invalid", Changes: []ThresholdChange{change}}, day(1), day(30))
+			assert.Error(t, err, "%+v", change)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAMLStorageRoundTrip(t *testing.T) {
+	// Setup
+	dbFile := "test_aml_roundtrip.db"
+	defer os.Remove(dbFile)
+
+	storage, err := NewStorage(dbFile)
+	require.NoError(t, err)
+	defer storage.Close()
+
+	created := time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)
+	completed := created.Add(48 * time.Hour)
+
+	t.Run("Rule", func(t *testing.T) {
+		rule := &AMLRule{
+			ID:          "rule-structuring",
+			Name:        "Structuring Detection",
+			Type:        RuleStructuring,
+			Framework:   FINCEN_Framework,
+			Description: "Detect transactions designed to avoid CTR reporting",
+			Enabled:     true,
+			Thresholds: map[string]interface{}{
+				"threshold_percentage": 0.9,
+				"transaction_count":    3,
+				"minimum_volume":       int64(5000000),
+				"jurisdiction":         "US",
+				"include_wires":        true,
+			},
+			TimeWindows: map[string]int{
+				"detection_window": 72,
+			},
+			Currencies:   []string{"USD", "EUR"},
+			Countries:    []string{"US"},
+			BaseScore:    85,
+			RiskMultiple: 2.5,
+			CreatedAt:    created,
+			UpdatedAt:    created,
+		}
+
+		require.NoError(t, storage.SaveAMLRule(rule))
+
+		loaded, err := storage.GetAMLRule(rule.ID)
+		require.NoError(t, err)
+		assert.Equal(t, rule, loaded)
+
+		// Detection code type-asserts threshold values, so their Go types must survive
+		_, ok := loaded.Thresholds["transaction_count"].(int)
+		assert.True(t, ok, "int threshold should load as int")
+	})
+
+	t.Run("Every Rule Type", func(t *testing.T) {
+		for _, ruleType := range []AMLRuleType{RuleCTR, RuleSAR, RuleIdentityVerif, RuleHighRiskJuris, RuleUnexpectedGeography} {
+			rule := &AMLRule{ID: "rule-" + string(ruleType), Type: ruleType, Framework: OFAC_Framework}
+			require.NoError(t, storage.SaveAMLRule(rule))
+
+			loaded, err := storage.GetAMLRule(rule.ID)
+			require.NoError(t, err)
+			assert.Equal(t, ruleType, loaded.Type)
+			assert.Equal(t, OFAC_Framework, loaded.Framework)
+		}
+	})
+
+	t.Run("Alert", func(t *testing.T) {
+		alert := &AMLAlert{
+			ID:             "alert-1",
+			RuleType:       RuleSmurfing,
+			Framework:      BSA_Framework,
+			RiskLevel:      RiskHigh,
+			Title:          "Smurfing pattern",
+			Description:    "Multiple deposits just under threshold",
+			EntityID:       "customer-1",
+			EntityType:     "CUSTOMER",
+			TransactionIDs: []string{"txn-1", "txn-2"},
+			AccountIDs:     []string{"cash"},
+			Amount:         &Amount{Value: 1800000, Currency: "USD"},
+			Currency:       "USD",
+			DetectedAt:     created,
+			Status:         "ESCALATED",
+			AssignedTo:     "analyst",
+			Investigation: &AMLInvestigation{
+				ID:           "inv-1",
+				AlertID:      "alert-1",
+				Investigator: "analyst",
+				StartedAt:    created,
+				CompletedAt:  &completed,
+				Status:       "COMPLETED",
+				Priority:     "HIGH",
+				Findings:     []string{"Deposits split across branches"},
+				Actions: []InvestigationAction{
+					{ID: "act-1", Type: "REQUEST_INFO", Description: "Requested source of funds", TakenBy: "analyst", TakenAt: created, Result: "No response"},
+				},
+				Notes: []InvestigationNote{
+					{ID: "note-1", Content: "Customer unreachable", CreatedBy: "analyst", CreatedAt: created},
+				},
+			},
+			Evidence: []AMLEvidence{
+				{Type: "TRANSACTION", Description: "Deposit amount", Value: int64(950000), Source: "ledger", Confidence: 0.9, CollectedAt: created},
+				{Type: "PATTERN", Description: "Suspicion score", Value: 82, Source: "detector", Confidence: 0.75, CollectedAt: created},
+				{Type: "EXTERNAL", Description: "Country", Value: "KY", Source: "kyc", Confidence: 1.0, CollectedAt: created},
+			},
+			Dispositions: []AMLDisposition{
+				{ID: "disp-1", Type: "SAR_FILED", Description: "SAR filed", DecidedBy: "bsa_officer", DecidedAt: completed, Rationale: "Structuring", SARNumber: "SAR-001", ReportedTo: []string{"FINCEN"}},
+			},
+			CreatedAt: created,
+			UpdatedAt: completed,
+		}
+
+		require.NoError(t, storage.SaveAMLAlert(alert))
+
+		loaded, err := storage.GetAMLAlert(alert.ID)
+		require.NoError(t, err)
+		assert.Equal(t, alert, loaded)
+	})
+}
@@ -0,0 +1,45 @@
+package accounting
+
+import (
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Balance Snapshot Structures
+// ----------------------------------------------------------------------------
+
+// balanceSnapshotPeriodLayout names the calendar month a snapshot covers
+const balanceSnapshotPeriodLayout = "2006-01"
+
+// AccountBalanceSnapshot is the materialized movement on an account for one calendar
+// month of valid time. Storage keeps snapshots current as transactions are posted and
+// reversed, so a balance is the sum of an account's snapshots rather than a scan of
+// its entries.
+type AccountBalanceSnapshot struct {
+	AccountID     string    `json:"account_id"`
+	Period        string    `json:"period"` // e.g. "2025-04"
+	Debits        int64     `json:"debits"`
+	Credits       int64     `json:"credits"`
+	EntryCount    int64     `json:"entry_count"`
+	LastValidTime time.Time `json:"last_valid_time"` // latest entry in the period
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// PeriodStart returns the first instant of the snapshot's month
+func (s *AccountBalanceSnapshot) PeriodStart() time.Time {
+	start, _ := time.Parse(balanceSnapshotPeriodLayout, s.Period)
+	return start
+}
+
+// BalanceMovement is the movement on an account at one instant of valid time. It
+// backs the snapshot for the month containing an as-of date that falls mid-month.
+type BalanceMovement struct {
+	Debits     int64 `json:"debits"`
+	Credits    int64 `json:"credits"`
+	EntryCount int64 `json:"entry_count"`
+}
+
+// balanceSnapshotPeriod returns the snapshot period for a valid time
+func balanceSnapshotPeriod(validTime time.Time) string {
+	return validTime.UTC().Format(balanceSnapshotPeriodLayout)
+}
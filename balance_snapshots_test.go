@@ -0,0 +1,154 @@
+package accounting
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+func TestBalanceSnapshots(t *testing.T) {
+	// Setup
+	dbFile := "test_balance_snapshots.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	balance := func(accountID string, asOf time.Time) int64 {
+		result, err := engine.GetAccountBalance(accountID, asOf)
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+	sell := func(value int64, when time.Time) *Transaction {
+		txn := &Transaction{
+			Description: "Sale",
+			ValidTime:   when,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+
+	sell(10000, date(2025, 1, 10))
+	sell(2500, date(2025, 1, 31).Add(18*time.Hour))
+	sell(4000, date(2025, 2, 14).Add(9*time.Hour))
+	sell(700, date(2025, 2, 14).Add(15*time.Hour))
+	mistake := sell(99900, date(2025, 3, 3))
+
+	t.Run("Monthly Snapshots", func(t *testing.T) {
+		snapshots, err := engine.GetBalanceSnapshots("cash")
+		require.NoError(t, err)
+		require.Len(t, snapshots, 3)
+
+		assert.Equal(t, "2025-01", snapshots[0].Period)
+		assert.Equal(t, int64(12500), snapshots[0].Debits)
+		assert.Equal(t, int64(2), snapshots[0].EntryCount)
+		assert.Equal(t, date(2025, 1, 1), snapshots[0].PeriodStart())
+		assert.Equal(t, int64(4700), snapshots[1].Debits)
+	})
+
+	t.Run("Balances As Of", func(t *testing.T) {
+		assert.Equal(t, int64(0), balance("cash", date(2024, 12, 31)))
+		assert.Equal(t, int64(10000), balance("cash", date(2025, 1, 31)), "sale later that day is excluded")
+		assert.Equal(t, int64(12500), balance("cash", date(2025, 2, 1)))
+		assert.Equal(t, int64(16500), balance("cash", date(2025, 2, 14).Add(12*time.Hour)))
+		assert.Equal(t, int64(17200), balance("cash", date(2025, 2, 28)))
+		assert.Equal(t, int64(117100), balance("cash", date(2025, 3, 31)))
+		assert.Equal(t, int64(117100), balance("revenue", date(2025, 3, 31)))
+	})
+
+	t.Run("Reversal Removes Original", func(t *testing.T) {
+		_, err := engine.ReverseTransaction(mistake.ID, "Keyed in error", userID)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(17200), balance("cash", date(2025, 3, 31)))
+		snapshots, err := engine.GetBalanceSnapshots("cash")
+		require.NoError(t, err)
+		for _, snapshot := range snapshots {
+			assert.NotEqual(t, "2025-03", snapshot.Period, "emptied month is dropped")
+		}
+	})
+
+	t.Run("Rebuild From Events", func(t *testing.T) {
+		before, err := engine.GetTrialBalance(date(2030, 1, 1), nil)
+		require.NoError(t, err)
+		cashSnapshots, err := engine.GetBalanceSnapshots("cash")
+		require.NoError(t, err)
+
+		require.NoError(t, engine.RebuildBalanceSnapshots(userID))
+
+		after, err := engine.GetTrialBalance(date(2030, 1, 1), nil)
+		require.NoError(t, err)
+		require.Len(t, after, len(before))
+		for i := range before {
+			assert.Equal(t, before[i].Balance.Value, after[i].Balance.Value, before[i].AccountID)
+		}
+		rebuilt, err := engine.GetBalanceSnapshots("cash")
+		require.NoError(t, err)
+		require.Len(t, rebuilt, len(cashSnapshots))
+		for i := range rebuilt {
+			assert.Equal(t, cashSnapshots[i].Debits, rebuilt[i].Debits)
+			assert.Equal(t, cashSnapshots[i].Credits, rebuilt[i].Credits)
+		}
+	})
+
+	t.Run("Built On Open", func(t *testing.T) {
+		// A database from before snapshots existed has no snapshot buckets
+		require.NoError(t, engine.Close())
+		storage, err := NewStorage(dbFile)
+		require.NoError(t, err)
+		require.NoError(t, storage.db.Update(func(tx *bbolt.Tx) error {
+			if err := tx.DeleteBucket(BucketBalanceSnapshots); err != nil {
+				return err
+			}
+			return tx.DeleteBucket(BucketBalanceMovements)
+		}))
+		require.NoError(t, storage.Close())
+
+		engine, err = NewAccountingEngine(dbFile)
+		require.NoError(t, err)
+		defer engine.Close()
+		assert.Equal(t, int64(17200), balance("cash", date(2025, 3, 31)))
+	})
+}
+
+// BenchmarkTrialBalance measures a trial balance over a ledger of 5,000 postings
+func BenchmarkTrialBalance(b *testing.B) {
+	engine, err := NewAccountingEngineWithOptions(filepath.Join(b.TempDir(), "bench.db"), StorageOptions{NoSync: true})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { engine.Close() })
+	if err := engine.CreateStandardAccounts("bench"); err != nil {
+		b.Fatal(err)
+	}
+	txns, _ := bulkTestTransactions(5000)
+	for _, txn := range txns {
+		txn.Status = Posted
+	}
+	if err := engine.GetStorage().SaveTransactions(txns); err != nil {
+		b.Fatal(err)
+	}
+
+	asOf := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := engine.GetTrialBalance(asOf, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
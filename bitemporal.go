@@ -0,0 +1,169 @@
+package accounting
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// KnownPosting is a posted transaction as the ledger knew it at a knowledge date
+type KnownPosting struct {
+	TransactionID string     `json:"transaction_id"`
+	Description   string     `json:"description"`
+	SourceRef     string     `json:"source_ref,omitempty"`
+	ValidTime     time.Time  `json:"valid_time"`
+	RecordedAt    time.Time  `json:"recorded_at"`           // when the posting entered the ledger
+	ReversedAt    *time.Time `json:"reversed_at,omitempty"` // when its reversal was recorded, if known by then
+	Entries       []Entry    `json:"entries"`
+}
+
+// GetPostingsAsOf rebuilds the postings with a valid time on or before validAsOf as
+// the ledger knew them at knowledgeAsOf, by replaying the event log up to that moment.
+// Postings recorded later, including backdated corrections, are not yet known;
+// reversals recorded by then are marked but still listed.
+func (qa *QueryAPI) GetPostingsAsOf(validAsOf, knowledgeAsOf time.Time) ([]*KnownPosting, error) {
+	events, err := qa.storage.GetEvents(time.Unix(0, 0), knowledgeAsOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events: %w", err)
+	}
+
+	created := make(map[string]*Transaction)
+	postings := make(map[string]*KnownPosting)
+	for _, event := range events {
+		if event.TransactionTime.After(knowledgeAsOf) {
+			continue
+		}
+		switch event.EventType {
+		case EventCreateTransaction:
+			var payload TransactionCreatedEvent
+			if err := json.Unmarshal(event.Payload, &payload); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal transaction created event: %w", err)
+			}
+			if payload.Transaction != nil {
+				created[payload.Transaction.ID] = payload.Transaction
+			}
+		case EventPostTransaction:
+			var payload TransactionPostedEvent
+			if err := json.Unmarshal(event.Payload, &payload); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal transaction posted event: %w", err)
+			}
+			posting := &KnownPosting{
+				TransactionID: payload.TransactionID,
+				ValidTime:     event.ValidTime,
+				RecordedAt:    event.TransactionTime,
+				Entries:       payload.Entries,
+			}
+			if txn, ok := created[payload.TransactionID]; ok {
+				posting.Description = txn.Description
+				posting.SourceRef = txn.SourceRef
+			}
+			postings[payload.TransactionID] = posting
+		case EventReverseTransaction:
+			var payload TransactionReversedEvent
+			if err := json.Unmarshal(event.Payload, &payload); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal transaction reversed event: %w", err)
+			}
+			if posting, ok := postings[payload.TransactionID]; ok {
+				reversedAt := event.TransactionTime
+				posting.ReversedAt = &reversedAt
+			}
+		}
+	}
+
+	var result []*KnownPosting
+	for _, posting := range postings {
+		if !posting.ValidTime.After(validAsOf) {
+			result = append(result, posting)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].ValidTime.Equal(result[j].ValidTime) {
+			return result[i].ValidTime.Before(result[j].ValidTime)
+		}
+		return result[i].RecordedAt.Before(result[j].RecordedAt)
+	})
+	return result, nil
+}
+
+// GetAccountBalanceAsOf gets the balance of an account on validAsOf as known at knowledgeAsOf
+func (qa *QueryAPI) GetAccountBalanceAsOf(accountID string, validAsOf, knowledgeAsOf time.Time) (*BalanceResult, error) {
+	results, err := qa.balancesAsOf([]string{accountID}, validAsOf, knowledgeAsOf)
+	if err != nil {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// GetTrialBalanceAsOf generates the trial balance on validAsOf as the books showed it at
+// knowledgeAsOf, e.g. March 31 as known on April 5, before later backdated corrections
+func (qa *QueryAPI) GetTrialBalanceAsOf(validAsOf, knowledgeAsOf time.Time, accountTypes []AccountType) ([]*BalanceResult, error) {
+	accounts, err := qa.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	// Present accounts in chart of accounts order
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Code < accounts[j].Code
+	})
+
+	var accountIDs []string
+	for _, account := range accounts {
+		if account.CreatedAt.After(knowledgeAsOf) {
+			continue
+		}
+		if len(accountTypes) > 0 {
+			found := false
+			for _, accountType := range accountTypes {
+				if account.Type == accountType {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		accountIDs = append(accountIDs, account.ID)
+	}
+	return qa.balancesAsOf(accountIDs, validAsOf, knowledgeAsOf)
+}
+
+// balancesAsOf totals the known, unreversed postings for each account
+func (qa *QueryAPI) balancesAsOf(accountIDs []string, validAsOf, knowledgeAsOf time.Time) ([]*BalanceResult, error) {
+	results := make([]*BalanceResult, len(accountIDs))
+	byAccount := make(map[string]*BalanceResult, len(accountIDs))
+	for i, accountID := range accountIDs {
+		account, err := qa.storage.GetAccount(accountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account: %w", err)
+		}
+		results[i] = &BalanceResult{
+			AccountID:   account.ID,
+			AccountName: account.Name,
+			AccountType: account.Type,
+			Balance:     &Amount{Currency: account.Currency},
+			AsOfDate:    validAsOf,
+		}
+		byAccount[account.ID] = results[i]
+	}
+
+	postings, err := qa.GetPostingsAsOf(validAsOf, knowledgeAsOf)
+	if err != nil {
+		return nil, err
+	}
+	for _, posting := range postings {
+		if posting.ReversedAt != nil {
+			continue
+		}
+		for _, entry := range posting.Entries {
+			result, ok := byAccount[entry.AccountID]
+			if !ok {
+				continue
+			}
+			result.Balance.Value += entry.Amount.Value * int64(qa.postingEngine.getBalanceMultiplier(result.AccountType, entry.Type))
+		}
+	}
+	return results, nil
+}
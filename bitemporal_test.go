@@ -0,0 +1,100 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitemporalViews(t *testing.T) {
+	// Setup
+	dbFile := "test_bitemporal.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	beforeChart := time.Now()
+	userID := "auditor"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	date := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	sell := func(description string, value int64, when time.Time) *Transaction {
+		txn := &Transaction{
+			Description: description,
+			ValidTime:   when,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	balanceAsOf := func(accountID string, validAsOf, knowledgeAsOf time.Time) int64 {
+		result, err := engine.GetAccountBalanceAsOf(accountID, validAsOf, knowledgeAsOf)
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+
+	sell("March sales", 10000, date(3, 10))
+	closeKnown := time.Now() // the books as known at the April close
+	sell("Late March invoice", 500, date(3, 31))
+	mistake := sell("Duplicate invoice", 2000, date(3, 20))
+	beforeReversal := time.Now()
+	_, err = engine.ReverseTransaction(mistake.ID, "Duplicate", userID)
+	require.NoError(t, err)
+	sell("April sales", 7000, date(4, 2))
+
+	t.Run("Books As Known", func(t *testing.T) {
+		assert.Equal(t, int64(10000), balanceAsOf("cash", date(3, 31), closeKnown), "backdated postings were not yet known")
+		assert.Equal(t, int64(12500), balanceAsOf("cash", date(3, 31), beforeReversal))
+		assert.Equal(t, int64(10500), balanceAsOf("cash", date(3, 31), time.Now()))
+		assert.Equal(t, int64(17500), balanceAsOf("revenue", date(4, 30), time.Now()))
+	})
+
+	t.Run("Trial Balance As Known", func(t *testing.T) {
+		before, err := engine.GetTrialBalanceAsOf(date(3, 31), beforeChart, nil)
+		require.NoError(t, err)
+		assert.Empty(t, before, "accounts did not exist yet")
+
+		known, err := engine.GetTrialBalanceAsOf(date(3, 31), closeKnown, []AccountType{Income})
+		require.NoError(t, err)
+		require.NotEmpty(t, known)
+		for _, result := range known {
+			assert.Equal(t, Income, result.AccountType)
+		}
+
+		// Known as of now, the view agrees with the current ledger
+		current, err := engine.GetTrialBalance(date(3, 31), nil)
+		require.NoError(t, err)
+		asOfNow, err := engine.GetTrialBalanceAsOf(date(3, 31), time.Now(), nil)
+		require.NoError(t, err)
+		require.Len(t, asOfNow, len(current))
+		for i := range current {
+			assert.Equal(t, current[i].AccountID, asOfNow[i].AccountID)
+			assert.Equal(t, current[i].Balance.Value, asOfNow[i].Balance.Value, current[i].AccountID)
+		}
+	})
+
+	t.Run("Backdated Corrections", func(t *testing.T) {
+		postings, err := engine.GetPostingsAsOf(date(3, 31), time.Now())
+		require.NoError(t, err)
+		require.Len(t, postings, 3)
+
+		assert.Equal(t, "March sales", postings[0].Description)
+		assert.Equal(t, "Duplicate invoice", postings[1].Description)
+		assert.NotNil(t, postings[1].ReversedAt)
+		assert.True(t, postings[2].RecordedAt.After(closeKnown), "late invoice was recorded after the close")
+
+		known, err := engine.GetPostingsAsOf(date(3, 31), beforeReversal)
+		require.NoError(t, err)
+		require.Len(t, known, 3)
+		assert.Nil(t, known[1].ReversedAt, "reversal not yet known")
+	})
+}
@@ -0,0 +1,223 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// BudgetControlPolicy decides what happens when a posting would spend more than an
+// allocation has left
+type BudgetControlPolicy string
+
+const (
+	BudgetControlWarn  BudgetControlPolicy = "WARN"  // post and raise a budget warning
+	BudgetControlBlock BudgetControlPolicy = "BLOCK" // reject the posting
+)
+
+// BudgetWarning reports a posting that took an allocation over budget
+type BudgetWarning struct {
+	AllocationID  string    `json:"allocation_id"`
+	PeriodID      string    `json:"period_id"`
+	DepartmentID  string    `json:"department_id"`
+	AccountID     string    `json:"account_id"`
+	TransactionID string    `json:"transaction_id"`
+	Spent         *Amount   `json:"spent"`     // consumed by this transaction
+	Remaining     *Amount   `json:"remaining"` // negative once over budget
+	RaisedAt      time.Time `json:"raised_at"`
+}
+
+// budgetSpend is what one transaction spends against one allocation
+type budgetSpend struct {
+	allocation *BudgetAllocation
+	amount     int64 // net debits; negative when the transaction gives budget back
+}
+
+// ApplyCompanySettings adopts the company's budget control policy. Postings always
+// consume matching allocations; under BLOCK a posting that would exceed an
+// allocation's remaining budget is rejected instead of raising a warning.
+func (zbb *ZBBService) ApplyCompanySettings(settings *CompanySettings) {
+	zbb.mutex.Lock()
+	defer zbb.mutex.Unlock()
+	if settings == nil {
+		zbb.budgetControl = ""
+		return
+	}
+	zbb.budgetControl = settings.BudgetControl
+}
+
+// SetBudgetWarningHandler registers a callback for postings that take an allocation
+// over budget, for example to notify the budget owner. Warnings are also recorded as
+// BUDGET_EXCEEDED events and flagged on the budget tracking record.
+func (zbb *ZBBService) SetBudgetWarningHandler(handler func(*BudgetWarning)) {
+	zbb.mutex.Lock()
+	defer zbb.mutex.Unlock()
+	zbb.onWarning = handler
+}
+
+// matchBudget works out what a transaction spends against each allocation covering it.
+// An entry is covered when it posts to the allocation's account in its currency,
+// carries all of the allocation's dimensions and falls within the budget period.
+func (zbb *ZBBService) matchBudget(txn *Transaction) ([]*budgetSpend, error) {
+	allocations, err := zbb.storage.GetAllBudgetAllocations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget allocations: %w", err)
+	}
+
+	periods := make(map[string]*BudgetPeriod)
+	var spends []*budgetSpend
+	for _, allocation := range allocations {
+		var amount int64
+		matched := false
+		for i := range txn.Entries {
+			entry := &txn.Entries[i]
+			if entry.AccountID != allocation.AccountID || entry.Amount.Currency != allocation.Amount.Currency ||
+				!hasDimensions(entry.Dimensions, allocation.Dimensions) {
+				continue
+			}
+			matched = true
+			if entry.Type == Debit {
+				amount += entry.Amount.Value
+			} else {
+				amount -= entry.Amount.Value
+			}
+		}
+		if !matched || amount == 0 {
+			continue
+		}
+
+		period, ok := periods[allocation.PeriodID]
+		if !ok {
+			if period, err = zbb.storage.GetBudgetPeriod(allocation.PeriodID); err != nil {
+				return nil, fmt.Errorf("failed to get budget period for allocation %s: %w", allocation.ID, err)
+			}
+			periods[allocation.PeriodID] = period
+		}
+		// Budget periods cover whole days, whatever the time of day on their bounds
+		day := txn.ValidTime.Format("2006-01-02")
+		if day < period.StartDate.Format("2006-01-02") || day > period.EndDate.Format("2006-01-02") {
+			continue
+		}
+		spends = append(spends, &budgetSpend{allocation: allocation, amount: amount})
+	}
+	return spends, nil
+}
+
+// ValidateBudget rejects a transaction that would spend more than an allocation has
+// left when the company's policy is BLOCK. It is registered as a posting validator.
+func (zbb *ZBBService) ValidateBudget(txn *Transaction) error {
+	zbb.mutex.Lock()
+	policy := zbb.budgetControl
+	zbb.mutex.Unlock()
+	if policy != BudgetControlBlock {
+		return nil
+	}
+
+	spends, err := zbb.matchBudget(txn)
+	if err != nil {
+		return err
+	}
+	for _, spend := range spends {
+		if spend.amount > 0 && spend.amount > spend.allocation.Remaining.Value {
+			return fmt.Errorf("posting %s to account %s exceeds the %s remaining in budget allocation %s",
+				(&Amount{Value: spend.amount, Currency: spend.allocation.Amount.Currency}).Format(), spend.allocation.AccountID,
+				spend.allocation.Remaining.Format(), spend.allocation.ID)
+		}
+	}
+	return nil
+}
+
+// ConsumeBudget charges a posted transaction to the allocations covering it and raises
+// a warning for each one it takes over budget. Credits to a budgeted account, such as
+// refunds and contra entries, give budget back. It is registered as a posting hook.
+func (zbb *ZBBService) ConsumeBudget(txn *Transaction, userID string) error {
+	warnings, err := zbb.consumeBudget(txn, userID)
+	zbb.mutex.Lock()
+	handler := zbb.onWarning
+	zbb.mutex.Unlock()
+	if handler != nil {
+		for _, warning := range warnings {
+			handler(warning)
+		}
+	}
+	return err
+}
+
+// consumeBudget charges the transaction under the service mutex and returns the
+// warnings it raised, so the handler runs without the lock held
+func (zbb *ZBBService) consumeBudget(txn *Transaction, userID string) ([]*BudgetWarning, error) {
+	zbb.mutex.Lock()
+	defer zbb.mutex.Unlock()
+
+	spends, err := zbb.matchBudget(txn)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []*BudgetWarning
+	for _, spend := range spends {
+		if zbb.storage.HasBudgetTracking(spend.allocation.ID, txn.ID) {
+			continue
+		}
+		tracking, err := zbb.consumeAllocation(spend.allocation, txn, spend.amount)
+		if err != nil {
+			return warnings, err
+		}
+		if spend.amount <= 0 || !tracking.OverBudget {
+			continue
+		}
+
+		warning := &BudgetWarning{
+			AllocationID:  spend.allocation.ID,
+			PeriodID:      spend.allocation.PeriodID,
+			DepartmentID:  spend.allocation.DepartmentID,
+			AccountID:     spend.allocation.AccountID,
+			TransactionID: txn.ID,
+			Spent:         tracking.Amount,
+			Remaining:     tracking.RemainingBudget,
+			RaisedAt:      time.Now(),
+		}
+		if _, err := zbb.eventStore.CreateEvent(EventBudgetExceeded, warning, txn.ValidTime, userID); err != nil {
+			return warnings, fmt.Errorf("failed to create budget warning event: %w", err)
+		}
+		warnings = append(warnings, warning)
+	}
+	return warnings, nil
+}
+
+// consumeAllocation charges an amount to an allocation and records the tracking entry.
+// Callers hold the service mutex.
+func (zbb *ZBBService) consumeAllocation(allocation *BudgetAllocation, txn *Transaction, amount int64) (*BudgetTracking, error) {
+	allocation.SpentAmount.Value += amount
+	allocation.Remaining.Value -= amount
+	allocation.UpdatedAt = time.Now()
+
+	if err := zbb.storage.SaveBudgetAllocation(allocation); err != nil {
+		return nil, fmt.Errorf("failed to update allocation: %w", err)
+	}
+
+	tracking := &BudgetTracking{
+		AllocationID:    allocation.ID,
+		TransactionID:   txn.ID,
+		Amount:          &Amount{Value: amount, Currency: allocation.Amount.Currency},
+		Description:     txn.Description,
+		TrackedAt:       time.Now(),
+		RemainingBudget: &Amount{Value: allocation.Remaining.Value, Currency: allocation.Amount.Currency},
+		OverBudget:      allocation.Remaining.Value < 0,
+	}
+	if err := zbb.storage.SaveBudgetTracking(tracking); err != nil {
+		return nil, fmt.Errorf("failed to save budget tracking: %w", err)
+	}
+	return tracking, nil
+}
+
+// GetBudgetTracking lists the spending charged to an allocation, oldest first
+func (zbb *ZBBService) GetBudgetTracking(allocationID string) ([]*BudgetTracking, error) {
+	records, err := zbb.storage.GetBudgetTrackingByAllocation(allocationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget tracking: %w", err)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].TrackedAt.Before(records[j].TrackedAt)
+	})
+	return records, nil
+}
@@ -0,0 +1,142 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetControl(t *testing.T) {
+	dbFile := "test_budget_control.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "budget_manager"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "travel", Code: "5010", Name: "Travel", Type: Expense, ParentID: "expenses"}, userID))
+
+	sales := Dimension{Key: DimDepartment, Value: "sales"}
+	period := &BudgetPeriod{
+		Name:      "Q1-2025 Budget",
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, engine.CreateBudgetPeriod(period, userID))
+
+	request := &BudgetRequest{
+		PeriodID:     period.ID,
+		DepartmentID: "sales",
+		Title:        "Sales travel",
+		LineItems: []BudgetLineItem{{
+			AccountID:     "travel",
+			Amount:        &Amount{Value: 100000, Currency: "USD"},
+			Description:   "Customer visits",
+			Dimensions:    []Dimension{sales},
+			Justification: "Quarterly account reviews",
+		}},
+	}
+	require.NoError(t, engine.CreateBudgetRequest(request, userID))
+	require.NoError(t, engine.SubmitBudgetRequest(request.ID, userID))
+	require.NoError(t, engine.ApproveBudgetRequest(request.ID, "cfo", request.TotalAmount, "Approved"))
+	require.NoError(t, engine.CreateBudgetAllocation(request.ID, userID))
+
+	allocations, err := engine.GetStorage().GetBudgetAllocationsByPeriodAndDept(period.ID, "sales")
+	require.NoError(t, err)
+	require.Len(t, allocations, 1)
+	allocationID := allocations[0].ID
+	remaining := func() int64 {
+		allocation, err := engine.GetStorage().GetBudgetAllocation(allocationID)
+		require.NoError(t, err)
+		return allocation.Remaining.Value
+	}
+
+	var warnings []*BudgetWarning
+	engine.SetBudgetWarningHandler(func(warning *BudgetWarning) {
+		warnings = append(warnings, warning)
+	})
+
+	post := func(entryType EntryType, amount int64, validTime time.Time, dims ...Dimension) (*Transaction, error) {
+		other := Credit
+		if entryType == Credit {
+			other = Debit
+		}
+		txn := &Transaction{
+			Description: "Sales trip",
+			ValidTime:   validTime,
+			Entries: []Entry{
+				{AccountID: "travel", Type: entryType, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: dims},
+				{AccountID: "cash", Type: other, Amount: Amount{Value: amount, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		return txn, engine.PostTransaction(txn.ID, userID)
+	}
+	february := time.Date(2025, 2, 14, 15, 30, 0, 0, time.UTC)
+
+	t.Run("consumes matching allocations on posting", func(t *testing.T) {
+		txn, err := post(Debit, 60000, february, sales)
+		require.NoError(t, err)
+		assert.Equal(t, int64(40000), remaining())
+
+		records, err := engine.GetBudgetTracking(allocationID)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		assert.Equal(t, txn.ID, records[0].TransactionID)
+		assert.False(t, records[0].OverBudget)
+
+		err = engine.TrackBudgetSpending(txn.ID, allocationID)
+		assert.ErrorContains(t, err, "already tracked")
+		assert.Equal(t, int64(40000), remaining())
+	})
+
+	t.Run("ignores other dimensions and dates", func(t *testing.T) {
+		_, err := post(Debit, 5000, february, Dimension{Key: DimDepartment, Value: "ops"})
+		require.NoError(t, err)
+		_, err = post(Debit, 5000, time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), sales)
+		require.NoError(t, err)
+		_, err = post(Debit, 5000, time.Date(2025, 3, 31, 18, 0, 0, 0, time.UTC), sales)
+		require.NoError(t, err)
+		assert.Equal(t, int64(35000), remaining(), "only the last day of the period counts")
+	})
+
+	t.Run("warns when a posting goes over budget", func(t *testing.T) {
+		txn, err := post(Debit, 45000, february, sales)
+		require.NoError(t, err)
+		assert.Equal(t, int64(-10000), remaining())
+
+		require.Len(t, warnings, 1)
+		assert.Equal(t, txn.ID, warnings[0].TransactionID)
+		assert.Equal(t, int64(-10000), warnings[0].Remaining.Value)
+
+		records, err := engine.GetBudgetTracking(allocationID)
+		require.NoError(t, err)
+		assert.True(t, records[len(records)-1].OverBudget)
+	})
+
+	t.Run("credits give budget back", func(t *testing.T) {
+		_, err := post(Credit, 15000, february, sales)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5000), remaining())
+		assert.Len(t, warnings, 1)
+	})
+
+	t.Run("blocks over-budget postings under company policy", func(t *testing.T) {
+		engine.ApplyCompanySettings(&CompanySettings{BudgetControl: BudgetControlBlock})
+		defer engine.ApplyCompanySettings(nil)
+
+		_, err := post(Debit, 6000, february, sales)
+		assert.ErrorContains(t, err, "OVER_BUDGET")
+		assert.Equal(t, int64(5000), remaining())
+
+		_, err = post(Debit, 5000, february, sales)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), remaining())
+		assert.Len(t, warnings, 1)
+	})
+}
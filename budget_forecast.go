@@ -0,0 +1,422 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Budget Forecast Structures
+// ----------------------------------------------------------------------------
+
+// ForecastKind tells the approved budget snapshot apart from later reforecasts
+type ForecastKind string
+
+const (
+	ForecastBaseline   ForecastKind = "BASELINE"   // the approved budget spread by month
+	ForecastReforecast ForecastKind = "REFORECAST" // a revised outlook for the remaining months
+)
+
+// ForecastLine is the spending expected on one account and set of dimensions in one
+// month of the budget period
+type ForecastLine struct {
+	AccountID  string      `json:"account_id"`
+	Dimensions []Dimension `json:"dimensions,omitempty"`
+	Month      string      `json:"month"` // e.g. "2025-03"
+	Amount     *Amount     `json:"amount"`
+}
+
+// BudgetForecast is one version of a department's expected spending over a budget
+// period. Version 1 is the baseline snapshot of the approved allocations; each
+// reforecast copies the latest version and revises months from FromMonth on.
+type BudgetForecast struct {
+	ID           string         `json:"id"`
+	PeriodID     string         `json:"period_id"`
+	DepartmentID string         `json:"department_id"`
+	Version      int            `json:"version"`
+	Kind         ForecastKind   `json:"kind"`
+	FromMonth    string         `json:"from_month"` // first month this version revised
+	Lines        []ForecastLine `json:"lines"`
+	Notes        string         `json:"notes,omitempty"`
+	CreatedBy    string         `json:"created_by"`
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// ForecastVarianceMonth compares budget, forecast and actual spending for one month
+type ForecastVarianceMonth struct {
+	Month            string  `json:"month"`
+	Budget           *Amount `json:"budget"`
+	Forecast         *Amount `json:"forecast"`
+	Actual           *Amount `json:"actual"`
+	ForecastVariance *Amount `json:"forecast_variance"`  // forecast less budget
+	ActualVariance   *Amount `json:"actual_variance"`    // actual less budget
+	ActualVsForecast *Amount `json:"actual_vs_forecast"` // actual less forecast
+}
+
+// ForecastVarianceLine compares one account and set of dimensions month by month,
+// with totals for the whole period
+type ForecastVarianceLine struct {
+	AccountID  string                   `json:"account_id"`
+	Dimensions []Dimension              `json:"dimensions,omitempty"`
+	Months     []*ForecastVarianceMonth `json:"months"`
+	Total      *ForecastVarianceMonth   `json:"total"` // Month is empty
+}
+
+// ForecastVarianceReport is a department's budget vs forecast vs actual report
+type ForecastVarianceReport struct {
+	PeriodID        string                  `json:"period_id"`
+	DepartmentID    string                  `json:"department_id"`
+	ForecastID      string                  `json:"forecast_id"`
+	ForecastVersion int                     `json:"forecast_version"`
+	Lines           []*ForecastVarianceLine `json:"lines"`
+	GeneratedAt     time.Time               `json:"generated_at"`
+}
+
+// BudgetSnapshottedEvent records the baseline forecast taken from approved allocations
+type BudgetSnapshottedEvent struct {
+	ForecastID   string `json:"forecast_id"`
+	PeriodID     string `json:"period_id"`
+	DepartmentID string `json:"department_id"`
+}
+
+// BudgetReforecastEvent records a new forecast version
+type BudgetReforecastEvent struct {
+	ForecastID   string `json:"forecast_id"`
+	PeriodID     string `json:"period_id"`
+	DepartmentID string `json:"department_id"`
+	Version      int    `json:"version"`
+	FromMonth    string `json:"from_month"`
+}
+
+// ----------------------------------------------------------------------------
+// Budget Forecast Methods
+// ----------------------------------------------------------------------------
+
+// budgetPeriodMonths lists the calendar months a budget period spans
+func budgetPeriodMonths(period *BudgetPeriod) []string {
+	var months []string
+	start := time.Date(period.StartDate.Year(), period.StartDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for month := start; !month.After(period.EndDate); month = month.AddDate(0, 1, 0) {
+		months = append(months, month.Format(balanceSnapshotPeriodLayout))
+	}
+	return months
+}
+
+// forecastLineKey identifies a budget line by account and dimensions
+func forecastLineKey(accountID string, dimensions []Dimension) string {
+	return accountID + "|" + dimensionsKey(dimensions)
+}
+
+// SnapshotBudget takes the department's approved allocations for a budget period as
+// forecast version 1, spreading each allocation evenly over the period's months with
+// any remainder in the last month. A department's budget is snapshotted once; later
+// changes are entered as reforecasts.
+func (zbb *ZBBService) SnapshotBudget(periodID, departmentID, userID string) (*BudgetForecast, error) {
+	period, err := zbb.storage.GetBudgetPeriod(periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget period: %w", err)
+	}
+	existing, err := zbb.storage.GetBudgetForecastsByPeriodAndDept(periodID, departmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forecasts: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, fmt.Errorf("budget for department %s in period %s is already snapshotted; enter a reforecast instead", departmentID, periodID)
+	}
+	allocations, err := zbb.storage.GetBudgetAllocationsByPeriodAndDept(periodID, departmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get allocations: %w", err)
+	}
+	if len(allocations) == 0 {
+		return nil, fmt.Errorf("department %s has no approved allocations in period %s", departmentID, periodID)
+	}
+
+	months := budgetPeriodMonths(period)
+	forecast := &BudgetForecast{
+		ID:           newID(),
+		PeriodID:     periodID,
+		DepartmentID: departmentID,
+		Version:      1,
+		Kind:         ForecastBaseline,
+		FromMonth:    months[0],
+		CreatedBy:    userID,
+		CreatedAt:    time.Now(),
+	}
+	for _, allocation := range allocations {
+		share := allocation.Amount.Value / int64(len(months))
+		for i, month := range months {
+			amount := share
+			if i == len(months)-1 {
+				amount = allocation.Amount.Value - share*int64(len(months)-1)
+			}
+			forecast.addLine(ForecastLine{
+				AccountID:  allocation.AccountID,
+				Dimensions: allocation.Dimensions,
+				Month:      month,
+				Amount:     &Amount{Value: amount, Currency: allocation.Amount.Currency},
+			})
+		}
+	}
+	forecast.sortLines()
+
+	if _, err := zbb.eventStore.CreateEvent(EventSnapshotBudget, BudgetSnapshottedEvent{
+		ForecastID:   forecast.ID,
+		PeriodID:     periodID,
+		DepartmentID: departmentID,
+	}, period.StartDate, userID); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot event: %w", err)
+	}
+	if err := zbb.storage.SaveBudgetForecast(forecast); err != nil {
+		return nil, fmt.Errorf("failed to save forecast: %w", err)
+	}
+	return forecast, nil
+}
+
+// EnterReforecast saves a new forecast version revising the months from fromMonth on.
+// It starts from the latest version, so months and lines not given keep their
+// forecast, and each given line replaces the amount for its account, dimensions and
+// month. Months before fromMonth are closed to revision.
+func (zbb *ZBBService) EnterReforecast(periodID, departmentID, fromMonth string, lines []ForecastLine, notes, userID string) (*BudgetForecast, error) {
+	period, err := zbb.storage.GetBudgetPeriod(periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget period: %w", err)
+	}
+	latest, err := zbb.LatestForecast(periodID, departmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	months := budgetPeriodMonths(period)
+	inPeriod := make(map[string]bool, len(months))
+	for _, month := range months {
+		inPeriod[month] = true
+	}
+	if !inPeriod[fromMonth] {
+		return nil, fmt.Errorf("month %s is not in budget period %s", fromMonth, period.Name)
+	}
+	for _, line := range lines {
+		if line.AccountID == "" || line.Amount == nil {
+			return nil, fmt.Errorf("forecast lines need an account and an amount")
+		}
+		if !inPeriod[line.Month] {
+			return nil, fmt.Errorf("month %s is not in budget period %s", line.Month, period.Name)
+		}
+		if line.Month < fromMonth {
+			return nil, fmt.Errorf("month %s is before the reforecast starts in %s", line.Month, fromMonth)
+		}
+	}
+
+	forecast := &BudgetForecast{
+		ID:           newID(),
+		PeriodID:     periodID,
+		DepartmentID: departmentID,
+		Version:      latest.Version + 1,
+		Kind:         ForecastReforecast,
+		FromMonth:    fromMonth,
+		Lines:        append([]ForecastLine{}, latest.Lines...),
+		Notes:        notes,
+		CreatedBy:    userID,
+		CreatedAt:    time.Now(),
+	}
+	for _, line := range lines {
+		forecast.setLine(line)
+	}
+	forecast.sortLines()
+
+	if _, err := zbb.eventStore.CreateEvent(EventReforecastBudget, BudgetReforecastEvent{
+		ForecastID:   forecast.ID,
+		PeriodID:     periodID,
+		DepartmentID: departmentID,
+		Version:      forecast.Version,
+		FromMonth:    fromMonth,
+	}, time.Now(), userID); err != nil {
+		return nil, fmt.Errorf("failed to create reforecast event: %w", err)
+	}
+	if err := zbb.storage.SaveBudgetForecast(forecast); err != nil {
+		return nil, fmt.Errorf("failed to save forecast: %w", err)
+	}
+	return forecast, nil
+}
+
+// GetForecasts lists the forecast versions of a department's budget, oldest first
+func (zbb *ZBBService) GetForecasts(periodID, departmentID string) ([]*BudgetForecast, error) {
+	forecasts, err := zbb.storage.GetBudgetForecastsByPeriodAndDept(periodID, departmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forecasts: %w", err)
+	}
+	sort.Slice(forecasts, func(i, j int) bool {
+		return forecasts[i].Version < forecasts[j].Version
+	})
+	return forecasts, nil
+}
+
+// LatestForecast returns the most recent forecast version of a department's budget
+func (zbb *ZBBService) LatestForecast(periodID, departmentID string) (*BudgetForecast, error) {
+	forecasts, err := zbb.GetForecasts(periodID, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(forecasts) == 0 {
+		return nil, fmt.Errorf("budget for department %s in period %s has not been snapshotted", departmentID, periodID)
+	}
+	return forecasts[len(forecasts)-1], nil
+}
+
+// GetForecastVariance compares the baseline budget, a forecast version and actual
+// spending for each of a department's budget lines, month by month. Version 0 means
+// the latest forecast. Actuals are the net debits posted to the line's account with
+// its dimensions.
+func (zbb *ZBBService) GetForecastVariance(periodID, departmentID string, version int) (*ForecastVarianceReport, error) {
+	period, err := zbb.storage.GetBudgetPeriod(periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget period: %w", err)
+	}
+	forecasts, err := zbb.GetForecasts(periodID, departmentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(forecasts) == 0 {
+		return nil, fmt.Errorf("budget for department %s in period %s has not been snapshotted", departmentID, periodID)
+	}
+	baseline, forecast := forecasts[0], forecasts[len(forecasts)-1]
+	if version != 0 {
+		forecast = nil
+		for _, candidate := range forecasts {
+			if candidate.Version == version {
+				forecast = candidate
+			}
+		}
+		if forecast == nil {
+			return nil, classify(ErrNotFound, "forecast version %d not found", version)
+		}
+	}
+
+	months := budgetPeriodMonths(period)
+	report := &ForecastVarianceReport{
+		PeriodID:        periodID,
+		DepartmentID:    departmentID,
+		ForecastID:      forecast.ID,
+		ForecastVersion: forecast.Version,
+		GeneratedAt:     time.Now(),
+	}
+
+	// Budget line -> month -> amounts
+	type cell struct{ budget, forecast, actual int64 }
+	cells := make(map[string]map[string]*cell)
+	lines := make(map[string]*ForecastVarianceLine)
+	currencies := make(map[string]Currency)
+	lineFor := func(line ForecastLine) map[string]*cell {
+		key := forecastLineKey(line.AccountID, line.Dimensions)
+		if _, ok := lines[key]; !ok {
+			lines[key] = &ForecastVarianceLine{AccountID: line.AccountID, Dimensions: line.Dimensions}
+			report.Lines = append(report.Lines, lines[key])
+			cells[key] = make(map[string]*cell, len(months))
+			for _, month := range months {
+				cells[key][month] = &cell{}
+			}
+			currencies[key] = line.Amount.Currency
+		}
+		return cells[key]
+	}
+	for _, line := range baseline.Lines {
+		if c, ok := lineFor(line)[line.Month]; ok {
+			c.budget += line.Amount.Value
+		}
+	}
+	for _, line := range forecast.Lines {
+		if c, ok := lineFor(line)[line.Month]; ok {
+			c.forecast += line.Amount.Value
+		}
+	}
+
+	start := time.Date(period.StartDate.Year(), period.StartDate.Month(), period.StartDate.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(period.EndDate.Year(), period.EndDate.Month(), period.EndDate.Day()+1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
+	for _, line := range report.Lines {
+		key := forecastLineKey(line.AccountID, line.Dimensions)
+		err := zbb.storage.ForEachAccountPosting(line.AccountID, start, end, func(txn *Transaction) error {
+			c, ok := cells[key][balanceSnapshotPeriod(txn.ValidTime)]
+			if !ok {
+				return nil
+			}
+			for _, entry := range txn.Entries {
+				if entry.AccountID != line.AccountID || entry.Amount.Currency != currencies[key] || !hasDimensions(entry.Dimensions, line.Dimensions) {
+					continue
+				}
+				if entry.Type == Debit {
+					c.actual += entry.Amount.Value
+				} else {
+					c.actual -= entry.Amount.Value
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get actuals for account %s: %w", line.AccountID, err)
+		}
+
+		currency := currencies[key]
+		total := &cell{}
+		for _, month := range months {
+			c := cells[key][month]
+			line.Months = append(line.Months, newForecastVarianceMonth(month, c.budget, c.forecast, c.actual, currency))
+			total.budget += c.budget
+			total.forecast += c.forecast
+			total.actual += c.actual
+		}
+		line.Total = newForecastVarianceMonth("", total.budget, total.forecast, total.actual, currency)
+	}
+
+	sort.Slice(report.Lines, func(i, j int) bool {
+		return forecastLineKey(report.Lines[i].AccountID, report.Lines[i].Dimensions) < forecastLineKey(report.Lines[j].AccountID, report.Lines[j].Dimensions)
+	})
+	return report, nil
+}
+
+// newForecastVarianceMonth fills in the variances between budget, forecast and actual
+func newForecastVarianceMonth(month string, budget, forecast, actual int64, currency Currency) *ForecastVarianceMonth {
+	return &ForecastVarianceMonth{
+		Month:            month,
+		Budget:           &Amount{Value: budget, Currency: currency},
+		Forecast:         &Amount{Value: forecast, Currency: currency},
+		Actual:           &Amount{Value: actual, Currency: currency},
+		ForecastVariance: &Amount{Value: forecast - budget, Currency: currency},
+		ActualVariance:   &Amount{Value: actual - budget, Currency: currency},
+		ActualVsForecast: &Amount{Value: actual - forecast, Currency: currency},
+	}
+}
+
+// addLine adds an amount to the forecast for a line's account, dimensions and month
+func (f *BudgetForecast) addLine(line ForecastLine) {
+	for i := range f.Lines {
+		existing := &f.Lines[i]
+		if existing.Month == line.Month && forecastLineKey(existing.AccountID, existing.Dimensions) == forecastLineKey(line.AccountID, line.Dimensions) {
+			existing.Amount = &Amount{Value: existing.Amount.Value + line.Amount.Value, Currency: existing.Amount.Currency}
+			return
+		}
+	}
+	f.Lines = append(f.Lines, line)
+}
+
+// setLine replaces the forecast for a line's account, dimensions and month
+func (f *BudgetForecast) setLine(line ForecastLine) {
+	for i := range f.Lines {
+		existing := &f.Lines[i]
+		if existing.Month == line.Month && forecastLineKey(existing.AccountID, existing.Dimensions) == forecastLineKey(line.AccountID, line.Dimensions) {
+			*existing = line
+			return
+		}
+	}
+	f.Lines = append(f.Lines, line)
+}
+
+// sortLines orders lines by account, dimensions and month
+func (f *BudgetForecast) sortLines() {
+	sort.SliceStable(f.Lines, func(i, j int) bool {
+		ki, kj := forecastLineKey(f.Lines[i].AccountID, f.Lines[i].Dimensions), forecastLineKey(f.Lines[j].AccountID, f.Lines[j].Dimensions)
+		if ki != kj {
+			return ki < kj
+		}
+		return f.Lines[i].Month < f.Lines[j].Month
+	})
+}
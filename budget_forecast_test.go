@@ -0,0 +1,128 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetForecasts(t *testing.T) {
+	dbFile := "test_budget_forecast.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "budget_manager"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "travel", Code: "5010", Name: "Travel", Type: Expense, ParentID: "expenses"}, userID))
+
+	sales := Dimension{Key: DimDepartment, Value: "sales"}
+	period := &BudgetPeriod{
+		Name:      "Q1-2025 Budget",
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, engine.CreateBudgetPeriod(period, userID))
+
+	_, err = engine.SnapshotBudget(period.ID, "sales", userID)
+	assert.ErrorContains(t, err, "no approved allocations")
+
+	request := &BudgetRequest{
+		PeriodID:     period.ID,
+		DepartmentID: "sales",
+		Title:        "Sales travel",
+		LineItems: []BudgetLineItem{{
+			AccountID:     "travel",
+			Amount:        &Amount{Value: 100000, Currency: "USD"},
+			Description:   "Customer visits",
+			Dimensions:    []Dimension{sales},
+			Justification: "Quarterly account reviews",
+		}},
+	}
+	require.NoError(t, engine.CreateBudgetRequest(request, userID))
+	require.NoError(t, engine.SubmitBudgetRequest(request.ID, userID))
+	require.NoError(t, engine.ApproveBudgetRequest(request.ID, "cfo", request.TotalAmount, "Approved"))
+	require.NoError(t, engine.CreateBudgetAllocation(request.ID, userID))
+
+	t.Run("snapshots the approved budget by month", func(t *testing.T) {
+		baseline, err := engine.SnapshotBudget(period.ID, "sales", userID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, baseline.Version)
+		assert.Equal(t, ForecastBaseline, baseline.Kind)
+		require.Len(t, baseline.Lines, 3)
+		assert.Equal(t, []string{"2025-01", "2025-02", "2025-03"}, []string{baseline.Lines[0].Month, baseline.Lines[1].Month, baseline.Lines[2].Month})
+		assert.Equal(t, []int64{33333, 33333, 33334}, []int64{baseline.Lines[0].Amount.Value, baseline.Lines[1].Amount.Value, baseline.Lines[2].Amount.Value})
+
+		_, err = engine.SnapshotBudget(period.ID, "sales", userID)
+		assert.ErrorContains(t, err, "already snapshotted")
+	})
+
+	t.Run("reforecasts remaining months", func(t *testing.T) {
+		_, err := engine.EnterReforecast(period.ID, "sales", "2025-02", []ForecastLine{
+			{AccountID: "travel", Dimensions: []Dimension{sales}, Month: "2025-01", Amount: &Amount{Value: 1, Currency: "USD"}},
+		}, "", userID)
+		assert.ErrorContains(t, err, "before the reforecast starts")
+
+		_, err = engine.EnterReforecast(period.ID, "sales", "2025-04", nil, "", userID)
+		assert.ErrorContains(t, err, "not in budget period")
+
+		reforecast, err := engine.EnterReforecast(period.ID, "sales", "2025-02", []ForecastLine{
+			{AccountID: "travel", Dimensions: []Dimension{sales}, Month: "2025-03", Amount: &Amount{Value: 50000, Currency: "USD"}},
+		}, "Trade show moved into March", userID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, reforecast.Version)
+		assert.Equal(t, ForecastReforecast, reforecast.Kind)
+		require.Len(t, reforecast.Lines, 3)
+		assert.Equal(t, int64(33333), reforecast.Lines[1].Amount.Value, "months not given keep their forecast")
+		assert.Equal(t, int64(50000), reforecast.Lines[2].Amount.Value)
+
+		forecasts, err := engine.GetBudgetForecasts(period.ID, "sales")
+		require.NoError(t, err)
+		require.Len(t, forecasts, 2)
+		assert.Equal(t, int64(33334), forecasts[0].Lines[2].Amount.Value, "the baseline is unchanged")
+		assert.Equal(t, "Trade show moved into March", forecasts[1].Notes)
+	})
+
+	t.Run("compares budget, forecast and actual", func(t *testing.T) {
+		txn := &Transaction{
+			Description: "Sales trip",
+			ValidTime:   time.Date(2025, 2, 14, 15, 30, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "travel", Type: Debit, Amount: Amount{Value: 40000, Currency: "USD"}, Dimensions: []Dimension{sales}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: 40000, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+
+		report, err := engine.GetForecastVariance(period.ID, "sales", 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.ForecastVersion)
+		require.Len(t, report.Lines, 1)
+
+		line := report.Lines[0]
+		require.Len(t, line.Months, 3)
+		february := line.Months[1]
+		assert.Equal(t, int64(40000), february.Actual.Value)
+		assert.Equal(t, int64(6667), february.ActualVariance.Value)
+		assert.Equal(t, int64(6667), february.ActualVsForecast.Value)
+		assert.Equal(t, int64(16666), line.Months[2].ForecastVariance.Value)
+
+		assert.Equal(t, int64(100000), line.Total.Budget.Value)
+		assert.Equal(t, int64(116666), line.Total.Forecast.Value)
+		assert.Equal(t, int64(40000), line.Total.Actual.Value)
+		assert.Equal(t, int64(-76666), line.Total.ActualVsForecast.Value)
+
+		baseline, err := engine.GetForecastVariance(period.ID, "sales", 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), baseline.Lines[0].Total.ForecastVariance.Value)
+
+		_, err = engine.GetForecastVariance(period.ID, "sales", 9)
+		assert.ErrorContains(t, err, "version 9 not found")
+	})
+}
@@ -0,0 +1,142 @@
+package accounting
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// bulkTestTransactions builds n balanced two-line transactions
+func bulkTestTransactions(n int) ([]*Transaction, []*Entry) {
+	txns := make([]*Transaction, n)
+	var entries []*Entry
+	validTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("bulk-%08d", i)
+		amount := Amount{Value: int64(i + 1), Currency: "USD"}
+		txn := &Transaction{
+			ID:          id,
+			Description: "Bulk load",
+			ValidTime:   validTime,
+			Status:      Pending,
+			Entries: []Entry{
+				{ID: id + "-dr", TransactionID: id, AccountID: "cash", Type: Debit, Amount: amount},
+				{ID: id + "-cr", TransactionID: id, AccountID: "revenue", Type: Credit, Amount: amount},
+			},
+		}
+		txns[i] = txn
+		for j := range txn.Entries {
+			entries = append(entries, &txn.Entries[j])
+		}
+	}
+	return txns, entries
+}
+
+func TestBulkWrites(t *testing.T) {
+	// Setup
+	dbFile := "test_bulk_writes.db"
+	defer os.Remove(dbFile)
+
+	storage, err := NewStorageWithOptions(dbFile, StorageOptions{NoSync: true, InitialMmapSize: 1 << 20})
+	require.NoError(t, err)
+	defer storage.Close()
+
+	txns, entries := bulkTestTransactions(250)
+
+	t.Run("Save Transactions", func(t *testing.T) {
+		require.NoError(t, storage.SaveTransactions(txns))
+		txn, err := storage.GetTransaction("bulk-00000249")
+		require.NoError(t, err)
+		assert.Equal(t, int64(250), txn.Entries[0].Amount.Value)
+	})
+
+	t.Run("Save Entries", func(t *testing.T) {
+		require.NoError(t, storage.SaveEntries(entries))
+		cash, err := storage.GetEntriesByAccount("cash")
+		require.NoError(t, err)
+		assert.Len(t, cash, 250)
+	})
+
+	t.Run("Sync After Bulk Load", func(t *testing.T) {
+		require.NoError(t, storage.SetNoSync(false))
+		require.NoError(t, storage.SaveTransactions(nil))
+	})
+}
+
+func benchmarkStorage(b *testing.B, options StorageOptions) *Storage {
+	storage, err := NewStorageWithOptions(filepath.Join(b.TempDir(), "bench.db"), options)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { storage.Close() })
+	return storage
+}
+
+// BenchmarkSaveTransaction writes one transaction per bolt transaction, as callers
+// did before the batch APIs existed
+func BenchmarkSaveTransaction(b *testing.B) {
+	storage := benchmarkStorage(b, StorageOptions{})
+	txns, _ := bulkTestTransactions(b.N)
+	b.ResetTimer()
+	for _, txn := range txns {
+		if err := storage.SaveTransaction(txn); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSaveTransactionNoSync isolates the cost of fsync on each commit
+func BenchmarkSaveTransactionNoSync(b *testing.B) {
+	storage := benchmarkStorage(b, StorageOptions{NoSync: true})
+	txns, _ := bulkTestTransactions(b.N)
+	b.ResetTimer()
+	for _, txn := range txns {
+		if err := storage.SaveTransaction(txn); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSaveTransactions writes in batches of 1000 per bolt transaction
+func BenchmarkSaveTransactions(b *testing.B) {
+	storage := benchmarkStorage(b, StorageOptions{})
+	txns, _ := bulkTestTransactions(b.N)
+	b.ResetTimer()
+	for start := 0; start < len(txns); start += 1000 {
+		end := min(start+1000, len(txns))
+		if err := storage.SaveTransactions(txns[start:end]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSaveEntry writes one entry per bolt transaction
+func BenchmarkSaveEntry(b *testing.B) {
+	storage := benchmarkStorage(b, StorageOptions{})
+	_, entries := bulkTestTransactions((b.N + 1) / 2)
+	b.ResetTimer()
+	for _, entry := range entries[:b.N] {
+		if err := storage.SaveEntry(entry); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSaveEntries writes in batches of 1000 per bolt transaction
+func BenchmarkSaveEntries(b *testing.B) {
+	storage := benchmarkStorage(b, StorageOptions{})
+	_, entries := bulkTestTransactions((b.N + 1) / 2)
+	entries = entries[:b.N]
+	b.ResetTimer()
+	for start := 0; start < len(entries); start += 1000 {
+		end := min(start+1000, len(entries))
+		if err := storage.SaveEntries(entries[start:end]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
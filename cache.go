@@ -0,0 +1,278 @@
+package accounting
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	pb "accounting/proto/accounting"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+// CacheStats reports the activity of one cached bucket
+type CacheStats struct {
+	Name          string `json:"name"`
+	Hits          uint64 `json:"hits"`   // reads served from memory
+	Misses        uint64 `json:"misses"` // reads that had to load the bucket
+	Invalidations uint64 `json:"invalidations"`
+	Records       int    `json:"records"` // records currently held, 0 when not loaded
+}
+
+// recordCache keeps the decoded records of one bucket in memory. The cached buckets
+// are small and read on nearly every posting, so the whole bucket is loaded on first
+// use and dropped whenever it is written. Readers get copies, so changes they make
+// never leak into the cache.
+type recordCache[T any] struct {
+	name   string
+	bucket []byte
+	decode func(data []byte) (T, error)
+	clone  func(T) T
+
+	mu      sync.RWMutex
+	loaded  bool
+	keys    []string // bucket order
+	records map[string]T
+	version uint64 // bumped whenever the records are dropped
+	writers int    // writes to the bucket in progress
+
+	hits          atomic.Uint64
+	misses        atomic.Uint64
+	invalidations atomic.Uint64
+}
+
+// newRecordCache creates an empty cache over a bucket
+func newRecordCache[T any](name string, bucket []byte, decode func([]byte) (T, error), clone func(T) T) *recordCache[T] {
+	return &recordCache[T]{name: name, bucket: bucket, decode: decode, clone: clone}
+}
+
+// snapshot returns the bucket's keys and records, reading them from the database
+// unless they are held. What was read is only kept if no write began or ended
+// meanwhile, so a load racing a write never caches the records it replaced. The
+// returned map is never changed afterwards; a reload replaces it.
+func (c *recordCache[T]) snapshot(db *storageDB) ([]string, map[string]T, error) {
+	c.mu.RLock()
+	keys, records, loaded, version := c.keys, c.records, c.loaded, c.version
+	c.mu.RUnlock()
+	if loaded {
+		c.hits.Add(1)
+		return keys, records, nil
+	}
+
+	c.misses.Add(1)
+	keys = []string{}
+	records = make(map[string]T)
+	err := db.View(func(tx *bbolt.Tx) error {
+		return db.scanBucket(tx, c.bucket).ForEach(func(k, v []byte) error {
+			record, err := c.decode(v)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, string(k))
+			records[string(k)] = record
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	if c.version == version && c.writers == 0 {
+		c.keys, c.records, c.loaded = keys, records, true
+	}
+	c.mu.Unlock()
+	return keys, records, nil
+}
+
+// get returns a copy of the record stored under key
+func (c *recordCache[T]) get(db *storageDB, key string) (T, bool, error) {
+	var zero T
+	_, records, err := c.snapshot(db)
+	if err != nil {
+		return zero, false, err
+	}
+
+	record, ok := records[key]
+	if !ok {
+		return zero, false, nil
+	}
+	return c.clone(record), true, nil
+}
+
+// list returns copies of the records in bucket order that pass keep, or all of them
+// when keep is nil
+func (c *recordCache[T]) list(db *storageDB, keep func(T) bool) ([]T, error) {
+	keys, records, err := c.snapshot(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []T
+	for _, key := range keys {
+		record := records[key]
+		if keep == nil || keep(record) {
+			kept = append(kept, c.clone(record))
+		}
+	}
+	return kept, nil
+}
+
+// invalidate drops the cached records so the next read reloads them
+func (c *recordCache[T]) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.drop()
+}
+
+// beginWrite drops the cached records before a write to the bucket commits, and
+// keeps reads from caching them again until endWrite
+func (c *recordCache[T]) beginWrite() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writers++
+	c.drop()
+}
+
+// endWrite marks a write begun with beginWrite as committed or abandoned
+func (c *recordCache[T]) endWrite() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writers--
+	c.version++
+}
+
+// drop empties the cache; the caller holds the write lock
+func (c *recordCache[T]) drop() {
+	c.invalidations.Add(1)
+	c.version++
+	c.keys, c.records, c.loaded = nil, nil, false
+}
+
+// stats reports the cache's counters
+func (c *recordCache[T]) stats() CacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return CacheStats{
+		Name:          c.name,
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Invalidations: c.invalidations.Load(),
+		Records:       len(c.records),
+	}
+}
+
+// readCache holds the hot, rarely written buckets: accounts, periods, compliance
+// rules and exchange rates
+type readCache struct {
+	accounts        *recordCache[*Account]
+	periods         *recordCache[*Period]
+	complianceRules *recordCache[*ComplianceRule]
+	exchangeRates   *recordCache[*ExchangeRate]
+}
+
+// newReadCache creates the read cache with every bucket unloaded
+func newReadCache() *readCache {
+	return &readCache{
+		accounts: newRecordCache("accounts", BucketAccounts, func(data []byte) (*Account, error) {
+			pbAccount := &pb.Account{}
+			if err := proto.Unmarshal(data, pbAccount); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal account: %w", err)
+			}
+			return AccountFromProto(pbAccount), nil
+		}, cloneAccount),
+		periods: newRecordCache("periods", BucketPeriods, func(data []byte) (*Period, error) {
+			pbPeriod := &pb.Period{}
+			if err := proto.Unmarshal(data, pbPeriod); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal period: %w", err)
+			}
+			return PeriodFromProto(pbPeriod), nil
+		}, clonePeriod),
+		complianceRules: newRecordCache("compliance_rules", BucketComplianceRules, func(data []byte) (*ComplianceRule, error) {
+			pbRule := &pb.ComplianceRule{}
+			if err := proto.Unmarshal(data, pbRule); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal compliance rule: %w", err)
+			}
+			return ComplianceRuleFromProto(pbRule), nil
+		}, cloneComplianceRule),
+		exchangeRates: newRecordCache("exchange_rates", BucketExchangeRates, func(data []byte) (*ExchangeRate, error) {
+			pbRate := &pb.ExchangeRate{}
+			if err := proto.Unmarshal(data, pbRate); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal exchange rate: %w", err)
+			}
+			return ExchangeRateFromProto(pbRate), nil
+		}, cloneExchangeRate),
+	}
+}
+
+// CacheStats reports hits, misses and invalidations for each cached bucket. It is
+// empty when the cache is disabled.
+func (s *Storage) CacheStats() []CacheStats {
+	if s.cache == nil {
+		return nil
+	}
+	return []CacheStats{
+		s.cache.accounts.stats(),
+		s.cache.periods.stats(),
+		s.cache.complianceRules.stats(),
+		s.cache.exchangeRates.stats(),
+	}
+}
+
+// InvalidateCache drops every cached record. Writes through Storage invalidate the
+// cache themselves; call this after changing the database by other means.
+func (s *Storage) InvalidateCache() {
+	if s.cache == nil {
+		return
+	}
+	s.cache.accounts.invalidate()
+	s.cache.periods.invalidate()
+	s.cache.complianceRules.invalidate()
+	s.cache.exchangeRates.invalidate()
+}
+
+// cloneAccount copies an account so callers can change it freely
+func cloneAccount(account *Account) *Account {
+	clone := *account
+	if account.Dimensions != nil {
+		clone.Dimensions = append([]Dimension{}, account.Dimensions...)
+	}
+	if account.ClosedAt != nil {
+		closedAt := *account.ClosedAt
+		clone.ClosedAt = &closedAt
+	}
+	return &clone
+}
+
+// clonePeriod copies a period so callers can change it freely
+func clonePeriod(period *Period) *Period {
+	clone := *period
+	if period.SoftClosedAt != nil {
+		softClosedAt := *period.SoftClosedAt
+		clone.SoftClosedAt = &softClosedAt
+	}
+	if period.HardClosedAt != nil {
+		hardClosedAt := *period.HardClosedAt
+		clone.HardClosedAt = &hardClosedAt
+	}
+	return &clone
+}
+
+// cloneComplianceRule copies a compliance rule so callers can change it freely
+func cloneComplianceRule(rule *ComplianceRule) *ComplianceRule {
+	clone := *rule
+	if rule.Conditions != nil {
+		clone.Conditions = append([]string{}, rule.Conditions...)
+	}
+	if rule.Actions != nil {
+		clone.Actions = append([]string{}, rule.Actions...)
+	}
+	return &clone
+}
+
+// cloneExchangeRate copies an exchange rate so callers can change it freely
+func cloneExchangeRate(rate *ExchangeRate) *ExchangeRate {
+	clone := *rate
+	return &clone
+}
@@ -0,0 +1,175 @@
+package accounting
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCache(t *testing.T) {
+	dbFile := "test_cache.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	storage := engine.GetStorage()
+
+	statsFor := func(name string) CacheStats {
+		for _, stats := range engine.GetCacheStats() {
+			if stats.Name == name {
+				return stats
+			}
+		}
+		t.Fatalf("no cache stats for %s", name)
+		return CacheStats{}
+	}
+
+	t.Run("serves repeat reads from memory", func(t *testing.T) {
+		_, err := storage.GetAccount("cash")
+		require.NoError(t, err)
+		before := statsFor("accounts")
+
+		for i := 0; i < 10; i++ {
+			_, err := storage.GetAccount("cash")
+			require.NoError(t, err)
+		}
+		after := statsFor("accounts")
+		assert.Equal(t, before.Hits+10, after.Hits)
+		assert.Equal(t, before.Misses, after.Misses)
+		assert.Equal(t, 8, after.Records)
+
+		_, err = storage.GetAccount("missing")
+		assert.ErrorContains(t, err, "account not found: missing")
+	})
+
+	t.Run("hands out copies", func(t *testing.T) {
+		account, err := storage.GetAccount("cash")
+		require.NoError(t, err)
+		account.Name = "Changed without saving"
+
+		again, err := storage.GetAccount("cash")
+		require.NoError(t, err)
+		assert.Equal(t, "Cash", again.Name)
+	})
+
+	t.Run("invalidates on write", func(t *testing.T) {
+		before := statsFor("accounts")
+		account, err := storage.GetAccount("cash")
+		require.NoError(t, err)
+		account.Name = "Cash at Bank"
+		require.NoError(t, storage.SaveAccount(account))
+
+		assert.Equal(t, before.Invalidations+1, statsFor("accounts").Invalidations)
+		again, err := storage.GetAccount("cash")
+		require.NoError(t, err)
+		assert.Equal(t, "Cash at Bank", again.Name)
+	})
+
+	t.Run("sees newly closed periods", func(t *testing.T) {
+		period := &Period{Name: "2024-01", Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)}
+		require.NoError(t, engine.CreatePeriod(period, userID))
+
+		txn := func() *Transaction {
+			return &Transaction{
+				Description: "January sale",
+				ValidTime:   time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+				Entries: []Entry{
+					{AccountID: "cash", Type: Debit, Amount: Amount{Value: 100, Currency: "USD"}},
+					{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 100, Currency: "USD"}},
+				},
+			}
+		}
+		require.NoError(t, engine.CreateTransaction(txn(), userID))
+
+		closedAt := time.Now()
+		period.HardClosedAt = &closedAt
+		require.NoError(t, storage.SavePeriod(period))
+		assert.Error(t, engine.CreateTransaction(txn(), userID))
+	})
+
+	t.Run("handles concurrent readers and writers", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 50; j++ {
+					if i == 0 && j%10 == 0 {
+						account, err := storage.GetAccount("revenue")
+						assert.NoError(t, err)
+						assert.NoError(t, storage.SaveAccount(account))
+						continue
+					}
+					accounts, err := storage.GetAllAccounts()
+					assert.NoError(t, err)
+					assert.Len(t, accounts, 8)
+				}
+			}(i)
+		}
+		wg.Wait()
+	})
+
+	t.Run("never misses or serves replaced records while saving", func(t *testing.T) {
+		// Run with -race: readers load and drop the cache while a writer renames an account
+		period := &Period{Name: "2024-02", Start: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)}
+		require.NoError(t, engine.CreatePeriod(period, userID))
+
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					_, err := storage.GetAccount("expenses")
+					assert.NoError(t, err)
+					_, err = storage.GetPeriod(period.ID)
+					assert.NoError(t, err)
+				}
+			}()
+		}
+
+		account, err := storage.GetAccount("expenses")
+		require.NoError(t, err)
+		for i := 0; i < 100; i++ {
+			account.Name = fmt.Sprintf("Expenses %d", i)
+			require.NoError(t, storage.SaveAccount(account))
+			require.NoError(t, storage.SavePeriod(period))
+
+			saved, err := storage.GetAccount("expenses")
+			require.NoError(t, err)
+			require.Equal(t, account.Name, saved.Name)
+		}
+		close(done)
+		wg.Wait()
+	})
+
+	t.Run("can be disabled", func(t *testing.T) {
+		uncachedFile := "test_cache_disabled.db"
+		defer os.Remove(uncachedFile)
+
+		uncached, err := NewAccountingEngineWithOptions(uncachedFile, StorageOptions{DisableCache: true})
+		require.NoError(t, err)
+		defer uncached.Close()
+
+		require.NoError(t, uncached.CreateStandardAccounts(userID))
+		assert.Empty(t, uncached.GetCacheStats())
+		account, err := uncached.GetStorage().GetAccount("cash")
+		require.NoError(t, err)
+		assert.Equal(t, "Cash", account.Name)
+	})
+}
@@ -0,0 +1,181 @@
+package accounting
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Supporting Calculations
+// ----------------------------------------------------------------------------
+
+// CalculationValue is a named input, intermediate value or result of a calculation.
+// Values are kept as the decimal text used in the calculation so they read back
+// exactly as computed.
+type CalculationValue struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Unit        string `json:"unit,omitempty"` // currency code, "%", "periods", ...
+	Description string `json:"description,omitempty"`
+}
+
+// CalculationBreakdown is the working behind a computed amount: the formula, the
+// inputs it was applied to and the intermediate values in order, stored with the
+// record so a reviewer can re-perform the calculation later
+type CalculationBreakdown struct {
+	Formula    string             `json:"formula"`
+	Inputs     []CalculationValue `json:"inputs"`
+	Steps      []CalculationValue `json:"steps,omitempty"`
+	Result     CalculationValue   `json:"result"`
+	Notes      string             `json:"notes,omitempty"`
+	ComputedAt time.Time          `json:"computed_at"`
+	ComputedBy string             `json:"computed_by,omitempty"`
+}
+
+// NewCalculationBreakdown starts a breakdown for a formula
+func NewCalculationBreakdown(formula, computedBy string) *CalculationBreakdown {
+	return &CalculationBreakdown{Formula: formula, ComputedAt: time.Now(), ComputedBy: computedBy}
+}
+
+// AddInput records a value the calculation starts from
+func (b *CalculationBreakdown) AddInput(name, value, unit string) *CalculationBreakdown {
+	b.Inputs = append(b.Inputs, CalculationValue{Name: name, Value: value, Unit: unit})
+	return b
+}
+
+// AddStep records an intermediate value
+func (b *CalculationBreakdown) AddStep(name, value, unit, description string) *CalculationBreakdown {
+	b.Steps = append(b.Steps, CalculationValue{Name: name, Value: value, Unit: unit, Description: description})
+	return b
+}
+
+// SetResult records the amount the calculation arrived at
+func (b *CalculationBreakdown) SetResult(name, value, unit string) *CalculationBreakdown {
+	b.Result = CalculationValue{Name: name, Value: value, Unit: unit}
+	return b
+}
+
+// Validate checks that the breakdown names its formula, inputs and result
+func (b *CalculationBreakdown) Validate() error {
+	if b.Formula == "" {
+		return fmt.Errorf("calculation formula is required")
+	}
+	if len(b.Inputs) == 0 {
+		return fmt.Errorf("calculation inputs are required")
+	}
+	if b.Result.Name == "" || b.Result.Value == "" {
+		return fmt.Errorf("calculation result is required")
+	}
+	for _, value := range append(append([]CalculationValue{}, b.Inputs...), b.Steps...) {
+		if value.Name == "" {
+			return fmt.Errorf("every calculation value needs a name")
+		}
+	}
+	return nil
+}
+
+// Explain renders the breakdown as plain text for a reviewer
+func (b *CalculationBreakdown) Explain() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Formula: %s\n", b.Formula)
+	write := func(heading string, values []CalculationValue) {
+		if len(values) == 0 {
+			return
+		}
+		fmt.Fprintf(&sb, "%s:\n", heading)
+		for _, value := range values {
+			fmt.Fprintf(&sb, "  %s = %s", value.Name, strings.TrimSpace(value.Value+" "+value.Unit))
+			if value.Description != "" {
+				fmt.Fprintf(&sb, " (%s)", value.Description)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	write("Inputs", b.Inputs)
+	write("Steps", b.Steps)
+	fmt.Fprintf(&sb, "Result: %s = %s\n", b.Result.Name, strings.TrimSpace(b.Result.Value+" "+b.Result.Unit))
+	if b.Notes != "" {
+		fmt.Fprintf(&sb, "Notes: %s\n", b.Notes)
+	}
+	return sb.String()
+}
+
+// formatCalculationNumber renders a float with as many digits as it needs
+func formatCalculationNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// recognitionBreakdown shows how a recognition schedule splits its total across
+// periods
+func recognitionBreakdown(schedule *RecognitionSchedule, total *Amount, amounts []*Amount, dates []time.Time, userID string) *CalculationBreakdown {
+	currency := string(total.Currency)
+	breakdown := NewCalculationBreakdown(
+		"period amount = total / occurrences, with any remainder spread one minor unit at a time from the first period",
+		userID,
+	).
+		AddInput("total", FormatMinorUnits(total.Value, total.Currency), currency).
+		AddInput("occurrences", strconv.Itoa(schedule.Occurrences), "periods").
+		AddInput("frequency", string(schedule.Frequency), "").
+		AddInput("start", schedule.StartTime.Format("2006-01-02"), "")
+	if schedule.Occurrences > 0 {
+		breakdown.AddStep("remainder", FormatMinorUnits(total.Value%int64(schedule.Occurrences), total.Currency), currency,
+			"left over after an even split")
+	}
+	for i, amount := range amounts {
+		breakdown.AddStep(fmt.Sprintf("period %d", i+1), FormatMinorUnits(amount.Value, amount.Currency), currency,
+			"recognized "+dates[i].Format("2006-01-02"))
+	}
+	return breakdown.SetResult("total recognized", FormatMinorUnits(total.Value, total.Currency), currency)
+}
+
+// taxBreakdown shows how a tax calculation applied its rules to an amount in unit.
+// Calculations combining several rules show each rule's tax as a step.
+func taxBreakdown(calc *TaxCalculation, exemptions []string, unit string) *CalculationBreakdown {
+	formula := "tax = taxable amount × rate"
+	combined := len(calc.Components) > 1 || (len(calc.Components) == 1 && calc.Components[0].Compound)
+	if combined {
+		formula = "tax = sum of each rule's taxable amount × rate, compound rules taxing the amount plus the taxes before them"
+	}
+	breakdown := NewCalculationBreakdown(formula, "").
+		AddInput("base amount", formatCalculationNumber(calc.BaseAmount), unit).
+		AddInput("rate", formatCalculationNumber(calc.TaxRate), "")
+	if calc.RuleID != "" {
+		rules := make([]string, len(calc.Components))
+		for i, component := range calc.Components {
+			rules[i] = component.RuleID
+		}
+		name := "rule"
+		if combined {
+			name = "rules"
+		}
+		breakdown.AddInput(name, strings.Join(rules, ", "), "")
+	} else {
+		breakdown.Notes = "no tax rule applied to the amount"
+	}
+	if len(exemptions) > 0 {
+		breakdown.AddInput("exemptions claimed", strings.Join(exemptions, ", "), "")
+	}
+	if calc.RuleID != "" && len(calc.Exemptions) > 0 {
+		description := "the whole amount is exempt"
+		if combined {
+			description = "exempt from the rules granting them"
+		}
+		breakdown.AddStep("exemptions applied", strings.Join(calc.Exemptions, ", "), "", description)
+	}
+	breakdown.AddStep("taxable amount", formatCalculationNumber(calc.TaxableAmount), unit, "")
+	if combined {
+		for _, component := range calc.Components {
+			description := fmt.Sprintf("%s × %s", formatCalculationNumber(component.TaxableAmount), formatCalculationNumber(component.TaxRate))
+			if component.Compound {
+				description += ", compound"
+			}
+			breakdown.AddStep(cmp.Or(component.Name, component.RuleID), formatCalculationNumber(component.TaxAmount), unit, description)
+		}
+	}
+	breakdown.SetResult("tax", formatCalculationNumber(calc.TaxAmount), unit)
+	breakdown.ComputedAt = calc.CalculatedAt
+	return breakdown
+}
@@ -0,0 +1,88 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculationBreakdowns(t *testing.T) {
+	dbFile := "test_calculation_breakdown.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "accountant"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	t.Run("recognition schedules keep their split", func(t *testing.T) {
+		schedule, err := engine.CreateAccrualSchedule("prepaid_support", &Amount{Value: 1000, Currency: "USD"}, Monthly, 3,
+			time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), nil, userID)
+		require.NoError(t, err)
+
+		schedules, err := engine.GetStorage().GetAllSchedules()
+		require.NoError(t, err)
+		require.Len(t, schedules, 1)
+		breakdown := schedules[0].Breakdown
+		require.NotNil(t, breakdown)
+		assert.Equal(t, userID, breakdown.ComputedBy)
+		assert.Equal(t, CalculationValue{Name: "total", Value: "10.00", Unit: "USD"}, breakdown.Inputs[0])
+		require.Len(t, breakdown.Steps, 4)
+		assert.Equal(t, "0.01", breakdown.Steps[0].Value)
+		assert.Equal(t, CalculationValue{Name: "period 1", Value: "3.34", Unit: "USD", Description: "recognized 2025-01-15"}, breakdown.Steps[1])
+		assert.Equal(t, "recognized 2025-03-15", breakdown.Steps[3].Description)
+		assert.Equal(t, "10.00", breakdown.Result.Value)
+		assert.Contains(t, breakdown.Explain(), "period 2 = 3.33 USD (recognized 2025-02-15)")
+
+		err = engine.AttachAccrualBreakdown(schedule.ID, &CalculationBreakdown{Formula: "usage × price"}, userID)
+		assert.ErrorContains(t, err, "inputs are required")
+
+		estimate := NewCalculationBreakdown("accrual = hours × rate", "").
+			AddInput("hours", "40", "hours").
+			AddInput("rate", "0.25", "USD").
+			SetResult("accrual", "10.00", "USD")
+		estimate.Notes = "Vendor timesheet for January"
+		require.NoError(t, engine.AttachAccrualBreakdown(schedule.ID, estimate, "reviewer"))
+
+		schedules, err = engine.GetStorage().GetAllSchedules()
+		require.NoError(t, err)
+		assert.Equal(t, "accrual = hours × rate", schedules[0].Breakdown.Formula)
+		assert.Equal(t, "reviewer", schedules[0].Breakdown.ComputedBy)
+		assert.Equal(t, "Vendor timesheet for January", schedules[0].Breakdown.Notes)
+	})
+
+	t.Run("tax calculations show their working", func(t *testing.T) {
+		compliance := engine.GetComplianceService()
+		require.NoError(t, compliance.CreateTaxRule(TaxRule{
+			Jurisdiction:  US_STATE,
+			TaxType:       SALES_TAX,
+			Name:          "State sales tax",
+			Rate:          0.0725,
+			EffectiveFrom: time.Now().AddDate(-1, 0, 0),
+		}))
+
+		calc, err := compliance.CalculateTaxOnAmount(&Amount{Value: 1999, Currency: "USD"}, US_STATE, SALES_TAX, nil)
+		require.NoError(t, err)
+		require.NotNil(t, calc.Breakdown)
+		assert.Equal(t, "tax = taxable amount × rate", calc.Breakdown.Formula)
+		assert.Equal(t, CalculationValue{Name: "base amount", Value: "19.99", Unit: "USD"}, calc.Breakdown.Inputs[0])
+		assert.Equal(t, "0.0725", calc.Breakdown.Inputs[1].Value)
+		assert.Equal(t, CalculationValue{Name: "tax", Value: "1.45", Unit: "USD"}, calc.Breakdown.Result)
+		last := calc.Breakdown.Steps[len(calc.Breakdown.Steps)-1]
+		assert.Equal(t, "tax before rounding", last.Name)
+		assert.Equal(t, "1.449275", last.Value)
+
+		taxReturn := &TaxReturn{ID: "return_q1", Jurisdiction: US_STATE, TaxType: SALES_TAX, Calculations: []TaxCalculation{*calc}}
+		require.NoError(t, engine.GetStorage().SaveTaxReturn(taxReturn))
+		stored, err := engine.GetStorage().GetTaxReturn("return_q1")
+		require.NoError(t, err)
+		require.Len(t, stored.Calculations, 1)
+		assert.Equal(t, calc.Breakdown.Steps, stored.Calculations[0].Breakdown.Steps)
+		assert.Equal(t, calc.RuleID, stored.Calculations[0].RuleID)
+	})
+}
@@ -0,0 +1,270 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CashFlowMethod is how the operating section of a cash flow statement is derived
+type CashFlowMethod string
+
+const (
+	CashFlowDirect   CashFlowMethod = "DIRECT"   // cash receipts and payments
+	CashFlowIndirect CashFlowMethod = "INDIRECT" // net income adjusted for non-cash items and working capital
+)
+
+// recognitionSourcePrefix marks transactions posted by a recognition schedule; the
+// schedule ID follows it
+const recognitionSourcePrefix = "ACCRUAL_"
+
+// IndirectCashFlowOptions says how balance sheet accounts feed an indirect cash flow
+// statement. Accounts not listed anywhere are working capital. Nil uses the defaults.
+type IndirectCashFlowOptions struct {
+	CashAccountIDs      []string `json:"cash_account_ids,omitempty"`
+	NonCashAccountIDs   []string `json:"non_cash_account_ids,omitempty"` // e.g. accumulated depreciation; movements are added back
+	InvestingAccountIDs []string `json:"investing_account_ids,omitempty"`
+	FinancingAccountIDs []string `json:"financing_account_ids,omitempty"` // equity accounts are always financing
+}
+
+// DefaultIndirectCashFlowOptions follows the account IDs the direct method
+// categorizes by
+func DefaultIndirectCashFlowOptions() *IndirectCashFlowOptions {
+	return &IndirectCashFlowOptions{
+		CashAccountIDs:      []string{"cash"},
+		NonCashAccountIDs:   []string{"accumulated_depreciation"},
+		InvestingAccountIDs: []string{"equipment", "property"},
+		FinancingAccountIDs: []string{"notes_payable", "bonds_payable"},
+	}
+}
+
+// cashFlowLine accumulates one account's cash effect in a section
+type cashFlowLine struct {
+	account *Account
+	amount  int64
+}
+
+// GenerateIndirectCashFlowStatement derives operating cash flow from net income:
+// non-cash items (schedule recognitions and the movements of non-cash accounts) are
+// backed out and working capital changes applied, then investing and financing
+// movements are added. The result is reconciled to the cash accounts' own movement.
+func (rs *ReportingService) GenerateIndirectCashFlowStatement(fromDate, toDate time.Time, currency string, options *IndirectCashFlowOptions) (_ *CashFlowStatement, err error) {
+	defer rs.metrics.timeReport("indirect_cash_flow")(&err)
+	defer rs.tracer.report("indirect_cash_flow")(&err)
+
+	if options == nil {
+		options = DefaultIndirectCashFlowOptions()
+	}
+	if len(options.CashAccountIDs) == 0 {
+		return nil, fmt.Errorf("at least one cash account is required")
+	}
+
+	accounts, err := rs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	accountsByID := make(map[string]*Account, len(accounts))
+	for _, account := range accounts {
+		accountsByID[account.ID] = account
+	}
+	for _, id := range options.CashAccountIDs {
+		if _, ok := accountsByID[id]; !ok {
+			return nil, notFound("cash account", id)
+		}
+	}
+
+	schedules, err := rs.storage.GetAllSchedules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recognition schedules: %w", err)
+	}
+	recognitionRefs := make(map[string]bool, len(schedules))
+	for _, schedule := range schedules {
+		recognitionRefs[recognitionSourcePrefix+schedule.ID] = true
+	}
+
+	cf := &CashFlowStatement{
+		Name:     "Cash Flow Statement (Indirect Method)",
+		Method:   CashFlowIndirect,
+		FromDate: fromDate,
+		ToDate:   toDate,
+		Currency: currency,
+	}
+
+	var netIncome int64
+	recognized := make(map[string]*cashFlowLine)
+	nonCash := make(map[string]*cashFlowLine)
+	workingCapital := make(map[string]*cashFlowLine)
+	investing := make(map[string]*cashFlowLine)
+	financing := make(map[string]*cashFlowLine)
+	add := func(lines map[string]*cashFlowLine, account *Account, amount int64) {
+		if lines[account.ID] == nil {
+			lines[account.ID] = &cashFlowLine{account: account}
+		}
+		lines[account.ID].amount += amount
+	}
+
+	err = rs.storage.ForEachPostedTransaction(fromDate, toDate, func(txn *Transaction) error {
+		for _, entry := range txn.Entries {
+			account, ok := accountsByID[entry.AccountID]
+			if !ok {
+				return fmt.Errorf("transaction %s posts to unknown account %s", txn.ID, entry.AccountID)
+			}
+			translated, err := rs.translateAmount(&entry.Amount, currency, txn.ValidTime)
+			if err != nil {
+				return fmt.Errorf("failed to translate entry on transaction %s: %w", txn.ID, err)
+			}
+			debit := translated.Value
+			if entry.Type == Credit {
+				debit = -debit
+			}
+
+			// A debit to an asset uses cash and a credit to a liability provides it, so
+			// a balance sheet movement's cash effect is its credit
+			switch {
+			case slices.Contains(options.CashAccountIDs, account.ID):
+				// reconciled against the cash balances below
+			case account.Type == Income || account.Type == Expense:
+				netIncome -= debit
+			case recognitionRefs[txn.SourceRef]:
+				add(recognized, account, -debit)
+			case slices.Contains(options.NonCashAccountIDs, account.ID):
+				add(nonCash, account, -debit)
+			case slices.Contains(options.InvestingAccountIDs, account.ID):
+				add(investing, account, -debit)
+			case account.Type == Equity || slices.Contains(options.FinancingAccountIDs, account.ID):
+				add(financing, account, -debit)
+			default:
+				add(workingCapital, account, -debit)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read postings: %w", err)
+	}
+
+	amount := func(value int64) *Amount { return &Amount{Value: value, Currency: Currency(currency)} }
+	items := func(lines map[string]*cashFlowLine, category CashFlowCategory, describe func(*Account) string) ([]*CashFlowItem, int64) {
+		sorted := make([]*cashFlowLine, 0, len(lines))
+		for _, line := range lines {
+			if line.amount != 0 {
+				sorted = append(sorted, line)
+			}
+		}
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].account.Code < sorted[j].account.Code })
+		var total int64
+		result := make([]*CashFlowItem, len(sorted))
+		for i, line := range sorted {
+			result[i] = &CashFlowItem{Description: describe(line.account), Amount: amount(line.amount), Category: category}
+			total += line.amount
+		}
+		return result, total
+	}
+	named := func(account *Account) string { return account.Name }
+
+	recognizedItems, recognizedTotal := items(recognized, CashFlowOperating, func(a *Account) string { return "Recognized from schedules: " + a.Name })
+	nonCashItems, nonCashTotal := items(nonCash, CashFlowOperating, named)
+	cf.NonCashAdjustments = append(recognizedItems, nonCashItems...)
+	var workingCapitalTotal, investingTotal, financingTotal int64
+	cf.WorkingCapitalChanges, workingCapitalTotal = items(workingCapital, CashFlowOperating, func(a *Account) string { return "Change in " + a.Name })
+	cf.InvestingActivities, investingTotal = items(investing, CashFlowInvesting, named)
+	cf.FinancingActivities, financingTotal = items(financing, CashFlowFinancing, named)
+
+	cf.NetIncome = amount(netIncome)
+	cf.OperatingCashFlow = amount(netIncome + recognizedTotal + nonCashTotal + workingCapitalTotal)
+	cf.NetCashFlow = amount(cf.OperatingCashFlow.Value + investingTotal + financingTotal)
+
+	// Reconcile to the cash accounts' balances either side of the period
+	var opening, closing int64
+	for _, id := range options.CashAccountIDs {
+		before, err := rs.cashBalance(id, fromDate.Add(-time.Nanosecond), currency)
+		if err != nil {
+			return nil, err
+		}
+		after, err := rs.cashBalance(id, toDate, currency)
+		if err != nil {
+			return nil, err
+		}
+		opening += before
+		closing += after
+	}
+	cf.BeginningCash = amount(opening)
+	cf.EndingCash = amount(opening + cf.NetCashFlow.Value)
+	cf.CashMovement = amount(closing - opening)
+	cf.ReconciliationDifference = amount(cf.CashMovement.Value - cf.NetCashFlow.Value)
+	return cf, nil
+}
+
+// cashBalance is a cash account's balance on a date in the reporting currency
+func (rs *ReportingService) cashBalance(accountID string, asOf time.Time, currency string) (int64, error) {
+	balance, err := rs.queryAPI.GetAccountBalance(accountID, asOf)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cash balance for %s: %w", accountID, err)
+	}
+	translated, err := rs.translateAmount(balance.Balance, currency, asOf)
+	if err != nil {
+		return 0, fmt.Errorf("failed to translate cash balance for %s: %w", accountID, err)
+	}
+	return translated.Value, nil
+}
+
+// formatIndirectCashFlowStatement formats the operating section as a reconciliation
+// from net income
+func (rs *ReportingService) formatIndirectCashFlowStatement(cf *CashFlowStatement) string {
+	var output strings.Builder
+	symbol := currencySymbol(cf.Currency)
+	currency := Currency(cf.Currency)
+	line := func(description string, value int64) {
+		output.WriteString(fmt.Sprintf("  %-40s %s%10s\n", description, symbol, FormatMinorUnits(value, currency)))
+	}
+	section := func(title string, items []*CashFlowItem) {
+		if len(items) == 0 {
+			return
+		}
+		output.WriteString(fmt.Sprintf("  %s:\n", title))
+		for _, item := range items {
+			line("  "+item.Description, item.Amount.Value)
+		}
+	}
+
+	output.WriteString(fmt.Sprintf("\n%s\n", cf.Name))
+	output.WriteString(fmt.Sprintf("Period: %s to %s\n", cf.FromDate.Format("2006-01-02"), cf.ToDate.Format("2006-01-02")))
+	output.WriteString(fmt.Sprintf("Currency: %s\n", cf.Currency))
+	output.WriteString("==========================================\n")
+
+	output.WriteString("OPERATING ACTIVITIES:\n")
+	line("Net income", cf.NetIncome.Value)
+	section("Adjustments for non-cash items", cf.NonCashAdjustments)
+	section("Changes in working capital", cf.WorkingCapitalChanges)
+	line("Net Cash from Operations", cf.OperatingCashFlow.Value)
+	output.WriteString("\n")
+
+	var investing, financing int64
+	output.WriteString("INVESTING ACTIVITIES:\n")
+	for _, item := range cf.InvestingActivities {
+		line(item.Description, item.Amount.Value)
+		investing += item.Amount.Value
+	}
+	line("Net Cash from Investing", investing)
+	output.WriteString("\n")
+
+	output.WriteString("FINANCING ACTIVITIES:\n")
+	for _, item := range cf.FinancingActivities {
+		line(item.Description, item.Amount.Value)
+		financing += item.Amount.Value
+	}
+	line("Net Cash from Financing", financing)
+	output.WriteString("\n")
+
+	output.WriteString("CASH FLOW SUMMARY:\n")
+	line("Beginning Cash", cf.BeginningCash.Value)
+	line("Net Cash Flow", cf.NetCashFlow.Value)
+	line("Ending Cash", cf.EndingCash.Value)
+	line("Movement in cash accounts", cf.CashMovement.Value)
+	if cf.ReconciliationDifference.Value != 0 {
+		line("Difference (exchange rates)", cf.ReconciliationDifference.Value)
+	}
+	return output.String()
+}
@@ -0,0 +1,119 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndirectCashFlowStatement(t *testing.T) {
+	dbFile := "test_cash_flow_indirect.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	for _, account := range []*Account{
+		{ID: "equipment", Code: "1500", Name: "Equipment", Type: Asset},
+		{ID: "accumulated_depreciation", Code: "1510", Name: "Accumulated Depreciation", Type: Asset},
+		{ID: "notes_payable", Code: "2500", Name: "Notes Payable", Type: Liability},
+		{ID: "owner_equity", Code: "3000", Name: "Owner Equity", Type: Equity},
+	} {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	post := func(date time.Time, debit, credit string, value int64) *Transaction {
+		txn := &Transaction{
+			Description: "Activity",
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	june := func(day int) time.Time { return time.Date(2026, 6, day, 0, 0, 0, 0, time.UTC) }
+
+	post(time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), "cash", "owner_equity", 500000)
+	post(june(2), "cash", "revenue", 100000)
+	post(june(3), "accounts_receivable", "revenue", 40000)
+	post(june(4), "expenses", "accounts_payable", 30000)
+	post(june(5), "equipment", "cash", 120000)
+	post(june(28), "expenses", "accumulated_depreciation", 10000)
+	post(june(10), "cash", "notes_payable", 200000)
+
+	// Cash received in advance, a third of which is recognized as revenue in June
+	advance := post(june(1), "cash", "unearned_revenue", 60000)
+	_, err = engine.CreateAccrualSchedule(advance.ID, &Amount{Value: 60000, Currency: "USD"}, Monthly, 3, june(15), nil, userID)
+	require.NoError(t, err)
+	require.NoError(t, engine.ProcessAccruals(june(30), userID))
+
+	cf, err := engine.GenerateIndirectCashFlowStatement(june(1), june(30), "USD", nil)
+	require.NoError(t, err)
+
+	byDescription := func(items []*CashFlowItem) map[string]int64 {
+		values := make(map[string]int64)
+		for _, item := range items {
+			values[item.Description] = item.Amount.Value
+		}
+		return values
+	}
+
+	t.Run("operating cash starts from net income", func(t *testing.T) {
+		assert.Equal(t, CashFlowIndirect, cf.Method)
+		assert.Equal(t, int64(100000+40000+20000-30000-10000), cf.NetIncome.Value)
+		assert.Equal(t, map[string]int64{
+			"Recognized from schedules: Unearned Revenue": -20000,
+			"Accumulated Depreciation":                    10000,
+		}, byDescription(cf.NonCashAdjustments))
+		assert.Equal(t, map[string]int64{
+			"Change in Accounts Receivable": -40000,
+			"Change in Accounts Payable":    30000,
+			"Change in Unearned Revenue":    60000,
+		}, byDescription(cf.WorkingCapitalChanges))
+		assert.Equal(t, int64(160000), cf.OperatingCashFlow.Value)
+	})
+
+	t.Run("investing and financing", func(t *testing.T) {
+		assert.Equal(t, map[string]int64{"Equipment": -120000}, byDescription(cf.InvestingActivities))
+		assert.Equal(t, map[string]int64{"Notes Payable": 200000}, byDescription(cf.FinancingActivities))
+	})
+
+	t.Run("reconciles to the cash account", func(t *testing.T) {
+		assert.Equal(t, int64(240000), cf.NetCashFlow.Value)
+		assert.Equal(t, int64(240000), cf.CashMovement.Value)
+		assert.Zero(t, cf.ReconciliationDifference.Value)
+		assert.Equal(t, int64(500000), cf.BeginningCash.Value)
+		assert.Equal(t, int64(740000), cf.EndingCash.Value)
+	})
+
+	t.Run("formatted as a reconciliation", func(t *testing.T) {
+		text := engine.FormatCashFlowStatement(cf)
+		assert.Contains(t, text, "Cash Flow Statement (Indirect Method)")
+		assert.Contains(t, text, "Net income")
+		assert.Contains(t, text, "Adjustments for non-cash items:")
+		assert.Contains(t, text, "Changes in working capital:")
+		assert.NotContains(t, text, "Difference")
+	})
+
+	t.Run("direct method is unchanged", func(t *testing.T) {
+		direct, err := engine.GenerateCashFlowStatement(june(1), june(30), "USD")
+		require.NoError(t, err)
+		assert.Equal(t, CashFlowDirect, direct.Method)
+		assert.Nil(t, direct.NetIncome)
+	})
+
+	t.Run("cash accounts must exist", func(t *testing.T) {
+		_, err := engine.GenerateIndirectCashFlowStatement(june(1), june(30), "USD", &IndirectCashFlowOptions{CashAccountIDs: []string{"petty_cash"}})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,440 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// On-Chain Reconciliation Structures
+// ----------------------------------------------------------------------------
+
+// ChainTransfer is a token transfer observed on-chain. Amounts are in the minor
+// units of the ledger currency the token is booked in.
+type ChainTransfer struct {
+	TxHash      string    `json:"tx_hash"`
+	FromAddress string    `json:"from_address"`
+	ToAddress   string    `json:"to_address"`
+	Amount      int64     `json:"amount"`
+	BlockNumber uint64    `json:"block_number"`
+	BlockTime   time.Time `json:"block_time"`
+}
+
+// ChainDataProvider looks up token balances and transfers by address, e.g. backed by
+// a node RPC endpoint or a block explorer API
+type ChainDataProvider interface {
+	// GetBalance returns the token balance held by an address at a point in time
+	GetBalance(chain, address, token string, asOf time.Time) (int64, error)
+	// GetTransfers returns token transfers into or out of an address within a time range
+	GetTransfers(chain, address, token string, from, to time.Time) ([]*ChainTransfer, error)
+}
+
+// ChainWallet links a ledger crypto account to the on-chain address holding the tokens
+type ChainWallet struct {
+	AccountID string `json:"account_id"`
+	Chain     string `json:"chain"`   // e.g. "ethereum", "solana"
+	Address   string `json:"address"` // wallet address
+	Token     string `json:"token"`   // token contract or symbol, e.g. "USDC"
+}
+
+// ChainDiscrepancyType classifies a difference between the ledger and the chain
+type ChainDiscrepancyType string
+
+const (
+	DiscrepancyBalanceMismatch ChainDiscrepancyType = "BALANCE_MISMATCH" // closing balances differ
+	DiscrepancyUnknownTransfer ChainDiscrepancyType = "UNKNOWN_TRANSFER" // on-chain transfer with no ledger entry
+	DiscrepancyNotOnChain      ChainDiscrepancyType = "NOT_ON_CHAIN"     // ledger entry with no on-chain transfer
+	DiscrepancyAmountMismatch  ChainDiscrepancyType = "AMOUNT_MISMATCH"  // same tx hash, different amount
+)
+
+// ChainDiscrepancy is a single reconciling item raised for AML review
+type ChainDiscrepancy struct {
+	Type        ChainDiscrepancyType `json:"type"`
+	Description string               `json:"description"`
+	EntryID     string               `json:"entry_id,omitempty"`
+	Transfer    *ChainTransfer       `json:"transfer,omitempty"`
+	Amount      int64                `json:"amount"`
+	AlertID     string               `json:"alert_id,omitempty"`
+}
+
+// ChainReconciliationStatus is the outcome of a reconciliation run
+type ChainReconciliationStatus string
+
+const (
+	ChainReconciled    ChainReconciliationStatus = "RECONCILED"
+	ChainDiscrepancies ChainReconciliationStatus = "DISCREPANCIES"
+)
+
+// ChainReconciliation is the result of reconciling a wallet's ledger account to the chain
+type ChainReconciliation struct {
+	ID            string                    `json:"id"`
+	AccountID     string                    `json:"account_id"`
+	Chain         string                    `json:"chain"`
+	Address       string                    `json:"address"`
+	Token         string                    `json:"token"`
+	PeriodStart   time.Time                 `json:"period_start"`
+	PeriodEnd     time.Time                 `json:"period_end"`
+	LedgerBalance *Amount                   `json:"ledger_balance"`
+	ChainBalance  *Amount                   `json:"chain_balance"`
+	Difference    *Amount                   `json:"difference"` // chain minus ledger
+	MatchedCount  int                       `json:"matched_count"`
+	Discrepancies []*ChainDiscrepancy       `json:"discrepancies"`
+	Status        ChainReconciliationStatus `json:"status"`
+	CreatedBy     string                    `json:"created_by"`
+	CreatedAt     time.Time                 `json:"created_at"`
+}
+
+// chainMatchWindow is how far a ledger date may drift from the block time when
+// matching an entry that carries no transaction hash
+const chainMatchWindow = 24 * time.Hour
+
+// ----------------------------------------------------------------------------
+// On-Chain Reconciliation Service
+// ----------------------------------------------------------------------------
+
+// ChainReconciliationService reconciles ledger crypto accounts to on-chain data
+type ChainReconciliationService struct {
+	storage    *Storage
+	eventStore *EventStore
+	queryAPI   *QueryAPI
+	amlService *AMLService
+	provider   ChainDataProvider
+	wallets    map[string]*ChainWallet // by account ID
+	mutex      sync.RWMutex
+}
+
+// NewChainReconciliationService creates a new on-chain reconciliation service
+func NewChainReconciliationService(storage *Storage, eventStore *EventStore, queryAPI *QueryAPI, amlService *AMLService) *ChainReconciliationService {
+	return &ChainReconciliationService{
+		storage:    storage,
+		eventStore: eventStore,
+		queryAPI:   queryAPI,
+		amlService: amlService,
+		wallets:    make(map[string]*ChainWallet),
+	}
+}
+
+// SetProvider sets the source of on-chain balances and transfers
+func (cs *ChainReconciliationService) SetProvider(provider ChainDataProvider) {
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.provider = provider
+}
+
+// RegisterWallet links a ledger account to an on-chain address
+func (cs *ChainReconciliationService) RegisterWallet(wallet *ChainWallet) error {
+	if wallet.Chain == "" || wallet.Address == "" || wallet.Token == "" {
+		return fmt.Errorf("chain, address and token are required")
+	}
+	account, err := cs.storage.GetAccount(wallet.AccountID)
+	if err != nil {
+		return fmt.Errorf("invalid wallet account: %w", err)
+	}
+	if account.Type != Asset {
+		return fmt.Errorf("wallet account must be an asset account")
+	}
+
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	cs.wallets[wallet.AccountID] = wallet
+	return nil
+}
+
+// Reconcile compares the account's ledger activity and closing balance for the period
+// with the chain. Each discrepancy, including transfers the ledger does not know
+// about, is raised as an AML alert for review.
+func (cs *ChainReconciliationService) Reconcile(accountID string, periodStart, periodEnd time.Time, userID string) (*ChainReconciliation, error) {
+	cs.mutex.RLock()
+	provider := cs.provider
+	wallet := cs.wallets[accountID]
+	cs.mutex.RUnlock()
+
+	if provider == nil {
+		return nil, fmt.Errorf("no chain data provider configured")
+	}
+	if wallet == nil {
+		return nil, fmt.Errorf("no wallet registered for account %s", accountID)
+	}
+
+	ledgerBalance, err := cs.queryAPI.GetAccountBalance(accountID, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger balance: %w", err)
+	}
+	chainBalance, err := provider.GetBalance(wallet.Chain, wallet.Address, wallet.Token, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get on-chain balance: %w", err)
+	}
+	transfers, err := provider.GetTransfers(wallet.Chain, wallet.Address, wallet.Token, periodStart, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get on-chain transfers: %w", err)
+	}
+	sort.Slice(transfers, func(i, j int) bool {
+		return transfers[i].BlockTime.Before(transfers[j].BlockTime)
+	})
+
+	entries, err := cs.ledgerEntries(accountID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := ledgerBalance.Balance.Currency
+	recon := &ChainReconciliation{
+		ID:            newID(),
+		AccountID:     accountID,
+		Chain:         wallet.Chain,
+		Address:       wallet.Address,
+		Token:         wallet.Token,
+		PeriodStart:   periodStart,
+		PeriodEnd:     periodEnd,
+		LedgerBalance: ledgerBalance.Balance,
+		ChainBalance:  &Amount{Value: chainBalance, Currency: currency},
+		Difference:    &Amount{Value: chainBalance - ledgerBalance.Balance.Value, Currency: currency},
+		Status:        ChainReconciled,
+		CreatedBy:     userID,
+		CreatedAt:     time.Now(),
+	}
+
+	matched := make(map[string]bool)
+	for _, transfer := range transfers {
+		flow := transfer.Amount
+		if !strings.EqualFold(transfer.ToAddress, wallet.Address) {
+			flow = -flow
+		}
+
+		// Entries tagged with the transaction hash are matched first
+		if entry := findEntryByHash(entries, matched, transfer.TxHash); entry != nil {
+			matched[entry.entry.ID] = true
+			if entry.flow != flow {
+				recon.Discrepancies = append(recon.Discrepancies, &ChainDiscrepancy{
+					Type:        DiscrepancyAmountMismatch,
+					Description: fmt.Sprintf("Transfer %s moved %d on-chain but %d was booked", transfer.TxHash, flow, entry.flow),
+					EntryID:     entry.entry.ID,
+					Transfer:    transfer,
+					Amount:      flow - entry.flow,
+				})
+				continue
+			}
+			recon.MatchedCount++
+			continue
+		}
+
+		// Otherwise fall back to an untagged entry of the same amount close to the block time
+		if entry := findEntryByAmount(entries, matched, flow, transfer.BlockTime); entry != nil {
+			matched[entry.entry.ID] = true
+			recon.MatchedCount++
+			continue
+		}
+
+		counterparty := transfer.FromAddress
+		if flow < 0 {
+			counterparty = transfer.ToAddress
+		}
+		recon.Discrepancies = append(recon.Discrepancies, &ChainDiscrepancy{
+			Type:        DiscrepancyUnknownTransfer,
+			Description: fmt.Sprintf("On-chain transfer %s with %s is not recorded in the ledger", transfer.TxHash, counterparty),
+			Transfer:    transfer,
+			Amount:      flow,
+		})
+	}
+
+	for _, entry := range entries {
+		if matched[entry.entry.ID] {
+			continue
+		}
+		recon.Discrepancies = append(recon.Discrepancies, &ChainDiscrepancy{
+			Type:        DiscrepancyNotOnChain,
+			Description: fmt.Sprintf("Ledger entry dated %s has no matching on-chain transfer", entry.date.Format("2006-01-02")),
+			EntryID:     entry.entry.ID,
+			Amount:      entry.flow,
+		})
+	}
+
+	if recon.Difference.Value != 0 {
+		recon.Discrepancies = append(recon.Discrepancies, &ChainDiscrepancy{
+			Type: DiscrepancyBalanceMismatch,
+			Description: fmt.Sprintf("On-chain balance %s differs from ledger balance %s",
+				recon.ChainBalance.Format(), recon.LedgerBalance.Format()),
+			Amount: recon.Difference.Value,
+		})
+	}
+
+	if len(recon.Discrepancies) > 0 {
+		recon.Status = ChainDiscrepancies
+		if err := cs.flagForReview(recon); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = cs.eventStore.CreateEvent(EventReconcileChain, recon, periodEnd, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chain reconciliation event: %w", err)
+	}
+	if err := cs.storage.SaveChainReconciliation(recon); err != nil {
+		return nil, fmt.Errorf("failed to save chain reconciliation: %w", err)
+	}
+
+	return recon, nil
+}
+
+// GetReconciliations returns an account's reconciliation runs, most recent first
+func (cs *ChainReconciliationService) GetReconciliations(accountID string) ([]*ChainReconciliation, error) {
+	all, err := cs.storage.GetAllChainReconciliations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain reconciliations: %w", err)
+	}
+
+	var result []*ChainReconciliation
+	for _, recon := range all {
+		if accountID == "" || recon.AccountID == accountID {
+			result = append(result, recon)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// chainLedgerEntry is a posted entry with its signed effect on the wallet balance
+type chainLedgerEntry struct {
+	entry  *Entry
+	date   time.Time
+	txHash string
+	flow   int64 // positive for receipts into the wallet
+}
+
+// ledgerEntries returns the account's posted entries dated within the period
+func (cs *ChainReconciliationService) ledgerEntries(accountID string, periodStart, periodEnd time.Time) ([]*chainLedgerEntry, error) {
+	entries, err := cs.storage.GetEntriesByAccount(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger entries: %w", err)
+	}
+
+	var result []*chainLedgerEntry
+	for _, entry := range entries {
+		txn, err := cs.storage.GetTransaction(entry.TransactionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction: %w", err)
+		}
+		if txn.ValidTime.Before(periodStart) || txn.ValidTime.After(periodEnd) {
+			continue
+		}
+
+		flow := entry.Amount.Value
+		if entry.Type == Credit {
+			flow = -flow
+		}
+
+		// A hash on the entry identifies the transfer more precisely than the
+		// transaction reference, which may cover several transfers
+		txHash := txn.SourceRef
+		for _, dim := range entry.Dimensions {
+			if dim.Key == DimChainTxHash {
+				txHash = dim.Value
+			}
+		}
+
+		result = append(result, &chainLedgerEntry{entry: entry, date: txn.ValidTime, txHash: txHash, flow: flow})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].date.Before(result[j].date)
+	})
+	return result, nil
+}
+
+// findEntryByHash returns the unmatched entry booked against a transaction hash
+func findEntryByHash(entries []*chainLedgerEntry, matched map[string]bool, txHash string) *chainLedgerEntry {
+	if txHash == "" {
+		return nil
+	}
+	for _, entry := range entries {
+		if !matched[entry.entry.ID] && strings.EqualFold(entry.txHash, txHash) {
+			return entry
+		}
+	}
+	return nil
+}
+
+// findEntryByAmount returns the closest unmatched entry of the same signed amount
+// within the match window of the block time
+func findEntryByAmount(entries []*chainLedgerEntry, matched map[string]bool, flow int64, blockTime time.Time) *chainLedgerEntry {
+	var best *chainLedgerEntry
+	var bestGap time.Duration
+	for _, entry := range entries {
+		if matched[entry.entry.ID] || entry.flow != flow {
+			continue
+		}
+		gap := entry.date.Sub(blockTime)
+		if gap < 0 {
+			gap = -gap
+		}
+		if gap > chainMatchWindow {
+			continue
+		}
+		if best == nil || gap < bestGap {
+			best = entry
+			bestGap = gap
+		}
+	}
+	return best
+}
+
+// flagForReview raises an AML alert for each discrepancy in the reconciliation
+func (cs *ChainReconciliationService) flagForReview(recon *ChainReconciliation) error {
+	for _, discrepancy := range recon.Discrepancies {
+		riskLevel := RiskMedium
+		if discrepancy.Type == DiscrepancyUnknownTransfer || discrepancy.Type == DiscrepancyBalanceMismatch {
+			riskLevel = RiskHigh
+		}
+
+		evidence := []AMLEvidence{{
+			Type:        "EXTERNAL",
+			Description: discrepancy.Description,
+			Value:       string(discrepancy.Type),
+			Source:      fmt.Sprintf("%s:%s", recon.Chain, recon.Address),
+			Confidence:  1.0,
+			CollectedAt: recon.CreatedAt,
+		}}
+		if discrepancy.Transfer != nil {
+			evidence = append(evidence, AMLEvidence{
+				Type:        "TRANSACTION",
+				Description: "On-chain transfer",
+				Value:       discrepancy.Transfer.TxHash,
+				Source:      recon.Chain,
+				Confidence:  1.0,
+				CollectedAt: recon.CreatedAt,
+			})
+		}
+
+		alert := &AMLAlert{
+			ID:          newID(),
+			RuleType:    RuleCryptocurrency,
+			RiskLevel:   riskLevel,
+			Title:       fmt.Sprintf("On-chain reconciliation: %s", discrepancy.Type),
+			Description: discrepancy.Description,
+			EntityID:    recon.AccountID,
+			EntityType:  "ACCOUNT",
+			AccountIDs:  []string{recon.AccountID},
+			Amount:      &Amount{Value: abs64(discrepancy.Amount), Currency: recon.LedgerBalance.Currency},
+			Currency:    string(recon.LedgerBalance.Currency),
+			DetectedAt:  recon.CreatedAt,
+			Status:      "OPEN",
+			Evidence:    evidence,
+			CreatedAt:   recon.CreatedAt,
+			UpdatedAt:   recon.CreatedAt,
+		}
+		if discrepancy.Transfer != nil {
+			alert.TransactionIDs = []string{discrepancy.Transfer.TxHash}
+		}
+
+		if err := cs.amlService.RaiseAlert(alert); err != nil {
+			return fmt.Errorf("failed to raise AML alert: %w", err)
+		}
+		discrepancy.AlertID = alert.ID
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubChainProvider serves a fixed balance and transfer list
+type stubChainProvider struct {
+	balance   int64
+	transfers []*ChainTransfer
+}
+
+func (p *stubChainProvider) GetBalance(chain, address, token string, asOf time.Time) (int64, error) {
+	return p.balance, nil
+}
+
+func (p *stubChainProvider) GetTransfers(chain, address, token string, from, to time.Time) ([]*ChainTransfer, error) {
+	return p.transfers, nil
+}
+
+func TestChainReconciliation(t *testing.T) {
+	// Setup
+	dbFile := "test_chain_reconciliation.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "treasury"
+	wallet := "0xTreasuryWallet"
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	accounts := []*Account{
+		{ID: "usdc_wallet", Code: "1050", Name: "USDC Treasury Wallet", Type: Asset, Currency: "USDC"},
+		{ID: "usdc_capital", Code: "3000", Name: "Share Capital", Type: Equity, Currency: "USDC"},
+		{ID: "usdc_fees", Code: "6100", Name: "Vendor Fees", Type: Expense, Currency: "USDC"},
+	}
+	for _, account := range accounts {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	txns := []*Transaction{
+		{
+			Description: "Capital funded on-chain",
+			ValidTime:   day(5),
+			SourceRef:   "0xaaa",
+			Entries: []Entry{
+				{AccountID: "usdc_wallet", Type: Debit, Amount: Amount{Value: 1000000, Currency: "USDC"}},
+				{AccountID: "usdc_capital", Type: Credit, Amount: Amount{Value: 1000000, Currency: "USDC"}},
+			},
+		},
+		{
+			Description: "Vendor paid in USDC",
+			ValidTime:   day(10),
+			Entries: []Entry{
+				{AccountID: "usdc_fees", Type: Debit, Amount: Amount{Value: 200000, Currency: "USDC"}},
+				{AccountID: "usdc_wallet", Type: Credit, Amount: Amount{Value: 200000, Currency: "USDC"}},
+			},
+		},
+		{
+			Description: "Expected receipt never sent",
+			ValidTime:   day(20),
+			Entries: []Entry{
+				{AccountID: "usdc_wallet", Type: Debit, Amount: Amount{Value: 50000, Currency: "USDC"}},
+				{AccountID: "usdc_capital", Type: Credit, Amount: Amount{Value: 50000, Currency: "USDC"}},
+			},
+		},
+	}
+	for _, txn := range txns {
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+
+	engine.SetChainDataProvider(&stubChainProvider{
+		balance: 875000,
+		transfers: []*ChainTransfer{
+			{TxHash: "0xAAA", FromAddress: "0xInvestor", ToAddress: wallet, Amount: 1000000, BlockTime: day(5)},
+			{TxHash: "0xbbb", FromAddress: "0xtreasurywallet", ToAddress: "0xVendor", Amount: 200000, BlockTime: day(10).Add(12 * time.Hour)},
+			{TxHash: "0xccc", FromAddress: "0xUnknown", ToAddress: wallet, Amount: 75000, BlockTime: day(15)},
+		},
+	})
+
+	_, err = engine.ReconcileChainWallet("usdc_wallet", start, end, userID)
+	assert.Error(t, err, "wallet must be registered first")
+
+	require.NoError(t, engine.RegisterChainWallet(&ChainWallet{
+		AccountID: "usdc_wallet", Chain: "ethereum", Address: wallet, Token: "USDC",
+	}))
+
+	recon, err := engine.ReconcileChainWallet("usdc_wallet", start, end, userID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, recon.MatchedCount)
+	assert.Equal(t, ChainDiscrepancies, recon.Status)
+	assert.Equal(t, int64(850000), recon.LedgerBalance.Value)
+	assert.Equal(t, int64(25000), recon.Difference.Value)
+
+	byType := make(map[ChainDiscrepancyType]*ChainDiscrepancy)
+	for _, d := range recon.Discrepancies {
+		byType[d.Type] = d
+		assert.NotEmpty(t, d.AlertID)
+	}
+	require.Len(t, byType, 3)
+	assert.Equal(t, "0xccc", byType[DiscrepancyUnknownTransfer].Transfer.TxHash)
+	assert.Equal(t, int64(50000), byType[DiscrepancyNotOnChain].Amount)
+	assert.Equal(t, int64(25000), byType[DiscrepancyBalanceMismatch].Amount)
+
+	alerts, err := engine.GetAMLService().GetAMLAlerts("OPEN", RiskHigh, 0)
+	require.NoError(t, err)
+	assert.Len(t, alerts, 2)
+
+	history, err := engine.GetChainReconciliationService().GetReconciliations("usdc_wallet")
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	assert.Len(t, history[0].Discrepancies, 3)
+}
@@ -0,0 +1,388 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// AccountRollupBalance is a trial balance line with its descendants' balances rolled in
+type AccountRollupBalance struct {
+	AccountID      string      `json:"account_id"`
+	ParentID       string      `json:"parent_id,omitempty"`
+	Code           string      `json:"code"`
+	AccountName    string      `json:"account_name"`
+	AccountType    AccountType `json:"account_type"`
+	Level          int         `json:"level"`            // 0 for top-level accounts
+	Active         bool        `json:"active"`           // false once deactivated
+	Balance        *Amount     `json:"balance"`          // postings made to the account itself
+	RolledUpAmount *Amount     `json:"rolled_up_amount"` // own balance plus all descendants
+	AsOfDate       time.Time   `json:"as_of_date"`
+}
+
+// ChartOfAccountsService maintains the account hierarchy, numbering and activation
+type ChartOfAccountsService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+	numbering     *AccountNumberingScheme // enforced on new codes when set
+	mutex         sync.Mutex
+}
+
+// NewChartOfAccountsService creates a new chart of accounts service
+func NewChartOfAccountsService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *ChartOfAccountsService {
+	return &ChartOfAccountsService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+	}
+}
+
+// PrepareAccount validates a new account's place in the hierarchy and assigns it the
+// next free code in its parent's or type's numbering range when none is given. Under
+// a configured numbering scheme a given code must fit the account type's range.
+func (cs *ChartOfAccountsService) PrepareAccount(account *Account) error {
+	accounts, err := cs.storage.GetAllAccounts()
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	if err := cs.validateParent(account, account.ParentID, accounts); err != nil {
+		return err
+	}
+	if account.LedgerID != "" {
+		if _, err := cs.storage.GetLedger(account.LedgerID); err != nil {
+			return fmt.Errorf("invalid ledger: %w", err)
+		}
+	}
+
+	scheme, enforced := cs.numberingScheme()
+	if account.Code == "" {
+		code, err := cs.nextAccountCode(account, scheme, enforced, accounts)
+		if err != nil {
+			return err
+		}
+		account.Code = code
+	} else if enforced {
+		if err := scheme.CheckCode(account.Code, account.Type); err != nil {
+			return err
+		}
+	}
+	for _, existing := range accounts {
+		if existing.ID != account.ID && existing.Code == account.Code {
+			return fmt.Errorf("account code %s is already used by account %s", account.Code, existing.ID)
+		}
+	}
+	return nil
+}
+
+// MoveAccount re-parents an account; an empty parentID makes it a top-level account
+func (cs *ChartOfAccountsService) MoveAccount(accountID, parentID, userID string) (*Account, error) {
+	account, err := cs.storage.GetAccount(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	accounts, err := cs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	if err := cs.validateParent(account, parentID, accounts); err != nil {
+		return nil, err
+	}
+
+	account.ParentID = parentID
+	if err := cs.saveAccount(account, userID); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// DeactivateAccount closes an account to new postings. The account must carry no
+// balance and have no active children.
+func (cs *ChartOfAccountsService) DeactivateAccount(accountID, userID string) (*Account, error) {
+	account, err := cs.storage.GetAccount(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if account.ClosedAt != nil {
+		return nil, fmt.Errorf("account %s is already inactive", accountID)
+	}
+
+	accounts, err := cs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	for _, child := range accounts {
+		if child.ParentID == account.ID && child.ClosedAt == nil {
+			return nil, fmt.Errorf("account %s has active child account %s", accountID, child.ID)
+		}
+	}
+
+	now := time.Now()
+	balance, err := cs.postingEngine.CalculateAccountBalance(account.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate balance for account %s: %w", account.ID, err)
+	}
+	if balance.Value != 0 {
+		return nil, fmt.Errorf("account %s still has a balance of %d", accountID, balance.Value)
+	}
+
+	account.ClosedAt = &now
+	if err := cs.saveAccount(account, userID); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// ActivateAccount reopens a deactivated account. Its parent must be active.
+func (cs *ChartOfAccountsService) ActivateAccount(accountID, userID string) (*Account, error) {
+	account, err := cs.storage.GetAccount(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if account.ClosedAt == nil {
+		return nil, fmt.Errorf("account %s is already active", accountID)
+	}
+	if account.ParentID != "" {
+		parent, err := cs.storage.GetAccount(account.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent account: %w", err)
+		}
+		if parent.ClosedAt != nil {
+			return nil, fmt.Errorf("parent account %s is inactive", parent.ID)
+		}
+	}
+
+	account.ClosedAt = nil
+	if err := cs.saveAccount(account, userID); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// validateParent checks that parentID exists, is active, has the same type as the
+// account and is not the account itself or one of its descendants
+func (cs *ChartOfAccountsService) validateParent(account *Account, parentID string, accounts []*Account) error {
+	if parentID == "" {
+		return nil
+	}
+
+	byID := make(map[string]*Account, len(accounts))
+	for _, existing := range accounts {
+		byID[existing.ID] = existing
+	}
+
+	parent, ok := byID[parentID]
+	if !ok {
+		return fmt.Errorf("parent account %s does not exist", parentID)
+	}
+	if parent.Type != account.Type {
+		return fmt.Errorf("parent account %s is %s but account is %s", parentID, parent.Type, account.Type)
+	}
+	if parent.ClosedAt != nil {
+		return fmt.Errorf("parent account %s is inactive", parentID)
+	}
+
+	// Walk up from the new parent; reaching the account means a cycle
+	for current := parent; current != nil; current = byID[current.ParentID] {
+		if current.ID == account.ID {
+			return fmt.Errorf("account %s cannot be placed under its own descendant %s", account.ID, parentID)
+		}
+	}
+	return nil
+}
+
+// nextAccountCode numbers a child in steps after its parent's code and a top-level
+// account from its type's range, skipping codes already in use. Codes only have to
+// stay inside the range when the scheme is enforced.
+func (cs *ChartOfAccountsService) nextAccountCode(account *Account, scheme *AccountNumberingScheme, enforced bool, accounts []*Account) (string, error) {
+	used := make(map[string]bool, len(accounts))
+	for _, existing := range accounts {
+		used[existing.Code] = true
+	}
+
+	r, ok := scheme.codeRange(account.Type)
+	if !ok {
+		return "", fmt.Errorf("numbering scheme %s has no code range for %s accounts", scheme.Name, account.Type)
+	}
+	start, step := r.From, int64(1)
+	if account.ParentID != "" {
+		parent, err := cs.storage.GetAccount(account.ParentID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get parent account: %w", err)
+		}
+		parentCode, err := strconv.ParseInt(parent.Code, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("parent account %s has non-numeric code %s", parent.ID, parent.Code)
+		}
+		start, step = parentCode+scheme.childStep(), scheme.childStep()
+	}
+
+	limit := int64(math.MaxInt64 - step)
+	if enforced {
+		limit = r.To
+	}
+	for code := start; code <= limit; code += step {
+		candidate := strconv.FormatInt(code, 10)
+		if !used[candidate] {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no free account code left in the %d-%d range for %s accounts", r.From, r.To, account.Type)
+}
+
+// saveAccount records an account update and persists it
+func (cs *ChartOfAccountsService) saveAccount(account *Account, userID string) error {
+	_, err := cs.eventStore.CreateEvent(
+		EventUpdateAccount,
+		AccountUpdatedEvent{Account: account},
+		time.Now(),
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create account update event: %w", err)
+	}
+	return cs.storage.SaveAccount(account)
+}
+
+// GetRollupTrialBalance generates a trial balance in hierarchy order where each line
+// also carries the total of its own and all descendant balances. Account balances are
+// calculated in parallel, bounded by the configured concurrency.
+func (qa *QueryAPI) GetRollupTrialBalance(asOfDate time.Time, accountTypes []AccountType) ([]*AccountRollupBalance, error) {
+	roots, err := qa.GetAccountHierarchy()
+	if err != nil {
+		return nil, err
+	}
+
+	// Lay the lines out in hierarchy order, remembering each line's parent line
+	var results []*AccountRollupBalance
+	var parents []int
+	var layout func(node *AccountNode, level, parent int)
+	layout = func(node *AccountNode, level, parent int) {
+		account := node.Account
+		results = append(results, &AccountRollupBalance{
+			AccountID:   account.ID,
+			ParentID:    account.ParentID,
+			Code:        account.Code,
+			AccountName: account.Name,
+			AccountType: account.Type,
+			Level:       level,
+			Active:      account.ClosedAt == nil,
+			AsOfDate:    asOfDate,
+		})
+		parents = append(parents, parent)
+		index := len(results) - 1
+		for _, child := range node.Children {
+			layout(child, level+1, index)
+		}
+	}
+	for _, root := range roots {
+		if len(accountTypes) > 0 && !containsAccountType(accountTypes, root.Account.Type) {
+			continue
+		}
+		layout(root, 0, -1)
+	}
+
+	err = forEachParallel(len(results), qa.workers(), func(i int) error {
+		line := results[i]
+		balance, err := qa.postingEngine.CalculateAccountBalance(line.AccountID, asOfDate)
+		if err != nil {
+			return fmt.Errorf("failed to calculate balance for account %s: %w", line.AccountID, err)
+		}
+		line.Balance = balance
+		line.RolledUpAmount = &Amount{Value: balance.Value, Currency: balance.Currency}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Children always follow their parent, so walking backwards rolls each subtree up
+	// before its parent is added to its own parent
+	for i := len(results) - 1; i >= 0; i-- {
+		if parent := parents[i]; parent >= 0 {
+			results[parent].RolledUpAmount.Value += results[i].RolledUpAmount.Value
+		}
+	}
+	return results, nil
+}
+
+// rollupLineItems nests account line items under their parent accounts. A parent becomes
+// a subtotal of its children; postings made directly to it are shown as a child line.
+func (rs *ReportingService) rollupLineItems(items []*FinancialLineItem, currency string) ([]*FinancialLineItem, error) {
+	accounts, err := rs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	parents := make(map[string]string, len(accounts))
+	codes := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		parents[account.ID] = account.ParentID
+		codes[account.ID] = account.Code
+	}
+
+	byID := make(map[string]*FinancialLineItem, len(items))
+	children := make(map[string][]*FinancialLineItem)
+	for _, item := range items {
+		byID[item.AccountID] = item
+	}
+
+	var roots []*FinancialLineItem
+	for _, item := range items {
+		parentID := parents[item.AccountID]
+		if _, ok := byID[parentID]; parentID != "" && ok {
+			children[parentID] = append(children[parentID], item)
+		} else {
+			roots = append(roots, item)
+		}
+	}
+
+	var build func(item *FinancialLineItem, level int) *FinancialLineItem
+	build = func(item *FinancialLineItem, level int) *FinancialLineItem {
+		item.Level = level
+		kids := children[item.AccountID]
+		if len(kids) == 0 {
+			return item
+		}
+		sort.Slice(kids, func(i, j int) bool {
+			return codes[kids[i].AccountID] < codes[kids[j].AccountID]
+		})
+
+		total := &Amount{Value: item.Amount.Value, Currency: Currency(currency)}
+		var nested []*FinancialLineItem
+		if item.Amount.Value != 0 {
+			nested = append(nested, &FinancialLineItem{
+				AccountID:   item.AccountID,
+				AccountName: item.AccountName + " (direct)",
+				AccountType: item.AccountType,
+				Amount:      item.Amount,
+				Level:       level + 1,
+			})
+		}
+		for _, kid := range kids {
+			child := build(kid, level+1)
+			total.Value += child.Amount.Value
+			nested = append(nested, child)
+		}
+
+		return &FinancialLineItem{
+			AccountID:   item.AccountID,
+			AccountName: item.AccountName,
+			AccountType: item.AccountType,
+			Amount:      total,
+			Level:       level,
+			IsSubtotal:  true,
+			Children:    nested,
+		}
+	}
+
+	result := make([]*FinancialLineItem, 0, len(roots))
+	for _, root := range roots {
+		result = append(result, build(root, 1))
+	}
+	return result, nil
+}
@@ -0,0 +1,157 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartOfAccountsHierarchy(t *testing.T) {
+	dbFile := "test_chart_of_accounts.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	opex := &Account{ID: "opex", Code: "6000", Name: "Operating Expenses Group", Type: Expense, Currency: "USD"}
+	require.NoError(t, engine.CreateAccount(opex, userID))
+	rent := &Account{ID: "rent", ParentID: "opex", Name: "Rent", Type: Expense, Currency: "USD"}
+	require.NoError(t, engine.CreateAccount(rent, userID))
+	travel := &Account{ID: "travel", ParentID: "opex", Name: "Travel", Type: Expense, Currency: "USD"}
+	require.NoError(t, engine.CreateAccount(travel, userID))
+	flights := &Account{ID: "flights", ParentID: "travel", Name: "Flights", Type: Expense, Currency: "USD"}
+	require.NoError(t, engine.CreateAccount(flights, userID))
+
+	post := func(accountID string, amount int64) {
+		txn := &Transaction{
+			Description: "Expense " + accountID,
+			ValidTime:   time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: accountID, Type: Debit, Amount: Amount{Value: amount, Currency: "USD"}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: amount, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	post("rent", 100000)
+	post("travel", 5000)
+	post("flights", 20000)
+
+	asOf := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("assigns codes within the parent's range", func(t *testing.T) {
+		assert.Equal(t, "6001", rent.Code)
+		assert.Equal(t, "6002", travel.Code)
+		assert.Equal(t, "6003", flights.Code)
+
+		auto := &Account{ID: "prepaid", Name: "Prepaid Expenses", Type: Asset}
+		require.NoError(t, engine.CreateAccount(auto, userID))
+		assert.Equal(t, "1000", auto.Code)
+	})
+
+	t.Run("rejects invalid hierarchy and duplicate codes", func(t *testing.T) {
+		err := engine.CreateAccount(&Account{ID: "dup", Code: "1001", Name: "Duplicate", Type: Asset}, userID)
+		assert.ErrorContains(t, err, "already used")
+
+		err = engine.CreateAccount(&Account{ID: "orphan", ParentID: "missing", Name: "Orphan", Type: Expense}, userID)
+		assert.ErrorContains(t, err, "does not exist")
+
+		err = engine.CreateAccount(&Account{ID: "mixed", ParentID: "opex", Name: "Mixed", Type: Asset}, userID)
+		assert.ErrorContains(t, err, "EXPENSE")
+
+		_, err = engine.MoveAccount("opex", "flights", userID)
+		assert.ErrorContains(t, err, "descendant")
+	})
+
+	t.Run("builds the account tree", func(t *testing.T) {
+		roots, err := engine.GetAccountHierarchy()
+		require.NoError(t, err)
+
+		var opexNode *AccountNode
+		for _, root := range roots {
+			if root.Account.ID == "opex" {
+				opexNode = root
+			}
+		}
+		require.NotNil(t, opexNode)
+		require.Len(t, opexNode.Children, 2)
+		assert.Equal(t, "rent", opexNode.Children[0].Account.ID)
+		require.Len(t, opexNode.Children[1].Children, 1)
+		assert.Equal(t, "flights", opexNode.Children[1].Children[0].Account.ID)
+	})
+
+	t.Run("rolls child balances into parents in the trial balance", func(t *testing.T) {
+		lines, err := engine.GetRollupTrialBalance(asOf, []AccountType{Expense})
+		require.NoError(t, err)
+
+		byID := make(map[string]*AccountRollupBalance)
+		for _, line := range lines {
+			byID[line.AccountID] = line
+		}
+		assert.Equal(t, int64(0), byID["opex"].Balance.Value)
+		assert.Equal(t, int64(125000), byID["opex"].RolledUpAmount.Value)
+		assert.Equal(t, int64(25000), byID["travel"].RolledUpAmount.Value)
+		assert.Equal(t, 2, byID["flights"].Level)
+		assert.NotContains(t, byID, "cash")
+	})
+
+	t.Run("rolls child balances into parents in the profit and loss", func(t *testing.T) {
+		pl, err := engine.GenerateProfitAndLoss(
+			time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), asOf, "USD")
+		require.NoError(t, err)
+
+		var opexLine *FinancialLineItem
+		for _, item := range pl.LineItems[1].Children {
+			if item.AccountID == "opex" {
+				opexLine = item
+			}
+		}
+		require.NotNil(t, opexLine)
+		assert.True(t, opexLine.IsSubtotal)
+		assert.Equal(t, int64(125000), opexLine.Amount.Value)
+		require.Len(t, opexLine.Children, 2)
+
+		travelLine := opexLine.Children[1]
+		assert.Equal(t, int64(25000), travelLine.Amount.Value)
+		require.Len(t, travelLine.Children, 2)
+		assert.Equal(t, "Travel (direct)", travelLine.Children[0].AccountName)
+		assert.Equal(t, int64(125000), pl.LineItems[1].Amount.Value)
+	})
+
+	t.Run("deactivates and reactivates accounts", func(t *testing.T) {
+		_, err := engine.DeactivateAccount("rent", userID)
+		assert.ErrorContains(t, err, "balance")
+
+		empty := &Account{ID: "supplies", ParentID: "opex", Name: "Supplies", Type: Expense, Currency: "USD"}
+		require.NoError(t, engine.CreateAccount(empty, userID))
+		_, err = engine.DeactivateAccount("opex", userID)
+		assert.ErrorContains(t, err, "active child")
+
+		account, err := engine.DeactivateAccount("supplies", userID)
+		require.NoError(t, err)
+		assert.NotNil(t, account.ClosedAt)
+
+		txn := &Transaction{
+			Description: "Supplies",
+			ValidTime:   time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "supplies", Type: Debit, Amount: Amount{Value: 100, Currency: "USD"}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: 100, Currency: "USD"}},
+			},
+		}
+		assert.ErrorContains(t, engine.CreateTransaction(txn, userID), "inactive")
+
+		account, err = engine.ActivateAccount("supplies", userID)
+		require.NoError(t, err)
+		assert.Nil(t, account.ClosedAt)
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+	})
+}
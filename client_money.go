@@ -0,0 +1,403 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Client Money Structures
+// ----------------------------------------------------------------------------
+
+// ClientMoneyRole distinguishes the two sides of a client money (trust) ledger
+type ClientMoneyRole string
+
+const (
+	// ClientBank is an asset account holding clients' money, segregated from the firm's own
+	ClientBank ClientMoneyRole = "CLIENT_BANK"
+	// ClientLedger is a liability account recording what is owed to each client
+	ClientLedger ClientMoneyRole = "CLIENT_LEDGER"
+)
+
+// ClientMoneyAccount designates a ledger account as holding or owing client money.
+// Every entry on a designated account must name its client with DimClient, and
+// within each transaction the client bank and client ledger movements must match
+// client by client, so one client's money can never fund another client or the firm.
+type ClientMoneyAccount struct {
+	ID           string          `json:"id"` // the designated account ID
+	Role         ClientMoneyRole `json:"role"`
+	Currency     Currency        `json:"currency"`
+	DesignatedBy string          `json:"designated_by"`
+	DesignatedAt time.Time       `json:"designated_at"`
+}
+
+// ClientMoneyStatus is the outcome of a client money reconciliation
+type ClientMoneyStatus string
+
+const (
+	ClientMoneyBalanced  ClientMoneyStatus = "BALANCED"
+	ClientMoneyShortfall ClientMoneyStatus = "SHORTFALL"
+	ClientMoneyExcess    ClientMoneyStatus = "EXCESS"
+)
+
+// ClientBankBalance compares a client bank account's cash book with the bank statement
+type ClientBankBalance struct {
+	AccountID  string `json:"account_id"`
+	CashBook   int64  `json:"cash_book"`
+	Statement  *int64 `json:"statement,omitempty"`
+	Difference int64  `json:"difference"` // statement less cash book
+}
+
+// ClientLedgerBalance is the amount held for one client; negative means overdrawn
+type ClientLedgerBalance struct {
+	ClientID string `json:"client_id"`
+	Balance  int64  `json:"balance"`
+}
+
+// ClientMoneyReconciliation is a daily three-way reconciliation of bank statements,
+// the client cash book and the client ledgers. The requirement is what the firm owes
+// its clients, counting overdrawn clients as zero since one client's money cannot
+// cover another's debt; the resource is the lower of cash book and statement.
+type ClientMoneyReconciliation struct {
+	ID               string                `json:"id"`
+	AsOfDate         time.Time             `json:"as_of_date"`
+	Currency         Currency              `json:"currency"`
+	BankAccounts     []ClientBankBalance   `json:"bank_accounts"`
+	Clients          []ClientLedgerBalance `json:"clients"`
+	Requirement      int64                 `json:"requirement"`
+	Resource         int64                 `json:"resource"`
+	Shortfall        int64                 `json:"shortfall"`
+	Excess           int64                 `json:"excess"`
+	OverdrawnClients []string              `json:"overdrawn_clients,omitempty"`
+	Status           ClientMoneyStatus     `json:"status"`
+	AlertID          string                `json:"alert_id,omitempty"`
+	PerformedBy      string                `json:"performed_by"`
+	PerformedAt      time.Time             `json:"performed_at"`
+}
+
+// ----------------------------------------------------------------------------
+// Client Money Service
+// ----------------------------------------------------------------------------
+
+// ClientMoneyService enforces client money segregation and reconciles it daily
+type ClientMoneyService struct {
+	storage      *Storage
+	eventStore   *EventStore
+	amlService   *AMLService
+	designations map[string]*ClientMoneyAccount
+	mutex        sync.RWMutex
+}
+
+// NewClientMoneyService creates a new client money service
+func NewClientMoneyService(storage *Storage, eventStore *EventStore, amlService *AMLService) *ClientMoneyService {
+	return &ClientMoneyService{
+		storage:    storage,
+		eventStore: eventStore,
+		amlService: amlService,
+	}
+}
+
+// DesignateAccount marks an account as a client bank or client ledger account. Client
+// bank accounts must be assets and client ledger accounts liabilities.
+func (cs *ClientMoneyService) DesignateAccount(accountID string, role ClientMoneyRole, currency Currency, userID string) (*ClientMoneyAccount, error) {
+	account, err := cs.storage.GetAccount(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account: %w", err)
+	}
+	switch role {
+	case ClientBank:
+		if account.Type != Asset {
+			return nil, fmt.Errorf("client bank account %s must be an asset account", accountID)
+		}
+	case ClientLedger:
+		if account.Type != Liability {
+			return nil, fmt.Errorf("client ledger account %s must be a liability account", accountID)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported client money role: %s", role)
+	}
+	if currency == "" {
+		currency = account.Currency
+	}
+	if currency == "" {
+		return nil, fmt.Errorf("client money account currency is required")
+	}
+
+	designations, err := cs.loadDesignations()
+	if err != nil {
+		return nil, err
+	}
+	if _, exists := designations[accountID]; exists {
+		return nil, fmt.Errorf("account %s is already designated as client money", accountID)
+	}
+
+	designation := &ClientMoneyAccount{
+		ID:           accountID,
+		Role:         role,
+		Currency:     currency,
+		DesignatedBy: userID,
+		DesignatedAt: time.Now(),
+	}
+	_, err = cs.eventStore.CreateEvent(EventDesignateClientMoneyAccount, designation, designation.DesignatedAt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client money account event: %w", err)
+	}
+	if err := cs.storage.SaveClientMoneyAccount(designation); err != nil {
+		return nil, fmt.Errorf("failed to save client money account: %w", err)
+	}
+
+	// Replace rather than mutate the cached map so validators reading it need no lock
+	cs.mutex.Lock()
+	updated := make(map[string]*ClientMoneyAccount, len(cs.designations)+1)
+	for id, existing := range cs.designations {
+		updated[id] = existing
+	}
+	updated[accountID] = designation
+	cs.designations = updated
+	cs.mutex.Unlock()
+	return designation, nil
+}
+
+// ValidateTransaction rejects postings that would commingle client money: entries on
+// client money accounts without a client, and transactions whose client bank and
+// client ledger movements differ for any client. Registered with the posting engine.
+func (cs *ClientMoneyService) ValidateTransaction(txn *Transaction) error {
+	designations, err := cs.loadDesignations()
+	if err != nil {
+		return err
+	}
+
+	// Debit-positive movement per client across both roles; a bank receipt (debit)
+	// must be matched by a ledger credit for the same client, netting to zero
+	net := make(map[string]int64)
+	for i := range txn.Entries {
+		entry := &txn.Entries[i]
+		designation, ok := designations[entry.AccountID]
+		if !ok {
+			continue
+		}
+		client := entryDimension(entry, DimClient)
+		if client == "" {
+			return fmt.Errorf("entry on client money account %s does not name a client", entry.AccountID)
+		}
+		if entry.Amount.Currency != designation.Currency {
+			return fmt.Errorf("client money account %s holds %s, not %s", entry.AccountID, designation.Currency, entry.Amount.Currency)
+		}
+		value := entry.Amount.Value
+		if entry.Type == Credit {
+			value = -value
+		}
+		net[client] += value
+	}
+
+	clients := make([]string, 0, len(net))
+	for client, movement := range net {
+		if movement != 0 {
+			clients = append(clients, client)
+		}
+	}
+	if len(clients) > 0 {
+		sort.Strings(clients)
+		return fmt.Errorf("client bank and client ledger movements differ for client %s", clients[0])
+	}
+	return nil
+}
+
+// Reconcile performs the daily client money reconciliation for a currency from
+// transactions valid up to asOfDate. statementBalances holds bank statement balances by client bank
+// account ID; accounts without one are reconciled on the cash book alone. A
+// shortfall raises a critical AML alert so it is escalated the same day.
+func (cs *ClientMoneyService) Reconcile(asOfDate time.Time, currency Currency, statementBalances map[string]int64, userID string) (*ClientMoneyReconciliation, error) {
+	designations, err := cs.loadDesignations()
+	if err != nil {
+		return nil, err
+	}
+
+	recon := &ClientMoneyReconciliation{
+		ID:          newID(),
+		AsOfDate:    asOfDate,
+		Currency:    currency,
+		PerformedBy: userID,
+		PerformedAt: time.Now(),
+	}
+
+	cashBook := make(map[string]int64)
+	ledgers := make(map[string]int64)
+	for _, designation := range designations {
+		if designation.Currency == currency && designation.Role == ClientBank {
+			cashBook[designation.ID] = 0
+		}
+	}
+	if len(cashBook) == 0 {
+		return nil, fmt.Errorf("no client bank accounts are designated in %s", currency)
+	}
+
+	txns, err := cs.storage.GetTransactionsByDateRange("", time.Time{}, asOfDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	for _, txn := range txns {
+		if txn.Status != Posted && txn.Status != Reversed {
+			continue
+		}
+		for i := range txn.Entries {
+			entry := &txn.Entries[i]
+			designation, ok := designations[entry.AccountID]
+			if !ok || designation.Currency != currency {
+				continue
+			}
+			value := entry.Amount.Value
+			if entry.Type == Credit {
+				value = -value
+			}
+			if designation.Role == ClientBank {
+				cashBook[entry.AccountID] += value
+			} else {
+				ledgers[entryDimension(entry, DimClient)] -= value
+			}
+		}
+	}
+
+	var totalCashBook, totalStatement int64
+	for accountID, balance := range cashBook {
+		bank := ClientBankBalance{AccountID: accountID, CashBook: balance}
+		totalCashBook += balance
+		if statement, ok := statementBalances[accountID]; ok {
+			bank.Statement = &statement
+			bank.Difference = statement - balance
+			totalStatement += statement
+		} else {
+			totalStatement += balance
+		}
+		recon.BankAccounts = append(recon.BankAccounts, bank)
+	}
+	sort.Slice(recon.BankAccounts, func(i, j int) bool {
+		return recon.BankAccounts[i].AccountID < recon.BankAccounts[j].AccountID
+	})
+
+	for client, balance := range ledgers {
+		if balance == 0 {
+			continue
+		}
+		recon.Clients = append(recon.Clients, ClientLedgerBalance{ClientID: client, Balance: balance})
+		if balance > 0 {
+			recon.Requirement += balance
+		} else {
+			recon.OverdrawnClients = append(recon.OverdrawnClients, client)
+		}
+	}
+	sort.Slice(recon.Clients, func(i, j int) bool {
+		return recon.Clients[i].ClientID < recon.Clients[j].ClientID
+	})
+	sort.Strings(recon.OverdrawnClients)
+
+	recon.Resource = totalCashBook
+	if totalStatement < recon.Resource {
+		recon.Resource = totalStatement
+	}
+	switch {
+	case recon.Resource < recon.Requirement:
+		recon.Status = ClientMoneyShortfall
+		recon.Shortfall = recon.Requirement - recon.Resource
+	case recon.Resource > recon.Requirement:
+		recon.Status = ClientMoneyExcess
+		recon.Excess = recon.Resource - recon.Requirement
+	default:
+		recon.Status = ClientMoneyBalanced
+	}
+
+	if recon.Status == ClientMoneyShortfall {
+		if err := cs.raiseShortfallAlert(recon); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = cs.eventStore.CreateEvent(EventReconcileClientMoney, recon, asOfDate, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client money reconciliation event: %w", err)
+	}
+	if err := cs.storage.SaveClientMoneyReconciliation(recon); err != nil {
+		return nil, fmt.Errorf("failed to save client money reconciliation: %w", err)
+	}
+	return recon, nil
+}
+
+// GetReconciliations returns client money reconciliations for a currency, oldest first
+func (cs *ClientMoneyService) GetReconciliations(currency Currency) ([]*ClientMoneyReconciliation, error) {
+	recons, err := cs.storage.GetAllClientMoneyReconciliations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client money reconciliations: %w", err)
+	}
+
+	var result []*ClientMoneyReconciliation
+	for _, recon := range recons {
+		if currency == "" || recon.Currency == currency {
+			result = append(result, recon)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].AsOfDate.Before(result[j].AsOfDate)
+	})
+	return result, nil
+}
+
+// raiseShortfallAlert records the shortfall in the AML review queue
+func (cs *ClientMoneyService) raiseShortfallAlert(recon *ClientMoneyReconciliation) error {
+	accountIDs := make([]string, len(recon.BankAccounts))
+	for i, bank := range recon.BankAccounts {
+		accountIDs[i] = bank.AccountID
+	}
+	description := fmt.Sprintf("Client money of %s held against client balances of %s on %s",
+		FormatMinorUnits(recon.Resource, recon.Currency), FormatMinorUnits(recon.Requirement, recon.Currency), recon.AsOfDate.Format("2006-01-02"))
+	if len(recon.OverdrawnClients) > 0 {
+		description += fmt.Sprintf("; overdrawn clients: %v", recon.OverdrawnClients)
+	}
+
+	alert := &AMLAlert{
+		ID:          newID(),
+		RuleType:    RuleClientShortfall,
+		RiskLevel:   RiskCritical,
+		Title:       fmt.Sprintf("Client money shortfall of %s %s", FormatMinorUnits(recon.Shortfall, recon.Currency), recon.Currency),
+		Description: description,
+		EntityID:    recon.ID,
+		EntityType:  "CLIENT_MONEY_RECONCILIATION",
+		AccountIDs:  accountIDs,
+		Amount:      &Amount{Value: recon.Shortfall, Currency: recon.Currency},
+		Currency:    string(recon.Currency),
+		DetectedAt:  recon.PerformedAt,
+		Status:      "OPEN",
+		CreatedAt:   recon.PerformedAt,
+		UpdatedAt:   recon.PerformedAt,
+	}
+	if err := cs.amlService.RaiseAlert(alert); err != nil {
+		return fmt.Errorf("failed to raise AML alert: %w", err)
+	}
+	recon.AlertID = alert.ID
+	return nil
+}
+
+// loadDesignations returns the designated accounts, reading them from storage once
+func (cs *ClientMoneyService) loadDesignations() (map[string]*ClientMoneyAccount, error) {
+	cs.mutex.RLock()
+	designations := cs.designations
+	cs.mutex.RUnlock()
+	if designations != nil {
+		return designations, nil
+	}
+
+	cs.mutex.Lock()
+	defer cs.mutex.Unlock()
+	if cs.designations == nil {
+		accounts, err := cs.storage.GetAllClientMoneyAccounts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get client money accounts: %w", err)
+		}
+		cs.designations = make(map[string]*ClientMoneyAccount, len(accounts))
+		for _, account := range accounts {
+			cs.designations[account.ID] = account
+		}
+	}
+	return cs.designations, nil
+}
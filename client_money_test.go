@@ -0,0 +1,130 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientMoney(t *testing.T) {
+	// Setup
+	dbFile := "test_client_money.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "trust_clerk"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	accounts := []*Account{
+		{ID: "client_bank", Code: "1015", Name: "Client Bank Account", Type: Asset},
+		{ID: "client_ledger", Code: "2150", Name: "Client Ledger", Type: Liability},
+	}
+	for _, account := range accounts {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	day := time.Date(2025, 4, 14, 0, 0, 0, 0, time.UTC)
+	entry := func(accountID string, entryType EntryType, value int64, client string) Entry {
+		e := Entry{AccountID: accountID, Type: entryType, Amount: Amount{Value: value, Currency: "USD"}}
+		if client != "" {
+			e.Dimensions = []Dimension{{Key: DimClient, Value: client}}
+		}
+		return e
+	}
+	post := func(description string, entries ...Entry) error {
+		txn := &Transaction{Description: description, ValidTime: day, Entries: entries}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		return engine.PostTransaction(txn.ID, userID)
+	}
+
+	t.Run("Designation", func(t *testing.T) {
+		_, err := engine.DesignateClientMoneyAccount("client_ledger", ClientBank, "USD", userID)
+		assert.Error(t, err, "client bank accounts must be assets")
+		_, err = engine.DesignateClientMoneyAccount("client_bank", ClientLedger, "USD", userID)
+		assert.Error(t, err, "client ledger accounts must be liabilities")
+
+		bank, err := engine.DesignateClientMoneyAccount("client_bank", ClientBank, "USD", userID)
+		require.NoError(t, err)
+		assert.Equal(t, ClientBank, bank.Role)
+		_, err = engine.DesignateClientMoneyAccount("client_ledger", ClientLedger, "USD", userID)
+		require.NoError(t, err)
+
+		_, err = engine.DesignateClientMoneyAccount("client_bank", ClientBank, "USD", userID)
+		assert.Error(t, err, "an account is designated once")
+	})
+
+	t.Run("Commingling Controls", func(t *testing.T) {
+		require.NoError(t, post("Retainer from Acme",
+			entry("client_bank", Debit, 500000, "acme"),
+			entry("client_ledger", Credit, 500000, "acme")))
+		require.NoError(t, post("Settlement funds from Birch",
+			entry("client_bank", Debit, 200000, "birch"),
+			entry("client_ledger", Credit, 200000, "birch")))
+
+		err := post("Untagged receipt",
+			entry("client_bank", Debit, 1000, ""),
+			entry("client_ledger", Credit, 1000, ""))
+		assert.ErrorContains(t, err, "does not name a client")
+
+		err = post("Pay Birch's costs from Acme's money",
+			entry("client_ledger", Debit, 10000, "birch"),
+			entry("client_bank", Credit, 10000, "acme"))
+		assert.ErrorContains(t, err, "client bank and client ledger movements differ")
+
+		err = post("Firm borrows from client account",
+			entry("cash", Debit, 5000, ""),
+			entry("client_bank", Credit, 5000, "acme"))
+		assert.ErrorContains(t, err, "client bank and client ledger movements differ")
+
+		// Fees billed to a client may be transferred once the ledger is charged in the same journal
+		require.NoError(t, post("Transfer Acme's fee to office",
+			entry("client_ledger", Debit, 50000, "acme"),
+			entry("client_bank", Credit, 50000, "acme"),
+			entry("cash", Debit, 50000, ""),
+			entry("revenue", Credit, 50000, "")))
+	})
+
+	t.Run("Balanced Reconciliation", func(t *testing.T) {
+		recon, err := engine.ReconcileClientMoney(day, "USD", map[string]int64{"client_bank": 650000}, userID)
+		require.NoError(t, err)
+
+		assert.Equal(t, ClientMoneyBalanced, recon.Status)
+		assert.Equal(t, int64(650000), recon.Requirement)
+		assert.Equal(t, int64(650000), recon.Resource)
+		require.Len(t, recon.BankAccounts, 1)
+		assert.Equal(t, int64(650000), recon.BankAccounts[0].CashBook)
+		assert.Equal(t, int64(0), recon.BankAccounts[0].Difference)
+		assert.Equal(t, []ClientLedgerBalance{
+			{ClientID: "acme", Balance: 450000},
+			{ClientID: "birch", Balance: 200000},
+		}, recon.Clients)
+		assert.Empty(t, recon.AlertID)
+	})
+
+	t.Run("Shortfall Alert", func(t *testing.T) {
+		// The bank has debited charges the cash book has not recorded
+		recon, err := engine.ReconcileClientMoney(day, "USD", map[string]int64{"client_bank": 640000}, userID)
+		require.NoError(t, err)
+
+		assert.Equal(t, ClientMoneyShortfall, recon.Status)
+		assert.Equal(t, int64(10000), recon.Shortfall)
+		assert.Equal(t, int64(-10000), recon.BankAccounts[0].Difference)
+		require.NotEmpty(t, recon.AlertID)
+
+		alert, err := engine.GetStorage().GetAMLAlert(recon.AlertID)
+		require.NoError(t, err)
+		assert.Equal(t, RuleClientShortfall, alert.RuleType)
+		assert.Equal(t, RiskCritical, alert.RiskLevel)
+		assert.Equal(t, int64(10000), alert.Amount.Value)
+
+		recons, err := engine.GetClientMoneyService().GetReconciliations("USD")
+		require.NoError(t, err)
+		assert.Len(t, recons, 2)
+		require.NotNil(t, recons[1].BankAccounts[0].Statement)
+	})
+}
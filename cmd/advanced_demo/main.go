@@ -261,68 +261,69 @@ func demonstrateMultiCompany() {
 }
 
 func demonstrateAdvancedAnalytics(engine *accounting.AccountingEngine, userID string) {
-	// Get trial balance for analytics
-	trialBalance, err := engine.GetTrialBalance(time.Now(), nil)
+	// Get the year-to-date ratios
+	ratios, err := engine.GenerateFinancialRatios(time.Now(), accounting.Yearly, "USD", nil)
 	if err != nil {
-		fmt.Println("❌ Failed to get trial balance for analytics")
+		fmt.Println("❌ Failed to compute financial ratios")
 		return
 	}
-
-	// Calculate key financial ratios
-	var totalAssets, totalLiabilities, totalRevenue, totalExpenses int64
-
-	for _, balance := range trialBalance {
-		switch balance.AccountType {
-		case accounting.Asset:
-			totalAssets += balance.Balance.Value
-		case accounting.Liability:
-			totalLiabilities += balance.Balance.Value
-		case accounting.Income:
-			totalRevenue += balance.Balance.Value
-		case accounting.Expense:
-			totalExpenses += balance.Balance.Value
-		}
-	}
-
-	totalEquity := totalAssets - totalLiabilities
-	netIncome := totalRevenue - totalExpenses
+	figures := ratios.Figures
 
 	fmt.Println("📊 Key Financial Metrics:")
-	fmt.Printf("   Total Assets:           $%8.2f\n", float64(totalAssets)/100)
-	fmt.Printf("   Total Liabilities:      $%8.2f\n", float64(totalLiabilities)/100)
-	fmt.Printf("   Total Equity:           $%8.2f\n", float64(totalEquity)/100)
-	fmt.Printf("   Total Revenue:          $%8.2f\n", float64(totalRevenue)/100)
-	fmt.Printf("   Total Expenses:         $%8.2f\n", float64(totalExpenses)/100)
-	fmt.Printf("   Net Income:             $%8.2f\n", float64(netIncome)/100)
-
-	// Calculate ratios
-	if totalLiabilities > 0 {
-		debtToEquity := float64(totalLiabilities) / float64(totalEquity)
-		fmt.Printf("   Debt-to-Equity Ratio:   %.2f\n", debtToEquity)
+	fmt.Printf("   Total Assets:           $%8.2f\n", float64(figures.TotalAssets)/100)
+	fmt.Printf("   Total Liabilities:      $%8.2f\n", float64(figures.TotalLiabilities)/100)
+	fmt.Printf("   Total Equity:           $%8.2f\n", float64(figures.Equity)/100)
+	fmt.Printf("   Total Revenue:          $%8.2f\n", float64(figures.Revenue)/100)
+	fmt.Printf("   Net Income:             $%8.2f\n", float64(figures.NetIncome)/100)
+
+	// Ratios are nil when their denominator is zero
+	if r := ratios.Leverage.DebtToEquity; r != nil {
+		fmt.Printf("   Debt-to-Equity Ratio:   %.2f\n", *r)
 	}
-
-	if totalRevenue > 0 {
-		profitMargin := float64(netIncome) / float64(totalRevenue) * 100
-		fmt.Printf("   Profit Margin:          %.1f%%\n", profitMargin)
+	if r := ratios.Profitability.NetMargin; r != nil {
+		fmt.Printf("   Profit Margin:          %.1f%%\n", *r*100)
 	}
-
-	if totalAssets > 0 {
-		roa := float64(netIncome) / float64(totalAssets) * 100
-		fmt.Printf("   Return on Assets:       %.1f%%\n", roa)
+	if r := ratios.Profitability.ReturnOnAssets; r != nil {
+		fmt.Printf("   Return on Assets:       %.1f%%\n", *r*100)
+	}
+	if r := ratios.Liquidity.CurrentRatio; r != nil {
+		fmt.Printf("   Current Ratio:          %.2f\n", *r)
 	}
 
 	// Demonstrate dimension-based analytics
 	fmt.Println("\n📈 Dimension-based Analytics:")
 
-	// This would normally query by dimensions
-	fmt.Println("   By Department:")
-	fmt.Println("     - Sales:     Revenue tracking")
-	fmt.Println("     - Marketing: Campaign ROI analysis")
-	fmt.Println("     - Admin:     Cost center management")
+	now := time.Now()
+	startOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	segments, err := engine.GenerateProfitAndLossByDimension(accounting.DimDepartment, startOfMonth, now, "USD")
+	if err != nil {
+		log.Printf("Failed to generate departmental P&L: %v", err)
+		return
+	}
+	fmt.Println("   Net Income by Department:")
+	for _, segment := range segments.Segments {
+		department := segment.Value
+		if department == "" {
+			department = "unassigned"
+		}
+		fmt.Printf("     - %-12s $%8.2f\n", department+":", float64(segment.Statement.NetIncome.Value)/100)
+	}
 
+	byProduct, err := engine.GetBalancesByDimensions(&accounting.QueryFilter{
+		AccountTypes: []accounting.AccountType{accounting.Income, accounting.Expense},
+	}, []accounting.DimensionKey{accounting.DimProduct})
+	if err != nil {
+		log.Printf("Failed to get balances by product: %v", err)
+		return
+	}
 	fmt.Println("   By Product:")
-	fmt.Println("     - Enterprise Software: Performance metrics")
-	fmt.Println("     - Professional Services: Profitability analysis")
+	for _, subtotal := range byProduct.Subtotals {
+		product := subtotal.Dimensions[0].Value
+		if product == "" {
+			product = "untagged"
+		}
+		fmt.Printf("     - %-20s %-8s $%8.2f\n", product, subtotal.AccountType, float64(subtotal.Amount.Value)/100)
+	}
 
 	fmt.Println("✅ Advanced analytics completed")
 }
@@ -73,6 +73,11 @@ func main() {
 			return fmt.Errorf("failed to create EUR account: %w", err)
 		}
 
+		// Reports translate the EUR balance at the day's rate
+		if _, err := engine.SetExchangeRate("EUR", "USD", 1.18, time.Now().AddDate(0, 0, -1), "test_user"); err != nil {
+			return fmt.Errorf("failed to set EUR/USD rate: %w", err)
+		}
+
 		// Multi-currency transaction
 		txn := &accounting.Transaction{
 			Description:     "EUR transaction with exchange rate",
@@ -278,14 +283,14 @@ func main() {
 
 	// 10. Intercompany Transactions
 	testFeature("Intercompany Transactions", func() error {
-		// Create intercompany accounts
+		// Create USD intercompany accounts beside the standard ones (codes 1300 and 2200)
 		intercoReceivable := &accounting.Account{
-			Code: "1300", Name: "Intercompany Receivable", Type: accounting.Asset,
+			Code: "1310", Name: "Intercompany Receivable", Type: accounting.Asset,
 			Currency: "USD",
 		}
 
 		intercoPayable := &accounting.Account{
-			Code: "2200", Name: "Intercompany Payable", Type: accounting.Liability,
+			Code: "2210", Name: "Intercompany Payable", Type: accounting.Liability,
 			Currency: "USD",
 		}
 
@@ -25,6 +25,15 @@ func main() {
 	if err := engine.CreateStandardAccounts("admin"); err != nil {
 		log.Fatalf("Failed to create chart of accounts: %v", err)
 	}
+	// Accounts the scenarios move money through beyond the standard chart
+	for _, account := range []*accounting.Account{
+		{ID: "deposits", Code: "2050", Name: "Customer Deposits", Type: accounting.Liability},
+		{ID: "investments", Code: "1500", Name: "Investments", Type: accounting.Asset},
+	} {
+		if err := engine.CreateAccount(account, "admin"); err != nil {
+			log.Fatalf("Failed to create %s account: %v", account.ID, err)
+		}
+	}
 
 	// Get AML service
 	amlService := engine.GetAMLService()
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"time"
 
@@ -17,8 +18,9 @@ func main() {
 	dbFile := "demo_accounting.db"
 	os.Remove(dbFile)
 
-	// Initialize the accounting engine
-	engine, err := accounting.NewAccountingEngine(dbFile)
+	// Initialize the accounting engine, logging warnings and errors to stderr
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	engine, err := accounting.NewAccountingEngineWithOptions(dbFile, accounting.StorageOptions{Logger: logger})
 	if err != nil {
 		log.Fatalf("Failed to create accounting engine: %v", err)
 	}
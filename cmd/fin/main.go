@@ -0,0 +1,117 @@
+// Command fin runs operator tasks against an accounting database. The database must
+// not be open in another process, since bbolt locks the file.
+//
+//	go run ./cmd/fin profile -db accounting.db -iterations 10
+package main
+
+import (
+	"accounting"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// commands maps each subcommand to its implementation
+var commands = map[string]func(args []string) error{
+	"profile": profile,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		usage()
+	}
+	if err := command(os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: fin <command> [flags]\n\ncommands:\n  profile  time representative workloads and suggest indexes or compaction")
+	os.Exit(2)
+}
+
+// profile runs the storage profiling workloads and prints the results
+func profile(args []string) error {
+	flags := flag.NewFlagSet("profile", flag.ExitOnError)
+	dbPath := flags.String("db", "accounting.db", "database file to profile")
+	iterations := flags.Int("iterations", 5, "runs per workload")
+	operations := flags.String("ops", "", "comma-separated workloads to run; empty runs all")
+	currency := flags.String("currency", "USD", "currency for the statement workloads")
+	asJSON := flags.Bool("json", false, "print the profile as JSON")
+	flags.Parse(args)
+
+	if _, err := os.Stat(*dbPath); err != nil {
+		return fmt.Errorf("cannot profile %s: %w", *dbPath, err)
+	}
+	engine, err := accounting.NewAccountingEngine(*dbPath)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	options := &accounting.ProfileOptions{Iterations: *iterations, Currency: *currency}
+	if *operations != "" {
+		options.Operations = strings.Split(*operations, ",")
+	}
+	result, err := engine.ProfileStorage(options)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+	return printProfile(os.Stdout, result)
+}
+
+// printProfile writes a profile as tables of operations, scans and suggestions
+func printProfile(w io.Writer, profile *accounting.StorageProfile) error {
+	stats := profile.Storage
+	fmt.Fprintf(w, "%s: %d bytes, %d events, %.0f%% free\n\n", stats.Path, stats.SizeBytes, stats.Events, stats.FreeRatio()*100)
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "OPERATION\tRUNS\tMEAN\tP95\tMAX\tREAD TXS\tCURSORS\tSCANS\t")
+	for _, op := range profile.Operations {
+		fmt.Fprintf(table, "%s\t%d\t%s\t%s\t%s\t%d\t%d\t%d\t\n", op.Name, op.Runs, op.Mean, op.P95, op.Max, op.ReadTxs, op.Cursors, op.TotalScans)
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nFull bucket scans per run:")
+	table = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "OPERATION\tBUCKET\tSCANS\tKEYS\t")
+	for _, op := range profile.Operations {
+		for _, scan := range op.Scans {
+			fmt.Fprintf(table, "%s\t%s\t%.1f\t%d\t\n", op.Name, scan.Bucket, scan.PerRun, scan.Keys)
+		}
+	}
+	if err := table.Flush(); err != nil {
+		return err
+	}
+
+	for _, op := range profile.Operations {
+		if op.Error != "" {
+			fmt.Fprintf(w, "\n%s failed: %s\n", op.Name, op.Error)
+		}
+	}
+	if len(profile.Suggestions) == 0 {
+		fmt.Fprintln(w, "\nNo suggestions.")
+		return nil
+	}
+	fmt.Fprintln(w, "\nSuggestions:")
+	for _, suggestion := range profile.Suggestions {
+		fmt.Fprintf(w, "  [%s] %s\n", suggestion.Kind, suggestion.Message)
+	}
+	return nil
+}
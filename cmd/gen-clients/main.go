@@ -0,0 +1,136 @@
+// Command gen-clients writes typed TypeScript and Python models for the accounting
+// protobuf messages from the engine's descriptor set. The models follow the
+// protobuf JSON mapping, so they read and write what protojson produces.
+//
+//	go run ./cmd/gen-clients -out clients -lang typescript,python
+package main
+
+import (
+	"accounting"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// timestampName is the well-known type timestamps are declared with
+const timestampName protoreflect.FullName = "google.protobuf.Timestamp"
+
+// generators maps each target language to its output file and renderer
+var generators = map[string]struct {
+	path   string
+	render func(*schema) (string, error)
+}{
+	"typescript": {path: filepath.Join("typescript", "accounting.ts"), render: renderTypeScript},
+	"python":     {path: filepath.Join("python", "accounting.py"), render: renderPython},
+}
+
+func main() {
+	out := flag.String("out", "clients", "directory to write the clients to")
+	langs := flag.String("lang", "typescript,python", "comma-separated languages to generate")
+	flag.Parse()
+
+	s, err := loadSchema()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, lang := range strings.Split(*langs, ",") {
+		generator, ok := generators[strings.TrimSpace(lang)]
+		if !ok {
+			log.Fatalf("unknown language %q", lang)
+		}
+		source, err := generator.render(s)
+		if err != nil {
+			log.Fatalf("failed to generate %s client: %v", lang, err)
+		}
+		path := filepath.Join(*out, generator.path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("wrote", path)
+	}
+}
+
+// schema is the accounting package's messages and enums, sorted by name
+type schema struct {
+	pkg      protoreflect.FullName
+	messages []protoreflect.MessageDescriptor
+	enums    []protoreflect.EnumDescriptor
+}
+
+// loadSchema reads the descriptor set the engine publishes
+func loadSchema() (*schema, error) {
+	data, err := accounting.ProtoDescriptorSet()
+	if err != nil {
+		return nil, err
+	}
+	set := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, set); err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set: %w", err)
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build descriptors: %w", err)
+	}
+
+	s := &schema{}
+	var collect func(messages protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors)
+	collect = func(messages protoreflect.MessageDescriptors, enums protoreflect.EnumDescriptors) {
+		for i := 0; i < enums.Len(); i++ {
+			s.enums = append(s.enums, enums.Get(i))
+		}
+		for i := 0; i < messages.Len(); i++ {
+			message := messages.Get(i)
+			if message.IsMapEntry() {
+				continue
+			}
+			s.messages = append(s.messages, message)
+			collect(message.Messages(), message.Enums())
+		}
+	}
+	files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		if strings.HasPrefix(string(file.Package()), "google.") {
+			return true
+		}
+		s.pkg = file.Package()
+		collect(file.Messages(), file.Enums())
+		return true
+	})
+	if len(s.messages) == 0 {
+		return nil, fmt.Errorf("descriptor set has no messages")
+	}
+	sort.Slice(s.messages, func(i, j int) bool { return s.messages[i].FullName() < s.messages[j].FullName() })
+	sort.Slice(s.enums, func(i, j int) bool { return s.enums[i].FullName() < s.enums[j].FullName() })
+	return s, nil
+}
+
+// typeName is a descriptor's name in generated code; nested types join their
+// parents' names with underscores
+func (s *schema) typeName(name protoreflect.FullName) (string, error) {
+	local, ok := strings.CutPrefix(string(name), string(s.pkg)+".")
+	if !ok {
+		return "", fmt.Errorf("type %s is outside package %s", name, s.pkg)
+	}
+	return strings.ReplaceAll(local, ".", "_"), nil
+}
+
+// is64Bit reports whether the JSON mapping writes a field kind as a decimal string
+func is64Bit(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return true
+	}
+	return false
+}
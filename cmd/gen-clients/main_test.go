@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateClients(t *testing.T) {
+	s, err := loadSchema()
+	require.NoError(t, err)
+
+	t.Run("typescript follows the JSON mapping", func(t *testing.T) {
+		source, err := renderTypeScript(s)
+		require.NoError(t, err)
+		assert.Contains(t, source, "export interface Amount {\n  value?: string;\n  currency?: string;\n")
+		assert.Contains(t, source, "  exchangeRateDate?: string;\n")
+		assert.Contains(t, source, "  entries?: Entry[];\n")
+		assert.Contains(t, source, `export type EntryType = "ENTRY_TYPE_UNSPECIFIED" | "ENTRY_TYPE_DEBIT" | "ENTRY_TYPE_CREDIT";`)
+		assert.Contains(t, source, "  thresholdValues?: { [key: string]: AMLValue };\n")
+	})
+
+	t.Run("python converts on the way in and out", func(t *testing.T) {
+		source, err := renderPython(s)
+		require.NoError(t, err)
+		assert.Contains(t, source, "class EntryType(str, enum.Enum):\n")
+		assert.Contains(t, source, "    value: Optional[int] = None\n")
+		assert.Contains(t, source, `            value=_optional(data.get("value"), int),`)
+		assert.Contains(t, source, `            data["value"] = str(self.value)`)
+		assert.Contains(t, source, `            exchange_rate_date=_optional(data.get("exchangeRateDate"), _parse_timestamp),`)
+		assert.Contains(t, source, `            entries=[Entry.from_dict(v) for v in data.get("entries", [])],`)
+		assert.Contains(t, source, "    from_: Optional[int] = None\n", "keywords get a trailing underscore")
+	})
+}
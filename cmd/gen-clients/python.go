@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const pythonHeader = `# Code generated by gen-clients from the accounting protobuf schema. DO NOT EDIT.
+"""Typed models for the accounting protobuf messages.
+
+from_dict reads the protobuf JSON mapping and to_dict writes it: 64-bit integers
+are decimal strings, bytes are base64 and timestamps are RFC 3339 strings.
+"""
+
+from __future__ import annotations
+
+import base64
+import dataclasses
+import datetime
+import enum
+import re
+from typing import Any, Callable, Dict, List, Optional, TypeVar
+
+_T = TypeVar("_T")
+
+
+def _optional(value: Any, convert: Callable[[Any], _T]) -> Optional[_T]:
+    return None if value is None else convert(value)
+
+
+def _parse_timestamp(value: str) -> datetime.datetime:
+    # fromisoformat takes at most microseconds and, before Python 3.11, no "Z"
+    value = re.sub(r"(\.\d{6})\d+", r"\1", value).replace("Z", "+00:00")
+    return datetime.datetime.fromisoformat(value)
+
+
+def _format_timestamp(value: datetime.datetime) -> str:
+    return value.astimezone(datetime.timezone.utc).isoformat().replace("+00:00", "Z")
+
+
+def _decode_bytes(value: str) -> bytes:
+    return base64.b64decode(value)
+
+
+def _encode_bytes(value: bytes) -> str:
+    return base64.b64encode(value).decode("ascii")
+`
+
+// pythonKeywords can't be attribute names, so fields named after them get a
+// trailing underscore
+var pythonKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true,
+	"async": true, "await": true, "break": true, "class": true, "continue": true,
+	"def": true, "del": true, "elif": true, "else": true, "except": true,
+	"finally": true, "for": true, "from": true, "global": true, "if": true,
+	"import": true, "in": true, "is": true, "lambda": true, "nonlocal": true,
+	"not": true, "or": true, "pass": true, "raise": true, "return": true, "try": true,
+	"while": true, "with": true, "yield": true,
+}
+
+// pythonValue is how one value of a field is typed and converted; an empty
+// conversion means the JSON value is used as is
+type pythonValue struct {
+	typ    string
+	decode string
+	encode string
+}
+
+// renderPython writes an enum as a str Enum and a message as a dataclass with
+// from_dict and to_dict
+func renderPython(s *schema) (string, error) {
+	var out strings.Builder
+	out.WriteString(pythonHeader)
+
+	for _, enum := range s.enums {
+		name, err := s.typeName(enum.FullName())
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&out, "\n\nclass %s(str, enum.Enum):\n", name)
+		values := enum.Values()
+		for i := 0; i < values.Len(); i++ {
+			fmt.Fprintf(&out, "    %s = %q\n", values.Get(i).Name(), values.Get(i).Name())
+		}
+	}
+
+	for _, message := range s.messages {
+		if err := s.renderPythonMessage(&out, message); err != nil {
+			return "", err
+		}
+	}
+	return out.String(), nil
+}
+
+// renderPythonMessage writes one dataclass
+func (s *schema) renderPythonMessage(out *strings.Builder, message protoreflect.MessageDescriptor) error {
+	name, err := s.typeName(message.FullName())
+	if err != nil {
+		return err
+	}
+	var attributes, decodes, encodes strings.Builder
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		attribute := string(field.Name())
+		if pythonKeywords[attribute] {
+			attribute += "_"
+		}
+		key := field.JSONName()
+
+		var value pythonValue
+		if field.IsMap() {
+			value, err = s.pythonValue(field.MapValue())
+		} else {
+			value, err = s.pythonValue(field)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", field.FullName(), err)
+		}
+
+		switch {
+		case field.IsMap():
+			fmt.Fprintf(&attributes, "    %s: Dict[str, %s] = dataclasses.field(default_factory=dict)\n", attribute, value.typ)
+			fmt.Fprintf(&decodes, "            %s={k: %s for k, v in data.get(%q, {}).items()},\n", attribute, apply(value.decode, "v"), key)
+			fmt.Fprintf(&encodes, "        if self.%s:\n            data[%q] = {k: %s for k, v in self.%s.items()}\n", attribute, key, apply(value.encode, "v"), attribute)
+		case field.IsList():
+			fmt.Fprintf(&attributes, "    %s: List[%s] = dataclasses.field(default_factory=list)\n", attribute, value.typ)
+			fmt.Fprintf(&decodes, "            %s=[%s for v in data.get(%q, [])],\n", attribute, apply(value.decode, "v"), key)
+			fmt.Fprintf(&encodes, "        if self.%s:\n            data[%q] = [%s for v in self.%s]\n", attribute, key, apply(value.encode, "v"), attribute)
+		default:
+			fmt.Fprintf(&attributes, "    %s: Optional[%s] = None\n", attribute, value.typ)
+			decode := fmt.Sprintf("data.get(%q)", key)
+			if value.decode != "" {
+				decode = fmt.Sprintf("_optional(data.get(%q), %s)", key, value.decode)
+			}
+			fmt.Fprintf(&decodes, "            %s=%s,\n", attribute, decode)
+			fmt.Fprintf(&encodes, "        if self.%s is not None:\n            data[%q] = %s\n", attribute, key, apply(value.encode, "self."+attribute))
+		}
+	}
+
+	fmt.Fprintf(out, "\n\n@dataclasses.dataclass\nclass %s:\n", name)
+	out.WriteString(attributes.String())
+	if fields.Len() > 0 {
+		out.WriteString("\n")
+	}
+	fmt.Fprintf(out, "    @classmethod\n    def from_dict(cls, data: Dict[str, Any]) -> %s:\n", name)
+	if fields.Len() == 0 {
+		out.WriteString("        return cls()\n")
+	} else {
+		fmt.Fprintf(out, "        return cls(\n%s        )\n", decodes.String())
+	}
+	out.WriteString("\n    def to_dict(self) -> Dict[str, Any]:\n        data: Dict[str, Any] = {}\n")
+	out.WriteString(encodes.String())
+	out.WriteString("        return data\n")
+	return nil
+}
+
+// pythonValue types a single value of a field
+func (s *schema) pythonValue(field protoreflect.FieldDescriptor) (pythonValue, error) {
+	switch kind := field.Kind(); {
+	case kind == protoreflect.EnumKind:
+		name, err := s.typeName(field.Enum().FullName())
+		return pythonValue{typ: name, decode: name, encode: "(lambda e: e.value)"}, err
+	case kind == protoreflect.MessageKind || kind == protoreflect.GroupKind:
+		if field.Message().FullName() == timestampName {
+			return pythonValue{typ: "datetime.datetime", decode: "_parse_timestamp", encode: "_format_timestamp"}, nil
+		}
+		name, err := s.typeName(field.Message().FullName())
+		return pythonValue{typ: name, decode: name + ".from_dict", encode: "(lambda m: m.to_dict())"}, err
+	case kind == protoreflect.BoolKind:
+		return pythonValue{typ: "bool"}, nil
+	case kind == protoreflect.StringKind:
+		return pythonValue{typ: "str"}, nil
+	case kind == protoreflect.BytesKind:
+		return pythonValue{typ: "bytes", decode: "_decode_bytes", encode: "_encode_bytes"}, nil
+	case is64Bit(kind):
+		return pythonValue{typ: "int", decode: "int", encode: "str"}, nil
+	case kind == protoreflect.FloatKind || kind == protoreflect.DoubleKind:
+		// NaN and the infinities arrive as strings
+		return pythonValue{typ: "float", decode: "float"}, nil
+	default:
+		return pythonValue{typ: "int"}, nil
+	}
+}
+
+// apply calls a conversion on an expression, or returns the expression when there
+// is no conversion
+func apply(convert, expr string) string {
+	if convert == "" {
+		return expr
+	}
+	return fmt.Sprintf("%s(%s)", convert, expr)
+}
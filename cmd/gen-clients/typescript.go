@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const typeScriptHeader = `// Code generated by gen-clients from the accounting protobuf schema. DO NOT EDIT.
+//
+// Types follow the protobuf JSON mapping: fields use their JSON names and are
+// absent when unset, 64-bit integers are decimal strings, bytes are base64 and
+// timestamps are RFC 3339 strings.
+`
+
+// renderTypeScript writes an enum as a string union and a message as an interface
+func renderTypeScript(s *schema) (string, error) {
+	var out strings.Builder
+	out.WriteString(typeScriptHeader)
+
+	for _, enum := range s.enums {
+		name, err := s.typeName(enum.FullName())
+		if err != nil {
+			return "", err
+		}
+		values := enum.Values()
+		literals := make([]string, values.Len())
+		for i := range literals {
+			literals[i] = fmt.Sprintf("%q", values.Get(i).Name())
+		}
+		fmt.Fprintf(&out, "\nexport type %s = %s;\n", name, strings.Join(literals, " | "))
+	}
+
+	for _, message := range s.messages {
+		name, err := s.typeName(message.FullName())
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&out, "\nexport interface %s {\n", name)
+		fields := message.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			typ, err := s.typeScriptFieldType(field)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", field.FullName(), err)
+			}
+			fmt.Fprintf(&out, "  %s?: %s;\n", field.JSONName(), typ)
+		}
+		out.WriteString("}\n")
+	}
+	return out.String(), nil
+}
+
+// typeScriptFieldType is a field's type including repetition
+func (s *schema) typeScriptFieldType(field protoreflect.FieldDescriptor) (string, error) {
+	switch {
+	case field.IsMap():
+		value, err := s.typeScriptType(field.MapValue())
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("{ [key: string]: %s }", value), nil
+	case field.IsList():
+		element, err := s.typeScriptType(field)
+		if err != nil {
+			return "", err
+		}
+		return element + "[]", nil
+	}
+	return s.typeScriptType(field)
+}
+
+// typeScriptType is the type of a single value of a field
+func (s *schema) typeScriptType(field protoreflect.FieldDescriptor) (string, error) {
+	switch kind := field.Kind(); {
+	case kind == protoreflect.EnumKind:
+		return s.typeName(field.Enum().FullName())
+	case kind == protoreflect.MessageKind || kind == protoreflect.GroupKind:
+		if field.Message().FullName() == timestampName {
+			return "string", nil
+		}
+		return s.typeName(field.Message().FullName())
+	case kind == protoreflect.BoolKind:
+		return "boolean", nil
+	case kind == protoreflect.StringKind || kind == protoreflect.BytesKind || is64Bit(kind):
+		return "string", nil
+	default:
+		return "number", nil
+	}
+}
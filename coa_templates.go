@@ -0,0 +1,160 @@
+package accounting
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+)
+
+// coaTemplateFiles holds the chart of accounts templates, one JSON file per template
+//
+//go:embed coa_templates/*.json
+var coaTemplateFiles embed.FS
+
+// ChartTemplate is a ready-made chart of accounts for an industry or locale.
+// Accounts are listed parents first.
+type ChartTemplate struct {
+	ID       string     `json:"id"`
+	Name     string     `json:"name"`
+	Industry string     `json:"industry"`
+	Locale   string     `json:"locale"`
+	Currency Currency   `json:"currency"`
+	Accounts []*Account `json:"accounts"`
+}
+
+// TemplateConflict is a template account that cannot be created as is
+type TemplateConflict struct {
+	AccountID         string `json:"account_id"`
+	Code              string `json:"code"`
+	Reason            string `json:"reason"`
+	ExistingAccountID string `json:"existing_account_id,omitempty"`
+}
+
+// TemplateValidation is the result of checking a template against the existing accounts
+type TemplateValidation struct {
+	TemplateID string             `json:"template_id"`
+	Accounts   int                `json:"accounts"` // accounts the template would create
+	Conflicts  []TemplateConflict `json:"conflicts,omitempty"`
+}
+
+// Valid reports whether the template can be loaded without collisions
+func (tv *TemplateValidation) Valid() bool {
+	return len(tv.Conflicts) == 0
+}
+
+// ListChartTemplates returns the available chart of accounts templates sorted by ID
+func ListChartTemplates() ([]*ChartTemplate, error) {
+	files, err := coaTemplateFiles.ReadDir("coa_templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chart templates: %w", err)
+	}
+
+	var templates []*ChartTemplate
+	for _, file := range files {
+		template, err := loadChartTemplate(path.Join("coa_templates", file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].ID < templates[j].ID
+	})
+	return templates, nil
+}
+
+// GetChartTemplate returns a chart of accounts template by ID
+func GetChartTemplate(templateID string) (*ChartTemplate, error) {
+	templates, err := ListChartTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for _, template := range templates {
+		if template.ID == templateID {
+			return template, nil
+		}
+	}
+	return nil, notFound("chart template", templateID)
+}
+
+// loadChartTemplate reads one template file and fills in the template currency
+func loadChartTemplate(name string) (*ChartTemplate, error) {
+	data, err := coaTemplateFiles.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chart template %s: %w", name, err)
+	}
+	var template ChartTemplate
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse chart template %s: %w", name, err)
+	}
+	for _, account := range template.Accounts {
+		if account.Currency == "" {
+			account.Currency = template.Currency
+		}
+	}
+	return &template, nil
+}
+
+// ValidateTemplate checks a template against the existing chart of accounts. Account
+// IDs and codes must not already be in use, and every parent must come earlier in the
+// template or already exist with the same account type.
+func (cs *ChartOfAccountsService) ValidateTemplate(templateID string) (*TemplateValidation, error) {
+	template, err := GetChartTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := cs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	byID := make(map[string]*Account, len(existing))
+	byCode := make(map[string]*Account, len(existing))
+	for _, account := range existing {
+		byID[account.ID] = account
+		byCode[account.Code] = account
+	}
+
+	validation := &TemplateValidation{TemplateID: template.ID, Accounts: len(template.Accounts)}
+	conflict := func(account *Account, reason, existingID string) {
+		validation.Conflicts = append(validation.Conflicts, TemplateConflict{
+			AccountID:         account.ID,
+			Code:              account.Code,
+			Reason:            reason,
+			ExistingAccountID: existingID,
+		})
+	}
+
+	seenIDs := make(map[string]*Account)
+	seenCodes := make(map[string]bool)
+	for _, account := range template.Accounts {
+		if other, ok := byID[account.ID]; ok {
+			conflict(account, "account ID already exists", other.ID)
+		} else if seenIDs[account.ID] != nil {
+			conflict(account, "account ID repeated in template", "")
+		}
+		if other, ok := byCode[account.Code]; ok {
+			conflict(account, "account code already in use", other.ID)
+		} else if seenCodes[account.Code] {
+			conflict(account, "account code repeated in template", "")
+		}
+
+		if account.ParentID != "" {
+			parent := seenIDs[account.ParentID]
+			if parent == nil {
+				parent = byID[account.ParentID]
+			}
+			if parent == nil {
+				conflict(account, fmt.Sprintf("parent account %s not found", account.ParentID), "")
+			} else if parent.Type != account.Type {
+				conflict(account, fmt.Sprintf("parent account %s is %s", parent.ID, parent.Type), "")
+			}
+		}
+
+		seenIDs[account.ID] = account
+		seenCodes[account.Code] = true
+	}
+	return validation, nil
+}
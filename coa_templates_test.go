@@ -0,0 +1,103 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChartTemplates(t *testing.T) {
+	t.Run("ships the standard templates", func(t *testing.T) {
+		templates, err := ListChartTemplates()
+		require.NoError(t, err)
+
+		ids := make([]string, len(templates))
+		for i, template := range templates {
+			ids[i] = template.ID
+			assert.NotEmpty(t, template.Accounts, template.ID)
+		}
+		assert.Equal(t, []string{"de_skr03", "nonprofit", "retail", "saas"}, ids)
+
+		skr03, err := GetChartTemplate("de_skr03")
+		require.NoError(t, err)
+		assert.Equal(t, "de-DE", skr03.Locale)
+		assert.Equal(t, Currency("EUR"), skr03.Accounts[0].Currency)
+
+		_, err = GetChartTemplate("missing")
+		assert.Error(t, err)
+	})
+
+	t.Run("loads a template into an empty ledger", func(t *testing.T) {
+		dbFile := "test_coa_templates.db"
+		defer os.Remove(dbFile)
+
+		engine, err := NewAccountingEngine(dbFile)
+		require.NoError(t, err)
+		defer engine.Close()
+
+		validation, err := engine.ValidateChartTemplate("saas")
+		require.NoError(t, err)
+		assert.True(t, validation.Valid())
+
+		accounts, err := engine.CreateAccountsFromTemplate("saas", "controller")
+		require.NoError(t, err)
+		assert.Equal(t, validation.Accounts, len(accounts))
+
+		hosting, err := engine.GetStorage().GetAccount("hosting")
+		require.NoError(t, err)
+		assert.Equal(t, "cost_of_revenue", hosting.ParentID)
+		assert.Equal(t, Currency("USD"), hosting.Currency)
+
+		txn := &Transaction{
+			Description: "AWS invoice",
+			ValidTime:   time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "hosting", Type: Debit, Amount: Amount{Value: 40000, Currency: "USD"}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: 40000, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, "controller"))
+		require.NoError(t, engine.PostTransaction(txn.ID, "controller"))
+
+		lines, err := engine.GetRollupTrialBalance(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), []AccountType{Expense})
+		require.NoError(t, err)
+		for _, line := range lines {
+			if line.AccountID == "cost_of_revenue" {
+				assert.Equal(t, int64(40000), line.RolledUpAmount.Value)
+			}
+		}
+	})
+
+	t.Run("rejects templates that collide with existing accounts", func(t *testing.T) {
+		dbFile := "test_coa_templates_conflict.db"
+		defer os.Remove(dbFile)
+
+		engine, err := NewAccountingEngine(dbFile)
+		require.NoError(t, err)
+		defer engine.Close()
+
+		require.NoError(t, engine.CreateStandardAccounts("controller"))
+
+		validation, err := engine.ValidateChartTemplate("retail")
+		require.NoError(t, err)
+		assert.False(t, validation.Valid())
+
+		conflicts := make(map[string]bool)
+		for _, conflict := range validation.Conflicts {
+			conflicts[conflict.AccountID+": "+conflict.Reason] = true
+		}
+		assert.True(t, conflicts["cash: account ID already exists"])
+		assert.True(t, conflicts["cash: account code already in use"])
+		assert.True(t, conflicts["inventory: account code already in use"]) // 1300 is the intercompany receivable
+		assert.NotContains(t, conflicts, "store_rent: account code already in use")
+
+		_, err = engine.CreateAccountsFromTemplate("retail", "controller")
+		assert.ErrorContains(t, err, "conflicts")
+
+		_, err = engine.GetStorage().GetAccount("inventory")
+		assert.Error(t, err, "nothing is created when the template collides")
+	})
+}
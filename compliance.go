@@ -2,10 +2,13 @@ package accounting
 
 import (
 	"fmt"
+	"log/slog"
+	"math/big"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 // ComplianceFramework represents different accounting standards
@@ -69,6 +72,30 @@ type TaxRule struct {
 	EffectiveFrom time.Time       `json:"effective_from"`
 	EffectiveTo   *time.Time      `json:"effective_to,omitempty"`
 	Active        bool            `json:"active"`
+
+	// Tiered rules tax each slice of the amount at its bracket's rate and ignore Rate
+	Brackets []TaxBracket `json:"brackets,omitempty"`
+	Compound bool         `json:"compound,omitempty"` // charged on the amount plus the taxes applied before it
+	Priority int          `json:"priority,omitempty"` // rules apply in ascending priority
+}
+
+// TaxBracket is one tier of a tiered rate: the part of the amount between From
+// and To is taxed at Rate
+type TaxBracket struct {
+	From float64 `json:"from"`
+	To   float64 `json:"to"` // 0 = no upper limit
+	Rate float64 `json:"rate"`
+}
+
+// TaxComponent is the tax one rule contributed to a calculation
+type TaxComponent struct {
+	RuleID        string   `json:"rule_id"`
+	Name          string   `json:"name"`
+	Compound      bool     `json:"compound,omitempty"`
+	TaxableAmount float64  `json:"taxable_amount"` // includes earlier taxes for compound rules
+	TaxRate       float64  `json:"tax_rate"`       // effective rate for tiered rules
+	TaxAmount     float64  `json:"tax_amount"`
+	Exemptions    []string `json:"exemptions,omitempty"`
 }
 
 // TaxCalculation represents a calculated tax amount
@@ -80,6 +107,10 @@ type TaxCalculation struct {
 	TaxAmount     float64   `json:"tax_amount"`
 	Exemptions    []string  `json:"exemptions"`
 	CalculatedAt  time.Time `json:"calculated_at"`
+	// How the tax was derived, kept so the calculation can be re-performed later
+	Breakdown *CalculationBreakdown `json:"breakdown,omitempty"`
+	// One per rule applied, in the order they were applied
+	Components []TaxComponent `json:"components,omitempty"`
 }
 
 // ComplianceViolation represents a compliance rule violation
@@ -90,10 +121,24 @@ type ComplianceViolation struct {
 	AccountID     string     `json:"account_id,omitempty"`
 	Description   string     `json:"description"`
 	Severity      string     `json:"severity"`
-	Status        string     `json:"status"` // "OPEN", "ACKNOWLEDGED", "RESOLVED"
+	Status        string     `json:"status"` // see ViolationOpen and the other violation statuses
 	DetectedAt    time.Time  `json:"detected_at"`
 	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
 	Notes         string     `json:"notes"`
+
+	// Remediation, see compliance_violations.go
+	AssignedTo        string             `json:"assigned_to,omitempty"`
+	AcknowledgedBy    string             `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt    *time.Time         `json:"acknowledged_at,omitempty"`
+	ResolvedBy        string             `json:"resolved_by,omitempty"` // who resolved or approved the waiver
+	DueAt             *time.Time         `json:"due_at,omitempty"`      // remediation deadline, from the severity
+	EscalatedAt       *time.Time         `json:"escalated_at,omitempty"`
+	WaiverRequestedBy string             `json:"waiver_requested_by,omitempty"`
+	WaivedBy          string             `json:"waived_by,omitempty"`
+	RecurrenceOf      string             `json:"recurrence_of,omitempty"` // first violation of the same rule on the same account
+	Occurrences       int                `json:"occurrences,omitempty"`   // of the rule on the account, this one included
+	Comments          []ViolationComment `json:"comments,omitempty"`
+	UpdatedAt         time.Time          `json:"updated_at,omitempty"`
 }
 
 // TaxReturn represents a tax filing/return
@@ -113,26 +158,51 @@ type TaxReturn struct {
 	FiledAt        *time.Time       `json:"filed_at,omitempty"`
 	DueDate        time.Time        `json:"due_date"`
 	Calculations   []TaxCalculation `json:"calculations"`
-	Attachments    []string         `json:"attachments"`
+	Attachments    []string         `json:"attachments"` // document IDs, see DocumentService
 	CreatedAt      time.Time        `json:"created_at"`
 	UpdatedAt      time.Time        `json:"updated_at"`
+
+	// VAT returns only, see VATReturnService
+	VATFormat    VATReturnFormat `json:"vat_format,omitempty"`
+	VATMappingID string          `json:"vat_mapping_id,omitempty"`
+	Currency     Currency        `json:"currency,omitempty"`
+	PeriodKey    string          `json:"period_key,omitempty"` // the tax authority's reference for the period
+	VATBoxes     []VATBoxValue   `json:"vat_boxes,omitempty"`
 }
 
 // ComplianceService handles regulatory and tax compliance
 type ComplianceService struct {
-	storage Storage
+	storage     Storage
+	materiality *MaterialityService
+	workflows   *WorkflowService
+	remediation violationRemediation
+	logger      *slog.Logger
 }
 
 // NewComplianceService creates a new compliance service
 func NewComplianceService(storage Storage) *ComplianceService {
-	return &ComplianceService{
+	cs := &ComplianceService{
 		storage: storage,
+		logger:  discardLogger,
 	}
+	cs.UseWorkflows(NewWorkflowService(&cs.storage))
+	return cs
+}
+
+// SetLogger sends the service's logs to a host logger; nil discards them
+func (cs *ComplianceService) SetLogger(logger *slog.Logger) {
+	cs.logger = componentLogger(logger, "compliance")
+}
+
+// SetMaterialityService lets materiality rules use assessed materiality instead of a
+// fixed amount
+func (cs *ComplianceService) SetMaterialityService(materiality *MaterialityService) {
+	cs.materiality = materiality
 }
 
 // CreateComplianceRule creates a new compliance rule
 func (cs *ComplianceService) CreateComplianceRule(rule ComplianceRule) error {
-	rule.ID = uuid.New().String()
+	rule.ID = newID()
 	rule.CreatedAt = time.Now()
 	rule.Active = true
 
@@ -141,23 +211,104 @@ func (cs *ComplianceService) CreateComplianceRule(rule ComplianceRule) error {
 
 // CreateTaxRule creates a new tax rule
 func (cs *ComplianceService) CreateTaxRule(rule TaxRule) error {
-	rule.ID = uuid.New().String()
+	if err := rule.validate(); err != nil {
+		return classify(ErrValidation, "invalid tax rule %s: %v", rule.Name, err)
+	}
+	rule.ID = newID()
 	rule.Active = true
 
 	return cs.storage.SaveTaxRule(&rule)
 }
 
-// CalculateTax calculates tax for a given amount and jurisdiction
+// CalculateTax calculates tax for a given amount and jurisdiction. Every rule in
+// effect for the amount applies, in priority order, and the calculation carries
+// one component per rule; compound rules are charged on the amount plus the taxes
+// before them. Amounts are not rounded, see CalculateTaxOnAmount.
 func (cs *ComplianceService) CalculateTax(amount float64, jurisdiction TaxJurisdiction, taxType TaxType, exemptions []string) (*TaxCalculation, error) {
+	exact, err := decimalRat(amount)
+	if err != nil {
+		return nil, classify(ErrValidation, "invalid taxable amount: %v", err)
+	}
+	calc, _, _, err := cs.calculateTax(exact, jurisdiction, taxType, exemptions, nil)
+	if err != nil {
+		return nil, err
+	}
+	calc.Breakdown = taxBreakdown(calc, exemptions, "")
+	return calc, nil
+}
+
+// CalculateTaxOnAmount calculates tax on an amount in minor units, rounding the tax
+// to the number of decimal places the amount's currency carries. The jurisdiction's
+// rounding rule decides the policy and whether each rule's tax or only the total
+// is rounded.
+func (cs *ComplianceService) CalculateTaxOnAmount(amount *Amount, jurisdiction TaxJurisdiction, taxType TaxType, exemptions []string) (*TaxCalculation, error) {
+	calc, _, err := cs.calculateTaxOnAmount(amount, jurisdiction, taxType, exemptions)
+	return calc, err
+}
+
+// calculateTaxOnAmount is CalculateTaxOnAmount, also returning the rounded tax in
+// the currency's minor units so callers don't round it a second time
+func (cs *ComplianceService) calculateTaxOnAmount(amount *Amount, jurisdiction TaxJurisdiction, taxType TaxType, exemptions []string) (*TaxCalculation, int64, error) {
+	rounding, err := cs.GetTaxRounding(jurisdiction)
+	if err != nil {
+		return nil, 0, err
+	}
+	scale := pow10Rat(MinorUnits(amount.Currency))
+	toMinor := func(value *big.Rat) (int64, error) {
+		minor, err := roundRat(new(big.Rat).Mul(value, scale), rounding.Policy)
+		if err != nil {
+			return 0, fmt.Errorf("failed to round tax amount: %w", err)
+		}
+		return minor, nil
+	}
+
+	var perRule func(*big.Rat) (*big.Rat, error)
+	if rounding.Level != TaxRoundTotal {
+		perRule = func(value *big.Rat) (*big.Rat, error) {
+			minor, err := toMinor(value)
+			if err != nil {
+				return nil, err
+			}
+			return new(big.Rat).Quo(new(big.Rat).SetInt64(minor), scale), nil
+		}
+	}
+	base := new(big.Rat).Quo(new(big.Rat).SetInt64(amount.Value), scale)
+	calc, total, unrounded, err := cs.calculateTax(base, jurisdiction, taxType, exemptions, perRule)
+	if err != nil {
+		return nil, 0, err
+	}
+	tax, err := toMinor(total)
+	if err != nil {
+		return nil, 0, err
+	}
+	calc.TaxAmount = ToMajorUnits(tax, amount.Currency)
+
+	before, _ := unrounded.Float64()
+	calc.Breakdown = taxBreakdown(calc, exemptions, string(amount.Currency))
+	calc.Breakdown.AddStep("tax before rounding", formatCalculationNumber(before), string(amount.Currency),
+		fmt.Sprintf("rounded %s to %d decimal places %s", rounding.describePolicy(), MinorUnits(amount.Currency), rounding.describeLevel()))
+	return calc, tax, nil
+}
+
+// calculateTax applies the rules in effect for the amount, keeping every tax exact.
+// round, when set, rounds each rule's tax before later compound rules build on it.
+// The total tax, rounded per rule or not, and the unrounded total are returned
+// alongside the calculation.
+func (cs *ComplianceService) calculateTax(
+	amount *big.Rat,
+	jurisdiction TaxJurisdiction,
+	taxType TaxType,
+	exemptions []string,
+	round func(*big.Rat) (*big.Rat, error),
+) (*TaxCalculation, *big.Rat, *big.Rat, error) {
 	rules, err := cs.storage.GetTaxRulesByJurisdiction(jurisdiction, taxType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tax rules: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to get tax rules: %w", err)
 	}
 
+	baseAmount, _ := amount.Float64()
 	now := time.Now()
-	var applicableRule *TaxRule
-
-	// Find the most recent applicable rule
+	var applicable []*TaxRule
 	for _, rule := range rules {
 		if !rule.Active {
 			continue
@@ -172,54 +323,81 @@ func (cs *ComplianceService) CalculateTax(amount float64, jurisdiction TaxJurisd
 		}
 
 		// Check if amount is within rule limits
-		if rule.MinAmount > 0 && amount < rule.MinAmount {
+		if rule.MinAmount > 0 && baseAmount < rule.MinAmount {
 			continue
 		}
 
-		if rule.MaxAmount > 0 && amount > rule.MaxAmount {
+		if rule.MaxAmount > 0 && baseAmount > rule.MaxAmount {
 			continue
 		}
 
-		applicableRule = rule
-		break
-	}
-
-	if applicableRule == nil {
-		return &TaxCalculation{
-			BaseAmount:    amount,
-			TaxableAmount: 0,
-			TaxRate:       0,
-			TaxAmount:     0,
-			Exemptions:    exemptions,
-			CalculatedAt:  now,
-		}, nil
+		applicable = append(applicable, rule)
 	}
+	sort.Slice(applicable, func(i, j int) bool {
+		if applicable[i].Priority != applicable[j].Priority {
+			return applicable[i].Priority < applicable[j].Priority
+		}
+		return applicable[i].ID < applicable[j].ID
+	})
+
+	calc := &TaxCalculation{
+		BaseAmount:   baseAmount,
+		Exemptions:   []string{},
+		CalculatedAt: now,
+	}
+	total := new(big.Rat)
+	unrounded := new(big.Rat)
+	for _, rule := range applicable {
+		component := TaxComponent{
+			RuleID:   rule.ID,
+			Name:     rule.Name,
+			Compound: rule.Compound,
+			TaxRate:  rule.Rate,
+		}
+		taxable := amount
+		if rule.Compound {
+			taxable = new(big.Rat).Add(amount, total)
+		}
 
-	// Check exemptions
-	taxableAmount := amount
-	appliedExemptions := []string{}
-
-	for _, exemption := range exemptions {
-		for _, ruleExemption := range applicableRule.Exemptions {
-			if strings.EqualFold(exemption, ruleExemption) {
-				taxableAmount = 0
-				appliedExemptions = append(appliedExemptions, exemption)
-				break
+		// Check exemptions
+		for _, exemption := range exemptions {
+			if slices.ContainsFunc(rule.Exemptions, func(ruleExemption string) bool { return strings.EqualFold(exemption, ruleExemption) }) {
+				taxable = new(big.Rat)
+				component.Exemptions = append(component.Exemptions, exemption)
+				if !slices.Contains(calc.Exemptions, exemption) {
+					calc.Exemptions = append(calc.Exemptions, exemption)
+				}
 			}
 		}
-	}
 
-	taxAmount := taxableAmount * applicableRule.Rate
+		tax, err := rule.taxOn(taxable)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to apply tax rule %s: %w", rule.Name, err)
+		}
+		if len(rule.Brackets) > 0 && taxable.Sign() > 0 {
+			component.TaxRate, _ = new(big.Rat).Quo(tax, taxable).Float64()
+		}
+		unrounded.Add(unrounded, tax)
+		if round != nil {
+			if tax, err = round(tax); err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		total.Add(total, tax)
+		component.TaxableAmount, _ = taxable.Float64()
+		component.TaxAmount, _ = tax.Float64()
 
-	return &TaxCalculation{
-		RuleID:        applicableRule.ID,
-		BaseAmount:    amount,
-		TaxableAmount: taxableAmount,
-		TaxRate:       applicableRule.Rate,
-		TaxAmount:     taxAmount,
-		Exemptions:    appliedExemptions,
-		CalculatedAt:  now,
-	}, nil
+		if calc.RuleID == "" {
+			calc.RuleID = rule.ID
+		}
+		if taxable.Sign() > 0 {
+			calc.TaxableAmount = baseAmount
+		}
+		calc.TaxRate += component.TaxRate
+		calc.Components = append(calc.Components, component)
+	}
+	calc.TaxAmount, _ = total.Float64()
+	return calc, total, unrounded, nil
 }
 
 // ValidateTransaction validates a transaction against compliance rules
@@ -238,10 +416,17 @@ func (cs *ComplianceService) ValidateTransaction(transaction Transaction) ([]Com
 
 		violation := cs.checkTransactionAgainstRule(transaction, *rule)
 		if violation != nil {
+			cs.logger.Warn("compliance rule violated",
+				LogKeyRuleID, rule.ID,
+				LogKeyTransactionID, transaction.ID,
+				"severity", violation.Severity,
+				"description", violation.Description)
 			violations = append(violations, *violation)
 		}
 	}
 
+	cs.logger.Debug("transaction checked against compliance rules",
+		LogKeyTransactionID, transaction.ID, "rules", len(rules), "violations", len(violations))
 	return violations, nil
 }
 
@@ -263,48 +448,77 @@ func (cs *ComplianceService) checkTransactionAgainstRule(transaction Transaction
 	}
 }
 
-// checkSegregationOfDuties validates segregation of duties
+// checkSegregationOfDuties flags a transaction whose maker also posted or approved it.
+// Transactions not yet posted or approved have nothing to compare.
 func (cs *ComplianceService) checkSegregationOfDuties(transaction Transaction, rule ComplianceRule) *ComplianceViolation {
-	// Check if the same user created and approved the transaction
-	// Since the base Transaction struct doesn't have approval fields, we'll check if UserID is repeated
-	if transaction.UserID != "" {
-		return &ComplianceViolation{
-			ID:            uuid.New().String(),
-			RuleID:        rule.ID,
-			TransactionID: transaction.ID,
-			Description:   "Transaction requires segregation of duties validation",
-			Severity:      rule.Severity,
-			Status:        "OPEN",
-			DetectedAt:    time.Now(),
-		}
+	maker := transactionMaker(&transaction)
+	if maker == "" {
+		return nil
+	}
+	var description string
+	switch maker {
+	case transaction.ApprovedBy:
+		description = fmt.Sprintf("Transaction was created and approved by the same user (%s)", maker)
+	case transaction.PostedBy:
+		description = fmt.Sprintf("Transaction was created and posted by the same user (%s)", maker)
+	default:
+		return nil
+	}
+	return &ComplianceViolation{
+		ID:            newID(),
+		RuleID:        rule.ID,
+		TransactionID: transaction.ID,
+		Description:   description,
+		Severity:      rule.Severity,
+		Status:        "OPEN",
+		DetectedAt:    time.Now(),
 	}
-	return nil
 }
 
-// checkMaterialityThreshold validates materiality thresholds
+// checkMaterialityThreshold flags transactions above the materiality threshold. The
+// performance materiality in effect on the transaction date takes precedence, scoped
+// to a company by a MATERIALITY_COMPANY=<id> condition; without an assessment the
+// AMOUNT_THRESHOLD=<amount> condition, in major units, is used.
 func (cs *ComplianceService) checkMaterialityThreshold(transaction Transaction, rule ComplianceRule) *ComplianceViolation {
-	// Extract threshold from conditions (simplified)
 	var threshold float64 = 10000 // Default threshold
+	companyID := ""
 
 	for _, condition := range rule.Conditions {
-		if strings.Contains(condition, "AMOUNT_THRESHOLD") {
-			// Parse threshold from condition string
-			// In a real implementation, this would be more sophisticated
-			threshold = 10000
+		key, value, found := strings.Cut(condition, "=")
+		if !found {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "AMOUNT_THRESHOLD":
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				threshold = parsed
+			}
+		case "MATERIALITY_COMPANY":
+			companyID = strings.TrimSpace(value)
+		}
+	}
+
+	source := "materiality threshold"
+	if cs.materiality != nil {
+		if assessment, err := cs.materiality.GetEffectiveMateriality(companyID, transaction.ValidTime); err == nil {
+			threshold = ToMajorUnits(assessment.Performance.Value, assessment.Performance.Currency)
+			source = fmt.Sprintf("performance materiality (%s)", assessment.Benchmark)
 		}
 	}
 
 	totalAmount := 0.0
 	for _, entry := range transaction.Entries {
-		totalAmount += float64(entry.Amount.Value) / 100 // Convert from cents
+		if entry.Type == Debit {
+			totalAmount += ToMajorUnits(entry.Amount.Value, entry.Amount.Currency)
+		}
 	}
 
 	if totalAmount > threshold {
 		return &ComplianceViolation{
-			ID:            uuid.New().String(),
+			ID:            newID(),
 			RuleID:        rule.ID,
 			TransactionID: transaction.ID,
-			Description:   fmt.Sprintf("Transaction amount %.2f exceeds materiality threshold %.2f", totalAmount, threshold),
+			Description:   fmt.Sprintf("Transaction amount %.2f exceeds %s %.2f", totalAmount, source, threshold),
 			Severity:      rule.Severity,
 			Status:        "OPEN",
 			DetectedAt:    time.Now(),
@@ -318,7 +532,7 @@ func (cs *ComplianceService) checkAuthorizationRequired(transaction Transaction,
 	// Since base Transaction doesn't have approval fields, we check basic validation
 	if transaction.Status == Pending {
 		return &ComplianceViolation{
-			ID:            uuid.New().String(),
+			ID:            newID(),
 			RuleID:        rule.ID,
 			TransactionID: transaction.ID,
 			Description:   "Transaction requires authorization before posting",
@@ -346,7 +560,7 @@ func (cs *ComplianceService) checkJournalEntryBalance(transaction Transaction, r
 	// Allow for small rounding differences
 	if abs64(totalDebits-totalCredits) > 1 { // 1 cent tolerance
 		return &ComplianceViolation{
-			ID:            uuid.New().String(),
+			ID:            newID(),
 			RuleID:        rule.ID,
 			TransactionID: transaction.ID,
 			Description:   fmt.Sprintf("Journal entry not balanced: Debits=%d, Credits=%d", totalDebits, totalCredits),
@@ -367,7 +581,7 @@ func (cs *ComplianceService) checkAccountTypeRestriction(transaction Transaction
 
 // CreateTaxReturn creates a new tax return
 func (cs *ComplianceService) CreateTaxReturn(taxReturn TaxReturn) error {
-	taxReturn.ID = uuid.New().String()
+	taxReturn.ID = newID()
 	taxReturn.CreatedAt = time.Now()
 	taxReturn.UpdatedAt = time.Now()
 	taxReturn.FilingStatus = "DRAFT"
@@ -392,11 +606,11 @@ func (cs *ComplianceService) CalculateTaxReturn(companyID string, jurisdiction T
 		for _, entry := range transaction.Entries {
 			// Check if this is revenue (credit entry to revenue account)
 			if entry.Type == Credit && strings.Contains(strings.ToLower(entry.AccountID), "revenue") {
-				entryAmount := float64(entry.Amount.Value) / 100 // Convert from cents
+				entryAmount := ToMajorUnits(entry.Amount.Value, entry.Amount.Currency)
 				grossRevenue += entryAmount
 
 				// Calculate tax on this entry
-				calc, err := cs.CalculateTax(entryAmount, jurisdiction, taxType, []string{})
+				calc, err := cs.CalculateTaxOnAmount(&entry.Amount, jurisdiction, taxType, []string{})
 				if err != nil {
 					continue
 				}
@@ -438,21 +652,6 @@ func (cs *ComplianceService) GetComplianceViolations(companyID string) ([]*Compl
 	return cs.storage.GetComplianceViolations(companyID)
 }
 
-// ResolveViolation marks a compliance violation as resolved
-func (cs *ComplianceService) ResolveViolation(violationID, notes string) error {
-	violation, err := cs.storage.GetComplianceViolation(violationID)
-	if err != nil {
-		return fmt.Errorf("failed to get violation: %w", err)
-	}
-
-	now := time.Now()
-	violation.Status = "RESOLVED"
-	violation.ResolvedAt = &now
-	violation.Notes = notes
-
-	return cs.storage.SaveComplianceViolation(violation)
-}
-
 // SetupStandardComplianceRules creates standard compliance rules
 func (cs *ComplianceService) SetupStandardComplianceRules(framework ComplianceFramework) error {
 	var rules []ComplianceRule
@@ -0,0 +1,499 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Compliance Violation Remediation
+// ----------------------------------------------------------------------------
+
+// Compliance violation statuses
+const (
+	ViolationOpen            = "OPEN"
+	ViolationAcknowledged    = "ACKNOWLEDGED"
+	ViolationInRemediation   = "IN_REMEDIATION" // assigned to someone to fix
+	ViolationEscalated       = "ESCALATED"
+	ViolationWaiverRequested = "WAIVER_REQUESTED"
+	ViolationWaived          = "WAIVED" // accepted without remediation, with approval
+	ViolationResolved        = "RESOLVED"
+)
+
+// WorkflowComplianceViolation is the remediation of compliance violations; its
+// subjects are violation IDs
+const WorkflowComplianceViolation = "compliance_violation"
+
+// violationRemediationDays is how long a violation of each severity may stay
+// unremediated before it is overdue
+var violationRemediationDays = map[string]int{
+	"ERROR":   7,
+	"WARNING": 30,
+	"INFO":    90,
+}
+
+// defaultViolationRemediationDays applies to severities not listed above
+const defaultViolationRemediationDays = 30
+
+// ViolationComment is a note on a violation, either on its own or given with an
+// action such as a resolution or a waiver request
+type ViolationComment struct {
+	UserID string    `json:"user_id"`
+	Action string    `json:"action,omitempty"`
+	Text   string    `json:"text"`
+	At     time.Time `json:"at"`
+}
+
+// violationRemediation holds who approves waivers and who overdue violations are
+// escalated to
+type violationRemediation struct {
+	mu                 sync.RWMutex
+	waiverApprovers    []string // empty means anyone but the requester
+	escalationContacts []string // empty leaves escalated violations with their assignee
+}
+
+// violationActive are the statuses a violation is awaiting remediation in
+var violationActive = []string{ViolationOpen, ViolationAcknowledged, ViolationInRemediation, ViolationEscalated}
+
+// UseWorkflows runs violation remediation on a shared workflow service. Call it while
+// the engine is being assembled.
+func (cs *ComplianceService) UseWorkflows(workflows *WorkflowService) {
+	workflows.mustRegister(cs.violationWorkflow())
+	cs.workflows = workflows
+}
+
+// SetViolationWaiverApprovers designates the users who may approve violation waivers
+func (cs *ComplianceService) SetViolationWaiverApprovers(userIDs []string) {
+	cs.remediation.mu.Lock()
+	defer cs.remediation.mu.Unlock()
+	cs.remediation.waiverApprovers = append([]string(nil), userIDs...)
+}
+
+// SetViolationEscalationContacts designates the users overdue violations are
+// escalated to
+func (cs *ComplianceService) SetViolationEscalationContacts(userIDs []string) {
+	cs.remediation.mu.Lock()
+	defer cs.remediation.mu.Unlock()
+	cs.remediation.escalationContacts = append([]string(nil), userIDs...)
+}
+
+// RecordViolation saves a new violation as OPEN with a remediation deadline from its
+// severity. Earlier violations of the same rule on the same account make it a
+// recurrence of the first of them.
+func (cs *ComplianceService) RecordViolation(violation *ComplianceViolation, userID string) error {
+	if violation.RuleID == "" {
+		return classify(ErrValidation, "violation rule is required")
+	}
+	if violation.Description == "" {
+		return classify(ErrValidation, "violation description is required")
+	}
+	if violation.ID == "" {
+		violation.ID = newID()
+	}
+	if violation.DetectedAt.IsZero() {
+		violation.DetectedAt = time.Now()
+	}
+	days, ok := violationRemediationDays[violation.Severity]
+	if !ok {
+		days = defaultViolationRemediationDays
+	}
+	due := violation.DetectedAt.AddDate(0, 0, days)
+	violation.Status = ViolationOpen
+	violation.DueAt = &due
+	violation.UpdatedAt = time.Now()
+
+	existing, err := cs.storage.GetComplianceViolations("")
+	if err != nil {
+		return fmt.Errorf("failed to get violations: %w", err)
+	}
+	var first *ComplianceViolation
+	violation.Occurrences = 1
+	for _, earlier := range existing {
+		if earlier.ID == violation.ID || earlier.RuleID != violation.RuleID || earlier.AccountID != violation.AccountID {
+			continue
+		}
+		if earlier.DetectedAt.After(violation.DetectedAt) {
+			continue
+		}
+		violation.Occurrences++
+		if first == nil || earlier.DetectedAt.Before(first.DetectedAt) {
+			first = earlier
+		}
+	}
+	if first != nil {
+		violation.RecurrenceOf = first.ID
+		cs.logger.Warn("compliance violation recurred", LogKeyViolationID, violation.ID, LogKeyRuleID, violation.RuleID,
+			LogKeyAccountID, violation.AccountID, "occurrences", violation.Occurrences, "first", first.ID)
+	}
+
+	if err := cs.storage.SaveComplianceViolation(violation); err != nil {
+		return fmt.Errorf("failed to save violation: %w", err)
+	}
+	if _, err := cs.workflows.Start(WorkflowComplianceViolation, violation.ID); err != nil {
+		return fmt.Errorf("failed to start violation workflow: %w", err)
+	}
+	cs.logger.Info("compliance violation recorded", LogKeyViolationID, violation.ID, LogKeyRuleID, violation.RuleID, LogKeyUserID, userID)
+	return nil
+}
+
+// RecordTransactionViolations checks a transaction against the compliance rules and
+// records what it breaks
+func (cs *ComplianceService) RecordTransactionViolations(transaction *Transaction, userID string) ([]*ComplianceViolation, error) {
+	found, err := cs.ValidateTransaction(*transaction)
+	if err != nil {
+		return nil, err
+	}
+	var recorded []*ComplianceViolation
+	for i := range found {
+		violation := &found[i]
+		if err := cs.RecordViolation(violation, userID); err != nil {
+			return recorded, err
+		}
+		recorded = append(recorded, violation)
+	}
+	return recorded, nil
+}
+
+// AcknowledgeViolation records that someone has seen a violation and taken it on
+func (cs *ComplianceService) AcknowledgeViolation(violationID, comment, userID string) (*ComplianceViolation, error) {
+	return cs.fireViolation(violationID, &WorkflowRequest{Action: "acknowledge", Actor: userID, Comment: comment})
+}
+
+// AssignViolation hands a violation to a user to remediate
+func (cs *ComplianceService) AssignViolation(violationID, assignee, comment, userID string) (*ComplianceViolation, error) {
+	if assignee == "" {
+		return nil, classify(ErrValidation, "assignee is required")
+	}
+	return cs.fireViolation(violationID, &WorkflowRequest{
+		Action: "assign", Actor: userID, Comment: comment, Assignees: []string{assignee},
+	})
+}
+
+// ResolveViolation marks a violation remediated, with notes on what was done. Only
+// its assignees may resolve an assigned violation.
+func (cs *ComplianceService) ResolveViolation(violationID, notes, userID string) (*ComplianceViolation, error) {
+	return cs.fireViolation(violationID, &WorkflowRequest{Action: "resolve", Actor: userID, Comment: notes, Assignees: []string{}})
+}
+
+// RequestViolationWaiver asks for a violation to be accepted without remediation.
+// The waiver approvers, or anyone else when none are set, decide on it.
+func (cs *ComplianceService) RequestViolationWaiver(violationID, reason, userID string) (*ComplianceViolation, error) {
+	cs.remediation.mu.RLock()
+	approvers := append([]string{}, cs.remediation.waiverApprovers...)
+	cs.remediation.mu.RUnlock()
+	return cs.fireViolation(violationID, &WorkflowRequest{Action: "request_waiver", Actor: userID, Comment: reason, Assignees: approvers})
+}
+
+// ApproveViolationWaiver waives a violation. The requester cannot approve their own
+// waiver.
+func (cs *ComplianceService) ApproveViolationWaiver(violationID, comment, userID string) (*ComplianceViolation, error) {
+	return cs.fireViolation(violationID, &WorkflowRequest{Action: "approve_waiver", Actor: userID, Comment: comment, Assignees: []string{}})
+}
+
+// RejectViolationWaiver turns down a waiver request, returning the violation to its
+// assignee
+func (cs *ComplianceService) RejectViolationWaiver(violationID, comment, userID string) (*ComplianceViolation, error) {
+	return cs.returnToAssignee(violationID, &WorkflowRequest{Action: "reject_waiver", Actor: userID, Comment: comment})
+}
+
+// ReopenViolation reopens a resolved or waived violation with a fresh deadline,
+// back with its assignee
+func (cs *ComplianceService) ReopenViolation(violationID, reason, userID string) (*ComplianceViolation, error) {
+	return cs.returnToAssignee(violationID, &WorkflowRequest{Action: "reopen", Actor: userID, Comment: reason})
+}
+
+// returnToAssignee takes an action that hands a violation back to whoever it was
+// assigned to, or to no one in particular
+func (cs *ComplianceService) returnToAssignee(violationID string, request *WorkflowRequest) (*ComplianceViolation, error) {
+	violation, err := cs.storage.GetComplianceViolation(violationID)
+	if err != nil {
+		return nil, err
+	}
+	request.Assignees = []string{}
+	if violation.AssignedTo != "" {
+		request.Assignees = []string{violation.AssignedTo}
+	}
+	return cs.fireViolation(violationID, request)
+}
+
+// CommentOnViolation adds a comment to a violation without changing its status
+func (cs *ComplianceService) CommentOnViolation(violationID, text, userID string) (*ComplianceViolation, error) {
+	if text == "" {
+		return nil, classify(ErrValidation, "comment text is required")
+	}
+	violation, err := cs.storage.GetComplianceViolation(violationID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	violation.Comments = append(violation.Comments, ViolationComment{UserID: userID, Text: text, At: now})
+	violation.UpdatedAt = now
+	if err := cs.storage.SaveComplianceViolation(violation); err != nil {
+		return nil, fmt.Errorf("failed to save violation: %w", err)
+	}
+	return violation, nil
+}
+
+// EscalateOverdueViolations escalates every violation still awaiting remediation
+// after its deadline to the escalation contacts. Escalated violations are not
+// escalated again.
+func (cs *ComplianceService) EscalateOverdueViolations(asOf time.Time) ([]*ComplianceViolation, error) {
+	violations, err := cs.storage.GetComplianceViolations("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get violations: %w", err)
+	}
+	sort.Slice(violations, func(i, j int) bool { return violations[i].DetectedAt.Before(violations[j].DetectedAt) })
+
+	cs.remediation.mu.RLock()
+	contacts := cs.remediation.escalationContacts
+	cs.remediation.mu.RUnlock()
+	var assignees []string // nil keeps the current assignees
+	if len(contacts) > 0 {
+		assignees = append([]string(nil), contacts...)
+	}
+
+	var escalated []*ComplianceViolation
+	for _, violation := range violations {
+		if violation.DueAt == nil || !violation.DueAt.Before(asOf) || violation.Status == ViolationEscalated {
+			continue
+		}
+		if !slices.Contains(violationActive, cs.violationStatus(violation)) {
+			continue
+		}
+		updated, err := cs.fireViolation(violation.ID, &WorkflowRequest{
+			Action:    "escalate",
+			Actor:     workflowTimerUserID,
+			Comment:   fmt.Sprintf("remediation was due %s", violation.DueAt.Format("2006-01-02")),
+			Assignees: assignees,
+		})
+		if err != nil {
+			return escalated, fmt.Errorf("failed to escalate violation %s: %w", violation.ID, err)
+		}
+		cs.logger.Warn("overdue compliance violation escalated", LogKeyViolationID, violation.ID, LogKeyRuleID, violation.RuleID)
+		escalated = append(escalated, updated)
+	}
+	return escalated, nil
+}
+
+// fireViolation takes a remediation action and returns the updated violation
+func (cs *ComplianceService) fireViolation(violationID string, request *WorkflowRequest) (*ComplianceViolation, error) {
+	if _, err := cs.workflows.Fire(WorkflowComplianceViolation, violationID, request); err != nil {
+		return nil, err
+	}
+	return cs.storage.GetComplianceViolation(violationID)
+}
+
+// violationStatus is a violation's status, reading violations saved without one as
+// OPEN
+func (cs *ComplianceService) violationStatus(violation *ComplianceViolation) string {
+	if violation.Status == "" {
+		return ViolationOpen
+	}
+	return violation.Status
+}
+
+// violationWorkflow defines how violations move from detected to resolved or waived
+func (cs *ComplianceService) violationWorkflow() *WorkflowDefinition {
+	return &WorkflowDefinition{
+		Name: WorkflowComplianceViolation,
+		Transitions: []WorkflowTransition{
+			{Action: "acknowledge", From: []string{ViolationOpen, ViolationEscalated}, To: ViolationAcknowledged, Guard: AssigneesOnly},
+			{Action: "assign", From: violationActive, To: ViolationInRemediation},
+			{Action: "escalate", From: []string{ViolationOpen, ViolationAcknowledged, ViolationInRemediation}, To: ViolationEscalated},
+			{Action: "request_waiver", From: violationActive, To: ViolationWaiverRequested, Guard: AssigneesOnly, RequireComment: true},
+			{Action: "approve_waiver", From: []string{ViolationWaiverRequested}, To: ViolationWaived, Guard: cs.canDecideWaiver},
+			{Action: "reject_waiver", From: []string{ViolationWaiverRequested}, To: ViolationAcknowledged, Guard: cs.canDecideWaiver, RequireComment: true},
+			{Action: "resolve", From: violationActive, To: ViolationResolved, Guard: AssigneesOnly, RequireComment: true},
+			{Action: "reopen", From: []string{ViolationResolved, ViolationWaived}, To: ViolationOpen, RequireComment: true},
+		},
+		State: func(violationID string) (string, error) {
+			violation, err := cs.storage.GetComplianceViolation(violationID)
+			if err != nil {
+				return "", err
+			}
+			return cs.violationStatus(violation), nil
+		},
+		NotAllowed: func(instance *WorkflowInstance, request *WorkflowRequest) error {
+			return classify(ErrConflict, "cannot %s compliance violation %s: violation is %s", request.Action, instance.SubjectID, instance.State)
+		},
+		OnTransition: cs.applyViolationTransition,
+	}
+}
+
+// canDecideWaiver lets a waiver approver other than the requester decide on a waiver
+func (cs *ComplianceService) canDecideWaiver(instance *WorkflowInstance, request *WorkflowRequest) error {
+	violation, err := cs.storage.GetComplianceViolation(instance.SubjectID)
+	if err != nil {
+		return err
+	}
+	if request.Actor == violation.WaiverRequestedBy {
+		return classify(ErrPermissionDenied, "%s requested the waiver of violation %s and cannot decide on it", request.Actor, violation.ID)
+	}
+	cs.remediation.mu.RLock()
+	defer cs.remediation.mu.RUnlock()
+	if approvers := cs.remediation.waiverApprovers; len(approvers) > 0 && !slices.Contains(approvers, request.Actor) {
+		return classify(ErrPermissionDenied, "%s is not a violation waiver approver", request.Actor)
+	}
+	return nil
+}
+
+// applyViolationTransition records a violation's new status and who moved it there
+func (cs *ComplianceService) applyViolationTransition(instance *WorkflowInstance, request *WorkflowRequest, _ string) error {
+	violation, err := cs.storage.GetComplianceViolation(instance.SubjectID)
+	if err != nil {
+		return err
+	}
+	now := instance.EnteredAt
+	violation.Status = instance.State
+	violation.UpdatedAt = now
+
+	switch request.Action {
+	case "acknowledge":
+		violation.AcknowledgedBy = request.Actor
+		violation.AcknowledgedAt = &now
+	case "assign":
+		violation.AssignedTo = request.Assignees[0]
+	case "escalate":
+		violation.EscalatedAt = &now
+	case "request_waiver":
+		violation.WaiverRequestedBy = request.Actor
+	case "reject_waiver":
+		violation.WaiverRequestedBy = ""
+	case "approve_waiver":
+		violation.WaivedBy = request.Actor
+		violation.ResolvedBy = request.Actor
+		violation.ResolvedAt = &now
+	case "resolve":
+		violation.ResolvedBy = request.Actor
+		violation.ResolvedAt = &now
+		violation.Notes = request.Comment
+	case "reopen":
+		days, ok := violationRemediationDays[violation.Severity]
+		if !ok {
+			days = defaultViolationRemediationDays
+		}
+		due := now.AddDate(0, 0, days)
+		violation.DueAt = &due
+		violation.ResolvedAt, violation.ResolvedBy = nil, ""
+		violation.WaiverRequestedBy, violation.WaivedBy = "", ""
+		violation.EscalatedAt = nil
+	}
+	if request.Comment != "" {
+		violation.Comments = append(violation.Comments, ViolationComment{
+			UserID: request.Actor, Action: request.Action, Text: request.Comment, At: now,
+		})
+	}
+	return cs.storage.SaveComplianceViolation(violation)
+}
+
+// ----------------------------------------------------------------------------
+// Violation Aging
+// ----------------------------------------------------------------------------
+
+// ViolationAgingBucket counts unremediated violations by days since detection
+type ViolationAgingBucket struct {
+	Label      string         `json:"label"`
+	MinDays    int            `json:"min_days"`
+	MaxDays    int            `json:"max_days"` // -1 for no upper bound
+	Count      int            `json:"count"`
+	Overdue    int            `json:"overdue"`
+	BySeverity map[string]int `json:"by_severity"`
+}
+
+// ViolationAgingLine is one unremediated violation in the aging report
+type ViolationAgingLine struct {
+	ViolationID string     `json:"violation_id"`
+	RuleID      string     `json:"rule_id"`
+	AccountID   string     `json:"account_id,omitempty"`
+	Severity    string     `json:"severity"`
+	Status      string     `json:"status"`
+	AssignedTo  string     `json:"assigned_to,omitempty"`
+	AgeDays     int        `json:"age_days"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	Overdue     bool       `json:"overdue"`
+	Occurrences int        `json:"occurrences"`
+}
+
+// ViolationAgingReport summarizes the violations awaiting remediation as of a date
+type ViolationAgingReport struct {
+	AsOfDate   time.Time               `json:"as_of_date"`
+	Buckets    []*ViolationAgingBucket `json:"buckets"`
+	Violations []*ViolationAgingLine   `json:"violations"` // oldest first
+	Open       int                     `json:"open"`
+	Overdue    int                     `json:"overdue"`
+	Recurring  int                     `json:"recurring"` // violations of a rule already broken on the same account
+}
+
+// GenerateViolationAgingReport ages the violations detected by a date and not
+// resolved or waived by then
+func (cs *ComplianceService) GenerateViolationAgingReport(asOfDate time.Time) (*ViolationAgingReport, error) {
+	violations, err := cs.storage.GetComplianceViolations("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get violations: %w", err)
+	}
+
+	report := &ViolationAgingReport{
+		AsOfDate: asOfDate,
+		Buckets: []*ViolationAgingBucket{
+			{Label: "0-7", MinDays: 0, MaxDays: 7},
+			{Label: "8-30", MinDays: 8, MaxDays: 30},
+			{Label: "31-60", MinDays: 31, MaxDays: 60},
+			{Label: "61-90", MinDays: 61, MaxDays: 90},
+			{Label: "90+", MinDays: 91, MaxDays: -1},
+		},
+	}
+	for _, bucket := range report.Buckets {
+		bucket.BySeverity = make(map[string]int)
+	}
+
+	for _, violation := range violations {
+		if violation.DetectedAt.After(asOfDate) {
+			continue
+		}
+		if violation.ResolvedAt != nil && !violation.ResolvedAt.After(asOfDate) {
+			continue
+		}
+
+		line := &ViolationAgingLine{
+			ViolationID: violation.ID,
+			RuleID:      violation.RuleID,
+			AccountID:   violation.AccountID,
+			Severity:    violation.Severity,
+			Status:      cs.violationStatus(violation),
+			AssignedTo:  violation.AssignedTo,
+			AgeDays:     int(asOfDate.Sub(violation.DetectedAt).Hours() / 24),
+			DueAt:       violation.DueAt,
+			Overdue:     violation.DueAt != nil && violation.DueAt.Before(asOfDate),
+			Occurrences: max(violation.Occurrences, 1),
+		}
+		report.Violations = append(report.Violations, line)
+		report.Open++
+		if line.Overdue {
+			report.Overdue++
+		}
+		if line.Occurrences > 1 {
+			report.Recurring++
+		}
+
+		bucket := report.Buckets[len(report.Buckets)-1]
+		for _, candidate := range report.Buckets {
+			if line.AgeDays >= candidate.MinDays && (candidate.MaxDays < 0 || line.AgeDays <= candidate.MaxDays) {
+				bucket = candidate
+				break
+			}
+		}
+		bucket.Count++
+		bucket.BySeverity[line.Severity]++
+		if line.Overdue {
+			bucket.Overdue++
+		}
+	}
+
+	sort.Slice(report.Violations, func(i, j int) bool {
+		return report.Violations[i].AgeDays > report.Violations[j].AgeDays
+	})
+	return report, nil
+}
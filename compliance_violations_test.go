@@ -0,0 +1,214 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComplianceViolationRemediation(t *testing.T) {
+	// Setup
+	dbFile := "test_compliance_violations.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	analyst, fixer, officer := "compliance_analyst", "ap_lead", "chief_compliance_officer"
+	require.NoError(t, engine.CreateStandardAccounts(analyst))
+
+	now := time.Now()
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+	record := func(ruleID, accountID, severity string, detectedAt time.Time) *ComplianceViolation {
+		violation := &ComplianceViolation{
+			RuleID: ruleID, AccountID: accountID, Severity: severity, DetectedAt: detectedAt,
+			Description: "Cash disbursement without a second signature",
+		}
+		require.NoError(t, engine.RecordComplianceViolation(violation, analyst))
+		return violation
+	}
+	assignees := func(violationID string) []string {
+		instance, err := engine.GetWorkflowInstance(WorkflowComplianceViolation, violationID)
+		require.NoError(t, err)
+		return instance.Assignees
+	}
+
+	var first *ComplianceViolation
+	t.Run("Recording And Recurrence", func(t *testing.T) {
+		first = record("CASH-SIG", "cash", "ERROR", daysAgo(61))
+		assert.Equal(t, ViolationOpen, first.Status)
+		assert.Equal(t, daysAgo(61).AddDate(0, 0, 7), *first.DueAt)
+		assert.Equal(t, 1, first.Occurrences)
+		assert.Empty(t, first.RecurrenceOf)
+
+		again := record("CASH-SIG", "cash", "WARNING", daysAgo(11))
+		assert.Equal(t, 2, again.Occurrences)
+		assert.Equal(t, first.ID, again.RecurrenceOf)
+		assert.Equal(t, daysAgo(11).AddDate(0, 0, 30), *again.DueAt)
+
+		elsewhere := record("CASH-SIG", "accounts_payable", "INFO", daysAgo(3))
+		assert.Equal(t, 1, elsewhere.Occurrences, "recurrence is per account")
+
+		err := engine.RecordComplianceViolation(&ComplianceViolation{Description: "No rule"}, analyst)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("Violations From Transactions", func(t *testing.T) {
+		require.NoError(t, engine.GetComplianceService().SetupStandardComplianceRules(SOX_Framework))
+		txn := &Transaction{
+			Description: "Petty cash top-up",
+			ValidTime:   now,
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 5000, Currency: "USD"}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: 5000, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, fixer))
+		require.NoError(t, engine.PostTransaction(txn.ID, fixer))
+
+		violations, err := engine.CheckTransactionCompliance(txn.ID, analyst)
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, txn.ID, violations[0].TransactionID)
+		assert.Equal(t, ViolationOpen, violations[0].Status)
+
+		stored, err := engine.GetStorage().GetComplianceViolation(violations[0].ID)
+		require.NoError(t, err)
+		assert.NotNil(t, stored.DueAt)
+
+		resolved, err := engine.ResolveViolation(stored.ID, "Second approval obtained after the fact", officer)
+		require.NoError(t, err)
+		assert.Equal(t, ViolationResolved, resolved.Status)
+	})
+
+	t.Run("Acknowledge Assign Resolve", func(t *testing.T) {
+		violation := record("JE-LIMIT", "revenue", "WARNING", now)
+
+		violation, err := engine.AcknowledgeViolation(violation.ID, "Looking into it", analyst)
+		require.NoError(t, err)
+		assert.Equal(t, ViolationAcknowledged, violation.Status)
+		assert.Equal(t, analyst, violation.AcknowledgedBy)
+		require.NotNil(t, violation.AcknowledgedAt)
+
+		violation, err = engine.AssignViolation(violation.ID, fixer, "Please reverse and rebook", analyst)
+		require.NoError(t, err)
+		assert.Equal(t, ViolationInRemediation, violation.Status)
+		assert.Equal(t, fixer, violation.AssignedTo)
+		assert.Equal(t, []string{fixer}, assignees(violation.ID))
+
+		_, err = engine.ResolveViolation(violation.ID, "Done", analyst)
+		assert.Error(t, err, "only the assignee resolves an assigned violation")
+		_, err = engine.ResolveViolation(violation.ID, "", fixer)
+		assert.Error(t, err, "resolutions need notes")
+
+		_, err = engine.CommentOnViolation(violation.ID, "Rebooked in JE-2291", fixer)
+		require.NoError(t, err)
+		violation, err = engine.ResolveViolation(violation.ID, "Reversed and rebooked with approval", fixer)
+		require.NoError(t, err)
+		assert.Equal(t, ViolationResolved, violation.Status)
+		assert.Equal(t, fixer, violation.ResolvedBy)
+		assert.Equal(t, "Reversed and rebooked with approval", violation.Notes)
+		require.NotNil(t, violation.ResolvedAt)
+		assert.Empty(t, assignees(violation.ID))
+
+		var actions []string
+		for _, comment := range violation.Comments {
+			actions = append(actions, comment.Action)
+		}
+		assert.Equal(t, []string{"acknowledge", "assign", "", "resolve"}, actions)
+
+		_, err = engine.AcknowledgeViolation(violation.ID, "", analyst)
+		assert.ErrorIs(t, err, ErrConflict)
+
+		violation, err = engine.ReopenViolation(violation.ID, "Rebooked entry was also unapproved", officer)
+		require.NoError(t, err)
+		assert.Equal(t, ViolationOpen, violation.Status)
+		assert.Nil(t, violation.ResolvedAt)
+		assert.True(t, violation.DueAt.After(now))
+		assert.Equal(t, []string{fixer}, assignees(violation.ID))
+
+		_, err = engine.ResolveViolation(violation.ID, "Approved by the controller", fixer)
+		require.NoError(t, err)
+	})
+
+	t.Run("Waivers Need Approval", func(t *testing.T) {
+		engine.SetViolationWaiverApprovers([]string{officer})
+		violation := record("VENDOR-W9", "accounts_payable", "INFO", now)
+
+		_, err := engine.RequestViolationWaiver(violation.ID, "", analyst)
+		assert.Error(t, err, "waivers need a reason")
+		violation, err = engine.RequestViolationWaiver(violation.ID, "Vendor is a government agency", analyst)
+		require.NoError(t, err)
+		assert.Equal(t, ViolationWaiverRequested, violation.Status)
+		assert.Equal(t, []string{officer}, assignees(violation.ID))
+
+		_, err = engine.ApproveViolationWaiver(violation.ID, "", analyst)
+		assert.ErrorIs(t, err, ErrPermissionDenied, "requesters cannot approve their own waiver")
+		_, err = engine.ApproveViolationWaiver(violation.ID, "", fixer)
+		assert.ErrorIs(t, err, ErrPermissionDenied)
+
+		violation, err = engine.RejectViolationWaiver(violation.ID, "Get the exemption certificate first", officer)
+		require.NoError(t, err)
+		assert.Equal(t, ViolationAcknowledged, violation.Status)
+		assert.Empty(t, violation.WaiverRequestedBy)
+
+		_, err = engine.RequestViolationWaiver(violation.ID, "Exemption certificate attached", analyst)
+		require.NoError(t, err)
+		violation, err = engine.ApproveViolationWaiver(violation.ID, "Certificate checked", officer)
+		require.NoError(t, err)
+		assert.Equal(t, ViolationWaived, violation.Status)
+		assert.Equal(t, officer, violation.WaivedBy)
+		require.NotNil(t, violation.ResolvedAt)
+	})
+
+	t.Run("Overdue Escalation", func(t *testing.T) {
+		engine.SetViolationEscalationContacts([]string{officer})
+		escalated, err := engine.EscalateOverdueViolations(now)
+		require.NoError(t, err)
+		require.Len(t, escalated, 1)
+		assert.Equal(t, first.ID, escalated[0].ID)
+		assert.Equal(t, ViolationEscalated, escalated[0].Status)
+		require.NotNil(t, escalated[0].EscalatedAt)
+		assert.Equal(t, []string{officer}, assignees(first.ID))
+
+		escalated, err = engine.EscalateOverdueViolations(now)
+		require.NoError(t, err)
+		assert.Empty(t, escalated, "escalated violations are not escalated again")
+
+		assigned, err := engine.GetAssignedWork(officer)
+		require.NoError(t, err)
+		assert.Len(t, assigned, 1)
+	})
+
+	t.Run("Aging Report", func(t *testing.T) {
+		report, err := engine.GenerateViolationAgingReport(time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, 3, report.Open)
+		assert.Equal(t, 1, report.Overdue)
+		assert.Equal(t, 1, report.Recurring)
+
+		counts := make(map[string]int)
+		for _, bucket := range report.Buckets {
+			counts[bucket.Label] = bucket.Count
+		}
+		assert.Equal(t, map[string]int{"0-7": 1, "8-30": 1, "31-60": 0, "61-90": 1, "90+": 0}, counts)
+		assert.Equal(t, map[string]int{"ERROR": 1}, report.Buckets[3].BySeverity)
+		assert.Equal(t, 1, report.Buckets[3].Overdue)
+
+		require.Len(t, report.Violations, 3)
+		oldest := report.Violations[0]
+		assert.Equal(t, first.ID, oldest.ViolationID)
+		assert.Equal(t, 61, oldest.AgeDays)
+		assert.Equal(t, ViolationEscalated, oldest.Status)
+		assert.True(t, oldest.Overdue)
+
+		// As of two months ago nothing had been detected yet
+		report, err = engine.GenerateViolationAgingReport(daysAgo(62))
+		require.NoError(t, err)
+		assert.Zero(t, report.Open)
+	})
+}
@@ -0,0 +1,397 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Confirmation Structures
+// ----------------------------------------------------------------------------
+
+// ConfirmationType identifies the balance being confirmed with a third party
+type ConfirmationType string
+
+const (
+	ConfirmationBank       ConfirmationType = "BANK"
+	ConfirmationReceivable ConfirmationType = "RECEIVABLE"
+	ConfirmationPayable    ConfirmationType = "PAYABLE"
+)
+
+// ConfirmationStatus tracks a confirmation request through the audit cycle
+type ConfirmationStatus string
+
+const (
+	ConfirmationPending   ConfirmationStatus = "PENDING"
+	ConfirmationSent      ConfirmationStatus = "SENT"
+	ConfirmationReceived  ConfirmationStatus = "RECEIVED"
+	ConfirmationException ConfirmationStatus = "EXCEPTION"
+)
+
+// ConfirmationRequest is an external confirmation of a balance as of a date.
+// Bank confirmations cover a single account and are addressed to the bank named
+// by the account's DimCounterparty dimension; receivable and payable
+// confirmations cover one counterparty's balance on a control account, taken
+// from the DimCounterparty dimension of its entries.
+type ConfirmationRequest struct {
+	ID               string             `json:"id"`
+	Type             ConfirmationType   `json:"type"`
+	AccountID        string             `json:"account_id"`
+	Counterparty     string             `json:"counterparty"`
+	AsOfDate         time.Time          `json:"as_of_date"`
+	Balance          *Amount            `json:"balance"`
+	Status           ConfirmationStatus `json:"status"`
+	Letter           string             `json:"letter"`
+	SentAt           *time.Time         `json:"sent_at,omitempty"`
+	ReceivedAt       *time.Time         `json:"received_at,omitempty"`
+	ConfirmedBalance *Amount            `json:"confirmed_balance,omitempty"`
+	ResponseNotes    string             `json:"response_notes,omitempty"`
+	CreatedBy        string             `json:"created_by"`
+	CreatedAt        time.Time          `json:"created_at"`
+	UpdatedAt        time.Time          `json:"updated_at"`
+}
+
+// Difference returns the recorded balance less the balance confirmed by the third party
+func (cr *ConfirmationRequest) Difference() int64 {
+	if cr.ConfirmedBalance == nil || cr.Balance == nil {
+		return 0
+	}
+	return cr.Balance.Value - cr.ConfirmedBalance.Value
+}
+
+// ConfirmationSummary reports progress of the confirmations for an audit date
+type ConfirmationSummary struct {
+	AsOfDate   time.Time                  `json:"as_of_date"`
+	Total      int                        `json:"total"`
+	ByStatus   map[ConfirmationStatus]int `json:"by_status"`
+	Exceptions []*ConfirmationRequest     `json:"exceptions,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// Confirmation Service
+// ----------------------------------------------------------------------------
+
+// ConfirmationService generates audit confirmation letters and tracks responses
+type ConfirmationService struct {
+	storage    *Storage
+	eventStore *EventStore
+	queryAPI   *QueryAPI
+	entityName string
+}
+
+// NewConfirmationService creates a new confirmation service
+func NewConfirmationService(storage *Storage, eventStore *EventStore, queryAPI *QueryAPI) *ConfirmationService {
+	return &ConfirmationService{
+		storage:    storage,
+		eventStore: eventStore,
+		queryAPI:   queryAPI,
+	}
+}
+
+// SetEntityName sets the reporting entity named in confirmation letters
+func (cs *ConfirmationService) SetEntityName(name string) {
+	cs.entityName = name
+}
+
+// GenerateConfirmations creates confirmation requests for the given accounts as of a date.
+// Requests already generated for the same account, counterparty and date are not duplicated.
+func (cs *ConfirmationService) GenerateConfirmations(confirmationType ConfirmationType, accountIDs []string, asOfDate time.Time, userID string) ([]*ConfirmationRequest, error) {
+	switch confirmationType {
+	case ConfirmationBank, ConfirmationReceivable, ConfirmationPayable:
+	default:
+		return nil, fmt.Errorf("unknown confirmation type: %s", confirmationType)
+	}
+	if len(accountIDs) == 0 {
+		return nil, fmt.Errorf("at least one account is required")
+	}
+
+	existing, err := cs.storage.GetConfirmationRequestsByDate(asOfDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing confirmations: %w", err)
+	}
+	generated := make(map[string]bool)
+	for _, request := range existing {
+		generated[request.AccountID+"|"+request.Counterparty] = true
+	}
+
+	var requests []*ConfirmationRequest
+	for _, accountID := range accountIDs {
+		account, err := cs.storage.GetAccount(accountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get account: %w", err)
+		}
+
+		var balances map[string]*Amount
+		if confirmationType == ConfirmationBank {
+			balance, err := cs.queryAPI.GetAccountBalance(accountID, asOfDate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get balance for %s: %w", accountID, err)
+			}
+			balances = map[string]*Amount{bankName(account): balance.Balance}
+		} else {
+			balances, err = cs.counterpartyBalances(account, asOfDate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get counterparty balances for %s: %w", accountID, err)
+			}
+		}
+
+		counterparties := make([]string, 0, len(balances))
+		for counterparty := range balances {
+			counterparties = append(counterparties, counterparty)
+		}
+		sort.Strings(counterparties)
+
+		for _, counterparty := range counterparties {
+			balance := balances[counterparty]
+			// Nil customer and vendor balances need no confirmation; bank accounts are always confirmed
+			if confirmationType != ConfirmationBank && balance.Value == 0 {
+				continue
+			}
+			if generated[accountID+"|"+counterparty] {
+				continue
+			}
+
+			request := &ConfirmationRequest{
+				ID:           newID(),
+				Type:         confirmationType,
+				AccountID:    accountID,
+				Counterparty: counterparty,
+				AsOfDate:     asOfDate,
+				Balance:      balance,
+				Status:       ConfirmationPending,
+				CreatedBy:    userID,
+				CreatedAt:    time.Now(),
+			}
+			request.UpdatedAt = request.CreatedAt
+			request.Letter = cs.renderLetter(request, account)
+
+			if err := cs.saveRequest(request, EventGenerateConfirmation, userID); err != nil {
+				return nil, err
+			}
+			generated[accountID+"|"+counterparty] = true
+			requests = append(requests, request)
+		}
+	}
+
+	return requests, nil
+}
+
+// MarkSent records that a confirmation request has been dispatched
+func (cs *ConfirmationService) MarkSent(requestID string, sentAt time.Time, userID string) (*ConfirmationRequest, error) {
+	request, err := cs.storage.GetConfirmationRequest(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmation request: %w", err)
+	}
+	if request.Status != ConfirmationPending {
+		return nil, fmt.Errorf("confirmation request %s has already been sent", requestID)
+	}
+
+	request.Status = ConfirmationSent
+	request.SentAt = &sentAt
+	request.UpdatedAt = time.Now()
+
+	if err := cs.saveRequest(request, EventUpdateConfirmation, userID); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// RecordResponse records the balance confirmed by the third party. A response that
+// disagrees with the recorded balance is marked as an exception.
+func (cs *ConfirmationService) RecordResponse(requestID string, confirmedValue int64, notes string, receivedAt time.Time, userID string) (*ConfirmationRequest, error) {
+	request, err := cs.storage.GetConfirmationRequest(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmation request: %w", err)
+	}
+	if request.Status == ConfirmationPending {
+		return nil, fmt.Errorf("confirmation request %s has not been sent", requestID)
+	}
+
+	request.ConfirmedBalance = &Amount{Value: confirmedValue, Currency: request.Balance.Currency}
+	request.ResponseNotes = notes
+	request.ReceivedAt = &receivedAt
+	request.UpdatedAt = time.Now()
+
+	request.Status = ConfirmationReceived
+	if request.Difference() != 0 {
+		request.Status = ConfirmationException
+	}
+
+	if err := cs.saveRequest(request, EventUpdateConfirmation, userID); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ResolveException closes an exception once the difference has been explained
+func (cs *ConfirmationService) ResolveException(requestID, explanation, userID string) (*ConfirmationRequest, error) {
+	request, err := cs.storage.GetConfirmationRequest(requestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmation request: %w", err)
+	}
+	if request.Status != ConfirmationException {
+		return nil, fmt.Errorf("confirmation request %s is not an exception", requestID)
+	}
+	if explanation == "" {
+		return nil, fmt.Errorf("an explanation is required to resolve an exception")
+	}
+
+	request.Status = ConfirmationReceived
+	request.ResponseNotes = strings.TrimSpace(request.ResponseNotes + "\nResolved: " + explanation)
+	request.UpdatedAt = time.Now()
+
+	if err := cs.saveRequest(request, EventUpdateConfirmation, userID); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// GetConfirmations returns the confirmation requests for an audit date, optionally filtered by status
+func (cs *ConfirmationService) GetConfirmations(asOfDate time.Time, status ConfirmationStatus) ([]*ConfirmationRequest, error) {
+	all, err := cs.storage.GetConfirmationRequestsByDate(asOfDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmations: %w", err)
+	}
+
+	var requests []*ConfirmationRequest
+	for _, request := range all {
+		if status == "" || request.Status == status {
+			requests = append(requests, request)
+		}
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		if requests[i].Type != requests[j].Type {
+			return requests[i].Type < requests[j].Type
+		}
+		if requests[i].AccountID != requests[j].AccountID {
+			return requests[i].AccountID < requests[j].AccountID
+		}
+		return requests[i].Counterparty < requests[j].Counterparty
+	})
+	return requests, nil
+}
+
+// GetConfirmationSummary counts confirmations by status and lists open exceptions
+func (cs *ConfirmationService) GetConfirmationSummary(asOfDate time.Time) (*ConfirmationSummary, error) {
+	requests, err := cs.GetConfirmations(asOfDate, "")
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &ConfirmationSummary{
+		AsOfDate: asOfDate,
+		Total:    len(requests),
+		ByStatus: make(map[ConfirmationStatus]int),
+	}
+	for _, request := range requests {
+		summary.ByStatus[request.Status]++
+		if request.Status == ConfirmationException {
+			summary.Exceptions = append(summary.Exceptions, request)
+		}
+	}
+	return summary, nil
+}
+
+// counterpartyBalances sums posted entries on a control account by counterparty
+func (cs *ConfirmationService) counterpartyBalances(account *Account, asOfDate time.Time) (map[string]*Amount, error) {
+	entries, err := cs.storage.GetEntriesByAccount(account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entries: %w", err)
+	}
+
+	currency := account.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+
+	balances := make(map[string]*Amount)
+	for _, entry := range entries {
+		counterparty := entryDimension(entry, DimCounterparty)
+		if counterparty == "" {
+			continue
+		}
+
+		txn, err := cs.storage.GetTransaction(entry.TransactionID)
+		if err != nil {
+			continue
+		}
+		if txn.ValidTime.After(asOfDate) || txn.Status != Posted {
+			continue
+		}
+
+		if balances[counterparty] == nil {
+			balances[counterparty] = &Amount{Value: 0, Currency: currency}
+		}
+		multiplier := int64(cs.queryAPI.postingEngine.getBalanceMultiplier(account.Type, entry.Type))
+		balances[counterparty].Value += entry.Amount.Value * multiplier
+	}
+
+	return balances, nil
+}
+
+// renderLetter produces the text of the confirmation letter sent to the third party
+func (cs *ConfirmationService) renderLetter(request *ConfirmationRequest, account *Account) string {
+	entity := cs.entityName
+	if entity == "" {
+		entity = "our company"
+	}
+	date := request.AsOfDate.Format("January 2, 2006")
+	balance := request.Balance.Format()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "To: %s\n\n", request.Counterparty)
+	b.WriteString("Dear Sir or Madam,\n\n")
+
+	switch request.Type {
+	case ConfirmationBank:
+		fmt.Fprintf(&b, "In connection with the audit of the financial statements of %s, please confirm the balance held with you on account %s (%s) at the close of business on %s.\n\n", entity, account.Name, account.Code, date)
+		fmt.Fprintf(&b, "Our records show a balance of %s.\n\n", balance)
+		b.WriteString("Please also list any other accounts, loans, guarantees or facilities held in our name at that date.\n\n")
+	case ConfirmationReceivable:
+		fmt.Fprintf(&b, "In connection with the audit of the financial statements of %s, please confirm the amount you owed us at %s.\n\n", entity, date)
+		fmt.Fprintf(&b, "Our records show a balance due from you of %s.\n\n", balance)
+	case ConfirmationPayable:
+		fmt.Fprintf(&b, "In connection with the audit of the financial statements of %s, please confirm the amount we owed you at %s.\n\n", entity, date)
+		fmt.Fprintf(&b, "Our records show a balance due to you of %s.\n\n", balance)
+	}
+
+	b.WriteString("If this agrees with your records, please confirm. If it does not, please state the balance shown in your records and provide details of the difference.\n\n")
+	fmt.Fprintf(&b, "Reference: %s\n", request.ID)
+	return b.String()
+}
+
+// saveRequest records an event for the request and persists it
+func (cs *ConfirmationService) saveRequest(request *ConfirmationRequest, eventType, userID string) error {
+	_, err := cs.eventStore.CreateEvent(eventType, request, request.AsOfDate, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create confirmation event: %w", err)
+	}
+	if err := cs.storage.SaveConfirmationRequest(request); err != nil {
+		return fmt.Errorf("failed to save confirmation request: %w", err)
+	}
+	return nil
+}
+
+// bankName returns the bank holding an account, taken from the account's
+// DimCounterparty dimension and defaulting to the account name
+func bankName(account *Account) string {
+	for _, dimension := range account.Dimensions {
+		if dimension.Key == DimCounterparty {
+			return dimension.Value
+		}
+	}
+	return account.Name
+}
+
+// entryDimension returns the value of a dimension on an entry, or "" when absent
+func entryDimension(entry *Entry, key DimensionKey) string {
+	for _, dimension := range entry.Dimensions {
+		if dimension.Key == key {
+			return dimension.Value
+		}
+	}
+	return ""
+}
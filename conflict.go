@@ -0,0 +1,69 @@
+package accounting
+
+import (
+	"errors"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrConflict matches every ConflictError, for callers that only need to know a
+// save lost a race: errors.Is(err, ErrConflict)
+var ErrConflict = errors.New("conflicting update")
+
+// ConflictError is returned when saving a record that changed since it was read.
+// Reload the record, reapply the change and save again.
+type ConflictError struct {
+	Entity  string `json:"entity"` // e.g. "AML alert"
+	ID      string `json:"id"`
+	Read    int64  `json:"read"`    // the version the caller read
+	Current int64  `json:"current"` // the version now stored
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s: %s %s is at version %d, not %d", ErrConflict, e.Entity, e.ID, e.Current, e.Read)
+}
+
+// Is makes errors.Is(err, ErrConflict) true for conflicts
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// versionedMessage is a stored proto message carrying its entity's version
+type versionedMessage interface {
+	proto.Message
+	GetVersion() int64
+}
+
+// updateVersioned runs a write transaction saving a record the caller read at
+// *version. The record is written at the next version, which the caller's copy
+// keeps only if the write commits.
+func (s *Storage) updateVersioned(version *int64, fn func(tx *bbolt.Tx, read int64) error) error {
+	read := *version
+	*version = read + 1
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		return fn(tx, read)
+	})
+	if err != nil {
+		*version = read
+	}
+	return err
+}
+
+// checkVersion fails with a ConflictError unless the record stored under id is at
+// the version the caller read; a record not yet stored is at version 0. stored
+// receives the decoded record.
+func checkVersion(b *bbolt.Bucket, entity, id string, read int64, stored versionedMessage) error {
+	var current int64
+	if data := b.Get([]byte(id)); data != nil {
+		if err := proto.Unmarshal(data, stored); err != nil {
+			return fmt.Errorf("failed to unmarshal %s: %w", entity, err)
+		}
+		current = stored.GetVersion()
+	}
+	if current != read {
+		return &ConflictError{Entity: entity, ID: id, Read: read, Current: current}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package accounting
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptimisticConcurrency(t *testing.T) {
+	// Setup
+	dbFile := "test_conflict.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	storage := engine.GetStorage()
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Stale AML Alert", func(t *testing.T) {
+		alert := &AMLAlert{ID: "alert-1", Title: "Structuring", Status: "OPEN", DetectedAt: now, CreatedAt: now}
+		require.NoError(t, storage.SaveAMLAlert(alert))
+		assert.Equal(t, int64(1), alert.Version)
+
+		first, err := storage.GetAMLAlert(alert.ID)
+		require.NoError(t, err)
+		second, err := storage.GetAMLAlert(alert.ID)
+		require.NoError(t, err)
+
+		first.Status = "INVESTIGATING"
+		require.NoError(t, storage.SaveAMLAlert(first))
+		assert.Equal(t, int64(2), first.Version)
+
+		second.Status = "CLOSED"
+		err = storage.SaveAMLAlert(second)
+		require.ErrorIs(t, err, ErrConflict)
+		var conflict *ConflictError
+		require.True(t, errors.As(err, &conflict))
+		assert.Equal(t, "AML alert", conflict.Entity)
+		assert.Equal(t, alert.ID, conflict.ID)
+		assert.Equal(t, int64(1), conflict.Read)
+		assert.Equal(t, int64(2), conflict.Current)
+		assert.Equal(t, int64(1), second.Version, "a failed save leaves the copy as read")
+
+		stored, err := storage.GetAMLAlert(alert.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "INVESTIGATING", stored.Status)
+		assert.Equal(t, int64(2), stored.Version)
+
+		// Reloading and reapplying the change succeeds, and the rejected save left the
+		// dashboard aggregates alone
+		stored.Status = "CLOSED"
+		require.NoError(t, storage.SaveAMLAlert(stored))
+		assert.Equal(t, int64(3), stored.Version)
+		days, err := storage.GetAMLDailyAggregates(amlAggregateDay(now), amlAggregateDay(now))
+		require.NoError(t, err)
+		require.Len(t, days, 1)
+		assert.Equal(t, 1, days[0].TotalAlerts)
+		assert.Equal(t, 1, days[0].ClosedAlerts)
+	})
+
+	t.Run("Stale Budget Request", func(t *testing.T) {
+		request := &BudgetRequest{ID: "req-1", Title: "Travel", Status: BudgetRequestDraft, CreatedAt: now}
+		require.NoError(t, storage.SaveBudgetRequest(request))
+
+		stale := *request
+		request.Title = "Travel and lodging"
+		require.NoError(t, storage.SaveBudgetRequest(request))
+
+		stale.Status = BudgetRequestSubmitted
+		assert.ErrorIs(t, storage.SaveBudgetRequest(&stale), ErrConflict)
+
+		// A new request with an ID already in use conflicts rather than overwriting
+		err := storage.SaveBudgetRequest(&BudgetRequest{ID: "req-1", Title: "Other"})
+		assert.ErrorIs(t, err, ErrConflict)
+	})
+
+	t.Run("Stale AML Customer", func(t *testing.T) {
+		customer := &AMLCustomer{ID: "cust-1", Name: "Ada Lovelace"}
+		require.NoError(t, engine.GetAMLService().RegisterCustomer(customer))
+		stale, err := storage.GetAMLCustomer(customer.ID)
+		require.NoError(t, err)
+
+		require.NoError(t, engine.GetAMLService().UpdateCustomerRisk(customer.ID, RiskHigh, "adverse media"))
+		stale.Name = "Ada King"
+		assert.ErrorIs(t, storage.SaveAMLCustomer(stale), ErrConflict)
+	})
+
+	t.Run("Stale Workflow Instance", func(t *testing.T) {
+		instance := &WorkflowInstance{ID: "approval/doc-1", Workflow: "approval", SubjectID: "doc-1", State: "PENDING", CreatedAt: now}
+		require.NoError(t, storage.SaveWorkflowInstance(instance))
+		stale := *instance
+
+		instance.State = "APPROVED"
+		require.NoError(t, storage.SaveWorkflowInstance(instance))
+		stale.State = "REJECTED"
+		assert.ErrorIs(t, storage.SaveWorkflowInstance(&stale), ErrConflict)
+	})
+
+	t.Run("Version Survives Proto", func(t *testing.T) {
+		assert.Equal(t, int64(7), AMLAlertFromProto((&AMLAlert{Version: 7}).ToProto()).Version)
+		assert.Equal(t, int64(7), AMLCustomerFromProto((&AMLCustomer{Version: 7}).ToProto()).Version)
+		assert.Equal(t, int64(7), BudgetRequestFromProto((&BudgetRequest{Version: 7}).ToProto()).Version)
+		assert.Equal(t, int64(7), WorkflowInstanceFromProto((&WorkflowInstance{Version: 7}).ToProto()).Version)
+	})
+}
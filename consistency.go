@@ -0,0 +1,333 @@
+package accounting
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Cross-Module Consistency Checks
+// ----------------------------------------------------------------------------
+
+// ConsistencyCheck names an invariant that spans modules
+type ConsistencyCheck string
+
+const (
+	CheckBudgetSpent          ConsistencyCheck = "BUDGET_SPENT"          // allocation spent and remaining agree with its tracking records
+	CheckBudgetTracking       ConsistencyCheck = "BUDGET_TRACKING"       // tracking records reference an existing allocation and transaction
+	CheckAllocationRequest    ConsistencyCheck = "ALLOCATION_REQUEST"    // allocations come from an approved budget request
+	CheckAlertTransactions    ConsistencyCheck = "ALERT_TRANSACTIONS"    // AML alerts reference existing transactions
+	CheckViolationTransaction ConsistencyCheck = "VIOLATION_TRANSACTION" // compliance violations reference an existing transaction
+)
+
+// DefaultConsistencySchedule runs the checks nightly at 02:00
+const DefaultConsistencySchedule = "0 2 * * *"
+
+// consistencyUserID is who runs started by the background job are recorded against
+const consistencyUserID = "consistency_checker"
+
+// ConsistencyIssue is one broken invariant
+type ConsistencyIssue struct {
+	Check       ConsistencyCheck `json:"check"`
+	EntityType  string           `json:"entity_type"` // the record holding the bad value or reference
+	EntityID    string           `json:"entity_id"`
+	Reference   string           `json:"reference,omitempty"` // the missing or mismatched record it points at
+	Description string           `json:"description"`
+	Expected    int64            `json:"expected,omitempty"` // for amount drift, what the records add up to
+	Actual      int64            `json:"actual,omitempty"`
+	Currency    string           `json:"currency,omitempty"`
+	Repairable  bool             `json:"repairable"` // derived values that can be recomputed from primary records
+	Repaired    bool             `json:"repaired"`
+}
+
+// ConsistencyRun is one pass of the consistency checks
+type ConsistencyRun struct {
+	ID         string              `json:"id"`
+	Repair     bool                `json:"repair"`  // whether repairable drift was fixed
+	Checked    int                 `json:"checked"` // records examined
+	Issues     []*ConsistencyIssue `json:"issues"`
+	Repaired   int                 `json:"repaired"`
+	Error      string              `json:"error,omitempty"`
+	RunBy      string              `json:"run_by"`
+	StartedAt  time.Time           `json:"started_at"`
+	FinishedAt time.Time           `json:"finished_at"`
+}
+
+// Consistent reports whether the run found nothing left to fix
+func (r *ConsistencyRun) Consistent() bool {
+	for _, issue := range r.Issues {
+		if !issue.Repaired {
+			return false
+		}
+	}
+	return r.Error == ""
+}
+
+// ConsistencyService verifies invariants between budgets, compliance, AML and the
+// ledger, on demand or on a schedule, and can repair drift in derived budget amounts.
+// Broken references are only reported, since fixing them needs a person to decide
+// which side is wrong.
+type ConsistencyService struct {
+	storage    *Storage
+	eventStore *EventStore
+	now        func() time.Time
+	logger     *slog.Logger
+
+	mu   sync.Mutex // guards the runner
+	stop chan struct{}
+	done chan struct{}
+
+	runMu sync.Mutex // one pass at a time
+}
+
+// NewConsistencyService creates a new consistency service
+func NewConsistencyService(storage *Storage, eventStore *EventStore) *ConsistencyService {
+	return &ConsistencyService{
+		storage:    storage,
+		eventStore: eventStore,
+		now:        time.Now,
+		logger:     discardLogger,
+	}
+}
+
+// SetLogger sends the service's logs to a host logger; nil discards them. Set it
+// before Start.
+func (cs *ConsistencyService) SetLogger(logger *slog.Logger) {
+	cs.logger = componentLogger(logger, "consistency")
+}
+
+// Check runs every consistency check and records the run. With repair, allocation
+// spent and remaining amounts that drifted from their tracking records are recomputed.
+func (cs *ConsistencyService) Check(repair bool, userID string) (*ConsistencyRun, error) {
+	cs.runMu.Lock()
+	defer cs.runMu.Unlock()
+
+	run := &ConsistencyRun{Repair: repair, RunBy: userID, StartedAt: cs.now()}
+	if err := cs.storage.assignID(&run.ID, "consistency run", BucketConsistencyRuns); err != nil {
+		return nil, err
+	}
+	if err := cs.check(run); err != nil {
+		run.Error = err.Error()
+	} else if repair {
+		if err := cs.repair(run); err != nil {
+			run.Error = err.Error()
+		}
+	}
+	run.FinishedAt = cs.now()
+
+	for _, issue := range run.Issues {
+		cs.logger.Warn("consistency issue", "check", issue.Check, "entity_type", issue.EntityType, "entity_id", issue.EntityID,
+			"reference", issue.Reference, "repaired", issue.Repaired)
+	}
+	if run.Error != "" {
+		cs.logger.Error("consistency check failed", LogKeyRunID, run.ID, LogKeyError, run.Error)
+	} else {
+		cs.logger.Info("consistency check finished", LogKeyRunID, run.ID, "checked", run.Checked, "issues", len(run.Issues), "repaired", run.Repaired)
+	}
+
+	if _, err := cs.eventStore.CreateEvent(EventRecordConsistencyRun, run, run.StartedAt, userID); err != nil {
+		return nil, fmt.Errorf("failed to create consistency run event: %w", err)
+	}
+	if err := cs.storage.SaveConsistencyRun(run); err != nil {
+		return nil, fmt.Errorf("failed to save consistency run: %w", err)
+	}
+	return run, nil
+}
+
+// check runs the checks, adding what it finds to the run
+func (cs *ConsistencyService) check(run *ConsistencyRun) error {
+	transactionExists := func(id string) bool { return cs.storage.HasKey(BucketTransactions, id) }
+
+	allocations, err := cs.storage.GetAllBudgetAllocations()
+	if err != nil {
+		return fmt.Errorf("failed to get budget allocations: %w", err)
+	}
+	tracking, err := cs.storage.GetAllBudgetTracking()
+	if err != nil {
+		return fmt.Errorf("failed to get budget tracking: %w", err)
+	}
+	allocationsByID := make(map[string]*BudgetAllocation, len(allocations))
+	for _, allocation := range allocations {
+		allocationsByID[allocation.ID] = allocation
+	}
+
+	spent := make(map[string]int64)
+	for _, record := range tracking {
+		run.Checked++
+		key := record.AllocationID + "/" + record.TransactionID
+		if allocationsByID[record.AllocationID] == nil {
+			run.Issues = append(run.Issues, &ConsistencyIssue{
+				Check: CheckBudgetTracking, EntityType: "budget_tracking", EntityID: key, Reference: record.AllocationID,
+				Description: fmt.Sprintf("budget tracking references missing allocation %s", record.AllocationID),
+			})
+		}
+		if !transactionExists(record.TransactionID) {
+			run.Issues = append(run.Issues, &ConsistencyIssue{
+				Check: CheckBudgetTracking, EntityType: "budget_tracking", EntityID: key, Reference: record.TransactionID,
+				Description: fmt.Sprintf("budget tracking references missing transaction %s", record.TransactionID),
+			})
+		}
+		if record.Amount != nil {
+			spent[record.AllocationID] += record.Amount.Value
+		}
+	}
+
+	requests := make(map[string]*BudgetRequest)
+	for _, allocation := range allocations {
+		run.Checked++
+		if allocation.Amount != nil {
+			expected := spent[allocation.ID]
+			actual := int64(0)
+			if allocation.SpentAmount != nil {
+				actual = allocation.SpentAmount.Value
+			}
+			remainingDrift := allocation.Remaining == nil || allocation.Remaining.Value != allocation.Amount.Value-expected
+			if actual != expected || remainingDrift {
+				run.Issues = append(run.Issues, &ConsistencyIssue{
+					Check: CheckBudgetSpent, EntityType: "budget_allocation", EntityID: allocation.ID,
+					Description: fmt.Sprintf("allocation records %s spent but its tracking adds up to %s",
+						FormatMinorUnits(actual, allocation.Amount.Currency), FormatMinorUnits(expected, allocation.Amount.Currency)),
+					Expected: expected, Actual: actual, Currency: string(allocation.Amount.Currency), Repairable: true,
+				})
+			}
+		}
+
+		request, ok := requests[allocation.RequestID]
+		if !ok {
+			request, _ = cs.storage.GetBudgetRequest(allocation.RequestID)
+			requests[allocation.RequestID] = request
+		}
+		switch {
+		case request == nil:
+			run.Issues = append(run.Issues, &ConsistencyIssue{
+				Check: CheckAllocationRequest, EntityType: "budget_allocation", EntityID: allocation.ID, Reference: allocation.RequestID,
+				Description: fmt.Sprintf("allocation references missing budget request %s", allocation.RequestID),
+			})
+		case request.Status != BudgetRequestApproved:
+			run.Issues = append(run.Issues, &ConsistencyIssue{
+				Check: CheckAllocationRequest, EntityType: "budget_allocation", EntityID: allocation.ID, Reference: allocation.RequestID,
+				Description: fmt.Sprintf("allocation comes from budget request %s, which is %s rather than approved", request.ID, request.Status),
+			})
+		}
+	}
+
+	alerts, err := cs.storage.GetAMLAlerts()
+	if err != nil {
+		return fmt.Errorf("failed to get AML alerts: %w", err)
+	}
+	for _, alert := range alerts {
+		run.Checked++
+		for _, txnID := range alert.TransactionIDs {
+			if txnID != "" && !transactionExists(txnID) {
+				run.Issues = append(run.Issues, &ConsistencyIssue{
+					Check: CheckAlertTransactions, EntityType: "aml_alert", EntityID: alert.ID, Reference: txnID,
+					Description: fmt.Sprintf("AML alert references missing transaction %s", txnID),
+				})
+			}
+		}
+	}
+
+	violations, err := cs.storage.GetAllComplianceViolations()
+	if err != nil {
+		return fmt.Errorf("failed to get compliance violations: %w", err)
+	}
+	for _, violation := range violations {
+		run.Checked++
+		if violation.TransactionID != "" && !transactionExists(violation.TransactionID) {
+			run.Issues = append(run.Issues, &ConsistencyIssue{
+				Check: CheckViolationTransaction, EntityType: "compliance_violation", EntityID: violation.ID, Reference: violation.TransactionID,
+				Description: fmt.Sprintf("compliance violation references missing transaction %s", violation.TransactionID),
+			})
+		}
+	}
+	return nil
+}
+
+// repair recomputes drifted budget amounts from the tracking records
+func (cs *ConsistencyService) repair(run *ConsistencyRun) error {
+	drifted := false
+	for _, issue := range run.Issues {
+		drifted = drifted || issue.Check == CheckBudgetSpent
+	}
+	if !drifted {
+		return nil
+	}
+	corrected, err := cs.storage.RebuildBudgetSpending()
+	if err != nil {
+		return fmt.Errorf("failed to rebuild budget spending: %w", err)
+	}
+	for _, issue := range run.Issues {
+		if issue.Check == CheckBudgetSpent {
+			issue.Repaired = true
+		}
+	}
+	run.Repaired = corrected
+	return nil
+}
+
+// GetRuns returns the consistency run history, most recent first
+func (cs *ConsistencyService) GetRuns() ([]*ConsistencyRun, error) {
+	runs, err := cs.storage.GetAllConsistencyRuns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consistency runs: %w", err)
+	}
+	sort.SliceStable(runs, func(i, j int) bool { return runs[i].StartedAt.After(runs[j].StartedAt) })
+	return runs, nil
+}
+
+// Start runs the checks in the background at the times of a cron schedule, such as
+// DefaultConsistencySchedule, until Stop is called
+func (cs *ConsistencyService) Start(cron string, repair bool) error {
+	schedule, err := ParseCronSchedule(cron)
+	if err != nil {
+		return err
+	}
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.stop != nil {
+		return fmt.Errorf("consistency checker is already running")
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	cs.stop, cs.done = stop, done
+
+	go func() {
+		defer close(done)
+		for {
+			timer := time.NewTimer(schedule.Next(cs.now()).Sub(cs.now()))
+			select {
+			case <-stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			if _, err := cs.Check(repair, consistencyUserID); err != nil {
+				cs.logger.Error("failed to record consistency run", LogKeyError, err)
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops the background checks, waiting for a pass in progress to finish. It does
+// nothing when they are not started.
+func (cs *ConsistencyService) Stop() {
+	cs.mu.Lock()
+	stop, done := cs.stop, cs.done
+	cs.stop, cs.done = nil, nil
+	cs.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+// Running reports whether the background checks are started
+func (cs *ConsistencyService) Running() bool {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return cs.stop != nil
+}
@@ -0,0 +1,143 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsistencyChecks(t *testing.T) {
+	dbFile := "test_consistency.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	storage := engine.GetStorage()
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	purchase := &Transaction{
+		Description: "Office supplies",
+		ValidTime:   now,
+		Entries: []Entry{
+			{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 30000, Currency: "USD"}},
+			{AccountID: "cash", Type: Credit, Amount: Amount{Value: 30000, Currency: "USD"}},
+		},
+	}
+	require.NoError(t, engine.CreateTransaction(purchase, userID))
+
+	approved := &BudgetRequest{ID: "req-approved", Title: "Supplies", Status: BudgetRequestApproved, CreatedAt: now}
+	draft := &BudgetRequest{ID: "req-draft", Title: "Travel", Status: BudgetRequestDraft, CreatedAt: now}
+	require.NoError(t, storage.SaveBudgetRequest(approved))
+	require.NoError(t, storage.SaveBudgetRequest(draft))
+
+	// spent was recorded as 500.00 but only 300.00 of spending is tracked against it
+	drifted := &BudgetAllocation{
+		ID: "alloc-supplies", RequestID: approved.ID, AccountID: "expenses",
+		Amount:      &Amount{Value: 100000, Currency: "USD"},
+		SpentAmount: &Amount{Value: 50000, Currency: "USD"},
+		Remaining:   &Amount{Value: 50000, Currency: "USD"},
+		CreatedAt:   now,
+	}
+	unapproved := &BudgetAllocation{
+		ID: "alloc-travel", RequestID: draft.ID, AccountID: "expenses",
+		Amount:      &Amount{Value: 20000, Currency: "USD"},
+		SpentAmount: &Amount{Value: 0, Currency: "USD"},
+		Remaining:   &Amount{Value: 20000, Currency: "USD"},
+		CreatedAt:   now,
+	}
+	require.NoError(t, storage.SaveBudgetAllocation(drifted))
+	require.NoError(t, storage.SaveBudgetAllocation(unapproved))
+	require.NoError(t, storage.SaveBudgetTracking(&BudgetTracking{
+		AllocationID: drifted.ID, TransactionID: purchase.ID, Amount: &Amount{Value: 30000, Currency: "USD"}, TrackedAt: now,
+	}))
+
+	require.NoError(t, storage.SaveAMLAlert(&AMLAlert{
+		ID: "alert-1", RuleType: RuleCTR, TransactionIDs: []string{purchase.ID, "txn-deleted"}, Status: "OPEN", DetectedAt: now,
+	}))
+	require.NoError(t, storage.SaveComplianceViolation(&ComplianceViolation{
+		ID: "violation-1", RuleID: "rule-1", TransactionID: "txn-purged", Status: "OPEN", DetectedAt: now,
+	}))
+
+	issuesByCheck := func(run *ConsistencyRun) map[ConsistencyCheck][]*ConsistencyIssue {
+		grouped := make(map[ConsistencyCheck][]*ConsistencyIssue)
+		for _, issue := range run.Issues {
+			grouped[issue.Check] = append(grouped[issue.Check], issue)
+		}
+		return grouped
+	}
+
+	run, err := engine.CheckConsistency(false, userID)
+	require.NoError(t, err)
+	assert.Empty(t, run.Error)
+	assert.False(t, run.Consistent())
+	assert.Equal(t, 5, run.Checked) // one tracking record, two allocations, one alert, one violation
+	issues := issuesByCheck(run)
+
+	require.Len(t, issues[CheckBudgetSpent], 1)
+	spent := issues[CheckBudgetSpent][0]
+	assert.Equal(t, drifted.ID, spent.EntityID)
+	assert.Equal(t, int64(30000), spent.Expected)
+	assert.Equal(t, int64(50000), spent.Actual)
+	assert.True(t, spent.Repairable)
+	assert.False(t, spent.Repaired)
+
+	require.Len(t, issues[CheckAllocationRequest], 1)
+	assert.Equal(t, unapproved.ID, issues[CheckAllocationRequest][0].EntityID)
+	assert.Equal(t, draft.ID, issues[CheckAllocationRequest][0].Reference)
+
+	require.Len(t, issues[CheckAlertTransactions], 1)
+	assert.Equal(t, "alert-1", issues[CheckAlertTransactions][0].EntityID)
+	assert.Equal(t, "txn-deleted", issues[CheckAlertTransactions][0].Reference)
+
+	require.Len(t, issues[CheckViolationTransaction], 1)
+	assert.Equal(t, "txn-purged", issues[CheckViolationTransaction][0].Reference)
+	assert.Empty(t, issues[CheckBudgetTracking])
+
+	allocation, err := storage.GetBudgetAllocation(drifted.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50000), allocation.SpentAmount.Value, "a check without repair leaves the data alone")
+
+	t.Run("repair recomputes drifted budget amounts", func(t *testing.T) {
+		repaired, err := engine.CheckConsistency(true, userID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, repaired.Repaired)
+		issues := issuesByCheck(repaired)
+		assert.True(t, issues[CheckBudgetSpent][0].Repaired)
+		assert.False(t, issues[CheckAlertTransactions][0].Repaired, "broken references are only reported")
+
+		allocation, err := storage.GetBudgetAllocation(drifted.ID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(30000), allocation.SpentAmount.Value)
+		assert.Equal(t, int64(70000), allocation.Remaining.Value)
+
+		again, err := engine.CheckConsistency(false, userID)
+		require.NoError(t, err)
+		assert.Empty(t, issuesByCheck(again)[CheckBudgetSpent])
+	})
+
+	t.Run("runs are recorded", func(t *testing.T) {
+		runs, err := engine.GetConsistencyRuns()
+		require.NoError(t, err)
+		require.Len(t, runs, 3)
+		assert.Equal(t, run.ID, runs[2].ID)
+		assert.Len(t, runs[2].Issues, len(run.Issues))
+		assert.Equal(t, userID, runs[2].RunBy)
+		assert.True(t, runs[1].Repair)
+	})
+
+	t.Run("nightly job", func(t *testing.T) {
+		assert.Error(t, engine.StartConsistencyChecks("not a schedule", false))
+		require.NoError(t, engine.StartConsistencyChecks(DefaultConsistencySchedule, true))
+		assert.True(t, engine.GetConsistencyService().Running())
+		assert.Error(t, engine.StartConsistencyChecks(DefaultConsistencySchedule, true))
+		engine.StopConsistencyChecks()
+		assert.False(t, engine.GetConsistencyService().Running())
+	})
+}
@@ -0,0 +1,533 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Consolidated Financial Statements
+// ----------------------------------------------------------------------------
+
+// ConsolidatedCompany records how one company of a group entered a consolidated statement
+type ConsolidatedCompany struct {
+	CompanyID   string  `json:"company_id"`
+	CompanyName string  `json:"company_name"`
+	Currency    string  `json:"currency"`
+	Ownership   float64 `json:"ownership"` // share held by the group, 1 for the parent
+	Rate        float64 `json:"rate"`      // closing or average rate into the presentation currency
+}
+
+// ConsolidatedStatement is a balance sheet or P&L for a consolidation group, with
+// the companies, intercompany eliminations and non-controlling interest behind it
+type ConsolidatedStatement struct {
+	GroupID      string                 `json:"group_id"`
+	GroupName    string                 `json:"group_name"`
+	Method       string                 `json:"method"`
+	Statement    *FinancialStatement    `json:"statement"`
+	Companies    []*ConsolidatedCompany `json:"companies"`
+	Eliminations []*EliminationEntry    `json:"eliminations,omitempty"`
+	// Non-controlling share of subsidiaries' net assets on a balance sheet, or of
+	// their net income on a P&L
+	MinorityInterest *Amount `json:"minority_interest"`
+}
+
+// consolidation holds what a consolidated statement is built from: the group, its
+// companies, and the rates balances are translated at
+type consolidation struct {
+	group     *ConsolidationGroup
+	method    string
+	currency  Currency
+	rates     *ExchangeRateService // the parent company's
+	rateDates []time.Time          // averaged over; a single closing date for balance sheets
+	cache     map[Currency]float64
+	members   []*consolidationMember
+}
+
+// consolidationMember is one company of the group
+type consolidationMember struct {
+	company   *Company
+	engine    *AccountingEngine
+	ownership float64
+	parent    bool
+}
+
+// GenerateConsolidatedBalanceSheet combines the balance sheets of a group's parent and
+// child companies as of a date. Balances are translated at the closing rate, balances
+// arising from matched intercompany transactions within the group are eliminated,
+// and under full consolidation the share of partly owned subsidiaries' net assets
+// held by outside shareholders is shown as non-controlling interests in equity.
+func (mce *MultiCompanyEngine) GenerateConsolidatedBalanceSheet(groupID string, asOfDate time.Time) (*ConsolidatedStatement, error) {
+	c, err := mce.prepareConsolidation(groupID, []time.Time{asOfDate})
+	if err != nil {
+		return nil, err
+	}
+	result := c.newStatement()
+	lines := newConsolidatedLines()
+	minority := int64(0)
+
+	for _, member := range c.members {
+		trialBalance, err := companyTrialBalance(member.engine, asOfDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trial balance for company %s: %w", member.company.ID, err)
+		}
+		netAssets := int64(0)
+		for _, balance := range trialBalance {
+			if !containsAccountType([]AccountType{Asset, Liability, Equity}, balance.AccountType) {
+				continue
+			}
+			amount, err := c.translate(balance.Balance, member.company)
+			if err != nil {
+				return nil, fmt.Errorf("failed to translate %s for company %s: %w", balance.AccountID, member.company.ID, err)
+			}
+			lines.add(balance.AccountID, balance.AccountName, balance.AccountType, scaleValue(amount.Value, c.lineFactor(member, balance.AccountType)))
+			switch balance.AccountType {
+			case Asset:
+				netAssets += amount.Value
+			case Liability:
+				netAssets -= amount.Value
+			}
+		}
+		if c.method == "FULL" && !member.parent {
+			minority += scaleValue(netAssets, 1-member.ownership)
+		}
+		if result.Companies, err = c.appendCompany(result.Companies, member); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Eliminations, err = mce.intercompanyEliminations(c, func(txn *Transaction) bool {
+		return !txn.ValidTime.After(asOfDate)
+	}, []AccountType{Asset, Liability, Equity})
+	if err != nil {
+		return nil, err
+	}
+	lines.eliminate(result.Eliminations)
+
+	bs := &FinancialStatement{
+		Name:        "Consolidated Balance Sheet",
+		AsOfDate:    asOfDate,
+		Currency:    string(c.currency),
+		TotalAssets: c.amount(0),
+		TotalLiabs:  c.amount(0),
+		TotalEquity: c.amount(0),
+	}
+	assets := lines.items(Asset, c.currency, bs.TotalAssets)
+	liabilities := lines.items(Liability, c.currency, bs.TotalLiabs)
+	equity := lines.items(Equity, c.currency, bs.TotalEquity)
+	if c.method == "FULL" && len(c.members) > 1 {
+		equity = append(equity, &FinancialLineItem{
+			AccountName: "Non-controlling interests",
+			AccountType: Equity,
+			Amount:      c.amount(minority),
+			Level:       1,
+		})
+		bs.TotalEquity.Value += minority
+	}
+	bs.LineItems = []*FinancialLineItem{
+		{AccountName: "ASSETS", Level: 0, IsSubtotal: true, Amount: bs.TotalAssets, Children: assets},
+		{AccountName: "LIABILITIES", Level: 0, IsSubtotal: true, Amount: bs.TotalLiabs, Children: liabilities},
+		{AccountName: "EQUITY", Level: 0, IsSubtotal: true, Amount: bs.TotalEquity, Children: equity},
+	}
+
+	result.Statement = bs
+	result.MinorityInterest = c.amount(minority)
+	return result, nil
+}
+
+// GenerateConsolidatedProfitAndLoss combines the P&L of a group's parent and child
+// companies for a period. Each company's results are translated at the average rate
+// for the period, income and expenses from matched intercompany transactions within
+// the group are eliminated, and under full consolidation net income is attributed
+// between the owners of the parent and non-controlling interests.
+func (mce *MultiCompanyEngine) GenerateConsolidatedProfitAndLoss(groupID string, fromDate, toDate time.Time) (*ConsolidatedStatement, error) {
+	if toDate.Before(fromDate) {
+		return nil, fmt.Errorf("period end %s is before its start %s", toDate.Format("2006-01-02"), fromDate.Format("2006-01-02"))
+	}
+	c, err := mce.prepareConsolidation(groupID, averageRateDates(fromDate, toDate))
+	if err != nil {
+		return nil, err
+	}
+	result := c.newStatement()
+	lines := newConsolidatedLines()
+	minority := int64(0)
+
+	for _, member := range c.members {
+		closing, err := companyTrialBalance(member.engine, toDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trial balance for company %s: %w", member.company.ID, err)
+		}
+		opening, err := companyTrialBalance(member.engine, fromDate.Add(-time.Nanosecond))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trial balance for company %s: %w", member.company.ID, err)
+		}
+		openingValues := make(map[string]int64, len(opening))
+		for _, balance := range opening {
+			openingValues[balance.AccountID] = balance.Balance.Value
+		}
+
+		netIncome := int64(0)
+		for _, balance := range closing {
+			if balance.AccountType != Income && balance.AccountType != Expense {
+				continue
+			}
+			movement := &Amount{Value: balance.Balance.Value - openingValues[balance.AccountID], Currency: balance.Balance.Currency}
+			amount, err := c.translate(movement, member.company)
+			if err != nil {
+				return nil, fmt.Errorf("failed to translate %s for company %s: %w", balance.AccountID, member.company.ID, err)
+			}
+			lines.add(balance.AccountID, balance.AccountName, balance.AccountType, scaleValue(amount.Value, c.lineFactor(member, balance.AccountType)))
+			netIncome += amount.Value * -debitSign(balance.AccountType)
+		}
+		if c.method == "FULL" && !member.parent {
+			minority += scaleValue(netIncome, 1-member.ownership)
+		}
+		if result.Companies, err = c.appendCompany(result.Companies, member); err != nil {
+			return nil, err
+		}
+	}
+
+	result.Eliminations, err = mce.intercompanyEliminations(c, func(txn *Transaction) bool {
+		return !txn.ValidTime.Before(fromDate) && !txn.ValidTime.After(toDate)
+	}, []AccountType{Income, Expense})
+	if err != nil {
+		return nil, err
+	}
+	lines.eliminate(result.Eliminations)
+
+	totalRevenue := c.amount(0)
+	totalExpenses := c.amount(0)
+	revenue := lines.items(Income, c.currency, totalRevenue)
+	expenses := lines.items(Expense, c.currency, totalExpenses)
+	netIncome := c.amount(totalRevenue.Value - totalExpenses.Value)
+
+	pl := &FinancialStatement{
+		Name:      "Consolidated Profit & Loss Statement",
+		AsOfDate:  toDate,
+		FromDate:  &fromDate,
+		Currency:  string(c.currency),
+		NetIncome: netIncome,
+		LineItems: []*FinancialLineItem{
+			{AccountName: "REVENUE", Level: 0, IsSubtotal: true, Amount: totalRevenue, Children: revenue},
+			{AccountName: "EXPENSES", Level: 0, IsSubtotal: true, Amount: totalExpenses, Children: expenses},
+			{AccountName: "NET INCOME", Level: 0, IsSubtotal: true, Amount: netIncome},
+		},
+	}
+	if c.method == "FULL" && len(c.members) > 1 {
+		pl.LineItems = append(pl.LineItems,
+			&FinancialLineItem{AccountName: "Attributable to owners of the parent", Level: 1, Amount: c.amount(netIncome.Value - minority)},
+			&FinancialLineItem{AccountName: "Attributable to non-controlling interests", Level: 1, Amount: c.amount(minority)},
+		)
+	}
+
+	result.Statement = pl
+	result.MinorityInterest = c.amount(minority)
+	return result, nil
+}
+
+// prepareConsolidation loads a group's companies and picks the presentation currency
+// and the rates balances are translated at
+func (mce *MultiCompanyEngine) prepareConsolidation(groupID string, rateDates []time.Time) (*consolidation, error) {
+	group, err := mce.storage.GetConsolidationGroup(groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consolidation group: %w", err)
+	}
+	method := group.ConsolidationMethod
+	switch method {
+	case "":
+		method = "FULL"
+	case "FULL", "PROPORTIONAL":
+	case "EQUITY":
+		return nil, fmt.Errorf("group %s uses the equity method, which does not consolidate line by line", group.Name)
+	default:
+		return nil, fmt.Errorf("unknown consolidation method %s", method)
+	}
+
+	c := &consolidation{
+		group:     group,
+		method:    method,
+		rateDates: rateDates,
+		cache:     make(map[Currency]float64),
+	}
+	for i, companyID := range append([]string{group.ParentCompany}, group.ChildCompanies...) {
+		company, err := mce.GetCompany(companyID)
+		if err != nil {
+			return nil, err
+		}
+		engine, err := mce.GetAccountingEngine(companyID)
+		if err != nil {
+			return nil, err
+		}
+		ownership := 1.0
+		if share, ok := group.Ownership[companyID]; ok && i > 0 {
+			ownership = share
+		}
+		c.members = append(c.members, &consolidationMember{company: company, engine: engine, ownership: ownership, parent: i == 0})
+	}
+
+	parent := c.members[0]
+	c.rates = parent.engine.exchangeRateService
+	c.currency = Currency(group.PresentationCurrency)
+	if c.currency == "" {
+		c.currency = Currency(parent.company.BaseCurrency)
+	}
+	if c.currency == "" {
+		c.currency = "USD"
+	}
+	return c, nil
+}
+
+// newStatement starts the result for the group
+func (c *consolidation) newStatement() *ConsolidatedStatement {
+	return &ConsolidatedStatement{
+		GroupID:   c.group.ID,
+		GroupName: c.group.Name,
+		Method:    c.method,
+	}
+}
+
+// appendCompany adds a member to the companies a statement was built from
+func (c *consolidation) appendCompany(companies []*ConsolidatedCompany, member *consolidationMember) ([]*ConsolidatedCompany, error) {
+	currency := c.nativeCurrency("", member.company)
+	rate, err := c.rate(currency)
+	if err != nil {
+		return nil, err
+	}
+	return append(companies, &ConsolidatedCompany{
+		CompanyID:   member.company.ID,
+		CompanyName: member.company.Name,
+		Currency:    string(currency),
+		Ownership:   member.ownership,
+		Rate:        rate,
+	}), nil
+}
+
+// lineFactor is the share of a member's balance that is brought into the group.
+// Full consolidation brings in every line but moves the outside share of a
+// subsidiary's equity to non-controlling interests; proportional consolidation
+// brings in the group's share of every line.
+func (c *consolidation) lineFactor(member *consolidationMember, accountType AccountType) float64 {
+	if c.method == "PROPORTIONAL" || (!member.parent && accountType == Equity) {
+		return member.ownership
+	}
+	return 1
+}
+
+// nativeCurrency is the currency an amount is held in, taking amounts without one
+// to be in the company's base currency
+func (c *consolidation) nativeCurrency(currency Currency, company *Company) Currency {
+	if currency != "" {
+		return currency
+	}
+	if company.BaseCurrency != "" {
+		return Currency(company.BaseCurrency)
+	}
+	return c.currency
+}
+
+// rate returns the rate from a currency into the presentation currency, averaged
+// over the rate dates
+func (c *consolidation) rate(from Currency) (float64, error) {
+	if from == c.currency {
+		return 1, nil
+	}
+	if rate, ok := c.cache[from]; ok {
+		return rate, nil
+	}
+	total := 0.0
+	for _, date := range c.rateDates {
+		rate, err := c.rates.GetRate(from, c.currency, date)
+		if err != nil {
+			return 0, err
+		}
+		total += rate.Rate
+	}
+	rate := total / float64(len(c.rateDates))
+	c.cache[from] = rate
+	return rate, nil
+}
+
+// translate converts a company's amount into the presentation currency
+func (c *consolidation) translate(amount *Amount, company *Company) (*Amount, error) {
+	from := c.nativeCurrency(amount.Currency, company)
+	if from == c.currency {
+		return c.amount(amount.Value), nil
+	}
+	rate, err := c.rate(from)
+	if err != nil {
+		return nil, err
+	}
+	native := &Amount{Value: amount.Value, Currency: from}
+	return native.Convert(c.currency, rate, c.rateDates[len(c.rateDates)-1], c.rates.GetRoundingPolicy())
+}
+
+// amount is a value in the presentation currency
+func (c *consolidation) amount(value int64) *Amount {
+	return &Amount{Value: value, Currency: c.currency}
+}
+
+// member returns the group company with an ID
+func (c *consolidation) member(companyID string) *consolidationMember {
+	for _, member := range c.members {
+		if member.company.ID == companyID {
+			return member
+		}
+	}
+	return nil
+}
+
+// intercompanyEliminations reverses, on both sides, the intercompany entries of
+// matched and reconciled transactions between companies of the group. Only
+// transactions accepted by include and entries on accounts of the given types are
+// eliminated.
+func (mce *MultiCompanyEngine) intercompanyEliminations(c *consolidation, include func(*Transaction) bool, accountTypes []AccountType) ([]*EliminationEntry, error) {
+	var eliminations []*EliminationEntry
+	seen := make(map[string]bool)
+	for _, member := range c.members {
+		icTxns, err := mce.storage.GetIntercompanyTransactionsByCompany(member.company.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get intercompany transactions: %w", err)
+		}
+		for _, icTxn := range icTxns {
+			if seen[icTxn.ID] || (icTxn.MatchingStatus != IntercompanyMatched && icTxn.MatchingStatus != IntercompanyReconciled) {
+				continue
+			}
+			source, target := c.member(icTxn.SourceCompanyID), c.member(icTxn.TargetCompanyID)
+			if source == nil || target == nil {
+				continue
+			}
+			seen[icTxn.ID] = true
+
+			sides := []struct {
+				member *consolidationMember
+				txnID  string
+			}{{source, icTxn.SourceTransactionID}, {target, icTxn.TargetTransactionID}}
+			for _, side := range sides {
+				txn, err := side.member.engine.storage.GetTransaction(side.txnID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get intercompany transaction %s in company %s: %w", side.txnID, side.member.company.ID, err)
+				}
+				if txn.Status != Posted || !include(txn) {
+					continue
+				}
+				for i := range txn.Entries {
+					entry := &txn.Entries[i]
+					if entryDimension(entry, DimIntercompany) == "" {
+						continue
+					}
+					account, err := side.member.engine.storage.GetAccount(entry.AccountID)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get account %s: %w", entry.AccountID, err)
+					}
+					if !containsAccountType(accountTypes, account.Type) {
+						continue
+					}
+					value := entry.Amount.Value * debitSign(account.Type)
+					if entry.Type == Credit {
+						value = -value
+					}
+					amount, err := c.translate(&Amount{Value: value, Currency: entry.Amount.Currency}, side.member.company)
+					if err != nil {
+						return nil, fmt.Errorf("failed to translate intercompany entry on %s: %w", entry.AccountID, err)
+					}
+					eliminations = append(eliminations, &EliminationEntry{
+						Description: fmt.Sprintf("Eliminate intercompany transaction: %s", icTxn.Description),
+						CompanyID:   side.member.company.ID,
+						AccountID:   entry.AccountID,
+						Amount:      c.amount(-scaleValue(amount.Value, c.lineFactor(side.member, account.Type))),
+						RuleID:      icTxn.ID,
+					})
+				}
+			}
+		}
+	}
+	return eliminations, nil
+}
+
+// companyTrialBalance returns a company's trial balance, restated when it reports in
+// a hyperinflationary economy
+func companyTrialBalance(engine *AccountingEngine, asOfDate time.Time) ([]*BalanceResult, error) {
+	if engine.GetInflationAdjustmentService().IsConfigured() {
+		restated, err := engine.GenerateRestatedTrialBalance(asOfDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restate trial balance: %w", err)
+		}
+		return restated.Balances, nil
+	}
+	return engine.GetTrialBalance(asOfDate, nil)
+}
+
+// averageRateDates samples a period for its average rate: the start, each month end
+// within the period, and the end
+func averageRateDates(fromDate, toDate time.Time) []time.Time {
+	dates := []time.Time{fromDate}
+	for monthEnd := endOfMonth(fromDate); monthEnd.Before(toDate); monthEnd = endOfMonth(monthEnd.Add(time.Nanosecond)) {
+		dates = append(dates, monthEnd)
+	}
+	return append(dates, toDate)
+}
+
+// endOfMonth is the last instant of the month containing t
+func endOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+}
+
+// scaleValue multiplies a value by a share, rounding half away from zero
+func scaleValue(value int64, share float64) int64 {
+	if share == 1 {
+		return value
+	}
+	return int64(math.Round(float64(value) * share))
+}
+
+// consolidatedLines accumulates statement lines by account in first-seen order
+type consolidatedLines struct {
+	order []*FinancialLineItem
+	byID  map[string]*FinancialLineItem
+}
+
+func newConsolidatedLines() *consolidatedLines {
+	return &consolidatedLines{byID: make(map[string]*FinancialLineItem)}
+}
+
+// add adds a value to an account's line
+func (l *consolidatedLines) add(accountID, accountName string, accountType AccountType, value int64) {
+	line, ok := l.byID[accountID]
+	if !ok {
+		line = &FinancialLineItem{
+			AccountID:   accountID,
+			AccountName: accountName,
+			AccountType: accountType,
+			Amount:      &Amount{},
+			Level:       1,
+		}
+		l.byID[accountID] = line
+		l.order = append(l.order, line)
+	}
+	line.Amount.Value += value
+}
+
+// eliminate applies elimination entries to the lines they adjust
+func (l *consolidatedLines) eliminate(eliminations []*EliminationEntry) {
+	for _, elimination := range eliminations {
+		if line, ok := l.byID[elimination.AccountID]; ok {
+			line.Amount.Value += elimination.Amount.Value
+		}
+	}
+}
+
+// items returns the lines of an account type in the presentation currency, adding
+// them to total
+func (l *consolidatedLines) items(accountType AccountType, currency Currency, total *Amount) []*FinancialLineItem {
+	items := make([]*FinancialLineItem, 0)
+	for _, line := range l.order {
+		if line.AccountType != accountType {
+			continue
+		}
+		line.Amount.Currency = currency
+		items = append(items, line)
+		total.Value += line.Amount.Value
+	}
+	return items
+}
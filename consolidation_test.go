@@ -0,0 +1,178 @@
+package accounting
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsolidatedStatements(t *testing.T) {
+	// Each company's engine opens its own database in the working directory
+	t.Chdir(t.TempDir())
+
+	dbFile := fmt.Sprintf("test_consolidation_%d.db", time.Now().UnixNano())
+	defer os.Remove(dbFile)
+
+	storage, err := NewStorage(dbFile)
+	require.NoError(t, err)
+	defer storage.Close()
+
+	mce := NewMultiCompanyEngine(*storage)
+	defer mce.Close()
+	userID := "group_controller"
+
+	engines := make(map[string]*AccountingEngine)
+	for _, company := range []*Company{
+		{ID: "holdco", Name: "Holding Corp", BaseCurrency: "USD"},
+		{ID: "alpha", Name: "Alpha Inc", BaseCurrency: "USD", ParentCompanyID: "holdco"},
+		{ID: "beta", Name: "Beta GmbH", BaseCurrency: "EUR", ParentCompanyID: "holdco"},
+	} {
+		company.Settings = &CompanySettings{DefaultChartOfAccounts: "standard", AllowIntercompanyTxn: true}
+		require.NoError(t, mce.CreateCompany(company, userID))
+		engine, err := mce.GetAccountingEngine(company.ID)
+		require.NoError(t, err)
+		require.NoError(t, engine.CreateAccount(&Account{ID: "share_capital", Name: "Share Capital", Type: Equity}, userID))
+		engines[company.ID] = engine
+	}
+
+	post := func(companyID string, validTime time.Time, currency Currency, value int64, debit, credit string, dims ...Dimension) string {
+		txn := &Transaction{
+			Description: fmt.Sprintf("%s to %s", debit, credit),
+			ValidTime:   validTime,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: value, Currency: currency}, Dimensions: dims},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: value, Currency: currency}, Dimensions: dims},
+			},
+		}
+		require.NoError(t, engines[companyID].CreateTransaction(txn, userID))
+		require.NoError(t, engines[companyID].PostTransaction(txn.ID, userID))
+		return txn.ID
+	}
+	jan := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	post("holdco", jan, "USD", 100000, "cash", "share_capital")
+	post("alpha", jan, "USD", 20000, "cash", "share_capital")
+	post("beta", jan, "EUR", 10000, "cash", "share_capital")
+	post("holdco", time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC), "USD", 3000, "cash", "revenue")
+	post("beta", time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC), "EUR", 5000, "cash", "revenue")
+	post("beta", time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC), "EUR", 1000, "expenses", "cash")
+
+	// Holdco charges Alpha a management fee
+	feeDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	feeSource := post("holdco", feeDate, "USD", 2000, "intercompany_receivable", "revenue", Dimension{Key: DimIntercompany, Value: "alpha"})
+	feeTarget := post("alpha", feeDate, "USD", 2000, "expenses", "intercompany_payable", Dimension{Key: DimIntercompany, Value: "holdco"})
+	require.NoError(t, storage.SaveIntercompanyTransaction(&IntercompanyTransaction{
+		ID: "fee", Description: "Management fee", SourceCompanyID: "holdco", TargetCompanyID: "alpha",
+		SourceTransactionID: feeSource, TargetTransactionID: feeTarget,
+		Amount: &Amount{Value: 2000, Currency: "USD"}, MatchingStatus: IntercompanyMatched,
+	}))
+	loan, err := mce.CreateIntercompanyTransaction("holdco", "alpha", &Amount{Value: 50000, Currency: "USD"}, "Intercompany loan", userID)
+	require.NoError(t, err)
+
+	_, err = engines["holdco"].SetExchangeRate("EUR", "USD", 1.10, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), userID)
+	require.NoError(t, err)
+	_, err = engines["holdco"].SetExchangeRate("EUR", "USD", 1.20, time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC), userID)
+	require.NoError(t, err)
+
+	t.Run("validates ownership", func(t *testing.T) {
+		err := mce.CreateConsolidationGroup(&ConsolidationGroup{Name: "Bad", ParentCompany: "holdco",
+			ChildCompanies: []string{"beta"}, Ownership: map[string]float64{"beta": 80}}, userID)
+		assert.ErrorContains(t, err, "at most 1")
+
+		err = mce.CreateConsolidationGroup(&ConsolidationGroup{Name: "Bad", ParentCompany: "holdco",
+			ChildCompanies: []string{"beta"}, Ownership: map[string]float64{"alpha": 0.5}}, userID)
+		assert.ErrorContains(t, err, "not a child company")
+	})
+
+	group := &ConsolidationGroup{
+		ID:                  "holdco_group",
+		Name:                "Holding Group",
+		ParentCompany:       "holdco",
+		ChildCompanies:      []string{"alpha", "beta"},
+		ConsolidationMethod: "FULL",
+		Ownership:           map[string]float64{"beta": 0.8},
+	}
+	require.NoError(t, mce.CreateConsolidationGroup(group, userID))
+
+	lineValues := func(section *FinancialLineItem) map[string]int64 {
+		values := make(map[string]int64)
+		for _, line := range section.Children {
+			values[line.AccountName] = line.Amount.Value
+		}
+		return values
+	}
+
+	t.Run("balance sheet", func(t *testing.T) {
+		result, err := mce.GenerateConsolidatedBalanceSheet(group.ID, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		bs := result.Statement
+		assert.Equal(t, "USD", bs.Currency)
+
+		// Beta's 14000 EUR of cash is translated at the 1.20 closing rate
+		assets := lineValues(bs.LineItems[0])
+		assert.Equal(t, int64(53000+70000+16800), assets["Cash"])
+		assert.Equal(t, int64(0), assets["Intercompany Receivable"])
+		assert.Equal(t, int64(0), lineValues(bs.LineItems[1])["Intercompany Payable"])
+		assert.Equal(t, int64(139800), bs.TotalAssets.Value)
+
+		// A fifth of Beta's 16800 USD of net assets belongs to outside shareholders
+		equity := lineValues(bs.LineItems[2])
+		assert.Equal(t, int64(100000+20000+9600), equity["Share Capital"])
+		assert.Equal(t, int64(3360), equity["Non-controlling interests"])
+		assert.Equal(t, int64(3360), result.MinorityInterest.Value)
+
+		require.Len(t, result.Eliminations, 4)
+		eliminated := make(map[string]int64)
+		for _, elimination := range result.Eliminations {
+			eliminated[elimination.RuleID+"/"+elimination.AccountID] += elimination.Amount.Value
+		}
+		assert.Equal(t, int64(-50000), eliminated[loan.ID+"/intercompany_receivable"])
+		assert.Equal(t, int64(-50000), eliminated[loan.ID+"/intercompany_payable"])
+		assert.Equal(t, int64(-2000), eliminated["fee/intercompany_receivable"])
+
+		require.Len(t, result.Companies, 3)
+		assert.Equal(t, 1.2, result.Companies[2].Rate)
+		assert.Equal(t, 0.8, result.Companies[2].Ownership)
+	})
+
+	t.Run("profit and loss", func(t *testing.T) {
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+		result, err := mce.GenerateConsolidatedProfitAndLoss(group.ID, from, to)
+		require.NoError(t, err)
+		pl := result.Statement
+
+		// Beta's results are translated at 1.15, the average of the rates on
+		// Jan 1, Jan 31, Feb 28 and Mar 31; the management fee is eliminated
+		assert.InDelta(t, 1.15, result.Companies[2].Rate, 1e-9)
+		assert.Equal(t, int64(3000+5750), pl.LineItems[0].Amount.Value)
+		assert.Equal(t, int64(1150), pl.LineItems[1].Amount.Value)
+		assert.Equal(t, int64(7600), pl.NetIncome.Value)
+		require.Len(t, result.Eliminations, 2)
+
+		assert.Equal(t, int64(920), result.MinorityInterest.Value)
+		require.Len(t, pl.LineItems, 5)
+		assert.Equal(t, int64(6680), pl.LineItems[3].Amount.Value)
+		assert.Equal(t, int64(920), pl.LineItems[4].Amount.Value)
+	})
+
+	t.Run("proportional consolidation", func(t *testing.T) {
+		proportional := &ConsolidationGroup{Name: "Joint Venture", ParentCompany: "holdco", ChildCompanies: []string{"beta"},
+			ConsolidationMethod: "PROPORTIONAL", Ownership: map[string]float64{"beta": 0.5}}
+		require.NoError(t, mce.CreateConsolidationGroup(proportional, userID))
+
+		result, err := mce.GenerateConsolidatedBalanceSheet(proportional.ID, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(53000+8400), lineValues(result.Statement.LineItems[0])["Cash"])
+		assert.Equal(t, int64(0), result.MinorityInterest.Value)
+		assert.Len(t, result.Statement.LineItems[2].Children, 1)
+
+		equityMethod := &ConsolidationGroup{Name: "Associates", ParentCompany: "holdco", ChildCompanies: []string{"beta"}, ConsolidationMethod: "EQUITY"}
+		require.NoError(t, mce.CreateConsolidationGroup(equityMethod, userID))
+		_, err = mce.GenerateConsolidatedBalanceSheet(equityMethod.ID, time.Now())
+		assert.ErrorContains(t, err, "equity method")
+	})
+}
@@ -0,0 +1,56 @@
+package accounting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronSchedule(t *testing.T) {
+	at := func(s string) time.Time {
+		parsed, err := time.Parse("2006-01-02 15:04", s)
+		require.NoError(t, err)
+		return parsed
+	}
+	next := func(expr, from string) string {
+		schedule, err := ParseCronSchedule(expr)
+		require.NoError(t, err)
+		return schedule.Next(at(from)).Format("2006-01-02 15:04 Mon")
+	}
+
+	t.Run("fields, ranges, lists and steps", func(t *testing.T) {
+		assert.Equal(t, "2024-05-01 06:00 Wed", next("0 6 1 * *", "2024-04-15 12:00"))
+		assert.Equal(t, "2024-04-15 12:15 Mon", next("*/15 * * * *", "2024-04-15 12:00"))
+		assert.Equal(t, "2024-04-15 12:20 Mon", next("5/15 * * * *", "2024-04-15 12:05"))
+		assert.Equal(t, "2024-04-16 09:30 Tue", next("30 9 * * mon-fri", "2024-04-15 09:30"))
+		assert.Equal(t, "2024-04-20 08:00 Sat", next("0 8,20 * * 6,7", "2024-04-15 09:30"))
+		assert.Equal(t, "2024-07-01 00:00 Mon", next("0 0 1 jan,apr,jul,oct *", "2024-04-15 09:30"))
+		assert.Equal(t, "2024-05-01 00:00 Wed", next("@monthly", "2024-04-15 09:30"))
+	})
+
+	t.Run("last day of the month", func(t *testing.T) {
+		assert.Equal(t, "2024-02-29 18:00 Thu", next("0 18 L * *", "2024-02-10 00:00"))
+		assert.Equal(t, "2024-03-31 18:00 Sun", next("0 18 L * *", "2024-02-29 18:00"))
+	})
+
+	t.Run("either day field matches when both are restricted", func(t *testing.T) {
+		// The 13th or any Friday
+		assert.Equal(t, "2024-04-12 00:00 Fri", next("0 0 13 * fri", "2024-04-10 00:00"))
+		assert.Equal(t, "2024-04-13 00:00 Sat", next("0 0 13 * fri", "2024-04-12 00:00"))
+	})
+
+	t.Run("invalid expressions", func(t *testing.T) {
+		for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "*/0 * * * *", "5-1 * * * *", "* * * * funday"} {
+			_, err := ParseCronSchedule(expr)
+			assert.Error(t, err, expr)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		schedule, err := ParseCronSchedule("0 0 31 2 *")
+		require.NoError(t, err)
+		assert.True(t, schedule.Next(at("2024-01-01 00:00")).IsZero())
+	})
+}
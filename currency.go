@@ -0,0 +1,334 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Currency Minor Units
+// ----------------------------------------------------------------------------
+
+// DefaultMinorUnits is the number of decimal places assumed for currencies
+// without registered metadata
+const DefaultMinorUnits = 2
+
+// currencyMinorUnits holds the ISO 4217 exponent for currencies that do not use
+// two decimal places
+var (
+	currencyMinorUnits = map[Currency]int{
+		"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+		"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0,
+		"XOF": 0, "XPF": 0,
+		"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	}
+	minorUnitsMutex sync.RWMutex
+)
+
+// MinorUnits returns the number of decimal places an amount in the currency carries.
+// Amount.Value is always expressed in these minor units.
+func MinorUnits(currency Currency) int {
+	minorUnitsMutex.RLock()
+	defer minorUnitsMutex.RUnlock()
+	if units, ok := currencyMinorUnits[currency]; ok {
+		return units
+	}
+	return DefaultMinorUnits
+}
+
+// RegisterMinorUnits sets the number of decimal places for a currency, for example
+// for a crypto asset or a currency not covered by the built-in table
+func RegisterMinorUnits(currency Currency, units int) error {
+	if currency == "" {
+		return fmt.Errorf("currency is required")
+	}
+	if units < 0 || units > 18 {
+		return fmt.Errorf("minor units for %s must be between 0 and 18, got %d", currency, units)
+	}
+
+	minorUnitsMutex.Lock()
+	defer minorUnitsMutex.Unlock()
+	currencyMinorUnits[currency] = units
+	return nil
+}
+
+// ToMajorUnits converts a value in minor units to a decimal number of major units
+func ToMajorUnits(value int64, currency Currency) float64 {
+	return float64(value) / math.Pow10(MinorUnits(currency))
+}
+
+// FromMajorUnits converts a decimal number of major units to minor units using the policy
+func FromMajorUnits(value float64, currency Currency, policy RoundingPolicy) (int64, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("invalid amount %v", value)
+	}
+
+	scaled, err := decimalRat(value)
+	if err != nil {
+		return 0, err
+	}
+	scaled.Mul(scaled, pow10Rat(MinorUnits(currency)))
+	return roundRat(scaled, policy)
+}
+
+// decimalRat is the decimal a float64 is written as, so 1.15 is 115/100 rather than
+// the binary fraction just below it, and half points round where they should
+func decimalRat(value float64) (*big.Rat, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("invalid number %v", value)
+	}
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(value, 'g', -1, 64))
+	if !ok {
+		return nil, fmt.Errorf("invalid number %v", value)
+	}
+	return r, nil
+}
+
+// applyRate multiplies a value in minor units by a decimal rate, such as a
+// percentage or a weight, and rounds the exact product once under the policy
+func applyRate(value int64, rate float64, policy RoundingPolicy) (int64, error) {
+	exact, err := decimalRat(rate)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rate: %w", err)
+	}
+	return roundRat(exact.Mul(exact, new(big.Rat).SetInt64(value)), policy)
+}
+
+// FormatMinorUnits renders a value with the currency's number of decimal places,
+// without floating-point rounding
+func FormatMinorUnits(value int64, currency Currency) string {
+	units := MinorUnits(currency)
+
+	sign := ""
+	magnitude := new(big.Int).SetInt64(value)
+	if magnitude.Sign() < 0 {
+		sign = "-"
+		magnitude.Neg(magnitude)
+	}
+
+	digits := magnitude.String()
+	if units == 0 {
+		return sign + digits
+	}
+	if len(digits) <= units {
+		digits = strings.Repeat("0", units-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-units] + "." + digits[len(digits)-units:]
+}
+
+// Format renders the amount with its currency symbol and decimal places
+func (a *Amount) Format() string {
+	if a == nil {
+		return ""
+	}
+	return currencySymbol(string(a.Currency)) + FormatMinorUnits(a.Value, a.Currency)
+}
+
+// ----------------------------------------------------------------------------
+// Amount Arithmetic
+// ----------------------------------------------------------------------------
+
+// RoundingPolicy controls how fractional minor units are resolved during conversion
+type RoundingPolicy string
+
+const (
+	RoundHalfUp     RoundingPolicy = "HALF_UP"     // 0.5 rounds away from zero
+	RoundHalfEven   RoundingPolicy = "HALF_EVEN"   // 0.5 rounds to the nearest even unit (banker's rounding)
+	RoundTowardZero RoundingPolicy = "TOWARD_ZERO" // fractions are truncated
+)
+
+// Add returns the sum of two amounts in the same currency
+func (a *Amount) Add(other *Amount) (*Amount, error) {
+	if err := a.checkSameCurrency(other); err != nil {
+		return nil, err
+	}
+
+	sum := a.Value + other.Value
+	if (other.Value > 0 && sum < a.Value) || (other.Value < 0 && sum > a.Value) {
+		return nil, fmt.Errorf("amount overflow adding %d and %d", a.Value, other.Value)
+	}
+
+	return &Amount{Value: sum, Currency: a.Currency}, nil
+}
+
+// Sub returns the difference of two amounts in the same currency
+func (a *Amount) Sub(other *Amount) (*Amount, error) {
+	if err := a.checkSameCurrency(other); err != nil {
+		return nil, err
+	}
+
+	diff := a.Value - other.Value
+	if (other.Value < 0 && diff < a.Value) || (other.Value > 0 && diff > a.Value) {
+		return nil, fmt.Errorf("amount overflow subtracting %d from %d", other.Value, a.Value)
+	}
+
+	return &Amount{Value: diff, Currency: a.Currency}, nil
+}
+
+// Convert translates the amount into another currency at the given rate, where rate
+// is the number of target currency units per unit of the amount's currency. The
+// result is rescaled when the two currencies use different minor units.
+func (a *Amount) Convert(to Currency, rate float64, rateDate time.Time, policy RoundingPolicy) (*Amount, error) {
+	if a == nil {
+		return nil, fmt.Errorf("cannot convert a nil amount")
+	}
+	if rate <= 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return nil, fmt.Errorf("invalid exchange rate %v for %s/%s", rate, a.Currency, to)
+	}
+
+	product, err := decimalRat(rate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exchange rate %v for %s/%s: %w", rate, a.Currency, to, err)
+	}
+	product.Mul(product, new(big.Rat).SetInt64(a.Value))
+	product.Mul(product, pow10Rat(MinorUnits(to)))
+	product.Quo(product, pow10Rat(MinorUnits(a.Currency)))
+
+	value, err := roundRat(product, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %d %s to %s: %w", a.Value, a.Currency, to, err)
+	}
+
+	return &Amount{
+		Value:            value,
+		Currency:         to,
+		ExchangeRate:     rate,
+		ExchangeRateDate: &rateDate,
+	}, nil
+}
+
+// Allocate splits the amount into parts that differ by at most one minor unit and sum
+// exactly to the original value. The remainder goes to the earliest parts.
+func (a *Amount) Allocate(parts int) ([]*Amount, error) {
+	if a == nil {
+		return nil, fmt.Errorf("cannot allocate a nil amount")
+	}
+	if parts <= 0 {
+		return nil, fmt.Errorf("cannot allocate into %d parts", parts)
+	}
+
+	share := a.Value / int64(parts)
+	remainder := a.Value % int64(parts)
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+		remainder = -remainder
+	}
+
+	allocations := make([]*Amount, parts)
+	for i := range allocations {
+		value := share
+		if int64(i) < remainder {
+			value += step
+		}
+		allocations[i] = &Amount{Value: value, Currency: a.Currency}
+	}
+	return allocations, nil
+}
+
+// AllocateByWeights splits the amount in proportion to the weights. Shares are
+// rounded down and the minor units left over go to the largest fractions, so the
+// parts sum exactly to the original value.
+func (a *Amount) AllocateByWeights(weights []int64) ([]*Amount, error) {
+	if a == nil {
+		return nil, fmt.Errorf("cannot allocate a nil amount")
+	}
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("cannot allocate without weights")
+	}
+	total := new(big.Int)
+	for _, weight := range weights {
+		if weight < 0 {
+			return nil, fmt.Errorf("allocation weight %d is negative", weight)
+		}
+		total.Add(total, big.NewInt(weight))
+	}
+	if total.Sign() == 0 {
+		return nil, fmt.Errorf("allocation weights sum to zero")
+	}
+
+	allocations := make([]*Amount, len(weights))
+	remainders := make([]*big.Int, len(weights))
+	allocated := int64(0)
+	for i, weight := range weights {
+		product := new(big.Int).Mul(big.NewInt(a.Value), big.NewInt(weight))
+		share, remainder := new(big.Int).QuoRem(product, total, new(big.Int))
+		allocations[i] = &Amount{Value: share.Int64(), Currency: a.Currency}
+		remainders[i] = remainder.Abs(remainder)
+		allocated += share.Int64()
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return remainders[order[i]].Cmp(remainders[order[j]]) > 0 })
+
+	step := int64(1)
+	if a.Value < 0 {
+		step = -1
+	}
+	for i := 0; allocated != a.Value; i++ {
+		allocations[order[i]].Value += step
+		allocated += step
+	}
+	return allocations, nil
+}
+
+// checkSameCurrency rejects arithmetic between amounts in different currencies
+func (a *Amount) checkSameCurrency(other *Amount) error {
+	if a == nil || other == nil {
+		return fmt.Errorf("cannot combine nil amounts")
+	}
+	if a.Currency != other.Currency {
+		return fmt.Errorf("currency mismatch: %s and %s", a.Currency, other.Currency)
+	}
+	return nil
+}
+
+// roundRat rounds an exact rational value to an integer number of minor units
+func roundRat(r *big.Rat, policy RoundingPolicy) (int64, error) {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 {
+		// Compare twice the remainder against the denominator to find the half point
+		twiceRem := new(big.Int).Abs(rem)
+		twiceRem.Lsh(twiceRem, 1)
+		cmp := twiceRem.Cmp(den)
+
+		awayFromZero := false
+		switch policy {
+		case RoundTowardZero:
+			awayFromZero = false
+		case RoundHalfEven:
+			awayFromZero = cmp > 0 || (cmp == 0 && quo.Bit(0) == 1)
+		case RoundHalfUp, "":
+			awayFromZero = cmp >= 0
+		default:
+			return 0, fmt.Errorf("unknown rounding policy %s", policy)
+		}
+
+		if awayFromZero {
+			quo.Add(quo, big.NewInt(int64(rem.Sign())))
+		}
+	}
+
+	if !quo.IsInt64() {
+		return 0, fmt.Errorf("converted amount overflows int64")
+	}
+	return quo.Int64(), nil
+}
+
+// pow10Rat returns 10^n as a rational number
+func pow10Rat(n int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+}
@@ -0,0 +1,321 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticRateProvider struct {
+	rates map[string]float64
+}
+
+func (p *staticRateProvider) Name() string { return "STATIC" }
+
+func (p *staticRateProvider) GetRate(from, to Currency, date time.Time) (float64, error) {
+	if rate, ok := p.rates[string(from)+string(to)]; ok {
+		return rate, nil
+	}
+	return 0, fmt.Errorf("rate not available")
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	rateDate := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Add and Sub", func(t *testing.T) {
+		sum, err := (&Amount{Value: 1050, Currency: "USD"}).Add(&Amount{Value: 250, Currency: "USD"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1300), sum.Value)
+
+		diff, err := (&Amount{Value: 1050, Currency: "USD"}).Sub(&Amount{Value: 2000, Currency: "USD"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(-950), diff.Value)
+
+		_, err = (&Amount{Value: 100, Currency: "USD"}).Add(&Amount{Value: 100, Currency: "EUR"})
+		assert.Error(t, err, "mixed currencies must not be added")
+
+		_, err = (&Amount{Value: math.MaxInt64, Currency: "USD"}).Add(&Amount{Value: 1, Currency: "USD"})
+		assert.Error(t, err, "overflow must be reported")
+	})
+
+	t.Run("Convert Rounding", func(t *testing.T) {
+		// 0.25 * 10 = 2.5 minor units
+		amount := &Amount{Value: 10, Currency: "USD"}
+
+		halfUp, err := amount.Convert("EUR", 0.25, rateDate, RoundHalfUp)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), halfUp.Value)
+
+		halfEven, err := amount.Convert("EUR", 0.25, rateDate, RoundHalfEven)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), halfEven.Value)
+
+		truncated, err := (&Amount{Value: -10, Currency: "USD"}).Convert("EUR", 0.29, rateDate, RoundTowardZero)
+		require.NoError(t, err)
+		assert.Equal(t, int64(-2), truncated.Value)
+
+		assert.Equal(t, Currency("EUR"), halfUp.Currency)
+		assert.Equal(t, 0.25, halfUp.ExchangeRate)
+
+		_, err = amount.Convert("EUR", 0, rateDate, RoundHalfUp)
+		assert.Error(t, err)
+	})
+
+	t.Run("Half Points", func(t *testing.T) {
+		// Rates and major units are taken as the decimals they are written as, so
+		// each value below lands exactly on a half point
+		cases := []struct {
+			value                    int64   // minor units
+			rate                     float64 // applied to value
+			major                    float64 // the same product as major units
+			halfUp, halfEven, toward int64
+		}{
+			{10, 1.05, 0.105, 11, 10, 10},
+			{10, 1.15, 0.115, 12, 12, 11},
+			{10, 1.25, 0.125, 13, 12, 12},
+			{10, 1.45, 0.145, 15, 14, 14},
+			{10, 0.35, 0.035, 4, 4, 3},
+			{-10, 1.15, -0.115, -12, -12, -11},
+		}
+		for _, c := range cases {
+			for policy, want := range map[RoundingPolicy]int64{RoundHalfUp: c.halfUp, RoundHalfEven: c.halfEven, RoundTowardZero: c.toward} {
+				converted, err := (&Amount{Value: c.value, Currency: "USD"}).Convert("EUR", c.rate, rateDate, policy)
+				require.NoError(t, err)
+				assert.Equal(t, want, converted.Value, "%d x %v under %s", c.value, c.rate, policy)
+
+				applied, err := applyRate(c.value, c.rate, policy)
+				require.NoError(t, err)
+				assert.Equal(t, want, applied, "%d x %v under %s", c.value, c.rate, policy)
+
+				major, err := FromMajorUnits(c.major, "USD", policy)
+				require.NoError(t, err)
+				assert.Equal(t, want, major, "%v major units under %s", c.major, policy)
+			}
+		}
+
+		_, err := applyRate(10, math.NaN(), RoundHalfUp)
+		assert.Error(t, err)
+	})
+}
+
+func TestCurrencyMinorUnits(t *testing.T) {
+	rateDate := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Formatting", func(t *testing.T) {
+		assert.Equal(t, "1234.56", FormatMinorUnits(123456, "USD"))
+		assert.Equal(t, "123456", FormatMinorUnits(123456, "JPY"))
+		assert.Equal(t, "123.456", FormatMinorUnits(123456, "BHD"))
+		assert.Equal(t, "-0.005", FormatMinorUnits(-5, "KWD"))
+		assert.Equal(t, "¥1500", (&Amount{Value: 1500, Currency: "JPY"}).Format())
+	})
+
+	t.Run("Major Units", func(t *testing.T) {
+		assert.Equal(t, 1.5, ToMajorUnits(1500, "BHD"))
+		assert.Equal(t, 1500.0, ToMajorUnits(1500, "JPY"))
+
+		value, err := FromMajorUnits(12.3456, "BHD", RoundHalfUp)
+		require.NoError(t, err)
+		assert.Equal(t, int64(12346), value)
+
+		value, err = FromMajorUnits(99.5, "JPY", RoundHalfEven)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100), value)
+	})
+
+	t.Run("Convert Between Exponents", func(t *testing.T) {
+		// USD 10.00 at 150 JPY per USD is JPY 1500
+		converted, err := (&Amount{Value: 1000, Currency: "USD"}).Convert("JPY", 150, rateDate, RoundHalfEven)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1500), converted.Value)
+
+		// BHD 1.000 at 2.65 USD per BHD is USD 2.65
+		converted, err = (&Amount{Value: 1000, Currency: "BHD"}).Convert("USD", 2.65, rateDate, RoundHalfEven)
+		require.NoError(t, err)
+		assert.Equal(t, int64(265), converted.Value)
+	})
+
+	t.Run("Allocate", func(t *testing.T) {
+		parts, err := (&Amount{Value: 1000, Currency: "JPY"}).Allocate(3)
+		require.NoError(t, err)
+		require.Len(t, parts, 3)
+		assert.Equal(t, []int64{334, 333, 333}, []int64{parts[0].Value, parts[1].Value, parts[2].Value})
+
+		_, err = (&Amount{Value: 1000, Currency: "JPY"}).Allocate(0)
+		assert.Error(t, err)
+	})
+}
+
+func TestExchangeRateService(t *testing.T) {
+	// Setup
+	dbFile := "test_exchange_rates.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "test_user"
+	january := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err = engine.SetExchangeRate("EUR", "USD", 1.10, january, userID)
+	require.NoError(t, err)
+	_, err = engine.SetExchangeRate("EUR", "USD", 1.20, february, userID)
+	require.NoError(t, err)
+
+	t.Run("Effective Dates", func(t *testing.T) {
+		rate, err := engine.GetExchangeRate("EUR", "USD", january.AddDate(0, 0, 15))
+		require.NoError(t, err)
+		assert.Equal(t, 1.10, rate.Rate)
+
+		rate, err = engine.GetExchangeRate("EUR", "USD", february.AddDate(0, 0, 15))
+		require.NoError(t, err)
+		assert.Equal(t, 1.20, rate.Rate)
+
+		_, err = engine.GetExchangeRate("EUR", "USD", january.AddDate(0, 0, -1))
+		assert.Error(t, err, "no rate is effective before the first entry")
+	})
+
+	t.Run("Inverse Rate", func(t *testing.T) {
+		converted, err := engine.ConvertAmount(&Amount{Value: 12000, Currency: "USD"}, "EUR", february)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10000), converted.Value)
+	})
+
+	t.Run("Provider Fallback", func(t *testing.T) {
+		engine.RegisterExchangeRateProvider(&staticRateProvider{rates: map[string]float64{"GBPUSD": 1.25}})
+
+		rate, err := engine.GetExchangeRate("GBP", "USD", february)
+		require.NoError(t, err)
+		assert.Equal(t, 1.25, rate.Rate)
+		assert.Equal(t, "STATIC", rate.Source)
+
+		history, err := engine.GetExchangeRateService().GetRateHistory("GBP", "USD")
+		require.NoError(t, err)
+		assert.Len(t, history, 1, "provider rates are retained")
+	})
+
+	t.Run("Translated Statements", func(t *testing.T) {
+		err := engine.CreateAccount(&Account{ID: "eur_bank", Code: "1010", Name: "EUR Bank", Type: Asset, Currency: "EUR"}, userID)
+		require.NoError(t, err)
+		err = engine.CreateAccount(&Account{ID: "eur_capital", Code: "3010", Name: "EUR Capital", Type: Equity, Currency: "EUR"}, userID)
+		require.NoError(t, err)
+
+		txn := &Transaction{
+			Description: "EUR capital injection",
+			ValidTime:   february,
+			Entries: []Entry{
+				{AccountID: "eur_bank", Type: Debit, Amount: Amount{Value: 100000, Currency: "EUR"}},
+				{AccountID: "eur_capital", Type: Credit, Amount: Amount{Value: 100000, Currency: "EUR"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+
+		trialBalance, err := engine.GenerateTrialBalance(february, "USD")
+		require.NoError(t, err)
+
+		translated := make(map[string]int64)
+		for _, balance := range trialBalance {
+			assert.Equal(t, Currency("USD"), balance.Balance.Currency)
+			translated[balance.AccountID] = balance.Balance.Value
+		}
+		assert.Equal(t, int64(120000), translated["eur_bank"])
+		assert.Equal(t, int64(120000), translated["eur_capital"])
+
+		balanceSheet, err := engine.GenerateBalanceSheet(february, "USD")
+		require.NoError(t, err)
+		assert.Equal(t, int64(120000), balanceSheet.TotalAssets.Value)
+		assert.Equal(t, int64(120000), balanceSheet.TotalEquity.Value)
+	})
+}
+
+func TestFXRevaluationAtClose(t *testing.T) {
+	// Setup
+	dbFile := "test_fx_revaluation.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "test_user"
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	accounts := []*Account{
+		{ID: "eur_bank", Code: "1010", Name: "EUR Bank", Type: Asset, Currency: "EUR"},
+		{ID: "eur_loan", Code: "2300", Name: "EUR Loan", Type: Liability, Currency: "EUR"},
+		{ID: "fx_gain", Code: "4900", Name: "Unrealized FX Gain", Type: Income},
+		{ID: "fx_loss", Code: "5900", Name: "Unrealized FX Loss", Type: Expense},
+	}
+	for _, account := range accounts {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	_, err = engine.SetExchangeRate("EUR", "USD", 1.10, start, userID)
+	require.NoError(t, err)
+	_, err = engine.SetExchangeRate("EUR", "USD", 1.15, end, userID)
+	require.NoError(t, err)
+
+	// EUR 1,000 borrowed and held in the bank, recorded at 1.10
+	txn := &Transaction{
+		Description: "EUR loan drawdown",
+		ValidTime:   start,
+		Entries: []Entry{
+			{AccountID: "eur_bank", Type: Debit, Amount: Amount{Value: 100000, Currency: "EUR"}},
+			{AccountID: "eur_loan", Type: Credit, Amount: Amount{Value: 100000, Currency: "EUR"}},
+		},
+	}
+	require.NoError(t, engine.CreateTransaction(txn, userID))
+	require.NoError(t, engine.PostTransaction(txn.ID, userID))
+
+	period := &Period{Name: "2024-03", Start: start, End: end}
+	require.NoError(t, engine.CreatePeriod(period, userID))
+
+	require.NoError(t, engine.ConfigureFXRevaluation(RevaluationConfig{
+		BaseCurrency:  "USD",
+		GainAccountID: "fx_gain",
+		LossAccountID: "fx_loss",
+	}))
+
+	preview, err := engine.CalculateFXRevaluation(period.ID)
+	require.NoError(t, err)
+	require.Len(t, preview.Lines, 2)
+
+	lines := make(map[string]*RevaluationLine)
+	for _, line := range preview.Lines {
+		lines[line.AccountID] = line
+	}
+	assert.Equal(t, int64(5000), lines["eur_bank"].UnrealizedGain)
+	assert.Equal(t, Debit, lines["eur_bank"].AdjustmentEntry)
+	assert.Equal(t, int64(-5000), lines["eur_loan"].UnrealizedGain)
+	assert.Equal(t, Credit, lines["eur_loan"].AdjustmentEntry)
+	assert.Equal(t, int64(0), preview.TotalGain)
+
+	require.NoError(t, engine.ClosePeriod(period.ID, true, userID))
+
+	gain, err := engine.GetAccountBalance("fx_gain", end)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), gain.Balance.Value)
+
+	loss, err := engine.GetAccountBalance("fx_loss", end)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), loss.Balance.Value)
+
+	// Foreign balances are untouched by the revaluation
+	bank, err := engine.GetAccountBalance("eur_bank", end)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100000), bank.Balance.Value)
+
+	// A second close finds nothing further to revalue
+	after, err := engine.CalculateFXRevaluation(period.ID)
+	require.NoError(t, err)
+	assert.Empty(t, after.Lines)
+}
@@ -0,0 +1,368 @@
+package accounting
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ----------------------------------------------------------------------------
+// Data Export Structures
+// ----------------------------------------------------------------------------
+
+// ExportDataset names the records ExportData writes
+type ExportDataset string
+
+const (
+	ExportCustomers    ExportDataset = "CUSTOMERS"
+	ExportVendors      ExportDataset = "VENDORS"
+	ExportAMLCustomers ExportDataset = "AML_CUSTOMERS" // needs MANAGE_AML_CASES
+	ExportAMLAlerts    ExportDataset = "AML_ALERTS"    // needs MANAGE_AML_CASES
+)
+
+// Sensitive fields, named dataset.column in lower case
+const (
+	FieldCustomerName        = "customers.name"
+	FieldCustomerTaxID       = "customers.tax_id"
+	FieldCustomerBankAccount = "customers.bank_account"
+	FieldCustomerEmail       = "customers.email"
+	FieldVendorName          = "vendors.name"
+	FieldVendorTaxID         = "vendors.tax_id"
+	FieldVendorBankAccount   = "vendors.bank_account"
+	FieldAMLCustomerName     = "aml_customers.name"
+	FieldAMLAlertSARNumber   = "aml_alerts.sar_number"
+)
+
+// MaskAction is how a sensitive field is written for users without VIEW_PII
+type MaskAction string
+
+const (
+	MaskShow     MaskAction = "SHOW"      // written as is
+	MaskInitials MaskAction = "INITIALS"  // "Jane Doe" becomes "J. D."
+	MaskLastFour MaskAction = "LAST_FOUR" // all but the last four characters become *
+	MaskRedact   MaskAction = "REDACT"    // replaced by [REDACTED]
+	MaskOmit     MaskAction = "OMIT"      // left out of the export entirely
+)
+
+// maskRedacted replaces redacted values
+const maskRedacted = "[REDACTED]"
+
+// MaskingRule sets the action for a field, in one export format or, with no format,
+// in all of them
+type MaskingRule struct {
+	Field  string       `json:"field"`
+	Format ExportFormat `json:"format,omitempty"`
+	Action MaskAction   `json:"action"`
+}
+
+// MaskingPolicy decides how sensitive fields are written for users without VIEW_PII.
+// A rule for the export's format wins over one for all formats; fields without a
+// rule are written as is.
+type MaskingPolicy struct {
+	Rules []MaskingRule `json:"rules"`
+}
+
+// DefaultMaskingPolicy shows initials of names, the last four characters of tax IDs
+// and bank accounts, redacts emails and leaves out SAR numbers
+func DefaultMaskingPolicy() *MaskingPolicy {
+	return &MaskingPolicy{Rules: []MaskingRule{
+		{Field: FieldCustomerName, Action: MaskInitials},
+		{Field: FieldCustomerTaxID, Action: MaskLastFour},
+		{Field: FieldCustomerBankAccount, Action: MaskLastFour},
+		{Field: FieldCustomerEmail, Action: MaskRedact},
+		{Field: FieldVendorName, Action: MaskInitials},
+		{Field: FieldVendorTaxID, Action: MaskLastFour},
+		{Field: FieldVendorBankAccount, Action: MaskLastFour},
+		{Field: FieldAMLCustomerName, Action: MaskInitials},
+		{Field: FieldAMLAlertSARNumber, Action: MaskOmit},
+	}}
+}
+
+// action returns how a field is written in a format
+func (p *MaskingPolicy) action(field string, format ExportFormat) MaskAction {
+	action := MaskShow
+	for _, rule := range p.Rules {
+		if rule.Field != field {
+			continue
+		}
+		if rule.Format == format {
+			return rule.Action
+		}
+		if rule.Format == "" {
+			action = rule.Action
+		}
+	}
+	return action
+}
+
+// validate checks every rule names a field and a known action
+func (p *MaskingPolicy) validate() error {
+	for _, rule := range p.Rules {
+		if rule.Field == "" {
+			return fmt.Errorf("masking rule needs a field")
+		}
+		switch rule.Action {
+		case MaskShow, MaskInitials, MaskLastFour, MaskRedact, MaskOmit:
+		default:
+			return fmt.Errorf("unknown mask action %q for field %s", rule.Action, rule.Field)
+		}
+	}
+	return nil
+}
+
+// maskValue applies a mask action to a value
+func maskValue(value string, action MaskAction) string {
+	if value == "" {
+		return ""
+	}
+	switch action {
+	case MaskInitials:
+		var initials []string
+		for _, word := range strings.Fields(value) {
+			r, _ := utf8.DecodeRuneInString(word)
+			initials = append(initials, string(r)+".")
+		}
+		return strings.Join(initials, " ")
+	case MaskLastFour:
+		runes := []rune(value)
+		if len(runes) <= 4 {
+			return strings.Repeat("*", len(runes))
+		}
+		return strings.Repeat("*", len(runes)-4) + string(runes[len(runes)-4:])
+	case MaskRedact:
+		return maskRedacted
+	}
+	return value
+}
+
+// DataExportResult describes an export, and is recorded with the user who ran it
+type DataExportResult struct {
+	Dataset    ExportDataset `json:"dataset"`
+	Format     ExportFormat  `json:"format"`
+	Rows       int           `json:"rows"`
+	Unmasked   bool          `json:"unmasked"`          // the user holds VIEW_PII
+	Masked     []string      `json:"masked,omitempty"`  // fields masked or redacted
+	Omitted    []string      `json:"omitted,omitempty"` // fields left out
+	ExportedBy string        `json:"exported_by"`
+	ExportedAt time.Time     `json:"exported_at"`
+}
+
+// exportTable is a dataset laid out as columns and rows
+type exportTable struct {
+	columns []string
+	rows    [][]any
+}
+
+// ----------------------------------------------------------------------------
+// Data Export Service
+// ----------------------------------------------------------------------------
+
+// DataExportService exports master data and AML case data, masking sensitive fields
+// for users who do not hold VIEW_PII
+type DataExportService struct {
+	storage    *Storage
+	eventStore *EventStore
+	access     *AccessControlService
+	mu         sync.RWMutex
+	policy     *MaskingPolicy
+}
+
+// NewDataExportService creates a new data export service using the default masking
+// policy
+func NewDataExportService(storage *Storage, eventStore *EventStore, access *AccessControlService) *DataExportService {
+	return &DataExportService{
+		storage:    storage,
+		eventStore: eventStore,
+		access:     access,
+		policy:     DefaultMaskingPolicy(),
+	}
+}
+
+// SetMaskingPolicy replaces the masking policy; nil restores the default
+func (des *DataExportService) SetMaskingPolicy(policy *MaskingPolicy) error {
+	if policy == nil {
+		policy = DefaultMaskingPolicy()
+	}
+	if err := policy.validate(); err != nil {
+		return err
+	}
+	des.mu.Lock()
+	defer des.mu.Unlock()
+	des.policy = &MaskingPolicy{Rules: slices.Clone(policy.Rules)}
+	return nil
+}
+
+// GetMaskingPolicy returns the masking policy in force
+func (des *DataExportService) GetMaskingPolicy() *MaskingPolicy {
+	des.mu.RLock()
+	defer des.mu.RUnlock()
+	return &MaskingPolicy{Rules: slices.Clone(des.policy.Rules)}
+}
+
+// Export writes a dataset as CSV or JSON lines. Users without VIEW_PII get sensitive
+// fields masked or left out as the policy sets for the format. The export is recorded
+// as an EXPORT_DATA event.
+func (des *DataExportService) Export(w io.Writer, dataset ExportDataset, format ExportFormat, userID string) (*DataExportResult, error) {
+	if dataset == ExportAMLCustomers || dataset == ExportAMLAlerts {
+		if err := des.access.Authorize(userID, PermissionManageAMLCases, "export "+strings.ToLower(string(dataset))); err != nil {
+			return nil, err
+		}
+	}
+	unmasked, err := des.access.HasPermission(userID, PermissionViewPII)
+	if err != nil {
+		return nil, err
+	}
+	table, err := des.table(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DataExportResult{Dataset: dataset, Format: format, Unmasked: unmasked, ExportedBy: userID, ExportedAt: time.Now()}
+	prefix := strings.ToLower(string(dataset)) + "."
+	actions := make([]MaskAction, len(table.columns))
+	var header []string
+	policy := des.GetMaskingPolicy()
+	for i, column := range table.columns {
+		actions[i] = MaskShow
+		if !unmasked {
+			actions[i] = policy.action(prefix+column, format)
+		}
+		switch actions[i] {
+		case MaskOmit:
+			result.Omitted = append(result.Omitted, prefix+column)
+			continue
+		case MaskShow:
+		default:
+			result.Masked = append(result.Masked, prefix+column)
+		}
+		header = append(header, column)
+	}
+
+	stream, err := newReportStream(w, format, header)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range table.rows {
+		record := make([]string, 0, len(header))
+		object := make(map[string]any, len(header))
+		for i, value := range row {
+			if actions[i] == MaskOmit {
+				continue
+			}
+			if text, ok := value.(string); ok && actions[i] != MaskShow {
+				value = maskValue(text, actions[i])
+			}
+			record = append(record, formatExportValue(value))
+			object[table.columns[i]] = value
+		}
+		if err := stream.write(record, object); err != nil {
+			return nil, err
+		}
+		result.Rows++
+	}
+	if err := stream.close(); err != nil {
+		return nil, err
+	}
+
+	if _, err := des.eventStore.CreateEvent(EventExportData, result, result.ExportedAt, userID); err != nil {
+		return nil, fmt.Errorf("failed to create data export event: %w", err)
+	}
+	return result, nil
+}
+
+// formatExportValue writes a value for a CSV cell
+func formatExportValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339Nano)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return v.Format(time.RFC3339Nano)
+	case *Amount:
+		if v == nil {
+			return ""
+		}
+		return FormatMinorUnits(v.Value, v.Currency)
+	}
+	return fmt.Sprint(value)
+}
+
+// table reads a dataset's records, ordered by ID
+func (des *DataExportService) table(dataset ExportDataset) (*exportTable, error) {
+	switch dataset {
+	case ExportCustomers:
+		customers, err := des.storage.GetAllCustomers()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get customers: %w", err)
+		}
+		slices.SortFunc(customers, func(a, b *Customer) int { return strings.Compare(a.ID, b.ID) })
+		table := &exportTable{columns: []string{"id", "name", "tax_id", "bank_account", "email", "currency", "active", "merged_into", "created_at"}}
+		for _, c := range customers {
+			table.rows = append(table.rows, []any{c.ID, c.Name, c.TaxID, c.BankAccount, c.Email, string(c.Currency), c.Active, c.MergedInto, c.CreatedAt})
+		}
+		return table, nil
+
+	case ExportVendors:
+		vendors, err := des.storage.GetAllVendors()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vendors: %w", err)
+		}
+		slices.SortFunc(vendors, func(a, b *Vendor) int { return strings.Compare(a.ID, b.ID) })
+		table := &exportTable{columns: []string{"id", "name", "tax_id", "bank_account", "currency", "payment_terms_days", "active", "merged_into", "created_at"}}
+		for _, v := range vendors {
+			table.rows = append(table.rows, []any{v.ID, v.Name, v.TaxID, v.BankAccount, string(v.Currency), v.PaymentTermsDays, v.Active, v.MergedInto, v.CreatedAt})
+		}
+		return table, nil
+
+	case ExportAMLCustomers:
+		customers, err := des.storage.GetAllAMLCustomers()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get AML customers: %w", err)
+		}
+		slices.SortFunc(customers, func(a, b *AMLCustomer) int { return strings.Compare(a.ID, b.ID) })
+		table := &exportTable{columns: []string{"id", "customer_id", "name", "type", "risk_level", "country", "is_pep", "is_high_risk", "sanctions_match", "next_review_date"}}
+		for _, c := range customers {
+			table.rows = append(table.rows, []any{c.ID, c.CustomerID, c.Name, c.Type, string(c.RiskLevel), c.Country, c.IsPEP, c.IsHighRisk, c.SanctionsMatch, c.NextReviewDate})
+		}
+		return table, nil
+
+	case ExportAMLAlerts:
+		alerts, err := des.storage.GetAMLAlerts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get AML alerts: %w", err)
+		}
+		slices.SortFunc(alerts, func(a, b *AMLAlert) int { return strings.Compare(a.ID, b.ID) })
+		table := &exportTable{columns: []string{"id", "rule_type", "risk_level", "status", "entity_id", "assigned_to", "amount", "detected_at", "disposition", "sar_number"}}
+		for _, a := range alerts {
+			var disposition string
+			var sarNumbers []string
+			for _, d := range a.Dispositions {
+				disposition = d.Type
+				if d.SARNumber != "" {
+					sarNumbers = append(sarNumbers, d.SARNumber)
+				}
+			}
+			table.rows = append(table.rows, []any{a.ID, string(a.RuleType), string(a.RiskLevel), a.Status, a.EntityID, a.AssignedTo, a.Amount, a.DetectedAt, disposition, strings.Join(sarNumbers, ";")})
+		}
+		return table, nil
+	}
+	return nil, fmt.Errorf("unknown export dataset: %s", dataset)
+}
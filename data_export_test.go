@@ -0,0 +1,124 @@
+package accounting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataExportMasking(t *testing.T) {
+	dbFile := "test_data_export.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	admin, analyst, clerk := "admin", "analyst", "clerk"
+	require.NoError(t, engine.CreateCustomer(&Customer{
+		ID: "cust-1", Name: "Jane Doe", TaxID: "123-45-6789", BankAccount: "GB29NWBK60161331926819", Email: "jane@example.com", Currency: "USD",
+	}, admin))
+	require.NoError(t, engine.GetAMLService().RegisterCustomer(&AMLCustomer{ID: "aml-1", CustomerID: "cust-1", Name: "Jane Doe", Type: "INDIVIDUAL", RiskLevel: RiskHigh}))
+	require.NoError(t, engine.GetAMLService().RaiseAlert(&AMLAlert{
+		ID: "alert-1", RuleType: "STRUCTURING", RiskLevel: RiskHigh, EntityID: "cust-1", DetectedAt: time.Now(),
+		Dispositions: []AMLDisposition{{ID: "d-1", Type: "SAR_FILED", SARNumber: "SAR-2025-0042"}},
+	}))
+
+	exportCSV := func(dataset ExportDataset, userID string) ([][]string, *DataExportResult) {
+		var out bytes.Buffer
+		result, err := engine.ExportData(&out, dataset, ExportFormatCSV, userID)
+		require.NoError(t, err)
+		records, err := csv.NewReader(&out).ReadAll()
+		require.NoError(t, err)
+		return records, result
+	}
+
+	t.Run("Everything Is Shown Before Users Are Registered", func(t *testing.T) {
+		records, result := exportCSV(ExportCustomers, clerk)
+		assert.True(t, result.Unmasked)
+		assert.Equal(t, "Jane Doe", records[1][1])
+	})
+
+	administrators := &Role{Name: "Administrators", Permissions: []Permission{PermissionManageAccess, PermissionViewPII, PermissionManageAMLCases}}
+	compliance := &Role{Name: "Compliance", Permissions: []Permission{PermissionManageAMLCases}}
+	require.NoError(t, engine.CreateRole(administrators, admin))
+	require.NoError(t, engine.CreateRole(compliance, admin))
+	require.NoError(t, engine.CreateUser(&User{ID: admin, RoleIDs: []string{administrators.ID}}, admin))
+	require.NoError(t, engine.CreateUser(&User{ID: analyst, RoleIDs: []string{compliance.ID}}, admin))
+	require.NoError(t, engine.CreateUser(&User{ID: clerk}, admin))
+
+	t.Run("Default Policy Masks Customers", func(t *testing.T) {
+		records, result := exportCSV(ExportCustomers, clerk)
+		assert.False(t, result.Unmasked)
+		assert.Equal(t, 1, result.Rows)
+		assert.Equal(t, []string{FieldCustomerName, FieldCustomerTaxID, FieldCustomerBankAccount, FieldCustomerEmail}, result.Masked)
+		assert.Equal(t, []string{"id", "name", "tax_id", "bank_account", "email", "currency", "active", "merged_into", "created_at"}, records[0])
+		assert.Equal(t, []string{"cust-1", "J. D.", "*******6789", "******************6819", "[REDACTED]", "USD"}, records[1][:6])
+
+		records, result = exportCSV(ExportCustomers, admin)
+		assert.True(t, result.Unmasked)
+		assert.Empty(t, result.Masked)
+		assert.Equal(t, []string{"cust-1", "Jane Doe", "123-45-6789", "GB29NWBK60161331926819", "jane@example.com"}, records[1][:5])
+	})
+
+	t.Run("SAR Numbers Are Left Out", func(t *testing.T) {
+		var out bytes.Buffer
+		_, err := engine.ExportData(&out, ExportAMLAlerts, ExportFormatCSV, clerk)
+		assert.ErrorContains(t, err, "MANAGE_AML_CASES")
+
+		records, result := exportCSV(ExportAMLAlerts, analyst)
+		assert.Equal(t, []string{FieldAMLAlertSARNumber}, result.Omitted)
+		assert.NotContains(t, records[0], "sar_number")
+		assert.NotContains(t, strings.Join(records[1], ","), "SAR-2025-0042")
+		assert.Contains(t, records[1], "SAR_FILED")
+
+		records, _ = exportCSV(ExportAMLAlerts, admin)
+		assert.Equal(t, "sar_number", records[0][len(records[0])-1])
+		assert.Equal(t, "SAR-2025-0042", records[1][len(records[1])-1])
+	})
+
+	t.Run("Policy Is Configurable Per Field And Format", func(t *testing.T) {
+		assert.ErrorContains(t, engine.SetMaskingPolicy(&MaskingPolicy{Rules: []MaskingRule{{Field: FieldAMLCustomerName, Action: "SCRAMBLE"}}}), "unknown mask action")
+		require.NoError(t, engine.SetMaskingPolicy(&MaskingPolicy{Rules: []MaskingRule{
+			{Field: FieldAMLCustomerName, Action: MaskRedact},
+			{Field: FieldAMLCustomerName, Format: ExportFormatJSONLines, Action: MaskOmit},
+		}}))
+		defer engine.SetMaskingPolicy(nil)
+
+		records, _ := exportCSV(ExportAMLCustomers, analyst)
+		assert.Equal(t, "[REDACTED]", records[1][2])
+
+		var out bytes.Buffer
+		result, err := engine.ExportData(&out, ExportAMLCustomers, ExportFormatJSONLines, analyst)
+		require.NoError(t, err)
+		assert.Equal(t, []string{FieldAMLCustomerName}, result.Omitted)
+		var row map[string]any
+		require.NoError(t, json.Unmarshal(out.Bytes(), &row))
+		assert.Equal(t, "aml-1", row["id"])
+		assert.NotContains(t, row, "name")
+		assert.Equal(t, "HIGH", row["risk_level"])
+	})
+
+	t.Run("Exports Are Recorded", func(t *testing.T) {
+		events, err := engine.GetEvents(time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		var exports []DataExportResult
+		for _, event := range events {
+			if event.EventType == EventExportData {
+				var result DataExportResult
+				require.NoError(t, json.Unmarshal(event.Payload, &result))
+				exports = append(exports, result)
+			}
+		}
+		require.NotEmpty(t, exports)
+		assert.Equal(t, clerk, exports[0].ExportedBy)
+		assert.Equal(t, ExportCustomers, exports[0].Dataset)
+	})
+}
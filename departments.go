@@ -0,0 +1,296 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Department and Cost Center Structures
+// ----------------------------------------------------------------------------
+
+// DepartmentKind tells departments apart from the cost centers within them
+type DepartmentKind string
+
+const (
+	DepartmentKindDepartment DepartmentKind = "DEPARTMENT"
+	DepartmentKindCostCenter DepartmentKind = "COST_CENTER"
+)
+
+// Department is a node of the organization tree that budgets are requested and
+// allocated for. Budget requests reference it by ID; its default dimensions are
+// added to budget lines that do not set those keys themselves.
+type Department struct {
+	ID                string         `json:"id"`
+	Code              string         `json:"code"`
+	Name              string         `json:"name"`
+	Kind              DepartmentKind `json:"kind"`
+	ParentID          string         `json:"parent_id,omitempty"`
+	Owners            []string       `json:"owners,omitempty"` // users accountable for its budget
+	ActiveFrom        time.Time      `json:"active_from"`      // zero for always
+	ActiveTo          *time.Time     `json:"active_to,omitempty"`
+	DefaultDimensions []Dimension    `json:"default_dimensions,omitempty"`
+	CreatedBy         string         `json:"created_by"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+}
+
+// ActiveDuring reports whether the department is active at any time from start to end
+func (d *Department) ActiveDuring(start, end time.Time) bool {
+	return !d.ActiveFrom.After(end) && (d.ActiveTo == nil || !d.ActiveTo.Before(start))
+}
+
+// OrgBudgetSummary is a department's budget summary with the totals of its whole
+// subtree rolled in
+type OrgBudgetSummary struct {
+	DepartmentID   string                   `json:"department_id"`
+	Name           string                   `json:"name"`
+	Kind           DepartmentKind           `json:"kind"`
+	Level          int                      `json:"level"` // 0 for the department asked for
+	Summary        *DepartmentBudgetSummary `json:"summary"`
+	TotalRequested *Amount                  `json:"total_requested"` // including descendants
+	TotalApproved  *Amount                  `json:"total_approved"`
+	TotalAllocated *Amount                  `json:"total_allocated"`
+	TotalSpent     *Amount                  `json:"total_spent"`
+	Children       []*OrgBudgetSummary      `json:"children,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// Department Methods
+// ----------------------------------------------------------------------------
+
+// CreateDepartment adds a department or cost center to the organization tree
+func (zbb *ZBBService) CreateDepartment(department *Department, userID string) error {
+	if err := zbb.storage.assignID(&department.ID, "department", BucketDepartments); err != nil {
+		return err
+	}
+	if department.Kind == "" {
+		department.Kind = DepartmentKindDepartment
+	}
+	department.CreatedBy = userID
+	department.CreatedAt = time.Now()
+	department.UpdatedAt = department.CreatedAt
+
+	if err := zbb.validateDepartment(department); err != nil {
+		return err
+	}
+	return zbb.saveDepartment(department, EventCreateDepartment, userID)
+}
+
+// UpdateDepartment replaces a department's details, for example to move it in the
+// tree, change its owners or close it by setting ActiveTo
+func (zbb *ZBBService) UpdateDepartment(department *Department, userID string) error {
+	existing, err := zbb.storage.GetDepartment(department.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get department: %w", err)
+	}
+	if department.Kind == "" {
+		department.Kind = existing.Kind
+	}
+	department.CreatedBy = existing.CreatedBy
+	department.CreatedAt = existing.CreatedAt
+	department.UpdatedAt = time.Now()
+
+	if err := zbb.validateDepartment(department); err != nil {
+		return err
+	}
+	return zbb.saveDepartment(department, EventUpdateDepartment, userID)
+}
+
+// GetDepartment retrieves a department or cost center
+func (zbb *ZBBService) GetDepartment(departmentID string) (*Department, error) {
+	return zbb.storage.GetDepartment(departmentID)
+}
+
+// ListDepartments returns the departments active at some point from start to end,
+// or all of them when both are zero, sorted by code and then ID
+func (zbb *ZBBService) ListDepartments(start, end time.Time) ([]*Department, error) {
+	departments, err := zbb.storage.GetAllDepartments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get departments: %w", err)
+	}
+	var listed []*Department
+	for _, department := range departments {
+		if (start.IsZero() && end.IsZero()) || department.ActiveDuring(start, end) {
+			listed = append(listed, department)
+		}
+	}
+	sortDepartments(listed)
+	return listed, nil
+}
+
+// GetOrgBudgetSummary summarizes a department's budget for a period along with each
+// of its descendants, rolling requested, approved, allocated and spent amounts up
+// the tree
+func (zbb *ZBBService) GetOrgBudgetSummary(periodID, departmentID string) (*OrgBudgetSummary, error) {
+	departments, err := zbb.storage.GetAllDepartments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get departments: %w", err)
+	}
+	children := make(map[string][]*Department)
+	var root *Department
+	for _, department := range departments {
+		children[department.ParentID] = append(children[department.ParentID], department)
+		if department.ID == departmentID {
+			root = department
+		}
+	}
+	if root == nil {
+		return nil, notFound("department", departmentID)
+	}
+
+	var summarize func(department *Department, level int) (*OrgBudgetSummary, error)
+	summarize = func(department *Department, level int) (*OrgBudgetSummary, error) {
+		summary, err := zbb.GetDepartmentBudgetSummary(periodID, department.ID)
+		if err != nil {
+			return nil, err
+		}
+		allocations, err := zbb.storage.GetBudgetAllocationsByPeriodAndDept(periodID, department.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get allocations: %w", err)
+		}
+
+		node := &OrgBudgetSummary{
+			DepartmentID:   department.ID,
+			Name:           department.Name,
+			Kind:           department.Kind,
+			Level:          level,
+			Summary:        summary,
+			TotalRequested: &Amount{Value: summary.TotalRequested.Value, Currency: "USD"},
+			TotalApproved:  &Amount{Value: summary.TotalApproved.Value, Currency: "USD"},
+			TotalAllocated: &Amount{Value: 0, Currency: "USD"},
+			TotalSpent:     &Amount{Value: 0, Currency: "USD"},
+		}
+		for _, allocation := range allocations {
+			node.TotalAllocated.Value += allocation.Amount.Value
+			node.TotalSpent.Value += allocation.SpentAmount.Value
+		}
+
+		kids := children[department.ID]
+		sortDepartments(kids)
+		for _, kid := range kids {
+			child, err := summarize(kid, level+1)
+			if err != nil {
+				return nil, err
+			}
+			node.Children = append(node.Children, child)
+			node.TotalRequested.Value += child.TotalRequested.Value
+			node.TotalApproved.Value += child.TotalApproved.Value
+			node.TotalAllocated.Value += child.TotalAllocated.Value
+			node.TotalSpent.Value += child.TotalSpent.Value
+		}
+		return node, nil
+	}
+	return summarize(root, 0)
+}
+
+// budgetDepartment checks a budget's department against the organization tree. While
+// no departments are registered department IDs are free-form and nil is returned;
+// after that the department must exist and be active during the budget period.
+func (zbb *ZBBService) budgetDepartment(departmentID, periodID string) (*Department, error) {
+	departments, err := zbb.storage.GetAllDepartments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get departments: %w", err)
+	}
+	if len(departments) == 0 {
+		return nil, nil
+	}
+
+	var department *Department
+	for _, candidate := range departments {
+		if candidate.ID == departmentID {
+			department = candidate
+		}
+	}
+	if department == nil {
+		return nil, classify(ErrNotFound, "department %s does not exist", departmentID)
+	}
+	period, err := zbb.storage.GetBudgetPeriod(periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget period: %w", err)
+	}
+	if !department.ActiveDuring(period.StartDate, period.EndDate) {
+		return nil, fmt.Errorf("department %s is not active during budget period %s", departmentID, period.Name)
+	}
+	return department, nil
+}
+
+// applyDepartmentDefaults adds the department's default dimensions to budget lines
+// that do not already carry those keys
+func applyDepartmentDefaults(department *Department, items []BudgetLineItem) {
+	for i := range items {
+		item := &items[i]
+		set := make(map[DimensionKey]bool, len(item.Dimensions))
+		for _, dim := range item.Dimensions {
+			set[dim.Key] = true
+		}
+		for _, def := range department.DefaultDimensions {
+			if !set[def.Key] {
+				item.Dimensions = append(item.Dimensions, def)
+			}
+		}
+	}
+}
+
+// validateDepartment checks a department's name, active dates and place in the tree
+func (zbb *ZBBService) validateDepartment(department *Department) error {
+	if department.Name == "" {
+		return fmt.Errorf("department name is required")
+	}
+	if department.Kind != DepartmentKindDepartment && department.Kind != DepartmentKindCostCenter {
+		return fmt.Errorf("unknown department kind %s", department.Kind)
+	}
+	if department.ActiveTo != nil && department.ActiveTo.Before(department.ActiveFrom) {
+		return fmt.Errorf("department %s is active to %s before it is active from %s",
+			department.ID, department.ActiveTo.Format("2006-01-02"), department.ActiveFrom.Format("2006-01-02"))
+	}
+	if department.ParentID == "" {
+		return nil
+	}
+
+	departments, err := zbb.storage.GetAllDepartments()
+	if err != nil {
+		return fmt.Errorf("failed to get departments: %w", err)
+	}
+	byID := make(map[string]*Department, len(departments))
+	for _, existing := range departments {
+		byID[existing.ID] = existing
+	}
+	parent, ok := byID[department.ParentID]
+	if !ok {
+		return fmt.Errorf("parent department %s does not exist", department.ParentID)
+	}
+	if parent.Kind == DepartmentKindCostCenter && department.Kind == DepartmentKindDepartment {
+		return fmt.Errorf("department %s cannot sit under cost center %s", department.ID, parent.ID)
+	}
+
+	// Walk up from the new parent; reaching the department means a cycle
+	for current := parent; current != nil; current = byID[current.ParentID] {
+		if current.ID == department.ID {
+			return fmt.Errorf("department %s cannot be placed under its own descendant %s", department.ID, department.ParentID)
+		}
+	}
+	return nil
+}
+
+// saveDepartment records an event for the department and persists it
+func (zbb *ZBBService) saveDepartment(department *Department, eventType, userID string) error {
+	if _, err := zbb.eventStore.CreateEvent(eventType, department, department.UpdatedAt, userID); err != nil {
+		return fmt.Errorf("failed to create department event: %w", err)
+	}
+	if err := zbb.storage.SaveDepartment(department); err != nil {
+		return fmt.Errorf("failed to save department: %w", err)
+	}
+	return nil
+}
+
+// sortDepartments orders departments by code, then ID
+func sortDepartments(departments []*Department) {
+	sort.Slice(departments, func(i, j int) bool {
+		if departments[i].Code != departments[j].Code {
+			return departments[i].Code < departments[j].Code
+		}
+		return departments[i].ID < departments[j].ID
+	})
+}
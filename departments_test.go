@@ -0,0 +1,138 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepartments(t *testing.T) {
+	dbFile := "test_departments.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "budget_manager"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	period := &BudgetPeriod{
+		Name:      "FY2025",
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, engine.CreateBudgetPeriod(period, userID))
+
+	request := func(departmentID string, amount int64, dims ...Dimension) (*BudgetRequest, error) {
+		request := &BudgetRequest{
+			PeriodID:     period.ID,
+			DepartmentID: departmentID,
+			Title:        "Operating costs",
+			LineItems: []BudgetLineItem{{
+				AccountID:     "expenses",
+				Amount:        &Amount{Value: amount, Currency: "USD"},
+				Description:   "Running costs",
+				Dimensions:    dims,
+				Justification: "Needed to operate",
+			}},
+		}
+		return request, engine.CreateBudgetRequest(request, userID)
+	}
+	allocate := func(request *BudgetRequest) {
+		require.NoError(t, engine.SubmitBudgetRequest(request.ID, userID))
+		require.NoError(t, engine.ApproveBudgetRequest(request.ID, "cfo", request.TotalAmount, "Approved"))
+		require.NoError(t, engine.CreateBudgetAllocation(request.ID, userID))
+	}
+
+	t.Run("department IDs are free-form until departments exist", func(t *testing.T) {
+		_, err := request("anything", 1000)
+		assert.NoError(t, err)
+	})
+
+	ops := &Department{ID: "ops", Code: "100", Name: "Operations", Owners: []string{"coo"}}
+	require.NoError(t, engine.CreateDepartment(ops, userID))
+	fleet := &Department{ID: "fleet", Code: "110", Name: "Fleet", ParentID: "ops", Kind: DepartmentKindCostCenter,
+		DefaultDimensions: []Dimension{{Key: DimCostCenter, Value: "fleet"}, {Key: DimRegion, Value: "north"}}}
+	require.NoError(t, engine.CreateDepartment(fleet, userID))
+	closedAt := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	legacy := &Department{ID: "legacy", Code: "120", Name: "Legacy Depot", ParentID: "ops", Kind: DepartmentKindCostCenter, ActiveTo: &closedAt}
+	require.NoError(t, engine.CreateDepartment(legacy, userID))
+
+	t.Run("validates the tree", func(t *testing.T) {
+		err := engine.CreateDepartment(&Department{Name: "Orphan", ParentID: "missing"}, userID)
+		assert.ErrorContains(t, err, "does not exist")
+
+		err = engine.CreateDepartment(&Department{Name: "Trucks", ParentID: "fleet"}, userID)
+		assert.ErrorContains(t, err, "cannot sit under cost center")
+
+		moved := *ops
+		moved.ParentID = "ops"
+		assert.ErrorContains(t, engine.UpdateDepartment(&moved, userID), "own descendant")
+
+		reopened := *legacy
+		from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+		reopened.ActiveFrom = from
+		assert.ErrorContains(t, engine.UpdateDepartment(&reopened, userID), "before it is active from")
+
+		stored, err := engine.GetDepartment("ops")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"coo"}, stored.Owners)
+		assert.Empty(t, stored.ParentID)
+	})
+
+	t.Run("lists departments active in a range", func(t *testing.T) {
+		active, err := engine.ListDepartments(period.StartDate, period.EndDate)
+		require.NoError(t, err)
+		require.Len(t, active, 2)
+		assert.Equal(t, []string{"ops", "fleet"}, []string{active[0].ID, active[1].ID})
+
+		all, err := engine.ListDepartments(time.Time{}, time.Time{})
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+	})
+
+	t.Run("budget requests reference active departments", func(t *testing.T) {
+		_, err := request("anything", 1000)
+		assert.ErrorContains(t, err, "department anything does not exist")
+
+		_, err = request("legacy", 1000)
+		assert.ErrorContains(t, err, "not active during budget period FY2025")
+	})
+
+	t.Run("applies dimension defaults to budget lines", func(t *testing.T) {
+		req, err := request("fleet", 40000, Dimension{Key: DimRegion, Value: "south"})
+		require.NoError(t, err)
+		assert.Equal(t, []Dimension{{Key: DimRegion, Value: "south"}, {Key: DimCostCenter, Value: "fleet"}}, req.LineItems[0].Dimensions)
+		allocate(req)
+
+		allocations, err := engine.GetStorage().GetBudgetAllocationsByPeriodAndDept(period.ID, "fleet")
+		require.NoError(t, err)
+		require.Len(t, allocations, 1)
+		assert.Equal(t, req.LineItems[0].Dimensions, allocations[0].Dimensions)
+	})
+
+	t.Run("rolls budget summaries up the tree", func(t *testing.T) {
+		req, err := request("ops", 100000)
+		require.NoError(t, err)
+		allocate(req)
+		_, err = request("fleet", 5000)
+		require.NoError(t, err)
+
+		summary, err := engine.GetOrgBudgetSummary(period.ID, "ops")
+		require.NoError(t, err)
+		assert.Equal(t, int64(145000), summary.TotalRequested.Value)
+		assert.Equal(t, int64(140000), summary.TotalApproved.Value)
+		assert.Equal(t, int64(140000), summary.TotalAllocated.Value)
+		assert.Equal(t, int64(100000), summary.Summary.TotalRequested.Value)
+
+		require.Len(t, summary.Children, 2)
+		assert.Equal(t, "fleet", summary.Children[0].DepartmentID)
+		assert.Equal(t, 1, summary.Children[0].Level)
+		assert.Equal(t, int64(45000), summary.Children[0].TotalRequested.Value)
+		assert.Equal(t, int64(0), summary.Children[1].TotalRequested.Value)
+	})
+}
@@ -0,0 +1,287 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DimensionValueStatus is the lifecycle state of a managed dimension value
+type DimensionValueStatus string
+
+const (
+	DimensionValueActive  DimensionValueStatus = "ACTIVE"
+	DimensionValueRetired DimensionValueStatus = "RETIRED"
+	DimensionValueMerged  DimensionValueStatus = "MERGED"
+)
+
+// DimensionValue is one allowed value of a managed dimension
+type DimensionValue struct {
+	Value      string               `json:"value"`
+	Name       string               `json:"name,omitempty"`
+	Status     DimensionValueStatus `json:"status"`
+	MergedInto string               `json:"merged_into,omitempty"`
+	RetiredAt  *time.Time           `json:"retired_at,omitempty"`
+}
+
+// DimensionDefinition registers a dimension key. Closed dimensions only accept their
+// listed active values; open ones accept any value. Entries on accounts of the
+// RequiredFor types must carry the dimension.
+type DimensionDefinition struct {
+	Key         DimensionKey     `json:"key"`
+	Name        string           `json:"name"`
+	Open        bool             `json:"open"`
+	Values      []DimensionValue `json:"values,omitempty"`
+	RequiredFor []AccountType    `json:"required_for,omitempty"`
+	CreatedBy   string           `json:"created_by"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// value returns the definition's entry for a value, or nil
+func (dd *DimensionDefinition) value(value string) *DimensionValue {
+	for i := range dd.Values {
+		if dd.Values[i].Value == value {
+			return &dd.Values[i]
+		}
+	}
+	return nil
+}
+
+// DimensionService maintains the dimension registry and validates entry dimensions
+type DimensionService struct {
+	storage    *Storage
+	eventStore *EventStore
+}
+
+// NewDimensionService creates a new dimension service
+func NewDimensionService(storage *Storage, eventStore *EventStore) *DimensionService {
+	return &DimensionService{
+		storage:    storage,
+		eventStore: eventStore,
+	}
+}
+
+// DefineDimension registers or replaces a dimension definition. Listed values
+// without a status are active.
+func (ds *DimensionService) DefineDimension(def *DimensionDefinition, userID string) error {
+	if def.Key == "" {
+		return fmt.Errorf("dimension key is required")
+	}
+	seen := make(map[string]bool)
+	for i := range def.Values {
+		value := &def.Values[i]
+		if value.Value == "" {
+			return fmt.Errorf("dimension %s has an empty value", def.Key)
+		}
+		if seen[value.Value] {
+			return fmt.Errorf("dimension %s lists value %s twice", def.Key, value.Value)
+		}
+		seen[value.Value] = true
+		if value.Status == "" {
+			value.Status = DimensionValueActive
+		}
+	}
+
+	now := time.Now()
+	if existing, err := ds.storage.GetDimensionDefinition(string(def.Key)); err == nil {
+		def.CreatedBy = existing.CreatedBy
+		def.CreatedAt = existing.CreatedAt
+	} else {
+		def.CreatedBy = userID
+		def.CreatedAt = now
+	}
+	def.UpdatedAt = now
+
+	return ds.saveDefinition(def, EventDefineDimension, userID)
+}
+
+// AddDimensionValue adds an active value to a dimension
+func (ds *DimensionService) AddDimensionValue(key DimensionKey, value, name, userID string) (*DimensionDefinition, error) {
+	def, err := ds.storage.GetDimensionDefinition(string(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dimension definition: %w", err)
+	}
+	if def.value(value) != nil {
+		return nil, fmt.Errorf("dimension %s already has value %s", key, value)
+	}
+	if value == "" {
+		return nil, fmt.Errorf("dimension value is required")
+	}
+
+	def.Values = append(def.Values, DimensionValue{Value: value, Name: name, Status: DimensionValueActive})
+	def.UpdatedAt = time.Now()
+	if err := ds.saveDefinition(def, EventUpdateDimension, userID); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// ListDimensionValues returns a dimension's values sorted by value, leaving out
+// retired and merged ones unless includeInactive is set
+func (ds *DimensionService) ListDimensionValues(key DimensionKey, includeInactive bool) ([]DimensionValue, error) {
+	def, err := ds.storage.GetDimensionDefinition(string(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dimension definition: %w", err)
+	}
+
+	var values []DimensionValue
+	for _, value := range def.Values {
+		if includeInactive || value.Status == DimensionValueActive {
+			values = append(values, value)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Value < values[j].Value
+	})
+	return values, nil
+}
+
+// RetireDimensionValue stops a value being used on new transactions. Entries
+// already tagged with it are unaffected.
+func (ds *DimensionService) RetireDimensionValue(key DimensionKey, value, userID string) (*DimensionDefinition, error) {
+	def, err := ds.storage.GetDimensionDefinition(string(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dimension definition: %w", err)
+	}
+	entry := def.value(value)
+	if entry == nil {
+		return nil, fmt.Errorf("dimension %s has no value %s", key, value)
+	}
+	if entry.Status != DimensionValueActive {
+		return nil, fmt.Errorf("dimension value %s is already %s", value, entry.Status)
+	}
+
+	now := time.Now()
+	entry.Status = DimensionValueRetired
+	entry.RetiredAt = &now
+	def.UpdatedAt = now
+	if err := ds.saveDefinition(def, EventUpdateDimension, userID); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// MergeDimensionValues folds one value into another. The merged value is retired and
+// new transactions using it are pointed to the survivor; historical entries keep
+// their tags and can be moved with a reclassification.
+func (ds *DimensionService) MergeDimensionValues(key DimensionKey, fromValue, intoValue, userID string) (*DimensionDefinition, error) {
+	if fromValue == intoValue {
+		return nil, fmt.Errorf("cannot merge a dimension value into itself")
+	}
+	def, err := ds.storage.GetDimensionDefinition(string(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dimension definition: %w", err)
+	}
+	from := def.value(fromValue)
+	if from == nil {
+		return nil, fmt.Errorf("dimension %s has no value %s", key, fromValue)
+	}
+	into := def.value(intoValue)
+	if into == nil {
+		return nil, fmt.Errorf("dimension %s has no value %s", key, intoValue)
+	}
+	if from.Status != DimensionValueActive || into.Status != DimensionValueActive {
+		return nil, fmt.Errorf("both dimension values must be active to merge")
+	}
+
+	now := time.Now()
+	from.Status = DimensionValueMerged
+	from.MergedInto = intoValue
+	from.RetiredAt = &now
+	def.UpdatedAt = now
+	if err := ds.saveDefinition(def, EventUpdateDimension, userID); err != nil {
+		return nil, err
+	}
+	return def, nil
+}
+
+// ValidateTransaction checks entry dimensions against the registry. With no
+// definitions every dimension is free-form. Otherwise keys must be defined, closed
+// dimensions only take active values, merged values are replaced by the value they
+// were merged into, and required dimensions must be present.
+func (ds *DimensionService) ValidateTransaction(txn *Transaction) error {
+	errs, err := ds.entryErrors(txn)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// entryErrors checks the dimensions of every entry, reporting the first problem of
+// each entry that has one
+func (ds *DimensionService) entryErrors(txn *Transaction) ([]error, error) {
+	defs, err := ds.storage.GetAllDimensionDefinitions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dimension definitions: %w", err)
+	}
+	if len(defs) == 0 {
+		return nil, nil
+	}
+	byKey := make(map[DimensionKey]*DimensionDefinition, len(defs))
+	for _, def := range defs {
+		byKey[def.Key] = def
+	}
+
+	var errs []error
+	for i := range txn.Entries {
+		if err := ds.validateEntry(i, &txn.Entries[i], defs, byKey); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs, nil
+}
+
+// validateEntry checks the dimensions of the i-th entry
+func (ds *DimensionService) validateEntry(i int, entry *Entry, defs []*DimensionDefinition, byKey map[DimensionKey]*DimensionDefinition) error {
+	present := make(map[DimensionKey]bool)
+	for j := range entry.Dimensions {
+		dim := &entry.Dimensions[j]
+		def, ok := byKey[dim.Key]
+		if !ok {
+			return fmt.Errorf("entry %d: dimension %s is not defined", i+1, dim.Key)
+		}
+		present[dim.Key] = true
+		if def.Open {
+			continue
+		}
+
+		value := def.value(dim.Value)
+		if value != nil && value.Status == DimensionValueMerged {
+			dim.Value = value.MergedInto
+			value = def.value(dim.Value)
+		}
+		switch {
+		case value == nil:
+			return fmt.Errorf("entry %d: %s is not an allowed value of dimension %s", i+1, dim.Value, dim.Key)
+		case value.Status != DimensionValueActive:
+			return fmt.Errorf("entry %d: value %s of dimension %s is retired", i+1, dim.Value, dim.Key)
+		}
+	}
+
+	account, err := ds.storage.GetAccount(entry.AccountID)
+	if err != nil {
+		return nil // unknown accounts are reported by posting validation
+	}
+	for _, def := range defs {
+		if !present[def.Key] && containsAccountType(def.RequiredFor, account.Type) {
+			return fmt.Errorf("entry %d: dimension %s is required on %s accounts", i+1, def.Key, account.Type)
+		}
+	}
+	return nil
+}
+
+// saveDefinition records an event for the definition and persists it
+func (ds *DimensionService) saveDefinition(def *DimensionDefinition, eventType, userID string) error {
+	_, err := ds.eventStore.CreateEvent(eventType, def, def.UpdatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create dimension event: %w", err)
+	}
+	if err := ds.storage.SaveDimensionDefinition(def); err != nil {
+		return fmt.Errorf("failed to save dimension definition: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package accounting
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDimensionRegistry(t *testing.T) {
+	dbFile := "test_dimensions.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	expense := func(dims ...Dimension) *Transaction {
+		return &Transaction{
+			Description: "Office supplies",
+			ValidTime:   time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 1000, Currency: "USD"}, Dimensions: dims},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: 1000, Currency: "USD"}},
+			},
+		}
+	}
+
+	t.Run("dimensions are free-form until defined", func(t *testing.T) {
+		require.NoError(t, engine.CreateTransaction(expense(Dimension{Key: "anything", Value: "goes"}), userID))
+	})
+
+	require.NoError(t, engine.DefineDimension(&DimensionDefinition{
+		Key:         DimDepartment,
+		Name:        "Department",
+		Values:      []DimensionValue{{Value: "sales", Name: "Sales"}, {Value: "ops", Name: "Operations"}},
+		RequiredFor: []AccountType{Expense},
+	}, userID))
+	require.NoError(t, engine.DefineDimension(&DimensionDefinition{Key: DimProject, Name: "Project", Open: true}, userID))
+
+	t.Run("validates keys, values and required dimensions", func(t *testing.T) {
+		err := engine.CreateTransaction(expense(Dimension{Key: DimDepartment, Value: "sales"}, Dimension{Key: DimProject, Value: "apollo"}), userID)
+		assert.NoError(t, err)
+
+		err = engine.CreateTransaction(expense(Dimension{Key: DimDepartment, Value: "legal"}), userID)
+		assert.ErrorContains(t, err, "not an allowed value")
+
+		err = engine.CreateTransaction(expense(Dimension{Key: DimDepartment, Value: "ops"}, Dimension{Key: DimRegion, Value: "emea"}), userID)
+		assert.ErrorContains(t, err, "not defined")
+
+		err = engine.CreateTransaction(expense(), userID)
+		assert.ErrorContains(t, err, "required on EXPENSE accounts")
+	})
+
+	t.Run("manages values", func(t *testing.T) {
+		_, err := engine.AddDimensionValue(DimDepartment, "marketing", "Marketing", userID)
+		require.NoError(t, err)
+		_, err = engine.AddDimensionValue(DimDepartment, "sales", "Sales", userID)
+		assert.Error(t, err)
+
+		_, err = engine.RetireDimensionValue(DimDepartment, "ops", userID)
+		require.NoError(t, err)
+		err = engine.CreateTransaction(expense(Dimension{Key: DimDepartment, Value: "ops"}), userID)
+		assert.ErrorContains(t, err, "retired")
+
+		_, err = engine.MergeDimensionValues(DimDepartment, "marketing", "sales", userID)
+		require.NoError(t, err)
+		txn := expense(Dimension{Key: DimDepartment, Value: "marketing"})
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		assert.Equal(t, "sales", txn.Entries[0].Dimensions[0].Value)
+
+		active, err := engine.ListDimensionValues(DimDepartment, false)
+		require.NoError(t, err)
+		require.Len(t, active, 1)
+		assert.Equal(t, "sales", active[0].Value)
+
+		all, err := engine.ListDimensionValues(DimDepartment, true)
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		assert.Equal(t, DimensionValueMerged, all[0].Status)
+		assert.Equal(t, "sales", all[0].MergedInto)
+	})
+
+	t.Run("validates imports", func(t *testing.T) {
+		csv := "ref,date,account_id,type,amount,currency,dimensions\n" +
+			"D-1,2024-03-06,expenses,DEBIT,10.00,USD,department=legal\n" +
+			"D-1,2024-03-06,cash,CREDIT,10.00,USD,\n"
+		result, err := engine.ImportTransactions(strings.NewReader(csv), ImportFormatCSV, ImportOptions{}, userID)
+		require.NoError(t, err)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "INVALID_DIMENSION", result.Errors[0].Code)
+	})
+}
@@ -0,0 +1,300 @@
+package accounting
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Disclosure Structures
+// ----------------------------------------------------------------------------
+
+// DisclosureCategory groups notes to the financial statements
+type DisclosureCategory string
+
+const (
+	DisclosureAccountingPolicy DisclosureCategory = "ACCOUNTING_POLICY"
+	DisclosureCommitment       DisclosureCategory = "COMMITMENT"
+	DisclosureRelatedParty     DisclosureCategory = "RELATED_PARTY"
+)
+
+// Disclosure is a reusable footnote template in the disclosure library.
+//
+// The body may reference ledger and period-specific values with placeholders:
+//
+//	{{balance:ACCOUNT_ID}}  closing balance of the account at period end
+//	{{activity:ACCOUNT_ID}} net movement on the account during the period
+//	{{value:KEY}}           value entered for the period with SetPeriodValue
+type Disclosure struct {
+	ID        string             `json:"id"`
+	Category  DisclosureCategory `json:"category"`
+	Title     string             `json:"title"`
+	Body      string             `json:"body"`
+	SortOrder int                `json:"sort_order"`
+	Active    bool               `json:"active"`
+	CreatedBy string             `json:"created_by"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// DisclosureValue is a period-specific value that cannot be derived from the ledger,
+// such as the counterparty to a related-party arrangement
+type DisclosureValue struct {
+	ID           string    `json:"id"`
+	DisclosureID string    `json:"disclosure_id"`
+	PeriodID     string    `json:"period_id"`
+	Key          string    `json:"key"`
+	Value        string    `json:"value"`
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// StatementFootnote is a disclosure rendered for a specific period
+type StatementFootnote struct {
+	Number       int                `json:"number"`
+	DisclosureID string             `json:"disclosure_id"`
+	Category     DisclosureCategory `json:"category"`
+	Title        string             `json:"title"`
+	Text         string             `json:"text"`
+	Unresolved   []string           `json:"unresolved,omitempty"` // placeholders with no value
+}
+
+// disclosurePlaceholder matches {{source:key}} references in a disclosure body
+var disclosurePlaceholder = regexp.MustCompile(`\{\{\s*(balance|activity|value)\s*:\s*([^}\s]+)\s*\}\}`)
+
+// ----------------------------------------------------------------------------
+// Disclosure Service
+// ----------------------------------------------------------------------------
+
+// DisclosureService manages the disclosure library and renders footnotes for statements
+type DisclosureService struct {
+	storage          *Storage
+	reportingService *ReportingService
+}
+
+// NewDisclosureService creates a new disclosure service
+func NewDisclosureService(storage *Storage, reportingService *ReportingService) *DisclosureService {
+	return &DisclosureService{
+		storage:          storage,
+		reportingService: reportingService,
+	}
+}
+
+// CreateDisclosure adds a disclosure to the library
+func (ds *DisclosureService) CreateDisclosure(disclosure *Disclosure, userID string) error {
+	if disclosure.Title == "" || disclosure.Body == "" {
+		return fmt.Errorf("disclosure title and body are required")
+	}
+	switch disclosure.Category {
+	case DisclosureAccountingPolicy, DisclosureCommitment, DisclosureRelatedParty:
+	default:
+		return fmt.Errorf("unknown disclosure category: %s", disclosure.Category)
+	}
+
+	if err := ds.storage.assignID(&disclosure.ID, "disclosure", BucketDisclosures); err != nil {
+		return err
+	}
+	disclosure.Active = true
+	disclosure.CreatedBy = userID
+	disclosure.CreatedAt = time.Now()
+	disclosure.UpdatedAt = disclosure.CreatedAt
+
+	if err := ds.storage.SaveDisclosure(disclosure); err != nil {
+		return fmt.Errorf("failed to save disclosure: %w", err)
+	}
+	return nil
+}
+
+// UpdateDisclosure replaces the wording of an existing disclosure
+func (ds *DisclosureService) UpdateDisclosure(disclosureID, title, body string) (*Disclosure, error) {
+	disclosure, err := ds.storage.GetDisclosure(disclosureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disclosure: %w", err)
+	}
+
+	if title != "" {
+		disclosure.Title = title
+	}
+	if body != "" {
+		disclosure.Body = body
+	}
+	disclosure.UpdatedAt = time.Now()
+
+	if err := ds.storage.SaveDisclosure(disclosure); err != nil {
+		return nil, fmt.Errorf("failed to save disclosure: %w", err)
+	}
+	return disclosure, nil
+}
+
+// SetDisclosureActive includes or excludes a disclosure from future statements
+func (ds *DisclosureService) SetDisclosureActive(disclosureID string, active bool) error {
+	disclosure, err := ds.storage.GetDisclosure(disclosureID)
+	if err != nil {
+		return fmt.Errorf("failed to get disclosure: %w", err)
+	}
+
+	disclosure.Active = active
+	disclosure.UpdatedAt = time.Now()
+
+	return ds.storage.SaveDisclosure(disclosure)
+}
+
+// GetDisclosures returns the library in presentation order, optionally filtered by category
+func (ds *DisclosureService) GetDisclosures(category DisclosureCategory) ([]*Disclosure, error) {
+	all, err := ds.storage.GetAllDisclosures()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disclosures: %w", err)
+	}
+
+	var disclosures []*Disclosure
+	for _, disclosure := range all {
+		if category == "" || disclosure.Category == category {
+			disclosures = append(disclosures, disclosure)
+		}
+	}
+
+	sortDisclosures(disclosures)
+	return disclosures, nil
+}
+
+// SetPeriodValue records a period-specific value referenced by a disclosure as {{value:key}}
+func (ds *DisclosureService) SetPeriodValue(disclosureID, periodID, key, value, userID string) (*DisclosureValue, error) {
+	if _, err := ds.storage.GetDisclosure(disclosureID); err != nil {
+		return nil, fmt.Errorf("failed to get disclosure: %w", err)
+	}
+	if _, err := ds.storage.GetPeriod(periodID); err != nil {
+		return nil, fmt.Errorf("failed to get period: %w", err)
+	}
+
+	disclosureValue := &DisclosureValue{
+		// One value per disclosure, period and key; later entries replace earlier ones
+		ID:           fmt.Sprintf("%s|%s|%s", disclosureID, periodID, key),
+		DisclosureID: disclosureID,
+		PeriodID:     periodID,
+		Key:          key,
+		Value:        value,
+		CreatedBy:    userID,
+		CreatedAt:    time.Now(),
+	}
+
+	if err := ds.storage.SaveDisclosureValue(disclosureValue); err != nil {
+		return nil, fmt.Errorf("failed to save disclosure value: %w", err)
+	}
+	return disclosureValue, nil
+}
+
+// RenderFootnotes renders every active disclosure for a period, pulling balances from the ledger
+func (ds *DisclosureService) RenderFootnotes(periodID, currency string) ([]*StatementFootnote, error) {
+	period, err := ds.storage.GetPeriod(periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get period: %w", err)
+	}
+
+	disclosures, err := ds.GetDisclosures("")
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := ds.storage.GetDisclosureValuesByPeriod(periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disclosure values: %w", err)
+	}
+	valuesByDisclosure := make(map[string]map[string]string)
+	for _, v := range values {
+		if valuesByDisclosure[v.DisclosureID] == nil {
+			valuesByDisclosure[v.DisclosureID] = make(map[string]string)
+		}
+		valuesByDisclosure[v.DisclosureID][v.Key] = v.Value
+	}
+
+	var footnotes []*StatementFootnote
+	for _, disclosure := range disclosures {
+		if !disclosure.Active {
+			continue
+		}
+
+		footnote := &StatementFootnote{
+			Number:       len(footnotes) + 1,
+			DisclosureID: disclosure.ID,
+			Category:     disclosure.Category,
+			Title:        disclosure.Title,
+		}
+		footnote.Text = disclosurePlaceholder.ReplaceAllStringFunc(disclosure.Body, func(match string) string {
+			parts := disclosurePlaceholder.FindStringSubmatch(match)
+			text, ok := ds.resolvePlaceholder(parts[1], parts[2], period, currency, valuesByDisclosure[disclosure.ID])
+			if !ok {
+				footnote.Unresolved = append(footnote.Unresolved, match)
+				return match
+			}
+			return text
+		})
+
+		footnotes = append(footnotes, footnote)
+	}
+
+	return footnotes, nil
+}
+
+// AttachFootnotes renders the period's disclosures onto a generated financial statement
+func (ds *DisclosureService) AttachFootnotes(statement *FinancialStatement, periodID string) error {
+	footnotes, err := ds.RenderFootnotes(periodID, statement.Currency)
+	if err != nil {
+		return err
+	}
+	statement.Footnotes = footnotes
+	return nil
+}
+
+// resolvePlaceholder returns the text for a single placeholder
+func (ds *DisclosureService) resolvePlaceholder(source, key string, period *Period, currency string, values map[string]string) (string, bool) {
+	var amount *Amount
+	var err error
+
+	switch source {
+	case "value":
+		value, ok := values[key]
+		return value, ok
+	case "balance":
+		var balance *BalanceResult
+		balance, err = ds.reportingService.queryAPI.GetAccountBalance(key, period.End)
+		if err == nil {
+			amount = balance.Balance
+		}
+	case "activity":
+		amount, err = ds.reportingService.calculatePeriodBalance(key, period.Start, period.End)
+	default:
+		return "", false
+	}
+	if err != nil {
+		return "", false
+	}
+
+	amount, err = ds.reportingService.translateAmount(amount, currency, period.End)
+	if err != nil {
+		return "", false
+	}
+
+	return currencySymbol(currency) + FormatMinorUnits(amount.Value, Currency(currency)), true
+}
+
+// sortDisclosures orders disclosures by category and then by sort order
+func sortDisclosures(disclosures []*Disclosure) {
+	categoryOrder := map[DisclosureCategory]int{
+		DisclosureAccountingPolicy: 0,
+		DisclosureCommitment:       1,
+		DisclosureRelatedParty:     2,
+	}
+
+	sort.SliceStable(disclosures, func(i, j int) bool {
+		ci, cj := categoryOrder[disclosures[i].Category], categoryOrder[disclosures[j].Category]
+		if ci != cj {
+			return ci < cj
+		}
+		if disclosures[i].SortOrder != disclosures[j].SortOrder {
+			return disclosures[i].SortOrder < disclosures[j].SortOrder
+		}
+		return disclosures[i].CreatedAt.Before(disclosures[j].CreatedAt)
+	})
+}
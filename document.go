@@ -0,0 +1,290 @@
+package accounting
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// MaxDocumentSize is the largest file the document store accepts, in bytes
+const MaxDocumentSize = 16 << 20
+
+// DocumentTargetType is the kind of record a document supports
+type DocumentTargetType string
+
+const (
+	DocumentTargetTransaction      DocumentTargetType = "TRANSACTION"
+	DocumentTargetAMLInvestigation DocumentTargetType = "AML_INVESTIGATION" // identified by its alert ID
+	DocumentTargetBudgetRequest    DocumentTargetType = "BUDGET_REQUEST"
+	DocumentTargetTaxReturn        DocumentTargetType = "TAX_RETURN"
+	DocumentTargetControlTest      DocumentTargetType = "CONTROL_TEST" // evidence for a SOX control test result
+)
+
+// DocumentLink attaches a document to a record
+type DocumentLink struct {
+	TargetType      DocumentTargetType `json:"target_type"`
+	TargetID        string             `json:"target_id"`
+	JustificationID string             `json:"justification_id,omitempty"` // budget request justification listing the document
+	LinkedBy        string             `json:"linked_by"`
+	LinkedAt        time.Time          `json:"linked_at"`
+}
+
+// Document is a stored file's metadata. The content is kept once per checksum, so
+// the same file uploaded twice shares its bytes.
+type Document struct {
+	ID          string         `json:"id"`
+	Name        string         `json:"name"`
+	ContentType string         `json:"content_type"`
+	Size        int64          `json:"size"`
+	Checksum    string         `json:"checksum"` // SHA-256 of the content, hex encoded
+	Description string         `json:"description,omitempty"`
+	Links       []DocumentLink `json:"links,omitempty"`
+	UploadedBy  string         `json:"uploaded_by"`
+	UploadedAt  time.Time      `json:"uploaded_at"`
+}
+
+// documentLinkChange is the payload of document link and unlink events
+type documentLinkChange struct {
+	DocumentID string       `json:"document_id"`
+	Link       DocumentLink `json:"link"`
+}
+
+// DocumentService stores supporting documents and links them to transactions, AML
+// investigations, budget requests, tax returns and control test results
+type DocumentService struct {
+	storage    *Storage
+	eventStore *EventStore
+}
+
+// NewDocumentService creates a new document service
+func NewDocumentService(storage *Storage, eventStore *EventStore) *DocumentService {
+	return &DocumentService{
+		storage:    storage,
+		eventStore: eventStore,
+	}
+}
+
+// StoreDocument saves a file and its metadata. Events record the metadata only;
+// the content lives in the document store under its checksum.
+func (ds *DocumentService) StoreDocument(name, contentType string, content []byte, description, userID string) (*Document, error) {
+	if name == "" {
+		return nil, fmt.Errorf("document name is required")
+	}
+	if len(content) == 0 {
+		return nil, fmt.Errorf("document %s is empty", name)
+	}
+	if len(content) > MaxDocumentSize {
+		return nil, fmt.Errorf("document %s is %d bytes, over the %d byte limit", name, len(content), MaxDocumentSize)
+	}
+
+	sum := sha256.Sum256(content)
+	document := &Document{
+		Name:        name,
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		Checksum:    hex.EncodeToString(sum[:]),
+		Description: description,
+		UploadedBy:  userID,
+		UploadedAt:  time.Now(),
+	}
+	if err := ds.storage.assignID(&document.ID, "doc", BucketDocuments); err != nil {
+		return nil, err
+	}
+
+	_, err := ds.eventStore.CreateEvent(EventStoreDocument, document, document.UploadedAt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document event: %w", err)
+	}
+	if err := ds.storage.SaveDocumentContent(document.Checksum, content); err != nil {
+		return nil, fmt.Errorf("failed to save document content: %w", err)
+	}
+	if err := ds.storage.SaveDocument(document); err != nil {
+		return nil, fmt.Errorf("failed to save document: %w", err)
+	}
+	return document, nil
+}
+
+// LinkDocument attaches a document to a record, which must exist. Tax returns list
+// the document ID in their attachments, and budget requests in the supporting docs
+// of the justification named by link.JustificationID, if any.
+func (ds *DocumentService) LinkDocument(documentID string, link DocumentLink, userID string) error {
+	document, err := ds.storage.GetDocument(documentID)
+	if err != nil {
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+	if slices.ContainsFunc(document.Links, func(existing DocumentLink) bool { return sameDocumentTarget(existing, link) }) {
+		return fmt.Errorf("document %s is already linked to %s %s", documentID, link.TargetType, link.TargetID)
+	}
+	if err := ds.attachToTarget(documentID, link); err != nil {
+		return err
+	}
+
+	link.LinkedBy = userID
+	link.LinkedAt = time.Now()
+	document.Links = append(document.Links, link)
+
+	_, err = ds.eventStore.CreateEvent(EventLinkDocument, documentLinkChange{DocumentID: documentID, Link: link}, link.LinkedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create document link event: %w", err)
+	}
+	return ds.storage.SaveDocument(document)
+}
+
+// UnlinkDocument detaches a document from a record. The document itself is kept.
+func (ds *DocumentService) UnlinkDocument(documentID string, targetType DocumentTargetType, targetID, userID string) error {
+	document, err := ds.storage.GetDocument(documentID)
+	if err != nil {
+		return fmt.Errorf("failed to get document: %w", err)
+	}
+	target := DocumentLink{TargetType: targetType, TargetID: targetID}
+	i := slices.IndexFunc(document.Links, func(link DocumentLink) bool { return sameDocumentTarget(link, target) })
+	if i < 0 {
+		return fmt.Errorf("document %s is not linked to %s %s", documentID, targetType, targetID)
+	}
+	link := document.Links[i]
+	if err := ds.detachFromTarget(documentID, link); err != nil {
+		return err
+	}
+	document.Links = slices.Delete(document.Links, i, i+1)
+
+	_, err = ds.eventStore.CreateEvent(EventUnlinkDocument, documentLinkChange{DocumentID: documentID, Link: link}, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create document unlink event: %w", err)
+	}
+	return ds.storage.SaveDocument(document)
+}
+
+// GetDocument retrieves a document's metadata
+func (ds *DocumentService) GetDocument(documentID string) (*Document, error) {
+	return ds.storage.GetDocument(documentID)
+}
+
+// GetDocumentContent retrieves a document's content, checking it still matches
+// the recorded checksum
+func (ds *DocumentService) GetDocumentContent(documentID string) (*Document, []byte, error) {
+	document, err := ds.storage.GetDocument(documentID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	content, err := ds.storage.GetDocumentContent(document.Checksum)
+	if err != nil {
+		return nil, nil, err
+	}
+	sum := sha256.Sum256(content)
+	if hex.EncodeToString(sum[:]) != document.Checksum {
+		return nil, nil, fmt.Errorf("document %s content does not match its checksum", documentID)
+	}
+	return document, content, nil
+}
+
+// GetDocumentsFor returns the documents linked to a record, oldest upload first
+func (ds *DocumentService) GetDocumentsFor(targetType DocumentTargetType, targetID string) ([]*Document, error) {
+	documents, err := ds.storage.GetAllDocuments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+	target := DocumentLink{TargetType: targetType, TargetID: targetID}
+	var linked []*Document
+	for _, document := range documents {
+		if slices.ContainsFunc(document.Links, func(link DocumentLink) bool { return sameDocumentTarget(link, target) }) {
+			linked = append(linked, document)
+		}
+	}
+	sort.SliceStable(linked, func(i, j int) bool {
+		return linked[i].UploadedAt.Before(linked[j].UploadedAt)
+	})
+	return linked, nil
+}
+
+// attachToTarget checks the linked record exists and lists the document on
+// records that keep their own document references
+func (ds *DocumentService) attachToTarget(documentID string, link DocumentLink) error {
+	switch link.TargetType {
+	case DocumentTargetTransaction:
+		if _, err := ds.storage.GetTransaction(link.TargetID); err != nil {
+			return fmt.Errorf("invalid transaction: %w", err)
+		}
+	case DocumentTargetAMLInvestigation:
+		alert, err := ds.storage.GetAMLAlert(link.TargetID)
+		if err != nil {
+			return fmt.Errorf("invalid AML alert: %w", err)
+		}
+		if alert.Investigation == nil {
+			return fmt.Errorf("AML alert %s has no investigation", link.TargetID)
+		}
+	case DocumentTargetBudgetRequest:
+		request, err := ds.storage.GetBudgetRequest(link.TargetID)
+		if err != nil {
+			return fmt.Errorf("invalid budget request: %w", err)
+		}
+		if link.JustificationID == "" {
+			return nil
+		}
+		i := slices.IndexFunc(request.Justifications, func(j Justification) bool { return j.ID == link.JustificationID })
+		if i < 0 {
+			return fmt.Errorf("budget request %s has no justification %s", link.TargetID, link.JustificationID)
+		}
+		request.Justifications[i].SupportingDocs = append(request.Justifications[i].SupportingDocs, documentID)
+		return ds.storage.SaveBudgetRequest(request)
+	case DocumentTargetTaxReturn:
+		taxReturn, err := ds.storage.GetTaxReturn(link.TargetID)
+		if err != nil {
+			return fmt.Errorf("invalid tax return: %w", err)
+		}
+		taxReturn.Attachments = append(taxReturn.Attachments, documentID)
+		return ds.storage.SaveTaxReturn(taxReturn)
+	case DocumentTargetControlTest:
+		result, err := ds.storage.GetControlTestResult(link.TargetID)
+		if err != nil {
+			return fmt.Errorf("invalid control test result: %w", err)
+		}
+		result.Attachments = append(result.Attachments, documentID)
+		return ds.storage.SaveControlTestResult(result)
+	default:
+		return fmt.Errorf("unknown document target type %s", link.TargetType)
+	}
+	return nil
+}
+
+// detachFromTarget removes the document from records that list it
+func (ds *DocumentService) detachFromTarget(documentID string, link DocumentLink) error {
+	switch link.TargetType {
+	case DocumentTargetBudgetRequest:
+		if link.JustificationID == "" {
+			return nil
+		}
+		request, err := ds.storage.GetBudgetRequest(link.TargetID)
+		if err != nil {
+			return fmt.Errorf("failed to get budget request: %w", err)
+		}
+		for i := range request.Justifications {
+			if request.Justifications[i].ID == link.JustificationID {
+				request.Justifications[i].SupportingDocs = slices.DeleteFunc(request.Justifications[i].SupportingDocs, func(id string) bool { return id == documentID })
+			}
+		}
+		return ds.storage.SaveBudgetRequest(request)
+	case DocumentTargetTaxReturn:
+		taxReturn, err := ds.storage.GetTaxReturn(link.TargetID)
+		if err != nil {
+			return fmt.Errorf("failed to get tax return: %w", err)
+		}
+		taxReturn.Attachments = slices.DeleteFunc(taxReturn.Attachments, func(id string) bool { return id == documentID })
+		return ds.storage.SaveTaxReturn(taxReturn)
+	case DocumentTargetControlTest:
+		result, err := ds.storage.GetControlTestResult(link.TargetID)
+		if err != nil {
+			return fmt.Errorf("failed to get control test result: %w", err)
+		}
+		result.Attachments = slices.DeleteFunc(result.Attachments, func(id string) bool { return id == documentID })
+		return ds.storage.SaveControlTestResult(result)
+	}
+	return nil
+}
+
+// sameDocumentTarget reports whether two links point at the same record
+func sameDocumentTarget(a, b DocumentLink) bool {
+	return a.TargetType == b.TargetType && a.TargetID == b.TargetID
+}
@@ -0,0 +1,119 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+func TestDocumentStorage(t *testing.T) {
+	dbFile := "test_documents.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "clerk"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	storage := engine.GetStorage()
+
+	txn := &Transaction{
+		Description: "Office supplies",
+		ValidTime:   time.Now(),
+		Entries: []Entry{
+			{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 4500, Currency: "USD"}},
+			{AccountID: "cash", Type: Credit, Amount: Amount{Value: 4500, Currency: "USD"}},
+		},
+	}
+	require.NoError(t, engine.CreateTransaction(txn, userID))
+
+	receipt := []byte("%PDF-1.7 receipt for office supplies")
+	doc, err := engine.StoreDocument("receipt.pdf", "application/pdf", receipt, "Supplier receipt", userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(receipt)), doc.Size)
+	assert.Len(t, doc.Checksum, 64)
+
+	copyDoc, err := engine.StoreDocument("receipt-copy.pdf", "application/pdf", receipt, "", userID)
+	require.NoError(t, err)
+	assert.Equal(t, doc.Checksum, copyDoc.Checksum, "identical content shares a checksum")
+
+	_, err = engine.StoreDocument("empty.txt", "text/plain", nil, "", userID)
+	assert.Error(t, err)
+
+	t.Run("transaction links", func(t *testing.T) {
+		link := DocumentLink{TargetType: DocumentTargetTransaction, TargetID: txn.ID}
+		require.NoError(t, engine.LinkDocument(doc.ID, link, userID))
+		assert.Error(t, engine.LinkDocument(doc.ID, link, userID), "a document links to a record once")
+		assert.Error(t, engine.LinkDocument(doc.ID, DocumentLink{TargetType: DocumentTargetTransaction, TargetID: "missing"}, userID))
+
+		docs, err := engine.GetDocumentsFor(DocumentTargetTransaction, txn.ID)
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.Equal(t, doc.ID, docs[0].ID)
+		assert.Equal(t, userID, docs[0].Links[0].LinkedBy)
+
+		stored, content, err := engine.GetDocumentContent(doc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, receipt, content)
+		assert.Equal(t, "receipt.pdf", stored.Name)
+	})
+
+	t.Run("tax return attachments", func(t *testing.T) {
+		require.NoError(t, storage.SaveTaxReturn(&TaxReturn{ID: "vat-q1", Attachments: []string{}}))
+		link := DocumentLink{TargetType: DocumentTargetTaxReturn, TargetID: "vat-q1"}
+		require.NoError(t, engine.LinkDocument(doc.ID, link, userID))
+
+		taxReturn, err := storage.GetTaxReturn("vat-q1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{doc.ID}, taxReturn.Attachments)
+
+		require.NoError(t, engine.UnlinkDocument(doc.ID, DocumentTargetTaxReturn, "vat-q1", userID))
+		taxReturn, err = storage.GetTaxReturn("vat-q1")
+		require.NoError(t, err)
+		assert.Empty(t, taxReturn.Attachments)
+		assert.Error(t, engine.UnlinkDocument(doc.ID, DocumentTargetTaxReturn, "vat-q1", userID))
+	})
+
+	t.Run("budget request justification", func(t *testing.T) {
+		request := &BudgetRequest{
+			ID:             "req-1",
+			Title:          "New laptops",
+			Justifications: []Justification{{ID: "just-1", Title: "Ageing hardware"}},
+		}
+		require.NoError(t, storage.SaveBudgetRequest(request))
+
+		bad := DocumentLink{TargetType: DocumentTargetBudgetRequest, TargetID: "req-1", JustificationID: "nope"}
+		assert.Error(t, engine.LinkDocument(copyDoc.ID, bad, userID))
+
+		link := DocumentLink{TargetType: DocumentTargetBudgetRequest, TargetID: "req-1", JustificationID: "just-1"}
+		require.NoError(t, engine.LinkDocument(copyDoc.ID, link, userID))
+		stored, err := storage.GetBudgetRequest("req-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{copyDoc.ID}, stored.Justifications[0].SupportingDocs)
+	})
+
+	t.Run("AML investigation needs an investigation", func(t *testing.T) {
+		require.NoError(t, storage.SaveAMLAlert(&AMLAlert{ID: "alert-1"}))
+		link := DocumentLink{TargetType: DocumentTargetAMLInvestigation, TargetID: "alert-1"}
+		assert.Error(t, engine.LinkDocument(doc.ID, link, userID))
+
+		_, err := engine.GetAMLService().CreateInvestigation("alert-1", userID)
+		require.NoError(t, err)
+		require.NoError(t, engine.LinkDocument(doc.ID, link, userID))
+	})
+
+	t.Run("corrupted content is detected", func(t *testing.T) {
+		tampered := append([]byte(nil), receipt...)
+		tampered[0] = 'X'
+		require.NoError(t, storage.db.Update(func(tx *bbolt.Tx) error {
+			return tx.Bucket(BucketDocumentContents).Put([]byte(doc.Checksum), tampered)
+		}))
+		_, _, err := engine.GetDocumentContent(doc.ID)
+		assert.Error(t, err)
+	})
+}
@@ -1,32 +1,98 @@
 package accounting
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
 	"time"
 
-	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // AccountingEngine is the main entry point for the accounting system
 type AccountingEngine struct {
-	storage               *Storage
-	eventStore            *EventStore
-	processor             *EventProcessor
-	postingEngine         *PostingEngine
-	queryAPI              *QueryAPI
-	reconciliationService *ReconciliationService
-	accrualService        *AccrualService
-	reportingService      *ReportingService  // Add reporting service
-	zbbService            *ZBBService        // Add ZBB service
-	complianceService     *ComplianceService // Add compliance service
-	amlService            *AMLService        // Add AML service
-	forensicService       *ForensicService   // Add forensic service
+	storage                  *Storage
+	eventStore               *EventStore
+	processor                *EventProcessor
+	postingEngine            *PostingEngine
+	queryAPI                 *QueryAPI
+	reconciliationService    *ReconciliationService
+	accrualService           *AccrualService
+	reportingService         *ReportingService  // Add reporting service
+	zbbService               *ZBBService        // Add ZBB service
+	complianceService        *ComplianceService // Add compliance service
+	amlService               *AMLService        // Add AML service
+	forensicService          *ForensicService   // Add forensic service
+	periodCloseService       *PeriodCloseService
+	exchangeRateService      *ExchangeRateService
+	disclosureService        *DisclosureService
+	revaluationService       *RevaluationService
+	confirmationService      *ConfirmationService
+	materialityService       *MaterialityService
+	inflationService         *InflationAdjustmentService
+	payablesService          *PayablesService
+	chainReconService        *ChainReconciliationService
+	installmentService       *InstallmentService
+	recurringService         *RecurringTransactionService
+	refundService            *RefundService
+	storedValueService       *StoredValueService
+	journalApprovalService   *JournalApprovalService
+	importService            *ImportService
+	loyaltyService           *LoyaltyService
+	clientMoneyService       *ClientMoneyService
+	pspSettlementService     *PSPSettlementService
+	merchantReserveService   *MerchantReserveService
+	feeScheduleService       *FeeScheduleService
+	regulatoryService        *RegulatoryReportingService
+	yearEndService           *YearEndCloseService
+	chartOfAccountsService   *ChartOfAccountsService
+	masterDataService        *MasterDataService
+	reclassService           *ReclassificationService
+	dimensionService         *DimensionService
+	validator                *Validator
+	accessControlService     *AccessControlService
+	documentService          *DocumentService
+	scriptService            *ScriptService
+	statementTemplateService *StatementTemplateService
+	narrativeService         *NarrativeService
+	ledgerService            *LedgerService
+	segmentService           *SegmentService
+	reportScheduler          *ReportScheduler
+	goingConcern             *GoingConcernService
+	notifications            *NotificationService
+	metrics                  *EngineMetrics
+	logger                   *slog.Logger
+	tracer                   *engineTracer
+	revenueAnalytics         *RevenueAnalyticsService
+	expenseAnomalies         *ExpenseAnomalyService
+	consistency              *ConsistencyService
+	workflows                *WorkflowService
+	dataExport               *DataExportService
+	piiErasureService        *PIIErasureService
+
+	idempotencyMu        sync.Mutex // serializes creates that carry an idempotency key
+	idempotencyTTL       time.Duration
+	contractService      *ContractService
+	taxLineService       *TaxLineService
+	vatReturnService     *VATReturnService
+	form1099Service      *Form1099Service
+	soxControlService    *SOXControlService
+	frameworkAdjustments *FrameworkAdjustmentService
 }
 
 // NewAccountingEngine creates a new accounting engine
 func NewAccountingEngine(dbPath string) (*AccountingEngine, error) {
+	return NewAccountingEngineWithOptions(dbPath, StorageOptions{})
+}
+
+// NewAccountingEngineWithOptions creates a new accounting engine over storage with tuned bbolt options
+func NewAccountingEngineWithOptions(dbPath string, options StorageOptions) (*AccountingEngine, error) {
 	// Initialize storage
-	storage, err := NewStorage(dbPath)
+	storage, err := NewStorageWithOptions(dbPath, options)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize storage: %w", err)
 	}
@@ -44,39 +110,243 @@ func NewAccountingEngine(dbPath string) (*AccountingEngine, error) {
 	// Initialize services
 	reconciliationService := NewReconciliationService(storage, queryAPI)
 	accrualService := NewAccrualService(storage, postingEngine, eventStore)
-	reportingService := NewReportingService(storage, queryAPI)
-	zbbService := NewZBBService(storage)                                     // Add ZBB service
+	exchangeRateService := NewExchangeRateService(storage, eventStore)
+	reportingService := NewReportingService(storage, queryAPI, exchangeRateService)
+	zbbService := NewZBBService(storage, eventStore)                         // Add ZBB service
 	complianceService := NewComplianceService(*storage)                      // Add compliance service (dereference)
 	forensicService := NewForensicService(storage, eventStore)               // Add forensic service
 	amlService := NewAMLService(storage, complianceService, forensicService) // Add AML service
+	disclosureService := NewDisclosureService(storage, reportingService)
+	revaluationService := NewRevaluationService(storage, eventStore, postingEngine, exchangeRateService)
+	inflationService := NewInflationAdjustmentService(storage, eventStore, postingEngine)
+	materialityService := NewMaterialityService(storage, eventStore, reportingService)
+	complianceService.SetMaterialityService(materialityService)
+	periodCloseService := NewPeriodCloseService(storage, eventStore, reportingService, disclosureService, materialityService, postingEngine)
+	confirmationService := NewConfirmationService(storage, eventStore, queryAPI)
+	payablesService := NewPayablesService(storage, eventStore, postingEngine)
+	chainReconService := NewChainReconciliationService(storage, eventStore, queryAPI, amlService)
+	installmentService := NewInstallmentService(storage, eventStore, postingEngine)
+	recurringService := NewRecurringTransactionService(storage, eventStore, postingEngine)
+	refundService := NewRefundService(storage, eventStore, postingEngine, amlService)
+	storedValueService := NewStoredValueService(storage, eventStore, postingEngine)
+	journalApprovalService := NewJournalApprovalService(storage, eventStore, postingEngine)
+	dimensionService := NewDimensionService(storage, eventStore)
+	validator := NewValidator(storage, postingEngine, dimensionService)
+	importService := NewImportService(storage, eventStore, postingEngine, complianceService, journalApprovalService, dimensionService)
+	loyaltyService := NewLoyaltyService(storage, eventStore, postingEngine)
+	clientMoneyService := NewClientMoneyService(storage, eventStore, amlService)
+	postingEngine.AddValidator("CLIENT_MONEY_COMMINGLING", clientMoneyService.ValidateTransaction)
+	postingEngine.AddValidator("OVER_BUDGET", zbbService.ValidateBudget)
+	postingEngine.AddPostingHook(zbbService.ConsumeBudget)
+	pspSettlementService := NewPSPSettlementService(storage, eventStore, postingEngine, refundService)
+	merchantReserveService := NewMerchantReserveService(storage, eventStore, postingEngine)
+	feeScheduleService := NewFeeScheduleService(storage, eventStore, postingEngine)
+	regulatoryService := NewRegulatoryReportingService(storage, eventStore, queryAPI)
+	yearEndService := NewYearEndCloseService(storage, eventStore, postingEngine, periodCloseService)
+	chartOfAccountsService := NewChartOfAccountsService(storage, eventStore, postingEngine)
+	masterDataService := NewMasterDataService(storage, eventStore)
+	reclassService := NewReclassificationService(storage, eventStore, postingEngine)
+	accessControlService := NewAccessControlService(storage, eventStore)
+	documentService := NewDocumentService(storage, eventStore)
+	scriptService := NewScriptService(storage, eventStore, queryAPI)
+	postingEngine.AddValidator("SCRIPT_VALIDATION", scriptService.ValidateTransaction)
+	amlService.AddEnricher(scriptService.EnrichAMLTransaction)
+	statementTemplateService := NewStatementTemplateService(storage, eventStore, reportingService)
+	narrativeService := NewNarrativeService(storage, eventStore, amlService)
+	ledgerService := NewLedgerService(storage, eventStore, reportingService)
+	postingEngine.AddValidator("CROSS_LEDGER_POSTING", ledgerService.ValidateTransaction)
+	segmentService := NewSegmentService(storage, eventStore, reportingService)
+	reportScheduler := NewReportScheduler(storage, eventStore, reportingService, segmentService)
+	goingConcern := NewGoingConcernService(storage, eventStore, reportingService)
+	notifications, err := NewNotificationService(storage, eventStore, amlService)
+	if err != nil {
+		return nil, err
+	}
+	metrics := NewEngineMetrics(NewMetricsRegistry())
+	storage.Instrument(metrics)
+	eventStore.AddListener(metrics.eventAppended)
+	amlService.Instrument(metrics)
+	reportingService.Instrument(metrics)
+	metrics.watchStorage(storage)
+	metrics.watchAML(storage)
+	complianceService.SetLogger(options.Logger)
+	amlService.SetLogger(options.Logger)
+	accrualService.SetLogger(options.Logger)
+	notifications.SetLogger(options.Logger)
+	reportScheduler.SetLogger(options.Logger)
+	tracer := storage.db.tracer
+	amlService.setTracer(tracer)
+	reportingService.setTracer(tracer)
+	revenueAnalytics := NewRevenueAnalyticsService(storage, reportingService)
+	expenseAnomalies := NewExpenseAnomalyService(storage, reportingService)
+	forensicService.SetExpenseAnomalyService(expenseAnomalies)
+	consistency := NewConsistencyService(storage, eventStore)
+	consistency.SetLogger(options.Logger)
+	dataExport := NewDataExportService(storage, eventStore, accessControlService)
+	piiErasureService := NewPIIErasureService(storage, eventStore, amlService)
+	contractService := NewContractService(storage, eventStore, postingEngine, accrualService)
+	taxLineService := NewTaxLineService(storage, eventStore, complianceService)
+	vatReturnService := NewVATReturnService(storage, eventStore)
+	form1099Service := NewForm1099Service(storage, eventStore, payablesService)
+	postingEngine.AddPostingHook(form1099Service.RecordPayments)
+	soxControlService := NewSOXControlService(storage, eventStore, complianceService)
+	soxControlService.SetLogger(options.Logger)
+	frameworkAdjustments := NewFrameworkAdjustmentService(storage, eventStore, postingEngine)
+	workflows := NewWorkflowService(storage)
+	zbbService.UseWorkflows(workflows)
+	amlService.UseWorkflows(workflows)
+	complianceService.UseWorkflows(workflows)
+	periodCloseService.UseWorkflows(workflows)
+	journalApprovalService.UseWorkflows(workflows)
 
-	return &AccountingEngine{
-		storage:               storage,
-		eventStore:            eventStore,
-		processor:             processor,
-		postingEngine:         postingEngine,
-		queryAPI:              queryAPI,
-		reconciliationService: reconciliationService,
-		accrualService:        accrualService,
-		reportingService:      reportingService,  // Add reporting service
-		zbbService:            zbbService,        // Add ZBB service
-		complianceService:     complianceService, // Add compliance service
-		amlService:            amlService,        // Add AML service
-		forensicService:       forensicService,   // Add forensic service
-	}, nil
+	ae := &AccountingEngine{
+		storage:                  storage,
+		eventStore:               eventStore,
+		processor:                processor,
+		postingEngine:            postingEngine,
+		queryAPI:                 queryAPI,
+		reconciliationService:    reconciliationService,
+		accrualService:           accrualService,
+		reportingService:         reportingService,  // Add reporting service
+		zbbService:               zbbService,        // Add ZBB service
+		complianceService:        complianceService, // Add compliance service
+		amlService:               amlService,        // Add AML service
+		forensicService:          forensicService,   // Add forensic service
+		periodCloseService:       periodCloseService,
+		exchangeRateService:      exchangeRateService,
+		disclosureService:        disclosureService,
+		revaluationService:       revaluationService,
+		confirmationService:      confirmationService,
+		materialityService:       materialityService,
+		inflationService:         inflationService,
+		payablesService:          payablesService,
+		chainReconService:        chainReconService,
+		installmentService:       installmentService,
+		recurringService:         recurringService,
+		refundService:            refundService,
+		storedValueService:       storedValueService,
+		journalApprovalService:   journalApprovalService,
+		importService:            importService,
+		loyaltyService:           loyaltyService,
+		clientMoneyService:       clientMoneyService,
+		pspSettlementService:     pspSettlementService,
+		merchantReserveService:   merchantReserveService,
+		feeScheduleService:       feeScheduleService,
+		regulatoryService:        regulatoryService,
+		yearEndService:           yearEndService,
+		chartOfAccountsService:   chartOfAccountsService,
+		masterDataService:        masterDataService,
+		reclassService:           reclassService,
+		dimensionService:         dimensionService,
+		validator:                validator,
+		accessControlService:     accessControlService,
+		documentService:          documentService,
+		scriptService:            scriptService,
+		statementTemplateService: statementTemplateService,
+		narrativeService:         narrativeService,
+		ledgerService:            ledgerService,
+		segmentService:           segmentService,
+		reportScheduler:          reportScheduler,
+		goingConcern:             goingConcern,
+		notifications:            notifications,
+		metrics:                  metrics,
+		logger:                   componentLogger(options.Logger, "engine"),
+		tracer:                   tracer,
+		revenueAnalytics:         revenueAnalytics,
+		expenseAnomalies:         expenseAnomalies,
+		consistency:              consistency,
+		workflows:                workflows,
+		dataExport:               dataExport,
+		piiErasureService:        piiErasureService,
+		idempotencyTTL:           DefaultIdempotencyTTL,
+		contractService:          contractService,
+		taxLineService:           taxLineService,
+		vatReturnService:         vatReturnService,
+		form1099Service:          form1099Service,
+		soxControlService:        soxControlService,
+		frameworkAdjustments:     frameworkAdjustments,
+	}
+	periodCloseService.setBeforeClose(ae.beforePeriodClose)
+	return ae, nil
 }
 
 // Close closes the accounting engine and releases resources
 func (ae *AccountingEngine) Close() error {
+	ae.reportScheduler.Stop()
+	ae.consistency.Stop()
+	ae.soxControlService.Stop()
+	ae.notifications.Close()
 	return ae.storage.Close()
 }
 
+// GetCacheStats reports hits, misses and invalidations of the storage read cache
+func (ae *AccountingEngine) GetCacheStats() []CacheStats {
+	return ae.storage.CacheStats()
+}
+
+// GetStorageStats reports the database file's size, free space and per-bucket key
+// counts
+func (ae *AccountingEngine) GetStorageStats() (*StorageStats, error) {
+	return ae.storage.Stats()
+}
+
+// CompactStorage rewrites the database file to give back free pages. Operations wait
+// while it runs, so schedule it for a quiet period.
+func (ae *AccountingEngine) CompactStorage() (*CompactionResult, error) {
+	return ae.storage.Compact()
+}
+
+// BackupStorage writes a consistent copy of the database to w while the engine keeps
+// running. Keep the manifest with the backup to verify it on restore.
+func (ae *AccountingEngine) BackupStorage(w io.Writer) (*BackupManifest, error) {
+	return ae.storage.Backup(w)
+}
+
+// ExportEvents writes the events recorded after since to w, for shipping the event
+// log between backups
+func (ae *AccountingEngine) ExportEvents(w io.Writer, since time.Time) (*EventExport, error) {
+	return ae.storage.ExportEvents(w, since)
+}
+
+// RestoreStorage verifies a backup and swaps it in for the database. Services keep
+// some settings in memory, so close and reopen the engine afterwards.
+func (ae *AccountingEngine) RestoreStorage(r io.Reader, manifest *BackupManifest) (*RestoreResult, error) {
+	return ae.storage.Restore(r, manifest)
+}
+
+// ApplyEventExport rolls a restored backup forward with an event export, up to the
+// events recorded at until, and rebuilds derived data. Reopen the engine afterwards.
+func (ae *AccountingEngine) ApplyEventExport(r io.Reader, manifest *BackupManifest, until time.Time) (*EventReplayResult, error) {
+	return ae.storage.ApplyEventExport(r, manifest, until)
+}
+
+// RestoreStorageTo restores a backup and replays an event export onto it, giving the
+// database as it was at until. Reopen the engine afterwards.
+func (ae *AccountingEngine) RestoreStorageTo(backup io.Reader, manifest *BackupManifest, export io.Reader, until time.Time) (*RestoreResult, *EventReplayResult, error) {
+	return ae.storage.RestoreTo(backup, manifest, export, until)
+}
+
+// InvalidateCache drops the storage read cache, for use after the database file was
+// changed outside this engine
+func (ae *AccountingEngine) InvalidateCache() {
+	ae.storage.InvalidateCache()
+}
+
+// SetReportConcurrency bounds how many accounts trial balances and financial
+// statements work on at once. Zero or less uses one worker per CPU.
+func (ae *AccountingEngine) SetReportConcurrency(workers int) {
+	ae.queryAPI.SetConcurrency(workers)
+}
+
 // CreateAccount creates a new account
 func (ae *AccountingEngine) CreateAccount(account *Account, userID string) error {
 	// Set timestamps
 	account.CreatedAt = time.Now()
-	if account.ID == "" {
-		account.ID = uuid.New().String()
+	if err := ae.storage.assignID(&account.ID, "account", BucketAccounts); err != nil {
+		return err
+	}
+	if err := ae.chartOfAccountsService.PrepareAccount(account); err != nil {
+		return err
 	}
 
 	// Create account creation event
@@ -94,26 +364,78 @@ func (ae *AccountingEngine) CreateAccount(account *Account, userID string) error
 	return ae.storage.SaveAccount(account)
 }
 
-// CreateTransaction creates a new transaction
+// CreateTransaction creates a new transaction. Caller-supplied transaction and entry
+// IDs are kept as long as they are not already in use. A transaction with an
+// IdempotencyKey is created once: retries with the same key and request return the
+// original transaction.
 func (ae *AccountingEngine) CreateTransaction(txn *Transaction, userID string) error {
+	return ae.CreateTransactionContext(context.Background(), txn, userID)
+}
+
+// CreateTransactionContext creates a new transaction, tracing it as a child of any
+// span in ctx
+func (ae *AccountingEngine) CreateTransactionContext(ctx context.Context, txn *Transaction, userID string) (err error) {
+	_, span := ae.tracer.start(ctx, "engine.CreateTransaction", TraceKeyUserID.String(userID))
+	defer func() {
+		span.SetAttributes(transactionAttributes(txn)...)
+		endSpan(span, err)
+	}()
+	if txn.IdempotencyKey != "" {
+		return ae.createTransactionIdempotent(txn, userID)
+	}
+	return ae.createTransaction(txn, userID, nil)
+}
+
+// ValidateTransaction checks a transaction as CreateTransaction would for userID
+// without storing it, listing every violation
+func (ae *AccountingEngine) ValidateTransaction(txn *Transaction, userID string) (*ValidationResult, error) {
+	return ae.validator.Validate(txn, userID)
+}
+
+// createTransaction validates, numbers and saves a new transaction. An idempotency
+// record is completed with the transaction's ID and saved with it.
+func (ae *AccountingEngine) createTransaction(txn *Transaction, userID string, idempotency *IdempotencyRecord) error {
 	// Set timestamps and IDs
-	if txn.ID == "" {
-		txn.ID = uuid.New().String()
+	if err := ae.storage.assignID(&txn.ID, "transaction", BucketTransactions); err != nil {
+		return err
 	}
 	txn.CreatedAt = time.Now()
 	txn.UpdatedAt = time.Now()
 	txn.Status = Pending
+	txn.CreatedBy = userID
+
+	// Tax lines are appended first so they are validated with the rest of the entries
+	entries := len(txn.Entries)
+	taxLines, err := ae.taxLineService.appendTaxLines(txn)
+	if err != nil {
+		return err
+	}
+
+	validation, err := ae.validator.Validate(txn, userID)
+	if err == nil {
+		err = validation.Err()
+	}
+	if err != nil {
+		txn.Entries = txn.Entries[:entries] // hand the caller back its own entries
+		return err
+	}
 
 	// Generate entry IDs
+	entryIDs := make(map[string]bool)
 	for i := range txn.Entries {
-		if txn.Entries[i].ID == "" {
-			txn.Entries[i].ID = uuid.New().String()
+		if err := ae.storage.assignID(&txn.Entries[i].ID, "entry", BucketEntries); err != nil {
+			return err
+		}
+		if entryIDs[txn.Entries[i].ID] {
+			return fmt.Errorf("entry ID repeated in transaction: %s", txn.Entries[i].ID)
 		}
+		entryIDs[txn.Entries[i].ID] = true
 		txn.Entries[i].TransactionID = txn.ID
+		txn.Entries[i].Tags = normalizeTags(txn.Entries[i].Tags)
 	}
 
 	// Create transaction creation event
-	_, err := ae.eventStore.CreateEvent(
+	_, err = ae.eventStore.CreateEvent(
 		EventCreateTransaction,
 		TransactionCreatedEvent{Transaction: txn},
 		txn.ValidTime,
@@ -124,17 +446,61 @@ func (ae *AccountingEngine) CreateTransaction(txn *Transaction, userID string) e
 	}
 
 	// Process the event
-	return ae.storage.SaveTransaction(txn)
+	if idempotency != nil {
+		idempotency.TransactionID = txn.ID
+		err = ae.storage.SaveIdempotentTransaction(txn, idempotency)
+	} else {
+		err = ae.storage.SaveTransaction(txn)
+	}
+	if err != nil {
+		return err
+	}
+	if err := ae.taxLineService.recordTaxLines(txn, taxLines, userID); err != nil {
+		return err
+	}
+	ae.logger.Debug("transaction created", LogKeyTransactionID, txn.ID, LogKeyUserID, userID, "entries", len(txn.Entries))
+	return nil
 }
 
-// PostTransaction posts a transaction to the ledger
+// PostTransaction posts a transaction to the ledger. Transactions above the company's
+// approval threshold are held in PENDING_APPROVAL until approved instead.
 func (ae *AccountingEngine) PostTransaction(txnID string, userID string) error {
+	return ae.PostTransactionContext(context.Background(), txnID, userID)
+}
+
+// PostTransactionContext posts a transaction to the ledger, tracing it as a child of
+// any span in ctx
+func (ae *AccountingEngine) PostTransactionContext(ctx context.Context, txnID string, userID string) error {
+	_, span := ae.tracer.start(ctx, "engine.PostTransaction", TraceKeyTransactionID.String(txnID), TraceKeyUserID.String(userID))
+	held, err := ae.postTransaction(span, txnID, userID)
+	span.SetAttributes(TraceKeyHeld.Bool(held))
+	endSpan(span, err)
+	switch {
+	case err != nil:
+		ae.logger.Warn("transaction not posted", LogKeyTransactionID, txnID, LogKeyUserID, userID, LogKeyError, err)
+	case held:
+		ae.logger.Info("transaction held for approval", LogKeyTransactionID, txnID, LogKeyUserID, userID)
+	default:
+		ae.logger.Info("transaction posted", LogKeyTransactionID, txnID, LogKeyUserID, userID)
+	}
+	return err
+}
+
+// postTransaction posts a transaction, reporting whether it was held for approval
+func (ae *AccountingEngine) postTransaction(span trace.Span, txnID string, userID string) (bool, error) {
+	if err := ae.accessControlService.Authorize(userID, PermissionPostTransactions, "post transaction "+txnID); err != nil {
+		return false, err
+	}
 	txn, err := ae.storage.GetTransaction(txnID)
 	if err != nil {
-		return fmt.Errorf("failed to get transaction: %w", err)
+		return false, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	span.SetAttributes(transactionAttributes(txn)...)
+	if err := ae.postingEngine.checkPostingFourEyes(txn, userID); err != nil {
+		return false, err
 	}
 
-	return ae.postingEngine.PostTransaction(txn, userID)
+	return ae.journalApprovalService.Post(txn, userID)
 }
 
 // GetAccountBalance gets the current balance of an account
@@ -147,10 +513,66 @@ func (ae *AccountingEngine) GetTrialBalance(asOfDate time.Time, accountTypes []A
 	return ae.queryAPI.GetTrialBalance(asOfDate, accountTypes)
 }
 
+// GetRollupTrialBalance generates a trial balance with child balances rolled into their parents
+func (ae *AccountingEngine) GetRollupTrialBalance(asOfDate time.Time, accountTypes []AccountType) ([]*AccountRollupBalance, error) {
+	return ae.queryAPI.GetRollupTrialBalance(asOfDate, accountTypes)
+}
+
+// GetAccountBalanceAsOf gets an account balance on validAsOf as known at knowledgeAsOf
+func (ae *AccountingEngine) GetAccountBalanceAsOf(accountID string, validAsOf, knowledgeAsOf time.Time) (*BalanceResult, error) {
+	return ae.queryAPI.GetAccountBalanceAsOf(accountID, validAsOf, knowledgeAsOf)
+}
+
+// GetTrialBalanceAsOf generates the trial balance on validAsOf as known at knowledgeAsOf
+func (ae *AccountingEngine) GetTrialBalanceAsOf(validAsOf, knowledgeAsOf time.Time, accountTypes []AccountType) ([]*BalanceResult, error) {
+	return ae.queryAPI.GetTrialBalanceAsOf(validAsOf, knowledgeAsOf, accountTypes)
+}
+
+// SearchEntries finds entries by memo, reference, tags, account, date and status
+func (ae *AccountingEngine) SearchEntries(search *EntrySearch) ([]*EntrySearchResult, error) {
+	return ae.queryAPI.SearchEntries(search)
+}
+
+// Rebuild regenerates a derived store, or ALL of them, from the event log and
+// primary records
+func (ae *AccountingEngine) Rebuild(component RebuildComponent) ([]*RebuildResult, error) {
+	return ae.storage.Rebuild(component)
+}
+
+// ExportSyncManifest hashes this database's records for another database to diff
+// against
+func (ae *AccountingEngine) ExportSyncManifest() (*SyncManifest, error) {
+	return ae.storage.SyncManifest()
+}
+
+// ExportChanges packages the records the remote manifest is missing or holds in a
+// different version
+func (ae *AccountingEngine) ExportChanges(remote *SyncManifest) (*SyncPackage, error) {
+	return ae.storage.ExportChanges(remote)
+}
+
+// ImportChanges applies a package exported by another database and records the
+// import in the event log
+func (ae *AccountingEngine) ImportChanges(pkg *SyncPackage, policy SyncConflictPolicy, userID string) (*SyncResult, error) {
+	result, err := ae.storage.ImportChanges(pkg, policy)
+	if err != nil {
+		return result, err
+	}
+	if _, err := ae.eventStore.CreateEvent(EventImportSyncChanges, result, time.Now(), userID); err != nil {
+		return result, fmt.Errorf("failed to create sync import event: %w", err)
+	}
+	return result, nil
+}
+
+// GetPostingsAsOf lists the postings valid by validAsOf as known at knowledgeAsOf
+func (ae *AccountingEngine) GetPostingsAsOf(validAsOf, knowledgeAsOf time.Time) ([]*KnownPosting, error) {
+	return ae.queryAPI.GetPostingsAsOf(validAsOf, knowledgeAsOf)
+}
+
 // CreatePeriod creates a new accounting period
 func (ae *AccountingEngine) CreatePeriod(period *Period, userID string) error {
-	if period.ID == "" {
-		period.ID = uuid.New().String()
+	if err := ae.storage.assignID(&period.ID, "period", BucketPeriods); err != nil {
+		return err
 	}
 
 	// Create period creation event
@@ -167,36 +589,33 @@ func (ae *AccountingEngine) CreatePeriod(period *Period, userID string) error {
 	return ae.storage.SavePeriod(period)
 }
 
-// ClosePeriod closes an accounting period
+// ClosePeriod closes an accounting period. A soft close is made by a controller and
+// leaves the period open to controller adjustments; a hard close is made by a hard
+// closer and blocks all postings until the period is formally reopened. When FX
+// revaluation is configured, foreign-currency balances are remeasured at the closing
+// rate before the period closes.
 func (ae *AccountingEngine) ClosePeriod(periodID string, softClose bool, userID string) error {
-	period, err := ae.storage.GetPeriod(periodID)
-	if err != nil {
-		return fmt.Errorf("failed to get period: %w", err)
+	if err := ae.accessControlService.Authorize(userID, PermissionClosePeriods, "close period "+periodID); err != nil {
+		return err
 	}
+	return ae.periodCloseService.ClosePeriod(periodID, softClose, userID)
+}
 
-	now := time.Now()
-	if softClose {
-		period.SoftClosedAt = &now
-	} else {
-		period.HardClosedAt = &now
+// beforePeriodClose remeasures foreign-currency balances and restates for
+// hyperinflation, when configured, just before a period closes
+func (ae *AccountingEngine) beforePeriodClose(periodID, userID string) error {
+	if ae.revaluationService.IsConfigured() {
+		if _, err := ae.revaluationService.RevaluePeriod(periodID, userID); err != nil {
+			return fmt.Errorf("failed to revalue foreign currency balances: %w", err)
+		}
 	}
 
-	// Create period close event
-	_, err = ae.eventStore.CreateEvent(
-		EventClosePeriod,
-		map[string]interface{}{
-			"period_id":  periodID,
-			"soft_close": softClose,
-			"closed_at":  now,
-		},
-		time.Now(),
-		userID,
-	)
-	if err != nil {
-		return fmt.Errorf("failed to create period close event: %w", err)
+	if ae.inflationService.IsConfigured() {
+		if _, err := ae.inflationService.RestatePeriod(periodID, userID); err != nil {
+			return fmt.Errorf("failed to restate for hyperinflation: %w", err)
+		}
 	}
-
-	return ae.storage.SavePeriod(period)
+	return nil
 }
 
 // AutoReconcile performs automatic reconciliation
@@ -224,11 +643,56 @@ func (ae *AccountingEngine) CreateAccrualSchedule(
 	)
 }
 
+// AttachAccrualBreakdown stores the supporting calculation for an accrual schedule
+func (ae *AccountingEngine) AttachAccrualBreakdown(scheduleID string, breakdown *CalculationBreakdown, userID string) error {
+	return ae.accrualService.AttachScheduleBreakdown(scheduleID, breakdown, userID)
+}
+
 // ProcessAccruals processes pending accrual recognitions
 func (ae *AccountingEngine) ProcessAccruals(upToDate time.Time, userID string) error {
 	return ae.accrualService.ProcessPendingRecognitions(upToDate, userID)
 }
 
+// RunRecognition posts the recognition entries of every schedule due by asOf and
+// reports what was posted. Entries already posted are never posted twice, so runs
+// can be repeated or retried after a failure.
+func (ae *AccountingEngine) RunRecognition(asOf time.Time, userID string) (*RecognitionRun, error) {
+	if err := ae.accessControlService.Authorize(userID, PermissionPostTransactions, "run revenue recognition"); err != nil {
+		return nil, err
+	}
+	return ae.accrualService.RunRecognition(asOf, userID)
+}
+
+// GetAccrualScheduleStatus reports how far a recognition schedule has been posted
+func (ae *AccountingEngine) GetAccrualScheduleStatus(scheduleID string) (*ScheduleStatus, error) {
+	return ae.accrualService.GetScheduleStatus(scheduleID)
+}
+
+// SaveAccrualTemplate adds or replaces a template in the accrual template catalog
+func (ae *AccountingEngine) SaveAccrualTemplate(template *AccrualTemplate, userID string) error {
+	return ae.accrualService.SaveTemplate(template, userID)
+}
+
+// ListAccrualTemplates lists the built-in and saved accrual templates
+func (ae *AccountingEngine) ListAccrualTemplates() ([]*AccrualTemplate, error) {
+	return ae.accrualService.ListTemplates()
+}
+
+// CreateAccrualScheduleFromTemplate creates a recognition schedule from a catalog template
+func (ae *AccountingEngine) CreateAccrualScheduleFromTemplate(
+	templateID string,
+	txnID string,
+	totalAmount *Amount,
+	frequency ScheduleFrequency,
+	occurrences int,
+	startDate time.Time,
+	userID string,
+) (*RecognitionSchedule, error) {
+	return ae.accrualService.CreateScheduleFromTemplate(
+		templateID, txnID, totalAmount, frequency, occurrences, startDate, userID,
+	)
+}
+
 // GetReconciliationSummary gets reconciliation summary for an account
 func (ae *AccountingEngine) GetReconciliationSummary(accountID string, asOfDate time.Time) (*ReconciliationSummary, error) {
 	return ae.reconciliationService.GetReconciliationSummary(accountID, asOfDate)
@@ -236,6 +700,13 @@ func (ae *AccountingEngine) GetReconciliationSummary(accountID string, asOfDate
 
 // ReverseTransaction creates a reversing transaction
 func (ae *AccountingEngine) ReverseTransaction(originalTxnID string, description string, userID string) (*Transaction, error) {
+	original, err := ae.storage.GetTransaction(originalTxnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get original transaction: %w", err)
+	}
+	if err := ae.postingEngine.checkReversalFourEyes(original, userID); err != nil {
+		return nil, err
+	}
 	return ae.postingEngine.ReverseTransaction(originalTxnID, description, userID)
 }
 
@@ -246,6 +717,11 @@ func (ae *AccountingEngine) GetEvents(from, to time.Time) ([]*JournalEvent, erro
 
 // Financial Reporting Methods
 
+// GenerateTrialBalance generates a trial balance translated into the given currency
+func (ae *AccountingEngine) GenerateTrialBalance(asOfDate time.Time, currency string) ([]*BalanceResult, error) {
+	return ae.reportingService.GenerateTrialBalance(asOfDate, currency)
+}
+
 // GenerateBalanceSheet generates a balance sheet as of a specific date
 func (ae *AccountingEngine) GenerateBalanceSheet(asOfDate time.Time, currency string) (*FinancialStatement, error) {
 	return ae.reportingService.GenerateBalanceSheet(asOfDate, currency)
@@ -256,157 +732,1975 @@ func (ae *AccountingEngine) GenerateProfitAndLoss(fromDate, toDate time.Time, cu
 	return ae.reportingService.GenerateProfitAndLoss(fromDate, toDate, currency)
 }
 
+// DiffStatements compares the balance sheet and P&L on dateA with dateB, as known
+// now, listing the transactions behind each line's change
+func (ae *AccountingEngine) DiffStatements(dateA, dateB time.Time, currency string) (*StatementDiff, error) {
+	return ae.reportingService.DiffStatements(StatementPoint{ValidAsOf: dateA}, StatementPoint{ValidAsOf: dateB}, currency)
+}
+
+// DiffStatementsAsKnown compares the statements on validAsOf as known at knownA with
+// knownB, e.g. the figures reported at close with today's restated figures
+func (ae *AccountingEngine) DiffStatementsAsKnown(validAsOf, knownA, knownB time.Time, currency string) (*StatementDiff, error) {
+	return ae.reportingService.DiffStatements(
+		StatementPoint{ValidAsOf: validAsOf, KnowledgeAsOf: knownA},
+		StatementPoint{ValidAsOf: validAsOf, KnowledgeAsOf: knownB},
+		currency,
+	)
+}
+
+// DiffStatementPoints compares the statements at two arbitrary valid and knowledge times
+func (ae *AccountingEngine) DiffStatementPoints(a, b StatementPoint, currency string) (*StatementDiff, error) {
+	return ae.reportingService.DiffStatements(a, b, currency)
+}
+
+// GenerateProfitAndLossByDimension generates a P&L for a period split by the values of a dimension
+func (ae *AccountingEngine) GenerateProfitAndLossByDimension(key DimensionKey, fromDate, toDate time.Time, currency string) (*SegmentProfitAndLoss, error) {
+	return ae.reportingService.GenerateProfitAndLossByDimension(key, fromDate, toDate, currency)
+}
+
+// GetBalancesByDimensions aggregates posted balances matching a filter by dimension values
+func (ae *AccountingEngine) GetBalancesByDimensions(filter *QueryFilter, groupBy []DimensionKey) (*DimensionBalanceReport, error) {
+	return ae.reportingService.GetBalancesByDimensions(filter, groupBy)
+}
+
+// WriteGeneralLedgerDetail streams the general ledger detail for accounts, or all
+// accounts when none are given, with opening, running and closing balances
+func (ae *AccountingEngine) WriteGeneralLedgerDetail(w io.Writer, format ExportFormat, accountIDs []string, from, to time.Time) error {
+	return ae.reportingService.WriteGeneralLedgerDetail(w, format, accountIDs, from, to)
+}
+
+// GetGeneralLedger returns a page of the general ledger detail with each account's
+// opening balance, entries with running balances, and closing balance
+func (ae *AccountingEngine) GetGeneralLedger(query *GeneralLedgerQuery) (*GeneralLedgerPage, error) {
+	return ae.reportingService.GetGeneralLedger(query)
+}
+
+// WriteJournalListing streams the entries posted between two dates in valid-time order
+func (ae *AccountingEngine) WriteJournalListing(w io.Writer, format ExportFormat, from, to time.Time) error {
+	return ae.reportingService.WriteJournalListing(w, format, from, to)
+}
+
+// ExportTrialBalance writes the trial balance as of a date in an export format:
+// CSV, JSON, XLSX or PDF
+func (ae *AccountingEngine) ExportTrialBalance(w io.Writer, format ExportFormat, asOfDate time.Time, currency string) error {
+	balances, err := ae.reportingService.GenerateTrialBalance(asOfDate, currency)
+	if err != nil {
+		return err
+	}
+	return ExportReport(w, format, TrialBalanceDocument(balances, asOfDate, currency))
+}
+
+// ExportTransactions streams posted transactions matching a filter in the import layout
+func (ae *AccountingEngine) ExportTransactions(w io.Writer, format ExportFormat, filter *QueryFilter) error {
+	return ae.reportingService.ExportTransactions(w, format, filter)
+}
+
 // GenerateCashFlowStatement generates a cash flow statement for a period
 func (ae *AccountingEngine) GenerateCashFlowStatement(fromDate, toDate time.Time, currency string) (*CashFlowStatement, error) {
 	return ae.reportingService.GenerateCashFlowStatement(fromDate, toDate, currency)
 }
 
+// GenerateIndirectCashFlowStatement generates a cash flow statement that reconciles
+// net income to cash. Nil options use DefaultIndirectCashFlowOptions.
+func (ae *AccountingEngine) GenerateIndirectCashFlowStatement(fromDate, toDate time.Time, currency string, options *IndirectCashFlowOptions) (*CashFlowStatement, error) {
+	return ae.reportingService.GenerateIndirectCashFlowStatement(fromDate, toDate, currency, options)
+}
+
+// GenerateFinancialRatios computes liquidity, profitability, leverage and efficiency
+// ratios for the month, quarter or year to date, with the prior period for
+// comparison. Nil options use DefaultFinancialRatioOptions.
+func (ae *AccountingEngine) GenerateFinancialRatios(asOf time.Time, period ScheduleFrequency, currency string, options *FinancialRatioOptions) (*FinancialRatios, error) {
+	return ae.reportingService.GenerateFinancialRatios(asOf, period, currency, options)
+}
+
 // FormatFinancialStatement formats a financial statement for display
 func (ae *AccountingEngine) FormatFinancialStatement(statement *FinancialStatement) string {
 	return ae.reportingService.FormatFinancialStatement(statement)
 }
 
+// FormatFinancialStatementAs renders a financial statement as text, CSV or JSON
+func (ae *AccountingEngine) FormatFinancialStatementAs(statement *FinancialStatement, format ExportFormat) (string, error) {
+	return ae.reportingService.FormatFinancialStatementAs(statement, format)
+}
+
 // FormatCashFlowStatement formats a cash flow statement for display
 func (ae *AccountingEngine) FormatCashFlowStatement(cf *CashFlowStatement) string {
 	return ae.reportingService.FormatCashFlowStatement(cf)
 }
 
 // ----------------------------------------------------------------------------
-// Zero-Based Budgeting Methods
+// Exchange Rate Methods
 // ----------------------------------------------------------------------------
 
-// CreateBudgetPeriod creates a new budget period
-func (ae *AccountingEngine) CreateBudgetPeriod(period *BudgetPeriod, userID string) error {
-	return ae.zbbService.CreateBudgetPeriod(period, userID)
+// SetExchangeRate records a manual exchange rate effective from the given date
+func (ae *AccountingEngine) SetExchangeRate(from, to Currency, rate float64, effectiveDate time.Time, userID string) (*ExchangeRate, error) {
+	return ae.exchangeRateService.SetRate(from, to, rate, effectiveDate, userID)
 }
 
-// CreateBudgetRequest creates a new zero-based budget request
-func (ae *AccountingEngine) CreateBudgetRequest(request *BudgetRequest, userID string) error {
-	return ae.zbbService.CreateBudgetRequest(request, userID)
+// GetExchangeRate returns the exchange rate in effect on the given date
+func (ae *AccountingEngine) GetExchangeRate(from, to Currency, date time.Time) (*ExchangeRate, error) {
+	return ae.exchangeRateService.GetRate(from, to, date)
 }
 
-// AddJustification adds business justification to a budget request
-func (ae *AccountingEngine) AddJustification(requestID string, justification *Justification, userID string) error {
-	return ae.zbbService.AddJustification(requestID, justification, userID)
+// ConvertAmount translates an amount into another currency at the rate in effect on the given date
+func (ae *AccountingEngine) ConvertAmount(amount *Amount, to Currency, date time.Time) (*Amount, error) {
+	return ae.exchangeRateService.Convert(amount, to, date)
 }
 
-// SubmitBudgetRequest submits request for approval
-func (ae *AccountingEngine) SubmitBudgetRequest(requestID string, userID string) error {
-	return ae.zbbService.SubmitBudgetRequest(requestID, userID)
+// ConfigureFXRevaluation enables revaluation of foreign-currency balances at period close
+func (ae *AccountingEngine) ConfigureFXRevaluation(config RevaluationConfig) error {
+	return ae.revaluationService.Configure(config)
 }
 
-// ApproveBudgetRequest approves a budget request
-func (ae *AccountingEngine) ApproveBudgetRequest(requestID string, approverID string, approvedAmount *Amount, comments string) error {
-	return ae.zbbService.ApproveBudgetRequest(requestID, approverID, approvedAmount, comments)
+// CalculateFXRevaluation previews the unrealized FX gains and losses for a period without posting
+func (ae *AccountingEngine) CalculateFXRevaluation(periodID string) (*RevaluationResult, error) {
+	return ae.revaluationService.CalculateRevaluation(periodID)
 }
 
-// CreateBudgetAllocation creates budget allocation from approved request
-func (ae *AccountingEngine) CreateBudgetAllocation(requestID string, userID string) error {
-	return ae.zbbService.CreateBudgetAllocation(requestID, userID)
+// RegisterExchangeRateProvider adds a provider consulted when no stored rate exists
+func (ae *AccountingEngine) RegisterExchangeRateProvider(provider ExchangeRateProvider) {
+	ae.exchangeRateService.RegisterProvider(provider)
 }
 
-// TrackBudgetSpending tracks actual spending against budget allocations
-func (ae *AccountingEngine) TrackBudgetSpending(transactionID string, allocationID string) error {
-	return ae.zbbService.TrackBudgetSpending(transactionID, allocationID)
+// ----------------------------------------------------------------------------
+// Disclosure Methods
+// ----------------------------------------------------------------------------
+
+// CreateDisclosure adds an accounting policy, commitment or related-party note to the library
+func (ae *AccountingEngine) CreateDisclosure(disclosure *Disclosure, userID string) error {
+	return ae.disclosureService.CreateDisclosure(disclosure, userID)
 }
 
-// GetBudgetVariance calculates variance between budget and actual
-func (ae *AccountingEngine) GetBudgetVariance(periodID string, departmentID string) (*BudgetVarianceReport, error) {
-	return ae.zbbService.GetBudgetVariance(periodID, departmentID)
+// SetDisclosureValue records a period-specific value referenced by a disclosure
+func (ae *AccountingEngine) SetDisclosureValue(disclosureID, periodID, key, value, userID string) (*DisclosureValue, error) {
+	return ae.disclosureService.SetPeriodValue(disclosureID, periodID, key, value, userID)
 }
 
-// GetDepartmentBudgetSummary provides summary of all budget requests for a department
-func (ae *AccountingEngine) GetDepartmentBudgetSummary(periodID string, departmentID string) (*DepartmentBudgetSummary, error) {
-	return ae.zbbService.GetDepartmentBudgetSummary(periodID, departmentID)
+// AttachDisclosures renders the period's disclosures as footnotes on a generated statement
+func (ae *AccountingEngine) AttachDisclosures(statement *FinancialStatement, periodID string) error {
+	return ae.disclosureService.AttachFootnotes(statement, periodID)
 }
 
-// Helper methods for common operations
+// ----------------------------------------------------------------------------
+// Period Close Methods
+// ----------------------------------------------------------------------------
 
-// CreateStandardAccounts creates a basic chart of accounts
-func (ae *AccountingEngine) CreateStandardAccounts(userID string) error {
-	accounts := []*Account{
-		{
-			ID:   "cash",
-			Code: "1001",
-			Name: "Cash",
-			Type: Asset,
-		},
-		{
-			ID:   "accounts_receivable",
-			Code: "1200",
-			Name: "Accounts Receivable",
-			Type: Asset,
-		},
-		{
-			ID:   "intercompany_receivable",
-			Code: "1300",
-			Name: "Intercompany Receivable",
-			Type: Asset,
-		},
-		{
-			ID:   "accounts_payable",
-			Code: "2001",
-			Name: "Accounts Payable",
-			Type: Liability,
-		},
-		{
-			ID:   "intercompany_payable",
-			Code: "2200",
-			Name: "Intercompany Payable",
-			Type: Liability,
-		},
-		{
-			ID:   "revenue",
-			Code: "4001",
-			Name: "Revenue",
-			Type: Income,
-		},
-		{
-			ID:   "expenses",
-			Code: "5001",
-			Name: "Operating Expenses",
-			Type: Expense,
-		},
-		{
-			ID:   "unearned_revenue",
-			Code: "2100",
-			Name: "Unearned Revenue",
-			Type: Liability,
-		},
-	}
+// AddVarianceCommentary records an explanation for an account's movement in a period
+func (ae *AccountingEngine) AddVarianceCommentary(periodID, accountID, comment, userID string) (*VarianceCommentary, error) {
+	return ae.periodCloseService.AddVarianceCommentary(periodID, accountID, comment, userID)
+}
 
-	for _, account := range accounts {
-		if err := ae.CreateAccount(account, userID); err != nil {
-			return fmt.Errorf("failed to create account %s: %w", account.Name, err)
-		}
-	}
+// SignOffPeriod records a preparer, reviewer or controller sign-off for a period
+func (ae *AccountingEngine) SignOffPeriod(periodID string, role SignOffRole, comments, userID string) (*CloseSignOff, error) {
+	return ae.periodCloseService.SignOffPeriod(periodID, role, comments, userID)
+}
 
-	return nil
+// GenerateClosingBinder assembles and archives the closing binder for a period
+func (ae *AccountingEngine) GenerateClosingBinder(periodID, currency, userID string) (*ClosingBinder, *ClosingBinderArchive, error) {
+	return ae.periodCloseService.GenerateClosingBinder(periodID, currency, userID)
 }
 
-// CreateLedger creates a new ledger
-func (ae *AccountingEngine) CreateLedger(ledger *Ledger) error {
-	if ledger.ID == "" {
-		ledger.ID = uuid.New().String()
+// GetCloseChecklist returns the close checklist for a period
+func (ae *AccountingEngine) GetCloseChecklist(periodID string) (*PeriodCloseChecklist, error) {
+	return ae.periodCloseService.GetCloseChecklist(periodID)
+}
+
+// UpdateCloseChecklistItem marks a close checklist step complete, incomplete or not applicable
+func (ae *AccountingEngine) UpdateCloseChecklistItem(periodID, step string, status ChecklistItemStatus, note, userID string) (*PeriodCloseChecklist, error) {
+	return ae.periodCloseService.UpdateChecklistItem(periodID, step, status, note, userID)
+}
+
+// ReopenPeriod reopens a closed period for posting, recording the reason
+func (ae *AccountingEngine) ReopenPeriod(periodID, reason, userID string) (*PeriodReopening, error) {
+	if err := ae.accessControlService.Authorize(userID, PermissionClosePeriods, "reopen period "+periodID); err != nil {
+		return nil, err
 	}
-	return ae.storage.SaveLedger(ledger)
+	return ae.periodCloseService.ReopenPeriod(periodID, reason, userID)
+}
+
+// GetPeriodReopenings returns the reopening history of a period
+func (ae *AccountingEngine) GetPeriodReopenings(periodID string) ([]*PeriodReopening, error) {
+	return ae.periodCloseService.GetReopenings(periodID)
 }
 
 // ----------------------------------------------------------------------------
-// Service Getters
+// Hyperinflation Methods
 // ----------------------------------------------------------------------------
 
-// GetAMLService returns the AML service
-func (ae *AccountingEngine) GetAMLService() *AMLService {
-	return ae.amlService
+// RecordPriceIndex records a general price index reading used for IAS 29 restatement
+func (ae *AccountingEngine) RecordPriceIndex(indexName string, date time.Time, value float64, userID string) (*PriceIndex, error) {
+	return ae.inflationService.RecordPriceIndex(indexName, date, value, userID)
 }
 
-// GetComplianceService returns the compliance service
-func (ae *AccountingEngine) GetComplianceService() *ComplianceService {
-	return ae.complianceService
+// ConfigureHyperinflation enables IAS 29 restatement when periods are closed
+func (ae *AccountingEngine) ConfigureHyperinflation(config HyperinflationConfig) error {
+	return ae.inflationService.Configure(config)
 }
 
-// GetForensicService returns the forensic service
+// GenerateRestatedTrialBalance restates the trial balance to the price index at a date
+func (ae *AccountingEngine) GenerateRestatedTrialBalance(asOfDate time.Time) (*RestatedTrialBalance, error) {
+	return ae.inflationService.GenerateRestatedTrialBalance(asOfDate)
+}
+
+// ----------------------------------------------------------------------------
+// Materiality Methods
+// ----------------------------------------------------------------------------
+
+// CalculateMateriality derives and records a company's materiality for a period
+func (ae *AccountingEngine) CalculateMateriality(request MaterialityRequest, userID string) (*MaterialityAssessment, error) {
+	return ae.materialityService.CalculateMateriality(request, userID)
+}
+
+// GetEffectiveMateriality returns the company's materiality in effect on a date
+func (ae *AccountingEngine) GetEffectiveMateriality(companyID string, date time.Time) (*MaterialityAssessment, error) {
+	return ae.materialityService.GetEffectiveMateriality(companyID, date)
+}
+
+// ----------------------------------------------------------------------------
+// Audit Confirmation Methods
+// ----------------------------------------------------------------------------
+
+// GenerateConfirmations creates bank, receivable or payable confirmation requests as of a date
+func (ae *AccountingEngine) GenerateConfirmations(confirmationType ConfirmationType, accountIDs []string, asOfDate time.Time, userID string) ([]*ConfirmationRequest, error) {
+	return ae.confirmationService.GenerateConfirmations(confirmationType, accountIDs, asOfDate, userID)
+}
+
+// MarkConfirmationSent records that a confirmation request has been dispatched
+func (ae *AccountingEngine) MarkConfirmationSent(requestID string, sentAt time.Time, userID string) (*ConfirmationRequest, error) {
+	return ae.confirmationService.MarkSent(requestID, sentAt, userID)
+}
+
+// RecordConfirmationResponse records the balance confirmed by the third party
+func (ae *AccountingEngine) RecordConfirmationResponse(requestID string, confirmedValue int64, notes string, receivedAt time.Time, userID string) (*ConfirmationRequest, error) {
+	return ae.confirmationService.RecordResponse(requestID, confirmedValue, notes, receivedAt, userID)
+}
+
+// GetConfirmationSummary reports confirmation progress for an audit date
+func (ae *AccountingEngine) GetConfirmationSummary(asOfDate time.Time) (*ConfirmationSummary, error) {
+	return ae.confirmationService.GetConfirmationSummary(asOfDate)
+}
+
+// ----------------------------------------------------------------------------
+// Accounts Payable Methods
+// ----------------------------------------------------------------------------
+
+// ApplyCompanySettings applies company-level controls such as the bill and journal
+// approval threshold, the budget control policy, the account numbering scheme, the
+// posting date window and the four-eyes policy. An invalid numbering scheme or window
+// is rejected before anything is applied.
+func (ae *AccountingEngine) ApplyCompanySettings(settings *CompanySettings) error {
+	if settings != nil && settings.PostingDateWindow != nil {
+		if err := settings.PostingDateWindow.Validate(); err != nil {
+			return fmt.Errorf("invalid posting date window: %w", err)
+		}
+	}
+	if err := ae.chartOfAccountsService.ApplyCompanySettings(settings); err != nil {
+		return err
+	}
+	if err := ae.postingEngine.ApplyCompanySettings(settings); err != nil {
+		return err
+	}
+	ae.payablesService.ApplyCompanySettings(settings)
+	ae.journalApprovalService.ApplyCompanySettings(settings)
+	ae.zbbService.ApplyCompanySettings(settings)
+	return nil
+}
+
+// CreateVendor adds a vendor to the payables sub-ledger
+func (ae *AccountingEngine) CreateVendor(vendor *Vendor, userID string) error {
+	return ae.payablesService.CreateVendor(vendor, userID)
+}
+
+// EnterBill records a vendor bill as a draft
+func (ae *AccountingEngine) EnterBill(bill *VendorBill, userID string) error {
+	return ae.payablesService.EnterBill(bill, userID)
+}
+
+// SubmitBill submits a bill for approval, posting it directly when under the approval threshold
+func (ae *AccountingEngine) SubmitBill(billID, userID string) (*VendorBill, error) {
+	return ae.payablesService.SubmitBill(billID, userID)
+}
+
+// ApproveBill approves a bill and posts the liability
+func (ae *AccountingEngine) ApproveBill(billID, userID string) (*VendorBill, error) {
+	return ae.payablesService.ApproveBill(billID, userID)
+}
+
+// SchedulePaymentRun selects approved bills due by a date for payment from an account
+func (ae *AccountingEngine) SchedulePaymentRun(scheduledDate, dueBy time.Time, paymentAccountID, userID string) (*PaymentRun, error) {
+	return ae.payablesService.SchedulePaymentRun(scheduledDate, dueBy, paymentAccountID, userID)
+}
+
+// ExecutePaymentRun posts the payment transaction for a scheduled run
+func (ae *AccountingEngine) ExecutePaymentRun(runID, userID string) (*PaymentRun, error) {
+	return ae.payablesService.ExecutePaymentRun(runID, userID)
+}
+
+// GenerateAPAgingReport ages unpaid bills in a currency as of a date
+func (ae *AccountingEngine) GenerateAPAgingReport(asOfDate time.Time, currency Currency) (*APAgingReport, error) {
+	return ae.payablesService.GenerateAgingReport(asOfDate, currency)
+}
+
+// ----------------------------------------------------------------------------
+// On-Chain Reconciliation Methods
+// ----------------------------------------------------------------------------
+
+// SetChainDataProvider sets the source of on-chain balances and transfers
+func (ae *AccountingEngine) SetChainDataProvider(provider ChainDataProvider) {
+	ae.chainReconService.SetProvider(provider)
+}
+
+// RegisterChainWallet links a crypto asset account to its on-chain address
+func (ae *AccountingEngine) RegisterChainWallet(wallet *ChainWallet) error {
+	return ae.chainReconService.RegisterWallet(wallet)
+}
+
+// ReconcileChainWallet reconciles a crypto asset account to the chain, flagging discrepancies for AML review
+func (ae *AccountingEngine) ReconcileChainWallet(accountID string, periodStart, periodEnd time.Time, userID string) (*ChainReconciliation, error) {
+	return ae.chainReconService.Reconcile(accountID, periodStart, periodEnd, userID)
+}
+
+// ----------------------------------------------------------------------------
+// Installment Plan Methods
+// ----------------------------------------------------------------------------
+
+// CreateInstallmentPlan posts an installment sale and builds its receivable schedule
+func (ae *AccountingEngine) CreateInstallmentPlan(request InstallmentPlanRequest, userID string) (*InstallmentPlan, error) {
+	return ae.installmentService.CreatePlan(request, userID)
+}
+
+// RecognizeInstallmentInterest posts imputed interest for installment periods ending by a date
+func (ae *AccountingEngine) RecognizeInstallmentInterest(asOfDate time.Time, userID string) ([]*Transaction, error) {
+	return ae.installmentService.RecognizeInterest(asOfDate, userID)
+}
+
+// RecordInstallmentReceipt applies a customer payment to an installment plan
+func (ae *AccountingEngine) RecordInstallmentReceipt(planID string, amount int64, receivedAt time.Time, cashAccountID, userID string) (*InstallmentPlan, error) {
+	return ae.installmentService.RecordReceipt(planID, amount, receivedAt, cashAccountID, userID)
+}
+
+// ----------------------------------------------------------------------------
+// Recurring Transaction Methods
+// ----------------------------------------------------------------------------
+
+// CreateRecurringTemplate saves a template for a transaction posted on a fixed schedule
+func (ae *AccountingEngine) CreateRecurringTemplate(template *RecurringTransactionTemplate, userID string) error {
+	return ae.recurringService.CreateTemplate(template, userID)
+}
+
+// PauseRecurringTemplate stops a template from posting until it is resumed
+func (ae *AccountingEngine) PauseRecurringTemplate(templateID, userID string) (*RecurringTransactionTemplate, error) {
+	return ae.recurringService.PauseTemplate(templateID, userID)
+}
+
+// ResumeRecurringTemplate resumes posting for a paused template
+func (ae *AccountingEngine) ResumeRecurringTemplate(templateID, userID string) (*RecurringTransactionTemplate, error) {
+	return ae.recurringService.ResumeTemplate(templateID, userID)
+}
+
+// RunDue posts every recurring transaction due by a date, recording skipped and failed runs
+func (ae *AccountingEngine) RunDue(asOfDate time.Time, userID string) ([]*RecurringRun, error) {
+	return ae.recurringService.RunDue(asOfDate, userID)
+}
+
+// ----------------------------------------------------------------------------
+// Refund and Chargeback Methods
+// ----------------------------------------------------------------------------
+
+// RefundSale posts a refund reversing a sale's revenue, tax and fees in proportion to the amount refunded
+func (ae *AccountingEngine) RefundSale(request SaleReversalRequest, userID string) (*SaleReversal, error) {
+	return ae.refundService.RefundSale(request, userID)
+}
+
+// RecordChargeback posts a chargeback against a sale and opens it for dispute
+func (ae *AccountingEngine) RecordChargeback(request SaleReversalRequest, userID string) (*SaleReversal, error) {
+	return ae.refundService.RecordChargeback(request, userID)
+}
+
+// SubmitChargebackDispute contests an open chargeback with evidence
+func (ae *AccountingEngine) SubmitChargebackDispute(chargebackID, evidence, userID string) (*SaleReversal, error) {
+	return ae.refundService.SubmitDispute(chargebackID, evidence, userID)
+}
+
+// ResolveChargebackDispute records the issuer's decision, reinstating the sale when won
+func (ae *AccountingEngine) ResolveChargebackDispute(chargebackID string, won bool, resolvedAt time.Time, userID string) (*SaleReversal, error) {
+	return ae.refundService.ResolveDispute(chargebackID, won, resolvedAt, userID)
+}
+
+// MonitorChargebackRates reports chargeback rates per product and channel, raising fraud alerts above the alert rate
+func (ae *AccountingEngine) MonitorChargebackRates(periodStart, periodEnd time.Time) (*ChargebackRateReport, error) {
+	return ae.refundService.MonitorChargebackRates(periodStart, periodEnd)
+}
+
+// ----------------------------------------------------------------------------
+// Stored Value Methods
+// ----------------------------------------------------------------------------
+
+// CreateStoredValueProgram creates a gift card or other stored-value program
+func (ae *AccountingEngine) CreateStoredValueProgram(program *StoredValueProgram, userID string) error {
+	return ae.storedValueService.CreateProgram(program, userID)
+}
+
+// IssueGiftCard sells a gift card, recognizing its value as a liability
+func (ae *AccountingEngine) IssueGiftCard(programID string, value int64, customerID string, issuedAt time.Time, cashAccountID, userID string) (*GiftCard, error) {
+	return ae.storedValueService.IssueCard(programID, value, customerID, issuedAt, cashAccountID, userID)
+}
+
+// RedeemGiftCard spends part of a gift card balance and recognizes the revenue
+func (ae *AccountingEngine) RedeemGiftCard(cardID string, amount int64, redeemedAt time.Time, userID string) (*GiftCard, error) {
+	return ae.storedValueService.RedeemCard(cardID, amount, redeemedAt, userID)
+}
+
+// RecognizeBreakage brings stored-value breakage up to date as of the date
+func (ae *AccountingEngine) RecognizeBreakage(asOfDate time.Time, userID string) ([]*Transaction, error) {
+	return ae.storedValueService.RecognizeBreakage(asOfDate, userID)
+}
+
+// GenerateStoredValueRollForward reconciles a program's liability across a period
+func (ae *AccountingEngine) GenerateStoredValueRollForward(programID string, periodStart, periodEnd time.Time) (*StoredValueRollForward, error) {
+	return ae.storedValueService.GenerateRollForward(programID, periodStart, periodEnd)
+}
+
+// ----------------------------------------------------------------------------
+// Journal Approval Methods
+// ----------------------------------------------------------------------------
+
+// SetPeriodClosePermissions designates who may soft-close, hard-close and reopen
+// periods and post adjustments into soft-closed ones
+func (ae *AccountingEngine) SetPeriodClosePermissions(permissions PeriodClosePermissions) {
+	ae.postingEngine.SetPeriodClosePermissions(permissions)
+}
+
+// SetJournalApprovers designates the users who may approve held journal entries
+func (ae *AccountingEngine) SetJournalApprovers(userIDs []string) {
+	ae.journalApprovalService.SetApprovers(userIDs)
+}
+
+// ApproveTransaction approves a transaction held for approval and posts it
+func (ae *AccountingEngine) ApproveTransaction(txnID, comment, userID string) (*JournalApproval, error) {
+	if err := ae.accessControlService.Authorize(userID, PermissionPostTransactions, "approve transaction "+txnID); err != nil {
+		return nil, err
+	}
+	txn, err := ae.storage.GetTransaction(txnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if err := ae.postingEngine.checkPostingFourEyes(txn, userID); err != nil {
+		return nil, err
+	}
+	return ae.journalApprovalService.Approve(txnID, comment, userID)
+}
+
+// RejectTransaction returns a transaction held for approval to its preparer
+func (ae *AccountingEngine) RejectTransaction(txnID, comment, userID string) (*JournalApproval, error) {
+	return ae.journalApprovalService.Reject(txnID, comment, userID)
+}
+
+// GetPendingApprovals lists the held transactions the user may approve
+func (ae *AccountingEngine) GetPendingApprovals(userID string) ([]*JournalApproval, error) {
+	return ae.journalApprovalService.GetPendingApprovals(userID)
+}
+
+// ----------------------------------------------------------------------------
+// Transaction Import Methods
+// ----------------------------------------------------------------------------
+
+// ImportTransactions validates and posts a CSV or JSON lines batch of transactions
+func (ae *AccountingEngine) ImportTransactions(reader io.Reader, format ImportFormat, options ImportOptions, userID string) (*ImportResult, error) {
+	return ae.importService.ImportTransactions(reader, format, options, userID)
+}
+
+// ----------------------------------------------------------------------------
+// Loyalty Methods
+// ----------------------------------------------------------------------------
+
+// CreateLoyaltyProgram creates a customer loyalty points program
+func (ae *AccountingEngine) CreateLoyaltyProgram(program *LoyaltyProgram, userID string) error {
+	return ae.loyaltyService.CreateProgram(program, userID)
+}
+
+// AwardLoyaltyPoints credits points earned on a sale and defers the revenue allocated to them
+func (ae *AccountingEngine) AwardLoyaltyPoints(programID, memberID string, points, salePrice int64, earnedAt time.Time, saleRef, userID string) (*LoyaltyMemberAccount, error) {
+	return ae.loyaltyService.AwardPoints(programID, memberID, points, salePrice, earnedAt, saleRef, userID)
+}
+
+// RedeemLoyaltyPoints spends a member's points and releases the deferred revenue
+func (ae *AccountingEngine) RedeemLoyaltyPoints(programID, memberID string, points int64, redeemedAt time.Time, userID string) (*LoyaltyMemberAccount, error) {
+	return ae.loyaltyService.RedeemPoints(programID, memberID, points, redeemedAt, userID)
+}
+
+// ExpireLoyaltyPoints recognizes breakage on points expired by the date
+func (ae *AccountingEngine) ExpireLoyaltyPoints(asOfDate time.Time, userID string) ([]*Transaction, error) {
+	return ae.loyaltyService.ExpirePoints(asOfDate, userID)
+}
+
+// GenerateLoyaltyLiabilityReport rolls a program's points liability forward across a period
+func (ae *AccountingEngine) GenerateLoyaltyLiabilityReport(programID string, periodStart, periodEnd time.Time) (*LoyaltyLiabilityReport, error) {
+	return ae.loyaltyService.GenerateLiabilityReport(programID, periodStart, periodEnd)
+}
+
+// ----------------------------------------------------------------------------
+// Client Money Methods
+// ----------------------------------------------------------------------------
+
+// DesignateClientMoneyAccount designates an account as a client bank or client ledger account
+func (ae *AccountingEngine) DesignateClientMoneyAccount(accountID string, role ClientMoneyRole, currency Currency, userID string) (*ClientMoneyAccount, error) {
+	return ae.clientMoneyService.DesignateAccount(accountID, role, currency, userID)
+}
+
+// ReconcileClientMoney performs the daily client money reconciliation and alerts on a shortfall
+func (ae *AccountingEngine) ReconcileClientMoney(asOfDate time.Time, currency Currency, statementBalances map[string]int64, userID string) (*ClientMoneyReconciliation, error) {
+	return ae.clientMoneyService.Reconcile(asOfDate, currency, statementBalances, userID)
+}
+
+// ----------------------------------------------------------------------------
+// Balance Snapshot Methods
+// ----------------------------------------------------------------------------
+
+// RebuildBalanceSnapshots rebuilds the materialized account balances and the posting
+// index by replaying the posting events, for recovery or after restoring the
+// transactions bucket
+func (ae *AccountingEngine) RebuildBalanceSnapshots(userID string) error {
+	now := time.Now()
+	if _, err := ae.eventStore.CreateEvent(EventRebuildBalanceSnapshots, BalanceSnapshotsRebuiltEvent{RebuiltAt: now}, now, userID); err != nil {
+		return fmt.Errorf("failed to create balance snapshot rebuild event: %w", err)
+	}
+	if err := ae.storage.RebuildBalanceSnapshots(); err != nil {
+		return fmt.Errorf("failed to rebuild balance snapshots: %w", err)
+	}
+	return nil
+}
+
+// GetBalanceSnapshots returns an account's monthly balance snapshots, oldest first
+func (ae *AccountingEngine) GetBalanceSnapshots(accountID string) ([]*AccountBalanceSnapshot, error) {
+	return ae.storage.GetBalanceSnapshots(accountID)
+}
+
+// ----------------------------------------------------------------------------
+// PSP Settlement Methods
+// ----------------------------------------------------------------------------
+
+// RegisterPSPProvider registers a payment service provider and its settlement accounts
+func (ae *AccountingEngine) RegisterPSPProvider(provider *PSPProvider, userID string) error {
+	return ae.pspSettlementService.RegisterProvider(provider, userID)
+}
+
+// ReconcilePSPSettlement matches a PSP settlement batch to the ledger and posts the payout
+func (ae *AccountingEngine) ReconcilePSPSettlement(batch *PSPSettlementBatch, userID string) (*PSPSettlementBatch, error) {
+	return ae.pspSettlementService.ReconcileSettlement(batch, userID)
+}
+
+// GeneratePSPSettlementReport lists a provider's settlement batches with their unreconciled deltas
+func (ae *AccountingEngine) GeneratePSPSettlementReport(providerID string, periodStart, periodEnd time.Time) (*PSPSettlementReport, error) {
+	return ae.pspSettlementService.GenerateSettlementReport(providerID, periodStart, periodEnd)
+}
+
+// ----------------------------------------------------------------------------
+// Merchant Reserve Methods
+// ----------------------------------------------------------------------------
+
+// ConfigureMerchantReserve sets a merchant's rolling reserve rate and release schedule
+func (ae *AccountingEngine) ConfigureMerchantReserve(reserve *MerchantReserve, userID string) error {
+	return ae.merchantReserveService.ConfigureReserve(reserve, userID)
+}
+
+// RecordMerchantCapture withholds the merchant's rolling reserve from a capture
+func (ae *AccountingEngine) RecordMerchantCapture(merchantID, captureRef string, amount int64, capturedAt time.Time, userID string) (*ReserveHold, error) {
+	return ae.merchantReserveService.RecordCapture(merchantID, captureRef, amount, capturedAt, userID)
+}
+
+// ReleaseMerchantReserves releases all reserve tranches due by the date
+func (ae *AccountingEngine) ReleaseMerchantReserves(asOfDate time.Time, userID string) ([]*Transaction, error) {
+	return ae.merchantReserveService.ReleaseDue(asOfDate, userID)
+}
+
+// GenerateMerchantReserveReport reports reserve liability per merchant with its release profile
+func (ae *AccountingEngine) GenerateMerchantReserveReport(asOfDate time.Time) (*MerchantReserveReport, error) {
+	return ae.merchantReserveService.GenerateReserveReport(asOfDate)
+}
+
+// ----------------------------------------------------------------------------
+// Fee Schedule Methods
+// ----------------------------------------------------------------------------
+
+// SaveFeeSchedule creates or updates a tiered fee schedule
+func (ae *AccountingEngine) SaveFeeSchedule(schedule *FeeSchedule, userID string) error {
+	return ae.feeScheduleService.SaveSchedule(schedule, userID)
+}
+
+// ApplyFeeSchedule prices the schedule's transaction stream for the period and journals the fees
+func (ae *AccountingEngine) ApplyFeeSchedule(scheduleID string, periodStart, periodEnd time.Time, userID string) ([]*FeeCharge, error) {
+	return ae.feeScheduleService.ApplyFees(scheduleID, periodStart, periodEnd, userID)
+}
+
+// ReconcileFeeStatement matches a provider fee statement against the fees charged in the ledger
+func (ae *AccountingEngine) ReconcileFeeStatement(scheduleID, statementRef string, periodStart, periodEnd time.Time, lines []FeeStatementLine, userID string) (*FeeReconciliation, error) {
+	return ae.feeScheduleService.ReconcileStatement(scheduleID, statementRef, periodStart, periodEnd, lines, userID)
+}
+
+// ----------------------------------------------------------------------------
+// Regulatory Reporting Methods
+// ----------------------------------------------------------------------------
+
+// SaveRegulatoryReturn creates or updates the account mappings for a regulatory return
+func (ae *AccountingEngine) SaveRegulatoryReturn(ret *RegulatoryReturn, userID string) error {
+	return ae.regulatoryService.SaveReturn(ret, userID)
+}
+
+// GenerateRegulatoryReturn produces a regulatory return's dataset for a period
+func (ae *AccountingEngine) GenerateRegulatoryReturn(returnID, periodID, userID string) (*RegulatoryReturnDataset, error) {
+	return ae.regulatoryService.GenerateReturn(returnID, periodID, userID)
+}
+
+// ExportRegulatoryReturn renders a generated return dataset as JSON or CSV for filing
+func (ae *AccountingEngine) ExportRegulatoryReturn(datasetID, format string) ([]byte, error) {
+	return ae.regulatoryService.ExportDataset(datasetID, format)
+}
+
+// ----------------------------------------------------------------------------
+// Year-End Close Methods
+// ----------------------------------------------------------------------------
+
+// CloseFiscalYear closes income and expense accounts into retained earnings and locks the year
+func (ae *AccountingEngine) CloseFiscalYear(fy *FiscalYearClose, userID string) (*FiscalYearClose, error) {
+	return ae.yearEndService.CloseFiscalYear(fy, userID)
+}
+
+// ReopenFiscalYear unlocks a closed fiscal year and reverses its closing entries
+func (ae *AccountingEngine) ReopenFiscalYear(fiscalYearID, reason, userID string) (*FiscalYearClose, error) {
+	return ae.yearEndService.ReopenFiscalYear(fiscalYearID, reason, userID)
+}
+
+// GetFiscalYearClose returns a fiscal year close and its closing report
+func (ae *AccountingEngine) GetFiscalYearClose(fiscalYearID string) (*FiscalYearClose, error) {
+	return ae.storage.GetFiscalYearClose(fiscalYearID)
+}
+
+// ----------------------------------------------------------------------------
+// Chart of Accounts Methods
+// ----------------------------------------------------------------------------
+
+// MoveAccount moves an account under a new parent, or to the top level when parentID is empty
+func (ae *AccountingEngine) MoveAccount(accountID, parentID, userID string) (*Account, error) {
+	return ae.chartOfAccountsService.MoveAccount(accountID, parentID, userID)
+}
+
+// DeactivateAccount closes a zero-balance account to further postings
+func (ae *AccountingEngine) DeactivateAccount(accountID, userID string) (*Account, error) {
+	return ae.chartOfAccountsService.DeactivateAccount(accountID, userID)
+}
+
+// ActivateAccount reopens a deactivated account for postings
+func (ae *AccountingEngine) ActivateAccount(accountID, userID string) (*Account, error) {
+	return ae.chartOfAccountsService.ActivateAccount(accountID, userID)
+}
+
+// GetAccountByCode finds the account with a code
+func (ae *AccountingEngine) GetAccountByCode(code string) (*Account, error) {
+	return ae.chartOfAccountsService.GetAccountByCode(code)
+}
+
+// FindAccountsByName lists the accounts whose names contain the text, ignoring case
+func (ae *AccountingEngine) FindAccountsByName(text string) ([]*Account, error) {
+	return ae.chartOfAccountsService.FindAccountsByName(text)
+}
+
+// LookupAccount resolves an account by ID, code or name
+func (ae *AccountingEngine) LookupAccount(ref string) (*Account, error) {
+	return ae.chartOfAccountsService.LookupAccount(ref)
+}
+
+// CheckAccountCodes lists the accounts whose codes do not fit the numbering scheme
+func (ae *AccountingEngine) CheckAccountCodes() ([]AccountCodeViolation, error) {
+	return ae.chartOfAccountsService.CheckAccountCodes()
+}
+
+// GetAccountHierarchy returns the chart of accounts as a tree
+func (ae *AccountingEngine) GetAccountHierarchy() ([]*AccountNode, error) {
+	return ae.queryAPI.GetAccountHierarchy()
+}
+
+// ----------------------------------------------------------------------------
+// Master Data Methods
+// ----------------------------------------------------------------------------
+
+// CreateCustomer adds a customer to the customer master
+func (ae *AccountingEngine) CreateCustomer(customer *Customer, userID string) error {
+	return ae.masterDataService.CreateCustomer(customer, userID)
+}
+
+// GetCustomer retrieves a customer by ID
+func (ae *AccountingEngine) GetCustomer(customerID string) (*Customer, error) {
+	return ae.storage.GetCustomer(customerID)
+}
+
+// FindDuplicateParties lists likely duplicate customers or vendors scoring at least minScore
+func (ae *AccountingEngine) FindDuplicateParties(partyType PartyType, minScore float64) ([]*DuplicateMatch, error) {
+	return ae.masterDataService.FindDuplicates(partyType, minScore)
+}
+
+// MergeParties merges a duplicate customer or vendor into the survivor and re-points its history
+func (ae *AccountingEngine) MergeParties(partyType PartyType, survivorID, mergedID, userID string) (*PartyMerge, error) {
+	return ae.masterDataService.MergeParties(partyType, survivorID, mergedID, userID)
+}
+
+// ----------------------------------------------------------------------------
+// Reclassification Methods
+// ----------------------------------------------------------------------------
+
+// PreviewReclassification lists the entries a bulk reclassification would move without posting
+func (ae *AccountingEngine) PreviewReclassification(batch *ReclassBatch, userID string) (*ReclassBatch, error) {
+	return ae.reclassService.PreviewReclass(batch, userID)
+}
+
+// ExecuteReclassification posts a previewed reclassification as balanced journals
+func (ae *AccountingEngine) ExecuteReclassification(batchID, userID string) (*ReclassBatch, error) {
+	return ae.reclassService.ExecuteReclass(batchID, userID)
+}
+
+// RollbackReclassification reverses a posted reclassification with contra journals
+func (ae *AccountingEngine) RollbackReclassification(batchID, userID string) (*ReclassBatch, error) {
+	return ae.reclassService.RollbackReclass(batchID, userID)
+}
+
+// ----------------------------------------------------------------------------
+// Dimension Registry Methods
+// ----------------------------------------------------------------------------
+
+// DefineDimension registers or replaces a managed dimension
+func (ae *AccountingEngine) DefineDimension(def *DimensionDefinition, userID string) error {
+	return ae.dimensionService.DefineDimension(def, userID)
+}
+
+// AddDimensionValue adds an allowed value to a managed dimension
+func (ae *AccountingEngine) AddDimensionValue(key DimensionKey, value, name, userID string) (*DimensionDefinition, error) {
+	return ae.dimensionService.AddDimensionValue(key, value, name, userID)
+}
+
+// ListDimensionValues lists a managed dimension's values
+func (ae *AccountingEngine) ListDimensionValues(key DimensionKey, includeInactive bool) ([]DimensionValue, error) {
+	return ae.dimensionService.ListDimensionValues(key, includeInactive)
+}
+
+// RetireDimensionValue stops a dimension value being used on new transactions
+func (ae *AccountingEngine) RetireDimensionValue(key DimensionKey, value, userID string) (*DimensionDefinition, error) {
+	return ae.dimensionService.RetireDimensionValue(key, value, userID)
+}
+
+// MergeDimensionValues folds one dimension value into another
+func (ae *AccountingEngine) MergeDimensionValues(key DimensionKey, fromValue, intoValue, userID string) (*DimensionDefinition, error) {
+	return ae.dimensionService.MergeDimensionValues(key, fromValue, intoValue, userID)
+}
+
+// ----------------------------------------------------------------------------
+// Access Control Methods
+// ----------------------------------------------------------------------------
+
+// CreateRole defines a role granting a set of permissions
+func (ae *AccountingEngine) CreateRole(role *Role, userID string) error {
+	return ae.accessControlService.CreateRole(role, userID)
+}
+
+// CreateUser registers a user; the first user turns authorization on
+func (ae *AccountingEngine) CreateUser(user *User, userID string) error {
+	return ae.accessControlService.CreateUser(user, userID)
+}
+
+// AssignRole grants a role to a user
+func (ae *AccountingEngine) AssignRole(targetUserID, roleID, userID string) error {
+	return ae.accessControlService.AssignRole(targetUserID, roleID, userID)
+}
+
+// RevokeRole takes a role away from a user
+func (ae *AccountingEngine) RevokeRole(targetUserID, roleID, userID string) error {
+	return ae.accessControlService.RevokeRole(targetUserID, roleID, userID)
+}
+
+// GetAccessDenials returns the refused attempts recorded between from and to
+func (ae *AccountingEngine) GetAccessDenials(from, to time.Time) ([]*AccessDenial, error) {
+	return ae.accessControlService.GetAccessDenials(from, to)
+}
+
+// UpdateAMLAlertStatus moves an AML alert through its workflow
+func (ae *AccountingEngine) UpdateAMLAlertStatus(alertID, status, userID string) error {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageAMLCases, "update AML alert "+alertID); err != nil {
+		return err
+	}
+	return ae.amlService.UpdateAlertStatus(alertID, status, userID)
+}
+
+// CreateAMLInvestigation opens an investigation into an AML alert
+func (ae *AccountingEngine) CreateAMLInvestigation(alertID, userID string) (*AMLInvestigation, error) {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageAMLCases, "investigate AML alert "+alertID); err != nil {
+		return nil, err
+	}
+	return ae.amlService.CreateInvestigation(alertID, userID)
+}
+
+// AddAMLInvestigationNote adds a note to an AML investigation
+func (ae *AccountingEngine) AddAMLInvestigationNote(alertID, content, userID string) error {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageAMLCases, "add a note to AML alert "+alertID); err != nil {
+		return err
+	}
+	return ae.amlService.AddInvestigationNote(alertID, content, userID)
+}
+
+// EndAMLCustomerRelationship records the end of an AML customer's business
+// relationship, starting the retention period for their records
+func (ae *AccountingEngine) EndAMLCustomerRelationship(customerID string, endedAt time.Time, userID string) error {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageAMLCases, "end the relationship with AML customer "+customerID); err != nil {
+		return err
+	}
+	return ae.amlService.EndRelationship(customerID, endedAt)
+}
+
+// PseudonymizeAMLCustomer replaces a customer's personal data with a pseudonym once
+// their records are past retention, keeping alerts and the accounting trail
+func (ae *AccountingEngine) PseudonymizeAMLCustomer(customerID, userID string) (*PIIErasureCertificate, error) {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageAMLCases, "pseudonymize AML customer "+customerID); err != nil {
+		return nil, err
+	}
+	return ae.piiErasureService.Pseudonymize(customerID, userID)
+}
+
+// EraseAMLCustomer erases a customer's personal data once their records are past
+// retention, keeping alerts and the accounting trail
+func (ae *AccountingEngine) EraseAMLCustomer(customerID, userID string) (*PIIErasureCertificate, error) {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageAMLCases, "erase AML customer "+customerID); err != nil {
+		return nil, err
+	}
+	return ae.piiErasureService.Erase(customerID, userID)
+}
+
+// GetAMLCustomersDueForErasure lists the customers past retention whose personal
+// data is still held
+func (ae *AccountingEngine) GetAMLCustomersDueForErasure(asOf time.Time) ([]*AMLCustomer, error) {
+	return ae.piiErasureService.GetDueForErasure(asOf)
+}
+
+// GetErasureCertificates returns the PII erasure certificates issued between from and to
+func (ae *AccountingEngine) GetErasureCertificates(from, to time.Time) ([]*PIIErasureCertificate, error) {
+	return ae.piiErasureService.GetCertificates(from, to)
+}
+
+// SimulateAMLThresholds reports how alert volumes and filings over a period would
+// change under hypothetical thresholds. Nothing is saved.
+func (ae *AccountingEngine) SimulateAMLThresholds(scenario *ThresholdScenario, fromDate, toDate time.Time) (*ThresholdSimulation, error) {
+	return ae.amlService.SimulateThresholds(scenario, fromDate, toDate)
+}
+
+// ----------------------------------------------------------------------------
+// Document Methods
+// ----------------------------------------------------------------------------
+
+// StoreDocument saves a supporting document
+func (ae *AccountingEngine) StoreDocument(name, contentType string, content []byte, description, userID string) (*Document, error) {
+	return ae.documentService.StoreDocument(name, contentType, content, description, userID)
+}
+
+// LinkDocument attaches a stored document to a transaction, AML investigation,
+// budget request or tax return
+func (ae *AccountingEngine) LinkDocument(documentID string, link DocumentLink, userID string) error {
+	return ae.documentService.LinkDocument(documentID, link, userID)
+}
+
+// UnlinkDocument detaches a document from a record
+func (ae *AccountingEngine) UnlinkDocument(documentID string, targetType DocumentTargetType, targetID, userID string) error {
+	return ae.documentService.UnlinkDocument(documentID, targetType, targetID, userID)
+}
+
+// GetDocumentContent retrieves a document and its checksum-verified content
+func (ae *AccountingEngine) GetDocumentContent(documentID string) (*Document, []byte, error) {
+	return ae.documentService.GetDocumentContent(documentID)
+}
+
+// GetDocumentsFor returns the documents linked to a record
+func (ae *AccountingEngine) GetDocumentsFor(targetType DocumentTargetType, targetID string) ([]*Document, error) {
+	return ae.documentService.GetDocumentsFor(targetType, targetID)
+}
+
+// ----------------------------------------------------------------------------
+// Scripting Methods
+// ----------------------------------------------------------------------------
+
+// RegisterScript binds an administrator's script to a hook
+func (ae *AccountingEngine) RegisterScript(script *Script, userID string) error {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageScripts, "register script "+script.Name); err != nil {
+		return err
+	}
+	return ae.scriptService.RegisterScript(script, userID)
+}
+
+// UpdateScript replaces a script's source
+func (ae *AccountingEngine) UpdateScript(scriptID, source, userID string) error {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageScripts, "update script "+scriptID); err != nil {
+		return err
+	}
+	return ae.scriptService.UpdateScript(scriptID, source, userID)
+}
+
+// SetScriptActive switches a script on or off
+func (ae *AccountingEngine) SetScriptActive(scriptID string, active bool, userID string) error {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageScripts, "switch script "+scriptID); err != nil {
+		return err
+	}
+	return ae.scriptService.SetScriptActive(scriptID, active, userID)
+}
+
+// ComputeKPI runs the named KPI script as of a date
+func (ae *AccountingEngine) ComputeKPI(name string, asOf time.Time) (float64, error) {
+	return ae.scriptService.ComputeKPI(name, asOf)
+}
+
+// ----------------------------------------------------------------------------
+// Statement Template Methods
+// ----------------------------------------------------------------------------
+
+// SaveStatementTemplate creates or replaces a financial statement layout
+func (ae *AccountingEngine) SaveStatementTemplate(template *StatementTemplate, userID string) error {
+	return ae.statementTemplateService.SaveTemplate(template, userID)
+}
+
+// GenerateTemplateStatement renders a statement from a template, with an optional
+// comparative column
+func (ae *AccountingEngine) GenerateTemplateStatement(templateID string, fromDate, toDate time.Time, currency string, comparison StatementComparison) (*FinancialStatement, error) {
+	return ae.statementTemplateService.GenerateStatement(templateID, fromDate, toDate, currency, comparison)
+}
+
+// ----------------------------------------------------------------------------
+// AML Narrative Methods
+// ----------------------------------------------------------------------------
+
+// SaveNarrativeTemplate creates or replaces an alert or SAR narrative template
+func (ae *AccountingEngine) SaveNarrativeTemplate(tmpl *NarrativeTemplate, userID string) error {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageAMLCases, "save narrative template "+tmpl.Name); err != nil {
+		return err
+	}
+	return ae.narrativeService.SaveTemplate(tmpl, userID)
+}
+
+// GenerateNarrative drafts an alert or SAR narrative from the alert's evidence,
+// transactions and customer profile
+func (ae *AccountingEngine) GenerateNarrative(alertID string, kind NarrativeKind, templateID, userID string) (*Narrative, error) {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageAMLCases, "draft a narrative for AML alert "+alertID); err != nil {
+		return nil, err
+	}
+	return ae.narrativeService.GenerateNarrative(alertID, kind, templateID, userID)
+}
+
+// EditNarrativeSection replaces the text of a narrative section
+func (ae *AccountingEngine) EditNarrativeSection(narrativeID, key, text, userID string) (*Narrative, error) {
+	if err := ae.accessControlService.Authorize(userID, PermissionManageAMLCases, "edit narrative "+narrativeID); err != nil {
+		return nil, err
+	}
+	return ae.narrativeService.EditNarrativeSection(narrativeID, key, text, userID)
+}
+
+// GetNarratives returns the narratives drafted for an alert
+func (ae *AccountingEngine) GetNarratives(alertID string) ([]*Narrative, error) {
+	return ae.narrativeService.GetNarratives(alertID)
+}
+
+// ----------------------------------------------------------------------------
+// Ledger Methods
+// ----------------------------------------------------------------------------
+
+// AssignAccountLedger moves an account into a ledger, or back to the general ledger
+// when ledgerID is empty
+func (ae *AccountingEngine) AssignAccountLedger(accountID, ledgerID, userID string) (*Account, error) {
+	return ae.ledgerService.AssignAccount(accountID, ledgerID, userID)
+}
+
+// SaveLedgerPostingRule allows transactions recorded in one ledger to post to another
+func (ae *AccountingEngine) SaveLedgerPostingRule(rule *LedgerPostingRule, userID string) error {
+	return ae.ledgerService.SavePostingRule(rule, userID)
+}
+
+// GenerateLedgerTrialBalance generates the trial balance of one ledger
+func (ae *AccountingEngine) GenerateLedgerTrialBalance(ledgerID string, asOfDate time.Time, currency string) (*LedgerTrialBalance, error) {
+	return ae.ledgerService.GenerateLedgerTrialBalance(ledgerID, asOfDate, currency)
+}
+
+// GenerateCombinedTrialBalance generates every ledger's trial balance with totals across them
+func (ae *AccountingEngine) GenerateCombinedTrialBalance(asOfDate time.Time, currency string) (*CombinedTrialBalance, error) {
+	return ae.ledgerService.GenerateCombinedTrialBalance(asOfDate, currency)
+}
+
+// ----------------------------------------------------------------------------
+// Operating Segment Methods
+// ----------------------------------------------------------------------------
+
+// DefineSegment creates or updates an operating segment mapped from dimension values
+// or group companies
+func (ae *AccountingEngine) DefineSegment(segment *OperatingSegment, userID string) error {
+	return ae.segmentService.DefineSegment(segment, userID)
+}
+
+// SaveSegmentAllocationRule saves a rule spreading shared balances across segments
+func (ae *AccountingEngine) SaveSegmentAllocationRule(rule *SegmentAllocationRule, userID string) error {
+	return ae.segmentService.SaveAllocationRule(rule, userID)
+}
+
+// GetSegments returns the operating segments
+func (ae *AccountingEngine) GetSegments() ([]*OperatingSegment, error) {
+	return ae.segmentService.GetSegments()
+}
+
+// GenerateSegmentDisclosure reports each operating segment's results, assets and
+// liabilities for a period, reconciled to the consolidated totals
+func (ae *AccountingEngine) GenerateSegmentDisclosure(fromDate, toDate time.Time, currency string) (*SegmentDisclosure, error) {
+	return ae.segmentService.GenerateSegmentDisclosure(fromDate, toDate, currency)
+}
+
+// ----------------------------------------------------------------------------
+// Scheduled Report Methods
+// ----------------------------------------------------------------------------
+
+// SaveReportSchedule creates or updates a scheduled report
+func (ae *AccountingEngine) SaveReportSchedule(schedule *ReportSchedule, userID string) error {
+	return ae.reportScheduler.SaveSchedule(schedule, userID)
+}
+
+// SetReportScheduleActive pauses or resumes a scheduled report
+func (ae *AccountingEngine) SetReportScheduleActive(scheduleID string, active bool, userID string) (*ReportSchedule, error) {
+	return ae.reportScheduler.SetActive(scheduleID, active, userID)
+}
+
+// RegisterReportCallback makes a delivery callback available to scheduled reports by name
+func (ae *AccountingEngine) RegisterReportCallback(name string, callback ReportCallback) {
+	ae.reportScheduler.RegisterCallback(name, callback)
+}
+
+// RunDueReports generates the scheduled reports due at or before now
+func (ae *AccountingEngine) RunDueReports(now time.Time, userID string) ([]*ReportRun, error) {
+	return ae.reportScheduler.RunDue(now, userID)
+}
+
+// RunScheduledReport generates a scheduled report straight away
+func (ae *AccountingEngine) RunScheduledReport(scheduleID, userID string) (*ReportRun, error) {
+	return ae.reportScheduler.RunNow(scheduleID, userID)
+}
+
+// GetReportRuns returns the run history of a scheduled report, most recent first
+func (ae *AccountingEngine) GetReportRuns(scheduleID string) ([]*ReportRun, error) {
+	return ae.reportScheduler.GetRuns(scheduleID)
+}
+
+// StartReportScheduler generates due reports in the background, checking every
+// interval, until StopReportScheduler or Close is called
+func (ae *AccountingEngine) StartReportScheduler(interval time.Duration) error {
+	return ae.reportScheduler.Start(interval)
+}
+
+// StopReportScheduler stops generating reports in the background
+func (ae *AccountingEngine) StopReportScheduler() {
+	ae.reportScheduler.Stop()
+}
+
+// ----------------------------------------------------------------------------
+// Going Concern Methods
+// ----------------------------------------------------------------------------
+
+// SaveDebtCovenant creates or updates a debt covenant tested in going-concern assessments
+func (ae *AccountingEngine) SaveDebtCovenant(covenant *DebtCovenant, userID string) error {
+	return ae.goingConcern.SaveCovenant(covenant, userID)
+}
+
+// AssessGoingConcern computes liquidity stress signals and the going-concern indicator
+func (ae *AccountingEngine) AssessGoingConcern(asOf time.Time, currency string, options *GoingConcernOptions) (*GoingConcernAssessment, error) {
+	return ae.goingConcern.AssessGoingConcern(asOf, currency, options)
+}
+
+// ----------------------------------------------------------------------------
+// Notification Methods
+// ----------------------------------------------------------------------------
+
+// Subscribe registers a webhook or callback for notifications on some topics
+func (ae *AccountingEngine) Subscribe(subscription *NotificationSubscription, userID string) error {
+	return ae.notifications.Subscribe(subscription, userID)
+}
+
+// SetSubscriptionActive pauses or resumes a notification subscription
+func (ae *AccountingEngine) SetSubscriptionActive(subscriptionID string, active bool, userID string) error {
+	return ae.notifications.SetActive(subscriptionID, active, userID)
+}
+
+// RegisterNotificationCallback makes a Go callback available to subscriptions by name
+func (ae *AccountingEngine) RegisterNotificationCallback(name string, callback NotificationCallback) {
+	ae.notifications.RegisterCallback(name, callback)
+}
+
+// GetNotificationDeliveries returns the delivery log of a subscription
+func (ae *AccountingEngine) GetNotificationDeliveries(subscriptionID string) ([]*NotificationDelivery, error) {
+	return ae.notifications.GetDeliveries(subscriptionID)
+}
+
+// ----------------------------------------------------------------------------
+// Metrics Methods
+// ----------------------------------------------------------------------------
+
+// MetricsHandler serves the engine's metrics for Prometheus to scrape
+func (ae *AccountingEngine) MetricsHandler() http.Handler {
+	return ae.metrics.Registry().Handler()
+}
+
+// ----------------------------------------------------------------------------
+// Revenue Analytics Methods
+// ----------------------------------------------------------------------------
+
+// GenerateRevenueConcentration ranks customers by revenue and reports the top customers' share
+func (ae *AccountingEngine) GenerateRevenueConcentration(from, to time.Time, currency string, options *RevenueAnalyticsOptions) (*RevenueConcentration, error) {
+	return ae.revenueAnalytics.GenerateRevenueConcentration(from, to, currency, options)
+}
+
+// GenerateRecurringRevenue computes MRR and ARR movements, churn and cohort retention
+// from the recognition schedules
+func (ae *AccountingEngine) GenerateRecurringRevenue(fromMonth, toMonth time.Time, currency string, options *RevenueAnalyticsOptions) (*RecurringRevenueReport, error) {
+	return ae.revenueAnalytics.GenerateRecurringRevenue(fromMonth, toMonth, currency, options)
+}
+
+// ----------------------------------------------------------------------------
+// Expense Anomaly Methods
+// ----------------------------------------------------------------------------
+
+// DetectExpenseAnomalies flags spend spikes, large spend with new vendors and
+// duplicate-amount sequences between two dates
+func (ae *AccountingEngine) DetectExpenseAnomalies(from, to time.Time, currency string, options *ExpenseAnomalyOptions) (*ExpenseAnomalyReport, error) {
+	return ae.expenseAnomalies.DetectExpenseAnomalies(from, to, currency, options)
+}
+
+// ----------------------------------------------------------------------------
+// Consistency Check Methods
+// ----------------------------------------------------------------------------
+
+// CheckConsistency verifies the invariants between budgets, AML alerts, compliance
+// violations and the ledger. With repair, budget amounts that drifted from their
+// tracking records are recomputed.
+func (ae *AccountingEngine) CheckConsistency(repair bool, userID string) (*ConsistencyRun, error) {
+	return ae.consistency.Check(repair, userID)
+}
+
+// GetConsistencyRuns returns past consistency runs, most recent first
+func (ae *AccountingEngine) GetConsistencyRuns() ([]*ConsistencyRun, error) {
+	return ae.consistency.GetRuns()
+}
+
+// StartConsistencyChecks runs the consistency checks in the background on a cron
+// schedule, such as DefaultConsistencySchedule, until StopConsistencyChecks or Close
+// is called
+func (ae *AccountingEngine) StartConsistencyChecks(cron string, repair bool) error {
+	return ae.consistency.Start(cron, repair)
+}
+
+// StopConsistencyChecks stops the background consistency checks
+func (ae *AccountingEngine) StopConsistencyChecks() {
+	ae.consistency.Stop()
+}
+
+// ----------------------------------------------------------------------------
+// Workflow Methods
+// ----------------------------------------------------------------------------
+
+// RegisterWorkflow adds a workflow alongside the built-in budget request, journal
+// approval, AML alert and period close workflows, which it may not replace
+func (ae *AccountingEngine) RegisterWorkflow(definition *WorkflowDefinition) error {
+	if slices.Contains(builtinWorkflows, definition.Name) {
+		return fmt.Errorf("workflow %s is built in", definition.Name)
+	}
+	return ae.workflows.Register(definition)
+}
+
+// builtinWorkflows are driven through their modules' methods, which check permissions
+var builtinWorkflows = []string{WorkflowBudgetRequest, WorkflowJournalApproval, WorkflowAMLAlert, WorkflowPeriodClose}
+
+// FireWorkflow takes an action on a subject of a workflow added with RegisterWorkflow
+func (ae *AccountingEngine) FireWorkflow(workflow, subjectID, action, comment, userID string) (*WorkflowInstance, error) {
+	if slices.Contains(builtinWorkflows, workflow) {
+		return nil, fmt.Errorf("workflow %s is driven through its module's methods", workflow)
+	}
+	return ae.workflows.Fire(workflow, subjectID, &WorkflowRequest{Action: action, Actor: userID, Comment: comment})
+}
+
+// GetWorkflowInstance returns a subject's state, assignees and history in a workflow
+func (ae *AccountingEngine) GetWorkflowInstance(workflow, subjectID string) (*WorkflowInstance, error) {
+	return ae.workflows.GetInstance(workflow, subjectID)
+}
+
+// GetAssignedWork returns the workflow instances waiting on a user, oldest first
+func (ae *AccountingEngine) GetAssignedWork(userID string) ([]*WorkflowInstance, error) {
+	return ae.workflows.GetAssigned(userID)
+}
+
+// FireWorkflowTimers takes the timed action, such as escalating a stale AML alert, on
+// every subject left waiting past its state's timer
+func (ae *AccountingEngine) FireWorkflowTimers(asOf time.Time) ([]*WorkflowInstance, error) {
+	return ae.workflows.FireTimers(asOf)
+}
+
+// ----------------------------------------------------------------------------
+// Data Export Methods
+// ----------------------------------------------------------------------------
+
+// ExportData writes customers, vendors, AML customers or AML alerts as CSV or JSON
+// lines, masking sensitive fields for users without VIEW_PII
+func (ae *AccountingEngine) ExportData(w io.Writer, dataset ExportDataset, format ExportFormat, userID string) (*DataExportResult, error) {
+	return ae.dataExport.Export(w, dataset, format, userID)
+}
+
+// SetMaskingPolicy sets how sensitive fields are masked in exports, per field and format
+func (ae *AccountingEngine) SetMaskingPolicy(policy *MaskingPolicy) error {
+	return ae.dataExport.SetMaskingPolicy(policy)
+}
+
+// GetMaskingPolicy returns the export masking policy in force
+func (ae *AccountingEngine) GetMaskingPolicy() *MaskingPolicy {
+	return ae.dataExport.GetMaskingPolicy()
+}
+
+// ----------------------------------------------------------------------------
+// Revenue Contract Methods
+// ----------------------------------------------------------------------------
+
+// CreateRevenueContract bills a customer contract and schedules recognition of its performance obligations
+func (ae *AccountingEngine) CreateRevenueContract(request RevenueContractRequest, userID string) (*RevenueContract, error) {
+	return ae.contractService.CreateContract(request, userID)
+}
+
+// SatisfyPerformanceObligation schedules a point-in-time obligation's revenue once control transfers
+func (ae *AccountingEngine) SatisfyPerformanceObligation(contractID, obligationID string, satisfiedAt time.Time, userID string) (*RevenueContract, error) {
+	return ae.contractService.SatisfyObligation(contractID, obligationID, satisfiedAt, userID)
+}
+
+// GetRevenueContract retrieves a revenue contract
+func (ae *AccountingEngine) GetRevenueContract(contractID string) (*RevenueContract, error) {
+	return ae.storage.GetRevenueContract(contractID)
+}
+
+// DeferredRevenueWaterfall reports when contract revenue deferred at a date will be recognized
+func (ae *AccountingEngine) DeferredRevenueWaterfall(asOf time.Time, months int) (*DeferredRevenueWaterfall, error) {
+	return ae.contractService.DeferredRevenueWaterfall(asOf, months)
+}
+
+// ----------------------------------------------------------------------------
+// Tax Line Methods
+// ----------------------------------------------------------------------------
+
+// SaveTaxLineRule adds or replaces a rule generating tax entries for taxable entries
+func (ae *AccountingEngine) SaveTaxLineRule(rule *TaxLineRule, userID string) error {
+	return ae.taxLineService.SaveRule(rule, userID)
+}
+
+// GetTaxLineRules lists the tax line rules
+func (ae *AccountingEngine) GetTaxLineRules() ([]*TaxLineRule, error) {
+	return ae.storage.GetAllTaxLineRules()
+}
+
+// GetTransactionTaxLines returns the tax calculations behind a transaction's generated tax entries
+func (ae *AccountingEngine) GetTransactionTaxLines(txnID string) (*TransactionTaxLines, error) {
+	return ae.storage.GetTransactionTaxLines(txnID)
+}
+
+// ----------------------------------------------------------------------------
+// VAT Return Methods
+// ----------------------------------------------------------------------------
+
+// SaveVATReturnMapping maps accounts and tax codes to the boxes of a VAT return format
+func (ae *AccountingEngine) SaveVATReturnMapping(mapping *VATReturnMapping, userID string) error {
+	return ae.vatReturnService.SaveMapping(mapping, userID)
+}
+
+// PrepareVATReturn aggregates a period's posted VAT into a draft VAT return
+func (ae *AccountingEngine) PrepareVATReturn(req VATReturnRequest, userID string) (*TaxReturn, error) {
+	return ae.vatReturnService.PrepareReturn(req, userID)
+}
+
+// ExportVATReturn renders a prepared VAT return as JSON, CSV or an HMRC MTD submission
+func (ae *AccountingEngine) ExportVATReturn(returnID, format string) ([]byte, error) {
+	return ae.vatReturnService.ExportReturn(returnID, format)
+}
+
+// ----------------------------------------------------------------------------
+// Form 1099 Methods
+// ----------------------------------------------------------------------------
+
+// SetVendorTaxProfile sets a vendor's TIN details, 1099 reporting and withholding rate
+func (ae *AccountingEngine) SetVendorTaxProfile(vendorID string, profile *VendorTaxProfile, userID string) (*Vendor, error) {
+	return ae.payablesService.SetVendorTaxProfile(vendorID, profile, userID)
+}
+
+// Generate1099Summary totals a year's reportable vendor payments per vendor and form
+func (ae *AccountingEngine) Generate1099Summary(year int) (*Form1099Summary, error) {
+	return ae.form1099Service.Summary(year)
+}
+
+// Export1099s renders a year's 1099s as CSV, or as an IRS FIRE file for the payer
+func (ae *AccountingEngine) Export1099s(year int, format string, payer *Form1099Payer) ([]byte, error) {
+	return ae.form1099Service.Export(year, format, payer)
+}
+
+// ----------------------------------------------------------------------------
+// SOX Control Methods
+// ----------------------------------------------------------------------------
+
+// SaveSOXControl adds a control to the SOX controls catalog or updates it
+func (ae *AccountingEngine) SaveSOXControl(control *SOXControl, userID string) error {
+	return ae.soxControlService.SaveControl(control, userID)
+}
+
+// SetSOXControlActive takes a control out of scheduled testing or puts it back
+func (ae *AccountingEngine) SetSOXControlActive(controlID string, active bool, userID string) (*SOXControl, error) {
+	return ae.soxControlService.SetActive(controlID, active, userID)
+}
+
+// GetSOXControls returns the SOX controls catalog
+func (ae *AccountingEngine) GetSOXControls() ([]*SOXControl, error) {
+	return ae.soxControlService.GetControls()
+}
+
+// TestSOXControl tests an automated control over a period on demand
+func (ae *AccountingEngine) TestSOXControl(controlID string, periodStart, periodEnd time.Time, userID string) (*ControlTestResult, error) {
+	return ae.soxControlService.TestControl(controlID, periodStart, periodEnd, userID)
+}
+
+// RunDueSOXControls tests the automated controls whose period has ended
+func (ae *AccountingEngine) RunDueSOXControls(now time.Time, userID string) ([]*ControlTestResult, error) {
+	return ae.soxControlService.RunDue(now, userID)
+}
+
+// RecordManualControlTest records the owner's test of a manual control
+func (ae *AccountingEngine) RecordManualControlTest(result *ControlTestResult, userID string) error {
+	return ae.soxControlService.RecordManualResult(result, userID)
+}
+
+// GetControlTestResults returns a control's test history, most recent first
+func (ae *AccountingEngine) GetControlTestResults(controlID string) ([]*ControlTestResult, error) {
+	return ae.soxControlService.GetResults(controlID)
+}
+
+// VerifyControlTestResult reports whether a test result's evidence is unchanged
+func (ae *AccountingEngine) VerifyControlTestResult(resultID string) (bool, error) {
+	return ae.soxControlService.VerifyResult(resultID)
+}
+
+// StartSOXControlTesting tests due controls in the background every interval until
+// StopSOXControlTesting or Close is called
+func (ae *AccountingEngine) StartSOXControlTesting(interval time.Duration) error {
+	return ae.soxControlService.Start(interval)
+}
+
+// StopSOXControlTesting stops background control testing
+func (ae *AccountingEngine) StopSOXControlTesting() {
+	ae.soxControlService.Stop()
+}
+
+// ----------------------------------------------------------------------------
+// Compliance Violation Methods
+// ----------------------------------------------------------------------------
+
+// RecordComplianceViolation records a violation for remediation
+func (ae *AccountingEngine) RecordComplianceViolation(violation *ComplianceViolation, userID string) error {
+	return ae.complianceService.RecordViolation(violation, userID)
+}
+
+// CheckTransactionCompliance checks a transaction against the compliance rules and
+// records the violations found
+func (ae *AccountingEngine) CheckTransactionCompliance(txnID, userID string) ([]*ComplianceViolation, error) {
+	txn, err := ae.storage.GetTransaction(txnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	return ae.complianceService.RecordTransactionViolations(txn, userID)
+}
+
+// AcknowledgeViolation records that a user has taken on a compliance violation
+func (ae *AccountingEngine) AcknowledgeViolation(violationID, comment, userID string) (*ComplianceViolation, error) {
+	return ae.complianceService.AcknowledgeViolation(violationID, comment, userID)
+}
+
+// AssignViolation hands a compliance violation to a user to remediate
+func (ae *AccountingEngine) AssignViolation(violationID, assignee, comment, userID string) (*ComplianceViolation, error) {
+	return ae.complianceService.AssignViolation(violationID, assignee, comment, userID)
+}
+
+// ResolveViolation marks a compliance violation remediated
+func (ae *AccountingEngine) ResolveViolation(violationID, notes, userID string) (*ComplianceViolation, error) {
+	return ae.complianceService.ResolveViolation(violationID, notes, userID)
+}
+
+// RequestViolationWaiver asks for a compliance violation to be accepted as it is
+func (ae *AccountingEngine) RequestViolationWaiver(violationID, reason, userID string) (*ComplianceViolation, error) {
+	return ae.complianceService.RequestViolationWaiver(violationID, reason, userID)
+}
+
+// ApproveViolationWaiver waives a compliance violation
+func (ae *AccountingEngine) ApproveViolationWaiver(violationID, comment, userID string) (*ComplianceViolation, error) {
+	return ae.complianceService.ApproveViolationWaiver(violationID, comment, userID)
+}
+
+// RejectViolationWaiver turns down a waiver request
+func (ae *AccountingEngine) RejectViolationWaiver(violationID, comment, userID string) (*ComplianceViolation, error) {
+	return ae.complianceService.RejectViolationWaiver(violationID, comment, userID)
+}
+
+// ReopenViolation reopens a resolved or waived compliance violation
+func (ae *AccountingEngine) ReopenViolation(violationID, reason, userID string) (*ComplianceViolation, error) {
+	return ae.complianceService.ReopenViolation(violationID, reason, userID)
+}
+
+// CommentOnViolation adds a comment to a compliance violation
+func (ae *AccountingEngine) CommentOnViolation(violationID, text, userID string) (*ComplianceViolation, error) {
+	return ae.complianceService.CommentOnViolation(violationID, text, userID)
+}
+
+// SetViolationWaiverApprovers designates who may approve violation waivers
+func (ae *AccountingEngine) SetViolationWaiverApprovers(userIDs []string) {
+	ae.complianceService.SetViolationWaiverApprovers(userIDs)
+}
+
+// SetViolationEscalationContacts designates who overdue violations escalate to
+func (ae *AccountingEngine) SetViolationEscalationContacts(userIDs []string) {
+	ae.complianceService.SetViolationEscalationContacts(userIDs)
+}
+
+// EscalateOverdueViolations escalates violations past their remediation deadline
+func (ae *AccountingEngine) EscalateOverdueViolations(asOf time.Time) ([]*ComplianceViolation, error) {
+	return ae.complianceService.EscalateOverdueViolations(asOf)
+}
+
+// GenerateViolationAgingReport ages the violations awaiting remediation
+func (ae *AccountingEngine) GenerateViolationAgingReport(asOfDate time.Time) (*ViolationAgingReport, error) {
+	return ae.complianceService.GenerateViolationAgingReport(asOfDate)
+}
+
+// ----------------------------------------------------------------------------
+// Framework Adjustment Methods
+// ----------------------------------------------------------------------------
+
+// BookFrameworkAdjustment records an adjustment that applies under GAAP or IFRS only
+func (ae *AccountingEngine) BookFrameworkAdjustment(adjustment *FrameworkAdjustment, userID string) error {
+	return ae.frameworkAdjustments.BookAdjustment(adjustment, userID)
+}
+
+// ReverseFrameworkAdjustment withdraws an adjustment from its framework's statements
+func (ae *AccountingEngine) ReverseFrameworkAdjustment(adjustmentID, reason, userID string) (*FrameworkAdjustment, error) {
+	return ae.frameworkAdjustments.ReverseAdjustment(adjustmentID, reason, userID)
+}
+
+// GetFrameworkAdjustments lists a framework's adjustments, or all of them for an empty framework
+func (ae *AccountingEngine) GetFrameworkAdjustments(framework ReportingStandard) ([]*FrameworkAdjustment, error) {
+	return ae.frameworkAdjustments.GetAdjustments(framework)
+}
+
+// GenerateFrameworkTrialBalance generates a trial balance including a framework's adjustments
+func (ae *AccountingEngine) GenerateFrameworkTrialBalance(asOfDate time.Time, currency string, framework ReportingStandard) ([]*BalanceResult, error) {
+	return ae.reportingService.GenerateFrameworkTrialBalance(asOfDate, currency, framework)
+}
+
+// GenerateFrameworkBalanceSheet generates a balance sheet under GAAP or IFRS from the shared ledger
+func (ae *AccountingEngine) GenerateFrameworkBalanceSheet(asOfDate time.Time, currency string, framework ReportingStandard) (*FinancialStatement, error) {
+	return ae.reportingService.GenerateFrameworkBalanceSheet(asOfDate, currency, framework)
+}
+
+// GenerateFrameworkProfitAndLoss generates a P&L under GAAP or IFRS from the shared ledger
+func (ae *AccountingEngine) GenerateFrameworkProfitAndLoss(fromDate, toDate time.Time, currency string, framework ReportingStandard) (*FinancialStatement, error) {
+	return ae.reportingService.GenerateFrameworkProfitAndLoss(fromDate, toDate, currency, framework)
+}
+
+// ----------------------------------------------------------------------------
+// Zero-Based Budgeting Methods
+// ----------------------------------------------------------------------------
+
+// CreateBudgetPeriod creates a new budget period
+func (ae *AccountingEngine) CreateBudgetPeriod(period *BudgetPeriod, userID string) error {
+	return ae.zbbService.CreateBudgetPeriod(period, userID)
+}
+
+// CreateBudgetRequest creates a new zero-based budget request
+func (ae *AccountingEngine) CreateBudgetRequest(request *BudgetRequest, userID string) error {
+	return ae.zbbService.CreateBudgetRequest(request, userID)
+}
+
+// AddJustification adds business justification to a budget request
+func (ae *AccountingEngine) AddJustification(requestID string, justification *Justification, userID string) error {
+	return ae.zbbService.AddJustification(requestID, justification, userID)
+}
+
+// SubmitBudgetRequest submits request for approval
+func (ae *AccountingEngine) SubmitBudgetRequest(requestID string, userID string) error {
+	return ae.zbbService.SubmitBudgetRequest(requestID, userID)
+}
+
+// ApproveBudgetRequest approves a budget request
+func (ae *AccountingEngine) ApproveBudgetRequest(requestID string, approverID string, approvedAmount *Amount, comments string) error {
+	if err := ae.accessControlService.Authorize(approverID, PermissionApproveBudgets, "approve budget request "+requestID); err != nil {
+		return err
+	}
+	return ae.zbbService.ApproveBudgetRequest(requestID, approverID, approvedAmount, comments)
+}
+
+// ReviewBudgetRequest marks a submitted budget request as under review
+func (ae *AccountingEngine) ReviewBudgetRequest(requestID string, reviewerID string) error {
+	if err := ae.accessControlService.Authorize(reviewerID, PermissionApproveBudgets, "review budget request "+requestID); err != nil {
+		return err
+	}
+	return ae.zbbService.ReviewBudgetRequest(requestID, reviewerID)
+}
+
+// RejectBudgetRequest rejects a budget request with a reason
+func (ae *AccountingEngine) RejectBudgetRequest(requestID string, approverID string, reason string) error {
+	if err := ae.accessControlService.Authorize(approverID, PermissionApproveBudgets, "reject budget request "+requestID); err != nil {
+		return err
+	}
+	return ae.zbbService.RejectBudgetRequest(requestID, approverID, reason)
+}
+
+// RequestBudgetRevision sends a budget request back to its requestor for changes
+func (ae *AccountingEngine) RequestBudgetRevision(requestID string, approverID string, comment string) error {
+	if err := ae.accessControlService.Authorize(approverID, PermissionApproveBudgets, "request revision of budget request "+requestID); err != nil {
+		return err
+	}
+	return ae.zbbService.RequestBudgetRevision(requestID, approverID, comment)
+}
+
+// CreateBudgetAllocation creates budget allocation from approved request
+func (ae *AccountingEngine) CreateBudgetAllocation(requestID string, userID string) error {
+	return ae.zbbService.CreateBudgetAllocation(requestID, userID)
+}
+
+// TrackBudgetSpending tracks actual spending against budget allocations
+func (ae *AccountingEngine) TrackBudgetSpending(transactionID string, allocationID string) error {
+	return ae.zbbService.TrackBudgetSpending(transactionID, allocationID)
+}
+
+// GetBudgetTracking lists the spending charged to a budget allocation, oldest first
+func (ae *AccountingEngine) GetBudgetTracking(allocationID string) ([]*BudgetTracking, error) {
+	return ae.zbbService.GetBudgetTracking(allocationID)
+}
+
+// SetBudgetWarningHandler registers a callback for postings that take an allocation over budget
+func (ae *AccountingEngine) SetBudgetWarningHandler(handler func(*BudgetWarning)) {
+	ae.zbbService.SetBudgetWarningHandler(handler)
+}
+
+// GetBudgetVariance calculates variance between budget and actual
+func (ae *AccountingEngine) GetBudgetVariance(periodID string, departmentID string) (*BudgetVarianceReport, error) {
+	return ae.zbbService.GetBudgetVariance(periodID, departmentID)
+}
+
+// GetDepartmentBudgetSummary provides summary of all budget requests for a department
+func (ae *AccountingEngine) GetDepartmentBudgetSummary(periodID string, departmentID string) (*DepartmentBudgetSummary, error) {
+	return ae.zbbService.GetDepartmentBudgetSummary(periodID, departmentID)
+}
+
+// SnapshotBudget takes a department's approved allocations as its baseline forecast
+func (ae *AccountingEngine) SnapshotBudget(periodID, departmentID, userID string) (*BudgetForecast, error) {
+	return ae.zbbService.SnapshotBudget(periodID, departmentID, userID)
+}
+
+// EnterReforecast saves a new forecast version revising months from fromMonth on
+func (ae *AccountingEngine) EnterReforecast(periodID, departmentID, fromMonth string, lines []ForecastLine, notes, userID string) (*BudgetForecast, error) {
+	return ae.zbbService.EnterReforecast(periodID, departmentID, fromMonth, lines, notes, userID)
+}
+
+// GetBudgetForecasts lists the forecast versions of a department's budget, oldest first
+func (ae *AccountingEngine) GetBudgetForecasts(periodID, departmentID string) ([]*BudgetForecast, error) {
+	return ae.zbbService.GetForecasts(periodID, departmentID)
+}
+
+// GetForecastVariance compares budget, forecast and actual spending by month; version 0 is the latest
+func (ae *AccountingEngine) GetForecastVariance(periodID, departmentID string, version int) (*ForecastVarianceReport, error) {
+	return ae.zbbService.GetForecastVariance(periodID, departmentID, version)
+}
+
+// CreateDepartment adds a department or cost center to the organization tree
+func (ae *AccountingEngine) CreateDepartment(department *Department, userID string) error {
+	return ae.zbbService.CreateDepartment(department, userID)
+}
+
+// UpdateDepartment replaces a department's details, including its place in the tree
+func (ae *AccountingEngine) UpdateDepartment(department *Department, userID string) error {
+	return ae.zbbService.UpdateDepartment(department, userID)
+}
+
+// GetDepartment retrieves a department or cost center
+func (ae *AccountingEngine) GetDepartment(departmentID string) (*Department, error) {
+	return ae.zbbService.GetDepartment(departmentID)
+}
+
+// ListDepartments returns the departments active from start to end, or all when both are zero
+func (ae *AccountingEngine) ListDepartments(start, end time.Time) ([]*Department, error) {
+	return ae.zbbService.ListDepartments(start, end)
+}
+
+// GetOrgBudgetSummary summarizes a department's budget with its descendants rolled up
+func (ae *AccountingEngine) GetOrgBudgetSummary(periodID, departmentID string) (*OrgBudgetSummary, error) {
+	return ae.zbbService.GetOrgBudgetSummary(periodID, departmentID)
+}
+
+// Helper methods for common operations
+
+// CreateStandardAccounts creates a basic chart of accounts. Accounts that already
+// exist are left as they are, so it can be run again on an existing ledger.
+func (ae *AccountingEngine) CreateStandardAccounts(userID string) error {
+	accounts := []*Account{
+		{
+			ID:   "cash",
+			Code: "1001",
+			Name: "Cash",
+			Type: Asset,
+		},
+		{
+			ID:   "accounts_receivable",
+			Code: "1200",
+			Name: "Accounts Receivable",
+			Type: Asset,
+		},
+		{
+			ID:   "intercompany_receivable",
+			Code: "1300",
+			Name: "Intercompany Receivable",
+			Type: Asset,
+		},
+		{
+			ID:   "accounts_payable",
+			Code: "2001",
+			Name: "Accounts Payable",
+			Type: Liability,
+		},
+		{
+			ID:   "intercompany_payable",
+			Code: "2200",
+			Name: "Intercompany Payable",
+			Type: Liability,
+		},
+		{
+			ID:   "revenue",
+			Code: "4001",
+			Name: "Revenue",
+			Type: Income,
+		},
+		{
+			ID:   "expenses",
+			Code: "5001",
+			Name: "Operating Expenses",
+			Type: Expense,
+		},
+		{
+			ID:   "unearned_revenue",
+			Code: "2100",
+			Name: "Unearned Revenue",
+			Type: Liability,
+		},
+	}
+
+	for _, account := range accounts {
+		if ae.storage.HasKey(BucketAccounts, account.ID) {
+			continue
+		}
+		if err := ae.CreateAccount(account, userID); err != nil {
+			return fmt.Errorf("failed to create account %s: %w", account.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateChartTemplate checks a chart of accounts template for collisions with the existing accounts
+func (ae *AccountingEngine) ValidateChartTemplate(templateID string) (*TemplateValidation, error) {
+	return ae.chartOfAccountsService.ValidateTemplate(templateID)
+}
+
+// CreateAccountsFromTemplate creates the accounts of a chart of accounts template,
+// e.g. "saas", "retail", "nonprofit" or "de_skr03". Nothing is created when the
+// template collides with existing accounts.
+func (ae *AccountingEngine) CreateAccountsFromTemplate(templateID string, userID string) ([]*Account, error) {
+	validation, err := ae.chartOfAccountsService.ValidateTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	if !validation.Valid() {
+		first := validation.Conflicts[0]
+		return nil, fmt.Errorf("template %s has %d conflicts, first: account %s: %s",
+			templateID, len(validation.Conflicts), first.AccountID, first.Reason)
+	}
+
+	template, err := GetChartTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range template.Accounts {
+		if err := ae.CreateAccount(account, userID); err != nil {
+			return nil, fmt.Errorf("failed to create account %s: %w", account.Name, err)
+		}
+	}
+	return template.Accounts, nil
+}
+
+// CreateLedger creates a new ledger
+func (ae *AccountingEngine) CreateLedger(ledger *Ledger, userID string) error {
+	return ae.ledgerService.CreateLedger(ledger, userID)
+}
+
+// ----------------------------------------------------------------------------
+// Service Getters
+// ----------------------------------------------------------------------------
+
+// GetAMLService returns the AML service
+func (ae *AccountingEngine) GetAMLService() *AMLService {
+	return ae.amlService
+}
+
+// GetComplianceService returns the compliance service
+func (ae *AccountingEngine) GetComplianceService() *ComplianceService {
+	return ae.complianceService
+}
+
+// GetForensicService returns the forensic service
 func (ae *AccountingEngine) GetForensicService() *ForensicService {
 	return ae.forensicService
 }
+
+// GetPeriodCloseService returns the period close service
+func (ae *AccountingEngine) GetPeriodCloseService() *PeriodCloseService {
+	return ae.periodCloseService
+}
+
+// GetExchangeRateService returns the exchange rate service
+func (ae *AccountingEngine) GetExchangeRateService() *ExchangeRateService {
+	return ae.exchangeRateService
+}
+
+// GetDisclosureService returns the disclosure service
+func (ae *AccountingEngine) GetDisclosureService() *DisclosureService {
+	return ae.disclosureService
+}
+
+// GetConfirmationService returns the audit confirmation service
+func (ae *AccountingEngine) GetConfirmationService() *ConfirmationService {
+	return ae.confirmationService
+}
+
+// GetMaterialityService returns the materiality service
+func (ae *AccountingEngine) GetMaterialityService() *MaterialityService {
+	return ae.materialityService
+}
+
+// GetInflationAdjustmentService returns the hyperinflation restatement service
+func (ae *AccountingEngine) GetInflationAdjustmentService() *InflationAdjustmentService {
+	return ae.inflationService
+}
+
+// GetPayablesService returns the accounts payable service
+func (ae *AccountingEngine) GetPayablesService() *PayablesService {
+	return ae.payablesService
+}
+
+// GetChainReconciliationService returns the on-chain reconciliation service
+func (ae *AccountingEngine) GetChainReconciliationService() *ChainReconciliationService {
+	return ae.chainReconService
+}
+
+// GetInstallmentService returns the installment plan service
+func (ae *AccountingEngine) GetInstallmentService() *InstallmentService {
+	return ae.installmentService
+}
+
+// GetRecurringTransactionService returns the recurring transaction service
+func (ae *AccountingEngine) GetRecurringTransactionService() *RecurringTransactionService {
+	return ae.recurringService
+}
+
+// GetRefundService returns the refund and chargeback service
+func (ae *AccountingEngine) GetRefundService() *RefundService {
+	return ae.refundService
+}
+
+// GetStoredValueService returns the stored value service
+func (ae *AccountingEngine) GetStoredValueService() *StoredValueService {
+	return ae.storedValueService
+}
+
+// GetJournalApprovalService returns the journal approval service
+func (ae *AccountingEngine) GetJournalApprovalService() *JournalApprovalService {
+	return ae.journalApprovalService
+}
+
+// GetImportService returns the transaction import service
+func (ae *AccountingEngine) GetImportService() *ImportService {
+	return ae.importService
+}
+
+// GetLoyaltyService returns the loyalty service
+func (ae *AccountingEngine) GetLoyaltyService() *LoyaltyService {
+	return ae.loyaltyService
+}
+
+// GetClientMoneyService returns the client money service
+func (ae *AccountingEngine) GetClientMoneyService() *ClientMoneyService {
+	return ae.clientMoneyService
+}
+
+// GetPSPSettlementService returns the PSP settlement service
+func (ae *AccountingEngine) GetPSPSettlementService() *PSPSettlementService {
+	return ae.pspSettlementService
+}
+
+// GetMerchantReserveService returns the merchant reserve service
+func (ae *AccountingEngine) GetMerchantReserveService() *MerchantReserveService {
+	return ae.merchantReserveService
+}
+
+// GetFeeScheduleService returns the fee schedule service
+func (ae *AccountingEngine) GetFeeScheduleService() *FeeScheduleService {
+	return ae.feeScheduleService
+}
+
+// GetRegulatoryReportingService returns the regulatory reporting service
+func (ae *AccountingEngine) GetRegulatoryReportingService() *RegulatoryReportingService {
+	return ae.regulatoryService
+}
+
+// GetYearEndCloseService returns the year-end close service
+func (ae *AccountingEngine) GetYearEndCloseService() *YearEndCloseService {
+	return ae.yearEndService
+}
+
+// GetChartOfAccountsService returns the chart of accounts service
+func (ae *AccountingEngine) GetChartOfAccountsService() *ChartOfAccountsService {
+	return ae.chartOfAccountsService
+}
+
+// GetMasterDataService returns the master data service
+func (ae *AccountingEngine) GetMasterDataService() *MasterDataService {
+	return ae.masterDataService
+}
+
+// GetReclassificationService returns the reclassification service
+func (ae *AccountingEngine) GetReclassificationService() *ReclassificationService {
+	return ae.reclassService
+}
+
+// GetDimensionService returns the dimension registry service
+func (ae *AccountingEngine) GetDimensionService() *DimensionService {
+	return ae.dimensionService
+}
+
+// GetAccessControlService returns the access control service
+func (ae *AccountingEngine) GetAccessControlService() *AccessControlService {
+	return ae.accessControlService
+}
+
+// GetDocumentService returns the document service
+func (ae *AccountingEngine) GetDocumentService() *DocumentService {
+	return ae.documentService
+}
+
+// GetScriptService returns the scripting hook service
+func (ae *AccountingEngine) GetScriptService() *ScriptService {
+	return ae.scriptService
+}
+
+// GetStatementTemplateService returns the statement template service
+func (ae *AccountingEngine) GetStatementTemplateService() *StatementTemplateService {
+	return ae.statementTemplateService
+}
+
+// GetNarrativeService returns the alert and SAR narrative service
+func (ae *AccountingEngine) GetNarrativeService() *NarrativeService {
+	return ae.narrativeService
+}
+
+// GetLedgerService returns the ledger service
+func (ae *AccountingEngine) GetLedgerService() *LedgerService {
+	return ae.ledgerService
+}
+
+// GetSegmentService returns the operating segment service
+func (ae *AccountingEngine) GetSegmentService() *SegmentService {
+	return ae.segmentService
+}
+
+// GetReportScheduler returns the report scheduler
+func (ae *AccountingEngine) GetReportScheduler() *ReportScheduler {
+	return ae.reportScheduler
+}
+
+// GetGoingConcernService returns the going-concern service
+func (ae *AccountingEngine) GetGoingConcernService() *GoingConcernService {
+	return ae.goingConcern
+}
+
+// GetNotificationService returns the notification service
+func (ae *AccountingEngine) GetNotificationService() *NotificationService {
+	return ae.notifications
+}
+
+// GetMetrics returns the engine metrics
+func (ae *AccountingEngine) GetMetrics() *EngineMetrics {
+	return ae.metrics
+}
+
+// GetRevenueAnalyticsService returns the revenue analytics service
+func (ae *AccountingEngine) GetRevenueAnalyticsService() *RevenueAnalyticsService {
+	return ae.revenueAnalytics
+}
+
+// GetExpenseAnomalyService returns the expense anomaly service
+func (ae *AccountingEngine) GetExpenseAnomalyService() *ExpenseAnomalyService {
+	return ae.expenseAnomalies
+}
+
+// GetConsistencyService returns the cross-module consistency checker
+func (ae *AccountingEngine) GetConsistencyService() *ConsistencyService {
+	return ae.consistency
+}
+
+// GetWorkflowService returns the workflow engine shared by approvals, alerts and close
+func (ae *AccountingEngine) GetWorkflowService() *WorkflowService {
+	return ae.workflows
+}
+
+// GetDataExportService returns the permissions-aware data export service
+func (ae *AccountingEngine) GetDataExportService() *DataExportService {
+	return ae.dataExport
+}
+
+// GetPIIErasureService returns the AML customer PII erasure service
+func (ae *AccountingEngine) GetPIIErasureService() *PIIErasureService {
+	return ae.piiErasureService
+}
+
+// GetContractService returns the revenue contract service
+func (ae *AccountingEngine) GetContractService() *ContractService {
+	return ae.contractService
+}
+
+// GetTaxLineService returns the automatic tax line service
+func (ae *AccountingEngine) GetTaxLineService() *TaxLineService {
+	return ae.taxLineService
+}
+
+// GetVATReturnService returns the VAT return service
+func (ae *AccountingEngine) GetVATReturnService() *VATReturnService {
+	return ae.vatReturnService
+}
+
+// GetForm1099Service returns the form 1099 service
+func (ae *AccountingEngine) GetForm1099Service() *Form1099Service {
+	return ae.form1099Service
+}
+
+// GetSOXControlService GetSOXControlService returns the SOX control service
+func (ae *AccountingEngine) GetSOXControlService() *SOXControlService {
+	return ae.soxControlService
+}
+
+// GetFrameworkAdjustmentService returns the framework adjustment service
+func (ae *AccountingEngine) GetFrameworkAdjustmentService() *FrameworkAdjustmentService {
+	return ae.frameworkAdjustments
+}
+
+// GetStorage returns the underlying storage
+func (ae *AccountingEngine) GetStorage() *Storage {
+	return ae.storage
+}
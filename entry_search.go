@@ -0,0 +1,107 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// EntrySearch selects entries by their line-level context. Every condition that is
+// set must match.
+type EntrySearch struct {
+	Text          string              `json:"text,omitempty"`      // in the memo or reference, ignoring case
+	Reference     string              `json:"reference,omitempty"` // exact external reference
+	Tags          []string            `json:"tags,omitempty"`      // entries carrying all of these tags
+	AccountIDs    []string            `json:"account_ids,omitempty"`
+	ValidTimeFrom *time.Time          `json:"valid_time_from,omitempty"`
+	ValidTimeTo   *time.Time          `json:"valid_time_to,omitempty"`
+	Status        []TransactionStatus `json:"status,omitempty"`
+}
+
+// EntrySearchResult is a matching entry with the transaction it belongs to
+type EntrySearchResult struct {
+	Entry                  *Entry            `json:"entry"`
+	TransactionDescription string            `json:"transaction_description"`
+	ValidTime              time.Time         `json:"valid_time"`
+	Status                 TransactionStatus `json:"status"`
+}
+
+// SearchEntries finds entries by memo, reference, tags, account, date and status,
+// ordered by valid time and then by transaction
+func (qa *QueryAPI) SearchEntries(search *EntrySearch) ([]*EntrySearchResult, error) {
+	if search == nil {
+		search = &EntrySearch{}
+	}
+	transactions, err := qa.storage.GetAllTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	text := strings.ToLower(strings.TrimSpace(search.Text))
+	tags := normalizeTags(search.Tags)
+
+	var results []*EntrySearchResult
+	for _, txn := range transactions {
+		if search.ValidTimeFrom != nil && txn.ValidTime.Before(*search.ValidTimeFrom) {
+			continue
+		}
+		if search.ValidTimeTo != nil && txn.ValidTime.After(*search.ValidTimeTo) {
+			continue
+		}
+		if len(search.Status) > 0 && !slices.Contains(search.Status, txn.Status) {
+			continue
+		}
+		for i := range txn.Entries {
+			entry := &txn.Entries[i]
+			if len(search.AccountIDs) > 0 && !slices.Contains(search.AccountIDs, entry.AccountID) {
+				continue
+			}
+			if search.Reference != "" && entry.Reference != search.Reference {
+				continue
+			}
+			if text != "" && !strings.Contains(strings.ToLower(entry.Memo), text) && !strings.Contains(strings.ToLower(entry.Reference), text) {
+				continue
+			}
+			if !hasTags(entry.Tags, tags) {
+				continue
+			}
+			results = append(results, &EntrySearchResult{
+				Entry:                  entry,
+				TransactionDescription: txn.Description,
+				ValidTime:              txn.ValidTime,
+				Status:                 txn.Status,
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if !results[i].ValidTime.Equal(results[j].ValidTime) {
+			return results[i].ValidTime.Before(results[j].ValidTime)
+		}
+		return results[i].Entry.TransactionID < results[j].Entry.TransactionID
+	})
+	return results, nil
+}
+
+// normalizeTags trims and lowercases tags, dropping blanks and repeats
+func normalizeTags(tags []string) []string {
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" && !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// hasTags reports whether tags includes every wanted tag
+func hasTags(tags, wanted []string) bool {
+	for _, tag := range wanted {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryMemoReferenceAndTags(t *testing.T) {
+	dbFile := "test_entry_search.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "bookkeeper"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	march := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	bill := &Transaction{
+		Description: "Supplier bills",
+		ValidTime:   march,
+		Entries: []Entry{
+			{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 1200, Currency: "USD"},
+				Memo: "Printer toner", Reference: "INV-1001", Tags: []string{" Office ", "supplies", "office", ""}},
+			{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 800, Currency: "USD"},
+				Memo: "Courier to client site", Reference: "INV-1002", Tags: []string{"travel"}},
+			{AccountID: "accounts_payable", Type: Credit, Amount: Amount{Value: 2000, Currency: "USD"}},
+		},
+	}
+	require.NoError(t, engine.CreateTransaction(bill, userID))
+	require.NoError(t, engine.PostTransaction(bill.ID, userID))
+
+	t.Run("persists line-level context", func(t *testing.T) {
+		stored, err := engine.GetStorage().GetTransaction(bill.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Printer toner", stored.Entries[0].Memo)
+		assert.Equal(t, "INV-1001", stored.Entries[0].Reference)
+		assert.Equal(t, []string{"office", "supplies"}, stored.Entries[0].Tags)
+	})
+
+	reversal, err := engine.ReverseTransaction(bill.ID, "Bills entered twice", userID)
+	require.NoError(t, err)
+
+	t.Run("searches by tag, text and reference", func(t *testing.T) {
+		results, err := engine.SearchEntries(&EntrySearch{Tags: []string{"OFFICE", "supplies"}})
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, bill.ID, results[0].Entry.TransactionID)
+		assert.Equal(t, "Supplier bills", results[0].TransactionDescription)
+		assert.Equal(t, reversal.ID, results[1].Entry.TransactionID)
+		assert.Equal(t, Credit, results[1].Entry.Type)
+
+		results, err = engine.SearchEntries(&EntrySearch{Text: "courier", Status: []TransactionStatus{Reversed}})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, "INV-1002", results[0].Entry.Reference)
+
+		results, err = engine.SearchEntries(&EntrySearch{Text: "inv-100", AccountIDs: []string{"expenses"}})
+		require.NoError(t, err)
+		assert.Len(t, results, 4)
+
+		results, err = engine.SearchEntries(&EntrySearch{Reference: "INV-1001", ValidTimeTo: &march})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, bill.ID, results[0].Entry.TransactionID)
+	})
+}
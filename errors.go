@@ -0,0 +1,112 @@
+package accounting
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors classifying the package's failures. Errors keep their descriptive
+// messages; test the class with errors.Is, or use errors.As with NotFoundError,
+// ValidationError, AccessDeniedError or ConflictError for the details.
+var (
+	ErrNotFound         = errors.New("not found")
+	ErrValidation       = errors.New("validation failed")
+	ErrUnbalanced       = errors.New("debits and credits do not balance")
+	ErrPeriodClosed     = errors.New("period closed")
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// classifiedError is a message filed under one of the sentinel errors without
+// repeating the sentinel's text
+type classifiedError struct {
+	class   error
+	message string
+}
+
+func (e *classifiedError) Error() string {
+	return e.message
+}
+
+func (e *classifiedError) Unwrap() error {
+	return e.class
+}
+
+// classify formats an error like fmt.Errorf that errors.Is matches to class
+func classify(class error, format string, args ...any) error {
+	return &classifiedError{class: class, message: fmt.Sprintf(format, args...)}
+}
+
+// NotFoundError reports a lookup of a record that does not exist
+type NotFoundError struct {
+	Entity string `json:"entity"` // e.g. "account"
+	ID     string `json:"id"`
+}
+
+func (e *NotFoundError) Error() string {
+	if e.ID == "" {
+		return e.Entity + " not found"
+	}
+	return fmt.Sprintf("%s not found: %s", e.Entity, e.ID)
+}
+
+// Is makes errors.Is(err, ErrNotFound) true for missing records
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// notFound reports that the entity stored under id does not exist
+func notFound(entity, id string) error {
+	return &NotFoundError{Entity: entity, ID: id}
+}
+
+// ValidationError reports the checks a transaction failed before posting. Besides
+// ErrValidation it matches ErrUnbalanced or ErrPeriodClosed when one of the failed
+// checks was the balance or period check.
+type ValidationError struct {
+	Errors []PostingError `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("transaction validation failed: %v", e.Errors)
+}
+
+// validationClasses maps posting error codes to the sentinels they match
+var validationClasses = map[string]error{
+	ViolationUnbalanced:   ErrUnbalanced,
+	ViolationPeriodClosed: ErrPeriodClosed,
+}
+
+func (e *ValidationError) Is(target error) bool {
+	if target == ErrValidation {
+		return true
+	}
+	for _, failure := range e.Errors {
+		if class, ok := validationClasses[failure.Code]; ok && class == target {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns the result as a ValidationError, or nil when the transaction is valid
+func (r *ValidationResult) Err() error {
+	if r.Valid {
+		return nil
+	}
+	return &ValidationError{Errors: r.Errors}
+}
+
+// AccessDeniedError reports an action refused by access control, carrying the
+// denial that was recorded for the audit trail
+type AccessDeniedError struct {
+	Denial *AccessDenial `json:"denial"`
+}
+
+func (e *AccessDeniedError) Error() string {
+	return fmt.Sprintf("access denied: %s may not %s: %s", e.Denial.UserID, e.Denial.Action, e.Denial.Reason)
+}
+
+// Is makes errors.Is(err, ErrPermissionDenied) true for denials
+func (e *AccessDeniedError) Is(target error) bool {
+	return target == ErrPermissionDenied
+}
@@ -0,0 +1,97 @@
+package accounting
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorClasses(t *testing.T) {
+	// Setup
+	dbFile := "test_errors.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	admin, clerk := "admin", "clerk"
+	require.NoError(t, engine.CreateStandardAccounts(admin))
+
+	sale := func(validTime time.Time, debit, credit int64) *Transaction {
+		return &Transaction{
+			Description: "Sale",
+			ValidTime:   validTime,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: debit, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: credit, Currency: "USD"}},
+			},
+		}
+	}
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := engine.GetStorage().GetAccount("no-such-account")
+		require.ErrorIs(t, err, ErrNotFound)
+		var missing *NotFoundError
+		require.True(t, errors.As(err, &missing))
+		assert.Equal(t, "account", missing.Entity)
+		assert.Equal(t, "no-such-account", missing.ID)
+		assert.EqualError(t, err, "account not found: no-such-account")
+
+		// Wrapping keeps the class
+		err = engine.PostTransaction("no-such-transaction", admin)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.NotErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("Unbalanced", func(t *testing.T) {
+		err := engine.CreateTransaction(sale(time.Now(), 1000, 900), admin)
+		assert.ErrorIs(t, err, ErrValidation)
+		assert.ErrorIs(t, err, ErrUnbalanced)
+		assert.NotErrorIs(t, err, ErrPeriodClosed)
+
+		var invalid *ValidationError
+		require.True(t, errors.As(err, &invalid))
+		require.Len(t, invalid.Errors, 1)
+		assert.Equal(t, "UNBALANCED_TRANSACTION", invalid.Errors[0].Code)
+		assert.ErrorContains(t, err, "transaction does not balance")
+	})
+
+	t.Run("Period Closed", func(t *testing.T) {
+		closedAt := time.Now()
+		period := &Period{
+			Name:         "2024",
+			Start:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:          time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+			HardClosedAt: &closedAt,
+		}
+		require.NoError(t, engine.CreatePeriod(period, admin))
+
+		txn := sale(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), 500, 500)
+		err := engine.CreateTransaction(txn, admin)
+		assert.ErrorIs(t, err, ErrPeriodClosed)
+		assert.NotErrorIs(t, err, ErrUnbalanced)
+	})
+
+	t.Run("Permission Denied", func(t *testing.T) {
+		administrators := &Role{Name: "Administrators", Permissions: []Permission{PermissionManageAccess}}
+		require.NoError(t, engine.CreateRole(administrators, admin))
+		require.NoError(t, engine.CreateUser(&User{ID: admin, Name: "Admin", RoleIDs: []string{administrators.ID}}, admin))
+		require.NoError(t, engine.CreateUser(&User{ID: clerk, Name: "Clerk"}, admin))
+
+		txn := sale(time.Now(), 300, 300)
+		require.NoError(t, engine.CreateTransaction(txn, admin))
+		err := engine.PostTransaction(txn.ID, clerk)
+		require.ErrorIs(t, err, ErrPermissionDenied)
+
+		var denied *AccessDeniedError
+		require.True(t, errors.As(err, &denied))
+		assert.Equal(t, clerk, denied.Denial.UserID)
+		assert.Equal(t, PermissionPostTransactions, denied.Denial.Permission)
+		assert.ErrorContains(t, err, "access denied: clerk may not")
+	})
+}
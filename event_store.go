@@ -4,27 +4,138 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 // EventType constants for different event types
 const (
-	EventCreateAccount      = "CREATE_ACCOUNT"
-	EventUpdateAccount      = "UPDATE_ACCOUNT"
-	EventCreateTransaction  = "CREATE_TRANSACTION"
-	EventPostTransaction    = "POST_TRANSACTION"
-	EventReverseTransaction = "REVERSE_TRANSACTION"
-	EventCreatePeriod       = "CREATE_PERIOD"
-	EventClosePeriod        = "CLOSE_PERIOD"
-	EventReconcile          = "RECONCILE"
+	EventCreateAccount                = "CREATE_ACCOUNT"
+	EventUpdateAccount                = "UPDATE_ACCOUNT"
+	EventCreateTransaction            = "CREATE_TRANSACTION"
+	EventPostTransaction              = "POST_TRANSACTION"
+	EventReverseTransaction           = "REVERSE_TRANSACTION"
+	EventCreatePeriod                 = "CREATE_PERIOD"
+	EventClosePeriod                  = "CLOSE_PERIOD" // recorded before soft and hard closes had their own events
+	EventReconcile                    = "RECONCILE"
+	EventSignOffPeriod                = "SIGN_OFF_PERIOD"
+	EventAddVarianceCommentary        = "ADD_VARIANCE_COMMENTARY"
+	EventGenerateClosingBinder        = "GENERATE_CLOSING_BINDER"
+	EventSetExchangeRate              = "SET_EXCHANGE_RATE"
+	EventRevaluePeriod                = "REVALUE_PERIOD"
+	EventGenerateConfirmation         = "GENERATE_CONFIRMATION"
+	EventUpdateConfirmation           = "UPDATE_CONFIRMATION"
+	EventSetMateriality               = "SET_MATERIALITY"
+	EventRecordPriceIndex             = "RECORD_PRICE_INDEX"
+	EventRestatePeriod                = "RESTATE_PERIOD"
+	EventCreateVendor                 = "CREATE_VENDOR"
+	EventEnterBill                    = "ENTER_BILL"
+	EventUpdateBill                   = "UPDATE_BILL"
+	EventApproveBill                  = "APPROVE_BILL"
+	EventSchedulePaymentRun           = "SCHEDULE_PAYMENT_RUN"
+	EventExecutePaymentRun            = "EXECUTE_PAYMENT_RUN"
+	EventReconcileChain               = "RECONCILE_CHAIN"
+	EventCreateInstallmentPlan        = "CREATE_INSTALLMENT_PLAN"
+	EventRecognizeInstallmentInterest = "RECOGNIZE_INSTALLMENT_INTEREST"
+	EventRecordInstallmentReceipt     = "RECORD_INSTALLMENT_RECEIPT"
+	EventCreateRecurringTemplate      = "CREATE_RECURRING_TEMPLATE"
+	EventUpdateRecurringTemplate      = "UPDATE_RECURRING_TEMPLATE"
+	EventRecordRecurringRun           = "RECORD_RECURRING_RUN"
+	EventRefundSale                   = "REFUND_SALE"
+	EventRecordChargeback             = "RECORD_CHARGEBACK"
+	EventUpdateChargeback             = "UPDATE_CHARGEBACK"
+	EventCreateStoredValueProgram     = "CREATE_STORED_VALUE_PROGRAM"
+	EventIssueGiftCard                = "ISSUE_GIFT_CARD"
+	EventRedeemGiftCard               = "REDEEM_GIFT_CARD"
+	EventRecognizeBreakage            = "RECOGNIZE_BREAKAGE"
+	EventSubmitJournalApproval        = "SUBMIT_JOURNAL_APPROVAL"
+	EventApproveJournal               = "APPROVE_JOURNAL"
+	EventRejectJournal                = "REJECT_JOURNAL"
+	EventImportTransactions           = "IMPORT_TRANSACTIONS"
+	EventCreateLoyaltyProgram         = "CREATE_LOYALTY_PROGRAM"
+	EventAwardLoyaltyPoints           = "AWARD_LOYALTY_POINTS"
+	EventRedeemLoyaltyPoints          = "REDEEM_LOYALTY_POINTS"
+	EventExpireLoyaltyPoints          = "EXPIRE_LOYALTY_POINTS"
+	EventDesignateClientMoneyAccount  = "DESIGNATE_CLIENT_MONEY_ACCOUNT"
+	EventReconcileClientMoney         = "RECONCILE_CLIENT_MONEY"
+	EventRebuildBalanceSnapshots      = "REBUILD_BALANCE_SNAPSHOTS"
+	EventRegisterPSPProvider          = "REGISTER_PSP_PROVIDER"
+	EventReconcilePSPSettlement       = "RECONCILE_PSP_SETTLEMENT"
+	EventConfigureMerchantReserve     = "CONFIGURE_MERCHANT_RESERVE"
+	EventHoldMerchantReserve          = "HOLD_MERCHANT_RESERVE"
+	EventReleaseMerchantReserve       = "RELEASE_MERCHANT_RESERVE"
+	EventSaveFeeSchedule              = "SAVE_FEE_SCHEDULE"
+	EventChargeFee                    = "CHARGE_FEE"
+	EventReconcileFeeStatement        = "RECONCILE_FEE_STATEMENT"
+	EventUpdateCloseChecklist         = "UPDATE_CLOSE_CHECKLIST"
+	EventReopenPeriod                 = "REOPEN_PERIOD"
+	EventSaveRegulatoryReturn         = "SAVE_REGULATORY_RETURN"
+	EventGenerateRegulatoryReturn     = "GENERATE_REGULATORY_RETURN"
+	EventCloseFiscalYear              = "CLOSE_FISCAL_YEAR"
+	EventReopenFiscalYear             = "REOPEN_FISCAL_YEAR"
+	EventCreateCustomer               = "CREATE_CUSTOMER"
+	EventMergeParty                   = "MERGE_PARTY"
+	EventPreviewReclass               = "PREVIEW_RECLASS"
+	EventExecuteReclass               = "EXECUTE_RECLASS"
+	EventRollbackReclass              = "ROLLBACK_RECLASS"
+	EventDefineDimension              = "DEFINE_DIMENSION"
+	EventUpdateDimension              = "UPDATE_DIMENSION"
+	EventBudgetExceeded               = "BUDGET_EXCEEDED"
+	EventSnapshotBudget               = "SNAPSHOT_BUDGET"
+	EventReforecastBudget             = "REFORECAST_BUDGET"
+	EventCreateDepartment             = "CREATE_DEPARTMENT"
+	EventUpdateDepartment             = "UPDATE_DEPARTMENT"
+	EventSoftClosePeriod              = "SOFT_CLOSE_PERIOD"
+	EventHardClosePeriod              = "HARD_CLOSE_PERIOD"
+	EventFormallyReopenPeriod         = "FORMALLY_REOPEN_PERIOD"
+	EventCreateRole                   = "CREATE_ROLE"
+	EventCreateUser                   = "CREATE_USER"
+	EventAssignRole                   = "ASSIGN_ROLE"
+	EventRevokeRole                   = "REVOKE_ROLE"
+	EventAccessDenied                 = "ACCESS_DENIED"
+	EventImportSyncChanges            = "IMPORT_SYNC_CHANGES"
+	EventStoreDocument                = "STORE_DOCUMENT"
+	EventLinkDocument                 = "LINK_DOCUMENT"
+	EventUnlinkDocument               = "UNLINK_DOCUMENT"
+	EventRegisterScript               = "REGISTER_SCRIPT"
+	EventUpdateScript                 = "UPDATE_SCRIPT"
+	EventSaveStatementTemplate        = "SAVE_STATEMENT_TEMPLATE"
+	EventSaveNarrativeTemplate        = "SAVE_NARRATIVE_TEMPLATE"
+	EventGenerateNarrative            = "GENERATE_NARRATIVE"
+	EventEditNarrative                = "EDIT_NARRATIVE"
+	EventCreateLedger                 = "CREATE_LEDGER"
+	EventSaveLedgerPostingRule        = "SAVE_LEDGER_POSTING_RULE"
+	EventDefineSegment                = "DEFINE_SEGMENT"
+	EventSaveSegmentAllocationRule    = "SAVE_SEGMENT_ALLOCATION_RULE"
+	EventSaveReportSchedule           = "SAVE_REPORT_SCHEDULE"
+	EventRecordReportRun              = "RECORD_REPORT_RUN"
+	EventSaveDebtCovenant             = "SAVE_DEBT_COVENANT"
+	EventSaveNotificationSubscription = "SAVE_NOTIFICATION_SUBSCRIPTION"
+	EventRecordConsistencyRun         = "RECORD_CONSISTENCY_RUN"
+	EventExportData                   = "EXPORT_DATA"
+	EventEraseAMLCustomer             = "ERASE_AML_CUSTOMER"
+	EventSaveAccrualTemplate          = "SAVE_ACCRUAL_TEMPLATE"
+	EventCreateRevenueContract        = "CREATE_REVENUE_CONTRACT"
+	EventSatisfyPerformanceObligation = "SATISFY_PERFORMANCE_OBLIGATION"
+	EventSaveTaxLineRule              = "SAVE_TAX_LINE_RULE"
+	EventRecordTaxLines               = "RECORD_TAX_LINES"
+	EventSaveVATReturnMapping         = "SAVE_VAT_RETURN_MAPPING"
+	EventPrepareVATReturn             = "PREPARE_VAT_RETURN"
+	EventSetVendorTaxProfile          = "SET_VENDOR_TAX_PROFILE"
+	EventRecord1099Payment            = "RECORD_1099_PAYMENT"
+	EventSaveSOXControl               = "SAVE_SOX_CONTROL"
+	EventRecordControlTest            = "RECORD_CONTROL_TEST"
+	EventBookFrameworkAdjustment      = "BOOK_FRAMEWORK_ADJUSTMENT"
+	EventReverseFrameworkAdjustment   = "REVERSE_FRAMEWORK_ADJUSTMENT"
 )
 
 // EventStore manages the append-only event log
 type EventStore struct {
-	storage *Storage
+	storage   *Storage
+	listeners []EventListener
 }
 
+// EventListener is called with each event after it is appended to the log
+type EventListener func(event *JournalEvent)
+
 // NewEventStore creates a new event store
 func NewEventStore(storage *Storage) *EventStore {
 	return &EventStore{storage: storage}
@@ -38,7 +149,7 @@ func (es *EventStore) CreateEvent(eventType string, payload interface{}, validTi
 	}
 
 	event := &JournalEvent{
-		ID:              uuid.New().String(),
+		ID:              newID(),
 		EventType:       eventType,
 		Payload:         payloadData,
 		ValidTime:       validTime,
@@ -49,10 +160,19 @@ func (es *EventStore) CreateEvent(eventType string, payload interface{}, validTi
 	if err := es.storage.AppendEvent(event); err != nil {
 		return nil, fmt.Errorf("failed to append event: %w", err)
 	}
+	for _, listener := range es.listeners {
+		listener(event)
+	}
 
 	return event, nil
 }
 
+// AddListener registers a listener for appended events. Register listeners while
+// the engine is being assembled.
+func (es *EventStore) AddListener(listener EventListener) {
+	es.listeners = append(es.listeners, listener)
+}
+
 // GetEvents retrieves events within a time range
 func (es *EventStore) GetEvents(from, to time.Time) ([]*JournalEvent, error) {
 	return es.storage.GetEvents(from, to)
@@ -79,6 +199,12 @@ type AccountCreatedEvent struct {
 	Account *Account `json:"account"`
 }
 
+// AccountUpdatedEvent represents an account update event payload, e.g. a move
+// in the hierarchy or a deactivation
+type AccountUpdatedEvent struct {
+	Account *Account `json:"account"`
+}
+
 // TransactionCreatedEvent represents a transaction creation event payload
 type TransactionCreatedEvent struct {
 	Transaction *Transaction `json:"transaction"`
@@ -88,9 +214,22 @@ type TransactionCreatedEvent struct {
 type TransactionPostedEvent struct {
 	TransactionID string    `json:"transaction_id"`
 	PostedAt      time.Time `json:"posted_at"`
+	PostedBy      string    `json:"posted_by,omitempty"`
 	Entries       []Entry   `json:"entries"`
 }
 
+// TransactionReversedEvent records that a posted transaction was reversed by a contra transaction
+type TransactionReversedEvent struct {
+	TransactionID          string    `json:"transaction_id"`
+	ReversingTransactionID string    `json:"reversing_transaction_id"`
+	ReversedAt             time.Time `json:"reversed_at"`
+}
+
+// BalanceSnapshotsRebuiltEvent records a rebuild of the materialized account balances
+type BalanceSnapshotsRebuiltEvent struct {
+	RebuiltAt time.Time `json:"rebuilt_at"`
+}
+
 // EventProcessor processes events and updates projections
 type EventProcessor struct {
 	storage *Storage
@@ -106,10 +245,14 @@ func (ep *EventProcessor) ProcessEvent(event *JournalEvent) error {
 	switch event.EventType {
 	case EventCreateAccount:
 		return ep.handleAccountCreated(event)
+	case EventUpdateAccount:
+		return ep.handleAccountUpdated(event)
 	case EventCreateTransaction:
 		return ep.handleTransactionCreated(event)
 	case EventPostTransaction:
 		return ep.handleTransactionPosted(event)
+	case EventReverseTransaction:
+		return ep.handleTransactionReversed(event)
 	default:
 		return fmt.Errorf("unknown event type: %s", event.EventType)
 	}
@@ -124,6 +267,15 @@ func (ep *EventProcessor) handleAccountCreated(event *JournalEvent) error {
 	return ep.storage.SaveAccount(payload.Account)
 }
 
+func (ep *EventProcessor) handleAccountUpdated(event *JournalEvent) error {
+	var payload AccountUpdatedEvent
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal account updated event: %w", err)
+	}
+
+	return ep.storage.SaveAccount(payload.Account)
+}
+
 func (ep *EventProcessor) handleTransactionCreated(event *JournalEvent) error {
 	var payload TransactionCreatedEvent
 	if err := json.Unmarshal(event.Payload, &payload); err != nil {
@@ -146,18 +298,36 @@ func (ep *EventProcessor) handleTransactionPosted(event *JournalEvent) error {
 	}
 
 	txn.Status = Posted
+	txn.PostedBy = payload.PostedBy
 	txn.UpdatedAt = time.Now()
 
 	if err := ep.storage.SaveTransaction(txn); err != nil {
 		return fmt.Errorf("failed to update transaction: %w", err)
 	}
 
-	// Save all entries
-	for _, entry := range payload.Entries {
-		if err := ep.storage.SaveEntry(&entry); err != nil {
-			return fmt.Errorf("failed to save entry: %w", err)
-		}
+	// Save all entries in one write
+	entries := make([]*Entry, len(payload.Entries))
+	for i := range payload.Entries {
+		entries[i] = &payload.Entries[i]
+	}
+	if err := ep.storage.SaveEntries(entries); err != nil {
+		return fmt.Errorf("failed to save entries: %w", err)
 	}
 
 	return nil
 }
+
+func (ep *EventProcessor) handleTransactionReversed(event *JournalEvent) error {
+	var payload TransactionReversedEvent
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("failed to unmarshal transaction reversed event: %w", err)
+	}
+
+	txn, err := ep.storage.GetTransaction(payload.TransactionID)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction: %w", err)
+	}
+	txn.Status = Reversed
+	txn.UpdatedAt = payload.ReversedAt
+	return ep.storage.SaveTransaction(txn)
+}
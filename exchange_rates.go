@@ -0,0 +1,216 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Exchange Rate Structures
+// ----------------------------------------------------------------------------
+
+// ExchangeRateSourceManual marks rates entered directly by a user
+const ExchangeRateSourceManual = "MANUAL"
+
+// ExchangeRate is the number of ToCurrency units per FromCurrency unit effective from a date
+type ExchangeRate struct {
+	ID            string    `json:"id"`
+	FromCurrency  Currency  `json:"from_currency"`
+	ToCurrency    Currency  `json:"to_currency"`
+	Rate          float64   `json:"rate"`
+	EffectiveDate time.Time `json:"effective_date"`
+	Source        string    `json:"source"` // MANUAL or the provider name
+	CreatedBy     string    `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ExchangeRateProvider supplies rates that have not been entered manually,
+// for example from a central bank or market data feed
+type ExchangeRateProvider interface {
+	Name() string
+	GetRate(from, to Currency, date time.Time) (float64, error)
+}
+
+// ----------------------------------------------------------------------------
+// Exchange Rate Service
+// ----------------------------------------------------------------------------
+
+// ExchangeRateService stores effective-dated rates and converts amounts between currencies
+type ExchangeRateService struct {
+	storage    *Storage
+	eventStore *EventStore
+	providers  []ExchangeRateProvider
+	rounding   RoundingPolicy
+	mutex      sync.RWMutex
+}
+
+// NewExchangeRateService creates a new exchange rate service
+func NewExchangeRateService(storage *Storage, eventStore *EventStore) *ExchangeRateService {
+	return &ExchangeRateService{
+		storage:    storage,
+		eventStore: eventStore,
+		rounding:   RoundHalfEven,
+	}
+}
+
+// RegisterProvider adds a rate provider consulted when no stored rate is available.
+// Providers are consulted in registration order.
+func (ers *ExchangeRateService) RegisterProvider(provider ExchangeRateProvider) {
+	ers.mutex.Lock()
+	defer ers.mutex.Unlock()
+	ers.providers = append(ers.providers, provider)
+}
+
+// SetRoundingPolicy sets the rounding policy used when converting amounts
+func (ers *ExchangeRateService) SetRoundingPolicy(policy RoundingPolicy) {
+	ers.mutex.Lock()
+	defer ers.mutex.Unlock()
+	ers.rounding = policy
+}
+
+// GetRoundingPolicy returns the rounding policy used when converting amounts
+func (ers *ExchangeRateService) GetRoundingPolicy() RoundingPolicy {
+	ers.mutex.RLock()
+	defer ers.mutex.RUnlock()
+	return ers.rounding
+}
+
+// SetRate records a manual exchange rate effective from the given date
+func (ers *ExchangeRateService) SetRate(from, to Currency, rate float64, effectiveDate time.Time, userID string) (*ExchangeRate, error) {
+	return ers.saveRate(from, to, rate, effectiveDate, ExchangeRateSourceManual, userID)
+}
+
+// GetRate returns the rate in effect on the given date. Stored rates take precedence,
+// falling back to the inverse of a stored rate and then to registered providers.
+func (ers *ExchangeRateService) GetRate(from, to Currency, date time.Time) (*ExchangeRate, error) {
+	if from == to {
+		return &ExchangeRate{FromCurrency: from, ToCurrency: to, Rate: 1, EffectiveDate: date}, nil
+	}
+
+	rate, err := ers.findStoredRate(from, to, date)
+	if err != nil {
+		return nil, err
+	}
+	if rate != nil {
+		return rate, nil
+	}
+
+	inverse, err := ers.findStoredRate(to, from, date)
+	if err != nil {
+		return nil, err
+	}
+	if inverse != nil {
+		return &ExchangeRate{
+			ID:            inverse.ID,
+			FromCurrency:  from,
+			ToCurrency:    to,
+			Rate:          1 / inverse.Rate,
+			EffectiveDate: inverse.EffectiveDate,
+			Source:        inverse.Source,
+			CreatedBy:     inverse.CreatedBy,
+			CreatedAt:     inverse.CreatedAt,
+		}, nil
+	}
+
+	ers.mutex.RLock()
+	providers := append([]ExchangeRateProvider(nil), ers.providers...)
+	ers.mutex.RUnlock()
+
+	for _, provider := range providers {
+		value, err := provider.GetRate(from, to, date)
+		if err != nil {
+			continue
+		}
+		// Retain fetched rates so reports can be reproduced without the provider
+		return ers.saveRate(from, to, value, date, provider.Name(), provider.Name())
+	}
+
+	return nil, fmt.Errorf("no exchange rate for %s/%s on %s", from, to, date.Format("2006-01-02"))
+}
+
+// Convert translates an amount into the target currency at the rate in effect on the given date
+func (ers *ExchangeRateService) Convert(amount *Amount, to Currency, date time.Time) (*Amount, error) {
+	if amount == nil {
+		return nil, fmt.Errorf("cannot convert a nil amount")
+	}
+	if amount.Currency == to {
+		return &Amount{Value: amount.Value, Currency: to}, nil
+	}
+
+	rate, err := ers.GetRate(amount.Currency, to, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return amount.Convert(to, rate.Rate, rate.EffectiveDate, ers.GetRoundingPolicy())
+}
+
+// GetRateHistory returns all stored rates for a currency pair ordered by effective date
+func (ers *ExchangeRateService) GetRateHistory(from, to Currency) ([]*ExchangeRate, error) {
+	rates, err := ers.storage.GetExchangeRatesByPair(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		return rates[i].EffectiveDate.Before(rates[j].EffectiveDate)
+	})
+	return rates, nil
+}
+
+// findStoredRate returns the latest stored rate effective on or before the date
+func (ers *ExchangeRateService) findStoredRate(from, to Currency, date time.Time) (*ExchangeRate, error) {
+	rates, err := ers.storage.GetExchangeRatesByPair(from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+
+	var latest *ExchangeRate
+	for _, rate := range rates {
+		if rate.EffectiveDate.After(date) {
+			continue
+		}
+		if latest == nil || rate.EffectiveDate.After(latest.EffectiveDate) ||
+			(rate.EffectiveDate.Equal(latest.EffectiveDate) && rate.CreatedAt.After(latest.CreatedAt)) {
+			latest = rate
+		}
+	}
+
+	return latest, nil
+}
+
+// saveRate validates, records and persists a rate
+func (ers *ExchangeRateService) saveRate(from, to Currency, value float64, effectiveDate time.Time, source, userID string) (*ExchangeRate, error) {
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("exchange rate currencies are required")
+	}
+	if from == to {
+		return nil, fmt.Errorf("exchange rate currencies must differ")
+	}
+	if value <= 0 {
+		return nil, fmt.Errorf("exchange rate must be positive, got %v", value)
+	}
+
+	rate := &ExchangeRate{
+		ID:            newID(),
+		FromCurrency:  from,
+		ToCurrency:    to,
+		Rate:          value,
+		EffectiveDate: effectiveDate,
+		Source:        source,
+		CreatedBy:     userID,
+		CreatedAt:     time.Now(),
+	}
+
+	_, err := ers.eventStore.CreateEvent(EventSetExchangeRate, rate, effectiveDate, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exchange rate event: %w", err)
+	}
+
+	if err := ers.storage.SaveExchangeRate(rate); err != nil {
+		return nil, fmt.Errorf("failed to save exchange rate: %w", err)
+	}
+
+	return rate, nil
+}
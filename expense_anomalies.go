@@ -0,0 +1,575 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Expense Anomaly Detection
+// ----------------------------------------------------------------------------
+
+// ExpenseAnomalyType classifies an unusual pattern of spend
+type ExpenseAnomalyType string
+
+const (
+	ExpenseSpendSpike       ExpenseAnomalyType = "SPEND_SPIKE"       // a month well above the vendor's seasonal norm
+	ExpenseNewVendorSpend   ExpenseAnomalyType = "NEW_VENDOR_SPEND"  // large spend with a vendor straight after it first appears
+	ExpenseDuplicateAmounts ExpenseAnomalyType = "DUPLICATE_AMOUNTS" // the same amount paid to a vendor repeatedly in quick succession
+)
+
+// ExpenseAnomalyOptions tunes the expense anomaly detector. Amounts are minor units of
+// the analysis currency. Nil uses the defaults.
+type ExpenseAnomalyOptions struct {
+	VendorDimension DimensionKey `json:"vendor_dimension"` // names the vendor on expense entries or their transaction
+
+	BaselineMonths   int     `json:"baseline_months"`    // trailing months that set a vendor's normal spend
+	MinHistoryMonths int     `json:"min_history_months"` // months of history before a vendor's spend is judged
+	SpikeRatio       float64 `json:"spike_ratio"`        // multiple of expected spend that counts as a spike
+	SpikeZScore      float64 `json:"spike_z_score"`      // standard deviations above expected that counts as a spike
+	MinimumSpike     int64   `json:"minimum_spike"`      // smallest excess over expected worth reporting
+
+	NewVendorDays   int   `json:"new_vendor_days"`   // days after a vendor's first spend that count as immediate
+	NewVendorAmount int64 `json:"new_vendor_amount"` // immediate spend with a new vendor that is flagged
+
+	DuplicateWindowDays     int   `json:"duplicate_window_days"`     // longest gap between repeats of an amount
+	DuplicateMinOccurrences int   `json:"duplicate_min_occurrences"` // repeats that make a sequence
+	DuplicateMinAmount      int64 `json:"duplicate_min_amount"`      // smallest amount checked for duplicates
+}
+
+// DefaultExpenseAnomalyOptions returns the detector's default thresholds
+func DefaultExpenseAnomalyOptions() *ExpenseAnomalyOptions {
+	return &ExpenseAnomalyOptions{
+		VendorDimension:         DimCounterparty,
+		BaselineMonths:          12,
+		MinHistoryMonths:        3,
+		SpikeRatio:              2,
+		SpikeZScore:             3,
+		MinimumSpike:            10000,
+		NewVendorDays:           30,
+		NewVendorAmount:         500000,
+		DuplicateWindowDays:     7,
+		DuplicateMinOccurrences: 2,
+		DuplicateMinAmount:      10000,
+	}
+}
+
+// ExpenseAnomaly is one unusual pattern of spend with a vendor in an expense category
+type ExpenseAnomaly struct {
+	Type         ExpenseAnomalyType `json:"type"`
+	Severity     Severity           `json:"severity"`
+	Vendor       string             `json:"vendor"`   // empty for spend with no vendor
+	Category     string             `json:"category"` // expense account ID
+	CategoryName string             `json:"category_name"`
+	Currency     string             `json:"currency"`
+	Date         time.Time          `json:"date"` // month of a spike, otherwise the first transaction
+
+	Amount      int64    `json:"amount"`             // the month's spend, the immediate spend or the sequence total
+	Expected    int64    `json:"expected,omitempty"` // seasonal expectation for a spike
+	Ratio       float64  `json:"ratio,omitempty"`    // amount over expected for a spike
+	ZScore      *float64 `json:"z_score,omitempty"`  // nil when the baseline never varied
+	Occurrences int      `json:"occurrences,omitempty"`
+
+	Transactions []string `json:"transactions"`
+	Description  string   `json:"description"`
+}
+
+// ExpenseAnomalyReport is the expense anomalies found between two dates, most severe
+// first
+type ExpenseAnomalyReport struct {
+	FromDate       time.Time         `json:"from_date"`
+	ToDate         time.Time         `json:"to_date"`
+	Currency       string            `json:"currency"` // empty when each currency was analysed on its own
+	Anomalies      []*ExpenseAnomaly `json:"anomalies"`
+	SpikeCount     int               `json:"spike_count"`
+	NewVendorCount int               `json:"new_vendor_count"`
+	DuplicateCount int               `json:"duplicate_count"`
+	FlaggedSpend   int64             `json:"flagged_spend"` // in the report currency; zero when currencies were analysed separately
+}
+
+// ExpenseAnomalyService models typical spend per vendor and expense category and flags
+// spikes, large spend with new vendors and duplicate-amount sequences
+type ExpenseAnomalyService struct {
+	storage   *Storage
+	reporting *ReportingService
+}
+
+// NewExpenseAnomalyService creates a new expense anomaly service
+func NewExpenseAnomalyService(storage *Storage, reporting *ReportingService) *ExpenseAnomalyService {
+	return &ExpenseAnomalyService{
+		storage:   storage,
+		reporting: reporting,
+	}
+}
+
+// expenseLine is one posted expense entry with its vendor
+type expenseLine struct {
+	txnID    string
+	date     time.Time
+	vendor   string
+	category string
+	currency string // analysis currency
+	value    int64  // signed spend in the analysis currency, refunds negative
+	original Amount // the entry's own amount, for duplicate matching
+	debit    bool
+}
+
+// expenseSeries keys a vendor's spend in one category and currency
+type expenseSeries struct {
+	vendor   string
+	category string
+	currency string
+}
+
+// DetectExpenseAnomalies looks for anomalies in expense spend between two dates. Posted
+// history before the period sets the baselines. An empty currency analyses each
+// currency on its own without translation.
+func (es *ExpenseAnomalyService) DetectExpenseAnomalies(from, to time.Time, currency string, options *ExpenseAnomalyOptions) (*ExpenseAnomalyReport, error) {
+	if options == nil {
+		options = DefaultExpenseAnomalyOptions()
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("expense anomaly period ends before it starts")
+	}
+	accounts, err := es.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	names := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		names[account.ID] = account.Name
+	}
+	lines, err := es.expenseLines(accountTypes(accounts), to, currency, options.VendorDimension)
+	if err != nil {
+		return nil, err
+	}
+
+	var anomalies []*ExpenseAnomaly
+	anomalies = append(anomalies, detectSpendSpikes(lines, from, to, options)...)
+	anomalies = append(anomalies, detectNewVendorSpend(lines, from, to, options)...)
+	anomalies = append(anomalies, detectDuplicateAmounts(lines, from, to, options)...)
+
+	report := &ExpenseAnomalyReport{FromDate: from, ToDate: to, Currency: currency, Anomalies: anomalies}
+	for _, anomaly := range anomalies {
+		anomaly.CategoryName = names[anomaly.Category]
+		switch anomaly.Type {
+		case ExpenseSpendSpike:
+			report.SpikeCount++
+		case ExpenseNewVendorSpend:
+			report.NewVendorCount++
+		case ExpenseDuplicateAmounts:
+			report.DuplicateCount++
+		}
+		if currency != "" {
+			report.FlaggedSpend += anomaly.Amount
+		}
+	}
+	rank := map[Severity]int{SeverityHigh: 0, SeverityMedium: 1, SeverityLow: 2}
+	sort.SliceStable(anomalies, func(i, j int) bool {
+		if rank[anomalies[i].Severity] != rank[anomalies[j].Severity] {
+			return rank[anomalies[i].Severity] < rank[anomalies[j].Severity]
+		}
+		if anomalies[i].Amount != anomalies[j].Amount {
+			return anomalies[i].Amount > anomalies[j].Amount
+		}
+		if !anomalies[i].Date.Equal(anomalies[j].Date) {
+			return anomalies[i].Date.Before(anomalies[j].Date)
+		}
+		return anomalies[i].Vendor+anomalies[i].Category < anomalies[j].Vendor+anomalies[j].Category
+	})
+	return report, nil
+}
+
+// expenseLines gathers posted expense entries up to a date, oldest first
+func (es *ExpenseAnomalyService) expenseLines(types map[string]AccountType, to time.Time, currency string, key DimensionKey) ([]*expenseLine, error) {
+	txns, err := es.storage.GetAllTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	var lines []*expenseLine
+	for _, txn := range txns {
+		if txn.Status != Posted || txn.ValidTime.After(to) {
+			continue
+		}
+		for i := range txn.Entries {
+			entry := &txn.Entries[i]
+			if types[entry.AccountID] != Expense {
+				continue
+			}
+			line := &expenseLine{
+				txnID:    txn.ID,
+				date:     txn.ValidTime,
+				vendor:   entryDimension(entry, key),
+				category: entry.AccountID,
+				currency: string(entry.Amount.Currency),
+				value:    entry.Amount.Value,
+				original: entry.Amount,
+				debit:    entry.Type == Debit,
+			}
+			if line.vendor == "" {
+				line.vendor = transactionDimension(txn, key)
+			}
+			if currency != "" {
+				translated, err := es.reporting.translateAmount(&entry.Amount, currency, txn.ValidTime)
+				if err != nil {
+					return nil, fmt.Errorf("failed to translate spend on %s: %w", entry.AccountID, err)
+				}
+				line.currency, line.value = currency, translated.Value
+			}
+			if !line.debit {
+				line.value = -line.value
+			}
+			lines = append(lines, line)
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].date.Before(lines[j].date) })
+	return lines, nil
+}
+
+// expenseMonth is the first of a date's month in UTC
+func expenseMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// detectSpendSpikes compares each month's spend per vendor and category with the
+// trailing average, scaled by the category's seasonal index for that month. The index
+// is the category's spend in the same month a year earlier over its average for the
+// twelve months that followed, so spend that always peaks at year end is expected to.
+func detectSpendSpikes(lines []*expenseLine, from, to time.Time, options *ExpenseAnomalyOptions) []*ExpenseAnomaly {
+	type categoryKey struct{ category, currency string }
+	monthly := make(map[expenseSeries]map[time.Time]int64)
+	categories := make(map[categoryKey]map[time.Time]int64)
+	firstMonth := make(map[expenseSeries]time.Time)
+	categoryFirst := make(map[categoryKey]time.Time)
+	txnsByMonth := make(map[expenseSeries]map[time.Time][]string)
+	for _, line := range lines {
+		series := expenseSeries{line.vendor, line.category, line.currency}
+		category := categoryKey{line.category, line.currency}
+		month := expenseMonth(line.date)
+		if monthly[series] == nil {
+			monthly[series] = make(map[time.Time]int64)
+			txnsByMonth[series] = make(map[time.Time][]string)
+			firstMonth[series] = month
+		}
+		if categories[category] == nil {
+			categories[category] = make(map[time.Time]int64)
+			categoryFirst[category] = month
+		}
+		monthly[series][month] += line.value
+		categories[category][month] += line.value
+		if ids := txnsByMonth[series][month]; len(ids) == 0 || ids[len(ids)-1] != line.txnID {
+			txnsByMonth[series][month] = append(ids, line.txnID)
+		}
+	}
+
+	seasonalIndex := func(category categoryKey, month time.Time) float64 {
+		yearAgo := month.AddDate(-1, 0, 0)
+		if categoryFirst[category].After(yearAgo) {
+			return 1
+		}
+		total := int64(0)
+		for at := yearAgo; at.Before(month); at = at.AddDate(0, 1, 0) {
+			total += categories[category][at]
+		}
+		if total <= 0 {
+			return 1
+		}
+		return float64(categories[category][yearAgo]) / (float64(total) / 12)
+	}
+
+	var anomalies []*ExpenseAnomaly
+	for series, spend := range monthly {
+		for month := expenseMonth(from); !month.After(to); month = month.AddDate(0, 1, 0) {
+			actual := spend[month]
+			if actual <= 0 {
+				continue
+			}
+			start := month.AddDate(0, -options.BaselineMonths, 0)
+			if start.Before(firstMonth[series]) {
+				start = firstMonth[series]
+			}
+			var history []float64
+			for at := start; at.Before(month); at = at.AddDate(0, 1, 0) {
+				history = append(history, float64(spend[at]))
+			}
+			if len(history) < options.MinHistoryMonths {
+				continue
+			}
+			mean, deviation := meanAndDeviation(history)
+			expected := mean * seasonalIndex(categoryKey{series.category, series.currency}, month)
+			excess := float64(actual) - expected
+			if excess < float64(options.MinimumSpike) || float64(actual) < expected*options.SpikeRatio {
+				continue
+			}
+			var zScore *float64
+			if deviation > 0 {
+				z := excess / deviation
+				if z < options.SpikeZScore {
+					continue
+				}
+				zScore = &z
+			}
+
+			anomaly := &ExpenseAnomaly{
+				Type:         ExpenseSpendSpike,
+				Severity:     SeverityMedium,
+				Vendor:       series.vendor,
+				Category:     series.category,
+				Currency:     series.currency,
+				Date:         month,
+				Amount:       actual,
+				Expected:     int64(math.Round(expected)),
+				ZScore:       zScore,
+				Transactions: txnsByMonth[series][month],
+			}
+			if expected > 0 {
+				anomaly.Ratio = float64(actual) / expected
+			}
+			if expected <= 0 || anomaly.Ratio >= 2*options.SpikeRatio {
+				anomaly.Severity = SeverityHigh
+			}
+			anomaly.Description = fmt.Sprintf("%s spend on %s in %s was %s against an expected %s",
+				vendorLabel(series.vendor), series.category, month.Format("2006-01"),
+				FormatMinorUnits(actual, Currency(series.currency)), FormatMinorUnits(anomaly.Expected, Currency(series.currency)))
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+	return anomalies
+}
+
+// detectNewVendorSpend flags vendors first paid during the period whose spend in the
+// days after that first payment reaches the new vendor threshold
+func detectNewVendorSpend(lines []*expenseLine, from, to time.Time, options *ExpenseAnomalyOptions) []*ExpenseAnomaly {
+	type vendorKey struct{ vendor, currency string }
+	byVendor := make(map[vendorKey][]*expenseLine)
+	var order []vendorKey
+	for _, line := range lines {
+		if line.vendor == "" || !line.debit {
+			continue
+		}
+		key := vendorKey{line.vendor, line.currency}
+		if byVendor[key] == nil {
+			order = append(order, key)
+		}
+		byVendor[key] = append(byVendor[key], line)
+	}
+
+	var anomalies []*ExpenseAnomaly
+	for _, key := range order {
+		vendorLines := byVendor[key]
+		first := vendorLines[0]
+		if first.date.Before(from) || first.date.After(to) {
+			continue
+		}
+		window := first.date.AddDate(0, 0, options.NewVendorDays)
+		spend := int64(0)
+		categories := make(map[string]int64)
+		var txnIDs []string
+		for _, line := range vendorLines {
+			if line.date.After(window) {
+				break
+			}
+			spend += line.value
+			categories[line.category] += line.value
+			if len(txnIDs) == 0 || txnIDs[len(txnIDs)-1] != line.txnID {
+				txnIDs = append(txnIDs, line.txnID)
+			}
+		}
+		if spend < options.NewVendorAmount {
+			continue
+		}
+
+		anomaly := &ExpenseAnomaly{
+			Type:         ExpenseNewVendorSpend,
+			Severity:     SeverityMedium,
+			Vendor:       key.vendor,
+			Category:     largestCategory(categories),
+			Currency:     key.currency,
+			Date:         first.date,
+			Amount:       spend,
+			Occurrences:  len(txnIDs),
+			Transactions: txnIDs,
+			Description: fmt.Sprintf("New vendor %s was paid %s within %d days of first appearing on %s",
+				key.vendor, FormatMinorUnits(spend, Currency(key.currency)), options.NewVendorDays, first.date.Format("2006-01-02")),
+		}
+		if first.value >= options.NewVendorAmount {
+			anomaly.Severity = SeverityHigh
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+	return anomalies
+}
+
+// detectDuplicateAmounts flags runs of separate transactions paying a vendor the same
+// amount with no more than the duplicate window between them
+func detectDuplicateAmounts(lines []*expenseLine, from, to time.Time, options *ExpenseAnomalyOptions) []*ExpenseAnomaly {
+	type amountKey struct {
+		vendor string
+		amount Amount
+	}
+	byAmount := make(map[amountKey][]*expenseLine)
+	var order []amountKey
+	for _, line := range lines {
+		if line.vendor == "" || !line.debit || line.original.Value < options.DuplicateMinAmount {
+			continue
+		}
+		key := amountKey{line.vendor, line.original}
+		if previous := byAmount[key]; len(previous) > 0 && previous[len(previous)-1].txnID == line.txnID {
+			continue
+		}
+		if byAmount[key] == nil {
+			order = append(order, key)
+		}
+		byAmount[key] = append(byAmount[key], line)
+	}
+
+	window := time.Duration(options.DuplicateWindowDays) * 24 * time.Hour
+	var anomalies []*ExpenseAnomaly
+	for _, key := range order {
+		repeats := byAmount[key]
+		for start := 0; start < len(repeats); {
+			end := start + 1
+			for end < len(repeats) && repeats[end].date.Sub(repeats[end-1].date) <= window {
+				end++
+			}
+			run := repeats[start:end]
+			start = end
+			if len(run) < options.DuplicateMinOccurrences || run[len(run)-1].date.Before(from) || run[0].date.After(to) {
+				continue
+			}
+
+			anomaly := &ExpenseAnomaly{
+				Type:        ExpenseDuplicateAmounts,
+				Severity:    SeverityMedium,
+				Vendor:      key.vendor,
+				Category:    run[0].category,
+				Currency:    run[0].currency,
+				Date:        run[0].date,
+				Occurrences: len(run),
+				Description: fmt.Sprintf("%s was paid %s %d times between %s and %s",
+					key.vendor, FormatMinorUnits(key.amount.Value, key.amount.Currency), len(run),
+					run[0].date.Format("2006-01-02"), run[len(run)-1].date.Format("2006-01-02")),
+			}
+			for _, line := range run {
+				anomaly.Amount += line.value
+				anomaly.Transactions = append(anomaly.Transactions, line.txnID)
+			}
+			if len(run) > 2 {
+				anomaly.Severity = SeverityHigh
+			}
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+	return anomalies
+}
+
+// meanAndDeviation returns the mean and population standard deviation of values
+func meanAndDeviation(values []float64) (float64, float64) {
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+	mean := sum / float64(len(values))
+	variance := 0.0
+	for _, value := range values {
+		variance += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// largestCategory is the category with the most spend, the first by name on a tie
+func largestCategory(spend map[string]int64) string {
+	largest := ""
+	for category, value := range spend {
+		if largest == "" || value > spend[largest] || (value == spend[largest] && category < largest) {
+			largest = category
+		}
+	}
+	return largest
+}
+
+// vendorLabel names a vendor in descriptions
+func vendorLabel(vendor string) string {
+	if vendor == "" {
+		return "Unattributed"
+	}
+	return vendor
+}
+
+// expenseAnomalyPatterns presents expense anomalies as forensic patterns
+func expenseAnomalyPatterns(report *ExpenseAnomalyReport) []SuspiciousPattern {
+	flags := map[ExpenseAnomalyType]FlagType{
+		ExpenseSpendSpike:       FlagExpenseSpike,
+		ExpenseNewVendorSpend:   FlagNewVendorSpend,
+		ExpenseDuplicateAmounts: FlagDuplicateAmounts,
+	}
+	var patterns []SuspiciousPattern
+	for _, anomaly := range report.Anomalies {
+		pattern := SuspiciousPattern{
+			ID:           newID(),
+			Type:         flags[anomaly.Type],
+			Severity:     anomaly.Severity,
+			Description:  anomaly.Description,
+			Accounts:     []string{anomaly.Category},
+			Transactions: anomaly.Transactions,
+			Timeline:     []time.Time{anomaly.Date},
+			Evidence:     []string{fmt.Sprintf("vendor %s", vendorLabel(anomaly.Vendor))},
+			DetectedAt:   time.Now(),
+		}
+		switch anomaly.Type {
+		case ExpenseSpendSpike:
+			pattern.Confidence = 1 - float64(anomaly.Expected)/float64(anomaly.Amount)
+			if anomaly.ZScore != nil {
+				pattern.Evidence = append(pattern.Evidence, fmt.Sprintf("%.1f standard deviations above expected", *anomaly.ZScore))
+			}
+		case ExpenseNewVendorSpend:
+			pattern.Confidence = 0.6
+		case ExpenseDuplicateAmounts:
+			pattern.Confidence = math.Min(0.5+0.1*float64(anomaly.Occurrences), 0.95)
+		}
+		pattern.Evidence = append(pattern.Evidence, fmt.Sprintf("%d transactions", len(anomaly.Transactions)))
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// ExpenseExceptionsDocument lays out expense anomalies as a management exceptions
+// report for export
+func ExpenseExceptionsDocument(report *ExpenseAnomalyReport) *ReportDocument {
+	sheet := &ReportSheet{Name: "Expense Exceptions", Columns: []string{"Severity", "Type", "Date", "Vendor", "Category",
+		"Currency", "Amount", "Expected", "Occurrences", "Detail"}}
+	for _, anomaly := range report.Anomalies {
+		currency := Currency(anomaly.Currency)
+		expected := TextCell("")
+		if anomaly.Type == ExpenseSpendSpike {
+			expected = AmountCell(anomaly.Expected, currency)
+		}
+		sheet.Rows = append(sheet.Rows, []ReportCell{
+			TextCell(strings.ToUpper(string(anomaly.Severity))),
+			TextCell(string(anomaly.Type)),
+			TextCell(anomaly.Date.Format("2006-01-02")),
+			TextCell(vendorLabel(anomaly.Vendor)),
+			TextCell(anomaly.CategoryName),
+			TextCell(anomaly.Currency),
+			AmountCell(anomaly.Amount, currency),
+			expected,
+			NumberCell(float64(max(anomaly.Occurrences, 1)), 0),
+			TextCell(anomaly.Description),
+		})
+	}
+
+	subtitle := fmt.Sprintf("%s to %s: %d spikes, %d new vendors, %d duplicate sequences", report.FromDate.Format("2006-01-02"),
+		report.ToDate.Format("2006-01-02"), report.SpikeCount, report.NewVendorCount, report.DuplicateCount)
+	if report.Currency != "" {
+		subtitle += fmt.Sprintf(", %s flagged", FormatMinorUnits(report.FlaggedSpend, Currency(report.Currency)))
+	}
+	return &ReportDocument{
+		Title:    "Expense Exceptions",
+		Subtitle: subtitle,
+		Sheets:   []*ReportSheet{sheet},
+	}
+}
@@ -0,0 +1,154 @@
+package accounting
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpenseAnomalies(t *testing.T) {
+	dbFile := "test_expense_anomalies.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "marketing", Name: "Marketing", Type: Expense}, userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "software", Name: "Software", Type: Expense}, userID))
+
+	spend := func(vendor, category string, date time.Time, amount int64) {
+		txn := &Transaction{
+			Description: "Paid " + vendor,
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: category, Type: Debit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: []Dimension{{Key: DimCounterparty, Value: vendor}}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: amount, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	for month := day(2024, 1, 15); month.Year() < 2026; month = month.AddDate(0, 1, 0) {
+		if month.Month() == time.December {
+			spend("adco", "marketing", month, 400000) // the holiday campaign, every year
+		} else {
+			spend("adco", "marketing", month, 100000)
+		}
+	}
+	for month := day(2025, 1, 1); month.Year() < 2026; month = month.AddDate(0, 1, 0) {
+		if month.Month() == time.November {
+			spend("cloudco", "software", month.AddDate(0, 0, 9), 250000)
+		} else {
+			spend("cloudco", "software", month.AddDate(0, 0, 9), 50000)
+		}
+		if month.Month() <= time.November {
+			spend("printco", "expenses", month, 45000)
+		}
+	}
+	spend("printco", "expenses", day(2025, 11, 3), 45000)
+	spend("printco", "expenses", day(2025, 11, 5), 45000)
+	spend("fastconsult", "expenses", day(2025, 10, 5), 300000)
+	spend("fastconsult", "expenses", day(2025, 10, 20), 300000)
+
+	from, to := day(2025, 10, 1), day(2025, 12, 31)
+	report, err := engine.DetectExpenseAnomalies(from, to, "USD", nil)
+	require.NoError(t, err)
+
+	type found struct {
+		kind     ExpenseAnomalyType
+		severity Severity
+		vendor   string
+		amount   int64
+	}
+	var anomalies []found
+	for _, anomaly := range report.Anomalies {
+		anomalies = append(anomalies, found{anomaly.Type, anomaly.Severity, anomaly.Vendor, anomaly.Amount})
+	}
+	assert.Equal(t, []found{
+		{ExpenseSpendSpike, SeverityHigh, "cloudco", 250000},
+		{ExpenseDuplicateAmounts, SeverityHigh, "printco", 135000},
+		{ExpenseNewVendorSpend, SeverityMedium, "fastconsult", 600000},
+		{ExpenseSpendSpike, SeverityMedium, "printco", 135000},
+	}, anomalies, "the seasonal December marketing spend is not a spike")
+	assert.Equal(t, 2, report.SpikeCount)
+	assert.Equal(t, 1, report.NewVendorCount)
+	assert.Equal(t, 1, report.DuplicateCount)
+	assert.Equal(t, int64(1120000), report.FlaggedSpend)
+
+	spike := report.Anomalies[0]
+	assert.Equal(t, "software", spike.Category)
+	assert.Equal(t, "Software", spike.CategoryName)
+	assert.Equal(t, day(2025, 11, 1), spike.Date)
+	assert.Equal(t, int64(50000), spike.Expected)
+	assert.InDelta(t, 5.0, spike.Ratio, 1e-9)
+	assert.Nil(t, spike.ZScore, "a flat baseline has no deviation")
+	assert.Len(t, spike.Transactions, 1)
+
+	duplicates := report.Anomalies[1]
+	assert.Equal(t, 3, duplicates.Occurrences)
+	assert.Equal(t, day(2025, 11, 1), duplicates.Date)
+	assert.Len(t, duplicates.Transactions, 3)
+
+	assert.Equal(t, 2, report.Anomalies[2].Occurrences)
+	assert.Equal(t, day(2025, 10, 5), report.Anomalies[2].Date)
+
+	t.Run("spend that did not repeat its seasonal peak is judged against the year before", func(t *testing.T) {
+		report, err := engine.DetectExpenseAnomalies(day(2024, 12, 1), day(2024, 12, 31), "USD", nil)
+		require.NoError(t, err)
+		require.Len(t, report.Anomalies, 1)
+		assert.Equal(t, "adco", report.Anomalies[0].Vendor)
+		assert.Equal(t, int64(100000), report.Anomalies[0].Expected, "no prior December to learn from")
+	})
+
+	t.Run("thresholds are configurable", func(t *testing.T) {
+		options := DefaultExpenseAnomalyOptions()
+		options.SpikeRatio = 4
+		options.NewVendorAmount = 1000000
+		options.DuplicateMinOccurrences = 4
+		report, err := engine.DetectExpenseAnomalies(from, to, "USD", options)
+		require.NoError(t, err)
+		require.Len(t, report.Anomalies, 1)
+		assert.Equal(t, "cloudco", report.Anomalies[0].Vendor)
+
+		_, err = engine.DetectExpenseAnomalies(to, from, "USD", nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("anomalies feed the forensic patterns", func(t *testing.T) {
+		patterns, err := engine.GetForensicService().DetectSuspiciousPatterns(from, to, "")
+		require.NoError(t, err)
+		counts := make(map[FlagType]int)
+		for _, pattern := range patterns {
+			counts[pattern.Type]++
+			if pattern.Type == FlagDuplicateAmounts {
+				assert.Equal(t, SeverityHigh, pattern.Severity)
+				assert.Equal(t, []string{"expenses"}, pattern.Accounts)
+				assert.Len(t, pattern.Transactions, 3)
+				assert.InDelta(t, 0.8, pattern.Confidence, 1e-9)
+			}
+		}
+		assert.Equal(t, 2, counts[FlagExpenseSpike])
+		assert.Equal(t, 1, counts[FlagNewVendorSpend])
+		assert.Equal(t, 1, counts[FlagDuplicateAmounts])
+	})
+
+	t.Run("exceptions report", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, ExportReport(&buf, ExportFormatCSV, ExpenseExceptionsDocument(report)))
+		output := buf.String()
+		assert.Contains(t, output, "Severity,Type,Date,Vendor,Category,Currency,Amount,Expected,Occurrences,Detail")
+		assert.Contains(t, output, "HIGH,SPEND_SPIKE,2025-11-01,cloudco,Software,USD,2500.00,500.00,1,")
+		assert.Contains(t, output, "MEDIUM,NEW_VENDOR_SPEND,2025-10-05,fastconsult,Operating Expenses,USD,6000.00,,2,")
+	})
+}
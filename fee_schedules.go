@@ -0,0 +1,484 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Fee Schedule Structures
+// ----------------------------------------------------------------------------
+
+// FeeDirection is whether a fee schedule is charged by us or to us
+type FeeDirection string
+
+const (
+	FeeRevenue FeeDirection = "REVENUE" // fees we charge, e.g. merchant discount rate
+	FeeExpense FeeDirection = "EXPENSE" // fees we pay, e.g. interchange and scheme fees
+)
+
+// FeeTier is one rate in a fee schedule. The tier applied to a transaction is the
+// highest MinVolume reached by month-to-date volume before it, preferring tiers for
+// the transaction's currency over tiers with no currency.
+type FeeTier struct {
+	Currency    Currency `json:"currency,omitempty"` // empty applies to any currency
+	MinVolume   int64    `json:"min_volume"`         // month-to-date volume at which the tier starts
+	PercentRate float64  `json:"percent_rate"`       // e.g. 0.029 for 2.9%
+	FixedFee    int64    `json:"fixed_fee"`          // per transaction, in minor units
+}
+
+// FeeSchedule prices a stream of transactions: every debit to the source account
+// incurs a fee, journaled between the fee account and the counter account
+type FeeSchedule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ProviderID       string       `json:"provider_id,omitempty"` // whose statements bill the fees
+	Direction        FeeDirection `json:"direction"`
+	SourceAccountID  string       `json:"source_account_id"`  // debits to this account are the stream
+	FeeAccountID     string       `json:"fee_account_id"`     // revenue or expense
+	CounterAccountID string       `json:"counter_account_id"` // receivable, payable or clearing
+	Tiers            []FeeTier    `json:"tiers"`
+	Active           bool         `json:"active"`
+	CreatedBy        string       `json:"created_by"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+}
+
+// tierFor returns the tier for a currency at a month-to-date volume
+func (fs *FeeSchedule) tierFor(currency Currency, volume int64) (int, bool) {
+	best := -1
+	for i, tier := range fs.Tiers {
+		if tier.Currency != "" && tier.Currency != currency {
+			continue
+		}
+		if tier.MinVolume > volume {
+			continue
+		}
+		if best >= 0 {
+			current := fs.Tiers[best]
+			if current.Currency != "" && tier.Currency == "" {
+				continue
+			}
+			if current.Currency == tier.Currency && current.MinVolume >= tier.MinVolume {
+				continue
+			}
+		}
+		best = i
+	}
+	return best, best >= 0
+}
+
+// FeeCharge is the fee computed for one transaction in a stream
+type FeeCharge struct {
+	ID            string    `json:"id"` // schedule ID and source transaction ID
+	ScheduleID    string    `json:"schedule_id"`
+	TransactionID string    `json:"transaction_id"`
+	Reference     string    `json:"reference"` // the transaction's SourceRef, or its ID
+	Currency      Currency  `json:"currency"`
+	BaseAmount    int64     `json:"base_amount"`
+	VolumeBefore  int64     `json:"volume_before"` // month-to-date volume the tier was chosen on
+	TierIndex     int       `json:"tier_index"`
+	PercentRate   float64   `json:"percent_rate"`
+	FixedFee      int64     `json:"fixed_fee"`
+	FeeAmount     int64     `json:"fee_amount"`
+	ValidTime     time.Time `json:"valid_time"`
+	JournalID     string    `json:"journal_id"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// FeeStatementLine is one fee billed on a provider statement
+type FeeStatementLine struct {
+	Reference string `json:"reference"` // the source transaction's SourceRef or ID
+	Amount    int64  `json:"amount"`
+}
+
+// FeeReconciliationStatus is the outcome of matching a statement line to a charge
+type FeeReconciliationStatus string
+
+const (
+	FeeMatched        FeeReconciliationStatus = "MATCHED"
+	FeeAmountMismatch FeeReconciliationStatus = "AMOUNT_MISMATCH"
+	FeeNotBilled      FeeReconciliationStatus = "NOT_BILLED"    // charged in the ledger, missing from the statement
+	FeeNotInLedger    FeeReconciliationStatus = "NOT_IN_LEDGER" // billed, with no charge in the ledger
+)
+
+// FeeReconciliationLine compares the fee expected for a reference with the fee billed
+type FeeReconciliationLine struct {
+	Reference     string                  `json:"reference"`
+	TransactionID string                  `json:"transaction_id,omitempty"`
+	Expected      int64                   `json:"expected"`
+	Billed        int64                   `json:"billed"`
+	Difference    int64                   `json:"difference"` // billed less expected
+	Status        FeeReconciliationStatus `json:"status"`
+}
+
+// FeeReconciliation compares a provider's fee statement with the charges the ledger
+// computed for the same schedule and period
+type FeeReconciliation struct {
+	ID            string                  `json:"id"`
+	ScheduleID    string                  `json:"schedule_id"`
+	StatementRef  string                  `json:"statement_ref"`
+	PeriodStart   time.Time               `json:"period_start"`
+	PeriodEnd     time.Time               `json:"period_end"`
+	Lines         []FeeReconciliationLine `json:"lines"`
+	ExpectedTotal int64                   `json:"expected_total"`
+	BilledTotal   int64                   `json:"billed_total"`
+	Difference    int64                   `json:"difference"`
+	Exceptions    int                     `json:"exceptions"`
+	ReconciledBy  string                  `json:"reconciled_by"`
+	ReconciledAt  time.Time               `json:"reconciled_at"`
+}
+
+// ----------------------------------------------------------------------------
+// Fee Schedule Service
+// ----------------------------------------------------------------------------
+
+// FeeScheduleService applies fee schedules to transaction streams, journals the fees
+// and reconciles them against provider statements
+type FeeScheduleService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+}
+
+// NewFeeScheduleService creates a new fee schedule service
+func NewFeeScheduleService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *FeeScheduleService {
+	return &FeeScheduleService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+	}
+}
+
+// SaveSchedule validates and saves a fee schedule
+func (fss *FeeScheduleService) SaveSchedule(schedule *FeeSchedule, userID string) error {
+	if schedule.ID == "" {
+		return fmt.Errorf("fee schedule ID is required")
+	}
+	if schedule.Direction != FeeRevenue && schedule.Direction != FeeExpense {
+		return fmt.Errorf("invalid fee direction: %s", schedule.Direction)
+	}
+	if len(schedule.Tiers) == 0 {
+		return fmt.Errorf("fee schedule needs at least one tier")
+	}
+	for _, tier := range schedule.Tiers {
+		if tier.MinVolume < 0 || tier.FixedFee < 0 || tier.PercentRate < 0 || tier.PercentRate > 1 || math.IsNaN(tier.PercentRate) {
+			return fmt.Errorf("invalid fee tier: rates must be between 0 and 1 and amounts non-negative")
+		}
+	}
+	if schedule.SourceAccountID == schedule.CounterAccountID {
+		return fmt.Errorf("counter account must differ from the source account")
+	}
+
+	feeType := Income
+	if schedule.Direction == FeeExpense {
+		feeType = Expense
+	}
+	for _, accountID := range []string{schedule.SourceAccountID, schedule.FeeAccountID, schedule.CounterAccountID} {
+		account, err := fss.storage.GetAccount(accountID)
+		if err != nil {
+			return fmt.Errorf("invalid fee schedule account: %w", err)
+		}
+		if accountID == schedule.FeeAccountID && account.Type != feeType {
+			return fmt.Errorf("fee account %s must be a %s account", accountID, strings.ToLower(string(feeType)))
+		}
+	}
+
+	now := time.Now()
+	if existing, err := fss.storage.GetFeeSchedule(schedule.ID); err == nil {
+		schedule.CreatedBy = existing.CreatedBy
+		schedule.CreatedAt = existing.CreatedAt
+	} else {
+		schedule.CreatedBy = userID
+		schedule.CreatedAt = now
+	}
+	schedule.UpdatedAt = now
+
+	_, err := fss.eventStore.CreateEvent(EventSaveFeeSchedule, schedule, now, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create fee schedule event: %w", err)
+	}
+	if err := fss.storage.SaveFeeSchedule(schedule); err != nil {
+		return fmt.Errorf("failed to save fee schedule: %w", err)
+	}
+	return nil
+}
+
+// ApplyFees prices every uncharged transaction in the schedule's stream posted in the
+// period and journals the fees, one journal per currency. Transactions already charged
+// are skipped, so overlapping runs are safe.
+func (fss *FeeScheduleService) ApplyFees(scheduleID string, periodStart, periodEnd time.Time, userID string) ([]*FeeCharge, error) {
+	schedule, err := fss.storage.GetFeeSchedule(scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee schedule: %w", err)
+	}
+	if !schedule.Active {
+		return nil, fmt.Errorf("fee schedule %s is not active", scheduleID)
+	}
+
+	existing, err := fss.GetCharges(scheduleID, time.Time{}, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	charged := make(map[string]bool)
+	volumes := make(map[string]int64) // by currency and month
+	for _, charge := range existing {
+		charged[charge.TransactionID] = true
+	}
+
+	// Tiers depend on volume from the start of the first month in the run
+	monthStart := time.Date(periodStart.Year(), periodStart.Month(), 1, 0, 0, 0, 0, periodStart.Location())
+	txns, err := fss.storage.GetTransactionsByDateRange("", monthStart, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	sort.SliceStable(txns, func(i, j int) bool {
+		return txns[i].ValidTime.Before(txns[j].ValidTime)
+	})
+
+	now := time.Now()
+	var charges []*FeeCharge
+	for _, txn := range txns {
+		if txn.Status != Posted || strings.HasPrefix(txn.SourceRef, "FEE_") {
+			continue
+		}
+		var base int64
+		var currency Currency
+		for i := range txn.Entries {
+			entry := &txn.Entries[i]
+			if entry.AccountID == schedule.SourceAccountID && entry.Type == Debit {
+				base += entry.Amount.Value
+				currency = entry.Amount.Currency
+			}
+		}
+		if base == 0 {
+			continue
+		}
+
+		volumeKey := string(currency) + txn.ValidTime.Format("2006-01")
+		volumeBefore := volumes[volumeKey]
+		volumes[volumeKey] += base
+		if charged[txn.ID] || txn.ValidTime.Before(periodStart) {
+			continue
+		}
+
+		tierIndex, ok := schedule.tierFor(currency, volumeBefore)
+		if !ok {
+			continue
+		}
+		tier := schedule.Tiers[tierIndex]
+		percentFee, err := applyRate(base, tier.PercentRate, RoundHalfUp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate fee: %w", err)
+		}
+		reference := txn.SourceRef
+		if reference == "" {
+			reference = txn.ID
+		}
+		charges = append(charges, &FeeCharge{
+			ID:            scheduleID + "_" + txn.ID,
+			ScheduleID:    scheduleID,
+			TransactionID: txn.ID,
+			Reference:     reference,
+			Currency:      currency,
+			BaseAmount:    base,
+			VolumeBefore:  volumeBefore,
+			TierIndex:     tierIndex,
+			PercentRate:   tier.PercentRate,
+			FixedFee:      tier.FixedFee,
+			FeeAmount:     percentFee + tier.FixedFee,
+			ValidTime:     txn.ValidTime,
+			CreatedAt:     now,
+		})
+	}
+
+	byCurrency := make(map[Currency][]*FeeCharge)
+	var currencies []string
+	for _, charge := range charges {
+		if _, seen := byCurrency[charge.Currency]; !seen {
+			currencies = append(currencies, string(charge.Currency))
+		}
+		byCurrency[charge.Currency] = append(byCurrency[charge.Currency], charge)
+	}
+	sort.Strings(currencies)
+
+	for _, code := range currencies {
+		currency := Currency(code)
+		var total int64
+		for _, charge := range byCurrency[currency] {
+			total += charge.FeeAmount
+		}
+		if total > 0 {
+			txn := fss.feeTransaction(schedule, currency, total, periodStart, periodEnd, userID)
+			if err := fss.postTransaction(txn, userID); err != nil {
+				return nil, err
+			}
+			for _, charge := range byCurrency[currency] {
+				charge.JournalID = txn.ID
+			}
+		}
+		for _, charge := range byCurrency[currency] {
+			_, err := fss.eventStore.CreateEvent(EventChargeFee, charge, charge.ValidTime, userID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create fee charge event: %w", err)
+			}
+			if err := fss.storage.SaveFeeCharge(charge); err != nil {
+				return nil, fmt.Errorf("failed to save fee charge: %w", err)
+			}
+		}
+	}
+	return charges, nil
+}
+
+// ReconcileStatement matches the fees billed on a provider statement against the
+// schedule's charges for the period, line by reference
+func (fss *FeeScheduleService) ReconcileStatement(scheduleID, statementRef string, periodStart, periodEnd time.Time, lines []FeeStatementLine, userID string) (*FeeReconciliation, error) {
+	if statementRef == "" {
+		return nil, fmt.Errorf("statement reference is required")
+	}
+	if _, err := fss.storage.GetFeeSchedule(scheduleID); err != nil {
+		return nil, fmt.Errorf("failed to get fee schedule: %w", err)
+	}
+	charges, err := fss.GetCharges(scheduleID, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	recon := &FeeReconciliation{
+		ID:           scheduleID + "_" + statementRef,
+		ScheduleID:   scheduleID,
+		StatementRef: statementRef,
+		PeriodStart:  periodStart,
+		PeriodEnd:    periodEnd,
+		ReconciledBy: userID,
+		ReconciledAt: time.Now(),
+	}
+	if _, err := fss.storage.GetFeeReconciliation(recon.ID); err == nil {
+		return nil, fmt.Errorf("statement %s has already been reconciled", statementRef)
+	}
+
+	byReference := make(map[string]*FeeReconciliationLine)
+	var references []string
+	for _, charge := range charges {
+		line, ok := byReference[charge.Reference]
+		if !ok {
+			line = &FeeReconciliationLine{Reference: charge.Reference, TransactionID: charge.TransactionID}
+			byReference[charge.Reference] = line
+			references = append(references, charge.Reference)
+		}
+		line.Expected += charge.FeeAmount
+		recon.ExpectedTotal += charge.FeeAmount
+	}
+	billed := make(map[string]bool)
+	for _, statementLine := range lines {
+		line, ok := byReference[statementLine.Reference]
+		if !ok {
+			line = &FeeReconciliationLine{Reference: statementLine.Reference}
+			byReference[statementLine.Reference] = line
+			references = append(references, statementLine.Reference)
+		}
+		line.Billed += statementLine.Amount
+		billed[statementLine.Reference] = true
+		recon.BilledTotal += statementLine.Amount
+	}
+
+	for _, reference := range references {
+		line := byReference[reference]
+		line.Difference = line.Billed - line.Expected
+		switch {
+		case line.TransactionID == "":
+			line.Status = FeeNotInLedger
+		case !billed[reference]:
+			line.Status = FeeNotBilled
+		case line.Difference != 0:
+			line.Status = FeeAmountMismatch
+		default:
+			line.Status = FeeMatched
+		}
+		if line.Status != FeeMatched {
+			recon.Exceptions++
+		}
+		recon.Lines = append(recon.Lines, *line)
+	}
+	recon.Difference = recon.BilledTotal - recon.ExpectedTotal
+
+	_, err = fss.eventStore.CreateEvent(EventReconcileFeeStatement, recon, periodEnd, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fee reconciliation event: %w", err)
+	}
+	if err := fss.storage.SaveFeeReconciliation(recon); err != nil {
+		return nil, fmt.Errorf("failed to save fee reconciliation: %w", err)
+	}
+	return recon, nil
+}
+
+// GetCharges returns a schedule's fee charges for transactions in the period, oldest first
+func (fss *FeeScheduleService) GetCharges(scheduleID string, periodStart, periodEnd time.Time) ([]*FeeCharge, error) {
+	all, err := fss.storage.GetAllFeeCharges()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee charges: %w", err)
+	}
+	var charges []*FeeCharge
+	for _, charge := range all {
+		if charge.ScheduleID != scheduleID || charge.ValidTime.Before(periodStart) || charge.ValidTime.After(periodEnd) {
+			continue
+		}
+		charges = append(charges, charge)
+	}
+	sort.SliceStable(charges, func(i, j int) bool {
+		return charges[i].ValidTime.Before(charges[j].ValidTime)
+	})
+	return charges, nil
+}
+
+// feeTransaction journals a run's fees: revenue fees are owed to us by the
+// counterparty, expense fees are owed by us
+func (fss *FeeScheduleService) feeTransaction(schedule *FeeSchedule, currency Currency, total int64, periodStart, periodEnd time.Time, userID string) *Transaction {
+	debitAccountID, creditAccountID := schedule.CounterAccountID, schedule.FeeAccountID
+	if schedule.Direction == FeeExpense {
+		debitAccountID, creditAccountID = schedule.FeeAccountID, schedule.CounterAccountID
+	}
+
+	txnID := newID()
+	amount := Amount{Value: total, Currency: currency}
+	return &Transaction{
+		ID:              txnID,
+		Description:     fmt.Sprintf("%s fees %s to %s", schedule.Name, periodStart.Format("2006-01-02"), periodEnd.Format("2006-01-02")),
+		ValidTime:       periodEnd,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       fmt.Sprintf("FEE_%s_%s_%s", schedule.ID, currency, periodEnd.Format("20060102")),
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		Entries: []Entry{
+			{ID: newID(), TransactionID: txnID, AccountID: debitAccountID, Type: Debit, Amount: amount},
+			{ID: newID(), TransactionID: txnID, AccountID: creditAccountID, Type: Credit, Amount: amount},
+		},
+	}
+}
+
+// postTransaction records, saves and posts a fee transaction
+func (fss *FeeScheduleService) postTransaction(txn *Transaction, userID string) error {
+	_, err := fss.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := fss.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	if err := fss.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
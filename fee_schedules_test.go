@@ -0,0 +1,180 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeSchedules(t *testing.T) {
+	// Setup
+	dbFile := "test_fee_schedules.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "payments_ops"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	accounts := []*Account{
+		{ID: "card_clearing", Code: "1130", Name: "Card Clearing", Type: Asset},
+		{ID: "merchant_payable", Code: "2310", Name: "Merchant Payables", Type: Liability},
+		{ID: "fee_income", Code: "4300", Name: "Merchant Service Charges", Type: Income},
+		{ID: "interchange", Code: "6160", Name: "Interchange Fees", Type: Expense},
+	}
+	for _, account := range accounts {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 0, 0, 0, 0, time.UTC) }
+	balance := func(accountID string) int64 {
+		result, err := engine.GetAccountBalance(accountID, day(31))
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+	capture := func(ref string, value int64, currency Currency, when time.Time) {
+		txn := &Transaction{
+			Description: "Card capture",
+			ValidTime:   when,
+			SourceRef:   ref,
+			Entries: []Entry{
+				{AccountID: "card_clearing", Type: Debit, Amount: Amount{Value: value, Currency: currency}},
+				{AccountID: "merchant_payable", Type: Credit, Amount: Amount{Value: value, Currency: currency}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+
+	msc := &FeeSchedule{
+		ID:               "msc",
+		Name:             "Merchant Service Charge",
+		Direction:        FeeRevenue,
+		SourceAccountID:  "card_clearing",
+		FeeAccountID:     "fee_income",
+		CounterAccountID: "merchant_payable",
+		Tiers: []FeeTier{
+			{PercentRate: 0.029, FixedFee: 30},
+			{MinVolume: 100000, PercentRate: 0.025, FixedFee: 30},
+			{Currency: "EUR", PercentRate: 0.015},
+		},
+		Active: true,
+	}
+
+	t.Run("Schedule Validation", func(t *testing.T) {
+		invalid := *msc
+		invalid.FeeAccountID = "interchange"
+		assert.Error(t, engine.SaveFeeSchedule(&invalid, userID), "revenue fees post to an income account")
+		invalid = *msc
+		invalid.Tiers = []FeeTier{{PercentRate: 1.5}}
+		assert.Error(t, engine.SaveFeeSchedule(&invalid, userID))
+		require.NoError(t, engine.SaveFeeSchedule(msc, userID))
+		require.NoError(t, engine.SaveFeeSchedule(&FeeSchedule{
+			ID:               "interchange",
+			Name:             "Interchange",
+			ProviderID:       "acmepay",
+			Direction:        FeeExpense,
+			SourceAccountID:  "card_clearing",
+			FeeAccountID:     "interchange",
+			CounterAccountID: "cash",
+			Tiers:            []FeeTier{{Currency: "USD", PercentRate: 0.018, FixedFee: 10}},
+			Active:           true,
+		}, userID))
+	})
+
+	capture("ch_a", 60000, "USD", day(3))
+	capture("ch_b", 50000, "USD", day(9))
+	capture("ch_c", 10000, "USD", day(15))
+	capture("ch_d", 20000, "EUR", day(20))
+
+	t.Run("Tiered Fees", func(t *testing.T) {
+		charges, err := engine.ApplyFeeSchedule("msc", day(1), day(31), userID)
+		require.NoError(t, err)
+		require.Len(t, charges, 4)
+
+		assert.Equal(t, int64(1770), charges[0].FeeAmount)
+		assert.Equal(t, int64(1480), charges[1].FeeAmount, "tier is chosen on volume before the capture")
+		assert.Equal(t, int64(110000), charges[2].VolumeBefore)
+		assert.Equal(t, int64(280), charges[2].FeeAmount, "volume tier reached")
+		assert.Equal(t, int64(300), charges[3].FeeAmount, "currency tier takes precedence")
+		assert.NotEmpty(t, charges[0].JournalID)
+		assert.NotEqual(t, charges[0].JournalID, charges[3].JournalID, "one journal per currency")
+
+		assert.Equal(t, int64(3830), balance("fee_income"))
+
+		again, err := engine.ApplyFeeSchedule("msc", day(1), day(31), userID)
+		require.NoError(t, err)
+		assert.Empty(t, again, "charged transactions are not charged twice")
+	})
+
+	t.Run("Expense Fees", func(t *testing.T) {
+		charges, err := engine.ApplyFeeSchedule("interchange", day(1), day(31), userID)
+		require.NoError(t, err)
+		require.Len(t, charges, 3, "no tier for EUR")
+		// 1,080 + 900 + 180 plus 10 per capture
+		assert.Equal(t, int64(2190), balance("interchange"))
+		assert.Equal(t, int64(-2190), balance("cash"))
+	})
+
+	t.Run("Statement Reconciliation", func(t *testing.T) {
+		recon, err := engine.ReconcileFeeStatement("msc", "stmt_2025_01", day(1), day(31), []FeeStatementLine{
+			{Reference: "ch_a", Amount: 1770},
+			{Reference: "ch_b", Amount: 1500},
+			{Reference: "ch_d", Amount: 300},
+			{Reference: "ch_x", Amount: 99},
+		}, userID)
+		require.NoError(t, err)
+
+		require.Len(t, recon.Lines, 5)
+		statuses := make(map[string]FeeReconciliationStatus)
+		for _, line := range recon.Lines {
+			statuses[line.Reference] = line.Status
+		}
+		assert.Equal(t, FeeMatched, statuses["ch_a"])
+		assert.Equal(t, FeeAmountMismatch, statuses["ch_b"])
+		assert.Equal(t, FeeNotBilled, statuses["ch_c"])
+		assert.Equal(t, FeeMatched, statuses["ch_d"])
+		assert.Equal(t, FeeNotInLedger, statuses["ch_x"])
+		assert.Equal(t, 3, recon.Exceptions)
+		assert.Equal(t, int64(3830), recon.ExpectedTotal)
+		assert.Equal(t, int64(3669), recon.BilledTotal)
+		assert.Equal(t, int64(-161), recon.Difference)
+
+		_, err = engine.ReconcileFeeStatement("msc", "stmt_2025_01", day(1), day(31), nil, userID)
+		assert.Error(t, err, "a statement is reconciled once")
+	})
+
+	t.Run("Half Points Round Up", func(t *testing.T) {
+		require.NoError(t, engine.CreateAccount(&Account{ID: "wallet_clearing", Code: "1131", Name: "Wallet Clearing", Type: Asset}, userID))
+		require.NoError(t, engine.SaveFeeSchedule(&FeeSchedule{
+			ID:               "wallet",
+			Name:             "Wallet Top-Up Charge",
+			Direction:        FeeRevenue,
+			SourceAccountID:  "wallet_clearing",
+			FeeAccountID:     "fee_income",
+			CounterAccountID: "merchant_payable",
+			Tiers:            []FeeTier{{PercentRate: 0.015}},
+			Active:           true,
+		}, userID))
+		topUp := &Transaction{
+			Description: "Wallet top-up",
+			ValidTime:   day(21),
+			Entries: []Entry{
+				{AccountID: "wallet_clearing", Type: Debit, Amount: Amount{Value: 100, Currency: "USD"}},
+				{AccountID: "merchant_payable", Type: Credit, Amount: Amount{Value: 100, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(topUp, userID))
+		require.NoError(t, engine.PostTransaction(topUp.ID, userID))
+
+		// 1.5% of 100 is exactly 1.5, not the 1.4999... of 0.015's binary expansion
+		charges, err := engine.ApplyFeeSchedule("wallet", day(1), day(31), userID)
+		require.NoError(t, err)
+		require.Len(t, charges, 1)
+		assert.Equal(t, int64(2), charges[0].FeeAmount)
+	})
+}
@@ -0,0 +1,345 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// FinancialRatioOptions says which accounts make up the balance sheet classes ratios
+// need. An account belongs to a class when it or one of its ancestors is listed, so
+// a chart's "current_assets" parent covers the accounts under it. Nil uses the
+// defaults.
+type FinancialRatioOptions struct {
+	CurrentAssetIDs      []string `json:"current_asset_ids,omitempty"`
+	CurrentLiabilityIDs  []string `json:"current_liability_ids,omitempty"`
+	CashAccountIDs       []string `json:"cash_account_ids,omitempty"`
+	InventoryAccountIDs  []string `json:"inventory_account_ids,omitempty"`
+	ReceivableAccountIDs []string `json:"receivable_account_ids,omitempty"`
+	PayableAccountIDs    []string `json:"payable_account_ids,omitempty"`
+	CostOfSalesIDs       []string `json:"cost_of_sales_ids,omitempty"`
+	InterestExpenseIDs   []string `json:"interest_expense_ids,omitempty"`
+}
+
+// DefaultFinancialRatioOptions follows the standard accounts and the chart
+// templates' account IDs
+func DefaultFinancialRatioOptions() *FinancialRatioOptions {
+	return &FinancialRatioOptions{
+		CurrentAssetIDs:      []string{"current_assets", "cash", "accounts_receivable", "intercompany_receivable", "inventory", "prepaid_expenses"},
+		CurrentLiabilityIDs:  []string{"current_liabilities", "accounts_payable", "intercompany_payable", "unearned_revenue", "sales_tax_payable"},
+		CashAccountIDs:       []string{"cash"},
+		InventoryAccountIDs:  []string{"inventory"},
+		ReceivableAccountIDs: []string{"accounts_receivable"},
+		PayableAccountIDs:    []string{"accounts_payable"},
+		CostOfSalesIDs:       []string{"cost_of_goods_sold", "cost_of_revenue"},
+		InterestExpenseIDs:   []string{"interest_expense"},
+	}
+}
+
+// Ratios are fractions (0.25 is 25%) or day counts, and nil where the denominator
+// is zero. Averages are of the opening and closing balances of the period.
+
+// LiquidityRatios measure the ability to meet short-term obligations
+type LiquidityRatios struct {
+	CurrentRatio   *float64 `json:"current_ratio,omitempty"`
+	QuickRatio     *float64 `json:"quick_ratio,omitempty"` // current assets less inventory
+	CashRatio      *float64 `json:"cash_ratio,omitempty"`
+	WorkingCapital int64    `json:"working_capital"` // current assets less current liabilities, minor units
+}
+
+// ProfitabilityRatios measure returns on sales and on resources
+type ProfitabilityRatios struct {
+	GrossMargin    *float64 `json:"gross_margin,omitempty"`
+	NetMargin      *float64 `json:"net_margin,omitempty"`
+	ReturnOnAssets *float64 `json:"return_on_assets,omitempty"` // net income over average total assets
+	ReturnOnEquity *float64 `json:"return_on_equity,omitempty"` // net income over average equity
+}
+
+// LeverageRatios measure reliance on borrowed funds
+type LeverageRatios struct {
+	DebtToEquity     *float64 `json:"debt_to_equity,omitempty"`
+	DebtRatio        *float64 `json:"debt_ratio,omitempty"`
+	EquityMultiplier *float64 `json:"equity_multiplier,omitempty"`
+	InterestCoverage *float64 `json:"interest_coverage,omitempty"` // earnings before interest over interest expense
+}
+
+// EfficiencyRatios measure how quickly resources turn over
+type EfficiencyRatios struct {
+	AssetTurnover            *float64 `json:"asset_turnover,omitempty"`
+	ReceivablesTurnover      *float64 `json:"receivables_turnover,omitempty"`
+	DaysSalesOutstanding     *float64 `json:"days_sales_outstanding,omitempty"`
+	InventoryTurnover        *float64 `json:"inventory_turnover,omitempty"` // cost of sales over average inventory
+	DaysInventoryOutstanding *float64 `json:"days_inventory_outstanding,omitempty"`
+	PayablesTurnover         *float64 `json:"payables_turnover,omitempty"` // cost of sales over average payables
+	DaysPayablesOutstanding  *float64 `json:"days_payables_outstanding,omitempty"`
+}
+
+// RatioFigures are the totals ratios are computed from, in minor units of the
+// reporting currency. Balances are at the end of the period.
+type RatioFigures struct {
+	CurrentAssets      int64 `json:"current_assets"`
+	CurrentLiabilities int64 `json:"current_liabilities"`
+	Cash               int64 `json:"cash"`
+	Inventory          int64 `json:"inventory"`
+	Receivables        int64 `json:"receivables"`
+	Payables           int64 `json:"payables"`
+	TotalAssets        int64 `json:"total_assets"`
+	TotalLiabilities   int64 `json:"total_liabilities"`
+	Equity             int64 `json:"equity"` // total assets less total liabilities, so including unclosed earnings
+	Revenue            int64 `json:"revenue"`
+	CostOfSales        int64 `json:"cost_of_sales"`
+	InterestExpense    int64 `json:"interest_expense"`
+	NetIncome          int64 `json:"net_income"`
+	AverageAssets      int64 `json:"average_assets"`
+	AverageEquity      int64 `json:"average_equity"`
+	AverageReceivables int64 `json:"average_receivables"`
+	AverageInventory   int64 `json:"average_inventory"`
+	AveragePayables    int64 `json:"average_payables"`
+}
+
+// FinancialRatios are the ratios for the period to date ending AsOf, with the
+// prior whole period alongside for comparison
+type FinancialRatios struct {
+	Period        ScheduleFrequency   `json:"period"`
+	FromDate      time.Time           `json:"from_date"`
+	AsOf          time.Time           `json:"as_of"`
+	Days          int                 `json:"days"` // calendar days in the period, for the day-count ratios
+	Currency      string              `json:"currency"`
+	Liquidity     LiquidityRatios     `json:"liquidity"`
+	Profitability ProfitabilityRatios `json:"profitability"`
+	Leverage      LeverageRatios      `json:"leverage"`
+	Efficiency    EfficiencyRatios    `json:"efficiency"`
+	Figures       RatioFigures        `json:"figures"`
+	Comparative   *FinancialRatios    `json:"comparative,omitempty"`
+}
+
+// balancePosition is the balance sheet classes on one date
+type balancePosition struct {
+	assets, liabilities                    int64
+	currentAssets, currentLiabilities      int64
+	cash, inventory, receivables, payables int64
+}
+
+// GenerateFinancialRatios computes ratios for the calendar month, quarter or year
+// to date ending asOf from the trial balance and the period's income statement
+// movements. The comparative is the whole period before, so comparisons are like
+// for like when asOf is a period end.
+func (rs *ReportingService) GenerateFinancialRatios(asOf time.Time, period ScheduleFrequency, currency string, options *FinancialRatioOptions) (_ *FinancialRatios, err error) {
+	defer rs.metrics.timeReport("financial_ratios")(&err)
+	defer rs.tracer.report("financial_ratios")(&err)
+
+	if options == nil {
+		options = DefaultFinancialRatioOptions()
+	}
+	current, err := rs.financialRatios(asOf, period, currency, options)
+	if err != nil {
+		return nil, err
+	}
+	current.Comparative, err = rs.financialRatios(current.FromDate.Add(-time.Nanosecond), period, currency, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute comparative ratios: %w", err)
+	}
+	return current, nil
+}
+
+// financialRatios computes one period's ratios
+func (rs *ReportingService) financialRatios(asOf time.Time, period ScheduleFrequency, currency string, options *FinancialRatioOptions) (*FinancialRatios, error) {
+	fromDate, err := ratioPeriodStart(asOf, period)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := rs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	byID := make(map[string]*Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+	// in reports whether an account or one of its ancestors is listed
+	in := func(accountID string, ids []string) bool {
+		for account, depth := byID[accountID], 0; account != nil && depth <= len(byID); account, depth = byID[account.ParentID], depth+1 {
+			if slices.Contains(ids, account.ID) {
+				return true
+			}
+		}
+		return false
+	}
+
+	position := func(date time.Time) (*balancePosition, error) {
+		trialBalance, err := rs.GenerateTrialBalance(date, currency)
+		if err != nil {
+			return nil, err
+		}
+		p := &balancePosition{}
+		for _, balance := range trialBalance {
+			value := balance.Balance.Value
+			switch balance.AccountType {
+			case Asset:
+				p.assets += value
+				if in(balance.AccountID, options.CurrentAssetIDs) {
+					p.currentAssets += value
+				}
+			case Liability:
+				p.liabilities += value
+				if in(balance.AccountID, options.CurrentLiabilityIDs) {
+					p.currentLiabilities += value
+				}
+			}
+			if in(balance.AccountID, options.CashAccountIDs) {
+				p.cash += value
+			}
+			if in(balance.AccountID, options.InventoryAccountIDs) {
+				p.inventory += value
+			}
+			if in(balance.AccountID, options.ReceivableAccountIDs) {
+				p.receivables += value
+			}
+			if in(balance.AccountID, options.PayableAccountIDs) {
+				p.payables += value
+			}
+		}
+		return p, nil
+	}
+	closing, err := position(asOf)
+	if err != nil {
+		return nil, err
+	}
+	opening, err := position(fromDate.Add(-time.Nanosecond))
+	if err != nil {
+		return nil, err
+	}
+
+	// Income statement movements for the period, translated as the P&L does
+	var incomeStatement []*Account
+	for _, account := range accounts {
+		if account.Type == Income || account.Type == Expense {
+			incomeStatement = append(incomeStatement, account)
+		}
+	}
+	movements := make([]int64, len(incomeStatement))
+	err = forEachParallel(len(incomeStatement), rs.queryAPI.workers(), func(i int) error {
+		movement, err := rs.calculatePeriodBalance(incomeStatement[i].ID, fromDate, asOf)
+		if err != nil {
+			return fmt.Errorf("failed to get movement for account %s: %w", incomeStatement[i].ID, err)
+		}
+		translated, err := rs.translateAmount(movement, currency, asOf)
+		if err != nil {
+			return fmt.Errorf("failed to translate movement for account %s: %w", incomeStatement[i].ID, err)
+		}
+		movements[i] = translated.Value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	figures := RatioFigures{
+		CurrentAssets:      closing.currentAssets,
+		CurrentLiabilities: closing.currentLiabilities,
+		Cash:               closing.cash,
+		Inventory:          closing.inventory,
+		Receivables:        closing.receivables,
+		Payables:           closing.payables,
+		TotalAssets:        closing.assets,
+		TotalLiabilities:   closing.liabilities,
+		Equity:             closing.assets - closing.liabilities,
+		AverageAssets:      (opening.assets + closing.assets) / 2,
+		AverageEquity:      (opening.assets - opening.liabilities + closing.assets - closing.liabilities) / 2,
+		AverageReceivables: (opening.receivables + closing.receivables) / 2,
+		AverageInventory:   (opening.inventory + closing.inventory) / 2,
+		AveragePayables:    (opening.payables + closing.payables) / 2,
+	}
+	for i, account := range incomeStatement {
+		switch {
+		case account.Type == Income:
+			figures.Revenue += movements[i]
+			figures.NetIncome += movements[i]
+		default:
+			figures.NetIncome -= movements[i]
+			if in(account.ID, options.CostOfSalesIDs) {
+				figures.CostOfSales += movements[i]
+			}
+			if in(account.ID, options.InterestExpenseIDs) {
+				figures.InterestExpense += movements[i]
+			}
+		}
+	}
+
+	days := int(ratioDate(asOf).Sub(ratioDate(fromDate)).Hours()/24) + 1
+	daysPer := func(numerator, denominator int64) *float64 {
+		r := ratio(numerator, denominator)
+		if r != nil {
+			*r *= float64(days)
+		}
+		return r
+	}
+
+	return &FinancialRatios{
+		Period:   period,
+		FromDate: fromDate,
+		AsOf:     asOf,
+		Days:     days,
+		Currency: currency,
+		Liquidity: LiquidityRatios{
+			CurrentRatio:   ratio(figures.CurrentAssets, figures.CurrentLiabilities),
+			QuickRatio:     ratio(figures.CurrentAssets-figures.Inventory, figures.CurrentLiabilities),
+			CashRatio:      ratio(figures.Cash, figures.CurrentLiabilities),
+			WorkingCapital: figures.CurrentAssets - figures.CurrentLiabilities,
+		},
+		Profitability: ProfitabilityRatios{
+			GrossMargin:    ratio(figures.Revenue-figures.CostOfSales, figures.Revenue),
+			NetMargin:      ratio(figures.NetIncome, figures.Revenue),
+			ReturnOnAssets: ratio(figures.NetIncome, figures.AverageAssets),
+			ReturnOnEquity: ratio(figures.NetIncome, figures.AverageEquity),
+		},
+		Leverage: LeverageRatios{
+			DebtToEquity:     ratio(figures.TotalLiabilities, figures.Equity),
+			DebtRatio:        ratio(figures.TotalLiabilities, figures.TotalAssets),
+			EquityMultiplier: ratio(figures.TotalAssets, figures.Equity),
+			InterestCoverage: ratio(figures.NetIncome+figures.InterestExpense, figures.InterestExpense),
+		},
+		Efficiency: EfficiencyRatios{
+			AssetTurnover:            ratio(figures.Revenue, figures.AverageAssets),
+			ReceivablesTurnover:      ratio(figures.Revenue, figures.AverageReceivables),
+			DaysSalesOutstanding:     daysPer(figures.AverageReceivables, figures.Revenue),
+			InventoryTurnover:        ratio(figures.CostOfSales, figures.AverageInventory),
+			DaysInventoryOutstanding: daysPer(figures.AverageInventory, figures.CostOfSales),
+			PayablesTurnover:         ratio(figures.CostOfSales, figures.AveragePayables),
+			DaysPayablesOutstanding:  daysPer(figures.AveragePayables, figures.CostOfSales),
+		},
+		Figures: figures,
+	}, nil
+}
+
+// ratioPeriodStart is the start of the calendar month, quarter or year containing
+// asOf
+func ratioPeriodStart(asOf time.Time, period ScheduleFrequency) (time.Time, error) {
+	year, month, _ := asOf.Date()
+	switch period {
+	case Monthly:
+	case Quarterly:
+		month -= (month - 1) % 3
+	case Yearly:
+		month = time.January
+	default:
+		return time.Time{}, fmt.Errorf("unknown ratio period %s", period)
+	}
+	return time.Date(year, month, 1, 0, 0, 0, 0, asOf.Location()), nil
+}
+
+// ratioDate drops the time of day
+func ratioDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// ratio divides two amounts, or is nil when the denominator is zero
+func ratio(numerator, denominator int64) *float64 {
+	if denominator == 0 {
+		return nil
+	}
+	r := float64(numerator) / float64(denominator)
+	return &r
+}
@@ -0,0 +1,135 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinancialRatios(t *testing.T) {
+	dbFile := "test_financial_ratios.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	for _, account := range []*Account{
+		{ID: "inventory", Code: "1400", Name: "Inventory", Type: Asset},
+		{ID: "notes_payable", Code: "2500", Name: "Notes Payable", Type: Liability},
+		{ID: "owner_equity", Code: "3000", Name: "Owner Equity", Type: Equity},
+		{ID: "cost_of_goods_sold", Code: "5000", Name: "Cost of Goods Sold", Type: Expense},
+		{ID: "interest_expense", Code: "7000", Name: "Interest Expense", Type: Expense},
+	} {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	post := func(date time.Time, debit, credit string, value int64) {
+		txn := &Transaction{
+			Description: "Activity",
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	date := func(month time.Month, day int) time.Time { return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC) }
+
+	post(date(time.May, 10), "cash", "owner_equity", 1000000)
+	post(date(time.May, 20), "cash", "revenue", 200000)
+	post(date(time.June, 2), "inventory", "accounts_payable", 300000)
+	post(date(time.June, 5), "accounts_receivable", "revenue", 400000)
+	post(date(time.June, 5), "cost_of_goods_sold", "inventory", 150000)
+	post(date(time.June, 8), "cash", "notes_payable", 500000)
+	post(date(time.June, 20), "interest_expense", "cash", 10000)
+	post(date(time.June, 25), "expenses", "cash", 40000)
+
+	ratios, err := engine.GenerateFinancialRatios(date(time.June, 30), Monthly, "USD", nil)
+	require.NoError(t, err)
+
+	t.Run("figures come from the trial balance and the month's movements", func(t *testing.T) {
+		assert.Equal(t, date(time.June, 1), ratios.FromDate)
+		assert.Equal(t, 30, ratios.Days)
+		assert.Equal(t, RatioFigures{
+			CurrentAssets:      2200000,
+			CurrentLiabilities: 300000,
+			Cash:               1650000,
+			Inventory:          150000,
+			Receivables:        400000,
+			Payables:           300000,
+			TotalAssets:        2200000,
+			TotalLiabilities:   800000,
+			Equity:             1400000,
+			Revenue:            400000,
+			CostOfSales:        150000,
+			InterestExpense:    10000,
+			NetIncome:          200000,
+			AverageAssets:      1700000,
+			AverageEquity:      1300000,
+			AverageReceivables: 200000,
+			AverageInventory:   75000,
+			AveragePayables:    150000,
+		}, ratios.Figures)
+	})
+
+	t.Run("ratios by category", func(t *testing.T) {
+		assert.InDelta(t, 2200000.0/300000, *ratios.Liquidity.CurrentRatio, 1e-9)
+		assert.InDelta(t, 2050000.0/300000, *ratios.Liquidity.QuickRatio, 1e-9)
+		assert.InDelta(t, 5.5, *ratios.Liquidity.CashRatio, 1e-9)
+		assert.Equal(t, int64(1900000), ratios.Liquidity.WorkingCapital)
+
+		assert.InDelta(t, 0.625, *ratios.Profitability.GrossMargin, 1e-9)
+		assert.InDelta(t, 0.5, *ratios.Profitability.NetMargin, 1e-9)
+		assert.InDelta(t, 200000.0/1700000, *ratios.Profitability.ReturnOnAssets, 1e-9)
+		assert.InDelta(t, 200000.0/1300000, *ratios.Profitability.ReturnOnEquity, 1e-9)
+
+		assert.InDelta(t, 800000.0/1400000, *ratios.Leverage.DebtToEquity, 1e-9)
+		assert.InDelta(t, 800000.0/2200000, *ratios.Leverage.DebtRatio, 1e-9)
+		assert.InDelta(t, 2200000.0/1400000, *ratios.Leverage.EquityMultiplier, 1e-9)
+		assert.InDelta(t, 21.0, *ratios.Leverage.InterestCoverage, 1e-9)
+
+		assert.InDelta(t, 400000.0/1700000, *ratios.Efficiency.AssetTurnover, 1e-9)
+		assert.InDelta(t, 2.0, *ratios.Efficiency.ReceivablesTurnover, 1e-9)
+		assert.InDelta(t, 15.0, *ratios.Efficiency.DaysSalesOutstanding, 1e-9)
+		assert.InDelta(t, 2.0, *ratios.Efficiency.InventoryTurnover, 1e-9)
+		assert.InDelta(t, 15.0, *ratios.Efficiency.DaysInventoryOutstanding, 1e-9)
+		assert.InDelta(t, 1.0, *ratios.Efficiency.PayablesTurnover, 1e-9)
+		assert.InDelta(t, 30.0, *ratios.Efficiency.DaysPayablesOutstanding, 1e-9)
+	})
+
+	t.Run("prior period for comparison", func(t *testing.T) {
+		prior := ratios.Comparative
+		require.NotNil(t, prior)
+		assert.Nil(t, prior.Comparative)
+		assert.Equal(t, date(time.May, 1), prior.FromDate)
+		assert.Equal(t, 31, prior.Days)
+		assert.Equal(t, int64(200000), prior.Figures.Revenue)
+		assert.Equal(t, int64(1200000), prior.Figures.TotalAssets)
+		assert.Nil(t, prior.Liquidity.CurrentRatio, "no current liabilities")
+		assert.InDelta(t, 0.0, *prior.Leverage.DebtToEquity, 1e-9)
+		assert.InDelta(t, 200000.0/600000, *prior.Profitability.ReturnOnAssets, 1e-9)
+	})
+
+	t.Run("periods", func(t *testing.T) {
+		quarterly, err := engine.GenerateFinancialRatios(date(time.June, 30), Quarterly, "USD", nil)
+		require.NoError(t, err)
+		assert.Equal(t, date(time.April, 1), quarterly.FromDate)
+		assert.Equal(t, int64(600000), quarterly.Figures.Revenue)
+		assert.Equal(t, date(time.January, 1), quarterly.Comparative.FromDate)
+
+		yearly, err := engine.GenerateFinancialRatios(date(time.June, 30), Yearly, "USD", nil)
+		require.NoError(t, err)
+		assert.Equal(t, date(time.January, 1), yearly.FromDate)
+
+		_, err = engine.GenerateFinancialRatios(date(time.June, 30), "WEEKLY", "USD", nil)
+		assert.Error(t, err)
+	})
+}
@@ -5,8 +5,6 @@ import (
 	"sort"
 	"strings"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 // QueryOptions represents query parameters for entry searches
@@ -24,15 +22,11 @@ type Filter struct {
 	Value    interface{} `json:"value"`
 }
 
-// generateUUID generates a new UUID string
-func generateUUID() string {
-	return uuid.New().String()
-}
-
 // ForensicService provides forensic accounting capabilities
 type ForensicService struct {
-	storage    *Storage
-	eventStore *EventStore
+	storage          *Storage
+	eventStore       *EventStore
+	expenseAnomalies *ExpenseAnomalyService
 }
 
 // NewForensicService creates a new forensic service
@@ -43,6 +37,11 @@ func NewForensicService(storage *Storage, eventStore *EventStore) *ForensicServi
 	}
 }
 
+// SetExpenseAnomalyService adds expense anomalies to the patterns the service detects
+func (fs *ForensicService) SetExpenseAnomalyService(expenseAnomalies *ExpenseAnomalyService) {
+	fs.expenseAnomalies = expenseAnomalies
+}
+
 // MoneyTrail represents a complete path of money movement
 type MoneyTrail struct {
 	ID          string           `json:"id"`
@@ -97,6 +96,15 @@ const (
 	FlagVelocityAnomaly FlagType = "velocity_anomaly"
 	FlagAmountThreshold FlagType = "amount_threshold"
 	FlagSmurfingPattern FlagType = "smurfing_pattern"
+	// Expense anomaly flags
+	FlagExpenseSpike     FlagType = "expense_spike"
+	FlagNewVendorSpend   FlagType = "new_vendor_spend"
+	FlagDuplicateAmounts FlagType = "duplicate_amounts"
+	// Digit analysis flags
+	FlagBenfordDeviation FlagType = "benford_deviation"
+	// Duplicate flags
+	FlagDuplicatePayment FlagType = "duplicate_payment"
+	FlagDuplicateInvoice FlagType = "duplicate_invoice"
 )
 
 type Severity string
@@ -148,7 +156,8 @@ type SuspiciousPattern struct {
 	DetectedAt   time.Time   `json:"detected_at"`
 }
 
-// TrackMoneyTrail follows money movement from source to destination
+// TrackMoneyTrail follows money movement from source to destination, one hop from
+// the source account; TraceMoneyTrails follows it further
 func (fs *ForensicService) TrackMoneyTrail(sourceAccountID string, startDate, endDate time.Time, minAmount int64) (*MoneyTrail, error) {
 	// Get all entries for the source account in the date range
 	entries, err := fs.storage.GetEntriesByAccount(sourceAccountID)
@@ -181,7 +190,7 @@ func (fs *ForensicService) TrackMoneyTrail(sourceAccountID string, startDate, en
 
 	// Build money trail
 	trail := &MoneyTrail{
-		ID:        generateUUID(),
+		ID:        newID(),
 		StartDate: startDate,
 		EndDate:   endDate,
 		Path:      []MoneyTrailStep{},
@@ -396,9 +405,23 @@ func (fs *ForensicService) DetectSuspiciousPatterns(startDate, endDate time.Time
 	patterns = append(patterns, fs.detectRoundAmountPattern(entries)...)
 	patterns = append(patterns, fs.detectHighFrequencyPattern(entries)...)
 	patterns = append(patterns, fs.detectStructuringPattern(entries)...)
-	patterns = append(patterns, fs.detectCircularTransferPattern(entries)...)
+	patterns = append(patterns, fs.detectCircularTransferPattern(entries, startDate, endDate)...)
 	patterns = append(patterns, fs.detectUnusualTimingPattern(entries)...)
 
+	duplicates, err := fs.DetectDuplicatePayments(startDate, endDate, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect duplicate payments: %w", err)
+	}
+	patterns = append(patterns, duplicates...)
+
+	if fs.expenseAnomalies != nil {
+		report, err := fs.expenseAnomalies.DetectExpenseAnomalies(startDate, endDate, "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect expense anomalies: %w", err)
+		}
+		patterns = append(patterns, expenseAnomalyPatterns(report)...)
+	}
+
 	return patterns, nil
 }
 
@@ -456,7 +479,7 @@ func (fs *ForensicService) detectRoundAmountPattern(entries []*Entry) []Suspicio
 	var patterns []SuspiciousPattern
 	if roundAmountCount > 10 { // More than 10 round amounts
 		patterns = append(patterns, SuspiciousPattern{
-			ID:           generateUUID(),
+			ID:           newID(),
 			Type:         FlagRoundAmounts,
 			Severity:     SeverityMedium,
 			Description:  "High frequency of round number transactions",
@@ -500,7 +523,7 @@ func (fs *ForensicService) detectHighFrequencyPattern(entries []*Entry) []Suspic
 		for date, count := range activity {
 			if count > 50 { // More than 50 transactions per day
 				patterns = append(patterns, SuspiciousPattern{
-					ID:          generateUUID(),
+					ID:          newID(),
 					Type:        FlagHighFrequency,
 					Severity:    SeverityHigh,
 					Description: "Unusually high transaction frequency",
@@ -533,7 +556,7 @@ func (fs *ForensicService) detectStructuringPattern(entries []*Entry) []Suspicio
 	var patterns []SuspiciousPattern
 	if structuringCount > 5 {
 		patterns = append(patterns, SuspiciousPattern{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Type:        FlagStructuring,
 			Severity:    SeverityHigh,
 			Description: "Potential structuring - amounts just under reporting thresholds",
@@ -546,44 +569,23 @@ func (fs *ForensicService) detectStructuringPattern(entries []*Entry) []Suspicio
 	return patterns
 }
 
-func (fs *ForensicService) detectCircularTransferPattern(entries []*Entry) []SuspiciousPattern {
-	// This is a simplified version - a full implementation would use graph algorithms
-	// to detect actual cycles in the transaction flow
-	accountPairs := make(map[string]int) // "account1->account2" -> count
-
-	// Group entries by transaction
-	txnMap := make(map[string][]*Entry)
+// detectCircularTransferPattern searches the transactions behind the entries that
+// are dated within the analysis period for cycles, using the default bounds
+func (fs *ForensicService) detectCircularTransferPattern(entries []*Entry, startDate, endDate time.Time) []SuspiciousPattern {
+	seen := make(map[string]bool)
+	var transactions []*Transaction
 	for _, entry := range entries {
-		txnMap[entry.TransactionID] = append(txnMap[entry.TransactionID], entry)
-	}
-
-	// Look for back-and-forth transactions
-	for _, txnEntries := range txnMap {
-		if len(txnEntries) == 2 {
-			var account1, account2 string
-			for _, entry := range txnEntries {
-				if entry.Type == Debit {
-					account2 = entry.AccountID
-				} else {
-					account1 = entry.AccountID
-				}
-			}
-
-			if account1 != "" && account2 != "" {
-				pair := fmt.Sprintf("%s->%s", account1, account2)
-				reversePair := fmt.Sprintf("%s->%s", account2, account1)
-				accountPairs[pair]++
-
-				// Check if reverse transaction exists
-				if accountPairs[reversePair] > 0 {
-					// Potential circular transfer detected
-				}
-			}
+		if seen[entry.TransactionID] {
+			continue
 		}
+		seen[entry.TransactionID] = true
+		txn, err := fs.storage.GetTransaction(entry.TransactionID)
+		if err != nil || txn.ValidTime.Before(startDate) || txn.ValidTime.After(endDate) {
+			continue
+		}
+		transactions = append(transactions, txn)
 	}
-
-	// For now, return empty - full cycle detection would require more complex graph analysis
-	return []SuspiciousPattern{}
+	return circularTransferPatterns(transactions, DefaultCircularTransferOptions())
 }
 
 func (fs *ForensicService) detectUnusualTimingPattern(entries []*Entry) []SuspiciousPattern {
@@ -623,7 +625,7 @@ func (fs *ForensicService) detectUnusualTimingPattern(entries []*Entry) []Suspic
 
 	if weekendCount > totalEntries/10 { // More than 10% on weekends
 		patterns = append(patterns, SuspiciousPattern{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Type:        FlagUnusualTiming,
 			Severity:    SeverityMedium,
 			Description: "High percentage of weekend transactions",
@@ -635,7 +637,7 @@ func (fs *ForensicService) detectUnusualTimingPattern(entries []*Entry) []Suspic
 
 	if afterHoursCount > totalEntries/5 { // More than 20% after hours
 		patterns = append(patterns, SuspiciousPattern{
-			ID:          generateUUID(),
+			ID:          newID(),
 			Type:        FlagUnusualTiming,
 			Severity:    SeverityMedium,
 			Description: "High percentage of after-hours transactions",
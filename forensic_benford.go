@@ -0,0 +1,397 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Benford's Law Analysis
+// ----------------------------------------------------------------------------
+
+// BenfordOptions tunes the Benford analysis. Nil uses the defaults.
+type BenfordOptions struct {
+	AccountIDs   []string          `json:"account_ids,omitempty"` // accounts to analyze; empty for all
+	Period       ScheduleFrequency `json:"period,omitempty"`      // splits each account's entries by period; empty for the whole range
+	MinEntries   int               `json:"min_entries"`           // smallest sample an account and period is tested on
+	MinAmount    int64             `json:"min_amount"`            // smallest amount analyzed, in minor units
+	Significance float64           `json:"significance"`          // p-value below which a distribution deviates
+}
+
+// DefaultBenfordOptions returns the analysis defaults: samples of at least 50
+// amounts of 10.00 or more, tested at the 5% significance level
+func DefaultBenfordOptions() *BenfordOptions {
+	return &BenfordOptions{
+		MinEntries:   50,
+		MinAmount:    1000,
+		Significance: 0.05,
+	}
+}
+
+// BenfordDigit compares how often a digit occurs with Benford's expectation
+type BenfordDigit struct {
+	Digit     int     `json:"digit"`
+	Count     int     `json:"count"`
+	Observed  float64 `json:"observed"`  // share of the sample
+	Expected  float64 `json:"expected"`  // share Benford's Law predicts
+	Deviation float64 `json:"deviation"` // observed less expected
+}
+
+// BenfordTest is one digit position's distribution and its chi-square goodness of
+// fit to Benford's Law
+type BenfordTest struct {
+	Sample      int            `json:"sample"`
+	Digits      []BenfordDigit `json:"digits"`
+	ChiSquare   float64        `json:"chi_square"`
+	PValue      float64        `json:"p_value"`
+	MAD         float64        `json:"mad"` // mean absolute deviation of the shares
+	Significant bool           `json:"significant"`
+}
+
+// BenfordResult is the analysis of one account's amounts over one period
+type BenfordResult struct {
+	AccountID   string       `json:"account_id"`
+	AccountName string       `json:"account_name"`
+	PeriodStart time.Time    `json:"period_start"`
+	PeriodEnd   time.Time    `json:"period_end"`
+	Entries     int          `json:"entries"`
+	FirstDigit  *BenfordTest `json:"first_digit"`
+	SecondDigit *BenfordTest `json:"second_digit"`
+	Significant bool         `json:"significant"` // either test deviates
+}
+
+// BenfordReport lists the accounts and periods tested and the patterns raised for
+// those whose digits deviate from Benford's Law
+type BenfordReport struct {
+	FromDate time.Time           `json:"from_date"`
+	ToDate   time.Time           `json:"to_date"`
+	Period   ScheduleFrequency   `json:"period,omitempty"`
+	Results  []*BenfordResult    `json:"results"`
+	Skipped  int                 `json:"skipped"` // accounts and periods with too few amounts to test
+	Patterns []SuspiciousPattern `json:"patterns"`
+}
+
+// benfordFirstDigits and benfordSecondDigits are the shares Benford's Law expects
+// for digits 1-9 in first position and 0-9 in second position
+var benfordFirstDigits, benfordSecondDigits = benfordExpectations()
+
+func benfordExpectations() (first, second []float64) {
+	first = make([]float64, 9)
+	for d := 1; d <= 9; d++ {
+		first[d-1] = math.Log10(1 + 1/float64(d))
+	}
+	second = make([]float64, 10)
+	for d := 0; d <= 9; d++ {
+		for k := 1; k <= 9; k++ {
+			second[d] += math.Log10(1 + 1/float64(10*k+d))
+		}
+	}
+	return first, second
+}
+
+// leadingDigits returns the first two significant digits of a positive value; the
+// second is -1 for single-digit values
+func leadingDigits(value int64) (first, second int) {
+	if value < 10 {
+		return int(value), -1
+	}
+	for value >= 100 {
+		value /= 10
+	}
+	return int(value / 10), int(value % 10)
+}
+
+// benfordSample counts the leading digits of one account's amounts over one period
+type benfordSample struct {
+	accountID    string
+	periodStart  time.Time
+	entries      int
+	first        [9]int
+	second       [10]int
+	firstDigitTx [9][]string // transactions behind each first digit
+}
+
+// AnalyzeBenford tests whether the leading digits of each account's entry amounts,
+// per period, follow Benford's Law. Naturally occurring amounts do; invented or
+// manipulated ones often do not. Posted transactions dated from from to to
+// inclusive are analyzed.
+func (fs *ForensicService) AnalyzeBenford(from, to time.Time, options *BenfordOptions) (*BenfordReport, error) {
+	if options == nil {
+		options = DefaultBenfordOptions()
+	}
+	if to.Before(from) {
+		return nil, classify(ErrValidation, "Benford analysis period ends before it starts")
+	}
+	switch options.Period {
+	case "", Monthly, Quarterly, Yearly:
+	default:
+		return nil, classify(ErrValidation, "unsupported Benford analysis period: %s", options.Period)
+	}
+	if options.Significance <= 0 || options.Significance >= 1 {
+		return nil, classify(ErrValidation, "significance must be between 0 and 1")
+	}
+	if options.MinEntries < 1 {
+		return nil, classify(ErrValidation, "minimum sample must be at least one entry")
+	}
+
+	accounts, err := fs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	names := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		names[account.ID] = account.Name
+	}
+	selected := make(map[string]bool, len(options.AccountIDs))
+	for _, accountID := range options.AccountIDs {
+		if _, ok := names[accountID]; !ok {
+			return nil, notFound("account", accountID)
+		}
+		selected[accountID] = true
+	}
+
+	transactions, err := fs.storage.GetTransactionsByDateRange("", from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	samples := make(map[string]*benfordSample)
+	for _, txn := range transactions {
+		if txn.Status != Posted {
+			continue
+		}
+		periodStart := benfordPeriodStart(txn.ValidTime, from, options.Period)
+		for _, entry := range txn.Entries {
+			if len(selected) > 0 && !selected[entry.AccountID] {
+				continue
+			}
+			value := entry.Amount.Value
+			if value < 0 {
+				value = -value
+			}
+			if value == 0 || value < options.MinAmount {
+				continue
+			}
+
+			key := entry.AccountID + "|" + periodStart.Format(time.RFC3339)
+			sample, ok := samples[key]
+			if !ok {
+				sample = &benfordSample{accountID: entry.AccountID, periodStart: periodStart}
+				samples[key] = sample
+			}
+			first, second := leadingDigits(value)
+			sample.entries++
+			sample.first[first-1]++
+			sample.firstDigitTx[first-1] = append(sample.firstDigitTx[first-1], txn.ID)
+			if second >= 0 {
+				sample.second[second]++
+			}
+		}
+	}
+
+	report := &BenfordReport{FromDate: from, ToDate: to, Period: options.Period}
+	ordered := make([]*benfordSample, 0, len(samples))
+	for _, sample := range samples {
+		ordered = append(ordered, sample)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		if ordered[i].accountID != ordered[j].accountID {
+			return ordered[i].accountID < ordered[j].accountID
+		}
+		return ordered[i].periodStart.Before(ordered[j].periodStart)
+	})
+	for _, sample := range ordered {
+		if sample.entries < options.MinEntries {
+			report.Skipped++
+			continue
+		}
+		result := &BenfordResult{
+			AccountID:   sample.accountID,
+			AccountName: names[sample.accountID],
+			PeriodStart: sample.periodStart,
+			PeriodEnd:   benfordPeriodEnd(sample.periodStart, to, options.Period),
+			Entries:     sample.entries,
+			FirstDigit:  benfordTest(sample.first[:], 1, benfordFirstDigits, options.Significance),
+			SecondDigit: benfordTest(sample.second[:], 0, benfordSecondDigits, options.Significance),
+		}
+		result.Significant = result.FirstDigit.Significant || result.SecondDigit.Significant
+		report.Results = append(report.Results, result)
+		if result.Significant {
+			report.Patterns = append(report.Patterns, benfordPattern(result, sample))
+		}
+	}
+	return report, nil
+}
+
+// benfordPeriodStart is the start of the period containing t, or the start of the
+// analysis when it is not split by period
+func benfordPeriodStart(t, from time.Time, period ScheduleFrequency) time.Time {
+	t = t.In(from.Location())
+	switch period {
+	case Monthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, from.Location())
+	case Quarterly:
+		return time.Date(t.Year(), (t.Month()-1)/3*3+1, 1, 0, 0, 0, 0, from.Location())
+	case Yearly:
+		return time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, from.Location())
+	default:
+		return from
+	}
+}
+
+// benfordPeriodEnd is the last instant of the period starting at start, no later
+// than the end of the analysis
+func benfordPeriodEnd(start, to time.Time, period ScheduleFrequency) time.Time {
+	var next time.Time
+	switch period {
+	case Monthly:
+		next = start.AddDate(0, 1, 0)
+	case Quarterly:
+		next = start.AddDate(0, 3, 0)
+	case Yearly:
+		next = start.AddDate(1, 0, 0)
+	default:
+		return to
+	}
+	if end := next.Add(-time.Nanosecond); end.Before(to) {
+		return end
+	}
+	return to
+}
+
+// benfordTest compares digit counts, the first of which is for digit firstDigit,
+// with the expected shares
+func benfordTest(counts []int, firstDigit int, expected []float64, significance float64) *BenfordTest {
+	test := &BenfordTest{}
+	for _, count := range counts {
+		test.Sample += count
+	}
+	if test.Sample == 0 {
+		test.PValue = 1
+		return test
+	}
+	n := float64(test.Sample)
+	for i, count := range counts {
+		observed := float64(count) / n
+		test.Digits = append(test.Digits, BenfordDigit{
+			Digit:     firstDigit + i,
+			Count:     count,
+			Observed:  observed,
+			Expected:  expected[i],
+			Deviation: observed - expected[i],
+		})
+		want := expected[i] * n
+		test.ChiSquare += (float64(count) - want) * (float64(count) - want) / want
+		test.MAD += math.Abs(observed - expected[i])
+	}
+	test.MAD /= float64(len(counts))
+	test.PValue = chiSquarePValue(test.ChiSquare, len(counts)-1)
+	test.Significant = test.PValue < significance
+	return test
+}
+
+// benfordPattern raises a deviating account and period for review, pointing at the
+// transactions behind its most over-represented first digit
+func benfordPattern(result *BenfordResult, sample *benfordSample) SuspiciousPattern {
+	pattern := SuspiciousPattern{
+		ID:          newID(),
+		Type:        FlagBenfordDeviation,
+		Severity:    SeverityMedium,
+		Description: fmt.Sprintf("Leading digits of %s amounts deviate from Benford's Law", result.AccountID),
+		Accounts:    []string{result.AccountID},
+		Timeline:    []time.Time{result.PeriodStart, result.PeriodEnd},
+		DetectedAt:  time.Now(),
+	}
+	if result.FirstDigit.Significant && result.SecondDigit.Significant {
+		pattern.Severity = SeverityHigh
+	}
+
+	pValue := 1.0
+	for _, named := range []struct {
+		name string
+		test *BenfordTest
+	}{{"first", result.FirstDigit}, {"second", result.SecondDigit}} {
+		if !named.test.Significant {
+			continue
+		}
+		pValue = math.Min(pValue, named.test.PValue)
+		pattern.Evidence = append(pattern.Evidence, fmt.Sprintf("%s digits: chi-square %.1f (p=%.4f, MAD %.4f) over %d amounts",
+			named.name, named.test.ChiSquare, named.test.PValue, named.test.MAD, named.test.Sample))
+	}
+	pattern.Confidence = 1 - pValue
+
+	top := result.FirstDigit.Digits[0]
+	for _, digit := range result.FirstDigit.Digits[1:] {
+		if digit.Deviation > top.Deviation {
+			top = digit
+		}
+	}
+	pattern.Evidence = append(pattern.Evidence, fmt.Sprintf("first digit %d: %.1f%% observed, %.1f%% expected",
+		top.Digit, top.Observed*100, top.Expected*100))
+	seen := make(map[string]bool)
+	for _, txnID := range sample.firstDigitTx[top.Digit-1] {
+		if !seen[txnID] {
+			seen[txnID] = true
+			pattern.Transactions = append(pattern.Transactions, txnID)
+		}
+	}
+	return pattern
+}
+
+// chiSquarePValue is the probability of a chi-square statistic at least as large
+// with the given degrees of freedom
+func chiSquarePValue(chiSquare float64, degreesOfFreedom int) float64 {
+	if chiSquare <= 0 {
+		return 1
+	}
+	return upperIncompleteGamma(float64(degreesOfFreedom)/2, chiSquare/2)
+}
+
+// upperIncompleteGamma is the regularized upper incomplete gamma function Q(a, x),
+// by its series below a+1 and its continued fraction above
+func upperIncompleteGamma(a, x float64) float64 {
+	const (
+		epsilon    = 1e-14
+		iterations = 500
+		tiny       = 1e-300
+	)
+	lgamma, _ := math.Lgamma(a)
+	prefix := math.Exp(-x + a*math.Log(x) - lgamma)
+
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n < iterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+		return math.Max(0, 1-sum*prefix)
+	}
+
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < iterations; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return prefix * h
+}
@@ -0,0 +1,163 @@
+package accounting
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenfordAnalysis(t *testing.T) {
+	// Setup
+	dbFile := "test_forensic_benford.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "forensic_auditor"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	forensics := engine.GetForensicService()
+
+	date := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC) }
+	post := func(debit, credit string, value int64, on time.Time) string {
+		txn := &Transaction{
+			Description: "Sales and purchases",
+			ValidTime:   on,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn.ID
+	}
+
+	// Sales spread geometrically over three orders of magnitude follow Benford's Law
+	const sales = 300
+	for i := 0; i < sales; i++ {
+		value := int64(math.Round(math.Pow(10, 3+3*float64(i)/sales)))
+		post("cash", "revenue", value, date(time.Month(1+i%3), 1+i%28))
+	}
+	// Invented expense claims cluster just under a 50.00 approval limit
+	var invented []string
+	for i := 0; i < 120; i++ {
+		invented = append(invented, post("expenses", "accounts_payable", 4500+int64(i*37%480), date(2, 1+i%28)))
+	}
+	// Too few rent payments to judge
+	for i := 0; i < 10; i++ {
+		post("expenses", "cash", 250000, date(3, 1+i))
+	}
+
+	t.Run("Digit Expectations", func(t *testing.T) {
+		assert.InDelta(t, 0.30103, benfordFirstDigits[0], 1e-5)
+		assert.InDelta(t, 0.04576, benfordFirstDigits[8], 1e-5)
+		assert.InDelta(t, 0.11968, benfordSecondDigits[0], 1e-5)
+		assert.InDelta(t, 1, benfordSecondDigits[0]+benfordSecondDigits[1]+benfordSecondDigits[2]+benfordSecondDigits[3]+
+			benfordSecondDigits[4]+benfordSecondDigits[5]+benfordSecondDigits[6]+benfordSecondDigits[7]+benfordSecondDigits[8]+benfordSecondDigits[9], 1e-9)
+		assert.InDelta(t, 0.05, chiSquarePValue(15.507, 8), 1e-3)
+		assert.InDelta(t, 0.01, chiSquarePValue(21.666, 9), 1e-3)
+		assert.InDelta(t, 0.99, chiSquarePValue(1.646, 8), 1e-3)
+
+		first, second := leadingDigits(123456)
+		assert.Equal(t, []int{1, 2}, []int{first, second})
+		first, second = leadingDigits(7)
+		assert.Equal(t, []int{7, -1}, []int{first, second})
+	})
+
+	t.Run("Whole Range", func(t *testing.T) {
+		report, err := forensics.AnalyzeBenford(date(1, 1), date(3, 31), nil)
+		require.NoError(t, err)
+
+		results := make(map[string]*BenfordResult)
+		for _, result := range report.Results {
+			results[result.AccountID] = result
+		}
+		require.Contains(t, results, "revenue")
+		revenue := results["revenue"]
+		assert.Equal(t, sales, revenue.Entries)
+		assert.False(t, revenue.Significant)
+		assert.Greater(t, revenue.FirstDigit.PValue, 0.5)
+		assert.Less(t, revenue.FirstDigit.MAD, 0.006)
+		assert.Len(t, revenue.FirstDigit.Digits, 9)
+		assert.Len(t, revenue.SecondDigit.Digits, 10)
+		assert.Equal(t, 0, revenue.SecondDigit.Digits[0].Digit)
+
+		cash := results["cash"]
+		require.NotNil(t, cash)
+		assert.Equal(t, sales+10, cash.Entries)
+
+		payables := results["accounts_payable"]
+		require.NotNil(t, payables)
+		assert.True(t, payables.FirstDigit.Significant)
+		assert.True(t, payables.SecondDigit.Significant)
+		assert.Equal(t, 120, payables.FirstDigit.Digits[3].Count+payables.FirstDigit.Digits[4].Count)
+		assert.Equal(t, "Accounts Payable", payables.AccountName)
+
+		var flagged []string
+		for _, pattern := range report.Patterns {
+			assert.Equal(t, FlagBenfordDeviation, pattern.Type)
+			flagged = append(flagged, pattern.Accounts[0])
+		}
+		assert.ElementsMatch(t, []string{"accounts_payable", "expenses"}, flagged)
+
+		pattern := report.Patterns[0]
+		assert.Equal(t, []string{"accounts_payable"}, pattern.Accounts)
+		assert.Equal(t, SeverityHigh, pattern.Severity)
+		assert.Greater(t, pattern.Confidence, 0.99)
+		assert.Equal(t, []time.Time{date(1, 1), date(3, 31)}, pattern.Timeline)
+		assert.Contains(t, pattern.Evidence[len(pattern.Evidence)-1], "first digit 4")
+		assert.Subset(t, invented, pattern.Transactions)
+	})
+
+	t.Run("Per Account And Month", func(t *testing.T) {
+		options := DefaultBenfordOptions()
+		options.Period = Monthly
+		options.AccountIDs = []string{"revenue", "expenses"}
+		report, err := forensics.AnalyzeBenford(date(1, 1), date(3, 31), options)
+		require.NoError(t, err)
+
+		var periods []string
+		for _, result := range report.Results {
+			periods = append(periods, result.AccountID+" "+result.PeriodStart.Format("2006-01"))
+		}
+		assert.Equal(t, []string{"expenses 2025-02", "revenue 2025-01", "revenue 2025-02", "revenue 2025-03"}, periods)
+		assert.Equal(t, 1, report.Skipped, "March rent is too small a sample")
+		assert.Equal(t, date(3, 1).Add(-time.Nanosecond), report.Results[0].PeriodEnd)
+
+		require.Len(t, report.Patterns, 1)
+		assert.Equal(t, []string{"expenses"}, report.Patterns[0].Accounts)
+		assert.Equal(t, date(2, 1), report.Patterns[0].Timeline[0])
+
+		options.Period = Yearly
+		report, err = forensics.AnalyzeBenford(date(1, 1), date(3, 31), options)
+		require.NoError(t, err)
+		require.Len(t, report.Results, 2)
+		assert.Equal(t, date(3, 31), report.Results[0].PeriodEnd)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := forensics.AnalyzeBenford(date(3, 31), date(1, 1), nil)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		options := DefaultBenfordOptions()
+		options.Period = "HOURLY"
+		_, err = forensics.AnalyzeBenford(date(1, 1), date(3, 31), options)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		options = DefaultBenfordOptions()
+		options.Significance = 0
+		_, err = forensics.AnalyzeBenford(date(1, 1), date(3, 31), options)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		options = DefaultBenfordOptions()
+		options.AccountIDs = []string{"missing"}
+		_, err = forensics.AnalyzeBenford(date(1, 1), date(3, 31), options)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
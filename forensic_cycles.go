@@ -0,0 +1,287 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Circular Transfer Detection
+// ----------------------------------------------------------------------------
+
+// CircularTransferOptions bounds the search for money that leaves an account and
+// comes back to it through others. Nil uses the defaults.
+type CircularTransferOptions struct {
+	MaxCycleLength int           `json:"max_cycle_length"` // most transfers in a cycle, at least 2
+	Window         time.Duration `json:"window"`           // longest time from the first transfer to the last
+	MinAmount      int64         `json:"min_amount"`       // smallest transfer followed, in minor units
+	MinRetention   float64       `json:"min_retention"`    // smallest share of the largest transfer every hop must carry
+}
+
+// DefaultCircularTransferOptions returns the detector's defaults: cycles of up to
+// four transfers within a week that carry at least 90% of the money round
+func DefaultCircularTransferOptions() *CircularTransferOptions {
+	return &CircularTransferOptions{
+		MaxCycleLength: 4,
+		Window:         7 * 24 * time.Hour,
+		MinRetention:   0.9,
+	}
+}
+
+// maxCycleSearchSteps caps the hops one search explores so dense ledgers cannot
+// stall pattern detection; cycles found before the cap are still reported
+const maxCycleSearchSteps = 1000000
+
+// transferEdge is money moving from a credited account to a debited one in a
+// posted transaction, the same direction as the transaction graph's edges
+type transferEdge struct {
+	from, to string
+	amount   int64
+	currency Currency
+	at       time.Time
+	txnID    string
+}
+
+// transferEdges splits posted transactions into transfers ordered by date. A
+// credit is shared among the transaction's debits in the same currency in
+// proportion to their amounts.
+func transferEdges(transactions []*Transaction) []transferEdge {
+	var edges []transferEdge
+	for _, txn := range transactions {
+		if txn.Status != Posted {
+			continue
+		}
+		debitTotals := make(map[Currency]int64)
+		for _, entry := range txn.Entries {
+			if entry.Type == Debit {
+				debitTotals[entry.Amount.Currency] += entry.Amount.Value
+			}
+		}
+		for _, credit := range txn.Entries {
+			if credit.Type != Credit {
+				continue
+			}
+			for _, debit := range txn.Entries {
+				total := debitTotals[credit.Amount.Currency]
+				if debit.Type != Debit || debit.Amount.Currency != credit.Amount.Currency || debit.AccountID == credit.AccountID || total == 0 {
+					continue
+				}
+				amount := scaleValue(credit.Amount.Value, float64(debit.Amount.Value)/float64(total))
+				if amount <= 0 {
+					continue
+				}
+				edges = append(edges, transferEdge{
+					from:     credit.AccountID,
+					to:       debit.AccountID,
+					amount:   amount,
+					currency: credit.Amount.Currency,
+					at:       txn.ValidTime,
+					txnID:    txn.ID,
+				})
+			}
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		if !edges[i].at.Equal(edges[j].at) {
+			return edges[i].at.Before(edges[j].at)
+		}
+		return edges[i].txnID < edges[j].txnID
+	})
+	return edges
+}
+
+// cycleSearch finds chains of transfers that return money to the account it left,
+// each transfer following the one before it in time. Every transfer belongs to at
+// most one reported cycle, so repeated back-and-forth movements are counted once
+// each rather than in every combination.
+type cycleSearch struct {
+	edges   []transferEdge
+	out     map[string][]int // transfers leaving each account, in date order
+	used    []bool
+	options *CircularTransferOptions
+	steps   int
+
+	// state of the search from one starting transfer
+	path    []int
+	visited map[string]bool
+	best    []int
+}
+
+// findCircularTransfers returns each cycle found as the indexes of its transfers
+func findCircularTransfers(edges []transferEdge, options *CircularTransferOptions) [][]int {
+	search := &cycleSearch{
+		edges:   edges,
+		out:     make(map[string][]int),
+		used:    make([]bool, len(edges)),
+		options: options,
+	}
+	for i, edge := range edges {
+		search.out[edge.from] = append(search.out[edge.from], i)
+	}
+
+	var cycles [][]int
+	for start, edge := range edges {
+		if search.used[start] || edge.amount < options.MinAmount {
+			continue
+		}
+		search.path = append(search.path[:0], start)
+		search.visited = map[string]bool{edge.from: true, edge.to: true}
+		search.best = nil
+		search.extend(edge.amount, edge.amount)
+		if search.best != nil {
+			for _, i := range search.best {
+				search.used[i] = true
+			}
+			cycles = append(cycles, search.best)
+		}
+		if search.steps >= maxCycleSearchSteps {
+			break
+		}
+	}
+	return cycles
+}
+
+// extend follows the transfers out of the account the path last reached, keeping
+// the cycle that closes soonest. Paths that cannot close before it are pruned.
+func (cs *cycleSearch) extend(smallest, largest int64) {
+	first := cs.edges[cs.path[0]]
+	last := cs.edges[cs.path[len(cs.path)-1]]
+	deadline := first.at.Add(cs.options.Window)
+	if cs.best != nil {
+		deadline = cs.edges[cs.best[len(cs.best)-1]].at
+	}
+
+	for _, next := range cs.out[last.to] {
+		if next <= cs.path[len(cs.path)-1] || cs.used[next] {
+			continue
+		}
+		edge := cs.edges[next]
+		if edge.at.After(deadline) {
+			break
+		}
+		if cs.steps++; cs.steps >= maxCycleSearchSteps {
+			return
+		}
+		if edge.currency != first.currency || edge.amount < cs.options.MinAmount {
+			continue
+		}
+		low, high := min(smallest, edge.amount), max(largest, edge.amount)
+		if float64(low) < cs.options.MinRetention*float64(high) {
+			continue
+		}
+
+		if edge.to == first.from {
+			if cs.best == nil || edge.at.Before(deadline) {
+				cs.best = append(append([]int(nil), cs.path...), next)
+				deadline = edge.at
+			}
+			continue
+		}
+		if cs.visited[edge.to] || len(cs.path)+1 >= cs.options.MaxCycleLength {
+			continue
+		}
+		cs.path = append(cs.path, next)
+		cs.visited[edge.to] = true
+		cs.extend(low, high)
+		cs.visited[edge.to] = false
+		cs.path = cs.path[:len(cs.path)-1]
+		if cs.best != nil {
+			deadline = cs.edges[cs.best[len(cs.best)-1]].at
+		}
+	}
+}
+
+// circularTransferPattern describes a cycle of transfers. Confidence grows with the
+// share of the money that came back and with how quickly it did.
+func circularTransferPattern(edges []transferEdge, cycle []int, window time.Duration) SuspiciousPattern {
+	first, last := edges[cycle[0]], edges[cycle[len(cycle)-1]]
+	elapsed := last.at.Sub(first.at)
+
+	pattern := SuspiciousPattern{
+		ID:         newID(),
+		Type:       FlagCircularTransfers,
+		Severity:   SeverityMedium,
+		DetectedAt: time.Now(),
+	}
+	route := []string{first.from}
+	smallest, largest := int64(math.MaxInt64), int64(0)
+	for _, i := range cycle {
+		edge := edges[i]
+		route = append(route, edge.to)
+		pattern.Accounts = append(pattern.Accounts, edge.from)
+		pattern.Transactions = append(pattern.Transactions, edge.txnID)
+		pattern.Timeline = append(pattern.Timeline, edge.at)
+		amount := &Amount{Value: edge.amount, Currency: edge.currency}
+		pattern.Evidence = append(pattern.Evidence, fmt.Sprintf("%s → %s: %s on %s (transaction %s)",
+			edge.from, edge.to, amount.Format(), edge.at.Format("2006-01-02"), edge.txnID))
+		smallest, largest = min(smallest, edge.amount), max(largest, edge.amount)
+	}
+
+	retention := float64(smallest) / float64(largest)
+	speed := 1.0
+	if window > 0 {
+		speed = 1 - float64(elapsed)/float64(window)
+	}
+	pattern.Confidence = math.Min(0.3+0.5*retention+0.2*speed, 0.99)
+	if pattern.Confidence >= 0.85 {
+		pattern.Severity = SeverityHigh
+	}
+	pattern.Description = fmt.Sprintf("Funds cycled %s within %s", strings.Join(route, " → "), formatElapsed(elapsed))
+	pattern.Evidence = append(pattern.Evidence, fmt.Sprintf("%d transfers; %.0f%% of the largest transfer made the full cycle", len(cycle), retention*100))
+	return pattern
+}
+
+// formatElapsed renders a duration in days and hours
+func formatElapsed(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	default:
+		return fmt.Sprintf("%dh", hours)
+	}
+}
+
+// DetectCircularTransfers finds money that left an account and came back to it
+// through other accounts, by a depth-first search of the transfers between
+// accounts bounded by the cycle length and time window. Posted transactions dated
+// from startDate to endDate inclusive are searched.
+func (fs *ForensicService) DetectCircularTransfers(startDate, endDate time.Time, options *CircularTransferOptions) ([]SuspiciousPattern, error) {
+	if options == nil {
+		options = DefaultCircularTransferOptions()
+	}
+	if endDate.Before(startDate) {
+		return nil, classify(ErrValidation, "circular transfer search ends before it starts")
+	}
+	if options.MaxCycleLength < 2 {
+		return nil, classify(ErrValidation, "a cycle needs at least two transfers")
+	}
+	if options.Window <= 0 {
+		return nil, classify(ErrValidation, "circular transfer window must be positive")
+	}
+	if options.MinRetention < 0 || options.MinRetention > 1 {
+		return nil, classify(ErrValidation, "minimum retention must be between 0 and 1")
+	}
+
+	transactions, err := fs.storage.GetTransactionsByDateRange("", startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	return circularTransferPatterns(transactions, options), nil
+}
+
+// circularTransferPatterns searches transactions for cycles and describes each one
+func circularTransferPatterns(transactions []*Transaction, options *CircularTransferOptions) []SuspiciousPattern {
+	edges := transferEdges(transactions)
+	patterns := []SuspiciousPattern{}
+	for _, cycle := range findCircularTransfers(edges, options) {
+		patterns = append(patterns, circularTransferPattern(edges, cycle, options.Window))
+	}
+	return patterns
+}
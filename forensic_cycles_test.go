@@ -0,0 +1,153 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircularTransfers(t *testing.T) {
+	// Setup
+	dbFile := "test_forensic_cycles.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "forensic_auditor"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	for _, account := range []*Account{
+		{ID: "shell_a", Code: "1910", Name: "Shell Company A", Type: Asset},
+		{ID: "shell_b", Code: "1920", Name: "Shell Company B", Type: Asset},
+	} {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+	forensics := engine.GetForensicService()
+
+	date := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 9, 0, 0, 0, time.UTC) }
+	transfer := func(from, to string, value int64, on time.Time) string {
+		txn := &Transaction{
+			Description: "Transfer",
+			ValidTime:   on,
+			Entries: []Entry{
+				{AccountID: to, Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: from, Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn.ID
+	}
+
+	// January: money goes round two shell companies and back within two days
+	ring := []string{
+		transfer("cash", "shell_a", 1000000, date(1, 2)),
+		transfer("shell_a", "shell_b", 990000, date(1, 3)),
+		transfer("shell_b", "cash", 980000, date(1, 4)),
+	}
+	// Ordinary trading and a reversed posting are not cycles
+	transfer("revenue", "accounts_receivable", 250000, date(1, 10))
+	transfer("accounts_receivable", "cash", 250000, date(1, 20))
+	mistake := transfer("cash", "expenses", 70000, date(1, 12))
+	_, err = engine.ReverseTransaction(mistake, "Posted to the wrong period", userID)
+	require.NoError(t, err)
+
+	// February: the money comes back, but only after nineteen days
+	transfer("cash", "shell_a", 500000, date(2, 1))
+	transfer("shell_a", "cash", 500000, date(2, 20))
+	// March: most of the money stays away
+	transfer("cash", "shell_b", 1000000, date(3, 1))
+	transfer("shell_b", "cash", 400000, date(3, 2))
+	// April: the accounts form a ring, but not in time order
+	transfer("shell_b", "shell_a", 300000, date(4, 5))
+	transfer("cash", "shell_b", 300000, date(4, 6))
+	transfer("shell_a", "cash", 300000, date(4, 7))
+	// May: the same money ping-pongs three times
+	for day := 1; day <= 6; day += 2 {
+		transfer("cash", "shell_a", 200000, date(5, day))
+		transfer("shell_a", "cash", 200000, date(5, day+1))
+	}
+
+	yearStart, yearEnd := date(1, 1), date(12, 31)
+
+	t.Run("Default Bounds", func(t *testing.T) {
+		patterns, err := forensics.DetectCircularTransfers(yearStart, yearEnd, nil)
+		require.NoError(t, err)
+		require.Len(t, patterns, 4, "the January ring and three May round trips")
+
+		ringPattern := patterns[0]
+		assert.Equal(t, FlagCircularTransfers, ringPattern.Type)
+		assert.Equal(t, []string{"cash", "shell_a", "shell_b"}, ringPattern.Accounts)
+		assert.Equal(t, ring, ringPattern.Transactions)
+		assert.Equal(t, []time.Time{date(1, 2), date(1, 3), date(1, 4)}, ringPattern.Timeline)
+		assert.Equal(t, "Funds cycled cash → shell_a → shell_b → cash within 2d", ringPattern.Description)
+		assert.Equal(t, SeverityHigh, ringPattern.Severity)
+		assert.InDelta(t, 0.3+0.5*0.98+0.2*5.0/7.0, ringPattern.Confidence, 1e-9)
+		assert.Contains(t, ringPattern.Evidence[0], "cash → shell_a: $10000.00 on 2025-01-02")
+		assert.Equal(t, "3 transfers; 98% of the largest transfer made the full cycle", ringPattern.Evidence[3])
+
+		for _, pattern := range patterns[1:] {
+			assert.Equal(t, []string{"cash", "shell_a"}, pattern.Accounts)
+			assert.Len(t, pattern.Transactions, 2)
+		}
+		assert.Equal(t, date(5, 5), patterns[3].Timeline[0], "each transfer joins one cycle")
+	})
+
+	t.Run("Search Bounds", func(t *testing.T) {
+		options := DefaultCircularTransferOptions()
+		options.Window = 30 * 24 * time.Hour
+		patterns, err := forensics.DetectCircularTransfers(date(2, 1), date(2, 28), options)
+		require.NoError(t, err)
+		require.Len(t, patterns, 1)
+		assert.Equal(t, "Funds cycled cash → shell_a → cash within 19d", patterns[0].Description)
+		assert.InDelta(t, 0.3+0.5+0.2*11.0/30.0, patterns[0].Confidence, 1e-9, "slow cycles score lower")
+
+		options = DefaultCircularTransferOptions()
+		options.MinRetention = 0.3
+		patterns, err = forensics.DetectCircularTransfers(date(3, 1), date(3, 31), options)
+		require.NoError(t, err)
+		require.Len(t, patterns, 1)
+		assert.Contains(t, patterns[0].Evidence[2], "40% of the largest transfer")
+
+		options = DefaultCircularTransferOptions()
+		options.MaxCycleLength = 2
+		patterns, err = forensics.DetectCircularTransfers(date(1, 1), date(1, 31), options)
+		require.NoError(t, err)
+		assert.Empty(t, patterns)
+
+		options.MinAmount = 300000
+		patterns, err = forensics.DetectCircularTransfers(yearStart, yearEnd, options)
+		require.NoError(t, err)
+		assert.Empty(t, patterns, "the May transfers are below the minimum")
+
+		patterns, err = forensics.DetectCircularTransfers(date(4, 1), date(4, 30), nil)
+		require.NoError(t, err)
+		assert.Empty(t, patterns, "transfers must follow each other in time")
+	})
+
+	t.Run("Suspicious Patterns", func(t *testing.T) {
+		patterns, err := forensics.DetectSuspiciousPatterns(date(1, 1), date(1, 31), "")
+		require.NoError(t, err)
+		var cycles []SuspiciousPattern
+		for _, pattern := range patterns {
+			if pattern.Type == FlagCircularTransfers {
+				cycles = append(cycles, pattern)
+			}
+		}
+		require.Len(t, cycles, 1)
+		assert.Equal(t, ring, cycles[0].Transactions)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := forensics.DetectCircularTransfers(yearEnd, yearStart, nil)
+		assert.ErrorIs(t, err, ErrValidation)
+		_, err = forensics.DetectCircularTransfers(yearStart, yearEnd, &CircularTransferOptions{MaxCycleLength: 1, Window: time.Hour})
+		assert.ErrorIs(t, err, ErrValidation)
+		_, err = forensics.DetectCircularTransfers(yearStart, yearEnd, &CircularTransferOptions{MaxCycleLength: 3})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
@@ -0,0 +1,271 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// Duplicate Payment and Invoice Detection
+// ----------------------------------------------------------------------------
+
+// DuplicatePaymentOptions sets how alike two payments or invoices must be to be
+// reported as duplicates. Nil uses the defaults.
+type DuplicatePaymentOptions struct {
+	VendorDimension DimensionKey  `json:"vendor_dimension"` // entry dimension naming the vendor; untagged entries match on account
+	Window          time.Duration `json:"window"`           // longest time between the original and the duplicate
+	AmountTolerance float64       `json:"amount_tolerance"` // largest difference between amounts, as a share of the larger
+	MinAmount       int64         `json:"min_amount"`       // smallest amount checked, in minor units
+	MinSimilarity   float64       `json:"min_similarity"`   // how alike descriptions must be, from 0 to 1
+}
+
+// DefaultDuplicatePaymentOptions returns the detector's defaults: vendors named by
+// the counterparty dimension, amounts within 1% of each other two weeks apart at
+// most, and descriptions at least 80% alike
+func DefaultDuplicatePaymentOptions() *DuplicatePaymentOptions {
+	return &DuplicatePaymentOptions{
+		VendorDimension: DimCounterparty,
+		Window:          14 * 24 * time.Hour,
+		AmountTolerance: 0.01,
+		MinAmount:       10000,
+		MinSimilarity:   0.8,
+	}
+}
+
+// disbursement is a posted transaction that pays money out or records money owed:
+// a payment credits an asset account, an invoice credits a liability
+type disbursement struct {
+	txn      *Transaction
+	flag     FlagType // FlagDuplicatePayment or FlagDuplicateInvoice
+	payee    string   // the vendor, or the debited account for untagged entries
+	account  string   // the account debited the most
+	amount   int64
+	currency Currency
+}
+
+// disbursements classifies posted transactions as payments or invoices. Reversals,
+// and transactions that are neither, are left out.
+func (fs *ForensicService) disbursements(transactions []*Transaction, options *DuplicatePaymentOptions) ([]*disbursement, error) {
+	accountTypes := make(map[string]AccountType)
+	accountType := func(id string) (AccountType, error) {
+		if accountType, ok := accountTypes[id]; ok {
+			return accountType, nil
+		}
+		account, err := fs.storage.GetAccount(id)
+		if err != nil {
+			return "", fmt.Errorf("failed to get account %s: %w", id, err)
+		}
+		accountTypes[id] = account.Type
+		return account.Type, nil
+	}
+
+	var result []*disbursement
+	for _, txn := range transactions {
+		if txn.Status != Posted || strings.HasPrefix(txn.SourceRef, "REVERSAL_") {
+			continue
+		}
+		item := &disbursement{txn: txn}
+		var largest int64
+		for i := range txn.Entries {
+			entry := &txn.Entries[i]
+			if vendor := entryDimension(entry, options.VendorDimension); vendor != "" && item.payee == "" {
+				item.payee = vendor
+			}
+			if entry.Type == Credit {
+				accountType, err := accountType(entry.AccountID)
+				if err != nil {
+					return nil, err
+				}
+				switch {
+				case accountType == Liability:
+					item.flag = FlagDuplicateInvoice
+				case accountType == Asset && item.flag == "":
+					item.flag = FlagDuplicatePayment
+				}
+			} else if entry.Amount.Value > largest {
+				largest = entry.Amount.Value
+				item.account, item.currency = entry.AccountID, entry.Amount.Currency
+			}
+		}
+		if item.flag == "" || item.account == "" {
+			continue
+		}
+		for _, entry := range txn.Entries {
+			if entry.Type == Debit && entry.Amount.Currency == item.currency {
+				item.amount += entry.Amount.Value
+			}
+		}
+		if item.amount < options.MinAmount {
+			continue
+		}
+		if item.payee == "" {
+			item.payee = item.account
+		}
+		result = append(result, item)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].txn.ValidTime.Before(result[j].txn.ValidTime) })
+	return result, nil
+}
+
+// normalizeDescription lowercases a description and keeps only its words and numbers
+func normalizeDescription(description string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// descriptionSimilarity is one minus the edit distance between normalized
+// descriptions relative to the longer one; identical descriptions score 1
+func descriptionSimilarity(a, b string) float64 {
+	ra, rb := []rune(normalizeDescription(a)), []rune(normalizeDescription(b))
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// duplicateMatch is a later disbursement that repeats an earlier one
+type duplicateMatch struct {
+	original, duplicate *disbursement
+	similarity          float64
+	sameReference       bool
+	confidence          float64
+}
+
+// matchDuplicate scores a pair of disbursements, returning nil when they are not
+// alike enough to be duplicates
+func matchDuplicate(original, duplicate *disbursement, options *DuplicatePaymentOptions) *duplicateMatch {
+	if original.flag != duplicate.flag || original.payee != duplicate.payee || original.currency != duplicate.currency {
+		return nil
+	}
+	gap := duplicate.txn.ValidTime.Sub(original.txn.ValidTime)
+	if gap > options.Window {
+		return nil
+	}
+	larger := max(original.amount, duplicate.amount)
+	difference := math.Abs(float64(original.amount - duplicate.amount))
+	if difference > options.AmountTolerance*float64(larger) {
+		return nil
+	}
+
+	match := &duplicateMatch{
+		original:      original,
+		duplicate:     duplicate,
+		similarity:    descriptionSimilarity(original.txn.Description, duplicate.txn.Description),
+		sameReference: original.txn.SourceRef != "" && original.txn.SourceRef == duplicate.txn.SourceRef,
+	}
+	if !match.sameReference && match.similarity < options.MinSimilarity {
+		return nil
+	}
+
+	amountCloseness := 1.0
+	if difference > 0 {
+		amountCloseness = 1 - difference/(options.AmountTolerance*float64(larger))
+	}
+	match.confidence = 0.4 + 0.3*match.similarity + 0.2*amountCloseness + 0.1*(1-float64(gap)/float64(options.Window))
+	if match.sameReference {
+		match.confidence = max(match.confidence, 0.95)
+	}
+	match.confidence = math.Min(match.confidence, 0.99)
+	return match
+}
+
+// findDuplicates pairs each disbursement with the earlier one it most resembles.
+// A disbursement is reported as a duplicate at most once, so a payment made three
+// times gives two pairs.
+func findDuplicates(items []*disbursement, options *DuplicatePaymentOptions) []*duplicateMatch {
+	var matches []*duplicateMatch
+	for j, duplicate := range items {
+		var best *duplicateMatch
+		for i := j - 1; i >= 0; i-- {
+			if duplicate.txn.ValidTime.Sub(items[i].txn.ValidTime) > options.Window {
+				break
+			}
+			if match := matchDuplicate(items[i], duplicate, options); match != nil && (best == nil || match.confidence > best.confidence) {
+				best = match
+			}
+		}
+		if best != nil {
+			matches = append(matches, best)
+		}
+	}
+	return matches
+}
+
+// duplicatePattern describes a duplicate pair, original first
+func duplicatePattern(match *duplicateMatch) SuspiciousPattern {
+	original, duplicate := match.original, match.duplicate
+	kind := "payment"
+	if original.flag == FlagDuplicateInvoice {
+		kind = "invoice"
+	}
+	pattern := SuspiciousPattern{
+		ID:           newID(),
+		Type:         original.flag,
+		Severity:     SeverityMedium,
+		Description:  fmt.Sprintf("Possible duplicate %s to %s", kind, original.payee),
+		Accounts:     []string{original.account},
+		Transactions: []string{original.txn.ID, duplicate.txn.ID},
+		Timeline:     []time.Time{original.txn.ValidTime, duplicate.txn.ValidTime},
+		Confidence:   match.confidence,
+		DetectedAt:   time.Now(),
+	}
+	if duplicate.account != original.account {
+		pattern.Accounts = append(pattern.Accounts, duplicate.account)
+	}
+	if pattern.Confidence >= 0.85 {
+		pattern.Severity = SeverityHigh
+	}
+	for _, item := range []*disbursement{original, duplicate} {
+		amount := &Amount{Value: item.amount, Currency: item.currency}
+		pattern.Evidence = append(pattern.Evidence, fmt.Sprintf("%s on %s: %q (transaction %s)",
+			amount.Format(), item.txn.ValidTime.Format("2006-01-02"), item.txn.Description, item.txn.ID))
+	}
+	pattern.Evidence = append(pattern.Evidence, fmt.Sprintf("descriptions %.0f%% alike", match.similarity*100))
+	if match.sameReference {
+		pattern.Evidence = append(pattern.Evidence, fmt.Sprintf("same reference %s", original.txn.SourceRef))
+	}
+	return pattern
+}
+
+// DetectDuplicatePayments finds payments and invoices recorded twice: the same
+// vendor, or the same account when entries carry no vendor, with near-identical
+// amounts within the window and descriptions alike by edit distance or sharing a
+// source reference. Each pattern pairs the original with its duplicate. Posted
+// transactions dated from startDate to endDate inclusive are searched.
+func (fs *ForensicService) DetectDuplicatePayments(startDate, endDate time.Time, options *DuplicatePaymentOptions) ([]SuspiciousPattern, error) {
+	if options == nil {
+		options = DefaultDuplicatePaymentOptions()
+	}
+	if endDate.Before(startDate) {
+		return nil, classify(ErrValidation, "duplicate payment search ends before it starts")
+	}
+	if options.Window <= 0 {
+		return nil, classify(ErrValidation, "duplicate payment window must be positive")
+	}
+	if options.AmountTolerance < 0 || options.AmountTolerance >= 1 {
+		return nil, classify(ErrValidation, "amount tolerance must be at least 0 and below 1")
+	}
+	if options.MinSimilarity < 0 || options.MinSimilarity > 1 {
+		return nil, classify(ErrValidation, "minimum similarity must be between 0 and 1")
+	}
+
+	transactions, err := fs.storage.GetTransactionsByDateRange("", startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	items, err := fs.disbursements(transactions, options)
+	if err != nil {
+		return nil, err
+	}
+	patterns := []SuspiciousPattern{}
+	for _, match := range findDuplicates(items, options) {
+		patterns = append(patterns, duplicatePattern(match))
+	}
+	return patterns, nil
+}
@@ -0,0 +1,154 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicatePayments(t *testing.T) {
+	// Setup
+	dbFile := "test_forensic_duplicates.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "forensic_auditor"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	forensics := engine.GetForensicService()
+
+	date := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 10, 0, 0, 0, time.UTC) }
+	book := func(debit, credit, vendor, description, reference string, value int64, on time.Time) string {
+		var dimensions []Dimension
+		if vendor != "" {
+			dimensions = []Dimension{{Key: DimCounterparty, Value: vendor}}
+		}
+		txn := &Transaction{
+			Description: description,
+			SourceRef:   reference,
+			ValidTime:   on,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: value, Currency: "USD"}, Dimensions: dimensions},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: value, Currency: "USD"}, Dimensions: dimensions},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn.ID
+	}
+
+	// The same invoice is booked twice under different descriptions
+	invoice := book("expenses", "accounts_payable", "acme", "Acme invoice 1001", "INV-1001", 250000, date(1, 3))
+	rebooked := book("expenses", "accounts_payable", "acme", "Office supplies", "INV-1001", 250000, date(1, 8))
+	// ...and paid twice
+	payment := book("accounts_payable", "cash", "acme", "Payment for INV-1001", "", 250000, date(1, 10))
+	repaid := book("accounts_payable", "cash", "acme", "Payment for INV 1001", "", 250000, date(1, 12))
+	// Another vendor paid the same amount is not a duplicate
+	book("accounts_payable", "cash", "globex", "Payment for INV-1001", "", 250000, date(1, 11))
+	// Nor is a different amount, or the next invoice weeks later
+	book("accounts_payable", "cash", "acme", "Payment for INV-1003", "", 260000, date(1, 13))
+	book("accounts_payable", "cash", "acme", "Payment for INV-1002", "", 250000, date(2, 20))
+	// A duplicate that was caught and reversed is not reported
+	mistake := book("accounts_payable", "cash", "globex", "Payment for INV-1001", "", 250000, date(1, 14))
+	_, err = engine.ReverseTransaction(mistake, "Paid twice", userID)
+	require.NoError(t, err)
+
+	// Untagged rent is matched on the account, with a small difference in amount
+	rent := book("expenses", "cash", "", "Office rent March", "", 400000, date(3, 1))
+	rentAgain := book("expenses", "cash", "", "Office rent - March", "", 399000, date(3, 3))
+	// Different spending of the same amount
+	book("expenses", "cash", "", "Courier", "", 30000, date(3, 5))
+	book("expenses", "cash", "", "Team lunch", "", 30000, date(3, 6))
+
+	from, to := date(1, 1), date(3, 31)
+
+	t.Run("Similarity", func(t *testing.T) {
+		assert.Equal(t, 1.0, descriptionSimilarity("Payment for INV-1001", "payment for inv 1001"))
+		assert.InDelta(t, 0.95, descriptionSimilarity("Payment for INV-1001", "Payment for INV-1002"), 1e-9)
+		assert.Less(t, descriptionSimilarity("Courier", "Team lunch"), 0.5)
+	})
+
+	t.Run("Detection", func(t *testing.T) {
+		patterns, err := forensics.DetectDuplicatePayments(from, to, nil)
+		require.NoError(t, err)
+		require.Len(t, patterns, 3)
+
+		invoicePattern := patterns[0]
+		assert.Equal(t, FlagDuplicateInvoice, invoicePattern.Type)
+		assert.Equal(t, []string{invoice, rebooked}, invoicePattern.Transactions)
+		assert.Equal(t, []string{"expenses"}, invoicePattern.Accounts)
+		assert.Equal(t, "Possible duplicate invoice to acme", invoicePattern.Description)
+		assert.Equal(t, 0.95, invoicePattern.Confidence, "a shared reference is strong evidence")
+		assert.Equal(t, SeverityHigh, invoicePattern.Severity)
+		assert.Equal(t, "same reference INV-1001", invoicePattern.Evidence[len(invoicePattern.Evidence)-1])
+
+		paymentPattern := patterns[1]
+		assert.Equal(t, FlagDuplicatePayment, paymentPattern.Type)
+		assert.Equal(t, []string{payment, repaid}, paymentPattern.Transactions)
+		assert.Equal(t, []time.Time{date(1, 10), date(1, 12)}, paymentPattern.Timeline)
+		assert.InDelta(t, 0.4+0.3+0.2+0.1*12/14.0, paymentPattern.Confidence, 1e-9)
+		assert.Contains(t, paymentPattern.Evidence[0], `$2500.00 on 2025-01-10: "Payment for INV-1001"`)
+		assert.Equal(t, "descriptions 100% alike", paymentPattern.Evidence[2])
+
+		rentPattern := patterns[2]
+		assert.Equal(t, []string{rent, rentAgain}, rentPattern.Transactions)
+		assert.Equal(t, "Possible duplicate payment to expenses", rentPattern.Description)
+		assert.Less(t, rentPattern.Confidence, paymentPattern.Confidence, "amounts differ by 0.25%")
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		options := DefaultDuplicatePaymentOptions()
+		options.Window = 60 * 24 * time.Hour
+		patterns, err := forensics.DetectDuplicatePayments(from, to, options)
+		require.NoError(t, err)
+		require.Len(t, patterns, 4, "the next invoice's payment now resembles the last one")
+		assert.Equal(t, []time.Time{date(1, 12), date(2, 20)}, patterns[2].Timeline)
+
+		options = DefaultDuplicatePaymentOptions()
+		options.AmountTolerance = 0
+		options.MinAmount = 300000
+		patterns, err = forensics.DetectDuplicatePayments(from, to, options)
+		require.NoError(t, err)
+		assert.Empty(t, patterns)
+
+		options = DefaultDuplicatePaymentOptions()
+		options.MinSimilarity = 0
+		patterns, err = forensics.DetectDuplicatePayments(date(3, 4), to, options)
+		require.NoError(t, err)
+		require.Len(t, patterns, 1, "any description matches")
+	})
+
+	t.Run("Suspicious Patterns", func(t *testing.T) {
+		patterns, err := forensics.DetectSuspiciousPatterns(from, to, "")
+		require.NoError(t, err)
+		var duplicates int
+		for _, pattern := range patterns {
+			if pattern.Type == FlagDuplicatePayment || pattern.Type == FlagDuplicateInvoice {
+				duplicates++
+			}
+		}
+		assert.Equal(t, 3, duplicates)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := forensics.DetectDuplicatePayments(to, from, nil)
+		assert.ErrorIs(t, err, ErrValidation)
+		options := DefaultDuplicatePaymentOptions()
+		options.Window = 0
+		_, err = forensics.DetectDuplicatePayments(from, to, options)
+		assert.ErrorIs(t, err, ErrValidation)
+		options = DefaultDuplicatePaymentOptions()
+		options.AmountTolerance = 1
+		_, err = forensics.DetectDuplicatePayments(from, to, options)
+		assert.ErrorIs(t, err, ErrValidation)
+		options = DefaultDuplicatePaymentOptions()
+		options.MinSimilarity = 2
+		_, err = forensics.DetectDuplicatePayments(from, to, options)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+}
@@ -0,0 +1,283 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Multi-Hop Money Trails
+// ----------------------------------------------------------------------------
+
+// MoneyTrailOptions bounds how far TraceMoneyTrails follows funds out of an
+// account. Nil uses the defaults.
+type MoneyTrailOptions struct {
+	MaxDepth       int           `json:"max_depth"`       // most transfers in a trail, at least 1
+	DecayTolerance float64       `json:"decay_tolerance"` // largest share of the traced funds one hop may shed, from 0 to 1
+	MaxHopGap      time.Duration `json:"max_hop_gap"`     // longest wait before the funds move on; zero for no limit
+	MinAmount      int64         `json:"min_amount"`      // smallest transfer followed, in minor units
+	MaxPaths       int           `json:"max_paths"`       // most trails reported; zero for no limit
+}
+
+// DefaultMoneyTrailOptions returns the tracer's defaults: up to five hops, each
+// carrying at least half the funds onward within thirty days of the last
+func DefaultMoneyTrailOptions() *MoneyTrailOptions {
+	return &MoneyTrailOptions{
+		MaxDepth:       5,
+		DecayTolerance: 0.5,
+		MaxHopGap:      30 * 24 * time.Hour,
+		MaxPaths:       100,
+	}
+}
+
+// Per-path flag thresholds
+const (
+	trailLayeringHops      = 3              // transfers before intact pass-through counts as layering
+	trailLayeringRetention = 0.9            // share of the funds that must survive every hop
+	trailRapidHopGap       = 24 * time.Hour // onward transfers sooner than this are rapid
+	trailComplexHops       = 4              // transfers before a route counts as complex
+	trailRoundUnit         = int64(100000)  // amounts in multiples of this are round
+)
+
+// MoneyTrailReport is every trail followed out of one account
+type MoneyTrailReport struct {
+	SourceAccountID string        `json:"source_account_id"`
+	StartDate       time.Time     `json:"start_date"`
+	EndDate         time.Time     `json:"end_date"`
+	Trails          []*MoneyTrail `json:"trails"`
+	Pruned          int           `json:"pruned"`    // branches cut by the decay, gap or amount bounds
+	Truncated       bool          `json:"truncated"` // the path or step cap stopped the search
+}
+
+// trailSearch follows transfers depth-first from the source account. A trail ends
+// where the funds stop moving or the next hop breaks a bound; only those complete
+// trails are reported, not their prefixes.
+type trailSearch struct {
+	edges   []transferEdge
+	out     map[string][]int // transfers leaving each account, in date order
+	options *MoneyTrailOptions
+	steps   int
+
+	path    []int
+	visited map[string]bool
+	found   [][]int
+	pruned  int
+}
+
+// extend follows every onward transfer from the account the path last reached,
+// carrying the smallest amount seen so far as the traced funds
+func (ts *trailSearch) extend(carried int64) {
+	last := ts.edges[ts.path[len(ts.path)-1]]
+	extended := false
+	if len(ts.path) < ts.options.MaxDepth {
+		for _, next := range ts.out[last.to] {
+			if ts.full() {
+				return
+			}
+			edge := ts.edges[next]
+			if next <= ts.path[len(ts.path)-1] || edge.txnID == last.txnID {
+				continue
+			}
+			if ts.options.MaxHopGap > 0 && edge.at.Sub(last.at) > ts.options.MaxHopGap {
+				break
+			}
+			if ts.steps++; ts.steps >= maxCycleSearchSteps {
+				return
+			}
+			if ts.visited[edge.to] || edge.currency != last.currency {
+				continue
+			}
+			if edge.amount < ts.options.MinAmount || float64(edge.amount) < (1-ts.options.DecayTolerance)*float64(carried) {
+				ts.pruned++
+				continue
+			}
+
+			extended = true
+			ts.path = append(ts.path, next)
+			ts.visited[edge.to] = true
+			ts.extend(min(carried, edge.amount))
+			ts.visited[edge.to] = false
+			ts.path = ts.path[:len(ts.path)-1]
+		}
+	}
+	if !extended && !ts.full() {
+		ts.found = append(ts.found, append([]int(nil), ts.path...))
+	}
+}
+
+// full reports whether the search has found as many trails as it may report
+func (ts *trailSearch) full() bool {
+	return ts.options.MaxPaths > 0 && len(ts.found) >= ts.options.MaxPaths
+}
+
+// TraceMoneyTrails follows funds out of an account through every account they
+// reach, hop by hop. Each transfer must come after the one before it, within
+// MaxHopGap, and carry at least 1-DecayTolerance of the funds traced so far;
+// branches that fail are pruned. Accounts are not revisited, so funds returning
+// to an account end the trail (DetectCircularTransfers reports those). Posted
+// transactions dated from startDate to endDate inclusive are searched.
+func (fs *ForensicService) TraceMoneyTrails(sourceAccountID string, startDate, endDate time.Time, options *MoneyTrailOptions) (*MoneyTrailReport, error) {
+	if options == nil {
+		options = DefaultMoneyTrailOptions()
+	}
+	if endDate.Before(startDate) {
+		return nil, classify(ErrValidation, "money trail search ends before it starts")
+	}
+	if options.MaxDepth < 1 {
+		return nil, classify(ErrValidation, "a money trail needs at least one transfer")
+	}
+	if options.DecayTolerance < 0 || options.DecayTolerance > 1 {
+		return nil, classify(ErrValidation, "decay tolerance must be between 0 and 1")
+	}
+	if options.MaxHopGap < 0 {
+		return nil, classify(ErrValidation, "maximum hop gap cannot be negative")
+	}
+	if _, err := fs.storage.GetAccount(sourceAccountID); err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	transactions, err := fs.storage.GetTransactionsByDateRange("", startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	edges := transferEdges(transactions)
+	search := &trailSearch{
+		edges:   edges,
+		out:     make(map[string][]int),
+		options: options,
+	}
+	for i, edge := range edges {
+		search.out[edge.from] = append(search.out[edge.from], i)
+	}
+
+	for _, start := range search.out[sourceAccountID] {
+		if search.full() || search.steps >= maxCycleSearchSteps {
+			break
+		}
+		edge := edges[start]
+		if edge.amount < options.MinAmount {
+			search.pruned++
+			continue
+		}
+		search.path = append(search.path[:0], start)
+		search.visited = map[string]bool{edge.from: true, edge.to: true}
+		search.extend(edge.amount)
+	}
+
+	report := &MoneyTrailReport{
+		SourceAccountID: sourceAccountID,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Trails:          []*MoneyTrail{},
+		Pruned:          search.pruned,
+		Truncated:       search.full() || search.steps >= maxCycleSearchSteps,
+	}
+	byID := make(map[string]*Transaction, len(transactions))
+	for _, txn := range transactions {
+		byID[txn.ID] = txn
+	}
+	for _, path := range search.found {
+		report.Trails = append(report.Trails, moneyTrail(edges, path, byID))
+	}
+	// Longest trails first, then by when they started
+	sort.SliceStable(report.Trails, func(i, j int) bool {
+		if report.Trails[i].TotalSteps != report.Trails[j].TotalSteps {
+			return report.Trails[i].TotalSteps > report.Trails[j].TotalSteps
+		}
+		return report.Trails[i].Path[0].Date.Before(report.Trails[j].Path[0].Date)
+	})
+	return report, nil
+}
+
+// moneyTrail describes one path of transfers and flags what is suspicious about it
+func moneyTrail(edges []transferEdge, path []int, transactions map[string]*Transaction) *MoneyTrail {
+	first, last := edges[path[0]], edges[path[len(path)-1]]
+	trail := &MoneyTrail{
+		ID:          newID(),
+		StartAmount: Amount{Value: first.amount, Currency: first.currency},
+		EndAmount:   Amount{Value: last.amount, Currency: last.currency},
+		StartDate:   first.at,
+		EndDate:     last.at,
+		TotalSteps:  len(path),
+	}
+	for _, i := range path {
+		edge := edges[i]
+		step := MoneyTrailStep{
+			TransactionID: edge.txnID,
+			FromAccount:   edge.from,
+			ToAccount:     edge.to,
+			Amount:        Amount{Value: edge.amount, Currency: edge.currency},
+			Date:          edge.at,
+		}
+		if txn := transactions[edge.txnID]; txn != nil {
+			step.Description = txn.Description
+			step.UserID = txn.CreatedBy
+		}
+		trail.Path = append(trail.Path, step)
+	}
+	trail.Flags = trailFlags(trail)
+	trail.Suspicious = len(trail.Flags) > 0
+	return trail
+}
+
+// trailFlags flags one path of a multi-hop trail: funds passed on nearly intact
+// through several accounts, moved on within a day at every hop, routed through
+// many accounts, or moved only in round amounts
+func trailFlags(trail *MoneyTrail) []ForensicFlag {
+	var flags []ForensicFlag
+	route := trail.Path[0].FromAccount
+	smallest, largest := int64(math.MaxInt64), int64(0)
+	rapid, round := 0, 0
+	for i, step := range trail.Path {
+		route += " → " + step.ToAccount
+		smallest, largest = min(smallest, step.Amount.Value), max(largest, step.Amount.Value)
+		if i > 0 && step.Date.Sub(trail.Path[i-1].Date) < trailRapidHopGap {
+			rapid++
+		}
+		if step.Amount.Value%trailRoundUnit == 0 {
+			round++
+		}
+	}
+	hops := len(trail.Path)
+	retention := float64(smallest) / float64(largest)
+
+	if hops >= trailLayeringHops && retention >= trailLayeringRetention {
+		flags = append(flags, ForensicFlag{
+			Type:        FlagLayering,
+			Severity:    SeverityHigh,
+			Description: "Funds passed through intermediary accounts nearly intact",
+			Evidence:    []string{route, fmt.Sprintf("%.0f%% of the funds reached %s", retention*100, trail.Path[hops-1].ToAccount)},
+			Triggered:   time.Now(),
+		})
+	}
+	if hops > 1 && rapid == hops-1 {
+		flags = append(flags, ForensicFlag{
+			Type:        FlagRapidMovement,
+			Severity:    SeverityMedium,
+			Description: "Funds moved on within a day at every hop",
+			Evidence:    []string{fmt.Sprintf("%d hops in %s", hops, formatElapsed(trail.EndDate.Sub(trail.StartDate)))},
+			Triggered:   time.Now(),
+		})
+	}
+	if hops >= trailComplexHops {
+		flags = append(flags, ForensicFlag{
+			Type:        FlagComplexRouting,
+			Severity:    SeverityMedium,
+			Description: "Funds routed through many accounts",
+			Evidence:    []string{route},
+			Triggered:   time.Now(),
+		})
+	}
+	if hops > 1 && round == hops {
+		flags = append(flags, ForensicFlag{
+			Type:        FlagRoundAmounts,
+			Severity:    SeverityLow,
+			Description: "Every transfer on the trail is a round amount",
+			Evidence:    []string{fmt.Sprintf("%d round transfers", round)},
+			Triggered:   time.Now(),
+		})
+	}
+	return flags
+}
@@ -0,0 +1,158 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiHopMoneyTrails(t *testing.T) {
+	// Setup
+	dbFile := "test_forensic_trails.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "forensic_auditor"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	for _, account := range []*Account{
+		{ID: "intermediary", Code: "1930", Name: "Intermediary Ltd", Type: Asset},
+		{ID: "offshore", Code: "1940", Name: "Offshore Holdings", Type: Asset},
+		{ID: "shell", Code: "1950", Name: "Shell Company", Type: Asset},
+	} {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+	forensics := engine.GetForensicService()
+
+	at := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2025, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	transfer := func(from, to string, value int64, on time.Time) string {
+		txn := &Transaction{
+			Description: "Transfer to " + to,
+			ValidTime:   on,
+			Entries: []Entry{
+				{AccountID: to, Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: from, Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn.ID
+	}
+
+	// The shell's earlier payment cannot be funded by money that arrives later
+	transfer("shell", "accounts_payable", 900000, at(1, 1, 9))
+	layered := []string{
+		transfer("cash", "intermediary", 1000000, at(1, 2, 9)),
+		transfer("intermediary", "offshore", 980000, at(1, 2, 15)),
+		transfer("offshore", "shell", 970000, at(1, 3, 9)),
+	}
+	// A small payment out of the intermediary sheds most of the funds
+	skim := transfer("intermediary", "expenses", 100000, at(1, 5, 9))
+	// The shell spends the money two months later
+	transfer("shell", "expenses", 900000, at(3, 1, 9))
+	// An ordinary expense goes nowhere further
+	expense := transfer("cash", "expenses", 50000, at(1, 10, 9))
+
+	from, to := at(1, 1, 0), at(3, 31, 0)
+
+	t.Run("Default Bounds", func(t *testing.T) {
+		report, err := forensics.TraceMoneyTrails("cash", from, to, nil)
+		require.NoError(t, err)
+		require.Len(t, report.Trails, 2)
+		assert.Equal(t, 1, report.Pruned, "the skim sheds more than half the funds")
+		assert.False(t, report.Truncated)
+
+		trail := report.Trails[0]
+		require.Equal(t, 3, trail.TotalSteps)
+		var route []string
+		for i, step := range trail.Path {
+			assert.Equal(t, layered[i], step.TransactionID)
+			route = append(route, step.FromAccount)
+		}
+		assert.Equal(t, []string{"cash", "intermediary", "offshore"}, route)
+		assert.Equal(t, "shell", trail.Path[2].ToAccount)
+		assert.Equal(t, "Transfer to offshore", trail.Path[1].Description)
+		assert.Equal(t, userID, trail.Path[1].UserID)
+		assert.Equal(t, int64(1000000), trail.StartAmount.Value)
+		assert.Equal(t, int64(970000), trail.EndAmount.Value)
+		assert.Equal(t, at(1, 2, 9), trail.StartDate)
+		assert.Equal(t, at(1, 3, 9), trail.EndDate)
+
+		assert.True(t, trail.Suspicious)
+		var flags []FlagType
+		for _, flag := range trail.Flags {
+			flags = append(flags, flag.Type)
+		}
+		assert.Equal(t, []FlagType{FlagLayering, FlagRapidMovement}, flags)
+		assert.Equal(t, []string{"cash → intermediary → offshore → shell", "97% of the funds reached shell"}, trail.Flags[0].Evidence)
+		assert.Equal(t, SeverityHigh, trail.Flags[0].Severity)
+
+		ordinary := report.Trails[1]
+		require.Len(t, ordinary.Path, 1)
+		assert.Equal(t, expense, ordinary.Path[0].TransactionID)
+		assert.False(t, ordinary.Suspicious)
+	})
+
+	t.Run("Configured Bounds", func(t *testing.T) {
+		options := DefaultMoneyTrailOptions()
+		options.MaxHopGap = 0
+		report, err := forensics.TraceMoneyTrails("cash", from, to, options)
+		require.NoError(t, err)
+		require.Equal(t, 4, report.Trails[0].TotalSteps, "without a gap limit the March payment continues the trail")
+		assert.Equal(t, "expenses", report.Trails[0].Path[3].ToAccount)
+		var flags []FlagType
+		for _, flag := range report.Trails[0].Flags {
+			flags = append(flags, flag.Type)
+		}
+		assert.Equal(t, []FlagType{FlagLayering, FlagComplexRouting}, flags)
+
+		options = DefaultMoneyTrailOptions()
+		options.MaxDepth = 2
+		report, err = forensics.TraceMoneyTrails("cash", from, to, options)
+		require.NoError(t, err)
+		assert.Equal(t, 2, report.Trails[0].TotalSteps)
+		assert.Equal(t, "offshore", report.Trails[0].Path[1].ToAccount)
+		require.Len(t, report.Trails[0].Flags, 1, "two hops are not layering")
+		assert.Equal(t, FlagRapidMovement, report.Trails[0].Flags[0].Type)
+
+		options = DefaultMoneyTrailOptions()
+		options.DecayTolerance = 0.95
+		report, err = forensics.TraceMoneyTrails("cash", from, to, options)
+		require.NoError(t, err)
+		require.Len(t, report.Trails, 3)
+		assert.Equal(t, skim, report.Trails[1].Path[1].TransactionID)
+		assert.Zero(t, report.Pruned)
+
+		options = DefaultMoneyTrailOptions()
+		options.MaxPaths = 1
+		report, err = forensics.TraceMoneyTrails("cash", from, to, options)
+		require.NoError(t, err)
+		require.Len(t, report.Trails, 1)
+		assert.True(t, report.Truncated)
+
+		report, err = forensics.TraceMoneyTrails("intermediary", at(1, 3, 0), to, nil)
+		require.NoError(t, err)
+		require.Len(t, report.Trails, 1, "only transfers dated in the range are followed")
+		assert.Equal(t, skim, report.Trails[0].Path[0].TransactionID)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := forensics.TraceMoneyTrails("cash", to, from, nil)
+		assert.ErrorIs(t, err, ErrValidation)
+		_, err = forensics.TraceMoneyTrails("cash", from, to, &MoneyTrailOptions{})
+		assert.ErrorIs(t, err, ErrValidation)
+		_, err = forensics.TraceMoneyTrails("cash", from, to, &MoneyTrailOptions{MaxDepth: 3, DecayTolerance: 1.5})
+		assert.ErrorIs(t, err, ErrValidation)
+		_, err = forensics.TraceMoneyTrails("cash", from, to, &MoneyTrailOptions{MaxDepth: 3, MaxHopGap: -time.Hour})
+		assert.ErrorIs(t, err, ErrValidation)
+		_, err = forensics.TraceMoneyTrails("missing", from, to, nil)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
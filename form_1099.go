@@ -0,0 +1,706 @@
+package accounting
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// Vendor Tax Profiles
+// ----------------------------------------------------------------------------
+
+// TINType says whether a taxpayer identification number is an EIN or an SSN
+type TINType string
+
+const (
+	TINEmployer   TINType = "EIN"
+	TINIndividual TINType = "SSN"
+)
+
+// Form1099Type is the information return a vendor's payments are reported on
+type Form1099Type string
+
+const (
+	Form1099NEC  Form1099Type = "1099-NEC"
+	Form1099MISC Form1099Type = "1099-MISC"
+)
+
+// form1099Boxes are the payment boxes of each form, with the amount code the IRS
+// FIRE format reports each under. Box 4, federal income tax withheld, is filled
+// from withholding rather than mapped.
+var form1099Boxes = map[Form1099Type]map[string]string{
+	Form1099NEC:  {"1": "1"},
+	Form1099MISC: {"1": "1", "2": "2", "3": "3", "6": "6", "10": "C"},
+}
+
+// form1099WithheldBox is the federal income tax withheld box on both forms
+const form1099WithheldBox = "4"
+
+// form1099Threshold is the amount, in cents, from which a box must be reported:
+// $10 of royalties, $600 of anything else
+func form1099Threshold(form Form1099Type, box string) int64 {
+	if form == Form1099MISC && box == "2" {
+		return 1000
+	}
+	return 60000
+}
+
+// VendorTaxProfile holds what is needed to report a vendor's payments to the IRS
+// and to withhold tax from them. The TIN itself is the vendor's TaxID.
+type VendorTaxProfile struct {
+	TINType         TINType      `json:"tin_type"`
+	LegalName       string       `json:"legal_name,omitempty"` // the name the TIN is registered to, when not the vendor name
+	Address         string       `json:"address,omitempty"`
+	City            string       `json:"city,omitempty"`
+	State           string       `json:"state,omitempty"` // two-letter code
+	PostalCode      string       `json:"postal_code,omitempty"`
+	Reportable      bool         `json:"reportable"` // payments go on a 1099
+	Form            Form1099Type `json:"form,omitempty"`
+	Box             string       `json:"box,omitempty"`              // e.g. "1" for nonemployee compensation on a 1099-NEC
+	WithholdingRate float64      `json:"withholding_rate,omitempty"` // e.g. 0.24 for backup withholding
+}
+
+// validate checks the profile against the vendor's TIN. Reportable vendors default
+// to box 1 of the 1099-NEC.
+func (p *VendorTaxProfile) validate(taxID string) error {
+	if p.WithholdingRate < 0 || p.WithholdingRate >= 1 {
+		return fmt.Errorf("withholding rate must be at least 0 and below 1")
+	}
+	if p.State != "" && len(p.State) != 2 {
+		return fmt.Errorf("state must be a two-letter code")
+	}
+	if p.TINType != "" && p.TINType != TINEmployer && p.TINType != TINIndividual {
+		return fmt.Errorf("unknown TIN type %s", p.TINType)
+	}
+	if !p.Reportable {
+		return nil
+	}
+
+	if len(tinDigits(taxID)) != 9 {
+		return fmt.Errorf("reportable vendors need a nine-digit tax ID")
+	}
+	if p.TINType == "" {
+		return fmt.Errorf("reportable vendors need a TIN type")
+	}
+	p.Form = cmp.Or(p.Form, Form1099NEC)
+	boxes, ok := form1099Boxes[p.Form]
+	if !ok {
+		return fmt.Errorf("unknown form %s", p.Form)
+	}
+	if p.Box == "" && p.Form == Form1099NEC {
+		p.Box = "1"
+	}
+	if _, ok := boxes[p.Box]; !ok {
+		return fmt.Errorf("%s has no payment box %q", p.Form, p.Box)
+	}
+	return nil
+}
+
+// tinDigits strips the dashes and spaces from a TIN
+func tinDigits(taxID string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, taxID)
+}
+
+// SetVendorTaxProfile sets or replaces a vendor's tax profile
+func (ps *PayablesService) SetVendorTaxProfile(vendorID string, profile *VendorTaxProfile, userID string) (*Vendor, error) {
+	vendor, err := ps.storage.GetVendor(vendorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vendor: %w", err)
+	}
+	if err := profile.validate(vendor.TaxID); err != nil {
+		return nil, classify(ErrValidation, "invalid tax profile for vendor %s: %v", vendor.Name, err)
+	}
+	vendor.TaxProfile = profile
+
+	_, err = ps.eventStore.CreateEvent(EventSetVendorTaxProfile, vendor, time.Now(), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vendor tax profile event: %w", err)
+	}
+	if err := ps.storage.SaveVendor(vendor); err != nil {
+		return nil, fmt.Errorf("failed to save vendor: %w", err)
+	}
+	return vendor, nil
+}
+
+// withholdingOn returns the tax to withhold from paying a bill at its vendor's rate
+func (ps *PayablesService) withholdingOn(bill *VendorBill) (int64, error) {
+	vendor, err := ps.storage.GetVendor(bill.VendorID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get vendor: %w", err)
+	}
+	if vendor.TaxProfile == nil || vendor.TaxProfile.WithholdingRate == 0 {
+		return 0, nil
+	}
+	withheld, err := applyRate(bill.Total.Value, vendor.TaxProfile.WithholdingRate, RoundHalfUp)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate withholding: %w", err)
+	}
+	return withheld, nil
+}
+
+// ----------------------------------------------------------------------------
+// Reportable Payments
+// ----------------------------------------------------------------------------
+
+// Vendor1099Payment is a payment to a reportable vendor, recorded as it posts
+type Vendor1099Payment struct {
+	ID            string       `json:"id"`
+	VendorID      string       `json:"vendor_id"`
+	TransactionID string       `json:"transaction_id"`
+	PaidAt        time.Time    `json:"paid_at"`
+	Year          int          `json:"year"`
+	Form          Form1099Type `json:"form"`
+	Box           string       `json:"box"`
+	Amount        int64        `json:"amount"`   // gross, before withholding; negative for a voided payment
+	Withheld      int64        `json:"withheld"` // federal income tax withheld
+	Currency      Currency     `json:"currency"`
+	RecordedAt    time.Time    `json:"recorded_at"`
+}
+
+// Vendor1099Summary is what one vendor is reported for on one form for a year, in cents
+type Vendor1099Summary struct {
+	VendorID       string           `json:"vendor_id"`
+	VendorName     string           `json:"vendor_name"`
+	PayeeName      string           `json:"payee_name"`
+	TaxID          string           `json:"tax_id"`
+	TINType        TINType          `json:"tin_type"`
+	Address        string           `json:"address,omitempty"`
+	City           string           `json:"city,omitempty"`
+	State          string           `json:"state,omitempty"`
+	PostalCode     string           `json:"postal_code,omitempty"`
+	Form           Form1099Type     `json:"form"`
+	Boxes          map[string]int64 `json:"boxes"`
+	Total          int64            `json:"total"` // payments, excluding the tax withheld box
+	Withheld       int64            `json:"withheld"`
+	Payments       int              `json:"payments"`
+	FilingRequired bool             `json:"filing_required"` // a box reached its threshold, or tax was withheld
+}
+
+// Form1099Summary is the year-end 1099 position of every reportable vendor
+type Form1099Summary struct {
+	Year    int                  `json:"year"`
+	Vendors []*Vendor1099Summary `json:"vendors"`
+	Filings map[Form1099Type]int `json:"filings"` // forms to file, by type
+	// Payments in other currencies than USD, which must be converted before filing
+	UnconvertedPayments int       `json:"unconverted_payments,omitempty"`
+	GeneratedAt         time.Time `json:"generated_at"`
+}
+
+// Form1099Payer identifies the filer of a FIRE submission
+type Form1099Payer struct {
+	TIN          string `json:"tin"`
+	TCC          string `json:"tcc"` // transmitter control code issued by the IRS
+	Name         string `json:"name"`
+	Address      string `json:"address"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+	PostalCode   string `json:"postal_code"`
+	Phone        string `json:"phone"`
+	ContactName  string `json:"contact_name"`
+	ContactEmail string `json:"contact_email"`
+	Test         bool   `json:"test"` // marks the file as a test submission
+}
+
+// ----------------------------------------------------------------------------
+// Form 1099 Service
+// ----------------------------------------------------------------------------
+
+// Form1099Service accumulates payments to reportable vendors and produces the
+// year-end 1099 summaries and filings
+type Form1099Service struct {
+	storage    *Storage
+	eventStore *EventStore
+	payables   *PayablesService
+}
+
+// NewForm1099Service creates a new form 1099 service
+func NewForm1099Service(storage *Storage, eventStore *EventStore, payables *PayablesService) *Form1099Service {
+	return &Form1099Service{
+		storage:    storage,
+		eventStore: eventStore,
+		payables:   payables,
+	}
+}
+
+// RecordPayments is a posting hook recording payments to reportable vendors. A
+// payment is a transaction crediting an asset account, such as a bank account: each
+// reportable vendor's debits in it, less its credits, are the gross payment, and
+// its credits on the withholding account are the tax withheld. Vendors are found by
+// the counterparty dimension. A reversal voids the payments of the transaction it
+// reverses.
+func (fs *Form1099Service) RecordPayments(txn *Transaction, userID string) error {
+	if originalID, ok := strings.CutPrefix(txn.SourceRef, "REVERSAL_"); ok {
+		return fs.voidPayments(originalID, txn, userID)
+	}
+	paysOut, err := fs.creditsAsset(txn)
+	if err != nil || !paysOut {
+		return err
+	}
+
+	withholdingAccountID := fs.payables.getWithholdingAccountID()
+	var payments []*Vendor1099Payment
+	for _, entry := range txn.Entries {
+		vendorID := counterpartyOf(entry)
+		if vendorID == "" {
+			continue
+		}
+		index := slices.IndexFunc(payments, func(p *Vendor1099Payment) bool {
+			return p.VendorID == vendorID && p.Currency == entry.Amount.Currency
+		})
+		if index < 0 {
+			payments = append(payments, &Vendor1099Payment{VendorID: vendorID, Currency: entry.Amount.Currency})
+			index = len(payments) - 1
+		}
+		payment := payments[index]
+		switch {
+		case entry.Type == Credit && entry.AccountID == withholdingAccountID:
+			payment.Withheld += entry.Amount.Value
+		case entry.Type == Debit:
+			payment.Amount += entry.Amount.Value
+		default:
+			payment.Amount -= entry.Amount.Value
+		}
+	}
+
+	for _, payment := range payments {
+		if payment.Amount == 0 && payment.Withheld == 0 {
+			continue
+		}
+		vendor, err := fs.storage.GetVendor(payment.VendorID)
+		if errors.Is(err, ErrNotFound) {
+			continue // a counterparty that is not a vendor
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get vendor: %w", err)
+		}
+		if vendor.TaxProfile == nil || !vendor.TaxProfile.Reportable {
+			continue
+		}
+		payment.Form = vendor.TaxProfile.Form
+		payment.Box = vendor.TaxProfile.Box
+		if err := fs.record(payment, txn, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// voidPayments records the reversal of a transaction's reportable payments
+func (fs *Form1099Service) voidPayments(originalID string, reversal *Transaction, userID string) error {
+	recorded, err := fs.storage.GetAllVendor1099Payments()
+	if err != nil {
+		return fmt.Errorf("failed to get 1099 payments: %w", err)
+	}
+	for _, original := range recorded {
+		if original.TransactionID != originalID {
+			continue
+		}
+		voided := &Vendor1099Payment{
+			VendorID: original.VendorID,
+			Form:     original.Form,
+			Box:      original.Box,
+			Amount:   -original.Amount,
+			Withheld: -original.Withheld,
+			Currency: original.Currency,
+		}
+		if err := fs.record(voided, reversal, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// record dates a payment by its transaction and saves it, once: a transaction
+// posted again does not pay the vendor twice
+func (fs *Form1099Service) record(payment *Vendor1099Payment, txn *Transaction, userID string) error {
+	if fs.storage.HasVendor1099Payment(txn.ID, payment.VendorID, payment.Currency) {
+		return nil
+	}
+	payment.ID = vendor1099PaymentKey(txn.ID, payment.VendorID, payment.Currency)
+	payment.TransactionID = txn.ID
+	payment.PaidAt = txn.ValidTime
+	payment.Year = txn.ValidTime.Year()
+	payment.RecordedAt = time.Now()
+
+	_, err := fs.eventStore.CreateEvent(EventRecord1099Payment, payment, txn.ValidTime, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create 1099 payment event: %w", err)
+	}
+	if err := fs.storage.SaveVendor1099Payment(payment); err != nil {
+		return fmt.Errorf("failed to save 1099 payment: %w", err)
+	}
+	return nil
+}
+
+// creditsAsset reports whether a transaction pays money out of an asset account
+func (fs *Form1099Service) creditsAsset(txn *Transaction) (bool, error) {
+	for _, entry := range txn.Entries {
+		if entry.Type != Credit {
+			continue
+		}
+		account, err := fs.storage.GetAccount(entry.AccountID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get account %s: %w", entry.AccountID, err)
+		}
+		if account.Type == Asset {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// counterpartyOf returns the counterparty an entry is tagged with
+func counterpartyOf(entry Entry) string {
+	for _, dim := range entry.Dimensions {
+		if dim.Key == DimCounterparty {
+			return dim.Value
+		}
+	}
+	return ""
+}
+
+// Summary totals a year's reportable payments per vendor and form. Filing is
+// required once a box reaches its threshold, or whenever tax was withheld.
+func (fs *Form1099Service) Summary(year int) (*Form1099Summary, error) {
+	recorded, err := fs.storage.GetAllVendor1099Payments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get 1099 payments: %w", err)
+	}
+
+	summary := &Form1099Summary{Year: year, Filings: make(map[Form1099Type]int), GeneratedAt: time.Now()}
+	for _, payment := range recorded {
+		if payment.Year != year {
+			continue
+		}
+		if payment.Currency != "USD" {
+			summary.UnconvertedPayments++
+			continue
+		}
+		index := slices.IndexFunc(summary.Vendors, func(v *Vendor1099Summary) bool {
+			return v.VendorID == payment.VendorID && v.Form == payment.Form
+		})
+		if index < 0 {
+			line, err := fs.vendorSummary(payment.VendorID, payment.Form)
+			if err != nil {
+				return nil, err
+			}
+			summary.Vendors = append(summary.Vendors, line)
+			index = len(summary.Vendors) - 1
+		}
+		line := summary.Vendors[index]
+		if payment.Amount != 0 {
+			line.Boxes[payment.Box] += payment.Amount
+			line.Total += payment.Amount
+		}
+		if payment.Withheld != 0 {
+			line.Boxes[form1099WithheldBox] += payment.Withheld
+			line.Withheld += payment.Withheld
+		}
+		line.Payments++
+	}
+
+	for _, line := range summary.Vendors {
+		line.FilingRequired = line.Withheld > 0
+		for box, amount := range line.Boxes {
+			if box != form1099WithheldBox && amount >= form1099Threshold(line.Form, box) {
+				line.FilingRequired = true
+			}
+		}
+		if line.FilingRequired {
+			summary.Filings[line.Form]++
+		}
+	}
+	slices.SortFunc(summary.Vendors, func(a, b *Vendor1099Summary) int {
+		return cmp.Or(cmp.Compare(a.Form, b.Form), cmp.Compare(a.PayeeName, b.PayeeName), cmp.Compare(a.VendorID, b.VendorID))
+	})
+	return summary, nil
+}
+
+// vendorSummary starts a vendor's summary line from its current tax profile
+func (fs *Form1099Service) vendorSummary(vendorID string, form Form1099Type) (*Vendor1099Summary, error) {
+	vendor, err := fs.storage.GetVendor(vendorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vendor: %w", err)
+	}
+	line := &Vendor1099Summary{
+		VendorID:   vendor.ID,
+		VendorName: vendor.Name,
+		PayeeName:  vendor.Name,
+		TaxID:      vendor.TaxID,
+		Form:       form,
+		Boxes:      make(map[string]int64),
+	}
+	if profile := vendor.TaxProfile; profile != nil {
+		line.PayeeName = cmp.Or(profile.LegalName, vendor.Name)
+		line.TINType = profile.TINType
+		line.Address = profile.Address
+		line.City = profile.City
+		line.State = profile.State
+		line.PostalCode = profile.PostalCode
+	}
+	return line, nil
+}
+
+// Export renders a year's 1099s as "CSV", one row per vendor and form with every box,
+// or as "FIRE", the fixed-width file of IRS Publication 1220 for the vendors that must
+// be filed for. Both carry full TINs.
+func (fs *Form1099Service) Export(year int, format string, payer *Form1099Payer) ([]byte, error) {
+	summary, err := fs.Summary(year)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "CSV":
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		rows := [][]string{{"year", "form", "vendor_id", "payee_name", "tin_type", "tin", "address", "city", "state", "postal_code", "box", "amount", "filing_required"}}
+		for _, line := range summary.Vendors {
+			boxes := make([]string, 0, len(line.Boxes))
+			for box := range line.Boxes {
+				boxes = append(boxes, box)
+			}
+			slices.SortFunc(boxes, compareBoxes)
+			for _, box := range boxes {
+				rows = append(rows, []string{
+					strconv.Itoa(year), string(line.Form), line.VendorID, line.PayeeName, string(line.TINType), line.TaxID,
+					line.Address, line.City, line.State, line.PostalCode,
+					box, FormatMinorUnits(line.Boxes[box], "USD"), strconv.FormatBool(line.FilingRequired),
+				})
+			}
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return nil, fmt.Errorf("failed to write CSV: %w", err)
+		}
+		return buf.Bytes(), nil
+	case "FIRE":
+		if payer == nil {
+			return nil, classify(ErrValidation, "a FIRE file needs the payer")
+		}
+		return fireFile(summary, payer)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// compareBoxes orders box numbers numerically
+func compareBoxes(a, b string) int {
+	x, _ := strconv.Atoi(a)
+	y, _ := strconv.Atoi(b)
+	return cmp.Compare(x, y)
+}
+
+// ----------------------------------------------------------------------------
+// IRS FIRE Format
+// ----------------------------------------------------------------------------
+
+// fireRecordLength is the length of every FIRE record, including its line break
+const fireRecordLength = 750
+
+// fireAmountCodes are the FIRE amount codes in the order of the payment amount fields
+const fireAmountCodes = "123456789ABCDEFGH"
+
+// fireReturnTypes are the type of return codes of the A record
+var fireReturnTypes = map[Form1099Type]string{
+	Form1099MISC: "A",
+	Form1099NEC:  "NE",
+}
+
+// fireRecord is one fixed-width record, addressed by the 1-based positions of Publication 1220
+type fireRecord []byte
+
+func newFireRecord(recordType string) fireRecord {
+	record := fireRecord(bytes.Repeat([]byte{' '}, fireRecordLength))
+	record.text(1, 1, recordType)
+	copy(record[fireRecordLength-2:], "\r\n")
+	return record
+}
+
+// text writes an upper-case value left-justified, truncated to the field
+func (r fireRecord) text(position, width int, value string) {
+	value = strings.ToUpper(value)
+	if len(value) > width {
+		value = value[:width]
+	}
+	copy(r[position-1:position-1+width], value)
+}
+
+// number writes a value right-justified and zero-filled
+func (r fireRecord) number(position, width int, value int64) {
+	r.text(position, width, fmt.Sprintf("%0*d", width, value))
+}
+
+// fireFile writes the transmitter record, then per form a payer record, its payee
+// records and their totals, then the end of transmission record
+func fireFile(summary *Form1099Summary, payer *Form1099Payer) ([]byte, error) {
+	if len(tinDigits(payer.TIN)) != 9 {
+		return nil, classify(ErrValidation, "the payer needs a nine-digit TIN")
+	}
+	if len(payer.TCC) != 5 {
+		return nil, classify(ErrValidation, "the payer needs a five-character transmitter control code")
+	}
+	if payer.Name == "" {
+		return nil, classify(ErrValidation, "the payer needs a name")
+	}
+
+	var forms []Form1099Type
+	var payees int
+	for _, line := range summary.Vendors {
+		if !line.FilingRequired {
+			continue
+		}
+		if len(tinDigits(line.TaxID)) != 9 {
+			return nil, classify(ErrValidation, "vendor %s has no valid TIN to file", line.VendorName)
+		}
+		if !slices.Contains(forms, line.Form) {
+			forms = append(forms, line.Form)
+		}
+		payees++
+	}
+	if payees == 0 {
+		return nil, classify(ErrValidation, "no 1099s to file for %d", summary.Year)
+	}
+
+	var buf bytes.Buffer
+	sequence := int64(0)
+	write := func(record fireRecord) {
+		sequence++
+		record.number(500, 8, sequence)
+		buf.Write(record)
+	}
+
+	transmitter := newFireRecord("T")
+	transmitter.number(2, 4, int64(summary.Year))
+	transmitter.text(7, 9, tinDigits(payer.TIN))
+	transmitter.text(16, 5, payer.TCC)
+	if payer.Test {
+		transmitter.text(28, 1, "T")
+	}
+	transmitter.text(30, 40, payer.Name)
+	transmitter.text(110, 40, payer.Name)
+	transmitter.text(190, 40, payer.Address)
+	transmitter.text(230, 40, payer.City)
+	transmitter.text(270, 2, payer.State)
+	transmitter.text(272, 9, tinDigits(payer.PostalCode))
+	transmitter.number(296, 8, int64(payees))
+	transmitter.text(304, 40, payer.ContactName)
+	transmitter.text(344, 15, tinDigits(payer.Phone))
+	transmitter.text(359, 50, payer.ContactEmail)
+	transmitter.text(518, 1, "I")
+	write(transmitter)
+
+	for _, form := range forms {
+		var lines []*Vendor1099Summary
+		codes := map[byte]bool{}
+		for _, line := range summary.Vendors {
+			if line.Form != form || !line.FilingRequired {
+				continue
+			}
+			lines = append(lines, line)
+			for box, amount := range line.Boxes {
+				if amount != 0 {
+					codes[fireAmountCode(form, box)] = true
+				}
+			}
+		}
+		var amountCodes []byte
+		for i := range len(fireAmountCodes) {
+			if codes[fireAmountCodes[i]] {
+				amountCodes = append(amountCodes, fireAmountCodes[i])
+			}
+		}
+
+		payerRecord := newFireRecord("A")
+		payerRecord.number(2, 4, int64(summary.Year))
+		payerRecord.text(12, 9, tinDigits(payer.TIN))
+		payerRecord.text(26, 2, fireReturnTypes[form])
+		payerRecord.text(28, 18, string(amountCodes))
+		payerRecord.text(53, 40, payer.Name)
+		payerRecord.text(133, 1, "0")
+		payerRecord.text(134, 40, payer.Address)
+		payerRecord.text(174, 40, payer.City)
+		payerRecord.text(214, 2, payer.State)
+		payerRecord.text(216, 9, tinDigits(payer.PostalCode))
+		payerRecord.text(225, 15, tinDigits(payer.Phone))
+		write(payerRecord)
+
+		totals := make([]int64, len(fireAmountCodes))
+		for _, line := range lines {
+			payee := newFireRecord("B")
+			payee.number(2, 4, int64(summary.Year))
+			payee.text(7, 4, fireNameControl(line))
+			if line.TINType == TINIndividual {
+				payee.text(11, 1, "2")
+			} else {
+				payee.text(11, 1, "1")
+			}
+			payee.text(12, 9, tinDigits(line.TaxID))
+			payee.text(21, 20, line.VendorID)
+			for box, amount := range line.Boxes {
+				index := strings.IndexByte(fireAmountCodes, fireAmountCode(form, box))
+				payee.number(55+index*12, 12, amount)
+				totals[index] += amount
+			}
+			payee.text(248, 40, line.PayeeName)
+			payee.text(328, 40, line.Address)
+			payee.text(408, 40, line.City)
+			payee.text(448, 2, line.State)
+			payee.text(450, 9, tinDigits(line.PostalCode))
+			write(payee)
+		}
+
+		end := newFireRecord("C")
+		end.number(2, 8, int64(len(lines)))
+		for index, total := range totals {
+			end.number(16+index*18, 18, total)
+		}
+		write(end)
+	}
+
+	transmission := newFireRecord("F")
+	transmission.number(2, 8, int64(len(forms)))
+	transmission.number(10, 21, 0)
+	transmission.number(50, 8, int64(payees))
+	write(transmission)
+
+	return buf.Bytes(), nil
+}
+
+// fireAmountCode returns the amount code a box is reported under
+func fireAmountCode(form Form1099Type, box string) byte {
+	if box == form1099WithheldBox {
+		return '4'
+	}
+	return form1099Boxes[form][box][0]
+}
+
+// fireNameControl is the first four letters or digits of a business payee's name.
+// Individuals' name controls come from the surname, which is left to the IRS to derive.
+func fireNameControl(line *Vendor1099Summary) string {
+	if line.TINType == TINIndividual {
+		return ""
+	}
+	var control []rune
+	for _, r := range strings.TrimPrefix(strings.ToUpper(line.PayeeName), "THE ") {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '&' || r == '-' {
+			control = append(control, r)
+		}
+		if len(control) == 4 {
+			break
+		}
+	}
+	return string(control)
+}
@@ -0,0 +1,256 @@
+package accounting
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForm1099(t *testing.T) {
+	// Setup
+	dbFile := "test_form_1099.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "ap_manager"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "withholding_payable", Code: "2460", Name: "Backup Withholding Payable", Type: Liability}, userID))
+
+	vendor := func(name, taxID string, profile *VendorTaxProfile) *Vendor {
+		v := &Vendor{Name: name, Currency: "USD", PaymentTermsDays: 30, DefaultExpenseAccountID: "expenses", TaxID: taxID}
+		require.NoError(t, engine.CreateVendor(v, userID))
+		if profile != nil {
+			_, err := engine.SetVendorTaxProfile(v.ID, profile, userID)
+			require.NoError(t, err)
+		}
+		return v
+	}
+	consultant := vendor("Dana Reyes", "123-45-6789", &VendorTaxProfile{
+		TINType: TINIndividual, Address: "12 Elm St", City: "Austin", State: "TX", PostalCode: "78701", Reportable: true,
+	})
+	designer := vendor("Orbit Design", "98-7654321", &VendorTaxProfile{
+		TINType: TINEmployer, LegalName: "Orbit Design LLC", City: "Denver", State: "CO", Reportable: true, WithholdingRate: 0.24,
+	})
+	landlord := vendor("Harbor Properties", "11-2223333", &VendorTaxProfile{
+		TINType: TINEmployer, City: "Boston", State: "MA", Reportable: true, Form: Form1099MISC, Box: "1",
+	})
+	handyman := vendor("Small Jobs", "222-33-4444", &VendorTaxProfile{TINType: TINIndividual, Reportable: true})
+	supplier := vendor("Office Depot", "", nil)
+
+	billDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	for _, bill := range []*VendorBill{
+		{VendorID: consultant.ID, BillNumber: "DR-1", Lines: []BillLine{{Description: "Consulting", Amount: 400000}}},
+		{VendorID: designer.ID, BillNumber: "OD-7", Lines: []BillLine{{Description: "Logo", Amount: 100000}}},
+		{VendorID: handyman.ID, BillNumber: "SJ-2", Lines: []BillLine{{Description: "Repairs", Amount: 50000}}},
+		{VendorID: supplier.ID, BillNumber: "OFF-9", Lines: []BillLine{{Description: "Paper", Amount: 30000}}},
+	} {
+		bill.BillDate = billDate
+		require.NoError(t, engine.EnterBill(bill, userID))
+		_, err := engine.SubmitBill(bill.ID, userID)
+		require.NoError(t, err)
+	}
+
+	balance := func(accountID string) int64 {
+		result, err := engine.GetAccountBalance(accountID, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+
+	t.Run("Payment Runs Withhold Tax", func(t *testing.T) {
+		payments, err := engine.GetStorage().GetAllVendor1099Payments()
+		require.NoError(t, err)
+		assert.Empty(t, payments, "approving a bill is not a payment")
+
+		run, err := engine.SchedulePaymentRun(time.Date(2025, 4, 5, 0, 0, 0, 0, time.UTC), time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), "cash", userID)
+		require.NoError(t, err)
+		_, err = engine.ExecutePaymentRun(run.ID, userID)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(24000), balance("withholding_payable"))
+		assert.Equal(t, int64(-580000+24000), balance("cash"))
+		assert.Zero(t, balance("accounts_payable"))
+
+		payments, err = engine.GetStorage().GetAllVendor1099Payments()
+		require.NoError(t, err)
+		assert.Len(t, payments, 3, "only reportable vendors are recorded")
+	})
+
+	t.Run("Direct Payments Count", func(t *testing.T) {
+		rent := &Transaction{
+			Description: "Office rent",
+			ValidTime:   time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 120000, Currency: "USD"}, Dimensions: []Dimension{{Key: DimCounterparty, Value: landlord.ID}}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: 120000, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(rent, userID))
+		require.NoError(t, engine.PostTransaction(rent.ID, userID))
+
+		// Posting again neither reaches the ledger nor pays the landlord twice
+		before, err := engine.GetAccountBalance("cash", rent.ValidTime)
+		require.NoError(t, err)
+		assert.ErrorIs(t, engine.PostTransaction(rent.ID, userID), ErrConflict)
+		posted, err := engine.GetStorage().GetTransaction(rent.ID)
+		require.NoError(t, err)
+		require.NoError(t, engine.form1099Service.RecordPayments(posted, userID))
+
+		payments, err := engine.GetStorage().GetAllVendor1099Payments()
+		require.NoError(t, err)
+		var recorded int
+		for _, payment := range payments {
+			if payment.TransactionID == rent.ID {
+				recorded++
+			}
+		}
+		assert.Equal(t, 1, recorded)
+		after, err := engine.GetAccountBalance("cash", rent.ValidTime)
+		require.NoError(t, err)
+		assert.Equal(t, before.Balance.Value, after.Balance.Value)
+	})
+
+	t.Run("Year End Summary", func(t *testing.T) {
+		summary, err := engine.Generate1099Summary(2025)
+		require.NoError(t, err)
+		require.Len(t, summary.Vendors, 4)
+		assert.Equal(t, map[Form1099Type]int{Form1099NEC: 2, Form1099MISC: 1}, summary.Filings)
+
+		byVendor := make(map[string]*Vendor1099Summary)
+		for _, line := range summary.Vendors {
+			byVendor[line.VendorID] = line
+		}
+		assert.Equal(t, Form1099MISC, summary.Vendors[0].Form)
+		assert.Equal(t, map[string]int64{"1": 120000}, byVendor[landlord.ID].Boxes)
+		assert.True(t, byVendor[landlord.ID].FilingRequired)
+
+		assert.Equal(t, map[string]int64{"1": 400000}, byVendor[consultant.ID].Boxes)
+		assert.True(t, byVendor[consultant.ID].FilingRequired)
+
+		orbit := byVendor[designer.ID]
+		assert.Equal(t, "Orbit Design LLC", orbit.PayeeName)
+		assert.Equal(t, map[string]int64{"1": 100000, "4": 24000}, orbit.Boxes)
+		assert.Equal(t, int64(100000), orbit.Total)
+		assert.True(t, orbit.FilingRequired)
+
+		assert.Equal(t, int64(50000), byVendor[handyman.ID].Total)
+		assert.False(t, byVendor[handyman.ID].FilingRequired, "below the $600 threshold")
+		assert.NotContains(t, byVendor, supplier.ID)
+	})
+
+	t.Run("Voided Payments", func(t *testing.T) {
+		// Reversals are dated when they are made, so both fall in the current year
+		extra := &Transaction{
+			Description: "Parking",
+			ValidTime:   time.Now(),
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 90000, Currency: "USD"}, Dimensions: []Dimension{{Key: DimCounterparty, Value: landlord.ID}}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: 90000, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(extra, userID))
+		require.NoError(t, engine.PostTransaction(extra.ID, userID))
+		_, err := engine.ReverseTransaction(extra.ID, "Paid in error", userID)
+		require.NoError(t, err)
+
+		summary, err := engine.Generate1099Summary(time.Now().Year())
+		require.NoError(t, err)
+		require.Len(t, summary.Vendors, 1)
+		assert.Zero(t, summary.Vendors[0].Total)
+		assert.Equal(t, 2, summary.Vendors[0].Payments)
+		assert.False(t, summary.Vendors[0].FilingRequired)
+	})
+
+	t.Run("CSV Export", func(t *testing.T) {
+		data, err := engine.Export1099s(2025, "CSV", nil)
+		require.NoError(t, err)
+		rows := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, rows, 6)
+		assert.Contains(t, rows, "2025,1099-NEC,"+designer.ID+",Orbit Design LLC,EIN,98-7654321,,Denver,CO,,4,240.00,true")
+	})
+
+	t.Run("FIRE Export", func(t *testing.T) {
+		payer := &Form1099Payer{
+			TIN: "55-1234567", TCC: "A1B2C", Name: "Acme Widgets Inc", Address: "1 Main St", City: "Springfield",
+			State: "IL", PostalCode: "62701", Phone: "217-555-0100", ContactName: "Pat Lee", ContactEmail: "ap@acme.test", Test: true,
+		}
+		data, err := engine.Export1099s(2025, "FIRE", payer)
+		require.NoError(t, err)
+		require.Zero(t, len(data)%750)
+
+		var records []string
+		for len(data) > 0 {
+			records = append(records, string(data[:750]))
+			data = data[750:]
+		}
+		var types []string
+		for i, record := range records {
+			types = append(types, record[:1])
+			assert.Equal(t, "0000000"+string(rune('1'+i)), record[499:507], "sequence number")
+			assert.True(t, strings.HasSuffix(record, "\r\n"))
+		}
+		assert.Equal(t, []string{"T", "A", "B", "C", "A", "B", "B", "C", "F"}, types)
+
+		transmitter := records[0]
+		assert.Equal(t, "2025", transmitter[1:5])
+		assert.Equal(t, "551234567A1B2C", transmitter[6:20])
+		assert.Equal(t, "T", transmitter[27:28])
+		assert.Equal(t, "00000003", transmitter[295:303])
+
+		nec := records[4]
+		assert.Equal(t, "NE", nec[25:27])
+		assert.Equal(t, "14", strings.TrimSpace(nec[27:45]))
+
+		var orbit string
+		for _, record := range records[5:7] {
+			if strings.Contains(record, "ORBIT DESIGN LLC") {
+				orbit = record
+			}
+		}
+		require.NotEmpty(t, orbit)
+		assert.Equal(t, "ORBI1987654321", orbit[6:20])
+		assert.Equal(t, "000000100000", orbit[54:66])
+		assert.Equal(t, "000000024000", orbit[90:102])
+
+		totals := records[7]
+		assert.Equal(t, "00000002", totals[1:9])
+		assert.Equal(t, "000000000000500000", totals[15:33])
+		assert.Equal(t, "000000000000024000", totals[69:87])
+
+		assert.True(t, strings.HasPrefix(records[8], "F00000002"))
+
+		_, err = engine.Export1099s(2025, "FIRE", &Form1099Payer{TIN: "55-1234567", Name: "Acme"})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		_, err := engine.SetVendorTaxProfile(supplier.ID, &VendorTaxProfile{TINType: TINEmployer, Reportable: true}, userID)
+		assert.ErrorIs(t, err, ErrValidation, "reportable vendors need a TIN")
+		_, err = engine.SetVendorTaxProfile(landlord.ID, &VendorTaxProfile{TINType: TINEmployer, Reportable: true, Form: Form1099MISC, Box: "7"}, userID)
+		assert.ErrorIs(t, err, ErrValidation)
+		_, err = engine.SetVendorTaxProfile(landlord.ID, &VendorTaxProfile{WithholdingRate: 1.5}, userID)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		stored, err := engine.GetStorage().GetVendor(landlord.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "1", stored.TaxProfile.Box)
+		assert.Equal(t, Form1099MISC, stored.TaxProfile.Form)
+	})
+
+	t.Run("Withholding Half Points Round Up", func(t *testing.T) {
+		contractor := vendor("Half Cent Co", "33-4445555", &VendorTaxProfile{
+			TINType: TINEmployer, City: "Reno", State: "NV", Reportable: true, WithholdingRate: 0.35,
+		})
+
+		// 35% of 0.10 is exactly 0.035, not the 0.0349... of 0.35's binary expansion
+		withheld, err := engine.GetPayablesService().withholdingOn(&VendorBill{VendorID: contractor.ID, Total: &Amount{Value: 10, Currency: "USD"}})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), withheld)
+	})
+}
@@ -0,0 +1,63 @@
+package accounting
+
+import "slices"
+
+// FourEyesPolicy chooses the sensitive operations that need a second person: the
+// user completing the operation must not be the one who started it
+type FourEyesPolicy struct {
+	Posting     bool `json:"posting,omitempty"`      // the poster or approver must not have created the transaction
+	Reversal    bool `json:"reversal,omitempty"`     // the reverser must not have created the original transaction
+	PeriodClose bool `json:"period_close,omitempty"` // the closer must not have completed any close checklist step
+}
+
+// transactionMaker is the user who entered a transaction, falling back to the
+// user recorded on transactions entered before makers were tracked
+func transactionMaker(txn *Transaction) string {
+	if txn.CreatedBy != "" {
+		return txn.CreatedBy
+	}
+	return txn.UserID
+}
+
+// fourEyesPolicy returns the company's four-eyes policy, which is empty when none
+// is configured
+func (pe *PostingEngine) fourEyesPolicy() FourEyesPolicy {
+	pe.mutex.RLock()
+	defer pe.mutex.RUnlock()
+	return pe.fourEyes
+}
+
+// checkPostingFourEyes stops the maker of a transaction from also posting or
+// approving it
+func (pe *PostingEngine) checkPostingFourEyes(txn *Transaction, userID string) error {
+	if !pe.fourEyesPolicy().Posting {
+		return nil
+	}
+	if maker := transactionMaker(txn); maker != "" && maker == userID {
+		return classify(ErrPermissionDenied, "four-eyes: %s created transaction %s and cannot also post it", userID, txn.ID)
+	}
+	return nil
+}
+
+// checkReversalFourEyes stops the maker of a transaction from also reversing it
+func (pe *PostingEngine) checkReversalFourEyes(txn *Transaction, userID string) error {
+	if !pe.fourEyesPolicy().Reversal {
+		return nil
+	}
+	if maker := transactionMaker(txn); maker != "" && maker == userID {
+		return classify(ErrPermissionDenied, "four-eyes: %s created transaction %s and cannot also reverse it", userID, txn.ID)
+	}
+	return nil
+}
+
+// checkCloseFourEyes stops a user who prepared a period's close checklist from
+// also closing the period
+func (pe *PostingEngine) checkCloseFourEyes(checklist *PeriodCloseChecklist, userID string) error {
+	if !pe.fourEyesPolicy().PeriodClose {
+		return nil
+	}
+	if slices.ContainsFunc(checklist.Items, func(item CloseChecklistItem) bool { return item.CompletedBy == userID }) {
+		return classify(ErrPermissionDenied, "four-eyes: %s completed close steps for period %s and cannot also close it", userID, checklist.ID)
+	}
+	return nil
+}
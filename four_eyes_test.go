@@ -0,0 +1,109 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFourEyesPrinciple(t *testing.T) {
+	dbFile := "test_four_eyes.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	maker, checker, controller := "maker", "checker", "controller"
+	require.NoError(t, engine.CreateStandardAccounts(maker))
+
+	journal := func(value int64) *Transaction {
+		txn := &Transaction{
+			Description: "Manual journal",
+			ValidTime:   time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, maker))
+		return txn
+	}
+
+	selfPosted := journal(100)
+	require.NoError(t, engine.PostTransaction(selfPosted.ID, maker), "one person may do both without a policy")
+
+	compliance := engine.GetComplianceService()
+	require.NoError(t, compliance.SetupStandardComplianceRules(SOX_Framework))
+
+	policy := &FourEyesPolicy{Posting: true, Reversal: true, PeriodClose: true}
+	require.NoError(t, engine.ApplyCompanySettings(&CompanySettings{FourEyes: policy}))
+	defer engine.ApplyCompanySettings(nil)
+
+	t.Run("posting", func(t *testing.T) {
+		txn := journal(200)
+		assert.ErrorContains(t, engine.PostTransaction(txn.ID, maker), "cannot also post it")
+		require.NoError(t, engine.PostTransaction(txn.ID, checker))
+
+		stored, err := engine.GetStorage().GetTransaction(txn.ID)
+		require.NoError(t, err)
+		assert.Equal(t, maker, stored.CreatedBy)
+		assert.Equal(t, checker, stored.PostedBy)
+
+		violations, err := compliance.ValidateTransaction(*stored)
+		require.NoError(t, err)
+		assert.Empty(t, violations)
+	})
+
+	t.Run("approval", func(t *testing.T) {
+		require.NoError(t, engine.ApplyCompanySettings(&CompanySettings{
+			FourEyes:            policy,
+			RequireApprovalOver: &Amount{Value: 1000, Currency: "USD"},
+		}))
+		txn := journal(5000)
+		require.NoError(t, engine.PostTransaction(txn.ID, checker))
+		_, err := engine.ApproveTransaction(txn.ID, "", maker)
+		assert.ErrorContains(t, err, "cannot also post it")
+		_, err = engine.ApproveTransaction(txn.ID, "Looks right", controller)
+		require.NoError(t, err)
+
+		stored, err := engine.GetStorage().GetTransaction(txn.ID)
+		require.NoError(t, err)
+		assert.Equal(t, controller, stored.ApprovedBy)
+		assert.Equal(t, controller, stored.PostedBy)
+		require.NoError(t, engine.ApplyCompanySettings(&CompanySettings{FourEyes: policy}))
+	})
+
+	t.Run("reversal", func(t *testing.T) {
+		_, err := engine.ReverseTransaction(selfPosted.ID, "Wrong account", maker)
+		assert.ErrorContains(t, err, "cannot also reverse it")
+		reversal, err := engine.ReverseTransaction(selfPosted.ID, "Wrong account", checker)
+		require.NoError(t, err)
+		assert.Equal(t, checker, reversal.CreatedBy)
+	})
+
+	t.Run("period close", func(t *testing.T) {
+		period := &Period{Name: "June 2025", Start: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2025, 6, 30, 23, 59, 59, 0, time.UTC)}
+		require.NoError(t, engine.CreatePeriod(period, controller))
+		_, err := engine.UpdateCloseChecklistItem(period.ID, CloseStepAccruals, ChecklistComplete, "", maker)
+		require.NoError(t, err)
+
+		assert.ErrorContains(t, engine.ClosePeriod(period.ID, true, maker), "cannot also close it")
+		require.NoError(t, engine.ClosePeriod(period.ID, true, controller))
+	})
+
+	t.Run("segregation of duties rule", func(t *testing.T) {
+		stored, err := engine.GetStorage().GetTransaction(selfPosted.ID)
+		require.NoError(t, err)
+		violations, err := compliance.ValidateTransaction(*stored)
+		require.NoError(t, err)
+		require.Len(t, violations, 1)
+		assert.Contains(t, violations[0].Description, "created and posted by the same user (maker)")
+
+		restored := CompanyFromProto((&Company{ID: "acme", Settings: &CompanySettings{FourEyes: policy}}).ToProto())
+		assert.Equal(t, policy, restored.Settings.FourEyes)
+	})
+}
@@ -0,0 +1,313 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Framework Adjustment Layers
+// ----------------------------------------------------------------------------
+
+// FrameworkAdjustment is a balanced set of entries that applies under one reporting
+// framework only, such as capitalising a lease under IFRS 16 that US GAAP keeps as
+// an operating lease. Adjustments never post to the ledger: statements generated
+// for their framework add them on top of the shared ledger balances, so one ledger
+// reports under both frameworks.
+type FrameworkAdjustment struct {
+	ID          string            `json:"id"`
+	Framework   ReportingStandard `json:"framework"`
+	Layer       string            `json:"layer,omitempty"` // groups related adjustments, e.g. "IFRS 16 leases"
+	Description string            `json:"description"`
+	ValidTime   time.Time         `json:"valid_time"`
+	Entries     []Entry           `json:"entries"`
+	Reference   string            `json:"reference,omitempty"`
+	CreatedBy   string            `json:"created_by"`
+	CreatedAt   time.Time         `json:"created_at"`
+
+	// A reversed adjustment drops out of every statement
+	ReversedBy     string     `json:"reversed_by,omitempty"`
+	ReversedAt     *time.Time `json:"reversed_at,omitempty"`
+	ReversalReason string     `json:"reversal_reason,omitempty"`
+}
+
+// validReportingStandard reports whether adjustments can be booked for a framework
+func validReportingStandard(framework ReportingStandard) bool {
+	return framework == GAAP || framework == IFRS
+}
+
+// FrameworkAdjustmentService books and reverses framework-specific adjustments
+type FrameworkAdjustmentService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+}
+
+// NewFrameworkAdjustmentService creates a new framework adjustment service
+func NewFrameworkAdjustmentService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *FrameworkAdjustmentService {
+	return &FrameworkAdjustmentService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+	}
+}
+
+// BookAdjustment records an adjustment for GAAP or IFRS reporting. Its entries must
+// balance, be in the currencies their accounts are kept in and fall in an open period.
+func (fs *FrameworkAdjustmentService) BookAdjustment(adjustment *FrameworkAdjustment, userID string) error {
+	if !validReportingStandard(adjustment.Framework) {
+		return classify(ErrValidation, "unsupported reporting framework: %q", adjustment.Framework)
+	}
+	if adjustment.Description == "" {
+		return classify(ErrValidation, "adjustment description is required")
+	}
+	if adjustment.ValidTime.IsZero() {
+		return classify(ErrValidation, "adjustment date is required")
+	}
+	if len(adjustment.Entries) < 2 {
+		return classify(ErrValidation, "an adjustment needs at least two entries")
+	}
+	for i := range adjustment.Entries {
+		entry := &adjustment.Entries[i]
+		if entry.Type != Debit && entry.Type != Credit {
+			return classify(ErrValidation, "entry %d has unsupported type %q", i+1, entry.Type)
+		}
+		if entry.Amount.Value <= 0 {
+			return classify(ErrValidation, "entry %d must have a positive amount", i+1)
+		}
+		account, err := fs.storage.GetAccount(entry.AccountID)
+		if err != nil {
+			return fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		if entry.Amount.Currency == "" {
+			entry.Amount.Currency = account.Currency
+		}
+		if account.Currency != "" && entry.Amount.Currency != account.Currency {
+			return classify(ErrValidation, "entry %d is in %s but account %s is kept in %s", i+1, entry.Amount.Currency, account.ID, account.Currency)
+		}
+	}
+	if errs := balanceErrors(&Transaction{Entries: adjustment.Entries}); len(errs) > 0 {
+		return classify(ErrValidation, "adjustment does not balance: %v", errs[0])
+	}
+	if err := fs.postingEngine.validatePeriod(adjustment.ValidTime, userID); err != nil {
+		return err
+	}
+	if err := fs.storage.assignID(&adjustment.ID, "framework adjustment", BucketFrameworkAdjustments); err != nil {
+		return classify(ErrValidation, "%v", err)
+	}
+
+	for i := range adjustment.Entries {
+		adjustment.Entries[i].ID = newID()
+		adjustment.Entries[i].TransactionID = adjustment.ID
+	}
+	adjustment.CreatedBy = userID
+	adjustment.CreatedAt = time.Now()
+	adjustment.ReversedBy, adjustment.ReversedAt, adjustment.ReversalReason = "", nil, ""
+	return fs.save(adjustment, EventBookFrameworkAdjustment, userID)
+}
+
+// ReverseAdjustment withdraws an adjustment from its framework's statements. The
+// adjustment is kept for the audit trail.
+func (fs *FrameworkAdjustmentService) ReverseAdjustment(adjustmentID, reason, userID string) (*FrameworkAdjustment, error) {
+	if reason == "" {
+		return nil, classify(ErrValidation, "a reason is required to reverse an adjustment")
+	}
+	adjustment, err := fs.storage.GetFrameworkAdjustment(adjustmentID)
+	if err != nil {
+		return nil, err
+	}
+	if adjustment.ReversedAt != nil {
+		return nil, classify(ErrConflict, "adjustment %s was already reversed", adjustmentID)
+	}
+	if err := fs.postingEngine.validatePeriod(adjustment.ValidTime, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	adjustment.ReversedBy = userID
+	adjustment.ReversedAt = &now
+	adjustment.ReversalReason = reason
+	if err := fs.save(adjustment, EventReverseFrameworkAdjustment, userID); err != nil {
+		return nil, err
+	}
+	return adjustment, nil
+}
+
+// GetAdjustments returns a framework's adjustments, or every framework's when it is
+// empty, in date order
+func (fs *FrameworkAdjustmentService) GetAdjustments(framework ReportingStandard) ([]*FrameworkAdjustment, error) {
+	all, err := fs.storage.GetAllFrameworkAdjustments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get framework adjustments: %w", err)
+	}
+	var adjustments []*FrameworkAdjustment
+	for _, adjustment := range all {
+		if framework == "" || adjustment.Framework == framework {
+			adjustments = append(adjustments, adjustment)
+		}
+	}
+	sortFrameworkAdjustments(adjustments)
+	return adjustments, nil
+}
+
+// save records and stores an adjustment
+func (fs *FrameworkAdjustmentService) save(adjustment *FrameworkAdjustment, eventType, userID string) error {
+	if _, err := fs.eventStore.CreateEvent(eventType, adjustment, time.Now(), userID); err != nil {
+		return fmt.Errorf("failed to create framework adjustment event: %w", err)
+	}
+	return fs.storage.SaveFrameworkAdjustment(adjustment)
+}
+
+// sortFrameworkAdjustments orders adjustments by date, then by when they were booked
+func sortFrameworkAdjustments(adjustments []*FrameworkAdjustment) {
+	sort.Slice(adjustments, func(i, j int) bool {
+		if !adjustments[i].ValidTime.Equal(adjustments[j].ValidTime) {
+			return adjustments[i].ValidTime.Before(adjustments[j].ValidTime)
+		}
+		return adjustments[i].CreatedAt.Before(adjustments[j].CreatedAt)
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Framework Reporting
+// ----------------------------------------------------------------------------
+
+// frameworkLayer is what a framework's adjustments add to each account's balance
+type frameworkLayer struct {
+	movements map[string]int64 // by account ID, signed by the account's normal balance
+	layers    []string         // layers the adjustments came from, in first-use order
+}
+
+// add folds the layer's movement for an account into a balance, returning a new amount
+func (l *frameworkLayer) add(accountID string, balance *Amount) *Amount {
+	if l == nil {
+		return balance
+	}
+	return &Amount{Value: balance.Value + l.movements[accountID], Currency: balance.Currency}
+}
+
+// loadFrameworkLayer totals the unreversed adjustments of framework dated from
+// fromDate to toDate inclusive; a zero fromDate has no lower bound. An empty
+// framework reports the ledger alone and loads nothing.
+func (rs *ReportingService) loadFrameworkLayer(framework ReportingStandard, fromDate, toDate time.Time) (*frameworkLayer, error) {
+	if framework == "" {
+		return nil, nil
+	}
+	if !validReportingStandard(framework) {
+		return nil, classify(ErrValidation, "unsupported reporting framework: %q", framework)
+	}
+	adjustments, err := rs.storage.GetAllFrameworkAdjustments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get framework adjustments: %w", err)
+	}
+	sortFrameworkAdjustments(adjustments)
+
+	layer := &frameworkLayer{movements: make(map[string]int64)}
+	accountTypes := make(map[string]AccountType)
+	seen := make(map[string]bool)
+	for _, adjustment := range adjustments {
+		if adjustment.Framework != framework || adjustment.ReversedAt != nil ||
+			adjustment.ValidTime.Before(fromDate) || adjustment.ValidTime.After(toDate) {
+			continue
+		}
+		if name := adjustment.Layer; name != "" && !seen[name] {
+			seen[name] = true
+			layer.layers = append(layer.layers, name)
+		}
+		for _, entry := range adjustment.Entries {
+			accountType, ok := accountTypes[entry.AccountID]
+			if !ok {
+				account, err := rs.storage.GetAccount(entry.AccountID)
+				if err != nil {
+					return nil, fmt.Errorf("adjustment %s: %w", adjustment.ID, err)
+				}
+				accountType = account.Type
+				accountTypes[entry.AccountID] = accountType
+			}
+			layer.movements[entry.AccountID] += entry.Amount.Value * int64(rs.queryAPI.postingEngine.getBalanceMultiplier(accountType, entry.Type))
+		}
+	}
+	return layer, nil
+}
+
+// GenerateFrameworkTrialBalance generates a trial balance that includes the
+// framework's adjustments dated up to the as-of date
+func (rs *ReportingService) GenerateFrameworkTrialBalance(asOfDate time.Time, currency string, framework ReportingStandard) (_ []*BalanceResult, err error) {
+	defer rs.metrics.timeReport("trial_balance")(&err)
+	defer rs.tracer.report("trial_balance")(&err)
+
+	trialBalance, _, err := rs.frameworkTrialBalance(asOfDate, currency, framework)
+	return trialBalance, err
+}
+
+// GenerateFrameworkBalanceSheet generates a balance sheet under GAAP or IFRS: the
+// ledger balances plus that framework's adjustment layers. An empty framework
+// gives the ledger's own balance sheet.
+func (rs *ReportingService) GenerateFrameworkBalanceSheet(asOfDate time.Time, currency string, framework ReportingStandard) (_ *FinancialStatement, err error) {
+	defer rs.metrics.timeReport("balance_sheet")(&err)
+	defer rs.tracer.report("balance_sheet")(&err)
+
+	trialBalance, layer, err := rs.frameworkTrialBalance(asOfDate, currency, framework)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := rs.balanceSheet(trialBalance, asOfDate, currency)
+	if err != nil {
+		return nil, err
+	}
+	labelFrameworkStatement(bs, framework, layer)
+	return bs, nil
+}
+
+// GenerateFrameworkProfitAndLoss generates a P&L under GAAP or IFRS, including the
+// framework's adjustments dated within the period
+func (rs *ReportingService) GenerateFrameworkProfitAndLoss(fromDate, toDate time.Time, currency string, framework ReportingStandard) (_ *FinancialStatement, err error) {
+	defer rs.metrics.timeReport("profit_and_loss")(&err)
+	defer rs.tracer.report("profit_and_loss")(&err)
+
+	layer, err := rs.loadFrameworkLayer(framework, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	pl, err := rs.profitAndLoss(fromDate, toDate, currency, layer)
+	if err != nil {
+		return nil, err
+	}
+	labelFrameworkStatement(pl, framework, layer)
+	return pl, nil
+}
+
+// frameworkTrialBalance adds a framework's adjustments to the ledger's trial balance
+// before translating it into the reporting currency
+func (rs *ReportingService) frameworkTrialBalance(asOfDate time.Time, currency string, framework ReportingStandard) ([]*BalanceResult, *frameworkLayer, error) {
+	layer, err := rs.loadFrameworkLayer(framework, time.Time{}, asOfDate)
+	if err != nil {
+		return nil, nil, err
+	}
+	trialBalance, err := rs.queryAPI.GetTrialBalance(asOfDate, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get trial balance: %w", err)
+	}
+	for i, balance := range trialBalance {
+		adjusted := *balance
+		adjusted.Balance = layer.add(balance.AccountID, balance.Balance)
+		trialBalance[i] = &adjusted
+	}
+	translated, err := rs.translateTrialBalance(trialBalance, asOfDate, currency)
+	if err != nil {
+		return nil, nil, err
+	}
+	return translated, layer, nil
+}
+
+// labelFrameworkStatement names the framework a statement was prepared under and the
+// adjustment layers it includes
+func labelFrameworkStatement(statement *FinancialStatement, framework ReportingStandard, layer *frameworkLayer) {
+	if framework == "" {
+		return
+	}
+	statement.Name = fmt.Sprintf("%s (%s)", statement.Name, framework)
+	statement.Framework = framework
+	statement.AdjustmentLayers = layer.layers
+}
@@ -0,0 +1,197 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrameworkAdjustments(t *testing.T) {
+	// Setup
+	dbFile := "test_framework_adjustments.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "group_reporting"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	for _, account := range []*Account{
+		{ID: "right_of_use_asset", Code: "1600", Name: "Right-of-Use Asset", Type: Asset, Currency: "USD"},
+		{ID: "lease_liability", Code: "2600", Name: "Lease Liability", Type: Liability, Currency: "USD"},
+		{ID: "depreciation", Code: "6600", Name: "Depreciation", Type: Expense, Currency: "USD"},
+	} {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	date := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC) }
+	usd := func(value int64) Amount { return Amount{Value: value, Currency: "USD"} }
+	post := func(debit, credit string, value int64, on time.Time) {
+		txn := &Transaction{
+			Description: "January activity",
+			ValidTime:   on,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: usd(value)},
+				{AccountID: credit, Type: Credit, Amount: usd(value)},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	post("cash", "revenue", 500000, date(1, 3))
+	post("expenses", "cash", 120000, date(1, 5)) // office rent
+
+	capitalise := &FrameworkAdjustment{
+		Framework: IFRS, Layer: "IFRS 16 leases", Description: "Recognise the office lease", ValidTime: date(1, 1),
+		Entries: []Entry{
+			{AccountID: "right_of_use_asset", Type: Debit, Amount: usd(1000000)},
+			{AccountID: "lease_liability", Type: Credit, Amount: usd(1000000)},
+		},
+	}
+	depreciate := &FrameworkAdjustment{
+		Framework: IFRS, Layer: "IFRS 16 leases", Description: "January depreciation, rent reclassified to the liability", ValidTime: date(1, 31),
+		Entries: []Entry{
+			{AccountID: "depreciation", Type: Debit, Amount: usd(80000)},
+			{AccountID: "lease_liability", Type: Debit, Amount: usd(100000)},
+			{AccountID: "right_of_use_asset", Type: Credit, Amount: usd(80000)},
+			{AccountID: "expenses", Type: Credit, Amount: usd(100000)},
+		},
+	}
+	inventory := &FrameworkAdjustment{
+		Framework: GAAP, Layer: "LIFO inventory", Description: "LIFO reserve", ValidTime: date(1, 15),
+		Entries: []Entry{
+			{AccountID: "expenses", Type: Debit, Amount: usd(30000)},
+			{AccountID: "accounts_payable", Type: Credit, Amount: usd(30000)},
+		},
+	}
+
+	t.Run("Booking Adjustments", func(t *testing.T) {
+		for _, adjustment := range []*FrameworkAdjustment{depreciate, inventory, capitalise} {
+			require.NoError(t, engine.BookFrameworkAdjustment(adjustment, userID))
+		}
+		assert.NotEmpty(t, capitalise.ID)
+		assert.Equal(t, capitalise.ID, capitalise.Entries[0].TransactionID)
+		assert.Equal(t, userID, capitalise.CreatedBy)
+
+		adjustments, err := engine.GetFrameworkAdjustments(IFRS)
+		require.NoError(t, err)
+		require.Len(t, adjustments, 2)
+		assert.Equal(t, capitalise.ID, adjustments[0].ID)
+		assert.Len(t, adjustments[1].Entries, 4)
+
+		all, err := engine.GetFrameworkAdjustments("")
+		require.NoError(t, err)
+		assert.Len(t, all, 3)
+
+		balance, err := engine.GetAccountBalance("right_of_use_asset", date(12, 31))
+		require.NoError(t, err)
+		assert.Zero(t, balance.Balance.Value, "adjustments stay out of the ledger")
+	})
+
+	t.Run("Dual Profit And Loss", func(t *testing.T) {
+		ledger, err := engine.GenerateProfitAndLoss(date(1, 1), date(1, 31), "USD")
+		require.NoError(t, err)
+		assert.Equal(t, int64(380000), ledger.NetIncome.Value)
+		assert.Empty(t, ledger.Framework)
+
+		ifrs, err := engine.GenerateFrameworkProfitAndLoss(date(1, 1), date(1, 31), "USD", IFRS)
+		require.NoError(t, err)
+		assert.Equal(t, "Profit & Loss Statement (IFRS)", ifrs.Name)
+		assert.Equal(t, IFRS, ifrs.Framework)
+		assert.Equal(t, []string{"IFRS 16 leases"}, ifrs.AdjustmentLayers)
+		assert.Equal(t, int64(400000), ifrs.NetIncome.Value)
+		assert.Equal(t, int64(100000), ifrs.LineItems[1].Amount.Value, "rent replaced by depreciation")
+
+		gaap, err := engine.GenerateFrameworkProfitAndLoss(date(1, 1), date(1, 31), "USD", GAAP)
+		require.NoError(t, err)
+		assert.Equal(t, int64(350000), gaap.NetIncome.Value)
+		assert.Equal(t, []string{"LIFO inventory"}, gaap.AdjustmentLayers)
+
+		february, err := engine.GenerateFrameworkProfitAndLoss(date(2, 1), date(2, 28), "USD", IFRS)
+		require.NoError(t, err)
+		assert.Zero(t, february.NetIncome.Value)
+		assert.Empty(t, february.AdjustmentLayers)
+	})
+
+	t.Run("Dual Balance Sheet", func(t *testing.T) {
+		ledger, err := engine.GenerateBalanceSheet(date(1, 31), "USD")
+		require.NoError(t, err)
+		assert.Equal(t, int64(380000), ledger.TotalAssets.Value)
+		assert.Zero(t, ledger.TotalLiabs.Value)
+
+		ifrs, err := engine.GenerateFrameworkBalanceSheet(date(1, 31), "USD", IFRS)
+		require.NoError(t, err)
+		assert.Equal(t, "Balance Sheet (IFRS)", ifrs.Name)
+		assert.Equal(t, int64(1300000), ifrs.TotalAssets.Value)
+		assert.Equal(t, int64(900000), ifrs.TotalLiabs.Value)
+
+		gaap, err := engine.GenerateFrameworkBalanceSheet(date(1, 31), "USD", GAAP)
+		require.NoError(t, err)
+		assert.Equal(t, int64(380000), gaap.TotalAssets.Value)
+		assert.Equal(t, int64(30000), gaap.TotalLiabs.Value)
+
+		before, err := engine.GenerateFrameworkBalanceSheet(date(1, 20), "USD", IFRS)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000000), before.TotalLiabs.Value, "later adjustments are excluded")
+
+		trialBalance, err := engine.GenerateFrameworkTrialBalance(date(1, 31), "USD", IFRS)
+		require.NoError(t, err)
+		balances := make(map[string]int64)
+		for _, balance := range trialBalance {
+			balances[balance.AccountID] = balance.Balance.Value
+		}
+		assert.Equal(t, int64(920000), balances["right_of_use_asset"])
+		assert.Equal(t, int64(20000), balances["expenses"])
+	})
+
+	t.Run("Reversing Adjustments", func(t *testing.T) {
+		_, err := engine.ReverseFrameworkAdjustment(inventory.ID, "", userID)
+		assert.ErrorIs(t, err, ErrValidation, "a reason is required")
+
+		reversed, err := engine.ReverseFrameworkAdjustment(inventory.ID, "Reserve booked twice", userID)
+		require.NoError(t, err)
+		require.NotNil(t, reversed.ReversedAt)
+		assert.Equal(t, userID, reversed.ReversedBy)
+
+		gaap, err := engine.GenerateFrameworkProfitAndLoss(date(1, 1), date(1, 31), "USD", GAAP)
+		require.NoError(t, err)
+		assert.Equal(t, int64(380000), gaap.NetIncome.Value)
+		assert.Empty(t, gaap.AdjustmentLayers)
+
+		_, err = engine.ReverseFrameworkAdjustment(inventory.ID, "Again", userID)
+		assert.ErrorIs(t, err, ErrConflict)
+
+		stored, err := engine.GetStorage().GetFrameworkAdjustment(inventory.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Reserve booked twice", stored.ReversalReason)
+		assert.Equal(t, GAAP, stored.Framework)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		adjustment := func(framework ReportingStandard, entries ...Entry) *FrameworkAdjustment {
+			return &FrameworkAdjustment{Framework: framework, Description: "Test", ValidTime: date(1, 31), Entries: entries}
+		}
+		debit := Entry{AccountID: "expenses", Type: Debit, Amount: usd(1000)}
+		credit := Entry{AccountID: "cash", Type: Credit, Amount: usd(1000)}
+
+		err := engine.BookFrameworkAdjustment(adjustment("LOCAL", debit, credit), userID)
+		assert.ErrorIs(t, err, ErrValidation)
+		err = engine.BookFrameworkAdjustment(adjustment(IFRS, debit, Entry{AccountID: "cash", Type: Credit, Amount: usd(900)}), userID)
+		assert.ErrorIs(t, err, ErrValidation, "entries must balance")
+		err = engine.BookFrameworkAdjustment(adjustment(IFRS, debit, Entry{AccountID: "cash", Type: Credit, Amount: Amount{Value: 1000, Currency: "EUR"}}), userID)
+		assert.ErrorIs(t, err, ErrValidation, "entries are in the account's currency")
+		err = engine.BookFrameworkAdjustment(adjustment(IFRS, debit, Entry{AccountID: "missing", Type: Credit, Amount: usd(1000)}), userID)
+		assert.ErrorIs(t, err, ErrNotFound)
+		err = engine.BookFrameworkAdjustment(adjustment(IFRS, debit), userID)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		_, err = engine.GenerateFrameworkBalanceSheet(date(1, 31), "USD", "LOCAL")
+		assert.ErrorIs(t, err, ErrValidation)
+		_, err = engine.ReverseFrameworkAdjustment("missing", "Test", userID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,332 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// FX Revaluation Structures
+// ----------------------------------------------------------------------------
+
+// RevaluationConfig identifies the functional currency and the accounts that receive
+// unrealized exchange differences
+type RevaluationConfig struct {
+	BaseCurrency  Currency `json:"base_currency"`
+	GainAccountID string   `json:"gain_account_id"`
+	LossAccountID string   `json:"loss_account_id"`
+}
+
+// RevaluationLine is the revaluation calculation for a single foreign-currency account
+type RevaluationLine struct {
+	AccountID       string    `json:"account_id"`
+	AccountName     string    `json:"account_name"`
+	Currency        Currency  `json:"currency"`
+	ForeignBalance  int64     `json:"foreign_balance"`  // in the account currency
+	ClosingRate     float64   `json:"closing_rate"`     // base units per foreign unit
+	CarryingAmount  int64     `json:"carrying_amount"`  // in base currency before revaluation
+	RevaluedAmount  int64     `json:"revalued_amount"`  // in base currency at the closing rate
+	UnrealizedGain  int64     `json:"unrealized_gain"`  // negative for a loss
+	AdjustmentEntry EntryType `json:"adjustment_entry"` // side posted to the account
+}
+
+// RevaluationResult summarizes a period-end revaluation run
+type RevaluationResult struct {
+	PeriodID      string             `json:"period_id"`
+	BaseCurrency  Currency           `json:"base_currency"`
+	RateDate      time.Time          `json:"rate_date"`
+	Lines         []*RevaluationLine `json:"lines"`
+	TotalGain     int64              `json:"total_gain"` // net, negative for a loss
+	TransactionID string             `json:"transaction_id,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// FX Revaluation Service
+// ----------------------------------------------------------------------------
+
+// RevaluationService remeasures foreign-currency monetary balances at period end
+type RevaluationService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+	exchangeRates *ExchangeRateService
+	config        *RevaluationConfig
+	mutex         sync.RWMutex
+}
+
+// NewRevaluationService creates a new FX revaluation service
+func NewRevaluationService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine, exchangeRates *ExchangeRateService) *RevaluationService {
+	return &RevaluationService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+		exchangeRates: exchangeRates,
+	}
+}
+
+// Configure enables revaluation at period close
+func (rs *RevaluationService) Configure(config RevaluationConfig) error {
+	if config.BaseCurrency == "" {
+		return fmt.Errorf("base currency is required")
+	}
+	for _, accountID := range []string{config.GainAccountID, config.LossAccountID} {
+		if _, err := rs.storage.GetAccount(accountID); err != nil {
+			return fmt.Errorf("invalid revaluation account %s: %w", accountID, err)
+		}
+	}
+
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+	rs.config = &config
+	return nil
+}
+
+// IsConfigured reports whether revaluation should run at period close
+func (rs *RevaluationService) IsConfigured() bool {
+	rs.mutex.RLock()
+	defer rs.mutex.RUnlock()
+	return rs.config != nil
+}
+
+// CalculateRevaluation computes unrealized gains and losses for a period without posting
+func (rs *RevaluationService) CalculateRevaluation(periodID string) (*RevaluationResult, error) {
+	rs.mutex.RLock()
+	config := rs.config
+	rs.mutex.RUnlock()
+	if config == nil {
+		return nil, fmt.Errorf("FX revaluation is not configured")
+	}
+
+	period, err := rs.storage.GetPeriod(periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get period: %w", err)
+	}
+
+	accounts, err := rs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Code < accounts[j].Code
+	})
+
+	result := &RevaluationResult{
+		PeriodID:     period.ID,
+		BaseCurrency: config.BaseCurrency,
+		RateDate:     period.End,
+	}
+
+	for _, account := range accounts {
+		// Only monetary balances held in a foreign currency are remeasured
+		if account.Currency == "" || account.Currency == config.BaseCurrency {
+			continue
+		}
+		if account.Type != Asset && account.Type != Liability {
+			continue
+		}
+
+		line, err := rs.revalueAccount(account, config.BaseCurrency, period.End)
+		if err != nil {
+			return nil, fmt.Errorf("failed to revalue account %s: %w", account.ID, err)
+		}
+		if line == nil {
+			continue
+		}
+
+		result.Lines = append(result.Lines, line)
+		result.TotalGain += line.UnrealizedGain
+	}
+
+	return result, nil
+}
+
+// RevaluePeriod calculates and posts the revaluation transaction for a period
+func (rs *RevaluationService) RevaluePeriod(periodID, userID string) (*RevaluationResult, error) {
+	result, err := rs.CalculateRevaluation(periodID)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Lines) == 0 {
+		return result, nil
+	}
+
+	rs.mutex.RLock()
+	config := rs.config
+	rs.mutex.RUnlock()
+
+	txn := &Transaction{
+		ID:              newID(),
+		Description:     fmt.Sprintf("FX revaluation to %s at %s", config.BaseCurrency, result.RateDate.Format("2006-01-02")),
+		ValidTime:       result.RateDate,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       fmt.Sprintf("FX_REVALUATION_%s", periodID),
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	rateDate := result.RateDate
+	for _, line := range result.Lines {
+		adjustment := abs64(line.UnrealizedGain)
+
+		// The account entry carries no foreign-currency value; it only moves the base amount
+		txn.Entries = append(txn.Entries, Entry{
+			ID:            newID(),
+			TransactionID: txn.ID,
+			AccountID:     line.AccountID,
+			Type:          line.AdjustmentEntry,
+			Amount: Amount{
+				Value:            0,
+				Currency:         line.Currency,
+				BaseValue:        adjustment,
+				BaseCurrency:     config.BaseCurrency,
+				ExchangeRate:     line.ClosingRate,
+				ExchangeRateDate: &rateDate,
+			},
+		})
+
+		contraAccount, contraType := config.GainAccountID, Credit
+		if line.AdjustmentEntry == Credit {
+			contraType = Debit
+		}
+		if line.UnrealizedGain < 0 {
+			contraAccount = config.LossAccountID
+		}
+
+		txn.Entries = append(txn.Entries, Entry{
+			ID:            newID(),
+			TransactionID: txn.ID,
+			AccountID:     contraAccount,
+			Type:          contraType,
+			Amount: Amount{
+				Value:        adjustment,
+				Currency:     config.BaseCurrency,
+				BaseValue:    adjustment,
+				BaseCurrency: config.BaseCurrency,
+			},
+		})
+	}
+
+	_, err = rs.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := rs.storage.SaveTransaction(txn); err != nil {
+		return nil, fmt.Errorf("failed to save revaluation transaction: %w", err)
+	}
+
+	if err := rs.postingEngine.PostTransaction(txn, userID); err != nil {
+		return nil, fmt.Errorf("failed to post revaluation transaction: %w", err)
+	}
+
+	result.TransactionID = txn.ID
+
+	// Record the full calculation alongside the posting for audit
+	_, err = rs.eventStore.CreateEvent(EventRevaluePeriod, result, result.RateDate, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create revaluation event: %w", err)
+	}
+
+	return result, nil
+}
+
+// revalueAccount compares an account's base-currency carrying amount with its
+// foreign balance translated at the closing rate
+func (rs *RevaluationService) revalueAccount(account *Account, baseCurrency Currency, asOfDate time.Time) (*RevaluationLine, error) {
+	entries, err := rs.storage.GetEntriesByAccount(account.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get entries: %w", err)
+	}
+
+	var foreignBalance, carryingAmount int64
+	for _, entry := range entries {
+		txn, err := rs.storage.GetTransaction(entry.TransactionID)
+		if err != nil {
+			continue
+		}
+		if txn.ValidTime.After(asOfDate) || txn.Status != Posted {
+			continue
+		}
+
+		multiplier := int64(rs.postingEngine.getBalanceMultiplier(account.Type, entry.Type))
+		foreignBalance += entry.Amount.Value * multiplier
+
+		baseValue, err := rs.baseValue(&entry.Amount, baseCurrency, txn.ValidTime)
+		if err != nil {
+			return nil, err
+		}
+		carryingAmount += baseValue * multiplier
+	}
+
+	rate, err := rs.exchangeRates.GetRate(account.Currency, baseCurrency, asOfDate)
+	if err != nil {
+		return nil, err
+	}
+	revalued, err := (&Amount{Value: foreignBalance, Currency: account.Currency}).Convert(baseCurrency, rate.Rate, asOfDate, rs.exchangeRates.GetRoundingPolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	// Difference in the account's normal-balance direction
+	difference := revalued.Value - carryingAmount
+	if difference == 0 {
+		return nil, nil
+	}
+
+	// Increasing an asset or decreasing a liability is a gain
+	gain := difference
+	adjustment := Debit
+	if account.Type == Liability {
+		gain = -difference
+		adjustment = Credit
+	}
+	if difference < 0 {
+		adjustment = oppositeEntryType(adjustment)
+	}
+
+	return &RevaluationLine{
+		AccountID:       account.ID,
+		AccountName:     account.Name,
+		Currency:        account.Currency,
+		ForeignBalance:  foreignBalance,
+		ClosingRate:     rate.Rate,
+		CarryingAmount:  carryingAmount,
+		RevaluedAmount:  revalued.Value,
+		UnrealizedGain:  gain,
+		AdjustmentEntry: adjustment,
+	}, nil
+}
+
+// baseValue returns an entry amount in base currency, using the recorded projection
+// when present and otherwise the historical rate on the transaction date
+func (rs *RevaluationService) baseValue(amount *Amount, baseCurrency Currency, date time.Time) (int64, error) {
+	if amount.BaseCurrency == baseCurrency {
+		return amount.BaseValue, nil
+	}
+	if amount.Currency == baseCurrency {
+		return amount.Value, nil
+	}
+
+	converted, err := rs.exchangeRates.Convert(amount, baseCurrency, date)
+	if err != nil {
+		return 0, fmt.Errorf("failed to determine historical base amount: %w", err)
+	}
+	return converted.Value, nil
+}
+
+// oppositeEntryType flips debit and credit
+func oppositeEntryType(entryType EntryType) EntryType {
+	if entryType == Debit {
+		return Credit
+	}
+	return Debit
+}
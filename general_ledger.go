@@ -0,0 +1,173 @@
+package accounting
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+)
+
+// DefaultGeneralLedgerPageSize is the number of entries per general ledger page
+// when a query does not set one
+const DefaultGeneralLedgerPageSize = 500
+
+// GeneralLedgerQuery selects a page of the general ledger detail
+type GeneralLedgerQuery struct {
+	AccountIDs []string  `json:"account_ids,omitempty"` // empty for all accounts
+	FromDate   time.Time `json:"from_date"`             // zero for inception
+	ToDate     time.Time `json:"to_date"`
+	Page       int       `json:"page"`      // from 1
+	PageSize   int       `json:"page_size"` // entries, 0 for the default
+}
+
+// AccountLedger is one account's part of a general ledger page. The balances and
+// totals cover the whole date range; Entries holds just the entries on the page,
+// each with its running balance, and drill down to their transactions by ID.
+type AccountLedger struct {
+	AccountID      string              `json:"account_id"`
+	AccountCode    string              `json:"account_code"`
+	AccountName    string              `json:"account_name"`
+	AccountType    AccountType         `json:"account_type"`
+	Currency       Currency            `json:"currency"`
+	OpeningBalance int64               `json:"opening_balance"` // minor units on the account's normal side
+	TotalDebits    int64               `json:"total_debits"`
+	TotalCredits   int64               `json:"total_credits"`
+	ClosingBalance int64               `json:"closing_balance"`
+	EntryCount     int                 `json:"entry_count"`
+	Entries        []*GeneralLedgerRow `json:"entries"`
+}
+
+// GeneralLedgerPage is a page of the general ledger detail report
+type GeneralLedgerPage struct {
+	FromDate     time.Time        `json:"from_date"`
+	ToDate       time.Time        `json:"to_date"`
+	Page         int              `json:"page"`
+	PageSize     int              `json:"page_size"`
+	TotalEntries int              `json:"total_entries"`
+	TotalPages   int              `json:"total_pages"`
+	Accounts     []*AccountLedger `json:"accounts"`
+}
+
+// GetGeneralLedger returns a page of the general ledger detail: the accounts in
+// account code order, each with its opening balance, posted entries and closing
+// balance, as WriteGeneralLedgerDetail streams them. Pages split the entries of all
+// the accounts together, so an account's entries may run over several pages; each
+// page repeats its balances. Accounts without entries in the range but with a
+// balance appear on the page where their entries would have been. Only the page's
+// entries are held in memory.
+func (rs *ReportingService) GetGeneralLedger(query *GeneralLedgerQuery) (*GeneralLedgerPage, error) {
+	if query.Page < 1 {
+		return nil, fmt.Errorf("page must be at least 1, got %d", query.Page)
+	}
+	if query.ToDate.Before(query.FromDate) {
+		return nil, fmt.Errorf("to date %s is before from date %s", query.ToDate.Format(time.DateOnly), query.FromDate.Format(time.DateOnly))
+	}
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultGeneralLedgerPageSize
+	}
+	accounts, err := rs.ledgerAccounts(query.AccountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	first := (query.Page - 1) * pageSize
+	last := first + pageSize
+	result := &GeneralLedgerPage{
+		FromDate: query.FromDate,
+		ToDate:   query.ToDate,
+		Page:     query.Page,
+		PageSize: pageSize,
+	}
+
+	// Each account's position among all the entries decides which page it is on
+	type placed struct {
+		ledger *AccountLedger
+		start  int
+	}
+	var ledgers []placed
+	position := 0
+	for _, account := range accounts {
+		var ledger *AccountLedger
+		start := position
+		err := rs.walkAccountLedger(account, query.FromDate, query.ToDate, func(row *GeneralLedgerRow) error {
+			switch row.RowType {
+			case LedgerRowOpening:
+				ledger = &AccountLedger{
+					AccountID:      account.ID,
+					AccountCode:    account.Code,
+					AccountName:    account.Name,
+					AccountType:    account.Type,
+					Currency:       account.Currency,
+					OpeningBalance: row.Balance,
+				}
+			case LedgerRowEntry:
+				ledger.EntryCount++
+				ledger.TotalDebits += row.Debit
+				ledger.TotalCredits += row.Credit
+				if position >= first && position < last {
+					ledger.Entries = append(ledger.Entries, row)
+				}
+				position++
+			case LedgerRowClosing:
+				ledger.ClosingBalance = row.Balance
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if ledger != nil {
+			ledgers = append(ledgers, placed{ledger: ledger, start: start})
+		}
+	}
+
+	result.TotalEntries = position
+	result.TotalPages = max(1, (position+pageSize-1)/pageSize)
+	for _, p := range ledgers {
+		switch {
+		case len(p.ledger.Entries) > 0:
+		case p.ledger.EntryCount == 0 && p.start >= first && p.start < last:
+		case p.ledger.EntryCount == 0 && p.start == position && query.Page == result.TotalPages:
+			// after the last entry, so on the last page
+		default:
+			continue
+		}
+		result.Accounts = append(result.Accounts, p.ledger)
+	}
+	return result, nil
+}
+
+// WriteCSV writes the page in the general ledger detail CSV layout, each account's
+// entries between its opening and closing rows
+func (p *GeneralLedgerPage) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(generalLedgerHeader); err != nil {
+		return fmt.Errorf("failed to write header: %w", err)
+	}
+	for _, ledger := range p.Accounts {
+		balanceRow := func(rowType string, date time.Time, balance int64) *GeneralLedgerRow {
+			return &GeneralLedgerRow{
+				RowType:     rowType,
+				AccountID:   ledger.AccountID,
+				AccountCode: ledger.AccountCode,
+				AccountName: ledger.AccountName,
+				Date:        date,
+				Currency:    ledger.Currency,
+				Balance:     balance,
+			}
+		}
+		rows := append([]*GeneralLedgerRow{balanceRow(LedgerRowOpening, p.FromDate, ledger.OpeningBalance)}, ledger.Entries...)
+		rows = append(rows, balanceRow(LedgerRowClosing, p.ToDate, ledger.ClosingBalance))
+		for _, row := range rows {
+			if err := writer.Write(row.csvRecord()); err != nil {
+				return fmt.Errorf("failed to write row: %w", err)
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write rows: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package accounting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneralLedger(t *testing.T) {
+	dbFile := "test_general_ledger.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	post := func(debit, credit string, amount int64, validTime time.Time, dims ...Dimension) *Transaction {
+		txn := &Transaction{
+			Description: "Activity",
+			ValidTime:   validTime,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: dims},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: dims},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 9, 0, 0, 0, time.UTC) }
+
+	post("cash", "revenue", 10000, day(1))
+	post("cash", "accounts_payable", 3000, day(1))
+	sale := post("accounts_receivable", "revenue", 25000, day(5), Dimension{Key: DimDepartment, Value: "sales"})
+	post("cash", "revenue", 5000, day(10))
+	post("expenses", "cash", 2000, day(12))
+
+	query := &GeneralLedgerQuery{FromDate: day(2), ToDate: day(31), Page: 1, PageSize: 4}
+	first, err := engine.GetGeneralLedger(query)
+	require.NoError(t, err)
+
+	t.Run("opening balance, entries with running balances and closing balance", func(t *testing.T) {
+		assert.Equal(t, 6, first.TotalEntries)
+		assert.Equal(t, 2, first.TotalPages)
+
+		ids := make([]string, len(first.Accounts))
+		for i, ledger := range first.Accounts {
+			ids[i] = ledger.AccountID
+		}
+		assert.Equal(t, []string{"cash", "accounts_receivable", "accounts_payable", "revenue"}, ids)
+
+		cash := first.Accounts[0]
+		assert.Equal(t, int64(13000), cash.OpeningBalance)
+		assert.Equal(t, int64(5000), cash.TotalDebits)
+		assert.Equal(t, int64(2000), cash.TotalCredits)
+		assert.Equal(t, int64(16000), cash.ClosingBalance)
+		require.Len(t, cash.Entries, 2)
+		assert.Equal(t, int64(18000), cash.Entries[0].Balance)
+		assert.Equal(t, int64(16000), cash.Entries[1].Balance)
+
+		receivable := first.Accounts[1].Entries[0]
+		assert.Equal(t, sale.ID, receivable.TransactionID, "entries drill down to their transaction")
+		assert.Equal(t, "Activity", receivable.Description)
+		assert.Equal(t, []Dimension{{Key: DimDepartment, Value: "sales"}}, receivable.Dimensions)
+
+		payable := first.Accounts[2]
+		assert.Zero(t, payable.EntryCount, "quiet accounts with a balance are listed")
+		assert.Equal(t, int64(3000), payable.OpeningBalance)
+		assert.Equal(t, int64(3000), payable.ClosingBalance)
+	})
+
+	t.Run("accounts continue onto the next page", func(t *testing.T) {
+		revenue := first.Accounts[3]
+		assert.Equal(t, 2, revenue.EntryCount)
+		require.Len(t, revenue.Entries, 1)
+		assert.Equal(t, int64(35000), revenue.Entries[0].Balance)
+
+		query.Page = 2
+		second, err := engine.GetGeneralLedger(query)
+		require.NoError(t, err)
+		require.Len(t, second.Accounts, 2)
+		assert.Equal(t, "revenue", second.Accounts[0].AccountID)
+		assert.Equal(t, int64(10000), second.Accounts[0].OpeningBalance)
+		require.Len(t, second.Accounts[0].Entries, 1)
+		assert.Equal(t, int64(40000), second.Accounts[0].Entries[0].Balance)
+		assert.Equal(t, int64(40000), second.Accounts[0].ClosingBalance)
+		assert.Equal(t, "expenses", second.Accounts[1].AccountID)
+
+		var out bytes.Buffer
+		require.NoError(t, second.WriteCSV(&out))
+		rows, err := csv.NewReader(&out).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 7)
+		assert.Equal(t, generalLedgerHeader, rows[0])
+		assert.Equal(t, []string{LedgerRowOpening, "revenue", "100.00"}, []string{rows[1][0], rows[1][1], rows[1][11]})
+		assert.Equal(t, []string{LedgerRowEntry, "revenue", "400.00"}, []string{rows[2][0], rows[2][1], rows[2][11]})
+		assert.Equal(t, []string{LedgerRowClosing, "expenses", "20.00"}, []string{rows[6][0], rows[6][1], rows[6][11]})
+
+		query.Page = 3
+		beyond, err := engine.GetGeneralLedger(query)
+		require.NoError(t, err)
+		assert.Empty(t, beyond.Accounts)
+	})
+
+	t.Run("single account with the default page size", func(t *testing.T) {
+		page, err := engine.GetGeneralLedger(&GeneralLedgerQuery{AccountIDs: []string{"revenue"}, ToDate: day(31), Page: 1})
+		require.NoError(t, err)
+		assert.Equal(t, DefaultGeneralLedgerPageSize, page.PageSize)
+		require.Len(t, page.Accounts, 1)
+		assert.Zero(t, page.Accounts[0].OpeningBalance, "a zero from date starts at inception")
+		assert.Len(t, page.Accounts[0].Entries, 3)
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		_, err := engine.GetGeneralLedger(&GeneralLedgerQuery{ToDate: day(31)})
+		assert.Error(t, err)
+		_, err = engine.GetGeneralLedger(&GeneralLedgerQuery{FromDate: day(31), ToDate: day(2), Page: 1})
+		assert.Error(t, err)
+		_, err = engine.GetGeneralLedger(&GeneralLedgerQuery{AccountIDs: []string{"missing"}, ToDate: day(31), Page: 1})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,623 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Going Concern and Liquidity Stress
+// ----------------------------------------------------------------------------
+
+// CovenantMetric is the measure a debt covenant is tested on
+type CovenantMetric string
+
+const (
+	CovenantCurrentRatio     CovenantMetric = "CURRENT_RATIO"
+	CovenantQuickRatio       CovenantMetric = "QUICK_RATIO"
+	CovenantDebtToEquity     CovenantMetric = "DEBT_TO_EQUITY"
+	CovenantInterestCoverage CovenantMetric = "INTEREST_COVERAGE"
+	CovenantMinimumCash      CovenantMetric = "MINIMUM_CASH" // minor units
+	CovenantNetWorth         CovenantMetric = "NET_WORTH"    // minor units
+)
+
+// CovenantBound says whether a covenant sets a floor or a ceiling
+type CovenantBound string
+
+const (
+	CovenantMinimum CovenantBound = "MINIMUM"
+	CovenantMaximum CovenantBound = "MAXIMUM"
+)
+
+// DebtCovenant is a financial covenant in a lending agreement
+type DebtCovenant struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	Lender    string         `json:"lender,omitempty"`
+	Metric    CovenantMetric `json:"metric"`
+	Bound     CovenantBound  `json:"bound"` // defaults to a ceiling for debt to equity and a floor otherwise
+	Threshold float64        `json:"threshold"`
+	CreatedBy string         `json:"created_by"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// StressSeverity grades a liquidity stress signal
+type StressSeverity string
+
+const (
+	StressNone     StressSeverity = "NONE"
+	StressWatch    StressSeverity = "WATCH"
+	StressWarning  StressSeverity = "WARNING"
+	StressCritical StressSeverity = "CRITICAL"
+)
+
+// GoingConcernIndicator sums up the signals for management and auditors
+type GoingConcernIndicator string
+
+const (
+	GoingConcernLow              GoingConcernIndicator = "LOW"
+	GoingConcernElevated         GoingConcernIndicator = "ELEVATED"
+	GoingConcernSignificantDoubt GoingConcernIndicator = "SIGNIFICANT_DOUBT"
+)
+
+// GoingConcernOptions tunes the assessment. Nil uses the defaults.
+type GoingConcernOptions struct {
+	Periods                int                    `json:"periods"` // months in the trend, including the current one
+	Ratios                 *FinancialRatioOptions `json:"ratios,omitempty"`
+	CustomerDimension      DimensionKey           `json:"customer_dimension"` // names the customer on revenue transactions
+	QuickRatioFloor        float64                `json:"quick_ratio_floor"`
+	PayablesStretchDays    float64                `json:"payables_stretch_days"` // warning above, critical above twice this
+	ConcentrationWarning   float64                `json:"concentration_warning"` // top customer's share of revenue
+	ConcentrationCritical  float64                `json:"concentration_critical"`
+	CovenantWarningMargin  float64                `json:"covenant_warning_margin"`  // headroom below which a covenant is at risk
+	RunwayWarningMonths    float64                `json:"runway_warning_months"`    // critical below half this
+	ConcentrationLookback  int                    `json:"concentration_lookback"`   // months of revenue for concentration
+	OperatingLossesPeriods int                    `json:"operating_losses_periods"` // loss-making months in the trend for a warning
+}
+
+// DefaultGoingConcernOptions are common thresholds for the indicators
+func DefaultGoingConcernOptions() *GoingConcernOptions {
+	return &GoingConcernOptions{
+		Periods:                4,
+		CustomerDimension:      DimCounterparty,
+		QuickRatioFloor:        1,
+		PayablesStretchDays:    15,
+		ConcentrationWarning:   0.25,
+		ConcentrationCritical:  0.5,
+		CovenantWarningMargin:  0.1,
+		RunwayWarningMonths:    12,
+		ConcentrationLookback:  12,
+		OperatingLossesPeriods: 2,
+	}
+}
+
+// StressSignal is one liquidity stress indicator
+type StressSignal struct {
+	Code      string         `json:"code"`
+	Name      string         `json:"name"`
+	Severity  StressSeverity `json:"severity"`
+	Value     *float64       `json:"value,omitempty"`
+	Threshold float64        `json:"threshold"`
+	Detail    string         `json:"detail"`
+}
+
+// CovenantTest is a covenant measured at the assessment date
+type CovenantTest struct {
+	Covenant *DebtCovenant  `json:"covenant"`
+	Actual   *float64       `json:"actual,omitempty"`   // nil when the measure cannot be computed
+	Headroom *float64       `json:"headroom,omitempty"` // fraction of the threshold, negative when breached
+	Status   StressSeverity `json:"status"`
+}
+
+// LiquidityTrendPoint is one month of the liquidity trend
+type LiquidityTrendPoint struct {
+	PeriodEnd      time.Time `json:"period_end"`
+	QuickRatio     *float64  `json:"quick_ratio,omitempty"`
+	PayablesDays   *float64  `json:"payables_days,omitempty"` // payables over the month's expenses, in days
+	Cash           int64     `json:"cash"`
+	NetIncome      int64     `json:"net_income"`
+	WorkingCapital int64     `json:"working_capital"`
+}
+
+// GoingConcernAssessment is the liquidity stress signals, covenant tests and the
+// overall going-concern indicator at a date
+type GoingConcernAssessment struct {
+	AsOf      time.Time              `json:"as_of"`
+	Currency  string                 `json:"currency"`
+	Indicator GoingConcernIndicator  `json:"indicator"`
+	Signals   []*StressSignal        `json:"signals"`
+	Covenants []*CovenantTest        `json:"covenants"`
+	Trend     []*LiquidityTrendPoint `json:"trend"` // oldest first
+	Summary   []string               `json:"summary"`
+}
+
+// GoingConcernService tracks debt covenants and assesses going-concern risk
+type GoingConcernService struct {
+	storage    *Storage
+	eventStore *EventStore
+	reporting  *ReportingService
+}
+
+// NewGoingConcernService creates a new going-concern service
+func NewGoingConcernService(storage *Storage, eventStore *EventStore, reporting *ReportingService) *GoingConcernService {
+	return &GoingConcernService{
+		storage:    storage,
+		eventStore: eventStore,
+		reporting:  reporting,
+	}
+}
+
+// SaveCovenant creates or updates a debt covenant
+func (gs *GoingConcernService) SaveCovenant(covenant *DebtCovenant, userID string) error {
+	if covenant.Name == "" {
+		return fmt.Errorf("covenant name is required")
+	}
+	switch covenant.Metric {
+	case CovenantCurrentRatio, CovenantQuickRatio, CovenantInterestCoverage, CovenantMinimumCash, CovenantNetWorth:
+		if covenant.Bound == "" {
+			covenant.Bound = CovenantMinimum
+		}
+	case CovenantDebtToEquity:
+		if covenant.Bound == "" {
+			covenant.Bound = CovenantMaximum
+		}
+	default:
+		return fmt.Errorf("unsupported covenant metric: %s", covenant.Metric)
+	}
+	if covenant.Bound != CovenantMinimum && covenant.Bound != CovenantMaximum {
+		return fmt.Errorf("unsupported covenant bound: %s", covenant.Bound)
+	}
+
+	if covenant.ID == "" {
+		if err := gs.storage.assignID(&covenant.ID, "covenant", BucketDebtCovenants); err != nil {
+			return err
+		}
+		covenant.CreatedBy = userID
+		covenant.CreatedAt = time.Now()
+	}
+
+	_, err := gs.eventStore.CreateEvent(EventSaveDebtCovenant, covenant, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create covenant event: %w", err)
+	}
+	return gs.storage.SaveDebtCovenant(covenant)
+}
+
+// AssessGoingConcern computes liquidity stress signals as of a date from the monthly
+// trend leading up to it: the quick ratio's level and direction, payables being
+// stretched, dependence on the largest customer, covenant headroom, recurring
+// losses, cash runway and working capital. Any critical signal points to
+// significant doubt and any warning to elevated risk.
+func (gs *GoingConcernService) AssessGoingConcern(asOf time.Time, currency string, options *GoingConcernOptions) (*GoingConcernAssessment, error) {
+	if options == nil {
+		options = DefaultGoingConcernOptions()
+	}
+	if options.Periods < 2 {
+		return nil, fmt.Errorf("the trend needs at least 2 periods, got %d", options.Periods)
+	}
+	ratioOptions := options.Ratios
+	if ratioOptions == nil {
+		ratioOptions = DefaultFinancialRatioOptions()
+	}
+
+	// Month to date for the current month, whole months before it
+	periods := make([]*FinancialRatios, options.Periods)
+	monthStart := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, asOf.Location())
+	for i := range periods {
+		end := asOf
+		if i > 0 {
+			end = monthStart.AddDate(0, -i+1, 0).Add(-time.Nanosecond)
+		}
+		ratios, err := gs.reporting.financialRatios(end, Monthly, currency, ratioOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute ratios to %s: %w", end.Format(time.DateOnly), err)
+		}
+		periods[len(periods)-1-i] = ratios
+	}
+
+	assessment := &GoingConcernAssessment{AsOf: asOf, Currency: currency}
+	for _, period := range periods {
+		figures := period.Figures
+		assessment.Trend = append(assessment.Trend, &LiquidityTrendPoint{
+			PeriodEnd:      period.AsOf,
+			QuickRatio:     period.Liquidity.QuickRatio,
+			PayablesDays:   payablesDays(period),
+			Cash:           figures.Cash,
+			NetIncome:      figures.NetIncome,
+			WorkingCapital: period.Liquidity.WorkingCapital,
+		})
+	}
+	latest := periods[len(periods)-1]
+
+	assessment.Signals = append(assessment.Signals,
+		quickRatioSignal(assessment.Trend, options),
+		payablesStretchSignal(assessment.Trend, options),
+	)
+	concentration, err := gs.revenueConcentrationSignal(asOf, currency, options)
+	if err != nil {
+		return nil, err
+	}
+	assessment.Signals = append(assessment.Signals, concentration)
+
+	covenants, err := gs.storage.GetAllDebtCovenants()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get covenants: %w", err)
+	}
+	sort.Slice(covenants, func(i, j int) bool {
+		return covenants[i].Name < covenants[j].Name
+	})
+	for _, covenant := range covenants {
+		assessment.Covenants = append(assessment.Covenants, testCovenant(covenant, latest, options))
+	}
+	assessment.Signals = append(assessment.Signals,
+		covenantSignal(assessment.Covenants),
+		operatingLossesSignal(assessment.Trend, options),
+		cashRunwaySignal(assessment.Trend, options, Currency(currency)),
+		workingCapitalSignal(latest, Currency(currency)),
+	)
+
+	assessment.Indicator = GoingConcernLow
+	for _, signal := range assessment.Signals {
+		switch signal.Severity {
+		case StressCritical:
+			assessment.Indicator = GoingConcernSignificantDoubt
+		case StressWarning:
+			if assessment.Indicator == GoingConcernLow {
+				assessment.Indicator = GoingConcernElevated
+			}
+		}
+		if signal.Severity != StressNone {
+			assessment.Summary = append(assessment.Summary, fmt.Sprintf("%s (%s): %s", signal.Name, strings.ToLower(string(signal.Severity)), signal.Detail))
+		}
+	}
+	if len(assessment.Summary) == 0 {
+		assessment.Summary = []string{"No liquidity stress indicators were identified."}
+	}
+	return assessment, nil
+}
+
+// payablesDays is closing payables over the period's expenses, in days
+func payablesDays(period *FinancialRatios) *float64 {
+	expenses := period.Figures.Revenue - period.Figures.NetIncome
+	days := ratio(period.Figures.Payables, expenses)
+	if days != nil {
+		*days *= float64(period.Days)
+	}
+	return days
+}
+
+// quickRatioSignal flags a quick ratio below the floor, more so when it has fallen
+// over the trend or is below half the floor
+func quickRatioSignal(trend []*LiquidityTrendPoint, options *GoingConcernOptions) *StressSignal {
+	signal := &StressSignal{Code: "QUICK_RATIO_TREND", Name: "Quick ratio trend", Threshold: options.QuickRatioFloor, Severity: StressNone}
+	first, latest := trend[0].QuickRatio, trend[len(trend)-1].QuickRatio
+	if latest == nil {
+		signal.Detail = "no current liabilities"
+		return signal
+	}
+	signal.Value = latest
+	declining := first != nil && *latest < *first
+	switch {
+	case *latest < options.QuickRatioFloor/2:
+		signal.Severity = StressCritical
+	case *latest < options.QuickRatioFloor && declining:
+		signal.Severity = StressWarning
+	case *latest < options.QuickRatioFloor || (declining && *latest < *first*0.8):
+		signal.Severity = StressWatch
+	}
+	signal.Detail = fmt.Sprintf("quick ratio %.2f against a floor of %.2f", *latest, options.QuickRatioFloor)
+	if first != nil {
+		signal.Detail += fmt.Sprintf(", from %.2f %d months earlier", *first, len(trend)-1)
+	}
+	return signal
+}
+
+// payablesStretchSignal flags payables days rising above their earlier average,
+// a sign suppliers are being paid later to preserve cash
+func payablesStretchSignal(trend []*LiquidityTrendPoint, options *GoingConcernOptions) *StressSignal {
+	signal := &StressSignal{Code: "PAYABLES_STRETCH", Name: "Payables stretch", Threshold: options.PayablesStretchDays, Severity: StressNone}
+	latest := trend[len(trend)-1].PayablesDays
+	var earlier []float64
+	for _, point := range trend[:len(trend)-1] {
+		if point.PayablesDays != nil {
+			earlier = append(earlier, *point.PayablesDays)
+		}
+	}
+	if latest == nil || len(earlier) == 0 {
+		signal.Detail = "not enough expense history"
+		return signal
+	}
+	average := 0.0
+	for _, days := range earlier {
+		average += days
+	}
+	average /= float64(len(earlier))
+	stretch := *latest - average
+	signal.Value = &stretch
+	switch {
+	case stretch > 2*options.PayablesStretchDays:
+		signal.Severity = StressCritical
+	case stretch > options.PayablesStretchDays:
+		signal.Severity = StressWarning
+	case stretch > options.PayablesStretchDays/2:
+		signal.Severity = StressWatch
+	}
+	signal.Detail = fmt.Sprintf("payables at %.0f days of expenses against an average of %.0f", *latest, average)
+	return signal
+}
+
+// revenueConcentrationSignal flags dependence on the largest customer over the
+// lookback
+func (gs *GoingConcernService) revenueConcentrationSignal(asOf time.Time, currency string, options *GoingConcernOptions) (*StressSignal, error) {
+	signal := &StressSignal{Code: "REVENUE_CONCENTRATION", Name: "Revenue concentration", Threshold: options.ConcentrationWarning, Severity: StressNone}
+	from := time.Date(asOf.Year(), asOf.Month()-time.Month(options.ConcentrationLookback)+1, 1, 0, 0, 0, 0, asOf.Location())
+
+	byCustomer, err := customerRevenue(gs.storage, gs.reporting, from, asOf, currency, options.CustomerDimension)
+	if err != nil {
+		return nil, err
+	}
+	total := int64(0)
+	for _, revenue := range byCustomer {
+		total += revenue
+	}
+	if total <= 0 {
+		signal.Detail = "no revenue in the lookback"
+		return signal, nil
+	}
+
+	top, topRevenue := "", int64(0)
+	for customer, revenue := range byCustomer {
+		if customer != "" && (revenue > topRevenue || (revenue == topRevenue && customer < top)) {
+			top, topRevenue = customer, revenue
+		}
+	}
+	if top == "" {
+		signal.Detail = "no revenue is attributed to customers"
+		return signal, nil
+	}
+	share := float64(topRevenue) / float64(total)
+	signal.Value = &share
+	switch {
+	case share > options.ConcentrationCritical:
+		signal.Severity = StressCritical
+	case share > options.ConcentrationWarning:
+		signal.Severity = StressWarning
+	case share > options.ConcentrationWarning/2:
+		signal.Severity = StressWatch
+	}
+	signal.Detail = fmt.Sprintf("largest customer %s is %.0f%% of revenue over %d months", top, share*100, options.ConcentrationLookback)
+	return signal, nil
+}
+
+// testCovenant measures a covenant against the latest period
+func testCovenant(covenant *DebtCovenant, period *FinancialRatios, options *GoingConcernOptions) *CovenantTest {
+	test := &CovenantTest{Covenant: covenant, Status: StressNone}
+	amount := func(value int64) *float64 {
+		v := float64(value)
+		return &v
+	}
+	switch covenant.Metric {
+	case CovenantCurrentRatio:
+		test.Actual = period.Liquidity.CurrentRatio
+	case CovenantQuickRatio:
+		test.Actual = period.Liquidity.QuickRatio
+	case CovenantDebtToEquity:
+		switch {
+		case period.Figures.TotalLiabilities == 0:
+			test.Actual = amount(0)
+		case period.Figures.Equity > 0:
+			test.Actual = period.Leverage.DebtToEquity
+		}
+	case CovenantInterestCoverage:
+		test.Actual = period.Leverage.InterestCoverage
+	case CovenantMinimumCash:
+		test.Actual = amount(period.Figures.Cash)
+	case CovenantNetWorth:
+		test.Actual = amount(period.Figures.Equity)
+	}
+
+	if test.Actual == nil {
+		// Nothing to cover passes; debt with no equity to gear against fails
+		if covenant.Metric == CovenantDebtToEquity {
+			test.Status = StressCritical
+		}
+		return test
+	}
+	headroom := *test.Actual - covenant.Threshold
+	if covenant.Bound == CovenantMaximum {
+		headroom = -headroom
+	}
+	if covenant.Threshold != 0 {
+		headroom /= math.Abs(covenant.Threshold)
+	}
+	test.Headroom = &headroom
+	switch {
+	case headroom < 0:
+		test.Status = StressCritical
+	case headroom < options.CovenantWarningMargin:
+		test.Status = StressWarning
+	}
+	return test
+}
+
+// covenantSignal is the worst covenant result
+func covenantSignal(tests []*CovenantTest) *StressSignal {
+	signal := &StressSignal{Code: "COVENANT_HEADROOM", Name: "Covenant headroom", Severity: StressNone, Detail: "no covenants"}
+	if len(tests) == 0 {
+		return signal
+	}
+	signal.Detail = fmt.Sprintf("all %d covenants have headroom", len(tests))
+	var breached, atRisk []string
+	for _, test := range tests {
+		if test.Headroom != nil && (signal.Value == nil || *test.Headroom < *signal.Value) {
+			signal.Value = test.Headroom
+		}
+		switch test.Status {
+		case StressCritical:
+			breached = append(breached, test.Covenant.Name)
+		case StressWarning:
+			atRisk = append(atRisk, test.Covenant.Name)
+		}
+	}
+	switch {
+	case len(breached) > 0:
+		signal.Severity = StressCritical
+		signal.Detail = "breached: " + strings.Join(breached, ", ")
+	case len(atRisk) > 0:
+		signal.Severity = StressWarning
+		signal.Detail = "little headroom: " + strings.Join(atRisk, ", ")
+	}
+	return signal
+}
+
+// operatingLossesSignal flags recurring losses over the trend
+func operatingLossesSignal(trend []*LiquidityTrendPoint, options *GoingConcernOptions) *StressSignal {
+	losses := 0
+	for _, point := range trend {
+		if point.NetIncome < 0 {
+			losses++
+		}
+	}
+	value := float64(losses)
+	signal := &StressSignal{Code: "OPERATING_LOSSES", Name: "Recurring losses", Severity: StressNone, Value: &value,
+		Threshold: float64(options.OperatingLossesPeriods),
+		Detail:    fmt.Sprintf("losses in %d of the last %d months", losses, len(trend))}
+	latestLoss := trend[len(trend)-1].NetIncome < 0
+	switch {
+	case losses == len(trend):
+		signal.Severity = StressCritical
+	case losses >= options.OperatingLossesPeriods && latestLoss:
+		signal.Severity = StressWarning
+	case losses > 0:
+		signal.Severity = StressWatch
+	}
+	return signal
+}
+
+// cashRunwaySignal flags cash running out at the trend's average monthly burn
+func cashRunwaySignal(trend []*LiquidityTrendPoint, options *GoingConcernOptions, currency Currency) *StressSignal {
+	signal := &StressSignal{Code: "CASH_RUNWAY", Name: "Cash runway", Threshold: options.RunwayWarningMonths, Severity: StressNone}
+	first, latest := trend[0].Cash, trend[len(trend)-1].Cash
+	burn := float64(first-latest) / float64(len(trend)-1)
+	if burn <= 0 {
+		signal.Detail = "cash is not declining"
+		return signal
+	}
+	months := float64(latest) / burn
+	if months < 0 {
+		months = 0
+	}
+	signal.Value = &months
+	switch {
+	case months < options.RunwayWarningMonths/2:
+		signal.Severity = StressCritical
+	case months < options.RunwayWarningMonths:
+		signal.Severity = StressWarning
+	}
+	signal.Detail = fmt.Sprintf("%.1f months of cash at a burn of %s a month", months, FormatMinorUnits(int64(math.Round(burn)), currency))
+	return signal
+}
+
+// workingCapitalSignal flags net current liabilities, and net liabilities overall
+func workingCapitalSignal(period *FinancialRatios, currency Currency) *StressSignal {
+	workingCapital := float64(period.Liquidity.WorkingCapital)
+	signal := &StressSignal{Code: "WORKING_CAPITAL", Name: "Working capital", Severity: StressNone, Value: &workingCapital,
+		Detail: fmt.Sprintf("working capital of %s", FormatMinorUnits(period.Liquidity.WorkingCapital, currency))}
+	switch {
+	case period.Figures.Equity < 0:
+		signal.Severity = StressCritical
+		signal.Detail += fmt.Sprintf(" and net liabilities of %s", FormatMinorUnits(-period.Figures.Equity, currency))
+	case period.Liquidity.WorkingCapital < 0:
+		signal.Severity = StressWarning
+	}
+	return signal
+}
+
+// FormatGoingConcernSummary renders an assessment as a plain text summary for
+// management and auditors
+func FormatGoingConcernSummary(assessment *GoingConcernAssessment) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Going-concern indicators as of %s (%s)\n", assessment.AsOf.Format(time.DateOnly), assessment.Currency)
+	fmt.Fprintf(&b, "Overall indicator: %s\n\n", assessment.Indicator)
+	for _, signal := range assessment.Signals {
+		fmt.Fprintf(&b, "  %-22s %-9s %s\n", signal.Name, signal.Severity, signal.Detail)
+	}
+	if len(assessment.Covenants) > 0 {
+		b.WriteString("\nCovenants\n")
+		for _, test := range assessment.Covenants {
+			actual, headroom := "n/a", "n/a"
+			if test.Actual != nil {
+				actual = fmt.Sprintf("%.2f", *test.Actual)
+			}
+			if test.Headroom != nil {
+				headroom = fmt.Sprintf("%.1f%%", *test.Headroom*100)
+			}
+			fmt.Fprintf(&b, "  %-22s %s %s %.2f, actual %s, headroom %s (%s)\n", test.Covenant.Name,
+				strings.ToLower(string(test.Covenant.Bound)), strings.ToLower(string(test.Covenant.Metric)),
+				test.Covenant.Threshold, actual, headroom, test.Status)
+		}
+	}
+	b.WriteString("\nSummary\n")
+	for _, line := range assessment.Summary {
+		fmt.Fprintf(&b, "  - %s\n", line)
+	}
+	return b.String()
+}
+
+// GoingConcernDocument lays out an assessment for export
+func GoingConcernDocument(assessment *GoingConcernAssessment) *ReportDocument {
+	optional := func(value *float64, decimals int) ReportCell {
+		if value == nil {
+			return TextCell("")
+		}
+		return NumberCell(*value, decimals)
+	}
+
+	signals := &ReportSheet{Name: "Signals", Columns: []string{"Indicator", "Severity", "Value", "Threshold", "Detail"}}
+	for _, signal := range assessment.Signals {
+		signals.Rows = append(signals.Rows, []ReportCell{
+			TextCell(signal.Name),
+			TextCell(string(signal.Severity)),
+			optional(signal.Value, 2),
+			NumberCell(signal.Threshold, 2),
+			TextCell(signal.Detail),
+		})
+	}
+	signals.Rows = append(signals.Rows, []ReportCell{TextCell("Overall").Bolded(), TextCell(string(assessment.Indicator)).Bolded()})
+
+	covenants := &ReportSheet{Name: "Covenants", Columns: []string{"Covenant", "Lender", "Metric", "Bound", "Threshold", "Actual", "Headroom", "Status"}}
+	for _, test := range assessment.Covenants {
+		covenants.Rows = append(covenants.Rows, []ReportCell{
+			TextCell(test.Covenant.Name),
+			TextCell(test.Covenant.Lender),
+			TextCell(string(test.Covenant.Metric)),
+			TextCell(string(test.Covenant.Bound)),
+			NumberCell(test.Covenant.Threshold, 2),
+			optional(test.Actual, 2),
+			optional(test.Headroom, 4),
+			TextCell(string(test.Status)),
+		})
+	}
+
+	currency := Currency(assessment.Currency)
+	trend := &ReportSheet{Name: "Liquidity Trend", Columns: []string{"Period end", "Quick ratio", "Payables days", "Cash", "Net income", "Working capital"}}
+	for _, point := range assessment.Trend {
+		trend.Rows = append(trend.Rows, []ReportCell{
+			TextCell(point.PeriodEnd.Format(time.DateOnly)),
+			optional(point.QuickRatio, 2),
+			optional(point.PayablesDays, 1),
+			AmountCell(point.Cash, currency),
+			AmountCell(point.NetIncome, currency),
+			AmountCell(point.WorkingCapital, currency),
+		})
+	}
+
+	return &ReportDocument{
+		Title:    "Going-Concern Indicators",
+		Subtitle: fmt.Sprintf("As of %s (%s)", assessment.AsOf.Format(time.DateOnly), assessment.Currency),
+		Sheets:   []*ReportSheet{signals, covenants, trend},
+	}
+}
@@ -0,0 +1,183 @@
+package accounting
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+func TestGoingConcern(t *testing.T) {
+	dbFile := "test_going_concern.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "cfo"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "share_capital", Name: "Share Capital", Type: Equity}, userID))
+
+	post := func(validTime time.Time, debit, credit string, amount int64, dims ...Dimension) {
+		txn := &Transaction{
+			Description: "Activity",
+			ValidTime:   validTime,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: dims},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: dims},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	customer := func(name string) Dimension { return Dimension{Key: DimCounterparty, Value: name} }
+
+	// Four loss-making months: cash falls by 8,000 a month while suppliers wait longer
+	post(time.Date(2024, 11, 1, 9, 0, 0, 0, time.UTC), "cash", "share_capital", 60000)
+	for _, month := range []time.Month{11, 12, 1, 2} {
+		year := 2024
+		if month < 11 {
+			year = 2025
+		}
+		day := func(d int) time.Time { return time.Date(year, month, d, 9, 0, 0, 0, time.UTC) }
+		post(day(5), "cash", "revenue", 20000, customer("acme"))
+		post(day(6), "cash", "revenue", 2000, customer("globex"))
+		post(day(10), "expenses", "cash", 30000)
+		post(day(20), "expenses", "accounts_payable", 10000)
+	}
+
+	// Before the first month of activity
+	t.Run("a quiet book has no indicators", func(t *testing.T) {
+		result, err := engine.AssessGoingConcern(time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC), "USD", nil)
+		require.NoError(t, err)
+		assert.Equal(t, GoingConcernLow, result.Indicator)
+		assert.Equal(t, []string{"No liquidity stress indicators were identified."}, result.Summary)
+	})
+
+	require.NoError(t, engine.SaveDebtCovenant(&DebtCovenant{Name: "Minimum cash", Lender: "First Bank", Metric: CovenantMinimumCash, Threshold: 30000}, userID))
+	require.NoError(t, engine.SaveDebtCovenant(&DebtCovenant{Name: "Current ratio", Lender: "First Bank", Metric: CovenantCurrentRatio, Threshold: 0.65}, userID))
+	require.NoError(t, engine.SaveDebtCovenant(&DebtCovenant{Name: "Leverage", Lender: "First Bank", Metric: CovenantDebtToEquity, Threshold: 2}, userID))
+
+	t.Run("covenants are validated", func(t *testing.T) {
+		err := engine.SaveDebtCovenant(&DebtCovenant{Name: "EBITDA", Metric: "EBITDA", Threshold: 1}, userID)
+		assert.ErrorContains(t, err, "unsupported covenant metric")
+		err = engine.SaveDebtCovenant(&DebtCovenant{Metric: CovenantQuickRatio}, userID)
+		assert.ErrorContains(t, err, "name is required")
+
+		covenant := &DebtCovenant{Name: "Quick ratio", Metric: CovenantQuickRatio, Threshold: 1}
+		require.NoError(t, engine.GetGoingConcernService().SaveCovenant(covenant, userID))
+		assert.Equal(t, CovenantMinimum, covenant.Bound)
+		require.NoError(t, engine.GetStorage().db.Update(func(tx *bbolt.Tx) error {
+			return tx.Bucket(BucketDebtCovenants).Delete([]byte(covenant.ID))
+		}))
+	})
+
+	asOf := time.Date(2025, 2, 28, 23, 59, 59, 0, time.UTC)
+	assessment, err := engine.AssessGoingConcern(asOf, "USD", nil)
+	require.NoError(t, err)
+	signals := make(map[string]*StressSignal)
+	for _, signal := range assessment.Signals {
+		signals[signal.Code] = signal
+	}
+
+	t.Run("trend covers the months to date", func(t *testing.T) {
+		require.Len(t, assessment.Trend, 4)
+		assert.Equal(t, time.Date(2024, 11, 30, 23, 59, 59, 999999999, time.UTC), assessment.Trend[0].PeriodEnd)
+		var cash []int64
+		for _, point := range assessment.Trend {
+			cash = append(cash, point.Cash)
+		}
+		assert.Equal(t, []int64{52000, 44000, 36000, 28000}, cash)
+		assert.Equal(t, int64(-12000), assessment.Trend[3].WorkingCapital)
+	})
+
+	t.Run("quick ratio below the floor and falling", func(t *testing.T) {
+		signal := signals["QUICK_RATIO_TREND"]
+		require.NotNil(t, signal.Value)
+		assert.InDelta(t, 0.7, *signal.Value, 1e-9)
+		assert.Equal(t, StressWarning, signal.Severity)
+		assert.Contains(t, signal.Detail, "from 5.20 3 months earlier")
+	})
+
+	t.Run("payables stretch against the earlier average", func(t *testing.T) {
+		signal := signals["PAYABLES_STRETCH"]
+		require.NotNil(t, signal.Value)
+		// 28 days now against (7.5 + 15.5 + 23.25) / 3 before
+		assert.InDelta(t, 28-46.25/3, *signal.Value, 1e-9)
+		assert.Equal(t, StressWatch, signal.Severity)
+	})
+
+	t.Run("largest customer dominates revenue", func(t *testing.T) {
+		signal := signals["REVENUE_CONCENTRATION"]
+		require.NotNil(t, signal.Value)
+		assert.InDelta(t, 20.0/22, *signal.Value, 1e-9)
+		assert.Equal(t, StressCritical, signal.Severity)
+		assert.Contains(t, signal.Detail, "acme is 91% of revenue")
+	})
+
+	t.Run("covenants are tested on the latest month", func(t *testing.T) {
+		require.Len(t, assessment.Covenants, 3)
+		byName := make(map[string]*CovenantTest)
+		for _, test := range assessment.Covenants {
+			byName[test.Covenant.Name] = test
+		}
+		assert.InDelta(t, -2000.0/30000, *byName["Minimum cash"].Headroom, 1e-9)
+		assert.Equal(t, StressCritical, byName["Minimum cash"].Status)
+		assert.InDelta(t, (0.7-0.65)/0.65, *byName["Current ratio"].Headroom, 1e-9)
+		assert.Equal(t, StressWarning, byName["Current ratio"].Status)
+		// Negative equity breaches the leverage covenant outright
+		assert.Nil(t, byName["Leverage"].Actual)
+		assert.Equal(t, StressCritical, byName["Leverage"].Status)
+
+		signal := signals["COVENANT_HEADROOM"]
+		assert.Equal(t, StressCritical, signal.Severity)
+		assert.Equal(t, "breached: Leverage, Minimum cash", signal.Detail)
+	})
+
+	t.Run("losses, runway and net liabilities", func(t *testing.T) {
+		assert.Equal(t, StressCritical, signals["OPERATING_LOSSES"].Severity)
+		runway := signals["CASH_RUNWAY"]
+		require.NotNil(t, runway.Value)
+		assert.InDelta(t, 3.5, *runway.Value, 1e-9)
+		assert.Equal(t, StressCritical, runway.Severity)
+		assert.Contains(t, runway.Detail, "burn of 80.00 a month")
+		capital := signals["WORKING_CAPITAL"]
+		assert.Equal(t, StressCritical, capital.Severity)
+		assert.Contains(t, capital.Detail, "net liabilities of 120.00")
+	})
+
+	t.Run("indicator and summary", func(t *testing.T) {
+		assert.Equal(t, GoingConcernSignificantDoubt, assessment.Indicator)
+		assert.Len(t, assessment.Summary, 7)
+
+		text := FormatGoingConcernSummary(assessment)
+		assert.Contains(t, text, "Overall indicator: SIGNIFICANT_DOUBT")
+		assert.Contains(t, text, "headroom -6.7% (CRITICAL)")
+
+		var buf bytes.Buffer
+		require.NoError(t, ExportReport(&buf, ExportFormatCSV, GoingConcernDocument(assessment)))
+		assert.Contains(t, buf.String(), "Revenue concentration,CRITICAL")
+	})
+
+	t.Run("thresholds are configurable", func(t *testing.T) {
+		options := DefaultGoingConcernOptions()
+		options.Periods = 1
+		_, err := engine.AssessGoingConcern(asOf, "USD", options)
+		assert.ErrorContains(t, err, "at least 2 periods")
+
+		options = DefaultGoingConcernOptions()
+		options.ConcentrationCritical = 0.95
+		result, err := engine.AssessGoingConcern(asOf, "USD", options)
+		require.NoError(t, err)
+		for _, signal := range result.Signals {
+			if signal.Code == "REVENUE_CONCENTRATION" {
+				assert.Equal(t, StressWarning, signal.Severity)
+			}
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package accounting
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Transaction Graph Export
+// ----------------------------------------------------------------------------
+
+// Neo4j labels of the exported graph
+const (
+	neo4jAccountLabel     = "Account"
+	neo4jTransferRelation = "TRANSFERRED_TO"
+)
+
+// ExportTransactionGraph writes a money-flow graph for an external tool: GraphML for
+// yEd or Gephi, DOT for Graphviz, or a zip of Neo4j import CSVs. Accounts and
+// transfers are written in account order so exports of the same graph compare
+// equal. Amounts are in minor units.
+func ExportTransactionGraph(w io.Writer, format ExportFormat, graph *TransactionGraph) error {
+	nodes, edges := sortedGraph(graph)
+	switch format {
+	case ExportFormatGraphML:
+		return writeGraphML(w, nodes, edges)
+	case ExportFormatDOT:
+		return writeDOT(w, nodes, edges)
+	case ExportFormatNeo4jCSV:
+		var accounts, transfers strings.Builder
+		if err := writeNeo4jNodes(&accounts, nodes); err != nil {
+			return err
+		}
+		if err := writeNeo4jRelationships(&transfers, edges); err != nil {
+			return err
+		}
+		archive := zip.NewWriter(w)
+		if err := writeZipPart(archive, "accounts.csv", accounts.String()); err != nil {
+			return err
+		}
+		if err := writeZipPart(archive, "transfers.csv", transfers.String()); err != nil {
+			return err
+		}
+		if err := archive.Close(); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported graph export format: %s", format)
+	}
+}
+
+// ExportTransactionGraphNeo4j writes the Neo4j import CSVs to separate writers, for
+//
+//	neo4j-admin database import full --nodes=accounts.csv --relationships=transfers.csv
+func ExportTransactionGraphNeo4j(nodes, relationships io.Writer, graph *TransactionGraph) error {
+	sortedNodes, sortedEdges := sortedGraph(graph)
+	if err := writeNeo4jNodes(nodes, sortedNodes); err != nil {
+		return err
+	}
+	return writeNeo4jRelationships(relationships, sortedEdges)
+}
+
+// sortedGraph orders a graph's accounts by ID and its transfers by source, then target
+func sortedGraph(graph *TransactionGraph) ([]GraphNode, []GraphEdge) {
+	nodes := append([]GraphNode(nil), graph.Nodes...)
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].AccountID < nodes[j].AccountID })
+	edges := append([]GraphEdge(nil), graph.Edges...)
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].FromAccount != edges[j].FromAccount {
+			return edges[i].FromAccount < edges[j].FromAccount
+		}
+		return edges[i].ToAccount < edges[j].ToAccount
+	})
+	return nodes, edges
+}
+
+// graphTime formats an edge timestamp as ISO 8601, which all three formats accept
+func graphTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// writeGraphML writes a directed GraphML document with typed attribute keys
+func writeGraphML(w io.Writer, nodes []GraphNode, edges []GraphEdge) error {
+	out := bufio.NewWriter(w)
+	out.WriteString(xml.Header)
+	out.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"` +
+		` xsi:schemaLocation="http://graphml.graphdrawing.org/xmlns http://graphml.graphdrawing.org/xmlns/1.0/graphml.xsd">` + "\n")
+	for _, key := range []struct{ id, domain, name, typ string }{
+		{"name", "node", "name", "string"},
+		{"inflow", "node", "total_inflow", "long"},
+		{"outflow", "node", "total_outflow", "long"},
+		{"node_currency", "node", "currency", "string"},
+		{"centrality", "node", "centrality", "double"},
+		{"amount", "edge", "total_amount", "long"},
+		{"edge_currency", "edge", "currency", "string"},
+		{"count", "edge", "transaction_count", "int"},
+		{"first", "edge", "first_transaction", "string"},
+		{"last", "edge", "last_transaction", "string"},
+		{"weight", "edge", "weight", "double"},
+	} {
+		fmt.Fprintf(out, `  <key id="%s" for="%s" attr.name="%s" attr.type="%s"/>`+"\n", key.id, key.domain, key.name, key.typ)
+	}
+	out.WriteString(`  <graph id="money_flow" edgedefault="directed">` + "\n")
+
+	data := func(key, value string) {
+		fmt.Fprintf(out, `      <data key="%s">%s</data>`+"\n", key, xmlEscape(value))
+	}
+	for _, node := range nodes {
+		fmt.Fprintf(out, `    <node id="%s">`+"\n", xmlEscape(node.AccountID))
+		data("name", node.AccountName)
+		data("inflow", strconv.FormatInt(node.TotalInflow.Value, 10))
+		data("outflow", strconv.FormatInt(node.TotalOutflow.Value, 10))
+		data("node_currency", string(node.TotalInflow.Currency))
+		data("centrality", strconv.FormatFloat(node.Centrality, 'f', -1, 64))
+		out.WriteString("    </node>\n")
+	}
+	for i, edge := range edges {
+		fmt.Fprintf(out, `    <edge id="e%d" source="%s" target="%s">`+"\n", i, xmlEscape(edge.FromAccount), xmlEscape(edge.ToAccount))
+		data("amount", strconv.FormatInt(edge.TotalAmount.Value, 10))
+		data("edge_currency", string(edge.TotalAmount.Currency))
+		data("count", strconv.Itoa(edge.TransactionCount))
+		data("first", graphTime(edge.FirstTransaction))
+		data("last", graphTime(edge.LastTransaction))
+		data("weight", strconv.FormatFloat(edge.Weight, 'f', -1, 64))
+		out.WriteString("    </edge>\n")
+	}
+	out.WriteString("  </graph>\n</graphml>\n")
+	if err := out.Flush(); err != nil {
+		return fmt.Errorf("failed to write GraphML: %w", err)
+	}
+	return nil
+}
+
+// dotQuote quotes an identifier or label for the DOT language
+func dotQuote(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + replacer.Replace(text) + `"`
+}
+
+// writeDOT writes a left-to-right digraph. Edge labels carry the amount moved and
+// the number of transactions; larger flows are drawn with thicker lines.
+func writeDOT(w io.Writer, nodes []GraphNode, edges []GraphEdge) error {
+	var largest int64
+	for _, edge := range edges {
+		largest = max(largest, edge.TotalAmount.Value)
+	}
+
+	out := bufio.NewWriter(w)
+	out.WriteString("digraph money_flow {\n  rankdir=LR;\n  node [shape=box, style=rounded];\n")
+	for _, node := range nodes {
+		name := node.AccountName
+		if name == "" {
+			name = node.AccountID
+		}
+		label := fmt.Sprintf("%s\nin %s / out %s", name, node.TotalInflow.Format(), node.TotalOutflow.Format())
+		fmt.Fprintf(out, "  %s [label=%s];\n", dotQuote(node.AccountID), dotQuote(label))
+	}
+	for _, edge := range edges {
+		penwidth := 1.0
+		if largest > 0 {
+			penwidth += 4 * float64(edge.TotalAmount.Value) / float64(largest)
+		}
+		label := fmt.Sprintf("%s (%d)", edge.TotalAmount.Format(), edge.TransactionCount)
+		fmt.Fprintf(out, "  %s -> %s [label=%s, penwidth=%.2f];\n", dotQuote(edge.FromAccount), dotQuote(edge.ToAccount), dotQuote(label), penwidth)
+	}
+	out.WriteString("}\n")
+	if err := out.Flush(); err != nil {
+		return fmt.Errorf("failed to write DOT: %w", err)
+	}
+	return nil
+}
+
+// writeNeo4jNodes writes the accounts with neo4j-admin import headers
+func writeNeo4jNodes(w io.Writer, nodes []GraphNode) error {
+	writer := csv.NewWriter(w)
+	rows := [][]string{{
+		"accountId:ID(" + neo4jAccountLabel + ")", "name", "totalInflow:long", "totalOutflow:long",
+		"currency", "centrality:double", ":LABEL",
+	}}
+	for _, node := range nodes {
+		rows = append(rows, []string{
+			node.AccountID,
+			node.AccountName,
+			strconv.FormatInt(node.TotalInflow.Value, 10),
+			strconv.FormatInt(node.TotalOutflow.Value, 10),
+			string(node.TotalInflow.Currency),
+			strconv.FormatFloat(node.Centrality, 'f', -1, 64),
+			neo4jAccountLabel,
+		})
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write Neo4j nodes: %w", err)
+	}
+	return nil
+}
+
+// writeNeo4jRelationships writes the transfers between accounts with neo4j-admin
+// import headers
+func writeNeo4jRelationships(w io.Writer, edges []GraphEdge) error {
+	writer := csv.NewWriter(w)
+	rows := [][]string{{
+		":START_ID(" + neo4jAccountLabel + ")", ":END_ID(" + neo4jAccountLabel + ")", "totalAmount:long", "currency",
+		"transactionCount:int", "firstTransaction:datetime", "lastTransaction:datetime", "weight:double", ":TYPE",
+	}}
+	for _, edge := range edges {
+		rows = append(rows, []string{
+			edge.FromAccount,
+			edge.ToAccount,
+			strconv.FormatInt(edge.TotalAmount.Value, 10),
+			string(edge.TotalAmount.Currency),
+			strconv.Itoa(edge.TransactionCount),
+			graphTime(edge.FirstTransaction),
+			graphTime(edge.LastTransaction),
+			strconv.FormatFloat(edge.Weight, 'f', -1, 64),
+			neo4jTransferRelation,
+		})
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write Neo4j relationships: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package accounting
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionGraphExport(t *testing.T) {
+	date := func(day int) time.Time { return time.Date(2025, 1, day, 9, 0, 0, 0, time.UTC) }
+	usd := func(value int64) Amount { return Amount{Value: value, Currency: "USD"} }
+	graph := &TransactionGraph{
+		Nodes: []GraphNode{
+			{AccountID: "vendor", AccountName: `Smith & "Sons" <Ltd>`, TotalInflow: usd(100000), TotalOutflow: usd(0), Centrality: 0.5},
+			{AccountID: "revenue", AccountName: "Revenue", TotalInflow: usd(0), TotalOutflow: usd(750000), Centrality: 0.5},
+			{AccountID: "cash", AccountName: "Cash", TotalInflow: usd(750000), TotalOutflow: usd(100000), Centrality: 1},
+		},
+		Edges: []GraphEdge{
+			{FromAccount: "revenue", ToAccount: "cash", TotalAmount: usd(750000), TransactionCount: 2, FirstTransaction: date(2), LastTransaction: date(9), Weight: 0.88},
+			{FromAccount: "cash", ToAccount: "vendor", TotalAmount: usd(100000), TransactionCount: 1, FirstTransaction: date(15), LastTransaction: date(15), Weight: 0.12},
+		},
+	}
+
+	t.Run("GraphML", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, ExportTransactionGraph(&buf, ExportFormatGraphML, graph))
+
+		var document struct {
+			Graph struct {
+				EdgeDefault string `xml:"edgedefault,attr"`
+				Nodes       []struct {
+					ID   string `xml:"id,attr"`
+					Data []struct {
+						Key   string `xml:"key,attr"`
+						Value string `xml:",chardata"`
+					} `xml:"data"`
+				} `xml:"node"`
+				Edges []struct {
+					Source string `xml:"source,attr"`
+					Target string `xml:"target,attr"`
+				} `xml:"edge"`
+			} `xml:"graph"`
+		}
+		require.NoError(t, xml.Unmarshal(buf.Bytes(), &document), "the document is well-formed XML")
+		assert.Equal(t, "directed", document.Graph.EdgeDefault)
+		require.Len(t, document.Graph.Nodes, 3)
+		assert.Equal(t, "cash", document.Graph.Nodes[0].ID)
+		assert.Equal(t, "vendor", document.Graph.Nodes[2].ID)
+		assert.Equal(t, `Smith & "Sons" <Ltd>`, document.Graph.Nodes[2].Data[0].Value)
+		require.Len(t, document.Graph.Edges, 2)
+		assert.Equal(t, "cash", document.Graph.Edges[0].Source)
+		assert.Equal(t, "vendor", document.Graph.Edges[0].Target)
+		assert.Contains(t, buf.String(), `<data key="amount">750000</data>`)
+		assert.Contains(t, buf.String(), `<data key="first">2025-01-02T09:00:00Z</data>`)
+	})
+
+	t.Run("DOT", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, ExportTransactionGraph(&buf, ExportFormatDOT, graph))
+		dot := buf.String()
+
+		assert.True(t, strings.HasPrefix(dot, "digraph money_flow {"))
+		assert.True(t, strings.HasSuffix(dot, "}\n"))
+		assert.Contains(t, dot, `"vendor" [label="Smith & \"Sons\" <Ltd>\nin $1000.00 / out $0.00"];`)
+		assert.Contains(t, dot, `"revenue" -> "cash" [label="$7500.00 (2)", penwidth=5.00];`)
+		assert.Contains(t, dot, `"cash" -> "vendor" [label="$1000.00 (1)", penwidth=1.53];`)
+	})
+
+	t.Run("Neo4j CSV", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, ExportTransactionGraph(&buf, ExportFormatNeo4jCSV, graph))
+
+		archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		files := make(map[string][][]string)
+		for _, file := range archive.File {
+			reader, err := file.Open()
+			require.NoError(t, err)
+			content, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			reader.Close()
+			files[file.Name], err = csv.NewReader(bytes.NewReader(content)).ReadAll()
+			require.NoError(t, err)
+		}
+
+		accounts := files["accounts.csv"]
+		require.Len(t, accounts, 4)
+		assert.Equal(t, "accountId:ID(Account)", accounts[0][0])
+		assert.Equal(t, ":LABEL", accounts[0][6])
+		assert.Equal(t, []string{"cash", "Cash", "750000", "100000", "USD", "1"}, accounts[1][:6])
+		assert.Equal(t, `Smith & "Sons" <Ltd>`, accounts[3][1])
+
+		transfers := files["transfers.csv"]
+		require.Len(t, transfers, 3)
+		assert.Equal(t, []string{":START_ID(Account)", ":END_ID(Account)"}, transfers[0][:2])
+		assert.Equal(t, []string{"revenue", "cash", "750000", "USD", "2", "2025-01-02T09:00:00Z", "2025-01-09T09:00:00Z"}, transfers[2][:7])
+		assert.Equal(t, "TRANSFERRED_TO", transfers[2][8])
+
+		var nodes, relationships bytes.Buffer
+		require.NoError(t, ExportTransactionGraphNeo4j(&nodes, &relationships, graph))
+		assert.Equal(t, 4, strings.Count(nodes.String(), "\n"))
+		assert.Equal(t, 3, strings.Count(relationships.String(), "\n"))
+	})
+
+	t.Run("Unsupported Format", func(t *testing.T) {
+		var buf bytes.Buffer
+		assert.Error(t, ExportTransactionGraph(&buf, ExportFormatPDF, graph))
+	})
+
+	t.Run("Built Graph", func(t *testing.T) {
+		dbFile := "test_graph_export.db"
+		defer os.Remove(dbFile)
+
+		engine, err := NewAccountingEngine(dbFile)
+		require.NoError(t, err)
+		defer engine.Close()
+
+		userID := "forensic_auditor"
+		require.NoError(t, engine.CreateStandardAccounts(userID))
+		txn := &Transaction{
+			Description: "Cash sale",
+			ValidTime:   date(2),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: usd(500000)},
+				{AccountID: "revenue", Type: Credit, Amount: usd(500000)},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+
+		built, err := engine.GetForensicService().BuildTransactionGraph(date(1), date(31), "")
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, ExportTransactionGraph(&buf, ExportFormatDOT, built))
+		assert.Contains(t, buf.String(), `"revenue" -> "cash"`)
+	})
+}
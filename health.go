@@ -0,0 +1,111 @@
+package accounting
+
+import (
+	"fmt"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Health Checks
+// ----------------------------------------------------------------------------
+
+// HealthStatus is how well a part of the engine is doing
+type HealthStatus string
+
+const (
+	HealthHealthy   HealthStatus = "HEALTHY"
+	HealthDegraded  HealthStatus = "DEGRADED"  // working, but needs an operator's attention
+	HealthUnhealthy HealthStatus = "UNHEALTHY" // not working
+)
+
+// severity orders statuses from best to worst
+func (s HealthStatus) severity() int {
+	switch s {
+	case HealthDegraded:
+		return 1
+	case HealthUnhealthy:
+		return 2
+	}
+	return 0
+}
+
+// Thresholds past which a health check reports the engine degraded
+const (
+	// HealthMaxFreeRatio is the share of the file in free pages above which
+	// compaction is recommended
+	HealthMaxFreeRatio = 0.5
+	// HealthMaxEventBacklog is how many notification deliveries may be in flight
+	HealthMaxEventBacklog = 1000
+)
+
+// HealthCheckResult is the outcome of one check
+type HealthCheckResult struct {
+	Name    string       `json:"name"`
+	Status  HealthStatus `json:"status"`
+	Message string       `json:"message"`
+}
+
+// HealthReport is the outcome of a health check, with the diagnostics it was based on
+type HealthReport struct {
+	Status       HealthStatus         `json:"status"` // the worst of the checks
+	Checks       []*HealthCheckResult `json:"checks"`
+	Storage      *StorageStats        `json:"storage,omitempty"`
+	EventBacklog int                  `json:"event_backlog"` // notification deliveries still in flight
+	CheckedAt    time.Time            `json:"checked_at"`
+	Duration     time.Duration        `json:"duration"`
+}
+
+// add records a check's outcome, worsening the overall status if needed
+func (r *HealthReport) add(name string, status HealthStatus, message string) {
+	r.Checks = append(r.Checks, &HealthCheckResult{Name: name, Status: status, Message: message})
+	if status.severity() > r.Status.severity() {
+		r.Status = status
+	}
+}
+
+// HealthCheck reports whether storage answers reads and writes, how much of the file
+// compaction could reclaim, the notification backlog, and the background jobs
+func (ae *AccountingEngine) HealthCheck() *HealthReport {
+	started := time.Now()
+	report := &HealthReport{Status: HealthHealthy, CheckedAt: started}
+
+	stats, err := ae.storage.Stats()
+	if err != nil {
+		report.add("storage", HealthUnhealthy, err.Error())
+	} else {
+		report.Storage = stats
+		if err := ae.storage.ping(); err != nil {
+			report.add("storage", HealthUnhealthy, err.Error())
+		} else {
+			report.add("storage", HealthHealthy, fmt.Sprintf("%d bytes in %d buckets", stats.SizeBytes, len(stats.Buckets)))
+		}
+
+		if ratio := stats.FreeRatio(); ratio > HealthMaxFreeRatio {
+			report.add("free_space", HealthDegraded, fmt.Sprintf("%.0f%% of the file is free pages; compaction is recommended", ratio*100))
+		} else {
+			report.add("free_space", HealthHealthy, fmt.Sprintf("%.0f%% of the file is free pages", ratio*100))
+		}
+	}
+
+	report.EventBacklog = ae.notifications.Backlog()
+	if report.EventBacklog > HealthMaxEventBacklog {
+		report.add("event_backlog", HealthDegraded, fmt.Sprintf("%d notification deliveries in flight", report.EventBacklog))
+	} else {
+		report.add("event_backlog", HealthHealthy, fmt.Sprintf("%d notification deliveries in flight", report.EventBacklog))
+	}
+
+	runs, err := ae.consistency.GetRuns()
+	switch {
+	case err != nil:
+		report.add("consistency", HealthUnhealthy, err.Error())
+	case len(runs) == 0:
+		report.add("consistency", HealthHealthy, "no consistency checks run yet")
+	case !runs[0].Consistent():
+		report.add("consistency", HealthDegraded, fmt.Sprintf("last consistency check %s found %d issues", runs[0].ID, len(runs[0].Issues)))
+	default:
+		report.add("consistency", HealthHealthy, fmt.Sprintf("last consistency check %s left nothing unresolved", runs[0].ID))
+	}
+
+	report.Duration = time.Since(started)
+	return report
+}
@@ -0,0 +1,186 @@
+package accounting
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+// findHealthCheck returns a report's check by name, or nil
+func findHealthCheck(report *HealthReport, name string) *HealthCheckResult {
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	return nil
+}
+
+func TestHealthCheckAndStorageDiagnostics(t *testing.T) {
+	dbFile := "test_health.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "operator"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	sale := &Transaction{
+		Description: "Sale",
+		ValidTime:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		Entries: []Entry{
+			{AccountID: "cash", Type: Debit, Amount: Amount{Value: 10000, Currency: "USD"}},
+			{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 10000, Currency: "USD"}},
+		},
+	}
+	require.NoError(t, engine.CreateTransaction(sale, userID))
+	require.NoError(t, engine.PostTransaction(sale.ID, userID))
+
+	report := engine.HealthCheck()
+	assert.Equal(t, HealthHealthy, report.Status)
+	for _, name := range []string{"storage", "free_space", "event_backlog", "consistency"} {
+		check := findHealthCheck(report, name)
+		require.NotNil(t, check, name)
+		assert.Equal(t, HealthHealthy, check.Status, name)
+	}
+	assert.Zero(t, report.EventBacklog)
+
+	stats := report.Storage
+	require.NotNil(t, stats)
+	assert.Equal(t, dbFile, stats.Path)
+	assert.Positive(t, stats.SizeBytes)
+	assert.Positive(t, stats.PageSize)
+	assert.Positive(t, stats.Events)
+	assert.Nil(t, stats.LastCompaction)
+	keys := make(map[string]int)
+	for _, bucket := range stats.Buckets {
+		keys[bucket.Name] = bucket.Keys
+	}
+	assert.Equal(t, 1, keys[string(BucketTransactions)])
+	assert.Equal(t, 2, keys[string(BucketEntries)])
+	assert.Equal(t, stats.Events, keys[string(BucketEvents)])
+
+	// Fill the file with scratch data and delete it, leaving mostly free pages
+	storage := engine.GetStorage()
+	scratch := bytes.Repeat([]byte("x"), 64<<10)
+	for batch := 0; batch < 8; batch++ {
+		require.NoError(t, storage.db.Update(func(tx *bbolt.Tx) error {
+			for i := 0; i < 16; i++ {
+				if err := tx.Bucket(BucketStorageMeta).Put([]byte(fmt.Sprintf("scratch-%d-%d", batch, i)), scratch); err != nil {
+					return err
+				}
+			}
+			return nil
+		}))
+	}
+	require.NoError(t, storage.db.Update(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(BucketStorageMeta).Cursor()
+		for k, _ := c.Seek([]byte("scratch-")); k != nil && bytes.HasPrefix(k, []byte("scratch-")); k, _ = c.Seek([]byte("scratch-")) {
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	report = engine.HealthCheck()
+	assert.Equal(t, HealthDegraded, report.Status)
+	assert.Equal(t, HealthDegraded, findHealthCheck(report, "free_space").Status)
+	assert.Contains(t, findHealthCheck(report, "free_space").Message, "compaction is recommended")
+
+	t.Run("compaction gives back free pages while operations wait", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan error, 4)
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 20; j++ {
+					if _, err := engine.GetAccountBalance("cash", sale.ValidTime); err != nil {
+						errs <- err
+						return
+					}
+				}
+			}()
+		}
+		result, err := engine.CompactStorage()
+		wg.Wait()
+		close(errs)
+		require.NoError(t, err)
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+		assert.Positive(t, result.Reclaimed())
+		assert.Less(t, result.SizeAfter, result.SizeBefore)
+
+		stats, err := engine.GetStorageStats()
+		require.NoError(t, err)
+		assert.Equal(t, result.SizeAfter, stats.SizeBytes)
+		require.NotNil(t, stats.LastCompaction)
+		assert.WithinDuration(t, result.CompactedAt, *stats.LastCompaction, time.Millisecond)
+		_, err = os.Stat(dbFile + ".compact")
+		assert.True(t, os.IsNotExist(err))
+
+		balance, err := engine.GetAccountBalance("cash", sale.ValidTime)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10000), balance.Balance.Value)
+		require.NoError(t, engine.CreateTransaction(&Transaction{
+			Description: "Sale after compaction",
+			ValidTime:   sale.ValidTime,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: 500, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 500, Currency: "USD"}},
+			},
+		}, userID))
+
+		assert.Equal(t, HealthHealthy, findHealthCheck(engine.HealthCheck(), "free_space").Status)
+	})
+
+	t.Run("sync settings wait for compaction", func(t *testing.T) {
+		var wg sync.WaitGroup
+		errs := make(chan error, 2)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				if err := engine.storage.SetNoSync(j%2 == 0); err != nil {
+					errs <- err
+					return
+				}
+				if err := engine.storage.Sync(); err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+		_, err := engine.CompactStorage()
+		wg.Wait()
+		close(errs)
+		require.NoError(t, err)
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+		assert.False(t, engine.storage.db.DB.NoSync, "the last setting survives the swap")
+		assert.Equal(t, dbFile, engine.storage.db.path())
+	})
+
+	t.Run("inconsistencies degrade health", func(t *testing.T) {
+		require.NoError(t, storage.SaveComplianceViolation(&ComplianceViolation{
+			ID: "violation-1", RuleID: "rule-1", TransactionID: "txn-purged", Status: "OPEN", DetectedAt: time.Now(),
+		}))
+		_, err := engine.CheckConsistency(false, userID)
+		require.NoError(t, err)
+
+		report := engine.HealthCheck()
+		assert.Equal(t, HealthDegraded, report.Status)
+		assert.Contains(t, findHealthCheck(report, "consistency").Message, "found 1 issues")
+	})
+}
@@ -0,0 +1,130 @@
+package accounting
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DefaultIdempotencyTTL is how long an idempotency key is remembered
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// IdempotencyRecord remembers the transaction created with an idempotency key
+type IdempotencyRecord struct {
+	Key           string    `json:"key"`
+	TransactionID string    `json:"transaction_id"`
+	Fingerprint   string    `json:"fingerprint"` // of the request, to catch a key reused for a different one
+	UserID        string    `json:"user_id"`
+	CreatedAt     time.Time `json:"created_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// transactionFingerprint hashes what a caller sends to create a transaction, leaving
+// out the IDs and timestamps the engine assigns
+func transactionFingerprint(txn *Transaction, userID string) (string, error) {
+	type entryRequest struct {
+		AccountID  string      `json:"account_id"`
+		Type       EntryType   `json:"type"`
+		Value      int64       `json:"value"`
+		Currency   Currency    `json:"currency"`
+		Dimensions []Dimension `json:"dimensions,omitempty"`
+		Memo       string      `json:"memo,omitempty"`
+		Reference  string      `json:"reference,omitempty"`
+		Tags       []string    `json:"tags,omitempty"`
+	}
+	request := struct {
+		UserID      string         `json:"user_id"`
+		Description string         `json:"description"`
+		ValidTime   time.Time      `json:"valid_time"`
+		LedgerID    string         `json:"ledger_id,omitempty"`
+		SourceRef   string         `json:"source_ref,omitempty"`
+		Entries     []entryRequest `json:"entries"`
+	}{
+		UserID:      userID,
+		Description: txn.Description,
+		ValidTime:   txn.ValidTime.UTC(),
+		LedgerID:    txn.LedgerID,
+		SourceRef:   txn.SourceRef,
+	}
+	for _, entry := range txn.Entries {
+		request.Entries = append(request.Entries, entryRequest{
+			AccountID:  entry.AccountID,
+			Type:       entry.Type,
+			Value:      entry.Amount.Value,
+			Currency:   entry.Amount.Currency,
+			Dimensions: entry.Dimensions,
+			Memo:       entry.Memo,
+			Reference:  entry.Reference,
+			Tags:       normalizeTags(entry.Tags),
+		})
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint transaction: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// createTransactionIdempotent creates a transaction unless its idempotency key was
+// used within the key's lifetime, in which case txn is filled with the transaction
+// first created with it. Reusing a key for a different request is an error.
+func (ae *AccountingEngine) createTransactionIdempotent(txn *Transaction, userID string) error {
+	fingerprint, err := transactionFingerprint(txn, userID)
+	if err != nil {
+		return err
+	}
+
+	// Hold the lock across the lookup and the create, so concurrent retries of one
+	// request create it once
+	ae.idempotencyMu.Lock()
+	defer ae.idempotencyMu.Unlock()
+
+	now := time.Now()
+	record, err := ae.storage.GetIdempotencyRecord(txn.IdempotencyKey)
+	if err != nil {
+		return err
+	}
+	if record != nil && record.ExpiresAt.After(now) {
+		if record.Fingerprint != fingerprint {
+			return classify(ErrConflict, "idempotency key %s was already used for a different transaction", txn.IdempotencyKey)
+		}
+		original, err := ae.storage.GetTransaction(record.TransactionID)
+		if err != nil {
+			return fmt.Errorf("failed to get transaction for idempotency key %s: %w", txn.IdempotencyKey, err)
+		}
+		*txn = *original
+		ae.logger.Debug("transaction creation replayed", LogKeyTransactionID, txn.ID, LogKeyUserID, userID)
+		return nil
+	}
+
+	return ae.createTransaction(txn, userID, &IdempotencyRecord{
+		Key:         txn.IdempotencyKey,
+		Fingerprint: fingerprint,
+		UserID:      userID,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ae.idempotencyTTL),
+	})
+}
+
+// SetIdempotencyTTL sets how long idempotency keys are remembered; zero restores
+// the default. Keys already used keep the lifetime they were given.
+func (ae *AccountingEngine) SetIdempotencyTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	ae.idempotencyMu.Lock()
+	defer ae.idempotencyMu.Unlock()
+	ae.idempotencyTTL = ttl
+}
+
+// PurgeExpiredIdempotencyKeys forgets the idempotency keys past their lifetime,
+// returning how many were removed. Expired keys are already ignored; purging only
+// reclaims their space.
+func (ae *AccountingEngine) PurgeExpiredIdempotencyKeys() (int, error) {
+	ae.idempotencyMu.Lock()
+	defer ae.idempotencyMu.Unlock()
+	return ae.storage.DeleteExpiredIdempotencyRecords(time.Now())
+}
@@ -0,0 +1,135 @@
+package accounting
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotentTransactionCreation(t *testing.T) {
+	// Setup
+	dbFile := "test_idempotency.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "api"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	validTime := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	request := func(key string, value int64) *Transaction {
+		return &Transaction{
+			Description:    "Card payment",
+			ValidTime:      validTime,
+			IdempotencyKey: key,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+	}
+	count := func() int {
+		txns, err := engine.GetStorage().GetAllTransactions()
+		require.NoError(t, err)
+		return len(txns)
+	}
+
+	t.Run("Replay Returns Original", func(t *testing.T) {
+		first := request("pay-1", 2500)
+		require.NoError(t, engine.CreateTransaction(first, userID))
+		require.NoError(t, engine.PostTransaction(first.ID, userID))
+		before := count()
+
+		retry := request("pay-1", 2500)
+		require.NoError(t, engine.CreateTransaction(retry, userID))
+		assert.Equal(t, first.ID, retry.ID)
+		assert.Equal(t, Posted, retry.Status, "the replay reflects the transaction as it is now")
+		assert.Equal(t, "pay-1", retry.IdempotencyKey)
+		assert.Equal(t, before, count())
+	})
+
+	t.Run("Key Reused For Different Request", func(t *testing.T) {
+		err := engine.CreateTransaction(request("pay-1", 9900), userID)
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.ErrorContains(t, err, "already used for a different transaction")
+
+		err = engine.CreateTransaction(request("pay-1", 2500), "another-client")
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.ErrorContains(t, err, "already used for a different transaction")
+	})
+
+	t.Run("Concurrent Retries", func(t *testing.T) {
+		before := count()
+		ids := make([]string, 8)
+		var wg sync.WaitGroup
+		for i := range ids {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				txn := request("pay-2", 700)
+				if assert.NoError(t, engine.CreateTransaction(txn, userID)) {
+					ids[i] = txn.ID
+				}
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, before+1, count())
+		for _, id := range ids {
+			assert.Equal(t, ids[0], id)
+		}
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		engine.SetIdempotencyTTL(time.Millisecond)
+		defer engine.SetIdempotencyTTL(0)
+
+		first := request("pay-3", 100)
+		require.NoError(t, engine.CreateTransaction(first, userID))
+		time.Sleep(5 * time.Millisecond)
+
+		later := request("pay-3", 100)
+		require.NoError(t, engine.CreateTransaction(later, userID))
+		assert.NotEqual(t, first.ID, later.ID, "an expired key is forgotten")
+
+		time.Sleep(5 * time.Millisecond)
+		purged, err := engine.PurgeExpiredIdempotencyKeys()
+		require.NoError(t, err)
+		assert.Equal(t, 1, purged)
+
+		record, err := engine.GetStorage().GetIdempotencyRecord("pay-3")
+		require.NoError(t, err)
+		assert.Nil(t, record)
+		record, err = engine.GetStorage().GetIdempotencyRecord("pay-1")
+		require.NoError(t, err)
+		assert.NotNil(t, record, "live keys are kept")
+	})
+
+	t.Run("Saved With Its Transaction", func(t *testing.T) {
+		txn := request("pay-4", 300)
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		record, err := engine.GetStorage().GetIdempotencyRecord("pay-4")
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		assert.Equal(t, txn.ID, record.TransactionID)
+
+		// A key that cannot be recorded leaves no transaction behind
+		orphan := &Transaction{ID: "orphan", Status: Pending, ValidTime: validTime, IdempotencyKey: "pay-5"}
+		err = engine.GetStorage().SaveIdempotentTransaction(orphan, &IdempotencyRecord{TransactionID: orphan.ID})
+		require.Error(t, err)
+		_, err = engine.GetStorage().GetTransaction(orphan.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Without Key", func(t *testing.T) {
+		before := count()
+		require.NoError(t, engine.CreateTransaction(request("", 100), userID))
+		require.NoError(t, engine.CreateTransaction(request("", 100), userID))
+		assert.Equal(t, before+2, count())
+	})
+}
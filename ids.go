@@ -0,0 +1,162 @@
+package accounting
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"go.etcd.io/bbolt"
+)
+
+// maxExternalIDLength bounds caller-supplied IDs so they stay usable as storage keys
+const maxExternalIDLength = 128
+
+// IDGenerator produces IDs for new records
+type IDGenerator interface {
+	NewID() string
+}
+
+// UUIDGenerator generates random version 4 UUIDs. It is the default generator.
+type UUIDGenerator struct{}
+
+// NewID returns a new UUID string
+func (UUIDGenerator) NewID() string {
+	return uuid.New().String()
+}
+
+// ULIDGenerator generates ULIDs: 26-character Crockford base32 IDs that sort by
+// creation time, so records iterate in the order they were created. IDs generated
+// within the same millisecond increase monotonically.
+type ULIDGenerator struct {
+	mu      sync.Mutex
+	lastMs  uint64
+	lastHi  uint16 // top 16 bits of the 80-bit random part
+	lastLow uint64 // bottom 64 bits of the random part
+}
+
+// NewULIDGenerator creates a new ULID generator
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{}
+}
+
+// NewID returns a new ULID string
+func (g *ULIDGenerator) NewID() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	ms := uint64(time.Now().UnixMilli())
+	if ms <= g.lastMs {
+		// Same millisecond or a clock step back: increment the random part instead
+		ms = g.lastMs
+		g.lastLow++
+		if g.lastLow == 0 {
+			g.lastHi++
+		}
+	} else {
+		var random [10]byte
+		if _, err := rand.Read(random[:]); err != nil {
+			panic(fmt.Sprintf("failed to read random bytes: %v", err))
+		}
+		g.lastMs = ms
+		g.lastHi = binary.BigEndian.Uint16(random[:2])
+		g.lastLow = binary.BigEndian.Uint64(random[2:])
+	}
+
+	var id [16]byte
+	id[0] = byte(ms >> 40)
+	id[1] = byte(ms >> 32)
+	binary.BigEndian.PutUint32(id[2:], uint32(ms))
+	binary.BigEndian.PutUint16(id[6:], g.lastHi)
+	binary.BigEndian.PutUint64(id[8:], g.lastLow)
+	return encodeULID(id)
+}
+
+// crockfordAlphabet is the base32 alphabet used by ULIDs
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// encodeULID encodes 128 bits as 26 base32 characters, most significant first
+func encodeULID(id [16]byte) string {
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+var (
+	idGeneratorMu sync.RWMutex
+	idGenerator   IDGenerator = UUIDGenerator{}
+)
+
+// SetIDGenerator replaces the generator used for new record IDs. It applies to every
+// engine in the process, so set it once at startup. A nil generator restores UUIDs.
+func SetIDGenerator(generator IDGenerator) {
+	idGeneratorMu.Lock()
+	defer idGeneratorMu.Unlock()
+	if generator == nil {
+		generator = UUIDGenerator{}
+	}
+	idGenerator = generator
+}
+
+// newID generates an ID with the configured generator
+func newID() string {
+	idGeneratorMu.RLock()
+	defer idGeneratorMu.RUnlock()
+	return idGenerator.NewID()
+}
+
+// ValidateExternalID checks that a caller-supplied ID can be used as a record key:
+// non-empty, at most 128 characters and free of whitespace and control characters
+func ValidateExternalID(id string) error {
+	if id == "" {
+		return fmt.Errorf("ID is empty")
+	}
+	if len(id) > maxExternalIDLength {
+		return fmt.Errorf("ID %q is longer than %d characters", id, maxExternalIDLength)
+	}
+	for _, r := range id {
+		if unicode.IsSpace(r) || unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return fmt.Errorf("ID %q contains invalid characters", id)
+		}
+	}
+	return nil
+}
+
+// assignID generates an ID when the caller did not supply one. A supplied ID is kept,
+// so records round-trip with the system that issued it, but it must be valid and not
+// already stored in the bucket.
+func (s *Storage) assignID(id *string, kind string, bucket []byte) error {
+	if *id == "" {
+		*id = newID()
+		return nil
+	}
+	if err := ValidateExternalID(*id); err != nil {
+		return fmt.Errorf("invalid %s ID: %w", kind, err)
+	}
+	if s.HasKey(bucket, *id) {
+		return fmt.Errorf("%s ID already exists: %s", kind, *id)
+	}
+	return nil
+}
+
+// HasKey reports whether a record is stored under the key in a bucket
+func (s *Storage) HasKey(bucket []byte, key string) bool {
+	found := false
+	s.db.View(func(tx *bbolt.Tx) error {
+		if b := tx.Bucket(bucket); b != nil {
+			found = b.Get([]byte(key)) != nil
+		}
+		return nil
+	})
+	return found
+}
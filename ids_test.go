@@ -0,0 +1,113 @@
+package accounting
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDGeneration(t *testing.T) {
+	t.Run("ULIDs sort by creation", func(t *testing.T) {
+		generator := NewULIDGenerator()
+		ids := make([]string, 1000)
+		for i := range ids {
+			ids[i] = generator.NewID()
+			assert.Len(t, ids[i], 26)
+		}
+		assert.True(t, sort.StringsAreSorted(ids))
+
+		seen := make(map[string]bool)
+		for _, id := range ids {
+			assert.False(t, seen[id], "duplicate ULID %s", id)
+			seen[id] = true
+		}
+	})
+
+	t.Run("validates external IDs", func(t *testing.T) {
+		assert.NoError(t, ValidateExternalID("INV-2024/0001"))
+		assert.Error(t, ValidateExternalID(""))
+		assert.Error(t, ValidateExternalID("has space"))
+		assert.Error(t, ValidateExternalID(strings.Repeat("x", 129)))
+	})
+
+	dbFile := "test_ids.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	payment := func(id string, entryIDs ...string) *Transaction {
+		txn := &Transaction{
+			ID:          id,
+			Description: "Customer payment",
+			ValidTime:   time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: 2500, Currency: "USD"}},
+				{AccountID: "accounts_receivable", Type: Credit, Amount: Amount{Value: 2500, Currency: "USD"}},
+			},
+		}
+		for i, entryID := range entryIDs {
+			txn.Entries[i].ID = entryID
+		}
+		return txn
+	}
+
+	t.Run("keeps caller-supplied IDs", func(t *testing.T) {
+		require.NoError(t, engine.CreateTransaction(payment("erp-1001", "erp-1001-1", "erp-1001-2"), userID))
+		require.NoError(t, engine.PostTransaction("erp-1001", userID))
+
+		txn, err := engine.GetStorage().GetTransaction("erp-1001")
+		require.NoError(t, err)
+		assert.Equal(t, "erp-1001-1", txn.Entries[0].ID)
+
+		err = engine.CreateTransaction(payment("erp-1001"), userID)
+		assert.ErrorContains(t, err, "transaction ID already exists")
+
+		err = engine.CreateTransaction(payment("erp-1002", "erp-1001-1"), userID)
+		assert.ErrorContains(t, err, "entry ID already exists")
+
+		err = engine.CreateTransaction(payment("erp-1003", "line", "line"), userID)
+		assert.ErrorContains(t, err, "repeated")
+
+		err = engine.CreateAccount(&Account{ID: "cash", Code: "1999", Name: "Cash Again", Type: Asset}, userID)
+		assert.ErrorContains(t, err, "account ID already exists")
+
+		require.NoError(t, engine.CreateCustomer(&Customer{ID: "CUST-7", Name: "Acme"}, userID))
+		err = engine.CreateCustomer(&Customer{ID: "CUST-7", Name: "Acme Again"}, userID)
+		assert.Error(t, err)
+	})
+
+	t.Run("uses the configured generator", func(t *testing.T) {
+		SetIDGenerator(NewULIDGenerator())
+		defer SetIDGenerator(nil)
+
+		first, second := payment(""), payment("")
+		require.NoError(t, engine.CreateTransaction(first, userID))
+		require.NoError(t, engine.CreateTransaction(second, userID))
+		assert.Len(t, first.ID, 26)
+		assert.Less(t, first.ID, second.ID)
+	})
+
+	t.Run("imports keep source IDs", func(t *testing.T) {
+		csv := "id,ref,date,account_id,type,amount,currency\n" +
+			"src-1,I-1,2024-04-03,cash,DEBIT,5.00,USD\n" +
+			"src-1,I-1,2024-04-03,revenue,CREDIT,5.00,USD\n" +
+			"erp-1001,I-2,2024-04-03,cash,DEBIT,5.00,USD\n" +
+			"erp-1001,I-2,2024-04-03,revenue,CREDIT,5.00,USD\n"
+		result, err := engine.ImportTransactions(strings.NewReader(csv), ImportFormatCSV, ImportOptions{}, userID)
+		require.NoError(t, err)
+		require.Len(t, result.Accepted, 1)
+		assert.Equal(t, "src-1", result.Accepted[0].TransactionID)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, "DUPLICATE_ID", result.Errors[0].Code)
+	})
+}
@@ -0,0 +1,543 @@
+package accounting
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Import Structures
+// ----------------------------------------------------------------------------
+
+// ImportFormat is the layout of a transaction import file
+type ImportFormat string
+
+const (
+	// ImportFormatCSV has one entry per row with a header naming the columns ref,
+	// date, description, account_id, type, amount, currency and dimensions, plus an
+	// optional id column. Consecutive rows sharing a ref form one transaction.
+	ImportFormatCSV ImportFormat = "CSV"
+	// ImportFormatJSONLines has one transaction object per line
+	ImportFormatJSONLines ImportFormat = "JSONL"
+)
+
+// ImportOptions controls how a batch is committed
+type ImportOptions struct {
+	// AllOrNothing imports nothing unless every transaction in the batch is valid
+	AllOrNothing bool `json:"all_or_nothing"`
+}
+
+// ImportRowError explains why a transaction in the file was not imported
+type ImportRowError struct {
+	Row     int    `json:"row"` // 1-based line of the transaction's first row, after any header
+	Ref     string `json:"ref,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ImportedTransaction is a transaction accepted from the file
+type ImportedTransaction struct {
+	Row           int               `json:"row"`
+	Ref           string            `json:"ref"`
+	TransactionID string            `json:"transaction_id"`
+	Status        TransactionStatus `json:"status"` // POSTED, or PENDING_APPROVAL above the approval threshold
+	Warnings      []string          `json:"warnings,omitempty"`
+}
+
+// ImportResult reports the outcome of a batch import
+type ImportResult struct {
+	ID                string                `json:"id"`
+	Format            ImportFormat          `json:"format"`
+	AllOrNothing      bool                  `json:"all_or_nothing"`
+	TotalTransactions int                   `json:"total_transactions"`
+	Accepted          []ImportedTransaction `json:"accepted"`
+	Errors            []ImportRowError      `json:"errors"`
+	Committed         bool                  `json:"committed"` // false when an all-or-nothing batch was rejected
+	ImportedBy        string                `json:"imported_by"`
+	ImportedAt        time.Time             `json:"imported_at"`
+}
+
+// importLine is one entry in JSON lines and CSV input
+type importLine struct {
+	AccountID  string            `json:"account_id"`
+	Type       string            `json:"type"`
+	Amount     json.Number       `json:"amount"` // major units, e.g. "125.50"
+	Currency   string            `json:"currency"`
+	Dimensions map[string]string `json:"dimensions,omitempty"`
+}
+
+// importRecord is one transaction in JSON lines input
+type importRecord struct {
+	ID          string       `json:"id,omitempty"` // transaction ID from the source system, kept as is
+	Ref         string       `json:"ref"`
+	Date        string       `json:"date"`
+	Description string       `json:"description"`
+	Entries     []importLine `json:"entries"`
+}
+
+// importCandidate is a parsed transaction awaiting validation
+type importCandidate struct {
+	row    int
+	record importRecord
+}
+
+// ----------------------------------------------------------------------------
+// Import Service
+// ----------------------------------------------------------------------------
+
+// ImportService loads transactions in bulk from CSV or JSON lines files
+type ImportService struct {
+	storage                *Storage
+	eventStore             *EventStore
+	postingEngine          *PostingEngine
+	complianceService      *ComplianceService
+	journalApprovalService *JournalApprovalService
+	dimensionService       *DimensionService
+}
+
+// NewImportService creates a new import service
+func NewImportService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine, complianceService *ComplianceService, journalApprovalService *JournalApprovalService, dimensionService *DimensionService) *ImportService {
+	return &ImportService{
+		storage:                storage,
+		eventStore:             eventStore,
+		postingEngine:          postingEngine,
+		complianceService:      complianceService,
+		journalApprovalService: journalApprovalService,
+		dimensionService:       dimensionService,
+	}
+}
+
+// ImportTransactions validates every transaction in the input, checking it balances,
+// its accounts exist, its period is open, its dimensions pass the registry, the ref has
+// not been imported before and no ERROR-severity compliance rule is violated, then posts
+// the valid ones. Posting goes through the journal approval threshold. In all-or-nothing
+// mode nothing is imported if any transaction fails, and transactions already committed
+// are rolled back if a later one cannot be.
+func (is *ImportService) ImportTransactions(reader io.Reader, format ImportFormat, options ImportOptions, userID string) (*ImportResult, error) {
+	var candidates []importCandidate
+	var parseErrors []ImportRowError
+	var err error
+	switch format {
+	case ImportFormatCSV:
+		candidates, parseErrors, err = parseImportCSV(reader)
+	case ImportFormatJSONLines:
+		candidates, parseErrors, err = parseImportJSONLines(reader)
+	default:
+		return nil, fmt.Errorf("unsupported import format: %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import: %w", err)
+	}
+
+	result := &ImportResult{
+		ID:                newID(),
+		Format:            format,
+		AllOrNothing:      options.AllOrNothing,
+		TotalTransactions: len(candidates) + len(parseErrors),
+		Errors:            parseErrors,
+		ImportedBy:        userID,
+		ImportedAt:        time.Now(),
+	}
+
+	type validated struct {
+		candidate importCandidate
+		txn       *Transaction
+		warnings  []string
+	}
+	var valid []validated
+	seen := make(map[string]bool)
+	seenIDs := make(map[string]bool)
+	for _, candidate := range candidates {
+		txn, rowErr := is.buildTransaction(candidate, userID)
+		if rowErr == nil && seen[candidate.record.Ref] {
+			rowErr = &ImportRowError{Code: "DUPLICATE_REF", Message: "ref appears more than once in the file"}
+		}
+		if rowErr == nil && candidate.record.ID != "" && seenIDs[candidate.record.ID] {
+			rowErr = &ImportRowError{Code: "DUPLICATE_ID", Message: "transaction ID appears more than once in the file"}
+		}
+		if rowErr == nil {
+			rowErr = is.checkAlreadyImported(txn)
+		}
+		var warnings []string
+		if rowErr == nil {
+			warnings, rowErr = is.validate(txn)
+		}
+		seen[candidate.record.Ref] = true
+		if candidate.record.ID != "" {
+			seenIDs[candidate.record.ID] = true
+		}
+		if rowErr != nil {
+			rowErr.Row = candidate.row
+			rowErr.Ref = candidate.record.Ref
+			result.Errors = append(result.Errors, *rowErr)
+			continue
+		}
+		valid = append(valid, validated{candidate: candidate, txn: txn, warnings: warnings})
+	}
+
+	if options.AllOrNothing && len(result.Errors) > 0 {
+		return result, is.recordImport(result, userID)
+	}
+
+	for _, v := range valid {
+		status, err := is.commit(v.txn, userID)
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{
+				Row:     v.candidate.row,
+				Ref:     v.candidate.record.Ref,
+				Code:    "COMMIT_FAILED",
+				Message: err.Error(),
+			})
+			if options.AllOrNothing {
+				if rollbackErr := is.rollback(result.Accepted, userID); rollbackErr != nil {
+					return result, fmt.Errorf("failed to roll back import: %w", rollbackErr)
+				}
+				result.Accepted = nil
+				return result, is.recordImport(result, userID)
+			}
+			continue
+		}
+		result.Accepted = append(result.Accepted, ImportedTransaction{
+			Row:           v.candidate.row,
+			Ref:           v.candidate.record.Ref,
+			TransactionID: v.txn.ID,
+			Status:        status,
+			Warnings:      v.warnings,
+		})
+	}
+
+	result.Committed = true
+	return result, is.recordImport(result, userID)
+}
+
+// buildTransaction converts a parsed record to a pending transaction
+func (is *ImportService) buildTransaction(candidate importCandidate, userID string) (*Transaction, *ImportRowError) {
+	record := candidate.record
+	if record.Ref == "" {
+		return nil, &ImportRowError{Code: "MISSING_REF", Message: "ref is required"}
+	}
+	validTime, err := parseImportDate(record.Date)
+	if err != nil {
+		return nil, &ImportRowError{Code: "INVALID_DATE", Message: err.Error()}
+	}
+	if len(record.Entries) < 2 {
+		return nil, &ImportRowError{Code: "TOO_FEW_ENTRIES", Message: "a transaction needs at least two entries"}
+	}
+
+	txnID := record.ID
+	if txnID == "" {
+		txnID = newID()
+	} else if err := ValidateExternalID(txnID); err != nil {
+		return nil, &ImportRowError{Code: "INVALID_ID", Message: err.Error()}
+	} else if is.storage.HasKey(BucketTransactions, txnID) {
+		return nil, &ImportRowError{Code: "DUPLICATE_ID", Message: fmt.Sprintf("transaction ID already exists: %s", txnID)}
+	}
+
+	now := time.Now()
+	txn := &Transaction{
+		ID:              txnID,
+		Description:     record.Description,
+		ValidTime:       validTime,
+		TransactionTime: now,
+		Status:          Pending,
+		SourceRef:       importSourceRef(record.Ref),
+		UserID:          userID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	for i, line := range record.Entries {
+		entryType := EntryType(strings.ToUpper(strings.TrimSpace(line.Type)))
+		if entryType != Debit && entryType != Credit {
+			return nil, &ImportRowError{Code: "INVALID_ENTRY_TYPE", Message: fmt.Sprintf("entry %d: type must be DEBIT or CREDIT, got %q", i+1, line.Type)}
+		}
+		currency := Currency(strings.ToUpper(strings.TrimSpace(line.Currency)))
+		if currency == "" {
+			return nil, &ImportRowError{Code: "INVALID_AMOUNT", Message: fmt.Sprintf("entry %d: currency is required", i+1)}
+		}
+		value, err := parseImportAmount(string(line.Amount), currency)
+		if err != nil {
+			return nil, &ImportRowError{Code: "INVALID_AMOUNT", Message: fmt.Sprintf("entry %d: %v", i+1, err)}
+		}
+
+		var dimensions []Dimension
+		for key, val := range line.Dimensions {
+			dimensions = append(dimensions, Dimension{Key: DimensionKey(key), Value: val})
+		}
+		txn.Entries = append(txn.Entries, Entry{
+			ID:            newID(),
+			TransactionID: txn.ID,
+			AccountID:     strings.TrimSpace(line.AccountID),
+			Type:          entryType,
+			Amount:        Amount{Value: value, Currency: currency},
+			Dimensions:    dimensions,
+		})
+	}
+	return txn, nil
+}
+
+// checkAlreadyImported rejects a ref imported by an earlier batch
+func (is *ImportService) checkAlreadyImported(txn *Transaction) *ImportRowError {
+	existing, err := is.storage.GetTransactionsByDateRange("", txn.ValidTime, txn.ValidTime)
+	if err != nil {
+		return &ImportRowError{Code: "LOOKUP_FAILED", Message: err.Error()}
+	}
+	for _, other := range existing {
+		if other.SourceRef == txn.SourceRef {
+			return &ImportRowError{Code: "ALREADY_IMPORTED", Message: fmt.Sprintf("ref was imported as transaction %s", other.ID)}
+		}
+	}
+	return nil
+}
+
+// validate applies posting and compliance checks. ERROR-severity compliance
+// violations reject the transaction; lesser ones are returned as warnings.
+func (is *ImportService) validate(txn *Transaction) ([]string, *ImportRowError) {
+	validation := is.postingEngine.ValidateTransaction(txn)
+	if !validation.Valid {
+		first := validation.Errors[0]
+		messages := make([]string, len(validation.Errors))
+		for i, e := range validation.Errors {
+			messages[i] = e.Message
+		}
+		return nil, &ImportRowError{Code: first.Code, Message: strings.Join(messages, "; ")}
+	}
+
+	if err := is.dimensionService.ValidateTransaction(txn); err != nil {
+		return nil, &ImportRowError{Code: "INVALID_DIMENSION", Message: err.Error()}
+	}
+
+	violations, err := is.complianceService.ValidateTransaction(*txn)
+	if err != nil {
+		return nil, &ImportRowError{Code: "COMPLIANCE_CHECK_FAILED", Message: err.Error()}
+	}
+	var warnings []string
+	for _, violation := range violations {
+		if violation.Severity == "ERROR" {
+			return nil, &ImportRowError{Code: "COMPLIANCE_VIOLATION", Message: violation.Description}
+		}
+		warnings = append(warnings, violation.Description)
+	}
+	return warnings, nil
+}
+
+// commit records and saves a validated transaction, then posts it subject to approval
+func (is *ImportService) commit(txn *Transaction, userID string) (TransactionStatus, error) {
+	_, err := is.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to create transaction event: %w", err)
+	}
+	if err := is.storage.SaveTransaction(txn); err != nil {
+		return "", fmt.Errorf("failed to save transaction: %w", err)
+	}
+	if _, err := is.journalApprovalService.Post(txn, userID); err != nil {
+		return "", err
+	}
+	return txn.Status, nil
+}
+
+// rollback undoes an all-or-nothing batch that failed part way: posted transactions
+// are reversed and those held for approval are withdrawn
+func (is *ImportService) rollback(accepted []ImportedTransaction, userID string) error {
+	for _, imported := range accepted {
+		if imported.Status == PendingApproval {
+			if err := is.journalApprovalService.Withdraw(imported.TransactionID, "import rolled back", userID); err != nil {
+				return err
+			}
+			continue
+		}
+		description := fmt.Sprintf("Rollback of import ref %s", imported.Ref)
+		if _, err := is.postingEngine.ReverseTransaction(imported.TransactionID, description, userID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordImport writes the batch outcome to the event store
+func (is *ImportService) recordImport(result *ImportResult, userID string) error {
+	_, err := is.eventStore.CreateEvent(EventImportTransactions, result, result.ImportedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create import event: %w", err)
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Import Parsing
+// ----------------------------------------------------------------------------
+
+// parseImportCSV groups consecutive rows sharing a ref into transactions. Rows
+// that cannot be read are reported against their ref and the group is dropped.
+func parseImportCSV(reader io.Reader) ([]importCandidate, []ImportRowError, error) {
+	r := csv.NewReader(reader)
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"ref", "date", "account_id", "type", "amount", "currency"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("missing column %q", required)
+		}
+	}
+	field := func(row []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var candidates []importCandidate
+	var rowErrors []ImportRowError
+	var current *importCandidate
+	var currentErr *ImportRowError
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if currentErr != nil {
+			rowErrors = append(rowErrors, *currentErr)
+		} else {
+			candidates = append(candidates, *current)
+		}
+		current, currentErr = nil, nil
+	}
+
+	for rowNum := 1; ; rowNum++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if _, ok := err.(*csv.ParseError); !ok {
+				return nil, nil, err
+			}
+			flush()
+			rowErrors = append(rowErrors, ImportRowError{Row: rowNum, Code: "MALFORMED_ROW", Message: err.Error()})
+			continue
+		}
+
+		ref := field(row, "ref")
+		if current == nil || ref != current.record.Ref {
+			flush()
+			current = &importCandidate{row: rowNum, record: importRecord{
+				ID:          field(row, "id"),
+				Ref:         ref,
+				Date:        field(row, "date"),
+				Description: field(row, "description"),
+			}}
+		} else if date := field(row, "date"); date != "" && date != current.record.Date && currentErr == nil {
+			currentErr = &ImportRowError{Row: current.row, Ref: ref, Code: "INCONSISTENT_DATE", Message: fmt.Sprintf("row %d has a different date than the rest of the transaction", rowNum)}
+		}
+
+		dimensions, err := parseImportDimensions(field(row, "dimensions"))
+		if err != nil && currentErr == nil {
+			currentErr = &ImportRowError{Row: current.row, Ref: ref, Code: "INVALID_DIMENSIONS", Message: fmt.Sprintf("row %d: %v", rowNum, err)}
+		}
+		current.record.Entries = append(current.record.Entries, importLine{
+			AccountID:  field(row, "account_id"),
+			Type:       field(row, "type"),
+			Amount:     json.Number(field(row, "amount")),
+			Currency:   field(row, "currency"),
+			Dimensions: dimensions,
+		})
+	}
+	flush()
+
+	return candidates, rowErrors, nil
+}
+
+// parseImportJSONLines reads one transaction object per non-blank line
+func parseImportJSONLines(reader io.Reader) ([]importCandidate, []ImportRowError, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+
+	var candidates []importCandidate
+	var rowErrors []ImportRowError
+	for rowNum := 1; scanner.Scan(); rowNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var record importRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Row: rowNum, Code: "MALFORMED_ROW", Message: err.Error()})
+			continue
+		}
+		candidates = append(candidates, importCandidate{row: rowNum, record: record})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return candidates, rowErrors, nil
+}
+
+// parseImportDimensions reads "key=value;key=value" pairs
+func parseImportDimensions(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	dimensions := make(map[string]string)
+	for _, pair := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("dimension %q is not key=value", pair)
+		}
+		dimensions[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return dimensions, nil
+}
+
+// parseImportDate accepts a calendar date or an RFC 3339 timestamp
+func parseImportDate(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("date %q is not YYYY-MM-DD or RFC 3339", value)
+}
+
+// parseImportAmount converts a positive decimal amount in major units to minor
+// units exactly, rejecting more decimal places than the currency has
+func parseImportAmount(value string, currency Currency) (int64, error) {
+	amount, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return 0, fmt.Errorf("amount %q is not a number", value)
+	}
+	if amount.Sign() <= 0 {
+		return 0, fmt.Errorf("amount must be positive")
+	}
+	amount.Mul(amount, pow10Rat(MinorUnits(currency)))
+	if !amount.IsInt() {
+		return 0, fmt.Errorf("amount %s has more decimal places than %s allows", value, currency)
+	}
+	if !amount.Num().IsInt64() {
+		return 0, fmt.Errorf("amount %s is too large", value)
+	}
+	return amount.Num().Int64(), nil
+}
+
+// importSourceRef tags imported transactions so a ref cannot be imported twice
+func importSourceRef(ref string) string {
+	return fmt.Sprintf("IMPORT_%s", ref)
+}
@@ -0,0 +1,146 @@
+package accounting
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportTransactions(t *testing.T) {
+	// Setup
+	dbFile := "test_import.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "data_migration"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	compliance := engine.GetComplianceService()
+	require.NoError(t, compliance.SetupStandardComplianceRules(GAAP_Framework))
+	require.NoError(t, compliance.CreateComplianceRule(ComplianceRule{
+		Framework:   GAAP_Framework,
+		RuleType:    "MATERIALITY_THRESHOLD",
+		Description: "Journal entries over one million must be entered manually",
+		Conditions:  []string{"AMOUNT_THRESHOLD=1000000"},
+		Severity:    "ERROR",
+	}))
+
+	balance := func(accountID string) int64 {
+		result, err := engine.GetAccountBalance(accountID, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+	codes := func(result *ImportResult) map[string]string {
+		byRef := make(map[string]string)
+		for _, e := range result.Errors {
+			byRef[e.Ref] = e.Code
+		}
+		return byRef
+	}
+
+	csvBatch := `ref,date,description,account_id,type,amount,currency,dimensions
+JE-1,2024-01-15,Cash sale,cash,DEBIT,150.25,USD,department=retail
+JE-1,2024-01-15,Cash sale,revenue,CREDIT,150.25,USD,department=retail
+JE-2,2024-01-16,Unbalanced,cash,DEBIT,100.00,USD,
+JE-2,2024-01-16,Unbalanced,revenue,CREDIT,90.00,USD,
+JE-3,2024-01-17,Unknown account,cash,DEBIT,10.00,USD,
+JE-3,2024-01-17,Unknown account,petty_cash,CREDIT,10.00,USD,
+JE-4,2024-01-18,Too precise,cash,DEBIT,10.001,USD,
+JE-4,2024-01-18,Too precise,revenue,CREDIT,10.001,USD,
+`
+
+	t.Run("All Or Nothing Rejects The Batch", func(t *testing.T) {
+		result, err := engine.ImportTransactions(strings.NewReader(csvBatch), ImportFormatCSV, ImportOptions{AllOrNothing: true}, userID)
+		require.NoError(t, err)
+		assert.False(t, result.Committed)
+		assert.Empty(t, result.Accepted)
+		assert.Equal(t, 4, result.TotalTransactions)
+		assert.Len(t, result.Errors, 3)
+		assert.Equal(t, int64(0), balance("cash"))
+	})
+
+	t.Run("CSV Partial Import", func(t *testing.T) {
+		result, err := engine.ImportTransactions(strings.NewReader(csvBatch), ImportFormatCSV, ImportOptions{}, userID)
+		require.NoError(t, err)
+		assert.True(t, result.Committed)
+		require.Len(t, result.Accepted, 1)
+		assert.Equal(t, "JE-1", result.Accepted[0].Ref)
+		assert.Equal(t, 1, result.Accepted[0].Row)
+		assert.Equal(t, Posted, result.Accepted[0].Status)
+
+		byRef := codes(result)
+		assert.Equal(t, "UNBALANCED_TRANSACTION", byRef["JE-2"])
+		assert.Equal(t, "INVALID_ACCOUNT", byRef["JE-3"])
+		assert.Equal(t, "INVALID_AMOUNT", byRef["JE-4"])
+		for _, e := range result.Errors {
+			if e.Ref == "JE-3" {
+				assert.Equal(t, 5, e.Row)
+			}
+		}
+
+		assert.Equal(t, int64(15025), balance("cash"))
+		txn, err := engine.GetStorage().GetTransaction(result.Accepted[0].TransactionID)
+		require.NoError(t, err)
+		assert.Equal(t, "IMPORT_JE-1", txn.SourceRef)
+		assert.Equal(t, []Dimension{{Key: DimDepartment, Value: "retail"}}, txn.Entries[0].Dimensions)
+	})
+
+	t.Run("Refs Are Imported Once", func(t *testing.T) {
+		result, err := engine.ImportTransactions(strings.NewReader(csvBatch), ImportFormatCSV, ImportOptions{}, userID)
+		require.NoError(t, err)
+		assert.Empty(t, result.Accepted)
+		assert.Equal(t, "ALREADY_IMPORTED", codes(result)["JE-1"])
+		assert.Equal(t, int64(15025), balance("cash"))
+	})
+
+	t.Run("JSON Lines With Compliance Pre-Checks", func(t *testing.T) {
+		engine.ApplyCompanySettings(&CompanySettings{RequireApprovalOver: &Amount{Value: 5000000, Currency: "USD"}})
+		defer engine.ApplyCompanySettings(nil)
+
+		batch := strings.Join([]string{
+			`{"ref":"J-1","date":"2024-02-01","description":"Consulting","entries":[{"account_id":"accounts_receivable","type":"DEBIT","amount":"25000.00","currency":"USD"},{"account_id":"revenue","type":"CREDIT","amount":25000,"currency":"USD"}]}`,
+			`{"ref":"J-2","date":"2024-02-02","description":"Equipment","entries":[{"account_id":"expenses","type":"DEBIT","amount":"75000","currency":"USD"},{"account_id":"cash","type":"CREDIT","amount":"75000","currency":"USD"}]}`,
+			``,
+			`{"ref":"J-3","date":"2024-02-03"`,
+			`{"ref":"J-4","date":"2024-02-04","description":"Acquisition","entries":[{"account_id":"expenses","type":"DEBIT","amount":"2000000","currency":"USD"},{"account_id":"cash","type":"CREDIT","amount":"2000000","currency":"USD"}]}`,
+			`{"ref":"J-1","date":"2024-02-05","description":"Repeat","entries":[{"account_id":"cash","type":"DEBIT","amount":"1","currency":"USD"},{"account_id":"revenue","type":"CREDIT","amount":"1","currency":"USD"}]}`,
+		}, "\n")
+
+		result, err := engine.ImportTransactions(strings.NewReader(batch), ImportFormatJSONLines, ImportOptions{}, userID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.TotalTransactions)
+		require.Len(t, result.Accepted, 2)
+
+		// Over the GAAP materiality threshold: accepted with a warning
+		assert.Equal(t, "J-1", result.Accepted[0].Ref)
+		assert.Equal(t, Posted, result.Accepted[0].Status)
+		assert.NotEmpty(t, result.Accepted[0].Warnings)
+
+		// Over the approval threshold: held for review rather than posted
+		assert.Equal(t, "J-2", result.Accepted[1].Ref)
+		assert.Equal(t, PendingApproval, result.Accepted[1].Status)
+
+		require.Len(t, result.Errors, 3)
+		assert.Equal(t, ImportRowError{Row: 4, Code: "MALFORMED_ROW", Message: result.Errors[0].Message}, result.Errors[0])
+		assert.Equal(t, "COMPLIANCE_VIOLATION", result.Errors[1].Code)
+		assert.Equal(t, "J-4", result.Errors[1].Ref)
+		assert.Equal(t, "DUPLICATE_REF", result.Errors[2].Code)
+		assert.Equal(t, 6, result.Errors[2].Row)
+
+		assert.Equal(t, int64(2500000), balance("accounts_receivable"))
+		assert.Equal(t, int64(0), balance("expenses"))
+	})
+
+	t.Run("Unsupported Input", func(t *testing.T) {
+		_, err := engine.ImportTransactions(strings.NewReader(""), ImportFormat("XLSX"), ImportOptions{}, userID)
+		assert.Error(t, err)
+		_, err = engine.ImportTransactions(strings.NewReader("ref,date\nJE-9,2024-01-01\n"), ImportFormatCSV, ImportOptions{}, userID)
+		assert.Error(t, err)
+	})
+}
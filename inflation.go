@@ -0,0 +1,503 @@
+package accounting
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Hyperinflation Structures
+// ----------------------------------------------------------------------------
+
+// inflationSourcePrefix marks restatement transactions so they can be excluded when
+// restated balances are recomputed from historical entries
+const inflationSourcePrefix = "IAS29_RESTATEMENT_"
+
+// PriceIndex is a general price index value, such as a monthly CPI reading
+type PriceIndex struct {
+	ID        string    `json:"id"`
+	IndexName string    `json:"index_name"`
+	Date      time.Time `json:"date"`
+	Value     float64   `json:"value"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// HyperinflationConfig identifies the price index for the entity's functional currency
+// and how its balances are classified under IAS 29. Asset and liability accounts are
+// monetary unless listed in NonMonetaryAccountIDs; equity is always non-monetary.
+type HyperinflationConfig struct {
+	IndexName                 string   `json:"index_name"`
+	MonetaryGainLossAccountID string   `json:"monetary_gain_loss_account_id"`
+	NonMonetaryAccountIDs     []string `json:"non_monetary_account_ids"`
+}
+
+// RestatementLine is the restatement of a single account to the closing index
+type RestatementLine struct {
+	AccountID       string      `json:"account_id"`
+	AccountName     string      `json:"account_name"`
+	AccountType     AccountType `json:"account_type"`
+	HistoricalValue int64       `json:"historical_value"` // before any restatement
+	CarryingValue   int64       `json:"carrying_value"`   // as currently recorded
+	RestatedValue   int64       `json:"restated_value"`   // at the closing index
+	Adjustment      int64       `json:"adjustment"`       // restated less carrying
+}
+
+// RestatementResult summarizes a hyperinflation restatement for a period
+type RestatementResult struct {
+	PeriodID      string             `json:"period_id"`
+	IndexName     string             `json:"index_name"`
+	ClosingIndex  float64            `json:"closing_index"`
+	AsOfDate      time.Time          `json:"as_of_date"`
+	Lines         []*RestatementLine `json:"lines"`
+	NetAdjustment int64              `json:"net_adjustment"` // credit to the gain/loss account, negative for a debit
+	TransactionID string             `json:"transaction_id,omitempty"`
+}
+
+// RestatedTrialBalance is a trial balance expressed in the measuring unit current at
+// the as-of date. Non-monetary items, equity, income and expenses are restated from
+// their transaction dates; the balancing difference is the gain or loss on the net
+// monetary position.
+type RestatedTrialBalance struct {
+	AsOfDate         time.Time        `json:"as_of_date"`
+	IndexName        string           `json:"index_name"`
+	ClosingIndex     float64          `json:"closing_index"`
+	Balances         []*BalanceResult `json:"balances"`
+	MonetaryGainLoss int64            `json:"monetary_gain_loss"` // negative for a loss
+}
+
+// ----------------------------------------------------------------------------
+// Inflation Adjustment Service
+// ----------------------------------------------------------------------------
+
+// InflationAdjustmentService restates balances of an entity reporting in a
+// hyperinflationary currency (IAS 29)
+type InflationAdjustmentService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+	config        *HyperinflationConfig
+	mutex         sync.RWMutex
+}
+
+// NewInflationAdjustmentService creates a new inflation adjustment service
+func NewInflationAdjustmentService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *InflationAdjustmentService {
+	return &InflationAdjustmentService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+	}
+}
+
+// RecordPriceIndex adds a price index reading effective from its date
+func (ias *InflationAdjustmentService) RecordPriceIndex(indexName string, date time.Time, value float64, userID string) (*PriceIndex, error) {
+	if indexName == "" {
+		return nil, fmt.Errorf("index name is required")
+	}
+	if value <= 0 {
+		return nil, fmt.Errorf("price index must be positive, got %v", value)
+	}
+
+	index := &PriceIndex{
+		ID:        newID(),
+		IndexName: indexName,
+		Date:      date,
+		Value:     value,
+		CreatedBy: userID,
+		CreatedAt: time.Now(),
+	}
+
+	_, err := ias.eventStore.CreateEvent(EventRecordPriceIndex, index, date, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create price index event: %w", err)
+	}
+
+	if err := ias.storage.SavePriceIndex(index); err != nil {
+		return nil, fmt.Errorf("failed to save price index: %w", err)
+	}
+	return index, nil
+}
+
+// GetIndexValue returns the latest index reading on or before the date
+func (ias *InflationAdjustmentService) GetIndexValue(indexName string, date time.Time) (float64, error) {
+	indices, err := ias.storage.GetPriceIndicesByName(indexName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get price indices: %w", err)
+	}
+
+	var latest *PriceIndex
+	for _, index := range indices {
+		if index.Date.After(date) {
+			continue
+		}
+		if latest == nil || index.Date.After(latest.Date) ||
+			(index.Date.Equal(latest.Date) && index.CreatedAt.After(latest.CreatedAt)) {
+			latest = index
+		}
+	}
+	if latest == nil {
+		return 0, fmt.Errorf("no %s index value on or before %s", indexName, date.Format("2006-01-02"))
+	}
+	return latest.Value, nil
+}
+
+// Configure enables hyperinflation accounting for the entity
+func (ias *InflationAdjustmentService) Configure(config HyperinflationConfig) error {
+	if config.IndexName == "" {
+		return fmt.Errorf("price index is required")
+	}
+	account, err := ias.storage.GetAccount(config.MonetaryGainLossAccountID)
+	if err != nil {
+		return fmt.Errorf("invalid monetary gain/loss account: %w", err)
+	}
+	if account.Type != Income && account.Type != Expense {
+		return fmt.Errorf("monetary gain/loss account must be an income or expense account")
+	}
+	for _, accountID := range config.NonMonetaryAccountIDs {
+		if _, err := ias.storage.GetAccount(accountID); err != nil {
+			return fmt.Errorf("invalid non-monetary account %s: %w", accountID, err)
+		}
+	}
+
+	ias.mutex.Lock()
+	defer ias.mutex.Unlock()
+	ias.config = &config
+	return nil
+}
+
+// IsConfigured reports whether the entity applies hyperinflation accounting
+func (ias *InflationAdjustmentService) IsConfigured() bool {
+	ias.mutex.RLock()
+	defer ias.mutex.RUnlock()
+	return ias.config != nil
+}
+
+// CalculateRestatement computes the adjustments that restate non-monetary balances and
+// equity to the index at period end, without posting
+func (ias *InflationAdjustmentService) CalculateRestatement(periodID string) (*RestatementResult, error) {
+	config, err := ias.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	period, err := ias.storage.GetPeriod(periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get period: %w", err)
+	}
+
+	closingIndex, err := ias.GetIndexValue(config.IndexName, period.End)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := ias.sortedAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RestatementResult{
+		PeriodID:     period.ID,
+		IndexName:    config.IndexName,
+		ClosingIndex: closingIndex,
+		AsOfDate:     period.End,
+	}
+
+	for _, account := range accounts {
+		if !ias.isNonMonetary(config, account) {
+			continue
+		}
+
+		historical, carrying, restated, err := ias.restateAccount(config, account, period.End, closingIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restate account %s: %w", account.ID, err)
+		}
+		if restated == carrying {
+			continue
+		}
+
+		line := &RestatementLine{
+			AccountID:       account.ID,
+			AccountName:     account.Name,
+			AccountType:     account.Type,
+			HistoricalValue: historical,
+			CarryingValue:   carrying,
+			RestatedValue:   restated,
+			Adjustment:      restated - carrying,
+		}
+		result.Lines = append(result.Lines, line)
+
+		// Increasing an asset needs a credit to the gain/loss account; increasing
+		// equity or a liability needs a debit
+		if account.Type == Asset {
+			result.NetAdjustment += line.Adjustment
+		} else {
+			result.NetAdjustment -= line.Adjustment
+		}
+	}
+
+	return result, nil
+}
+
+// RestatePeriod calculates and posts the restatement for a period. The offset to the
+// restated balances is recognised in the monetary gain/loss account.
+func (ias *InflationAdjustmentService) RestatePeriod(periodID, userID string) (*RestatementResult, error) {
+	result, err := ias.CalculateRestatement(periodID)
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Lines) == 0 {
+		return result, nil
+	}
+
+	config, err := ias.getConfig()
+	if err != nil {
+		return nil, err
+	}
+	currency, err := ias.functionalCurrency()
+	if err != nil {
+		return nil, err
+	}
+
+	txn := &Transaction{
+		ID:              newID(),
+		Description:     fmt.Sprintf("IAS 29 restatement to %s index %.4f", config.IndexName, result.ClosingIndex),
+		ValidTime:       result.AsOfDate,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       inflationSourcePrefix + periodID,
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	addEntry := func(accountID string, entryType EntryType, value int64) {
+		txn.Entries = append(txn.Entries, Entry{
+			ID:            newID(),
+			TransactionID: txn.ID,
+			AccountID:     accountID,
+			Type:          entryType,
+			Amount:        Amount{Value: value, Currency: currency},
+		})
+	}
+
+	for _, line := range result.Lines {
+		increase := Debit
+		if line.AccountType != Asset {
+			increase = Credit
+		}
+		if line.Adjustment > 0 {
+			addEntry(line.AccountID, increase, line.Adjustment)
+		} else {
+			addEntry(line.AccountID, oppositeEntryType(increase), -line.Adjustment)
+		}
+	}
+	if result.NetAdjustment > 0 {
+		addEntry(config.MonetaryGainLossAccountID, Credit, result.NetAdjustment)
+	} else if result.NetAdjustment < 0 {
+		addEntry(config.MonetaryGainLossAccountID, Debit, -result.NetAdjustment)
+	}
+
+	_, err = ias.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := ias.storage.SaveTransaction(txn); err != nil {
+		return nil, fmt.Errorf("failed to save restatement transaction: %w", err)
+	}
+
+	if err := ias.postingEngine.PostTransaction(txn, userID); err != nil {
+		return nil, fmt.Errorf("failed to post restatement transaction: %w", err)
+	}
+
+	result.TransactionID = txn.ID
+
+	_, err = ias.eventStore.CreateEvent(EventRestatePeriod, result, result.AsOfDate, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create restatement event: %w", err)
+	}
+
+	return result, nil
+}
+
+// GenerateRestatedTrialBalance restates every non-monetary balance, equity, income
+// and expense to the index at the as-of date for presentation and consolidation
+func (ias *InflationAdjustmentService) GenerateRestatedTrialBalance(asOfDate time.Time) (*RestatedTrialBalance, error) {
+	config, err := ias.getConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	closingIndex, err := ias.GetIndexValue(config.IndexName, asOfDate)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := ias.sortedAccounts()
+	if err != nil {
+		return nil, err
+	}
+	currency, err := ias.functionalCurrency()
+	if err != nil {
+		return nil, err
+	}
+
+	restatedTB := &RestatedTrialBalance{
+		AsOfDate:     asOfDate,
+		IndexName:    config.IndexName,
+		ClosingIndex: closingIndex,
+	}
+
+	var gainLossBalance *BalanceResult
+	var netDebits int64
+	for _, account := range accounts {
+		var balance int64
+		if ias.isNonMonetary(config, account) || account.Type == Income || account.Type == Expense {
+			_, _, balance, err = ias.restateAccount(config, account, asOfDate, closingIndex)
+		} else {
+			balance, _, _, err = ias.restateAccount(config, account, asOfDate, closingIndex)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to restate account %s: %w", account.ID, err)
+		}
+
+		result := &BalanceResult{
+			AccountID:   account.ID,
+			AccountName: account.Name,
+			AccountType: account.Type,
+			Balance:     &Amount{Value: balance, Currency: currency},
+			AsOfDate:    asOfDate,
+		}
+		restatedTB.Balances = append(restatedTB.Balances, result)
+
+		netDebits += balance * debitSign(account.Type)
+		if account.ID == config.MonetaryGainLossAccountID {
+			gainLossBalance = result
+		}
+	}
+
+	// Whatever no longer balances is the gain or loss on the net monetary position
+	restatedTB.MonetaryGainLoss = netDebits
+	if gainLossBalance != nil {
+		gainLossBalance.Balance.Value -= netDebits * debitSign(gainLossBalance.AccountType)
+	}
+
+	return restatedTB, nil
+}
+
+// restateAccount returns an account's historical balance excluding prior restatements,
+// its carrying balance as recorded and its balance restated to the closing index
+func (ias *InflationAdjustmentService) restateAccount(config *HyperinflationConfig, account *Account, asOfDate time.Time, closingIndex float64) (historical, carrying, restated int64, err error) {
+	entries, err := ias.storage.GetEntriesByAccount(account.ID)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("failed to get entries: %w", err)
+	}
+
+	closing, err := decimalRat(closingIndex)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid closing index: %w", err)
+	}
+	restatedTotal := new(big.Rat)
+	for _, entry := range entries {
+		txn, err := ias.storage.GetTransaction(entry.TransactionID)
+		if err != nil {
+			continue
+		}
+		if txn.ValidTime.After(asOfDate) || txn.Status != Posted {
+			continue
+		}
+
+		value := entry.Amount.Value * int64(ias.postingEngine.getBalanceMultiplier(account.Type, entry.Type))
+		carrying += value
+		if strings.HasPrefix(txn.SourceRef, inflationSourcePrefix) {
+			continue
+		}
+		historical += value
+
+		index, err := ias.GetIndexValue(config.IndexName, txn.ValidTime)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		factor, err := decimalRat(index)
+		if err != nil || factor.Sign() == 0 {
+			return 0, 0, 0, fmt.Errorf("invalid %s index %v at %s", config.IndexName, index, txn.ValidTime.Format("2006-01-02"))
+		}
+		factor.Quo(closing, factor)
+		restatedTotal.Add(restatedTotal, factor.Mul(factor, new(big.Rat).SetInt64(value)))
+	}
+
+	restated, err = roundRat(restatedTotal, RoundHalfUp)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return historical, carrying, restated, nil
+}
+
+// isNonMonetary reports whether an account's balance is restated under IAS 29
+func (ias *InflationAdjustmentService) isNonMonetary(config *HyperinflationConfig, account *Account) bool {
+	if account.Type == Equity {
+		return true
+	}
+	for _, accountID := range config.NonMonetaryAccountIDs {
+		if accountID == account.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// functionalCurrency returns the currency restatement entries are recorded in,
+// taken from the monetary gain/loss account
+func (ias *InflationAdjustmentService) functionalCurrency() (Currency, error) {
+	config, err := ias.getConfig()
+	if err != nil {
+		return "", err
+	}
+	account, err := ias.storage.GetAccount(config.MonetaryGainLossAccountID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get monetary gain/loss account: %w", err)
+	}
+	if account.Currency == "" {
+		return "USD", nil
+	}
+	return account.Currency, nil
+}
+
+// sortedAccounts returns the chart of accounts in code order
+func (ias *InflationAdjustmentService) sortedAccounts() ([]*Account, error) {
+	accounts, err := ias.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Code < accounts[j].Code
+	})
+	return accounts, nil
+}
+
+// getConfig returns the configuration or an error when hyperinflation accounting is off
+func (ias *InflationAdjustmentService) getConfig() (*HyperinflationConfig, error) {
+	ias.mutex.RLock()
+	defer ias.mutex.RUnlock()
+	if ias.config == nil {
+		return nil, fmt.Errorf("hyperinflation accounting is not configured")
+	}
+	return ias.config, nil
+}
+
+// debitSign is +1 for accounts with a normal debit balance and -1 otherwise
+func debitSign(accountType AccountType) int64 {
+	if accountType == Asset || accountType == Expense {
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,142 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHyperinflationRestatement(t *testing.T) {
+	// Setup
+	dbFile := "test_hyperinflation.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "test_user"
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	accounts := []*Account{
+		{ID: "ars_cash", Code: "1000", Name: "Cash", Type: Asset, Currency: "ARS"},
+		{ID: "ars_land", Code: "1500", Name: "Land", Type: Asset, Currency: "ARS"},
+		{ID: "ars_capital", Code: "3000", Name: "Share Capital", Type: Equity, Currency: "ARS"},
+		{ID: "ars_monetary", Code: "7900", Name: "Net Monetary Position", Type: Expense, Currency: "ARS"},
+	}
+	for _, account := range accounts {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	_, err = engine.RecordPriceIndex("AR_CPI", start, 100, userID)
+	require.NoError(t, err)
+	_, err = engine.RecordPriceIndex("AR_CPI", end, 200, userID)
+	require.NoError(t, err)
+
+	// Capital of 1,000 received and 600 spent on land while the index is 100
+	txns := []*Transaction{
+		{
+			Description: "Capital contribution",
+			ValidTime:   start,
+			Entries: []Entry{
+				{AccountID: "ars_cash", Type: Debit, Amount: Amount{Value: 100000, Currency: "ARS"}},
+				{AccountID: "ars_capital", Type: Credit, Amount: Amount{Value: 100000, Currency: "ARS"}},
+			},
+		},
+		{
+			Description: "Land purchase",
+			ValidTime:   start,
+			Entries: []Entry{
+				{AccountID: "ars_land", Type: Debit, Amount: Amount{Value: 60000, Currency: "ARS"}},
+				{AccountID: "ars_cash", Type: Credit, Amount: Amount{Value: 60000, Currency: "ARS"}},
+			},
+		},
+	}
+	for _, txn := range txns {
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+
+	require.NoError(t, engine.ConfigureHyperinflation(HyperinflationConfig{
+		IndexName:                 "AR_CPI",
+		MonetaryGainLossAccountID: "ars_monetary",
+		NonMonetaryAccountIDs:     []string{"ars_land"},
+	}))
+
+	balances := func(tb *RestatedTrialBalance) map[string]int64 {
+		result := make(map[string]int64)
+		for _, balance := range tb.Balances {
+			result[balance.AccountID] = balance.Balance.Value
+		}
+		return result
+	}
+
+	t.Run("Restated Trial Balance", func(t *testing.T) {
+		restated, err := engine.GenerateRestatedTrialBalance(end)
+		require.NoError(t, err)
+
+		values := balances(restated)
+		assert.Equal(t, int64(40000), values["ars_cash"], "monetary items are not restated")
+		assert.Equal(t, int64(120000), values["ars_land"])
+		assert.Equal(t, int64(200000), values["ars_capital"])
+		assert.Equal(t, int64(40000), values["ars_monetary"], "holding cash loses its purchasing power")
+		assert.Equal(t, int64(-40000), restated.MonetaryGainLoss)
+	})
+
+	t.Run("Restatement Posted At Close", func(t *testing.T) {
+		period := &Period{Name: "FY2024", Start: start, End: end}
+		require.NoError(t, engine.CreatePeriod(period, userID))
+		require.NoError(t, engine.ClosePeriod(period.ID, true, userID))
+
+		land, err := engine.GetAccountBalance("ars_land", end)
+		require.NoError(t, err)
+		assert.Equal(t, int64(120000), land.Balance.Value)
+
+		loss, err := engine.GetAccountBalance("ars_monetary", end)
+		require.NoError(t, err)
+		assert.Equal(t, int64(40000), loss.Balance.Value)
+
+		// Posted restatements are excluded when the trial balance is restated again
+		restated, err := engine.GenerateRestatedTrialBalance(end)
+		require.NoError(t, err)
+		assert.Equal(t, int64(120000), balances(restated)["ars_land"])
+		assert.Equal(t, int64(40000), balances(restated)["ars_monetary"])
+
+		again, err := engine.GetInflationAdjustmentService().CalculateRestatement(period.ID)
+		require.NoError(t, err)
+		assert.Empty(t, again.Lines)
+	})
+
+	t.Run("Half Points Round Up", func(t *testing.T) {
+		nextEnd := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+		_, err := engine.RecordPriceIndex("SMALL_CPI", start, 0.2, userID)
+		require.NoError(t, err)
+		_, err = engine.RecordPriceIndex("SMALL_CPI", nextEnd, 0.3, userID)
+		require.NoError(t, err)
+		require.NoError(t, engine.CreateAccount(&Account{ID: "ars_stamps", Code: "1510", Name: "Stamps", Type: Asset, Currency: "ARS"}, userID))
+		stamps := &Transaction{
+			Description: "Stamps",
+			ValidTime:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "ars_stamps", Type: Debit, Amount: Amount{Value: 1, Currency: "ARS"}},
+				{AccountID: "ars_cash", Type: Credit, Amount: Amount{Value: 1, Currency: "ARS"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(stamps, userID))
+		require.NoError(t, engine.PostTransaction(stamps.ID, userID))
+		require.NoError(t, engine.ConfigureHyperinflation(HyperinflationConfig{
+			IndexName:                 "SMALL_CPI",
+			MonetaryGainLossAccountID: "ars_monetary",
+			NonMonetaryAccountIDs:     []string{"ars_land", "ars_stamps"},
+		}))
+
+		// 0.3 / 0.2 is exactly 1.5, not the 1.4999... of the indexes' binary expansions
+		restated, err := engine.GenerateRestatedTrialBalance(nextEnd)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), balances(restated)["ars_stamps"])
+	})
+}
@@ -0,0 +1,478 @@
+package accounting
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Installment Plan Structures
+// ----------------------------------------------------------------------------
+
+// Default accounts used by installment plans when a request leaves them unset
+const (
+	DefaultReceivableAccountID = "accounts_receivable"
+	DefaultRevenueAccountID    = "revenue"
+)
+
+// InstallmentPlanStatus tracks whether a plan still has amounts outstanding
+type InstallmentPlanStatus string
+
+const (
+	InstallmentPlanActive  InstallmentPlanStatus = "ACTIVE"
+	InstallmentPlanSettled InstallmentPlanStatus = "SETTLED"
+)
+
+// InstallmentPlanRequest describes a sale settled in installments. With an annual
+// interest rate the receivable is discounted to present value and the difference
+// is recognized as interest income over the plan (IFRS 9 / ASC 835-30); without
+// one the plan is interest-free and carried at its nominal amount.
+type InstallmentPlanRequest struct {
+	PlanID                  string    `json:"plan_id,omitempty"` // generated when empty
+	CustomerID              string    `json:"customer_id"`
+	InvoiceRef              string    `json:"invoice_ref"`
+	SaleDate                time.Time `json:"sale_date"`
+	Nominal                 Amount    `json:"nominal"` // total of all installments
+	NumberOfInstallments    int       `json:"number_of_installments"`
+	FrequencyMonths         int       `json:"frequency_months"`         // defaults to monthly
+	FirstDueDate            time.Time `json:"first_due_date,omitempty"` // defaults to one period after the sale
+	AnnualInterestRate      float64   `json:"annual_interest_rate"`     // imputed market rate, e.g. 0.08
+	ReceivableAccountID     string    `json:"receivable_account_id,omitempty"`
+	RevenueAccountID        string    `json:"revenue_account_id,omitempty"`
+	InterestIncomeAccountID string    `json:"interest_income_account_id,omitempty"`
+}
+
+// Installment is one line of the receivable schedule, amortized under the
+// effective interest method
+type Installment struct {
+	Number                int       `json:"number"`
+	DueDate               time.Time `json:"due_date"`
+	Amount                int64     `json:"amount"`
+	OpeningCarrying       int64     `json:"opening_carrying"`
+	Interest              int64     `json:"interest"` // accrued over the period ending on the due date
+	ClosingCarrying       int64     `json:"closing_carrying"`
+	InterestRecognized    bool      `json:"interest_recognized"`
+	InterestTransactionID string    `json:"interest_transaction_id,omitempty"`
+	Paid                  int64     `json:"paid"`
+}
+
+// Outstanding returns the amount of the installment not yet received
+func (i *Installment) Outstanding() int64 {
+	return i.Amount - i.Paid
+}
+
+// InstallmentPlan is a receivable collected in scheduled installments
+type InstallmentPlan struct {
+	ID                      string                `json:"id"`
+	CustomerID              string                `json:"customer_id"`
+	InvoiceRef              string                `json:"invoice_ref"`
+	SaleDate                time.Time             `json:"sale_date"`
+	Nominal                 *Amount               `json:"nominal"`
+	PresentValue            *Amount               `json:"present_value"` // revenue recognized at sale
+	AnnualInterestRate      float64               `json:"annual_interest_rate"`
+	FrequencyMonths         int                   `json:"frequency_months"`
+	ReceivableAccountID     string                `json:"receivable_account_id"`
+	RevenueAccountID        string                `json:"revenue_account_id"`
+	InterestIncomeAccountID string                `json:"interest_income_account_id,omitempty"`
+	Installments            []*Installment        `json:"installments"`
+	Status                  InstallmentPlanStatus `json:"status"`
+	SaleTransactionID       string                `json:"sale_transaction_id"`
+	CreatedBy               string                `json:"created_by"`
+	CreatedAt               time.Time             `json:"created_at"`
+	UpdatedAt               time.Time             `json:"updated_at"`
+}
+
+// Outstanding returns the nominal amount of the plan not yet received
+func (ip *InstallmentPlan) Outstanding() int64 {
+	var outstanding int64
+	for _, installment := range ip.Installments {
+		outstanding += installment.Outstanding()
+	}
+	return outstanding
+}
+
+// ----------------------------------------------------------------------------
+// Installment Plan Service
+// ----------------------------------------------------------------------------
+
+// InstallmentService manages installment plan receivables and their imputed interest
+type InstallmentService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+}
+
+// NewInstallmentService creates a new installment plan service
+func NewInstallmentService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *InstallmentService {
+	return &InstallmentService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+	}
+}
+
+// CreatePlan builds the receivable schedule for a sale and posts the sale at the
+// present value of the installments. Plans whose final installment falls within a
+// year of the sale are not discounted, following the short-term practical expedient.
+func (is *InstallmentService) CreatePlan(request InstallmentPlanRequest, userID string) (*InstallmentPlan, error) {
+	if request.CustomerID == "" {
+		return nil, fmt.Errorf("customer is required")
+	}
+	if request.Nominal.Value <= 0 {
+		return nil, fmt.Errorf("plan amount must be positive")
+	}
+	if request.NumberOfInstallments <= 0 {
+		return nil, fmt.Errorf("plan must have at least one installment")
+	}
+	if request.AnnualInterestRate < 0 {
+		return nil, fmt.Errorf("interest rate cannot be negative")
+	}
+	if request.FrequencyMonths == 0 {
+		request.FrequencyMonths = 1
+	}
+	if request.FrequencyMonths < 0 {
+		return nil, fmt.Errorf("frequency cannot be negative")
+	}
+	if request.ReceivableAccountID == "" {
+		request.ReceivableAccountID = DefaultReceivableAccountID
+	}
+	if request.RevenueAccountID == "" {
+		request.RevenueAccountID = DefaultRevenueAccountID
+	}
+	if request.FirstDueDate.IsZero() {
+		request.FirstDueDate = request.SaleDate.AddDate(0, request.FrequencyMonths, 0)
+	}
+	if request.FirstDueDate.Before(request.SaleDate) {
+		return nil, fmt.Errorf("first installment cannot fall due before the sale")
+	}
+
+	accountIDs := []string{request.ReceivableAccountID, request.RevenueAccountID}
+	if request.InterestIncomeAccountID != "" {
+		accountIDs = append(accountIDs, request.InterestIncomeAccountID)
+	}
+	for _, accountID := range accountIDs {
+		if _, err := is.storage.GetAccount(accountID); err != nil {
+			return nil, fmt.Errorf("invalid account %s: %w", accountID, err)
+		}
+	}
+
+	if err := is.storage.assignID(&request.PlanID, "installment plan", BucketInstallmentPlans); err != nil {
+		return nil, err
+	}
+
+	amounts, err := request.Nominal.Allocate(request.NumberOfInstallments)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &InstallmentPlan{
+		ID:                      request.PlanID,
+		CustomerID:              request.CustomerID,
+		InvoiceRef:              request.InvoiceRef,
+		SaleDate:                request.SaleDate,
+		Nominal:                 &Amount{Value: request.Nominal.Value, Currency: request.Nominal.Currency},
+		FrequencyMonths:         request.FrequencyMonths,
+		ReceivableAccountID:     request.ReceivableAccountID,
+		RevenueAccountID:        request.RevenueAccountID,
+		InterestIncomeAccountID: request.InterestIncomeAccountID,
+		Status:                  InstallmentPlanActive,
+		CreatedBy:               userID,
+		CreatedAt:               time.Now(),
+		UpdatedAt:               time.Now(),
+	}
+	for i, amount := range amounts {
+		plan.Installments = append(plan.Installments, &Installment{
+			Number:  i + 1,
+			DueDate: request.FirstDueDate.AddDate(0, i*request.FrequencyMonths, 0),
+			Amount:  amount.Value,
+		})
+	}
+
+	finalDue := plan.Installments[len(plan.Installments)-1].DueDate
+	if request.AnnualInterestRate > 0 && finalDue.After(request.SaleDate.AddDate(1, 0, 0)) {
+		if request.InterestIncomeAccountID == "" {
+			return nil, fmt.Errorf("interest income account is required for an imputed interest rate")
+		}
+		plan.AnnualInterestRate = request.AnnualInterestRate
+	}
+
+	if err := plan.amortize(); err != nil {
+		return nil, err
+	}
+
+	txn := &Transaction{
+		ID:              newID(),
+		Description:     fmt.Sprintf("Installment sale %s", plan.InvoiceRef),
+		ValidTime:       plan.SaleDate,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       fmt.Sprintf("INSTALLMENT_SALE_%s", plan.ID),
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		Entries: []Entry{
+			{
+				ID:         newID(),
+				AccountID:  plan.ReceivableAccountID,
+				Type:       Debit,
+				Amount:     *plan.PresentValue,
+				Dimensions: []Dimension{{Key: DimCounterparty, Value: plan.CustomerID}},
+			},
+			{
+				ID:        newID(),
+				AccountID: plan.RevenueAccountID,
+				Type:      Credit,
+				Amount:    *plan.PresentValue,
+			},
+		},
+	}
+	if err := is.postTransaction(txn, userID); err != nil {
+		return nil, err
+	}
+	plan.SaleTransactionID = txn.ID
+
+	_, err = is.eventStore.CreateEvent(EventCreateInstallmentPlan, plan, plan.SaleDate, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create installment plan event: %w", err)
+	}
+	if err := is.storage.SaveInstallmentPlan(plan); err != nil {
+		return nil, fmt.Errorf("failed to save installment plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// RecognizeInterest posts the imputed interest for every installment period ending
+// on or before the date. Periods already recognized are skipped, so it is safe to
+// run at each period close.
+func (is *InstallmentService) RecognizeInterest(asOfDate time.Time, userID string) ([]*Transaction, error) {
+	plans, err := is.storage.GetAllInstallmentPlans()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installment plans: %w", err)
+	}
+
+	var posted []*Transaction
+	for _, plan := range plans {
+		changed := false
+		for _, installment := range plan.Installments {
+			if installment.InterestRecognized || installment.Interest == 0 || installment.DueDate.After(asOfDate) {
+				continue
+			}
+
+			amount := Amount{Value: installment.Interest, Currency: plan.Nominal.Currency}
+			txn := &Transaction{
+				ID:              newID(),
+				Description:     fmt.Sprintf("Imputed interest %s installment %d", plan.InvoiceRef, installment.Number),
+				ValidTime:       installment.DueDate,
+				TransactionTime: time.Now(),
+				Status:          Pending,
+				SourceRef:       fmt.Sprintf("INSTALLMENT_INTEREST_%s_%d", plan.ID, installment.Number),
+				UserID:          userID,
+				CreatedAt:       time.Now(),
+				UpdatedAt:       time.Now(),
+				Entries: []Entry{
+					{
+						ID:         newID(),
+						AccountID:  plan.ReceivableAccountID,
+						Type:       Debit,
+						Amount:     amount,
+						Dimensions: []Dimension{{Key: DimCounterparty, Value: plan.CustomerID}},
+					},
+					{
+						ID:        newID(),
+						AccountID: plan.InterestIncomeAccountID,
+						Type:      Credit,
+						Amount:    amount,
+					},
+				},
+			}
+			if err := is.postTransaction(txn, userID); err != nil {
+				return nil, err
+			}
+
+			installment.InterestRecognized = true
+			installment.InterestTransactionID = txn.ID
+			posted = append(posted, txn)
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+		plan.UpdatedAt = time.Now()
+		_, err := is.eventStore.CreateEvent(EventRecognizeInstallmentInterest, plan, asOfDate, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create interest recognition event: %w", err)
+		}
+		if err := is.storage.SaveInstallmentPlan(plan); err != nil {
+			return nil, fmt.Errorf("failed to save installment plan: %w", err)
+		}
+	}
+
+	return posted, nil
+}
+
+// RecordReceipt applies a customer payment to the plan's earliest outstanding
+// installments and posts it against the receivable
+func (is *InstallmentService) RecordReceipt(planID string, amount int64, receivedAt time.Time, cashAccountID, userID string) (*InstallmentPlan, error) {
+	plan, err := is.storage.GetInstallmentPlan(planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installment plan: %w", err)
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("receipt amount must be positive")
+	}
+	if outstanding := plan.Outstanding(); amount > outstanding {
+		return nil, fmt.Errorf("receipt of %d exceeds outstanding balance of %d", amount, outstanding)
+	}
+	if _, err := is.storage.GetAccount(cashAccountID); err != nil {
+		return nil, fmt.Errorf("invalid cash account: %w", err)
+	}
+
+	remaining := amount
+	for _, installment := range plan.Installments {
+		if remaining == 0 {
+			break
+		}
+		applied := installment.Outstanding()
+		if applied > remaining {
+			applied = remaining
+		}
+		installment.Paid += applied
+		remaining -= applied
+	}
+
+	receipt := Amount{Value: amount, Currency: plan.Nominal.Currency}
+	txn := &Transaction{
+		ID:              newID(),
+		Description:     fmt.Sprintf("Installment receipt %s", plan.InvoiceRef),
+		ValidTime:       receivedAt,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       fmt.Sprintf("INSTALLMENT_RECEIPT_%s", plan.ID),
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		Entries: []Entry{
+			{
+				ID:        newID(),
+				AccountID: cashAccountID,
+				Type:      Debit,
+				Amount:    receipt,
+			},
+			{
+				ID:         newID(),
+				AccountID:  plan.ReceivableAccountID,
+				Type:       Credit,
+				Amount:     receipt,
+				Dimensions: []Dimension{{Key: DimCounterparty, Value: plan.CustomerID}},
+			},
+		},
+	}
+	if err := is.postTransaction(txn, userID); err != nil {
+		return nil, err
+	}
+
+	if plan.Outstanding() == 0 {
+		plan.Status = InstallmentPlanSettled
+	}
+	plan.UpdatedAt = time.Now()
+
+	_, err = is.eventStore.CreateEvent(EventRecordInstallmentReceipt, plan, receivedAt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create installment receipt event: %w", err)
+	}
+	if err := is.storage.SaveInstallmentPlan(plan); err != nil {
+		return nil, fmt.Errorf("failed to save installment plan: %w", err)
+	}
+
+	return plan, nil
+}
+
+// GetPlans returns a customer's installment plans, or all plans when customerID is empty
+func (is *InstallmentService) GetPlans(customerID string) ([]*InstallmentPlan, error) {
+	plans, err := is.storage.GetAllInstallmentPlans()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installment plans: %w", err)
+	}
+
+	var result []*InstallmentPlan
+	for _, plan := range plans {
+		if customerID == "" || plan.CustomerID == customerID {
+			result = append(result, plan)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SaleDate.Before(result[j].SaleDate)
+	})
+	return result, nil
+}
+
+// amortize discounts the installments at the periodic rate and fills in the
+// effective interest schedule. The final period absorbs rounding so the carrying
+// amount ends at exactly zero.
+func (ip *InstallmentPlan) amortize() error {
+	periodicRate, err := decimalRat(ip.AnnualInterestRate)
+	if err != nil {
+		return fmt.Errorf("invalid interest rate: %w", err)
+	}
+	periodicRate.Mul(periodicRate, big.NewRat(int64(ip.FrequencyMonths), 12))
+	growth := new(big.Rat).Add(big.NewRat(1, 1), periodicRate)
+
+	presentValue := new(big.Rat)
+	discount := big.NewRat(1, 1)
+	for _, installment := range ip.Installments {
+		discount.Quo(discount, growth)
+		presentValue.Add(presentValue, new(big.Rat).Mul(new(big.Rat).SetInt64(installment.Amount), discount))
+	}
+	pv, err := roundRat(presentValue, RoundHalfUp)
+	if err != nil {
+		return fmt.Errorf("failed to discount installments: %w", err)
+	}
+	ip.PresentValue = &Amount{Value: pv, Currency: ip.Nominal.Currency}
+
+	carrying := pv
+	for i, installment := range ip.Installments {
+		installment.OpeningCarrying = carrying
+		if i == len(ip.Installments)-1 {
+			installment.Interest = installment.Amount - carrying
+		} else {
+			interest, err := roundRat(new(big.Rat).Mul(new(big.Rat).SetInt64(carrying), periodicRate), RoundHalfUp)
+			if err != nil {
+				return fmt.Errorf("failed to accrue interest: %w", err)
+			}
+			installment.Interest = interest
+		}
+		carrying += installment.Interest - installment.Amount
+		installment.ClosingCarrying = carrying
+	}
+	return nil
+}
+
+// postTransaction records, saves and posts an installment plan transaction
+func (is *InstallmentService) postTransaction(txn *Transaction, userID string) error {
+	for i := range txn.Entries {
+		txn.Entries[i].TransactionID = txn.ID
+	}
+
+	_, err := is.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := is.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	if err := is.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
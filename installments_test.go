@@ -0,0 +1,128 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallmentPlans(t *testing.T) {
+	// Setup
+	dbFile := "test_installments.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "ar_clerk"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "interest_income", Code: "4200", Name: "Interest Income", Type: Income}, userID))
+
+	saleDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Interest Free", func(t *testing.T) {
+		plan, err := engine.CreateInstallmentPlan(InstallmentPlanRequest{
+			CustomerID:           "cust-1",
+			InvoiceRef:           "INV-100",
+			SaleDate:             saleDate,
+			Nominal:              Amount{Value: 100000, Currency: "USD"},
+			NumberOfInstallments: 3,
+			AnnualInterestRate:   0.08, // ignored: final installment within a year
+		}, userID)
+		require.NoError(t, err)
+
+		require.Len(t, plan.Installments, 3)
+		assert.Equal(t, int64(100000), plan.PresentValue.Value)
+		assert.Equal(t, saleDate.AddDate(0, 1, 0), plan.Installments[0].DueDate)
+		var total int64
+		for _, installment := range plan.Installments {
+			total += installment.Amount
+			assert.Zero(t, installment.Interest)
+		}
+		assert.Equal(t, int64(100000), total)
+
+		plan, err = engine.RecordInstallmentReceipt(plan.ID, 50000, saleDate.AddDate(0, 1, 0), "cash", userID)
+		require.NoError(t, err)
+		assert.Equal(t, plan.Installments[0].Amount, plan.Installments[0].Paid)
+		assert.Equal(t, int64(50000), plan.Outstanding())
+		assert.Equal(t, InstallmentPlanActive, plan.Status)
+
+		_, err = engine.RecordInstallmentReceipt(plan.ID, 60000, saleDate.AddDate(0, 2, 0), "cash", userID)
+		assert.Error(t, err, "receipt cannot exceed the outstanding balance")
+
+		plan, err = engine.RecordInstallmentReceipt(plan.ID, 50000, saleDate.AddDate(0, 3, 0), "cash", userID)
+		require.NoError(t, err)
+		assert.Equal(t, InstallmentPlanSettled, plan.Status)
+	})
+
+	t.Run("Imputed Interest", func(t *testing.T) {
+		request := InstallmentPlanRequest{
+			CustomerID:           "cust-2",
+			InvoiceRef:           "INV-200",
+			SaleDate:             saleDate,
+			Nominal:              Amount{Value: 240000, Currency: "USD"},
+			NumberOfInstallments: 4,
+			FrequencyMonths:      6,
+			AnnualInterestRate:   0.08,
+		}
+		_, err := engine.CreateInstallmentPlan(request, userID)
+		assert.Error(t, err, "interest income account is required")
+
+		request.InterestIncomeAccountID = "interest_income"
+		plan, err := engine.CreateInstallmentPlan(request, userID)
+		require.NoError(t, err)
+
+		// Four payments of 60,000 discounted at 4% per half year
+		assert.Equal(t, int64(217794), plan.PresentValue.Value)
+		last := plan.Installments[len(plan.Installments)-1]
+		assert.Zero(t, last.ClosingCarrying)
+		var interest int64
+		for _, installment := range plan.Installments {
+			interest += installment.Interest
+		}
+		assert.Equal(t, plan.Nominal.Value-plan.PresentValue.Value, interest)
+
+		revenue, err := engine.GetAccountBalance("revenue", saleDate)
+		require.NoError(t, err)
+		assert.Equal(t, int64(100000+217794), revenue.Balance.Value)
+
+		posted, err := engine.RecognizeInstallmentInterest(saleDate.AddDate(1, 0, 0), userID)
+		require.NoError(t, err)
+		assert.Len(t, posted, 2)
+
+		// Re-running for the same date posts nothing new
+		posted, err = engine.RecognizeInstallmentInterest(saleDate.AddDate(1, 0, 0), userID)
+		require.NoError(t, err)
+		assert.Empty(t, posted)
+
+		income, err := engine.GetAccountBalance("interest_income", saleDate.AddDate(1, 0, 0))
+		require.NoError(t, err)
+		assert.Equal(t, plan.Installments[0].Interest+plan.Installments[1].Interest, income.Balance.Value)
+
+		plans, err := engine.GetInstallmentService().GetPlans("cust-2")
+		require.NoError(t, err)
+		require.Len(t, plans, 1)
+		assert.True(t, plans[0].Installments[1].InterestRecognized)
+		assert.False(t, plans[0].Installments[2].InterestRecognized)
+	})
+
+	t.Run("Half Points Round Up", func(t *testing.T) {
+		plan := &InstallmentPlan{
+			Nominal:            &Amount{Value: 13, Currency: "USD"},
+			AnnualInterestRate: 0.15,
+			FrequencyMonths:    12,
+			Installments:       []*Installment{{Amount: 1}, {Amount: 12}},
+		}
+		require.NoError(t, plan.amortize())
+
+		// 15% of a carrying amount of 10 is exactly 1.5, not 1.4999...
+		assert.Equal(t, int64(10), plan.PresentValue.Value)
+		assert.Equal(t, int64(2), plan.Installments[0].Interest)
+		assert.Equal(t, int64(1), plan.Installments[1].Interest)
+		assert.Zero(t, plan.Installments[1].ClosingCarrying)
+	})
+}
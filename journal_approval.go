@@ -0,0 +1,362 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Journal Approval Structures
+// ----------------------------------------------------------------------------
+
+// JournalApprovalStatus tracks a journal entry through review
+type JournalApprovalStatus string
+
+const (
+	JournalApprovalPending  JournalApprovalStatus = "PENDING"
+	JournalApprovalApproved JournalApprovalStatus = "APPROVED"
+	JournalApprovalRejected JournalApprovalStatus = "REJECTED"
+)
+
+// JournalApprovalAction is one step in a journal entry's review history
+type JournalApprovalAction struct {
+	Action  string    `json:"action"` // SUBMITTED, APPROVED, REJECTED or WITHDRAWN
+	UserID  string    `json:"user_id"`
+	Comment string    `json:"comment,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// JournalApproval is the review record for a journal entry held above the approval
+// threshold. It is keyed by the transaction ID and survives rejection and resubmission.
+type JournalApproval struct {
+	ID            string                  `json:"id"`
+	TransactionID string                  `json:"transaction_id"`
+	Description   string                  `json:"description"`
+	Amount        *Amount                 `json:"amount"` // total debits
+	Status        JournalApprovalStatus   `json:"status"`
+	RequestedBy   string                  `json:"requested_by"`
+	RequestedAt   time.Time               `json:"requested_at"`
+	Approvers     []string                `json:"approvers,omitempty"` // empty means anyone but the preparer
+	DecidedBy     string                  `json:"decided_by,omitempty"`
+	DecidedAt     *time.Time              `json:"decided_at,omitempty"`
+	History       []JournalApprovalAction `json:"history"`
+}
+
+// canApprove reports whether the user may decide on the entry
+func (ja *JournalApproval) canApprove(userID string) bool {
+	if userID == ja.RequestedBy {
+		return false
+	}
+	if len(ja.Approvers) == 0 {
+		return true
+	}
+	for _, approver := range ja.Approvers {
+		if approver == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// ----------------------------------------------------------------------------
+// Journal Approval Service
+// ----------------------------------------------------------------------------
+
+// WorkflowJournalApproval is the review of journal entries held above the approval
+// threshold; its subjects are transaction IDs
+const WorkflowJournalApproval = "journal_approval"
+
+// JournalApprovalService holds journal entries above the company's approval
+// threshold until a designated approver approves or rejects them
+type JournalApprovalService struct {
+	storage             *Storage
+	eventStore          *EventStore
+	postingEngine       *PostingEngine
+	workflows           *WorkflowService
+	requireApprovalOver *Amount
+	approvers           []string
+	mutex               sync.RWMutex
+}
+
+// NewJournalApprovalService creates a new journal approval service
+func NewJournalApprovalService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *JournalApprovalService {
+	js := &JournalApprovalService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+	}
+	js.UseWorkflows(NewWorkflowService(storage))
+	return js
+}
+
+// UseWorkflows runs the review on a shared workflow service. Call it while the
+// engine is being assembled.
+func (js *JournalApprovalService) UseWorkflows(workflows *WorkflowService) {
+	workflows.mustRegister(js.workflow())
+	js.workflows = workflows
+}
+
+// journalSubmission is the entry being submitted for review
+type journalSubmission struct {
+	txn   *Transaction
+	total Amount
+}
+
+// workflow defines how held entries move through review
+func (js *JournalApprovalService) workflow() *WorkflowDefinition {
+	pending := string(JournalApprovalPending)
+	return &WorkflowDefinition{
+		Name: WorkflowJournalApproval,
+		Transitions: []WorkflowTransition{
+			{Action: "submit", From: []string{WorkflowStateNew, string(JournalApprovalApproved), string(JournalApprovalRejected)}, To: pending},
+			{Action: "approve", From: []string{pending}, To: string(JournalApprovalApproved), Guard: js.canDecide},
+			{Action: "reject", From: []string{pending}, To: string(JournalApprovalRejected), Guard: js.canDecide, RequireComment: true},
+			{Action: "withdraw", From: []string{pending}, To: string(JournalApprovalRejected)},
+		},
+		State: func(txnID string) (string, error) {
+			approval, err := js.storage.GetJournalApproval(txnID)
+			if err != nil {
+				return WorkflowStateNew, nil
+			}
+			return string(approval.Status), nil
+		},
+		NotAllowed: func(instance *WorkflowInstance, request *WorkflowRequest) error {
+			if request.Action == "submit" {
+				return fmt.Errorf("transaction %s is awaiting approval", instance.SubjectID)
+			}
+			return fmt.Errorf("transaction %s is not awaiting approval", instance.SubjectID)
+		},
+		OnTransition: js.applyTransition,
+	}
+}
+
+// canDecide checks the user may approve or reject a held entry
+func (js *JournalApprovalService) canDecide(instance *WorkflowInstance, request *WorkflowRequest) error {
+	approval, err := js.storage.GetJournalApproval(instance.SubjectID)
+	if err != nil {
+		return fmt.Errorf("failed to get journal approval: %w", err)
+	}
+	if request.Actor == approval.RequestedBy {
+		return classify(ErrPermissionDenied, "transaction %s must be approved by someone other than its preparer", instance.SubjectID)
+	}
+	if !approval.canApprove(request.Actor) {
+		return classify(ErrPermissionDenied, "user %s is not a designated approver", request.Actor)
+	}
+	return nil
+}
+
+// applyTransition records a review step on the approval and the transaction
+func (js *JournalApprovalService) applyTransition(instance *WorkflowInstance, request *WorkflowRequest, from string) error {
+	txnID := instance.SubjectID
+	approval, err := js.storage.GetJournalApproval(txnID)
+	if err != nil {
+		approval = &JournalApproval{ID: txnID, TransactionID: txnID}
+	}
+	now := instance.EnteredAt
+	approval.Status = JournalApprovalStatus(instance.State)
+
+	if request.Action == "submit" {
+		submission, ok := request.Data.(*journalSubmission)
+		if !ok {
+			return fmt.Errorf("journal entries are submitted for approval by posting them")
+		}
+		approval.Approvers = append([]string(nil), instance.Assignees...)
+		approval.Description = submission.txn.Description
+		approval.Amount = &submission.total
+		approval.RequestedBy = request.Actor
+		approval.RequestedAt = now
+		approval.DecidedBy = ""
+		approval.DecidedAt = nil
+		approval.History = append(approval.History, JournalApprovalAction{Action: "SUBMITTED", UserID: request.Actor, At: now})
+		return js.saveApproval(approval, submission.txn, PendingApproval, EventSubmitJournalApproval, request.Actor)
+	}
+
+	txn, err := js.storage.GetTransaction(txnID)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction: %w", err)
+	}
+	approval.DecidedBy = request.Actor
+	approval.DecidedAt = &now
+	switch request.Action {
+	case "approve":
+		approval.History = append(approval.History, JournalApprovalAction{Action: "APPROVED", UserID: request.Actor, Comment: request.Comment, At: now})
+		txn.ApprovedBy = request.Actor
+		if err := js.saveApproval(approval, txn, Pending, EventApproveJournal, request.Actor); err != nil {
+			return err
+		}
+		if err := js.postingEngine.PostTransaction(txn, request.Actor); err != nil {
+			return fmt.Errorf("failed to post approved transaction: %w", err)
+		}
+		return nil
+	case "reject":
+		approval.History = append(approval.History, JournalApprovalAction{Action: "REJECTED", UserID: request.Actor, Comment: request.Comment, At: now})
+	default:
+		approval.History = append(approval.History, JournalApprovalAction{Action: "WITHDRAWN", UserID: request.Actor, Comment: request.Comment, At: now})
+	}
+	return js.saveApproval(approval, txn, Rejected, EventRejectJournal, request.Actor)
+}
+
+// ApplyCompanySettings adopts the company's approval threshold: journal entries
+// whose debits exceed RequireApprovalOver are held for approval before posting
+func (js *JournalApprovalService) ApplyCompanySettings(settings *CompanySettings) {
+	js.mutex.Lock()
+	defer js.mutex.Unlock()
+	if settings == nil {
+		js.requireApprovalOver = nil
+		return
+	}
+	js.requireApprovalOver = settings.RequireApprovalOver
+}
+
+// SetApprovers designates the users who may approve held journal entries. With no
+// approvers designated, anyone other than the preparer may approve.
+func (js *JournalApprovalService) SetApprovers(userIDs []string) {
+	js.mutex.Lock()
+	defer js.mutex.Unlock()
+	js.approvers = append([]string(nil), userIDs...)
+}
+
+// Post posts a transaction, or holds it in PENDING_APPROVAL when
+// it exceeds the approval threshold. It reports whether the transaction was held.
+func (js *JournalApprovalService) Post(txn *Transaction, userID string) (bool, error) {
+	if txn.Status == PendingApproval {
+		return false, fmt.Errorf("transaction %s is awaiting approval", txn.ID)
+	}
+
+	total := journalTotal(txn)
+	if !js.requiresApproval(total) {
+		if err := js.postingEngine.PostTransaction(txn, userID); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+
+	if err := js.postingEngine.ValidateTransaction(txn).Err(); err != nil {
+		return false, err
+	}
+
+	js.mutex.RLock()
+	approvers := append([]string{}, js.approvers...)
+	js.mutex.RUnlock()
+	_, err := js.workflows.Fire(WorkflowJournalApproval, txn.ID, &WorkflowRequest{
+		Action: "submit", Actor: userID, Assignees: approvers, Data: &journalSubmission{txn: txn, total: total},
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Approve approves a held journal entry and posts it
+func (js *JournalApprovalService) Approve(txnID, comment, userID string) (*JournalApproval, error) {
+	return js.decide(txnID, "approve", comment, userID)
+}
+
+// Reject returns a held journal entry to its preparer, who may correct and resubmit it
+func (js *JournalApprovalService) Reject(txnID, comment, userID string) (*JournalApproval, error) {
+	if comment == "" {
+		return nil, fmt.Errorf("a rejection comment is required")
+	}
+	return js.decide(txnID, "reject", comment, userID)
+}
+
+// Withdraw takes a held journal entry out of the approval queue without an approver
+// decision, as when the batch that submitted it is rolled back
+func (js *JournalApprovalService) Withdraw(txnID, reason, userID string) error {
+	_, err := js.decide(txnID, "withdraw", reason, userID)
+	return err
+}
+
+// decide takes a review action on a held entry and returns the updated approval
+func (js *JournalApprovalService) decide(txnID, action, comment, userID string) (*JournalApproval, error) {
+	_, err := js.workflows.Fire(WorkflowJournalApproval, txnID, &WorkflowRequest{
+		Action: action, Actor: userID, Comment: comment, Assignees: []string{},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return js.storage.GetJournalApproval(txnID)
+}
+
+// GetPendingApprovals returns the held journal entries the user may approve, oldest first
+func (js *JournalApprovalService) GetPendingApprovals(userID string) ([]*JournalApproval, error) {
+	approvals, err := js.storage.GetAllJournalApprovals()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get journal approvals: %w", err)
+	}
+
+	var result []*JournalApproval
+	for _, approval := range approvals {
+		if approval.Status == JournalApprovalPending && approval.canApprove(userID) {
+			result = append(result, approval)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RequestedAt.Before(result[j].RequestedAt)
+	})
+	return result, nil
+}
+
+// GetApproval returns the review record for a transaction
+func (js *JournalApprovalService) GetApproval(txnID string) (*JournalApproval, error) {
+	return js.storage.GetJournalApproval(txnID)
+}
+
+// saveApproval records the state change as an event and persists the approval and
+// the transaction's new status
+func (js *JournalApprovalService) saveApproval(approval *JournalApproval, txn *Transaction, status TransactionStatus, eventType string, userID string) error {
+	txn.Status = status
+	txn.UpdatedAt = time.Now()
+
+	_, err := js.eventStore.CreateEvent(eventType, approval, txn.ValidTime, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create journal approval event: %w", err)
+	}
+	if err := js.storage.SaveJournalApproval(approval); err != nil {
+		return fmt.Errorf("failed to save journal approval: %w", err)
+	}
+	if err := js.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+	return nil
+}
+
+// requiresApproval compares a journal total with the approval threshold
+func (js *JournalApprovalService) requiresApproval(total Amount) bool {
+	js.mutex.RLock()
+	defer js.mutex.RUnlock()
+	threshold := js.requireApprovalOver
+	if threshold == nil {
+		return false
+	}
+	// A threshold in another currency cannot be compared, so err on the side of review
+	if total.Currency == "" || threshold.Currency != "" && threshold.Currency != total.Currency {
+		return true
+	}
+	return total.Value > threshold.Value
+}
+
+// journalTotal sums a transaction's debits. Mixed-currency entries leave the
+// currency empty so they are always compared as foreign to the threshold.
+func journalTotal(txn *Transaction) Amount {
+	var total Amount
+	seen, mixed := false, false
+	for _, entry := range txn.Entries {
+		if entry.Type != Debit {
+			continue
+		}
+		if !seen {
+			total.Currency = entry.Amount.Currency
+			seen = true
+		} else if total.Currency != entry.Amount.Currency {
+			mixed = true
+		}
+		total.Value += entry.Amount.Value
+	}
+	if mixed {
+		total.Currency = ""
+	}
+	return total
+}
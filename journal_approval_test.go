@@ -0,0 +1,131 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalApproval(t *testing.T) {
+	// Setup
+	dbFile := "test_journal_approval.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	preparer := "staff_accountant"
+	require.NoError(t, engine.CreateStandardAccounts(preparer))
+	engine.ApplyCompanySettings(&CompanySettings{
+		RequireApprovalOver: &Amount{Value: 100000, Currency: "USD"},
+	})
+	engine.SetJournalApprovers([]string{"controller", "cfo"})
+
+	date := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	journal := func(value int64) *Transaction {
+		txn := &Transaction{
+			Description: "Accrued expenses",
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "accounts_payable", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, preparer))
+		return txn
+	}
+	status := func(txnID string) TransactionStatus {
+		txn, err := engine.GetStorage().GetTransaction(txnID)
+		require.NoError(t, err)
+		return txn.Status
+	}
+	balance := func(accountID string) int64 {
+		result, err := engine.GetAccountBalance(accountID, date)
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+
+	t.Run("Below Threshold Posts Immediately", func(t *testing.T) {
+		txn := journal(50000)
+		require.NoError(t, engine.PostTransaction(txn.ID, preparer))
+		assert.Equal(t, Posted, status(txn.ID))
+		assert.Equal(t, int64(50000), balance("expenses"))
+	})
+
+	t.Run("Approve", func(t *testing.T) {
+		txn := journal(250000)
+		require.NoError(t, engine.PostTransaction(txn.ID, preparer))
+		assert.Equal(t, PendingApproval, status(txn.ID))
+		assert.Equal(t, int64(50000), balance("expenses"))
+
+		// Held entries cannot be posted around the approval
+		assert.Error(t, engine.PostTransaction(txn.ID, preparer))
+
+		pending, err := engine.GetPendingApprovals("controller")
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+		assert.Equal(t, txn.ID, pending[0].TransactionID)
+		assert.Equal(t, int64(250000), pending[0].Amount.Value)
+
+		pending, err = engine.GetPendingApprovals(preparer)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		_, err = engine.ApproveTransaction(txn.ID, "", preparer)
+		assert.Error(t, err)
+		_, err = engine.ApproveTransaction(txn.ID, "", "ap_clerk")
+		assert.Error(t, err)
+
+		approval, err := engine.ApproveTransaction(txn.ID, "Agrees to the accrual schedule", "controller")
+		require.NoError(t, err)
+		assert.Equal(t, JournalApprovalApproved, approval.Status)
+		assert.Equal(t, "controller", approval.DecidedBy)
+		assert.Equal(t, Posted, status(txn.ID))
+		assert.Equal(t, int64(300000), balance("expenses"))
+	})
+
+	t.Run("Reject And Resubmit", func(t *testing.T) {
+		txn := journal(400000)
+		require.NoError(t, engine.PostTransaction(txn.ID, preparer))
+
+		_, err := engine.RejectTransaction(txn.ID, "", "cfo")
+		assert.Error(t, err)
+
+		approval, err := engine.RejectTransaction(txn.ID, "Missing support for the vendor invoice", "cfo")
+		require.NoError(t, err)
+		assert.Equal(t, JournalApprovalRejected, approval.Status)
+		assert.Equal(t, Rejected, status(txn.ID))
+
+		pending, err := engine.GetPendingApprovals("cfo")
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		// Resubmitting puts it back in the queue with its history intact
+		require.NoError(t, engine.PostTransaction(txn.ID, preparer))
+		assert.Equal(t, PendingApproval, status(txn.ID))
+
+		approval, err = engine.GetJournalApprovalService().GetApproval(txn.ID)
+		require.NoError(t, err)
+		require.Len(t, approval.History, 3)
+		assert.Equal(t, "SUBMITTED", approval.History[0].Action)
+		assert.Equal(t, "REJECTED", approval.History[1].Action)
+		assert.Equal(t, "Missing support for the vendor invoice", approval.History[1].Comment)
+		assert.Equal(t, "SUBMITTED", approval.History[2].Action)
+	})
+
+	t.Run("State Changes Are Recorded", func(t *testing.T) {
+		events, err := engine.GetStorage().GetEvents(time.Unix(0, 0), time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		counts := make(map[string]int)
+		for _, event := range events {
+			counts[event.EventType]++
+		}
+		assert.Equal(t, 3, counts[EventSubmitJournalApproval])
+		assert.Equal(t, 1, counts[EventApproveJournal])
+		assert.Equal(t, 1, counts[EventRejectJournal])
+	})
+}
@@ -0,0 +1,321 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Ledgers and Cross-Ledger Posting
+// ----------------------------------------------------------------------------
+
+// generalLedgerName labels accounts and transactions not assigned to a ledger
+const generalLedgerName = "General Ledger"
+
+// LedgerPostingRule lets transactions recorded in one ledger post to accounts of
+// another, such as AR invoices crediting revenue in the general ledger. An empty
+// ledger ID stands for the general ledger of unassigned accounts.
+type LedgerPostingRule struct {
+	ID           string    `json:"id"`
+	FromLedgerID string    `json:"from_ledger_id"`
+	ToLedgerID   string    `json:"to_ledger_id"`
+	AccountIDs   []string  `json:"account_ids,omitempty"` // accounts of the target ledger it opens; empty for all
+	CreatedBy    string    `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// LedgerTrialBalance is the trial balance of the accounts kept in one ledger. A
+// ledger only balances on its own when no cross-ledger postings touch it;
+// InterLedgerBalance is what those postings leave behind.
+type LedgerTrialBalance struct {
+	LedgerID           string           `json:"ledger_id"` // empty for the general ledger
+	LedgerName         string           `json:"ledger_name"`
+	LedgerType         LedgerType       `json:"ledger_type"`
+	Balances           []*BalanceResult `json:"balances"`
+	TotalDebits        int64            `json:"total_debits"`
+	TotalCredits       int64            `json:"total_credits"`
+	InterLedgerBalance int64            `json:"inter_ledger_balance"` // debits less credits
+}
+
+// CombinedTrialBalance lays out each ledger's trial balance with totals across all
+// of them, which balance whenever the books do
+type CombinedTrialBalance struct {
+	AsOfDate     time.Time             `json:"as_of_date"`
+	Currency     string                `json:"currency"`
+	Ledgers      []*LedgerTrialBalance `json:"ledgers"`
+	TotalDebits  int64                 `json:"total_debits"`
+	TotalCredits int64                 `json:"total_credits"`
+}
+
+// LedgerService keeps accounts in separate ledgers and polices postings between them
+type LedgerService struct {
+	storage    *Storage
+	eventStore *EventStore
+	reporting  *ReportingService
+}
+
+// NewLedgerService creates a new ledger service
+func NewLedgerService(storage *Storage, eventStore *EventStore, reporting *ReportingService) *LedgerService {
+	return &LedgerService{
+		storage:    storage,
+		eventStore: eventStore,
+		reporting:  reporting,
+	}
+}
+
+// CreateLedger creates a ledger accounts can be assigned to
+func (ls *LedgerService) CreateLedger(ledger *Ledger, userID string) error {
+	if ledger.Name == "" {
+		return fmt.Errorf("ledger name is required")
+	}
+	switch ledger.Type {
+	case GeneralLedger, AccountsReceivable, AccountsPayable, InventoryLedger:
+	default:
+		return fmt.Errorf("unsupported ledger type: %s", ledger.Type)
+	}
+	if err := ls.storage.assignID(&ledger.ID, "ledger", BucketLedgers); err != nil {
+		return err
+	}
+
+	_, err := ls.eventStore.CreateEvent(EventCreateLedger, ledger, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create ledger event: %w", err)
+	}
+	return ls.storage.SaveLedger(ledger)
+}
+
+// AssignAccount moves an account into a ledger; an empty ledgerID returns it to the
+// general ledger. Postings already made move with the account.
+func (ls *LedgerService) AssignAccount(accountID, ledgerID, userID string) (*Account, error) {
+	account, err := ls.storage.GetAccount(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+	if ledgerID != "" {
+		if _, err := ls.storage.GetLedger(ledgerID); err != nil {
+			return nil, fmt.Errorf("invalid ledger: %w", err)
+		}
+	}
+
+	account.LedgerID = ledgerID
+	_, err = ls.eventStore.CreateEvent(EventUpdateAccount, AccountUpdatedEvent{Account: account}, time.Now(), userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create account update event: %w", err)
+	}
+	if err := ls.storage.SaveAccount(account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// SavePostingRule allows postings from one ledger to another
+func (ls *LedgerService) SavePostingRule(rule *LedgerPostingRule, userID string) error {
+	if rule.FromLedgerID == rule.ToLedgerID {
+		return fmt.Errorf("a posting rule must join two different ledgers")
+	}
+	for _, ledgerID := range []string{rule.FromLedgerID, rule.ToLedgerID} {
+		if ledgerID == "" {
+			continue
+		}
+		if _, err := ls.storage.GetLedger(ledgerID); err != nil {
+			return fmt.Errorf("invalid ledger: %w", err)
+		}
+	}
+	for _, accountID := range rule.AccountIDs {
+		account, err := ls.storage.GetAccount(accountID)
+		if err != nil {
+			return fmt.Errorf("invalid account: %w", err)
+		}
+		if account.LedgerID != rule.ToLedgerID {
+			return fmt.Errorf("account %s is not kept in %s", accountID, ls.ledgerName(rule.ToLedgerID))
+		}
+	}
+
+	if rule.ID == "" {
+		if err := ls.storage.assignID(&rule.ID, "ledger_posting_rule", BucketLedgerPostingRules); err != nil {
+			return err
+		}
+		rule.CreatedBy = userID
+		rule.CreatedAt = time.Now()
+	}
+
+	_, err := ls.eventStore.CreateEvent(EventSaveLedgerPostingRule, rule, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create ledger posting rule event: %w", err)
+	}
+	return ls.storage.SaveLedgerPostingRule(rule)
+}
+
+// ValidateTransaction checks a transaction only reaches other ledgers through posting
+// rules. A transaction is recorded in its LedgerID, or else in the ledger of its
+// entries, or the general ledger when they span several. Registered with the posting
+// engine; transactions within one ledger always pass.
+func (ls *LedgerService) ValidateTransaction(txn *Transaction) error {
+	home := txn.LedgerID
+	if home != "" {
+		if _, err := ls.storage.GetLedger(home); err != nil {
+			return fmt.Errorf("transaction ledger: %w", err)
+		}
+	}
+
+	ledgerOf := make(map[string]string, len(txn.Entries))
+	var ledgers []string
+	for _, entry := range txn.Entries {
+		account, err := ls.storage.GetAccount(entry.AccountID)
+		if err != nil {
+			continue // reported by the account check
+		}
+		ledgerOf[account.ID] = account.LedgerID
+		if !slices.Contains(ledgers, account.LedgerID) {
+			ledgers = append(ledgers, account.LedgerID)
+		}
+	}
+	if len(ledgers) == 1 && (home == "" || home == ledgers[0]) {
+		return nil
+	}
+
+	rules, err := ls.storage.GetAllLedgerPostingRules()
+	if err != nil {
+		return fmt.Errorf("failed to get ledger posting rules: %w", err)
+	}
+	for _, entry := range txn.Entries {
+		accountID := entry.AccountID
+		ledgerID, ok := ledgerOf[accountID]
+		if !ok || ledgerID == home {
+			continue
+		}
+		allowed := slices.ContainsFunc(rules, func(rule *LedgerPostingRule) bool {
+			return rule.FromLedgerID == home && rule.ToLedgerID == ledgerID &&
+				(len(rule.AccountIDs) == 0 || slices.Contains(rule.AccountIDs, accountID))
+		})
+		if !allowed {
+			return fmt.Errorf("no posting rule lets %s post to account %s in %s",
+				ls.ledgerName(home), accountID, ls.ledgerName(ledgerID))
+		}
+	}
+	return nil
+}
+
+// GenerateLedgerTrialBalance returns the trial balance of one ledger, or of the
+// general ledger of unassigned accounts when ledgerID is empty
+func (ls *LedgerService) GenerateLedgerTrialBalance(ledgerID string, asOfDate time.Time, currency string) (*LedgerTrialBalance, error) {
+	if ledgerID != "" {
+		if _, err := ls.storage.GetLedger(ledgerID); err != nil {
+			return nil, fmt.Errorf("invalid ledger: %w", err)
+		}
+	}
+	combined, err := ls.GenerateCombinedTrialBalance(asOfDate, currency)
+	if err != nil {
+		return nil, err
+	}
+	for _, ledger := range combined.Ledgers {
+		if ledger.LedgerID == ledgerID {
+			return ledger, nil
+		}
+	}
+	return ls.newLedgerTrialBalance(ledgerID), nil
+}
+
+// GenerateCombinedTrialBalance returns every ledger's trial balance, the general
+// ledger first and the rest by name, with totals across them. The general ledger
+// of unassigned accounts is left out when every account is assigned.
+func (ls *LedgerService) GenerateCombinedTrialBalance(asOfDate time.Time, currency string) (*CombinedTrialBalance, error) {
+	trialBalance, err := ls.reporting.GenerateTrialBalance(asOfDate, currency)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := ls.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	ledgerOf := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		ledgerOf[account.ID] = account.LedgerID
+	}
+	ledgers, err := ls.storage.GetAllLedgers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledgers: %w", err)
+	}
+	sort.Slice(ledgers, func(i, j int) bool { return ledgers[i].Name < ledgers[j].Name })
+
+	combined := &CombinedTrialBalance{AsOfDate: asOfDate, Currency: currency}
+	byID := make(map[string]*LedgerTrialBalance, len(ledgers)+1)
+	general := ls.newLedgerTrialBalance("")
+	byID[""] = general
+	for _, ledger := range ledgers {
+		byID[ledger.ID] = &LedgerTrialBalance{LedgerID: ledger.ID, LedgerName: ledger.Name, LedgerType: ledger.Type}
+	}
+
+	for _, balance := range trialBalance {
+		section := byID[ledgerOf[balance.AccountID]]
+		section.Balances = append(section.Balances, balance)
+		value := balance.Balance.Value
+		if balance.AccountType != Asset && balance.AccountType != Expense {
+			value = -value
+		}
+		if value >= 0 {
+			section.TotalDebits += value
+		} else {
+			section.TotalCredits -= value
+		}
+	}
+
+	if len(general.Balances) > 0 {
+		combined.Ledgers = append(combined.Ledgers, general)
+	}
+	for _, ledger := range ledgers {
+		combined.Ledgers = append(combined.Ledgers, byID[ledger.ID])
+	}
+	for _, section := range combined.Ledgers {
+		section.InterLedgerBalance = section.TotalDebits - section.TotalCredits
+		combined.TotalDebits += section.TotalDebits
+		combined.TotalCredits += section.TotalCredits
+	}
+	return combined, nil
+}
+
+// FormatCombinedTrialBalance lays out a combined trial balance for reading
+func FormatCombinedTrialBalance(tb *CombinedTrialBalance) string {
+	var out strings.Builder
+	currency := Currency(tb.Currency)
+	fmt.Fprintf(&out, "COMBINED TRIAL BALANCE as of %s (%s)\n", tb.AsOfDate.Format(time.DateOnly), tb.Currency)
+	for _, ledger := range tb.Ledgers {
+		fmt.Fprintf(&out, "\n%s (%s)\n", ledger.LedgerName, ledger.LedgerType)
+		for _, balance := range ledger.Balances {
+			fmt.Fprintf(&out, "  %-40s %15s\n", balance.AccountName, FormatMinorUnits(balance.Balance.Value, currency))
+		}
+		fmt.Fprintf(&out, "  %-40s %15s %15s\n", "Total debits / credits", FormatMinorUnits(ledger.TotalDebits, currency), FormatMinorUnits(ledger.TotalCredits, currency))
+		if ledger.InterLedgerBalance != 0 {
+			fmt.Fprintf(&out, "  %-40s %15s\n", "Inter-ledger balance", FormatMinorUnits(ledger.InterLedgerBalance, currency))
+		}
+	}
+	fmt.Fprintf(&out, "\n%-42s %15s %15s\n", "All ledgers", FormatMinorUnits(tb.TotalDebits, currency), FormatMinorUnits(tb.TotalCredits, currency))
+	return out.String()
+}
+
+// newLedgerTrialBalance starts an empty trial balance for a ledger
+func (ls *LedgerService) newLedgerTrialBalance(ledgerID string) *LedgerTrialBalance {
+	if ledgerID == "" {
+		return &LedgerTrialBalance{LedgerName: generalLedgerName, LedgerType: GeneralLedger}
+	}
+	tb := &LedgerTrialBalance{LedgerID: ledgerID, LedgerName: ledgerID}
+	if ledger, err := ls.storage.GetLedger(ledgerID); err == nil {
+		tb.LedgerName = ledger.Name
+		tb.LedgerType = ledger.Type
+	}
+	return tb
+}
+
+// ledgerName names a ledger for messages
+func (ls *LedgerService) ledgerName(ledgerID string) string {
+	if ledgerID == "" {
+		return "the " + strings.ToLower(generalLedgerName)
+	}
+	if ledger, err := ls.storage.GetLedger(ledgerID); err == nil {
+		return "ledger " + ledger.Name
+	}
+	return "ledger " + ledgerID
+}
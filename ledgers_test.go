@@ -0,0 +1,107 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgers(t *testing.T) {
+	dbFile := "test_ledgers.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	receivables := &Ledger{Name: "Receivables", Type: AccountsReceivable, Currency: "USD"}
+	require.NoError(t, engine.CreateLedger(receivables, userID))
+	_, err = engine.AssignAccountLedger("accounts_receivable", receivables.ID, userID)
+	require.NoError(t, err)
+	require.NoError(t, engine.CreateAccount(&Account{ID: "ar_acme", Code: "1210", Name: "Acme Corp", Type: Asset, LedgerID: receivables.ID}, userID))
+
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	transaction := func(ledgerID, debit, credit string, value int64, date time.Time) *Transaction {
+		txn := &Transaction{
+			Description: "Activity",
+			ValidTime:   date,
+			LedgerID:    ledgerID,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		return txn
+	}
+
+	t.Run("postings within one ledger need no rule", func(t *testing.T) {
+		txn := transaction("", "ar_acme", "accounts_receivable", 2500, day(1))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		reversal, err := engine.ReverseTransaction(txn.ID, "Reclassified in error", userID)
+		require.NoError(t, err)
+		assert.Empty(t, reversal.LedgerID)
+	})
+
+	invoice := transaction(receivables.ID, "accounts_receivable", "revenue", 10000, day(2))
+	receipt := transaction("", "cash", "accounts_receivable", 4000, day(9))
+
+	t.Run("cross-ledger postings follow posting rules", func(t *testing.T) {
+		assert.ErrorContains(t, engine.PostTransaction(invoice.ID, userID), "no posting rule lets ledger Receivables post to account revenue in the general ledger")
+		assert.ErrorContains(t, engine.PostTransaction(receipt.ID, userID), "no posting rule lets the general ledger post to account accounts_receivable in ledger Receivables")
+
+		require.NoError(t, engine.SaveLedgerPostingRule(&LedgerPostingRule{FromLedgerID: receivables.ID, AccountIDs: []string{"revenue"}}, userID))
+		require.NoError(t, engine.SaveLedgerPostingRule(&LedgerPostingRule{ToLedgerID: receivables.ID}, userID))
+		require.NoError(t, engine.PostTransaction(invoice.ID, userID))
+		require.NoError(t, engine.PostTransaction(receipt.ID, userID))
+
+		refund := transaction(receivables.ID, "accounts_receivable", "cash", 100, day(10))
+		assert.ErrorContains(t, engine.PostTransaction(refund.ID, userID), "account cash", "the rule opens revenue only")
+	})
+
+	t.Run("per-ledger and combined trial balances", func(t *testing.T) {
+		ar, err := engine.GenerateLedgerTrialBalance(receivables.ID, day(31), "USD")
+		require.NoError(t, err)
+		assert.Equal(t, "Receivables", ar.LedgerName)
+		assert.Len(t, ar.Balances, 2)
+		assert.Equal(t, int64(6000), ar.TotalDebits)
+		assert.Zero(t, ar.TotalCredits)
+		assert.Equal(t, int64(6000), ar.InterLedgerBalance)
+
+		combined, err := engine.GenerateCombinedTrialBalance(day(31), "USD")
+		require.NoError(t, err)
+		require.Len(t, combined.Ledgers, 2)
+		general := combined.Ledgers[0]
+		assert.Empty(t, general.LedgerID)
+		assert.Equal(t, int64(4000), general.TotalDebits)
+		assert.Equal(t, int64(10000), general.TotalCredits)
+		assert.Equal(t, int64(-6000), general.InterLedgerBalance)
+		assert.Equal(t, int64(10000), combined.TotalDebits)
+		assert.Equal(t, combined.TotalDebits, combined.TotalCredits)
+		assert.Contains(t, FormatCombinedTrialBalance(combined), "Receivables (AR)")
+	})
+
+	t.Run("reversals stay in the original ledger", func(t *testing.T) {
+		reversal, err := engine.ReverseTransaction(invoice.ID, "Invoice cancelled", userID)
+		require.NoError(t, err)
+		assert.Equal(t, receivables.ID, reversal.LedgerID)
+	})
+
+	t.Run("invalid ledgers and rules", func(t *testing.T) {
+		assert.Error(t, engine.CreateLedger(&Ledger{Name: "Fixed assets", Type: "FA"}, userID))
+		assert.Error(t, engine.SaveLedgerPostingRule(&LedgerPostingRule{FromLedgerID: receivables.ID, ToLedgerID: receivables.ID}, userID))
+		assert.ErrorContains(t, engine.SaveLedgerPostingRule(&LedgerPostingRule{ToLedgerID: receivables.ID, AccountIDs: []string{"cash"}}, userID), "not kept in ledger Receivables")
+		assert.Error(t, engine.CreateAccount(&Account{ID: "ap_globex", Code: "2010", Name: "Globex", Type: Liability, LedgerID: "missing"}, userID))
+		_, err := engine.AssignAccountLedger("cash", "missing", userID)
+		assert.Error(t, err)
+
+		txn := transaction("missing", "cash", "revenue", 100, day(11))
+		assert.ErrorContains(t, engine.PostTransaction(txn.ID, userID), "transaction ledger")
+	})
+}
@@ -0,0 +1,36 @@
+package accounting
+
+import "log/slog"
+
+// ----------------------------------------------------------------------------
+// Structured Logging
+// ----------------------------------------------------------------------------
+
+// Attribute keys used across the engine's logs, so hosts can filter and index on them
+const (
+	LogKeyComponent     = "component"
+	LogKeyTransactionID = "transaction_id"
+	LogKeyAccountID     = "account_id"
+	LogKeyUserID        = "user_id"
+	LogKeyRuleID        = "rule_id"
+	LogKeyRuleType      = "rule_type"
+	LogKeyAlertID       = "alert_id"
+	LogKeyViolationID   = "violation_id"
+	LogKeyScheduleID    = "schedule_id"
+	LogKeyRunID         = "run_id"
+	LogKeySubscription  = "subscription_id"
+	LogKeyNotification  = "notification_id"
+	LogKeyError         = "error"
+)
+
+// discardLogger drops every record; services use it until given a logger
+var discardLogger = slog.New(slog.DiscardHandler)
+
+// componentLogger tags a host's logger with the component logging through it, or
+// discards when the host gave none
+func componentLogger(logger *slog.Logger, component string) *slog.Logger {
+	if logger == nil {
+		return discardLogger
+	}
+	return logger.With(slog.String(LogKeyComponent, component))
+}
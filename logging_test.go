@@ -0,0 +1,146 @@
+package accounting
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logRecords decodes JSON log lines
+func logRecords(t *testing.T, output *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(output.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		record := make(map[string]any)
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+// findLogRecord returns the first record with a message, or nil
+func findLogRecord(records []map[string]any, message string) map[string]any {
+	for _, record := range records {
+		if record["msg"] == message {
+			return record
+		}
+	}
+	return nil
+}
+
+func TestStructuredLogging(t *testing.T) {
+	dbFile := "test_structured_logging.db"
+	defer os.Remove(dbFile)
+
+	var output bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	engine, err := NewAccountingEngineWithOptions(dbFile, StorageOptions{Logger: logger})
+	require.NoError(t, err)
+
+	userID := "teller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.GetAMLService().SetupStandardAMLRules(BSA_Framework))
+
+	deposit := &Transaction{
+		Description: "Cash deposit",
+		ValidTime:   time.Date(2025, 5, 6, 10, 0, 0, 0, time.UTC),
+		SourceRef:   "CASH-001",
+		Entries: []Entry{
+			{AccountID: "cash", Type: Debit, Amount: Amount{Value: 1500000, Currency: "USD"}},
+			{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 1500000, Currency: "USD"}},
+		},
+	}
+	require.NoError(t, engine.CreateTransaction(deposit, userID))
+	require.NoError(t, engine.PostTransaction(deposit.ID, userID))
+	assert.Error(t, engine.PostTransaction("missing", userID))
+	alerts, err := engine.GetAMLService().MonitorTransaction(deposit, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, alerts)
+
+	require.NoError(t, engine.GetComplianceService().SetupStandardComplianceRules(GAAP_Framework))
+	unbalanced := *deposit
+	unbalanced.Entries = deposit.Entries[:1]
+	violations, err := engine.GetComplianceService().ValidateTransaction(unbalanced)
+	require.NoError(t, err)
+	require.NotEmpty(t, violations)
+	require.NoError(t, engine.Close())
+
+	records := logRecords(t, &output)
+
+	opened := findLogRecord(records, "storage opened")
+	require.NotNil(t, opened)
+	assert.Equal(t, "storage", opened[LogKeyComponent])
+	assert.Equal(t, dbFile, opened["path"])
+	assert.NotNil(t, findLogRecord(records, "storage closed"))
+
+	posted := findLogRecord(records, "transaction posted")
+	require.NotNil(t, posted)
+	assert.Equal(t, "INFO", posted["level"])
+	assert.Equal(t, "engine", posted[LogKeyComponent])
+	assert.Equal(t, deposit.ID, posted[LogKeyTransactionID])
+	assert.Equal(t, userID, posted[LogKeyUserID])
+
+	failed := findLogRecord(records, "transaction not posted")
+	require.NotNil(t, failed)
+	assert.Equal(t, "WARN", failed["level"])
+	assert.Equal(t, "missing", failed[LogKeyTransactionID])
+	assert.Contains(t, failed[LogKeyError], "failed to get transaction")
+
+	var matched map[string]any
+	for _, record := range records {
+		if record["msg"] == "AML rule matched" && record[LogKeyRuleType] == string(RuleCTR) {
+			matched = record
+		}
+	}
+	require.NotNil(t, matched)
+	assert.Equal(t, "aml", matched[LogKeyComponent])
+	assert.NotEmpty(t, matched[LogKeyRuleID])
+	assert.Equal(t, string(RuleCTR), matched[LogKeyRuleType])
+	assert.Equal(t, deposit.ID, matched[LogKeyTransactionID])
+
+	var raised map[string]any
+	for _, record := range records {
+		if record["msg"] == "AML alert raised" && record[LogKeyAlertID] == matched[LogKeyAlertID] {
+			raised = record
+		}
+	}
+	require.NotNil(t, raised)
+	assert.Equal(t, "WARN", raised["level"])
+
+	violated := findLogRecord(records, "compliance rule violated")
+	require.NotNil(t, violated)
+	assert.Equal(t, "compliance", violated[LogKeyComponent])
+	assert.Equal(t, violations[0].RuleID, violated[LogKeyRuleID])
+	assert.Equal(t, deposit.ID, violated[LogKeyTransactionID])
+
+	t.Run("the handler's level filters records", func(t *testing.T) {
+		dbFile := "test_structured_logging_level.db"
+		defer os.Remove(dbFile)
+
+		var output bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&output, &slog.HandlerOptions{Level: slog.LevelWarn}))
+		engine, err := NewAccountingEngineWithOptions(dbFile, StorageOptions{Logger: logger})
+		require.NoError(t, err)
+		require.NoError(t, engine.CreateStandardAccounts(userID))
+		assert.Error(t, engine.PostTransaction("missing", userID))
+		require.NoError(t, engine.Close())
+
+		records := logRecords(t, &output)
+		require.Len(t, records, 1)
+		assert.Equal(t, "transaction not posted", records[0]["msg"])
+	})
+
+	t.Run("no logger logs nothing", func(t *testing.T) {
+		assert.Same(t, discardLogger, componentLogger(nil, "engine"))
+		assert.False(t, discardLogger.Enabled(nil, slog.LevelError))
+	})
+}
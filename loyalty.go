@@ -0,0 +1,510 @@
+package accounting
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Loyalty Structures
+// ----------------------------------------------------------------------------
+
+// LoyaltyProgram is a customer points scheme. Points are a material right (IFRS 15
+// B39-B43 / ASC 606-10-55-41): part of the sale price is allocated to them and
+// deferred until they are redeemed or expire.
+type LoyaltyProgram struct {
+	ID                      string    `json:"id"`
+	Name                    string    `json:"name"`
+	Currency                Currency  `json:"currency"`
+	PointValue              float64   `json:"point_value"`              // redemption value of one point in minor units
+	ExpectedRedemptionRate  float64   `json:"expected_redemption_rate"` // share of points expected to be redeemed
+	ExpiryMonths            int       `json:"expiry_months,omitempty"`  // zero for points that never expire
+	LiabilityAccountID      string    `json:"liability_account_id"`
+	RevenueAccountID        string    `json:"revenue_account_id"`
+	BreakageIncomeAccountID string    `json:"breakage_income_account_id"`
+	CreatedBy               string    `json:"created_by"`
+	CreatedAt               time.Time `json:"created_at"`
+}
+
+// LoyaltyPointLot is a block of points earned on one sale, consumed first-in first-out
+type LoyaltyPointLot struct {
+	ID              string     `json:"id"`
+	SaleRef         string     `json:"sale_ref,omitempty"`
+	EarnedAt        time.Time  `json:"earned_at"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	Points          int64      `json:"points"`
+	RemainingPoints int64      `json:"remaining_points"`
+	Value           int64      `json:"value"` // revenue deferred to the lot
+	RemainingValue  int64      `json:"remaining_value"`
+}
+
+// LoyaltyActivityType classifies a movement in the loyalty liability
+type LoyaltyActivityType string
+
+const (
+	LoyaltyEarn   LoyaltyActivityType = "EARN"
+	LoyaltyRedeem LoyaltyActivityType = "REDEEM"
+	LoyaltyExpire LoyaltyActivityType = "EXPIRE"
+)
+
+// LoyaltyActivity is one movement on a member account
+type LoyaltyActivity struct {
+	Type          LoyaltyActivityType `json:"type"`
+	Points        int64               `json:"points"`
+	Value         int64               `json:"value"`
+	Date          time.Time           `json:"date"`
+	TransactionID string              `json:"transaction_id"`
+}
+
+// LoyaltyMemberAccount holds a member's points in one program
+type LoyaltyMemberAccount struct {
+	ID        string            `json:"id"`
+	ProgramID string            `json:"program_id"`
+	MemberID  string            `json:"member_id"`
+	Lots      []LoyaltyPointLot `json:"lots"`
+	Activity  []LoyaltyActivity `json:"activity"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// PointsBalance returns the member's unexpired, unredeemed points
+func (la *LoyaltyMemberAccount) PointsBalance() int64 {
+	var points int64
+	for _, lot := range la.Lots {
+		points += lot.RemainingPoints
+	}
+	return points
+}
+
+// LoyaltyLiabilityReport rolls a program's deferred revenue forward across a period
+type LoyaltyLiabilityReport struct {
+	ProgramID         string    `json:"program_id"`
+	Currency          Currency  `json:"currency"`
+	PeriodStart       time.Time `json:"period_start"`
+	PeriodEnd         time.Time `json:"period_end"`
+	OpeningLiability  int64     `json:"opening_liability"`
+	Deferred          int64     `json:"deferred"`
+	Released          int64     `json:"released"`
+	Breakage          int64     `json:"breakage"`
+	ClosingLiability  int64     `json:"closing_liability"`
+	OpeningPoints     int64     `json:"opening_points"`
+	PointsEarned      int64     `json:"points_earned"`
+	PointsRedeemed    int64     `json:"points_redeemed"`
+	PointsExpired     int64     `json:"points_expired"`
+	ClosingPoints     int64     `json:"closing_points"`
+	MembersWithPoints int       `json:"members_with_points"`
+	GeneratedAt       time.Time `json:"generated_at"`
+}
+
+// ----------------------------------------------------------------------------
+// Loyalty Service
+// ----------------------------------------------------------------------------
+
+// LoyaltyService accounts for points earned, redeemed and expired
+type LoyaltyService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+}
+
+// NewLoyaltyService creates a new loyalty service
+func NewLoyaltyService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *LoyaltyService {
+	return &LoyaltyService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+	}
+}
+
+// CreateProgram validates and saves a loyalty program
+func (ls *LoyaltyService) CreateProgram(program *LoyaltyProgram, userID string) error {
+	if program.Name == "" {
+		return fmt.Errorf("program name is required")
+	}
+	if program.Currency == "" {
+		return fmt.Errorf("program currency is required")
+	}
+	if program.PointValue <= 0 {
+		return fmt.Errorf("point value must be positive")
+	}
+	if program.ExpectedRedemptionRate <= 0 || program.ExpectedRedemptionRate > 1 {
+		return fmt.Errorf("expected redemption rate must be greater than 0 and at most 1")
+	}
+	if program.ExpiryMonths < 0 {
+		return fmt.Errorf("expiry cannot be negative")
+	}
+	if program.RevenueAccountID == "" {
+		program.RevenueAccountID = DefaultRevenueAccountID
+	}
+	for _, accountID := range []string{program.LiabilityAccountID, program.RevenueAccountID, program.BreakageIncomeAccountID} {
+		if _, err := ls.storage.GetAccount(accountID); err != nil {
+			return fmt.Errorf("invalid account %q: %w", accountID, err)
+		}
+	}
+
+	if err := ls.storage.assignID(&program.ID, "loyalty program", BucketLoyaltyPrograms); err != nil {
+		return err
+	}
+	program.CreatedBy = userID
+	program.CreatedAt = time.Now()
+
+	_, err := ls.eventStore.CreateEvent(EventCreateLoyaltyProgram, program, program.CreatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create loyalty program event: %w", err)
+	}
+	if err := ls.storage.SaveLoyaltyProgram(program); err != nil {
+		return fmt.Errorf("failed to save loyalty program: %w", err)
+	}
+	return nil
+}
+
+// AwardPoints credits points earned on a sale and defers part of the sale's revenue
+// to them. The sale price is allocated between the goods and the points on relative
+// standalone selling prices, the points being valued at their redemption value
+// adjusted for the expected redemption rate.
+func (ls *LoyaltyService) AwardPoints(programID, memberID string, points, salePrice int64, earnedAt time.Time, saleRef, userID string) (*LoyaltyMemberAccount, error) {
+	program, err := ls.storage.GetLoyaltyProgram(programID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loyalty program: %w", err)
+	}
+	if memberID == "" {
+		return nil, fmt.Errorf("member is required")
+	}
+	if points <= 0 {
+		return nil, fmt.Errorf("points must be positive")
+	}
+	if salePrice <= 0 {
+		return nil, fmt.Errorf("sale price must be positive")
+	}
+
+	pointsSSP, err := decimalRat(program.PointValue)
+	if err != nil {
+		return nil, fmt.Errorf("invalid point value: %w", err)
+	}
+	redemptionRate, err := decimalRat(program.ExpectedRedemptionRate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expected redemption rate: %w", err)
+	}
+	pointsSSP.Mul(pointsSSP, redemptionRate)
+	pointsSSP.Mul(pointsSSP, new(big.Rat).SetInt64(points))
+	price := new(big.Rat).SetInt64(salePrice)
+	allocated := new(big.Rat).Mul(price, pointsSSP)
+	allocated.Quo(allocated, new(big.Rat).Add(price, pointsSSP))
+	deferred, err := roundRat(allocated, RoundHalfUp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate sale price: %w", err)
+	}
+
+	account := ls.memberAccount(program.ID, memberID)
+	lot := LoyaltyPointLot{
+		ID:              newID(),
+		SaleRef:         saleRef,
+		EarnedAt:        earnedAt,
+		Points:          points,
+		RemainingPoints: points,
+		Value:           deferred,
+		RemainingValue:  deferred,
+	}
+	if program.ExpiryMonths > 0 {
+		expiresAt := earnedAt.AddDate(0, program.ExpiryMonths, 0)
+		lot.ExpiresAt = &expiresAt
+	}
+
+	var txnID string
+	if deferred > 0 {
+		txn := ls.newTransaction(fmt.Sprintf("Loyalty points earned by %s", memberID), earnedAt, fmt.Sprintf("LOYALTY_EARN_%s", lot.ID), userID)
+		txn.Entries = loyaltyEntries(txn.ID, program.RevenueAccountID, program.LiabilityAccountID, deferred, program.Currency, memberID)
+		if err := ls.postTransaction(txn, userID); err != nil {
+			return nil, err
+		}
+		txnID = txn.ID
+	}
+
+	account.Lots = append(account.Lots, lot)
+	account.Activity = append(account.Activity, LoyaltyActivity{Type: LoyaltyEarn, Points: points, Value: deferred, Date: earnedAt, TransactionID: txnID})
+
+	if err := ls.saveAccount(account, EventAwardLoyaltyPoints, earnedAt, userID); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// RedeemPoints spends a member's points, oldest first, and releases the revenue
+// deferred to them
+func (ls *LoyaltyService) RedeemPoints(programID, memberID string, points int64, redeemedAt time.Time, userID string) (*LoyaltyMemberAccount, error) {
+	program, err := ls.storage.GetLoyaltyProgram(programID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loyalty program: %w", err)
+	}
+	if points <= 0 {
+		return nil, fmt.Errorf("points must be positive")
+	}
+	account, err := ls.storage.GetLoyaltyMemberAccount(loyaltyAccountID(program.ID, memberID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loyalty account: %w", err)
+	}
+
+	var available int64
+	for _, lot := range account.Lots {
+		if lot.ExpiresAt == nil || lot.ExpiresAt.After(redeemedAt) {
+			available += lot.RemainingPoints
+		}
+	}
+	if points > available {
+		return nil, fmt.Errorf("redemption of %d points exceeds available balance of %d", points, available)
+	}
+
+	var released int64
+	remaining := points
+	for i := range account.Lots {
+		lot := &account.Lots[i]
+		if remaining == 0 {
+			break
+		}
+		if lot.RemainingPoints == 0 || lot.ExpiresAt != nil && !lot.ExpiresAt.After(redeemedAt) {
+			continue
+		}
+		used := remaining
+		if used > lot.RemainingPoints {
+			used = lot.RemainingPoints
+		}
+		release, err := lotRelease(lot, used)
+		if err != nil {
+			return nil, err
+		}
+		lot.RemainingPoints -= used
+		lot.RemainingValue -= release
+		released += release
+		remaining -= used
+	}
+
+	var txnID string
+	if released > 0 {
+		txn := ls.newTransaction(fmt.Sprintf("Loyalty points redeemed by %s", memberID), redeemedAt, fmt.Sprintf("LOYALTY_REDEEM_%s", newID()), userID)
+		txn.Entries = loyaltyEntries(txn.ID, program.LiabilityAccountID, program.RevenueAccountID, released, program.Currency, memberID)
+		if err := ls.postTransaction(txn, userID); err != nil {
+			return nil, err
+		}
+		txnID = txn.ID
+	}
+	account.Activity = append(account.Activity, LoyaltyActivity{Type: LoyaltyRedeem, Points: points, Value: released, Date: redeemedAt, TransactionID: txnID})
+
+	if err := ls.saveAccount(account, EventRedeemLoyaltyPoints, redeemedAt, userID); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// ExpirePoints recognizes breakage on every lot that has expired by the date. Safe to
+// run at each period close.
+func (ls *LoyaltyService) ExpirePoints(asOfDate time.Time, userID string) ([]*Transaction, error) {
+	accounts, err := ls.storage.GetAllLoyaltyMemberAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loyalty accounts: %w", err)
+	}
+	programs := make(map[string]*LoyaltyProgram)
+
+	var posted []*Transaction
+	for _, account := range accounts {
+		var points, value int64
+		for i := range account.Lots {
+			lot := &account.Lots[i]
+			if lot.RemainingPoints == 0 || lot.ExpiresAt == nil || lot.ExpiresAt.After(asOfDate) {
+				continue
+			}
+			points += lot.RemainingPoints
+			value += lot.RemainingValue
+			lot.RemainingPoints = 0
+			lot.RemainingValue = 0
+		}
+		if points == 0 {
+			continue
+		}
+
+		program, ok := programs[account.ProgramID]
+		if !ok {
+			program, err = ls.storage.GetLoyaltyProgram(account.ProgramID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get loyalty program: %w", err)
+			}
+			programs[account.ProgramID] = program
+		}
+
+		var txnID string
+		if value > 0 {
+			txn := ls.newTransaction(fmt.Sprintf("Loyalty points expired for %s", account.MemberID), asOfDate, fmt.Sprintf("LOYALTY_EXPIRE_%s_%s", account.ID, asOfDate.Format("2006-01-02")), userID)
+			txn.Entries = loyaltyEntries(txn.ID, program.LiabilityAccountID, program.BreakageIncomeAccountID, value, program.Currency, account.MemberID)
+			if err := ls.postTransaction(txn, userID); err != nil {
+				return nil, err
+			}
+			txnID = txn.ID
+			posted = append(posted, txn)
+		}
+		account.Activity = append(account.Activity, LoyaltyActivity{Type: LoyaltyExpire, Points: points, Value: value, Date: asOfDate, TransactionID: txnID})
+
+		if err := ls.saveAccount(account, EventExpireLoyaltyPoints, asOfDate, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return posted, nil
+}
+
+// GenerateLiabilityReport rolls a program's liability and points forward across a period
+func (ls *LoyaltyService) GenerateLiabilityReport(programID string, periodStart, periodEnd time.Time) (*LoyaltyLiabilityReport, error) {
+	program, err := ls.storage.GetLoyaltyProgram(programID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loyalty program: %w", err)
+	}
+	accounts, err := ls.storage.GetAllLoyaltyMemberAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loyalty accounts: %w", err)
+	}
+
+	report := &LoyaltyLiabilityReport{
+		ProgramID:   program.ID,
+		Currency:    program.Currency,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		GeneratedAt: time.Now(),
+	}
+	for _, account := range accounts {
+		if account.ProgramID != program.ID {
+			continue
+		}
+		var points int64
+		for _, activity := range account.Activity {
+			if activity.Date.After(periodEnd) {
+				continue
+			}
+			sign := int64(-1)
+			if activity.Type == LoyaltyEarn {
+				sign = 1
+			}
+			points += sign * activity.Points
+			if activity.Date.Before(periodStart) {
+				report.OpeningLiability += sign * activity.Value
+				report.OpeningPoints += sign * activity.Points
+				continue
+			}
+			switch activity.Type {
+			case LoyaltyEarn:
+				report.Deferred += activity.Value
+				report.PointsEarned += activity.Points
+			case LoyaltyRedeem:
+				report.Released += activity.Value
+				report.PointsRedeemed += activity.Points
+			case LoyaltyExpire:
+				report.Breakage += activity.Value
+				report.PointsExpired += activity.Points
+			}
+		}
+		if points > 0 {
+			report.MembersWithPoints++
+		}
+	}
+	report.ClosingLiability = report.OpeningLiability + report.Deferred - report.Released - report.Breakage
+	report.ClosingPoints = report.OpeningPoints + report.PointsEarned - report.PointsRedeemed - report.PointsExpired
+
+	return report, nil
+}
+
+// GetMemberAccount returns a member's points account in a program
+func (ls *LoyaltyService) GetMemberAccount(programID, memberID string) (*LoyaltyMemberAccount, error) {
+	return ls.storage.GetLoyaltyMemberAccount(loyaltyAccountID(programID, memberID))
+}
+
+// memberAccount loads a member's account, opening one on first award
+func (ls *LoyaltyService) memberAccount(programID, memberID string) *LoyaltyMemberAccount {
+	id := loyaltyAccountID(programID, memberID)
+	if account, err := ls.storage.GetLoyaltyMemberAccount(id); err == nil {
+		return account
+	}
+	return &LoyaltyMemberAccount{ID: id, ProgramID: programID, MemberID: memberID}
+}
+
+// lotRelease returns the deferred revenue released when points are used from a lot;
+// the last points in a lot release whatever value remains so no rounding is left behind
+func lotRelease(lot *LoyaltyPointLot, used int64) (int64, error) {
+	if used == lot.RemainingPoints {
+		return lot.RemainingValue, nil
+	}
+	share := new(big.Rat).SetFrac64(lot.Value*used, lot.Points)
+	release, err := roundRat(share, RoundHalfUp)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute loyalty release: %w", err)
+	}
+	if release > lot.RemainingValue {
+		release = lot.RemainingValue
+	}
+	return release, nil
+}
+
+// loyaltyEntries builds a two-line entry tagged with the member
+func loyaltyEntries(txnID, debitAccountID, creditAccountID string, value int64, currency Currency, memberID string) []Entry {
+	amount := Amount{Value: value, Currency: currency}
+	dimensions := []Dimension{{Key: DimCounterparty, Value: memberID}}
+	return []Entry{
+		{ID: newID(), TransactionID: txnID, AccountID: debitAccountID, Type: Debit, Amount: amount, Dimensions: dimensions},
+		{ID: newID(), TransactionID: txnID, AccountID: creditAccountID, Type: Credit, Amount: amount, Dimensions: dimensions},
+	}
+}
+
+// loyaltyAccountID is the storage key of a member's account in a program
+func loyaltyAccountID(programID, memberID string) string {
+	return programID + "_" + memberID
+}
+
+// newTransaction creates a pending loyalty transaction without entries
+func (ls *LoyaltyService) newTransaction(description string, validTime time.Time, sourceRef, userID string) *Transaction {
+	return &Transaction{
+		ID:              newID(),
+		Description:     description,
+		ValidTime:       validTime,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       sourceRef,
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// postTransaction records, saves and posts a loyalty transaction
+func (ls *LoyaltyService) postTransaction(txn *Transaction, userID string) error {
+	_, err := ls.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := ls.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	if err := ls.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
+
+// saveAccount records an event for the member account and persists it
+func (ls *LoyaltyService) saveAccount(account *LoyaltyMemberAccount, eventType string, validTime time.Time, userID string) error {
+	sort.SliceStable(account.Lots, func(i, j int) bool {
+		return account.Lots[i].EarnedAt.Before(account.Lots[j].EarnedAt)
+	})
+	account.UpdatedAt = time.Now()
+	_, err := ls.eventStore.CreateEvent(eventType, account, validTime, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create loyalty account event: %w", err)
+	}
+	if err := ls.storage.SaveLoyaltyMemberAccount(account); err != nil {
+		return fmt.Errorf("failed to save loyalty account: %w", err)
+	}
+	return nil
+}
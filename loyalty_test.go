@@ -0,0 +1,166 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoyaltyPoints(t *testing.T) {
+	// Setup
+	dbFile := "test_loyalty.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "crm_sync"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	accounts := []*Account{
+		{ID: "loyalty_liability", Code: "2450", Name: "Loyalty Points Liability", Type: Liability},
+		{ID: "loyalty_breakage", Code: "4310", Name: "Loyalty Breakage Income", Type: Income},
+	}
+	for _, account := range accounts {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	balance := func(accountID string) int64 {
+		result, err := engine.GetAccountBalance(accountID, date(2030, 1, 1))
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+	sell := func(value int64, when time.Time) {
+		txn := &Transaction{
+			Description: "Store sale",
+			ValidTime:   when,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+
+	program := &LoyaltyProgram{
+		Name:                    "Rewards",
+		Currency:                "USD",
+		PointValue:              1,
+		ExpectedRedemptionRate:  0.8,
+		ExpiryMonths:            12,
+		LiabilityAccountID:      "loyalty_liability",
+		BreakageIncomeAccountID: "loyalty_breakage",
+	}
+
+	t.Run("Program Validation", func(t *testing.T) {
+		err := engine.CreateLoyaltyProgram(&LoyaltyProgram{
+			Name: "Bad", Currency: "USD", PointValue: 1, ExpectedRedemptionRate: 0,
+			LiabilityAccountID: "loyalty_liability", BreakageIncomeAccountID: "loyalty_breakage",
+		}, userID)
+		assert.Error(t, err)
+		require.NoError(t, engine.CreateLoyaltyProgram(program, userID))
+	})
+
+	t.Run("Earn Defers Revenue", func(t *testing.T) {
+		sell(10000, date(2024, 1, 10))
+		account, err := engine.AwardLoyaltyPoints(program.ID, "member_1", 1000, 10000, date(2024, 1, 10), "SALE-1", userID)
+		require.NoError(t, err)
+
+		// 10000 * 800 / (10000 + 800) allocated to the points
+		require.Len(t, account.Lots, 1)
+		assert.Equal(t, int64(741), account.Lots[0].Value)
+		assert.Equal(t, int64(741), balance("loyalty_liability"))
+		assert.Equal(t, int64(9259), balance("revenue"))
+	})
+
+	t.Run("Redemption Releases Oldest Points First", func(t *testing.T) {
+		account, err := engine.RedeemLoyaltyPoints(program.ID, "member_1", 400, date(2024, 3, 1), userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(296), account.Activity[1].Value)
+		assert.Equal(t, int64(445), balance("loyalty_liability"))
+
+		sell(5000, date(2024, 6, 1))
+		_, err = engine.AwardLoyaltyPoints(program.ID, "member_1", 500, 5000, date(2024, 6, 1), "SALE-2", userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(815), balance("loyalty_liability"))
+
+		// Finishing the first lot releases its remaining value exactly
+		account, err = engine.RedeemLoyaltyPoints(program.ID, "member_1", 700, date(2024, 7, 1), userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(445+74), account.Activity[3].Value)
+		assert.Equal(t, int64(400), account.PointsBalance())
+		assert.Equal(t, int64(296), balance("loyalty_liability"))
+
+		_, err = engine.RedeemLoyaltyPoints(program.ID, "member_1", 401, date(2024, 8, 1), userID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Expiry Recognizes Breakage", func(t *testing.T) {
+		posted, err := engine.ExpireLoyaltyPoints(date(2025, 1, 31), userID)
+		require.NoError(t, err)
+		assert.Empty(t, posted)
+
+		_, err = engine.RedeemLoyaltyPoints(program.ID, "member_1", 100, date(2025, 6, 2), userID)
+		assert.Error(t, err)
+
+		posted, err = engine.ExpireLoyaltyPoints(date(2025, 6, 1), userID)
+		require.NoError(t, err)
+		assert.Len(t, posted, 1)
+		assert.Equal(t, int64(0), balance("loyalty_liability"))
+		assert.Equal(t, int64(296), balance("loyalty_breakage"))
+
+		posted, err = engine.ExpireLoyaltyPoints(date(2025, 6, 30), userID)
+		require.NoError(t, err)
+		assert.Empty(t, posted)
+
+		account, err := engine.GetLoyaltyService().GetMemberAccount(program.ID, "member_1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), account.PointsBalance())
+	})
+
+	t.Run("Liability Report", func(t *testing.T) {
+		report, err := engine.GenerateLoyaltyLiabilityReport(program.ID, date(2024, 1, 1), date(2024, 12, 31))
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), report.OpeningLiability)
+		assert.Equal(t, int64(1111), report.Deferred)
+		assert.Equal(t, int64(815), report.Released)
+		assert.Equal(t, int64(296), report.ClosingLiability)
+		assert.Equal(t, int64(1500), report.PointsEarned)
+		assert.Equal(t, int64(1100), report.PointsRedeemed)
+		assert.Equal(t, int64(400), report.ClosingPoints)
+		assert.Equal(t, 1, report.MembersWithPoints)
+
+		report, err = engine.GenerateLoyaltyLiabilityReport(program.ID, date(2025, 1, 1), date(2025, 6, 30))
+		require.NoError(t, err)
+		assert.Equal(t, int64(296), report.OpeningLiability)
+		assert.Equal(t, int64(296), report.Breakage)
+		assert.Equal(t, int64(0), report.ClosingLiability)
+		assert.Equal(t, int64(400), report.PointsExpired)
+		assert.Equal(t, int64(0), report.ClosingPoints)
+		assert.Equal(t, 0, report.MembersWithPoints)
+	})
+
+	t.Run("Half Points Round Up", func(t *testing.T) {
+		halfPoints := &LoyaltyProgram{
+			Name:                    "Stamps",
+			Currency:                "USD",
+			PointValue:              0.5,
+			ExpectedRedemptionRate:  0.3,
+			LiabilityAccountID:      "loyalty_liability",
+			BreakageIncomeAccountID: "loyalty_breakage",
+		}
+		require.NoError(t, engine.CreateLoyaltyProgram(halfPoints, userID))
+		sell(15, date(2025, 3, 1))
+
+		// 100 points are worth 100 * 0.5 * 0.3 = 15, so 15 * 15 / (15 + 15) = 7.5 is deferred
+		account, err := engine.AwardLoyaltyPoints(halfPoints.ID, "member_3", 100, 15, date(2025, 3, 1), "SALE-9", userID)
+		require.NoError(t, err)
+		require.Len(t, account.Lots, 1)
+		assert.Equal(t, int64(8), account.Lots[0].Value)
+	})
+}
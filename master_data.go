@@ -0,0 +1,490 @@
+package accounting
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// PartyType identifies which master a party belongs to
+type PartyType string
+
+const (
+	PartyCustomer PartyType = "CUSTOMER"
+	PartyVendor   PartyType = "VENDOR"
+)
+
+// Duplicate match reasons
+const (
+	MatchReasonName        = "NAME"
+	MatchReasonTaxID       = "TAX_ID"
+	MatchReasonBankAccount = "BANK_ACCOUNT"
+)
+
+// Customer is a receivables counterparty
+type Customer struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	TaxID       string    `json:"tax_id,omitempty"`
+	BankAccount string    `json:"bank_account,omitempty"`
+	Email       string    `json:"email,omitempty"`
+	Currency    Currency  `json:"currency,omitempty"`
+	Active      bool      `json:"active"`
+	MergedInto  string    `json:"merged_into,omitempty"` // surviving customer after a duplicate merge
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DuplicateMatch is a pair of parties that look like the same counterparty
+type DuplicateMatch struct {
+	PartyType     PartyType `json:"party_type"`
+	PartyID       string    `json:"party_id"`
+	PartyName     string    `json:"party_name"`
+	CandidateID   string    `json:"candidate_id"`
+	CandidateName string    `json:"candidate_name"`
+	Score         float64   `json:"score"` // 0 to 1; identifier matches score 1
+	Reasons       []string  `json:"reasons"`
+}
+
+// RepointedRecord is a historical record moved from the merged party to the survivor
+type RepointedRecord struct {
+	RecordType string `json:"record_type"` // e.g. VENDOR_BILL, TRANSACTION
+	RecordID   string `json:"record_id"`
+}
+
+// PartyMerge records a duplicate merge along with both parties as they were before it
+type PartyMerge struct {
+	ID               string            `json:"id"`
+	PartyType        PartyType         `json:"party_type"`
+	SurvivorID       string            `json:"survivor_id"`
+	MergedID         string            `json:"merged_id"`
+	SurvivorOriginal json.RawMessage   `json:"survivor_original"`
+	MergedOriginal   json.RawMessage   `json:"merged_original"`
+	Repointed        []RepointedRecord `json:"repointed"`
+	MergedBy         string            `json:"merged_by"`
+	MergedAt         time.Time         `json:"merged_at"`
+}
+
+// party is the view of a customer or vendor used for matching
+type party struct {
+	ID          string
+	Name        string
+	TaxID       string
+	BankAccount string
+}
+
+// legalSuffixes are dropped from names before comparing them
+var legalSuffixes = map[string]bool{
+	"the": true, "inc": true, "incorporated": true, "llc": true, "ltd": true, "limited": true,
+	"corp": true, "corporation": true, "co": true, "company": true, "plc": true, "gmbh": true, "sa": true,
+}
+
+// MasterDataService maintains customer and vendor masters, finds duplicates and merges them
+type MasterDataService struct {
+	storage    *Storage
+	eventStore *EventStore
+}
+
+// NewMasterDataService creates a new master data service
+func NewMasterDataService(storage *Storage, eventStore *EventStore) *MasterDataService {
+	return &MasterDataService{
+		storage:    storage,
+		eventStore: eventStore,
+	}
+}
+
+// CreateCustomer adds a customer to the master
+func (mds *MasterDataService) CreateCustomer(customer *Customer, userID string) error {
+	if customer.Name == "" {
+		return fmt.Errorf("customer name is required")
+	}
+
+	if err := mds.storage.assignID(&customer.ID, "customer", BucketCustomers); err != nil {
+		return err
+	}
+	customer.Active = true
+	customer.CreatedBy = userID
+	customer.CreatedAt = time.Now()
+
+	_, err := mds.eventStore.CreateEvent(EventCreateCustomer, customer, customer.CreatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create customer event: %w", err)
+	}
+
+	if err := mds.storage.SaveCustomer(customer); err != nil {
+		return fmt.Errorf("failed to save customer: %w", err)
+	}
+	return nil
+}
+
+// FindDuplicates compares every pair of active parties of a type and returns those
+// scoring at least minScore, best matches first. A shared tax ID or bank account is a
+// certain match; otherwise names are compared after dropping case, punctuation and
+// legal suffixes. Parties with different tax IDs are never matched on name alone.
+func (mds *MasterDataService) FindDuplicates(partyType PartyType, minScore float64) ([]*DuplicateMatch, error) {
+	parties, err := mds.activeParties(partyType)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*DuplicateMatch
+	for i := 0; i < len(parties); i++ {
+		for j := i + 1; j < len(parties); j++ {
+			match := compareParties(parties[i], parties[j], minScore)
+			if len(match.Reasons) == 0 {
+				continue
+			}
+			match.PartyType = partyType
+			matches = append(matches, match)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].PartyID < matches[j].PartyID
+	})
+	return matches, nil
+}
+
+// MergeParties folds a duplicate party into the survivor. Historical records that
+// reference the duplicate are re-pointed to the survivor, blank identifiers on the
+// survivor are filled from the duplicate, and the duplicate is deactivated. The merge
+// event keeps both parties exactly as they were before the merge.
+func (mds *MasterDataService) MergeParties(partyType PartyType, survivorID, mergedID, userID string) (*PartyMerge, error) {
+	if survivorID == mergedID {
+		return nil, fmt.Errorf("cannot merge a party into itself")
+	}
+
+	merge := &PartyMerge{
+		ID:         newID(),
+		PartyType:  partyType,
+		SurvivorID: survivorID,
+		MergedID:   mergedID,
+		MergedBy:   userID,
+		MergedAt:   time.Now(),
+	}
+
+	var writes *partyMergeWrites
+	var err error
+	switch partyType {
+	case PartyCustomer:
+		writes, err = mds.mergeCustomers(merge)
+	case PartyVendor:
+		writes, err = mds.mergeVendors(merge)
+	default:
+		return nil, fmt.Errorf("unknown party type %s", partyType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := mds.eventStore.CreateEvent(EventMergeParty, merge, merge.MergedAt, userID); err != nil {
+		return nil, fmt.Errorf("failed to create merge event: %w", err)
+	}
+	if err := mds.storage.CommitPartyMerge(merge, writes); err != nil {
+		return nil, fmt.Errorf("failed to save merge: %w", err)
+	}
+	return merge, nil
+}
+
+// partyMergeWrites is every record a merge changes. CommitPartyMerge writes them
+// with the merge itself, so a failed merge leaves no record re-pointed.
+type partyMergeWrites struct {
+	customers    []*Customer
+	vendors      []*Vendor
+	plans        []*InstallmentPlan
+	giftCards    []*GiftCard
+	bills        []*VendorBill
+	transactions []*Transaction
+}
+
+// mergeCustomers re-points installment plans, gift cards and ledger entries
+func (mds *MasterDataService) mergeCustomers(merge *PartyMerge) (*partyMergeWrites, error) {
+	survivor, err := mds.storage.GetCustomer(merge.SurvivorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get surviving customer: %w", err)
+	}
+	merged, err := mds.storage.GetCustomer(merge.MergedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merged customer: %w", err)
+	}
+	if !survivor.Active || !merged.Active {
+		return nil, fmt.Errorf("both customers must be active to merge")
+	}
+	if err := snapshotOriginals(merge, survivor, merged); err != nil {
+		return nil, err
+	}
+	writes := &partyMergeWrites{}
+
+	plans, err := mds.storage.GetAllInstallmentPlans()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installment plans: %w", err)
+	}
+	for _, plan := range plans {
+		if plan.CustomerID != merged.ID {
+			continue
+		}
+		plan.CustomerID = survivor.ID
+		writes.plans = append(writes.plans, plan)
+		merge.Repointed = append(merge.Repointed, RepointedRecord{RecordType: "INSTALLMENT_PLAN", RecordID: plan.ID})
+	}
+
+	cards, err := mds.storage.GetAllGiftCards()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift cards: %w", err)
+	}
+	for _, card := range cards {
+		if card.CustomerID != merged.ID {
+			continue
+		}
+		card.CustomerID = survivor.ID
+		writes.giftCards = append(writes.giftCards, card)
+		merge.Repointed = append(merge.Repointed, RepointedRecord{RecordType: "GIFT_CARD", RecordID: card.ID})
+	}
+
+	if writes.transactions, err = mds.repointTransactions(merge); err != nil {
+		return nil, err
+	}
+
+	if survivor.TaxID == "" {
+		survivor.TaxID = merged.TaxID
+	}
+	if survivor.BankAccount == "" {
+		survivor.BankAccount = merged.BankAccount
+	}
+	if survivor.Email == "" {
+		survivor.Email = merged.Email
+	}
+	merged.Active = false
+	merged.MergedInto = survivor.ID
+	writes.customers = []*Customer{survivor, merged}
+	return writes, nil
+}
+
+// mergeVendors re-points vendor bills and ledger entries
+func (mds *MasterDataService) mergeVendors(merge *PartyMerge) (*partyMergeWrites, error) {
+	survivor, err := mds.storage.GetVendor(merge.SurvivorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get surviving vendor: %w", err)
+	}
+	merged, err := mds.storage.GetVendor(merge.MergedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merged vendor: %w", err)
+	}
+	if !survivor.Active || !merged.Active {
+		return nil, fmt.Errorf("both vendors must be active to merge")
+	}
+	if survivor.Currency != merged.Currency {
+		return nil, fmt.Errorf("cannot merge vendor in %s into vendor in %s", merged.Currency, survivor.Currency)
+	}
+	if err := snapshotOriginals(merge, survivor, merged); err != nil {
+		return nil, err
+	}
+	writes := &partyMergeWrites{}
+
+	bills, err := mds.storage.GetAllVendorBills()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vendor bills: %w", err)
+	}
+	for _, bill := range bills {
+		if bill.VendorID != merged.ID {
+			continue
+		}
+		bill.VendorID = survivor.ID
+		writes.bills = append(writes.bills, bill)
+		merge.Repointed = append(merge.Repointed, RepointedRecord{RecordType: "VENDOR_BILL", RecordID: bill.ID})
+	}
+
+	if writes.transactions, err = mds.repointTransactions(merge); err != nil {
+		return nil, err
+	}
+
+	if survivor.TaxID == "" {
+		survivor.TaxID = merged.TaxID
+	}
+	if survivor.BankAccount == "" {
+		survivor.BankAccount = merged.BankAccount
+	}
+	merged.Active = false
+	merged.MergedInto = survivor.ID
+	writes.vendors = []*Vendor{survivor, merged}
+	return writes, nil
+}
+
+// repointTransactions moves the counterparty dimension on transaction entries from
+// the merged party to the survivor, returning the transactions it changed
+func (mds *MasterDataService) repointTransactions(merge *PartyMerge) ([]*Transaction, error) {
+	txns, err := mds.storage.GetAllTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	var changed []*Transaction
+	for _, txn := range txns {
+		if repointCounterparty(txn.Entries, merge) {
+			changed = append(changed, txn)
+			merge.Repointed = append(merge.Repointed, RepointedRecord{RecordType: "TRANSACTION", RecordID: txn.ID})
+		}
+	}
+	return changed, nil
+}
+
+// repointCounterparty moves the counterparty dimension on entries from the merged
+// party to the survivor, reporting whether any entry changed
+func repointCounterparty(entries []Entry, merge *PartyMerge) bool {
+	touched := false
+	for i := range entries {
+		for j := range entries[i].Dimensions {
+			dimension := &entries[i].Dimensions[j]
+			if dimension.Key == DimCounterparty && dimension.Value == merge.MergedID {
+				dimension.Value = merge.SurvivorID
+				touched = true
+			}
+		}
+	}
+	return touched
+}
+
+// activeParties loads the active customers or vendors in matching form
+func (mds *MasterDataService) activeParties(partyType PartyType) ([]*party, error) {
+	var parties []*party
+	switch partyType {
+	case PartyCustomer:
+		customers, err := mds.storage.GetAllCustomers()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get customers: %w", err)
+		}
+		for _, customer := range customers {
+			if customer.Active {
+				parties = append(parties, &party{ID: customer.ID, Name: customer.Name, TaxID: customer.TaxID, BankAccount: customer.BankAccount})
+			}
+		}
+	case PartyVendor:
+		vendors, err := mds.storage.GetAllVendors()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vendors: %w", err)
+		}
+		for _, vendor := range vendors {
+			if vendor.Active {
+				parties = append(parties, &party{ID: vendor.ID, Name: vendor.Name, TaxID: vendor.TaxID, BankAccount: vendor.BankAccount})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown party type %s", partyType)
+	}
+
+	sort.Slice(parties, func(i, j int) bool {
+		return parties[i].ID < parties[j].ID
+	})
+	return parties, nil
+}
+
+// snapshotOriginals captures both parties before the merge changes them
+func snapshotOriginals(merge *PartyMerge, survivor, merged interface{}) error {
+	var err error
+	if merge.SurvivorOriginal, err = json.Marshal(survivor); err != nil {
+		return fmt.Errorf("failed to snapshot surviving party: %w", err)
+	}
+	if merge.MergedOriginal, err = json.Marshal(merged); err != nil {
+		return fmt.Errorf("failed to snapshot merged party: %w", err)
+	}
+	return nil
+}
+
+// compareParties scores how likely two parties are the same counterparty. Names
+// only count as a reason when they are at least minScore alike.
+func compareParties(a, b *party, minScore float64) *DuplicateMatch {
+	match := &DuplicateMatch{
+		PartyID:       a.ID,
+		PartyName:     a.Name,
+		CandidateID:   b.ID,
+		CandidateName: b.Name,
+	}
+
+	taxA, taxB := normalizeIdentifier(a.TaxID), normalizeIdentifier(b.TaxID)
+	if taxA != "" && taxA == taxB {
+		match.Score = 1
+		match.Reasons = append(match.Reasons, MatchReasonTaxID)
+	}
+	bankA, bankB := normalizeIdentifier(a.BankAccount), normalizeIdentifier(b.BankAccount)
+	if bankA != "" && bankA == bankB {
+		match.Score = 1
+		match.Reasons = append(match.Reasons, MatchReasonBankAccount)
+	}
+
+	if taxA != "" && taxB != "" && taxA != taxB {
+		return match
+	}
+	if nameScore := nameSimilarity(a.Name, b.Name); nameScore >= minScore {
+		match.Reasons = append(match.Reasons, MatchReasonName)
+		if nameScore > match.Score {
+			match.Score = nameScore
+		}
+	}
+	return match
+}
+
+// normalizeIdentifier keeps only the letters and digits of a tax ID or bank account
+func normalizeIdentifier(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(value) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// normalizePartyName lowercases a name, strips punctuation and drops legal suffixes
+func normalizePartyName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	kept := words[:0]
+	for _, word := range words {
+		if !legalSuffixes[word] {
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// nameSimilarity is one minus the edit distance between normalized names relative to
+// the longer name, so identical names score 1 and unrelated names approach 0
+func nameSimilarity(a, b string) float64 {
+	ra, rb := []rune(normalizePartyName(a)), []rune(normalizePartyName(b))
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 0
+	}
+	return 1 - float64(editDistance(ra, rb))/float64(longest)
+}
+
+// editDistance is the Levenshtein distance between two rune slices
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,157 @@
+package accounting
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMasterDataDeduplication(t *testing.T) {
+	dbFile := "test_master_data.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	clerk := "ap_clerk"
+	require.NoError(t, engine.CreateStandardAccounts(clerk))
+
+	acme := &Vendor{ID: "acme", Name: "Acme Supplies Inc.", Currency: "USD", PaymentTermsDays: 30,
+		DefaultExpenseAccountID: "expenses", TaxID: "12-3456789"}
+	acmeDup := &Vendor{ID: "acme_dup", Name: "ACME Supplies, LLC", Currency: "USD", PaymentTermsDays: 30,
+		DefaultExpenseAccountID: "expenses", BankAccount: "GB29 NWBK 6016 1331 9268 19"}
+	acmeOther := &Vendor{ID: "acme_other", Name: "Acme Supplies Ltd", Currency: "USD",
+		DefaultExpenseAccountID: "expenses", TaxID: "98-7654321"}
+	globex := &Vendor{ID: "globex", Name: "Globex Corporation", Currency: "USD",
+		DefaultExpenseAccountID: "expenses", BankAccount: "gb29nwbk60161331926819"}
+	for _, vendor := range []*Vendor{acme, acmeDup, acmeOther, globex} {
+		require.NoError(t, engine.CreateVendor(vendor, clerk))
+	}
+
+	billDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	bill := &VendorBill{VendorID: acmeDup.ID, BillNumber: "INV-9", BillDate: billDate,
+		Lines: []BillLine{{Description: "Paper", Amount: 25000}}}
+	require.NoError(t, engine.EnterBill(bill, clerk))
+	bill, err = engine.SubmitBill(bill.ID, clerk)
+	require.NoError(t, err)
+
+	t.Run("detects duplicate vendors", func(t *testing.T) {
+		matches, err := engine.FindDuplicateParties(PartyVendor, 0.9)
+		require.NoError(t, err)
+
+		pairs := make(map[string]*DuplicateMatch)
+		for _, match := range matches {
+			pairs[match.PartyID+"|"+match.CandidateID] = match
+		}
+
+		byName := pairs["acme|acme_dup"]
+		require.NotNil(t, byName)
+		assert.Equal(t, 1.0, byName.Score)
+		assert.Equal(t, []string{MatchReasonName}, byName.Reasons)
+
+		byBank := pairs["acme_dup|globex"]
+		require.NotNil(t, byBank)
+		assert.Equal(t, []string{MatchReasonBankAccount}, byBank.Reasons)
+
+		assert.NotContains(t, pairs, "acme|acme_other", "different tax IDs are not duplicates")
+		assert.Contains(t, pairs, "acme_dup|acme_other")
+	})
+
+	t.Run("merges a vendor and re-points its history", func(t *testing.T) {
+		merge, err := engine.MergeParties(PartyVendor, acme.ID, acmeDup.ID, "controller")
+		require.NoError(t, err)
+		assert.Contains(t, merge.Repointed, RepointedRecord{RecordType: "VENDOR_BILL", RecordID: bill.ID})
+
+		bills, err := engine.GetPayablesService().GetVendorBills(acme.ID)
+		require.NoError(t, err)
+		require.Len(t, bills, 1)
+		assert.Equal(t, bill.ID, bills[0].ID)
+
+		txn, err := engine.GetStorage().GetTransaction(bill.TransactionID)
+		require.NoError(t, err)
+		for _, entry := range txn.Entries {
+			if entry.AccountID == "accounts_payable" {
+				assert.Equal(t, acme.ID, entryDimension(&entry, DimCounterparty))
+			}
+		}
+
+		merged, err := engine.GetStorage().GetVendor(acmeDup.ID)
+		require.NoError(t, err)
+		assert.False(t, merged.Active)
+		assert.Equal(t, acme.ID, merged.MergedInto)
+
+		survivor, err := engine.GetStorage().GetVendor(acme.ID)
+		require.NoError(t, err)
+		assert.Equal(t, acmeDup.BankAccount, survivor.BankAccount)
+
+		var original Vendor
+		require.NoError(t, json.Unmarshal(merge.MergedOriginal, &original))
+		assert.True(t, original.Active)
+		assert.Equal(t, "ACME Supplies, LLC", original.Name)
+
+		stored, err := engine.GetStorage().GetPartyMerge(merge.ID)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(merge.SurvivorOriginal), string(stored.SurvivorOriginal))
+
+		events, err := engine.GetStorage().GetEvents(time.Unix(0, 0), time.Now())
+		require.NoError(t, err)
+		found := false
+		for _, event := range events {
+			found = found || event.EventType == EventMergeParty
+		}
+		assert.True(t, found)
+
+		err = engine.EnterBill(&VendorBill{VendorID: acmeDup.ID, BillNumber: "INV-10", BillDate: billDate,
+			Lines: []BillLine{{Description: "Ink", Amount: 1000}}}, clerk)
+		assert.ErrorContains(t, err, "inactive")
+	})
+
+	t.Run("merges customers matched on tax ID", func(t *testing.T) {
+		first := &Customer{ID: "cust_1", Name: "Jane Doe", TaxID: "123-45-6789"}
+		second := &Customer{ID: "cust_2", Name: "J. Doe", TaxID: "123456789"}
+		require.NoError(t, engine.CreateCustomer(first, clerk))
+		require.NoError(t, engine.CreateCustomer(second, clerk))
+
+		matches, err := engine.FindDuplicateParties(PartyCustomer, 0.9)
+		require.NoError(t, err)
+		require.Len(t, matches, 1)
+		assert.Equal(t, []string{MatchReasonTaxID}, matches[0].Reasons)
+
+		sale := &Transaction{
+			Description: "Sale",
+			ValidTime:   billDate,
+			Entries: []Entry{
+				{AccountID: "accounts_receivable", Type: Debit, Amount: Amount{Value: 5000, Currency: "USD"},
+					Dimensions: []Dimension{{Key: DimCounterparty, Value: second.ID}}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 5000, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(sale, clerk))
+		require.NoError(t, engine.PostTransaction(sale.ID, clerk))
+
+		merge, err := engine.MergeParties(PartyCustomer, first.ID, second.ID, "controller")
+		require.NoError(t, err)
+		assert.Equal(t, []RepointedRecord{{RecordType: "TRANSACTION", RecordID: sale.ID}}, merge.Repointed)
+
+		receivable, err := engine.GetAccountBalance("accounts_receivable", billDate)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5000), receivable.Balance.Value)
+
+		entries, err := engine.GetStorage().GetEntriesByAccount("accounts_receivable")
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, first.ID, entryDimension(entries[0], DimCounterparty), "posted entries are re-pointed with their transaction")
+
+		merged, err := engine.GetCustomer(second.ID)
+		require.NoError(t, err)
+		assert.False(t, merged.Active)
+
+		_, err = engine.MergeParties(PartyCustomer, first.ID, second.ID, "controller")
+		assert.Error(t, err)
+	})
+}
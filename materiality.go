@@ -0,0 +1,269 @@
+package accounting
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Materiality Structures
+// ----------------------------------------------------------------------------
+
+// MaterialityBenchmark is the financial statement measure materiality is derived from
+type MaterialityBenchmark string
+
+const (
+	BenchmarkRevenue       MaterialityBenchmark = "REVENUE"
+	BenchmarkTotalAssets   MaterialityBenchmark = "TOTAL_ASSETS"
+	BenchmarkNetIncome     MaterialityBenchmark = "NET_INCOME"
+	BenchmarkTotalExpenses MaterialityBenchmark = "TOTAL_EXPENSES"
+)
+
+// Default proportions applied when a request leaves them unset
+const (
+	DefaultPerformanceMaterialityPercent = 75.0 // of overall materiality
+	DefaultClearlyTrivialPercent         = 5.0  // of overall materiality
+)
+
+// MaterialityRequest describes how materiality should be derived for a period
+type MaterialityRequest struct {
+	CompanyID          string               `json:"company_id"`
+	PeriodID           string               `json:"period_id"`
+	Benchmark          MaterialityBenchmark `json:"benchmark"`
+	Percentage         float64              `json:"percentage"`                    // of the benchmark, e.g. 1 for 1% of revenue
+	PerformancePercent float64              `json:"performance_percent,omitempty"` // of overall materiality
+	TrivialPercent     float64              `json:"trivial_percent,omitempty"`     // of overall materiality
+	Currency           string               `json:"currency"`
+	Rationale          string               `json:"rationale,omitempty"`
+}
+
+// MaterialityAssessment is the materiality determined for a company and period.
+// Overall materiality applies to the financial statements as a whole; performance
+// materiality is the lower working threshold used for individual balances and
+// transactions; misstatements below the clearly trivial amount need not be accumulated.
+type MaterialityAssessment struct {
+	ID                 string               `json:"id"`
+	CompanyID          string               `json:"company_id"`
+	PeriodID           string               `json:"period_id"`
+	PeriodStart        time.Time            `json:"period_start"`
+	PeriodEnd          time.Time            `json:"period_end"`
+	Benchmark          MaterialityBenchmark `json:"benchmark"`
+	BenchmarkAmount    *Amount              `json:"benchmark_amount"`
+	Percentage         float64              `json:"percentage"`
+	Overall            *Amount              `json:"overall"`
+	PerformancePercent float64              `json:"performance_percent"`
+	Performance        *Amount              `json:"performance"`
+	TrivialPercent     float64              `json:"trivial_percent"`
+	ClearlyTrivial     *Amount              `json:"clearly_trivial"`
+	Rationale          string               `json:"rationale,omitempty"`
+	CreatedBy          string               `json:"created_by"`
+	CreatedAt          time.Time            `json:"created_at"`
+}
+
+// Covers reports whether the assessment's period includes the date
+func (ma *MaterialityAssessment) Covers(date time.Time) bool {
+	return !date.Before(ma.PeriodStart) && !date.After(ma.PeriodEnd)
+}
+
+// ----------------------------------------------------------------------------
+// Materiality Service
+// ----------------------------------------------------------------------------
+
+// MaterialityService derives and stores per-company materiality thresholds
+type MaterialityService struct {
+	storage          *Storage
+	eventStore       *EventStore
+	reportingService *ReportingService
+}
+
+// NewMaterialityService creates a new materiality service
+func NewMaterialityService(storage *Storage, eventStore *EventStore, reportingService *ReportingService) *MaterialityService {
+	return &MaterialityService{
+		storage:          storage,
+		eventStore:       eventStore,
+		reportingService: reportingService,
+	}
+}
+
+// CalculateMateriality derives materiality from the benchmark for the period and
+// records it as the company's current assessment for that period
+func (ms *MaterialityService) CalculateMateriality(request MaterialityRequest, userID string) (*MaterialityAssessment, error) {
+	if request.CompanyID == "" {
+		return nil, fmt.Errorf("company is required")
+	}
+	if request.Percentage <= 0 || request.Percentage > 100 {
+		return nil, fmt.Errorf("materiality percentage must be between 0 and 100, got %v", request.Percentage)
+	}
+	if request.PerformancePercent == 0 {
+		request.PerformancePercent = DefaultPerformanceMaterialityPercent
+	}
+	if request.TrivialPercent == 0 {
+		request.TrivialPercent = DefaultClearlyTrivialPercent
+	}
+	if request.PerformancePercent < 0 || request.PerformancePercent > 100 ||
+		request.TrivialPercent < 0 || request.TrivialPercent > 100 {
+		return nil, fmt.Errorf("performance and trivial percentages must be between 0 and 100")
+	}
+
+	period, err := ms.storage.GetPeriod(request.PeriodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get period: %w", err)
+	}
+
+	benchmark, err := ms.benchmarkAmount(request.Benchmark, period, request.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if benchmark.Value == 0 {
+		return nil, fmt.Errorf("%s benchmark is zero for period %s", request.Benchmark, period.Name)
+	}
+
+	overall, err := percentOf(benchmark, request.Percentage)
+	if err != nil {
+		return nil, err
+	}
+	performance, err := percentOf(overall, request.PerformancePercent)
+	if err != nil {
+		return nil, err
+	}
+	trivial, err := percentOf(overall, request.TrivialPercent)
+	if err != nil {
+		return nil, err
+	}
+
+	assessment := &MaterialityAssessment{
+		ID:                 newID(),
+		CompanyID:          request.CompanyID,
+		PeriodID:           period.ID,
+		PeriodStart:        period.Start,
+		PeriodEnd:          period.End,
+		Benchmark:          request.Benchmark,
+		BenchmarkAmount:    benchmark,
+		Percentage:         request.Percentage,
+		Overall:            overall,
+		PerformancePercent: request.PerformancePercent,
+		Performance:        performance,
+		TrivialPercent:     request.TrivialPercent,
+		ClearlyTrivial:     trivial,
+		Rationale:          request.Rationale,
+		CreatedBy:          userID,
+		CreatedAt:          time.Now(),
+	}
+
+	_, err = ms.eventStore.CreateEvent(EventSetMateriality, assessment, period.End, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create materiality event: %w", err)
+	}
+
+	if err := ms.storage.SaveMaterialityAssessment(assessment); err != nil {
+		return nil, fmt.Errorf("failed to save materiality assessment: %w", err)
+	}
+
+	return assessment, nil
+}
+
+// GetMaterialityForPeriod returns the latest assessment for a period. An empty
+// companyID matches any company.
+func (ms *MaterialityService) GetMaterialityForPeriod(companyID, periodID string) (*MaterialityAssessment, error) {
+	assessments, err := ms.GetMaterialityHistory(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(assessments) - 1; i >= 0; i-- {
+		if assessments[i].PeriodID == periodID {
+			return assessments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no materiality assessed for period %s", periodID)
+}
+
+// GetEffectiveMateriality returns the latest assessment whose period covers the date.
+// An empty companyID matches any company.
+func (ms *MaterialityService) GetEffectiveMateriality(companyID string, date time.Time) (*MaterialityAssessment, error) {
+	assessments, err := ms.GetMaterialityHistory(companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := len(assessments) - 1; i >= 0; i-- {
+		if assessments[i].Covers(date) {
+			return assessments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no materiality in effect on %s", date.Format("2006-01-02"))
+}
+
+// GetMaterialityHistory returns a company's assessments in the order they were made
+func (ms *MaterialityService) GetMaterialityHistory(companyID string) ([]*MaterialityAssessment, error) {
+	assessments, err := ms.storage.GetAllMaterialityAssessments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get materiality assessments: %w", err)
+	}
+
+	var history []*MaterialityAssessment
+	for _, assessment := range assessments {
+		if companyID == "" || assessment.CompanyID == companyID {
+			history = append(history, assessment)
+		}
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].CreatedAt.Before(history[j].CreatedAt)
+	})
+	return history, nil
+}
+
+// benchmarkAmount measures the benchmark for the period from the financial statements
+func (ms *MaterialityService) benchmarkAmount(benchmark MaterialityBenchmark, period *Period, currency string) (*Amount, error) {
+	switch benchmark {
+	case BenchmarkTotalAssets:
+		balanceSheet, err := ms.reportingService.GenerateBalanceSheet(period.End, currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate balance sheet: %w", err)
+		}
+		return balanceSheet.TotalAssets, nil
+
+	case BenchmarkRevenue, BenchmarkNetIncome, BenchmarkTotalExpenses:
+		pl, err := ms.reportingService.GenerateProfitAndLoss(period.Start, period.End, currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate profit and loss: %w", err)
+		}
+		if benchmark == BenchmarkNetIncome {
+			// A loss-making period is benchmarked on the size of the loss
+			return &Amount{Value: abs64(pl.NetIncome.Value), Currency: pl.NetIncome.Currency}, nil
+		}
+
+		section := "REVENUE"
+		if benchmark == BenchmarkTotalExpenses {
+			section = "EXPENSES"
+		}
+		for _, item := range pl.LineItems {
+			if item.AccountName == section {
+				return item.Amount, nil
+			}
+		}
+		return nil, fmt.Errorf("profit and loss has no %s section", section)
+
+	default:
+		return nil, fmt.Errorf("unknown materiality benchmark: %s", benchmark)
+	}
+}
+
+// percentOf returns percent% of an amount, rounded half-up to whole minor units
+func percentOf(amount *Amount, percent float64) (*Amount, error) {
+	share, err := decimalRat(percent)
+	if err != nil {
+		return nil, fmt.Errorf("invalid percentage: %w", err)
+	}
+	share.Mul(share, new(big.Rat).SetInt64(amount.Value))
+	share.Quo(share, big.NewRat(100, 1))
+
+	value, err := roundRat(share, RoundHalfUp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply %v%%: %w", percent, err)
+	}
+	return &Amount{Value: value, Currency: amount.Currency}, nil
+}
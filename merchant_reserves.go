@@ -0,0 +1,459 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Merchant Reserve Structures
+// ----------------------------------------------------------------------------
+
+// ReserveReleaseStep releases a share of each hold a number of days after capture
+type ReserveReleaseStep struct {
+	AfterDays int     `json:"after_days"`
+	Rate      float64 `json:"rate"` // share of the hold released at this step
+}
+
+// MerchantReserve is the rolling reserve a PSP keeps against a merchant's chargeback
+// and refund exposure. A share of every capture is withheld from the merchant's
+// payable into the reserve and paid back on the release schedule.
+type MerchantReserve struct {
+	ID                 string               `json:"id"` // the merchant ID
+	Name               string               `json:"name"`
+	Currency           Currency             `json:"currency"`
+	RollingReserveRate float64              `json:"rolling_reserve_rate"` // e.g. 0.1 withholds 10% of captures
+	ReleaseSchedule    []ReserveReleaseStep `json:"release_schedule"`
+	PayableAccountID   string               `json:"payable_account_id"` // liability: funds due to the merchant
+	ReserveAccountID   string               `json:"reserve_account_id"` // liability: funds withheld in reserve
+	CreatedBy          string               `json:"created_by"`
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+}
+
+// ReserveRelease is one scheduled release of a hold
+type ReserveRelease struct {
+	DueDate       time.Time  `json:"due_date"`
+	Amount        int64      `json:"amount"`
+	ReleasedAt    *time.Time `json:"released_at,omitempty"`
+	TransactionID string     `json:"transaction_id,omitempty"`
+}
+
+// ReserveHold is the reserve withheld from one capture and its release schedule
+type ReserveHold struct {
+	ID            string           `json:"id"`
+	MerchantID    string           `json:"merchant_id"`
+	CaptureRef    string           `json:"capture_ref"`
+	CaptureAmount int64            `json:"capture_amount"`
+	HeldAmount    int64            `json:"held_amount"`
+	CapturedAt    time.Time        `json:"captured_at"`
+	TransactionID string           `json:"transaction_id"`
+	Releases      []ReserveRelease `json:"releases"`
+	CreatedBy     string           `json:"created_by"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// Outstanding returns the amount of the hold not yet released as of the date
+func (h *ReserveHold) Outstanding(asOfDate time.Time) int64 {
+	if h.CapturedAt.After(asOfDate) {
+		return 0
+	}
+	outstanding := h.HeldAmount
+	for _, release := range h.Releases {
+		if release.ReleasedAt != nil && !release.ReleasedAt.After(asOfDate) {
+			outstanding -= release.Amount
+		}
+	}
+	return outstanding
+}
+
+// MerchantReserveBalance is one merchant's line in the reserve liability report
+type MerchantReserveBalance struct {
+	MerchantID   string   `json:"merchant_id"`
+	Name         string   `json:"name"`
+	Currency     Currency `json:"currency"`
+	Held         int64    `json:"held"`     // withheld from captures to date
+	Released     int64    `json:"released"` // paid back to date
+	Balance      int64    `json:"balance"`  // reserve liability outstanding
+	Overdue      int64    `json:"overdue"`  // due but not yet released
+	DueWithin30  int64    `json:"due_within_30"`
+	DueWithin90  int64    `json:"due_within_90"` // due in 31 to 90 days
+	DueAfter90   int64    `json:"due_after_90"`
+	LedgerAmount int64    `json:"ledger_amount"` // reserve account balance tagged to the merchant
+}
+
+// MerchantReserveReport is the reserve liability by merchant with its release profile
+type MerchantReserveReport struct {
+	AsOfDate    time.Time                 `json:"as_of_date"`
+	Merchants   []*MerchantReserveBalance `json:"merchants"`
+	GeneratedAt time.Time                 `json:"generated_at"`
+}
+
+// ----------------------------------------------------------------------------
+// Merchant Reserve Service
+// ----------------------------------------------------------------------------
+
+// MerchantReserveService withholds rolling reserves from merchant captures and
+// releases them on schedule
+type MerchantReserveService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+}
+
+// NewMerchantReserveService creates a new merchant reserve service
+func NewMerchantReserveService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *MerchantReserveService {
+	return &MerchantReserveService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+	}
+}
+
+// ConfigureReserve validates and saves a merchant's reserve terms. Without a release
+// schedule the whole hold is released after 90 days.
+func (mrs *MerchantReserveService) ConfigureReserve(reserve *MerchantReserve, userID string) error {
+	if reserve.ID == "" {
+		return fmt.Errorf("merchant ID is required")
+	}
+	if reserve.Currency == "" {
+		return fmt.Errorf("reserve currency is required")
+	}
+	if reserve.RollingReserveRate < 0 || reserve.RollingReserveRate > 1 || math.IsNaN(reserve.RollingReserveRate) {
+		return fmt.Errorf("rolling reserve rate must be between 0 and 1")
+	}
+	if len(reserve.ReleaseSchedule) == 0 {
+		reserve.ReleaseSchedule = []ReserveReleaseStep{{AfterDays: 90, Rate: 1}}
+	}
+	var total float64
+	for _, step := range reserve.ReleaseSchedule {
+		if step.AfterDays < 0 || step.Rate <= 0 {
+			return fmt.Errorf("release steps need a non-negative delay and a positive rate")
+		}
+		total += step.Rate
+	}
+	if math.Abs(total-1) > 1e-9 {
+		return fmt.Errorf("release schedule rates must sum to 1, got %v", total)
+	}
+	sort.SliceStable(reserve.ReleaseSchedule, func(i, j int) bool {
+		return reserve.ReleaseSchedule[i].AfterDays < reserve.ReleaseSchedule[j].AfterDays
+	})
+	for _, accountID := range []string{reserve.PayableAccountID, reserve.ReserveAccountID} {
+		account, err := mrs.storage.GetAccount(accountID)
+		if err != nil {
+			return fmt.Errorf("invalid reserve account: %w", err)
+		}
+		if account.Type != Liability {
+			return fmt.Errorf("account %s must be a liability account", accountID)
+		}
+	}
+
+	now := time.Now()
+	if existing, err := mrs.storage.GetMerchantReserve(reserve.ID); err == nil {
+		reserve.CreatedBy = existing.CreatedBy
+		reserve.CreatedAt = existing.CreatedAt
+	} else {
+		reserve.CreatedBy = userID
+		reserve.CreatedAt = now
+	}
+	reserve.UpdatedAt = now
+
+	_, err := mrs.eventStore.CreateEvent(EventConfigureMerchantReserve, reserve, now, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create merchant reserve event: %w", err)
+	}
+	if err := mrs.storage.SaveMerchantReserve(reserve); err != nil {
+		return fmt.Errorf("failed to save merchant reserve: %w", err)
+	}
+	return nil
+}
+
+// RecordCapture withholds the rolling reserve from a capture, moving it from the
+// merchant's payable into the reserve, and schedules its release. Changes to the
+// merchant's terms apply to later captures only.
+func (mrs *MerchantReserveService) RecordCapture(merchantID, captureRef string, amount int64, capturedAt time.Time, userID string) (*ReserveHold, error) {
+	reserve, err := mrs.storage.GetMerchantReserve(merchantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchant reserve: %w", err)
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("capture amount must be positive")
+	}
+	if captureRef == "" {
+		return nil, fmt.Errorf("capture reference is required")
+	}
+	holdID := merchantID + "_" + captureRef
+	if _, err := mrs.storage.GetReserveHold(holdID); err == nil {
+		return nil, fmt.Errorf("capture %s has already been recorded", captureRef)
+	}
+
+	held, err := applyRate(amount, reserve.RollingReserveRate, RoundHalfUp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate reserve: %w", err)
+	}
+
+	hold := &ReserveHold{
+		ID:            holdID,
+		MerchantID:    merchantID,
+		CaptureRef:    captureRef,
+		CaptureAmount: amount,
+		HeldAmount:    held,
+		CapturedAt:    capturedAt,
+		CreatedBy:     userID,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	if held == 0 {
+		return hold, mrs.saveHold(hold, EventHoldMerchantReserve, capturedAt, userID)
+	}
+
+	// Each step is rounded from the hold; the last takes the remainder
+	remaining := held
+	for i, step := range reserve.ReleaseSchedule {
+		release := remaining
+		if i < len(reserve.ReleaseSchedule)-1 {
+			release, err = applyRate(held, step.Rate, RoundHalfUp)
+			if err != nil {
+				return nil, fmt.Errorf("failed to schedule release: %w", err)
+			}
+			release = min(release, remaining)
+		}
+		remaining -= release
+		if release > 0 {
+			hold.Releases = append(hold.Releases, ReserveRelease{
+				DueDate: capturedAt.AddDate(0, 0, step.AfterDays),
+				Amount:  release,
+			})
+		}
+	}
+
+	txn := mrs.newTransaction(fmt.Sprintf("Rolling reserve on capture %s for %s", captureRef, reserve.Name), capturedAt, "RESERVE_HOLD_"+holdID, userID)
+	txn.Entries = reserveEntries(txn.ID, reserve, reserve.PayableAccountID, reserve.ReserveAccountID, held)
+	if err := mrs.postTransaction(txn, userID); err != nil {
+		return nil, err
+	}
+	hold.TransactionID = txn.ID
+
+	if err := mrs.saveHold(hold, EventHoldMerchantReserve, capturedAt, userID); err != nil {
+		return nil, err
+	}
+	return hold, nil
+}
+
+// ReleaseDue releases every scheduled reserve release due by the date, posting one
+// journal per merchant from the reserve back to the merchant's payable
+func (mrs *MerchantReserveService) ReleaseDue(asOfDate time.Time, userID string) ([]*Transaction, error) {
+	holds, err := mrs.storage.GetAllReserveHolds()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reserve holds: %w", err)
+	}
+
+	due := make(map[string][]*ReserveHold)
+	var merchantIDs []string
+	for _, hold := range holds {
+		for _, release := range hold.Releases {
+			if release.ReleasedAt == nil && !release.DueDate.After(asOfDate) {
+				if _, seen := due[hold.MerchantID]; !seen {
+					merchantIDs = append(merchantIDs, hold.MerchantID)
+				}
+				due[hold.MerchantID] = append(due[hold.MerchantID], hold)
+				break
+			}
+		}
+	}
+	sort.Strings(merchantIDs)
+
+	var txns []*Transaction
+	for _, merchantID := range merchantIDs {
+		reserve, err := mrs.storage.GetMerchantReserve(merchantID)
+		if err != nil {
+			return txns, fmt.Errorf("failed to get merchant reserve: %w", err)
+		}
+
+		var total int64
+		for _, hold := range due[merchantID] {
+			for _, release := range hold.Releases {
+				if release.ReleasedAt == nil && !release.DueDate.After(asOfDate) {
+					total += release.Amount
+				}
+			}
+		}
+
+		txn := mrs.newTransaction(fmt.Sprintf("Rolling reserve release for %s", reserve.Name), asOfDate, fmt.Sprintf("RESERVE_RELEASE_%s_%s", merchantID, asOfDate.Format("2006-01-02")), userID)
+		txn.Entries = reserveEntries(txn.ID, reserve, reserve.ReserveAccountID, reserve.PayableAccountID, total)
+		if err := mrs.postTransaction(txn, userID); err != nil {
+			return txns, err
+		}
+		txns = append(txns, txn)
+
+		releasedAt := asOfDate
+		for _, hold := range due[merchantID] {
+			for i := range hold.Releases {
+				release := &hold.Releases[i]
+				if release.ReleasedAt == nil && !release.DueDate.After(asOfDate) {
+					release.ReleasedAt = &releasedAt
+					release.TransactionID = txn.ID
+				}
+			}
+			hold.UpdatedAt = time.Now()
+			if err := mrs.saveHold(hold, EventReleaseMerchantReserve, asOfDate, userID); err != nil {
+				return txns, err
+			}
+		}
+	}
+	return txns, nil
+}
+
+// GenerateReserveReport reports the reserve liability per merchant as of the date,
+// with the outstanding balance profiled by when it falls due, and the reserve
+// account balance tagged to each merchant for tie-out
+func (mrs *MerchantReserveService) GenerateReserveReport(asOfDate time.Time) (*MerchantReserveReport, error) {
+	reserves, err := mrs.storage.GetAllMerchantReserves()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merchant reserves: %w", err)
+	}
+	holds, err := mrs.storage.GetAllReserveHolds()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reserve holds: %w", err)
+	}
+	txns, err := mrs.storage.GetTransactionsByDateRange("", time.Time{}, asOfDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	report := &MerchantReserveReport{AsOfDate: asOfDate, GeneratedAt: time.Now()}
+	byMerchant := make(map[string]*MerchantReserveBalance)
+	for _, reserve := range reserves {
+		line := &MerchantReserveBalance{MerchantID: reserve.ID, Name: reserve.Name, Currency: reserve.Currency}
+		byMerchant[reserve.ID] = line
+		report.Merchants = append(report.Merchants, line)
+
+		for _, txn := range txns {
+			if txn.Status != Posted {
+				continue
+			}
+			for i := range txn.Entries {
+				entry := &txn.Entries[i]
+				if entry.AccountID != reserve.ReserveAccountID || entryDimension(entry, DimCounterparty) != reserve.ID {
+					continue
+				}
+				if entry.Type == Credit {
+					line.LedgerAmount += entry.Amount.Value
+				} else {
+					line.LedgerAmount -= entry.Amount.Value
+				}
+			}
+		}
+	}
+
+	for _, hold := range holds {
+		line, ok := byMerchant[hold.MerchantID]
+		if !ok || hold.CapturedAt.After(asOfDate) {
+			continue
+		}
+		line.Held += hold.HeldAmount
+		for _, release := range hold.Releases {
+			if release.ReleasedAt != nil && !release.ReleasedAt.After(asOfDate) {
+				line.Released += release.Amount
+				continue
+			}
+			switch days := release.DueDate.Sub(asOfDate).Hours() / 24; {
+			case days <= 0:
+				line.Overdue += release.Amount
+			case days <= 30:
+				line.DueWithin30 += release.Amount
+			case days <= 90:
+				line.DueWithin90 += release.Amount
+			default:
+				line.DueAfter90 += release.Amount
+			}
+		}
+		line.Balance = line.Held - line.Released
+	}
+
+	sort.Slice(report.Merchants, func(i, j int) bool {
+		return report.Merchants[i].MerchantID < report.Merchants[j].MerchantID
+	})
+	return report, nil
+}
+
+// GetHolds returns a merchant's reserve holds, oldest capture first
+func (mrs *MerchantReserveService) GetHolds(merchantID string) ([]*ReserveHold, error) {
+	holds, err := mrs.storage.GetAllReserveHolds()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reserve holds: %w", err)
+	}
+	var result []*ReserveHold
+	for _, hold := range holds {
+		if hold.MerchantID == merchantID {
+			result = append(result, hold)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CapturedAt.Before(result[j].CapturedAt)
+	})
+	return result, nil
+}
+
+// reserveEntries moves an amount between the merchant's payable and reserve accounts,
+// tagging both lines with the merchant
+func reserveEntries(txnID string, reserve *MerchantReserve, debitAccountID, creditAccountID string, amount int64) []Entry {
+	value := Amount{Value: amount, Currency: reserve.Currency}
+	dims := []Dimension{{Key: DimCounterparty, Value: reserve.ID}}
+	return []Entry{
+		{ID: newID(), TransactionID: txnID, AccountID: debitAccountID, Type: Debit, Amount: value, Dimensions: dims},
+		{ID: newID(), TransactionID: txnID, AccountID: creditAccountID, Type: Credit, Amount: value, Dimensions: dims},
+	}
+}
+
+// newTransaction creates a pending reserve transaction without entries
+func (mrs *MerchantReserveService) newTransaction(description string, validTime time.Time, sourceRef, userID string) *Transaction {
+	return &Transaction{
+		ID:              newID(),
+		Description:     description,
+		ValidTime:       validTime,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       sourceRef,
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// postTransaction records, saves and posts a reserve transaction
+func (mrs *MerchantReserveService) postTransaction(txn *Transaction, userID string) error {
+	_, err := mrs.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := mrs.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	if err := mrs.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
+
+// saveHold records an event for the hold and persists it
+func (mrs *MerchantReserveService) saveHold(hold *ReserveHold, eventType string, validTime time.Time, userID string) error {
+	_, err := mrs.eventStore.CreateEvent(eventType, hold, validTime, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create reserve hold event: %w", err)
+	}
+	if err := mrs.storage.SaveReserveHold(hold); err != nil {
+		return fmt.Errorf("failed to save reserve hold: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerchantReserves(t *testing.T) {
+	// Setup
+	dbFile := "test_merchant_reserves.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "risk"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	accounts := []*Account{
+		{ID: "merchant_payable", Code: "2310", Name: "Merchant Payables", Type: Liability},
+		{ID: "merchant_reserve", Code: "2320", Name: "Merchant Rolling Reserves", Type: Liability},
+	}
+	for _, account := range accounts {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	balance := func(accountID string, asOf time.Time) int64 {
+		result, err := engine.GetAccountBalance(accountID, asOf)
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+	capture := func(merchantID, ref string, value int64, when time.Time) *ReserveHold {
+		txn := &Transaction{
+			Description: "Capture " + ref,
+			ValidTime:   when,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "merchant_payable", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		hold, err := engine.RecordMerchantCapture(merchantID, ref, value, when, userID)
+		require.NoError(t, err)
+		return hold
+	}
+
+	t.Run("Configure Reserve", func(t *testing.T) {
+		invalid := &MerchantReserve{ID: "m_shoes", Currency: "USD", RollingReserveRate: 0.1,
+			ReleaseSchedule:  []ReserveReleaseStep{{AfterDays: 30, Rate: 0.5}, {AfterDays: 90, Rate: 0.4}},
+			PayableAccountID: "merchant_payable", ReserveAccountID: "merchant_reserve"}
+		assert.Error(t, engine.ConfigureMerchantReserve(invalid, userID), "schedule must release the whole hold")
+
+		invalid.ReleaseSchedule = nil
+		invalid.ReserveAccountID = "cash"
+		assert.Error(t, engine.ConfigureMerchantReserve(invalid, userID), "reserve account must be a liability")
+
+		require.NoError(t, engine.ConfigureMerchantReserve(&MerchantReserve{
+			ID:                 "m_shoes",
+			Name:               "Shoe Shop",
+			Currency:           "USD",
+			RollingReserveRate: 0.1,
+			ReleaseSchedule:    []ReserveReleaseStep{{AfterDays: 90, Rate: 0.5}, {AfterDays: 30, Rate: 0.5}},
+			PayableAccountID:   "merchant_payable",
+			ReserveAccountID:   "merchant_reserve",
+		}, userID))
+		require.NoError(t, engine.ConfigureMerchantReserve(&MerchantReserve{
+			ID:                 "m_travel",
+			Name:               "Travel Agent",
+			Currency:           "USD",
+			RollingReserveRate: 0.05,
+			PayableAccountID:   "merchant_payable",
+			ReserveAccountID:   "merchant_reserve",
+		}, userID))
+	})
+
+	t.Run("Hold On Capture", func(t *testing.T) {
+		hold := capture("m_shoes", "cap_1", 10001, day(1, 10))
+		assert.Equal(t, int64(1000), hold.HeldAmount)
+		require.Len(t, hold.Releases, 2)
+		assert.Equal(t, day(2, 9), hold.Releases[0].DueDate, "steps are ordered by delay")
+		assert.Equal(t, int64(500), hold.Releases[0].Amount)
+		assert.Equal(t, int64(500), hold.Releases[1].Amount)
+
+		capture("m_shoes", "cap_2", 333, day(1, 20))
+		travel := capture("m_travel", "cap_3", 40000, day(1, 15))
+		assert.Equal(t, int64(2000), travel.HeldAmount)
+		assert.Equal(t, day(4, 15), travel.Releases[0].DueDate, "default schedule releases after 90 days")
+
+		_, err := engine.RecordMerchantCapture("m_shoes", "cap_1", 10001, day(1, 10), userID)
+		assert.Error(t, err, "a capture is held once")
+
+		assert.Equal(t, int64(3033), balance("merchant_reserve", day(1, 31)))
+		assert.Equal(t, int64(50334-3033), balance("merchant_payable", day(1, 31)))
+	})
+
+	t.Run("Scheduled Release", func(t *testing.T) {
+		txns, err := engine.ReleaseMerchantReserves(day(2, 28), userID)
+		require.NoError(t, err)
+		require.Len(t, txns, 1, "only the shoe shop has tranches due")
+		// 500 from cap_1 and 17 of the 33 held on cap_2
+		assert.Equal(t, int64(517), txns[0].Entries[0].Amount.Value)
+		assert.Equal(t, int64(3033-517), balance("merchant_reserve", day(2, 28)))
+
+		txns, err = engine.ReleaseMerchantReserves(day(2, 28), userID)
+		require.NoError(t, err)
+		assert.Empty(t, txns, "released tranches are not released again")
+	})
+
+	t.Run("Reserve Liability Report", func(t *testing.T) {
+		report, err := engine.GenerateMerchantReserveReport(day(3, 1))
+		require.NoError(t, err)
+		require.Len(t, report.Merchants, 2)
+
+		shoes := report.Merchants[0]
+		assert.Equal(t, "m_shoes", shoes.MerchantID)
+		assert.Equal(t, int64(1033), shoes.Held)
+		assert.Equal(t, int64(517), shoes.Released)
+		assert.Equal(t, int64(516), shoes.Balance)
+		assert.Equal(t, int64(516), shoes.DueWithin90)
+		assert.Equal(t, shoes.Balance, shoes.LedgerAmount)
+
+		travel := report.Merchants[1]
+		assert.Equal(t, int64(2000), travel.Balance)
+		assert.Equal(t, int64(2000), travel.DueWithin90)
+		assert.Equal(t, travel.Balance, travel.LedgerAmount)
+
+		report, err = engine.GenerateMerchantReserveReport(day(5, 1))
+		require.NoError(t, err)
+		assert.Equal(t, int64(2000), report.Merchants[1].Overdue, "unreleased tranches past due")
+		assert.Equal(t, int64(516), report.Merchants[0].Overdue)
+	})
+
+	t.Run("Half Points Round Up", func(t *testing.T) {
+		require.NoError(t, engine.ConfigureMerchantReserve(&MerchantReserve{
+			ID:                 "m_tickets",
+			Name:               "Ticket Office",
+			Currency:           "USD",
+			RollingReserveRate: 0.015,
+			ReleaseSchedule:    []ReserveReleaseStep{{AfterDays: 30, Rate: 0.35}, {AfterDays: 60, Rate: 0.65}},
+			PayableAccountID:   "merchant_payable",
+			ReserveAccountID:   "merchant_reserve",
+		}, userID))
+
+		// 1.5% of 300 is exactly 4.5, and 35% of a 10 hold exactly 3.5
+		hold := capture("m_tickets", "cap_4", 300, day(5, 1))
+		assert.Equal(t, int64(5), hold.HeldAmount)
+		hold = capture("m_tickets", "cap_5", 667, day(5, 1))
+		assert.Equal(t, int64(10), hold.HeldAmount)
+		require.Len(t, hold.Releases, 2)
+		assert.Equal(t, int64(4), hold.Releases[0].Amount)
+		assert.Equal(t, int64(6), hold.Releases[1].Amount)
+	})
+}
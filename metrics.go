@@ -0,0 +1,449 @@
+package accounting
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Metrics Registry
+// ----------------------------------------------------------------------------
+
+// MetricKind is the Prometheus type of a metric family
+type MetricKind string
+
+const (
+	MetricCounter   MetricKind = "counter"
+	MetricGauge     MetricKind = "gauge"
+	MetricHistogram MetricKind = "histogram"
+)
+
+// DefaultLatencyBuckets are histogram bounds in seconds for request-sized work
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// StorageLatencyBuckets are histogram bounds in seconds for database transactions,
+// which mostly take well under a millisecond
+var StorageLatencyBuckets = []float64{0.00005, 0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.1, 0.5}
+
+// MetricSample is one labelled value reported by a collector function
+type MetricSample struct {
+	LabelValues []string
+	Value       float64
+}
+
+// MetricsRegistry holds metric families and writes them in the Prometheus text
+// exposition format, so a Prometheus server can scrape them from Handler
+type MetricsRegistry struct {
+	mu       sync.RWMutex
+	families map[string]*metricFamily
+}
+
+// metricFamily is a named metric and its series, one per combination of label values
+type metricFamily struct {
+	name, help string
+	kind       MetricKind
+	labels     []string
+	buckets    []float64                      // upper bounds, for histograms
+	collect    func() ([]MetricSample, error) // computes the series at scrape time when set
+
+	mu     sync.Mutex
+	series map[string]*metricSeries
+}
+
+// metricSeries is the value of one series, or its bucket counts for histograms
+type metricSeries struct {
+	labelValues []string
+	value       float64  // counters and gauges; the sum for histograms
+	counts      []uint64 // observations at or below each bucket bound
+	count       uint64
+}
+
+// NewMetricsRegistry creates an empty registry
+func NewMetricsRegistry() *MetricsRegistry {
+	return &MetricsRegistry{families: make(map[string]*metricFamily)}
+}
+
+// register adds a family, returning the existing one when it is registered again
+// the same way. Conflicting definitions are programming errors and panic.
+func (r *MetricsRegistry) register(family *metricFamily) *metricFamily {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.families[family.name]; ok {
+		if existing.kind != family.kind || strings.Join(existing.labels, ",") != strings.Join(family.labels, ",") || existing.collect != nil || family.collect != nil {
+			panic(fmt.Sprintf("metric %s is already registered differently", family.name))
+		}
+		return existing
+	}
+	family.series = make(map[string]*metricSeries)
+	if len(family.labels) == 0 && family.collect == nil {
+		family.series[""] = &metricSeries{} // unlabelled metrics report zero before their first update
+	}
+	r.families[family.name] = family
+	return family
+}
+
+// CounterVec is a counter with labels
+type CounterVec struct{ family *metricFamily }
+
+// Counter registers a counter, which only goes up
+func (r *MetricsRegistry) Counter(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{r.register(&metricFamily{name: name, help: help, kind: MetricCounter, labels: append([]string(nil), labels...)})}
+}
+
+// Inc adds one to the series with the label values
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds a non-negative amount to the series with the label values
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		panic(fmt.Sprintf("counter %s cannot decrease", c.family.name))
+	}
+	c.family.update(labelValues, func(s *metricSeries) { s.value += value })
+}
+
+// GaugeVec is a gauge with labels
+type GaugeVec struct{ family *metricFamily }
+
+// Gauge registers a gauge, which can go up and down
+func (r *MetricsRegistry) Gauge(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{r.register(&metricFamily{name: name, help: help, kind: MetricGauge, labels: append([]string(nil), labels...)})}
+}
+
+// Set sets the series with the label values
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	g.family.update(labelValues, func(s *metricSeries) { s.value = value })
+}
+
+// Add adds to the series with the label values
+func (g *GaugeVec) Add(value float64, labelValues ...string) {
+	g.family.update(labelValues, func(s *metricSeries) { s.value += value })
+}
+
+// HistogramVec is a histogram with labels
+type HistogramVec struct{ family *metricFamily }
+
+// Histogram registers a histogram counting observations into buckets with the given
+// upper bounds
+func (r *MetricsRegistry) Histogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+	return &HistogramVec{r.register(&metricFamily{name: name, help: help, kind: MetricHistogram, labels: append([]string(nil), labels...), buckets: bounds})}
+}
+
+// Observe records a value in the series with the label values
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.family.update(labelValues, func(s *metricSeries) {
+		if s.counts == nil {
+			s.counts = make([]uint64, len(h.family.buckets))
+		}
+		for i, bound := range h.family.buckets {
+			if value <= bound {
+				s.counts[i]++
+			}
+		}
+		s.count++
+		s.value += value
+	})
+}
+
+// ObserveDuration records an elapsed time in seconds
+func (h *HistogramVec) ObserveDuration(elapsed time.Duration, labelValues ...string) {
+	h.Observe(elapsed.Seconds(), labelValues...)
+}
+
+// GaugeFunc registers a gauge computed when the registry is scraped, for values
+// better read from storage than tracked
+func (r *MetricsRegistry) GaugeFunc(name, help string, labels []string, collect func() ([]MetricSample, error)) {
+	r.register(&metricFamily{name: name, help: help, kind: MetricGauge, labels: labels, collect: collect})
+}
+
+// CounterFunc registers a counter computed when the registry is scraped, for
+// counters kept elsewhere
+func (r *MetricsRegistry) CounterFunc(name, help string, labels []string, collect func() ([]MetricSample, error)) {
+	r.register(&metricFamily{name: name, help: help, kind: MetricCounter, labels: labels, collect: collect})
+}
+
+// update applies a change to the series with the label values, creating it
+func (f *metricFamily) update(labelValues []string, change func(*metricSeries)) {
+	if len(labelValues) != len(f.labels) {
+		panic(fmt.Sprintf("metric %s takes %d label values, got %d", f.name, len(f.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.series[key]
+	if !ok {
+		s = &metricSeries{labelValues: append([]string(nil), labelValues...)}
+		f.series[key] = s
+	}
+	change(s)
+}
+
+// snapshot copies the family's series sorted by label values, collecting them first
+// for function families
+func (f *metricFamily) snapshot() ([]metricSeries, error) {
+	var series []metricSeries
+	if f.collect != nil {
+		samples, err := f.collect()
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect %s: %w", f.name, err)
+		}
+		for _, sample := range samples {
+			if len(sample.LabelValues) != len(f.labels) {
+				return nil, fmt.Errorf("metric %s takes %d label values, got %d", f.name, len(f.labels), len(sample.LabelValues))
+			}
+			series = append(series, metricSeries{labelValues: sample.LabelValues, value: sample.Value})
+		}
+	} else {
+		f.mu.Lock()
+		for _, s := range f.series {
+			copied := *s
+			copied.counts = append([]uint64(nil), s.counts...)
+			series = append(series, copied)
+		}
+		f.mu.Unlock()
+	}
+	sort.Slice(series, func(i, j int) bool {
+		return strings.Join(series[i].labelValues, "\xff") < strings.Join(series[j].labelValues, "\xff")
+	})
+	return series, nil
+}
+
+// WriteText writes every family in the Prometheus text exposition format, sorted by
+// name. A collector failing fails the whole write, so scrapes never see partial data.
+func (r *MetricsRegistry) WriteText(w io.Writer) error {
+	r.mu.RLock()
+	families := make([]*metricFamily, 0, len(r.families))
+	for _, family := range r.families {
+		families = append(families, family)
+	}
+	r.mu.RUnlock()
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].name < families[j].name
+	})
+
+	var b strings.Builder
+	for _, family := range families {
+		series, err := family.snapshot()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n", family.name, escapeMetricHelp(family.help))
+		fmt.Fprintf(&b, "# TYPE %s %s\n", family.name, family.kind)
+		bucketLabels := append(append([]string(nil), family.labels...), "le")
+		for _, s := range series {
+			if family.kind != MetricHistogram {
+				fmt.Fprintf(&b, "%s%s %s\n", family.name, metricLabels(family.labels, s.labelValues), formatMetricValue(s.value))
+				continue
+			}
+			bucketValues := append(append([]string(nil), s.labelValues...), "")
+			for i, bound := range family.buckets {
+				var count uint64
+				if s.counts != nil {
+					count = s.counts[i]
+				}
+				bucketValues[len(bucketValues)-1] = formatMetricValue(bound)
+				fmt.Fprintf(&b, "%s_bucket%s %d\n", family.name, metricLabels(bucketLabels, bucketValues), count)
+			}
+			bucketValues[len(bucketValues)-1] = "+Inf"
+			fmt.Fprintf(&b, "%s_bucket%s %d\n", family.name, metricLabels(bucketLabels, bucketValues), s.count)
+			fmt.Fprintf(&b, "%s_sum%s %s\n", family.name, metricLabels(family.labels, s.labelValues), formatMetricValue(s.value))
+			fmt.Fprintf(&b, "%s_count%s %d\n", family.name, metricLabels(family.labels, s.labelValues), s.count)
+		}
+	}
+
+	out := bufio.NewWriter(w)
+	if _, err := out.WriteString(b.String()); err != nil {
+		return err
+	}
+	return out.Flush()
+}
+
+// Handler serves the registry for Prometheus to scrape
+func (r *MetricsRegistry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var b strings.Builder
+		if err := r.WriteText(&b); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = io.WriteString(w, b.String())
+	})
+}
+
+// metricLabels renders {name="value",...}, or nothing without labels
+func metricLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(values[i])
+		pairs[i] = name + `="` + value + `"`
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// escapeMetricHelp escapes backslashes and newlines in help text
+func escapeMetricHelp(help string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help)
+}
+
+// formatMetricValue formats a value as Prometheus expects, including infinities
+func formatMetricValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// ----------------------------------------------------------------------------
+// Engine Metrics
+// ----------------------------------------------------------------------------
+
+// EngineMetrics instruments the engine: posting throughput, storage latency, AML
+// alerts and investigations, and report generation time. Rates such as
+// transactions posted per second come from the counters, for example
+// rate(fin_transactions_posted_total[1m]). A nil *EngineMetrics records nothing.
+type EngineMetrics struct {
+	registry           *MetricsRegistry
+	transactionsPosted *CounterVec
+	events             *CounterVec
+	storageDuration    *HistogramVec
+	storageErrors      *CounterVec
+	alertsRaised       *CounterVec
+	amlMonitorDuration *HistogramVec
+	reportDuration     *HistogramVec
+	reportErrors       *CounterVec
+}
+
+// NewEngineMetrics registers the engine's metrics in a registry
+func NewEngineMetrics(registry *MetricsRegistry) *EngineMetrics {
+	return &EngineMetrics{
+		registry:           registry,
+		transactionsPosted: registry.Counter("fin_transactions_posted_total", "Transactions posted to the ledger."),
+		events:             registry.Counter("fin_journal_events_total", "Events appended to the journal event log.", "type"),
+		storageDuration:    registry.Histogram("fin_storage_transaction_duration_seconds", "Time spent in database transactions.", StorageLatencyBuckets, "operation"),
+		storageErrors:      registry.Counter("fin_storage_transaction_errors_total", "Database transactions that returned an error.", "operation"),
+		alertsRaised:       registry.Counter("fin_aml_alerts_raised_total", "AML alerts raised since the engine started.", "rule_type"),
+		amlMonitorDuration: registry.Histogram("fin_aml_monitor_duration_seconds", "Time spent monitoring a transaction for AML alerts.", DefaultLatencyBuckets),
+		reportDuration:     registry.Histogram("fin_report_generation_duration_seconds", "Time spent generating reports.", DefaultLatencyBuckets, "report"),
+		reportErrors:       registry.Counter("fin_report_generation_errors_total", "Reports that failed to generate.", "report"),
+	}
+}
+
+// Registry returns the registry the metrics are in, for serving or for adding
+// application metrics alongside them
+func (m *EngineMetrics) Registry() *MetricsRegistry {
+	return m.registry
+}
+
+// eventAppended counts journal events and postings
+func (m *EngineMetrics) eventAppended(event *JournalEvent) {
+	m.events.Inc(event.EventType)
+	if event.EventType == EventPostTransaction {
+		m.transactionsPosted.Inc()
+	}
+}
+
+// observeStorage records a read or write transaction
+func (m *EngineMetrics) observeStorage(operation string, elapsed time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	m.storageDuration.ObserveDuration(elapsed, operation)
+	if err != nil {
+		m.storageErrors.Inc(operation)
+	}
+}
+
+// observeMonitoring records a transaction monitored for AML alerts
+func (m *EngineMetrics) observeMonitoring(elapsed time.Duration) {
+	if m == nil {
+		return
+	}
+	m.amlMonitorDuration.ObserveDuration(elapsed)
+}
+
+// alertRaised counts a new AML alert
+func (m *EngineMetrics) alertRaised(alert *AMLAlert) {
+	m.alertsRaised.Inc(string(alert.RuleType))
+}
+
+// timeReport starts timing a report. Defer the result with the address of the
+// report's error result so failures are counted too.
+func (m *EngineMetrics) timeReport(report string) func(err *error) {
+	if m == nil {
+		return func(*error) {}
+	}
+	started := time.Now()
+	return func(err *error) {
+		m.reportDuration.ObserveDuration(time.Since(started), report)
+		if *err != nil {
+			m.reportErrors.Inc(report)
+		}
+	}
+}
+
+// watchStorage reports the storage read cache's counters at scrape time
+func (m *EngineMetrics) watchStorage(storage *Storage) {
+	m.registry.CounterFunc("fin_storage_cache_requests_total", "Reads served by the storage cache, by bucket and result.", []string{"bucket", "result"}, func() ([]MetricSample, error) {
+		var samples []MetricSample
+		for _, stats := range storage.CacheStats() {
+			samples = append(samples,
+				MetricSample{LabelValues: []string{stats.Name, "hit"}, Value: float64(stats.Hits)},
+				MetricSample{LabelValues: []string{stats.Name, "miss"}, Value: float64(stats.Misses)},
+			)
+		}
+		return samples, nil
+	})
+}
+
+// watchAML reports stored alerts by rule type and status, and open investigations,
+// at scrape time
+func (m *EngineMetrics) watchAML(storage *Storage) {
+	m.registry.GaugeFunc("fin_aml_alerts", "AML alerts on file, by rule type and status.", []string{"rule_type", "status"}, func() ([]MetricSample, error) {
+		alerts, err := storage.GetAMLAlerts()
+		if err != nil {
+			return nil, err
+		}
+		counts := make(map[[2]string]float64)
+		for _, alert := range alerts {
+			counts[[2]string{string(alert.RuleType), alert.Status}]++
+		}
+		samples := make([]MetricSample, 0, len(counts))
+		for labels, count := range counts {
+			samples = append(samples, MetricSample{LabelValues: []string{labels[0], labels[1]}, Value: count})
+		}
+		return samples, nil
+	})
+	m.registry.GaugeFunc("fin_aml_open_investigations", "AML investigations still active.", nil, func() ([]MetricSample, error) {
+		alerts, err := storage.GetAMLAlerts()
+		if err != nil {
+			return nil, err
+		}
+		open := 0
+		for _, alert := range alerts {
+			if alert.Investigation != nil && alert.Investigation.Status == "ACTIVE" {
+				open++
+			}
+		}
+		return []MetricSample{{Value: float64(open)}}, nil
+	})
+}
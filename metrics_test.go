@@ -0,0 +1,144 @@
+package accounting
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsRegistry(t *testing.T) {
+	registry := NewMetricsRegistry()
+	requests := registry.Counter("app_requests_total", "Requests served.", "method")
+	requests.Inc("GET")
+	requests.Add(2, "GET")
+	requests.Inc(`PO"ST`)
+	queue := registry.Gauge("app_queue_depth", "Jobs waiting.\nPer queue.")
+	queue.Set(7)
+	queue.Add(-2)
+	latency := registry.Histogram("app_latency_seconds", "Request latency.", []float64{0.5, 0.1}, "route")
+	latency.Observe(0.05, "/")
+	latency.Observe(0.3, "/")
+	latency.Observe(2, "/")
+	registry.GaugeFunc("app_workers", "Workers by state.", []string{"state"}, func() ([]MetricSample, error) {
+		return []MetricSample{{LabelValues: []string{"idle"}, Value: 3}, {LabelValues: []string{"busy"}, Value: 1}}, nil
+	})
+
+	var b strings.Builder
+	require.NoError(t, registry.WriteText(&b))
+	assert.Equal(t, `# HELP app_latency_seconds Request latency.
+# TYPE app_latency_seconds histogram
+app_latency_seconds_bucket{route="/",le="0.1"} 1
+app_latency_seconds_bucket{route="/",le="0.5"} 2
+app_latency_seconds_bucket{route="/",le="+Inf"} 3
+app_latency_seconds_sum{route="/"} 2.35
+app_latency_seconds_count{route="/"} 3
+# HELP app_queue_depth Jobs waiting.\nPer queue.
+# TYPE app_queue_depth gauge
+app_queue_depth 5
+# HELP app_requests_total Requests served.
+# TYPE app_requests_total counter
+app_requests_total{method="GET"} 3
+app_requests_total{method="PO\"ST"} 1
+# HELP app_workers Workers by state.
+# TYPE app_workers gauge
+app_workers{state="busy"} 1
+app_workers{state="idle"} 3
+`, b.String())
+
+	t.Run("registering again returns the same metric", func(t *testing.T) {
+		registry.Counter("app_requests_total", "Requests served.", "method").Inc("GET")
+		assert.Contains(t, scrape(t, registry.Handler()), `app_requests_total{method="GET"} 4`)
+		assert.Panics(t, func() { registry.Gauge("app_requests_total", "Requests served.", "method") })
+		assert.Panics(t, func() { requests.Inc() })
+		assert.Panics(t, func() { requests.Add(-1, "GET") })
+	})
+}
+
+// scrape fetches a metrics handler's output
+func scrape(t *testing.T, handler http.Handler) string {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", recorder.Header().Get("Content-Type"))
+	return recorder.Body.String()
+}
+
+// metricValue finds a series in scraped output
+func metricValue(t *testing.T, output, series string) float64 {
+	for _, line := range strings.Split(output, "\n") {
+		if value, ok := strings.CutPrefix(line, series+" "); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			require.NoError(t, err)
+			return parsed
+		}
+	}
+	t.Fatalf("series %s not found", series)
+	return 0
+}
+
+func TestEngineMetrics(t *testing.T) {
+	dbFile := "test_engine_metrics.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "sre"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	for i := 0; i < 3; i++ {
+		txn := &Transaction{
+			Description: "Sale",
+			ValidTime:   time.Date(2025, 4, 1, 9, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: 1000, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 1000, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	_, err = engine.GenerateTrialBalance(time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), "USD")
+	require.NoError(t, err)
+	_, err = engine.GenerateFinancialRatios(time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), "FORTNIGHTLY", "USD", nil)
+	require.Error(t, err)
+
+	aml := engine.GetAMLService()
+	structuring := &AMLAlert{RuleType: RuleStructuring, Title: "Split deposits", DetectedAt: time.Now()}
+	require.NoError(t, aml.RaiseAlert(structuring))
+	require.NoError(t, aml.RaiseAlert(&AMLAlert{RuleType: RuleCTR, Title: "Large cash deposit", DetectedAt: time.Now()}))
+	_, err = engine.CreateAMLInvestigation(structuring.ID, "analyst")
+	require.NoError(t, err)
+
+	output := scrape(t, engine.MetricsHandler())
+
+	assert.Equal(t, 3.0, metricValue(t, output, "fin_transactions_posted_total"))
+	assert.Equal(t, 3.0, metricValue(t, output, `fin_journal_events_total{type="POST_TRANSACTION"}`))
+	assert.Equal(t, 1.0, metricValue(t, output, `fin_report_generation_duration_seconds_count{report="trial_balance"}`))
+	assert.Equal(t, 1.0, metricValue(t, output, `fin_report_generation_errors_total{report="financial_ratios"}`))
+	assert.Equal(t, 1.0, metricValue(t, output, `fin_aml_alerts_raised_total{rule_type="STRUCTURING"}`))
+	assert.Equal(t, 1.0, metricValue(t, output, `fin_aml_alerts_raised_total{rule_type="CTR"}`))
+	assert.Equal(t, 1.0, metricValue(t, output, `fin_aml_alerts{rule_type="STRUCTURING",status="INVESTIGATING"}`))
+	assert.Equal(t, 1.0, metricValue(t, output, `fin_aml_alerts{rule_type="CTR",status="OPEN"}`))
+	assert.Equal(t, 1.0, metricValue(t, output, "fin_aml_open_investigations"))
+	assert.Greater(t, metricValue(t, output, `fin_storage_transaction_duration_seconds_count{operation="read"}`), 0.0)
+	assert.Greater(t, metricValue(t, output, `fin_storage_transaction_duration_seconds_count{operation="write"}`), 0.0)
+	assert.Contains(t, output, `fin_storage_cache_requests_total{bucket="accounts",result="hit"}`)
+
+	t.Run("transaction monitoring is timed", func(t *testing.T) {
+		before := metricValue(t, output, "fin_aml_monitor_duration_seconds_count")
+		_, err := aml.MonitorTransaction(&Transaction{ID: "t1", ValidTime: time.Now(), Entries: []Entry{
+			{AccountID: "cash", Type: Debit, Amount: Amount{Value: 500, Currency: "USD"}},
+		}}, nil)
+		require.NoError(t, err)
+		after := metricValue(t, scrape(t, engine.MetricsHandler()), "fin_aml_monitor_duration_seconds_count")
+		assert.Equal(t, before+1, after)
+	})
+}
@@ -2,9 +2,8 @@ package accounting
 
 import (
 	"fmt"
+	"slices"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 // Company represents a business entity in a multi-company environment
@@ -36,12 +35,16 @@ type Address struct {
 
 // CompanySettings represents company-specific settings
 type CompanySettings struct {
-	DefaultChartOfAccounts string             `json:"default_chart_of_accounts"`
-	AllowIntercompanyTxn   bool               `json:"allow_intercompany_transactions"`
-	RequireApprovalOver    *Amount            `json:"require_approval_over,omitempty"`
-	AutoPostingRules       []*AutoPostingRule `json:"auto_posting_rules,omitempty"`
-	PeriodLockingPolicy    string             `json:"period_locking_policy"`
-	ReportingCurrency      string             `json:"reporting_currency"`
+	DefaultChartOfAccounts string                  `json:"default_chart_of_accounts"`
+	AllowIntercompanyTxn   bool                    `json:"allow_intercompany_transactions"`
+	RequireApprovalOver    *Amount                 `json:"require_approval_over,omitempty"`
+	AutoPostingRules       []*AutoPostingRule      `json:"auto_posting_rules,omitempty"`
+	PeriodLockingPolicy    string                  `json:"period_locking_policy"`
+	ReportingCurrency      string                  `json:"reporting_currency"`
+	BudgetControl          BudgetControlPolicy     `json:"budget_control,omitempty"`    // WARN (default) or BLOCK
+	AccountNumbering       *AccountNumberingScheme `json:"account_numbering,omitempty"` // enforced on new account codes when set
+	PostingDateWindow      *PostingDateWindow      `json:"posting_date_window,omitempty"`
+	FourEyes               *FourEyesPolicy         `json:"four_eyes,omitempty"` // maker-checker for sensitive operations
 }
 
 // AutoPostingRule represents automatic posting rules
@@ -105,8 +108,14 @@ type ConsolidationGroup struct {
 	ChildCompanies      []string           `json:"child_companies"`
 	ConsolidationMethod string             `json:"consolidation_method"` // "FULL", "EQUITY", "PROPORTIONAL"
 	EliminationRules    []*EliminationRule `json:"elimination_rules"`
-	CreatedAt           time.Time          `json:"created_at"`
-	CreatedBy           string             `json:"created_by"`
+	// Share of each child company held by the group, from 0 to 1. Children not
+	// listed are wholly owned.
+	Ownership map[string]float64 `json:"ownership,omitempty"`
+	// Currency consolidated statements are presented in; the parent company's base
+	// currency when empty
+	PresentationCurrency string    `json:"presentation_currency,omitempty"`
+	CreatedAt            time.Time `json:"created_at"`
+	CreatedBy            string    `json:"created_by"`
 }
 
 // EliminationRule represents consolidation elimination rules
@@ -150,10 +159,19 @@ func (mce *MultiCompanyEngine) Close() error {
 
 // CreateCompany creates a new company
 func (mce *MultiCompanyEngine) CreateCompany(company *Company, userID string) error {
+	if err := mce.storage.assignID(&company.ID, "company", BucketCompanies); err != nil {
+		return err
+	}
 	company.CreatedAt = time.Now()
 	company.CreatedBy = userID
 	company.Status = CompanyActive
 
+	if company.Settings != nil && company.Settings.AccountNumbering != nil {
+		if err := company.Settings.AccountNumbering.Validate(); err != nil {
+			return fmt.Errorf("invalid account numbering scheme: %w", err)
+		}
+	}
+
 	if err := mce.storage.SaveCompany(company); err != nil {
 		return fmt.Errorf("failed to save company: %w", err)
 	}
@@ -168,9 +186,12 @@ func (mce *MultiCompanyEngine) CreateCompany(company *Company, userID string) er
 	// Store in cache
 	mce.companies[company.ID] = company
 	mce.engines[company.ID] = engine // Cache the engine
+	if err := engine.ApplyCompanySettings(company.Settings); err != nil {
+		return err
+	}
 
 	// Create standard chart of accounts if specified
-	if company.Settings.DefaultChartOfAccounts != "" {
+	if company.Settings != nil && company.Settings.DefaultChartOfAccounts != "" {
 		if err := engine.CreateStandardAccounts(userID); err != nil {
 			return fmt.Errorf("failed to create standard accounts: %w", err)
 		}
@@ -197,7 +218,7 @@ func (mce *MultiCompanyEngine) GetCompany(companyID string) (*Company, error) {
 // GetAccountingEngine gets the accounting engine for a specific company
 func (mce *MultiCompanyEngine) GetAccountingEngine(companyID string) (*AccountingEngine, error) {
 	// Verify company exists
-	_, err := mce.GetCompany(companyID)
+	company, err := mce.GetCompany(companyID)
 	if err != nil {
 		return nil, err
 	}
@@ -216,6 +237,9 @@ func (mce *MultiCompanyEngine) GetAccountingEngine(companyID string) (*Accountin
 
 	// Cache the engine
 	mce.engines[companyID] = engine
+	if err := engine.ApplyCompanySettings(company.Settings); err != nil {
+		return nil, err
+	}
 	return engine, nil
 }
 
@@ -243,7 +267,7 @@ func (mce *MultiCompanyEngine) CreateIntercompanyTransaction(
 
 	// Create intercompany transaction record
 	intercompanyTxn := &IntercompanyTransaction{
-		ID:              uuid.New().String(),
+		ID:              newID(),
 		Description:     description,
 		SourceCompanyID: sourceCompanyID,
 		TargetCompanyID: targetCompanyID,
@@ -274,7 +298,7 @@ func (mce *MultiCompanyEngine) CreateIntercompanyTransaction(
 				Type:      Debit,
 				Amount:    *amount,
 				Dimensions: []Dimension{
-					{Key: "intercompany", Value: targetCompanyID},
+					{Key: DimIntercompany, Value: targetCompanyID},
 					{Key: "transaction_type", Value: "intercompany_transfer"},
 				},
 			},
@@ -309,7 +333,7 @@ func (mce *MultiCompanyEngine) CreateIntercompanyTransaction(
 				Type:      Credit,
 				Amount:    *amount,
 				Dimensions: []Dimension{
-					{Key: "intercompany", Value: sourceCompanyID},
+					{Key: DimIntercompany, Value: sourceCompanyID},
 					{Key: "transaction_type", Value: "intercompany_transfer"},
 				},
 			},
@@ -389,9 +413,10 @@ func (mce *MultiCompanyEngine) GenerateConsolidatedTrialBalance(
 			continue // Skip on error
 		}
 
-		trialBalance, err := engine.GetTrialBalance(asOfDate, nil)
+		// Subsidiaries in hyperinflationary economies are consolidated on restated amounts
+		trialBalance, err := companyTrialBalance(engine, asOfDate)
 		if err != nil {
-			continue // Skip on error
+			return nil, fmt.Errorf("failed to get trial balance for company %s: %w", companyID, err)
 		}
 
 		company, _ := mce.GetCompany(companyID)
@@ -425,6 +450,7 @@ type TrialBalance struct {
 // EliminationEntry represents an elimination entry for consolidation
 type EliminationEntry struct {
 	Description string  `json:"description"`
+	CompanyID   string  `json:"company_id,omitempty"`
 	AccountID   string  `json:"account_id"`
 	Amount      *Amount `json:"amount"`
 	RuleID      string  `json:"rule_id"`
@@ -518,6 +544,17 @@ func (mce *MultiCompanyEngine) GetIntercompanyTransactions(companyID string) ([]
 
 // CreateConsolidationGroup creates a new consolidation group
 func (mce *MultiCompanyEngine) CreateConsolidationGroup(group *ConsolidationGroup, userID string) error {
+	if err := mce.storage.assignID(&group.ID, "consolidation group", BucketConsolidationGroups); err != nil {
+		return err
+	}
+	for companyID, share := range group.Ownership {
+		if !slices.Contains(group.ChildCompanies, companyID) {
+			return fmt.Errorf("ownership given for %s, which is not a child company of the group", companyID)
+		}
+		if share <= 0 || share > 1 {
+			return fmt.Errorf("ownership of %s must be above 0 and at most 1, got %v", companyID, share)
+		}
+	}
 	group.CreatedAt = time.Now()
 	group.CreatedBy = userID
 
@@ -0,0 +1,583 @@
+package accounting
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Event Notifications
+// ----------------------------------------------------------------------------
+
+// Notification topics subscribers can ask for
+const (
+	TopicTransactionPosted = "transaction.posted"
+	TopicAMLAlertCreated   = "aml.alert_created"
+	TopicPeriodClosed      = "period.closed"
+	TopicBudgetExceeded    = "budget.exceeded"
+	TopicAll               = "*"
+)
+
+// notificationTopics maps journal events to the topic they are published on
+var notificationTopics = map[string]string{
+	EventPostTransaction: TopicTransactionPosted,
+	EventClosePeriod:     TopicPeriodClosed,
+	EventSoftClosePeriod: TopicPeriodClosed,
+	EventHardClosePeriod: TopicPeriodClosed,
+	EventBudgetExceeded:  TopicBudgetExceeded,
+}
+
+// Headers on webhook requests
+const (
+	NotificationSignatureHeader = "X-Fin-Signature" // t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<body>">
+	NotificationTopicHeader     = "X-Fin-Topic"
+	NotificationDeliveryHeader  = "X-Fin-Delivery"
+)
+
+// NotificationSubscription sends notifications on some topics to a webhook URL or
+// to a Go callback registered under a name
+type NotificationSubscription struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Topics      []string  `json:"topics"` // TopicAll for every topic
+	URL         string    `json:"url,omitempty"`
+	Callback    string    `json:"callback,omitempty"`
+	Secret      string    `json:"-"` // signs webhook payloads; not signed when empty
+	Active      bool      `json:"active"`
+	MaxAttempts int       `json:"max_attempts"` // zero uses the service's retry policy
+	CreatedBy   string    `json:"created_by"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Notification is the body delivered to subscribers
+type Notification struct {
+	ID         string          `json:"id"`
+	Topic      string          `json:"topic"`
+	EventID    string          `json:"event_id,omitempty"`
+	EventType  string          `json:"event_type,omitempty"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	UserID     string          `json:"user_id,omitempty"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// NotificationCallback receives notifications in process. Returning an error has
+// the delivery retried.
+type NotificationCallback func(notification *Notification) error
+
+// NotificationDeliveryStatus is the outcome of one delivery attempt
+type NotificationDeliveryStatus string
+
+const (
+	DeliverySucceeded NotificationDeliveryStatus = "SUCCEEDED"
+	DeliveryRetrying  NotificationDeliveryStatus = "RETRYING" // failed, another attempt is scheduled
+	DeliveryFailed    NotificationDeliveryStatus = "FAILED"   // failed and given up on
+)
+
+// NotificationDelivery logs one attempt to deliver a notification
+type NotificationDelivery struct {
+	ID             string                     `json:"id"`
+	SubscriptionID string                     `json:"subscription_id"`
+	NotificationID string                     `json:"notification_id"`
+	Topic          string                     `json:"topic"`
+	Attempt        int                        `json:"attempt"`
+	Status         NotificationDeliveryStatus `json:"status"`
+	StatusCode     int                        `json:"status_code,omitempty"` // HTTP status for webhooks
+	Error          string                     `json:"error,omitempty"`
+	Duration       time.Duration              `json:"duration"`
+	AttemptedAt    time.Time                  `json:"attempted_at"`
+	NextAttemptAt  *time.Time                 `json:"next_attempt_at,omitempty"`
+}
+
+// NotificationRetryPolicy is how failed deliveries are retried: the wait doubles
+// (by Multiplier) after each attempt, up to MaxBackoff
+type NotificationRetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// DefaultNotificationRetryPolicy tries five times over about a quarter of an hour
+func DefaultNotificationRetryPolicy() NotificationRetryPolicy {
+	return NotificationRetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 30 * time.Second,
+		MaxBackoff:     10 * time.Minute,
+		Multiplier:     2,
+	}
+}
+
+// backoff is the wait before the attempt after the given one
+func (p NotificationRetryPolicy) backoff(attempt int) time.Duration {
+	wait := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		wait *= p.Multiplier
+		if wait >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+	return time.Duration(wait)
+}
+
+// NotificationService delivers notifications for journal events and AML alerts to
+// webhooks and callbacks. Deliveries run in the background, so a slow or failing
+// subscriber never holds up posting; every attempt is logged.
+type NotificationService struct {
+	storage    *Storage
+	eventStore *EventStore
+	client     *http.Client
+
+	mu            sync.RWMutex // guards the fields below
+	subscriptions map[string]*NotificationSubscription
+	callbacks     map[string]NotificationCallback
+	retry         NotificationRetryPolicy
+
+	ctx     context.Context // cancelled by Close to abandon pending retries
+	cancel  context.CancelFunc
+	pending sync.WaitGroup
+	backlog atomic.Int64 // deliveries started and not yet finished
+	logger  *slog.Logger
+}
+
+// NewNotificationService creates a new notification service and starts listening
+// for events and alerts
+func NewNotificationService(storage *Storage, eventStore *EventStore, aml *AMLService) (*NotificationService, error) {
+	subscriptions, err := storage.GetAllNotificationSubscriptions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notification subscriptions: %w", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ns := &NotificationService{
+		storage:       storage,
+		eventStore:    eventStore,
+		client:        &http.Client{Timeout: 10 * time.Second},
+		subscriptions: make(map[string]*NotificationSubscription, len(subscriptions)),
+		callbacks:     make(map[string]NotificationCallback),
+		retry:         DefaultNotificationRetryPolicy(),
+		ctx:           ctx,
+		cancel:        cancel,
+		logger:        discardLogger,
+	}
+	for _, subscription := range subscriptions {
+		ns.subscriptions[subscription.ID] = subscription
+	}
+
+	eventStore.AddListener(ns.eventAppended)
+	aml.AddAlertHandler(func(alert *AMLAlert) {
+		if _, err := ns.Publish(TopicAMLAlertCreated, alert, alert.DetectedAt, ""); err != nil {
+			ns.logger.Error("failed to publish notification", "topic", TopicAMLAlertCreated, LogKeyAlertID, alert.ID, LogKeyError, err)
+		}
+	})
+	return ns, nil
+}
+
+// SetLogger sends the service's logs to a host logger; nil discards them. Set it
+// while the engine is being assembled.
+func (ns *NotificationService) SetLogger(logger *slog.Logger) {
+	ns.logger = componentLogger(logger, "notifications")
+}
+
+// SetRetryPolicy changes how failed deliveries are retried
+func (ns *NotificationService) SetRetryPolicy(policy NotificationRetryPolicy) error {
+	if policy.MaxAttempts < 1 || policy.InitialBackoff < 0 || policy.MaxBackoff < policy.InitialBackoff || policy.Multiplier < 1 {
+		return fmt.Errorf("invalid retry policy: %+v", policy)
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.retry = policy
+	return nil
+}
+
+// SetHTTPClient replaces the client webhooks are posted with
+func (ns *NotificationService) SetHTTPClient(client *http.Client) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.client = client
+}
+
+// RegisterCallback makes a callback available to subscriptions by name. Callbacks
+// are not persisted and must be registered again after a restart.
+func (ns *NotificationService) RegisterCallback(name string, callback NotificationCallback) {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.callbacks[name] = callback
+}
+
+// Subscribe validates and saves a subscription. New subscriptions start active.
+func (ns *NotificationService) Subscribe(subscription *NotificationSubscription, userID string) error {
+	if subscription.Name == "" {
+		return fmt.Errorf("subscription name is required")
+	}
+	if (subscription.URL == "") == (subscription.Callback == "") {
+		return fmt.Errorf("subscription %s needs either a webhook URL or a callback", subscription.Name)
+	}
+	if subscription.URL != "" && !strings.HasPrefix(subscription.URL, "http://") && !strings.HasPrefix(subscription.URL, "https://") {
+		return fmt.Errorf("webhook URL must be http or https: %s", subscription.URL)
+	}
+	if len(subscription.Topics) == 0 {
+		return fmt.Errorf("subscription %s has no topics", subscription.Name)
+	}
+	for _, topic := range subscription.Topics {
+		if !knownNotificationTopic(topic) {
+			return fmt.Errorf("unknown notification topic: %s", topic)
+		}
+	}
+	if subscription.MaxAttempts < 0 {
+		return fmt.Errorf("max attempts cannot be negative")
+	}
+
+	now := time.Now()
+	if subscription.ID == "" {
+		if err := ns.storage.assignID(&subscription.ID, "subscription", BucketNotificationSubscriptions); err != nil {
+			return err
+		}
+		subscription.Active = true
+		subscription.CreatedBy = userID
+		subscription.CreatedAt = now
+	}
+	subscription.UpdatedAt = now
+	return ns.saveSubscription(subscription, userID)
+}
+
+// SetActive pauses or resumes a subscription
+func (ns *NotificationService) SetActive(subscriptionID string, active bool, userID string) error {
+	ns.mu.RLock()
+	existing, ok := ns.subscriptions[subscriptionID]
+	ns.mu.RUnlock()
+	if !ok {
+		return notFound("notification subscription", subscriptionID)
+	}
+	subscription := *existing
+	subscription.Active = active
+	subscription.UpdatedAt = time.Now()
+	return ns.saveSubscription(&subscription, userID)
+}
+
+// saveSubscription records and stores a subscription, then makes it live
+func (ns *NotificationService) saveSubscription(subscription *NotificationSubscription, userID string) error {
+	_, err := ns.eventStore.CreateEvent(EventSaveNotificationSubscription, subscription, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create subscription event: %w", err)
+	}
+	if err := ns.storage.SaveNotificationSubscription(subscription); err != nil {
+		return err
+	}
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	ns.subscriptions[subscription.ID] = subscription
+	return nil
+}
+
+// GetSubscriptions returns the subscriptions by name
+func (ns *NotificationService) GetSubscriptions() []*NotificationSubscription {
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+	subscriptions := make([]*NotificationSubscription, 0, len(ns.subscriptions))
+	for _, subscription := range ns.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].Name < subscriptions[j].Name
+	})
+	return subscriptions
+}
+
+// GetDeliveries returns a subscription's delivery attempts, oldest first
+func (ns *NotificationService) GetDeliveries(subscriptionID string) ([]*NotificationDelivery, error) {
+	all, err := ns.storage.GetAllNotificationDeliveries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deliveries: %w", err)
+	}
+	var deliveries []*NotificationDelivery
+	for _, delivery := range all {
+		if delivery.SubscriptionID == subscriptionID {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		if !deliveries[i].AttemptedAt.Equal(deliveries[j].AttemptedAt) {
+			return deliveries[i].AttemptedAt.Before(deliveries[j].AttemptedAt)
+		}
+		return deliveries[i].Attempt < deliveries[j].Attempt
+	})
+	return deliveries, nil
+}
+
+// eventAppended publishes journal events that map to a topic
+func (ns *NotificationService) eventAppended(event *JournalEvent) {
+	topic, ok := notificationTopics[event.EventType]
+	if !ok {
+		return
+	}
+	notification := &Notification{
+		ID:         newID(),
+		Topic:      topic,
+		EventID:    event.ID,
+		EventType:  event.EventType,
+		OccurredAt: event.ValidTime,
+		UserID:     event.UserID,
+		Payload:    event.Payload,
+	}
+	ns.dispatch(notification)
+}
+
+// Publish sends a notification on a topic to its subscribers, returning it once the
+// deliveries are queued
+func (ns *NotificationService) Publish(topic string, payload interface{}, occurredAt time.Time, userID string) (*Notification, error) {
+	if topic == TopicAll || !knownNotificationTopic(topic) {
+		return nil, fmt.Errorf("unknown notification topic: %s", topic)
+	}
+	payloadData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal notification payload: %w", err)
+	}
+	notification := &Notification{
+		ID:         newID(),
+		Topic:      topic,
+		OccurredAt: occurredAt,
+		UserID:     userID,
+		Payload:    payloadData,
+	}
+	ns.dispatch(notification)
+	return notification, nil
+}
+
+// dispatch starts a delivery to each active subscriber to the topic
+func (ns *NotificationService) dispatch(notification *Notification) {
+	ns.mu.RLock()
+	defer ns.mu.RUnlock()
+	if ns.ctx.Err() != nil {
+		return
+	}
+	for _, subscription := range ns.subscriptions {
+		if !subscription.Active || !(slices.Contains(subscription.Topics, notification.Topic) || slices.Contains(subscription.Topics, TopicAll)) {
+			continue
+		}
+		ns.pending.Add(1)
+		ns.backlog.Add(1)
+		go ns.deliver(subscription, notification)
+	}
+}
+
+// deliver makes attempts until one succeeds, the retries run out, or the service
+// is closed, logging each attempt
+func (ns *NotificationService) deliver(subscription *NotificationSubscription, notification *Notification) {
+	defer ns.pending.Done()
+	defer ns.backlog.Add(-1)
+	body, err := json.Marshal(notification)
+	if err != nil {
+		ns.logger.Error("failed to encode notification", LogKeyNotification, notification.ID, LogKeyError, err)
+		return
+	}
+
+	ns.mu.RLock()
+	policy := ns.retry
+	ns.mu.RUnlock()
+	maxAttempts := policy.MaxAttempts
+	if subscription.MaxAttempts > 0 {
+		maxAttempts = subscription.MaxAttempts
+	}
+
+	for attempt := 1; ; attempt++ {
+		delivery := &NotificationDelivery{
+			SubscriptionID: subscription.ID,
+			NotificationID: notification.ID,
+			Topic:          notification.Topic,
+			Attempt:        attempt,
+			AttemptedAt:    time.Now(),
+		}
+		var retryable bool
+		if subscription.URL != "" {
+			delivery.StatusCode, retryable, err = ns.postWebhook(subscription, notification, delivery, body)
+		} else {
+			retryable = true
+			err = ns.runCallback(subscription, notification)
+		}
+		delivery.Duration = time.Since(delivery.AttemptedAt)
+
+		var wait time.Duration
+		switch {
+		case err == nil:
+			delivery.Status = DeliverySucceeded
+		case retryable && attempt < maxAttempts:
+			delivery.Status = DeliveryRetrying
+			delivery.Error = err.Error()
+			wait = policy.backoff(attempt)
+			next := delivery.AttemptedAt.Add(delivery.Duration + wait)
+			delivery.NextAttemptAt = &next
+		default:
+			delivery.Status = DeliveryFailed
+			delivery.Error = err.Error()
+		}
+		ns.logDelivery(delivery)
+		logger := ns.logger.With(LogKeySubscription, subscription.ID, LogKeyNotification, notification.ID,
+			"topic", notification.Topic, "attempt", attempt)
+		switch delivery.Status {
+		case DeliverySucceeded:
+			logger.Debug("notification delivered", "duration", delivery.Duration)
+		case DeliveryRetrying:
+			logger.Warn("notification delivery failed, will retry", "retry_in", wait, LogKeyError, err)
+		default:
+			logger.Error("notification delivery failed", LogKeyError, err)
+		}
+		if delivery.Status != DeliveryRetrying {
+			return
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ns.ctx.Done():
+			return
+		}
+	}
+}
+
+// postWebhook posts a notification, reporting the response status and whether a
+// failure is worth retrying: network errors, timeouts, throttling and server errors are
+func (ns *NotificationService) postWebhook(subscription *NotificationSubscription, notification *Notification, delivery *NotificationDelivery, body []byte) (int, bool, error) {
+	request, err := http.NewRequestWithContext(ns.ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(NotificationTopicHeader, notification.Topic)
+	request.Header.Set(NotificationDeliveryHeader, notification.ID)
+	if subscription.Secret != "" {
+		request.Header.Set(NotificationSignatureHeader, SignNotificationPayload(subscription.Secret, delivery.AttemptedAt, body))
+	}
+
+	ns.mu.RLock()
+	client := ns.client
+	ns.mu.RUnlock()
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, true, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10))
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return response.StatusCode, false, nil
+	}
+	retryable := response.StatusCode >= 500 || response.StatusCode == http.StatusRequestTimeout || response.StatusCode == http.StatusTooManyRequests
+	return response.StatusCode, retryable, fmt.Errorf("webhook returned %s", response.Status)
+}
+
+// runCallback calls a subscription's callback, recovering from panics
+func (ns *NotificationService) runCallback(subscription *NotificationSubscription, notification *Notification) (err error) {
+	ns.mu.RLock()
+	callback, ok := ns.callbacks[subscription.Callback]
+	ns.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no notification callback registered as %s", subscription.Callback)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("notification callback %s panicked: %v", subscription.Callback, r)
+		}
+	}()
+	if err := callback(notification); err != nil {
+		return fmt.Errorf("notification callback %s failed: %w", subscription.Callback, err)
+	}
+	return nil
+}
+
+// logDelivery saves a delivery attempt. The log is best effort: a failure to write
+// it must not stop the delivery.
+func (ns *NotificationService) logDelivery(delivery *NotificationDelivery) {
+	if err := ns.storage.assignID(&delivery.ID, "delivery", BucketNotificationDeliveries); err != nil {
+		ns.logger.Error("failed to record notification delivery", LogKeySubscription, delivery.SubscriptionID, LogKeyError, err)
+		return
+	}
+	if err := ns.storage.SaveNotificationDelivery(delivery); err != nil {
+		ns.logger.Error("failed to record notification delivery", LogKeySubscription, delivery.SubscriptionID, LogKeyError, err)
+	}
+}
+
+// Wait blocks until the deliveries in flight have finished, including their retries
+func (ns *NotificationService) Wait() {
+	ns.pending.Wait()
+}
+
+// Backlog is how many deliveries are still being attempted, including those waiting
+// to retry
+func (ns *NotificationService) Backlog() int {
+	return int(ns.backlog.Load())
+}
+
+// Close stops accepting notifications, abandons pending retries and waits for
+// attempts in progress
+func (ns *NotificationService) Close() {
+	ns.mu.Lock()
+	ns.cancel()
+	ns.mu.Unlock()
+	ns.pending.Wait()
+}
+
+// knownNotificationTopic reports whether subscribers can ask for a topic
+func knownNotificationTopic(topic string) bool {
+	switch topic {
+	case TopicTransactionPosted, TopicAMLAlertCreated, TopicPeriodClosed, TopicBudgetExceeded, TopicAll:
+		return true
+	}
+	return false
+}
+
+// SignNotificationPayload returns the signature header value for a webhook body:
+// the timestamp and an HMAC-SHA256 of "<unix seconds>.<body>" under the secret
+func SignNotificationPayload(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix + "."))
+	mac.Write(body)
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyNotificationSignature checks a webhook's signature header against its body,
+// rejecting signatures older than the tolerance to stop replays. Receivers call it
+// with the subscription's secret.
+func VerifyNotificationSignature(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var unix string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return fmt.Errorf("malformed signature header")
+	}
+	timestamp := time.Unix(seconds, 0)
+	if tolerance > 0 && (now.Sub(timestamp) > tolerance || timestamp.Sub(now) > tolerance) {
+		return fmt.Errorf("signature timestamp %s is outside the tolerance", timestamp.UTC().Format(time.RFC3339))
+	}
+
+	_, expected, _ := strings.Cut(SignNotificationPayload(secret, timestamp, body), ",v1=")
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("signature does not match")
+}
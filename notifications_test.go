@@ -0,0 +1,241 @@
+package accounting
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifications(t *testing.T) {
+	dbFile := "test_notifications.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer func() { engine.Close() }()
+
+	userID := "integrator"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	notifications := engine.GetNotificationService()
+	require.NoError(t, notifications.SetRetryPolicy(NotificationRetryPolicy{
+		MaxAttempts: 3, InitialBackoff: 5 * time.Millisecond, MaxBackoff: 20 * time.Millisecond, Multiplier: 2,
+	}))
+
+	post := func() *Transaction {
+		txn := &Transaction{
+			Description: "Sale",
+			ValidTime:   time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: 2500, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 2500, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	statuses := func(subscriptionID string) []NotificationDeliveryStatus {
+		deliveries, err := engine.GetNotificationDeliveries(subscriptionID)
+		require.NoError(t, err)
+		var result []NotificationDeliveryStatus
+		for _, delivery := range deliveries {
+			result = append(result, delivery.Status)
+		}
+		return result
+	}
+
+	t.Run("subscriptions are validated", func(t *testing.T) {
+		err := engine.Subscribe(&NotificationSubscription{Name: "Both", URL: "https://example.com", Callback: "cb", Topics: []string{TopicAll}}, userID)
+		assert.ErrorContains(t, err, "either a webhook URL or a callback")
+		err = engine.Subscribe(&NotificationSubscription{Name: "FTP", URL: "ftp://example.com", Topics: []string{TopicAll}}, userID)
+		assert.ErrorContains(t, err, "must be http or https")
+		err = engine.Subscribe(&NotificationSubscription{Name: "Typo", Callback: "cb", Topics: []string{"transaction.psoted"}}, userID)
+		assert.ErrorContains(t, err, "unknown notification topic")
+		err = engine.Subscribe(&NotificationSubscription{Name: "None", Callback: "cb"}, userID)
+		assert.ErrorContains(t, err, "has no topics")
+	})
+
+	t.Run("webhooks are signed and logged", func(t *testing.T) {
+		secret := "whsec_test"
+		var mu sync.Mutex
+		var received []*Notification
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if err := VerifyNotificationSignature(secret, r.Header.Get(NotificationSignatureHeader), body, time.Minute, time.Now()); err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+			var notification Notification
+			require.NoError(t, json.Unmarshal(body, &notification))
+			assert.Equal(t, notification.Topic, r.Header.Get(NotificationTopicHeader))
+			assert.Equal(t, notification.ID, r.Header.Get(NotificationDeliveryHeader))
+			mu.Lock()
+			received = append(received, &notification)
+			mu.Unlock()
+		}))
+		defer server.Close()
+
+		subscription := &NotificationSubscription{Name: "Ledger feed", URL: server.URL, Secret: secret, Topics: []string{TopicTransactionPosted}}
+		require.NoError(t, engine.Subscribe(subscription, userID))
+		assert.True(t, subscription.Active)
+
+		txn := post()
+		notifications.Wait()
+
+		require.Len(t, received, 1)
+		assert.Equal(t, TopicTransactionPosted, received[0].Topic)
+		assert.Equal(t, EventPostTransaction, received[0].EventType)
+		assert.Equal(t, userID, received[0].UserID)
+		var payload TransactionPostedEvent
+		require.NoError(t, json.Unmarshal(received[0].Payload, &payload))
+		assert.Equal(t, txn.ID, payload.TransactionID)
+
+		deliveries, err := engine.GetNotificationDeliveries(subscription.ID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, DeliverySucceeded, deliveries[0].Status)
+		assert.Equal(t, http.StatusOK, deliveries[0].StatusCode)
+		assert.Equal(t, received[0].ID, deliveries[0].NotificationID)
+
+		// Paused subscriptions receive nothing
+		require.NoError(t, engine.SetSubscriptionActive(subscription.ID, false, userID))
+		post()
+		notifications.Wait()
+		assert.Len(t, received, 1)
+	})
+
+	t.Run("server errors are retried with backoff", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+
+		subscription := &NotificationSubscription{Name: "Flaky", URL: server.URL, Topics: []string{TopicTransactionPosted}}
+		require.NoError(t, engine.Subscribe(subscription, userID))
+		post()
+		notifications.Wait()
+		require.NoError(t, engine.SetSubscriptionActive(subscription.ID, false, userID))
+
+		assert.Equal(t, int32(3), calls.Load())
+		deliveries, err := engine.GetNotificationDeliveries(subscription.ID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 3)
+		assert.Equal(t, []NotificationDeliveryStatus{DeliveryRetrying, DeliveryRetrying, DeliverySucceeded}, statuses(subscription.ID))
+		assert.Equal(t, http.StatusServiceUnavailable, deliveries[0].StatusCode)
+		require.NotNil(t, deliveries[0].NextAttemptAt)
+		assert.False(t, deliveries[1].AttemptedAt.Before(*deliveries[0].NextAttemptAt))
+		assert.Equal(t, 5*time.Millisecond, NotificationRetryPolicy{InitialBackoff: 5 * time.Millisecond, MaxBackoff: 20 * time.Millisecond, Multiplier: 2}.backoff(1))
+		assert.Equal(t, 20*time.Millisecond, NotificationRetryPolicy{InitialBackoff: 5 * time.Millisecond, MaxBackoff: 20 * time.Millisecond, Multiplier: 2}.backoff(4))
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusGone)
+		}))
+		defer server.Close()
+
+		subscription := &NotificationSubscription{Name: "Gone", URL: server.URL, Topics: []string{TopicAll}}
+		require.NoError(t, engine.Subscribe(subscription, userID))
+		post()
+		notifications.Wait()
+		require.NoError(t, engine.SetSubscriptionActive(subscription.ID, false, userID))
+
+		assert.Equal(t, int32(1), calls.Load())
+		deliveries, err := engine.GetNotificationDeliveries(subscription.ID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, DeliveryFailed, deliveries[0].Status)
+		assert.Equal(t, "webhook returned 410 Gone", deliveries[0].Error)
+	})
+
+	t.Run("callbacks receive AML alerts and period closes", func(t *testing.T) {
+		var mu sync.Mutex
+		topics := make(map[string]int)
+		engine.RegisterNotificationCallback("compliance", func(notification *Notification) error {
+			mu.Lock()
+			defer mu.Unlock()
+			topics[notification.Topic]++
+			return nil
+		})
+		subscription := &NotificationSubscription{Name: "Compliance", Callback: "compliance", Topics: []string{TopicAMLAlertCreated, TopicPeriodClosed}}
+		require.NoError(t, engine.Subscribe(subscription, userID))
+
+		require.NoError(t, engine.GetAMLService().RaiseAlert(&AMLAlert{Title: "Reconciliation exception", Description: "Unmatched settlement", DetectedAt: time.Now()}))
+		_, err := engine.eventStore.CreateEvent(EventSoftClosePeriod, map[string]string{"period_id": "2025-03"}, time.Now(), userID)
+		require.NoError(t, err)
+		post()
+		notifications.Wait()
+
+		assert.Equal(t, map[string]int{TopicAMLAlertCreated: 1, TopicPeriodClosed: 1}, topics)
+		assert.Equal(t, []NotificationDeliveryStatus{DeliverySucceeded, DeliverySucceeded}, statuses(subscription.ID))
+		require.NoError(t, engine.SetSubscriptionActive(subscription.ID, false, userID))
+	})
+
+	t.Run("failing callbacks give up after the subscription's attempts", func(t *testing.T) {
+		var calls atomic.Int32
+		engine.RegisterNotificationCallback("broken", func(notification *Notification) error {
+			if calls.Add(1) == 1 {
+				panic("nil map")
+			}
+			return fmt.Errorf("downstream unavailable")
+		})
+		subscription := &NotificationSubscription{Name: "Broken", Callback: "broken", Topics: []string{TopicBudgetExceeded}, MaxAttempts: 2}
+		require.NoError(t, engine.Subscribe(subscription, userID))
+
+		_, err := notifications.Publish(TopicBudgetExceeded, map[string]int64{"over_by": 100}, time.Now(), userID)
+		require.NoError(t, err)
+		notifications.Wait()
+
+		deliveries, err := engine.GetNotificationDeliveries(subscription.ID)
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Contains(t, deliveries[0].Error, "panicked: nil map")
+		assert.Equal(t, DeliveryFailed, deliveries[1].Status)
+		assert.Equal(t, "notification callback broken failed: downstream unavailable", deliveries[1].Error)
+
+		_, err = notifications.Publish(TopicAll, nil, time.Now(), userID)
+		assert.ErrorContains(t, err, "unknown notification topic")
+	})
+
+	t.Run("signatures", func(t *testing.T) {
+		body := []byte(`{"id":"n1"}`)
+		signedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+		header := SignNotificationPayload("secret", signedAt, body)
+		assert.NoError(t, VerifyNotificationSignature("secret", header, body, 5*time.Minute, signedAt.Add(time.Minute)))
+		assert.ErrorContains(t, VerifyNotificationSignature("other", header, body, 0, signedAt), "does not match")
+		assert.ErrorContains(t, VerifyNotificationSignature("secret", header, []byte(`{"id":"n2"}`), 0, signedAt), "does not match")
+		assert.ErrorContains(t, VerifyNotificationSignature("secret", header, body, 5*time.Minute, signedAt.Add(time.Hour)), "outside the tolerance")
+		assert.ErrorContains(t, VerifyNotificationSignature("secret", "v1=abc", body, 0, signedAt), "malformed")
+	})
+
+	t.Run("subscriptions survive a restart", func(t *testing.T) {
+		require.NoError(t, engine.Close())
+		reopened, err := NewAccountingEngine(dbFile)
+		require.NoError(t, err)
+		engine = reopened
+
+		byName := make(map[string]*NotificationSubscription)
+		for _, subscription := range engine.GetNotificationService().GetSubscriptions() {
+			byName[subscription.Name] = subscription
+		}
+		require.Len(t, byName, 5)
+		assert.Equal(t, "whsec_test", byName["Ledger feed"].Secret)
+		assert.False(t, byName["Ledger feed"].Active)
+		assert.Equal(t, 2, byName["Broken"].MaxAttempts)
+	})
+}
@@ -0,0 +1,699 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Operating Segments (IFRS 8 / ASC 280)
+// ----------------------------------------------------------------------------
+
+// OperatingSegment is a component of the business whose results are reviewed on their
+// own. Entries are mapped to a segment by the company that records them or by a
+// dimension value they carry; a company mapping takes precedence.
+type OperatingSegment struct {
+	ID              string       `json:"id"`
+	Name            string       `json:"name"`
+	Description     string       `json:"description,omitempty"`
+	DimensionKey    DimensionKey `json:"dimension_key,omitempty"`
+	DimensionValues []string     `json:"dimension_values,omitempty"`
+	CompanyIDs      []string     `json:"company_ids,omitempty"` // group companies wholly in the segment
+	CreatedBy       string       `json:"created_by"`
+	CreatedAt       time.Time    `json:"created_at"`
+}
+
+// SegmentAllocationBasis is how shared balances are spread across segments
+type SegmentAllocationBasis string
+
+const (
+	AllocateByRevenue     SegmentAllocationBasis = "EXTERNAL_REVENUE" // in proportion to external revenue
+	AllocateByFixedShares SegmentAllocationBasis = "FIXED_SHARES"
+)
+
+// SegmentAllocationRule spreads the balances of shared asset and liability accounts
+// that no segment's entries account for, such as a head office building, across
+// the segments
+type SegmentAllocationRule struct {
+	ID         string                 `json:"id"`
+	Name       string                 `json:"name"`
+	AccountIDs []string               `json:"account_ids"`
+	Basis      SegmentAllocationBasis `json:"basis"`
+	Shares     map[string]float64     `json:"shares,omitempty"` // segment ID -> share summing to 1, for fixed shares
+	CreatedBy  string                 `json:"created_by"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// SegmentResult is what one segment reports for a period. Revenue and expenses
+// include inter-segment transactions, which are eliminated on reconciliation.
+type SegmentResult struct {
+	SegmentID           string  `json:"segment_id"` // empty for unallocated amounts
+	SegmentName         string  `json:"segment_name"`
+	ExternalRevenue     *Amount `json:"external_revenue"`
+	InterSegmentRevenue *Amount `json:"inter_segment_revenue"`
+	Revenue             *Amount `json:"revenue"`
+	Expenses            *Amount `json:"expenses"`
+	ProfitOrLoss        *Amount `json:"profit_or_loss"`
+	Assets              *Amount `json:"assets"`
+	AllocatedAssets     *Amount `json:"allocated_assets"` // part of Assets spread by allocation rules
+	Liabilities         *Amount `json:"liabilities"`
+}
+
+// SegmentReconciliation reconciles the segment total of one measure to the
+// consolidated financial statements
+type SegmentReconciliation struct {
+	Measure      string  `json:"measure"`
+	Segments     *Amount `json:"segments"`     // total of the operating segments
+	Eliminations *Amount `json:"eliminations"` // inter-segment amounts
+	Unallocated  *Amount `json:"unallocated"`  // corporate amounts not mapped to a segment
+	Other        *Amount `json:"other"`        // e.g. translation rounding
+	Consolidated *Amount `json:"consolidated"`
+}
+
+// SegmentDisclosure is the operating segment note: each segment's results, assets and
+// liabilities, and their reconciliation to the consolidated totals
+type SegmentDisclosure struct {
+	GroupID         string                   `json:"group_id,omitempty"` // set for a consolidation group
+	FromDate        time.Time                `json:"from_date"`
+	ToDate          time.Time                `json:"to_date"`
+	Currency        string                   `json:"currency"`
+	Segments        []*SegmentResult         `json:"segments"`
+	Unallocated     *SegmentResult           `json:"unallocated"`
+	Reconciliations []*SegmentReconciliation `json:"reconciliations"`
+	GeneratedAt     time.Time                `json:"generated_at"`
+}
+
+// SegmentService maintains operating segments and prepares the segment disclosure
+type SegmentService struct {
+	storage    *Storage
+	eventStore *EventStore
+	reporting  *ReportingService
+}
+
+// NewSegmentService creates a new segment service
+func NewSegmentService(storage *Storage, eventStore *EventStore, reporting *ReportingService) *SegmentService {
+	return &SegmentService{
+		storage:    storage,
+		eventStore: eventStore,
+		reporting:  reporting,
+	}
+}
+
+// DefineSegment creates or updates an operating segment. A dimension value or
+// company can only belong to one segment.
+func (ss *SegmentService) DefineSegment(segment *OperatingSegment, userID string) error {
+	if segment.Name == "" {
+		return fmt.Errorf("segment name is required")
+	}
+	if len(segment.DimensionValues) == 0 && len(segment.CompanyIDs) == 0 {
+		return fmt.Errorf("segment %s maps no dimension values or companies", segment.Name)
+	}
+	if len(segment.DimensionValues) > 0 && segment.DimensionKey == "" {
+		return fmt.Errorf("segment %s needs a dimension key for its dimension values", segment.Name)
+	}
+
+	segments, err := ss.storage.GetAllOperatingSegments()
+	if err != nil {
+		return fmt.Errorf("failed to get operating segments: %w", err)
+	}
+	for _, other := range segments {
+		if other.ID == segment.ID {
+			continue
+		}
+		if other.Name == segment.Name {
+			return fmt.Errorf("segment %s already exists", segment.Name)
+		}
+		for _, companyID := range segment.CompanyIDs {
+			if slices.Contains(other.CompanyIDs, companyID) {
+				return fmt.Errorf("company %s is already mapped to segment %s", companyID, other.Name)
+			}
+		}
+		if other.DimensionKey != segment.DimensionKey {
+			continue
+		}
+		for _, value := range segment.DimensionValues {
+			if slices.Contains(other.DimensionValues, value) {
+				return fmt.Errorf("%s %s is already mapped to segment %s", segment.DimensionKey, value, other.Name)
+			}
+		}
+	}
+
+	if segment.ID == "" {
+		if err := ss.storage.assignID(&segment.ID, "segment", BucketOperatingSegments); err != nil {
+			return err
+		}
+		segment.CreatedBy = userID
+		segment.CreatedAt = time.Now()
+	}
+
+	_, err = ss.eventStore.CreateEvent(EventDefineSegment, segment, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create segment event: %w", err)
+	}
+	return ss.storage.SaveOperatingSegment(segment)
+}
+
+// SaveAllocationRule creates or updates a rule spreading shared balances across
+// segments
+func (ss *SegmentService) SaveAllocationRule(rule *SegmentAllocationRule, userID string) error {
+	if len(rule.AccountIDs) == 0 {
+		return fmt.Errorf("allocation rule needs at least one account")
+	}
+	for _, accountID := range rule.AccountIDs {
+		account, err := ss.storage.GetAccount(accountID)
+		if err != nil {
+			return fmt.Errorf("invalid account: %w", err)
+		}
+		if account.Type != Asset && account.Type != Liability {
+			return fmt.Errorf("account %s is not an asset or liability account", accountID)
+		}
+	}
+	switch rule.Basis {
+	case AllocateByRevenue:
+	case AllocateByFixedShares:
+		total := 0.0
+		for segmentID, share := range rule.Shares {
+			if _, err := ss.storage.GetOperatingSegment(segmentID); err != nil {
+				return fmt.Errorf("invalid segment: %w", err)
+			}
+			if share < 0 {
+				return fmt.Errorf("share of segment %s is negative", segmentID)
+			}
+			total += share
+		}
+		if math.Abs(total-1) > 1e-9 {
+			return fmt.Errorf("fixed shares must sum to 1, got %g", total)
+		}
+	default:
+		return fmt.Errorf("unsupported allocation basis: %s", rule.Basis)
+	}
+
+	if rule.ID == "" {
+		if err := ss.storage.assignID(&rule.ID, "segment_allocation_rule", BucketSegmentAllocationRules); err != nil {
+			return err
+		}
+		rule.CreatedBy = userID
+		rule.CreatedAt = time.Now()
+	}
+
+	_, err := ss.eventStore.CreateEvent(EventSaveSegmentAllocationRule, rule, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create segment allocation rule event: %w", err)
+	}
+	return ss.storage.SaveSegmentAllocationRule(rule)
+}
+
+// GetSegments returns the operating segments by name
+func (ss *SegmentService) GetSegments() ([]*OperatingSegment, error) {
+	segments, err := ss.storage.GetAllOperatingSegments()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operating segments: %w", err)
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].Name < segments[j].Name
+	})
+	return segments, nil
+}
+
+// GenerateSegmentDisclosure reports each segment's revenue, profit or loss for a period
+// and its assets and liabilities at the period end, in the given currency, and
+// reconciles them to the profit & loss statement and balance sheet. Transactions
+// made up only of income and expense entries in more than one segment, such as
+// internal recharges, are inter-segment: they count in the segments' results and
+// are eliminated from the totals on reconciliation.
+func (ss *SegmentService) GenerateSegmentDisclosure(fromDate, toDate time.Time, currency string) (*SegmentDisclosure, error) {
+	if toDate.Before(fromDate) {
+		return nil, fmt.Errorf("period end %s is before its start %s", toDate.Format("2006-01-02"), fromDate.Format("2006-01-02"))
+	}
+	sb, err := ss.newSegmentBook(Currency(currency))
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := ss.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	txns, err := ss.storage.GetAllTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	translate := func(amount *Amount) (int64, error) {
+		translated, err := ss.reporting.translateAmount(amount, currency, toDate)
+		if err != nil {
+			return 0, err
+		}
+		return translated.Value, nil
+	}
+
+	types := accountTypes(accounts)
+	for _, txn := range txns {
+		if txn.Status != Posted || txn.ValidTime.After(toDate) {
+			continue
+		}
+		inPeriod := !txn.ValidTime.Before(fromDate)
+		inter := inPeriod && sb.spansSegments(txn, types)
+		for i := range txn.Entries {
+			entry := &txn.Entries[i]
+			if err := sb.add("", entry, types[entry.AccountID], inPeriod, inter, translate); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	pl, err := ss.reporting.GenerateProfitAndLoss(fromDate, toDate, currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate profit and loss: %w", err)
+	}
+	bs, err := ss.reporting.GenerateBalanceSheet(toDate, currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate balance sheet: %w", err)
+	}
+	return sb.disclosure("", fromDate, toDate, pl, bs, true), nil
+}
+
+// GenerateGroupSegmentDisclosure prepares the segment disclosure of a consolidation
+// group from the operating segments defined in its parent company. Results are
+// translated at the period's average rate and assets and liabilities at the closing
+// rate, as in the consolidated statements they reconcile to. Matched intercompany
+// income and expenses between companies of different segments are inter-segment;
+// between companies of the same segment they are left out of the segment's results.
+// Intercompany balances stay in segment assets and liabilities and are eliminated on
+// reconciliation.
+func (mce *MultiCompanyEngine) GenerateGroupSegmentDisclosure(groupID string, fromDate, toDate time.Time) (*SegmentDisclosure, error) {
+	if toDate.Before(fromDate) {
+		return nil, fmt.Errorf("period end %s is before its start %s", toDate.Format("2006-01-02"), fromDate.Format("2006-01-02"))
+	}
+	average, err := mce.prepareConsolidation(groupID, averageRateDates(fromDate, toDate))
+	if err != nil {
+		return nil, err
+	}
+	closing, err := mce.prepareConsolidation(groupID, []time.Time{toDate})
+	if err != nil {
+		return nil, err
+	}
+	sb, err := average.members[0].engine.segmentService.newSegmentBook(average.currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Company -> transaction ID -> counterparty company, for matched intercompany
+	// transactions within the group
+	counterparties := make(map[string]map[string]string)
+	for _, member := range average.members {
+		icTxns, err := mce.storage.GetIntercompanyTransactionsByCompany(member.company.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get intercompany transactions: %w", err)
+		}
+		for _, icTxn := range icTxns {
+			if icTxn.MatchingStatus != IntercompanyMatched && icTxn.MatchingStatus != IntercompanyReconciled {
+				continue
+			}
+			if average.member(icTxn.SourceCompanyID) == nil || average.member(icTxn.TargetCompanyID) == nil {
+				continue
+			}
+			for _, side := range []struct{ company, txnID, counterparty string }{
+				{icTxn.SourceCompanyID, icTxn.SourceTransactionID, icTxn.TargetCompanyID},
+				{icTxn.TargetCompanyID, icTxn.TargetTransactionID, icTxn.SourceCompanyID},
+			} {
+				if counterparties[side.company] == nil {
+					counterparties[side.company] = make(map[string]string)
+				}
+				counterparties[side.company][side.txnID] = side.counterparty
+			}
+		}
+	}
+
+	for i, member := range average.members {
+		companyID := member.company.ID
+		accounts, err := member.engine.storage.GetAllAccounts()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get accounts for company %s: %w", companyID, err)
+		}
+		txns, err := member.engine.storage.GetAllTransactions()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transactions for company %s: %w", companyID, err)
+		}
+		types := accountTypes(accounts)
+		closingMember := closing.members[i]
+
+		for _, txn := range txns {
+			if txn.Status != Posted || txn.ValidTime.After(toDate) {
+				continue
+			}
+			inPeriod := !txn.ValidTime.Before(fromDate)
+			counterparty, intercompany := counterparties[companyID][txn.ID]
+			for j := range txn.Entries {
+				entry := &txn.Entries[j]
+				accountType := types[entry.AccountID]
+				eliminated := intercompany && entryDimension(entry, DimIntercompany) != ""
+				pl := accountType == Income || accountType == Expense
+				if eliminated && pl {
+					own, other := sb.segmentOf(companyID, entry), sb.companySegment(counterparty)
+					if own != nil && own == other {
+						continue // eliminated within the segment
+					}
+				}
+				c, m := closing, closingMember
+				if pl {
+					c, m = average, member
+				}
+				translate := func(amount *Amount) (int64, error) {
+					translated, err := c.translate(amount, m.company)
+					if err != nil {
+						return 0, fmt.Errorf("failed to translate %s for company %s: %w", entry.AccountID, companyID, err)
+					}
+					return scaleValue(translated.Value, c.lineFactor(m, accountType)), nil
+				}
+				if err := sb.add(companyID, entry, accountType, inPeriod, eliminated, translate); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	pl, err := mce.GenerateConsolidatedProfitAndLoss(groupID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := mce.GenerateConsolidatedBalanceSheet(groupID, toDate)
+	if err != nil {
+		return nil, err
+	}
+	return sb.disclosure(groupID, fromDate, toDate, pl.Statement, bs.Statement, false), nil
+}
+
+// segmentBook accumulates translated amounts by segment while a disclosure is built
+type segmentBook struct {
+	segments    []*OperatingSegment
+	rules       []*SegmentAllocationRule
+	currency    Currency
+	results     map[*OperatingSegment]*SegmentResult // nil key for unallocated amounts
+	shared      map[string]int64                     // unallocated balance by account, on its normal side
+	sharedTypes map[string]AccountType
+	// Inter-segment amounts, eliminated on reconciliation
+	interRevenue, interExpenses, interAssets, interLiabilities int64
+}
+
+// newSegmentBook loads the segments and allocation rules
+func (ss *SegmentService) newSegmentBook(currency Currency) (*segmentBook, error) {
+	segments, err := ss.GetSegments()
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("no operating segments are defined")
+	}
+	rules, err := ss.storage.GetAllSegmentAllocationRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get segment allocation rules: %w", err)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].CreatedAt.Before(rules[j].CreatedAt)
+	})
+
+	sb := &segmentBook{
+		segments:    segments,
+		rules:       rules,
+		currency:    currency,
+		results:     make(map[*OperatingSegment]*SegmentResult),
+		shared:      make(map[string]int64),
+		sharedTypes: make(map[string]AccountType),
+	}
+	for _, segment := range append(segments, nil) {
+		result := &SegmentResult{SegmentName: "Unallocated"}
+		if segment != nil {
+			result.SegmentID, result.SegmentName = segment.ID, segment.Name
+		}
+		for _, amount := range []**Amount{&result.ExternalRevenue, &result.InterSegmentRevenue, &result.Revenue, &result.Expenses,
+			&result.ProfitOrLoss, &result.Assets, &result.AllocatedAssets, &result.Liabilities} {
+			*amount = &Amount{Currency: currency}
+		}
+		sb.results[segment] = result
+	}
+	return sb, nil
+}
+
+// companySegment is the segment a whole company is mapped to, if any
+func (sb *segmentBook) companySegment(companyID string) *OperatingSegment {
+	if companyID == "" {
+		return nil
+	}
+	for _, segment := range sb.segments {
+		if slices.Contains(segment.CompanyIDs, companyID) {
+			return segment
+		}
+	}
+	return nil
+}
+
+// segmentOf is the segment an entry recorded by a company belongs to, or nil
+func (sb *segmentBook) segmentOf(companyID string, entry *Entry) *OperatingSegment {
+	if segment := sb.companySegment(companyID); segment != nil {
+		return segment
+	}
+	for _, segment := range sb.segments {
+		if segment.DimensionKey != "" && slices.Contains(segment.DimensionValues, entryDimension(entry, segment.DimensionKey)) {
+			return segment
+		}
+	}
+	return nil
+}
+
+// spansSegments reports whether a transaction only has income and expense entries
+// and they fall in more than one segment
+func (sb *segmentBook) spansSegments(txn *Transaction, types map[string]AccountType) bool {
+	var seen *OperatingSegment
+	spans := false
+	for i := range txn.Entries {
+		entry := &txn.Entries[i]
+		if accountType := types[entry.AccountID]; accountType != Income && accountType != Expense {
+			return false
+		}
+		segment := sb.segmentOf("", entry)
+		if segment == nil {
+			return false
+		}
+		if seen != nil && segment != seen {
+			spans = true
+		}
+		seen = segment
+	}
+	return spans
+}
+
+// add books an entry to its segment: income and expenses when it falls in the
+// period, asset and liability balances whenever it does. Inter-segment amounts are
+// also counted for elimination.
+func (sb *segmentBook) add(companyID string, entry *Entry, accountType AccountType, inPeriod, inter bool, translate func(*Amount) (int64, error)) error {
+	pl := accountType == Income || accountType == Expense
+	if (pl && !inPeriod) || (!pl && accountType != Asset && accountType != Liability) {
+		return nil
+	}
+	value := entry.Amount.Value * debitSign(accountType)
+	if entry.Type == Credit {
+		value = -value
+	}
+	value, err := translate(&Amount{Value: value, Currency: entry.Amount.Currency})
+	if err != nil {
+		return err
+	}
+
+	segment := sb.segmentOf(companyID, entry)
+	result := sb.results[segment]
+	switch accountType {
+	case Income:
+		result.Revenue.Value += value
+		if inter {
+			result.InterSegmentRevenue.Value += value
+			sb.interRevenue += value
+		} else {
+			result.ExternalRevenue.Value += value
+		}
+	case Expense:
+		result.Expenses.Value += value
+		if inter {
+			sb.interExpenses += value
+		}
+	case Asset:
+		result.Assets.Value += value
+		if inter {
+			sb.interAssets += value
+		}
+	case Liability:
+		result.Liabilities.Value += value
+		if inter {
+			sb.interLiabilities += value
+		}
+	}
+	if segment == nil && !pl {
+		sb.shared[entry.AccountID] += value
+		sb.sharedTypes[entry.AccountID] = accountType
+	}
+	return nil
+}
+
+// allocate spreads unallocated balances of shared accounts across the segments
+// according to the allocation rules
+func (sb *segmentBook) allocate() {
+	unallocated := sb.results[nil]
+	for _, rule := range sb.rules {
+		shares := make([]float64, len(sb.segments))
+		switch rule.Basis {
+		case AllocateByRevenue:
+			total := int64(0)
+			for _, segment := range sb.segments {
+				total += sb.results[segment].ExternalRevenue.Value
+			}
+			if total == 0 {
+				continue
+			}
+			for i, segment := range sb.segments {
+				shares[i] = float64(sb.results[segment].ExternalRevenue.Value) / float64(total)
+			}
+		case AllocateByFixedShares:
+			for i, segment := range sb.segments {
+				shares[i] = rule.Shares[segment.ID]
+			}
+		}
+
+		for _, accountID := range rule.AccountIDs {
+			balance := sb.shared[accountID]
+			if balance == 0 {
+				continue
+			}
+			// The last segment with a share takes the rounding remainder
+			remaining, last := balance, -1
+			for i, share := range shares {
+				if share > 0 {
+					last = i
+				}
+			}
+			for i, segment := range sb.segments {
+				if shares[i] <= 0 {
+					continue
+				}
+				portion := scaleValue(balance, shares[i])
+				if i == last {
+					portion = remaining
+				}
+				remaining -= portion
+
+				result := sb.results[segment]
+				if sb.sharedTypes[accountID] == Asset {
+					result.Assets.Value += portion
+					result.AllocatedAssets.Value += portion
+					unallocated.Assets.Value -= portion
+				} else {
+					result.Liabilities.Value += portion
+					unallocated.Liabilities.Value -= portion
+				}
+			}
+			sb.shared[accountID] = remaining
+		}
+	}
+}
+
+// disclosure allocates shared balances, totals each segment and reconciles the
+// segments to the consolidated profit & loss statement and balance sheet. When the
+// statements are those of a single set of books they still carry the inter-segment
+// amounts, which are taken out of the consolidated totals.
+func (sb *segmentBook) disclosure(groupID string, fromDate, toDate time.Time, pl, bs *FinancialStatement, singleBooks bool) *SegmentDisclosure {
+	sb.allocate()
+
+	disclosure := &SegmentDisclosure{
+		GroupID:     groupID,
+		FromDate:    fromDate,
+		ToDate:      toDate,
+		Currency:    string(sb.currency),
+		Unallocated: sb.results[nil],
+		GeneratedAt: time.Now(),
+	}
+	var revenue, profit, assets, liabilities int64
+	for _, result := range sb.results {
+		result.ProfitOrLoss.Value = result.Revenue.Value - result.Expenses.Value
+	}
+	for _, segment := range sb.segments {
+		result := sb.results[segment]
+		disclosure.Segments = append(disclosure.Segments, result)
+		revenue += result.Revenue.Value
+		profit += result.ProfitOrLoss.Value
+		assets += result.Assets.Value
+		liabilities += result.Liabilities.Value
+	}
+
+	consolidatedRevenue := int64(0)
+	for _, item := range pl.LineItems {
+		if item.AccountName == "REVENUE" && item.Amount != nil {
+			consolidatedRevenue = item.Amount.Value
+		}
+	}
+	unallocated := sb.results[nil]
+	for _, measure := range []struct {
+		name                                          string
+		segments, eliminations, unallocated, reported int64
+	}{
+		{"Revenue", revenue, -sb.interRevenue, unallocated.Revenue.Value, consolidatedRevenue},
+		{"Profit or loss", profit, sb.interExpenses - sb.interRevenue, unallocated.ProfitOrLoss.Value, pl.NetIncome.Value},
+		{"Assets", assets, -sb.interAssets, unallocated.Assets.Value, bs.TotalAssets.Value},
+		{"Liabilities", liabilities, -sb.interLiabilities, unallocated.Liabilities.Value, bs.TotalLiabs.Value},
+	} {
+		if singleBooks {
+			measure.reported += measure.eliminations
+		}
+		disclosure.Reconciliations = append(disclosure.Reconciliations, &SegmentReconciliation{
+			Measure:      measure.name,
+			Segments:     &Amount{Value: measure.segments, Currency: sb.currency},
+			Eliminations: &Amount{Value: measure.eliminations, Currency: sb.currency},
+			Unallocated:  &Amount{Value: measure.unallocated, Currency: sb.currency},
+			Other:        &Amount{Value: measure.reported - measure.segments - measure.eliminations - measure.unallocated, Currency: sb.currency},
+			Consolidated: &Amount{Value: measure.reported, Currency: sb.currency},
+		})
+	}
+	return disclosure
+}
+
+// accountTypes maps account IDs to their types
+func accountTypes(accounts []*Account) map[string]AccountType {
+	types := make(map[string]AccountType, len(accounts))
+	for _, account := range accounts {
+		types[account.ID] = account.Type
+	}
+	return types
+}
+
+// SegmentDisclosureDocument lays out the segment disclosure for export: the segment
+// results with unallocated amounts, and the reconciliation to consolidated totals
+func SegmentDisclosureDocument(disclosure *SegmentDisclosure) *ReportDocument {
+	currency := Currency(disclosure.Currency)
+	results := &ReportSheet{Name: "Segment Results", Columns: []string{"Segment", "External revenue", "Inter-segment revenue",
+		"Revenue", "Expenses", "Profit or loss", "Assets", "Allocated assets", "Liabilities"}}
+	for _, result := range append(slices.Clone(disclosure.Segments), disclosure.Unallocated) {
+		row := []ReportCell{TextCell(result.SegmentName)}
+		for _, amount := range []*Amount{result.ExternalRevenue, result.InterSegmentRevenue, result.Revenue, result.Expenses,
+			result.ProfitOrLoss, result.Assets, result.AllocatedAssets, result.Liabilities} {
+			row = append(row, AmountCell(amount.Value, currency))
+		}
+		results.Rows = append(results.Rows, row)
+	}
+
+	reconciliation := &ReportSheet{Name: "Reconciliation", Columns: []string{"Measure", "Segments", "Eliminations", "Unallocated", "Other", "Consolidated"}}
+	for _, line := range disclosure.Reconciliations {
+		reconciliation.Rows = append(reconciliation.Rows, []ReportCell{
+			TextCell(line.Measure),
+			AmountCell(line.Segments.Value, currency),
+			AmountCell(line.Eliminations.Value, currency),
+			AmountCell(line.Unallocated.Value, currency),
+			AmountCell(line.Other.Value, currency),
+			AmountCell(line.Consolidated.Value, currency).Bolded(),
+		})
+	}
+
+	return &ReportDocument{
+		Title:    "Operating Segments",
+		Subtitle: fmt.Sprintf("%s to %s (%s)", disclosure.FromDate.Format(time.DateOnly), disclosure.ToDate.Format(time.DateOnly), disclosure.Currency),
+		Sheets:   []*ReportSheet{results, reconciliation},
+	}
+}
@@ -0,0 +1,242 @@
+package accounting
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatingSegments(t *testing.T) {
+	dbFile := "test_operating_segments.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	retail := &OperatingSegment{Name: "Retail", DimensionKey: DimProduct, DimensionValues: []string{"retail", "online"}}
+	wholesale := &OperatingSegment{Name: "Wholesale", DimensionKey: DimProduct, DimensionValues: []string{"wholesale"}}
+	require.NoError(t, engine.DefineSegment(retail, userID))
+	require.NoError(t, engine.DefineSegment(wholesale, userID))
+
+	t.Run("a dimension value belongs to one segment", func(t *testing.T) {
+		err := engine.DefineSegment(&OperatingSegment{Name: "Digital", DimensionKey: DimProduct, DimensionValues: []string{"online"}}, userID)
+		assert.ErrorContains(t, err, "already mapped to segment Retail")
+		err = engine.DefineSegment(&OperatingSegment{Name: "Empty"}, userID)
+		assert.ErrorContains(t, err, "maps no dimension values or companies")
+	})
+
+	post := func(validTime time.Time, debit, credit string, amount int64, dims ...Dimension) {
+		txn := &Transaction{
+			Description: "Activity",
+			ValidTime:   validTime,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: dims},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: dims},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	product := func(value string) Dimension { return Dimension{Key: DimProduct, Value: value} }
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 9, 0, 0, 0, time.UTC) }
+
+	post(time.Date(2023, 12, 15, 9, 0, 0, 0, time.UTC), "cash", "revenue", 999, product("retail"))
+	post(day(1, 10), "cash", "revenue", 7000, product("retail"))
+	post(day(1, 12), "cash", "revenue", 3000, product("online"))
+	post(day(2, 1), "accounts_receivable", "revenue", 30000, product("wholesale"))
+	post(day(2, 5), "expenses", "cash", 4000, product("retail"))
+	post(day(2, 20), "cash", "accounts_payable", 50000)
+	post(day(3, 1), "expenses", "cash", 500)
+
+	// Wholesale recharges Retail for shared warehousing
+	recharge := &Transaction{
+		Description: "Warehouse recharge",
+		ValidTime:   day(3, 15),
+		Entries: []Entry{
+			{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 1500, Currency: "USD"}, Dimensions: []Dimension{product("retail")}},
+			{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 1500, Currency: "USD"}, Dimensions: []Dimension{product("wholesale")}},
+		},
+	}
+	require.NoError(t, engine.CreateTransaction(recharge, userID))
+	require.NoError(t, engine.PostTransaction(recharge.ID, userID))
+
+	require.NoError(t, engine.SaveSegmentAllocationRule(&SegmentAllocationRule{
+		Name: "Head office payables", AccountIDs: []string{"accounts_payable"}, Basis: AllocateByFixedShares,
+		Shares: map[string]float64{retail.ID: 0.25, wholesale.ID: 0.75},
+	}, userID))
+	require.NoError(t, engine.SaveSegmentAllocationRule(&SegmentAllocationRule{
+		Name: "Treasury cash", AccountIDs: []string{"cash"}, Basis: AllocateByRevenue,
+	}, userID))
+
+	t.Run("allocation rules are validated", func(t *testing.T) {
+		err := engine.SaveSegmentAllocationRule(&SegmentAllocationRule{AccountIDs: []string{"revenue"}, Basis: AllocateByRevenue}, userID)
+		assert.ErrorContains(t, err, "not an asset or liability account")
+		err = engine.SaveSegmentAllocationRule(&SegmentAllocationRule{AccountIDs: []string{"cash"}, Basis: AllocateByFixedShares,
+			Shares: map[string]float64{retail.ID: 0.5}}, userID)
+		assert.ErrorContains(t, err, "must sum to 1")
+	})
+
+	disclosure, err := engine.GenerateSegmentDisclosure(day(1, 1), day(3, 31), "USD")
+	require.NoError(t, err)
+	require.Len(t, disclosure.Segments, 2)
+	retailResult, wholesaleResult := disclosure.Segments[0], disclosure.Segments[1]
+
+	t.Run("segment profit or loss with inter-segment revenue", func(t *testing.T) {
+		assert.Equal(t, "Retail", retailResult.SegmentName)
+		assert.Equal(t, int64(10000), retailResult.ExternalRevenue.Value)
+		assert.Equal(t, int64(0), retailResult.InterSegmentRevenue.Value)
+		assert.Equal(t, int64(5500), retailResult.Expenses.Value)
+		assert.Equal(t, int64(4500), retailResult.ProfitOrLoss.Value)
+
+		assert.Equal(t, int64(30000), wholesaleResult.ExternalRevenue.Value)
+		assert.Equal(t, int64(1500), wholesaleResult.InterSegmentRevenue.Value)
+		assert.Equal(t, int64(31500), wholesaleResult.Revenue.Value)
+		assert.Equal(t, int64(31500), wholesaleResult.ProfitOrLoss.Value)
+
+		assert.Equal(t, int64(500), disclosure.Unallocated.Expenses.Value)
+		assert.Equal(t, int64(-500), disclosure.Unallocated.ProfitOrLoss.Value)
+	})
+
+	t.Run("shared balances are allocated", func(t *testing.T) {
+		// 49500 of head office cash split 1:3 by external revenue
+		assert.Equal(t, int64(12375), retailResult.AllocatedAssets.Value)
+		assert.Equal(t, int64(999+7000+3000-4000+12375), retailResult.Assets.Value)
+		assert.Equal(t, int64(37125), wholesaleResult.AllocatedAssets.Value)
+		assert.Equal(t, int64(30000+37125), wholesaleResult.Assets.Value)
+		assert.Equal(t, int64(12500), retailResult.Liabilities.Value)
+		assert.Equal(t, int64(37500), wholesaleResult.Liabilities.Value)
+		assert.Equal(t, int64(0), disclosure.Unallocated.Assets.Value)
+		assert.Equal(t, int64(0), disclosure.Unallocated.Liabilities.Value)
+	})
+
+	t.Run("reconciles to the financial statements", func(t *testing.T) {
+		measures := make(map[string]*SegmentReconciliation)
+		for _, line := range disclosure.Reconciliations {
+			measures[line.Measure] = line
+			assert.Equal(t, int64(0), line.Other.Value, line.Measure)
+			assert.Equal(t, line.Consolidated.Value, line.Segments.Value+line.Eliminations.Value+line.Unallocated.Value+line.Other.Value, line.Measure)
+		}
+		assert.Equal(t, int64(41500), measures["Revenue"].Segments.Value)
+		assert.Equal(t, int64(-1500), measures["Revenue"].Eliminations.Value)
+		assert.Equal(t, int64(40000), measures["Revenue"].Consolidated.Value)
+		assert.Equal(t, int64(35500), measures["Profit or loss"].Consolidated.Value)
+		assert.Equal(t, int64(86499), measures["Assets"].Consolidated.Value)
+		assert.Equal(t, int64(50000), measures["Liabilities"].Consolidated.Value)
+	})
+
+	t.Run("exports", func(t *testing.T) {
+		doc := SegmentDisclosureDocument(disclosure)
+		require.Len(t, doc.Sheets, 2)
+		assert.Len(t, doc.Sheets[0].Rows, 3)
+		assert.Equal(t, "Unallocated", doc.Sheets[0].Rows[2][0].Text)
+		assert.Equal(t, "400.00", doc.Sheets[1].Rows[0][5].Text)
+	})
+}
+
+func TestGroupSegmentDisclosure(t *testing.T) {
+	// Company engines create their databases in the working directory
+	t.Chdir(t.TempDir())
+
+	dbFile := fmt.Sprintf("test_group_segments_%d.db", time.Now().UnixNano())
+	defer os.Remove(dbFile)
+
+	storage, err := NewStorage(dbFile)
+	require.NoError(t, err)
+	defer storage.Close()
+
+	mce := NewMultiCompanyEngine(*storage)
+	defer mce.Close()
+	userID := "group_controller"
+
+	engines := make(map[string]*AccountingEngine)
+	for _, company := range []*Company{
+		{ID: "holdco", Name: "Holding Corp", BaseCurrency: "USD"},
+		{ID: "alpha", Name: "Alpha Inc", BaseCurrency: "USD", ParentCompanyID: "holdco"},
+		{ID: "gamma", Name: "Gamma LLC", BaseCurrency: "USD", ParentCompanyID: "holdco"},
+	} {
+		company.Settings = &CompanySettings{DefaultChartOfAccounts: "standard", AllowIntercompanyTxn: true}
+		require.NoError(t, mce.CreateCompany(company, userID))
+		engine, err := mce.GetAccountingEngine(company.ID)
+		require.NoError(t, err)
+		engines[company.ID] = engine
+	}
+	post := func(companyID string, value int64, debit, credit string, dims ...Dimension) string {
+		txn := &Transaction{
+			Description: fmt.Sprintf("%s to %s", debit, credit),
+			ValidTime:   time.Now(),
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: value, Currency: "USD"}, Dimensions: dims},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: value, Currency: "USD"}, Dimensions: dims},
+			},
+		}
+		require.NoError(t, engines[companyID].CreateTransaction(txn, userID))
+		require.NoError(t, engines[companyID].PostTransaction(txn.ID, userID))
+		return txn.ID
+	}
+	fee := func(id, source, target string, value int64) {
+		sourceTxn := post(source, value, "intercompany_receivable", "revenue", Dimension{Key: DimIntercompany, Value: target})
+		targetTxn := post(target, value, "expenses", "intercompany_payable", Dimension{Key: DimIntercompany, Value: source})
+		require.NoError(t, storage.SaveIntercompanyTransaction(&IntercompanyTransaction{
+			ID: id, Description: "Management fee", SourceCompanyID: source, TargetCompanyID: target,
+			SourceTransactionID: sourceTxn, TargetTransactionID: targetTxn,
+			Amount: &Amount{Value: value, Currency: "USD"}, MatchingStatus: IntercompanyMatched,
+		}))
+	}
+
+	post("holdco", 5000, "cash", "revenue")
+	post("alpha", 20000, "cash", "revenue")
+	post("gamma", 8000, "cash", "revenue")
+	post("alpha", 6000, "expenses", "cash")
+	fee("fee_alpha", "holdco", "alpha", 2000) // between segments
+	fee("fee_gamma", "alpha", "gamma", 1000)  // within the Operations segment
+
+	require.NoError(t, engines["holdco"].DefineSegment(&OperatingSegment{Name: "Corporate", CompanyIDs: []string{"holdco"}}, userID))
+	require.NoError(t, engines["holdco"].DefineSegment(&OperatingSegment{Name: "Operations", CompanyIDs: []string{"alpha", "gamma"}}, userID))
+
+	group := &ConsolidationGroup{ID: "group", Name: "Group", ParentCompany: "holdco", ChildCompanies: []string{"alpha", "gamma"}}
+	require.NoError(t, mce.CreateConsolidationGroup(group, userID))
+
+	from := time.Now().Add(-time.Hour)
+	disclosure, err := mce.GenerateGroupSegmentDisclosure(group.ID, from, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, disclosure.Segments, 2)
+	corporate, operations := disclosure.Segments[0], disclosure.Segments[1]
+
+	t.Run("segments by company", func(t *testing.T) {
+		assert.Equal(t, int64(5000), corporate.ExternalRevenue.Value)
+		assert.Equal(t, int64(2000), corporate.InterSegmentRevenue.Value)
+		assert.Equal(t, int64(7000), corporate.ProfitOrLoss.Value)
+
+		// Alpha's fee to Gamma stays inside the segment; Holdco's fee to Alpha does not
+		assert.Equal(t, int64(28000), operations.ExternalRevenue.Value)
+		assert.Equal(t, int64(0), operations.InterSegmentRevenue.Value)
+		assert.Equal(t, int64(8000), operations.Expenses.Value)
+		assert.Equal(t, int64(20000), operations.ProfitOrLoss.Value)
+	})
+
+	t.Run("reconciles to the consolidated statements", func(t *testing.T) {
+		for _, line := range disclosure.Reconciliations {
+			assert.Equal(t, int64(0), line.Other.Value, line.Measure)
+			switch line.Measure {
+			case "Revenue":
+				assert.Equal(t, int64(-2000), line.Eliminations.Value)
+				assert.Equal(t, int64(33000), line.Consolidated.Value)
+			case "Profit or loss":
+				assert.Equal(t, int64(0), line.Eliminations.Value)
+				assert.Equal(t, int64(27000), line.Consolidated.Value)
+			case "Assets":
+				// Intercompany receivables of 3000 are eliminated
+				assert.Equal(t, int64(-3000), line.Eliminations.Value)
+				assert.Equal(t, int64(27000), line.Consolidated.Value)
+			}
+		}
+	})
+}
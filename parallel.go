@@ -0,0 +1,60 @@
+package accounting
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultReportConcurrency is how many accounts report generation works on at once
+// unless configured otherwise
+func DefaultReportConcurrency() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+// forEachParallel calls fn for every index below n on at most workers goroutines.
+// Indexes are handed out in order and no new ones are started after a failure, and
+// the error returned is the one from the lowest failing index, so callers writing
+// into index-addressed results see exactly what a serial loop would produce.
+func forEachParallel(n, workers int, fn func(i int) error) error {
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			if err := fn(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, n)
+	var next atomic.Int64
+	var failed atomic.Bool
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for !failed.Load() {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+				if err := fn(i); err != nil {
+					errs[i] = err
+					failed.Store(true)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
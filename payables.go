@@ -0,0 +1,769 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Accounts Payable Structures
+// ----------------------------------------------------------------------------
+
+// DefaultPayablesAccountID is the control account bills are credited to
+const DefaultPayablesAccountID = "accounts_payable"
+
+// DefaultWithholdingAccountID is the liability tax withheld from vendor payments is credited to
+const DefaultWithholdingAccountID = "withholding_payable"
+
+// Vendor is a supplier in the accounts payable sub-ledger
+type Vendor struct {
+	ID                      string    `json:"id"`
+	Name                    string    `json:"name"`
+	Currency                Currency  `json:"currency"`
+	PaymentTermsDays        int       `json:"payment_terms_days"`
+	DefaultExpenseAccountID string    `json:"default_expense_account_id,omitempty"`
+	TaxID                   string    `json:"tax_id,omitempty"`
+	BankAccount             string    `json:"bank_account,omitempty"`
+	Active                  bool      `json:"active"`
+	MergedInto              string    `json:"merged_into,omitempty"` // surviving vendor after a duplicate merge
+	CreatedBy               string    `json:"created_by"`
+	CreatedAt               time.Time `json:"created_at"`
+
+	// US information reporting and backup withholding, see form_1099.go
+	TaxProfile *VendorTaxProfile `json:"tax_profile,omitempty"`
+}
+
+// BillStatus tracks a vendor bill from entry to payment
+type BillStatus string
+
+const (
+	BillDraft           BillStatus = "DRAFT"
+	BillPendingApproval BillStatus = "PENDING_APPROVAL"
+	BillApproved        BillStatus = "APPROVED"
+	BillRejected        BillStatus = "REJECTED"
+	BillScheduled       BillStatus = "SCHEDULED"
+	BillPaid            BillStatus = "PAID"
+)
+
+// MatchStatus is the outcome of matching a bill to its purchase order and receipt
+type MatchStatus string
+
+const (
+	MatchNotRequired MatchStatus = "NOT_REQUIRED"
+	MatchPending     MatchStatus = "PENDING"
+	MatchMatched     MatchStatus = "MATCHED"
+	MatchException   MatchStatus = "EXCEPTION"
+)
+
+// BillLine is a single expense line on a vendor bill, in minor units of the bill currency
+type BillLine struct {
+	Description string `json:"description"`
+	AccountID   string `json:"account_id"`
+	Amount      int64  `json:"amount"`
+}
+
+// ThreeWayMatch records the purchase order and goods receipt a bill was matched to.
+// Matching itself happens outside the ledger; only the outcome is recorded here.
+type ThreeWayMatch struct {
+	Status           MatchStatus `json:"status"`
+	PurchaseOrderRef string      `json:"purchase_order_ref,omitempty"`
+	ReceiptRef       string      `json:"receipt_ref,omitempty"`
+	Notes            string      `json:"notes,omitempty"`
+	CheckedBy        string      `json:"checked_by,omitempty"`
+	CheckedAt        *time.Time  `json:"checked_at,omitempty"`
+}
+
+// VendorBill is an invoice received from a vendor
+type VendorBill struct {
+	ID                   string         `json:"id"`
+	VendorID             string         `json:"vendor_id"`
+	BillNumber           string         `json:"bill_number"`
+	BillDate             time.Time      `json:"bill_date"`
+	DueDate              time.Time      `json:"due_date"`
+	Lines                []BillLine     `json:"lines"`
+	Total                *Amount        `json:"total"`
+	Status               BillStatus     `json:"status"`
+	Match                *ThreeWayMatch `json:"match"`
+	RequiresApproval     bool           `json:"requires_approval"`
+	ApprovedBy           string         `json:"approved_by,omitempty"`
+	ApprovedAt           *time.Time     `json:"approved_at,omitempty"`
+	RejectionReason      string         `json:"rejection_reason,omitempty"`
+	TransactionID        string         `json:"transaction_id,omitempty"` // posting of the liability
+	PaymentRunID         string         `json:"payment_run_id,omitempty"`
+	PaymentTransactionID string         `json:"payment_transaction_id,omitempty"`
+	PaidAt               *time.Time     `json:"paid_at,omitempty"`
+	CreatedBy            string         `json:"created_by"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+}
+
+// PaymentRunStatus tracks a payment run
+type PaymentRunStatus string
+
+const (
+	PaymentRunScheduled PaymentRunStatus = "SCHEDULED"
+	PaymentRunExecuted  PaymentRunStatus = "EXECUTED"
+)
+
+// PaymentRun is a batch of approved bills paid together from one bank account
+type PaymentRun struct {
+	ID               string           `json:"id"`
+	ScheduledDate    time.Time        `json:"scheduled_date"`
+	PaymentAccountID string           `json:"payment_account_id"`
+	BillIDs          []string         `json:"bill_ids"`
+	Total            *Amount          `json:"total"`
+	Status           PaymentRunStatus `json:"status"`
+	TransactionID    string           `json:"transaction_id,omitempty"`
+	CreatedBy        string           `json:"created_by"`
+	CreatedAt        time.Time        `json:"created_at"`
+	ExecutedBy       string           `json:"executed_by,omitempty"`
+	ExecutedAt       *time.Time       `json:"executed_at,omitempty"`
+}
+
+// APAgingBucket totals open bills by days past due
+type APAgingBucket struct {
+	Label   string `json:"label"`
+	MinDays int    `json:"min_days"`
+	MaxDays int    `json:"max_days"` // -1 for no upper bound
+	Amount  int64  `json:"amount"`
+	Count   int    `json:"count"`
+}
+
+// APAgingVendor is a vendor's open balance split across the aging buckets
+type APAgingVendor struct {
+	VendorID   string  `json:"vendor_id"`
+	VendorName string  `json:"vendor_name"`
+	Buckets    []int64 `json:"buckets"` // parallel to APAgingReport.Buckets
+	Total      int64   `json:"total"`
+}
+
+// APAgingReport summarizes unpaid approved bills by age as of a date
+type APAgingReport struct {
+	AsOfDate time.Time        `json:"as_of_date"`
+	Currency Currency         `json:"currency"`
+	Buckets  []*APAgingBucket `json:"buckets"`
+	Vendors  []*APAgingVendor `json:"vendors"`
+	Total    int64            `json:"total"`
+}
+
+// ----------------------------------------------------------------------------
+// Accounts Payable Service
+// ----------------------------------------------------------------------------
+
+// PayablesService manages vendors, bill approval and payment runs
+type PayablesService struct {
+	storage              *Storage
+	eventStore           *EventStore
+	postingEngine        *PostingEngine
+	payablesAccountID    string
+	withholdingAccountID string
+	requireApprovalOver  *Amount
+	mutex                sync.RWMutex
+}
+
+// NewPayablesService creates a new accounts payable service
+func NewPayablesService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *PayablesService {
+	return &PayablesService{
+		storage:              storage,
+		eventStore:           eventStore,
+		postingEngine:        postingEngine,
+		payablesAccountID:    DefaultPayablesAccountID,
+		withholdingAccountID: DefaultWithholdingAccountID,
+	}
+}
+
+// ApplyCompanySettings adopts the company's approval threshold: bills above
+// RequireApprovalOver need approval by someone other than the person who entered them
+func (ps *PayablesService) ApplyCompanySettings(settings *CompanySettings) {
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	if settings == nil {
+		ps.requireApprovalOver = nil
+		return
+	}
+	ps.requireApprovalOver = settings.RequireApprovalOver
+}
+
+// SetPayablesAccount sets the control account bills are credited to
+func (ps *PayablesService) SetPayablesAccount(accountID string) error {
+	if _, err := ps.storage.GetAccount(accountID); err != nil {
+		return fmt.Errorf("invalid payables account: %w", err)
+	}
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	ps.payablesAccountID = accountID
+	return nil
+}
+
+// SetWithholdingAccount sets the liability tax withheld from vendor payments is credited to
+func (ps *PayablesService) SetWithholdingAccount(accountID string) error {
+	if _, err := ps.storage.GetAccount(accountID); err != nil {
+		return fmt.Errorf("invalid withholding account: %w", err)
+	}
+	ps.mutex.Lock()
+	defer ps.mutex.Unlock()
+	ps.withholdingAccountID = accountID
+	return nil
+}
+
+// CreateVendor adds a vendor to the sub-ledger
+func (ps *PayablesService) CreateVendor(vendor *Vendor, userID string) error {
+	if vendor.Name == "" {
+		return fmt.Errorf("vendor name is required")
+	}
+	if vendor.Currency == "" {
+		return fmt.Errorf("vendor currency is required")
+	}
+	if vendor.PaymentTermsDays < 0 {
+		return fmt.Errorf("payment terms cannot be negative")
+	}
+	if vendor.DefaultExpenseAccountID != "" {
+		if _, err := ps.storage.GetAccount(vendor.DefaultExpenseAccountID); err != nil {
+			return fmt.Errorf("invalid default expense account: %w", err)
+		}
+	}
+
+	if err := ps.storage.assignID(&vendor.ID, "vendor", BucketVendors); err != nil {
+		return err
+	}
+	vendor.Active = true
+	vendor.CreatedBy = userID
+	vendor.CreatedAt = time.Now()
+
+	_, err := ps.eventStore.CreateEvent(EventCreateVendor, vendor, vendor.CreatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create vendor event: %w", err)
+	}
+
+	if err := ps.storage.SaveVendor(vendor); err != nil {
+		return fmt.Errorf("failed to save vendor: %w", err)
+	}
+	return nil
+}
+
+// EnterBill records a vendor bill as a draft. Lines without an account use the
+// vendor's default expense account, and the due date defaults from payment terms.
+func (ps *PayablesService) EnterBill(bill *VendorBill, userID string) error {
+	vendor, err := ps.storage.GetVendor(bill.VendorID)
+	if err != nil {
+		return fmt.Errorf("failed to get vendor: %w", err)
+	}
+	if !vendor.Active {
+		return fmt.Errorf("vendor %s is inactive", vendor.Name)
+	}
+	if len(bill.Lines) == 0 {
+		return fmt.Errorf("bill must have at least one line")
+	}
+
+	var total int64
+	for i := range bill.Lines {
+		line := &bill.Lines[i]
+		if line.AccountID == "" {
+			line.AccountID = vendor.DefaultExpenseAccountID
+		}
+		if line.AccountID == "" {
+			return fmt.Errorf("bill line %d has no account", i+1)
+		}
+		if _, err := ps.storage.GetAccount(line.AccountID); err != nil {
+			return fmt.Errorf("invalid account on bill line %d: %w", i+1, err)
+		}
+		if line.Amount <= 0 {
+			return fmt.Errorf("bill line %d must have a positive amount", i+1)
+		}
+		total += line.Amount
+	}
+
+	if err := ps.storage.assignID(&bill.ID, "bill", BucketVendorBills); err != nil {
+		return err
+	}
+	if bill.BillDate.IsZero() {
+		bill.BillDate = time.Now()
+	}
+	if bill.DueDate.IsZero() {
+		bill.DueDate = bill.BillDate.AddDate(0, 0, vendor.PaymentTermsDays)
+	}
+	if bill.Match == nil {
+		bill.Match = &ThreeWayMatch{Status: MatchNotRequired}
+	}
+	bill.Total = &Amount{Value: total, Currency: vendor.Currency}
+	bill.Status = BillDraft
+	bill.CreatedBy = userID
+	bill.CreatedAt = time.Now()
+	bill.UpdatedAt = bill.CreatedAt
+
+	return ps.saveBill(bill, EventEnterBill, userID)
+}
+
+// RecordMatch records the result of matching a bill to its purchase order and receipt
+func (ps *PayablesService) RecordMatch(billID, purchaseOrderRef, receiptRef string, matched bool, notes, userID string) (*VendorBill, error) {
+	bill, err := ps.storage.GetVendorBill(billID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill: %w", err)
+	}
+	if bill.Status == BillPaid || bill.Status == BillScheduled {
+		return nil, fmt.Errorf("bill %s is already in payment", bill.BillNumber)
+	}
+
+	now := time.Now()
+	bill.Match = &ThreeWayMatch{
+		Status:           MatchException,
+		PurchaseOrderRef: purchaseOrderRef,
+		ReceiptRef:       receiptRef,
+		Notes:            notes,
+		CheckedBy:        userID,
+		CheckedAt:        &now,
+	}
+	if matched {
+		bill.Match.Status = MatchMatched
+	}
+	bill.UpdatedAt = now
+
+	if err := ps.saveBill(bill, EventUpdateBill, userID); err != nil {
+		return nil, err
+	}
+	return bill, nil
+}
+
+// SubmitBill sends a draft bill for approval. Bills at or below the approval
+// threshold are approved and posted immediately.
+func (ps *PayablesService) SubmitBill(billID, userID string) (*VendorBill, error) {
+	bill, err := ps.storage.GetVendorBill(billID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill: %w", err)
+	}
+	if bill.Status != BillDraft && bill.Status != BillRejected {
+		return nil, fmt.Errorf("bill %s cannot be submitted from status %s", bill.BillNumber, bill.Status)
+	}
+	if bill.Match != nil && bill.Match.Status == MatchPending {
+		return nil, fmt.Errorf("bill %s is awaiting three-way match", bill.BillNumber)
+	}
+
+	bill.RequiresApproval = ps.requiresApproval(bill.Total)
+	bill.RejectionReason = ""
+	bill.UpdatedAt = time.Now()
+
+	if bill.RequiresApproval {
+		bill.Status = BillPendingApproval
+		if err := ps.saveBill(bill, EventUpdateBill, userID); err != nil {
+			return nil, err
+		}
+		return bill, nil
+	}
+
+	return ps.approve(bill, userID)
+}
+
+// ApproveBill approves a bill awaiting approval and posts the liability
+func (ps *PayablesService) ApproveBill(billID, userID string) (*VendorBill, error) {
+	bill, err := ps.storage.GetVendorBill(billID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill: %w", err)
+	}
+	if bill.Status != BillPendingApproval {
+		return nil, fmt.Errorf("bill %s is not awaiting approval", bill.BillNumber)
+	}
+	if bill.CreatedBy == userID {
+		return nil, fmt.Errorf("bill %s must be approved by someone other than its preparer", bill.BillNumber)
+	}
+
+	return ps.approve(bill, userID)
+}
+
+// RejectBill returns a bill awaiting approval to its preparer
+func (ps *PayablesService) RejectBill(billID, reason, userID string) (*VendorBill, error) {
+	bill, err := ps.storage.GetVendorBill(billID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bill: %w", err)
+	}
+	if bill.Status != BillPendingApproval {
+		return nil, fmt.Errorf("bill %s is not awaiting approval", bill.BillNumber)
+	}
+	if reason == "" {
+		return nil, fmt.Errorf("a rejection reason is required")
+	}
+
+	bill.Status = BillRejected
+	bill.RejectionReason = reason
+	bill.UpdatedAt = time.Now()
+
+	if err := ps.saveBill(bill, EventUpdateBill, userID); err != nil {
+		return nil, err
+	}
+	return bill, nil
+}
+
+// SchedulePaymentRun selects approved bills due on or before dueBy in the payment
+// account's currency and reserves them for payment on the scheduled date. Bills with
+// a three-way match exception are held back.
+func (ps *PayablesService) SchedulePaymentRun(scheduledDate, dueBy time.Time, paymentAccountID, userID string) (*PaymentRun, error) {
+	paymentAccount, err := ps.storage.GetAccount(paymentAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment account: %w", err)
+	}
+	if paymentAccount.Type != Asset {
+		return nil, fmt.Errorf("payment account must be an asset account")
+	}
+
+	bills, err := ps.storage.GetAllVendorBills()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bills: %w", err)
+	}
+	sort.Slice(bills, func(i, j int) bool {
+		return bills[i].DueDate.Before(bills[j].DueDate)
+	})
+
+	run := &PaymentRun{
+		ID:               newID(),
+		ScheduledDate:    scheduledDate,
+		PaymentAccountID: paymentAccountID,
+		Status:           PaymentRunScheduled,
+		CreatedBy:        userID,
+		CreatedAt:        time.Now(),
+	}
+
+	var selected []*VendorBill
+	for _, bill := range bills {
+		if bill.Status != BillApproved || bill.DueDate.After(dueBy) {
+			continue
+		}
+		if bill.Match != nil && bill.Match.Status == MatchException {
+			continue
+		}
+		if paymentAccount.Currency != "" && bill.Total.Currency != paymentAccount.Currency {
+			continue
+		}
+		if run.Total == nil {
+			run.Total = &Amount{Currency: bill.Total.Currency}
+		}
+		if bill.Total.Currency != run.Total.Currency {
+			continue // one currency per run
+		}
+
+		run.Total.Value += bill.Total.Value
+		run.BillIDs = append(run.BillIDs, bill.ID)
+		selected = append(selected, bill)
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no approved bills due by %s", dueBy.Format("2006-01-02"))
+	}
+
+	_, err = ps.eventStore.CreateEvent(EventSchedulePaymentRun, run, scheduledDate, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment run event: %w", err)
+	}
+	if err := ps.storage.SavePaymentRun(run); err != nil {
+		return nil, fmt.Errorf("failed to save payment run: %w", err)
+	}
+
+	for _, bill := range selected {
+		bill.Status = BillScheduled
+		bill.PaymentRunID = run.ID
+		bill.UpdatedAt = time.Now()
+		if err := ps.storage.SaveVendorBill(bill); err != nil {
+			return nil, fmt.Errorf("failed to save bill: %w", err)
+		}
+	}
+
+	return run, nil
+}
+
+// ExecutePaymentRun posts a single balanced payment transaction for the run, debiting
+// the payables control account per vendor and crediting the payment account. Tax
+// withheld from vendors with a withholding rate is credited to the withholding
+// account instead, so the run's total stays the total of its bills.
+func (ps *PayablesService) ExecutePaymentRun(runID, userID string) (*PaymentRun, error) {
+	run, err := ps.storage.GetPaymentRun(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payment run: %w", err)
+	}
+	if run.Status != PaymentRunScheduled {
+		return nil, fmt.Errorf("payment run %s has already been executed", runID)
+	}
+
+	bills := make([]*VendorBill, 0, len(run.BillIDs))
+	for _, billID := range run.BillIDs {
+		bill, err := ps.storage.GetVendorBill(billID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bill: %w", err)
+		}
+		bills = append(bills, bill)
+	}
+
+	txn := &Transaction{
+		ID:              newID(),
+		Description:     fmt.Sprintf("Payment run %s", run.ScheduledDate.Format("2006-01-02")),
+		ValidTime:       run.ScheduledDate,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       fmt.Sprintf("PAYMENT_RUN_%s", run.ID),
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	var withheld int64
+	for _, bill := range bills {
+		txn.Entries = append(txn.Entries, Entry{
+			ID:            newID(),
+			TransactionID: txn.ID,
+			AccountID:     ps.getPayablesAccountID(),
+			Type:          Debit,
+			Amount:        Amount{Value: bill.Total.Value, Currency: bill.Total.Currency},
+			Dimensions:    []Dimension{{Key: DimCounterparty, Value: bill.VendorID}},
+		})
+
+		amount, err := ps.withholdingOn(bill)
+		if err != nil {
+			return nil, err
+		}
+		if amount == 0 {
+			continue
+		}
+		txn.Entries = append(txn.Entries, Entry{
+			ID:            newID(),
+			TransactionID: txn.ID,
+			AccountID:     ps.getWithholdingAccountID(),
+			Type:          Credit,
+			Amount:        Amount{Value: amount, Currency: bill.Total.Currency},
+			Dimensions:    []Dimension{{Key: DimCounterparty, Value: bill.VendorID}},
+			Memo:          fmt.Sprintf("Withholding on bill %s", bill.BillNumber),
+		})
+		withheld += amount
+	}
+	if withheld > 0 {
+		if _, err := ps.storage.GetAccount(ps.getWithholdingAccountID()); err != nil {
+			return nil, fmt.Errorf("invalid withholding account: %w", err)
+		}
+	}
+	txn.Entries = append(txn.Entries, Entry{
+		ID:            newID(),
+		TransactionID: txn.ID,
+		AccountID:     run.PaymentAccountID,
+		Type:          Credit,
+		Amount:        Amount{Value: run.Total.Value - withheld, Currency: run.Total.Currency},
+	})
+
+	if err := ps.postTransaction(txn, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	run.Status = PaymentRunExecuted
+	run.TransactionID = txn.ID
+	run.ExecutedBy = userID
+	run.ExecutedAt = &now
+
+	_, err = ps.eventStore.CreateEvent(EventExecutePaymentRun, run, run.ScheduledDate, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create payment run event: %w", err)
+	}
+	if err := ps.storage.SavePaymentRun(run); err != nil {
+		return nil, fmt.Errorf("failed to save payment run: %w", err)
+	}
+
+	for _, bill := range bills {
+		bill.Status = BillPaid
+		bill.PaymentTransactionID = txn.ID
+		bill.PaidAt = &run.ScheduledDate
+		bill.UpdatedAt = now
+		if err := ps.storage.SaveVendorBill(bill); err != nil {
+			return nil, fmt.Errorf("failed to save bill: %w", err)
+		}
+	}
+
+	return run, nil
+}
+
+// GetVendorBills returns a vendor's bills, or all bills when vendorID is empty
+func (ps *PayablesService) GetVendorBills(vendorID string) ([]*VendorBill, error) {
+	bills, err := ps.storage.GetAllVendorBills()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bills: %w", err)
+	}
+
+	var result []*VendorBill
+	for _, bill := range bills {
+		if vendorID == "" || bill.VendorID == vendorID {
+			result = append(result, bill)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].BillDate.Before(result[j].BillDate)
+	})
+	return result, nil
+}
+
+// GenerateAgingReport ages approved, unpaid bills in a currency by days past due
+func (ps *PayablesService) GenerateAgingReport(asOfDate time.Time, currency Currency) (*APAgingReport, error) {
+	bills, err := ps.storage.GetAllVendorBills()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bills: %w", err)
+	}
+
+	report := &APAgingReport{
+		AsOfDate: asOfDate,
+		Currency: currency,
+		Buckets: []*APAgingBucket{
+			{Label: "Current", MinDays: -1 << 31, MaxDays: 0},
+			{Label: "1-30", MinDays: 1, MaxDays: 30},
+			{Label: "31-60", MinDays: 31, MaxDays: 60},
+			{Label: "61-90", MinDays: 61, MaxDays: 90},
+			{Label: "90+", MinDays: 91, MaxDays: -1},
+		},
+	}
+
+	vendors := make(map[string]*APAgingVendor)
+	for _, bill := range bills {
+		if bill.Status != BillApproved && bill.Status != BillScheduled {
+			continue
+		}
+		if bill.Total.Currency != currency || bill.BillDate.After(asOfDate) {
+			continue
+		}
+
+		daysPastDue := int(asOfDate.Sub(bill.DueDate).Hours() / 24)
+		bucketIndex := len(report.Buckets) - 1
+		for i, bucket := range report.Buckets {
+			if daysPastDue >= bucket.MinDays && (bucket.MaxDays < 0 || daysPastDue <= bucket.MaxDays) {
+				bucketIndex = i
+				break
+			}
+		}
+		report.Buckets[bucketIndex].Amount += bill.Total.Value
+		report.Buckets[bucketIndex].Count++
+		report.Total += bill.Total.Value
+
+		vendorLine := vendors[bill.VendorID]
+		if vendorLine == nil {
+			vendorLine = &APAgingVendor{VendorID: bill.VendorID, Buckets: make([]int64, len(report.Buckets))}
+			if vendor, err := ps.storage.GetVendor(bill.VendorID); err == nil {
+				vendorLine.VendorName = vendor.Name
+			}
+			vendors[bill.VendorID] = vendorLine
+		}
+		vendorLine.Buckets[bucketIndex] += bill.Total.Value
+		vendorLine.Total += bill.Total.Value
+	}
+
+	for _, vendorLine := range vendors {
+		report.Vendors = append(report.Vendors, vendorLine)
+	}
+	sort.Slice(report.Vendors, func(i, j int) bool {
+		return report.Vendors[i].VendorName < report.Vendors[j].VendorName
+	})
+
+	return report, nil
+}
+
+// approve marks a bill approved and posts the liability to the ledger
+func (ps *PayablesService) approve(bill *VendorBill, userID string) (*VendorBill, error) {
+	txn := &Transaction{
+		ID:              newID(),
+		Description:     fmt.Sprintf("Vendor bill %s", bill.BillNumber),
+		ValidTime:       bill.BillDate,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       fmt.Sprintf("BILL_%s", bill.ID),
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	for _, line := range bill.Lines {
+		txn.Entries = append(txn.Entries, Entry{
+			ID:            newID(),
+			TransactionID: txn.ID,
+			AccountID:     line.AccountID,
+			Type:          Debit,
+			Amount:        Amount{Value: line.Amount, Currency: bill.Total.Currency},
+		})
+	}
+	txn.Entries = append(txn.Entries, Entry{
+		ID:            newID(),
+		TransactionID: txn.ID,
+		AccountID:     ps.getPayablesAccountID(),
+		Type:          Credit,
+		Amount:        Amount{Value: bill.Total.Value, Currency: bill.Total.Currency},
+		Dimensions:    []Dimension{{Key: DimCounterparty, Value: bill.VendorID}},
+	})
+
+	if err := ps.postTransaction(txn, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	bill.Status = BillApproved
+	bill.ApprovedBy = userID
+	bill.ApprovedAt = &now
+	bill.TransactionID = txn.ID
+	bill.UpdatedAt = now
+
+	if err := ps.saveBill(bill, EventApproveBill, userID); err != nil {
+		return nil, err
+	}
+	return bill, nil
+}
+
+// requiresApproval reports whether a bill total exceeds the approval threshold
+func (ps *PayablesService) requiresApproval(total *Amount) bool {
+	ps.mutex.RLock()
+	defer ps.mutex.RUnlock()
+	threshold := ps.requireApprovalOver
+	if threshold == nil {
+		return false
+	}
+	// A threshold in another currency cannot be compared, so err on the side of review
+	if threshold.Currency != "" && threshold.Currency != total.Currency {
+		return true
+	}
+	return total.Value > threshold.Value
+}
+
+// getPayablesAccountID returns the payables control account
+func (ps *PayablesService) getPayablesAccountID() string {
+	ps.mutex.RLock()
+	defer ps.mutex.RUnlock()
+	return ps.payablesAccountID
+}
+
+// getWithholdingAccountID returns the account withheld tax is credited to
+func (ps *PayablesService) getWithholdingAccountID() string {
+	ps.mutex.RLock()
+	defer ps.mutex.RUnlock()
+	return ps.withholdingAccountID
+}
+
+// postTransaction records, saves and posts a sub-ledger transaction
+func (ps *PayablesService) postTransaction(txn *Transaction, userID string) error {
+	_, err := ps.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := ps.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	if err := ps.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
+
+// saveBill records an event for the bill and persists it
+func (ps *PayablesService) saveBill(bill *VendorBill, eventType, userID string) error {
+	_, err := ps.eventStore.CreateEvent(eventType, bill, bill.BillDate, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create bill event: %w", err)
+	}
+	if err := ps.storage.SaveVendorBill(bill); err != nil {
+		return fmt.Errorf("failed to save bill: %w", err)
+	}
+	return nil
+}
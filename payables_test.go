@@ -0,0 +1,97 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayablesBillToPayment(t *testing.T) {
+	// Setup
+	dbFile := "test_payables.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	clerk := "ap_clerk"
+	controller := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(clerk))
+
+	engine.ApplyCompanySettings(&CompanySettings{
+		RequireApprovalOver: &Amount{Value: 100000, Currency: "USD"},
+	})
+
+	vendor := &Vendor{Name: "Acme Supplies", Currency: "USD", PaymentTermsDays: 30, DefaultExpenseAccountID: "expenses"}
+	require.NoError(t, engine.CreateVendor(vendor, clerk))
+
+	billDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	small := &VendorBill{VendorID: vendor.ID, BillNumber: "INV-1", BillDate: billDate,
+		Lines: []BillLine{{Description: "Paper", Amount: 25000}}}
+	large := &VendorBill{VendorID: vendor.ID, BillNumber: "INV-2", BillDate: billDate,
+		Lines: []BillLine{{Description: "Printer", Amount: 150000}}}
+	require.NoError(t, engine.EnterBill(small, clerk))
+	require.NoError(t, engine.EnterBill(large, clerk))
+	assert.Equal(t, billDate.AddDate(0, 0, 30), small.DueDate)
+
+	t.Run("Approval Threshold", func(t *testing.T) {
+		bill, err := engine.SubmitBill(small.ID, clerk)
+		require.NoError(t, err)
+		assert.Equal(t, BillApproved, bill.Status)
+		assert.False(t, bill.RequiresApproval)
+
+		bill, err = engine.SubmitBill(large.ID, clerk)
+		require.NoError(t, err)
+		assert.Equal(t, BillPendingApproval, bill.Status)
+
+		_, err = engine.ApproveBill(large.ID, clerk)
+		assert.Error(t, err, "preparer cannot approve their own bill")
+
+		bill, err = engine.ApproveBill(large.ID, controller)
+		require.NoError(t, err)
+		assert.Equal(t, BillApproved, bill.Status)
+
+		payable, err := engine.GetAccountBalance("accounts_payable", billDate)
+		require.NoError(t, err)
+		assert.Equal(t, int64(175000), payable.Balance.Value)
+	})
+
+	t.Run("Aging", func(t *testing.T) {
+		report, err := engine.GenerateAPAgingReport(billDate.AddDate(0, 0, 45), "USD")
+		require.NoError(t, err)
+		assert.Equal(t, int64(175000), report.Total)
+		assert.Equal(t, int64(175000), report.Buckets[1].Amount) // 15 days past due
+		require.Len(t, report.Vendors, 1)
+		assert.Equal(t, "Acme Supplies", report.Vendors[0].VendorName)
+	})
+
+	t.Run("Payment Run", func(t *testing.T) {
+		payDate := billDate.AddDate(0, 0, 30)
+		run, err := engine.SchedulePaymentRun(payDate, payDate, "cash", clerk)
+		require.NoError(t, err)
+		assert.Len(t, run.BillIDs, 2)
+		assert.Equal(t, int64(175000), run.Total.Value)
+
+		run, err = engine.ExecutePaymentRun(run.ID, controller)
+		require.NoError(t, err)
+		assert.Equal(t, PaymentRunExecuted, run.Status)
+
+		payable, err := engine.GetAccountBalance("accounts_payable", payDate)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), payable.Balance.Value)
+
+		bills, err := engine.GetPayablesService().GetVendorBills(vendor.ID)
+		require.NoError(t, err)
+		for _, bill := range bills {
+			assert.Equal(t, BillPaid, bill.Status)
+		}
+
+		report, err := engine.GenerateAPAgingReport(payDate, "USD")
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), report.Total)
+	})
+}
@@ -0,0 +1,1030 @@
+package accounting
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Period Close Structures
+// ----------------------------------------------------------------------------
+
+// SignOffRole identifies who is signing off on a period close
+type SignOffRole string
+
+const (
+	SignOffPreparer   SignOffRole = "PREPARER"
+	SignOffReviewer   SignOffRole = "REVIEWER"
+	SignOffController SignOffRole = "CONTROLLER"
+)
+
+// CloseSignOff records that a user has reviewed and approved a period close
+type CloseSignOff struct {
+	ID       string      `json:"id"`
+	PeriodID string      `json:"period_id"`
+	Role     SignOffRole `json:"role"`
+	UserID   string      `json:"user_id"`
+	Comments string      `json:"comments,omitempty"`
+	SignedAt time.Time   `json:"signed_at"`
+}
+
+// VarianceCommentary explains a period-over-period movement on an account
+type VarianceCommentary struct {
+	ID        string    `json:"id"`
+	PeriodID  string    `json:"period_id"`
+	AccountID string    `json:"account_id"`
+	Comment   string    `json:"comment"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ChecklistItemStatus is the completion state of a close checklist step
+type ChecklistItemStatus string
+
+const (
+	ChecklistComplete      ChecklistItemStatus = "COMPLETE"
+	ChecklistIncomplete    ChecklistItemStatus = "INCOMPLETE"
+	ChecklistNotApplicable ChecklistItemStatus = "NOT_APPLICABLE"
+)
+
+// BinderChecklistItem is a single close step and its status as captured in the binder
+type BinderChecklistItem struct {
+	Step        string              `json:"step"`
+	Description string              `json:"description"`
+	Status      ChecklistItemStatus `json:"status"`
+	Detail      string              `json:"detail,omitempty"`
+}
+
+// BinderVarianceLine compares an account's period activity against the prior period
+type BinderVarianceLine struct {
+	AccountID       string                `json:"account_id"`
+	AccountName     string                `json:"account_name"`
+	AccountType     AccountType           `json:"account_type"`
+	CurrentAmount   *Amount               `json:"current_amount"`
+	PriorAmount     *Amount               `json:"prior_amount"`
+	Variance        *Amount               `json:"variance"`
+	VariancePercent float64               `json:"variance_percent"`
+	Material        bool                  `json:"material"` // exceeds performance materiality
+	Commentary      []*VarianceCommentary `json:"commentary,omitempty"`
+}
+
+// BinderSection identifies a section of the closing binder
+type BinderSection string
+
+const (
+	BinderSectionChecklist       BinderSection = "CHECKLIST"
+	BinderSectionReconciliations BinderSection = "RECONCILIATIONS"
+	BinderSectionStatements      BinderSection = "STATEMENTS"
+	BinderSectionJournal         BinderSection = "JOURNAL"
+	BinderSectionVariance        BinderSection = "VARIANCE"
+	BinderSectionSignOffs        BinderSection = "SIGN_OFFS"
+)
+
+// BinderIndexEntry describes one file in the archived binder package
+type BinderIndexEntry struct {
+	Section   BinderSection `json:"section"`
+	FileName  string        `json:"file_name"`
+	Title     string        `json:"title"`
+	ItemCount int           `json:"item_count"`
+	SHA256    string        `json:"sha256"`
+}
+
+// ClosingBinder assembles every close artifact for a period for controller review
+type ClosingBinder struct {
+	ID              string                 `json:"id"`
+	PeriodID        string                 `json:"period_id"`
+	PeriodName      string                 `json:"period_name"`
+	PeriodStart     time.Time              `json:"period_start"`
+	PeriodEnd       time.Time              `json:"period_end"`
+	Currency        string                 `json:"currency"`
+	Checklist       []*BinderChecklistItem `json:"checklist"`
+	Reconciliations []*Reconciliation      `json:"reconciliations"`
+	BalanceSheet    *FinancialStatement    `json:"balance_sheet"`
+	ProfitAndLoss   *FinancialStatement    `json:"profit_and_loss"`
+	CashFlow        *CashFlowStatement     `json:"cash_flow"`
+	Journal         []*Transaction         `json:"journal"`
+	Variance        []*BinderVarianceLine  `json:"variance"`
+	SignOffs        []*CloseSignOff        `json:"sign_offs"`
+	Index           []BinderIndexEntry     `json:"index"`
+	GeneratedAt     time.Time              `json:"generated_at"`
+	GeneratedBy     string                 `json:"generated_by"`
+}
+
+// ClosingBinderArchive is the retained, immutable package produced for a closing binder
+type ClosingBinderArchive struct {
+	ID          string             `json:"id"`
+	PeriodID    string             `json:"period_id"`
+	PeriodName  string             `json:"period_name"`
+	Currency    string             `json:"currency"`
+	GeneratedAt time.Time          `json:"generated_at"`
+	GeneratedBy string             `json:"generated_by"`
+	Index       []BinderIndexEntry `json:"index"`
+	Archive     []byte             `json:"archive"` // zip package containing index.json and all sections
+}
+
+// Close checklist steps every period starts with
+const (
+	CloseStepAccruals        = "ACCRUALS_RUN"
+	CloseStepReconciliations = "RECONCILIATIONS_COMPLETE"
+	CloseStepReports         = "REPORTS_GENERATED"
+)
+
+// CloseChecklistItem is one step of a period's close checklist
+type CloseChecklistItem struct {
+	Step        string              `json:"step"`
+	Description string              `json:"description"`
+	Required    bool                `json:"required"` // must be complete or not applicable before a hard close
+	Status      ChecklistItemStatus `json:"status"`
+	Note        string              `json:"note,omitempty"`
+	CompletedBy string              `json:"completed_by,omitempty"`
+	CompletedAt *time.Time          `json:"completed_at,omitempty"`
+}
+
+// PeriodCloseChecklist tracks the close steps for a period; its ID is the period ID
+type PeriodCloseChecklist struct {
+	ID        string               `json:"id"`
+	Items     []CloseChecklistItem `json:"items"`
+	UpdatedBy string               `json:"updated_by,omitempty"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// Outstanding returns the required steps not yet complete or marked not applicable
+func (c *PeriodCloseChecklist) Outstanding() []string {
+	var steps []string
+	for _, item := range c.Items {
+		if item.Required && item.Status == ChecklistIncomplete {
+			steps = append(steps, item.Step)
+		}
+	}
+	return steps
+}
+
+// PeriodReopening records a closed period being reopened and why
+type PeriodReopening struct {
+	ID           string     `json:"id"`
+	PeriodID     string     `json:"period_id"`
+	Reason       string     `json:"reason"`
+	SoftClosedAt *time.Time `json:"soft_closed_at,omitempty"` // close state before reopening
+	HardClosedAt *time.Time `json:"hard_closed_at,omitempty"`
+	ReopenedBy   string     `json:"reopened_by"`
+	ReopenedAt   time.Time  `json:"reopened_at"`
+}
+
+// PeriodClosePermissions designates the users who may work with closed periods.
+// An empty list means anyone may.
+type PeriodClosePermissions struct {
+	Controllers []string `json:"controllers,omitempty"`  // soft-close, reopen soft closes and post adjustments into soft-closed periods
+	HardClosers []string `json:"hard_closers,omitempty"` // hard-close and formally reopen hard-closed periods
+}
+
+// ----------------------------------------------------------------------------
+// Period Close Service
+// ----------------------------------------------------------------------------
+
+// PeriodCloseService coordinates close artifacts such as sign-offs and closing binders
+type PeriodCloseService struct {
+	storage           *Storage
+	eventStore        *EventStore
+	reportingService  *ReportingService
+	disclosureService *DisclosureService
+	materiality       *MaterialityService
+	postingEngine     *PostingEngine
+	workflows         *WorkflowService
+	beforeClose       func(periodID, userID string) error // nil until the engine sets it
+}
+
+// NewPeriodCloseService creates a new period close service
+func NewPeriodCloseService(storage *Storage, eventStore *EventStore, reportingService *ReportingService, disclosureService *DisclosureService, materiality *MaterialityService, postingEngine *PostingEngine) *PeriodCloseService {
+	pcs := &PeriodCloseService{
+		storage:           storage,
+		eventStore:        eventStore,
+		reportingService:  reportingService,
+		disclosureService: disclosureService,
+		materiality:       materiality,
+		postingEngine:     postingEngine,
+	}
+	pcs.UseWorkflows(NewWorkflowService(storage))
+	return pcs
+}
+
+// UseWorkflows runs period close on a shared workflow service. Call it while the
+// engine is being assembled.
+func (pcs *PeriodCloseService) UseWorkflows(workflows *WorkflowService) {
+	workflows.mustRegister(pcs.workflow())
+	pcs.workflows = workflows
+}
+
+// setBeforeClose sets the adjustments, such as FX revaluation, made to a period
+// just before it closes. Call it while the engine is being assembled.
+func (pcs *PeriodCloseService) setBeforeClose(beforeClose func(periodID, userID string) error) {
+	pcs.beforeClose = beforeClose
+}
+
+// AddVarianceCommentary attaches an explanation of an account's movement to a period
+func (pcs *PeriodCloseService) AddVarianceCommentary(periodID, accountID, comment, userID string) (*VarianceCommentary, error) {
+	if _, err := pcs.storage.GetPeriod(periodID); err != nil {
+		return nil, fmt.Errorf("failed to get period: %w", err)
+	}
+	if comment == "" {
+		return nil, fmt.Errorf("variance commentary cannot be empty")
+	}
+
+	commentary := &VarianceCommentary{
+		ID:        newID(),
+		PeriodID:  periodID,
+		AccountID: accountID,
+		Comment:   comment,
+		CreatedBy: userID,
+		CreatedAt: time.Now(),
+	}
+
+	_, err := pcs.eventStore.CreateEvent(EventAddVarianceCommentary, commentary, commentary.CreatedAt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create variance commentary event: %w", err)
+	}
+
+	if err := pcs.storage.SaveVarianceCommentary(commentary); err != nil {
+		return nil, fmt.Errorf("failed to save variance commentary: %w", err)
+	}
+
+	return commentary, nil
+}
+
+// SignOffPeriod records a close sign-off for a period
+func (pcs *PeriodCloseService) SignOffPeriod(periodID string, role SignOffRole, comments, userID string) (*CloseSignOff, error) {
+	if _, err := pcs.storage.GetPeriod(periodID); err != nil {
+		return nil, fmt.Errorf("failed to get period: %w", err)
+	}
+
+	signOff := &CloseSignOff{
+		ID:       newID(),
+		PeriodID: periodID,
+		Role:     role,
+		UserID:   userID,
+		Comments: comments,
+		SignedAt: time.Now(),
+	}
+
+	_, err := pcs.eventStore.CreateEvent(EventSignOffPeriod, signOff, signOff.SignedAt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sign-off event: %w", err)
+	}
+
+	if err := pcs.storage.SaveCloseSignOff(signOff); err != nil {
+		return nil, fmt.Errorf("failed to save sign-off: %w", err)
+	}
+
+	return signOff, nil
+}
+
+// GetCloseChecklist returns a period's close checklist, starting from the standard
+// steps if none has been recorded yet
+func (pcs *PeriodCloseService) GetCloseChecklist(periodID string) (*PeriodCloseChecklist, error) {
+	if _, err := pcs.storage.GetPeriod(periodID); err != nil {
+		return nil, fmt.Errorf("failed to get period: %w", err)
+	}
+	if checklist, err := pcs.storage.GetPeriodCloseChecklist(periodID); err == nil {
+		return checklist, nil
+	}
+	return &PeriodCloseChecklist{
+		ID: periodID,
+		Items: []CloseChecklistItem{
+			{Step: CloseStepAccruals, Description: "Accruals and deferrals run for the period", Required: true, Status: ChecklistIncomplete},
+			{Step: CloseStepReconciliations, Description: "Account reconciliations complete", Required: true, Status: ChecklistIncomplete},
+			{Step: CloseStepReports, Description: "Period financial reports generated", Required: true, Status: ChecklistIncomplete},
+		},
+	}, nil
+}
+
+// UpdateChecklistItem sets the status of a close step, adding the step as optional
+// if the checklist does not have it yet
+func (pcs *PeriodCloseService) UpdateChecklistItem(periodID, step string, status ChecklistItemStatus, note, userID string) (*PeriodCloseChecklist, error) {
+	if step == "" {
+		return nil, fmt.Errorf("checklist step is required")
+	}
+	if status != ChecklistComplete && status != ChecklistIncomplete && status != ChecklistNotApplicable {
+		return nil, fmt.Errorf("invalid checklist status: %s", status)
+	}
+	checklist, err := pcs.GetCloseChecklist(periodID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	index := -1
+	for i := range checklist.Items {
+		if checklist.Items[i].Step == step {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		checklist.Items = append(checklist.Items, CloseChecklistItem{Step: step, Description: step})
+		index = len(checklist.Items) - 1
+	}
+	item := &checklist.Items[index]
+	item.Status = status
+	item.Note = note
+	item.CompletedBy, item.CompletedAt = "", nil
+	if status != ChecklistIncomplete {
+		item.CompletedBy = userID
+		item.CompletedAt = &now
+	}
+	checklist.UpdatedBy = userID
+	checklist.UpdatedAt = now
+
+	_, err = pcs.eventStore.CreateEvent(EventUpdateCloseChecklist, checklist, now, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create close checklist event: %w", err)
+	}
+	if err := pcs.storage.SavePeriodCloseChecklist(checklist); err != nil {
+		return nil, fmt.Errorf("failed to save close checklist: %w", err)
+	}
+	return checklist, nil
+}
+
+// ClosePeriod soft- or hard-closes a period. A soft close is made by a controller; a
+// hard close is made by a hard closer once every required checklist step is done.
+func (pcs *PeriodCloseService) ClosePeriod(periodID string, softClose bool, userID string) error {
+	action := "hard_close"
+	if softClose {
+		action = "soft_close"
+	}
+	_, err := pcs.workflows.Fire(WorkflowPeriodClose, periodID, &WorkflowRequest{Action: action, Actor: userID})
+	return err
+}
+
+// ReopenPeriod reopens a soft- or hard-closed period so it accepts postings again.
+// A reason is required and the prior close state is kept with the reopening.
+// Controllers reopen soft closes; a hard close needs a formal reopen by a hard closer.
+func (pcs *PeriodCloseService) ReopenPeriod(periodID, reason, userID string) (*PeriodReopening, error) {
+	if reason == "" {
+		return nil, fmt.Errorf("a reason is required to reopen a period")
+	}
+	reopening := &PeriodReopening{ID: newID(), PeriodID: periodID, Reason: reason, ReopenedBy: userID}
+	_, err := pcs.workflows.Fire(WorkflowPeriodClose, periodID, &WorkflowRequest{
+		Action: "reopen", Actor: userID, Comment: reason, Data: reopening,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reopening, nil
+}
+
+// ----------------------------------------------------------------------------
+// Period Close Workflow
+// ----------------------------------------------------------------------------
+
+// WorkflowPeriodClose is the closing and reopening of accounting periods; its
+// subjects are period IDs
+const WorkflowPeriodClose = "period_close"
+
+// Period close states, derived from a period's close times
+const (
+	PeriodStateOpen       = "OPEN"
+	PeriodStateSoftClosed = "SOFT_CLOSED"
+	PeriodStateHardClosed = "HARD_CLOSED"
+)
+
+// periodCloseState is the close state a period's close times record
+func periodCloseState(period *Period) string {
+	switch {
+	case period.HardClosedAt != nil:
+		return PeriodStateHardClosed
+	case period.SoftClosedAt != nil:
+		return PeriodStateSoftClosed
+	}
+	return PeriodStateOpen
+}
+
+// workflow defines how periods close and reopen
+func (pcs *PeriodCloseService) workflow() *WorkflowDefinition {
+	return &WorkflowDefinition{
+		Name: WorkflowPeriodClose,
+		Transitions: []WorkflowTransition{
+			{Action: "soft_close", From: []string{PeriodStateOpen}, To: PeriodStateSoftClosed, Guard: pcs.canSoftClose},
+			{Action: "hard_close", From: []string{PeriodStateOpen, PeriodStateSoftClosed}, To: PeriodStateHardClosed, Guard: pcs.canHardClose},
+			{Action: "reopen", From: []string{PeriodStateSoftClosed, PeriodStateHardClosed}, To: PeriodStateOpen, Guard: pcs.canReopen, RequireComment: true},
+		},
+		State: func(periodID string) (string, error) {
+			period, err := pcs.storage.GetPeriod(periodID)
+			if err != nil {
+				return "", fmt.Errorf("failed to get period: %w", err)
+			}
+			return periodCloseState(period), nil
+		},
+		NotAllowed: func(instance *WorkflowInstance, request *WorkflowRequest) error {
+			if request.Action == "reopen" {
+				return fmt.Errorf("period %s is not closed", instance.SubjectID)
+			}
+			period, err := pcs.storage.GetPeriod(instance.SubjectID)
+			if err != nil {
+				return fmt.Errorf("failed to get period: %w", err)
+			}
+			if instance.State == PeriodStateHardClosed {
+				return classify(ErrPeriodClosed, "period %s is already hard-closed", period.Name)
+			}
+			return classify(ErrPeriodClosed, "period %s is already soft-closed", period.Name)
+		},
+		OnTransition: pcs.applyTransition,
+	}
+}
+
+// canSoftClose checks the user is a controller and the close respects four eyes
+func (pcs *PeriodCloseService) canSoftClose(instance *WorkflowInstance, request *WorkflowRequest) error {
+	period, checklist, err := pcs.closeSubject(instance.SubjectID)
+	if err != nil {
+		return err
+	}
+	if !pcs.postingEngine.IsPeriodController(request.Actor) {
+		return classify(ErrPermissionDenied, "user %s may not soft-close period %s", request.Actor, period.Name)
+	}
+	return pcs.postingEngine.checkCloseFourEyes(checklist, request.Actor)
+}
+
+// canHardClose checks the user is a hard closer, the close respects four eyes and
+// every required checklist step is done
+func (pcs *PeriodCloseService) canHardClose(instance *WorkflowInstance, request *WorkflowRequest) error {
+	period, checklist, err := pcs.closeSubject(instance.SubjectID)
+	if err != nil {
+		return err
+	}
+	if !pcs.postingEngine.IsPeriodHardCloser(request.Actor) {
+		return classify(ErrPermissionDenied, "user %s may not hard-close period %s", request.Actor, period.Name)
+	}
+	if err := pcs.postingEngine.checkCloseFourEyes(checklist, request.Actor); err != nil {
+		return err
+	}
+	if outstanding := checklist.Outstanding(); len(outstanding) > 0 {
+		return fmt.Errorf("cannot hard-close period %s: checklist steps outstanding: %s", period.Name, strings.Join(outstanding, ", "))
+	}
+	return nil
+}
+
+// canReopen checks the user may reopen a period closed the way this one is
+func (pcs *PeriodCloseService) canReopen(instance *WorkflowInstance, request *WorkflowRequest) error {
+	if instance.State == PeriodStateHardClosed {
+		if !pcs.postingEngine.IsPeriodHardCloser(request.Actor) {
+			return classify(ErrPermissionDenied, "user %s may not reopen hard-closed period %s", request.Actor, instance.SubjectID)
+		}
+	} else if !pcs.postingEngine.IsPeriodController(request.Actor) {
+		return classify(ErrPermissionDenied, "user %s may not reopen soft-closed period %s", request.Actor, instance.SubjectID)
+	}
+	return nil
+}
+
+// closeSubject loads the period being closed and its checklist
+func (pcs *PeriodCloseService) closeSubject(periodID string) (*Period, *PeriodCloseChecklist, error) {
+	period, err := pcs.storage.GetPeriod(periodID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get period: %w", err)
+	}
+	checklist, err := pcs.GetCloseChecklist(periodID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return period, checklist, nil
+}
+
+// applyTransition closes or reopens the period
+func (pcs *PeriodCloseService) applyTransition(instance *WorkflowInstance, request *WorkflowRequest, from string) error {
+	periodID, userID := instance.SubjectID, request.Actor
+	if request.Action == "reopen" {
+		return pcs.reopen(periodID, from, request)
+	}
+
+	if pcs.beforeClose != nil {
+		if err := pcs.beforeClose(periodID, userID); err != nil {
+			return err
+		}
+	}
+	period, err := pcs.storage.GetPeriod(periodID)
+	if err != nil {
+		return fmt.Errorf("failed to get period: %w", err)
+	}
+
+	now := instance.EnteredAt
+	softClose := request.Action == "soft_close"
+	eventType := EventHardClosePeriod
+	if softClose {
+		period.SoftClosedAt = &now
+		eventType = EventSoftClosePeriod
+	} else {
+		period.HardClosedAt = &now
+	}
+
+	// Create period close event
+	_, err = pcs.eventStore.CreateEvent(
+		eventType,
+		map[string]interface{}{
+			"period_id":  periodID,
+			"soft_close": softClose,
+			"closed_at":  now,
+		},
+		time.Now(),
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create period close event: %w", err)
+	}
+
+	return pcs.storage.SavePeriod(period)
+}
+
+// reopen records a reopening and clears the period's close times
+func (pcs *PeriodCloseService) reopen(periodID, from string, request *WorkflowRequest) error {
+	reopening, ok := request.Data.(*PeriodReopening)
+	if !ok {
+		return fmt.Errorf("periods are reopened through ReopenPeriod")
+	}
+	period, err := pcs.storage.GetPeriod(periodID)
+	if err != nil {
+		return fmt.Errorf("failed to get period: %w", err)
+	}
+	eventType := EventReopenPeriod
+	if from == PeriodStateHardClosed {
+		eventType = EventFormallyReopenPeriod
+	}
+
+	reopening.SoftClosedAt = period.SoftClosedAt
+	reopening.HardClosedAt = period.HardClosedAt
+	reopening.ReopenedAt = time.Now()
+
+	_, err = pcs.eventStore.CreateEvent(eventType, reopening, reopening.ReopenedAt, request.Actor)
+	if err != nil {
+		return fmt.Errorf("failed to create period reopen event: %w", err)
+	}
+
+	period.SoftClosedAt = nil
+	period.HardClosedAt = nil
+	if err := pcs.storage.SavePeriod(period); err != nil {
+		return fmt.Errorf("failed to save period: %w", err)
+	}
+	if err := pcs.storage.SavePeriodReopening(reopening); err != nil {
+		return fmt.Errorf("failed to save period reopening: %w", err)
+	}
+	return nil
+}
+
+// GetReopenings returns a period's reopenings, oldest first
+func (pcs *PeriodCloseService) GetReopenings(periodID string) ([]*PeriodReopening, error) {
+	all, err := pcs.storage.GetAllPeriodReopenings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get period reopenings: %w", err)
+	}
+	var reopenings []*PeriodReopening
+	for _, reopening := range all {
+		if reopening.PeriodID == periodID {
+			reopenings = append(reopenings, reopening)
+		}
+	}
+	sort.Slice(reopenings, func(i, j int) bool {
+		return reopenings[i].ReopenedAt.Before(reopenings[j].ReopenedAt)
+	})
+	return reopenings, nil
+}
+
+// GenerateClosingBinder assembles all close artifacts for a period into a single
+// archived package with an index, and retains the archive in storage
+func (pcs *PeriodCloseService) GenerateClosingBinder(periodID, currency, userID string) (*ClosingBinder, *ClosingBinderArchive, error) {
+	period, err := pcs.storage.GetPeriod(periodID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get period: %w", err)
+	}
+
+	binder := &ClosingBinder{
+		ID:          newID(),
+		PeriodID:    period.ID,
+		PeriodName:  period.Name,
+		PeriodStart: period.Start,
+		PeriodEnd:   period.End,
+		Currency:    currency,
+		GeneratedAt: time.Now(),
+		GeneratedBy: userID,
+	}
+
+	if binder.Reconciliations, err = pcs.getPeriodReconciliations(period); err != nil {
+		return nil, nil, fmt.Errorf("failed to collect reconciliations: %w", err)
+	}
+
+	if binder.BalanceSheet, err = pcs.reportingService.GenerateBalanceSheet(period.End, currency); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate balance sheet: %w", err)
+	}
+	if binder.ProfitAndLoss, err = pcs.reportingService.GenerateProfitAndLoss(period.Start, period.End, currency); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate profit and loss: %w", err)
+	}
+	if binder.CashFlow, err = pcs.reportingService.GenerateCashFlowStatement(period.Start, period.End, currency); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate cash flow statement: %w", err)
+	}
+	for _, statement := range []*FinancialStatement{binder.BalanceSheet, binder.ProfitAndLoss} {
+		if err := pcs.disclosureService.AttachFootnotes(statement, period.ID); err != nil {
+			return nil, nil, fmt.Errorf("failed to attach disclosures: %w", err)
+		}
+	}
+
+	if binder.Journal, err = pcs.getPeriodJournal(period); err != nil {
+		return nil, nil, fmt.Errorf("failed to collect journal listing: %w", err)
+	}
+
+	if binder.Variance, err = pcs.buildVarianceAnalysis(period, binder.ProfitAndLoss); err != nil {
+		return nil, nil, fmt.Errorf("failed to build variance analysis: %w", err)
+	}
+
+	if binder.SignOffs, err = pcs.storage.GetCloseSignOffsByPeriod(period.ID); err != nil {
+		return nil, nil, fmt.Errorf("failed to collect sign-offs: %w", err)
+	}
+	sort.Slice(binder.SignOffs, func(i, j int) bool {
+		return binder.SignOffs[i].SignedAt.Before(binder.SignOffs[j].SignedAt)
+	})
+
+	binder.Checklist = pcs.buildChecklist(period, binder)
+
+	archive, err := pcs.archiveBinder(binder)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to archive closing binder: %w", err)
+	}
+
+	_, err = pcs.eventStore.CreateEvent(
+		EventGenerateClosingBinder,
+		map[string]interface{}{
+			"binder_id": binder.ID,
+			"period_id": period.ID,
+			"index":     binder.Index,
+		},
+		period.End,
+		userID,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create closing binder event: %w", err)
+	}
+
+	if err := pcs.storage.SaveClosingBinderArchive(archive); err != nil {
+		return nil, nil, fmt.Errorf("failed to save closing binder: %w", err)
+	}
+
+	return binder, archive, nil
+}
+
+// GetClosingBinderArchive retrieves a previously archived closing binder
+func (pcs *PeriodCloseService) GetClosingBinderArchive(binderID string) (*ClosingBinderArchive, error) {
+	return pcs.storage.GetClosingBinderArchive(binderID)
+}
+
+// getPeriodReconciliations returns reconciliations created within the period
+func (pcs *PeriodCloseService) getPeriodReconciliations(period *Period) ([]*Reconciliation, error) {
+	all, err := pcs.storage.GetAllReconciliations()
+	if err != nil {
+		return nil, err
+	}
+
+	var reconciliations []*Reconciliation
+	for _, recon := range all {
+		if recon.CreatedAt.Before(period.Start) || recon.CreatedAt.After(period.End) {
+			continue
+		}
+		reconciliations = append(reconciliations, recon)
+	}
+
+	sort.Slice(reconciliations, func(i, j int) bool {
+		return reconciliations[i].CreatedAt.Before(reconciliations[j].CreatedAt)
+	})
+
+	return reconciliations, nil
+}
+
+// getPeriodJournal returns all transactions dated within the period in date order
+func (pcs *PeriodCloseService) getPeriodJournal(period *Period) ([]*Transaction, error) {
+	transactions, err := pcs.storage.GetTransactionsByDateRange("", period.Start, period.End)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].ValidTime.Before(transactions[j].ValidTime)
+	})
+
+	return transactions, nil
+}
+
+// buildVarianceAnalysis compares P&L activity against the immediately preceding
+// period of equal length and attaches any recorded commentary. Variances above the
+// period's performance materiality are flagged as material.
+func (pcs *PeriodCloseService) buildVarianceAnalysis(period *Period, pl *FinancialStatement) ([]*BinderVarianceLine, error) {
+	comments, err := pcs.storage.GetVarianceCommentaryByPeriod(period.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var threshold *Amount
+	if pcs.materiality != nil {
+		if assessment, err := pcs.materiality.GetMaterialityForPeriod("", period.ID); err == nil {
+			threshold = assessment.Performance
+		}
+	}
+	commentsByAccount := make(map[string][]*VarianceCommentary)
+	for _, comment := range comments {
+		commentsByAccount[comment.AccountID] = append(commentsByAccount[comment.AccountID], comment)
+	}
+
+	length := period.End.Sub(period.Start)
+	priorEnd := period.Start.Add(-time.Nanosecond)
+	priorStart := priorEnd.Add(-length)
+
+	var lines []*BinderVarianceLine
+	for _, section := range pl.LineItems {
+		for _, item := range section.Children {
+			prior, err := pcs.reportingService.calculatePeriodBalance(item.AccountID, priorStart, priorEnd)
+			if err != nil {
+				return nil, fmt.Errorf("failed to calculate prior period balance for account %s: %w", item.AccountID, err)
+			}
+
+			variance := item.Amount.Value - prior.Value
+			variancePercent := 0.0
+			if prior.Value != 0 {
+				variancePercent = float64(variance) / float64(abs64(prior.Value)) * 100
+			}
+			material := threshold != nil && threshold.Currency == item.Amount.Currency &&
+				abs64(variance) > threshold.Value
+
+			lines = append(lines, &BinderVarianceLine{
+				AccountID:       item.AccountID,
+				AccountName:     item.AccountName,
+				AccountType:     item.AccountType,
+				CurrentAmount:   item.Amount,
+				PriorAmount:     prior,
+				Variance:        &Amount{Value: variance, Currency: item.Amount.Currency},
+				VariancePercent: variancePercent,
+				Material:        material,
+				Commentary:      commentsByAccount[item.AccountID],
+			})
+		}
+	}
+
+	return lines, nil
+}
+
+// buildChecklist derives the close checklist status from the artifacts collected
+func (pcs *PeriodCloseService) buildChecklist(period *Period, binder *ClosingBinder) []*BinderChecklistItem {
+	status := func(done bool) ChecklistItemStatus {
+		if done {
+			return ChecklistComplete
+		}
+		return ChecklistIncomplete
+	}
+
+	pending := 0
+	for _, txn := range binder.Journal {
+		if txn.Status == Pending {
+			pending++
+		}
+	}
+
+	balanced := pcs.isTrialBalanceBalanced(period.End)
+	reconciled, active := pcs.reconciledAccounts(binder)
+
+	// Once materiality is set, every material variance must be explained
+	commented, material, materialCommented := 0, 0, 0
+	for _, line := range binder.Variance {
+		if len(line.Commentary) > 0 {
+			commented++
+		}
+		if line.Material {
+			material++
+			if len(line.Commentary) > 0 {
+				materialCommented++
+			}
+		}
+	}
+	varianceReviewed := commented > 0 || len(binder.Variance) == 0
+	varianceDetail := fmt.Sprintf("%d of %d accounts commented", commented, len(binder.Variance))
+	if material > 0 {
+		varianceReviewed = materialCommented == material
+		varianceDetail = fmt.Sprintf("%d of %d material variances commented", materialCommented, material)
+	}
+
+	roles := make(map[SignOffRole]bool)
+	for _, signOff := range binder.SignOffs {
+		roles[signOff.Role] = true
+	}
+
+	return []*BinderChecklistItem{
+		{
+			Step:        "POST_PENDING",
+			Description: "All period transactions posted",
+			Status:      status(pending == 0),
+			Detail:      fmt.Sprintf("%d pending of %d transactions", pending, len(binder.Journal)),
+		},
+		{
+			Step:        "RECONCILE",
+			Description: "Account reconciliations completed",
+			Status:      status(reconciled == active),
+			Detail:      fmt.Sprintf("%d of %d balance sheet accounts with activity reconciled", reconciled, active),
+		},
+		{
+			Step:        "BALANCE",
+			Description: "Trial balance debits equal credits at period end",
+			Status:      status(balanced),
+		},
+		{
+			Step:        "VARIANCE_REVIEW",
+			Description: "Variance commentary provided",
+			Status:      status(varianceReviewed),
+			Detail:      varianceDetail,
+		},
+		{
+			Step:        "SOFT_CLOSE",
+			Description: "Period soft-closed",
+			Status:      status(period.SoftClosedAt != nil || period.HardClosedAt != nil),
+		},
+		{
+			Step:        "SIGN_OFF",
+			Description: "Preparer, reviewer and controller sign-offs captured",
+			Status:      status(roles[SignOffPreparer] && roles[SignOffReviewer] && roles[SignOffController]),
+			Detail:      fmt.Sprintf("%d sign-offs recorded", len(binder.SignOffs)),
+		},
+	}
+}
+
+// reconciledAccounts counts the balance sheet accounts with posted activity in the
+// binder's journal, and how many of them a reconciliation in the period covers
+func (pcs *PeriodCloseService) reconciledAccounts(binder *ClosingBinder) (reconciled, active int) {
+	entryAccounts := make(map[string]string)
+	activity := make(map[string]bool)
+	accountTypes := make(map[string]AccountType)
+	for _, txn := range binder.Journal {
+		if txn.Status != Posted && txn.Status != Reversed {
+			continue
+		}
+		for _, entry := range txn.Entries {
+			entryAccounts[entry.ID] = entry.AccountID
+			if _, ok := accountTypes[entry.AccountID]; !ok {
+				if account, err := pcs.storage.GetAccount(entry.AccountID); err == nil {
+					accountTypes[entry.AccountID] = account.Type
+				}
+			}
+			switch accountTypes[entry.AccountID] {
+			case Asset, Liability, Equity:
+				activity[entry.AccountID] = true
+			}
+		}
+	}
+
+	covered := make(map[string]bool)
+	for _, recon := range binder.Reconciliations {
+		for _, entryID := range recon.EntryIDs {
+			if accountID, ok := entryAccounts[entryID]; ok && activity[accountID] {
+				covered[accountID] = true
+			}
+		}
+	}
+	return len(covered), len(activity)
+}
+
+// isTrialBalanceBalanced checks that debit-normal and credit-normal balances agree
+func (pcs *PeriodCloseService) isTrialBalanceBalanced(asOfDate time.Time) bool {
+	trialBalance, err := pcs.reportingService.queryAPI.GetTrialBalance(asOfDate, nil)
+	if err != nil {
+		return false
+	}
+
+	var debits, credits int64
+	for _, balance := range trialBalance {
+		switch balance.AccountType {
+		case Asset, Expense:
+			debits += balance.Balance.Value
+		default:
+			credits += balance.Balance.Value
+		}
+	}
+
+	return debits == credits
+}
+
+// archiveBinder writes every binder section into a zip package with an index
+func (pcs *PeriodCloseService) archiveBinder(binder *ClosingBinder) (*ClosingBinderArchive, error) {
+	type binderFile struct {
+		section BinderSection
+		name    string
+		title   string
+		count   int
+		data    []byte
+	}
+
+	marshal := func(v interface{}) ([]byte, error) {
+		return json.MarshalIndent(v, "", "  ")
+	}
+
+	var files []binderFile
+	add := func(section BinderSection, name, title string, count int, v interface{}) error {
+		data, err := marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s: %w", name, err)
+		}
+		files = append(files, binderFile{section, name, title, count, data})
+		return nil
+	}
+
+	statements := map[string]interface{}{
+		"balance_sheet":   binder.BalanceSheet,
+		"profit_and_loss": binder.ProfitAndLoss,
+		"cash_flow":       binder.CashFlow,
+	}
+
+	if err := add(BinderSectionChecklist, "01_checklist.json", "Close Checklist", len(binder.Checklist), binder.Checklist); err != nil {
+		return nil, err
+	}
+	if err := add(BinderSectionReconciliations, "02_reconciliations.json", "Reconciliations", len(binder.Reconciliations), binder.Reconciliations); err != nil {
+		return nil, err
+	}
+	if err := add(BinderSectionStatements, "03_statements.json", "Financial Statements", len(statements), statements); err != nil {
+		return nil, err
+	}
+
+	// Human-readable statements for reviewers
+	readable := pcs.reportingService.FormatFinancialStatement(binder.BalanceSheet) +
+		pcs.reportingService.FormatFinancialStatement(binder.ProfitAndLoss) +
+		pcs.reportingService.FormatCashFlowStatement(binder.CashFlow)
+	files = append(files, binderFile{BinderSectionStatements, "03_statements.txt", "Financial Statements (formatted)", len(statements), []byte(readable)})
+
+	if err := add(BinderSectionJournal, "04_journal.json", "Journal Listing", len(binder.Journal), binder.Journal); err != nil {
+		return nil, err
+	}
+	if err := add(BinderSectionVariance, "05_variance.json", "Variance Commentary", len(binder.Variance), binder.Variance); err != nil {
+		return nil, err
+	}
+	if err := add(BinderSectionSignOffs, "06_sign_offs.json", "Sign-offs", len(binder.SignOffs), binder.SignOffs); err != nil {
+		return nil, err
+	}
+
+	binder.Index = make([]BinderIndexEntry, 0, len(files))
+	for _, f := range files {
+		sum := sha256.Sum256(f.data)
+		binder.Index = append(binder.Index, BinderIndexEntry{
+			Section:   f.section,
+			FileName:  f.name,
+			Title:     f.title,
+			ItemCount: f.count,
+			SHA256:    hex.EncodeToString(sum[:]),
+		})
+	}
+
+	index, err := marshal(map[string]interface{}{
+		"binder_id":    binder.ID,
+		"period_id":    binder.PeriodID,
+		"period_name":  binder.PeriodName,
+		"period_start": binder.PeriodStart,
+		"period_end":   binder.PeriodEnd,
+		"currency":     binder.Currency,
+		"generated_at": binder.GeneratedAt,
+		"generated_by": binder.GeneratedBy,
+		"entries":      binder.Index,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal index: %w", err)
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	write := func(name string, data []byte) error {
+		w, err := zw.CreateHeader(&zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: binder.GeneratedAt,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	if err := write("00_index.json", index); err != nil {
+		return nil, fmt.Errorf("failed to write index: %w", err)
+	}
+	for _, f := range files {
+		if err := write(f.name, f.data); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", f.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize archive: %w", err)
+	}
+
+	return &ClosingBinderArchive{
+		ID:          binder.ID,
+		PeriodID:    binder.PeriodID,
+		PeriodName:  binder.PeriodName,
+		Currency:    binder.Currency,
+		GeneratedAt: binder.GeneratedAt,
+		GeneratedBy: binder.GeneratedBy,
+		Index:       binder.Index,
+		Archive:     buf.Bytes(),
+	}, nil
+}
@@ -0,0 +1,426 @@
+package accounting
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClosingBinder(t *testing.T) {
+	// Setup
+	dbFile := "test_closing_binder.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "test_user"
+
+	err = engine.CreateStandardAccounts(userID)
+	require.NoError(t, err)
+
+	now := time.Now()
+	period := &Period{
+		Name:  "Current Month",
+		Start: now.AddDate(0, 0, -15),
+		End:   now.AddDate(0, 0, 15),
+	}
+	err = engine.CreatePeriod(period, userID)
+	require.NoError(t, err)
+
+	sale := &Transaction{
+		Description: "Test sale",
+		ValidTime:   now,
+		Entries: []Entry{
+			{AccountID: "cash", Type: Debit, Amount: Amount{Value: 50000, Currency: "USD"}},
+			{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 50000, Currency: "USD"}},
+		},
+	}
+	err = engine.CreateTransaction(sale, userID)
+	require.NoError(t, err)
+	err = engine.PostTransaction(sale.ID, userID)
+	require.NoError(t, err)
+
+	_, err = engine.AddVarianceCommentary(period.ID, "revenue", "New customer contract", userID)
+	require.NoError(t, err)
+
+	for _, role := range []SignOffRole{SignOffPreparer, SignOffReviewer, SignOffController} {
+		_, err = engine.SignOffPeriod(period.ID, role, "", userID)
+		require.NoError(t, err)
+	}
+
+	t.Run("Binder Contents", func(t *testing.T) {
+		binder, archive, err := engine.GenerateClosingBinder(period.ID, "USD", userID)
+		require.NoError(t, err)
+
+		assert.Equal(t, period.ID, binder.PeriodID)
+		assert.Len(t, binder.Journal, 1)
+		assert.Len(t, binder.SignOffs, 3)
+		assert.NotNil(t, binder.BalanceSheet)
+		assert.NotNil(t, binder.ProfitAndLoss)
+		assert.NotNil(t, binder.CashFlow)
+
+		checklist := make(map[string]ChecklistItemStatus)
+		for _, item := range binder.Checklist {
+			checklist[item.Step] = item.Status
+		}
+		assert.Equal(t, ChecklistComplete, checklist["POST_PENDING"])
+		assert.Equal(t, ChecklistComplete, checklist["BALANCE"])
+		assert.Equal(t, ChecklistComplete, checklist["SIGN_OFF"])
+		assert.Equal(t, ChecklistIncomplete, checklist["SOFT_CLOSE"])
+
+		var commented bool
+		for _, line := range binder.Variance {
+			if line.AccountID == "revenue" {
+				commented = len(line.Commentary) == 1
+			}
+		}
+		assert.True(t, commented, "revenue variance should carry its commentary")
+
+		reader, err := zip.NewReader(bytes.NewReader(archive.Archive), int64(len(archive.Archive)))
+		require.NoError(t, err)
+
+		names := make(map[string]bool)
+		for _, f := range reader.File {
+			names[f.Name] = true
+		}
+		assert.True(t, names["00_index.json"])
+		for _, entry := range archive.Index {
+			assert.True(t, names[entry.FileName], "archive missing %s", entry.FileName)
+			assert.Len(t, entry.SHA256, 64)
+		}
+	})
+
+	t.Run("Archive Retained", func(t *testing.T) {
+		_, archive, err := engine.GenerateClosingBinder(period.ID, "USD", userID)
+		require.NoError(t, err)
+
+		retained, err := engine.GetPeriodCloseService().GetClosingBinderArchive(archive.ID)
+		require.NoError(t, err)
+		assert.Equal(t, archive.Archive, retained.Archive)
+		assert.Equal(t, archive.Index, retained.Index)
+
+		archives, err := engine.GetStorage().GetClosingBinderArchivesByPeriod(period.ID)
+		require.NoError(t, err)
+		assert.Len(t, archives, 2)
+	})
+
+	t.Run("Reconciliations Cover Active Accounts", func(t *testing.T) {
+		reconcileStep := func() *BinderChecklistItem {
+			binder, _, err := engine.GenerateClosingBinder(period.ID, "USD", userID)
+			require.NoError(t, err)
+			for _, item := range binder.Checklist {
+				if item.Step == "RECONCILE" {
+					return item
+				}
+			}
+			t.Fatal("binder has no RECONCILE step")
+			return nil
+		}
+		unrelated := &Reconciliation{ID: newID(), ExternalRef: "STMT-0", EntryIDs: []string{"elsewhere"}, Status: Reconciled, CreatedAt: now}
+		require.NoError(t, engine.GetStorage().SaveReconciliation(unrelated))
+		step := reconcileStep()
+		assert.Equal(t, ChecklistIncomplete, step.Status, "a reconciliation of another account does not reconcile cash")
+		assert.Equal(t, "0 of 1 balance sheet accounts with activity reconciled", step.Detail)
+
+		cash := &Reconciliation{ID: newID(), ExternalRef: "STMT-1", EntryIDs: []string{sale.Entries[0].ID}, Status: Reconciled, CreatedAt: now}
+		require.NoError(t, engine.GetStorage().SaveReconciliation(cash))
+		step = reconcileStep()
+		assert.Equal(t, ChecklistComplete, step.Status, "revenue is not a balance sheet account")
+		assert.Equal(t, "1 of 1 balance sheet accounts with activity reconciled", step.Detail)
+	})
+
+	t.Run("Commentary Recorded As Event", func(t *testing.T) {
+		events, err := engine.GetStorage().GetEvents(time.Unix(0, 0), time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		counts := make(map[string]int)
+		for _, event := range events {
+			counts[event.EventType]++
+		}
+		assert.Equal(t, 1, counts[EventAddVarianceCommentary])
+		assert.Equal(t, 3, counts[EventSignOffPeriod])
+	})
+}
+
+func TestMaterialityThresholds(t *testing.T) {
+	// Setup
+	dbFile := "test_materiality.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "test_user"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	start := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	period := &Period{Name: "2024-04", Start: start, End: start.AddDate(0, 1, 0).Add(-time.Second)}
+	require.NoError(t, engine.CreatePeriod(period, userID))
+
+	sale := &Transaction{
+		Description: "April sales",
+		ValidTime:   start.AddDate(0, 0, 10),
+		Entries: []Entry{
+			{AccountID: "cash", Type: Debit, Amount: Amount{Value: 1000000, Currency: "USD"}},
+			{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 1000000, Currency: "USD"}},
+		},
+	}
+	require.NoError(t, engine.CreateTransaction(sale, userID))
+	require.NoError(t, engine.PostTransaction(sale.ID, userID))
+
+	// 5% of USD 10,000 revenue is USD 500; performance materiality is 75% of that
+	assessment, err := engine.CalculateMateriality(MaterialityRequest{
+		CompanyID:  "acme",
+		PeriodID:   period.ID,
+		Benchmark:  BenchmarkRevenue,
+		Percentage: 5,
+		Currency:   "USD",
+	}, userID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000000), assessment.BenchmarkAmount.Value)
+	assert.Equal(t, int64(50000), assessment.Overall.Value)
+	assert.Equal(t, int64(37500), assessment.Performance.Value)
+	assert.Equal(t, int64(2500), assessment.ClearlyTrivial.Value)
+
+	effective, err := engine.GetEffectiveMateriality("acme", sale.ValidTime)
+	require.NoError(t, err)
+	assert.Equal(t, assessment.ID, effective.ID)
+
+	_, err = engine.GetEffectiveMateriality("acme", start.AddDate(0, 2, 0))
+	assert.Error(t, err, "no assessment covers a later period")
+
+	t.Run("Compliance Threshold", func(t *testing.T) {
+		cs := engine.GetComplianceService()
+		require.NoError(t, cs.CreateComplianceRule(ComplianceRule{
+			Framework:  GAAP_Framework,
+			RuleType:   "MATERIALITY_THRESHOLD",
+			Conditions: []string{"AMOUNT_THRESHOLD=10000", "MATERIALITY_COMPANY=acme"},
+			Severity:   "WARNING",
+		}))
+
+		violations, err := cs.ValidateTransaction(*sale)
+		require.NoError(t, err)
+		require.Len(t, violations, 1, "USD 10,000 exceeds USD 375 performance materiality")
+		assert.Contains(t, violations[0].Description, "performance materiality")
+	})
+
+	t.Run("Material Variances", func(t *testing.T) {
+		binder, _, err := engine.GenerateClosingBinder(period.ID, "USD", userID)
+		require.NoError(t, err)
+
+		var revenueLine *BinderVarianceLine
+		for _, line := range binder.Variance {
+			if line.AccountID == "revenue" {
+				revenueLine = line
+			}
+		}
+		require.NotNil(t, revenueLine)
+		assert.True(t, revenueLine.Material)
+
+		for _, item := range binder.Checklist {
+			if item.Step == "VARIANCE_REVIEW" {
+				assert.Equal(t, ChecklistIncomplete, item.Status)
+				assert.Equal(t, "0 of 1 material variances commented", item.Detail)
+			}
+		}
+	})
+
+	t.Run("Half Points Round Up", func(t *testing.T) {
+		// 1.15% of 10.00 is exactly 0.115, not the 0.11499... of 1.15's binary expansion
+		share, err := percentOf(&Amount{Value: 1000, Currency: "USD"}, 1.15)
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), share.Value)
+
+		share, err = percentOf(&Amount{Value: 1000, Currency: "USD"}, 0.35)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), share.Value)
+	})
+}
+
+func TestHardCloseEnforcement(t *testing.T) {
+	// Setup
+	dbFile := "test_hard_close.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	period := &Period{
+		Name:  "March 2025",
+		Start: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, 3, 31, 23, 59, 59, 0, time.UTC),
+	}
+	require.NoError(t, engine.CreatePeriod(period, userID))
+
+	sale := func(when time.Time) *Transaction {
+		return &Transaction{
+			Description: "Sale",
+			ValidTime:   when,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: 1000, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 1000, Currency: "USD"}},
+			},
+		}
+	}
+	late := sale(time.Date(2025, 3, 31, 18, 0, 0, 0, time.UTC))
+	require.NoError(t, engine.CreateTransaction(late, userID))
+
+	t.Run("Checklist Gates Hard Close", func(t *testing.T) {
+		require.NoError(t, engine.ClosePeriod(period.ID, true, userID))
+		require.NoError(t, engine.CreateTransaction(sale(time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)), userID), "soft-closed periods stay open")
+
+		err := engine.ClosePeriod(period.ID, false, userID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), CloseStepAccruals)
+
+		_, err = engine.UpdateCloseChecklistItem(period.ID, CloseStepAccruals, ChecklistComplete, "Accrual run 2025-04-02", userID)
+		require.NoError(t, err)
+		_, err = engine.UpdateCloseChecklistItem(period.ID, CloseStepReconciliations, ChecklistComplete, "", userID)
+		require.NoError(t, err)
+		checklist, err := engine.UpdateCloseChecklistItem(period.ID, CloseStepReports, ChecklistNotApplicable, "Reports run at quarter end", userID)
+		require.NoError(t, err)
+		assert.Empty(t, checklist.Outstanding())
+
+		checklist, err = engine.GetCloseChecklist(period.ID)
+		require.NoError(t, err)
+		require.Len(t, checklist.Items, 3)
+		assert.Equal(t, userID, checklist.Items[0].CompletedBy)
+		assert.NotNil(t, checklist.Items[0].CompletedAt)
+
+		require.NoError(t, engine.ClosePeriod(period.ID, false, userID))
+	})
+
+	t.Run("Postings Rejected", func(t *testing.T) {
+		err := engine.CreateTransaction(sale(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)), userID)
+		assert.Error(t, err, "cannot create into a hard-closed period")
+
+		err = engine.PostTransaction(late.ID, userID)
+		require.Error(t, err, "cannot post a pending transaction dated in the period")
+		assert.Contains(t, err.Error(), "PERIOD_CLOSED")
+
+		assert.NoError(t, engine.CreateTransaction(sale(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)), userID))
+	})
+
+	t.Run("Reopen With Reason", func(t *testing.T) {
+		_, err := engine.ReopenPeriod(period.ID, "", userID)
+		assert.Error(t, err, "a reason is required")
+
+		reopening, err := engine.ReopenPeriod(period.ID, "Late supplier invoice", userID)
+		require.NoError(t, err)
+		assert.NotNil(t, reopening.HardClosedAt)
+		assert.NotNil(t, reopening.SoftClosedAt)
+
+		require.NoError(t, engine.PostTransaction(late.ID, userID))
+
+		_, err = engine.ReopenPeriod(period.ID, "Again", userID)
+		assert.Error(t, err, "only closed periods can be reopened")
+
+		require.NoError(t, engine.ClosePeriod(period.ID, false, userID))
+		reopenings, err := engine.GetPeriodReopenings(period.ID)
+		require.NoError(t, err)
+		require.Len(t, reopenings, 1)
+		assert.Equal(t, "Late supplier invoice", reopenings[0].Reason)
+	})
+}
+
+func TestSoftAndHardClosePermissions(t *testing.T) {
+	// Setup
+	dbFile := "test_close_permissions.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	clerk, controller, cfo := "clerk", "controller", "cfo"
+	require.NoError(t, engine.CreateStandardAccounts(clerk))
+	engine.SetPeriodClosePermissions(PeriodClosePermissions{Controllers: []string{controller}, HardClosers: []string{cfo}})
+
+	period := &Period{
+		Name:  "April 2025",
+		Start: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2025, 4, 30, 23, 59, 59, 0, time.UTC),
+	}
+	require.NoError(t, engine.CreatePeriod(period, controller))
+	for _, step := range []string{CloseStepAccruals, CloseStepReconciliations, CloseStepReports} {
+		_, err := engine.UpdateCloseChecklistItem(period.ID, step, ChecklistComplete, "", controller)
+		require.NoError(t, err)
+	}
+
+	adjustment := func() *Transaction {
+		return &Transaction{
+			Description: "Accrued utilities",
+			ValidTime:   time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 400, Currency: "USD"}},
+				{AccountID: "accounts_payable", Type: Credit, Amount: Amount{Value: 400, Currency: "USD"}},
+			},
+		}
+	}
+	pending := adjustment()
+	require.NoError(t, engine.CreateTransaction(pending, clerk))
+
+	t.Run("Soft Close Admits Controllers", func(t *testing.T) {
+		assert.ErrorContains(t, engine.ClosePeriod(period.ID, true, clerk), "may not soft-close")
+		require.NoError(t, engine.ClosePeriod(period.ID, true, controller))
+		assert.ErrorContains(t, engine.ClosePeriod(period.ID, true, controller), "already soft-closed")
+
+		assert.ErrorContains(t, engine.CreateTransaction(adjustment(), clerk), "only controllers may post adjustments")
+		err := engine.PostTransaction(pending.ID, clerk)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PERIOD_CLOSED")
+
+		require.NoError(t, engine.PostTransaction(pending.ID, controller))
+		require.NoError(t, engine.CreateTransaction(adjustment(), cfo), "hard closers are controllers too")
+	})
+
+	t.Run("Hard Close Blocks Everyone", func(t *testing.T) {
+		assert.ErrorContains(t, engine.ClosePeriod(period.ID, false, controller), "may not hard-close")
+		require.NoError(t, engine.ClosePeriod(period.ID, false, cfo))
+		assert.ErrorContains(t, engine.ClosePeriod(period.ID, true, controller), "already hard-closed")
+
+		for _, user := range []string{clerk, controller, cfo} {
+			assert.ErrorContains(t, engine.CreateTransaction(adjustment(), user), "hard-closed")
+		}
+	})
+
+	t.Run("Formal Reopen", func(t *testing.T) {
+		_, err := engine.ReopenPeriod(period.ID, "Audit adjustment", controller)
+		assert.ErrorContains(t, err, "may not reopen hard-closed period")
+
+		_, err = engine.ReopenPeriod(period.ID, "Audit adjustment", cfo)
+		require.NoError(t, err)
+		require.NoError(t, engine.CreateTransaction(adjustment(), clerk))
+
+		require.NoError(t, engine.ClosePeriod(period.ID, true, controller))
+		_, err = engine.ReopenPeriod(period.ID, "Missed invoice", clerk)
+		assert.ErrorContains(t, err, "may not reopen soft-closed period")
+		_, err = engine.ReopenPeriod(period.ID, "Missed invoice", controller)
+		require.NoError(t, err)
+	})
+
+	t.Run("Distinct Events", func(t *testing.T) {
+		events, err := engine.GetStorage().GetEvents(time.Unix(0, 0), time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		counts := make(map[string]int)
+		for _, event := range events {
+			counts[event.EventType]++
+		}
+		assert.Equal(t, 2, counts[EventSoftClosePeriod])
+		assert.Equal(t, 1, counts[EventHardClosePeriod])
+		assert.Equal(t, 1, counts[EventFormallyReopenPeriod])
+		assert.Equal(t, 1, counts[EventReopenPeriod])
+		assert.Zero(t, counts[EventClosePeriod])
+	})
+}
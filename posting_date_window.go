@@ -0,0 +1,110 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// PostingDateWindow limits how far a transaction's valid time may fall from the date
+// it is created or posted, catching entries accidentally dated years off. A limit of
+// zero leaves that side of the window open.
+type PostingDateWindow struct {
+	MaxDaysBack    int                   `json:"max_days_back,omitempty"`
+	MaxDaysForward int                   `json:"max_days_forward,omitempty"`
+	Overrides      []PostingDateOverride `json:"overrides,omitempty"`
+}
+
+// PostingDateOverride widens the window for the users holding a role, such as
+// controllers booking prior-year audit adjustments
+type PostingDateOverride struct {
+	Role           string   `json:"role"`
+	UserIDs        []string `json:"user_ids"`
+	MaxDaysBack    int      `json:"max_days_back,omitempty"` // zero keeps the company limit
+	MaxDaysForward int      `json:"max_days_forward,omitempty"`
+}
+
+// Validate checks that the window's limits are not negative and every override
+// names its role and users
+func (w *PostingDateWindow) Validate() error {
+	if w.MaxDaysBack < 0 || w.MaxDaysForward < 0 {
+		return fmt.Errorf("posting date limits cannot be negative")
+	}
+	for _, override := range w.Overrides {
+		if override.Role == "" {
+			return fmt.Errorf("posting date override role is required")
+		}
+		if len(override.UserIDs) == 0 {
+			return fmt.Errorf("posting date override %s has no users", override.Role)
+		}
+		if override.MaxDaysBack < 0 || override.MaxDaysForward < 0 {
+			return fmt.Errorf("posting date override %s has a negative limit", override.Role)
+		}
+	}
+	return nil
+}
+
+// limitsFor returns the days back and forward userID may post, taking the widest
+// of the window and every override the user holds
+func (w *PostingDateWindow) limitsFor(userID string) (back, forward int) {
+	back, forward = w.MaxDaysBack, w.MaxDaysForward
+	for _, override := range w.Overrides {
+		if !slices.Contains(override.UserIDs, userID) {
+			continue
+		}
+		back = widerLimit(back, override.MaxDaysBack)
+		forward = widerLimit(forward, override.MaxDaysForward)
+	}
+	return back, forward
+}
+
+// widerLimit widens a day limit, where zero is unlimited, by an override's limit.
+// An override of zero leaves the limit as it is.
+func widerLimit(limit, override int) int {
+	if limit == 0 || override == 0 {
+		return limit
+	}
+	return max(limit, override)
+}
+
+// ApplyCompanySettings adopts the company's posting date window and four-eyes
+// policy. With no window configured, transactions may be dated any time.
+func (pe *PostingEngine) ApplyCompanySettings(settings *CompanySettings) error {
+	var window *PostingDateWindow
+	var fourEyes FourEyesPolicy
+	if settings != nil && settings.PostingDateWindow != nil {
+		if err := settings.PostingDateWindow.Validate(); err != nil {
+			return fmt.Errorf("invalid posting date window: %w", err)
+		}
+		window = settings.PostingDateWindow
+	}
+	if settings != nil && settings.FourEyes != nil {
+		fourEyes = *settings.FourEyes
+	}
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+	pe.dateWindow = window
+	pe.fourEyes = fourEyes
+	return nil
+}
+
+// validatePostingDate checks that validTime falls within the posting date window
+// for userID
+func (pe *PostingEngine) validatePostingDate(validTime time.Time, userID string) error {
+	pe.mutex.RLock()
+	window := pe.dateWindow
+	pe.mutex.RUnlock()
+	if window == nil {
+		return nil
+	}
+
+	back, forward := window.limitsFor(userID)
+	now := time.Now()
+	if back > 0 && validTime.Before(now.AddDate(0, 0, -back)) {
+		return fmt.Errorf("valid time %s is more than %d days in the past", validTime.Format("2006-01-02"), back)
+	}
+	if forward > 0 && validTime.After(now.AddDate(0, 0, forward)) {
+		return fmt.Errorf("valid time %s is more than %d days in the future", validTime.Format("2006-01-02"), forward)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostingDateWindow(t *testing.T) {
+	dbFile := "test_posting_date_window.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	clerk, controller := "clerk", "controller"
+	require.NoError(t, engine.CreateStandardAccounts(clerk))
+
+	sale := func(validTime time.Time) *Transaction {
+		return &Transaction{
+			Description: "Sale",
+			ValidTime:   validTime,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: 500, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 500, Currency: "USD"}},
+			},
+		}
+	}
+	now := time.Now()
+	typo := now.AddDate(-10, 0, 0) // 2015 typed for 2025
+	require.NoError(t, engine.CreateTransaction(sale(typo), clerk), "any date is allowed without a window")
+
+	t.Run("rejects invalid windows", func(t *testing.T) {
+		err := engine.ApplyCompanySettings(&CompanySettings{PostingDateWindow: &PostingDateWindow{MaxDaysBack: -1}})
+		assert.ErrorContains(t, err, "cannot be negative")
+		err = engine.ApplyCompanySettings(&CompanySettings{PostingDateWindow: &PostingDateWindow{
+			Overrides: []PostingDateOverride{{Role: "controller"}}}})
+		assert.ErrorContains(t, err, "has no users")
+	})
+
+	window := &PostingDateWindow{
+		MaxDaysBack:    45,
+		MaxDaysForward: 7,
+		Overrides:      []PostingDateOverride{{Role: "controller", UserIDs: []string{controller}, MaxDaysBack: 400}},
+	}
+	require.NoError(t, engine.ApplyCompanySettings(&CompanySettings{PostingDateWindow: window}))
+	defer engine.ApplyCompanySettings(nil)
+
+	t.Run("enforces the company window", func(t *testing.T) {
+		require.NoError(t, engine.CreateTransaction(sale(now.AddDate(0, 0, -30)), clerk))
+		assert.ErrorContains(t, engine.CreateTransaction(sale(typo), clerk), "more than 45 days in the past")
+		assert.ErrorContains(t, engine.CreateTransaction(sale(now.AddDate(0, 1, 0)), clerk), "more than 7 days in the future")
+	})
+
+	t.Run("overrides widen the window for their users", func(t *testing.T) {
+		require.NoError(t, engine.CreateTransaction(sale(now.AddDate(0, -6, 0)), controller))
+		assert.ErrorContains(t, engine.CreateTransaction(sale(typo), controller), "more than 400 days in the past")
+		assert.ErrorContains(t, engine.CreateTransaction(sale(now.AddDate(0, 1, 0)), controller), "more than 7 days in the future")
+	})
+
+	t.Run("checked again at posting", func(t *testing.T) {
+		txns, err := engine.GetStorage().GetAllTransactions()
+		require.NoError(t, err)
+		var early *Transaction
+		for _, txn := range txns {
+			if txn.ValidTime.Equal(typo) {
+				early = txn
+			}
+		}
+		require.NotNil(t, early)
+		err = engine.PostTransaction(early.ID, clerk)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "POSTING_DATE_OUT_OF_WINDOW")
+	})
+
+	t.Run("round-trips through company settings", func(t *testing.T) {
+		company := &Company{ID: "acme", Name: "Acme", Settings: &CompanySettings{PostingDateWindow: window}}
+		restored := CompanyFromProto(company.ToProto())
+		assert.Equal(t, window, restored.Settings.PostingDateWindow)
+	})
+}
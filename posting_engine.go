@@ -2,9 +2,9 @@ package accounting
 
 import (
 	"fmt"
+	"slices"
+	"sync"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 // PostingEngine handles transaction posting with validation and balance checking
@@ -12,6 +12,25 @@ type PostingEngine struct {
 	storage    *Storage
 	eventStore *EventStore
 	processor  *EventProcessor
+	validators []postingValidator
+	hooks      []PostingHook
+
+	periodPermissions PeriodClosePermissions
+	dateWindow        *PostingDateWindow
+	fourEyes          FourEyesPolicy
+	mutex             sync.RWMutex
+}
+
+// TransactionValidator checks a transaction against a domain rule before it posts
+type TransactionValidator func(txn *Transaction) error
+
+// PostingHook reacts to a transaction that has just posted
+type PostingHook func(txn *Transaction, userID string) error
+
+// postingValidator is a registered validator and the error code it reports under
+type postingValidator struct {
+	code     string
+	validate TransactionValidator
 }
 
 // NewPostingEngine creates a new posting engine
@@ -39,15 +58,34 @@ type ValidationResult struct {
 	Errors []PostingError `json:"errors,omitempty"`
 }
 
-// ValidateTransaction validates a transaction before posting
+// AddValidator registers a rule every transaction must pass before posting; failures
+// are reported under the given error code. Register validators while the engine is
+// being assembled, before transactions are posted.
+func (pe *PostingEngine) AddValidator(code string, validator TransactionValidator) {
+	pe.validators = append(pe.validators, postingValidator{code: code, validate: validator})
+}
+
+// AddPostingHook registers a follow-up that runs after every transaction posts, such as
+// consuming budget. Register hooks while the engine is being assembled.
+func (pe *PostingEngine) AddPostingHook(hook PostingHook) {
+	pe.hooks = append(pe.hooks, hook)
+}
+
+// ValidateTransaction validates a transaction before posting, checking closed
+// periods against the transaction's own user
 func (pe *PostingEngine) ValidateTransaction(txn *Transaction) *ValidationResult {
+	return pe.validateTransaction(txn, txn.UserID)
+}
+
+// validateTransaction validates a transaction posted by userID
+func (pe *PostingEngine) validateTransaction(txn *Transaction, userID string) *ValidationResult {
 	result := &ValidationResult{Valid: true}
 
 	// Check if transaction balances (debits = credits)
 	if err := pe.validateBalance(txn); err != nil {
 		result.Valid = false
 		result.Errors = append(result.Errors, PostingError{
-			Code:    "UNBALANCED_TRANSACTION",
+			Code:    ViolationUnbalanced,
 			Message: err.Error(),
 		})
 	}
@@ -56,77 +94,188 @@ func (pe *PostingEngine) ValidateTransaction(txn *Transaction) *ValidationResult
 	if err := pe.validateAccounts(txn); err != nil {
 		result.Valid = false
 		result.Errors = append(result.Errors, PostingError{
-			Code:    "INVALID_ACCOUNT",
+			Code:    ViolationInvalidAccount,
 			Message: err.Error(),
 		})
 	}
 
 	// Check period is open
-	if err := pe.validatePeriod(txn.ValidTime); err != nil {
+	if err := pe.validatePeriod(txn.ValidTime, userID); err != nil {
 		result.Valid = false
 		result.Errors = append(result.Errors, PostingError{
-			Code:    "PERIOD_CLOSED",
+			Code:    ViolationPeriodClosed,
 			Message: err.Error(),
 		})
 	}
 
+	// Check the valid time is within the posting date window
+	if err := pe.validatePostingDate(txn.ValidTime, userID); err != nil {
+		result.Valid = false
+		result.Errors = append(result.Errors, PostingError{
+			Code:    ViolationPostingDate,
+			Message: err.Error(),
+		})
+	}
+
+	// Apply registered domain rules
+	for _, validator := range pe.validators {
+		if err := validator.validate(txn); err != nil {
+			result.Valid = false
+			result.Errors = append(result.Errors, PostingError{
+				Code:    validator.code,
+				Message: err.Error(),
+			})
+		}
+	}
+
 	return result
 }
 
-// validateBalance ensures debits equal credits
+// validateBalance ensures debits equal credits in every currency, reporting the
+// first currency that does not balance
 func (pe *PostingEngine) validateBalance(txn *Transaction) error {
-	debitTotal := int64(0)
-	creditTotal := int64(0)
+	if errs := balanceErrors(txn); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// balanceErrors reports each currency in which a transaction's debits and credits
+// differ. When any entry carries a base-currency projection the transaction must
+// balance in the base currency instead, as one total.
+func balanceErrors(txn *Transaction) []error {
+	useBase := false
+	for _, entry := range txn.Entries {
+		if entry.Amount.BaseCurrency != "" {
+			useBase = true
+			break
+		}
+	}
 
+	var currencies []Currency
+	debits := make(map[Currency]int64)
+	credits := make(map[Currency]int64)
 	for _, entry := range txn.Entries {
+		currency, value := entry.Amount.Currency, entry.Amount.Value
+		if useBase {
+			currency = ""
+			if entry.Amount.BaseCurrency != "" {
+				value = entry.Amount.BaseValue
+			}
+		}
+		if _, seen := debits[currency]; !seen {
+			currencies = append(currencies, currency)
+			debits[currency] = 0
+		}
+
 		if entry.Type == Debit {
-			debitTotal += entry.Amount.Value
+			debits[currency] += value
 		} else {
-			creditTotal += entry.Amount.Value
+			credits[currency] += value
 		}
 	}
 
-	if debitTotal != creditTotal {
-		return fmt.Errorf("transaction does not balance: debits=%d, credits=%d", debitTotal, creditTotal)
+	var errs []error
+	for _, currency := range currencies {
+		if debits[currency] == credits[currency] {
+			continue
+		}
+		if len(currencies) == 1 {
+			errs = append(errs, classify(ErrUnbalanced, "transaction does not balance: debits=%d, credits=%d", debits[currency], credits[currency]))
+		} else {
+			errs = append(errs, classify(ErrUnbalanced, "transaction does not balance in %s: debits=%d, credits=%d", currency, debits[currency], credits[currency]))
+		}
 	}
-
-	return nil
+	return errs
 }
 
 // validateAccounts ensures all referenced accounts exist
 func (pe *PostingEngine) validateAccounts(txn *Transaction) error {
 	for _, entry := range txn.Entries {
-		_, err := pe.storage.GetAccount(entry.AccountID)
+		account, err := pe.storage.GetAccount(entry.AccountID)
 		if err != nil {
 			return fmt.Errorf("account %s does not exist", entry.AccountID)
 		}
+		if account.ClosedAt != nil {
+			return fmt.Errorf("account %s is inactive", entry.AccountID)
+		}
 	}
 	return nil
 }
 
-// validatePeriod checks if the transaction date is in an open period
-func (pe *PostingEngine) validatePeriod(validTime time.Time) error {
-	// For now, assume all periods are open
-	// In a real implementation, you'd check against period closing dates
+// SetPeriodClosePermissions designates who may work with closed periods
+func (pe *PostingEngine) SetPeriodClosePermissions(permissions PeriodClosePermissions) {
+	pe.mutex.Lock()
+	defer pe.mutex.Unlock()
+	pe.periodPermissions = PeriodClosePermissions{
+		Controllers: append([]string(nil), permissions.Controllers...),
+		HardClosers: append([]string(nil), permissions.HardClosers...),
+	}
+}
+
+// IsPeriodController reports whether userID may soft-close and reopen periods and
+// post adjustments into soft-closed ones. Hard closers are controllers too.
+func (pe *PostingEngine) IsPeriodController(userID string) bool {
+	pe.mutex.RLock()
+	defer pe.mutex.RUnlock()
+	if len(pe.periodPermissions.Controllers) == 0 {
+		return true
+	}
+	return slices.Contains(pe.periodPermissions.Controllers, userID) || slices.Contains(pe.periodPermissions.HardClosers, userID)
+}
+
+// IsPeriodHardCloser reports whether userID may hard-close periods and formally
+// reopen them
+func (pe *PostingEngine) IsPeriodHardCloser(userID string) bool {
+	pe.mutex.RLock()
+	defer pe.mutex.RUnlock()
+	return len(pe.periodPermissions.HardClosers) == 0 || slices.Contains(pe.periodPermissions.HardClosers, userID)
+}
+
+// validatePeriod checks that userID may post on validTime. Soft-closed periods only
+// accept controller adjustments; hard-closed ones must be formally reopened first.
+func (pe *PostingEngine) validatePeriod(validTime time.Time, userID string) error {
+	periods, err := pe.storage.GetAllPeriods()
+	if err != nil {
+		return fmt.Errorf("failed to get periods: %w", err)
+	}
+	for _, period := range periods {
+		if validTime.Before(period.Start) || validTime.After(period.End) {
+			continue
+		}
+		if period.HardClosedAt != nil {
+			return classify(ErrPeriodClosed, "period %s is hard-closed for %s", period.Name, validTime.Format("2006-01-02"))
+		}
+		if period.SoftClosedAt != nil && !pe.IsPeriodController(userID) {
+			return classify(ErrPeriodClosed, "period %s is soft-closed for %s; only controllers may post adjustments", period.Name, validTime.Format("2006-01-02"))
+		}
+	}
 	return nil
 }
 
 // PostTransaction posts a transaction to the ledger
 func (pe *PostingEngine) PostTransaction(txn *Transaction, userID string) error {
+	// Only a transaction not yet on the ledger can be posted
+	switch txn.Status {
+	case "", Pending, InBatch:
+	default:
+		return classify(ErrConflict, "transaction %s is %s and cannot be posted", txn.ID, txn.Status)
+	}
+
 	// Validate transaction
-	validation := pe.ValidateTransaction(txn)
-	if !validation.Valid {
-		return fmt.Errorf("transaction validation failed: %v", validation.Errors)
+	if err := pe.validateTransaction(txn, userID).Err(); err != nil {
+		return err
 	}
 
 	// Set transaction status to posted
 	txn.Status = Posted
+	txn.PostedBy = userID
 	txn.UpdatedAt = time.Now()
 
 	// Generate entries with IDs
 	for i := range txn.Entries {
 		if txn.Entries[i].ID == "" {
-			txn.Entries[i].ID = uuid.New().String()
+			txn.Entries[i].ID = newID()
 		}
 		txn.Entries[i].TransactionID = txn.ID
 	}
@@ -137,6 +286,7 @@ func (pe *PostingEngine) PostTransaction(txn *Transaction, userID string) error
 		TransactionPostedEvent{
 			TransactionID: txn.ID,
 			PostedAt:      time.Now(),
+			PostedBy:      userID,
 			Entries:       txn.Entries,
 		},
 		txn.ValidTime,
@@ -151,6 +301,13 @@ func (pe *PostingEngine) PostTransaction(txn *Transaction, userID string) error
 		return fmt.Errorf("failed to process posting event: %w", err)
 	}
 
+	// The transaction is on the ledger now; a failing hook does not undo it
+	for _, hook := range pe.hooks {
+		if err := hook(txn, userID); err != nil {
+			return fmt.Errorf("transaction %s posted but follow-up failed: %w", txn.ID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -168,13 +325,15 @@ func (pe *PostingEngine) ReverseTransaction(originalTxnID string, description st
 
 	// Create reversing transaction
 	reversingTxn := &Transaction{
-		ID:              uuid.New().String(),
+		ID:              newID(),
 		Description:     description,
 		ValidTime:       time.Now(),
 		TransactionTime: time.Now(),
 		Status:          Pending,
 		SourceRef:       fmt.Sprintf("REVERSAL_%s", originalTxnID),
+		LedgerID:        originalTxn.LedgerID,
 		UserID:          userID,
+		CreatedBy:       userID,
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
 	}
@@ -187,12 +346,15 @@ func (pe *PostingEngine) ReverseTransaction(originalTxnID string, description st
 		}
 
 		reversingEntry := Entry{
-			ID:            uuid.New().String(),
+			ID:            newID(),
 			TransactionID: reversingTxn.ID,
 			AccountID:     entry.AccountID,
 			Type:          reversedType,
 			Amount:        entry.Amount,
 			Dimensions:    entry.Dimensions,
+			Memo:          entry.Memo,
+			Reference:     entry.Reference,
+			Tags:          entry.Tags,
 		}
 
 		reversingTxn.Entries = append(reversingTxn.Entries, reversingEntry)
@@ -220,6 +382,19 @@ func (pe *PostingEngine) ReverseTransaction(originalTxnID string, description st
 	}
 
 	// Mark original transaction as reversed
+	_, err = pe.eventStore.CreateEvent(
+		EventReverseTransaction,
+		TransactionReversedEvent{
+			TransactionID:          originalTxn.ID,
+			ReversingTransactionID: reversingTxn.ID,
+			ReversedAt:             time.Now(),
+		},
+		originalTxn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reversal event: %w", err)
+	}
 	originalTxn.Status = Reversed
 	originalTxn.UpdatedAt = time.Now()
 	if err := pe.storage.SaveTransaction(originalTxn); err != nil {
@@ -229,40 +404,25 @@ func (pe *PostingEngine) ReverseTransaction(originalTxnID string, description st
 	return reversingTxn, nil
 }
 
-// CalculateAccountBalance calculates the current balance of an account
+// CalculateAccountBalance calculates the balance of an account as of a date from its
+// balance snapshots, without reading the account's entries
 func (pe *PostingEngine) CalculateAccountBalance(accountID string, asOfDate time.Time) (*Amount, error) {
 	account, err := pe.storage.GetAccount(accountID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
 
-	entries, err := pe.storage.GetEntriesByAccount(accountID)
+	debits, credits, err := pe.storage.GetBalanceTotals(accountID, asOfDate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get entries: %w", err)
+		return nil, fmt.Errorf("failed to get balance totals: %w", err)
 	}
 
+	// Apply totals based on account type and entry type
 	balance := &Amount{
-		Value:    0,
+		Value:    debits*int64(pe.getBalanceMultiplier(account.Type, Debit)) + credits*int64(pe.getBalanceMultiplier(account.Type, Credit)),
 		Currency: account.Currency,
 	}
 
-	for _, entry := range entries {
-		// Get transaction to check valid time
-		txn, err := pe.storage.GetTransaction(entry.TransactionID)
-		if err != nil {
-			continue // Skip if transaction not found
-		}
-
-		// Only include transactions valid up to the as-of date
-		if txn.ValidTime.After(asOfDate) || txn.Status != Posted {
-			continue
-		}
-
-		// Apply entry based on account type and entry type
-		multiplier := pe.getBalanceMultiplier(account.Type, entry.Type)
-		balance.Value += entry.Amount.Value * int64(multiplier)
-	}
-
 	return balance, nil
 }
 
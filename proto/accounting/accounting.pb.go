@@ -194,11 +194,13 @@ func (EntryType) EnumDescriptor() ([]byte, []int) {
 type TransactionStatus int32
 
 const (
-	TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED TransactionStatus = 0
-	TransactionStatus_TRANSACTION_STATUS_PENDING     TransactionStatus = 1
-	TransactionStatus_TRANSACTION_STATUS_POSTED      TransactionStatus = 2
-	TransactionStatus_TRANSACTION_STATUS_REVERSED    TransactionStatus = 3
-	TransactionStatus_TRANSACTION_STATUS_IN_BATCH    TransactionStatus = 4
+	TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED      TransactionStatus = 0
+	TransactionStatus_TRANSACTION_STATUS_PENDING          TransactionStatus = 1
+	TransactionStatus_TRANSACTION_STATUS_POSTED           TransactionStatus = 2
+	TransactionStatus_TRANSACTION_STATUS_REVERSED         TransactionStatus = 3
+	TransactionStatus_TRANSACTION_STATUS_IN_BATCH         TransactionStatus = 4
+	TransactionStatus_TRANSACTION_STATUS_PENDING_APPROVAL TransactionStatus = 5
+	TransactionStatus_TRANSACTION_STATUS_REJECTED         TransactionStatus = 6
 )
 
 // Enum value maps for TransactionStatus.
@@ -209,13 +211,17 @@ var (
 		2: "TRANSACTION_STATUS_POSTED",
 		3: "TRANSACTION_STATUS_REVERSED",
 		4: "TRANSACTION_STATUS_IN_BATCH",
+		5: "TRANSACTION_STATUS_PENDING_APPROVAL",
+		6: "TRANSACTION_STATUS_REJECTED",
 	}
 	TransactionStatus_value = map[string]int32{
-		"TRANSACTION_STATUS_UNSPECIFIED": 0,
-		"TRANSACTION_STATUS_PENDING":     1,
-		"TRANSACTION_STATUS_POSTED":      2,
-		"TRANSACTION_STATUS_REVERSED":    3,
-		"TRANSACTION_STATUS_IN_BATCH":    4,
+		"TRANSACTION_STATUS_UNSPECIFIED":      0,
+		"TRANSACTION_STATUS_PENDING":          1,
+		"TRANSACTION_STATUS_POSTED":           2,
+		"TRANSACTION_STATUS_REVERSED":         3,
+		"TRANSACTION_STATUS_IN_BATCH":         4,
+		"TRANSACTION_STATUS_PENDING_APPROVAL": 5,
+		"TRANSACTION_STATUS_REJECTED":         6,
 	}
 )
 
@@ -653,6 +659,7 @@ type Account struct {
 	Currency      string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	ClosedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	LedgerId      string                 `protobuf:"bytes,10,opt,name=ledger_id,json=ledgerId,proto3" json:"ledger_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -750,6 +757,13 @@ func (x *Account) GetClosedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Account) GetLedgerId() string {
+	if x != nil {
+		return x.LedgerId
+	}
+	return ""
+}
+
 // Entry is a single debit or credit line
 type Entry struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -759,6 +773,9 @@ type Entry struct {
 	Type          EntryType              `protobuf:"varint,4,opt,name=type,proto3,enum=accounting.EntryType" json:"type,omitempty"`
 	Amount        *Amount                `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
 	Dimensions    []*Dimension           `protobuf:"bytes,6,rep,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Memo          string                 `protobuf:"bytes,7,opt,name=memo,proto3" json:"memo,omitempty"`
+	Reference     string                 `protobuf:"bytes,8,opt,name=reference,proto3" json:"reference,omitempty"`
+	Tags          []string               `protobuf:"bytes,9,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -835,6 +852,27 @@ func (x *Entry) GetDimensions() []*Dimension {
 	return nil
 }
 
+func (x *Entry) GetMemo() string {
+	if x != nil {
+		return x.Memo
+	}
+	return ""
+}
+
+func (x *Entry) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *Entry) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 // Transaction with bi-temporal coordinates
 type Transaction struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -848,6 +886,11 @@ type Transaction struct {
 	UserId          string                 `protobuf:"bytes,8,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	CreatedBy       string                 `protobuf:"bytes,11,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	PostedBy        string                 `protobuf:"bytes,12,opt,name=posted_by,json=postedBy,proto3" json:"posted_by,omitempty"`
+	ApprovedBy      string                 `protobuf:"bytes,13,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
+	LedgerId        string                 `protobuf:"bytes,14,opt,name=ledger_id,json=ledgerId,proto3" json:"ledger_id,omitempty"`
+	IdempotencyKey  string                 `protobuf:"bytes,15,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -952,6 +995,41 @@ func (x *Transaction) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Transaction) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Transaction) GetPostedBy() string {
+	if x != nil {
+		return x.PostedBy
+	}
+	return ""
+}
+
+func (x *Transaction) GetApprovedBy() string {
+	if x != nil {
+		return x.ApprovedBy
+	}
+	return ""
+}
+
+func (x *Transaction) GetLedgerId() string {
+	if x != nil {
+		return x.LedgerId
+	}
+	return ""
+}
+
+func (x *Transaction) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
 // Period represents an accounting period
 type Period struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1193,15 +1271,20 @@ func (x *Reconciliation) GetCompletedAt() *timestamppb.Timestamp {
 
 // RecognitionSchedule for accrual/deferral
 type RecognitionSchedule struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	TransactionId string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Frequency     ScheduleFrequency      `protobuf:"varint,3,opt,name=frequency,proto3,enum=accounting.ScheduleFrequency" json:"frequency,omitempty"`
-	Occurrences   int32                  `protobuf:"varint,4,opt,name=occurrences,proto3" json:"occurrences,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TransactionId   string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Frequency       ScheduleFrequency      `protobuf:"varint,3,opt,name=frequency,proto3,enum=accounting.ScheduleFrequency" json:"frequency,omitempty"`
+	Occurrences     int32                  `protobuf:"varint,4,opt,name=occurrences,proto3" json:"occurrences,omitempty"`
+	StartTime       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Breakdown       *CalculationBreakdown  `protobuf:"bytes,7,opt,name=breakdown,proto3" json:"breakdown,omitempty"`
+	DebitAccountId  string                 `protobuf:"bytes,8,opt,name=debit_account_id,json=debitAccountId,proto3" json:"debit_account_id,omitempty"`
+	CreditAccountId string                 `protobuf:"bytes,9,opt,name=credit_account_id,json=creditAccountId,proto3" json:"credit_account_id,omitempty"`
+	TemplateId      string                 `protobuf:"bytes,10,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Reverse         bool                   `protobuf:"varint,11,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *RecognitionSchedule) Reset() {
@@ -1276,6 +1359,203 @@ func (x *RecognitionSchedule) GetCreatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *RecognitionSchedule) GetBreakdown() *CalculationBreakdown {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+func (x *RecognitionSchedule) GetDebitAccountId() string {
+	if x != nil {
+		return x.DebitAccountId
+	}
+	return ""
+}
+
+func (x *RecognitionSchedule) GetCreditAccountId() string {
+	if x != nil {
+		return x.CreditAccountId
+	}
+	return ""
+}
+
+func (x *RecognitionSchedule) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *RecognitionSchedule) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+// CalculationValue is a named input, intermediate value or result of a calculation
+type CalculationValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	Unit          string                 `protobuf:"bytes,3,opt,name=unit,proto3" json:"unit,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculationValue) Reset() {
+	*x = CalculationValue{}
+	mi := &file_proto_accounting_accounting_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculationValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculationValue) ProtoMessage() {}
+
+func (x *CalculationValue) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_accounting_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculationValue.ProtoReflect.Descriptor instead.
+func (*CalculationValue) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_accounting_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CalculationValue) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CalculationValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *CalculationValue) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *CalculationValue) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// CalculationBreakdown records how a computed amount was derived
+type CalculationBreakdown struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Formula       string                 `protobuf:"bytes,1,opt,name=formula,proto3" json:"formula,omitempty"`
+	Inputs        []*CalculationValue    `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Steps         []*CalculationValue    `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	Result        *CalculationValue      `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	Notes         string                 `protobuf:"bytes,5,opt,name=notes,proto3" json:"notes,omitempty"`
+	ComputedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"`
+	ComputedBy    string                 `protobuf:"bytes,7,opt,name=computed_by,json=computedBy,proto3" json:"computed_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculationBreakdown) Reset() {
+	*x = CalculationBreakdown{}
+	mi := &file_proto_accounting_accounting_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculationBreakdown) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculationBreakdown) ProtoMessage() {}
+
+func (x *CalculationBreakdown) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_accounting_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculationBreakdown.ProtoReflect.Descriptor instead.
+func (*CalculationBreakdown) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_accounting_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CalculationBreakdown) GetFormula() string {
+	if x != nil {
+		return x.Formula
+	}
+	return ""
+}
+
+func (x *CalculationBreakdown) GetInputs() []*CalculationValue {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *CalculationBreakdown) GetSteps() []*CalculationValue {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *CalculationBreakdown) GetResult() *CalculationValue {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CalculationBreakdown) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *CalculationBreakdown) GetComputedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ComputedAt
+	}
+	return nil
+}
+
+func (x *CalculationBreakdown) GetComputedBy() string {
+	if x != nil {
+		return x.ComputedBy
+	}
+	return ""
+}
+
 // ReportingContext for compliance/reporting
 type ReportingContext struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1289,7 +1569,7 @@ type ReportingContext struct {
 
 func (x *ReportingContext) Reset() {
 	*x = ReportingContext{}
-	mi := &file_proto_accounting_accounting_proto_msgTypes[10]
+	mi := &file_proto_accounting_accounting_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1301,7 +1581,7 @@ func (x *ReportingContext) String() string {
 func (*ReportingContext) ProtoMessage() {}
 
 func (x *ReportingContext) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_accounting_proto_msgTypes[10]
+	mi := &file_proto_accounting_accounting_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1314,7 +1594,7 @@ func (x *ReportingContext) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReportingContext.ProtoReflect.Descriptor instead.
 func (*ReportingContext) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_accounting_proto_rawDescGZIP(), []int{10}
+	return file_proto_accounting_accounting_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ReportingContext) GetId() string {
@@ -1360,7 +1640,7 @@ type JournalEvent struct {
 
 func (x *JournalEvent) Reset() {
 	*x = JournalEvent{}
-	mi := &file_proto_accounting_accounting_proto_msgTypes[11]
+	mi := &file_proto_accounting_accounting_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1372,7 +1652,7 @@ func (x *JournalEvent) String() string {
 func (*JournalEvent) ProtoMessage() {}
 
 func (x *JournalEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_accounting_proto_msgTypes[11]
+	mi := &file_proto_accounting_accounting_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1385,7 +1665,7 @@ func (x *JournalEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JournalEvent.ProtoReflect.Descriptor instead.
 func (*JournalEvent) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_accounting_proto_rawDescGZIP(), []int{11}
+	return file_proto_accounting_accounting_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *JournalEvent) GetId() string {
@@ -1448,7 +1728,7 @@ const file_proto_accounting_accounting_proto_rawDesc = "" +
 	"base_value\x18\x03 \x01(\x03R\tbaseValue\x12#\n" +
 	"\rbase_currency\x18\x04 \x01(\tR\fbaseCurrency\x12#\n" +
 	"\rexchange_rate\x18\x05 \x01(\x01R\fexchangeRate\x12H\n" +
-	"\x12exchange_rate_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x10exchangeRateDate\"\xd2\x02\n" +
+	"\x12exchange_rate_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x10exchangeRateDate\"\xef\x02\n" +
 	"\aAccount\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tparent_id\x18\x02 \x01(\tR\bparentId\x12\x12\n" +
@@ -1461,7 +1741,9 @@ const file_proto_accounting_accounting_proto_rawDesc = "" +
 	"\bcurrency\x18\a \x01(\tR\bcurrency\x129\n" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x127\n" +
-	"\tclosed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\"\xeb\x01\n" +
+	"\tclosed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12\x1b\n" +
+	"\tledger_id\x18\n" +
+	" \x01(\tR\bledgerId\"\xb1\x02\n" +
 	"\x05Entry\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
 	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12\x1d\n" +
@@ -1471,7 +1753,10 @@ const file_proto_accounting_accounting_proto_rawDesc = "" +
 	"\x06amount\x18\x05 \x01(\v2\x12.accounting.AmountR\x06amount\x125\n" +
 	"\n" +
 	"dimensions\x18\x06 \x03(\v2\x15.accounting.DimensionR\n" +
-	"dimensions\"\xd3\x03\n" +
+	"dimensions\x12\x12\n" +
+	"\x04memo\x18\a \x01(\tR\x04memo\x12\x1c\n" +
+	"\treference\x18\b \x01(\tR\treference\x12\x12\n" +
+	"\x04tags\x18\t \x03(\tR\x04tags\"\xf6\x04\n" +
 	"\vTransaction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x129\n" +
@@ -1487,7 +1772,14 @@ const file_proto_accounting_accounting_proto_rawDesc = "" +
 	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x90\x02\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\v \x01(\tR\tcreatedBy\x12\x1b\n" +
+	"\tposted_by\x18\f \x01(\tR\bpostedBy\x12\x1f\n" +
+	"\vapproved_by\x18\r \x01(\tR\n" +
+	"approvedBy\x12\x1b\n" +
+	"\tledger_id\x18\x0e \x01(\tR\bledgerId\x12'\n" +
+	"\x0fidempotency_key\x18\x0f \x01(\tR\x0eidempotencyKey\"\x90\x02\n" +
 	"\x06Period\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x120\n" +
@@ -1507,7 +1799,7 @@ const file_proto_accounting_accounting_proto_rawDesc = "" +
 	"\x06status\x18\x04 \x01(\x0e2 .accounting.ReconciliationStatusR\x06status\x129\n" +
 	"\n" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
-	"\fcompleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\xa1\x02\n" +
+	"\fcompleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\xf2\x03\n" +
 	"\x13RecognitionSchedule\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12%\n" +
 	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12;\n" +
@@ -1516,7 +1808,29 @@ const file_proto_accounting_accounting_proto_rawDesc = "" +
 	"\n" +
 	"start_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x129\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x96\x01\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\tbreakdown\x18\a \x01(\v2 .accounting.CalculationBreakdownR\tbreakdown\x12(\n" +
+	"\x10debit_account_id\x18\b \x01(\tR\x0edebitAccountId\x12*\n" +
+	"\x11credit_account_id\x18\t \x01(\tR\x0fcreditAccountId\x12\x1f\n" +
+	"\vtemplate_id\x18\n" +
+	" \x01(\tR\n" +
+	"templateId\x12\x18\n" +
+	"\areverse\x18\v \x01(\bR\areverse\"r\n" +
+	"\x10CalculationValue\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x12\n" +
+	"\x04unit\x18\x03 \x01(\tR\x04unit\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xc4\x02\n" +
+	"\x14CalculationBreakdown\x12\x18\n" +
+	"\aformula\x18\x01 \x01(\tR\aformula\x124\n" +
+	"\x06inputs\x18\x02 \x03(\v2\x1c.accounting.CalculationValueR\x06inputs\x122\n" +
+	"\x05steps\x18\x03 \x03(\v2\x1c.accounting.CalculationValueR\x05steps\x124\n" +
+	"\x06result\x18\x04 \x01(\v2\x1c.accounting.CalculationValueR\x06result\x12\x14\n" +
+	"\x05notes\x18\x05 \x01(\tR\x05notes\x12;\n" +
+	"\vcomputed_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"computedAt\x12\x1f\n" +
+	"\vcomputed_by\x18\a \x01(\tR\n" +
+	"computedBy\"\x96\x01\n" +
 	"\x10ReportingContext\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
 	"\bstandard\x18\x02 \x01(\x0e2\x1d.accounting.ReportingStandardR\bstandard\x12\x1a\n" +
@@ -1548,13 +1862,15 @@ const file_proto_accounting_accounting_proto_rawDesc = "" +
 	"\tEntryType\x12\x1a\n" +
 	"\x16ENTRY_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10ENTRY_TYPE_DEBIT\x10\x01\x12\x15\n" +
-	"\x11ENTRY_TYPE_CREDIT\x10\x02*\xb8\x01\n" +
+	"\x11ENTRY_TYPE_CREDIT\x10\x02*\x82\x02\n" +
 	"\x11TransactionStatus\x12\"\n" +
 	"\x1eTRANSACTION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aTRANSACTION_STATUS_PENDING\x10\x01\x12\x1d\n" +
 	"\x19TRANSACTION_STATUS_POSTED\x10\x02\x12\x1f\n" +
 	"\x1bTRANSACTION_STATUS_REVERSED\x10\x03\x12\x1f\n" +
-	"\x1bTRANSACTION_STATUS_IN_BATCH\x10\x04*z\n" +
+	"\x1bTRANSACTION_STATUS_IN_BATCH\x10\x04\x12'\n" +
+	"#TRANSACTION_STATUS_PENDING_APPROVAL\x10\x05\x12\x1f\n" +
+	"\x1bTRANSACTION_STATUS_REJECTED\x10\x06*z\n" +
 	"\n" +
 	"LedgerType\x12\x1b\n" +
 	"\x17LEDGER_TYPE_UNSPECIFIED\x10\x00\x12\x12\n" +
@@ -1590,7 +1906,7 @@ func file_proto_accounting_accounting_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_accounting_accounting_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
-var file_proto_accounting_accounting_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_accounting_accounting_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_accounting_accounting_proto_goTypes = []any{
 	(DimensionKeyEnum)(0),         // 0: accounting.DimensionKeyEnum
 	(AccountType)(0),              // 1: accounting.AccountType
@@ -1610,44 +1926,51 @@ var file_proto_accounting_accounting_proto_goTypes = []any{
 	(*Ledger)(nil),                // 15: accounting.Ledger
 	(*Reconciliation)(nil),        // 16: accounting.Reconciliation
 	(*RecognitionSchedule)(nil),   // 17: accounting.RecognitionSchedule
-	(*ReportingContext)(nil),      // 18: accounting.ReportingContext
-	(*JournalEvent)(nil),          // 19: accounting.JournalEvent
-	(*timestamppb.Timestamp)(nil), // 20: google.protobuf.Timestamp
+	(*CalculationValue)(nil),      // 18: accounting.CalculationValue
+	(*CalculationBreakdown)(nil),  // 19: accounting.CalculationBreakdown
+	(*ReportingContext)(nil),      // 20: accounting.ReportingContext
+	(*JournalEvent)(nil),          // 21: accounting.JournalEvent
+	(*timestamppb.Timestamp)(nil), // 22: google.protobuf.Timestamp
 }
 var file_proto_accounting_accounting_proto_depIdxs = []int32{
-	20, // 0: accounting.Amount.exchange_rate_date:type_name -> google.protobuf.Timestamp
+	22, // 0: accounting.Amount.exchange_rate_date:type_name -> google.protobuf.Timestamp
 	1,  // 1: accounting.Account.type:type_name -> accounting.AccountType
 	9,  // 2: accounting.Account.dimensions:type_name -> accounting.Dimension
-	20, // 3: accounting.Account.created_at:type_name -> google.protobuf.Timestamp
-	20, // 4: accounting.Account.closed_at:type_name -> google.protobuf.Timestamp
+	22, // 3: accounting.Account.created_at:type_name -> google.protobuf.Timestamp
+	22, // 4: accounting.Account.closed_at:type_name -> google.protobuf.Timestamp
 	2,  // 5: accounting.Entry.type:type_name -> accounting.EntryType
 	10, // 6: accounting.Entry.amount:type_name -> accounting.Amount
 	9,  // 7: accounting.Entry.dimensions:type_name -> accounting.Dimension
-	20, // 8: accounting.Transaction.valid_time:type_name -> google.protobuf.Timestamp
-	20, // 9: accounting.Transaction.transaction_time:type_name -> google.protobuf.Timestamp
+	22, // 8: accounting.Transaction.valid_time:type_name -> google.protobuf.Timestamp
+	22, // 9: accounting.Transaction.transaction_time:type_name -> google.protobuf.Timestamp
 	3,  // 10: accounting.Transaction.status:type_name -> accounting.TransactionStatus
 	12, // 11: accounting.Transaction.entries:type_name -> accounting.Entry
-	20, // 12: accounting.Transaction.created_at:type_name -> google.protobuf.Timestamp
-	20, // 13: accounting.Transaction.updated_at:type_name -> google.protobuf.Timestamp
-	20, // 14: accounting.Period.start:type_name -> google.protobuf.Timestamp
-	20, // 15: accounting.Period.end:type_name -> google.protobuf.Timestamp
-	20, // 16: accounting.Period.soft_closed_at:type_name -> google.protobuf.Timestamp
-	20, // 17: accounting.Period.hard_closed_at:type_name -> google.protobuf.Timestamp
+	22, // 12: accounting.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	22, // 13: accounting.Transaction.updated_at:type_name -> google.protobuf.Timestamp
+	22, // 14: accounting.Period.start:type_name -> google.protobuf.Timestamp
+	22, // 15: accounting.Period.end:type_name -> google.protobuf.Timestamp
+	22, // 16: accounting.Period.soft_closed_at:type_name -> google.protobuf.Timestamp
+	22, // 17: accounting.Period.hard_closed_at:type_name -> google.protobuf.Timestamp
 	4,  // 18: accounting.Ledger.type:type_name -> accounting.LedgerType
 	5,  // 19: accounting.Reconciliation.status:type_name -> accounting.ReconciliationStatus
-	20, // 20: accounting.Reconciliation.created_at:type_name -> google.protobuf.Timestamp
-	20, // 21: accounting.Reconciliation.completed_at:type_name -> google.protobuf.Timestamp
+	22, // 20: accounting.Reconciliation.created_at:type_name -> google.protobuf.Timestamp
+	22, // 21: accounting.Reconciliation.completed_at:type_name -> google.protobuf.Timestamp
 	6,  // 22: accounting.RecognitionSchedule.frequency:type_name -> accounting.ScheduleFrequency
-	20, // 23: accounting.RecognitionSchedule.start_time:type_name -> google.protobuf.Timestamp
-	20, // 24: accounting.RecognitionSchedule.created_at:type_name -> google.protobuf.Timestamp
-	7,  // 25: accounting.ReportingContext.standard:type_name -> accounting.ReportingStandard
-	20, // 26: accounting.JournalEvent.valid_time:type_name -> google.protobuf.Timestamp
-	20, // 27: accounting.JournalEvent.transaction_time:type_name -> google.protobuf.Timestamp
-	28, // [28:28] is the sub-list for method output_type
-	28, // [28:28] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	22, // 23: accounting.RecognitionSchedule.start_time:type_name -> google.protobuf.Timestamp
+	22, // 24: accounting.RecognitionSchedule.created_at:type_name -> google.protobuf.Timestamp
+	19, // 25: accounting.RecognitionSchedule.breakdown:type_name -> accounting.CalculationBreakdown
+	18, // 26: accounting.CalculationBreakdown.inputs:type_name -> accounting.CalculationValue
+	18, // 27: accounting.CalculationBreakdown.steps:type_name -> accounting.CalculationValue
+	18, // 28: accounting.CalculationBreakdown.result:type_name -> accounting.CalculationValue
+	22, // 29: accounting.CalculationBreakdown.computed_at:type_name -> google.protobuf.Timestamp
+	7,  // 30: accounting.ReportingContext.standard:type_name -> accounting.ReportingStandard
+	22, // 31: accounting.JournalEvent.valid_time:type_name -> google.protobuf.Timestamp
+	22, // 32: accounting.JournalEvent.transaction_time:type_name -> google.protobuf.Timestamp
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_proto_accounting_accounting_proto_init() }
@@ -1661,7 +1984,7 @@ func file_proto_accounting_accounting_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_accounting_accounting_proto_rawDesc), len(file_proto_accounting_accounting_proto_rawDesc)),
 			NumEnums:      8,
-			NumMessages:   12,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
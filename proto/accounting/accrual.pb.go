@@ -80,17 +80,19 @@ func (AccrualType) EnumDescriptor() ([]byte, []int) {
 
 // RecognitionEntry
 type RecognitionEntry struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ScheduleId      string                 `protobuf:"bytes,2,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
-	PeriodNumber    int32                  `protobuf:"varint,3,opt,name=period_number,json=periodNumber,proto3" json:"period_number,omitempty"`
-	RecognitionDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=recognition_date,json=recognitionDate,proto3" json:"recognition_date,omitempty"`
-	Amount          *Amount                `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
-	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
-	TransactionId   string                 `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	ProcessedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ScheduleId            string                 `protobuf:"bytes,2,opt,name=schedule_id,json=scheduleId,proto3" json:"schedule_id,omitempty"`
+	PeriodNumber          int32                  `protobuf:"varint,3,opt,name=period_number,json=periodNumber,proto3" json:"period_number,omitempty"`
+	RecognitionDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=recognition_date,json=recognitionDate,proto3" json:"recognition_date,omitempty"`
+	Amount                *Amount                `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status                string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	TransactionId         string                 `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	ProcessedAt           *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
+	Error                 string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	ReversalTransactionId string                 `protobuf:"bytes,10,opt,name=reversal_transaction_id,json=reversalTransactionId,proto3" json:"reversal_transaction_id,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *RecognitionEntry) Reset() {
@@ -179,6 +181,20 @@ func (x *RecognitionEntry) GetProcessedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *RecognitionEntry) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RecognitionEntry) GetReversalTransactionId() string {
+	if x != nil {
+		return x.ReversalTransactionId
+	}
+	return ""
+}
+
 // AccrualTemplate
 type AccrualTemplate struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -190,6 +206,7 @@ type AccrualTemplate struct {
 	ExpenseAccountId  string                 `protobuf:"bytes,6,opt,name=expense_account_id,json=expenseAccountId,proto3" json:"expense_account_id,omitempty"`
 	DeferralAccountId string                 `protobuf:"bytes,7,opt,name=deferral_account_id,json=deferralAccountId,proto3" json:"deferral_account_id,omitempty"`
 	Dimensions        []*Dimension           `protobuf:"bytes,8,rep,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Reverse           bool                   `protobuf:"varint,9,opt,name=reverse,proto3" json:"reverse,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -280,12 +297,19 @@ func (x *AccrualTemplate) GetDimensions() []*Dimension {
 	return nil
 }
 
+func (x *AccrualTemplate) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
 var File_proto_accounting_accrual_proto protoreflect.FileDescriptor
 
 const file_proto_accounting_accrual_proto_rawDesc = "" +
 	"\n" +
 	"\x1eproto/accounting/accrual.proto\x12\n" +
-	"accounting\x1a\x1fgoogle/protobuf/timestamp.proto\x1a!proto/accounting/accounting.proto\"\xd9\x02\n" +
+	"accounting\x1a\x1fgoogle/protobuf/timestamp.proto\x1a!proto/accounting/accounting.proto\"\xa7\x03\n" +
 	"\x10RecognitionEntry\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vschedule_id\x18\x02 \x01(\tR\n" +
@@ -295,7 +319,10 @@ const file_proto_accounting_accrual_proto_rawDesc = "" +
 	"\x06amount\x18\x05 \x01(\v2\x12.accounting.AmountR\x06amount\x12\x16\n" +
 	"\x06status\x18\x06 \x01(\tR\x06status\x12%\n" +
 	"\x0etransaction_id\x18\a \x01(\tR\rtransactionId\x12=\n" +
-	"\fprocessed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\"\xe2\x02\n" +
+	"\fprocessed_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\x126\n" +
+	"\x17reversal_transaction_id\x18\n" +
+	" \x01(\tR\x15reversalTransactionId\"\xfc\x02\n" +
 	"\x0fAccrualTemplate\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12:\n" +
@@ -306,7 +333,8 @@ const file_proto_accounting_accrual_proto_rawDesc = "" +
 	"\x13deferral_account_id\x18\a \x01(\tR\x11deferralAccountId\x125\n" +
 	"\n" +
 	"dimensions\x18\b \x03(\v2\x15.accounting.DimensionR\n" +
-	"dimensions*\xa5\x01\n" +
+	"dimensions\x12\x18\n" +
+	"\areverse\x18\t \x01(\bR\areverse*\xa5\x01\n" +
 	"\vAccrualType\x12\x1c\n" +
 	"\x18ACCRUAL_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14ACCRUAL_TYPE_REVENUE\x10\x01\x12\x18\n" +
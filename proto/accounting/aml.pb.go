@@ -176,6 +176,8 @@ const (
 	AMLRuleType_AML_RULE_TYPE_CRYPTOCURRENCY         AMLRuleType = 32
 	AMLRuleType_AML_RULE_TYPE_JUST_UNDER_THRESHOLD   AMLRuleType = 33
 	AMLRuleType_AML_RULE_TYPE_UNEXPECTED_GEOGRAPHY   AMLRuleType = 34
+	AMLRuleType_AML_RULE_TYPE_CHARGEBACK_RATE        AMLRuleType = 35
+	AMLRuleType_AML_RULE_TYPE_CLIENT_SHORTFALL       AMLRuleType = 36
 )
 
 // Enum value maps for AMLRuleType.
@@ -216,6 +218,8 @@ var (
 		32: "AML_RULE_TYPE_CRYPTOCURRENCY",
 		33: "AML_RULE_TYPE_JUST_UNDER_THRESHOLD",
 		34: "AML_RULE_TYPE_UNEXPECTED_GEOGRAPHY",
+		35: "AML_RULE_TYPE_CHARGEBACK_RATE",
+		36: "AML_RULE_TYPE_CLIENT_SHORTFALL",
 	}
 	AMLRuleType_value = map[string]int32{
 		"AML_RULE_TYPE_UNSPECIFIED":            0,
@@ -253,6 +257,8 @@ var (
 		"AML_RULE_TYPE_CRYPTOCURRENCY":         32,
 		"AML_RULE_TYPE_JUST_UNDER_THRESHOLD":   33,
 		"AML_RULE_TYPE_UNEXPECTED_GEOGRAPHY":   34,
+		"AML_RULE_TYPE_CHARGEBACK_RATE":        35,
+		"AML_RULE_TYPE_CLIENT_SHORTFALL":       36,
 	}
 )
 
@@ -283,6 +289,153 @@ func (AMLRuleType) EnumDescriptor() ([]byte, []int) {
 	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{2}
 }
 
+// AMLValue preserves the Go type of a dynamically typed rule threshold or evidence value
+type AMLValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*AMLValue_IntValue
+	//	*AMLValue_Int64Value
+	//	*AMLValue_FloatValue
+	//	*AMLValue_StringValue
+	//	*AMLValue_BoolValue
+	//	*AMLValue_JsonValue
+	Kind          isAMLValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AMLValue) Reset() {
+	*x = AMLValue{}
+	mi := &file_proto_accounting_aml_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AMLValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AMLValue) ProtoMessage() {}
+
+func (x *AMLValue) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_aml_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AMLValue.ProtoReflect.Descriptor instead.
+func (*AMLValue) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *AMLValue) GetKind() isAMLValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *AMLValue) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*AMLValue_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *AMLValue) GetInt64Value() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*AMLValue_Int64Value); ok {
+			return x.Int64Value
+		}
+	}
+	return 0
+}
+
+func (x *AMLValue) GetFloatValue() float64 {
+	if x != nil {
+		if x, ok := x.Kind.(*AMLValue_FloatValue); ok {
+			return x.FloatValue
+		}
+	}
+	return 0
+}
+
+func (x *AMLValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*AMLValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *AMLValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*AMLValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *AMLValue) GetJsonValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*AMLValue_JsonValue); ok {
+			return x.JsonValue
+		}
+	}
+	return ""
+}
+
+type isAMLValue_Kind interface {
+	isAMLValue_Kind()
+}
+
+type AMLValue_IntValue struct {
+	IntValue int64 `protobuf:"varint,1,opt,name=int_value,json=intValue,proto3,oneof"` // Go int
+}
+
+type AMLValue_Int64Value struct {
+	Int64Value int64 `protobuf:"varint,2,opt,name=int64_value,json=int64Value,proto3,oneof"` // Go int64
+}
+
+type AMLValue_FloatValue struct {
+	FloatValue float64 `protobuf:"fixed64,3,opt,name=float_value,json=floatValue,proto3,oneof"`
+}
+
+type AMLValue_StringValue struct {
+	StringValue string `protobuf:"bytes,4,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type AMLValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,5,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type AMLValue_JsonValue struct {
+	JsonValue string `protobuf:"bytes,6,opt,name=json_value,json=jsonValue,proto3,oneof"` // any other type, JSON-encoded
+}
+
+func (*AMLValue_IntValue) isAMLValue_Kind() {}
+
+func (*AMLValue_Int64Value) isAMLValue_Kind() {}
+
+func (*AMLValue_FloatValue) isAMLValue_Kind() {}
+
+func (*AMLValue_StringValue) isAMLValue_Kind() {}
+
+func (*AMLValue_BoolValue) isAMLValue_Kind() {}
+
+func (*AMLValue_JsonValue) isAMLValue_Kind() {}
+
 // InvestigationAction
 type InvestigationAction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -298,7 +451,7 @@ type InvestigationAction struct {
 
 func (x *InvestigationAction) Reset() {
 	*x = InvestigationAction{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[0]
+	mi := &file_proto_accounting_aml_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -310,7 +463,7 @@ func (x *InvestigationAction) String() string {
 func (*InvestigationAction) ProtoMessage() {}
 
 func (x *InvestigationAction) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[0]
+	mi := &file_proto_accounting_aml_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,7 +476,7 @@ func (x *InvestigationAction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvestigationAction.ProtoReflect.Descriptor instead.
 func (*InvestigationAction) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{0}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *InvestigationAction) GetId() string {
@@ -381,7 +534,7 @@ type InvestigationNote struct {
 
 func (x *InvestigationNote) Reset() {
 	*x = InvestigationNote{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[1]
+	mi := &file_proto_accounting_aml_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -393,7 +546,7 @@ func (x *InvestigationNote) String() string {
 func (*InvestigationNote) ProtoMessage() {}
 
 func (x *InvestigationNote) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[1]
+	mi := &file_proto_accounting_aml_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -406,7 +559,7 @@ func (x *InvestigationNote) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InvestigationNote.ProtoReflect.Descriptor instead.
 func (*InvestigationNote) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{1}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *InvestigationNote) GetId() string {
@@ -456,7 +609,7 @@ type AMLInvestigation struct {
 
 func (x *AMLInvestigation) Reset() {
 	*x = AMLInvestigation{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[2]
+	mi := &file_proto_accounting_aml_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -468,7 +621,7 @@ func (x *AMLInvestigation) String() string {
 func (*AMLInvestigation) ProtoMessage() {}
 
 func (x *AMLInvestigation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[2]
+	mi := &file_proto_accounting_aml_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,7 +634,7 @@ func (x *AMLInvestigation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AMLInvestigation.ProtoReflect.Descriptor instead.
 func (*AMLInvestigation) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{2}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *AMLInvestigation) GetId() string {
@@ -559,17 +712,18 @@ type AMLEvidence struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
 	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	ValueJson     string                 `protobuf:"bytes,3,opt,name=value_json,json=valueJson,proto3" json:"value_json,omitempty"` // JSON-encoded value
+	ValueJson     string                 `protobuf:"bytes,3,opt,name=value_json,json=valueJson,proto3" json:"value_json,omitempty"` // Deprecated: use value
 	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
 	Confidence    float64                `protobuf:"fixed64,5,opt,name=confidence,proto3" json:"confidence,omitempty"`
 	CollectedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=collected_at,json=collectedAt,proto3" json:"collected_at,omitempty"`
+	Value         *AMLValue              `protobuf:"bytes,7,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AMLEvidence) Reset() {
 	*x = AMLEvidence{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[3]
+	mi := &file_proto_accounting_aml_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -581,7 +735,7 @@ func (x *AMLEvidence) String() string {
 func (*AMLEvidence) ProtoMessage() {}
 
 func (x *AMLEvidence) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[3]
+	mi := &file_proto_accounting_aml_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -594,7 +748,7 @@ func (x *AMLEvidence) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AMLEvidence.ProtoReflect.Descriptor instead.
 func (*AMLEvidence) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{3}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AMLEvidence) GetType() string {
@@ -639,6 +793,13 @@ func (x *AMLEvidence) GetCollectedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *AMLEvidence) GetValue() *AMLValue {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
 // AMLDisposition
 type AMLDisposition struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -656,7 +817,7 @@ type AMLDisposition struct {
 
 func (x *AMLDisposition) Reset() {
 	*x = AMLDisposition{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[4]
+	mi := &file_proto_accounting_aml_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -668,7 +829,7 @@ func (x *AMLDisposition) String() string {
 func (*AMLDisposition) ProtoMessage() {}
 
 func (x *AMLDisposition) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[4]
+	mi := &file_proto_accounting_aml_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -681,7 +842,7 @@ func (x *AMLDisposition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AMLDisposition.ProtoReflect.Descriptor instead.
 func (*AMLDisposition) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{4}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *AMLDisposition) GetId() string {
@@ -763,13 +924,14 @@ type AMLAlert struct {
 	Dispositions   []*AMLDisposition      `protobuf:"bytes,18,rep,name=dispositions,proto3" json:"dispositions,omitempty"`
 	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version        int64                  `protobuf:"varint,21,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *AMLAlert) Reset() {
 	*x = AMLAlert{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[5]
+	mi := &file_proto_accounting_aml_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -781,7 +943,7 @@ func (x *AMLAlert) String() string {
 func (*AMLAlert) ProtoMessage() {}
 
 func (x *AMLAlert) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[5]
+	mi := &file_proto_accounting_aml_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -794,7 +956,7 @@ func (x *AMLAlert) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AMLAlert.ProtoReflect.Descriptor instead.
 func (*AMLAlert) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{5}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *AMLAlert) GetId() string {
@@ -937,30 +1099,38 @@ func (x *AMLAlert) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *AMLAlert) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // AMLRule
 type AMLRule struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Type          AMLRuleType            `protobuf:"varint,3,opt,name=type,proto3,enum=accounting.AMLRuleType" json:"type,omitempty"`
-	Framework     AMLFramework           `protobuf:"varint,4,opt,name=framework,proto3,enum=accounting.AMLFramework" json:"framework,omitempty"`
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	Enabled       bool                   `protobuf:"varint,6,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	Thresholds    map[string]string      `protobuf:"bytes,7,rep,name=thresholds,proto3" json:"thresholds,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // JSON-encoded threshold values
-	TimeWindows   map[string]int32       `protobuf:"bytes,8,rep,name=time_windows,json=timeWindows,proto3" json:"time_windows,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
-	Currencies    []string               `protobuf:"bytes,9,rep,name=currencies,proto3" json:"currencies,omitempty"`
-	Countries     []string               `protobuf:"bytes,10,rep,name=countries,proto3" json:"countries,omitempty"`
-	BaseScore     int32                  `protobuf:"varint,11,opt,name=base_score,json=baseScore,proto3" json:"base_score,omitempty"`
-	RiskMultiple  float64                `protobuf:"fixed64,12,opt,name=risk_multiple,json=riskMultiple,proto3" json:"risk_multiple,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type            AMLRuleType            `protobuf:"varint,3,opt,name=type,proto3,enum=accounting.AMLRuleType" json:"type,omitempty"`
+	Framework       AMLFramework           `protobuf:"varint,4,opt,name=framework,proto3,enum=accounting.AMLFramework" json:"framework,omitempty"`
+	Description     string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Enabled         bool                   `protobuf:"varint,6,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Thresholds      map[string]string      `protobuf:"bytes,7,rep,name=thresholds,proto3" json:"thresholds,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Deprecated: use threshold_values
+	TimeWindows     map[string]int32       `protobuf:"bytes,8,rep,name=time_windows,json=timeWindows,proto3" json:"time_windows,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Currencies      []string               `protobuf:"bytes,9,rep,name=currencies,proto3" json:"currencies,omitempty"`
+	Countries       []string               `protobuf:"bytes,10,rep,name=countries,proto3" json:"countries,omitempty"`
+	BaseScore       int32                  `protobuf:"varint,11,opt,name=base_score,json=baseScore,proto3" json:"base_score,omitempty"`
+	RiskMultiple    float64                `protobuf:"fixed64,12,opt,name=risk_multiple,json=riskMultiple,proto3" json:"risk_multiple,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ThresholdValues map[string]*AMLValue   `protobuf:"bytes,15,rep,name=threshold_values,json=thresholdValues,proto3" json:"threshold_values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *AMLRule) Reset() {
 	*x = AMLRule{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[6]
+	mi := &file_proto_accounting_aml_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -972,7 +1142,7 @@ func (x *AMLRule) String() string {
 func (*AMLRule) ProtoMessage() {}
 
 func (x *AMLRule) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[6]
+	mi := &file_proto_accounting_aml_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -985,7 +1155,7 @@ func (x *AMLRule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AMLRule.ProtoReflect.Descriptor instead.
 func (*AMLRule) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{6}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *AMLRule) GetId() string {
@@ -1086,33 +1256,44 @@ func (x *AMLRule) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *AMLRule) GetThresholdValues() map[string]*AMLValue {
+	if x != nil {
+		return x.ThresholdValues
+	}
+	return nil
+}
+
 // AMLCustomer
 type AMLCustomer struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	CustomerId       string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	Name             string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Type             string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
-	RiskLevel        AMLRiskLevel           `protobuf:"varint,5,opt,name=risk_level,json=riskLevel,proto3,enum=accounting.AMLRiskLevel" json:"risk_level,omitempty"`
-	Country          string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
-	IsPep            bool                   `protobuf:"varint,7,opt,name=is_pep,json=isPep,proto3" json:"is_pep,omitempty"`
-	IsHighRisk       bool                   `protobuf:"varint,8,opt,name=is_high_risk,json=isHighRisk,proto3" json:"is_high_risk,omitempty"`
-	SanctionsMatch   bool                   `protobuf:"varint,9,opt,name=sanctions_match,json=sanctionsMatch,proto3" json:"sanctions_match,omitempty"`
-	LastKycDate      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_kyc_date,json=lastKycDate,proto3" json:"last_kyc_date,omitempty"`
-	LastCddDate      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_cdd_date,json=lastCddDate,proto3" json:"last_cdd_date,omitempty"`
-	NextReviewDate   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=next_review_date,json=nextReviewDate,proto3" json:"next_review_date,omitempty"`
-	OnboardingDate   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=onboarding_date,json=onboardingDate,proto3" json:"onboarding_date,omitempty"`
-	ExpectedActivity string                 `protobuf:"bytes,14,opt,name=expected_activity,json=expectedActivity,proto3" json:"expected_activity,omitempty"`
-	BusinessPurpose  string                 `protobuf:"bytes,15,opt,name=business_purpose,json=businessPurpose,proto3" json:"business_purpose,omitempty"`
-	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CustomerId          string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Name                string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Type                string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	RiskLevel           AMLRiskLevel           `protobuf:"varint,5,opt,name=risk_level,json=riskLevel,proto3,enum=accounting.AMLRiskLevel" json:"risk_level,omitempty"`
+	Country             string                 `protobuf:"bytes,6,opt,name=country,proto3" json:"country,omitempty"`
+	IsPep               bool                   `protobuf:"varint,7,opt,name=is_pep,json=isPep,proto3" json:"is_pep,omitempty"`
+	IsHighRisk          bool                   `protobuf:"varint,8,opt,name=is_high_risk,json=isHighRisk,proto3" json:"is_high_risk,omitempty"`
+	SanctionsMatch      bool                   `protobuf:"varint,9,opt,name=sanctions_match,json=sanctionsMatch,proto3" json:"sanctions_match,omitempty"`
+	LastKycDate         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_kyc_date,json=lastKycDate,proto3" json:"last_kyc_date,omitempty"`
+	LastCddDate         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_cdd_date,json=lastCddDate,proto3" json:"last_cdd_date,omitempty"`
+	NextReviewDate      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=next_review_date,json=nextReviewDate,proto3" json:"next_review_date,omitempty"`
+	OnboardingDate      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=onboarding_date,json=onboardingDate,proto3" json:"onboarding_date,omitempty"`
+	ExpectedActivity    string                 `protobuf:"bytes,14,opt,name=expected_activity,json=expectedActivity,proto3" json:"expected_activity,omitempty"`
+	BusinessPurpose     string                 `protobuf:"bytes,15,opt,name=business_purpose,json=businessPurpose,proto3" json:"business_purpose,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	RelationshipEndedAt *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=relationship_ended_at,json=relationshipEndedAt,proto3" json:"relationship_ended_at,omitempty"`
+	ErasedAt            *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`
+	ErasureMode         string                 `protobuf:"bytes,20,opt,name=erasure_mode,json=erasureMode,proto3" json:"erasure_mode,omitempty"`
+	Version             int64                  `protobuf:"varint,21,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *AMLCustomer) Reset() {
 	*x = AMLCustomer{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[7]
+	mi := &file_proto_accounting_aml_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1124,7 +1305,7 @@ func (x *AMLCustomer) String() string {
 func (*AMLCustomer) ProtoMessage() {}
 
 func (x *AMLCustomer) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[7]
+	mi := &file_proto_accounting_aml_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1137,7 +1318,7 @@ func (x *AMLCustomer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AMLCustomer.ProtoReflect.Descriptor instead.
 func (*AMLCustomer) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{7}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AMLCustomer) GetId() string {
@@ -1259,6 +1440,34 @@ func (x *AMLCustomer) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *AMLCustomer) GetRelationshipEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RelationshipEndedAt
+	}
+	return nil
+}
+
+func (x *AMLCustomer) GetErasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return nil
+}
+
+func (x *AMLCustomer) GetErasureMode() string {
+	if x != nil {
+		return x.ErasureMode
+	}
+	return ""
+}
+
+func (x *AMLCustomer) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // AMLTransaction
 type AMLTransaction struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -1282,7 +1491,7 @@ type AMLTransaction struct {
 
 func (x *AMLTransaction) Reset() {
 	*x = AMLTransaction{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[8]
+	mi := &file_proto_accounting_aml_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1294,7 +1503,7 @@ func (x *AMLTransaction) String() string {
 func (*AMLTransaction) ProtoMessage() {}
 
 func (x *AMLTransaction) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[8]
+	mi := &file_proto_accounting_aml_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1307,7 +1516,7 @@ func (x *AMLTransaction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AMLTransaction.ProtoReflect.Descriptor instead.
 func (*AMLTransaction) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{8}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AMLTransaction) GetTransactionId() string {
@@ -1424,7 +1633,7 @@ type CustomerRiskSummary struct {
 
 func (x *CustomerRiskSummary) Reset() {
 	*x = CustomerRiskSummary{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[9]
+	mi := &file_proto_accounting_aml_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1436,7 +1645,7 @@ func (x *CustomerRiskSummary) String() string {
 func (*CustomerRiskSummary) ProtoMessage() {}
 
 func (x *CustomerRiskSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[9]
+	mi := &file_proto_accounting_aml_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1449,7 +1658,7 @@ func (x *CustomerRiskSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CustomerRiskSummary.ProtoReflect.Descriptor instead.
 func (*CustomerRiskSummary) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{9}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CustomerRiskSummary) GetCustomerId() string {
@@ -1515,7 +1724,7 @@ type AMLComplianceMetrics struct {
 
 func (x *AMLComplianceMetrics) Reset() {
 	*x = AMLComplianceMetrics{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[10]
+	mi := &file_proto_accounting_aml_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1527,7 +1736,7 @@ func (x *AMLComplianceMetrics) String() string {
 func (*AMLComplianceMetrics) ProtoMessage() {}
 
 func (x *AMLComplianceMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[10]
+	mi := &file_proto_accounting_aml_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1540,7 +1749,7 @@ func (x *AMLComplianceMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AMLComplianceMetrics.ProtoReflect.Descriptor instead.
 func (*AMLComplianceMetrics) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{10}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *AMLComplianceMetrics) GetCtrFilingRate() float64 {
@@ -1591,7 +1800,7 @@ type AMLTrendAnalysis struct {
 
 func (x *AMLTrendAnalysis) Reset() {
 	*x = AMLTrendAnalysis{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[11]
+	mi := &file_proto_accounting_aml_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1603,7 +1812,7 @@ func (x *AMLTrendAnalysis) String() string {
 func (*AMLTrendAnalysis) ProtoMessage() {}
 
 func (x *AMLTrendAnalysis) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[11]
+	mi := &file_proto_accounting_aml_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1616,7 +1825,7 @@ func (x *AMLTrendAnalysis) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AMLTrendAnalysis.ProtoReflect.Descriptor instead.
 func (*AMLTrendAnalysis) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{11}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *AMLTrendAnalysis) GetAlertTrend_30Days() []int32 {
@@ -1662,7 +1871,7 @@ type AMLRecommendation struct {
 
 func (x *AMLRecommendation) Reset() {
 	*x = AMLRecommendation{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[12]
+	mi := &file_proto_accounting_aml_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1674,7 +1883,7 @@ func (x *AMLRecommendation) String() string {
 func (*AMLRecommendation) ProtoMessage() {}
 
 func (x *AMLRecommendation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[12]
+	mi := &file_proto_accounting_aml_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1687,7 +1896,7 @@ func (x *AMLRecommendation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AMLRecommendation.ProtoReflect.Descriptor instead.
 func (*AMLRecommendation) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{12}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *AMLRecommendation) GetPriority() string {
@@ -1750,7 +1959,7 @@ type AMLDashboard struct {
 
 func (x *AMLDashboard) Reset() {
 	*x = AMLDashboard{}
-	mi := &file_proto_accounting_aml_proto_msgTypes[13]
+	mi := &file_proto_accounting_aml_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1762,7 +1971,7 @@ func (x *AMLDashboard) String() string {
 func (*AMLDashboard) ProtoMessage() {}
 
 func (x *AMLDashboard) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_aml_proto_msgTypes[13]
+	mi := &file_proto_accounting_aml_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1775,7 +1984,7 @@ func (x *AMLDashboard) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AMLDashboard.ProtoReflect.Descriptor instead.
 func (*AMLDashboard) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{13}
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AMLDashboard) GetPeriodStart() *timestamppb.Timestamp {
@@ -1841,12 +2050,226 @@ func (x *AMLDashboard) GetRecommendedActions() []*AMLRecommendation {
 	return nil
 }
 
+// AMLCustomerActivity
+type AMLCustomerActivity struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId        string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	AlertCount        int32                  `protobuf:"varint,2,opt,name=alert_count,json=alertCount,proto3" json:"alert_count,omitempty"`
+	AlertVolume       int64                  `protobuf:"varint,3,opt,name=alert_volume,json=alertVolume,proto3" json:"alert_volume,omitempty"`
+	AlertsByType      map[string]int32       `protobuf:"bytes,4,rep,name=alerts_by_type,json=alertsByType,proto3" json:"alerts_by_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	LastActivity      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	TransactionCount  int64                  `protobuf:"varint,6,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	TransactionVolume int64                  `protobuf:"varint,7,opt,name=transaction_volume,json=transactionVolume,proto3" json:"transaction_volume,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AMLCustomerActivity) Reset() {
+	*x = AMLCustomerActivity{}
+	mi := &file_proto_accounting_aml_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AMLCustomerActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AMLCustomerActivity) ProtoMessage() {}
+
+func (x *AMLCustomerActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_aml_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AMLCustomerActivity.ProtoReflect.Descriptor instead.
+func (*AMLCustomerActivity) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *AMLCustomerActivity) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *AMLCustomerActivity) GetAlertCount() int32 {
+	if x != nil {
+		return x.AlertCount
+	}
+	return 0
+}
+
+func (x *AMLCustomerActivity) GetAlertVolume() int64 {
+	if x != nil {
+		return x.AlertVolume
+	}
+	return 0
+}
+
+func (x *AMLCustomerActivity) GetAlertsByType() map[string]int32 {
+	if x != nil {
+		return x.AlertsByType
+	}
+	return nil
+}
+
+func (x *AMLCustomerActivity) GetLastActivity() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivity
+	}
+	return nil
+}
+
+func (x *AMLCustomerActivity) GetTransactionCount() int64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *AMLCustomerActivity) GetTransactionVolume() int64 {
+	if x != nil {
+		return x.TransactionVolume
+	}
+	return 0
+}
+
+// AMLDailyAggregate
+type AMLDailyAggregate struct {
+	state             protoimpl.MessageState          `protogen:"open.v1"`
+	Day               string                          `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	TotalAlerts       int32                           `protobuf:"varint,2,opt,name=total_alerts,json=totalAlerts,proto3" json:"total_alerts,omitempty"`
+	AlertsByRiskLevel map[string]int32                `protobuf:"bytes,3,rep,name=alerts_by_risk_level,json=alertsByRiskLevel,proto3" json:"alerts_by_risk_level,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	AlertsByType      map[string]int32                `protobuf:"bytes,4,rep,name=alerts_by_type,json=alertsByType,proto3" json:"alerts_by_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	ClosedAlerts      int32                           `protobuf:"varint,5,opt,name=closed_alerts,json=closedAlerts,proto3" json:"closed_alerts,omitempty"`
+	FalsePositives    int32                           `protobuf:"varint,6,opt,name=false_positives,json=falsePositives,proto3" json:"false_positives,omitempty"`
+	TransactionCount  int64                           `protobuf:"varint,7,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	TransactionVolume int64                           `protobuf:"varint,8,opt,name=transaction_volume,json=transactionVolume,proto3" json:"transaction_volume,omitempty"`
+	Customers         map[string]*AMLCustomerActivity `protobuf:"bytes,9,rep,name=customers,proto3" json:"customers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *AMLDailyAggregate) Reset() {
+	*x = AMLDailyAggregate{}
+	mi := &file_proto_accounting_aml_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AMLDailyAggregate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AMLDailyAggregate) ProtoMessage() {}
+
+func (x *AMLDailyAggregate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_aml_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AMLDailyAggregate.ProtoReflect.Descriptor instead.
+func (*AMLDailyAggregate) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_aml_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AMLDailyAggregate) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *AMLDailyAggregate) GetTotalAlerts() int32 {
+	if x != nil {
+		return x.TotalAlerts
+	}
+	return 0
+}
+
+func (x *AMLDailyAggregate) GetAlertsByRiskLevel() map[string]int32 {
+	if x != nil {
+		return x.AlertsByRiskLevel
+	}
+	return nil
+}
+
+func (x *AMLDailyAggregate) GetAlertsByType() map[string]int32 {
+	if x != nil {
+		return x.AlertsByType
+	}
+	return nil
+}
+
+func (x *AMLDailyAggregate) GetClosedAlerts() int32 {
+	if x != nil {
+		return x.ClosedAlerts
+	}
+	return 0
+}
+
+func (x *AMLDailyAggregate) GetFalsePositives() int32 {
+	if x != nil {
+		return x.FalsePositives
+	}
+	return 0
+}
+
+func (x *AMLDailyAggregate) GetTransactionCount() int64 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *AMLDailyAggregate) GetTransactionVolume() int64 {
+	if x != nil {
+		return x.TransactionVolume
+	}
+	return 0
+}
+
+func (x *AMLDailyAggregate) GetCustomers() map[string]*AMLCustomerActivity {
+	if x != nil {
+		return x.Customers
+	}
+	return nil
+}
+
 var File_proto_accounting_aml_proto protoreflect.FileDescriptor
 
 const file_proto_accounting_aml_proto_rawDesc = "" +
 	"\n" +
 	"\x1aproto/accounting/aml.proto\x12\n" +
-	"accounting\x1a\x1fgoogle/protobuf/timestamp.proto\x1a!proto/accounting/accounting.proto\"\xc5\x01\n" +
+	"accounting\x1a\x1fgoogle/protobuf/timestamp.proto\x1a!proto/accounting/accounting.proto\"\xde\x01\n" +
+	"\bAMLValue\x12\x1d\n" +
+	"\tint_value\x18\x01 \x01(\x03H\x00R\bintValue\x12!\n" +
+	"\vint64_value\x18\x02 \x01(\x03H\x00R\n" +
+	"int64Value\x12!\n" +
+	"\vfloat_value\x18\x03 \x01(\x01H\x00R\n" +
+	"floatValue\x12#\n" +
+	"\fstring_value\x18\x04 \x01(\tH\x00R\vstringValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x05 \x01(\bH\x00R\tboolValue\x12\x1f\n" +
+	"\n" +
+	"json_value\x18\x06 \x01(\tH\x00R\tjsonValueB\x06\n" +
+	"\x04kind\"\xc5\x01\n" +
 	"\x13InvestigationAction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
@@ -1873,7 +2296,7 @@ const file_proto_accounting_aml_proto_rawDesc = "" +
 	"\bfindings\x18\b \x03(\tR\bfindings\x129\n" +
 	"\aactions\x18\t \x03(\v2\x1f.accounting.InvestigationActionR\aactions\x123\n" +
 	"\x05notes\x18\n" +
-	" \x03(\v2\x1d.accounting.InvestigationNoteR\x05notes\"\xd9\x01\n" +
+	" \x03(\v2\x1d.accounting.InvestigationNoteR\x05notes\"\x85\x02\n" +
 	"\vAMLEvidence\x12\x12\n" +
 	"\x04type\x18\x01 \x01(\tR\x04type\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1d\n" +
@@ -1883,7 +2306,8 @@ const file_proto_accounting_aml_proto_rawDesc = "" +
 	"\n" +
 	"confidence\x18\x05 \x01(\x01R\n" +
 	"confidence\x12=\n" +
-	"\fcollected_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcollectedAt\"\x8e\x02\n" +
+	"\fcollected_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vcollectedAt\x12*\n" +
+	"\x05value\x18\a \x01(\v2\x14.accounting.AMLValueR\x05value\"\x8e\x02\n" +
 	"\x0eAMLDisposition\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
@@ -1896,7 +2320,7 @@ const file_proto_accounting_aml_proto_rawDesc = "" +
 	"\n" +
 	"sar_number\x18\a \x01(\tR\tsarNumber\x12\x1f\n" +
 	"\vreported_to\x18\b \x03(\tR\n" +
-	"reportedTo\"\xee\x06\n" +
+	"reportedTo\"\x88\a\n" +
 	"\bAMLAlert\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x124\n" +
 	"\trule_type\x18\x02 \x01(\x0e2\x17.accounting.AMLRuleTypeR\bruleType\x126\n" +
@@ -1925,7 +2349,8 @@ const file_proto_accounting_aml_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd3\x05\n" +
+	"updated_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\x15 \x01(\x03R\aversion\"\x82\a\n" +
 	"\aAMLRule\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12+\n" +
@@ -1948,13 +2373,17 @@ const file_proto_accounting_aml_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a=\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12S\n" +
+	"\x10threshold_values\x18\x0f \x03(\v2(.accounting.AMLRule.ThresholdValuesEntryR\x0fthresholdValues\x1a=\n" +
 	"\x0fThresholdsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a>\n" +
 	"\x10TimeWindowsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xf4\x05\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1aX\n" +
+	"\x14ThresholdValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12*\n" +
+	"\x05value\x18\x02 \x01(\v2\x14.accounting.AMLValueR\x05value:\x028\x01\"\xba\a\n" +
 	"\vAMLCustomer\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\tR\n" +
@@ -1978,7 +2407,11 @@ const file_proto_accounting_aml_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf4\x03\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12N\n" +
+	"\x15relationship_ended_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\x13relationshipEndedAt\x127\n" +
+	"\terased_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\berasedAt\x12!\n" +
+	"\ferasure_mode\x18\x14 \x01(\tR\verasureMode\x12\x18\n" +
+	"\aversion\x18\x15 \x01(\x03R\aversion\"\xf4\x03\n" +
 	"\x0eAMLTransaction\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x12*\n" +
 	"\x06amount\x18\x02 \x01(\v2\x12.accounting.AmountR\x06amount\x12\x1a\n" +
@@ -2042,7 +2475,39 @@ const file_proto_accounting_aml_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a?\n" +
 	"\x11AlertsByTypeEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01*\xa6\x01\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xb1\x03\n" +
+	"\x13AMLCustomerActivity\x12\x1f\n" +
+	"\vcustomer_id\x18\x01 \x01(\tR\n" +
+	"customerId\x12\x1f\n" +
+	"\valert_count\x18\x02 \x01(\x05R\n" +
+	"alertCount\x12!\n" +
+	"\falert_volume\x18\x03 \x01(\x03R\valertVolume\x12W\n" +
+	"\x0ealerts_by_type\x18\x04 \x03(\v21.accounting.AMLCustomerActivity.AlertsByTypeEntryR\falertsByType\x12?\n" +
+	"\rlast_activity\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\x12+\n" +
+	"\x11transaction_count\x18\x06 \x01(\x03R\x10transactionCount\x12-\n" +
+	"\x12transaction_volume\x18\a \x01(\x03R\x11transactionVolume\x1a?\n" +
+	"\x11AlertsByTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xe2\x05\n" +
+	"\x11AMLDailyAggregate\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12!\n" +
+	"\ftotal_alerts\x18\x02 \x01(\x05R\vtotalAlerts\x12e\n" +
+	"\x14alerts_by_risk_level\x18\x03 \x03(\v24.accounting.AMLDailyAggregate.AlertsByRiskLevelEntryR\x11alertsByRiskLevel\x12U\n" +
+	"\x0ealerts_by_type\x18\x04 \x03(\v2/.accounting.AMLDailyAggregate.AlertsByTypeEntryR\falertsByType\x12#\n" +
+	"\rclosed_alerts\x18\x05 \x01(\x05R\fclosedAlerts\x12'\n" +
+	"\x0ffalse_positives\x18\x06 \x01(\x05R\x0efalsePositives\x12+\n" +
+	"\x11transaction_count\x18\a \x01(\x03R\x10transactionCount\x12-\n" +
+	"\x12transaction_volume\x18\b \x01(\x03R\x11transactionVolume\x12J\n" +
+	"\tcustomers\x18\t \x03(\v2,.accounting.AMLDailyAggregate.CustomersEntryR\tcustomers\x1aD\n" +
+	"\x16AlertsByRiskLevelEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a?\n" +
+	"\x11AlertsByTypeEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a]\n" +
+	"\x0eCustomersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x125\n" +
+	"\x05value\x18\x02 \x01(\v2\x1f.accounting.AMLCustomerActivityR\x05value:\x028\x01*\xa6\x01\n" +
 	"\fAMLFramework\x12\x1d\n" +
 	"\x19AML_FRAMEWORK_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11AML_FRAMEWORK_BSA\x10\x01\x12\x16\n" +
@@ -2055,7 +2520,7 @@ const file_proto_accounting_aml_proto_rawDesc = "" +
 	"\x12AML_RISK_LEVEL_LOW\x10\x01\x12\x19\n" +
 	"\x15AML_RISK_LEVEL_MEDIUM\x10\x02\x12\x17\n" +
 	"\x13AML_RISK_LEVEL_HIGH\x10\x03\x12\x1b\n" +
-	"\x17AML_RISK_LEVEL_CRITICAL\x10\x04*\xec\b\n" +
+	"\x17AML_RISK_LEVEL_CRITICAL\x10\x04*\xb3\t\n" +
 	"\vAMLRuleType\x12\x1d\n" +
 	"\x19AML_RULE_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11AML_RULE_TYPE_CTR\x10\x01\x12\x15\n" +
@@ -2092,7 +2557,9 @@ const file_proto_accounting_aml_proto_rawDesc = "" +
 	"\x1bAML_RULE_TYPE_PREPAID_CARDS\x10\x1f\x12 \n" +
 	"\x1cAML_RULE_TYPE_CRYPTOCURRENCY\x10 \x12&\n" +
 	"\"AML_RULE_TYPE_JUST_UNDER_THRESHOLD\x10!\x12&\n" +
-	"\"AML_RULE_TYPE_UNEXPECTED_GEOGRAPHY\x10\"B\x1dZ\x1baccounting/proto/accountingb\x06proto3"
+	"\"AML_RULE_TYPE_UNEXPECTED_GEOGRAPHY\x10\"\x12!\n" +
+	"\x1dAML_RULE_TYPE_CHARGEBACK_RATE\x10#\x12\"\n" +
+	"\x1eAML_RULE_TYPE_CLIENT_SHORTFALL\x10$B\x1dZ\x1baccounting/proto/accountingb\x06proto3"
 
 var (
 	file_proto_accounting_aml_proto_rawDescOnce sync.Once
@@ -2107,81 +2574,100 @@ func file_proto_accounting_aml_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_accounting_aml_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_proto_accounting_aml_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_proto_accounting_aml_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_proto_accounting_aml_proto_goTypes = []any{
 	(AMLFramework)(0),             // 0: accounting.AMLFramework
 	(AMLRiskLevel)(0),             // 1: accounting.AMLRiskLevel
 	(AMLRuleType)(0),              // 2: accounting.AMLRuleType
-	(*InvestigationAction)(nil),   // 3: accounting.InvestigationAction
-	(*InvestigationNote)(nil),     // 4: accounting.InvestigationNote
-	(*AMLInvestigation)(nil),      // 5: accounting.AMLInvestigation
-	(*AMLEvidence)(nil),           // 6: accounting.AMLEvidence
-	(*AMLDisposition)(nil),        // 7: accounting.AMLDisposition
-	(*AMLAlert)(nil),              // 8: accounting.AMLAlert
-	(*AMLRule)(nil),               // 9: accounting.AMLRule
-	(*AMLCustomer)(nil),           // 10: accounting.AMLCustomer
-	(*AMLTransaction)(nil),        // 11: accounting.AMLTransaction
-	(*CustomerRiskSummary)(nil),   // 12: accounting.CustomerRiskSummary
-	(*AMLComplianceMetrics)(nil),  // 13: accounting.AMLComplianceMetrics
-	(*AMLTrendAnalysis)(nil),      // 14: accounting.AMLTrendAnalysis
-	(*AMLRecommendation)(nil),     // 15: accounting.AMLRecommendation
-	(*AMLDashboard)(nil),          // 16: accounting.AMLDashboard
-	nil,                           // 17: accounting.AMLRule.ThresholdsEntry
-	nil,                           // 18: accounting.AMLRule.TimeWindowsEntry
-	nil,                           // 19: accounting.AMLDashboard.AlertsByRiskLevelEntry
-	nil,                           // 20: accounting.AMLDashboard.AlertsByTypeEntry
-	(*timestamppb.Timestamp)(nil), // 21: google.protobuf.Timestamp
-	(*Amount)(nil),                // 22: accounting.Amount
+	(*AMLValue)(nil),              // 3: accounting.AMLValue
+	(*InvestigationAction)(nil),   // 4: accounting.InvestigationAction
+	(*InvestigationNote)(nil),     // 5: accounting.InvestigationNote
+	(*AMLInvestigation)(nil),      // 6: accounting.AMLInvestigation
+	(*AMLEvidence)(nil),           // 7: accounting.AMLEvidence
+	(*AMLDisposition)(nil),        // 8: accounting.AMLDisposition
+	(*AMLAlert)(nil),              // 9: accounting.AMLAlert
+	(*AMLRule)(nil),               // 10: accounting.AMLRule
+	(*AMLCustomer)(nil),           // 11: accounting.AMLCustomer
+	(*AMLTransaction)(nil),        // 12: accounting.AMLTransaction
+	(*CustomerRiskSummary)(nil),   // 13: accounting.CustomerRiskSummary
+	(*AMLComplianceMetrics)(nil),  // 14: accounting.AMLComplianceMetrics
+	(*AMLTrendAnalysis)(nil),      // 15: accounting.AMLTrendAnalysis
+	(*AMLRecommendation)(nil),     // 16: accounting.AMLRecommendation
+	(*AMLDashboard)(nil),          // 17: accounting.AMLDashboard
+	(*AMLCustomerActivity)(nil),   // 18: accounting.AMLCustomerActivity
+	(*AMLDailyAggregate)(nil),     // 19: accounting.AMLDailyAggregate
+	nil,                           // 20: accounting.AMLRule.ThresholdsEntry
+	nil,                           // 21: accounting.AMLRule.TimeWindowsEntry
+	nil,                           // 22: accounting.AMLRule.ThresholdValuesEntry
+	nil,                           // 23: accounting.AMLDashboard.AlertsByRiskLevelEntry
+	nil,                           // 24: accounting.AMLDashboard.AlertsByTypeEntry
+	nil,                           // 25: accounting.AMLCustomerActivity.AlertsByTypeEntry
+	nil,                           // 26: accounting.AMLDailyAggregate.AlertsByRiskLevelEntry
+	nil,                           // 27: accounting.AMLDailyAggregate.AlertsByTypeEntry
+	nil,                           // 28: accounting.AMLDailyAggregate.CustomersEntry
+	(*timestamppb.Timestamp)(nil), // 29: google.protobuf.Timestamp
+	(*Amount)(nil),                // 30: accounting.Amount
 }
 var file_proto_accounting_aml_proto_depIdxs = []int32{
-	21, // 0: accounting.InvestigationAction.taken_at:type_name -> google.protobuf.Timestamp
-	21, // 1: accounting.InvestigationNote.created_at:type_name -> google.protobuf.Timestamp
-	21, // 2: accounting.AMLInvestigation.started_at:type_name -> google.protobuf.Timestamp
-	21, // 3: accounting.AMLInvestigation.completed_at:type_name -> google.protobuf.Timestamp
-	3,  // 4: accounting.AMLInvestigation.actions:type_name -> accounting.InvestigationAction
-	4,  // 5: accounting.AMLInvestigation.notes:type_name -> accounting.InvestigationNote
-	21, // 6: accounting.AMLEvidence.collected_at:type_name -> google.protobuf.Timestamp
-	21, // 7: accounting.AMLDisposition.decided_at:type_name -> google.protobuf.Timestamp
-	2,  // 8: accounting.AMLAlert.rule_type:type_name -> accounting.AMLRuleType
-	0,  // 9: accounting.AMLAlert.framework:type_name -> accounting.AMLFramework
-	1,  // 10: accounting.AMLAlert.risk_level:type_name -> accounting.AMLRiskLevel
-	22, // 11: accounting.AMLAlert.amount:type_name -> accounting.Amount
-	21, // 12: accounting.AMLAlert.detected_at:type_name -> google.protobuf.Timestamp
-	5,  // 13: accounting.AMLAlert.investigation:type_name -> accounting.AMLInvestigation
-	6,  // 14: accounting.AMLAlert.evidence:type_name -> accounting.AMLEvidence
-	7,  // 15: accounting.AMLAlert.dispositions:type_name -> accounting.AMLDisposition
-	21, // 16: accounting.AMLAlert.created_at:type_name -> google.protobuf.Timestamp
-	21, // 17: accounting.AMLAlert.updated_at:type_name -> google.protobuf.Timestamp
-	2,  // 18: accounting.AMLRule.type:type_name -> accounting.AMLRuleType
-	0,  // 19: accounting.AMLRule.framework:type_name -> accounting.AMLFramework
-	17, // 20: accounting.AMLRule.thresholds:type_name -> accounting.AMLRule.ThresholdsEntry
-	18, // 21: accounting.AMLRule.time_windows:type_name -> accounting.AMLRule.TimeWindowsEntry
-	21, // 22: accounting.AMLRule.created_at:type_name -> google.protobuf.Timestamp
-	21, // 23: accounting.AMLRule.updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 24: accounting.AMLCustomer.risk_level:type_name -> accounting.AMLRiskLevel
-	21, // 25: accounting.AMLCustomer.last_kyc_date:type_name -> google.protobuf.Timestamp
-	21, // 26: accounting.AMLCustomer.last_cdd_date:type_name -> google.protobuf.Timestamp
-	21, // 27: accounting.AMLCustomer.next_review_date:type_name -> google.protobuf.Timestamp
-	21, // 28: accounting.AMLCustomer.onboarding_date:type_name -> google.protobuf.Timestamp
-	21, // 29: accounting.AMLCustomer.created_at:type_name -> google.protobuf.Timestamp
-	21, // 30: accounting.AMLCustomer.updated_at:type_name -> google.protobuf.Timestamp
-	22, // 31: accounting.AMLTransaction.amount:type_name -> accounting.Amount
-	21, // 32: accounting.AMLTransaction.date:type_name -> google.protobuf.Timestamp
-	21, // 33: accounting.CustomerRiskSummary.last_activity:type_name -> google.protobuf.Timestamp
-	21, // 34: accounting.AMLRecommendation.due_date:type_name -> google.protobuf.Timestamp
-	21, // 35: accounting.AMLDashboard.period_start:type_name -> google.protobuf.Timestamp
-	21, // 36: accounting.AMLDashboard.period_end:type_name -> google.protobuf.Timestamp
-	19, // 37: accounting.AMLDashboard.alerts_by_risk_level:type_name -> accounting.AMLDashboard.AlertsByRiskLevelEntry
-	20, // 38: accounting.AMLDashboard.alerts_by_type:type_name -> accounting.AMLDashboard.AlertsByTypeEntry
-	12, // 39: accounting.AMLDashboard.top_risky_customers:type_name -> accounting.CustomerRiskSummary
-	13, // 40: accounting.AMLDashboard.compliance_metrics:type_name -> accounting.AMLComplianceMetrics
-	14, // 41: accounting.AMLDashboard.trend_analysis:type_name -> accounting.AMLTrendAnalysis
-	15, // 42: accounting.AMLDashboard.recommended_actions:type_name -> accounting.AMLRecommendation
-	43, // [43:43] is the sub-list for method output_type
-	43, // [43:43] is the sub-list for method input_type
-	43, // [43:43] is the sub-list for extension type_name
-	43, // [43:43] is the sub-list for extension extendee
-	0,  // [0:43] is the sub-list for field type_name
+	29, // 0: accounting.InvestigationAction.taken_at:type_name -> google.protobuf.Timestamp
+	29, // 1: accounting.InvestigationNote.created_at:type_name -> google.protobuf.Timestamp
+	29, // 2: accounting.AMLInvestigation.started_at:type_name -> google.protobuf.Timestamp
+	29, // 3: accounting.AMLInvestigation.completed_at:type_name -> google.protobuf.Timestamp
+	4,  // 4: accounting.AMLInvestigation.actions:type_name -> accounting.InvestigationAction
+	5,  // 5: accounting.AMLInvestigation.notes:type_name -> accounting.InvestigationNote
+	29, // 6: accounting.AMLEvidence.collected_at:type_name -> google.protobuf.Timestamp
+	3,  // 7: accounting.AMLEvidence.value:type_name -> accounting.AMLValue
+	29, // 8: accounting.AMLDisposition.decided_at:type_name -> google.protobuf.Timestamp
+	2,  // 9: accounting.AMLAlert.rule_type:type_name -> accounting.AMLRuleType
+	0,  // 10: accounting.AMLAlert.framework:type_name -> accounting.AMLFramework
+	1,  // 11: accounting.AMLAlert.risk_level:type_name -> accounting.AMLRiskLevel
+	30, // 12: accounting.AMLAlert.amount:type_name -> accounting.Amount
+	29, // 13: accounting.AMLAlert.detected_at:type_name -> google.protobuf.Timestamp
+	6,  // 14: accounting.AMLAlert.investigation:type_name -> accounting.AMLInvestigation
+	7,  // 15: accounting.AMLAlert.evidence:type_name -> accounting.AMLEvidence
+	8,  // 16: accounting.AMLAlert.dispositions:type_name -> accounting.AMLDisposition
+	29, // 17: accounting.AMLAlert.created_at:type_name -> google.protobuf.Timestamp
+	29, // 18: accounting.AMLAlert.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 19: accounting.AMLRule.type:type_name -> accounting.AMLRuleType
+	0,  // 20: accounting.AMLRule.framework:type_name -> accounting.AMLFramework
+	20, // 21: accounting.AMLRule.thresholds:type_name -> accounting.AMLRule.ThresholdsEntry
+	21, // 22: accounting.AMLRule.time_windows:type_name -> accounting.AMLRule.TimeWindowsEntry
+	29, // 23: accounting.AMLRule.created_at:type_name -> google.protobuf.Timestamp
+	29, // 24: accounting.AMLRule.updated_at:type_name -> google.protobuf.Timestamp
+	22, // 25: accounting.AMLRule.threshold_values:type_name -> accounting.AMLRule.ThresholdValuesEntry
+	1,  // 26: accounting.AMLCustomer.risk_level:type_name -> accounting.AMLRiskLevel
+	29, // 27: accounting.AMLCustomer.last_kyc_date:type_name -> google.protobuf.Timestamp
+	29, // 28: accounting.AMLCustomer.last_cdd_date:type_name -> google.protobuf.Timestamp
+	29, // 29: accounting.AMLCustomer.next_review_date:type_name -> google.protobuf.Timestamp
+	29, // 30: accounting.AMLCustomer.onboarding_date:type_name -> google.protobuf.Timestamp
+	29, // 31: accounting.AMLCustomer.created_at:type_name -> google.protobuf.Timestamp
+	29, // 32: accounting.AMLCustomer.updated_at:type_name -> google.protobuf.Timestamp
+	29, // 33: accounting.AMLCustomer.relationship_ended_at:type_name -> google.protobuf.Timestamp
+	29, // 34: accounting.AMLCustomer.erased_at:type_name -> google.protobuf.Timestamp
+	30, // 35: accounting.AMLTransaction.amount:type_name -> accounting.Amount
+	29, // 36: accounting.AMLTransaction.date:type_name -> google.protobuf.Timestamp
+	29, // 37: accounting.CustomerRiskSummary.last_activity:type_name -> google.protobuf.Timestamp
+	29, // 38: accounting.AMLRecommendation.due_date:type_name -> google.protobuf.Timestamp
+	29, // 39: accounting.AMLDashboard.period_start:type_name -> google.protobuf.Timestamp
+	29, // 40: accounting.AMLDashboard.period_end:type_name -> google.protobuf.Timestamp
+	23, // 41: accounting.AMLDashboard.alerts_by_risk_level:type_name -> accounting.AMLDashboard.AlertsByRiskLevelEntry
+	24, // 42: accounting.AMLDashboard.alerts_by_type:type_name -> accounting.AMLDashboard.AlertsByTypeEntry
+	13, // 43: accounting.AMLDashboard.top_risky_customers:type_name -> accounting.CustomerRiskSummary
+	14, // 44: accounting.AMLDashboard.compliance_metrics:type_name -> accounting.AMLComplianceMetrics
+	15, // 45: accounting.AMLDashboard.trend_analysis:type_name -> accounting.AMLTrendAnalysis
+	16, // 46: accounting.AMLDashboard.recommended_actions:type_name -> accounting.AMLRecommendation
+	25, // 47: accounting.AMLCustomerActivity.alerts_by_type:type_name -> accounting.AMLCustomerActivity.AlertsByTypeEntry
+	29, // 48: accounting.AMLCustomerActivity.last_activity:type_name -> google.protobuf.Timestamp
+	26, // 49: accounting.AMLDailyAggregate.alerts_by_risk_level:type_name -> accounting.AMLDailyAggregate.AlertsByRiskLevelEntry
+	27, // 50: accounting.AMLDailyAggregate.alerts_by_type:type_name -> accounting.AMLDailyAggregate.AlertsByTypeEntry
+	28, // 51: accounting.AMLDailyAggregate.customers:type_name -> accounting.AMLDailyAggregate.CustomersEntry
+	3,  // 52: accounting.AMLRule.ThresholdValuesEntry.value:type_name -> accounting.AMLValue
+	18, // 53: accounting.AMLDailyAggregate.CustomersEntry.value:type_name -> accounting.AMLCustomerActivity
+	54, // [54:54] is the sub-list for method output_type
+	54, // [54:54] is the sub-list for method input_type
+	54, // [54:54] is the sub-list for extension type_name
+	54, // [54:54] is the sub-list for extension extendee
+	0,  // [0:54] is the sub-list for field type_name
 }
 
 func init() { file_proto_accounting_aml_proto_init() }
@@ -2190,13 +2676,21 @@ func file_proto_accounting_aml_proto_init() {
 		return
 	}
 	file_proto_accounting_accounting_proto_init()
+	file_proto_accounting_aml_proto_msgTypes[0].OneofWrappers = []any{
+		(*AMLValue_IntValue)(nil),
+		(*AMLValue_Int64Value)(nil),
+		(*AMLValue_FloatValue)(nil),
+		(*AMLValue_StringValue)(nil),
+		(*AMLValue_BoolValue)(nil),
+		(*AMLValue_JsonValue)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_accounting_aml_proto_rawDesc), len(file_proto_accounting_aml_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   18,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -333,6 +333,9 @@ type TaxRule struct {
 	EffectiveFrom *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=effective_from,json=effectiveFrom,proto3" json:"effective_from,omitempty"`
 	EffectiveTo   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=effective_to,json=effectiveTo,proto3" json:"effective_to,omitempty"`
 	Active        bool                   `protobuf:"varint,11,opt,name=active,proto3" json:"active,omitempty"`
+	Brackets      []*TaxBracket          `protobuf:"bytes,12,rep,name=brackets,proto3" json:"brackets,omitempty"`
+	Compound      bool                   `protobuf:"varint,13,opt,name=compound,proto3" json:"compound,omitempty"`
+	Priority      int32                  `protobuf:"varint,14,opt,name=priority,proto3" json:"priority,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -444,6 +447,242 @@ func (x *TaxRule) GetActive() bool {
 	return false
 }
 
+func (x *TaxRule) GetBrackets() []*TaxBracket {
+	if x != nil {
+		return x.Brackets
+	}
+	return nil
+}
+
+func (x *TaxRule) GetCompound() bool {
+	if x != nil {
+		return x.Compound
+	}
+	return false
+}
+
+func (x *TaxRule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+// TaxBracket
+type TaxBracket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          float64                `protobuf:"fixed64,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            float64                `protobuf:"fixed64,2,opt,name=to,proto3" json:"to,omitempty"`
+	Rate          float64                `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxBracket) Reset() {
+	*x = TaxBracket{}
+	mi := &file_proto_accounting_compliance_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxBracket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxBracket) ProtoMessage() {}
+
+func (x *TaxBracket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_compliance_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxBracket.ProtoReflect.Descriptor instead.
+func (*TaxBracket) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_compliance_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TaxBracket) GetFrom() float64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *TaxBracket) GetTo() float64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *TaxBracket) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+// TaxComponent
+type TaxComponent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Compound      bool                   `protobuf:"varint,3,opt,name=compound,proto3" json:"compound,omitempty"`
+	TaxableAmount float64                `protobuf:"fixed64,4,opt,name=taxable_amount,json=taxableAmount,proto3" json:"taxable_amount,omitempty"`
+	TaxRate       float64                `protobuf:"fixed64,5,opt,name=tax_rate,json=taxRate,proto3" json:"tax_rate,omitempty"`
+	TaxAmount     float64                `protobuf:"fixed64,6,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"`
+	Exemptions    []string               `protobuf:"bytes,7,rep,name=exemptions,proto3" json:"exemptions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxComponent) Reset() {
+	*x = TaxComponent{}
+	mi := &file_proto_accounting_compliance_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxComponent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxComponent) ProtoMessage() {}
+
+func (x *TaxComponent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_compliance_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxComponent.ProtoReflect.Descriptor instead.
+func (*TaxComponent) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_compliance_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TaxComponent) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *TaxComponent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaxComponent) GetCompound() bool {
+	if x != nil {
+		return x.Compound
+	}
+	return false
+}
+
+func (x *TaxComponent) GetTaxableAmount() float64 {
+	if x != nil {
+		return x.TaxableAmount
+	}
+	return 0
+}
+
+func (x *TaxComponent) GetTaxRate() float64 {
+	if x != nil {
+		return x.TaxRate
+	}
+	return 0
+}
+
+func (x *TaxComponent) GetTaxAmount() float64 {
+	if x != nil {
+		return x.TaxAmount
+	}
+	return 0
+}
+
+func (x *TaxComponent) GetExemptions() []string {
+	if x != nil {
+		return x.Exemptions
+	}
+	return nil
+}
+
+// TaxRounding
+type TaxRounding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jurisdiction  TaxJurisdiction        `protobuf:"varint,1,opt,name=jurisdiction,proto3,enum=accounting.TaxJurisdiction" json:"jurisdiction,omitempty"`
+	Policy        string                 `protobuf:"bytes,2,opt,name=policy,proto3" json:"policy,omitempty"`
+	Level         string                 `protobuf:"bytes,3,opt,name=level,proto3" json:"level,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxRounding) Reset() {
+	*x = TaxRounding{}
+	mi := &file_proto_accounting_compliance_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxRounding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxRounding) ProtoMessage() {}
+
+func (x *TaxRounding) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_compliance_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxRounding.ProtoReflect.Descriptor instead.
+func (*TaxRounding) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_compliance_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TaxRounding) GetJurisdiction() TaxJurisdiction {
+	if x != nil {
+		return x.Jurisdiction
+	}
+	return TaxJurisdiction_TAX_JURISDICTION_UNSPECIFIED
+}
+
+func (x *TaxRounding) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *TaxRounding) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
 // TaxCalculation
 type TaxCalculation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -454,13 +693,15 @@ type TaxCalculation struct {
 	TaxAmount     float64                `protobuf:"fixed64,5,opt,name=tax_amount,json=taxAmount,proto3" json:"tax_amount,omitempty"`
 	Exemptions    []string               `protobuf:"bytes,6,rep,name=exemptions,proto3" json:"exemptions,omitempty"`
 	CalculatedAt  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=calculated_at,json=calculatedAt,proto3" json:"calculated_at,omitempty"`
+	Breakdown     *CalculationBreakdown  `protobuf:"bytes,8,opt,name=breakdown,proto3" json:"breakdown,omitempty"`
+	Components    []*TaxComponent        `protobuf:"bytes,9,rep,name=components,proto3" json:"components,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TaxCalculation) Reset() {
 	*x = TaxCalculation{}
-	mi := &file_proto_accounting_compliance_proto_msgTypes[2]
+	mi := &file_proto_accounting_compliance_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -472,7 +713,7 @@ func (x *TaxCalculation) String() string {
 func (*TaxCalculation) ProtoMessage() {}
 
 func (x *TaxCalculation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_compliance_proto_msgTypes[2]
+	mi := &file_proto_accounting_compliance_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,7 +726,7 @@ func (x *TaxCalculation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaxCalculation.ProtoReflect.Descriptor instead.
 func (*TaxCalculation) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_compliance_proto_rawDescGZIP(), []int{2}
+	return file_proto_accounting_compliance_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *TaxCalculation) GetRuleId() string {
@@ -537,26 +778,52 @@ func (x *TaxCalculation) GetCalculatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *TaxCalculation) GetBreakdown() *CalculationBreakdown {
+	if x != nil {
+		return x.Breakdown
+	}
+	return nil
+}
+
+func (x *TaxCalculation) GetComponents() []*TaxComponent {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
 // ComplianceViolation
 type ComplianceViolation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	RuleId        string                 `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
-	TransactionId string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	AccountId     string                 `protobuf:"bytes,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	Severity      string                 `protobuf:"bytes,6,opt,name=severity,proto3" json:"severity,omitempty"`
-	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
-	DetectedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
-	ResolvedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
-	Notes         string                 `protobuf:"bytes,10,opt,name=notes,proto3" json:"notes,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RuleId            string                 `protobuf:"bytes,2,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	TransactionId     string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	AccountId         string                 `protobuf:"bytes,4,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Description       string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Severity          string                 `protobuf:"bytes,6,opt,name=severity,proto3" json:"severity,omitempty"`
+	Status            string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	DetectedAt        *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	ResolvedAt        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	Notes             string                 `protobuf:"bytes,10,opt,name=notes,proto3" json:"notes,omitempty"`
+	AssignedTo        string                 `protobuf:"bytes,11,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	AcknowledgedBy    string                 `protobuf:"bytes,12,opt,name=acknowledged_by,json=acknowledgedBy,proto3" json:"acknowledged_by,omitempty"`
+	AcknowledgedAt    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`
+	ResolvedBy        string                 `protobuf:"bytes,14,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	DueAt             *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=due_at,json=dueAt,proto3" json:"due_at,omitempty"`
+	EscalatedAt       *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=escalated_at,json=escalatedAt,proto3" json:"escalated_at,omitempty"`
+	WaiverRequestedBy string                 `protobuf:"bytes,17,opt,name=waiver_requested_by,json=waiverRequestedBy,proto3" json:"waiver_requested_by,omitempty"`
+	WaivedBy          string                 `protobuf:"bytes,18,opt,name=waived_by,json=waivedBy,proto3" json:"waived_by,omitempty"`
+	RecurrenceOf      string                 `protobuf:"bytes,19,opt,name=recurrence_of,json=recurrenceOf,proto3" json:"recurrence_of,omitempty"`
+	Occurrences       int32                  `protobuf:"varint,20,opt,name=occurrences,proto3" json:"occurrences,omitempty"`
+	Comments          []*ViolationComment    `protobuf:"bytes,21,rep,name=comments,proto3" json:"comments,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ComplianceViolation) Reset() {
 	*x = ComplianceViolation{}
-	mi := &file_proto_accounting_compliance_proto_msgTypes[3]
+	mi := &file_proto_accounting_compliance_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -568,7 +835,7 @@ func (x *ComplianceViolation) String() string {
 func (*ComplianceViolation) ProtoMessage() {}
 
 func (x *ComplianceViolation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_compliance_proto_msgTypes[3]
+	mi := &file_proto_accounting_compliance_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -581,7 +848,7 @@ func (x *ComplianceViolation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ComplianceViolation.ProtoReflect.Descriptor instead.
 func (*ComplianceViolation) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_compliance_proto_rawDescGZIP(), []int{3}
+	return file_proto_accounting_compliance_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ComplianceViolation) GetId() string {
@@ -654,6 +921,159 @@ func (x *ComplianceViolation) GetNotes() string {
 	return ""
 }
 
+func (x *ComplianceViolation) GetAssignedTo() string {
+	if x != nil {
+		return x.AssignedTo
+	}
+	return ""
+}
+
+func (x *ComplianceViolation) GetAcknowledgedBy() string {
+	if x != nil {
+		return x.AcknowledgedBy
+	}
+	return ""
+}
+
+func (x *ComplianceViolation) GetAcknowledgedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return nil
+}
+
+func (x *ComplianceViolation) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *ComplianceViolation) GetDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueAt
+	}
+	return nil
+}
+
+func (x *ComplianceViolation) GetEscalatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EscalatedAt
+	}
+	return nil
+}
+
+func (x *ComplianceViolation) GetWaiverRequestedBy() string {
+	if x != nil {
+		return x.WaiverRequestedBy
+	}
+	return ""
+}
+
+func (x *ComplianceViolation) GetWaivedBy() string {
+	if x != nil {
+		return x.WaivedBy
+	}
+	return ""
+}
+
+func (x *ComplianceViolation) GetRecurrenceOf() string {
+	if x != nil {
+		return x.RecurrenceOf
+	}
+	return ""
+}
+
+func (x *ComplianceViolation) GetOccurrences() int32 {
+	if x != nil {
+		return x.Occurrences
+	}
+	return 0
+}
+
+func (x *ComplianceViolation) GetComments() []*ViolationComment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ComplianceViolation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// ViolationComment
+type ViolationComment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=at,proto3" json:"at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViolationComment) Reset() {
+	*x = ViolationComment{}
+	mi := &file_proto_accounting_compliance_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViolationComment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViolationComment) ProtoMessage() {}
+
+func (x *ViolationComment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_compliance_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViolationComment.ProtoReflect.Descriptor instead.
+func (*ViolationComment) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_compliance_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ViolationComment) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ViolationComment) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ViolationComment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ViolationComment) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
 // TaxReturn
 type TaxReturn struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -669,13 +1089,26 @@ type TaxReturn struct {
 	SupportingDocs []string               `protobuf:"bytes,10,rep,name=supporting_docs,json=supportingDocs,proto3" json:"supporting_docs,omitempty"`
 	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Calculations   []*TaxCalculation      `protobuf:"bytes,13,rep,name=calculations,proto3" json:"calculations,omitempty"`
+	CompanyId      string                 `protobuf:"bytes,14,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	PeriodStart    *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd      *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	GrossRevenue   float64                `protobuf:"fixed64,17,opt,name=gross_revenue,json=grossRevenue,proto3" json:"gross_revenue,omitempty"`
+	TaxableRevenue float64                `protobuf:"fixed64,18,opt,name=taxable_revenue,json=taxableRevenue,proto3" json:"taxable_revenue,omitempty"`
+	TaxPaid        float64                `protobuf:"fixed64,19,opt,name=tax_paid,json=taxPaid,proto3" json:"tax_paid,omitempty"`
+	TaxOwed        float64                `protobuf:"fixed64,20,opt,name=tax_owed,json=taxOwed,proto3" json:"tax_owed,omitempty"`
+	VatFormat      string                 `protobuf:"bytes,21,opt,name=vat_format,json=vatFormat,proto3" json:"vat_format,omitempty"`
+	VatMappingId   string                 `protobuf:"bytes,22,opt,name=vat_mapping_id,json=vatMappingId,proto3" json:"vat_mapping_id,omitempty"`
+	Currency       string                 `protobuf:"bytes,23,opt,name=currency,proto3" json:"currency,omitempty"`
+	PeriodKey      string                 `protobuf:"bytes,24,opt,name=period_key,json=periodKey,proto3" json:"period_key,omitempty"`
+	VatBoxes       []*VATBoxValue         `protobuf:"bytes,25,rep,name=vat_boxes,json=vatBoxes,proto3" json:"vat_boxes,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *TaxReturn) Reset() {
 	*x = TaxReturn{}
-	mi := &file_proto_accounting_compliance_proto_msgTypes[4]
+	mi := &file_proto_accounting_compliance_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -687,7 +1120,7 @@ func (x *TaxReturn) String() string {
 func (*TaxReturn) ProtoMessage() {}
 
 func (x *TaxReturn) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_compliance_proto_msgTypes[4]
+	mi := &file_proto_accounting_compliance_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -700,7 +1133,7 @@ func (x *TaxReturn) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaxReturn.ProtoReflect.Descriptor instead.
 func (*TaxReturn) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_compliance_proto_rawDescGZIP(), []int{4}
+	return file_proto_accounting_compliance_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *TaxReturn) GetId() string {
@@ -787,6 +1220,158 @@ func (x *TaxReturn) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *TaxReturn) GetCalculations() []*TaxCalculation {
+	if x != nil {
+		return x.Calculations
+	}
+	return nil
+}
+
+func (x *TaxReturn) GetCompanyId() string {
+	if x != nil {
+		return x.CompanyId
+	}
+	return ""
+}
+
+func (x *TaxReturn) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *TaxReturn) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
+func (x *TaxReturn) GetGrossRevenue() float64 {
+	if x != nil {
+		return x.GrossRevenue
+	}
+	return 0
+}
+
+func (x *TaxReturn) GetTaxableRevenue() float64 {
+	if x != nil {
+		return x.TaxableRevenue
+	}
+	return 0
+}
+
+func (x *TaxReturn) GetTaxPaid() float64 {
+	if x != nil {
+		return x.TaxPaid
+	}
+	return 0
+}
+
+func (x *TaxReturn) GetTaxOwed() float64 {
+	if x != nil {
+		return x.TaxOwed
+	}
+	return 0
+}
+
+func (x *TaxReturn) GetVatFormat() string {
+	if x != nil {
+		return x.VatFormat
+	}
+	return ""
+}
+
+func (x *TaxReturn) GetVatMappingId() string {
+	if x != nil {
+		return x.VatMappingId
+	}
+	return ""
+}
+
+func (x *TaxReturn) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TaxReturn) GetPeriodKey() string {
+	if x != nil {
+		return x.PeriodKey
+	}
+	return ""
+}
+
+func (x *TaxReturn) GetVatBoxes() []*VATBoxValue {
+	if x != nil {
+		return x.VatBoxes
+	}
+	return nil
+}
+
+// VATBoxValue
+type VATBoxValue struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Box           string                 `protobuf:"bytes,1,opt,name=box,proto3" json:"box,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VATBoxValue) Reset() {
+	*x = VATBoxValue{}
+	mi := &file_proto_accounting_compliance_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VATBoxValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VATBoxValue) ProtoMessage() {}
+
+func (x *VATBoxValue) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_compliance_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VATBoxValue.ProtoReflect.Descriptor instead.
+func (*VATBoxValue) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_compliance_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VATBoxValue) GetBox() string {
+	if x != nil {
+		return x.Box
+	}
+	return ""
+}
+
+func (x *VATBoxValue) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *VATBoxValue) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
 var File_proto_accounting_compliance_proto protoreflect.FileDescriptor
 
 const file_proto_accounting_compliance_proto_rawDesc = "" +
@@ -807,7 +1392,7 @@ const file_proto_accounting_compliance_proto_rawDesc = "" +
 	"\x06active\x18\t \x01(\bR\x06active\x129\n" +
 	"\n" +
 	"created_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xaa\x03\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x96\x04\n" +
 	"\aTaxRule\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12?\n" +
 	"\fjurisdiction\x18\x02 \x01(\x0e2\x1b.accounting.TaxJurisdictionR\fjurisdiction\x12.\n" +
@@ -824,7 +1409,30 @@ const file_proto_accounting_compliance_proto_rawDesc = "" +
 	"\x0eeffective_from\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\reffectiveFrom\x12=\n" +
 	"\feffective_to\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\veffectiveTo\x12\x16\n" +
-	"\x06active\x18\v \x01(\bR\x06active\"\x8c\x02\n" +
+	"\x06active\x18\v \x01(\bR\x06active\x122\n" +
+	"\bbrackets\x18\f \x03(\v2\x16.accounting.TaxBracketR\bbrackets\x12\x1a\n" +
+	"\bcompound\x18\r \x01(\bR\bcompound\x12\x1a\n" +
+	"\bpriority\x18\x0e \x01(\x05R\bpriority\"D\n" +
+	"\n" +
+	"TaxBracket\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x01R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x01R\x02to\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\x01R\x04rate\"\xd8\x01\n" +
+	"\fTaxComponent\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcompound\x18\x03 \x01(\bR\bcompound\x12%\n" +
+	"\x0etaxable_amount\x18\x04 \x01(\x01R\rtaxableAmount\x12\x19\n" +
+	"\btax_rate\x18\x05 \x01(\x01R\ataxRate\x12\x1d\n" +
+	"\n" +
+	"tax_amount\x18\x06 \x01(\x01R\ttaxAmount\x12\x1e\n" +
+	"\n" +
+	"exemptions\x18\a \x03(\tR\n" +
+	"exemptions\"|\n" +
+	"\vTaxRounding\x12?\n" +
+	"\fjurisdiction\x18\x01 \x01(\x0e2\x1b.accounting.TaxJurisdictionR\fjurisdiction\x12\x16\n" +
+	"\x06policy\x18\x02 \x01(\tR\x06policy\x12\x14\n" +
+	"\x05level\x18\x03 \x01(\tR\x05level\"\x86\x03\n" +
 	"\x0eTaxCalculation\x12\x17\n" +
 	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x1f\n" +
 	"\vbase_amount\x18\x02 \x01(\x01R\n" +
@@ -836,7 +1444,11 @@ const file_proto_accounting_compliance_proto_rawDesc = "" +
 	"\n" +
 	"exemptions\x18\x06 \x03(\tR\n" +
 	"exemptions\x12?\n" +
-	"\rcalculated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fcalculatedAt\"\xea\x02\n" +
+	"\rcalculated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fcalculatedAt\x12>\n" +
+	"\tbreakdown\x18\b \x01(\v2 .accounting.CalculationBreakdownR\tbreakdown\x128\n" +
+	"\n" +
+	"components\x18\t \x03(\v2\x18.accounting.TaxComponentR\n" +
+	"components\"\x95\a\n" +
 	"\x13ComplianceViolation\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\arule_id\x18\x02 \x01(\tR\x06ruleId\x12%\n" +
@@ -851,7 +1463,27 @@ const file_proto_accounting_compliance_proto_rawDesc = "" +
 	"\vresolved_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"resolvedAt\x12\x14\n" +
 	"\x05notes\x18\n" +
-	" \x01(\tR\x05notes\"\x8c\x04\n" +
+	" \x01(\tR\x05notes\x12\x1f\n" +
+	"\vassigned_to\x18\v \x01(\tR\n" +
+	"assignedTo\x12'\n" +
+	"\x0facknowledged_by\x18\f \x01(\tR\x0eacknowledgedBy\x12C\n" +
+	"\x0facknowledged_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x0eacknowledgedAt\x12\x1f\n" +
+	"\vresolved_by\x18\x0e \x01(\tR\n" +
+	"resolvedBy\x121\n" +
+	"\x06due_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\x05dueAt\x12=\n" +
+	"\fescalated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vescalatedAt\x12.\n" +
+	"\x13waiver_requested_by\x18\x11 \x01(\tR\x11waiverRequestedBy\x12\x1b\n" +
+	"\twaived_by\x18\x12 \x01(\tR\bwaivedBy\x12#\n" +
+	"\rrecurrence_of\x18\x13 \x01(\tR\frecurrenceOf\x12 \n" +
+	"\voccurrences\x18\x14 \x01(\x05R\voccurrences\x128\n" +
+	"\bcomments\x18\x15 \x03(\v2\x1c.accounting.ViolationCommentR\bcomments\x129\n" +
+	"\n" +
+	"updated_at\x18\x16 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x83\x01\n" +
+	"\x10ViolationComment\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12*\n" +
+	"\x02at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\"\x9f\b\n" +
 	"\tTaxReturn\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12?\n" +
 	"\fjurisdiction\x18\x02 \x01(\x0e2\x1b.accounting.TaxJurisdictionR\fjurisdiction\x12.\n" +
@@ -868,7 +1500,28 @@ const file_proto_accounting_compliance_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt*\x97\x01\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
+	"\fcalculations\x18\r \x03(\v2\x1a.accounting.TaxCalculationR\fcalculations\x12\x1d\n" +
+	"\n" +
+	"company_id\x18\x0e \x01(\tR\tcompanyId\x12=\n" +
+	"\fperiod_start\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x129\n" +
+	"\n" +
+	"period_end\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tperiodEnd\x12#\n" +
+	"\rgross_revenue\x18\x11 \x01(\x01R\fgrossRevenue\x12'\n" +
+	"\x0ftaxable_revenue\x18\x12 \x01(\x01R\x0etaxableRevenue\x12\x19\n" +
+	"\btax_paid\x18\x13 \x01(\x01R\ataxPaid\x12\x19\n" +
+	"\btax_owed\x18\x14 \x01(\x01R\ataxOwed\x12\x1d\n" +
+	"\n" +
+	"vat_format\x18\x15 \x01(\tR\tvatFormat\x12$\n" +
+	"\x0evat_mapping_id\x18\x16 \x01(\tR\fvatMappingId\x12\x1a\n" +
+	"\bcurrency\x18\x17 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"period_key\x18\x18 \x01(\tR\tperiodKey\x124\n" +
+	"\tvat_boxes\x18\x19 \x03(\v2\x17.accounting.VATBoxValueR\bvatBoxes\"Y\n" +
+	"\vVATBoxValue\x12\x10\n" +
+	"\x03box\x18\x01 \x01(\tR\x03box\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount*\x97\x01\n" +
 	"\x13ComplianceFramework\x12$\n" +
 	" COMPLIANCE_FRAMEWORK_UNSPECIFIED\x10\x00\x12\x1d\n" +
 	"\x19COMPLIANCE_FRAMEWORK_GAAP\x10\x01\x12\x1d\n" +
@@ -905,41 +1558,61 @@ func file_proto_accounting_compliance_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_accounting_compliance_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_proto_accounting_compliance_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_accounting_compliance_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_proto_accounting_compliance_proto_goTypes = []any{
 	(ComplianceFramework)(0),      // 0: accounting.ComplianceFramework
 	(TaxJurisdiction)(0),          // 1: accounting.TaxJurisdiction
 	(TaxType)(0),                  // 2: accounting.TaxType
 	(*ComplianceRule)(nil),        // 3: accounting.ComplianceRule
 	(*TaxRule)(nil),               // 4: accounting.TaxRule
-	(*TaxCalculation)(nil),        // 5: accounting.TaxCalculation
-	(*ComplianceViolation)(nil),   // 6: accounting.ComplianceViolation
-	(*TaxReturn)(nil),             // 7: accounting.TaxReturn
-	(AccountType)(0),              // 8: accounting.AccountType
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+	(*TaxBracket)(nil),            // 5: accounting.TaxBracket
+	(*TaxComponent)(nil),          // 6: accounting.TaxComponent
+	(*TaxRounding)(nil),           // 7: accounting.TaxRounding
+	(*TaxCalculation)(nil),        // 8: accounting.TaxCalculation
+	(*ComplianceViolation)(nil),   // 9: accounting.ComplianceViolation
+	(*ViolationComment)(nil),      // 10: accounting.ViolationComment
+	(*TaxReturn)(nil),             // 11: accounting.TaxReturn
+	(*VATBoxValue)(nil),           // 12: accounting.VATBoxValue
+	(AccountType)(0),              // 13: accounting.AccountType
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+	(*CalculationBreakdown)(nil),  // 15: accounting.CalculationBreakdown
 }
 var file_proto_accounting_compliance_proto_depIdxs = []int32{
 	0,  // 0: accounting.ComplianceRule.framework:type_name -> accounting.ComplianceFramework
-	8,  // 1: accounting.ComplianceRule.account_type:type_name -> accounting.AccountType
-	9,  // 2: accounting.ComplianceRule.created_at:type_name -> google.protobuf.Timestamp
+	13, // 1: accounting.ComplianceRule.account_type:type_name -> accounting.AccountType
+	14, // 2: accounting.ComplianceRule.created_at:type_name -> google.protobuf.Timestamp
 	1,  // 3: accounting.TaxRule.jurisdiction:type_name -> accounting.TaxJurisdiction
 	2,  // 4: accounting.TaxRule.tax_type:type_name -> accounting.TaxType
-	9,  // 5: accounting.TaxRule.effective_from:type_name -> google.protobuf.Timestamp
-	9,  // 6: accounting.TaxRule.effective_to:type_name -> google.protobuf.Timestamp
-	9,  // 7: accounting.TaxCalculation.calculated_at:type_name -> google.protobuf.Timestamp
-	9,  // 8: accounting.ComplianceViolation.detected_at:type_name -> google.protobuf.Timestamp
-	9,  // 9: accounting.ComplianceViolation.resolved_at:type_name -> google.protobuf.Timestamp
-	1,  // 10: accounting.TaxReturn.jurisdiction:type_name -> accounting.TaxJurisdiction
-	2,  // 11: accounting.TaxReturn.tax_type:type_name -> accounting.TaxType
-	9,  // 12: accounting.TaxReturn.filing_date:type_name -> google.protobuf.Timestamp
-	9,  // 13: accounting.TaxReturn.due_date:type_name -> google.protobuf.Timestamp
-	9,  // 14: accounting.TaxReturn.created_at:type_name -> google.protobuf.Timestamp
-	9,  // 15: accounting.TaxReturn.updated_at:type_name -> google.protobuf.Timestamp
-	16, // [16:16] is the sub-list for method output_type
-	16, // [16:16] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	14, // 5: accounting.TaxRule.effective_from:type_name -> google.protobuf.Timestamp
+	14, // 6: accounting.TaxRule.effective_to:type_name -> google.protobuf.Timestamp
+	5,  // 7: accounting.TaxRule.brackets:type_name -> accounting.TaxBracket
+	1,  // 8: accounting.TaxRounding.jurisdiction:type_name -> accounting.TaxJurisdiction
+	14, // 9: accounting.TaxCalculation.calculated_at:type_name -> google.protobuf.Timestamp
+	15, // 10: accounting.TaxCalculation.breakdown:type_name -> accounting.CalculationBreakdown
+	6,  // 11: accounting.TaxCalculation.components:type_name -> accounting.TaxComponent
+	14, // 12: accounting.ComplianceViolation.detected_at:type_name -> google.protobuf.Timestamp
+	14, // 13: accounting.ComplianceViolation.resolved_at:type_name -> google.protobuf.Timestamp
+	14, // 14: accounting.ComplianceViolation.acknowledged_at:type_name -> google.protobuf.Timestamp
+	14, // 15: accounting.ComplianceViolation.due_at:type_name -> google.protobuf.Timestamp
+	14, // 16: accounting.ComplianceViolation.escalated_at:type_name -> google.protobuf.Timestamp
+	10, // 17: accounting.ComplianceViolation.comments:type_name -> accounting.ViolationComment
+	14, // 18: accounting.ComplianceViolation.updated_at:type_name -> google.protobuf.Timestamp
+	14, // 19: accounting.ViolationComment.at:type_name -> google.protobuf.Timestamp
+	1,  // 20: accounting.TaxReturn.jurisdiction:type_name -> accounting.TaxJurisdiction
+	2,  // 21: accounting.TaxReturn.tax_type:type_name -> accounting.TaxType
+	14, // 22: accounting.TaxReturn.filing_date:type_name -> google.protobuf.Timestamp
+	14, // 23: accounting.TaxReturn.due_date:type_name -> google.protobuf.Timestamp
+	14, // 24: accounting.TaxReturn.created_at:type_name -> google.protobuf.Timestamp
+	14, // 25: accounting.TaxReturn.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 26: accounting.TaxReturn.calculations:type_name -> accounting.TaxCalculation
+	14, // 27: accounting.TaxReturn.period_start:type_name -> google.protobuf.Timestamp
+	14, // 28: accounting.TaxReturn.period_end:type_name -> google.protobuf.Timestamp
+	12, // 29: accounting.TaxReturn.vat_boxes:type_name -> accounting.VATBoxValue
+	30, // [30:30] is the sub-list for method output_type
+	30, // [30:30] is the sub-list for method input_type
+	30, // [30:30] is the sub-list for extension type_name
+	30, // [30:30] is the sub-list for extension extendee
+	0,  // [0:30] is the sub-list for field type_name
 }
 
 func init() { file_proto_accounting_compliance_proto_init() }
@@ -954,7 +1627,7 @@ func file_proto_accounting_compliance_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_accounting_compliance_proto_rawDesc), len(file_proto_accounting_compliance_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   5,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -359,13 +359,17 @@ func (x *AutoPostingRule) GetCreatedAt() *timestamppb.Timestamp {
 
 // CompanySettings
 type CompanySettings struct {
-	state                         protoimpl.MessageState `protogen:"open.v1"`
-	DefaultChartOfAccounts        string                 `protobuf:"bytes,1,opt,name=default_chart_of_accounts,json=defaultChartOfAccounts,proto3" json:"default_chart_of_accounts,omitempty"`
-	AllowIntercompanyTransactions bool                   `protobuf:"varint,2,opt,name=allow_intercompany_transactions,json=allowIntercompanyTransactions,proto3" json:"allow_intercompany_transactions,omitempty"`
-	RequireApprovalOver           *Amount                `protobuf:"bytes,3,opt,name=require_approval_over,json=requireApprovalOver,proto3" json:"require_approval_over,omitempty"`
-	AutoPostingRules              []*AutoPostingRule     `protobuf:"bytes,4,rep,name=auto_posting_rules,json=autoPostingRules,proto3" json:"auto_posting_rules,omitempty"`
-	PeriodLockingPolicy           string                 `protobuf:"bytes,5,opt,name=period_locking_policy,json=periodLockingPolicy,proto3" json:"period_locking_policy,omitempty"`
-	ReportingCurrency             string                 `protobuf:"bytes,6,opt,name=reporting_currency,json=reportingCurrency,proto3" json:"reporting_currency,omitempty"`
+	state                         protoimpl.MessageState  `protogen:"open.v1"`
+	DefaultChartOfAccounts        string                  `protobuf:"bytes,1,opt,name=default_chart_of_accounts,json=defaultChartOfAccounts,proto3" json:"default_chart_of_accounts,omitempty"`
+	AllowIntercompanyTransactions bool                    `protobuf:"varint,2,opt,name=allow_intercompany_transactions,json=allowIntercompanyTransactions,proto3" json:"allow_intercompany_transactions,omitempty"`
+	RequireApprovalOver           *Amount                 `protobuf:"bytes,3,opt,name=require_approval_over,json=requireApprovalOver,proto3" json:"require_approval_over,omitempty"`
+	AutoPostingRules              []*AutoPostingRule      `protobuf:"bytes,4,rep,name=auto_posting_rules,json=autoPostingRules,proto3" json:"auto_posting_rules,omitempty"`
+	PeriodLockingPolicy           string                  `protobuf:"bytes,5,opt,name=period_locking_policy,json=periodLockingPolicy,proto3" json:"period_locking_policy,omitempty"`
+	ReportingCurrency             string                  `protobuf:"bytes,6,opt,name=reporting_currency,json=reportingCurrency,proto3" json:"reporting_currency,omitempty"`
+	BudgetControl                 string                  `protobuf:"bytes,7,opt,name=budget_control,json=budgetControl,proto3" json:"budget_control,omitempty"`
+	AccountNumbering              *AccountNumberingScheme `protobuf:"bytes,8,opt,name=account_numbering,json=accountNumbering,proto3" json:"account_numbering,omitempty"`
+	PostingDateWindow             *PostingDateWindow      `protobuf:"bytes,9,opt,name=posting_date_window,json=postingDateWindow,proto3" json:"posting_date_window,omitempty"`
+	FourEyes                      *FourEyesPolicy         `protobuf:"bytes,10,opt,name=four_eyes,json=fourEyes,proto3" json:"four_eyes,omitempty"`
 	unknownFields                 protoimpl.UnknownFields
 	sizeCache                     protoimpl.SizeCache
 }
@@ -442,6 +446,355 @@ func (x *CompanySettings) GetReportingCurrency() string {
 	return ""
 }
 
+func (x *CompanySettings) GetBudgetControl() string {
+	if x != nil {
+		return x.BudgetControl
+	}
+	return ""
+}
+
+func (x *CompanySettings) GetAccountNumbering() *AccountNumberingScheme {
+	if x != nil {
+		return x.AccountNumbering
+	}
+	return nil
+}
+
+func (x *CompanySettings) GetPostingDateWindow() *PostingDateWindow {
+	if x != nil {
+		return x.PostingDateWindow
+	}
+	return nil
+}
+
+func (x *CompanySettings) GetFourEyes() *FourEyesPolicy {
+	if x != nil {
+		return x.FourEyes
+	}
+	return nil
+}
+
+// FourEyesPolicy
+type FourEyesPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Posting       bool                   `protobuf:"varint,1,opt,name=posting,proto3" json:"posting,omitempty"`
+	Reversal      bool                   `protobuf:"varint,2,opt,name=reversal,proto3" json:"reversal,omitempty"`
+	PeriodClose   bool                   `protobuf:"varint,3,opt,name=period_close,json=periodClose,proto3" json:"period_close,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FourEyesPolicy) Reset() {
+	*x = FourEyesPolicy{}
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FourEyesPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FourEyesPolicy) ProtoMessage() {}
+
+func (x *FourEyesPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FourEyesPolicy.ProtoReflect.Descriptor instead.
+func (*FourEyesPolicy) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FourEyesPolicy) GetPosting() bool {
+	if x != nil {
+		return x.Posting
+	}
+	return false
+}
+
+func (x *FourEyesPolicy) GetReversal() bool {
+	if x != nil {
+		return x.Reversal
+	}
+	return false
+}
+
+func (x *FourEyesPolicy) GetPeriodClose() bool {
+	if x != nil {
+		return x.PeriodClose
+	}
+	return false
+}
+
+// AccountCodeRange
+type AccountCodeRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountType   string                 `protobuf:"bytes,1,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`
+	From          int64                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountCodeRange) Reset() {
+	*x = AccountCodeRange{}
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountCodeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountCodeRange) ProtoMessage() {}
+
+func (x *AccountCodeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountCodeRange.ProtoReflect.Descriptor instead.
+func (*AccountCodeRange) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AccountCodeRange) GetAccountType() string {
+	if x != nil {
+		return x.AccountType
+	}
+	return ""
+}
+
+func (x *AccountCodeRange) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *AccountCodeRange) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// AccountNumberingScheme
+type AccountNumberingScheme struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CodeLength    int32                  `protobuf:"varint,2,opt,name=code_length,json=codeLength,proto3" json:"code_length,omitempty"`
+	Ranges        []*AccountCodeRange    `protobuf:"bytes,3,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	ChildStep     int64                  `protobuf:"varint,4,opt,name=child_step,json=childStep,proto3" json:"child_step,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountNumberingScheme) Reset() {
+	*x = AccountNumberingScheme{}
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountNumberingScheme) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountNumberingScheme) ProtoMessage() {}
+
+func (x *AccountNumberingScheme) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountNumberingScheme.ProtoReflect.Descriptor instead.
+func (*AccountNumberingScheme) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AccountNumberingScheme) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AccountNumberingScheme) GetCodeLength() int32 {
+	if x != nil {
+		return x.CodeLength
+	}
+	return 0
+}
+
+func (x *AccountNumberingScheme) GetRanges() []*AccountCodeRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *AccountNumberingScheme) GetChildStep() int64 {
+	if x != nil {
+		return x.ChildStep
+	}
+	return 0
+}
+
+// PostingDateOverride
+type PostingDateOverride struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Role           string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	UserIds        []string               `protobuf:"bytes,2,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	MaxDaysBack    int32                  `protobuf:"varint,3,opt,name=max_days_back,json=maxDaysBack,proto3" json:"max_days_back,omitempty"`
+	MaxDaysForward int32                  `protobuf:"varint,4,opt,name=max_days_forward,json=maxDaysForward,proto3" json:"max_days_forward,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PostingDateOverride) Reset() {
+	*x = PostingDateOverride{}
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostingDateOverride) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostingDateOverride) ProtoMessage() {}
+
+func (x *PostingDateOverride) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostingDateOverride.ProtoReflect.Descriptor instead.
+func (*PostingDateOverride) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *PostingDateOverride) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *PostingDateOverride) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *PostingDateOverride) GetMaxDaysBack() int32 {
+	if x != nil {
+		return x.MaxDaysBack
+	}
+	return 0
+}
+
+func (x *PostingDateOverride) GetMaxDaysForward() int32 {
+	if x != nil {
+		return x.MaxDaysForward
+	}
+	return 0
+}
+
+// PostingDateWindow
+type PostingDateWindow struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	MaxDaysBack    int32                  `protobuf:"varint,1,opt,name=max_days_back,json=maxDaysBack,proto3" json:"max_days_back,omitempty"`
+	MaxDaysForward int32                  `protobuf:"varint,2,opt,name=max_days_forward,json=maxDaysForward,proto3" json:"max_days_forward,omitempty"`
+	Overrides      []*PostingDateOverride `protobuf:"bytes,3,rep,name=overrides,proto3" json:"overrides,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PostingDateWindow) Reset() {
+	*x = PostingDateWindow{}
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostingDateWindow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostingDateWindow) ProtoMessage() {}
+
+func (x *PostingDateWindow) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostingDateWindow.ProtoReflect.Descriptor instead.
+func (*PostingDateWindow) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PostingDateWindow) GetMaxDaysBack() int32 {
+	if x != nil {
+		return x.MaxDaysBack
+	}
+	return 0
+}
+
+func (x *PostingDateWindow) GetMaxDaysForward() int32 {
+	if x != nil {
+		return x.MaxDaysForward
+	}
+	return 0
+}
+
+func (x *PostingDateWindow) GetOverrides() []*PostingDateOverride {
+	if x != nil {
+		return x.Overrides
+	}
+	return nil
+}
+
 // Company
 type Company struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -464,7 +817,7 @@ type Company struct {
 
 func (x *Company) Reset() {
 	*x = Company{}
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[4]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -476,7 +829,7 @@ func (x *Company) String() string {
 func (*Company) ProtoMessage() {}
 
 func (x *Company) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[4]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,7 +842,7 @@ func (x *Company) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Company.ProtoReflect.Descriptor instead.
 func (*Company) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{4}
+	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *Company) GetId() string {
@@ -605,7 +958,7 @@ type IntercompanyTransaction struct {
 
 func (x *IntercompanyTransaction) Reset() {
 	*x = IntercompanyTransaction{}
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[5]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -617,7 +970,7 @@ func (x *IntercompanyTransaction) String() string {
 func (*IntercompanyTransaction) ProtoMessage() {}
 
 func (x *IntercompanyTransaction) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[5]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -630,7 +983,7 @@ func (x *IntercompanyTransaction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IntercompanyTransaction.ProtoReflect.Descriptor instead.
 func (*IntercompanyTransaction) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{5}
+	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *IntercompanyTransaction) GetId() string {
@@ -740,7 +1093,7 @@ type ConsolidationRule struct {
 
 func (x *ConsolidationRule) Reset() {
 	*x = ConsolidationRule{}
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[6]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -752,7 +1105,7 @@ func (x *ConsolidationRule) String() string {
 func (*ConsolidationRule) ProtoMessage() {}
 
 func (x *ConsolidationRule) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[6]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -765,7 +1118,7 @@ func (x *ConsolidationRule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConsolidationRule.ProtoReflect.Descriptor instead.
 func (*ConsolidationRule) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{6}
+	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ConsolidationRule) GetId() string {
@@ -828,13 +1181,15 @@ type ConsolidationGroup struct {
 	Rules                 []*ConsolidationRule   `protobuf:"bytes,6,rep,name=rules,proto3" json:"rules,omitempty"`
 	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	CreatedBy             string                 `protobuf:"bytes,8,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	ConsolidationMethod   string                 `protobuf:"bytes,9,opt,name=consolidation_method,json=consolidationMethod,proto3" json:"consolidation_method,omitempty"`
+	Ownership             map[string]float64     `protobuf:"bytes,10,rep,name=ownership,proto3" json:"ownership,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
 
 func (x *ConsolidationGroup) Reset() {
 	*x = ConsolidationGroup{}
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[7]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -846,7 +1201,7 @@ func (x *ConsolidationGroup) String() string {
 func (*ConsolidationGroup) ProtoMessage() {}
 
 func (x *ConsolidationGroup) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[7]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -859,7 +1214,7 @@ func (x *ConsolidationGroup) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConsolidationGroup.ProtoReflect.Descriptor instead.
 func (*ConsolidationGroup) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{7}
+	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ConsolidationGroup) GetId() string {
@@ -918,6 +1273,20 @@ func (x *ConsolidationGroup) GetCreatedBy() string {
 	return ""
 }
 
+func (x *ConsolidationGroup) GetConsolidationMethod() string {
+	if x != nil {
+		return x.ConsolidationMethod
+	}
+	return ""
+}
+
+func (x *ConsolidationGroup) GetOwnership() map[string]float64 {
+	if x != nil {
+		return x.Ownership
+	}
+	return nil
+}
+
 // EliminationEntry
 type EliminationEntry struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -934,7 +1303,7 @@ type EliminationEntry struct {
 
 func (x *EliminationEntry) Reset() {
 	*x = EliminationEntry{}
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[8]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -946,7 +1315,7 @@ func (x *EliminationEntry) String() string {
 func (*EliminationEntry) ProtoMessage() {}
 
 func (x *EliminationEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[8]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -959,7 +1328,7 @@ func (x *EliminationEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EliminationEntry.ProtoReflect.Descriptor instead.
 func (*EliminationEntry) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{8}
+	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *EliminationEntry) GetId() string {
@@ -1029,7 +1398,7 @@ type ConsolidatedStatement struct {
 
 func (x *ConsolidatedStatement) Reset() {
 	*x = ConsolidatedStatement{}
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[9]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1041,7 +1410,7 @@ func (x *ConsolidatedStatement) String() string {
 func (*ConsolidatedStatement) ProtoMessage() {}
 
 func (x *ConsolidatedStatement) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_multi_company_proto_msgTypes[9]
+	mi := &file_proto_accounting_multi_company_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1054,7 +1423,7 @@ func (x *ConsolidatedStatement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConsolidatedStatement.ProtoReflect.Descriptor instead.
 func (*ConsolidatedStatement) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{9}
+	return file_proto_accounting_multi_company_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ConsolidatedStatement) GetId() string {
@@ -1149,14 +1518,43 @@ const file_proto_accounting_multi_company_proto_rawDesc = "" +
 	"\aactions\x18\x04 \x03(\v2\x19.accounting.PostingActionR\aactions\x12\x1b\n" +
 	"\tis_active\x18\x05 \x01(\bR\bisActive\x129\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x8a\x03\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x8a\x05\n" +
 	"\x0fCompanySettings\x129\n" +
 	"\x19default_chart_of_accounts\x18\x01 \x01(\tR\x16defaultChartOfAccounts\x12F\n" +
 	"\x1fallow_intercompany_transactions\x18\x02 \x01(\bR\x1dallowIntercompanyTransactions\x12F\n" +
 	"\x15require_approval_over\x18\x03 \x01(\v2\x12.accounting.AmountR\x13requireApprovalOver\x12I\n" +
 	"\x12auto_posting_rules\x18\x04 \x03(\v2\x1b.accounting.AutoPostingRuleR\x10autoPostingRules\x122\n" +
 	"\x15period_locking_policy\x18\x05 \x01(\tR\x13periodLockingPolicy\x12-\n" +
-	"\x12reporting_currency\x18\x06 \x01(\tR\x11reportingCurrency\"\xe9\x04\n" +
+	"\x12reporting_currency\x18\x06 \x01(\tR\x11reportingCurrency\x12%\n" +
+	"\x0ebudget_control\x18\a \x01(\tR\rbudgetControl\x12O\n" +
+	"\x11account_numbering\x18\b \x01(\v2\".accounting.AccountNumberingSchemeR\x10accountNumbering\x12M\n" +
+	"\x13posting_date_window\x18\t \x01(\v2\x1d.accounting.PostingDateWindowR\x11postingDateWindow\x127\n" +
+	"\tfour_eyes\x18\n" +
+	" \x01(\v2\x1a.accounting.FourEyesPolicyR\bfourEyes\"i\n" +
+	"\x0eFourEyesPolicy\x12\x18\n" +
+	"\aposting\x18\x01 \x01(\bR\aposting\x12\x1a\n" +
+	"\breversal\x18\x02 \x01(\bR\breversal\x12!\n" +
+	"\fperiod_close\x18\x03 \x01(\bR\vperiodClose\"Y\n" +
+	"\x10AccountCodeRange\x12!\n" +
+	"\faccount_type\x18\x01 \x01(\tR\vaccountType\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x03R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x03R\x02to\"\xa2\x01\n" +
+	"\x16AccountNumberingScheme\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vcode_length\x18\x02 \x01(\x05R\n" +
+	"codeLength\x124\n" +
+	"\x06ranges\x18\x03 \x03(\v2\x1c.accounting.AccountCodeRangeR\x06ranges\x12\x1d\n" +
+	"\n" +
+	"child_step\x18\x04 \x01(\x03R\tchildStep\"\x92\x01\n" +
+	"\x13PostingDateOverride\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds\x12\"\n" +
+	"\rmax_days_back\x18\x03 \x01(\x05R\vmaxDaysBack\x12(\n" +
+	"\x10max_days_forward\x18\x04 \x01(\x05R\x0emaxDaysForward\"\xa0\x01\n" +
+	"\x11PostingDateWindow\x12\"\n" +
+	"\rmax_days_back\x18\x01 \x01(\x05R\vmaxDaysBack\x12(\n" +
+	"\x10max_days_forward\x18\x02 \x01(\x05R\x0emaxDaysForward\x12=\n" +
+	"\toverrides\x18\x03 \x03(\v2\x1f.accounting.PostingDateOverrideR\toverrides\"\xe9\x04\n" +
 	"\aCompany\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
@@ -1205,7 +1603,7 @@ const file_proto_accounting_multi_company_proto_rawDesc = "" +
 	"parameters\x12\x1b\n" +
 	"\tis_active\x18\x06 \x01(\bR\bisActive\x129\n" +
 	"\n" +
-	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd1\x02\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x8f\x04\n" +
 	"\x12ConsolidationGroup\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12*\n" +
@@ -1216,7 +1614,13 @@ const file_proto_accounting_multi_company_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"created_by\x18\b \x01(\tR\tcreatedBy\"\xae\x02\n" +
+	"created_by\x18\b \x01(\tR\tcreatedBy\x121\n" +
+	"\x14consolidation_method\x18\t \x01(\tR\x13consolidationMethod\x12K\n" +
+	"\townership\x18\n" +
+	" \x03(\v2-.accounting.ConsolidationGroup.OwnershipEntryR\townership\x1a<\n" +
+	"\x0eOwnershipEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xae\x02\n" +
 	"\x10EliminationEntry\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
 	"\x10consolidation_id\x18\x02 \x01(\tR\x0fconsolidationId\x12 \n" +
@@ -1263,7 +1667,7 @@ func file_proto_accounting_multi_company_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_accounting_multi_company_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_accounting_multi_company_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_proto_accounting_multi_company_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_proto_accounting_multi_company_proto_goTypes = []any{
 	(CompanyStatus)(0),              // 0: accounting.CompanyStatus
 	(IntercompanyStatus)(0),         // 1: accounting.IntercompanyStatus
@@ -1271,44 +1675,56 @@ var file_proto_accounting_multi_company_proto_goTypes = []any{
 	(*PostingAction)(nil),           // 3: accounting.PostingAction
 	(*AutoPostingRule)(nil),         // 4: accounting.AutoPostingRule
 	(*CompanySettings)(nil),         // 5: accounting.CompanySettings
-	(*Company)(nil),                 // 6: accounting.Company
-	(*IntercompanyTransaction)(nil), // 7: accounting.IntercompanyTransaction
-	(*ConsolidationRule)(nil),       // 8: accounting.ConsolidationRule
-	(*ConsolidationGroup)(nil),      // 9: accounting.ConsolidationGroup
-	(*EliminationEntry)(nil),        // 10: accounting.EliminationEntry
-	(*ConsolidatedStatement)(nil),   // 11: accounting.ConsolidatedStatement
-	nil,                             // 12: accounting.PostingAction.ParametersEntry
-	nil,                             // 13: accounting.Company.MetadataEntry
-	(*timestamppb.Timestamp)(nil),   // 14: google.protobuf.Timestamp
-	(*Amount)(nil),                  // 15: accounting.Amount
+	(*FourEyesPolicy)(nil),          // 6: accounting.FourEyesPolicy
+	(*AccountCodeRange)(nil),        // 7: accounting.AccountCodeRange
+	(*AccountNumberingScheme)(nil),  // 8: accounting.AccountNumberingScheme
+	(*PostingDateOverride)(nil),     // 9: accounting.PostingDateOverride
+	(*PostingDateWindow)(nil),       // 10: accounting.PostingDateWindow
+	(*Company)(nil),                 // 11: accounting.Company
+	(*IntercompanyTransaction)(nil), // 12: accounting.IntercompanyTransaction
+	(*ConsolidationRule)(nil),       // 13: accounting.ConsolidationRule
+	(*ConsolidationGroup)(nil),      // 14: accounting.ConsolidationGroup
+	(*EliminationEntry)(nil),        // 15: accounting.EliminationEntry
+	(*ConsolidatedStatement)(nil),   // 16: accounting.ConsolidatedStatement
+	nil,                             // 17: accounting.PostingAction.ParametersEntry
+	nil,                             // 18: accounting.Company.MetadataEntry
+	nil,                             // 19: accounting.ConsolidationGroup.OwnershipEntry
+	(*timestamppb.Timestamp)(nil),   // 20: google.protobuf.Timestamp
+	(*Amount)(nil),                  // 21: accounting.Amount
 }
 var file_proto_accounting_multi_company_proto_depIdxs = []int32{
-	12, // 0: accounting.PostingAction.parameters:type_name -> accounting.PostingAction.ParametersEntry
+	17, // 0: accounting.PostingAction.parameters:type_name -> accounting.PostingAction.ParametersEntry
 	3,  // 1: accounting.AutoPostingRule.actions:type_name -> accounting.PostingAction
-	14, // 2: accounting.AutoPostingRule.created_at:type_name -> google.protobuf.Timestamp
-	15, // 3: accounting.CompanySettings.require_approval_over:type_name -> accounting.Amount
+	20, // 2: accounting.AutoPostingRule.created_at:type_name -> google.protobuf.Timestamp
+	21, // 3: accounting.CompanySettings.require_approval_over:type_name -> accounting.Amount
 	4,  // 4: accounting.CompanySettings.auto_posting_rules:type_name -> accounting.AutoPostingRule
-	14, // 5: accounting.Company.fiscal_year_end:type_name -> google.protobuf.Timestamp
-	2,  // 6: accounting.Company.address:type_name -> accounting.Address
-	5,  // 7: accounting.Company.settings:type_name -> accounting.CompanySettings
-	14, // 8: accounting.Company.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 9: accounting.Company.status:type_name -> accounting.CompanyStatus
-	13, // 10: accounting.Company.metadata:type_name -> accounting.Company.MetadataEntry
-	15, // 11: accounting.IntercompanyTransaction.amount:type_name -> accounting.Amount
-	1,  // 12: accounting.IntercompanyTransaction.matching_status:type_name -> accounting.IntercompanyStatus
-	14, // 13: accounting.IntercompanyTransaction.created_at:type_name -> google.protobuf.Timestamp
-	14, // 14: accounting.IntercompanyTransaction.reconciled_at:type_name -> google.protobuf.Timestamp
-	14, // 15: accounting.ConsolidationRule.created_at:type_name -> google.protobuf.Timestamp
-	8,  // 16: accounting.ConsolidationGroup.rules:type_name -> accounting.ConsolidationRule
-	14, // 17: accounting.ConsolidationGroup.created_at:type_name -> google.protobuf.Timestamp
-	15, // 18: accounting.EliminationEntry.amount:type_name -> accounting.Amount
-	14, // 19: accounting.EliminationEntry.created_at:type_name -> google.protobuf.Timestamp
-	14, // 20: accounting.ConsolidatedStatement.generated_at:type_name -> google.protobuf.Timestamp
-	21, // [21:21] is the sub-list for method output_type
-	21, // [21:21] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	8,  // 5: accounting.CompanySettings.account_numbering:type_name -> accounting.AccountNumberingScheme
+	10, // 6: accounting.CompanySettings.posting_date_window:type_name -> accounting.PostingDateWindow
+	6,  // 7: accounting.CompanySettings.four_eyes:type_name -> accounting.FourEyesPolicy
+	7,  // 8: accounting.AccountNumberingScheme.ranges:type_name -> accounting.AccountCodeRange
+	9,  // 9: accounting.PostingDateWindow.overrides:type_name -> accounting.PostingDateOverride
+	20, // 10: accounting.Company.fiscal_year_end:type_name -> google.protobuf.Timestamp
+	2,  // 11: accounting.Company.address:type_name -> accounting.Address
+	5,  // 12: accounting.Company.settings:type_name -> accounting.CompanySettings
+	20, // 13: accounting.Company.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 14: accounting.Company.status:type_name -> accounting.CompanyStatus
+	18, // 15: accounting.Company.metadata:type_name -> accounting.Company.MetadataEntry
+	21, // 16: accounting.IntercompanyTransaction.amount:type_name -> accounting.Amount
+	1,  // 17: accounting.IntercompanyTransaction.matching_status:type_name -> accounting.IntercompanyStatus
+	20, // 18: accounting.IntercompanyTransaction.created_at:type_name -> google.protobuf.Timestamp
+	20, // 19: accounting.IntercompanyTransaction.reconciled_at:type_name -> google.protobuf.Timestamp
+	20, // 20: accounting.ConsolidationRule.created_at:type_name -> google.protobuf.Timestamp
+	13, // 21: accounting.ConsolidationGroup.rules:type_name -> accounting.ConsolidationRule
+	20, // 22: accounting.ConsolidationGroup.created_at:type_name -> google.protobuf.Timestamp
+	19, // 23: accounting.ConsolidationGroup.ownership:type_name -> accounting.ConsolidationGroup.OwnershipEntry
+	21, // 24: accounting.EliminationEntry.amount:type_name -> accounting.Amount
+	20, // 25: accounting.EliminationEntry.created_at:type_name -> google.protobuf.Timestamp
+	20, // 26: accounting.ConsolidatedStatement.generated_at:type_name -> google.protobuf.Timestamp
+	27, // [27:27] is the sub-list for method output_type
+	27, // [27:27] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_proto_accounting_multi_company_proto_init() }
@@ -1323,7 +1739,7 @@ func file_proto_accounting_multi_company_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_accounting_multi_company_proto_rawDesc), len(file_proto_accounting_multi_company_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   12,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
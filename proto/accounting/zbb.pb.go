@@ -829,6 +829,7 @@ type BudgetRequest struct {
 	SubmittedAt    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
 	ApprovedAt     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=approved_at,json=approvedAt,proto3" json:"approved_at,omitempty"`
 	ApprovedBy     string                 `protobuf:"bytes,15,opt,name=approved_by,json=approvedBy,proto3" json:"approved_by,omitempty"`
+	Version        int64                  `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -968,6 +969,13 @@ func (x *BudgetRequest) GetApprovedBy() string {
 	return ""
 }
 
+func (x *BudgetRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 // BudgetApproval
 type BudgetApproval struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -1219,6 +1227,7 @@ type BudgetTracking struct {
 	Description     string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
 	TrackedAt       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=tracked_at,json=trackedAt,proto3" json:"tracked_at,omitempty"`
 	RemainingBudget *Amount                `protobuf:"bytes,6,opt,name=remaining_budget,json=remainingBudget,proto3" json:"remaining_budget,omitempty"`
+	OverBudget      bool                   `protobuf:"varint,7,opt,name=over_budget,json=overBudget,proto3" json:"over_budget,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -1295,6 +1304,332 @@ func (x *BudgetTracking) GetRemainingBudget() *Amount {
 	return nil
 }
 
+func (x *BudgetTracking) GetOverBudget() bool {
+	if x != nil {
+		return x.OverBudget
+	}
+	return false
+}
+
+// ForecastLine
+type ForecastLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Dimensions    []*Dimension           `protobuf:"bytes,2,rep,name=dimensions,proto3" json:"dimensions,omitempty"`
+	Month         string                 `protobuf:"bytes,3,opt,name=month,proto3" json:"month,omitempty"`
+	Amount        *Amount                `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForecastLine) Reset() {
+	*x = ForecastLine{}
+	mi := &file_proto_accounting_zbb_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForecastLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForecastLine) ProtoMessage() {}
+
+func (x *ForecastLine) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_zbb_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForecastLine.ProtoReflect.Descriptor instead.
+func (*ForecastLine) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_zbb_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ForecastLine) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ForecastLine) GetDimensions() []*Dimension {
+	if x != nil {
+		return x.Dimensions
+	}
+	return nil
+}
+
+func (x *ForecastLine) GetMonth() string {
+	if x != nil {
+		return x.Month
+	}
+	return ""
+}
+
+func (x *ForecastLine) GetAmount() *Amount {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+// BudgetForecast
+type BudgetForecast struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PeriodId      string                 `protobuf:"bytes,2,opt,name=period_id,json=periodId,proto3" json:"period_id,omitempty"`
+	DepartmentId  string                 `protobuf:"bytes,3,opt,name=department_id,json=departmentId,proto3" json:"department_id,omitempty"`
+	Version       int32                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	Kind          string                 `protobuf:"bytes,5,opt,name=kind,proto3" json:"kind,omitempty"`
+	FromMonth     string                 `protobuf:"bytes,6,opt,name=from_month,json=fromMonth,proto3" json:"from_month,omitempty"`
+	Lines         []*ForecastLine        `protobuf:"bytes,7,rep,name=lines,proto3" json:"lines,omitempty"`
+	Notes         string                 `protobuf:"bytes,8,opt,name=notes,proto3" json:"notes,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BudgetForecast) Reset() {
+	*x = BudgetForecast{}
+	mi := &file_proto_accounting_zbb_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BudgetForecast) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BudgetForecast) ProtoMessage() {}
+
+func (x *BudgetForecast) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_zbb_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BudgetForecast.ProtoReflect.Descriptor instead.
+func (*BudgetForecast) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_zbb_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *BudgetForecast) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BudgetForecast) GetPeriodId() string {
+	if x != nil {
+		return x.PeriodId
+	}
+	return ""
+}
+
+func (x *BudgetForecast) GetDepartmentId() string {
+	if x != nil {
+		return x.DepartmentId
+	}
+	return ""
+}
+
+func (x *BudgetForecast) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *BudgetForecast) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *BudgetForecast) GetFromMonth() string {
+	if x != nil {
+		return x.FromMonth
+	}
+	return ""
+}
+
+func (x *BudgetForecast) GetLines() []*ForecastLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *BudgetForecast) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *BudgetForecast) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *BudgetForecast) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Department
+type Department struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code              string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Name              string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Kind              string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`
+	ParentId          string                 `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Owners            []string               `protobuf:"bytes,6,rep,name=owners,proto3" json:"owners,omitempty"`
+	ActiveFrom        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=active_from,json=activeFrom,proto3" json:"active_from,omitempty"`
+	ActiveTo          *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=active_to,json=activeTo,proto3" json:"active_to,omitempty"`
+	DefaultDimensions []*Dimension           `protobuf:"bytes,9,rep,name=default_dimensions,json=defaultDimensions,proto3" json:"default_dimensions,omitempty"`
+	CreatedBy         string                 `protobuf:"bytes,10,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Department) Reset() {
+	*x = Department{}
+	mi := &file_proto_accounting_zbb_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Department) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Department) ProtoMessage() {}
+
+func (x *Department) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounting_zbb_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Department.ProtoReflect.Descriptor instead.
+func (*Department) Descriptor() ([]byte, []int) {
+	return file_proto_accounting_zbb_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Department) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Department) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Department) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Department) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Department) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Department) GetOwners() []string {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *Department) GetActiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActiveFrom
+	}
+	return nil
+}
+
+func (x *Department) GetActiveTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActiveTo
+	}
+	return nil
+}
+
+func (x *Department) GetDefaultDimensions() []*Dimension {
+	if x != nil {
+		return x.DefaultDimensions
+	}
+	return nil
+}
+
+func (x *Department) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Department) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Department) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
 // BudgetVarianceItem
 type BudgetVarianceItem struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -1310,7 +1645,7 @@ type BudgetVarianceItem struct {
 
 func (x *BudgetVarianceItem) Reset() {
 	*x = BudgetVarianceItem{}
-	mi := &file_proto_accounting_zbb_proto_msgTypes[9]
+	mi := &file_proto_accounting_zbb_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1322,7 +1657,7 @@ func (x *BudgetVarianceItem) String() string {
 func (*BudgetVarianceItem) ProtoMessage() {}
 
 func (x *BudgetVarianceItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_zbb_proto_msgTypes[9]
+	mi := &file_proto_accounting_zbb_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1335,7 +1670,7 @@ func (x *BudgetVarianceItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BudgetVarianceItem.ProtoReflect.Descriptor instead.
 func (*BudgetVarianceItem) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_zbb_proto_rawDescGZIP(), []int{9}
+	return file_proto_accounting_zbb_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BudgetVarianceItem) GetAccountId() string {
@@ -1397,7 +1732,7 @@ type BudgetVarianceReport struct {
 
 func (x *BudgetVarianceReport) Reset() {
 	*x = BudgetVarianceReport{}
-	mi := &file_proto_accounting_zbb_proto_msgTypes[10]
+	mi := &file_proto_accounting_zbb_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1409,7 +1744,7 @@ func (x *BudgetVarianceReport) String() string {
 func (*BudgetVarianceReport) ProtoMessage() {}
 
 func (x *BudgetVarianceReport) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_zbb_proto_msgTypes[10]
+	mi := &file_proto_accounting_zbb_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1422,7 +1757,7 @@ func (x *BudgetVarianceReport) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BudgetVarianceReport.ProtoReflect.Descriptor instead.
 func (*BudgetVarianceReport) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_zbb_proto_rawDescGZIP(), []int{10}
+	return file_proto_accounting_zbb_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *BudgetVarianceReport) GetPeriodId() string {
@@ -1499,7 +1834,7 @@ type BudgetRequestSummary struct {
 
 func (x *BudgetRequestSummary) Reset() {
 	*x = BudgetRequestSummary{}
-	mi := &file_proto_accounting_zbb_proto_msgTypes[11]
+	mi := &file_proto_accounting_zbb_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1511,7 +1846,7 @@ func (x *BudgetRequestSummary) String() string {
 func (*BudgetRequestSummary) ProtoMessage() {}
 
 func (x *BudgetRequestSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_zbb_proto_msgTypes[11]
+	mi := &file_proto_accounting_zbb_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1524,7 +1859,7 @@ func (x *BudgetRequestSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BudgetRequestSummary.ProtoReflect.Descriptor instead.
 func (*BudgetRequestSummary) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_zbb_proto_rawDescGZIP(), []int{11}
+	return file_proto_accounting_zbb_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *BudgetRequestSummary) GetId() string {
@@ -1606,7 +1941,7 @@ type DepartmentBudgetSummary struct {
 
 func (x *DepartmentBudgetSummary) Reset() {
 	*x = DepartmentBudgetSummary{}
-	mi := &file_proto_accounting_zbb_proto_msgTypes[12]
+	mi := &file_proto_accounting_zbb_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1618,7 +1953,7 @@ func (x *DepartmentBudgetSummary) String() string {
 func (*DepartmentBudgetSummary) ProtoMessage() {}
 
 func (x *DepartmentBudgetSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounting_zbb_proto_msgTypes[12]
+	mi := &file_proto_accounting_zbb_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1631,7 +1966,7 @@ func (x *DepartmentBudgetSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DepartmentBudgetSummary.ProtoReflect.Descriptor instead.
 func (*DepartmentBudgetSummary) Descriptor() ([]byte, []int) {
-	return file_proto_accounting_zbb_proto_rawDescGZIP(), []int{12}
+	return file_proto_accounting_zbb_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *DepartmentBudgetSummary) GetPeriodId() string {
@@ -1742,7 +2077,7 @@ const file_proto_accounting_zbb_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"created_by\x18\f \x01(\tR\tcreatedBy\"\xbd\x05\n" +
+	"created_by\x18\f \x01(\tR\tcreatedBy\"\xd7\x05\n" +
 	"\rBudgetRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tperiod_id\x18\x02 \x01(\tR\bperiodId\x12!\n" +
@@ -1764,7 +2099,8 @@ const file_proto_accounting_zbb_proto_rawDesc = "" +
 	"\vapproved_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"approvedAt\x12\x1f\n" +
 	"\vapproved_by\x18\x0f \x01(\tR\n" +
-	"approvedBy\"\x8c\x03\n" +
+	"approvedBy\x12\x18\n" +
+	"\aversion\x18\x10 \x01(\x03R\aversion\"\x8c\x03\n" +
 	"\x0eBudgetApproval\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\n" +
@@ -1798,7 +2134,7 @@ const file_proto_accounting_zbb_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa4\x02\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc5\x02\n" +
 	"\x0eBudgetTracking\x12#\n" +
 	"\rallocation_id\x18\x01 \x01(\tR\fallocationId\x12%\n" +
 	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12*\n" +
@@ -1806,7 +2142,51 @@ const file_proto_accounting_zbb_proto_rawDesc = "" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x129\n" +
 	"\n" +
 	"tracked_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttrackedAt\x12=\n" +
-	"\x10remaining_budget\x18\x06 \x01(\v2\x12.accounting.AmountR\x0fremainingBudget\"\xa0\x02\n" +
+	"\x10remaining_budget\x18\x06 \x01(\v2\x12.accounting.AmountR\x0fremainingBudget\x12\x1f\n" +
+	"\vover_budget\x18\a \x01(\bR\n" +
+	"overBudget\"\xa6\x01\n" +
+	"\fForecastLine\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x125\n" +
+	"\n" +
+	"dimensions\x18\x02 \x03(\v2\x15.accounting.DimensionR\n" +
+	"dimensions\x12\x14\n" +
+	"\x05month\x18\x03 \x01(\tR\x05month\x12*\n" +
+	"\x06amount\x18\x04 \x01(\v2\x12.accounting.AmountR\x06amount\"\xcf\x02\n" +
+	"\x0eBudgetForecast\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tperiod_id\x18\x02 \x01(\tR\bperiodId\x12#\n" +
+	"\rdepartment_id\x18\x03 \x01(\tR\fdepartmentId\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x05R\aversion\x12\x12\n" +
+	"\x04kind\x18\x05 \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"from_month\x18\x06 \x01(\tR\tfromMonth\x12.\n" +
+	"\x05lines\x18\a \x03(\v2\x18.accounting.ForecastLineR\x05lines\x12\x14\n" +
+	"\x05notes\x18\b \x01(\tR\x05notes\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xde\x03\n" +
+	"\n" +
+	"Department\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x1b\n" +
+	"\tparent_id\x18\x05 \x01(\tR\bparentId\x12\x16\n" +
+	"\x06owners\x18\x06 \x03(\tR\x06owners\x12;\n" +
+	"\vactive_from\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"activeFrom\x127\n" +
+	"\tactive_to\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bactiveTo\x12D\n" +
+	"\x12default_dimensions\x18\t \x03(\v2\x15.accounting.DimensionR\x11defaultDimensions\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\n" +
+	" \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xa0\x02\n" +
 	"\x12BudgetVarianceItem\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12 \n" +
@@ -1895,7 +2275,7 @@ func file_proto_accounting_zbb_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_accounting_zbb_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_proto_accounting_zbb_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_accounting_zbb_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_proto_accounting_zbb_proto_goTypes = []any{
 	(BudgetPeriodStatus)(0),         // 0: accounting.BudgetPeriodStatus
 	(BudgetRequestStatus)(0),        // 1: accounting.BudgetRequestStatus
@@ -1911,72 +2291,84 @@ var file_proto_accounting_zbb_proto_goTypes = []any{
 	(*BudgetApproval)(nil),          // 11: accounting.BudgetApproval
 	(*BudgetAllocation)(nil),        // 12: accounting.BudgetAllocation
 	(*BudgetTracking)(nil),          // 13: accounting.BudgetTracking
-	(*BudgetVarianceItem)(nil),      // 14: accounting.BudgetVarianceItem
-	(*BudgetVarianceReport)(nil),    // 15: accounting.BudgetVarianceReport
-	(*BudgetRequestSummary)(nil),    // 16: accounting.BudgetRequestSummary
-	(*DepartmentBudgetSummary)(nil), // 17: accounting.DepartmentBudgetSummary
-	nil,                             // 18: accounting.DepartmentBudgetSummary.StatusCountsEntry
-	(*timestamppb.Timestamp)(nil),   // 19: google.protobuf.Timestamp
-	(*Amount)(nil),                  // 20: accounting.Amount
-	(*Dimension)(nil),               // 21: accounting.Dimension
+	(*ForecastLine)(nil),            // 14: accounting.ForecastLine
+	(*BudgetForecast)(nil),          // 15: accounting.BudgetForecast
+	(*Department)(nil),              // 16: accounting.Department
+	(*BudgetVarianceItem)(nil),      // 17: accounting.BudgetVarianceItem
+	(*BudgetVarianceReport)(nil),    // 18: accounting.BudgetVarianceReport
+	(*BudgetRequestSummary)(nil),    // 19: accounting.BudgetRequestSummary
+	(*DepartmentBudgetSummary)(nil), // 20: accounting.DepartmentBudgetSummary
+	nil,                             // 21: accounting.DepartmentBudgetSummary.StatusCountsEntry
+	(*timestamppb.Timestamp)(nil),   // 22: google.protobuf.Timestamp
+	(*Amount)(nil),                  // 23: accounting.Amount
+	(*Dimension)(nil),               // 24: accounting.Dimension
 }
 var file_proto_accounting_zbb_proto_depIdxs = []int32{
-	19, // 0: accounting.BudgetPeriod.start_date:type_name -> google.protobuf.Timestamp
-	19, // 1: accounting.BudgetPeriod.end_date:type_name -> google.protobuf.Timestamp
+	22, // 0: accounting.BudgetPeriod.start_date:type_name -> google.protobuf.Timestamp
+	22, // 1: accounting.BudgetPeriod.end_date:type_name -> google.protobuf.Timestamp
 	0,  // 2: accounting.BudgetPeriod.status:type_name -> accounting.BudgetPeriodStatus
-	19, // 3: accounting.BudgetPeriod.created_at:type_name -> google.protobuf.Timestamp
-	20, // 4: accounting.BudgetLineItem.amount:type_name -> accounting.Amount
+	22, // 3: accounting.BudgetPeriod.created_at:type_name -> google.protobuf.Timestamp
+	23, // 4: accounting.BudgetLineItem.amount:type_name -> accounting.Amount
 	2,  // 5: accounting.BudgetLineItem.priority:type_name -> accounting.Priority
-	21, // 6: accounting.BudgetLineItem.dimensions:type_name -> accounting.Dimension
-	20, // 7: accounting.Alternative.cost:type_name -> accounting.Amount
+	24, // 6: accounting.BudgetLineItem.dimensions:type_name -> accounting.Dimension
+	23, // 7: accounting.Alternative.cost:type_name -> accounting.Amount
 	3,  // 8: accounting.Justification.category:type_name -> accounting.JustificationCategory
 	7,  // 9: accounting.Justification.alternatives:type_name -> accounting.Alternative
 	8,  // 10: accounting.Justification.metrics:type_name -> accounting.JustificationMetric
-	19, // 11: accounting.Justification.created_at:type_name -> google.protobuf.Timestamp
-	20, // 12: accounting.BudgetRequest.total_amount:type_name -> accounting.Amount
+	22, // 11: accounting.Justification.created_at:type_name -> google.protobuf.Timestamp
+	23, // 12: accounting.BudgetRequest.total_amount:type_name -> accounting.Amount
 	1,  // 13: accounting.BudgetRequest.status:type_name -> accounting.BudgetRequestStatus
 	6,  // 14: accounting.BudgetRequest.line_items:type_name -> accounting.BudgetLineItem
 	9,  // 15: accounting.BudgetRequest.justifications:type_name -> accounting.Justification
-	19, // 16: accounting.BudgetRequest.created_at:type_name -> google.protobuf.Timestamp
-	19, // 17: accounting.BudgetRequest.updated_at:type_name -> google.protobuf.Timestamp
-	19, // 18: accounting.BudgetRequest.submitted_at:type_name -> google.protobuf.Timestamp
-	19, // 19: accounting.BudgetRequest.approved_at:type_name -> google.protobuf.Timestamp
+	22, // 16: accounting.BudgetRequest.created_at:type_name -> google.protobuf.Timestamp
+	22, // 17: accounting.BudgetRequest.updated_at:type_name -> google.protobuf.Timestamp
+	22, // 18: accounting.BudgetRequest.submitted_at:type_name -> google.protobuf.Timestamp
+	22, // 19: accounting.BudgetRequest.approved_at:type_name -> google.protobuf.Timestamp
 	4,  // 20: accounting.BudgetApproval.status:type_name -> accounting.ApprovalStatus
-	20, // 21: accounting.BudgetApproval.approved_amount:type_name -> accounting.Amount
-	19, // 22: accounting.BudgetApproval.approved_at:type_name -> google.protobuf.Timestamp
-	19, // 23: accounting.BudgetApproval.created_at:type_name -> google.protobuf.Timestamp
-	20, // 24: accounting.BudgetAllocation.amount:type_name -> accounting.Amount
-	20, // 25: accounting.BudgetAllocation.spent_amount:type_name -> accounting.Amount
-	20, // 26: accounting.BudgetAllocation.remaining:type_name -> accounting.Amount
-	21, // 27: accounting.BudgetAllocation.dimensions:type_name -> accounting.Dimension
-	19, // 28: accounting.BudgetAllocation.created_at:type_name -> google.protobuf.Timestamp
-	19, // 29: accounting.BudgetAllocation.updated_at:type_name -> google.protobuf.Timestamp
-	20, // 30: accounting.BudgetTracking.amount:type_name -> accounting.Amount
-	19, // 31: accounting.BudgetTracking.tracked_at:type_name -> google.protobuf.Timestamp
-	20, // 32: accounting.BudgetTracking.remaining_budget:type_name -> accounting.Amount
-	20, // 33: accounting.BudgetVarianceItem.budget_amount:type_name -> accounting.Amount
-	20, // 34: accounting.BudgetVarianceItem.spent_amount:type_name -> accounting.Amount
-	20, // 35: accounting.BudgetVarianceItem.variance:type_name -> accounting.Amount
-	20, // 36: accounting.BudgetVarianceReport.total_budget:type_name -> accounting.Amount
-	20, // 37: accounting.BudgetVarianceReport.total_spent:type_name -> accounting.Amount
-	20, // 38: accounting.BudgetVarianceReport.total_variance:type_name -> accounting.Amount
-	14, // 39: accounting.BudgetVarianceReport.items:type_name -> accounting.BudgetVarianceItem
-	19, // 40: accounting.BudgetVarianceReport.generated_at:type_name -> google.protobuf.Timestamp
-	1,  // 41: accounting.BudgetRequestSummary.status:type_name -> accounting.BudgetRequestStatus
-	20, // 42: accounting.BudgetRequestSummary.requested_amount:type_name -> accounting.Amount
-	20, // 43: accounting.BudgetRequestSummary.approved_amount:type_name -> accounting.Amount
-	19, // 44: accounting.BudgetRequestSummary.submitted_at:type_name -> google.protobuf.Timestamp
-	19, // 45: accounting.BudgetRequestSummary.approved_at:type_name -> google.protobuf.Timestamp
-	20, // 46: accounting.DepartmentBudgetSummary.total_requested:type_name -> accounting.Amount
-	20, // 47: accounting.DepartmentBudgetSummary.total_approved:type_name -> accounting.Amount
-	18, // 48: accounting.DepartmentBudgetSummary.status_counts:type_name -> accounting.DepartmentBudgetSummary.StatusCountsEntry
-	16, // 49: accounting.DepartmentBudgetSummary.requests:type_name -> accounting.BudgetRequestSummary
-	19, // 50: accounting.DepartmentBudgetSummary.generated_at:type_name -> google.protobuf.Timestamp
-	51, // [51:51] is the sub-list for method output_type
-	51, // [51:51] is the sub-list for method input_type
-	51, // [51:51] is the sub-list for extension type_name
-	51, // [51:51] is the sub-list for extension extendee
-	0,  // [0:51] is the sub-list for field type_name
+	23, // 21: accounting.BudgetApproval.approved_amount:type_name -> accounting.Amount
+	22, // 22: accounting.BudgetApproval.approved_at:type_name -> google.protobuf.Timestamp
+	22, // 23: accounting.BudgetApproval.created_at:type_name -> google.protobuf.Timestamp
+	23, // 24: accounting.BudgetAllocation.amount:type_name -> accounting.Amount
+	23, // 25: accounting.BudgetAllocation.spent_amount:type_name -> accounting.Amount
+	23, // 26: accounting.BudgetAllocation.remaining:type_name -> accounting.Amount
+	24, // 27: accounting.BudgetAllocation.dimensions:type_name -> accounting.Dimension
+	22, // 28: accounting.BudgetAllocation.created_at:type_name -> google.protobuf.Timestamp
+	22, // 29: accounting.BudgetAllocation.updated_at:type_name -> google.protobuf.Timestamp
+	23, // 30: accounting.BudgetTracking.amount:type_name -> accounting.Amount
+	22, // 31: accounting.BudgetTracking.tracked_at:type_name -> google.protobuf.Timestamp
+	23, // 32: accounting.BudgetTracking.remaining_budget:type_name -> accounting.Amount
+	24, // 33: accounting.ForecastLine.dimensions:type_name -> accounting.Dimension
+	23, // 34: accounting.ForecastLine.amount:type_name -> accounting.Amount
+	14, // 35: accounting.BudgetForecast.lines:type_name -> accounting.ForecastLine
+	22, // 36: accounting.BudgetForecast.created_at:type_name -> google.protobuf.Timestamp
+	22, // 37: accounting.Department.active_from:type_name -> google.protobuf.Timestamp
+	22, // 38: accounting.Department.active_to:type_name -> google.protobuf.Timestamp
+	24, // 39: accounting.Department.default_dimensions:type_name -> accounting.Dimension
+	22, // 40: accounting.Department.created_at:type_name -> google.protobuf.Timestamp
+	22, // 41: accounting.Department.updated_at:type_name -> google.protobuf.Timestamp
+	23, // 42: accounting.BudgetVarianceItem.budget_amount:type_name -> accounting.Amount
+	23, // 43: accounting.BudgetVarianceItem.spent_amount:type_name -> accounting.Amount
+	23, // 44: accounting.BudgetVarianceItem.variance:type_name -> accounting.Amount
+	23, // 45: accounting.BudgetVarianceReport.total_budget:type_name -> accounting.Amount
+	23, // 46: accounting.BudgetVarianceReport.total_spent:type_name -> accounting.Amount
+	23, // 47: accounting.BudgetVarianceReport.total_variance:type_name -> accounting.Amount
+	17, // 48: accounting.BudgetVarianceReport.items:type_name -> accounting.BudgetVarianceItem
+	22, // 49: accounting.BudgetVarianceReport.generated_at:type_name -> google.protobuf.Timestamp
+	1,  // 50: accounting.BudgetRequestSummary.status:type_name -> accounting.BudgetRequestStatus
+	23, // 51: accounting.BudgetRequestSummary.requested_amount:type_name -> accounting.Amount
+	23, // 52: accounting.BudgetRequestSummary.approved_amount:type_name -> accounting.Amount
+	22, // 53: accounting.BudgetRequestSummary.submitted_at:type_name -> google.protobuf.Timestamp
+	22, // 54: accounting.BudgetRequestSummary.approved_at:type_name -> google.protobuf.Timestamp
+	23, // 55: accounting.DepartmentBudgetSummary.total_requested:type_name -> accounting.Amount
+	23, // 56: accounting.DepartmentBudgetSummary.total_approved:type_name -> accounting.Amount
+	21, // 57: accounting.DepartmentBudgetSummary.status_counts:type_name -> accounting.DepartmentBudgetSummary.StatusCountsEntry
+	19, // 58: accounting.DepartmentBudgetSummary.requests:type_name -> accounting.BudgetRequestSummary
+	22, // 59: accounting.DepartmentBudgetSummary.generated_at:type_name -> google.protobuf.Timestamp
+	60, // [60:60] is the sub-list for method output_type
+	60, // [60:60] is the sub-list for method input_type
+	60, // [60:60] is the sub-list for extension type_name
+	60, // [60:60] is the sub-list for extension extendee
+	0,  // [0:60] is the sub-list for field type_name
 }
 
 func init() { file_proto_accounting_zbb_proto_init() }
@@ -1991,7 +2383,7 @@ func file_proto_accounting_zbb_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_accounting_zbb_proto_rawDesc), len(file_proto_accounting_zbb_proto_rawDesc)),
 			NumEnums:      5,
-			NumMessages:   14,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -158,6 +158,7 @@ func (a *Account) ToProto() *pb.Account {
 		Currency:   string(a.Currency),
 		CreatedAt:  timeToProto(a.CreatedAt),
 		ClosedAt:   optionalTimeToProto(a.ClosedAt),
+		LedgerId:   a.LedgerID,
 	}
 }
 
@@ -190,6 +191,7 @@ func AccountFromProto(pbAcc *pb.Account) *Account {
 		Currency:   Currency(pbAcc.Currency),
 		CreatedAt:  protoToTime(pbAcc.CreatedAt),
 		ClosedAt:   protoToOptionalTime(pbAcc.ClosedAt),
+		LedgerID:   pbAcc.LedgerId,
 	}
 }
 
@@ -219,6 +221,9 @@ func (e *Entry) ToProto() *pb.Entry {
 		Type:          entryType,
 		Amount:        e.Amount.ToProto(),
 		Dimensions:    DimensionsToProto(e.Dimensions),
+		Memo:          e.Memo,
+		Reference:     e.Reference,
+		Tags:          e.Tags,
 	}
 }
 
@@ -247,6 +252,9 @@ func EntryFromProto(pbEntry *pb.Entry) *Entry {
 		Type:          entryType,
 		Amount:        *amount,
 		Dimensions:    DimensionsFromProto(pbEntry.Dimensions),
+		Memo:          pbEntry.Memo,
+		Reference:     pbEntry.Reference,
+		Tags:          pbEntry.Tags,
 	}
 }
 
@@ -288,6 +296,10 @@ func (t *Transaction) ToProto() *pb.Transaction {
 		status = pb.TransactionStatus_TRANSACTION_STATUS_REVERSED
 	case InBatch:
 		status = pb.TransactionStatus_TRANSACTION_STATUS_IN_BATCH
+	case PendingApproval:
+		status = pb.TransactionStatus_TRANSACTION_STATUS_PENDING_APPROVAL
+	case Rejected:
+		status = pb.TransactionStatus_TRANSACTION_STATUS_REJECTED
 	default:
 		status = pb.TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
 	}
@@ -303,6 +315,11 @@ func (t *Transaction) ToProto() *pb.Transaction {
 		UserId:          t.UserID,
 		CreatedAt:       timeToProto(t.CreatedAt),
 		UpdatedAt:       timeToProto(t.UpdatedAt),
+		CreatedBy:       t.CreatedBy,
+		PostedBy:        t.PostedBy,
+		ApprovedBy:      t.ApprovedBy,
+		LedgerId:        t.LedgerID,
+		IdempotencyKey:  t.IdempotencyKey,
 	}
 }
 
@@ -321,6 +338,10 @@ func TransactionFromProto(pbTxn *pb.Transaction) *Transaction {
 		status = Reversed
 	case pb.TransactionStatus_TRANSACTION_STATUS_IN_BATCH:
 		status = InBatch
+	case pb.TransactionStatus_TRANSACTION_STATUS_PENDING_APPROVAL:
+		status = PendingApproval
+	case pb.TransactionStatus_TRANSACTION_STATUS_REJECTED:
+		status = Rejected
 	}
 	
 	return &Transaction{
@@ -334,6 +355,11 @@ func TransactionFromProto(pbTxn *pb.Transaction) *Transaction {
 		UserID:          pbTxn.UserId,
 		CreatedAt:       protoToTime(pbTxn.CreatedAt),
 		UpdatedAt:       protoToTime(pbTxn.UpdatedAt),
+		CreatedBy:       pbTxn.CreatedBy,
+		PostedBy:        pbTxn.PostedBy,
+		ApprovedBy:      pbTxn.ApprovedBy,
+		LedgerID:        pbTxn.LedgerId,
+		IdempotencyKey:  pbTxn.IdempotencyKey,
 	}
 }
 
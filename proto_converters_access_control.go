@@ -0,0 +1,61 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Access Control Conversions
+// ====================================================================================
+
+func (r *Role) ToProto() *pb.Role {
+	permissions := make([]string, len(r.Permissions))
+	for i, permission := range r.Permissions {
+		permissions[i] = string(permission)
+	}
+	return &pb.Role{
+		Id:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: permissions,
+		CreatedBy:   r.CreatedBy,
+		CreatedAt:   timeToProto(r.CreatedAt),
+	}
+}
+
+func RoleFromProto(pbRole *pb.Role) *Role {
+	permissions := make([]Permission, len(pbRole.Permissions))
+	for i, permission := range pbRole.Permissions {
+		permissions[i] = Permission(permission)
+	}
+	return &Role{
+		ID:          pbRole.Id,
+		Name:        pbRole.Name,
+		Description: pbRole.Description,
+		Permissions: permissions,
+		CreatedBy:   pbRole.CreatedBy,
+		CreatedAt:   protoToTime(pbRole.CreatedAt),
+	}
+}
+
+func (u *User) ToProto() *pb.User {
+	return &pb.User{
+		Id:        u.ID,
+		Name:      u.Name,
+		RoleIds:   u.RoleIDs,
+		Active:    u.Active,
+		CreatedBy: u.CreatedBy,
+		CreatedAt: timeToProto(u.CreatedAt),
+	}
+}
+
+func UserFromProto(pbUser *pb.User) *User {
+	return &User{
+		ID:        pbUser.Id,
+		Name:      pbUser.Name,
+		RoleIDs:   pbUser.RoleIds,
+		Active:    pbUser.Active,
+		CreatedBy: pbUser.CreatedBy,
+		CreatedAt: protoToTime(pbUser.CreatedAt),
+	}
+}
@@ -0,0 +1,49 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Account Numbering Conversions
+// ====================================================================================
+
+func (s *AccountNumberingScheme) ToProto() *pb.AccountNumberingScheme {
+	if s == nil {
+		return nil
+	}
+	ranges := make([]*pb.AccountCodeRange, len(s.Ranges))
+	for i, r := range s.Ranges {
+		ranges[i] = &pb.AccountCodeRange{
+			AccountType: string(r.AccountType),
+			From:        r.From,
+			To:          r.To,
+		}
+	}
+	return &pb.AccountNumberingScheme{
+		Name:       s.Name,
+		CodeLength: int32(s.CodeLength),
+		Ranges:     ranges,
+		ChildStep:  s.ChildStep,
+	}
+}
+
+func AccountNumberingSchemeFromProto(pbScheme *pb.AccountNumberingScheme) *AccountNumberingScheme {
+	if pbScheme == nil {
+		return nil
+	}
+	ranges := make([]AccountCodeRange, len(pbScheme.Ranges))
+	for i, r := range pbScheme.Ranges {
+		ranges[i] = AccountCodeRange{
+			AccountType: AccountType(r.AccountType),
+			From:        r.From,
+			To:          r.To,
+		}
+	}
+	return &AccountNumberingScheme{
+		Name:       pbScheme.Name,
+		CodeLength: int(pbScheme.CodeLength),
+		Ranges:     ranges,
+		ChildStep:  pbScheme.ChildStep,
+	}
+}
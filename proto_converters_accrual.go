@@ -0,0 +1,86 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// RecognitionEntry Conversions
+// ====================================================================================
+
+func (e *RecognitionEntry) ToProto() *pb.RecognitionEntry {
+	return &pb.RecognitionEntry{
+		Id:              e.ID,
+		ScheduleId:      e.ScheduleID,
+		PeriodNumber:    int32(e.PeriodNumber),
+		RecognitionDate: timeToProto(e.RecognitionDate),
+		Amount:          e.Amount.ToProto(),
+		Status:          e.Status,
+		TransactionId:   e.TransactionID,
+		ProcessedAt:     optionalTimeToProto(e.ProcessedAt),
+		Error:           e.Error,
+
+		ReversalTransactionId: e.ReversalTransactionID,
+	}
+}
+
+func RecognitionEntryFromProto(pbEntry *pb.RecognitionEntry) *RecognitionEntry {
+	return &RecognitionEntry{
+		ID:              pbEntry.Id,
+		ScheduleID:      pbEntry.ScheduleId,
+		PeriodNumber:    int(pbEntry.PeriodNumber),
+		RecognitionDate: protoToTime(pbEntry.RecognitionDate),
+		Amount:          AmountFromProto(pbEntry.Amount),
+		Status:          pbEntry.Status,
+		TransactionID:   pbEntry.TransactionId,
+		ProcessedAt:     protoToOptionalTime(pbEntry.ProcessedAt),
+		Error:           pbEntry.Error,
+
+		ReversalTransactionID: pbEntry.ReversalTransactionId,
+	}
+}
+
+// ====================================================================================
+// AccrualTemplate Conversions
+// ====================================================================================
+
+// accrualTypesToProto maps accrual types to their proto enum
+var accrualTypesToProto = map[AccrualType]pb.AccrualType{
+	AccrualRevenue:  pb.AccrualType_ACCRUAL_TYPE_REVENUE,
+	AccrualExpense:  pb.AccrualType_ACCRUAL_TYPE_EXPENSE,
+	DeferralRevenue: pb.AccrualType_ACCRUAL_TYPE_DEFERRED_REVENUE,
+	DeferralExpense: pb.AccrualType_ACCRUAL_TYPE_DEFERRED_EXPENSE,
+}
+
+func (t *AccrualTemplate) ToProto() *pb.AccrualTemplate {
+	return &pb.AccrualTemplate{
+		Id:                t.ID,
+		Name:              t.Name,
+		AccrualType:       accrualTypesToProto[t.AccrualType],
+		AccrualAccountId:  t.AccrualAccountID,
+		RevenueAccountId:  t.RevenueAccountID,
+		ExpenseAccountId:  t.ExpenseAccountID,
+		DeferralAccountId: t.DeferralAccountID,
+		Dimensions:        DimensionsToProto(t.Dimensions),
+		Reverse:           t.Reverse,
+	}
+}
+
+func AccrualTemplateFromProto(pbTemplate *pb.AccrualTemplate) *AccrualTemplate {
+	template := &AccrualTemplate{
+		ID:                pbTemplate.Id,
+		Name:              pbTemplate.Name,
+		AccrualAccountID:  pbTemplate.AccrualAccountId,
+		RevenueAccountID:  pbTemplate.RevenueAccountId,
+		ExpenseAccountID:  pbTemplate.ExpenseAccountId,
+		DeferralAccountID: pbTemplate.DeferralAccountId,
+		Dimensions:        DimensionsFromProto(pbTemplate.Dimensions),
+		Reverse:           pbTemplate.Reverse,
+	}
+	for accrualType, pbType := range accrualTypesToProto {
+		if pbType == pbTemplate.AccrualType {
+			template.AccrualType = accrualType
+		}
+	}
+	return template
+}
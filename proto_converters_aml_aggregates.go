@@ -0,0 +1,98 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// AML Aggregate Conversions
+// ====================================================================================
+
+func (c *AMLCustomerActivity) ToProto() *pb.AMLCustomerActivity {
+	if c == nil {
+		return nil
+	}
+	alertsByType := make(map[string]int32, len(c.AlertsByType))
+	for ruleType, count := range c.AlertsByType {
+		alertsByType[string(ruleType)] = int32(count)
+	}
+	return &pb.AMLCustomerActivity{
+		CustomerId:        c.CustomerID,
+		AlertCount:        int32(c.AlertCount),
+		AlertVolume:       c.AlertVolume,
+		AlertsByType:      alertsByType,
+		LastActivity:      timeToProto(c.LastActivity),
+		TransactionCount:  c.TransactionCount,
+		TransactionVolume: c.TransactionVolume,
+	}
+}
+
+func AMLCustomerActivityFromProto(pbActivity *pb.AMLCustomerActivity) *AMLCustomerActivity {
+	if pbActivity == nil {
+		return nil
+	}
+	alertsByType := make(map[AMLRuleType]int, len(pbActivity.AlertsByType))
+	for ruleType, count := range pbActivity.AlertsByType {
+		alertsByType[AMLRuleType(ruleType)] = int(count)
+	}
+	return &AMLCustomerActivity{
+		CustomerID:        pbActivity.CustomerId,
+		AlertCount:        int(pbActivity.AlertCount),
+		AlertVolume:       pbActivity.AlertVolume,
+		AlertsByType:      alertsByType,
+		LastActivity:      protoToTime(pbActivity.LastActivity),
+		TransactionCount:  pbActivity.TransactionCount,
+		TransactionVolume: pbActivity.TransactionVolume,
+	}
+}
+
+func (a *AMLDailyAggregate) ToProto() *pb.AMLDailyAggregate {
+	if a == nil {
+		return nil
+	}
+	alertsByRiskLevel := make(map[string]int32, len(a.AlertsByRiskLevel))
+	for level, count := range a.AlertsByRiskLevel {
+		alertsByRiskLevel[string(level)] = int32(count)
+	}
+	alertsByType := make(map[string]int32, len(a.AlertsByType))
+	for ruleType, count := range a.AlertsByType {
+		alertsByType[string(ruleType)] = int32(count)
+	}
+	customers := make(map[string]*pb.AMLCustomerActivity, len(a.Customers))
+	for customerID, activity := range a.Customers {
+		customers[customerID] = activity.ToProto()
+	}
+	return &pb.AMLDailyAggregate{
+		Day:               a.Day,
+		TotalAlerts:       int32(a.TotalAlerts),
+		AlertsByRiskLevel: alertsByRiskLevel,
+		AlertsByType:      alertsByType,
+		ClosedAlerts:      int32(a.ClosedAlerts),
+		FalsePositives:    int32(a.FalsePositives),
+		TransactionCount:  a.TransactionCount,
+		TransactionVolume: a.TransactionVolume,
+		Customers:         customers,
+	}
+}
+
+func AMLDailyAggregateFromProto(pbAggregate *pb.AMLDailyAggregate) *AMLDailyAggregate {
+	if pbAggregate == nil {
+		return nil
+	}
+	aggregate := newAMLDailyAggregate(pbAggregate.Day)
+	aggregate.TotalAlerts = int(pbAggregate.TotalAlerts)
+	for level, count := range pbAggregate.AlertsByRiskLevel {
+		aggregate.AlertsByRiskLevel[AMLRiskLevel(level)] = int(count)
+	}
+	for ruleType, count := range pbAggregate.AlertsByType {
+		aggregate.AlertsByType[AMLRuleType(ruleType)] = int(count)
+	}
+	aggregate.ClosedAlerts = int(pbAggregate.ClosedAlerts)
+	aggregate.FalsePositives = int(pbAggregate.FalsePositives)
+	aggregate.TransactionCount = pbAggregate.TransactionCount
+	aggregate.TransactionVolume = pbAggregate.TransactionVolume
+	for customerID, activity := range pbAggregate.Customers {
+		aggregate.Customers[customerID] = AMLCustomerActivityFromProto(activity)
+	}
+	return aggregate
+}
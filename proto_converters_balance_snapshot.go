@@ -0,0 +1,61 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Balance Snapshot Conversions
+// ====================================================================================
+
+func (s *AccountBalanceSnapshot) ToProto() *pb.AccountBalanceSnapshot {
+	if s == nil {
+		return nil
+	}
+	return &pb.AccountBalanceSnapshot{
+		AccountId:     s.AccountID,
+		Period:        s.Period,
+		Debits:        s.Debits,
+		Credits:       s.Credits,
+		EntryCount:    s.EntryCount,
+		LastValidTime: timeToProto(s.LastValidTime),
+		UpdatedAt:     timeToProto(s.UpdatedAt),
+	}
+}
+
+func AccountBalanceSnapshotFromProto(pbSnapshot *pb.AccountBalanceSnapshot) *AccountBalanceSnapshot {
+	if pbSnapshot == nil {
+		return nil
+	}
+	return &AccountBalanceSnapshot{
+		AccountID:     pbSnapshot.AccountId,
+		Period:        pbSnapshot.Period,
+		Debits:        pbSnapshot.Debits,
+		Credits:       pbSnapshot.Credits,
+		EntryCount:    pbSnapshot.EntryCount,
+		LastValidTime: protoToTime(pbSnapshot.LastValidTime),
+		UpdatedAt:     protoToTime(pbSnapshot.UpdatedAt),
+	}
+}
+
+func (m *BalanceMovement) ToProto() *pb.BalanceMovement {
+	if m == nil {
+		return nil
+	}
+	return &pb.BalanceMovement{
+		Debits:     m.Debits,
+		Credits:    m.Credits,
+		EntryCount: m.EntryCount,
+	}
+}
+
+func BalanceMovementFromProto(pbMovement *pb.BalanceMovement) *BalanceMovement {
+	if pbMovement == nil {
+		return nil
+	}
+	return &BalanceMovement{
+		Debits:     pbMovement.Debits,
+		Credits:    pbMovement.Credits,
+		EntryCount: pbMovement.EntryCount,
+	}
+}
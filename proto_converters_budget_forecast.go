@@ -0,0 +1,63 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Budget Forecast Conversions
+// ====================================================================================
+
+func (f *BudgetForecast) ToProto() *pb.BudgetForecast {
+	if f == nil {
+		return nil
+	}
+	lines := make([]*pb.ForecastLine, len(f.Lines))
+	for i, line := range f.Lines {
+		lines[i] = &pb.ForecastLine{
+			AccountId:  line.AccountID,
+			Dimensions: DimensionsToProto(line.Dimensions),
+			Month:      line.Month,
+			Amount:     line.Amount.ToProto(),
+		}
+	}
+	return &pb.BudgetForecast{
+		Id:           f.ID,
+		PeriodId:     f.PeriodID,
+		DepartmentId: f.DepartmentID,
+		Version:      int32(f.Version),
+		Kind:         string(f.Kind),
+		FromMonth:    f.FromMonth,
+		Lines:        lines,
+		Notes:        f.Notes,
+		CreatedBy:    f.CreatedBy,
+		CreatedAt:    timeToProto(f.CreatedAt),
+	}
+}
+
+func BudgetForecastFromProto(pbForecast *pb.BudgetForecast) *BudgetForecast {
+	if pbForecast == nil {
+		return nil
+	}
+	lines := make([]ForecastLine, len(pbForecast.Lines))
+	for i, line := range pbForecast.Lines {
+		lines[i] = ForecastLine{
+			AccountID:  line.AccountId,
+			Dimensions: DimensionsFromProto(line.Dimensions),
+			Month:      line.Month,
+			Amount:     AmountFromProto(line.Amount),
+		}
+	}
+	return &BudgetForecast{
+		ID:           pbForecast.Id,
+		PeriodID:     pbForecast.PeriodId,
+		DepartmentID: pbForecast.DepartmentId,
+		Version:      int(pbForecast.Version),
+		Kind:         ForecastKind(pbForecast.Kind),
+		FromMonth:    pbForecast.FromMonth,
+		Lines:        lines,
+		Notes:        pbForecast.Notes,
+		CreatedBy:    pbForecast.CreatedBy,
+		CreatedAt:    protoToTime(pbForecast.CreatedAt),
+	}
+}
@@ -0,0 +1,110 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Supporting Calculation Conversions
+// ====================================================================================
+
+func (v CalculationValue) ToProto() *pb.CalculationValue {
+	return &pb.CalculationValue{
+		Name:        v.Name,
+		Value:       v.Value,
+		Unit:        v.Unit,
+		Description: v.Description,
+	}
+}
+
+func CalculationValueFromProto(pbValue *pb.CalculationValue) CalculationValue {
+	if pbValue == nil {
+		return CalculationValue{}
+	}
+	return CalculationValue{
+		Name:        pbValue.Name,
+		Value:       pbValue.Value,
+		Unit:        pbValue.Unit,
+		Description: pbValue.Description,
+	}
+}
+
+func calculationValuesToProto(values []CalculationValue) []*pb.CalculationValue {
+	var pbValues []*pb.CalculationValue
+	for _, value := range values {
+		pbValues = append(pbValues, value.ToProto())
+	}
+	return pbValues
+}
+
+func calculationValuesFromProto(pbValues []*pb.CalculationValue) []CalculationValue {
+	var values []CalculationValue
+	for _, pbValue := range pbValues {
+		values = append(values, CalculationValueFromProto(pbValue))
+	}
+	return values
+}
+
+func (b *CalculationBreakdown) ToProto() *pb.CalculationBreakdown {
+	if b == nil {
+		return nil
+	}
+	return &pb.CalculationBreakdown{
+		Formula:    b.Formula,
+		Inputs:     calculationValuesToProto(b.Inputs),
+		Steps:      calculationValuesToProto(b.Steps),
+		Result:     b.Result.ToProto(),
+		Notes:      b.Notes,
+		ComputedAt: timeToProto(b.ComputedAt),
+		ComputedBy: b.ComputedBy,
+	}
+}
+
+func CalculationBreakdownFromProto(pbBreakdown *pb.CalculationBreakdown) *CalculationBreakdown {
+	if pbBreakdown == nil {
+		return nil
+	}
+	return &CalculationBreakdown{
+		Formula:    pbBreakdown.Formula,
+		Inputs:     calculationValuesFromProto(pbBreakdown.Inputs),
+		Steps:      calculationValuesFromProto(pbBreakdown.Steps),
+		Result:     CalculationValueFromProto(pbBreakdown.Result),
+		Notes:      pbBreakdown.Notes,
+		ComputedAt: protoToTime(pbBreakdown.ComputedAt),
+		ComputedBy: pbBreakdown.ComputedBy,
+	}
+}
+
+func (t *TaxCalculation) ToProto() *pb.TaxCalculation {
+	if t == nil {
+		return nil
+	}
+	return &pb.TaxCalculation{
+		RuleId:        t.RuleID,
+		BaseAmount:    t.BaseAmount,
+		TaxableAmount: t.TaxableAmount,
+		TaxRate:       t.TaxRate,
+		TaxAmount:     t.TaxAmount,
+		Exemptions:    t.Exemptions,
+		CalculatedAt:  timeToProto(t.CalculatedAt),
+		Breakdown:     t.Breakdown.ToProto(),
+		Components:    taxComponentsToProto(t.Components),
+	}
+}
+
+func TaxCalculationFromProto(pbCalc *pb.TaxCalculation) *TaxCalculation {
+	if pbCalc == nil {
+		return nil
+	}
+	return &TaxCalculation{
+		RuleID:        pbCalc.RuleId,
+		BaseAmount:    pbCalc.BaseAmount,
+		TaxableAmount: pbCalc.TaxableAmount,
+		TaxRate:       pbCalc.TaxRate,
+		TaxAmount:     pbCalc.TaxAmount,
+		Exemptions:    pbCalc.Exemptions,
+		CalculatedAt:  protoToTime(pbCalc.CalculatedAt),
+		Breakdown:     CalculationBreakdownFromProto(pbCalc.Breakdown),
+		Components:    taxComponentsFromProto(pbCalc.Components),
+	}
+}
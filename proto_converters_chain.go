@@ -0,0 +1,105 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// On-Chain Reconciliation Conversions
+// ====================================================================================
+
+func (ct *ChainTransfer) ToProto() *pb.ChainTransfer {
+	if ct == nil {
+		return nil
+	}
+	return &pb.ChainTransfer{
+		TxHash:      ct.TxHash,
+		FromAddress: ct.FromAddress,
+		ToAddress:   ct.ToAddress,
+		Amount:      ct.Amount,
+		BlockNumber: ct.BlockNumber,
+		BlockTime:   timeToProto(ct.BlockTime),
+	}
+}
+
+func ChainTransferFromProto(pbTransfer *pb.ChainTransfer) *ChainTransfer {
+	if pbTransfer == nil {
+		return nil
+	}
+	return &ChainTransfer{
+		TxHash:      pbTransfer.TxHash,
+		FromAddress: pbTransfer.FromAddress,
+		ToAddress:   pbTransfer.ToAddress,
+		Amount:      pbTransfer.Amount,
+		BlockNumber: pbTransfer.BlockNumber,
+		BlockTime:   protoToTime(pbTransfer.BlockTime),
+	}
+}
+
+func (cr *ChainReconciliation) ToProto() *pb.ChainReconciliation {
+	if cr == nil {
+		return nil
+	}
+	discrepancies := make([]*pb.ChainDiscrepancy, len(cr.Discrepancies))
+	for i, d := range cr.Discrepancies {
+		discrepancies[i] = &pb.ChainDiscrepancy{
+			Type:        string(d.Type),
+			Description: d.Description,
+			EntryId:     d.EntryID,
+			Transfer:    d.Transfer.ToProto(),
+			Amount:      d.Amount,
+			AlertId:     d.AlertID,
+		}
+	}
+	return &pb.ChainReconciliation{
+		Id:            cr.ID,
+		AccountId:     cr.AccountID,
+		Chain:         cr.Chain,
+		Address:       cr.Address,
+		Token:         cr.Token,
+		PeriodStart:   timeToProto(cr.PeriodStart),
+		PeriodEnd:     timeToProto(cr.PeriodEnd),
+		LedgerBalance: cr.LedgerBalance.ToProto(),
+		ChainBalance:  cr.ChainBalance.ToProto(),
+		Difference:    cr.Difference.ToProto(),
+		MatchedCount:  int32(cr.MatchedCount),
+		Discrepancies: discrepancies,
+		Status:        string(cr.Status),
+		CreatedBy:     cr.CreatedBy,
+		CreatedAt:     timeToProto(cr.CreatedAt),
+	}
+}
+
+func ChainReconciliationFromProto(pbRecon *pb.ChainReconciliation) *ChainReconciliation {
+	if pbRecon == nil {
+		return nil
+	}
+	discrepancies := make([]*ChainDiscrepancy, len(pbRecon.Discrepancies))
+	for i, d := range pbRecon.Discrepancies {
+		discrepancies[i] = &ChainDiscrepancy{
+			Type:        ChainDiscrepancyType(d.Type),
+			Description: d.Description,
+			EntryID:     d.EntryId,
+			Transfer:    ChainTransferFromProto(d.Transfer),
+			Amount:      d.Amount,
+			AlertID:     d.AlertId,
+		}
+	}
+	return &ChainReconciliation{
+		ID:            pbRecon.Id,
+		AccountID:     pbRecon.AccountId,
+		Chain:         pbRecon.Chain,
+		Address:       pbRecon.Address,
+		Token:         pbRecon.Token,
+		PeriodStart:   protoToTime(pbRecon.PeriodStart),
+		PeriodEnd:     protoToTime(pbRecon.PeriodEnd),
+		LedgerBalance: AmountFromProto(pbRecon.LedgerBalance),
+		ChainBalance:  AmountFromProto(pbRecon.ChainBalance),
+		Difference:    AmountFromProto(pbRecon.Difference),
+		MatchedCount:  int(pbRecon.MatchedCount),
+		Discrepancies: discrepancies,
+		Status:        ChainReconciliationStatus(pbRecon.Status),
+		CreatedBy:     pbRecon.CreatedBy,
+		CreatedAt:     protoToTime(pbRecon.CreatedAt),
+	}
+}
@@ -0,0 +1,117 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Client Money Conversions
+// ====================================================================================
+
+func (ca *ClientMoneyAccount) ToProto() *pb.ClientMoneyAccount {
+	if ca == nil {
+		return nil
+	}
+	return &pb.ClientMoneyAccount{
+		Id:           ca.ID,
+		Role:         string(ca.Role),
+		Currency:     string(ca.Currency),
+		DesignatedBy: ca.DesignatedBy,
+		DesignatedAt: timeToProto(ca.DesignatedAt),
+	}
+}
+
+func ClientMoneyAccountFromProto(pbAccount *pb.ClientMoneyAccount) *ClientMoneyAccount {
+	if pbAccount == nil {
+		return nil
+	}
+	return &ClientMoneyAccount{
+		ID:           pbAccount.Id,
+		Role:         ClientMoneyRole(pbAccount.Role),
+		Currency:     Currency(pbAccount.Currency),
+		DesignatedBy: pbAccount.DesignatedBy,
+		DesignatedAt: protoToTime(pbAccount.DesignatedAt),
+	}
+}
+
+func (cr *ClientMoneyReconciliation) ToProto() *pb.ClientMoneyReconciliation {
+	if cr == nil {
+		return nil
+	}
+	banks := make([]*pb.ClientBankBalance, len(cr.BankAccounts))
+	for i, bank := range cr.BankAccounts {
+		banks[i] = &pb.ClientBankBalance{
+			AccountId:  bank.AccountID,
+			CashBook:   bank.CashBook,
+			Difference: bank.Difference,
+		}
+		if bank.Statement != nil {
+			banks[i].HasStatement = true
+			banks[i].Statement = *bank.Statement
+		}
+	}
+	clients := make([]*pb.ClientLedgerBalance, len(cr.Clients))
+	for i, client := range cr.Clients {
+		clients[i] = &pb.ClientLedgerBalance{
+			ClientId: client.ClientID,
+			Balance:  client.Balance,
+		}
+	}
+	return &pb.ClientMoneyReconciliation{
+		Id:               cr.ID,
+		AsOfDate:         timeToProto(cr.AsOfDate),
+		Currency:         string(cr.Currency),
+		BankAccounts:     banks,
+		Clients:          clients,
+		Requirement:      cr.Requirement,
+		Resource:         cr.Resource,
+		Shortfall:        cr.Shortfall,
+		Excess:           cr.Excess,
+		OverdrawnClients: cr.OverdrawnClients,
+		Status:           string(cr.Status),
+		AlertId:          cr.AlertID,
+		PerformedBy:      cr.PerformedBy,
+		PerformedAt:      timeToProto(cr.PerformedAt),
+	}
+}
+
+func ClientMoneyReconciliationFromProto(pbRecon *pb.ClientMoneyReconciliation) *ClientMoneyReconciliation {
+	if pbRecon == nil {
+		return nil
+	}
+	banks := make([]ClientBankBalance, len(pbRecon.BankAccounts))
+	for i, bank := range pbRecon.BankAccounts {
+		banks[i] = ClientBankBalance{
+			AccountID:  bank.AccountId,
+			CashBook:   bank.CashBook,
+			Difference: bank.Difference,
+		}
+		if bank.HasStatement {
+			statement := bank.Statement
+			banks[i].Statement = &statement
+		}
+	}
+	clients := make([]ClientLedgerBalance, len(pbRecon.Clients))
+	for i, client := range pbRecon.Clients {
+		clients[i] = ClientLedgerBalance{
+			ClientID: client.ClientId,
+			Balance:  client.Balance,
+		}
+	}
+	return &ClientMoneyReconciliation{
+		ID:               pbRecon.Id,
+		AsOfDate:         protoToTime(pbRecon.AsOfDate),
+		Currency:         Currency(pbRecon.Currency),
+		BankAccounts:     banks,
+		Clients:          clients,
+		Requirement:      pbRecon.Requirement,
+		Resource:         pbRecon.Resource,
+		Shortfall:        pbRecon.Shortfall,
+		Excess:           pbRecon.Excess,
+		OverdrawnClients: pbRecon.OverdrawnClients,
+		Status:           ClientMoneyStatus(pbRecon.Status),
+		AlertID:          pbRecon.AlertId,
+		PerformedBy:      pbRecon.PerformedBy,
+		PerformedAt:      protoToTime(pbRecon.PerformedAt),
+	}
+}
@@ -0,0 +1,195 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Period Close Conversions
+// ====================================================================================
+
+func (v *VarianceCommentary) ToProto() *pb.VarianceCommentary {
+	if v == nil {
+		return nil
+	}
+	return &pb.VarianceCommentary{
+		Id:        v.ID,
+		PeriodId:  v.PeriodID,
+		AccountId: v.AccountID,
+		Comment:   v.Comment,
+		CreatedBy: v.CreatedBy,
+		CreatedAt: timeToProto(v.CreatedAt),
+	}
+}
+
+func VarianceCommentaryFromProto(pbComment *pb.VarianceCommentary) *VarianceCommentary {
+	if pbComment == nil {
+		return nil
+	}
+	return &VarianceCommentary{
+		ID:        pbComment.Id,
+		PeriodID:  pbComment.PeriodId,
+		AccountID: pbComment.AccountId,
+		Comment:   pbComment.Comment,
+		CreatedBy: pbComment.CreatedBy,
+		CreatedAt: protoToTime(pbComment.CreatedAt),
+	}
+}
+
+func (s *CloseSignOff) ToProto() *pb.CloseSignOff {
+	if s == nil {
+		return nil
+	}
+	return &pb.CloseSignOff{
+		Id:       s.ID,
+		PeriodId: s.PeriodID,
+		Role:     string(s.Role),
+		UserId:   s.UserID,
+		Comments: s.Comments,
+		SignedAt: timeToProto(s.SignedAt),
+	}
+}
+
+func CloseSignOffFromProto(pbSignOff *pb.CloseSignOff) *CloseSignOff {
+	if pbSignOff == nil {
+		return nil
+	}
+	return &CloseSignOff{
+		ID:       pbSignOff.Id,
+		PeriodID: pbSignOff.PeriodId,
+		Role:     SignOffRole(pbSignOff.Role),
+		UserID:   pbSignOff.UserId,
+		Comments: pbSignOff.Comments,
+		SignedAt: protoToTime(pbSignOff.SignedAt),
+	}
+}
+
+func (c *ClosingBinderArchive) ToProto() *pb.ClosingBinderArchive {
+	if c == nil {
+		return nil
+	}
+	index := make([]*pb.BinderIndexEntry, len(c.Index))
+	for i, entry := range c.Index {
+		index[i] = &pb.BinderIndexEntry{
+			Section:   string(entry.Section),
+			FileName:  entry.FileName,
+			Title:     entry.Title,
+			ItemCount: int32(entry.ItemCount),
+			Sha256:    entry.SHA256,
+		}
+	}
+	return &pb.ClosingBinderArchive{
+		Id:          c.ID,
+		PeriodId:    c.PeriodID,
+		PeriodName:  c.PeriodName,
+		Currency:    c.Currency,
+		GeneratedAt: timeToProto(c.GeneratedAt),
+		GeneratedBy: c.GeneratedBy,
+		Index:       index,
+		Archive:     c.Archive,
+	}
+}
+
+func ClosingBinderArchiveFromProto(pbArchive *pb.ClosingBinderArchive) *ClosingBinderArchive {
+	if pbArchive == nil {
+		return nil
+	}
+	index := make([]BinderIndexEntry, len(pbArchive.Index))
+	for i, entry := range pbArchive.Index {
+		index[i] = BinderIndexEntry{
+			Section:   BinderSection(entry.Section),
+			FileName:  entry.FileName,
+			Title:     entry.Title,
+			ItemCount: int(entry.ItemCount),
+			SHA256:    entry.Sha256,
+		}
+	}
+	return &ClosingBinderArchive{
+		ID:          pbArchive.Id,
+		PeriodID:    pbArchive.PeriodId,
+		PeriodName:  pbArchive.PeriodName,
+		Currency:    pbArchive.Currency,
+		GeneratedAt: protoToTime(pbArchive.GeneratedAt),
+		GeneratedBy: pbArchive.GeneratedBy,
+		Index:       index,
+		Archive:     pbArchive.Archive,
+	}
+}
+
+func (c *PeriodCloseChecklist) ToProto() *pb.PeriodCloseChecklist {
+	if c == nil {
+		return nil
+	}
+	items := make([]*pb.CloseChecklistItem, len(c.Items))
+	for i, item := range c.Items {
+		items[i] = &pb.CloseChecklistItem{
+			Step:        item.Step,
+			Description: item.Description,
+			Required:    item.Required,
+			Status:      string(item.Status),
+			Note:        item.Note,
+			CompletedBy: item.CompletedBy,
+			CompletedAt: optionalTimeToProto(item.CompletedAt),
+		}
+	}
+	return &pb.PeriodCloseChecklist{
+		Id:        c.ID,
+		Items:     items,
+		UpdatedBy: c.UpdatedBy,
+		UpdatedAt: timeToProto(c.UpdatedAt),
+	}
+}
+
+func PeriodCloseChecklistFromProto(pbChecklist *pb.PeriodCloseChecklist) *PeriodCloseChecklist {
+	if pbChecklist == nil {
+		return nil
+	}
+	items := make([]CloseChecklistItem, len(pbChecklist.Items))
+	for i, item := range pbChecklist.Items {
+		items[i] = CloseChecklistItem{
+			Step:        item.Step,
+			Description: item.Description,
+			Required:    item.Required,
+			Status:      ChecklistItemStatus(item.Status),
+			Note:        item.Note,
+			CompletedBy: item.CompletedBy,
+			CompletedAt: protoToOptionalTime(item.CompletedAt),
+		}
+	}
+	return &PeriodCloseChecklist{
+		ID:        pbChecklist.Id,
+		Items:     items,
+		UpdatedBy: pbChecklist.UpdatedBy,
+		UpdatedAt: protoToTime(pbChecklist.UpdatedAt),
+	}
+}
+
+func (r *PeriodReopening) ToProto() *pb.PeriodReopening {
+	if r == nil {
+		return nil
+	}
+	return &pb.PeriodReopening{
+		Id:           r.ID,
+		PeriodId:     r.PeriodID,
+		Reason:       r.Reason,
+		SoftClosedAt: optionalTimeToProto(r.SoftClosedAt),
+		HardClosedAt: optionalTimeToProto(r.HardClosedAt),
+		ReopenedBy:   r.ReopenedBy,
+		ReopenedAt:   timeToProto(r.ReopenedAt),
+	}
+}
+
+func PeriodReopeningFromProto(pbReopening *pb.PeriodReopening) *PeriodReopening {
+	if pbReopening == nil {
+		return nil
+	}
+	return &PeriodReopening{
+		ID:           pbReopening.Id,
+		PeriodID:     pbReopening.PeriodId,
+		Reason:       pbReopening.Reason,
+		SoftClosedAt: protoToOptionalTime(pbReopening.SoftClosedAt),
+		HardClosedAt: protoToOptionalTime(pbReopening.HardClosedAt),
+		ReopenedBy:   pbReopening.ReopenedBy,
+		ReopenedAt:   protoToTime(pbReopening.ReopenedAt),
+	}
+}
@@ -0,0 +1,35 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Compliance Violation Remediation Conversions
+// ====================================================================================
+
+func violationCommentsToProto(comments []ViolationComment) []*pb.ViolationComment {
+	var pbComments []*pb.ViolationComment
+	for _, comment := range comments {
+		pbComments = append(pbComments, &pb.ViolationComment{
+			UserId: comment.UserID,
+			Action: comment.Action,
+			Text:   comment.Text,
+			At:     timeToProto(comment.At),
+		})
+	}
+	return pbComments
+}
+
+func violationCommentsFromProto(pbComments []*pb.ViolationComment) []ViolationComment {
+	var comments []ViolationComment
+	for _, comment := range pbComments {
+		comments = append(comments, ViolationComment{
+			UserID: comment.UserId,
+			Action: comment.Action,
+			Text:   comment.Text,
+			At:     protoToTime(comment.At),
+		})
+	}
+	return comments
+}
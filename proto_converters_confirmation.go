@@ -0,0 +1,55 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Confirmation Conversions
+// ====================================================================================
+
+func (cr *ConfirmationRequest) ToProto() *pb.ConfirmationRequest {
+	if cr == nil {
+		return nil
+	}
+	return &pb.ConfirmationRequest{
+		Id:               cr.ID,
+		Type:             string(cr.Type),
+		AccountId:        cr.AccountID,
+		Counterparty:     cr.Counterparty,
+		AsOfDate:         timeToProto(cr.AsOfDate),
+		Balance:          cr.Balance.ToProto(),
+		Status:           string(cr.Status),
+		Letter:           cr.Letter,
+		SentAt:           optionalTimeToProto(cr.SentAt),
+		ReceivedAt:       optionalTimeToProto(cr.ReceivedAt),
+		ConfirmedBalance: cr.ConfirmedBalance.ToProto(),
+		ResponseNotes:    cr.ResponseNotes,
+		CreatedBy:        cr.CreatedBy,
+		CreatedAt:        timeToProto(cr.CreatedAt),
+		UpdatedAt:        timeToProto(cr.UpdatedAt),
+	}
+}
+
+func ConfirmationRequestFromProto(pbRequest *pb.ConfirmationRequest) *ConfirmationRequest {
+	if pbRequest == nil {
+		return nil
+	}
+	return &ConfirmationRequest{
+		ID:               pbRequest.Id,
+		Type:             ConfirmationType(pbRequest.Type),
+		AccountID:        pbRequest.AccountId,
+		Counterparty:     pbRequest.Counterparty,
+		AsOfDate:         protoToTime(pbRequest.AsOfDate),
+		Balance:          AmountFromProto(pbRequest.Balance),
+		Status:           ConfirmationStatus(pbRequest.Status),
+		Letter:           pbRequest.Letter,
+		SentAt:           protoToOptionalTime(pbRequest.SentAt),
+		ReceivedAt:       protoToOptionalTime(pbRequest.ReceivedAt),
+		ConfirmedBalance: AmountFromProto(pbRequest.ConfirmedBalance),
+		ResponseNotes:    pbRequest.ResponseNotes,
+		CreatedBy:        pbRequest.CreatedBy,
+		CreatedAt:        protoToTime(pbRequest.CreatedAt),
+		UpdatedAt:        protoToTime(pbRequest.UpdatedAt),
+	}
+}
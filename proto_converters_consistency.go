@@ -0,0 +1,75 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Consistency Check Conversions
+// ====================================================================================
+
+func (i *ConsistencyIssue) ToProto() *pb.ConsistencyIssue {
+	return &pb.ConsistencyIssue{
+		Check:       string(i.Check),
+		EntityType:  i.EntityType,
+		EntityId:    i.EntityID,
+		Reference:   i.Reference,
+		Description: i.Description,
+		Expected:    i.Expected,
+		Actual:      i.Actual,
+		Currency:    i.Currency,
+		Repairable:  i.Repairable,
+		Repaired:    i.Repaired,
+	}
+}
+
+func ConsistencyIssueFromProto(pbIssue *pb.ConsistencyIssue) *ConsistencyIssue {
+	return &ConsistencyIssue{
+		Check:       ConsistencyCheck(pbIssue.Check),
+		EntityType:  pbIssue.EntityType,
+		EntityID:    pbIssue.EntityId,
+		Reference:   pbIssue.Reference,
+		Description: pbIssue.Description,
+		Expected:    pbIssue.Expected,
+		Actual:      pbIssue.Actual,
+		Currency:    pbIssue.Currency,
+		Repairable:  pbIssue.Repairable,
+		Repaired:    pbIssue.Repaired,
+	}
+}
+
+func (r *ConsistencyRun) ToProto() *pb.ConsistencyRun {
+	issues := make([]*pb.ConsistencyIssue, len(r.Issues))
+	for i, issue := range r.Issues {
+		issues[i] = issue.ToProto()
+	}
+	return &pb.ConsistencyRun{
+		Id:         r.ID,
+		Repair:     r.Repair,
+		Checked:    int32(r.Checked),
+		Issues:     issues,
+		Repaired:   int32(r.Repaired),
+		Error:      r.Error,
+		RunBy:      r.RunBy,
+		StartedAt:  timeToProto(r.StartedAt),
+		FinishedAt: timeToProto(r.FinishedAt),
+	}
+}
+
+func ConsistencyRunFromProto(pbRun *pb.ConsistencyRun) *ConsistencyRun {
+	issues := make([]*ConsistencyIssue, len(pbRun.Issues))
+	for i, issue := range pbRun.Issues {
+		issues[i] = ConsistencyIssueFromProto(issue)
+	}
+	return &ConsistencyRun{
+		ID:         pbRun.Id,
+		Repair:     pbRun.Repair,
+		Checked:    int(pbRun.Checked),
+		Issues:     issues,
+		Repaired:   int(pbRun.Repaired),
+		Error:      pbRun.Error,
+		RunBy:      pbRun.RunBy,
+		StartedAt:  protoToTime(pbRun.StartedAt),
+		FinishedAt: protoToTime(pbRun.FinishedAt),
+	}
+}
@@ -0,0 +1,95 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Revenue Contract Conversions
+// ====================================================================================
+
+func (po *PerformanceObligation) ToProto() *pb.PerformanceObligation {
+	if po == nil {
+		return nil
+	}
+	return &pb.PerformanceObligation{
+		Id:                     po.ID,
+		Description:            po.Description,
+		StandaloneSellingPrice: po.StandaloneSellingPrice,
+		AllocatedPrice:         po.AllocatedPrice,
+		Basis:                  string(po.Basis),
+		Start:                  timeToProto(po.Start),
+		Frequency:              string(po.Frequency),
+		Occurrences:            int32(po.Occurrences),
+		SatisfiedAt:            optionalTimeToProto(po.SatisfiedAt),
+		ScheduleId:             po.ScheduleID,
+	}
+}
+
+func PerformanceObligationFromProto(pbObligation *pb.PerformanceObligation) *PerformanceObligation {
+	if pbObligation == nil {
+		return nil
+	}
+	return &PerformanceObligation{
+		ID:                     pbObligation.Id,
+		Description:            pbObligation.Description,
+		StandaloneSellingPrice: pbObligation.StandaloneSellingPrice,
+		AllocatedPrice:         pbObligation.AllocatedPrice,
+		Basis:                  RecognitionBasis(pbObligation.Basis),
+		Start:                  protoToTime(pbObligation.Start),
+		Frequency:              ScheduleFrequency(pbObligation.Frequency),
+		Occurrences:            int(pbObligation.Occurrences),
+		SatisfiedAt:            protoToOptionalTime(pbObligation.SatisfiedAt),
+		ScheduleID:             pbObligation.ScheduleId,
+	}
+}
+
+func (rc *RevenueContract) ToProto() *pb.RevenueContract {
+	if rc == nil {
+		return nil
+	}
+	obligations := make([]*pb.PerformanceObligation, len(rc.Obligations))
+	for i, obligation := range rc.Obligations {
+		obligations[i] = obligation.ToProto()
+	}
+	return &pb.RevenueContract{
+		Id:                       rc.ID,
+		CustomerId:               rc.CustomerID,
+		Reference:                rc.Reference,
+		ContractDate:             timeToProto(rc.ContractDate),
+		TransactionPrice:         rc.TransactionPrice.ToProto(),
+		Obligations:              obligations,
+		ReceivableAccountId:      rc.ReceivableAccountID,
+		DeferredRevenueAccountId: rc.DeferredRevenueAccountID,
+		RevenueAccountId:         rc.RevenueAccountID,
+		BillingTransactionId:     rc.BillingTransactionID,
+		CreatedBy:                rc.CreatedBy,
+		CreatedAt:                timeToProto(rc.CreatedAt),
+		UpdatedAt:                timeToProto(rc.UpdatedAt),
+	}
+}
+
+func RevenueContractFromProto(pbContract *pb.RevenueContract) *RevenueContract {
+	if pbContract == nil {
+		return nil
+	}
+	obligations := make([]*PerformanceObligation, len(pbContract.Obligations))
+	for i, obligation := range pbContract.Obligations {
+		obligations[i] = PerformanceObligationFromProto(obligation)
+	}
+	return &RevenueContract{
+		ID:                       pbContract.Id,
+		CustomerID:               pbContract.CustomerId,
+		Reference:                pbContract.Reference,
+		ContractDate:             protoToTime(pbContract.ContractDate),
+		TransactionPrice:         AmountFromProto(pbContract.TransactionPrice),
+		Obligations:              obligations,
+		ReceivableAccountID:      pbContract.ReceivableAccountId,
+		DeferredRevenueAccountID: pbContract.DeferredRevenueAccountId,
+		RevenueAccountID:         pbContract.RevenueAccountId,
+		BillingTransactionID:     pbContract.BillingTransactionId,
+		CreatedBy:                pbContract.CreatedBy,
+		CreatedAt:                protoToTime(pbContract.CreatedAt),
+		UpdatedAt:                protoToTime(pbContract.UpdatedAt),
+	}
+}
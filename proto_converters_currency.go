@@ -0,0 +1,41 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Exchange Rate Conversions
+// ====================================================================================
+
+func (r *ExchangeRate) ToProto() *pb.ExchangeRate {
+	if r == nil {
+		return nil
+	}
+	return &pb.ExchangeRate{
+		Id:            r.ID,
+		FromCurrency:  string(r.FromCurrency),
+		ToCurrency:    string(r.ToCurrency),
+		Rate:          r.Rate,
+		EffectiveDate: timeToProto(r.EffectiveDate),
+		Source:        r.Source,
+		CreatedBy:     r.CreatedBy,
+		CreatedAt:     timeToProto(r.CreatedAt),
+	}
+}
+
+func ExchangeRateFromProto(pbRate *pb.ExchangeRate) *ExchangeRate {
+	if pbRate == nil {
+		return nil
+	}
+	return &ExchangeRate{
+		ID:            pbRate.Id,
+		FromCurrency:  Currency(pbRate.FromCurrency),
+		ToCurrency:    Currency(pbRate.ToCurrency),
+		Rate:          pbRate.Rate,
+		EffectiveDate: protoToTime(pbRate.EffectiveDate),
+		Source:        pbRate.Source,
+		CreatedBy:     pbRate.CreatedBy,
+		CreatedAt:     protoToTime(pbRate.CreatedAt),
+	}
+}
@@ -0,0 +1,49 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Department Conversions
+// ====================================================================================
+
+func (d *Department) ToProto() *pb.Department {
+	if d == nil {
+		return nil
+	}
+	return &pb.Department{
+		Id:                d.ID,
+		Code:              d.Code,
+		Name:              d.Name,
+		Kind:              string(d.Kind),
+		ParentId:          d.ParentID,
+		Owners:            d.Owners,
+		ActiveFrom:        timeToProto(d.ActiveFrom),
+		ActiveTo:          optionalTimeToProto(d.ActiveTo),
+		DefaultDimensions: DimensionsToProto(d.DefaultDimensions),
+		CreatedBy:         d.CreatedBy,
+		CreatedAt:         timeToProto(d.CreatedAt),
+		UpdatedAt:         timeToProto(d.UpdatedAt),
+	}
+}
+
+func DepartmentFromProto(pbDepartment *pb.Department) *Department {
+	if pbDepartment == nil {
+		return nil
+	}
+	return &Department{
+		ID:                pbDepartment.Id,
+		Code:              pbDepartment.Code,
+		Name:              pbDepartment.Name,
+		Kind:              DepartmentKind(pbDepartment.Kind),
+		ParentID:          pbDepartment.ParentId,
+		Owners:            pbDepartment.Owners,
+		ActiveFrom:        protoToTime(pbDepartment.ActiveFrom),
+		ActiveTo:          protoToOptionalTime(pbDepartment.ActiveTo),
+		DefaultDimensions: DimensionsFromProto(pbDepartment.DefaultDimensions),
+		CreatedBy:         pbDepartment.CreatedBy,
+		CreatedAt:         protoToTime(pbDepartment.CreatedAt),
+		UpdatedAt:         protoToTime(pbDepartment.UpdatedAt),
+	}
+}
@@ -0,0 +1,69 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Dimension Registry Conversions
+// ====================================================================================
+
+func (d *DimensionDefinition) ToProto() *pb.DimensionDefinition {
+	if d == nil {
+		return nil
+	}
+	values := make([]*pb.DimensionValue, len(d.Values))
+	for i, value := range d.Values {
+		values[i] = &pb.DimensionValue{
+			Value:      value.Value,
+			Name:       value.Name,
+			Status:     string(value.Status),
+			MergedInto: value.MergedInto,
+			RetiredAt:  optionalTimeToProto(value.RetiredAt),
+		}
+	}
+	requiredFor := make([]string, len(d.RequiredFor))
+	for i, accountType := range d.RequiredFor {
+		requiredFor[i] = string(accountType)
+	}
+	return &pb.DimensionDefinition{
+		Key:         string(d.Key),
+		Name:        d.Name,
+		Open:        d.Open,
+		Values:      values,
+		RequiredFor: requiredFor,
+		CreatedBy:   d.CreatedBy,
+		CreatedAt:   timeToProto(d.CreatedAt),
+		UpdatedAt:   timeToProto(d.UpdatedAt),
+	}
+}
+
+func DimensionDefinitionFromProto(pbDef *pb.DimensionDefinition) *DimensionDefinition {
+	if pbDef == nil {
+		return nil
+	}
+	values := make([]DimensionValue, len(pbDef.Values))
+	for i, value := range pbDef.Values {
+		values[i] = DimensionValue{
+			Value:      value.Value,
+			Name:       value.Name,
+			Status:     DimensionValueStatus(value.Status),
+			MergedInto: value.MergedInto,
+			RetiredAt:  protoToOptionalTime(value.RetiredAt),
+		}
+	}
+	requiredFor := make([]AccountType, len(pbDef.RequiredFor))
+	for i, accountType := range pbDef.RequiredFor {
+		requiredFor[i] = AccountType(accountType)
+	}
+	return &DimensionDefinition{
+		Key:         DimensionKey(pbDef.Key),
+		Name:        pbDef.Name,
+		Open:        pbDef.Open,
+		Values:      values,
+		RequiredFor: requiredFor,
+		CreatedBy:   pbDef.CreatedBy,
+		CreatedAt:   protoToTime(pbDef.CreatedAt),
+		UpdatedAt:   protoToTime(pbDef.UpdatedAt),
+	}
+}
@@ -0,0 +1,73 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Disclosure Conversions
+// ====================================================================================
+
+func (d *Disclosure) ToProto() *pb.Disclosure {
+	if d == nil {
+		return nil
+	}
+	return &pb.Disclosure{
+		Id:        d.ID,
+		Category:  string(d.Category),
+		Title:     d.Title,
+		Body:      d.Body,
+		SortOrder: int32(d.SortOrder),
+		Active:    d.Active,
+		CreatedBy: d.CreatedBy,
+		CreatedAt: timeToProto(d.CreatedAt),
+		UpdatedAt: timeToProto(d.UpdatedAt),
+	}
+}
+
+func DisclosureFromProto(pbDisclosure *pb.Disclosure) *Disclosure {
+	if pbDisclosure == nil {
+		return nil
+	}
+	return &Disclosure{
+		ID:        pbDisclosure.Id,
+		Category:  DisclosureCategory(pbDisclosure.Category),
+		Title:     pbDisclosure.Title,
+		Body:      pbDisclosure.Body,
+		SortOrder: int(pbDisclosure.SortOrder),
+		Active:    pbDisclosure.Active,
+		CreatedBy: pbDisclosure.CreatedBy,
+		CreatedAt: protoToTime(pbDisclosure.CreatedAt),
+		UpdatedAt: protoToTime(pbDisclosure.UpdatedAt),
+	}
+}
+
+func (v *DisclosureValue) ToProto() *pb.DisclosureValue {
+	if v == nil {
+		return nil
+	}
+	return &pb.DisclosureValue{
+		Id:           v.ID,
+		DisclosureId: v.DisclosureID,
+		PeriodId:     v.PeriodID,
+		Key:          v.Key,
+		Value:        v.Value,
+		CreatedBy:    v.CreatedBy,
+		CreatedAt:    timeToProto(v.CreatedAt),
+	}
+}
+
+func DisclosureValueFromProto(pbValue *pb.DisclosureValue) *DisclosureValue {
+	if pbValue == nil {
+		return nil
+	}
+	return &DisclosureValue{
+		ID:           pbValue.Id,
+		DisclosureID: pbValue.DisclosureId,
+		PeriodID:     pbValue.PeriodId,
+		Key:          pbValue.Key,
+		Value:        pbValue.Value,
+		CreatedBy:    pbValue.CreatedBy,
+		CreatedAt:    protoToTime(pbValue.CreatedAt),
+	}
+}
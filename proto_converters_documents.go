@@ -0,0 +1,57 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Document Conversions
+// ====================================================================================
+
+func (d *Document) ToProto() *pb.Document {
+	links := make([]*pb.DocumentLink, len(d.Links))
+	for i, link := range d.Links {
+		links[i] = &pb.DocumentLink{
+			TargetType:      string(link.TargetType),
+			TargetId:        link.TargetID,
+			JustificationId: link.JustificationID,
+			LinkedBy:        link.LinkedBy,
+			LinkedAt:        timeToProto(link.LinkedAt),
+		}
+	}
+	return &pb.Document{
+		Id:          d.ID,
+		Name:        d.Name,
+		ContentType: d.ContentType,
+		Size:        d.Size,
+		Checksum:    d.Checksum,
+		Description: d.Description,
+		Links:       links,
+		UploadedBy:  d.UploadedBy,
+		UploadedAt:  timeToProto(d.UploadedAt),
+	}
+}
+
+func DocumentFromProto(pbDocument *pb.Document) *Document {
+	links := make([]DocumentLink, len(pbDocument.Links))
+	for i, link := range pbDocument.Links {
+		links[i] = DocumentLink{
+			TargetType:      DocumentTargetType(link.TargetType),
+			TargetID:        link.TargetId,
+			JustificationID: link.JustificationId,
+			LinkedBy:        link.LinkedBy,
+			LinkedAt:        protoToTime(link.LinkedAt),
+		}
+	}
+	return &Document{
+		ID:          pbDocument.Id,
+		Name:        pbDocument.Name,
+		ContentType: pbDocument.ContentType,
+		Size:        pbDocument.Size,
+		Checksum:    pbDocument.Checksum,
+		Description: pbDocument.Description,
+		Links:       links,
+		UploadedBy:  pbDocument.UploadedBy,
+		UploadedAt:  protoToTime(pbDocument.UploadedAt),
+	}
+}
@@ -1,6 +1,9 @@
 package accounting
 
 import (
+	"encoding/json"
+	"strings"
+
 	pb "accounting/proto/accounting"
 	"google.golang.org/protobuf/proto"
 )
@@ -22,6 +25,8 @@ func (l *Ledger) ToProto() *pb.Ledger {
 		ledgerType = pb.LedgerType_LEDGER_TYPE_AR
 	case AccountsPayable:
 		ledgerType = pb.LedgerType_LEDGER_TYPE_AP
+	case InventoryLedger:
+		ledgerType = pb.LedgerType_LEDGER_TYPE_INV
 	default:
 		ledgerType = pb.LedgerType_LEDGER_TYPE_UNSPECIFIED
 	}
@@ -47,6 +52,8 @@ func LedgerFromProto(pbLedger *pb.Ledger) *Ledger {
 		ledgerType = AccountsReceivable
 	case pb.LedgerType_LEDGER_TYPE_AP:
 		ledgerType = AccountsPayable
+	case pb.LedgerType_LEDGER_TYPE_INV:
+		ledgerType = InventoryLedger
 	}
 	
 	return &Ledger{
@@ -141,6 +148,11 @@ func (r *RecognitionSchedule) ToProto() *pb.RecognitionSchedule {
 		Occurrences:   int32(r.Occurrences),
 		StartTime:     timeToProto(r.StartTime),
 		CreatedAt:     timeToProto(r.CreatedAt),
+		Breakdown:     r.Breakdown.ToProto(),
+		DebitAccountId:  r.DebitAccountID,
+		CreditAccountId: r.CreditAccountID,
+		TemplateId:      r.TemplateID,
+		Reverse:         r.Reverse,
 	}
 }
 
@@ -166,6 +178,11 @@ func RecognitionScheduleFromProto(pbSched *pb.RecognitionSchedule) *RecognitionS
 		Occurrences:   int(pbSched.Occurrences),
 		StartTime:     protoToTime(pbSched.StartTime),
 		CreatedAt:     protoToTime(pbSched.CreatedAt),
+		Breakdown:     CalculationBreakdownFromProto(pbSched.Breakdown),
+		DebitAccountID:  pbSched.DebitAccountId,
+		CreditAccountID: pbSched.CreditAccountId,
+		TemplateID:      pbSched.TemplateId,
+		Reverse:         pbSched.Reverse,
 	}
 }
 
@@ -258,6 +275,10 @@ RequireApprovalOver:         c.Settings.RequireApprovalOver.ToProto(),
 AutoPostingRules:            rules,
 PeriodLockingPolicy:         c.Settings.PeriodLockingPolicy,
 ReportingCurrency:           c.Settings.ReportingCurrency,
+BudgetControl:               string(c.Settings.BudgetControl),
+AccountNumbering:            c.Settings.AccountNumbering.ToProto(),
+PostingDateWindow:           c.Settings.PostingDateWindow.ToProto(),
+FourEyes:                    c.Settings.FourEyes.ToProto(),
 }
 }
 
@@ -345,6 +366,10 @@ RequireApprovalOver:    AmountFromProto(pbCompany.Settings.RequireApprovalOver),
 AutoPostingRules:       rules,
 PeriodLockingPolicy:    pbCompany.Settings.PeriodLockingPolicy,
 ReportingCurrency:      pbCompany.Settings.ReportingCurrency,
+BudgetControl:          BudgetControlPolicy(pbCompany.Settings.BudgetControl),
+AccountNumbering:       AccountNumberingSchemeFromProto(pbCompany.Settings.AccountNumbering),
+PostingDateWindow:      PostingDateWindowFromProto(pbCompany.Settings.PostingDateWindow),
+FourEyes:               FourEyesPolicyFromProto(pbCompany.Settings.FourEyes),
 }
 }
 
@@ -445,9 +470,11 @@ Id:                    c.ID,
 Name:                  c.Name,
 ParentCompanyId:       c.ParentCompany,
 SubsidiaryIds:         c.ChildCompanies,
-ConsolidationCurrency: "", // Currency not directly in Go struct
+ConsolidationCurrency: c.PresentationCurrency,
 CreatedAt:             timeToProto(c.CreatedAt),
 CreatedBy:             c.CreatedBy,
+ConsolidationMethod:   c.ConsolidationMethod,
+Ownership:             c.Ownership,
 }
 }
 
@@ -456,14 +483,21 @@ if pbGroup == nil {
 return nil
 }
 
+method := pbGroup.ConsolidationMethod
+if method == "" {
+method = "FULL" // Default method
+}
+
 return &ConsolidationGroup{
-ID:                  pbGroup.Id,
-Name:                pbGroup.Name,
-ParentCompany:       pbGroup.ParentCompanyId,
-ChildCompanies:      pbGroup.SubsidiaryIds,
-ConsolidationMethod: "FULL", // Default method
-CreatedAt:           protoToTime(pbGroup.CreatedAt),
-CreatedBy:           pbGroup.CreatedBy,
+ID:                   pbGroup.Id,
+Name:                 pbGroup.Name,
+ParentCompany:        pbGroup.ParentCompanyId,
+ChildCompanies:       pbGroup.SubsidiaryIds,
+ConsolidationMethod:  method,
+Ownership:            pbGroup.Ownership,
+PresentationCurrency: pbGroup.ConsolidationCurrency,
+CreatedAt:            protoToTime(pbGroup.CreatedAt),
+CreatedBy:            pbGroup.CreatedBy,
 }
 }
 
@@ -618,6 +652,7 @@ UpdatedAt:      timeToProto(b.UpdatedAt),
 SubmittedAt:    optionalTimeToProto(b.SubmittedAt),
 ApprovedAt:     optionalTimeToProto(b.ApprovedAt),
 ApprovedBy:     b.ApprovedBy,
+Version:        b.Version,
 }
 }
 
@@ -711,6 +746,7 @@ UpdatedAt:      protoToTime(pbRequest.UpdatedAt),
 SubmittedAt:    protoToOptionalTime(pbRequest.SubmittedAt),
 ApprovedAt:     protoToOptionalTime(pbRequest.ApprovedAt),
 ApprovedBy:     pbRequest.ApprovedBy,
+Version:        pbRequest.Version,
 }
 }
 
@@ -821,6 +857,7 @@ Amount:          b.Amount.ToProto(),
 Description:     b.Description,
 TrackedAt:       timeToProto(b.TrackedAt),
 RemainingBudget: b.RemainingBudget.ToProto(),
+OverBudget:      b.OverBudget,
 }
 }
 
@@ -835,6 +872,7 @@ Amount:          AmountFromProto(pbTracking.Amount),
 Description:     pbTracking.Description,
 TrackedAt:       protoToTime(pbTracking.TrackedAt),
 RemainingBudget: AmountFromProto(pbTracking.RemainingBudget),
+OverBudget:      pbTracking.OverBudget,
 }
 }
 
@@ -843,173 +881,367 @@ RemainingBudget: AmountFromProto(pbTracking.RemainingBudget),
 // AML Conversions
 // ====================================================================================
 
-func (a *AMLRule) ToProto() *pb.AMLRule {
-if a == nil {
-return nil
+// AML enum values share their Go names with the proto enums once the prefix is removed
+const (
+	amlRuleTypePrefix  = "AML_RULE_TYPE_"
+	amlFrameworkPrefix = "AML_FRAMEWORK_"
+	amlRiskLevelPrefix = "AML_RISK_LEVEL_"
+)
+
+func amlRuleTypeToProto(t AMLRuleType) pb.AMLRuleType {
+	return pb.AMLRuleType(pb.AMLRuleType_value[amlRuleTypePrefix+string(t)])
 }
-var ruleType pb.AMLRuleType
-switch a.Type {
-case RuleCTR:
-ruleType = pb.AMLRuleType_AML_RULE_TYPE_CTR
-case RuleSAR:
-ruleType = pb.AMLRuleType_AML_RULE_TYPE_SAR
-default:
-ruleType = pb.AMLRuleType_AML_RULE_TYPE_UNSPECIFIED
-}
-var framework pb.AMLFramework
-switch a.Framework {
-case BSA_Framework:
-framework = pb.AMLFramework_AML_FRAMEWORK_BSA
-case AMLD_Framework:
-framework = pb.AMLFramework_AML_FRAMEWORK_AMLD
-case FATF_Framework:
-framework = pb.AMLFramework_AML_FRAMEWORK_FATF
-}
-return &pb.AMLRule{
-Id:          a.ID,
-Name:        a.Name,
-Type:        ruleType,
-Framework:   framework,
-Description: a.Description,
-Enabled:     a.Enabled,
-CreatedAt:   timeToProto(a.CreatedAt),
-UpdatedAt:   timeToProto(a.UpdatedAt),
+
+func amlRuleTypeFromProto(t pb.AMLRuleType) AMLRuleType {
+	if t == pb.AMLRuleType_AML_RULE_TYPE_UNSPECIFIED {
+		return ""
+	}
+	return AMLRuleType(strings.TrimPrefix(t.String(), amlRuleTypePrefix))
 }
+
+func amlFrameworkToProto(f AMLFramework) pb.AMLFramework {
+	return pb.AMLFramework(pb.AMLFramework_value[amlFrameworkPrefix+string(f)])
 }
 
-func AMLRuleFromProto(pbRule *pb.AMLRule) *AMLRule {
-if pbRule == nil {
-return nil
+func amlFrameworkFromProto(f pb.AMLFramework) AMLFramework {
+	if f == pb.AMLFramework_AML_FRAMEWORK_UNSPECIFIED {
+		return ""
+	}
+	return AMLFramework(strings.TrimPrefix(f.String(), amlFrameworkPrefix))
 }
-var ruleType AMLRuleType
-switch pbRule.GetType() {
-case pb.AMLRuleType_AML_RULE_TYPE_CTR:
-ruleType = RuleCTR
-case pb.AMLRuleType_AML_RULE_TYPE_SAR:
-ruleType = RuleSAR
+
+func amlRiskLevelToProto(r AMLRiskLevel) pb.AMLRiskLevel {
+	return pb.AMLRiskLevel(pb.AMLRiskLevel_value[amlRiskLevelPrefix+string(r)])
 }
-var framework AMLFramework
-switch pbRule.GetFramework() {
-case pb.AMLFramework_AML_FRAMEWORK_BSA:
-framework = BSA_Framework
-case pb.AMLFramework_AML_FRAMEWORK_AMLD:
-framework = AMLD_Framework
-case pb.AMLFramework_AML_FRAMEWORK_FATF:
-framework = FATF_Framework
-}
-return &AMLRule{
-ID:          pbRule.Id,
-Name:        pbRule.Name,
-Type:        ruleType,
-Framework:   framework,
-Description: pbRule.Description,
-Enabled:     pbRule.Enabled,
-CreatedAt:   protoToTime(pbRule.CreatedAt),
-UpdatedAt:   protoToTime(pbRule.UpdatedAt),
+
+func amlRiskLevelFromProto(r pb.AMLRiskLevel) AMLRiskLevel {
+	if r == pb.AMLRiskLevel_AML_RISK_LEVEL_UNSPECIFIED {
+		return ""
+	}
+	return AMLRiskLevel(strings.TrimPrefix(r.String(), amlRiskLevelPrefix))
 }
+
+// amlValueToProto encodes a dynamically typed value so its Go type survives storage
+func amlValueToProto(v interface{}) *pb.AMLValue {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case int:
+		return &pb.AMLValue{Kind: &pb.AMLValue_IntValue{IntValue: int64(val)}}
+	case int64:
+		return &pb.AMLValue{Kind: &pb.AMLValue_Int64Value{Int64Value: val}}
+	case float64:
+		return &pb.AMLValue{Kind: &pb.AMLValue_FloatValue{FloatValue: val}}
+	case string:
+		return &pb.AMLValue{Kind: &pb.AMLValue_StringValue{StringValue: val}}
+	case bool:
+		return &pb.AMLValue{Kind: &pb.AMLValue_BoolValue{BoolValue: val}}
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return nil
+		}
+		return &pb.AMLValue{Kind: &pb.AMLValue_JsonValue{JsonValue: string(data)}}
+	}
 }
 
-func (a *AMLAlert) ToProto() *pb.AMLAlert {
-if a == nil {
-return nil
+func amlValueFromProto(pbValue *pb.AMLValue) interface{} {
+	switch val := pbValue.GetKind().(type) {
+	case *pb.AMLValue_IntValue:
+		return int(val.IntValue)
+	case *pb.AMLValue_Int64Value:
+		return val.Int64Value
+	case *pb.AMLValue_FloatValue:
+		return val.FloatValue
+	case *pb.AMLValue_StringValue:
+		return val.StringValue
+	case *pb.AMLValue_BoolValue:
+		return val.BoolValue
+	case *pb.AMLValue_JsonValue:
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(val.JsonValue), &decoded); err != nil {
+			return nil
+		}
+		return decoded
+	default:
+		return nil
+	}
 }
-var ruleType pb.AMLRuleType
-switch a.RuleType {
-case RuleCTR:
-ruleType = pb.AMLRuleType_AML_RULE_TYPE_CTR
-case RuleSAR:
-ruleType = pb.AMLRuleType_AML_RULE_TYPE_SAR
-}
-var framework pb.AMLFramework
-switch a.Framework {
-case BSA_Framework:
-framework = pb.AMLFramework_AML_FRAMEWORK_BSA
-case AMLD_Framework:
-framework = pb.AMLFramework_AML_FRAMEWORK_AMLD
-case FATF_Framework:
-framework = pb.AMLFramework_AML_FRAMEWORK_FATF
+
+func (a *AMLRule) ToProto() *pb.AMLRule {
+	if a == nil {
+		return nil
+	}
+	var thresholds map[string]*pb.AMLValue
+	if a.Thresholds != nil {
+		thresholds = make(map[string]*pb.AMLValue, len(a.Thresholds))
+		for key, value := range a.Thresholds {
+			thresholds[key] = amlValueToProto(value)
+		}
+	}
+	var timeWindows map[string]int32
+	if a.TimeWindows != nil {
+		timeWindows = make(map[string]int32, len(a.TimeWindows))
+		for key, hours := range a.TimeWindows {
+			timeWindows[key] = int32(hours)
+		}
+	}
+	return &pb.AMLRule{
+		Id:              a.ID,
+		Name:            a.Name,
+		Type:            amlRuleTypeToProto(a.Type),
+		Framework:       amlFrameworkToProto(a.Framework),
+		Description:     a.Description,
+		Enabled:         a.Enabled,
+		ThresholdValues: thresholds,
+		TimeWindows:     timeWindows,
+		Currencies:      a.Currencies,
+		Countries:       a.Countries,
+		BaseScore:       int32(a.BaseScore),
+		RiskMultiple:    a.RiskMultiple,
+		CreatedAt:       timeToProto(a.CreatedAt),
+		UpdatedAt:       timeToProto(a.UpdatedAt),
+	}
 }
-var riskLevel pb.AMLRiskLevel
-switch a.RiskLevel {
-case RiskLow:
-riskLevel = pb.AMLRiskLevel_AML_RISK_LEVEL_LOW
-case RiskMedium:
-riskLevel = pb.AMLRiskLevel_AML_RISK_LEVEL_MEDIUM
-case RiskHigh:
-riskLevel = pb.AMLRiskLevel_AML_RISK_LEVEL_HIGH
-case RiskCritical:
-riskLevel = pb.AMLRiskLevel_AML_RISK_LEVEL_CRITICAL
+
+func AMLRuleFromProto(pbRule *pb.AMLRule) *AMLRule {
+	if pbRule == nil {
+		return nil
+	}
+	var thresholds map[string]interface{}
+	if len(pbRule.ThresholdValues) > 0 {
+		thresholds = make(map[string]interface{}, len(pbRule.ThresholdValues))
+		for key, value := range pbRule.ThresholdValues {
+			thresholds[key] = amlValueFromProto(value)
+		}
+	}
+	var timeWindows map[string]int
+	if len(pbRule.TimeWindows) > 0 {
+		timeWindows = make(map[string]int, len(pbRule.TimeWindows))
+		for key, hours := range pbRule.TimeWindows {
+			timeWindows[key] = int(hours)
+		}
+	}
+	return &AMLRule{
+		ID:           pbRule.Id,
+		Name:         pbRule.Name,
+		Type:         amlRuleTypeFromProto(pbRule.GetType()),
+		Framework:    amlFrameworkFromProto(pbRule.GetFramework()),
+		Description:  pbRule.Description,
+		Enabled:      pbRule.Enabled,
+		Thresholds:   thresholds,
+		TimeWindows:  timeWindows,
+		Currencies:   pbRule.Currencies,
+		Countries:    pbRule.Countries,
+		BaseScore:    int(pbRule.BaseScore),
+		RiskMultiple: pbRule.RiskMultiple,
+		CreatedAt:    protoToTime(pbRule.CreatedAt),
+		UpdatedAt:    protoToTime(pbRule.UpdatedAt),
+	}
 }
-return &pb.AMLAlert{
-Id:             a.ID,
-RuleType:       ruleType,
-Framework:      framework,
-RiskLevel:      riskLevel,
-Title:          a.Title,
-Description:    a.Description,
-EntityId:       a.EntityID,
-EntityType:     a.EntityType,
-TransactionIds: a.TransactionIDs,
-AccountIds:     a.AccountIDs,
-Amount:         a.Amount.ToProto(),
-Currency:       a.Currency,
-DetectedAt:     timeToProto(a.DetectedAt),
-Status:         a.Status,
-AssignedTo:     a.AssignedTo,
-CreatedAt:      timeToProto(a.CreatedAt),
-UpdatedAt:      timeToProto(a.UpdatedAt),
+
+func (e *AMLEvidence) ToProto() *pb.AMLEvidence {
+	if e == nil {
+		return nil
+	}
+	return &pb.AMLEvidence{
+		Type:        e.Type,
+		Description: e.Description,
+		Value:       amlValueToProto(e.Value),
+		Source:      e.Source,
+		Confidence:  e.Confidence,
+		CollectedAt: timeToProto(e.CollectedAt),
+	}
 }
+
+func AMLEvidenceFromProto(pbEvidence *pb.AMLEvidence) AMLEvidence {
+	return AMLEvidence{
+		Type:        pbEvidence.GetType(),
+		Description: pbEvidence.GetDescription(),
+		Value:       amlValueFromProto(pbEvidence.GetValue()),
+		Source:      pbEvidence.GetSource(),
+		Confidence:  pbEvidence.GetConfidence(),
+		CollectedAt: protoToTime(pbEvidence.GetCollectedAt()),
+	}
 }
 
-func AMLAlertFromProto(pbAlert *pb.AMLAlert) *AMLAlert {
-if pbAlert == nil {
-return nil
+func (d *AMLDisposition) ToProto() *pb.AMLDisposition {
+	if d == nil {
+		return nil
+	}
+	return &pb.AMLDisposition{
+		Id:          d.ID,
+		Type:        d.Type,
+		Description: d.Description,
+		DecidedBy:   d.DecidedBy,
+		DecidedAt:   timeToProto(d.DecidedAt),
+		Rationale:   d.Rationale,
+		SarNumber:   d.SARNumber,
+		ReportedTo:  d.ReportedTo,
+	}
 }
-var ruleType AMLRuleType
-switch pbAlert.GetRuleType() {
-case pb.AMLRuleType_AML_RULE_TYPE_CTR:
-ruleType = RuleCTR
-case pb.AMLRuleType_AML_RULE_TYPE_SAR:
-ruleType = RuleSAR
-}
-var framework AMLFramework
-switch pbAlert.GetFramework() {
-case pb.AMLFramework_AML_FRAMEWORK_BSA:
-framework = BSA_Framework
-case pb.AMLFramework_AML_FRAMEWORK_AMLD:
-framework = AMLD_Framework
-case pb.AMLFramework_AML_FRAMEWORK_FATF:
-framework = FATF_Framework
+
+func AMLDispositionFromProto(pbDisposition *pb.AMLDisposition) AMLDisposition {
+	return AMLDisposition{
+		ID:          pbDisposition.GetId(),
+		Type:        pbDisposition.GetType(),
+		Description: pbDisposition.GetDescription(),
+		DecidedBy:   pbDisposition.GetDecidedBy(),
+		DecidedAt:   protoToTime(pbDisposition.GetDecidedAt()),
+		Rationale:   pbDisposition.GetRationale(),
+		SARNumber:   pbDisposition.GetSarNumber(),
+		ReportedTo:  pbDisposition.GetReportedTo(),
+	}
 }
-var riskLevel AMLRiskLevel
-switch pbAlert.GetRiskLevel() {
-case pb.AMLRiskLevel_AML_RISK_LEVEL_LOW:
-riskLevel = RiskLow
-case pb.AMLRiskLevel_AML_RISK_LEVEL_MEDIUM:
-riskLevel = RiskMedium
-case pb.AMLRiskLevel_AML_RISK_LEVEL_HIGH:
-riskLevel = RiskHigh
-case pb.AMLRiskLevel_AML_RISK_LEVEL_CRITICAL:
-riskLevel = RiskCritical
+
+func (i *AMLInvestigation) ToProto() *pb.AMLInvestigation {
+	if i == nil {
+		return nil
+	}
+	var actions []*pb.InvestigationAction
+	for _, action := range i.Actions {
+		actions = append(actions, &pb.InvestigationAction{
+			Id:          action.ID,
+			Type:        action.Type,
+			Description: action.Description,
+			TakenBy:     action.TakenBy,
+			TakenAt:     timeToProto(action.TakenAt),
+			Result:      action.Result,
+		})
+	}
+	var notes []*pb.InvestigationNote
+	for _, note := range i.Notes {
+		notes = append(notes, &pb.InvestigationNote{
+			Id:        note.ID,
+			Content:   note.Content,
+			CreatedBy: note.CreatedBy,
+			CreatedAt: timeToProto(note.CreatedAt),
+		})
+	}
+	return &pb.AMLInvestigation{
+		Id:           i.ID,
+		AlertId:      i.AlertID,
+		Investigator: i.Investigator,
+		StartedAt:    timeToProto(i.StartedAt),
+		CompletedAt:  optionalTimeToProto(i.CompletedAt),
+		Status:       i.Status,
+		Priority:     i.Priority,
+		Findings:     i.Findings,
+		Actions:      actions,
+		Notes:        notes,
+	}
 }
-return &AMLAlert{
-ID:             pbAlert.Id,
-RuleType:       ruleType,
-Framework:      framework,
-RiskLevel:      riskLevel,
-Title:          pbAlert.Title,
-Description:    pbAlert.Description,
-EntityID:       pbAlert.EntityId,
-EntityType:     pbAlert.EntityType,
-TransactionIDs: pbAlert.TransactionIds,
-AccountIDs:     pbAlert.AccountIds,
-Amount:         AmountFromProto(pbAlert.Amount),
-Currency:       pbAlert.Currency,
-DetectedAt:     protoToTime(pbAlert.DetectedAt),
-AssignedTo:     pbAlert.AssignedTo,
-CreatedAt:      protoToTime(pbAlert.CreatedAt),
-UpdatedAt:      protoToTime(pbAlert.UpdatedAt),
+
+func AMLInvestigationFromProto(pbInvestigation *pb.AMLInvestigation) *AMLInvestigation {
+	if pbInvestigation == nil {
+		return nil
+	}
+	var actions []InvestigationAction
+	for _, action := range pbInvestigation.Actions {
+		actions = append(actions, InvestigationAction{
+			ID:          action.Id,
+			Type:        action.Type,
+			Description: action.Description,
+			TakenBy:     action.TakenBy,
+			TakenAt:     protoToTime(action.TakenAt),
+			Result:      action.Result,
+		})
+	}
+	var notes []InvestigationNote
+	for _, note := range pbInvestigation.Notes {
+		notes = append(notes, InvestigationNote{
+			ID:        note.Id,
+			Content:   note.Content,
+			CreatedBy: note.CreatedBy,
+			CreatedAt: protoToTime(note.CreatedAt),
+		})
+	}
+	return &AMLInvestigation{
+		ID:           pbInvestigation.Id,
+		AlertID:      pbInvestigation.AlertId,
+		Investigator: pbInvestigation.Investigator,
+		StartedAt:    protoToTime(pbInvestigation.StartedAt),
+		CompletedAt:  protoToOptionalTime(pbInvestigation.CompletedAt),
+		Status:       pbInvestigation.Status,
+		Priority:     pbInvestigation.Priority,
+		Findings:     pbInvestigation.Findings,
+		Actions:      actions,
+		Notes:        notes,
+	}
 }
+
+func (a *AMLAlert) ToProto() *pb.AMLAlert {
+	if a == nil {
+		return nil
+	}
+	var evidence []*pb.AMLEvidence
+	for i := range a.Evidence {
+		evidence = append(evidence, a.Evidence[i].ToProto())
+	}
+	var dispositions []*pb.AMLDisposition
+	for i := range a.Dispositions {
+		dispositions = append(dispositions, a.Dispositions[i].ToProto())
+	}
+	return &pb.AMLAlert{
+		Id:             a.ID,
+		RuleType:       amlRuleTypeToProto(a.RuleType),
+		Framework:      amlFrameworkToProto(a.Framework),
+		RiskLevel:      amlRiskLevelToProto(a.RiskLevel),
+		Title:          a.Title,
+		Description:    a.Description,
+		EntityId:       a.EntityID,
+		EntityType:     a.EntityType,
+		TransactionIds: a.TransactionIDs,
+		AccountIds:     a.AccountIDs,
+		Amount:         a.Amount.ToProto(),
+		Currency:       a.Currency,
+		DetectedAt:     timeToProto(a.DetectedAt),
+		Status:         a.Status,
+		AssignedTo:     a.AssignedTo,
+		Investigation:  a.Investigation.ToProto(),
+		Evidence:       evidence,
+		Dispositions:   dispositions,
+		CreatedAt:      timeToProto(a.CreatedAt),
+		UpdatedAt:      timeToProto(a.UpdatedAt),
+		Version:        a.Version,
+	}
+}
+
+func AMLAlertFromProto(pbAlert *pb.AMLAlert) *AMLAlert {
+	if pbAlert == nil {
+		return nil
+	}
+	var evidence []AMLEvidence
+	for _, item := range pbAlert.Evidence {
+		evidence = append(evidence, AMLEvidenceFromProto(item))
+	}
+	var dispositions []AMLDisposition
+	for _, item := range pbAlert.Dispositions {
+		dispositions = append(dispositions, AMLDispositionFromProto(item))
+	}
+	return &AMLAlert{
+		ID:             pbAlert.Id,
+		RuleType:       amlRuleTypeFromProto(pbAlert.GetRuleType()),
+		Framework:      amlFrameworkFromProto(pbAlert.GetFramework()),
+		RiskLevel:      amlRiskLevelFromProto(pbAlert.GetRiskLevel()),
+		Title:          pbAlert.Title,
+		Description:    pbAlert.Description,
+		EntityID:       pbAlert.EntityId,
+		EntityType:     pbAlert.EntityType,
+		TransactionIDs: pbAlert.TransactionIds,
+		AccountIDs:     pbAlert.AccountIds,
+		Amount:         AmountFromProto(pbAlert.Amount),
+		Currency:       pbAlert.Currency,
+		DetectedAt:     protoToTime(pbAlert.DetectedAt),
+		Status:         pbAlert.Status,
+		AssignedTo:     pbAlert.AssignedTo,
+		Investigation:  AMLInvestigationFromProto(pbAlert.Investigation),
+		Evidence:       evidence,
+		Dispositions:   dispositions,
+		CreatedAt:      protoToTime(pbAlert.CreatedAt),
+		UpdatedAt:      protoToTime(pbAlert.UpdatedAt),
+		Version:        pbAlert.Version,
+	}
 }
 
 func (a *AMLCustomer) ToProto() *pb.AMLCustomer {
@@ -1045,6 +1277,10 @@ ExpectedActivity: a.ExpectedActivity,
 BusinessPurpose:  a.BusinessPurpose,
 CreatedAt:        timeToProto(a.CreatedAt),
 UpdatedAt:        timeToProto(a.UpdatedAt),
+Version:             a.Version,
+RelationshipEndedAt: optionalTimeToProto(a.RelationshipEndedAt),
+ErasedAt:            optionalTimeToProto(a.ErasedAt),
+ErasureMode:         string(a.ErasureMode),
 }
 }
 
@@ -1081,6 +1317,10 @@ ExpectedActivity: pbCustomer.ExpectedActivity,
 BusinessPurpose:  pbCustomer.BusinessPurpose,
 CreatedAt:        protoToTime(pbCustomer.CreatedAt),
 UpdatedAt:        protoToTime(pbCustomer.UpdatedAt),
+Version:             pbCustomer.Version,
+RelationshipEndedAt: protoToOptionalTime(pbCustomer.RelationshipEndedAt),
+ErasedAt:            protoToOptionalTime(pbCustomer.ErasedAt),
+ErasureMode:         PIIErasureMode(pbCustomer.ErasureMode),
 }
 }
 
@@ -1180,6 +1420,12 @@ case US_STATE:
 jurisdiction = pb.TaxJurisdiction_TAX_JURISDICTION_US_STATE
 case EU_VAT:
 jurisdiction = pb.TaxJurisdiction_TAX_JURISDICTION_EU_VAT
+case UK_VAT:
+jurisdiction = pb.TaxJurisdiction_TAX_JURISDICTION_UK_VAT
+case CANADA_GST:
+jurisdiction = pb.TaxJurisdiction_TAX_JURISDICTION_CANADA_GST
+case AUSTRALIA:
+jurisdiction = pb.TaxJurisdiction_TAX_JURISDICTION_AUSTRALIA
 }
 var taxType pb.TaxType
 switch t.TaxType {
@@ -1189,6 +1435,14 @@ case SALES_TAX:
 taxType = pb.TaxType_TAX_TYPE_SALES_TAX
 case VAT:
 taxType = pb.TaxType_TAX_TYPE_VAT
+case GST:
+taxType = pb.TaxType_TAX_TYPE_GST
+case PAYROLL_TAX:
+taxType = pb.TaxType_TAX_TYPE_PAYROLL_TAX
+case PROPERTY_TAX:
+taxType = pb.TaxType_TAX_TYPE_PROPERTY_TAX
+case WITHHOLDING:
+taxType = pb.TaxType_TAX_TYPE_WITHHOLDING
 }
 return &pb.TaxRule{
 Id:            t.ID,
@@ -1202,6 +1456,9 @@ Exemptions:    t.Exemptions,
 EffectiveFrom: timeToProto(t.EffectiveFrom),
 EffectiveTo:   optionalTimeToProto(t.EffectiveTo),
 Active:        t.Active,
+Brackets:      taxBracketsToProto(t.Brackets),
+Compound:      t.Compound,
+Priority:      int32(t.Priority),
 }
 }
 
@@ -1217,6 +1474,12 @@ case pb.TaxJurisdiction_TAX_JURISDICTION_US_STATE:
 jurisdiction = US_STATE
 case pb.TaxJurisdiction_TAX_JURISDICTION_EU_VAT:
 jurisdiction = EU_VAT
+case pb.TaxJurisdiction_TAX_JURISDICTION_UK_VAT:
+jurisdiction = UK_VAT
+case pb.TaxJurisdiction_TAX_JURISDICTION_CANADA_GST:
+jurisdiction = CANADA_GST
+case pb.TaxJurisdiction_TAX_JURISDICTION_AUSTRALIA:
+jurisdiction = AUSTRALIA
 }
 var taxType TaxType
 switch pbRule.GetTaxType() {
@@ -1226,6 +1489,14 @@ case pb.TaxType_TAX_TYPE_SALES_TAX:
 taxType = SALES_TAX
 case pb.TaxType_TAX_TYPE_VAT:
 taxType = VAT
+case pb.TaxType_TAX_TYPE_GST:
+taxType = GST
+case pb.TaxType_TAX_TYPE_PAYROLL_TAX:
+taxType = PAYROLL_TAX
+case pb.TaxType_TAX_TYPE_PROPERTY_TAX:
+taxType = PROPERTY_TAX
+case pb.TaxType_TAX_TYPE_WITHHOLDING:
+taxType = WITHHOLDING
 }
 return &TaxRule{
 ID:            pbRule.Id,
@@ -1239,6 +1510,9 @@ Exemptions:    pbRule.Exemptions,
 EffectiveFrom: protoToTime(pbRule.EffectiveFrom),
 EffectiveTo:   protoToOptionalTime(pbRule.EffectiveTo),
 Active:        pbRule.Active,
+Brackets:      taxBracketsFromProto(pbRule.Brackets),
+Compound:      pbRule.Compound,
+Priority:      int(pbRule.Priority),
 }
 }
 
@@ -1257,6 +1531,18 @@ Status:        c.Status,
 DetectedAt:    timeToProto(c.DetectedAt),
 ResolvedAt:    optionalTimeToProto(c.ResolvedAt),
 Notes:         c.Notes,
+AssignedTo:        c.AssignedTo,
+AcknowledgedBy:    c.AcknowledgedBy,
+AcknowledgedAt:    optionalTimeToProto(c.AcknowledgedAt),
+ResolvedBy:        c.ResolvedBy,
+DueAt:             optionalTimeToProto(c.DueAt),
+EscalatedAt:       optionalTimeToProto(c.EscalatedAt),
+WaiverRequestedBy: c.WaiverRequestedBy,
+WaivedBy:          c.WaivedBy,
+RecurrenceOf:      c.RecurrenceOf,
+Occurrences:       int32(c.Occurrences),
+Comments:          violationCommentsToProto(c.Comments),
+UpdatedAt:         timeToProto(c.UpdatedAt),
 }
 }
 
@@ -1275,6 +1561,18 @@ Status:        pbViolation.Status,
 DetectedAt:    protoToTime(pbViolation.DetectedAt),
 ResolvedAt:    protoToOptionalTime(pbViolation.ResolvedAt),
 Notes:         pbViolation.Notes,
+AssignedTo:        pbViolation.AssignedTo,
+AcknowledgedBy:    pbViolation.AcknowledgedBy,
+AcknowledgedAt:    protoToOptionalTime(pbViolation.AcknowledgedAt),
+ResolvedBy:        pbViolation.ResolvedBy,
+DueAt:             protoToOptionalTime(pbViolation.DueAt),
+EscalatedAt:       protoToOptionalTime(pbViolation.EscalatedAt),
+WaiverRequestedBy: pbViolation.WaiverRequestedBy,
+WaivedBy:          pbViolation.WaivedBy,
+RecurrenceOf:      pbViolation.RecurrenceOf,
+Occurrences:       int(pbViolation.Occurrences),
+Comments:          violationCommentsFromProto(pbViolation.Comments),
+UpdatedAt:         protoToTime(pbViolation.UpdatedAt),
 }
 }
 
@@ -1290,6 +1588,12 @@ case US_STATE:
 jurisdiction = pb.TaxJurisdiction_TAX_JURISDICTION_US_STATE
 case EU_VAT:
 jurisdiction = pb.TaxJurisdiction_TAX_JURISDICTION_EU_VAT
+case UK_VAT:
+jurisdiction = pb.TaxJurisdiction_TAX_JURISDICTION_UK_VAT
+case CANADA_GST:
+jurisdiction = pb.TaxJurisdiction_TAX_JURISDICTION_CANADA_GST
+case AUSTRALIA:
+jurisdiction = pb.TaxJurisdiction_TAX_JURISDICTION_AUSTRALIA
 }
 var taxType pb.TaxType
 switch t.TaxType {
@@ -1299,15 +1603,43 @@ case SALES_TAX:
 taxType = pb.TaxType_TAX_TYPE_SALES_TAX
 case VAT:
 taxType = pb.TaxType_TAX_TYPE_VAT
+case GST:
+taxType = pb.TaxType_TAX_TYPE_GST
+case PAYROLL_TAX:
+taxType = pb.TaxType_TAX_TYPE_PAYROLL_TAX
+case PROPERTY_TAX:
+taxType = pb.TaxType_TAX_TYPE_PROPERTY_TAX
+case WITHHOLDING:
+taxType = pb.TaxType_TAX_TYPE_WITHHOLDING
+}
+var calculations []*pb.TaxCalculation
+for i := range t.Calculations {
+calculations = append(calculations, t.Calculations[i].ToProto())
 }
 return &pb.TaxReturn{
 Id:             t.ID,
 Jurisdiction:   jurisdiction,
 TaxType:        taxType,
+FilingDate:     optionalTimeToProto(t.FiledAt),
 DueDate:        timeToProto(t.DueDate),
 TotalTax:       t.TotalTax,
+Status:         t.FilingStatus,
 CreatedAt:      timeToProto(t.CreatedAt),
 UpdatedAt:      timeToProto(t.UpdatedAt),
+Calculations:   calculations,
+SupportingDocs: t.Attachments,
+CompanyId:      t.CompanyID,
+PeriodStart:    timeToProto(t.PeriodStart),
+PeriodEnd:      timeToProto(t.PeriodEnd),
+GrossRevenue:   t.GrossRevenue,
+TaxableRevenue: t.TaxableRevenue,
+TaxPaid:        t.TaxPaid,
+TaxOwed:        t.TaxOwed,
+VatFormat:      string(t.VATFormat),
+VatMappingId:   t.VATMappingID,
+Currency:       string(t.Currency),
+PeriodKey:      t.PeriodKey,
+VatBoxes:       vatBoxValuesToProto(t.VATBoxes),
 }
 }
 
@@ -1323,6 +1655,12 @@ case pb.TaxJurisdiction_TAX_JURISDICTION_US_STATE:
 jurisdiction = US_STATE
 case pb.TaxJurisdiction_TAX_JURISDICTION_EU_VAT:
 jurisdiction = EU_VAT
+case pb.TaxJurisdiction_TAX_JURISDICTION_UK_VAT:
+jurisdiction = UK_VAT
+case pb.TaxJurisdiction_TAX_JURISDICTION_CANADA_GST:
+jurisdiction = CANADA_GST
+case pb.TaxJurisdiction_TAX_JURISDICTION_AUSTRALIA:
+jurisdiction = AUSTRALIA
 }
 var taxType TaxType
 switch pbReturn.GetTaxType() {
@@ -1332,15 +1670,43 @@ case pb.TaxType_TAX_TYPE_SALES_TAX:
 taxType = SALES_TAX
 case pb.TaxType_TAX_TYPE_VAT:
 taxType = VAT
+case pb.TaxType_TAX_TYPE_GST:
+taxType = GST
+case pb.TaxType_TAX_TYPE_PAYROLL_TAX:
+taxType = PAYROLL_TAX
+case pb.TaxType_TAX_TYPE_PROPERTY_TAX:
+taxType = PROPERTY_TAX
+case pb.TaxType_TAX_TYPE_WITHHOLDING:
+taxType = WITHHOLDING
+}
+var calculations []TaxCalculation
+for _, pbCalc := range pbReturn.Calculations {
+calculations = append(calculations, *TaxCalculationFromProto(pbCalc))
 }
 return &TaxReturn{
 ID:             pbReturn.Id,
+CompanyID:      pbReturn.CompanyId,
 Jurisdiction:   jurisdiction,
 TaxType:        taxType,
-DueDate:        protoToTime(pbReturn.DueDate),
+PeriodStart:    protoToTime(pbReturn.PeriodStart),
+PeriodEnd:      protoToTime(pbReturn.PeriodEnd),
+GrossRevenue:   pbReturn.GrossRevenue,
+TaxableRevenue: pbReturn.TaxableRevenue,
 TotalTax:       pbReturn.TotalTax,
+TaxPaid:        pbReturn.TaxPaid,
+TaxOwed:        pbReturn.TaxOwed,
+FilingStatus:   pbReturn.Status,
+FiledAt:        protoToOptionalTime(pbReturn.FilingDate),
+DueDate:        protoToTime(pbReturn.DueDate),
+Calculations:   calculations,
+Attachments:    pbReturn.SupportingDocs,
 CreatedAt:      protoToTime(pbReturn.CreatedAt),
 UpdatedAt:      protoToTime(pbReturn.UpdatedAt),
+VATFormat:      VATReturnFormat(pbReturn.VatFormat),
+VATMappingID:   pbReturn.VatMappingId,
+Currency:       Currency(pbReturn.Currency),
+PeriodKey:      pbReturn.PeriodKey,
+VATBoxes:       vatBoxValuesFromProto(pbReturn.VatBoxes),
 }
 }
 
@@ -0,0 +1,173 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Fee Schedule Conversions
+// ====================================================================================
+
+func (s *FeeSchedule) ToProto() *pb.FeeSchedule {
+	if s == nil {
+		return nil
+	}
+	tiers := make([]*pb.FeeTier, len(s.Tiers))
+	for i, tier := range s.Tiers {
+		tiers[i] = &pb.FeeTier{
+			Currency:    string(tier.Currency),
+			MinVolume:   tier.MinVolume,
+			PercentRate: tier.PercentRate,
+			FixedFee:    tier.FixedFee,
+		}
+	}
+	return &pb.FeeSchedule{
+		Id:               s.ID,
+		Name:             s.Name,
+		ProviderId:       s.ProviderID,
+		Direction:        string(s.Direction),
+		SourceAccountId:  s.SourceAccountID,
+		FeeAccountId:     s.FeeAccountID,
+		CounterAccountId: s.CounterAccountID,
+		Tiers:            tiers,
+		Active:           s.Active,
+		CreatedBy:        s.CreatedBy,
+		CreatedAt:        timeToProto(s.CreatedAt),
+		UpdatedAt:        timeToProto(s.UpdatedAt),
+	}
+}
+
+func FeeScheduleFromProto(pbSchedule *pb.FeeSchedule) *FeeSchedule {
+	if pbSchedule == nil {
+		return nil
+	}
+	tiers := make([]FeeTier, len(pbSchedule.Tiers))
+	for i, tier := range pbSchedule.Tiers {
+		tiers[i] = FeeTier{
+			Currency:    Currency(tier.Currency),
+			MinVolume:   tier.MinVolume,
+			PercentRate: tier.PercentRate,
+			FixedFee:    tier.FixedFee,
+		}
+	}
+	return &FeeSchedule{
+		ID:               pbSchedule.Id,
+		Name:             pbSchedule.Name,
+		ProviderID:       pbSchedule.ProviderId,
+		Direction:        FeeDirection(pbSchedule.Direction),
+		SourceAccountID:  pbSchedule.SourceAccountId,
+		FeeAccountID:     pbSchedule.FeeAccountId,
+		CounterAccountID: pbSchedule.CounterAccountId,
+		Tiers:            tiers,
+		Active:           pbSchedule.Active,
+		CreatedBy:        pbSchedule.CreatedBy,
+		CreatedAt:        protoToTime(pbSchedule.CreatedAt),
+		UpdatedAt:        protoToTime(pbSchedule.UpdatedAt),
+	}
+}
+
+func (c *FeeCharge) ToProto() *pb.FeeCharge {
+	if c == nil {
+		return nil
+	}
+	return &pb.FeeCharge{
+		Id:            c.ID,
+		ScheduleId:    c.ScheduleID,
+		TransactionId: c.TransactionID,
+		Reference:     c.Reference,
+		Currency:      string(c.Currency),
+		BaseAmount:    c.BaseAmount,
+		VolumeBefore:  c.VolumeBefore,
+		TierIndex:     int32(c.TierIndex),
+		PercentRate:   c.PercentRate,
+		FixedFee:      c.FixedFee,
+		FeeAmount:     c.FeeAmount,
+		ValidTime:     timeToProto(c.ValidTime),
+		JournalId:     c.JournalID,
+		CreatedAt:     timeToProto(c.CreatedAt),
+	}
+}
+
+func FeeChargeFromProto(pbCharge *pb.FeeCharge) *FeeCharge {
+	if pbCharge == nil {
+		return nil
+	}
+	return &FeeCharge{
+		ID:            pbCharge.Id,
+		ScheduleID:    pbCharge.ScheduleId,
+		TransactionID: pbCharge.TransactionId,
+		Reference:     pbCharge.Reference,
+		Currency:      Currency(pbCharge.Currency),
+		BaseAmount:    pbCharge.BaseAmount,
+		VolumeBefore:  pbCharge.VolumeBefore,
+		TierIndex:     int(pbCharge.TierIndex),
+		PercentRate:   pbCharge.PercentRate,
+		FixedFee:      pbCharge.FixedFee,
+		FeeAmount:     pbCharge.FeeAmount,
+		ValidTime:     protoToTime(pbCharge.ValidTime),
+		JournalID:     pbCharge.JournalId,
+		CreatedAt:     protoToTime(pbCharge.CreatedAt),
+	}
+}
+
+func (r *FeeReconciliation) ToProto() *pb.FeeReconciliation {
+	if r == nil {
+		return nil
+	}
+	lines := make([]*pb.FeeReconciliationLine, len(r.Lines))
+	for i, line := range r.Lines {
+		lines[i] = &pb.FeeReconciliationLine{
+			Reference:     line.Reference,
+			TransactionId: line.TransactionID,
+			Expected:      line.Expected,
+			Billed:        line.Billed,
+			Difference:    line.Difference,
+			Status:        string(line.Status),
+		}
+	}
+	return &pb.FeeReconciliation{
+		Id:            r.ID,
+		ScheduleId:    r.ScheduleID,
+		StatementRef:  r.StatementRef,
+		PeriodStart:   timeToProto(r.PeriodStart),
+		PeriodEnd:     timeToProto(r.PeriodEnd),
+		Lines:         lines,
+		ExpectedTotal: r.ExpectedTotal,
+		BilledTotal:   r.BilledTotal,
+		Difference:    r.Difference,
+		Exceptions:    int32(r.Exceptions),
+		ReconciledBy:  r.ReconciledBy,
+		ReconciledAt:  timeToProto(r.ReconciledAt),
+	}
+}
+
+func FeeReconciliationFromProto(pbRecon *pb.FeeReconciliation) *FeeReconciliation {
+	if pbRecon == nil {
+		return nil
+	}
+	lines := make([]FeeReconciliationLine, len(pbRecon.Lines))
+	for i, line := range pbRecon.Lines {
+		lines[i] = FeeReconciliationLine{
+			Reference:     line.Reference,
+			TransactionID: line.TransactionId,
+			Expected:      line.Expected,
+			Billed:        line.Billed,
+			Difference:    line.Difference,
+			Status:        FeeReconciliationStatus(line.Status),
+		}
+	}
+	return &FeeReconciliation{
+		ID:            pbRecon.Id,
+		ScheduleID:    pbRecon.ScheduleId,
+		StatementRef:  pbRecon.StatementRef,
+		PeriodStart:   protoToTime(pbRecon.PeriodStart),
+		PeriodEnd:     protoToTime(pbRecon.PeriodEnd),
+		Lines:         lines,
+		ExpectedTotal: pbRecon.ExpectedTotal,
+		BilledTotal:   pbRecon.BilledTotal,
+		Difference:    pbRecon.Difference,
+		Exceptions:    int(pbRecon.Exceptions),
+		ReconciledBy:  pbRecon.ReconciledBy,
+		ReconciledAt:  protoToTime(pbRecon.ReconciledAt),
+	}
+}
@@ -0,0 +1,31 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Four-Eyes Policy Conversions
+// ====================================================================================
+
+func (p *FourEyesPolicy) ToProto() *pb.FourEyesPolicy {
+	if p == nil {
+		return nil
+	}
+	return &pb.FourEyesPolicy{
+		Posting:     p.Posting,
+		Reversal:    p.Reversal,
+		PeriodClose: p.PeriodClose,
+	}
+}
+
+func FourEyesPolicyFromProto(pbPolicy *pb.FourEyesPolicy) *FourEyesPolicy {
+	if pbPolicy == nil {
+		return nil
+	}
+	return &FourEyesPolicy{
+		Posting:     pbPolicy.Posting,
+		Reversal:    pbPolicy.Reversal,
+		PeriodClose: pbPolicy.PeriodClose,
+	}
+}
@@ -0,0 +1,71 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Framework Adjustment Conversions
+// ====================================================================================
+
+func reportingStandardToProto(standard ReportingStandard) pb.ReportingStandard {
+	switch standard {
+	case GAAP:
+		return pb.ReportingStandard_REPORTING_STANDARD_GAAP
+	case IFRS:
+		return pb.ReportingStandard_REPORTING_STANDARD_IFRS
+	default:
+		return pb.ReportingStandard_REPORTING_STANDARD_UNSPECIFIED
+	}
+}
+
+func reportingStandardFromProto(standard pb.ReportingStandard) ReportingStandard {
+	switch standard {
+	case pb.ReportingStandard_REPORTING_STANDARD_GAAP:
+		return GAAP
+	case pb.ReportingStandard_REPORTING_STANDARD_IFRS:
+		return IFRS
+	default:
+		return ""
+	}
+}
+
+func (a *FrameworkAdjustment) ToProto() *pb.FrameworkAdjustment {
+	if a == nil {
+		return nil
+	}
+	return &pb.FrameworkAdjustment{
+		Id:             a.ID,
+		Framework:      reportingStandardToProto(a.Framework),
+		Layer:          a.Layer,
+		Description:    a.Description,
+		ValidTime:      timeToProto(a.ValidTime),
+		Entries:        EntriesToProto(a.Entries),
+		Reference:      a.Reference,
+		CreatedBy:      a.CreatedBy,
+		CreatedAt:      timeToProto(a.CreatedAt),
+		ReversedBy:     a.ReversedBy,
+		ReversedAt:     optionalTimeToProto(a.ReversedAt),
+		ReversalReason: a.ReversalReason,
+	}
+}
+
+func FrameworkAdjustmentFromProto(pbAdjustment *pb.FrameworkAdjustment) *FrameworkAdjustment {
+	if pbAdjustment == nil {
+		return nil
+	}
+	return &FrameworkAdjustment{
+		ID:             pbAdjustment.Id,
+		Framework:      reportingStandardFromProto(pbAdjustment.Framework),
+		Layer:          pbAdjustment.Layer,
+		Description:    pbAdjustment.Description,
+		ValidTime:      protoToTime(pbAdjustment.ValidTime),
+		Entries:        EntriesFromProto(pbAdjustment.Entries),
+		Reference:      pbAdjustment.Reference,
+		CreatedBy:      pbAdjustment.CreatedBy,
+		CreatedAt:      protoToTime(pbAdjustment.CreatedAt),
+		ReversedBy:     pbAdjustment.ReversedBy,
+		ReversedAt:     protoToOptionalTime(pbAdjustment.ReversedAt),
+		ReversalReason: pbAdjustment.ReversalReason,
+	}
+}
@@ -0,0 +1,35 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Debt Covenant Conversions
+// ====================================================================================
+
+func (c *DebtCovenant) ToProto() *pb.DebtCovenant {
+	return &pb.DebtCovenant{
+		Id:        c.ID,
+		Name:      c.Name,
+		Lender:    c.Lender,
+		Metric:    string(c.Metric),
+		Bound:     string(c.Bound),
+		Threshold: c.Threshold,
+		CreatedBy: c.CreatedBy,
+		CreatedAt: timeToProto(c.CreatedAt),
+	}
+}
+
+func DebtCovenantFromProto(pbCovenant *pb.DebtCovenant) *DebtCovenant {
+	return &DebtCovenant{
+		ID:        pbCovenant.Id,
+		Name:      pbCovenant.Name,
+		Lender:    pbCovenant.Lender,
+		Metric:    CovenantMetric(pbCovenant.Metric),
+		Bound:     CovenantBound(pbCovenant.Bound),
+		Threshold: pbCovenant.Threshold,
+		CreatedBy: pbCovenant.CreatedBy,
+		CreatedAt: protoToTime(pbCovenant.CreatedAt),
+	}
+}
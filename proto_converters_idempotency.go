@@ -0,0 +1,31 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Idempotency Conversions
+// ====================================================================================
+
+func (r *IdempotencyRecord) ToProto() *pb.IdempotencyRecord {
+	return &pb.IdempotencyRecord{
+		Key:           r.Key,
+		TransactionId: r.TransactionID,
+		Fingerprint:   r.Fingerprint,
+		UserId:        r.UserID,
+		CreatedAt:     timeToProto(r.CreatedAt),
+		ExpiresAt:     timeToProto(r.ExpiresAt),
+	}
+}
+
+func IdempotencyRecordFromProto(pbRecord *pb.IdempotencyRecord) *IdempotencyRecord {
+	return &IdempotencyRecord{
+		Key:           pbRecord.Key,
+		TransactionID: pbRecord.TransactionId,
+		Fingerprint:   pbRecord.Fingerprint,
+		UserID:        pbRecord.UserId,
+		CreatedAt:     protoToTime(pbRecord.CreatedAt),
+		ExpiresAt:     protoToTime(pbRecord.ExpiresAt),
+	}
+}
@@ -0,0 +1,37 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Price Index Conversions
+// ====================================================================================
+
+func (pi *PriceIndex) ToProto() *pb.PriceIndex {
+	if pi == nil {
+		return nil
+	}
+	return &pb.PriceIndex{
+		Id:        pi.ID,
+		IndexName: pi.IndexName,
+		Date:      timeToProto(pi.Date),
+		Value:     pi.Value,
+		CreatedBy: pi.CreatedBy,
+		CreatedAt: timeToProto(pi.CreatedAt),
+	}
+}
+
+func PriceIndexFromProto(pbIndex *pb.PriceIndex) *PriceIndex {
+	if pbIndex == nil {
+		return nil
+	}
+	return &PriceIndex{
+		ID:        pbIndex.Id,
+		IndexName: pbIndex.IndexName,
+		Date:      protoToTime(pbIndex.Date),
+		Value:     pbIndex.Value,
+		CreatedBy: pbIndex.CreatedBy,
+		CreatedAt: protoToTime(pbIndex.CreatedAt),
+	}
+}
@@ -0,0 +1,101 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Installment Plan Conversions
+// ====================================================================================
+
+func (i *Installment) ToProto() *pb.Installment {
+	if i == nil {
+		return nil
+	}
+	return &pb.Installment{
+		Number:                int32(i.Number),
+		DueDate:               timeToProto(i.DueDate),
+		Amount:                i.Amount,
+		OpeningCarrying:       i.OpeningCarrying,
+		Interest:              i.Interest,
+		ClosingCarrying:       i.ClosingCarrying,
+		InterestRecognized:    i.InterestRecognized,
+		InterestTransactionId: i.InterestTransactionID,
+		Paid:                  i.Paid,
+	}
+}
+
+func InstallmentFromProto(pbInstallment *pb.Installment) *Installment {
+	if pbInstallment == nil {
+		return nil
+	}
+	return &Installment{
+		Number:                int(pbInstallment.Number),
+		DueDate:               protoToTime(pbInstallment.DueDate),
+		Amount:                pbInstallment.Amount,
+		OpeningCarrying:       pbInstallment.OpeningCarrying,
+		Interest:              pbInstallment.Interest,
+		ClosingCarrying:       pbInstallment.ClosingCarrying,
+		InterestRecognized:    pbInstallment.InterestRecognized,
+		InterestTransactionID: pbInstallment.InterestTransactionId,
+		Paid:                  pbInstallment.Paid,
+	}
+}
+
+func (ip *InstallmentPlan) ToProto() *pb.InstallmentPlan {
+	if ip == nil {
+		return nil
+	}
+	installments := make([]*pb.Installment, len(ip.Installments))
+	for i, installment := range ip.Installments {
+		installments[i] = installment.ToProto()
+	}
+	return &pb.InstallmentPlan{
+		Id:                      ip.ID,
+		CustomerId:              ip.CustomerID,
+		InvoiceRef:              ip.InvoiceRef,
+		SaleDate:                timeToProto(ip.SaleDate),
+		Nominal:                 ip.Nominal.ToProto(),
+		PresentValue:            ip.PresentValue.ToProto(),
+		AnnualInterestRate:      ip.AnnualInterestRate,
+		FrequencyMonths:         int32(ip.FrequencyMonths),
+		ReceivableAccountId:     ip.ReceivableAccountID,
+		RevenueAccountId:        ip.RevenueAccountID,
+		InterestIncomeAccountId: ip.InterestIncomeAccountID,
+		Installments:            installments,
+		Status:                  string(ip.Status),
+		SaleTransactionId:       ip.SaleTransactionID,
+		CreatedBy:               ip.CreatedBy,
+		CreatedAt:               timeToProto(ip.CreatedAt),
+		UpdatedAt:               timeToProto(ip.UpdatedAt),
+	}
+}
+
+func InstallmentPlanFromProto(pbPlan *pb.InstallmentPlan) *InstallmentPlan {
+	if pbPlan == nil {
+		return nil
+	}
+	installments := make([]*Installment, len(pbPlan.Installments))
+	for i, installment := range pbPlan.Installments {
+		installments[i] = InstallmentFromProto(installment)
+	}
+	return &InstallmentPlan{
+		ID:                      pbPlan.Id,
+		CustomerID:              pbPlan.CustomerId,
+		InvoiceRef:              pbPlan.InvoiceRef,
+		SaleDate:                protoToTime(pbPlan.SaleDate),
+		Nominal:                 AmountFromProto(pbPlan.Nominal),
+		PresentValue:            AmountFromProto(pbPlan.PresentValue),
+		AnnualInterestRate:      pbPlan.AnnualInterestRate,
+		FrequencyMonths:         int(pbPlan.FrequencyMonths),
+		ReceivableAccountID:     pbPlan.ReceivableAccountId,
+		RevenueAccountID:        pbPlan.RevenueAccountId,
+		InterestIncomeAccountID: pbPlan.InterestIncomeAccountId,
+		Installments:            installments,
+		Status:                  InstallmentPlanStatus(pbPlan.Status),
+		SaleTransactionID:       pbPlan.SaleTransactionId,
+		CreatedBy:               pbPlan.CreatedBy,
+		CreatedAt:               protoToTime(pbPlan.CreatedAt),
+		UpdatedAt:               protoToTime(pbPlan.UpdatedAt),
+	}
+}
@@ -0,0 +1,65 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Journal Approval Conversions
+// ====================================================================================
+
+func (ja *JournalApproval) ToProto() *pb.JournalApproval {
+	if ja == nil {
+		return nil
+	}
+	history := make([]*pb.JournalApprovalAction, len(ja.History))
+	for i, action := range ja.History {
+		history[i] = &pb.JournalApprovalAction{
+			Action:  action.Action,
+			UserId:  action.UserID,
+			Comment: action.Comment,
+			At:      timeToProto(action.At),
+		}
+	}
+	return &pb.JournalApproval{
+		Id:            ja.ID,
+		TransactionId: ja.TransactionID,
+		Description:   ja.Description,
+		Amount:        ja.Amount.ToProto(),
+		Status:        string(ja.Status),
+		RequestedBy:   ja.RequestedBy,
+		RequestedAt:   timeToProto(ja.RequestedAt),
+		Approvers:     ja.Approvers,
+		DecidedBy:     ja.DecidedBy,
+		DecidedAt:     optionalTimeToProto(ja.DecidedAt),
+		History:       history,
+	}
+}
+
+func JournalApprovalFromProto(pbApproval *pb.JournalApproval) *JournalApproval {
+	if pbApproval == nil {
+		return nil
+	}
+	history := make([]JournalApprovalAction, len(pbApproval.History))
+	for i, action := range pbApproval.History {
+		history[i] = JournalApprovalAction{
+			Action:  action.Action,
+			UserID:  action.UserId,
+			Comment: action.Comment,
+			At:      protoToTime(action.At),
+		}
+	}
+	return &JournalApproval{
+		ID:            pbApproval.Id,
+		TransactionID: pbApproval.TransactionId,
+		Description:   pbApproval.Description,
+		Amount:        AmountFromProto(pbApproval.Amount),
+		Status:        JournalApprovalStatus(pbApproval.Status),
+		RequestedBy:   pbApproval.RequestedBy,
+		RequestedAt:   protoToTime(pbApproval.RequestedAt),
+		Approvers:     pbApproval.Approvers,
+		DecidedBy:     pbApproval.DecidedBy,
+		DecidedAt:     protoToOptionalTime(pbApproval.DecidedAt),
+		History:       history,
+	}
+}
@@ -0,0 +1,31 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Ledger Posting Rule Conversions
+// ====================================================================================
+
+func (r *LedgerPostingRule) ToProto() *pb.LedgerPostingRule {
+	return &pb.LedgerPostingRule{
+		Id:           r.ID,
+		FromLedgerId: r.FromLedgerID,
+		ToLedgerId:   r.ToLedgerID,
+		AccountIds:   r.AccountIDs,
+		CreatedBy:    r.CreatedBy,
+		CreatedAt:    timeToProto(r.CreatedAt),
+	}
+}
+
+func LedgerPostingRuleFromProto(pbRule *pb.LedgerPostingRule) *LedgerPostingRule {
+	return &LedgerPostingRule{
+		ID:           pbRule.Id,
+		FromLedgerID: pbRule.FromLedgerId,
+		ToLedgerID:   pbRule.ToLedgerId,
+		AccountIDs:   pbRule.AccountIds,
+		CreatedBy:    pbRule.CreatedBy,
+		CreatedAt:    protoToTime(pbRule.CreatedAt),
+	}
+}
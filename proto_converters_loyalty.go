@@ -0,0 +1,121 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Loyalty Conversions
+// ====================================================================================
+
+func (lp *LoyaltyProgram) ToProto() *pb.LoyaltyProgram {
+	if lp == nil {
+		return nil
+	}
+	return &pb.LoyaltyProgram{
+		Id:                      lp.ID,
+		Name:                    lp.Name,
+		Currency:                string(lp.Currency),
+		PointValue:              lp.PointValue,
+		ExpectedRedemptionRate:  lp.ExpectedRedemptionRate,
+		ExpiryMonths:            int32(lp.ExpiryMonths),
+		LiabilityAccountId:      lp.LiabilityAccountID,
+		RevenueAccountId:        lp.RevenueAccountID,
+		BreakageIncomeAccountId: lp.BreakageIncomeAccountID,
+		CreatedBy:               lp.CreatedBy,
+		CreatedAt:               timeToProto(lp.CreatedAt),
+	}
+}
+
+func LoyaltyProgramFromProto(pbProgram *pb.LoyaltyProgram) *LoyaltyProgram {
+	if pbProgram == nil {
+		return nil
+	}
+	return &LoyaltyProgram{
+		ID:                      pbProgram.Id,
+		Name:                    pbProgram.Name,
+		Currency:                Currency(pbProgram.Currency),
+		PointValue:              pbProgram.PointValue,
+		ExpectedRedemptionRate:  pbProgram.ExpectedRedemptionRate,
+		ExpiryMonths:            int(pbProgram.ExpiryMonths),
+		LiabilityAccountID:      pbProgram.LiabilityAccountId,
+		RevenueAccountID:        pbProgram.RevenueAccountId,
+		BreakageIncomeAccountID: pbProgram.BreakageIncomeAccountId,
+		CreatedBy:               pbProgram.CreatedBy,
+		CreatedAt:               protoToTime(pbProgram.CreatedAt),
+	}
+}
+
+func (la *LoyaltyMemberAccount) ToProto() *pb.LoyaltyMemberAccount {
+	if la == nil {
+		return nil
+	}
+	lots := make([]*pb.LoyaltyPointLot, len(la.Lots))
+	for i, lot := range la.Lots {
+		lots[i] = &pb.LoyaltyPointLot{
+			Id:              lot.ID,
+			SaleRef:         lot.SaleRef,
+			EarnedAt:        timeToProto(lot.EarnedAt),
+			ExpiresAt:       optionalTimeToProto(lot.ExpiresAt),
+			Points:          lot.Points,
+			RemainingPoints: lot.RemainingPoints,
+			Value:           lot.Value,
+			RemainingValue:  lot.RemainingValue,
+		}
+	}
+	activity := make([]*pb.LoyaltyActivity, len(la.Activity))
+	for i, a := range la.Activity {
+		activity[i] = &pb.LoyaltyActivity{
+			Type:          string(a.Type),
+			Points:        a.Points,
+			Value:         a.Value,
+			Date:          timeToProto(a.Date),
+			TransactionId: a.TransactionID,
+		}
+	}
+	return &pb.LoyaltyMemberAccount{
+		Id:        la.ID,
+		ProgramId: la.ProgramID,
+		MemberId:  la.MemberID,
+		Lots:      lots,
+		Activity:  activity,
+		UpdatedAt: timeToProto(la.UpdatedAt),
+	}
+}
+
+func LoyaltyMemberAccountFromProto(pbAccount *pb.LoyaltyMemberAccount) *LoyaltyMemberAccount {
+	if pbAccount == nil {
+		return nil
+	}
+	lots := make([]LoyaltyPointLot, len(pbAccount.Lots))
+	for i, lot := range pbAccount.Lots {
+		lots[i] = LoyaltyPointLot{
+			ID:              lot.Id,
+			SaleRef:         lot.SaleRef,
+			EarnedAt:        protoToTime(lot.EarnedAt),
+			ExpiresAt:       protoToOptionalTime(lot.ExpiresAt),
+			Points:          lot.Points,
+			RemainingPoints: lot.RemainingPoints,
+			Value:           lot.Value,
+			RemainingValue:  lot.RemainingValue,
+		}
+	}
+	activity := make([]LoyaltyActivity, len(pbAccount.Activity))
+	for i, a := range pbAccount.Activity {
+		activity[i] = LoyaltyActivity{
+			Type:          LoyaltyActivityType(a.Type),
+			Points:        a.Points,
+			Value:         a.Value,
+			Date:          protoToTime(a.Date),
+			TransactionID: a.TransactionId,
+		}
+	}
+	return &LoyaltyMemberAccount{
+		ID:        pbAccount.Id,
+		ProgramID: pbAccount.ProgramId,
+		MemberID:  pbAccount.MemberId,
+		Lots:      lots,
+		Activity:  activity,
+		UpdatedAt: protoToTime(pbAccount.UpdatedAt),
+	}
+}
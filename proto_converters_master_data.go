@@ -0,0 +1,93 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Master Data Conversions
+// ====================================================================================
+
+func (c *Customer) ToProto() *pb.Customer {
+	if c == nil {
+		return nil
+	}
+	return &pb.Customer{
+		Id:          c.ID,
+		Name:        c.Name,
+		TaxId:       c.TaxID,
+		BankAccount: c.BankAccount,
+		Email:       c.Email,
+		Currency:    string(c.Currency),
+		Active:      c.Active,
+		MergedInto:  c.MergedInto,
+		CreatedBy:   c.CreatedBy,
+		CreatedAt:   timeToProto(c.CreatedAt),
+	}
+}
+
+func CustomerFromProto(pbCustomer *pb.Customer) *Customer {
+	if pbCustomer == nil {
+		return nil
+	}
+	return &Customer{
+		ID:          pbCustomer.Id,
+		Name:        pbCustomer.Name,
+		TaxID:       pbCustomer.TaxId,
+		BankAccount: pbCustomer.BankAccount,
+		Email:       pbCustomer.Email,
+		Currency:    Currency(pbCustomer.Currency),
+		Active:      pbCustomer.Active,
+		MergedInto:  pbCustomer.MergedInto,
+		CreatedBy:   pbCustomer.CreatedBy,
+		CreatedAt:   protoToTime(pbCustomer.CreatedAt),
+	}
+}
+
+func (m *PartyMerge) ToProto() *pb.PartyMerge {
+	if m == nil {
+		return nil
+	}
+	repointed := make([]*pb.RepointedRecord, len(m.Repointed))
+	for i, record := range m.Repointed {
+		repointed[i] = &pb.RepointedRecord{
+			RecordType: record.RecordType,
+			RecordId:   record.RecordID,
+		}
+	}
+	return &pb.PartyMerge{
+		Id:               m.ID,
+		PartyType:        string(m.PartyType),
+		SurvivorId:       m.SurvivorID,
+		MergedId:         m.MergedID,
+		SurvivorOriginal: m.SurvivorOriginal,
+		MergedOriginal:   m.MergedOriginal,
+		Repointed:        repointed,
+		MergedBy:         m.MergedBy,
+		MergedAt:         timeToProto(m.MergedAt),
+	}
+}
+
+func PartyMergeFromProto(pbMerge *pb.PartyMerge) *PartyMerge {
+	if pbMerge == nil {
+		return nil
+	}
+	repointed := make([]RepointedRecord, len(pbMerge.Repointed))
+	for i, record := range pbMerge.Repointed {
+		repointed[i] = RepointedRecord{
+			RecordType: record.RecordType,
+			RecordID:   record.RecordId,
+		}
+	}
+	return &PartyMerge{
+		ID:               pbMerge.Id,
+		PartyType:        PartyType(pbMerge.PartyType),
+		SurvivorID:       pbMerge.SurvivorId,
+		MergedID:         pbMerge.MergedId,
+		SurvivorOriginal: pbMerge.SurvivorOriginal,
+		MergedOriginal:   pbMerge.MergedOriginal,
+		Repointed:        repointed,
+		MergedBy:         pbMerge.MergedBy,
+		MergedAt:         protoToTime(pbMerge.MergedAt),
+	}
+}
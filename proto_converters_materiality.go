@@ -0,0 +1,57 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Materiality Conversions
+// ====================================================================================
+
+func (ma *MaterialityAssessment) ToProto() *pb.MaterialityAssessment {
+	if ma == nil {
+		return nil
+	}
+	return &pb.MaterialityAssessment{
+		Id:                 ma.ID,
+		CompanyId:          ma.CompanyID,
+		PeriodId:           ma.PeriodID,
+		PeriodStart:        timeToProto(ma.PeriodStart),
+		PeriodEnd:          timeToProto(ma.PeriodEnd),
+		Benchmark:          string(ma.Benchmark),
+		BenchmarkAmount:    ma.BenchmarkAmount.ToProto(),
+		Percentage:         ma.Percentage,
+		Overall:            ma.Overall.ToProto(),
+		PerformancePercent: ma.PerformancePercent,
+		Performance:        ma.Performance.ToProto(),
+		TrivialPercent:     ma.TrivialPercent,
+		ClearlyTrivial:     ma.ClearlyTrivial.ToProto(),
+		Rationale:          ma.Rationale,
+		CreatedBy:          ma.CreatedBy,
+		CreatedAt:          timeToProto(ma.CreatedAt),
+	}
+}
+
+func MaterialityAssessmentFromProto(pbAssessment *pb.MaterialityAssessment) *MaterialityAssessment {
+	if pbAssessment == nil {
+		return nil
+	}
+	return &MaterialityAssessment{
+		ID:                 pbAssessment.Id,
+		CompanyID:          pbAssessment.CompanyId,
+		PeriodID:           pbAssessment.PeriodId,
+		PeriodStart:        protoToTime(pbAssessment.PeriodStart),
+		PeriodEnd:          protoToTime(pbAssessment.PeriodEnd),
+		Benchmark:          MaterialityBenchmark(pbAssessment.Benchmark),
+		BenchmarkAmount:    AmountFromProto(pbAssessment.BenchmarkAmount),
+		Percentage:         pbAssessment.Percentage,
+		Overall:            AmountFromProto(pbAssessment.Overall),
+		PerformancePercent: pbAssessment.PerformancePercent,
+		Performance:        AmountFromProto(pbAssessment.Performance),
+		TrivialPercent:     pbAssessment.TrivialPercent,
+		ClearlyTrivial:     AmountFromProto(pbAssessment.ClearlyTrivial),
+		Rationale:          pbAssessment.Rationale,
+		CreatedBy:          pbAssessment.CreatedBy,
+		CreatedAt:          protoToTime(pbAssessment.CreatedAt),
+	}
+}
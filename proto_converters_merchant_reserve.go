@@ -0,0 +1,115 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Merchant Reserve Conversions
+// ====================================================================================
+
+func (r *MerchantReserve) ToProto() *pb.MerchantReserve {
+	if r == nil {
+		return nil
+	}
+	schedule := make([]*pb.ReserveReleaseStep, len(r.ReleaseSchedule))
+	for i, step := range r.ReleaseSchedule {
+		schedule[i] = &pb.ReserveReleaseStep{
+			AfterDays: int32(step.AfterDays),
+			Rate:      step.Rate,
+		}
+	}
+	return &pb.MerchantReserve{
+		Id:                 r.ID,
+		Name:               r.Name,
+		Currency:           string(r.Currency),
+		RollingReserveRate: r.RollingReserveRate,
+		ReleaseSchedule:    schedule,
+		PayableAccountId:   r.PayableAccountID,
+		ReserveAccountId:   r.ReserveAccountID,
+		CreatedBy:          r.CreatedBy,
+		CreatedAt:          timeToProto(r.CreatedAt),
+		UpdatedAt:          timeToProto(r.UpdatedAt),
+	}
+}
+
+func MerchantReserveFromProto(pbReserve *pb.MerchantReserve) *MerchantReserve {
+	if pbReserve == nil {
+		return nil
+	}
+	schedule := make([]ReserveReleaseStep, len(pbReserve.ReleaseSchedule))
+	for i, step := range pbReserve.ReleaseSchedule {
+		schedule[i] = ReserveReleaseStep{
+			AfterDays: int(step.AfterDays),
+			Rate:      step.Rate,
+		}
+	}
+	return &MerchantReserve{
+		ID:                 pbReserve.Id,
+		Name:               pbReserve.Name,
+		Currency:           Currency(pbReserve.Currency),
+		RollingReserveRate: pbReserve.RollingReserveRate,
+		ReleaseSchedule:    schedule,
+		PayableAccountID:   pbReserve.PayableAccountId,
+		ReserveAccountID:   pbReserve.ReserveAccountId,
+		CreatedBy:          pbReserve.CreatedBy,
+		CreatedAt:          protoToTime(pbReserve.CreatedAt),
+		UpdatedAt:          protoToTime(pbReserve.UpdatedAt),
+	}
+}
+
+func (h *ReserveHold) ToProto() *pb.ReserveHold {
+	if h == nil {
+		return nil
+	}
+	releases := make([]*pb.ReserveRelease, len(h.Releases))
+	for i, release := range h.Releases {
+		releases[i] = &pb.ReserveRelease{
+			DueDate:       timeToProto(release.DueDate),
+			Amount:        release.Amount,
+			ReleasedAt:    optionalTimeToProto(release.ReleasedAt),
+			TransactionId: release.TransactionID,
+		}
+	}
+	return &pb.ReserveHold{
+		Id:            h.ID,
+		MerchantId:    h.MerchantID,
+		CaptureRef:    h.CaptureRef,
+		CaptureAmount: h.CaptureAmount,
+		HeldAmount:    h.HeldAmount,
+		CapturedAt:    timeToProto(h.CapturedAt),
+		TransactionId: h.TransactionID,
+		Releases:      releases,
+		CreatedBy:     h.CreatedBy,
+		CreatedAt:     timeToProto(h.CreatedAt),
+		UpdatedAt:     timeToProto(h.UpdatedAt),
+	}
+}
+
+func ReserveHoldFromProto(pbHold *pb.ReserveHold) *ReserveHold {
+	if pbHold == nil {
+		return nil
+	}
+	releases := make([]ReserveRelease, len(pbHold.Releases))
+	for i, release := range pbHold.Releases {
+		releases[i] = ReserveRelease{
+			DueDate:       protoToTime(release.DueDate),
+			Amount:        release.Amount,
+			ReleasedAt:    protoToOptionalTime(release.ReleasedAt),
+			TransactionID: release.TransactionId,
+		}
+	}
+	return &ReserveHold{
+		ID:            pbHold.Id,
+		MerchantID:    pbHold.MerchantId,
+		CaptureRef:    pbHold.CaptureRef,
+		CaptureAmount: pbHold.CaptureAmount,
+		HeldAmount:    pbHold.HeldAmount,
+		CapturedAt:    protoToTime(pbHold.CapturedAt),
+		TransactionID: pbHold.TransactionId,
+		Releases:      releases,
+		CreatedBy:     pbHold.CreatedBy,
+		CreatedAt:     protoToTime(pbHold.CreatedAt),
+		UpdatedAt:     protoToTime(pbHold.UpdatedAt),
+	}
+}
@@ -0,0 +1,107 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Narrative Conversions
+// ====================================================================================
+
+func (t *NarrativeTemplate) ToProto() *pb.NarrativeTemplate {
+	ruleTypes := make([]string, len(t.RuleTypes))
+	for i, ruleType := range t.RuleTypes {
+		ruleTypes[i] = string(ruleType)
+	}
+	sections := make([]*pb.NarrativeSectionTemplate, len(t.Sections))
+	for i, section := range t.Sections {
+		sections[i] = &pb.NarrativeSectionTemplate{
+			Key:     section.Key,
+			Heading: section.Heading,
+			Text:    section.Text,
+		}
+	}
+	return &pb.NarrativeTemplate{
+		Id:        t.ID,
+		Name:      t.Name,
+		Kind:      string(t.Kind),
+		RuleTypes: ruleTypes,
+		Sections:  sections,
+		CreatedBy: t.CreatedBy,
+		CreatedAt: timeToProto(t.CreatedAt),
+		UpdatedAt: timeToProto(t.UpdatedAt),
+	}
+}
+
+func NarrativeTemplateFromProto(pbTemplate *pb.NarrativeTemplate) *NarrativeTemplate {
+	var ruleTypes []AMLRuleType
+	for _, ruleType := range pbTemplate.RuleTypes {
+		ruleTypes = append(ruleTypes, AMLRuleType(ruleType))
+	}
+	sections := make([]NarrativeSectionTemplate, len(pbTemplate.Sections))
+	for i, section := range pbTemplate.Sections {
+		sections[i] = NarrativeSectionTemplate{
+			Key:     section.Key,
+			Heading: section.Heading,
+			Text:    section.Text,
+		}
+	}
+	return &NarrativeTemplate{
+		ID:        pbTemplate.Id,
+		Name:      pbTemplate.Name,
+		Kind:      NarrativeKind(pbTemplate.Kind),
+		RuleTypes: ruleTypes,
+		Sections:  sections,
+		CreatedBy: pbTemplate.CreatedBy,
+		CreatedAt: protoToTime(pbTemplate.CreatedAt),
+		UpdatedAt: protoToTime(pbTemplate.UpdatedAt),
+	}
+}
+
+func (n *Narrative) ToProto() *pb.Narrative {
+	sections := make([]*pb.NarrativeSection, len(n.Sections))
+	for i, section := range n.Sections {
+		sections[i] = &pb.NarrativeSection{
+			Key:       section.Key,
+			Heading:   section.Heading,
+			Text:      section.Text,
+			Generated: section.Generated,
+			EditedBy:  section.EditedBy,
+			EditedAt:  optionalTimeToProto(section.EditedAt),
+		}
+	}
+	return &pb.Narrative{
+		Id:          n.ID,
+		AlertId:     n.AlertID,
+		TemplateId:  n.TemplateID,
+		Kind:        string(n.Kind),
+		Sections:    sections,
+		GeneratedBy: n.GeneratedBy,
+		GeneratedAt: timeToProto(n.GeneratedAt),
+		UpdatedAt:   timeToProto(n.UpdatedAt),
+	}
+}
+
+func NarrativeFromProto(pbNarrative *pb.Narrative) *Narrative {
+	sections := make([]NarrativeSection, len(pbNarrative.Sections))
+	for i, section := range pbNarrative.Sections {
+		sections[i] = NarrativeSection{
+			Key:       section.Key,
+			Heading:   section.Heading,
+			Text:      section.Text,
+			Generated: section.Generated,
+			EditedBy:  section.EditedBy,
+			EditedAt:  protoToOptionalTime(section.EditedAt),
+		}
+	}
+	return &Narrative{
+		ID:          pbNarrative.Id,
+		AlertID:     pbNarrative.AlertId,
+		TemplateID:  pbNarrative.TemplateId,
+		Kind:        NarrativeKind(pbNarrative.Kind),
+		Sections:    sections,
+		GeneratedBy: pbNarrative.GeneratedBy,
+		GeneratedAt: protoToTime(pbNarrative.GeneratedAt),
+		UpdatedAt:   protoToTime(pbNarrative.UpdatedAt),
+	}
+}
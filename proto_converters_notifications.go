@@ -0,0 +1,79 @@
+package accounting
+
+import (
+	"time"
+
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Notification Subscription Conversions
+// ====================================================================================
+
+func (s *NotificationSubscription) ToProto() *pb.NotificationSubscription {
+	return &pb.NotificationSubscription{
+		Id:          s.ID,
+		Name:        s.Name,
+		Topics:      s.Topics,
+		Url:         s.URL,
+		Callback:    s.Callback,
+		Secret:      s.Secret,
+		Active:      s.Active,
+		MaxAttempts: int32(s.MaxAttempts),
+		CreatedBy:   s.CreatedBy,
+		CreatedAt:   timeToProto(s.CreatedAt),
+		UpdatedAt:   timeToProto(s.UpdatedAt),
+	}
+}
+
+func NotificationSubscriptionFromProto(pbSubscription *pb.NotificationSubscription) *NotificationSubscription {
+	return &NotificationSubscription{
+		ID:          pbSubscription.Id,
+		Name:        pbSubscription.Name,
+		Topics:      pbSubscription.Topics,
+		URL:         pbSubscription.Url,
+		Callback:    pbSubscription.Callback,
+		Secret:      pbSubscription.Secret,
+		Active:      pbSubscription.Active,
+		MaxAttempts: int(pbSubscription.MaxAttempts),
+		CreatedBy:   pbSubscription.CreatedBy,
+		CreatedAt:   protoToTime(pbSubscription.CreatedAt),
+		UpdatedAt:   protoToTime(pbSubscription.UpdatedAt),
+	}
+}
+
+// ====================================================================================
+// Notification Delivery Conversions
+// ====================================================================================
+
+func (d *NotificationDelivery) ToProto() *pb.NotificationDelivery {
+	return &pb.NotificationDelivery{
+		Id:             d.ID,
+		SubscriptionId: d.SubscriptionID,
+		NotificationId: d.NotificationID,
+		Topic:          d.Topic,
+		Attempt:        int32(d.Attempt),
+		Status:         string(d.Status),
+		StatusCode:     int32(d.StatusCode),
+		Error:          d.Error,
+		DurationNanos:  int64(d.Duration),
+		AttemptedAt:    timeToProto(d.AttemptedAt),
+		NextAttemptAt:  optionalTimeToProto(d.NextAttemptAt),
+	}
+}
+
+func NotificationDeliveryFromProto(pbDelivery *pb.NotificationDelivery) *NotificationDelivery {
+	return &NotificationDelivery{
+		ID:             pbDelivery.Id,
+		SubscriptionID: pbDelivery.SubscriptionId,
+		NotificationID: pbDelivery.NotificationId,
+		Topic:          pbDelivery.Topic,
+		Attempt:        int(pbDelivery.Attempt),
+		Status:         NotificationDeliveryStatus(pbDelivery.Status),
+		StatusCode:     int(pbDelivery.StatusCode),
+		Error:          pbDelivery.Error,
+		Duration:       time.Duration(pbDelivery.DurationNanos),
+		AttemptedAt:    protoToTime(pbDelivery.AttemptedAt),
+		NextAttemptAt:  protoToOptionalTime(pbDelivery.NextAttemptAt),
+	}
+}
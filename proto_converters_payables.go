@@ -0,0 +1,261 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Accounts Payable Conversions
+// ====================================================================================
+
+func (v *Vendor) ToProto() *pb.Vendor {
+	if v == nil {
+		return nil
+	}
+	return &pb.Vendor{
+		Id:                      v.ID,
+		Name:                    v.Name,
+		Currency:                string(v.Currency),
+		PaymentTermsDays:        int32(v.PaymentTermsDays),
+		DefaultExpenseAccountId: v.DefaultExpenseAccountID,
+		TaxId:                   v.TaxID,
+		BankAccount:             v.BankAccount,
+		Active:                  v.Active,
+		MergedInto:              v.MergedInto,
+		CreatedBy:               v.CreatedBy,
+		CreatedAt:               timeToProto(v.CreatedAt),
+		TaxProfile:              v.TaxProfile.ToProto(),
+	}
+}
+
+func VendorFromProto(pbVendor *pb.Vendor) *Vendor {
+	if pbVendor == nil {
+		return nil
+	}
+	return &Vendor{
+		ID:                      pbVendor.Id,
+		Name:                    pbVendor.Name,
+		Currency:                Currency(pbVendor.Currency),
+		PaymentTermsDays:        int(pbVendor.PaymentTermsDays),
+		DefaultExpenseAccountID: pbVendor.DefaultExpenseAccountId,
+		TaxID:                   pbVendor.TaxId,
+		BankAccount:             pbVendor.BankAccount,
+		Active:                  pbVendor.Active,
+		MergedInto:              pbVendor.MergedInto,
+		CreatedBy:               pbVendor.CreatedBy,
+		CreatedAt:               protoToTime(pbVendor.CreatedAt),
+		TaxProfile:              VendorTaxProfileFromProto(pbVendor.TaxProfile),
+	}
+}
+
+func (p *VendorTaxProfile) ToProto() *pb.VendorTaxProfile {
+	if p == nil {
+		return nil
+	}
+	return &pb.VendorTaxProfile{
+		TinType:         string(p.TINType),
+		LegalName:       p.LegalName,
+		Address:         p.Address,
+		City:            p.City,
+		State:           p.State,
+		PostalCode:      p.PostalCode,
+		Reportable:      p.Reportable,
+		Form:            string(p.Form),
+		Box:             p.Box,
+		WithholdingRate: p.WithholdingRate,
+	}
+}
+
+func VendorTaxProfileFromProto(pbProfile *pb.VendorTaxProfile) *VendorTaxProfile {
+	if pbProfile == nil {
+		return nil
+	}
+	return &VendorTaxProfile{
+		TINType:         TINType(pbProfile.TinType),
+		LegalName:       pbProfile.LegalName,
+		Address:         pbProfile.Address,
+		City:            pbProfile.City,
+		State:           pbProfile.State,
+		PostalCode:      pbProfile.PostalCode,
+		Reportable:      pbProfile.Reportable,
+		Form:            Form1099Type(pbProfile.Form),
+		Box:             pbProfile.Box,
+		WithholdingRate: pbProfile.WithholdingRate,
+	}
+}
+
+func (p *Vendor1099Payment) ToProto() *pb.Vendor1099Payment {
+	if p == nil {
+		return nil
+	}
+	return &pb.Vendor1099Payment{
+		Id:            p.ID,
+		VendorId:      p.VendorID,
+		TransactionId: p.TransactionID,
+		PaidAt:        timeToProto(p.PaidAt),
+		Year:          int32(p.Year),
+		Form:          string(p.Form),
+		Box:           p.Box,
+		Amount:        p.Amount,
+		Withheld:      p.Withheld,
+		Currency:      string(p.Currency),
+		RecordedAt:    timeToProto(p.RecordedAt),
+	}
+}
+
+func Vendor1099PaymentFromProto(pbPayment *pb.Vendor1099Payment) *Vendor1099Payment {
+	if pbPayment == nil {
+		return nil
+	}
+	return &Vendor1099Payment{
+		ID:            pbPayment.Id,
+		VendorID:      pbPayment.VendorId,
+		TransactionID: pbPayment.TransactionId,
+		PaidAt:        protoToTime(pbPayment.PaidAt),
+		Year:          int(pbPayment.Year),
+		Form:          Form1099Type(pbPayment.Form),
+		Box:           pbPayment.Box,
+		Amount:        pbPayment.Amount,
+		Withheld:      pbPayment.Withheld,
+		Currency:      Currency(pbPayment.Currency),
+		RecordedAt:    protoToTime(pbPayment.RecordedAt),
+	}
+}
+
+func (m *ThreeWayMatch) ToProto() *pb.ThreeWayMatch {
+	if m == nil {
+		return nil
+	}
+	return &pb.ThreeWayMatch{
+		Status:           string(m.Status),
+		PurchaseOrderRef: m.PurchaseOrderRef,
+		ReceiptRef:       m.ReceiptRef,
+		Notes:            m.Notes,
+		CheckedBy:        m.CheckedBy,
+		CheckedAt:        optionalTimeToProto(m.CheckedAt),
+	}
+}
+
+func ThreeWayMatchFromProto(pbMatch *pb.ThreeWayMatch) *ThreeWayMatch {
+	if pbMatch == nil {
+		return nil
+	}
+	return &ThreeWayMatch{
+		Status:           MatchStatus(pbMatch.Status),
+		PurchaseOrderRef: pbMatch.PurchaseOrderRef,
+		ReceiptRef:       pbMatch.ReceiptRef,
+		Notes:            pbMatch.Notes,
+		CheckedBy:        pbMatch.CheckedBy,
+		CheckedAt:        protoToOptionalTime(pbMatch.CheckedAt),
+	}
+}
+
+func (vb *VendorBill) ToProto() *pb.VendorBill {
+	if vb == nil {
+		return nil
+	}
+	lines := make([]*pb.BillLine, len(vb.Lines))
+	for i, line := range vb.Lines {
+		lines[i] = &pb.BillLine{
+			Description: line.Description,
+			AccountId:   line.AccountID,
+			Amount:      line.Amount,
+		}
+	}
+	return &pb.VendorBill{
+		Id:                   vb.ID,
+		VendorId:             vb.VendorID,
+		BillNumber:           vb.BillNumber,
+		BillDate:             timeToProto(vb.BillDate),
+		DueDate:              timeToProto(vb.DueDate),
+		Lines:                lines,
+		Total:                vb.Total.ToProto(),
+		Status:               string(vb.Status),
+		Match:                vb.Match.ToProto(),
+		RequiresApproval:     vb.RequiresApproval,
+		ApprovedBy:           vb.ApprovedBy,
+		ApprovedAt:           optionalTimeToProto(vb.ApprovedAt),
+		RejectionReason:      vb.RejectionReason,
+		TransactionId:        vb.TransactionID,
+		PaymentRunId:         vb.PaymentRunID,
+		PaymentTransactionId: vb.PaymentTransactionID,
+		PaidAt:               optionalTimeToProto(vb.PaidAt),
+		CreatedBy:            vb.CreatedBy,
+		CreatedAt:            timeToProto(vb.CreatedAt),
+		UpdatedAt:            timeToProto(vb.UpdatedAt),
+	}
+}
+
+func VendorBillFromProto(pbBill *pb.VendorBill) *VendorBill {
+	if pbBill == nil {
+		return nil
+	}
+	lines := make([]BillLine, len(pbBill.Lines))
+	for i, line := range pbBill.Lines {
+		lines[i] = BillLine{
+			Description: line.Description,
+			AccountID:   line.AccountId,
+			Amount:      line.Amount,
+		}
+	}
+	return &VendorBill{
+		ID:                   pbBill.Id,
+		VendorID:             pbBill.VendorId,
+		BillNumber:           pbBill.BillNumber,
+		BillDate:             protoToTime(pbBill.BillDate),
+		DueDate:              protoToTime(pbBill.DueDate),
+		Lines:                lines,
+		Total:                AmountFromProto(pbBill.Total),
+		Status:               BillStatus(pbBill.Status),
+		Match:                ThreeWayMatchFromProto(pbBill.Match),
+		RequiresApproval:     pbBill.RequiresApproval,
+		ApprovedBy:           pbBill.ApprovedBy,
+		ApprovedAt:           protoToOptionalTime(pbBill.ApprovedAt),
+		RejectionReason:      pbBill.RejectionReason,
+		TransactionID:        pbBill.TransactionId,
+		PaymentRunID:         pbBill.PaymentRunId,
+		PaymentTransactionID: pbBill.PaymentTransactionId,
+		PaidAt:               protoToOptionalTime(pbBill.PaidAt),
+		CreatedBy:            pbBill.CreatedBy,
+		CreatedAt:            protoToTime(pbBill.CreatedAt),
+		UpdatedAt:            protoToTime(pbBill.UpdatedAt),
+	}
+}
+
+func (pr *PaymentRun) ToProto() *pb.PaymentRun {
+	if pr == nil {
+		return nil
+	}
+	return &pb.PaymentRun{
+		Id:               pr.ID,
+		ScheduledDate:    timeToProto(pr.ScheduledDate),
+		PaymentAccountId: pr.PaymentAccountID,
+		BillIds:          pr.BillIDs,
+		Total:            pr.Total.ToProto(),
+		Status:           string(pr.Status),
+		TransactionId:    pr.TransactionID,
+		CreatedBy:        pr.CreatedBy,
+		CreatedAt:        timeToProto(pr.CreatedAt),
+		ExecutedBy:       pr.ExecutedBy,
+		ExecutedAt:       optionalTimeToProto(pr.ExecutedAt),
+	}
+}
+
+func PaymentRunFromProto(pbRun *pb.PaymentRun) *PaymentRun {
+	if pbRun == nil {
+		return nil
+	}
+	return &PaymentRun{
+		ID:               pbRun.Id,
+		ScheduledDate:    protoToTime(pbRun.ScheduledDate),
+		PaymentAccountID: pbRun.PaymentAccountId,
+		BillIDs:          pbRun.BillIds,
+		Total:            AmountFromProto(pbRun.Total),
+		Status:           PaymentRunStatus(pbRun.Status),
+		TransactionID:    pbRun.TransactionId,
+		CreatedBy:        pbRun.CreatedBy,
+		CreatedAt:        protoToTime(pbRun.CreatedAt),
+		ExecutedBy:       pbRun.ExecutedBy,
+		ExecutedAt:       protoToOptionalTime(pbRun.ExecutedAt),
+	}
+}
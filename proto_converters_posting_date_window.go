@@ -0,0 +1,49 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Posting Date Window Conversions
+// ====================================================================================
+
+func (w *PostingDateWindow) ToProto() *pb.PostingDateWindow {
+	if w == nil {
+		return nil
+	}
+	overrides := make([]*pb.PostingDateOverride, len(w.Overrides))
+	for i, o := range w.Overrides {
+		overrides[i] = &pb.PostingDateOverride{
+			Role:           o.Role,
+			UserIds:        o.UserIDs,
+			MaxDaysBack:    int32(o.MaxDaysBack),
+			MaxDaysForward: int32(o.MaxDaysForward),
+		}
+	}
+	return &pb.PostingDateWindow{
+		MaxDaysBack:    int32(w.MaxDaysBack),
+		MaxDaysForward: int32(w.MaxDaysForward),
+		Overrides:      overrides,
+	}
+}
+
+func PostingDateWindowFromProto(pbWindow *pb.PostingDateWindow) *PostingDateWindow {
+	if pbWindow == nil {
+		return nil
+	}
+	overrides := make([]PostingDateOverride, len(pbWindow.Overrides))
+	for i, o := range pbWindow.Overrides {
+		overrides[i] = PostingDateOverride{
+			Role:           o.Role,
+			UserIDs:        o.UserIds,
+			MaxDaysBack:    int(o.MaxDaysBack),
+			MaxDaysForward: int(o.MaxDaysForward),
+		}
+	}
+	return &PostingDateWindow{
+		MaxDaysBack:    int(pbWindow.MaxDaysBack),
+		MaxDaysForward: int(pbWindow.MaxDaysForward),
+		Overrides:      overrides,
+	}
+}
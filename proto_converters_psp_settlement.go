@@ -0,0 +1,137 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// PSP Settlement Conversions
+// ====================================================================================
+
+func (p *PSPProvider) ToProto() *pb.PSPProvider {
+	if p == nil {
+		return nil
+	}
+	return &pb.PSPProvider{
+		Id:                  p.ID,
+		Name:                p.Name,
+		Currency:            string(p.Currency),
+		ClearingAccountId:   p.ClearingAccountID,
+		BankAccountId:       p.BankAccountID,
+		FeeAccountId:        p.FeeAccountID,
+		ReserveAccountId:    p.ReserveAccountID,
+		DifferenceAccountId: p.DifferenceAccountID,
+		CreatedBy:           p.CreatedBy,
+		CreatedAt:           timeToProto(p.CreatedAt),
+	}
+}
+
+func PSPProviderFromProto(pbProvider *pb.PSPProvider) *PSPProvider {
+	if pbProvider == nil {
+		return nil
+	}
+	return &PSPProvider{
+		ID:                  pbProvider.Id,
+		Name:                pbProvider.Name,
+		Currency:            Currency(pbProvider.Currency),
+		ClearingAccountID:   pbProvider.ClearingAccountId,
+		BankAccountID:       pbProvider.BankAccountId,
+		FeeAccountID:        pbProvider.FeeAccountId,
+		ReserveAccountID:    pbProvider.ReserveAccountId,
+		DifferenceAccountID: pbProvider.DifferenceAccountId,
+		CreatedBy:           pbProvider.CreatedBy,
+		CreatedAt:           protoToTime(pbProvider.CreatedAt),
+	}
+}
+
+func (b *PSPSettlementBatch) ToProto() *pb.PSPSettlementBatch {
+	if b == nil {
+		return nil
+	}
+	lines := make([]*pb.PSPSettlementLine, len(b.Lines))
+	for i, line := range b.Lines {
+		lines[i] = &pb.PSPSettlementLine{
+			Type:              string(line.Type),
+			Reference:         line.Reference,
+			Amount:            line.Amount,
+			Status:            string(line.Status),
+			SaleTransactionId: line.SaleTransactionID,
+			ReversalId:        line.ReversalID,
+			LedgerAmount:      line.LedgerAmount,
+			Delta:             line.Delta,
+			Message:           line.Message,
+		}
+	}
+	return &pb.PSPSettlementBatch{
+		Id:                b.ID,
+		ProviderId:        b.ProviderID,
+		BatchRef:          b.BatchRef,
+		SettlementDate:    timeToProto(b.SettlementDate),
+		GrossSales:        b.GrossSales,
+		Refunds:           b.Refunds,
+		Chargebacks:       b.Chargebacks,
+		Fees:              b.Fees,
+		ReserveHeld:       b.ReserveHeld,
+		ReserveReleased:   b.ReserveReleased,
+		NetPayout:         b.NetPayout,
+		Lines:             lines,
+		Currency:          string(b.Currency),
+		MatchedCount:      int32(b.MatchedCount),
+		ExceptionCount:    int32(b.ExceptionCount),
+		UnmatchedAmount:   b.UnmatchedAmount,
+		AmountDifference:  b.AmountDifference,
+		HeaderDifference:  b.HeaderDifference,
+		PayoutDifference:  b.PayoutDifference,
+		UnreconciledDelta: b.UnreconciledDelta,
+		Status:            string(b.Status),
+		TransactionId:     b.TransactionID,
+		ReconciledBy:      b.ReconciledBy,
+		ReconciledAt:      timeToProto(b.ReconciledAt),
+	}
+}
+
+func PSPSettlementBatchFromProto(pbBatch *pb.PSPSettlementBatch) *PSPSettlementBatch {
+	if pbBatch == nil {
+		return nil
+	}
+	lines := make([]PSPSettlementLine, len(pbBatch.Lines))
+	for i, line := range pbBatch.Lines {
+		lines[i] = PSPSettlementLine{
+			Type:              PSPLineType(line.Type),
+			Reference:         line.Reference,
+			Amount:            line.Amount,
+			Status:            PSPMatchStatus(line.Status),
+			SaleTransactionID: line.SaleTransactionId,
+			ReversalID:        line.ReversalId,
+			LedgerAmount:      line.LedgerAmount,
+			Delta:             line.Delta,
+			Message:           line.Message,
+		}
+	}
+	return &PSPSettlementBatch{
+		ID:                pbBatch.Id,
+		ProviderID:        pbBatch.ProviderId,
+		BatchRef:          pbBatch.BatchRef,
+		SettlementDate:    protoToTime(pbBatch.SettlementDate),
+		GrossSales:        pbBatch.GrossSales,
+		Refunds:           pbBatch.Refunds,
+		Chargebacks:       pbBatch.Chargebacks,
+		Fees:              pbBatch.Fees,
+		ReserveHeld:       pbBatch.ReserveHeld,
+		ReserveReleased:   pbBatch.ReserveReleased,
+		NetPayout:         pbBatch.NetPayout,
+		Lines:             lines,
+		Currency:          Currency(pbBatch.Currency),
+		MatchedCount:      int(pbBatch.MatchedCount),
+		ExceptionCount:    int(pbBatch.ExceptionCount),
+		UnmatchedAmount:   pbBatch.UnmatchedAmount,
+		AmountDifference:  pbBatch.AmountDifference,
+		HeaderDifference:  pbBatch.HeaderDifference,
+		PayoutDifference:  pbBatch.PayoutDifference,
+		UnreconciledDelta: pbBatch.UnreconciledDelta,
+		Status:            PSPSettlementStatus(pbBatch.Status),
+		TransactionID:     pbBatch.TransactionId,
+		ReconciledBy:      pbBatch.ReconciledBy,
+		ReconciledAt:      protoToTime(pbBatch.ReconciledAt),
+	}
+}
@@ -0,0 +1,104 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Reclassification Conversions
+// ====================================================================================
+
+func (b *ReclassBatch) ToProto() *pb.ReclassBatch {
+	if b == nil {
+		return nil
+	}
+	rules := make([]*pb.ReclassRule, len(b.Rules))
+	for i, rule := range b.Rules {
+		rules[i] = &pb.ReclassRule{
+			FromAccountId:   rule.FromAccountID,
+			MatchDimensions: DimensionsToProto(rule.MatchDimensions),
+			ToAccountId:     rule.ToAccountID,
+			SetDimensions:   DimensionsToProto(rule.SetDimensions),
+		}
+	}
+	lines := make([]*pb.ReclassLine, len(b.Lines))
+	for i, line := range b.Lines {
+		lines[i] = &pb.ReclassLine{
+			SourceTransactionId: line.SourceTransactionID,
+			SourceEntryId:       line.SourceEntryID,
+			Rule:                int32(line.Rule),
+			EntryType:           string(line.EntryType),
+			Amount:              line.Amount.Value,
+			Currency:            string(line.Amount.Currency),
+			FromAccountId:       line.FromAccountID,
+			FromDimensions:      DimensionsToProto(line.FromDimensions),
+			ToAccountId:         line.ToAccountID,
+			ToDimensions:        DimensionsToProto(line.ToDimensions),
+		}
+	}
+	return &pb.ReclassBatch{
+		Id:                     b.ID,
+		Description:            b.Description,
+		Start:                  timeToProto(b.Start),
+		End:                    timeToProto(b.End),
+		PostingDate:            timeToProto(b.PostingDate),
+		Rules:                  rules,
+		Status:                 string(b.Status),
+		Lines:                  lines,
+		TransactionIds:         b.TransactionIDs,
+		RollbackTransactionIds: b.RollbackTransactionIDs,
+		CreatedBy:              b.CreatedBy,
+		CreatedAt:              timeToProto(b.CreatedAt),
+		PostedBy:               b.PostedBy,
+		PostedAt:               optionalTimeToProto(b.PostedAt),
+		RolledBackBy:           b.RolledBackBy,
+		RolledBackAt:           optionalTimeToProto(b.RolledBackAt),
+	}
+}
+
+func ReclassBatchFromProto(pbBatch *pb.ReclassBatch) *ReclassBatch {
+	if pbBatch == nil {
+		return nil
+	}
+	rules := make([]ReclassRule, len(pbBatch.Rules))
+	for i, rule := range pbBatch.Rules {
+		rules[i] = ReclassRule{
+			FromAccountID:   rule.FromAccountId,
+			MatchDimensions: DimensionsFromProto(rule.MatchDimensions),
+			ToAccountID:     rule.ToAccountId,
+			SetDimensions:   DimensionsFromProto(rule.SetDimensions),
+		}
+	}
+	lines := make([]ReclassLine, len(pbBatch.Lines))
+	for i, line := range pbBatch.Lines {
+		lines[i] = ReclassLine{
+			SourceTransactionID: line.SourceTransactionId,
+			SourceEntryID:       line.SourceEntryId,
+			Rule:                int(line.Rule),
+			EntryType:           EntryType(line.EntryType),
+			Amount:              Amount{Value: line.Amount, Currency: Currency(line.Currency)},
+			FromAccountID:       line.FromAccountId,
+			FromDimensions:      DimensionsFromProto(line.FromDimensions),
+			ToAccountID:         line.ToAccountId,
+			ToDimensions:        DimensionsFromProto(line.ToDimensions),
+		}
+	}
+	return &ReclassBatch{
+		ID:                     pbBatch.Id,
+		Description:            pbBatch.Description,
+		Start:                  protoToTime(pbBatch.Start),
+		End:                    protoToTime(pbBatch.End),
+		PostingDate:            protoToTime(pbBatch.PostingDate),
+		Rules:                  rules,
+		Status:                 ReclassStatus(pbBatch.Status),
+		Lines:                  lines,
+		TransactionIDs:         pbBatch.TransactionIds,
+		RollbackTransactionIDs: pbBatch.RollbackTransactionIds,
+		CreatedBy:              pbBatch.CreatedBy,
+		CreatedAt:              protoToTime(pbBatch.CreatedAt),
+		PostedBy:               pbBatch.PostedBy,
+		PostedAt:               protoToOptionalTime(pbBatch.PostedAt),
+		RolledBackBy:           pbBatch.RolledBackBy,
+		RolledBackAt:           protoToOptionalTime(pbBatch.RolledBackAt),
+	}
+}
@@ -0,0 +1,109 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Recurring Transaction Conversions
+// ====================================================================================
+
+func (rt *RecurringTransactionTemplate) ToProto() *pb.RecurringTransactionTemplate {
+	if rt == nil {
+		return nil
+	}
+	lines := make([]*pb.RecurringLine, len(rt.Lines))
+	for i, line := range rt.Lines {
+		lines[i] = &pb.RecurringLine{
+			AccountId:  line.AccountID,
+			Type:       string(line.Type),
+			Amount:     line.Amount,
+			Dimensions: DimensionsToProto(line.Dimensions),
+		}
+	}
+	return &pb.RecurringTransactionTemplate{
+		Id:          rt.ID,
+		Name:        rt.Name,
+		Description: rt.Description,
+		Currency:    string(rt.Currency),
+		Lines:       lines,
+		Frequency:   string(rt.Frequency),
+		StartDate:   timeToProto(rt.StartDate),
+		EndDate:     optionalTimeToProto(rt.EndDate),
+		Proration:   string(rt.Proration),
+		NextRunDate: timeToProto(rt.NextRunDate),
+		RunCount:    int32(rt.RunCount),
+		Status:      string(rt.Status),
+		CreatedBy:   rt.CreatedBy,
+		CreatedAt:   timeToProto(rt.CreatedAt),
+		UpdatedAt:   timeToProto(rt.UpdatedAt),
+	}
+}
+
+func RecurringTransactionTemplateFromProto(pbTemplate *pb.RecurringTransactionTemplate) *RecurringTransactionTemplate {
+	if pbTemplate == nil {
+		return nil
+	}
+	lines := make([]RecurringLine, len(pbTemplate.Lines))
+	for i, line := range pbTemplate.Lines {
+		lines[i] = RecurringLine{
+			AccountID:  line.AccountId,
+			Type:       EntryType(line.Type),
+			Amount:     line.Amount,
+			Dimensions: DimensionsFromProto(line.Dimensions),
+		}
+	}
+	return &RecurringTransactionTemplate{
+		ID:          pbTemplate.Id,
+		Name:        pbTemplate.Name,
+		Description: pbTemplate.Description,
+		Currency:    Currency(pbTemplate.Currency),
+		Lines:       lines,
+		Frequency:   ScheduleFrequency(pbTemplate.Frequency),
+		StartDate:   protoToTime(pbTemplate.StartDate),
+		EndDate:     protoToOptionalTime(pbTemplate.EndDate),
+		Proration:   ProrationRule(pbTemplate.Proration),
+		NextRunDate: protoToTime(pbTemplate.NextRunDate),
+		RunCount:    int(pbTemplate.RunCount),
+		Status:      RecurringTemplateStatus(pbTemplate.Status),
+		CreatedBy:   pbTemplate.CreatedBy,
+		CreatedAt:   protoToTime(pbTemplate.CreatedAt),
+		UpdatedAt:   protoToTime(pbTemplate.UpdatedAt),
+	}
+}
+
+func (rr *RecurringRun) ToProto() *pb.RecurringRun {
+	if rr == nil {
+		return nil
+	}
+	return &pb.RecurringRun{
+		Id:            rr.ID,
+		TemplateId:    rr.TemplateID,
+		ScheduledDate: timeToProto(rr.ScheduledDate),
+		Status:        string(rr.Status),
+		TransactionId: rr.TransactionID,
+		Amount:        rr.Amount,
+		Reason:        rr.Reason,
+		Attempts:      int32(rr.Attempts),
+		RunBy:         rr.RunBy,
+		RunAt:         timeToProto(rr.RunAt),
+	}
+}
+
+func RecurringRunFromProto(pbRun *pb.RecurringRun) *RecurringRun {
+	if pbRun == nil {
+		return nil
+	}
+	return &RecurringRun{
+		ID:            pbRun.Id,
+		TemplateID:    pbRun.TemplateId,
+		ScheduledDate: protoToTime(pbRun.ScheduledDate),
+		Status:        RecurringRunStatus(pbRun.Status),
+		TransactionID: pbRun.TransactionId,
+		Amount:        pbRun.Amount,
+		Reason:        pbRun.Reason,
+		Attempts:      int(pbRun.Attempts),
+		RunBy:         pbRun.RunBy,
+		RunAt:         protoToTime(pbRun.RunAt),
+	}
+}
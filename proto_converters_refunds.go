@@ -0,0 +1,65 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Refund and Chargeback Conversions
+// ====================================================================================
+
+func (sr *SaleReversal) ToProto() *pb.SaleReversal {
+	if sr == nil {
+		return nil
+	}
+	return &pb.SaleReversal{
+		Id:                         sr.ID,
+		Type:                       string(sr.Type),
+		SaleTransactionId:          sr.SaleTransactionID,
+		Amount:                     sr.Amount.ToProto(),
+		SaleAmount:                 sr.SaleAmount.ToProto(),
+		Product:                    sr.Product,
+		Channel:                    sr.Channel,
+		Reason:                     sr.Reason,
+		ReasonCode:                 sr.ReasonCode,
+		Status:                     string(sr.Status),
+		ReversalDate:               timeToProto(sr.ReversalDate),
+		TransactionId:              sr.TransactionID,
+		FeeTransactionId:           sr.FeeTransactionID,
+		ReinstatementTransactionId: sr.ReinstatementTransactionID,
+		DisputeEvidence:            sr.DisputeEvidence,
+		ResolvedBy:                 sr.ResolvedBy,
+		ResolvedAt:                 optionalTimeToProto(sr.ResolvedAt),
+		CreatedBy:                  sr.CreatedBy,
+		CreatedAt:                  timeToProto(sr.CreatedAt),
+		UpdatedAt:                  timeToProto(sr.UpdatedAt),
+	}
+}
+
+func SaleReversalFromProto(pbReversal *pb.SaleReversal) *SaleReversal {
+	if pbReversal == nil {
+		return nil
+	}
+	return &SaleReversal{
+		ID:                         pbReversal.Id,
+		Type:                       SaleReversalType(pbReversal.Type),
+		SaleTransactionID:          pbReversal.SaleTransactionId,
+		Amount:                     AmountFromProto(pbReversal.Amount),
+		SaleAmount:                 AmountFromProto(pbReversal.SaleAmount),
+		Product:                    pbReversal.Product,
+		Channel:                    pbReversal.Channel,
+		Reason:                     pbReversal.Reason,
+		ReasonCode:                 pbReversal.ReasonCode,
+		Status:                     SaleReversalStatus(pbReversal.Status),
+		ReversalDate:               protoToTime(pbReversal.ReversalDate),
+		TransactionID:              pbReversal.TransactionId,
+		FeeTransactionID:           pbReversal.FeeTransactionId,
+		ReinstatementTransactionID: pbReversal.ReinstatementTransactionId,
+		DisputeEvidence:            pbReversal.DisputeEvidence,
+		ResolvedBy:                 pbReversal.ResolvedBy,
+		ResolvedAt:                 protoToOptionalTime(pbReversal.ResolvedAt),
+		CreatedBy:                  pbReversal.CreatedBy,
+		CreatedAt:                  protoToTime(pbReversal.CreatedAt),
+		UpdatedAt:                  protoToTime(pbReversal.UpdatedAt),
+	}
+}
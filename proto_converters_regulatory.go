@@ -0,0 +1,207 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Regulatory Return Conversions
+// ====================================================================================
+
+func (r *RegulatoryReturn) ToProto() *pb.RegulatoryReturn {
+	if r == nil {
+		return nil
+	}
+	lines := make([]*pb.RegulatoryLine, len(r.Lines))
+	for i, line := range r.Lines {
+		mappings := make([]*pb.RegulatoryMapping, len(line.Mappings))
+		for j, mapping := range line.Mappings {
+			mappings[j] = &pb.RegulatoryMapping{
+				AccountId:         mapping.AccountID,
+				AccountCodePrefix: mapping.AccountCodePrefix,
+				Negate:            mapping.Negate,
+			}
+		}
+		lines[i] = &pb.RegulatoryLine{
+			Code:        line.Code,
+			Description: line.Description,
+			Basis:       string(line.Basis),
+			Weight:      line.Weight,
+			Mappings:    mappings,
+		}
+	}
+	ratios := make([]*pb.RegulatoryRatio, len(r.Ratios))
+	for i, ratio := range r.Ratios {
+		ratios[i] = &pb.RegulatoryRatio{
+			Code:        ratio.Code,
+			Description: ratio.Description,
+			Numerator:   ratio.Numerator,
+			Denominator: ratio.Denominator,
+			Minimum:     ratio.Minimum,
+		}
+	}
+	coverage := make([]string, len(r.CoverageTypes))
+	for i, accountType := range r.CoverageTypes {
+		coverage[i] = string(accountType)
+	}
+	return &pb.RegulatoryReturn{
+		Id:            r.ID,
+		Name:          r.Name,
+		Regulator:     r.Regulator,
+		Currency:      string(r.Currency),
+		Lines:         lines,
+		Ratios:        ratios,
+		CoverageTypes: coverage,
+		CreatedBy:     r.CreatedBy,
+		CreatedAt:     timeToProto(r.CreatedAt),
+		UpdatedAt:     timeToProto(r.UpdatedAt),
+	}
+}
+
+func RegulatoryReturnFromProto(pbReturn *pb.RegulatoryReturn) *RegulatoryReturn {
+	if pbReturn == nil {
+		return nil
+	}
+	lines := make([]RegulatoryLine, len(pbReturn.Lines))
+	for i, line := range pbReturn.Lines {
+		mappings := make([]RegulatoryMapping, len(line.Mappings))
+		for j, mapping := range line.Mappings {
+			mappings[j] = RegulatoryMapping{
+				AccountID:         mapping.AccountId,
+				AccountCodePrefix: mapping.AccountCodePrefix,
+				Negate:            mapping.Negate,
+			}
+		}
+		lines[i] = RegulatoryLine{
+			Code:        line.Code,
+			Description: line.Description,
+			Basis:       RegulatoryBasis(line.Basis),
+			Weight:      line.Weight,
+			Mappings:    mappings,
+		}
+	}
+	ratios := make([]RegulatoryRatio, len(pbReturn.Ratios))
+	for i, ratio := range pbReturn.Ratios {
+		ratios[i] = RegulatoryRatio{
+			Code:        ratio.Code,
+			Description: ratio.Description,
+			Numerator:   ratio.Numerator,
+			Denominator: ratio.Denominator,
+			Minimum:     ratio.Minimum,
+		}
+	}
+	coverage := make([]AccountType, len(pbReturn.CoverageTypes))
+	for i, accountType := range pbReturn.CoverageTypes {
+		coverage[i] = AccountType(accountType)
+	}
+	return &RegulatoryReturn{
+		ID:            pbReturn.Id,
+		Name:          pbReturn.Name,
+		Regulator:     pbReturn.Regulator,
+		Currency:      Currency(pbReturn.Currency),
+		Lines:         lines,
+		Ratios:        ratios,
+		CoverageTypes: coverage,
+		CreatedBy:     pbReturn.CreatedBy,
+		CreatedAt:     protoToTime(pbReturn.CreatedAt),
+		UpdatedAt:     protoToTime(pbReturn.UpdatedAt),
+	}
+}
+
+func (d *RegulatoryReturnDataset) ToProto() *pb.RegulatoryReturnDataset {
+	if d == nil {
+		return nil
+	}
+	lines := make([]*pb.RegulatoryLineValue, len(d.Lines))
+	for i, line := range d.Lines {
+		accounts := make([]*pb.RegulatoryAccountValue, len(line.Accounts))
+		for j, account := range line.Accounts {
+			accounts[j] = &pb.RegulatoryAccountValue{
+				AccountId:   account.AccountID,
+				AccountCode: account.AccountCode,
+				Amount:      account.Amount,
+			}
+		}
+		lines[i] = &pb.RegulatoryLineValue{
+			Code:           line.Code,
+			Description:    line.Description,
+			Amount:         line.Amount,
+			Weight:         line.Weight,
+			WeightedAmount: line.WeightedAmount,
+			Accounts:       accounts,
+		}
+	}
+	ratios := make([]*pb.RegulatoryRatioValue, len(d.Ratios))
+	for i, ratio := range d.Ratios {
+		ratios[i] = &pb.RegulatoryRatioValue{
+			Code:        ratio.Code,
+			Numerator:   ratio.Numerator,
+			Denominator: ratio.Denominator,
+			Value:       ratio.Value,
+			Minimum:     ratio.Minimum,
+			Breach:      ratio.Breach,
+		}
+	}
+	return &pb.RegulatoryReturnDataset{
+		Id:               d.ID,
+		ReturnId:         d.ReturnID,
+		PeriodId:         d.PeriodID,
+		PeriodStart:      timeToProto(d.PeriodStart),
+		PeriodEnd:        timeToProto(d.PeriodEnd),
+		Currency:         string(d.Currency),
+		Lines:            lines,
+		Ratios:           ratios,
+		UnmappedAccounts: d.UnmappedAccounts,
+		GeneratedBy:      d.GeneratedBy,
+		GeneratedAt:      timeToProto(d.GeneratedAt),
+	}
+}
+
+func RegulatoryReturnDatasetFromProto(pbDataset *pb.RegulatoryReturnDataset) *RegulatoryReturnDataset {
+	if pbDataset == nil {
+		return nil
+	}
+	lines := make([]RegulatoryLineValue, len(pbDataset.Lines))
+	for i, line := range pbDataset.Lines {
+		accounts := make([]RegulatoryAccountValue, len(line.Accounts))
+		for j, account := range line.Accounts {
+			accounts[j] = RegulatoryAccountValue{
+				AccountID:   account.AccountId,
+				AccountCode: account.AccountCode,
+				Amount:      account.Amount,
+			}
+		}
+		lines[i] = RegulatoryLineValue{
+			Code:           line.Code,
+			Description:    line.Description,
+			Amount:         line.Amount,
+			Weight:         line.Weight,
+			WeightedAmount: line.WeightedAmount,
+			Accounts:       accounts,
+		}
+	}
+	ratios := make([]RegulatoryRatioValue, len(pbDataset.Ratios))
+	for i, ratio := range pbDataset.Ratios {
+		ratios[i] = RegulatoryRatioValue{
+			Code:        ratio.Code,
+			Numerator:   ratio.Numerator,
+			Denominator: ratio.Denominator,
+			Value:       ratio.Value,
+			Minimum:     ratio.Minimum,
+			Breach:      ratio.Breach,
+		}
+	}
+	return &RegulatoryReturnDataset{
+		ID:               pbDataset.Id,
+		ReturnID:         pbDataset.ReturnId,
+		PeriodID:         pbDataset.PeriodId,
+		PeriodStart:      protoToTime(pbDataset.PeriodStart),
+		PeriodEnd:        protoToTime(pbDataset.PeriodEnd),
+		Currency:         Currency(pbDataset.Currency),
+		Lines:            lines,
+		Ratios:           ratios,
+		UnmappedAccounts: pbDataset.UnmappedAccounts,
+		GeneratedBy:      pbDataset.GeneratedBy,
+		GeneratedAt:      protoToTime(pbDataset.GeneratedAt),
+	}
+}
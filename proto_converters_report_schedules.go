@@ -0,0 +1,89 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Report Schedule Conversions
+// ====================================================================================
+
+func (s *ReportSchedule) ToProto() *pb.ReportSchedule {
+	return &pb.ReportSchedule{
+		Id:         s.ID,
+		Name:       s.Name,
+		ReportType: string(s.ReportType),
+		Currency:   s.Parameters.Currency,
+		Period:     string(s.Parameters.Period),
+		Format:     string(s.Format),
+		OutputDir:  s.OutputDir,
+		Callback:   s.Callback,
+		Cron:       s.Cron,
+		Active:     s.Active,
+		NextRunAt:  timeToProto(s.NextRunAt),
+		LastRunAt:  optionalTimeToProto(s.LastRunAt),
+		CreatedBy:  s.CreatedBy,
+		CreatedAt:  timeToProto(s.CreatedAt),
+		UpdatedAt:  timeToProto(s.UpdatedAt),
+	}
+}
+
+func ReportScheduleFromProto(pbSchedule *pb.ReportSchedule) *ReportSchedule {
+	return &ReportSchedule{
+		ID:         pbSchedule.Id,
+		Name:       pbSchedule.Name,
+		ReportType: ScheduledReportType(pbSchedule.ReportType),
+		Parameters: ReportParameters{
+			Currency: pbSchedule.Currency,
+			Period:   ReportPeriod(pbSchedule.Period),
+		},
+		Format:    ExportFormat(pbSchedule.Format),
+		OutputDir: pbSchedule.OutputDir,
+		Callback:  pbSchedule.Callback,
+		Cron:      pbSchedule.Cron,
+		Active:    pbSchedule.Active,
+		NextRunAt: protoToTime(pbSchedule.NextRunAt),
+		LastRunAt: protoToOptionalTime(pbSchedule.LastRunAt),
+		CreatedBy: pbSchedule.CreatedBy,
+		CreatedAt: protoToTime(pbSchedule.CreatedAt),
+		UpdatedAt: protoToTime(pbSchedule.UpdatedAt),
+	}
+}
+
+// ====================================================================================
+// Report Run Conversions
+// ====================================================================================
+
+func (r *ReportRun) ToProto() *pb.ReportRun {
+	return &pb.ReportRun{
+		Id:           r.ID,
+		ScheduleId:   r.ScheduleID,
+		ScheduledFor: timeToProto(r.ScheduledFor),
+		PeriodStart:  timeToProto(r.PeriodStart),
+		PeriodEnd:    timeToProto(r.PeriodEnd),
+		Status:       string(r.Status),
+		OutputPath:   r.OutputPath,
+		Size:         r.Size,
+		Error:        r.Error,
+		RunBy:        r.RunBy,
+		StartedAt:    timeToProto(r.StartedAt),
+		FinishedAt:   timeToProto(r.FinishedAt),
+	}
+}
+
+func ReportRunFromProto(pbRun *pb.ReportRun) *ReportRun {
+	return &ReportRun{
+		ID:           pbRun.Id,
+		ScheduleID:   pbRun.ScheduleId,
+		ScheduledFor: protoToTime(pbRun.ScheduledFor),
+		PeriodStart:  protoToTime(pbRun.PeriodStart),
+		PeriodEnd:    protoToTime(pbRun.PeriodEnd),
+		Status:       ReportRunStatus(pbRun.Status),
+		OutputPath:   pbRun.OutputPath,
+		Size:         pbRun.Size,
+		Error:        pbRun.Error,
+		RunBy:        pbRun.RunBy,
+		StartedAt:    protoToTime(pbRun.StartedAt),
+		FinishedAt:   protoToTime(pbRun.FinishedAt),
+	}
+}
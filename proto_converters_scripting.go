@@ -0,0 +1,37 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Script Conversions
+// ====================================================================================
+
+func (s *Script) ToProto() *pb.Script {
+	return &pb.Script{
+		Id:        s.ID,
+		Name:      s.Name,
+		Hook:      string(s.Hook),
+		Source:    s.Source,
+		Active:    s.Active,
+		MaxSteps:  s.MaxSteps,
+		CreatedBy: s.CreatedBy,
+		CreatedAt: timeToProto(s.CreatedAt),
+		UpdatedAt: timeToProto(s.UpdatedAt),
+	}
+}
+
+func ScriptFromProto(pbScript *pb.Script) *Script {
+	return &Script{
+		ID:        pbScript.Id,
+		Name:      pbScript.Name,
+		Hook:      ScriptHook(pbScript.Hook),
+		Source:    pbScript.Source,
+		Active:    pbScript.Active,
+		MaxSteps:  pbScript.MaxSteps,
+		CreatedBy: pbScript.CreatedBy,
+		CreatedAt: protoToTime(pbScript.CreatedAt),
+		UpdatedAt: protoToTime(pbScript.UpdatedAt),
+	}
+}
@@ -0,0 +1,63 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Operating Segment Conversions
+// ====================================================================================
+
+func (s *OperatingSegment) ToProto() *pb.OperatingSegment {
+	return &pb.OperatingSegment{
+		Id:              s.ID,
+		Name:            s.Name,
+		Description:     s.Description,
+		DimensionKey:    string(s.DimensionKey),
+		DimensionValues: s.DimensionValues,
+		CompanyIds:      s.CompanyIDs,
+		CreatedBy:       s.CreatedBy,
+		CreatedAt:       timeToProto(s.CreatedAt),
+	}
+}
+
+func OperatingSegmentFromProto(pbSegment *pb.OperatingSegment) *OperatingSegment {
+	return &OperatingSegment{
+		ID:              pbSegment.Id,
+		Name:            pbSegment.Name,
+		Description:     pbSegment.Description,
+		DimensionKey:    DimensionKey(pbSegment.DimensionKey),
+		DimensionValues: pbSegment.DimensionValues,
+		CompanyIDs:      pbSegment.CompanyIds,
+		CreatedBy:       pbSegment.CreatedBy,
+		CreatedAt:       protoToTime(pbSegment.CreatedAt),
+	}
+}
+
+// ====================================================================================
+// Segment Allocation Rule Conversions
+// ====================================================================================
+
+func (r *SegmentAllocationRule) ToProto() *pb.SegmentAllocationRule {
+	return &pb.SegmentAllocationRule{
+		Id:         r.ID,
+		Name:       r.Name,
+		AccountIds: r.AccountIDs,
+		Basis:      string(r.Basis),
+		Shares:     r.Shares,
+		CreatedBy:  r.CreatedBy,
+		CreatedAt:  timeToProto(r.CreatedAt),
+	}
+}
+
+func SegmentAllocationRuleFromProto(pbRule *pb.SegmentAllocationRule) *SegmentAllocationRule {
+	return &SegmentAllocationRule{
+		ID:         pbRule.Id,
+		Name:       pbRule.Name,
+		AccountIDs: pbRule.AccountIds,
+		Basis:      SegmentAllocationBasis(pbRule.Basis),
+		Shares:     pbRule.Shares,
+		CreatedBy:  pbRule.CreatedBy,
+		CreatedAt:  protoToTime(pbRule.CreatedAt),
+	}
+}
@@ -0,0 +1,121 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// SOX Control Conversions
+// ====================================================================================
+
+func (c *SOXControl) ToProto() *pb.SOXControl {
+	if c == nil {
+		return nil
+	}
+	return &pb.SOXControl{
+		Id:          c.ID,
+		Objective:   c.Objective,
+		Description: c.Description,
+		Frequency:   string(c.Frequency),
+		Owner:       c.Owner,
+		TestType:    string(c.TestType),
+		Threshold:   c.Threshold.ToProto(),
+		Active:      c.Active,
+		NextRunAt:   timeToProto(c.NextRunAt),
+		LastRunAt:   optionalTimeToProto(c.LastRunAt),
+		LastStatus:  string(c.LastStatus),
+		CreatedBy:   c.CreatedBy,
+		CreatedAt:   timeToProto(c.CreatedAt),
+		UpdatedAt:   timeToProto(c.UpdatedAt),
+	}
+}
+
+func SOXControlFromProto(pbControl *pb.SOXControl) *SOXControl {
+	if pbControl == nil {
+		return nil
+	}
+	return &SOXControl{
+		ID:          pbControl.Id,
+		Objective:   pbControl.Objective,
+		Description: pbControl.Description,
+		Frequency:   ControlFrequency(pbControl.Frequency),
+		Owner:       pbControl.Owner,
+		TestType:    ControlTestType(pbControl.TestType),
+		Threshold:   AmountFromProto(pbControl.Threshold),
+		Active:      pbControl.Active,
+		NextRunAt:   protoToTime(pbControl.NextRunAt),
+		LastRunAt:   protoToOptionalTime(pbControl.LastRunAt),
+		LastStatus:  ControlTestStatus(pbControl.LastStatus),
+		CreatedBy:   pbControl.CreatedBy,
+		CreatedAt:   protoToTime(pbControl.CreatedAt),
+		UpdatedAt:   protoToTime(pbControl.UpdatedAt),
+	}
+}
+
+func (r *ControlTestResult) ToProto() *pb.ControlTestResult {
+	if r == nil {
+		return nil
+	}
+	exceptions := make([]*pb.ControlException, len(r.Exceptions))
+	for i, exception := range r.Exceptions {
+		exceptions[i] = &pb.ControlException{
+			TransactionId: exception.TransactionID,
+			Description:   exception.Description,
+			UserId:        exception.UserID,
+			Amount:        exception.Amount.ToProto(),
+		}
+	}
+	return &pb.ControlTestResult{
+		Id:                   r.ID,
+		ControlId:            r.ControlID,
+		TestType:             string(r.TestType),
+		PeriodStart:          timeToProto(r.PeriodStart),
+		PeriodEnd:            timeToProto(r.PeriodEnd),
+		Status:               string(r.Status),
+		Population:           int32(r.Population),
+		TestedTransactionIds: r.TestedTransactionIDs,
+		Exceptions:           exceptions,
+		Notes:                r.Notes,
+		Error:                r.Error,
+		Checksum:             r.Checksum,
+		Scheduled:            r.Scheduled,
+		Attachments:          r.Attachments,
+		RunBy:                r.RunBy,
+		StartedAt:            timeToProto(r.StartedAt),
+		FinishedAt:           timeToProto(r.FinishedAt),
+	}
+}
+
+func ControlTestResultFromProto(pbResult *pb.ControlTestResult) *ControlTestResult {
+	if pbResult == nil {
+		return nil
+	}
+	var exceptions []ControlException
+	for _, exception := range pbResult.Exceptions {
+		exceptions = append(exceptions, ControlException{
+			TransactionID: exception.TransactionId,
+			Description:   exception.Description,
+			UserID:        exception.UserId,
+			Amount:        AmountFromProto(exception.Amount),
+		})
+	}
+	return &ControlTestResult{
+		ID:                   pbResult.Id,
+		ControlID:            pbResult.ControlId,
+		TestType:             ControlTestType(pbResult.TestType),
+		PeriodStart:          protoToTime(pbResult.PeriodStart),
+		PeriodEnd:            protoToTime(pbResult.PeriodEnd),
+		Status:               ControlTestStatus(pbResult.Status),
+		Population:           int(pbResult.Population),
+		TestedTransactionIDs: pbResult.TestedTransactionIds,
+		Exceptions:           exceptions,
+		Notes:                pbResult.Notes,
+		Error:                pbResult.Error,
+		Checksum:             pbResult.Checksum,
+		Scheduled:            pbResult.Scheduled,
+		Attachments:          pbResult.Attachments,
+		RunBy:                pbResult.RunBy,
+		StartedAt:            protoToTime(pbResult.StartedAt),
+		FinishedAt:           protoToTime(pbResult.FinishedAt),
+	}
+}
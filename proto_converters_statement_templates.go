@@ -0,0 +1,73 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Statement Template Conversions
+// ====================================================================================
+
+func (t *StatementTemplate) ToProto() *pb.StatementTemplate {
+	lines := make([]*pb.StatementTemplateLine, len(t.Lines))
+	for i, line := range t.Lines {
+		accountTypes := make([]string, len(line.AccountTypes))
+		for j, accountType := range line.AccountTypes {
+			accountTypes[j] = string(accountType)
+		}
+		lines[i] = &pb.StatementTemplateLine{
+			Key:          line.Key,
+			Label:        line.Label,
+			Level:        int32(line.Level),
+			AccountTypes: accountTypes,
+			AccountIds:   line.AccountIDs,
+			CodeFrom:     line.CodeFrom,
+			CodeTo:       line.CodeTo,
+			Tags:         DimensionsToProto(line.Tags),
+			Formula:      line.Formula,
+			Negate:       line.Negate,
+			ShowAccounts: line.ShowAccounts,
+		}
+	}
+	return &pb.StatementTemplate{
+		Id:        t.ID,
+		Name:      t.Name,
+		Basis:     string(t.Basis),
+		Lines:     lines,
+		CreatedBy: t.CreatedBy,
+		CreatedAt: timeToProto(t.CreatedAt),
+		UpdatedAt: timeToProto(t.UpdatedAt),
+	}
+}
+
+func StatementTemplateFromProto(pbTemplate *pb.StatementTemplate) *StatementTemplate {
+	lines := make([]StatementTemplateLine, len(pbTemplate.Lines))
+	for i, line := range pbTemplate.Lines {
+		var accountTypes []AccountType
+		for _, accountType := range line.AccountTypes {
+			accountTypes = append(accountTypes, AccountType(accountType))
+		}
+		lines[i] = StatementTemplateLine{
+			Key:          line.Key,
+			Label:        line.Label,
+			Level:        int(line.Level),
+			AccountTypes: accountTypes,
+			AccountIDs:   line.AccountIds,
+			CodeFrom:     line.CodeFrom,
+			CodeTo:       line.CodeTo,
+			Tags:         DimensionsFromProto(line.Tags),
+			Formula:      line.Formula,
+			Negate:       line.Negate,
+			ShowAccounts: line.ShowAccounts,
+		}
+	}
+	return &StatementTemplate{
+		ID:        pbTemplate.Id,
+		Name:      pbTemplate.Name,
+		Basis:     StatementBasis(pbTemplate.Basis),
+		Lines:     lines,
+		CreatedBy: pbTemplate.CreatedBy,
+		CreatedAt: protoToTime(pbTemplate.CreatedAt),
+		UpdatedAt: protoToTime(pbTemplate.UpdatedAt),
+	}
+}
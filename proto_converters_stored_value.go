@@ -0,0 +1,113 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Stored Value Conversions
+// ====================================================================================
+
+func (sp *StoredValueProgram) ToProto() *pb.StoredValueProgram {
+	if sp == nil {
+		return nil
+	}
+	return &pb.StoredValueProgram{
+		Id:                      sp.ID,
+		Name:                    sp.Name,
+		Currency:                string(sp.Currency),
+		LiabilityAccountId:      sp.LiabilityAccountID,
+		RevenueAccountId:        sp.RevenueAccountID,
+		BreakageIncomeAccountId: sp.BreakageIncomeAccountID,
+		BreakageModel:           string(sp.BreakageModel),
+		EstimatedBreakageRate:   sp.EstimatedBreakageRate,
+		RemoteAfterMonths:       int32(sp.RemoteAfterMonths),
+		ValidityMonths:          int32(sp.ValidityMonths),
+		CreatedBy:               sp.CreatedBy,
+		CreatedAt:               timeToProto(sp.CreatedAt),
+	}
+}
+
+func StoredValueProgramFromProto(pbProgram *pb.StoredValueProgram) *StoredValueProgram {
+	if pbProgram == nil {
+		return nil
+	}
+	return &StoredValueProgram{
+		ID:                      pbProgram.Id,
+		Name:                    pbProgram.Name,
+		Currency:                Currency(pbProgram.Currency),
+		LiabilityAccountID:      pbProgram.LiabilityAccountId,
+		RevenueAccountID:        pbProgram.RevenueAccountId,
+		BreakageIncomeAccountID: pbProgram.BreakageIncomeAccountId,
+		BreakageModel:           BreakageModel(pbProgram.BreakageModel),
+		EstimatedBreakageRate:   pbProgram.EstimatedBreakageRate,
+		RemoteAfterMonths:       int(pbProgram.RemoteAfterMonths),
+		ValidityMonths:          int(pbProgram.ValidityMonths),
+		CreatedBy:               pbProgram.CreatedBy,
+		CreatedAt:               protoToTime(pbProgram.CreatedAt),
+	}
+}
+
+func (gc *GiftCard) ToProto() *pb.GiftCard {
+	if gc == nil {
+		return nil
+	}
+	activity := make([]*pb.StoredValueActivity, len(gc.Activity))
+	for i, a := range gc.Activity {
+		activity[i] = &pb.StoredValueActivity{
+			Type:          string(a.Type),
+			Amount:        a.Amount,
+			Date:          timeToProto(a.Date),
+			TransactionId: a.TransactionID,
+		}
+	}
+	return &pb.GiftCard{
+		Id:                 gc.ID,
+		ProgramId:          gc.ProgramID,
+		Code:               gc.Code,
+		CustomerId:         gc.CustomerID,
+		InitialValue:       gc.InitialValue,
+		Balance:            gc.Balance,
+		Redeemed:           gc.Redeemed,
+		BreakageRecognized: gc.BreakageRecognized,
+		IssuedAt:           timeToProto(gc.IssuedAt),
+		ExpiresAt:          optionalTimeToProto(gc.ExpiresAt),
+		LastActivityAt:     timeToProto(gc.LastActivityAt),
+		Status:             string(gc.Status),
+		Activity:           activity,
+		CreatedBy:          gc.CreatedBy,
+		UpdatedAt:          timeToProto(gc.UpdatedAt),
+	}
+}
+
+func GiftCardFromProto(pbCard *pb.GiftCard) *GiftCard {
+	if pbCard == nil {
+		return nil
+	}
+	activity := make([]StoredValueActivity, len(pbCard.Activity))
+	for i, a := range pbCard.Activity {
+		activity[i] = StoredValueActivity{
+			Type:          StoredValueActivityType(a.Type),
+			Amount:        a.Amount,
+			Date:          protoToTime(a.Date),
+			TransactionID: a.TransactionId,
+		}
+	}
+	return &GiftCard{
+		ID:                 pbCard.Id,
+		ProgramID:          pbCard.ProgramId,
+		Code:               pbCard.Code,
+		CustomerID:         pbCard.CustomerId,
+		InitialValue:       pbCard.InitialValue,
+		Balance:            pbCard.Balance,
+		Redeemed:           pbCard.Redeemed,
+		BreakageRecognized: pbCard.BreakageRecognized,
+		IssuedAt:           protoToTime(pbCard.IssuedAt),
+		ExpiresAt:          protoToOptionalTime(pbCard.ExpiresAt),
+		LastActivityAt:     protoToTime(pbCard.LastActivityAt),
+		Status:             GiftCardStatus(pbCard.Status),
+		Activity:           activity,
+		CreatedBy:          pbCard.CreatedBy,
+		UpdatedAt:          protoToTime(pbCard.UpdatedAt),
+	}
+}
@@ -0,0 +1,106 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Tax Rate Conversions
+// ====================================================================================
+
+// taxJurisdictionsToProto maps tax jurisdictions to their proto enum
+var taxJurisdictionsToProto = map[TaxJurisdiction]pb.TaxJurisdiction{
+	US_FEDERAL: pb.TaxJurisdiction_TAX_JURISDICTION_US_FEDERAL,
+	US_STATE:   pb.TaxJurisdiction_TAX_JURISDICTION_US_STATE,
+	EU_VAT:     pb.TaxJurisdiction_TAX_JURISDICTION_EU_VAT,
+	UK_VAT:     pb.TaxJurisdiction_TAX_JURISDICTION_UK_VAT,
+	CANADA_GST: pb.TaxJurisdiction_TAX_JURISDICTION_CANADA_GST,
+	AUSTRALIA:  pb.TaxJurisdiction_TAX_JURISDICTION_AUSTRALIA,
+}
+
+func taxBracketsToProto(brackets []TaxBracket) []*pb.TaxBracket {
+	if len(brackets) == 0 {
+		return nil
+	}
+	result := make([]*pb.TaxBracket, len(brackets))
+	for i, bracket := range brackets {
+		result[i] = &pb.TaxBracket{From: bracket.From, To: bracket.To, Rate: bracket.Rate}
+	}
+	return result
+}
+
+func taxBracketsFromProto(pbBrackets []*pb.TaxBracket) []TaxBracket {
+	if len(pbBrackets) == 0 {
+		return nil
+	}
+	result := make([]TaxBracket, len(pbBrackets))
+	for i, bracket := range pbBrackets {
+		result[i] = TaxBracket{From: bracket.From, To: bracket.To, Rate: bracket.Rate}
+	}
+	return result
+}
+
+func taxComponentsToProto(components []TaxComponent) []*pb.TaxComponent {
+	if len(components) == 0 {
+		return nil
+	}
+	result := make([]*pb.TaxComponent, len(components))
+	for i, component := range components {
+		result[i] = &pb.TaxComponent{
+			RuleId:        component.RuleID,
+			Name:          component.Name,
+			Compound:      component.Compound,
+			TaxableAmount: component.TaxableAmount,
+			TaxRate:       component.TaxRate,
+			TaxAmount:     component.TaxAmount,
+			Exemptions:    component.Exemptions,
+		}
+	}
+	return result
+}
+
+func taxComponentsFromProto(pbComponents []*pb.TaxComponent) []TaxComponent {
+	if len(pbComponents) == 0 {
+		return nil
+	}
+	result := make([]TaxComponent, len(pbComponents))
+	for i, component := range pbComponents {
+		result[i] = TaxComponent{
+			RuleID:        component.RuleId,
+			Name:          component.Name,
+			Compound:      component.Compound,
+			TaxableAmount: component.TaxableAmount,
+			TaxRate:       component.TaxRate,
+			TaxAmount:     component.TaxAmount,
+			Exemptions:    component.Exemptions,
+		}
+	}
+	return result
+}
+
+func (r *TaxRounding) ToProto() *pb.TaxRounding {
+	if r == nil {
+		return nil
+	}
+	return &pb.TaxRounding{
+		Jurisdiction: taxJurisdictionsToProto[r.Jurisdiction],
+		Policy:       string(r.Policy),
+		Level:        string(r.Level),
+	}
+}
+
+func TaxRoundingFromProto(pbRounding *pb.TaxRounding) *TaxRounding {
+	if pbRounding == nil {
+		return nil
+	}
+	rounding := &TaxRounding{
+		Policy: RoundingPolicy(pbRounding.Policy),
+		Level:  TaxRoundingLevel(pbRounding.Level),
+	}
+	for jurisdiction, pbJurisdiction := range taxJurisdictionsToProto {
+		if pbJurisdiction == pbRounding.Jurisdiction {
+			rounding.Jurisdiction = jurisdiction
+		}
+	}
+	return rounding
+}
@@ -0,0 +1,113 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Tax Line Conversions
+// ====================================================================================
+
+// taxTypesToProto maps tax types to their proto enum
+var taxTypesToProto = map[TaxType]pb.TaxType{
+	INCOME_TAX:   pb.TaxType_TAX_TYPE_INCOME_TAX,
+	SALES_TAX:    pb.TaxType_TAX_TYPE_SALES_TAX,
+	VAT:          pb.TaxType_TAX_TYPE_VAT,
+	GST:          pb.TaxType_TAX_TYPE_GST,
+	PAYROLL_TAX:  pb.TaxType_TAX_TYPE_PAYROLL_TAX,
+	PROPERTY_TAX: pb.TaxType_TAX_TYPE_PROPERTY_TAX,
+	WITHHOLDING:  pb.TaxType_TAX_TYPE_WITHHOLDING,
+}
+
+func (r *TaxLineRule) ToProto() *pb.TaxLineRule {
+	if r == nil {
+		return nil
+	}
+	return &pb.TaxLineRule{
+		Id:                  r.ID,
+		Name:                r.Name,
+		AccountId:           r.AccountID,
+		Dimension:           r.Dimension.ToProto(),
+		Jurisdiction:        taxJurisdictionsToProto[r.Jurisdiction],
+		TaxType:             taxTypesToProto[r.TaxType],
+		OutputTaxAccountId:  r.OutputTaxAccountID,
+		InputTaxAccountId:   r.InputTaxAccountID,
+		ReceivableAccountId: r.ReceivableAccountID,
+		PayableAccountId:    r.PayableAccountID,
+		Active:              r.Active,
+		CreatedBy:           r.CreatedBy,
+		CreatedAt:           timeToProto(r.CreatedAt),
+	}
+}
+
+func TaxLineRuleFromProto(pbRule *pb.TaxLineRule) *TaxLineRule {
+	if pbRule == nil {
+		return nil
+	}
+	rule := &TaxLineRule{
+		ID:                  pbRule.Id,
+		Name:                pbRule.Name,
+		AccountID:           pbRule.AccountId,
+		Dimension:           DimensionFromProto(pbRule.Dimension),
+		OutputTaxAccountID:  pbRule.OutputTaxAccountId,
+		InputTaxAccountID:   pbRule.InputTaxAccountId,
+		ReceivableAccountID: pbRule.ReceivableAccountId,
+		PayableAccountID:    pbRule.PayableAccountId,
+		Active:              pbRule.Active,
+		CreatedBy:           pbRule.CreatedBy,
+		CreatedAt:           protoToTime(pbRule.CreatedAt),
+	}
+	for jurisdiction, pbJurisdiction := range taxJurisdictionsToProto {
+		if pbJurisdiction == pbRule.Jurisdiction {
+			rule.Jurisdiction = jurisdiction
+		}
+	}
+	for taxType, pbType := range taxTypesToProto {
+		if pbType == pbRule.TaxType {
+			rule.TaxType = taxType
+		}
+	}
+	return rule
+}
+
+func (tl *TransactionTaxLines) ToProto() *pb.TransactionTaxLines {
+	if tl == nil {
+		return nil
+	}
+	lines := make([]*pb.TaxLine, len(tl.Lines))
+	for i, line := range tl.Lines {
+		lines[i] = &pb.TaxLine{
+			RuleId:         line.RuleID,
+			TaxableEntryId: line.TaxableEntryID,
+			TaxEntryId:     line.TaxEntryID,
+			OffsetEntryId:  line.OffsetEntryID,
+			Calculation:    line.Calculation.ToProto(),
+		}
+	}
+	return &pb.TransactionTaxLines{
+		TransactionId: tl.TransactionID,
+		Lines:         lines,
+		CreatedAt:     timeToProto(tl.CreatedAt),
+	}
+}
+
+func TransactionTaxLinesFromProto(pbLines *pb.TransactionTaxLines) *TransactionTaxLines {
+	if pbLines == nil {
+		return nil
+	}
+	lines := make([]*TaxLine, len(pbLines.Lines))
+	for i, line := range pbLines.Lines {
+		lines[i] = &TaxLine{
+			RuleID:         line.RuleId,
+			TaxableEntryID: line.TaxableEntryId,
+			TaxEntryID:     line.TaxEntryId,
+			OffsetEntryID:  line.OffsetEntryId,
+			Calculation:    TaxCalculationFromProto(line.Calculation),
+		}
+	}
+	return &TransactionTaxLines{
+		TransactionID: pbLines.TransactionId,
+		Lines:         lines,
+		CreatedAt:     protoToTime(pbLines.CreatedAt),
+	}
+}
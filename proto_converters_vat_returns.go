@@ -0,0 +1,82 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// VAT Return Conversions
+// ====================================================================================
+
+func (m *VATReturnMapping) ToProto() *pb.VATReturnMapping {
+	if m == nil {
+		return nil
+	}
+	boxes := make([]*pb.VATBoxMapping, len(m.Boxes))
+	for i, box := range m.Boxes {
+		boxes[i] = &pb.VATBoxMapping{
+			Box:           box.Box,
+			AccountId:     box.AccountID,
+			TaxLineRuleId: box.TaxLineRuleID,
+			Negate:        box.Negate,
+		}
+	}
+	return &pb.VATReturnMapping{
+		Id:           m.ID,
+		Name:         m.Name,
+		Format:       string(m.Format),
+		Jurisdiction: taxJurisdictionsToProto[m.Jurisdiction],
+		Currency:     string(m.Currency),
+		Boxes:        boxes,
+		CreatedBy:    m.CreatedBy,
+		CreatedAt:    timeToProto(m.CreatedAt),
+		UpdatedAt:    timeToProto(m.UpdatedAt),
+	}
+}
+
+func VATReturnMappingFromProto(pbMapping *pb.VATReturnMapping) *VATReturnMapping {
+	if pbMapping == nil {
+		return nil
+	}
+	boxes := make([]VATBoxMapping, len(pbMapping.Boxes))
+	for i, box := range pbMapping.Boxes {
+		boxes[i] = VATBoxMapping{
+			Box:           box.Box,
+			AccountID:     box.AccountId,
+			TaxLineRuleID: box.TaxLineRuleId,
+			Negate:        box.Negate,
+		}
+	}
+	mapping := &VATReturnMapping{
+		ID:        pbMapping.Id,
+		Name:      pbMapping.Name,
+		Format:    VATReturnFormat(pbMapping.Format),
+		Currency:  Currency(pbMapping.Currency),
+		Boxes:     boxes,
+		CreatedBy: pbMapping.CreatedBy,
+		CreatedAt: protoToTime(pbMapping.CreatedAt),
+		UpdatedAt: protoToTime(pbMapping.UpdatedAt),
+	}
+	for jurisdiction, pbJurisdiction := range taxJurisdictionsToProto {
+		if pbJurisdiction == pbMapping.Jurisdiction {
+			mapping.Jurisdiction = jurisdiction
+		}
+	}
+	return mapping
+}
+
+func vatBoxValuesToProto(boxes []VATBoxValue) []*pb.VATBoxValue {
+	var pbBoxes []*pb.VATBoxValue
+	for _, box := range boxes {
+		pbBoxes = append(pbBoxes, &pb.VATBoxValue{Box: box.Box, Description: box.Description, Amount: box.Amount})
+	}
+	return pbBoxes
+}
+
+func vatBoxValuesFromProto(pbBoxes []*pb.VATBoxValue) []VATBoxValue {
+	var boxes []VATBoxValue
+	for _, box := range pbBoxes {
+		boxes = append(boxes, VATBoxValue{Box: box.Box, Description: box.Description, Amount: box.Amount})
+	}
+	return boxes
+}
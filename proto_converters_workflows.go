@@ -0,0 +1,73 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Workflow Conversions
+// ====================================================================================
+
+func (e *WorkflowHistoryEntry) ToProto() *pb.WorkflowHistoryEntry {
+	return &pb.WorkflowHistoryEntry{
+		Action:  e.Action,
+		From:    e.From,
+		To:      e.To,
+		Actor:   e.Actor,
+		Comment: e.Comment,
+		At:      timeToProto(e.At),
+	}
+}
+
+func WorkflowHistoryEntryFromProto(pbEntry *pb.WorkflowHistoryEntry) WorkflowHistoryEntry {
+	return WorkflowHistoryEntry{
+		Action:  pbEntry.Action,
+		From:    pbEntry.From,
+		To:      pbEntry.To,
+		Actor:   pbEntry.Actor,
+		Comment: pbEntry.Comment,
+		At:      protoToTime(pbEntry.At),
+	}
+}
+
+func (wi *WorkflowInstance) ToProto() *pb.WorkflowInstance {
+	history := make([]*pb.WorkflowHistoryEntry, len(wi.History))
+	for i := range wi.History {
+		history[i] = wi.History[i].ToProto()
+	}
+	return &pb.WorkflowInstance{
+		Id:        wi.ID,
+		Workflow:  wi.Workflow,
+		SubjectId: wi.SubjectID,
+		State:     wi.State,
+		Assignees: wi.Assignees,
+		EnteredAt: timeToProto(wi.EnteredAt),
+		DueAt:     optionalTimeToProto(wi.DueAt),
+		DueAction: wi.DueAction,
+		History:   history,
+		CreatedAt: timeToProto(wi.CreatedAt),
+		UpdatedAt: timeToProto(wi.UpdatedAt),
+		Version:   wi.Version,
+	}
+}
+
+func WorkflowInstanceFromProto(pbInstance *pb.WorkflowInstance) *WorkflowInstance {
+	history := make([]WorkflowHistoryEntry, len(pbInstance.History))
+	for i, entry := range pbInstance.History {
+		history[i] = WorkflowHistoryEntryFromProto(entry)
+	}
+	return &WorkflowInstance{
+		ID:        pbInstance.Id,
+		Workflow:  pbInstance.Workflow,
+		SubjectID: pbInstance.SubjectId,
+		State:     pbInstance.State,
+		Assignees: pbInstance.Assignees,
+		EnteredAt: protoToTime(pbInstance.EnteredAt),
+		DueAt:     protoToOptionalTime(pbInstance.DueAt),
+		DueAction: pbInstance.DueAction,
+		History:   history,
+		CreatedAt: protoToTime(pbInstance.CreatedAt),
+		UpdatedAt: protoToTime(pbInstance.UpdatedAt),
+		Version:   pbInstance.Version,
+	}
+}
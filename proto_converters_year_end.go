@@ -0,0 +1,105 @@
+package accounting
+
+import (
+	pb "accounting/proto/accounting"
+)
+
+// ====================================================================================
+// Year-End Close Conversions
+// ====================================================================================
+
+func (f *FiscalYearClose) ToProto() *pb.FiscalYearClose {
+	if f == nil {
+		return nil
+	}
+	keys := make([]string, len(f.DimensionKeys))
+	for i, key := range f.DimensionKeys {
+		keys[i] = string(key)
+	}
+	lines := make([]*pb.FiscalYearCloseLine, len(f.Lines))
+	for i, line := range f.Lines {
+		lines[i] = &pb.FiscalYearCloseLine{
+			AccountId:   line.AccountID,
+			AccountName: line.AccountName,
+			AccountType: string(line.AccountType),
+			Dimensions:  DimensionsToProto(line.Dimensions),
+			Currency:    string(line.Currency),
+			Amount:      line.Amount,
+		}
+	}
+	totals := make([]*pb.FiscalYearCloseTotal, len(f.Totals))
+	for i, total := range f.Totals {
+		totals[i] = &pb.FiscalYearCloseTotal{
+			Currency:  string(total.Currency),
+			Income:    total.Income,
+			Expenses:  total.Expenses,
+			NetIncome: total.NetIncome,
+		}
+	}
+	return &pb.FiscalYearClose{
+		Id:                        f.ID,
+		Name:                      f.Name,
+		Start:                     timeToProto(f.Start),
+		End:                       timeToProto(f.End),
+		RetainedEarningsAccountId: f.RetainedEarningsAccountID,
+		DimensionKeys:             keys,
+		Status:                    string(f.Status),
+		Lines:                     lines,
+		Totals:                    totals,
+		TransactionIds:            f.TransactionIDs,
+		ReversalTransactionIds:    f.ReversalTransactionIDs,
+		ClosedBy:                  f.ClosedBy,
+		ClosedAt:                  timeToProto(f.ClosedAt),
+		ReopenedBy:                f.ReopenedBy,
+		ReopenedAt:                optionalTimeToProto(f.ReopenedAt),
+		ReopenReason:              f.ReopenReason,
+	}
+}
+
+func FiscalYearCloseFromProto(pbClose *pb.FiscalYearClose) *FiscalYearClose {
+	if pbClose == nil {
+		return nil
+	}
+	keys := make([]DimensionKey, len(pbClose.DimensionKeys))
+	for i, key := range pbClose.DimensionKeys {
+		keys[i] = DimensionKey(key)
+	}
+	lines := make([]FiscalYearCloseLine, len(pbClose.Lines))
+	for i, line := range pbClose.Lines {
+		lines[i] = FiscalYearCloseLine{
+			AccountID:   line.AccountId,
+			AccountName: line.AccountName,
+			AccountType: AccountType(line.AccountType),
+			Dimensions:  DimensionsFromProto(line.Dimensions),
+			Currency:    Currency(line.Currency),
+			Amount:      line.Amount,
+		}
+	}
+	totals := make([]FiscalYearCloseTotal, len(pbClose.Totals))
+	for i, total := range pbClose.Totals {
+		totals[i] = FiscalYearCloseTotal{
+			Currency:  Currency(total.Currency),
+			Income:    total.Income,
+			Expenses:  total.Expenses,
+			NetIncome: total.NetIncome,
+		}
+	}
+	return &FiscalYearClose{
+		ID:                        pbClose.Id,
+		Name:                      pbClose.Name,
+		Start:                     protoToTime(pbClose.Start),
+		End:                       protoToTime(pbClose.End),
+		RetainedEarningsAccountID: pbClose.RetainedEarningsAccountId,
+		DimensionKeys:             keys,
+		Status:                    FiscalYearStatus(pbClose.Status),
+		Lines:                     lines,
+		Totals:                    totals,
+		TransactionIDs:            pbClose.TransactionIds,
+		ReversalTransactionIDs:    pbClose.ReversalTransactionIds,
+		ClosedBy:                  pbClose.ClosedBy,
+		ClosedAt:                  protoToTime(pbClose.ClosedAt),
+		ReopenedBy:                pbClose.ReopenedBy,
+		ReopenedAt:                protoToOptionalTime(pbClose.ReopenedAt),
+		ReopenReason:              pbClose.ReopenReason,
+	}
+}
@@ -0,0 +1,57 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+
+	pb "accounting/proto/accounting"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// protoPackage is the protobuf package the storage messages are declared in
+var protoPackage = pb.File_proto_accounting_accounting_proto.Package()
+
+// ProtoDescriptorSet returns a serialized FileDescriptorSet of every accounting
+// proto file and the files they import, dependencies first, as
+// protoc --include_imports --descriptor_set_out would write it. Client libraries in
+// other languages can be generated from it without the .proto sources.
+func ProtoDescriptorSet() ([]byte, error) {
+	var files []protoreflect.FileDescriptor
+	protoregistry.GlobalFiles.RangeFilesByPackage(protoPackage, func(file protoreflect.FileDescriptor) bool {
+		files = append(files, file)
+		return true
+	})
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no proto files registered for package %s", protoPackage)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path() < files[j].Path() })
+
+	set := &descriptorpb.FileDescriptorSet{}
+	added := make(map[string]bool)
+	var add func(file protoreflect.FileDescriptor)
+	add = func(file protoreflect.FileDescriptor) {
+		if added[file.Path()] {
+			return
+		}
+		added[file.Path()] = true
+		imports := file.Imports()
+		for i := 0; i < imports.Len(); i++ {
+			add(imports.Get(i).FileDescriptor)
+		}
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+	for _, file := range files {
+		add(file)
+	}
+
+	data, err := proto.Marshal(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal descriptor set: %w", err)
+	}
+	return data, nil
+}
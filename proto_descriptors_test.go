@@ -0,0 +1,39 @@
+package accounting
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func TestProtoDescriptorSet(t *testing.T) {
+	data, err := ProtoDescriptorSet()
+	require.NoError(t, err)
+
+	set := &descriptorpb.FileDescriptorSet{}
+	require.NoError(t, proto.Unmarshal(data, set))
+
+	files, err := protodesc.NewFiles(set)
+	require.NoError(t, err, "the set carries every import it needs")
+
+	for _, name := range []string{"accounting.Transaction", "accounting.Script", "accounting.StatementTemplate", "google.protobuf.Timestamp"} {
+		_, err := files.FindDescriptorByName(protoreflect.FullName(name))
+		assert.NoError(t, err, name)
+	}
+
+	positions := make(map[string]int)
+	for i, file := range set.File {
+		positions[file.GetName()] = i
+	}
+	assert.Less(t, positions["google/protobuf/timestamp.proto"], positions["proto/accounting/accounting.proto"], "dependencies come first")
+
+	again, err := ProtoDescriptorSet()
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(data, again), "the set is stable between calls")
+}
@@ -0,0 +1,516 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// PSP Settlement Structures
+// ----------------------------------------------------------------------------
+
+// PSPProvider links a payment service provider to the ledger accounts its settlements
+// post to. Card sales debit the clearing account; each settlement batch clears them
+// into the bank, net of fees and reserve movements.
+type PSPProvider struct {
+	ID                  string    `json:"id"` // e.g. "stripe", "adyen"
+	Name                string    `json:"name"`
+	Currency            Currency  `json:"currency"`
+	ClearingAccountID   string    `json:"clearing_account_id"`   // asset: sales awaiting settlement
+	BankAccountID       string    `json:"bank_account_id"`       // asset: payouts land here
+	FeeAccountID        string    `json:"fee_account_id"`        // expense: processing fees
+	ReserveAccountID    string    `json:"reserve_account_id"`    // asset: rolling reserve held by the PSP
+	DifferenceAccountID string    `json:"difference_account_id"` // suspense: payouts the batch does not explain
+	CreatedBy           string    `json:"created_by"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// PSPLineType is the kind of item a settlement batch line settles
+type PSPLineType string
+
+const (
+	PSPLineSale       PSPLineType = "SALE"
+	PSPLineRefund     PSPLineType = "REFUND"
+	PSPLineChargeback PSPLineType = "CHARGEBACK"
+)
+
+// PSPMatchStatus is the outcome of matching a settlement line to the ledger
+type PSPMatchStatus string
+
+const (
+	PSPMatched        PSPMatchStatus = "MATCHED"
+	PSPAmountMismatch PSPMatchStatus = "AMOUNT_MISMATCH" // matched, but the ledger amount differs
+	PSPUnmatched      PSPMatchStatus = "UNMATCHED"       // no sale, refund or chargeback found
+	PSPDuplicate      PSPMatchStatus = "DUPLICATE"       // already settled in an earlier batch
+)
+
+// PSPSettlementLine is one sale, refund or chargeback in a settlement batch. Reference
+// is the PSP payment reference, matched to the sale's SourceRef or transaction ID;
+// refunds and chargebacks carry the reference of the sale they reverse.
+type PSPSettlementLine struct {
+	Type      PSPLineType `json:"type"`
+	Reference string      `json:"reference"`
+	Amount    int64       `json:"amount"` // gross, always positive
+
+	// Filled in by reconciliation
+	Status            PSPMatchStatus `json:"status"`
+	SaleTransactionID string         `json:"sale_transaction_id,omitempty"`
+	ReversalID        string         `json:"reversal_id,omitempty"`
+	LedgerAmount      int64          `json:"ledger_amount"`
+	Delta             int64          `json:"delta"` // effect on the clearing account the ledger cannot explain
+	Message           string         `json:"message,omitempty"`
+}
+
+// PSPSettlementStatus is the outcome of reconciling a settlement batch
+type PSPSettlementStatus string
+
+const (
+	PSPSettlementReconciled PSPSettlementStatus = "RECONCILED"
+	PSPSettlementExceptions PSPSettlementStatus = "EXCEPTIONS"
+)
+
+// PSPSettlementBatch is a payout from a PSP with the lines it settles. The totals are
+// as reported by the PSP; the net payout should equal gross sales less refunds,
+// chargebacks, fees and reserve held, plus reserve released.
+type PSPSettlementBatch struct {
+	ID              string              `json:"id"` // provider ID and batch reference
+	ProviderID      string              `json:"provider_id"`
+	BatchRef        string              `json:"batch_ref"`
+	SettlementDate  time.Time           `json:"settlement_date"`
+	GrossSales      int64               `json:"gross_sales"`
+	Refunds         int64               `json:"refunds"`
+	Chargebacks     int64               `json:"chargebacks"`
+	Fees            int64               `json:"fees"`
+	ReserveHeld     int64               `json:"reserve_held"`
+	ReserveReleased int64               `json:"reserve_released"`
+	NetPayout       int64               `json:"net_payout"`
+	Lines           []PSPSettlementLine `json:"lines"`
+
+	// Filled in by reconciliation. Deltas are signed by their effect on the clearing
+	// account, except PayoutDifference, which is the payout less what the totals imply.
+	Currency          Currency            `json:"currency"`
+	MatchedCount      int                 `json:"matched_count"`
+	ExceptionCount    int                 `json:"exception_count"`
+	UnmatchedAmount   int64               `json:"unmatched_amount"`
+	AmountDifference  int64               `json:"amount_difference"`
+	HeaderDifference  int64               `json:"header_difference"` // totals less the sum of the lines
+	PayoutDifference  int64               `json:"payout_difference"`
+	UnreconciledDelta int64               `json:"unreconciled_delta"`
+	Status            PSPSettlementStatus `json:"status"`
+	TransactionID     string              `json:"transaction_id"`
+	ReconciledBy      string              `json:"reconciled_by"`
+	ReconciledAt      time.Time           `json:"reconciled_at"`
+}
+
+// clearingCredit is the amount the batch clears from the clearing account
+func (b *PSPSettlementBatch) clearingCredit() int64 {
+	return b.GrossSales - b.Refunds - b.Chargebacks
+}
+
+// PSPSettlementReport summarizes a provider's settlement batches for a period
+type PSPSettlementReport struct {
+	ProviderID        string                `json:"provider_id"`
+	PeriodStart       time.Time             `json:"period_start"`
+	PeriodEnd         time.Time             `json:"period_end"`
+	Batches           []*PSPSettlementBatch `json:"batches"`
+	TotalNetPayout    int64                 `json:"total_net_payout"`
+	TotalFees         int64                 `json:"total_fees"`
+	TotalUnreconciled int64                 `json:"total_unreconciled"`
+	ExceptionBatches  int                   `json:"exception_batches"`
+	GeneratedAt       time.Time             `json:"generated_at"`
+}
+
+// ----------------------------------------------------------------------------
+// PSP Settlement Service
+// ----------------------------------------------------------------------------
+
+// PSPSettlementService reconciles PSP settlement batches to the sales, refunds and
+// chargebacks in the ledger and posts each payout against the clearing account
+type PSPSettlementService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+	refundService *RefundService
+}
+
+// NewPSPSettlementService creates a new PSP settlement service
+func NewPSPSettlementService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine, refundService *RefundService) *PSPSettlementService {
+	return &PSPSettlementService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+		refundService: refundService,
+	}
+}
+
+// RegisterProvider validates and saves a PSP and its settlement accounts
+func (pss *PSPSettlementService) RegisterProvider(provider *PSPProvider, userID string) error {
+	if provider.ID == "" {
+		return fmt.Errorf("provider ID is required")
+	}
+	if provider.Currency == "" {
+		return fmt.Errorf("provider currency is required")
+	}
+	accounts := []struct {
+		id   string
+		name string
+		typ  AccountType
+	}{
+		{provider.ClearingAccountID, "clearing", Asset},
+		{provider.BankAccountID, "bank", Asset},
+		{provider.FeeAccountID, "fee", Expense},
+		{provider.ReserveAccountID, "reserve", Asset},
+	}
+	for _, a := range accounts {
+		account, err := pss.storage.GetAccount(a.id)
+		if err != nil {
+			return fmt.Errorf("invalid %s account: %w", a.name, err)
+		}
+		if account.Type != a.typ {
+			return fmt.Errorf("%s account %s must be a %s account", a.name, a.id, a.typ)
+		}
+	}
+	if _, err := pss.storage.GetAccount(provider.DifferenceAccountID); err != nil {
+		return fmt.Errorf("invalid difference account: %w", err)
+	}
+
+	provider.CreatedBy = userID
+	provider.CreatedAt = time.Now()
+	_, err := pss.eventStore.CreateEvent(EventRegisterPSPProvider, provider, provider.CreatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create PSP provider event: %w", err)
+	}
+	if err := pss.storage.SavePSPProvider(provider); err != nil {
+		return fmt.Errorf("failed to save PSP provider: %w", err)
+	}
+	return nil
+}
+
+// ReconcileSettlement matches each line of a settlement batch to the ledger, reports
+// the deltas, and posts the payout: the bank, fees and reserve against the clearing
+// account, with any payout the totals do not explain held in the difference account.
+// A batch is reconciled once; exceptions are resolved by correcting the ledger.
+func (pss *PSPSettlementService) ReconcileSettlement(batch *PSPSettlementBatch, userID string) (*PSPSettlementBatch, error) {
+	provider, err := pss.storage.GetPSPProvider(batch.ProviderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PSP provider: %w", err)
+	}
+	if batch.BatchRef == "" {
+		return nil, fmt.Errorf("batch reference is required")
+	}
+	if batch.SettlementDate.IsZero() {
+		return nil, fmt.Errorf("settlement date is required")
+	}
+	for _, amount := range []int64{batch.GrossSales, batch.Refunds, batch.Chargebacks, batch.Fees, batch.ReserveHeld, batch.ReserveReleased} {
+		if amount < 0 {
+			return nil, fmt.Errorf("settlement totals cannot be negative")
+		}
+	}
+	batch.ID = provider.ID + "_" + batch.BatchRef
+	if _, err := pss.storage.GetPSPSettlementBatch(batch.ID); err == nil {
+		return nil, fmt.Errorf("settlement batch %s has already been reconciled", batch.BatchRef)
+	}
+
+	if err := pss.matchLines(provider, batch); err != nil {
+		return nil, err
+	}
+
+	var lineTotal int64
+	for _, line := range batch.Lines {
+		lineTotal += pspLineSign(line.Type) * line.Amount
+	}
+	batch.Currency = provider.Currency
+	batch.HeaderDifference = batch.clearingCredit() - lineTotal
+	expectedPayout := batch.clearingCredit() - batch.Fees - batch.ReserveHeld + batch.ReserveReleased
+	batch.PayoutDifference = batch.NetPayout - expectedPayout
+	batch.UnreconciledDelta = batch.UnmatchedAmount + batch.AmountDifference + batch.HeaderDifference + batch.PayoutDifference
+	batch.Status = PSPSettlementReconciled
+	if batch.ExceptionCount > 0 || batch.HeaderDifference != 0 || batch.PayoutDifference != 0 {
+		batch.Status = PSPSettlementExceptions
+	}
+	batch.ReconciledBy = userID
+	batch.ReconciledAt = time.Now()
+
+	txn := pss.settlementTransaction(provider, batch, userID)
+	if err := pss.postTransaction(txn, userID); err != nil {
+		return nil, err
+	}
+	batch.TransactionID = txn.ID
+
+	_, err = pss.eventStore.CreateEvent(EventReconcilePSPSettlement, batch, batch.SettlementDate, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create PSP settlement event: %w", err)
+	}
+	if err := pss.storage.SavePSPSettlementBatch(batch); err != nil {
+		return nil, fmt.Errorf("failed to save PSP settlement batch: %w", err)
+	}
+	return batch, nil
+}
+
+// GenerateSettlementReport lists a provider's batches settled in the period with their
+// unreconciled deltas
+func (pss *PSPSettlementService) GenerateSettlementReport(providerID string, periodStart, periodEnd time.Time) (*PSPSettlementReport, error) {
+	batches, err := pss.storage.GetAllPSPSettlementBatches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PSP settlement batches: %w", err)
+	}
+
+	report := &PSPSettlementReport{
+		ProviderID:  providerID,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		GeneratedAt: time.Now(),
+	}
+	for _, batch := range batches {
+		if batch.ProviderID != providerID || batch.SettlementDate.Before(periodStart) || batch.SettlementDate.After(periodEnd) {
+			continue
+		}
+		report.Batches = append(report.Batches, batch)
+		report.TotalNetPayout += batch.NetPayout
+		report.TotalFees += batch.Fees
+		report.TotalUnreconciled += batch.UnreconciledDelta
+		if batch.Status == PSPSettlementExceptions {
+			report.ExceptionBatches++
+		}
+	}
+	sort.Slice(report.Batches, func(i, j int) bool {
+		return report.Batches[i].SettlementDate.Before(report.Batches[j].SettlementDate)
+	})
+	return report, nil
+}
+
+// matchLines matches each line to a sale, refund or chargeback that no earlier batch
+// has settled, recording the line's status and delta and the batch's match totals
+func (pss *PSPSettlementService) matchLines(provider *PSPProvider, batch *PSPSettlementBatch) error {
+	settled, err := pss.settledItems(provider.ID)
+	if err != nil {
+		return err
+	}
+	sales, err := pss.salesByReference(provider, batch.SettlementDate)
+	if err != nil {
+		return err
+	}
+
+	for i := range batch.Lines {
+		line := &batch.Lines[i]
+		if line.Amount <= 0 {
+			return fmt.Errorf("line %d: amount must be positive", i+1)
+		}
+		*line = PSPSettlementLine{Type: line.Type, Reference: line.Reference, Amount: line.Amount}
+
+		sale, ok := sales[line.Reference]
+		switch {
+		case !ok:
+			line.Status = PSPUnmatched
+			line.Message = "no sale on the clearing account has this reference"
+		case line.Type == PSPLineSale:
+			line.SaleTransactionID = sale.ID
+			line.LedgerAmount = clearingDebits(sale, provider.ClearingAccountID)
+			if settled[sale.ID] {
+				line.Status = PSPDuplicate
+				line.Message = "sale was settled in an earlier batch"
+			} else {
+				settled[sale.ID] = true
+				line.Status = PSPMatched
+			}
+		case line.Type == PSPLineRefund || line.Type == PSPLineChargeback:
+			line.SaleTransactionID = sale.ID
+			if err := pss.matchReversal(line, sale, settled); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("line %d: unsupported line type %s", i+1, line.Type)
+		}
+
+		if line.Status == PSPMatched && line.LedgerAmount != line.Amount {
+			line.Status = PSPAmountMismatch
+			line.Message = fmt.Sprintf("ledger amount is %s", FormatMinorUnits(line.LedgerAmount, provider.Currency))
+		}
+
+		sign := pspLineSign(line.Type)
+		switch line.Status {
+		case PSPMatched:
+			batch.MatchedCount++
+		case PSPAmountMismatch:
+			line.Delta = sign * (line.Amount - line.LedgerAmount)
+			batch.AmountDifference += line.Delta
+			batch.ExceptionCount++
+		default:
+			line.Delta = sign * line.Amount
+			batch.UnmatchedAmount += line.Delta
+			batch.ExceptionCount++
+		}
+	}
+	return nil
+}
+
+// matchReversal matches a refund or chargeback line to an unsettled reversal of the
+// sale, preferring one of the same amount
+func (pss *PSPSettlementService) matchReversal(line *PSPSettlementLine, sale *Transaction, settled map[string]bool) error {
+	reversals, err := pss.refundService.GetReversals(sale.ID)
+	if err != nil {
+		return err
+	}
+	reversalType := ReversalRefund
+	if line.Type == PSPLineChargeback {
+		reversalType = ReversalChargeback
+	}
+
+	var candidate *SaleReversal
+	for _, reversal := range reversals {
+		if reversal.Type != reversalType || settled[reversal.ID] {
+			continue
+		}
+		if reversal.Amount.Value == line.Amount {
+			candidate = reversal
+			break
+		}
+		if candidate == nil {
+			candidate = reversal
+		}
+	}
+	if candidate == nil {
+		line.Status = PSPUnmatched
+		line.Message = fmt.Sprintf("no unsettled %s recorded against the sale", strings.ToLower(string(reversalType)))
+		return nil
+	}
+	settled[candidate.ID] = true
+	line.ReversalID = candidate.ID
+	line.LedgerAmount = candidate.Amount.Value
+	line.Status = PSPMatched
+	return nil
+}
+
+// settledItems returns the sales and reversals settled in the provider's earlier batches
+func (pss *PSPSettlementService) settledItems(providerID string) (map[string]bool, error) {
+	batches, err := pss.storage.GetAllPSPSettlementBatches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PSP settlement batches: %w", err)
+	}
+	settled := make(map[string]bool)
+	for _, batch := range batches {
+		if batch.ProviderID != providerID {
+			continue
+		}
+		for _, line := range batch.Lines {
+			if line.Status != PSPMatched && line.Status != PSPAmountMismatch {
+				continue
+			}
+			if line.ReversalID != "" {
+				settled[line.ReversalID] = true
+			} else {
+				settled[line.SaleTransactionID] = true
+			}
+		}
+	}
+	return settled, nil
+}
+
+// salesByReference indexes the posted sales on the clearing account up to the
+// settlement date by source reference and transaction ID
+func (pss *PSPSettlementService) salesByReference(provider *PSPProvider, settlementDate time.Time) (map[string]*Transaction, error) {
+	txns, err := pss.storage.GetTransactionsByDateRange("", time.Time{}, settlementDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	sales := make(map[string]*Transaction)
+	for _, txn := range txns {
+		if txn.Status != Posted || clearingDebits(txn, provider.ClearingAccountID) == 0 {
+			continue
+		}
+		sales[txn.ID] = txn
+		if txn.SourceRef != "" {
+			sales[txn.SourceRef] = txn
+		}
+	}
+	return sales, nil
+}
+
+// settlementTransaction builds the payout journal: bank, fees and reserve movements
+// against the clearing account, balanced through the difference account
+func (pss *PSPSettlementService) settlementTransaction(provider *PSPProvider, batch *PSPSettlementBatch, userID string) *Transaction {
+	txn := &Transaction{
+		ID:              newID(),
+		Description:     fmt.Sprintf("%s settlement %s", provider.Name, batch.BatchRef),
+		ValidTime:       batch.SettlementDate,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       "PSP_SETTLEMENT_" + batch.ID,
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	// Signed debits; a negative amount is posted as a credit
+	lines := []struct {
+		accountID string
+		debit     int64
+	}{
+		{provider.BankAccountID, batch.NetPayout},
+		{provider.FeeAccountID, batch.Fees},
+		{provider.ReserveAccountID, batch.ReserveHeld - batch.ReserveReleased},
+		{provider.ClearingAccountID, -batch.clearingCredit()},
+		{provider.DifferenceAccountID, -batch.PayoutDifference},
+	}
+	for _, line := range lines {
+		if line.debit == 0 {
+			continue
+		}
+		entryType, value := Debit, line.debit
+		if value < 0 {
+			entryType, value = Credit, -value
+		}
+		txn.Entries = append(txn.Entries, Entry{
+			ID:            newID(),
+			TransactionID: txn.ID,
+			AccountID:     line.accountID,
+			Type:          entryType,
+			Amount:        Amount{Value: value, Currency: provider.Currency},
+		})
+	}
+	return txn
+}
+
+// postTransaction records, saves and posts a settlement transaction
+func (pss *PSPSettlementService) postTransaction(txn *Transaction, userID string) error {
+	_, err := pss.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := pss.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	if err := pss.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
+
+// pspLineSign is the direction a line moves the clearing account's settlement:
+// sales are cleared, refunds and chargebacks reduce what is cleared
+func pspLineSign(lineType PSPLineType) int64 {
+	if lineType == PSPLineSale {
+		return 1
+	}
+	return -1
+}
+
+// clearingDebits is the amount a sale debited to the clearing account
+func clearingDebits(txn *Transaction, clearingAccountID string) int64 {
+	var total int64
+	for _, entry := range txn.Entries {
+		if entry.AccountID == clearingAccountID && entry.Type == Debit {
+			total += entry.Amount.Value
+		}
+	}
+	return total
+}
@@ -0,0 +1,158 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPSPSettlement(t *testing.T) {
+	// Setup
+	dbFile := "test_psp_settlement.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "treasury"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	accounts := []*Account{
+		{ID: "psp_clearing", Code: "1120", Name: "Card Sales Clearing", Type: Asset},
+		{ID: "psp_reserve", Code: "1125", Name: "PSP Rolling Reserve", Type: Asset},
+		{ID: "psp_fees", Code: "6150", Name: "Payment Processing Fees", Type: Expense},
+		{ID: "psp_suspense", Code: "1199", Name: "Settlement Differences", Type: Asset},
+	}
+	for _, account := range accounts {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	day := func(d int) time.Time { return time.Date(2025, 5, d, 0, 0, 0, 0, time.UTC) }
+	balance := func(accountID string) int64 {
+		result, err := engine.GetAccountBalance(accountID, day(31))
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+	sell := func(ref string, value int64, when time.Time) *Transaction {
+		txn := &Transaction{
+			Description: "Card sale",
+			ValidTime:   when,
+			SourceRef:   ref,
+			Entries: []Entry{
+				{AccountID: "psp_clearing", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+
+	provider := &PSPProvider{
+		ID:                  "acmepay",
+		Name:                "AcmePay",
+		Currency:            "USD",
+		ClearingAccountID:   "psp_clearing",
+		BankAccountID:       "cash",
+		FeeAccountID:        "psp_fees",
+		ReserveAccountID:    "psp_reserve",
+		DifferenceAccountID: "psp_suspense",
+	}
+
+	sell("ch_001", 10000, day(1))
+	sell("ch_002", 5000, day(1))
+	disputed := sell("ch_003", 8000, day(2))
+	_, err = engine.RecordChargeback(SaleReversalRequest{SaleTransactionID: disputed.ID, Amount: 8000, Date: day(3), Reason: "Fraud"}, userID)
+	require.NoError(t, err)
+
+	t.Run("Provider Validation", func(t *testing.T) {
+		invalid := *provider
+		invalid.FeeAccountID = "psp_reserve"
+		assert.Error(t, engine.RegisterPSPProvider(&invalid, userID), "fee account must be an expense")
+		require.NoError(t, engine.RegisterPSPProvider(provider, userID))
+	})
+
+	t.Run("Reconciled Batch", func(t *testing.T) {
+		batch, err := engine.ReconcilePSPSettlement(&PSPSettlementBatch{
+			ProviderID:     "acmepay",
+			BatchRef:       "po_100",
+			SettlementDate: day(4),
+			GrossSales:     23000,
+			Chargebacks:    8000,
+			Fees:           690,
+			ReserveHeld:    1500,
+			NetPayout:      12810,
+			Lines: []PSPSettlementLine{
+				{Type: PSPLineSale, Reference: "ch_001", Amount: 10000},
+				{Type: PSPLineSale, Reference: "ch_002", Amount: 5000},
+				{Type: PSPLineSale, Reference: "ch_003", Amount: 8000},
+				{Type: PSPLineChargeback, Reference: "ch_003", Amount: 8000},
+			},
+		}, userID)
+		require.NoError(t, err)
+
+		assert.Equal(t, PSPSettlementReconciled, batch.Status)
+		assert.Equal(t, 4, batch.MatchedCount)
+		assert.Equal(t, int64(0), batch.UnreconciledDelta)
+		assert.Equal(t, disputed.ID, batch.Lines[3].SaleTransactionID)
+		assert.NotEmpty(t, batch.Lines[3].ReversalID)
+
+		assert.Equal(t, int64(0), balance("psp_clearing"), "clearing is fully settled")
+		assert.Equal(t, int64(690), balance("psp_fees"))
+		assert.Equal(t, int64(1500), balance("psp_reserve"))
+		assert.Equal(t, int64(12810), balance("cash"))
+
+		_, err = engine.ReconcilePSPSettlement(&PSPSettlementBatch{ProviderID: "acmepay", BatchRef: "po_100", SettlementDate: day(4)}, userID)
+		assert.Error(t, err, "a batch is reconciled once")
+	})
+
+	t.Run("Batch With Exceptions", func(t *testing.T) {
+		sell("ch_004", 4000, day(5))
+
+		batch, err := engine.ReconcilePSPSettlement(&PSPSettlementBatch{
+			ProviderID:      "acmepay",
+			BatchRef:        "po_101",
+			SettlementDate:  day(6),
+			GrossSales:      12500,
+			Fees:            375,
+			ReserveReleased: 1500,
+			NetPayout:       13600,
+			Lines: []PSPSettlementLine{
+				{Type: PSPLineSale, Reference: "ch_004", Amount: 4500},
+				{Type: PSPLineSale, Reference: "ch_001", Amount: 5000},
+				{Type: PSPLineSale, Reference: "ch_999", Amount: 3000},
+			},
+		}, userID)
+		require.NoError(t, err)
+
+		assert.Equal(t, PSPSettlementExceptions, batch.Status)
+		assert.Equal(t, 3, batch.ExceptionCount)
+		assert.Equal(t, PSPAmountMismatch, batch.Lines[0].Status)
+		assert.Equal(t, int64(500), batch.Lines[0].Delta)
+		assert.Equal(t, PSPDuplicate, batch.Lines[1].Status)
+		assert.Equal(t, PSPUnmatched, batch.Lines[2].Status)
+		assert.Equal(t, int64(500), batch.AmountDifference)
+		assert.Equal(t, int64(8000), batch.UnmatchedAmount)
+		assert.Equal(t, int64(0), batch.HeaderDifference)
+		// 12,500 - 375 + 1,500 = 13,625 expected against 13,600 paid
+		assert.Equal(t, int64(-25), batch.PayoutDifference)
+		assert.Equal(t, int64(8475), batch.UnreconciledDelta)
+
+		assert.Equal(t, int64(0), balance("psp_reserve"))
+		assert.Equal(t, int64(25), balance("psp_suspense"))
+		assert.Equal(t, int64(-8500), balance("psp_clearing"), "clearing shows what the ledger cannot explain")
+	})
+
+	t.Run("Settlement Report", func(t *testing.T) {
+		report, err := engine.GeneratePSPSettlementReport("acmepay", day(1), day(31))
+		require.NoError(t, err)
+		require.Len(t, report.Batches, 2)
+		assert.Equal(t, "po_100", report.Batches[0].BatchRef)
+		assert.Equal(t, 1, report.ExceptionBatches)
+		assert.Equal(t, int64(26410), report.TotalNetPayout)
+		assert.Equal(t, int64(8475), report.TotalUnreconciled)
+	})
+}
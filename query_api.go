@@ -2,6 +2,8 @@ package accounting
 
 import (
 	"fmt"
+	"sort"
+	"sync/atomic"
 	"time"
 )
 
@@ -47,6 +49,7 @@ type DimensionRollup struct {
 type QueryAPI struct {
 	storage       *Storage
 	postingEngine *PostingEngine
+	concurrency   atomic.Int64 // accounts worked on at once, 0 for the default
 }
 
 // NewQueryAPI creates a new query API
@@ -57,6 +60,24 @@ func NewQueryAPI(storage *Storage, postingEngine *PostingEngine) *QueryAPI {
 	}
 }
 
+// SetConcurrency bounds how many accounts trial balances and statements work on at
+// once. Zero or less restores the default of one per CPU, and 1 runs serially.
+// Output is in the same order whatever the setting.
+func (qa *QueryAPI) SetConcurrency(workers int) {
+	if workers < 0 {
+		workers = 0
+	}
+	qa.concurrency.Store(int64(workers))
+}
+
+// workers returns the configured report concurrency
+func (qa *QueryAPI) workers() int {
+	if workers := int(qa.concurrency.Load()); workers > 0 {
+		return workers
+	}
+	return DefaultReportConcurrency()
+}
+
 // GetTransactions retrieves transactions based on filters
 func (qa *QueryAPI) GetTransactions(filter *QueryFilter, page, pageSize int) (*QueryResult, error) {
 	// This is a simplified implementation
@@ -98,50 +119,49 @@ func (qa *QueryAPI) GetAccountBalance(accountID string, asOfDate time.Time) (*Ba
 	}, nil
 }
 
-// GetTrialBalance generates a trial balance report
+// GetTrialBalance generates a trial balance report. Account balances are calculated
+// in parallel, bounded by the configured concurrency.
 func (qa *QueryAPI) GetTrialBalance(asOfDate time.Time, accountTypes []AccountType) ([]*BalanceResult, error) {
-	var results []*BalanceResult
-
-	// Get all accounts - for this implementation, we'll use a simple approach
-	// In a real system, you'd have proper account indexing
-
-	// Get accounts from storage by iterating through known account IDs
-	// This is a simplified approach for the demo
-	standardAccountIDs := []string{"cash", "accounts_receivable", "accounts_payable", "revenue", "expenses", "unearned_revenue"}
-
-	for _, accountID := range standardAccountIDs {
-		account, err := qa.storage.GetAccount(accountID)
-		if err != nil {
-			continue // Skip if account doesn't exist
-		}
+	accounts, err := qa.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
 
-		// Filter by account type if specified
-		if len(accountTypes) > 0 {
-			found := false
-			for _, accountType := range accountTypes {
-				if account.Type == accountType {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
+	// Present accounts in chart of accounts order
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Code < accounts[j].Code
+	})
+
+	// Filter by account type if specified
+	if len(accountTypes) > 0 {
+		filtered := accounts[:0]
+		for _, account := range accounts {
+			if containsAccountType(accountTypes, account.Type) {
+				filtered = append(filtered, account)
 			}
 		}
+		accounts = filtered
+	}
 
+	// Include all accounts in trial balance, even those with zero balance
+	results := make([]*BalanceResult, len(accounts))
+	err = forEachParallel(len(accounts), qa.workers(), func(i int) error {
+		account := accounts[i]
 		balance, err := qa.postingEngine.CalculateAccountBalance(account.ID, asOfDate)
 		if err != nil {
-			return nil, fmt.Errorf("failed to calculate balance for account %s: %w", account.ID, err)
+			return fmt.Errorf("failed to calculate balance for account %s: %w", account.ID, err)
 		}
-
-		// Include all accounts in trial balance, even those with zero balance
-		results = append(results, &BalanceResult{
+		results[i] = &BalanceResult{
 			AccountID:   account.ID,
 			AccountName: account.Name,
 			AccountType: account.Type,
 			Balance:     balance,
 			AsOfDate:    asOfDate,
-		})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return results, nil
@@ -221,9 +241,15 @@ func (qa *QueryAPI) extractDimensions(dimensions []Dimension, rollupKeys []Dimen
 
 // GetAccountHierarchy builds the account hierarchy tree
 func (qa *QueryAPI) GetAccountHierarchy() ([]*AccountNode, error) {
-	// Get all accounts
-	// In a real system, this would be properly indexed
-	accounts := []*Account{} // Placeholder
+	accounts, err := qa.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	// Siblings are listed in chart of accounts order
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Code < accounts[j].Code
+	})
 
 	// Build hierarchy
 	accountMap := make(map[string]*AccountNode)
@@ -241,12 +267,10 @@ func (qa *QueryAPI) GetAccountHierarchy() ([]*AccountNode, error) {
 	// Build parent-child relationships
 	for _, account := range accounts {
 		node := accountMap[account.ID]
-		if account.ParentID == "" {
-			roots = append(roots, node)
+		if parent, exists := accountMap[account.ParentID]; exists && account.ParentID != "" {
+			parent.Children = append(parent.Children, node)
 		} else {
-			if parent, exists := accountMap[account.ParentID]; exists {
-				parent.Children = append(parent.Children, node)
-			}
+			roots = append(roots, node)
 		}
 	}
 
@@ -0,0 +1,66 @@
+package accounting
+
+import (
+	"fmt"
+	"time"
+)
+
+// RebuildComponent names a derived store that can be regenerated from the event log
+// and primary records
+type RebuildComponent string
+
+const (
+	RebuildBalances   RebuildComponent = "BALANCES"   // balance snapshots, movements and the posting index
+	RebuildIndexes    RebuildComponent = "INDEXES"    // the entry index by account
+	RebuildDashboards RebuildComponent = "DASHBOARDS" // AML dashboard aggregates
+	RebuildBudgets    RebuildComponent = "BUDGETS"    // allocation spent and remaining amounts
+	RebuildCache      RebuildComponent = "CACHE"      // the in-memory read cache
+	RebuildAll        RebuildComponent = "ALL"
+)
+
+// rebuildOrder is the order ALL rebuilds components in
+var rebuildOrder = []RebuildComponent{RebuildBalances, RebuildIndexes, RebuildDashboards, RebuildBudgets, RebuildCache}
+
+// RebuildResult reports the regeneration of one component
+type RebuildResult struct {
+	Component   RebuildComponent `json:"component"`
+	Corrected   int              `json:"corrected,omitempty"` // records changed, where the component counts them
+	StartedAt   time.Time        `json:"started_at"`
+	CompletedAt time.Time        `json:"completed_at"`
+}
+
+// Rebuild regenerates derived data purely from the event log and primary records, so
+// corruption or a fix to the derivation logic can be healed without editing the
+// database by hand. ALL rebuilds every component in turn.
+func (s *Storage) Rebuild(component RebuildComponent) ([]*RebuildResult, error) {
+	components := []RebuildComponent{component}
+	if component == RebuildAll {
+		components = rebuildOrder
+	}
+
+	var results []*RebuildResult
+	for _, c := range components {
+		result := &RebuildResult{Component: c, StartedAt: time.Now()}
+		var err error
+		switch c {
+		case RebuildBalances:
+			err = s.RebuildBalanceSnapshots()
+		case RebuildIndexes:
+			err = s.RebuildEntryIndex()
+		case RebuildDashboards:
+			err = s.RebuildAMLAggregates()
+		case RebuildBudgets:
+			result.Corrected, err = s.RebuildBudgetSpending()
+		case RebuildCache:
+			s.InvalidateCache()
+		default:
+			return results, fmt.Errorf("unknown rebuild component %s", c)
+		}
+		if err != nil {
+			return results, fmt.Errorf("failed to rebuild %s: %w", c, err)
+		}
+		result.CompletedAt = time.Now()
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,125 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+func TestRebuildDerivedStores(t *testing.T) {
+	dbFile := "test_rebuild.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "operator"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	storage := engine.GetStorage()
+
+	validTime := time.Date(2025, 5, 10, 0, 0, 0, 0, time.UTC)
+	for _, value := range []int64{1500, 2500} {
+		txn := &Transaction{
+			Description: "Office supplies",
+			ValidTime:   validTime,
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	balance := func() int64 {
+		result, err := engine.GetAccountBalance("expenses", validTime.AddDate(0, 1, 0))
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+	require.Equal(t, int64(4000), balance())
+
+	clear := func(bucket []byte) {
+		require.NoError(t, storage.db.Update(func(tx *bbolt.Tx) error {
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return err
+			}
+			_, err := tx.CreateBucket(bucket)
+			return err
+		}))
+	}
+
+	t.Run("balances and indexes", func(t *testing.T) {
+		clear(BucketBalanceSnapshots)
+		clear(BucketBalanceMovements)
+		clear(BucketEntries)
+		entries, err := storage.GetEntriesByAccount("expenses")
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+
+		results, err := engine.Rebuild(RebuildBalances)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, RebuildBalances, results[0].Component)
+		assert.Equal(t, int64(4000), balance())
+
+		_, err = engine.Rebuild(RebuildIndexes)
+		require.NoError(t, err)
+		entries, err = storage.GetEntriesByAccount("expenses")
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("budget spent amounts", func(t *testing.T) {
+		allocation := &BudgetAllocation{
+			ID:          "office",
+			AccountID:   "expenses",
+			Amount:      &Amount{Value: 10000, Currency: "USD"},
+			SpentAmount: &Amount{Value: 9999, Currency: "USD"},
+			Remaining:   &Amount{Value: 1, Currency: "USD"},
+		}
+		require.NoError(t, storage.SaveBudgetAllocation(allocation))
+		for _, tracking := range []*BudgetTracking{
+			{AllocationID: "office", TransactionID: "t1", Amount: &Amount{Value: 1500, Currency: "USD"}},
+			{AllocationID: "office", TransactionID: "t2", Amount: &Amount{Value: 2500, Currency: "USD"}},
+		} {
+			require.NoError(t, storage.SaveBudgetTracking(tracking))
+		}
+
+		results, err := engine.Rebuild(RebuildBudgets)
+		require.NoError(t, err)
+		assert.Equal(t, 1, results[0].Corrected)
+		rebuilt, err := storage.GetBudgetAllocation("office")
+		require.NoError(t, err)
+		assert.Equal(t, int64(4000), rebuilt.SpentAmount.Value)
+		assert.Equal(t, int64(6000), rebuilt.Remaining.Value)
+
+		results, err = engine.Rebuild(RebuildBudgets)
+		require.NoError(t, err)
+		assert.Zero(t, results[0].Corrected, "a healthy store needs no corrections")
+	})
+
+	t.Run("dashboards", func(t *testing.T) {
+		require.NoError(t, engine.GetAMLService().RaiseAlert(&AMLAlert{Title: "Structuring", RiskLevel: RiskHigh, DetectedAt: validTime}))
+		clear(BucketAMLAggregates)
+		_, err := engine.Rebuild(RebuildDashboards)
+		require.NoError(t, err)
+		activity, err := engine.GetAMLService().GetAMLActivity(validTime.AddDate(0, 0, -1), validTime.AddDate(0, 0, 1))
+		require.NoError(t, err)
+		assert.Equal(t, 1, activity.TotalAlerts)
+	})
+
+	t.Run("all components", func(t *testing.T) {
+		results, err := engine.Rebuild(RebuildAll)
+		require.NoError(t, err)
+		require.Len(t, results, 5)
+		assert.Equal(t, RebuildCache, results[4].Component)
+		assert.Equal(t, int64(4000), balance())
+
+		_, err = engine.Rebuild("LEDGER")
+		assert.ErrorContains(t, err, "unknown rebuild component")
+	})
+}
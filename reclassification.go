@@ -0,0 +1,515 @@
+package accounting
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Reclassification Structures
+// ----------------------------------------------------------------------------
+
+// ReclassStatus is the state of a reclassification batch
+type ReclassStatus string
+
+const (
+	ReclassPreview    ReclassStatus = "PREVIEW"
+	ReclassPosted     ReclassStatus = "POSTED"
+	ReclassRolledBack ReclassStatus = "ROLLED_BACK"
+)
+
+// ReclassRule moves matching entries to another account and/or dimension set
+type ReclassRule struct {
+	FromAccountID   string      `json:"from_account_id"`
+	MatchDimensions []Dimension `json:"match_dimensions,omitempty"` // entry must carry all of these
+	ToAccountID     string      `json:"to_account_id,omitempty"`    // empty keeps the account
+	SetDimensions   []Dimension `json:"set_dimensions,omitempty"`   // an empty value removes the dimension
+}
+
+// ReclassLine is one historical entry moved by a reclassification
+type ReclassLine struct {
+	SourceTransactionID string      `json:"source_transaction_id"`
+	SourceEntryID       string      `json:"source_entry_id"`
+	Rule                int         `json:"rule"` // index of the matching rule
+	EntryType           EntryType   `json:"entry_type"`
+	Amount              Amount      `json:"amount"`
+	FromAccountID       string      `json:"from_account_id"`
+	FromDimensions      []Dimension `json:"from_dimensions,omitempty"`
+	ToAccountID         string      `json:"to_account_id"`
+	ToDimensions        []Dimension `json:"to_dimensions,omitempty"`
+}
+
+// ReclassBatch is a controlled mass reclassification of historical entries. It is
+// previewed first, then executed as balanced reclassification journals dated
+// PostingDate, and can be rolled back with contra journals. Entries are never edited.
+type ReclassBatch struct {
+	ID          string        `json:"id"`
+	Description string        `json:"description"`
+	Start       time.Time     `json:"start"` // entries with a valid time in [Start, End] are considered
+	End         time.Time     `json:"end"`
+	PostingDate time.Time     `json:"posting_date"`
+	Rules       []ReclassRule `json:"rules"`
+
+	// Filled in by preview, execution and rollback
+	Status                 ReclassStatus `json:"status"`
+	Lines                  []ReclassLine `json:"lines"`
+	TransactionIDs         []string      `json:"transaction_ids,omitempty"`
+	RollbackTransactionIDs []string      `json:"rollback_transaction_ids,omitempty"`
+	CreatedBy              string        `json:"created_by"`
+	CreatedAt              time.Time     `json:"created_at"`
+	PostedBy               string        `json:"posted_by,omitempty"`
+	PostedAt               *time.Time    `json:"posted_at,omitempty"`
+	RolledBackBy           string        `json:"rolled_back_by,omitempty"`
+	RolledBackAt           *time.Time    `json:"rolled_back_at,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// Reclassification Service
+// ----------------------------------------------------------------------------
+
+// ReclassificationService previews, posts and rolls back bulk reclassifications
+type ReclassificationService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+}
+
+// NewReclassificationService creates a new reclassification service
+func NewReclassificationService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *ReclassificationService {
+	return &ReclassificationService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+	}
+}
+
+// PreviewReclass validates a batch's rules and lists the entries it would move
+// without posting anything. The batch is saved in PREVIEW status for execution.
+func (rs *ReclassificationService) PreviewReclass(batch *ReclassBatch, userID string) (*ReclassBatch, error) {
+	if len(batch.Rules) == 0 {
+		return nil, fmt.Errorf("reclassification needs at least one rule")
+	}
+	if batch.End.Before(batch.Start) {
+		return nil, fmt.Errorf("reclassification range ends before it starts")
+	}
+	if batch.PostingDate.IsZero() {
+		return nil, fmt.Errorf("posting date is required")
+	}
+	for i, rule := range batch.Rules {
+		if err := rs.validateRule(rule); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+	}
+
+	lines, err := rs.matchEntries(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	if batch.ID == "" {
+		batch.ID = newID()
+	}
+	batch.Status = ReclassPreview
+	batch.Lines = lines
+	batch.CreatedBy = userID
+	batch.CreatedAt = time.Now()
+
+	if err := rs.saveBatch(batch, EventPreviewReclass, userID); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// ExecuteReclass posts a previewed batch as one balanced journal per currency. Each
+// moved entry is reversed out of its original account and dimensions and
+// rebooked to the new ones. Execution fails if the ledger changed since the preview.
+func (rs *ReclassificationService) ExecuteReclass(batchID, userID string) (*ReclassBatch, error) {
+	batch, err := rs.storage.GetReclassBatch(batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reclassification batch: %w", err)
+	}
+	if batch.Status != ReclassPreview {
+		return nil, fmt.Errorf("reclassification %s is %s, not PREVIEW", batchID, batch.Status)
+	}
+	if len(batch.Lines) == 0 {
+		return nil, fmt.Errorf("reclassification %s matches no entries", batchID)
+	}
+
+	current, err := rs.matchEntries(batch)
+	if err != nil {
+		return nil, err
+	}
+	if reclassFingerprint(current) != reclassFingerprint(batch.Lines) {
+		return nil, fmt.Errorf("ledger changed since reclassification %s was previewed; preview it again", batchID)
+	}
+
+	if err := rs.postingEngine.validatePeriod(batch.PostingDate, userID); err != nil {
+		return nil, err
+	}
+
+	byCurrency := make(map[Currency][]ReclassLine)
+	var currencies []Currency
+	for _, line := range batch.Lines {
+		if _, ok := byCurrency[line.Amount.Currency]; !ok {
+			currencies = append(currencies, line.Amount.Currency)
+		}
+		byCurrency[line.Amount.Currency] = append(byCurrency[line.Amount.Currency], line)
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		return currencies[i] < currencies[j]
+	})
+
+	for _, currency := range currencies {
+		txn := rs.newTransaction(fmt.Sprintf("Reclassification: %s", batch.Description), batch.PostingDate,
+			fmt.Sprintf("RECLASS_%s_%s", batch.ID, currency), userID)
+		for _, line := range byCurrency[currency] {
+			txn.Entries = append(txn.Entries,
+				Entry{
+					ID:            newID(),
+					TransactionID: txn.ID,
+					AccountID:     line.FromAccountID,
+					Type:          oppositeEntryType(line.EntryType),
+					Amount:        line.Amount,
+					Dimensions:    line.FromDimensions,
+				},
+				Entry{
+					ID:            newID(),
+					TransactionID: txn.ID,
+					AccountID:     line.ToAccountID,
+					Type:          line.EntryType,
+					Amount:        line.Amount,
+					Dimensions:    line.ToDimensions,
+				})
+		}
+		if err := rs.postTransaction(txn, userID); err != nil {
+			return nil, err
+		}
+		batch.TransactionIDs = append(batch.TransactionIDs, txn.ID)
+	}
+
+	now := time.Now()
+	batch.Status = ReclassPosted
+	batch.PostedBy = userID
+	batch.PostedAt = &now
+
+	if err := rs.saveBatch(batch, EventExecuteReclass, userID); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// RollbackReclass undoes a posted batch with contra journals dated on the original
+// posting date, leaving the reclassification journals in the ledger for audit
+func (rs *ReclassificationService) RollbackReclass(batchID, userID string) (*ReclassBatch, error) {
+	batch, err := rs.storage.GetReclassBatch(batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reclassification batch: %w", err)
+	}
+	if batch.Status != ReclassPosted {
+		return nil, fmt.Errorf("reclassification %s is %s, not POSTED", batchID, batch.Status)
+	}
+
+	for _, txnID := range batch.TransactionIDs {
+		original, err := rs.storage.GetTransaction(txnID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reclassification transaction: %w", err)
+		}
+		sourceRef := strings.Replace(original.SourceRef, "RECLASS_", "RECLASS_ROLLBACK_", 1)
+		reversal := rs.newTransaction(fmt.Sprintf("Rollback of %s", original.Description), original.ValidTime, sourceRef, userID)
+		for _, entry := range original.Entries {
+			reversal.Entries = append(reversal.Entries, Entry{
+				ID:            newID(),
+				TransactionID: reversal.ID,
+				AccountID:     entry.AccountID,
+				Type:          oppositeEntryType(entry.Type),
+				Amount:        entry.Amount,
+				Dimensions:    entry.Dimensions,
+			})
+		}
+		if err := rs.postTransaction(reversal, userID); err != nil {
+			return nil, err
+		}
+		batch.RollbackTransactionIDs = append(batch.RollbackTransactionIDs, reversal.ID)
+	}
+
+	now := time.Now()
+	batch.Status = ReclassRolledBack
+	batch.RolledBackBy = userID
+	batch.RolledBackAt = &now
+
+	if err := rs.saveBatch(batch, EventRollbackReclass, userID); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// validateRule checks the rule's accounts exist and that it changes something
+func (rs *ReclassificationService) validateRule(rule ReclassRule) error {
+	if _, err := rs.storage.GetAccount(rule.FromAccountID); err != nil {
+		return fmt.Errorf("from account %s does not exist", rule.FromAccountID)
+	}
+	if rule.ToAccountID != "" {
+		account, err := rs.storage.GetAccount(rule.ToAccountID)
+		if err != nil {
+			return fmt.Errorf("to account %s does not exist", rule.ToAccountID)
+		}
+		if account.ClosedAt != nil {
+			return fmt.Errorf("to account %s is inactive", rule.ToAccountID)
+		}
+	}
+	if (rule.ToAccountID == "" || rule.ToAccountID == rule.FromAccountID) && len(rule.SetDimensions) == 0 {
+		return fmt.Errorf("rule changes neither the account nor any dimension")
+	}
+	return nil
+}
+
+// matchEntries finds the posted entries in the batch range that a rule moves. The
+// first matching rule applies; entries already moved by another posted batch and
+// the reclassification and rollback journals themselves are skipped.
+func (rs *ReclassificationService) matchEntries(batch *ReclassBatch) ([]ReclassLine, error) {
+	moved, err := rs.movedEntries(batch.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	txns, err := rs.storage.GetTransactionsByDateRange("", batch.Start, batch.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	sort.Slice(txns, func(i, j int) bool {
+		if !txns[i].ValidTime.Equal(txns[j].ValidTime) {
+			return txns[i].ValidTime.Before(txns[j].ValidTime)
+		}
+		return txns[i].ID < txns[j].ID
+	})
+
+	var lines []ReclassLine
+	for _, txn := range txns {
+		if txn.Status != Posted || strings.HasPrefix(txn.SourceRef, "RECLASS_") {
+			continue
+		}
+		for i := range txn.Entries {
+			entry := &txn.Entries[i]
+			if moved[entry.ID] {
+				continue
+			}
+			for r, rule := range batch.Rules {
+				if entry.AccountID != rule.FromAccountID || !hasDimensions(entry.Dimensions, rule.MatchDimensions) {
+					continue
+				}
+				line := ReclassLine{
+					SourceTransactionID: txn.ID,
+					SourceEntryID:       entry.ID,
+					Rule:                r,
+					EntryType:           entry.Type,
+					Amount:              entry.Amount,
+					FromAccountID:       entry.AccountID,
+					FromDimensions:      entry.Dimensions,
+					ToAccountID:         entry.AccountID,
+					ToDimensions:        applyDimensions(entry.Dimensions, rule.SetDimensions),
+				}
+				if rule.ToAccountID != "" {
+					line.ToAccountID = rule.ToAccountID
+				}
+				if line.ToAccountID != line.FromAccountID || dimensionsKey(line.ToDimensions) != dimensionsKey(keptDimensions(line.FromDimensions, nil)) {
+					lines = append(lines, line)
+				}
+				break
+			}
+		}
+	}
+	return lines, nil
+}
+
+// movedEntries lists entries already reclassified by posted batches other than batchID
+func (rs *ReclassificationService) movedEntries(batchID string) (map[string]bool, error) {
+	batches, err := rs.storage.GetAllReclassBatches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reclassification batches: %w", err)
+	}
+	moved := make(map[string]bool)
+	for _, batch := range batches {
+		if batch.ID == batchID || batch.Status != ReclassPosted {
+			continue
+		}
+		for _, line := range batch.Lines {
+			moved[line.SourceEntryID] = true
+		}
+	}
+	return moved, nil
+}
+
+// saveBatch records an event for the batch and persists it
+func (rs *ReclassificationService) saveBatch(batch *ReclassBatch, eventType, userID string) error {
+	_, err := rs.eventStore.CreateEvent(eventType, batch, batch.PostingDate, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create reclassification event: %w", err)
+	}
+	if err := rs.storage.SaveReclassBatch(batch); err != nil {
+		return fmt.Errorf("failed to save reclassification batch: %w", err)
+	}
+	return nil
+}
+
+// newTransaction creates a pending reclassification transaction without entries
+func (rs *ReclassificationService) newTransaction(description string, validTime time.Time, sourceRef, userID string) *Transaction {
+	return &Transaction{
+		ID:              newID(),
+		Description:     description,
+		ValidTime:       validTime,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       sourceRef,
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// postTransaction records, saves and posts a reclassification transaction
+func (rs *ReclassificationService) postTransaction(txn *Transaction, userID string) error {
+	_, err := rs.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := rs.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	if err := rs.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Reclassification Mapping Files
+// ----------------------------------------------------------------------------
+
+// ParseReclassMapping reads reclassification rules from a CSV mapping file with a
+// header naming the columns from_account_id, match_dimensions, to_account_id and
+// set_dimensions. Dimensions are written "key=value;key=value".
+func ParseReclassMapping(reader io.Reader) ([]ReclassRule, error) {
+	r := csv.NewReader(reader)
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["from_account_id"]; !ok {
+		return nil, fmt.Errorf("missing column %q", "from_account_id")
+	}
+	field := func(row []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	var rules []ReclassRule
+	for rowNum := 1; ; rowNum++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNum, err)
+		}
+
+		match, err := parseImportDimensions(field(row, "match_dimensions"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNum, err)
+		}
+		set, err := parseImportDimensions(field(row, "set_dimensions"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNum, err)
+		}
+		rules = append(rules, ReclassRule{
+			FromAccountID:   field(row, "from_account_id"),
+			MatchDimensions: dimensionsFromMap(match),
+			ToAccountID:     field(row, "to_account_id"),
+			SetDimensions:   dimensionsFromMap(set),
+		})
+	}
+	return rules, nil
+}
+
+// dimensionsFromMap turns parsed key=value pairs into dimensions sorted by key
+func dimensionsFromMap(values map[string]string) []Dimension {
+	var dims []Dimension
+	for key, value := range values {
+		dims = append(dims, Dimension{Key: DimensionKey(key), Value: value})
+	}
+	sort.Slice(dims, func(i, j int) bool {
+		return dims[i].Key < dims[j].Key
+	})
+	return dims
+}
+
+// hasDimensions reports whether dims carries every dimension in required
+func hasDimensions(dims, required []Dimension) bool {
+	for _, want := range required {
+		found := false
+		for _, dim := range dims {
+			if dim.Key == want.Key && dim.Value == want.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// applyDimensions overrides dims with set, removing keys set to an empty value.
+// The result is sorted by key.
+func applyDimensions(dims, set []Dimension) []Dimension {
+	values := make(map[DimensionKey]string)
+	for _, dim := range dims {
+		values[dim.Key] = dim.Value
+	}
+	for _, dim := range set {
+		if dim.Value == "" {
+			delete(values, dim.Key)
+		} else {
+			values[dim.Key] = dim.Value
+		}
+	}
+	var result []Dimension
+	for key, value := range values {
+		result = append(result, Dimension{Key: key, Value: value})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// reclassFingerprint identifies the entries and targets of a set of lines
+func reclassFingerprint(lines []ReclassLine) string {
+	parts := make([]string, len(lines))
+	for i, line := range lines {
+		parts[i] = fmt.Sprintf("%s:%s>%s[%s]", line.SourceEntryID, line.FromAccountID, line.ToAccountID, dimensionsKey(line.ToDimensions))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, "|")
+}
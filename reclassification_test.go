@@ -0,0 +1,146 @@
+package accounting
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkReclassification(t *testing.T) {
+	dbFile := "test_reclassification.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "marketing", Code: "5100", Name: "Marketing Expenses", Type: Expense}, userID))
+
+	march := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	post := func(amount int64, department string, validTime time.Time) {
+		txn := &Transaction{
+			Description: "Spend " + department,
+			ValidTime:   validTime,
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: amount, Currency: "USD"},
+					Dimensions: []Dimension{{Key: DimDepartment, Value: department}}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: amount, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	post(30000, "sales", march)
+	post(20000, "sales", march)
+	post(10000, "ops", march)
+
+	mapping := "from_account_id,match_dimensions,to_account_id,set_dimensions\n" +
+		"expenses,department=sales,marketing,department=marketing\n" +
+		"expenses,department=ops,,project=alpha\n"
+	rules, err := ParseReclassMapping(strings.NewReader(mapping))
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "marketing", rules[0].ToAccountID)
+	assert.Equal(t, []Dimension{{Key: DimDepartment, Value: "sales"}}, rules[0].MatchDimensions)
+
+	newBatch := func() *ReclassBatch {
+		return &ReclassBatch{
+			Description: "Move sales spend to marketing",
+			Start:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			End:         time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+			PostingDate: time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+			Rules:       rules,
+		}
+	}
+	balance := func(accountID string) int64 {
+		result, err := engine.GetAccountBalance(accountID, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+
+	var batchID string
+	t.Run("previews without posting", func(t *testing.T) {
+		batch, err := engine.PreviewReclassification(newBatch(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, ReclassPreview, batch.Status)
+		require.Len(t, batch.Lines, 3)
+
+		for _, line := range batch.Lines {
+			if line.Rule == 1 {
+				assert.Equal(t, "expenses", line.ToAccountID)
+				assert.Equal(t, []Dimension{{Key: DimDepartment, Value: "ops"}, {Key: DimProject, Value: "alpha"}}, line.ToDimensions)
+			} else {
+				assert.Equal(t, "marketing", line.ToAccountID)
+			}
+		}
+		assert.Equal(t, int64(60000), balance("expenses"))
+		assert.Equal(t, int64(0), balance("marketing"))
+		batchID = batch.ID
+	})
+
+	t.Run("executes as balanced journals", func(t *testing.T) {
+		batch, err := engine.ExecuteReclassification(batchID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, ReclassPosted, batch.Status)
+		require.Len(t, batch.TransactionIDs, 1)
+
+		txn, err := engine.GetStorage().GetTransaction(batch.TransactionIDs[0])
+		require.NoError(t, err)
+		assert.Len(t, txn.Entries, 6)
+		assert.Equal(t, "RECLASS_"+batchID+"_USD", txn.SourceRef)
+
+		assert.Equal(t, int64(10000), balance("expenses"))
+		assert.Equal(t, int64(50000), balance("marketing"))
+		assert.Equal(t, int64(-60000), balance("cash"))
+
+		_, err = engine.ExecuteReclassification(batchID, userID)
+		assert.Error(t, err)
+	})
+
+	t.Run("does not move entries twice", func(t *testing.T) {
+		batch, err := engine.PreviewReclassification(newBatch(), userID)
+		require.NoError(t, err)
+		assert.Empty(t, batch.Lines)
+	})
+
+	t.Run("rolls back with contra journals", func(t *testing.T) {
+		batch, err := engine.RollbackReclassification(batchID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, ReclassRolledBack, batch.Status)
+		require.Len(t, batch.RollbackTransactionIDs, 1)
+
+		assert.Equal(t, int64(60000), balance("expenses"))
+		assert.Equal(t, int64(0), balance("marketing"))
+
+		original, err := engine.GetStorage().GetTransaction(batch.TransactionIDs[0])
+		require.NoError(t, err)
+		assert.Equal(t, Posted, original.Status, "reclassification journal stays in the ledger")
+	})
+
+	t.Run("rejects execution after the ledger changed", func(t *testing.T) {
+		batch, err := engine.PreviewReclassification(newBatch(), userID)
+		require.NoError(t, err)
+		assert.Len(t, batch.Lines, 3)
+
+		post(5000, "sales", march)
+		_, err = engine.ExecuteReclassification(batch.ID, userID)
+		assert.ErrorContains(t, err, "preview it again")
+	})
+
+	t.Run("validates rules", func(t *testing.T) {
+		batch := newBatch()
+		batch.Rules = []ReclassRule{{FromAccountID: "expenses"}}
+		_, err := engine.PreviewReclassification(batch, userID)
+		assert.ErrorContains(t, err, "changes neither")
+
+		batch.Rules = []ReclassRule{{FromAccountID: "expenses", ToAccountID: "missing"}}
+		_, err = engine.PreviewReclassification(batch, userID)
+		assert.ErrorContains(t, err, "does not exist")
+	})
+}
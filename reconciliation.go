@@ -3,8 +3,6 @@ package accounting
 import (
 	"fmt"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 // ReconciliationService handles bank statement and account reconciliation
@@ -193,7 +191,7 @@ func (rs *ReconciliationService) ConfirmReconciliation(match *ReconciliationMatc
 	}
 
 	reconciliation := &Reconciliation{
-		ID:          uuid.New().String(),
+		ID:          newID(),
 		ExternalRef: match.ExternalStatement.Reference,
 		EntryIDs:    entryIDs,
 		Status:      Reconciled,
@@ -265,7 +263,7 @@ func (rs *ReconciliationService) GetReconciliationSummary(accountID string, asOf
 // CreateManualReconciliation creates a manual reconciliation entry
 func (rs *ReconciliationService) CreateManualReconciliation(externalRef string, entryIDs []string, userID string) (*Reconciliation, error) {
 	reconciliation := &Reconciliation{
-		ID:          uuid.New().String(),
+		ID:          newID(),
 		ExternalRef: externalRef,
 		EntryIDs:    entryIDs,
 		Status:      Reconciled,
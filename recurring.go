@@ -0,0 +1,493 @@
+package accounting
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Recurring Transaction Structures
+// ----------------------------------------------------------------------------
+
+// ProrationRule controls how an occurrence cut short by the template's end date is posted
+type ProrationRule string
+
+const (
+	// ProrationNone posts the full amount for every occurrence
+	ProrationNone ProrationRule = "NONE"
+	// ProrationDaily scales the final occurrence by the days it covers before the end date
+	ProrationDaily ProrationRule = "DAILY"
+)
+
+// RecurringTemplateStatus tracks whether a template still generates transactions
+type RecurringTemplateStatus string
+
+const (
+	RecurringActive    RecurringTemplateStatus = "ACTIVE"
+	RecurringPaused    RecurringTemplateStatus = "PAUSED"
+	RecurringCompleted RecurringTemplateStatus = "COMPLETED"
+)
+
+// RecurringLine is one entry of a recurring transaction, in minor units of the template currency
+type RecurringLine struct {
+	AccountID  string      `json:"account_id"`
+	Type       EntryType   `json:"type"`
+	Amount     int64       `json:"amount"`
+	Dimensions []Dimension `json:"dimensions,omitempty"`
+}
+
+// RecurringTransactionTemplate describes a transaction re-entered on a fixed
+// schedule, such as monthly rent or payroll. Occurrence n falls due n periods
+// after StartDate; none are generated after EndDate.
+type RecurringTransactionTemplate struct {
+	ID          string                  `json:"id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description,omitempty"`
+	Currency    Currency                `json:"currency"`
+	Lines       []RecurringLine         `json:"lines"`
+	Frequency   ScheduleFrequency       `json:"frequency"`
+	StartDate   time.Time               `json:"start_date"`
+	EndDate     *time.Time              `json:"end_date,omitempty"`
+	Proration   ProrationRule           `json:"proration"`
+	NextRunDate time.Time               `json:"next_run_date"`
+	RunCount    int                     `json:"run_count"` // occurrences posted or skipped
+	Status      RecurringTemplateStatus `json:"status"`
+	CreatedBy   string                  `json:"created_by"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+// RecurringRunStatus is the outcome of a single scheduled occurrence
+type RecurringRunStatus string
+
+const (
+	RecurringRunPosted  RecurringRunStatus = "POSTED"
+	RecurringRunSkipped RecurringRunStatus = "SKIPPED"
+	RecurringRunFailed  RecurringRunStatus = "FAILED"
+)
+
+// RecurringRun records what happened to one occurrence of a template. Its ID is
+// derived from the template and scheduled date, so an occurrence is only ever
+// posted once; failed occurrences are retried on the next run.
+type RecurringRun struct {
+	ID            string             `json:"id"`
+	TemplateID    string             `json:"template_id"`
+	ScheduledDate time.Time          `json:"scheduled_date"`
+	Status        RecurringRunStatus `json:"status"`
+	TransactionID string             `json:"transaction_id,omitempty"`
+	Amount        int64              `json:"amount"` // total debits posted
+	Reason        string             `json:"reason,omitempty"`
+	Attempts      int                `json:"attempts"`
+	RunBy         string             `json:"run_by"`
+	RunAt         time.Time          `json:"run_at"`
+}
+
+// ----------------------------------------------------------------------------
+// Recurring Transaction Service
+// ----------------------------------------------------------------------------
+
+// RecurringTransactionService generates and posts transactions from recurring templates
+type RecurringTransactionService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+}
+
+// NewRecurringTransactionService creates a new recurring transaction service
+func NewRecurringTransactionService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *RecurringTransactionService {
+	return &RecurringTransactionService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+	}
+}
+
+// CreateTemplate validates and saves a recurring transaction template
+func (rs *RecurringTransactionService) CreateTemplate(template *RecurringTransactionTemplate, userID string) error {
+	if template.Name == "" {
+		return fmt.Errorf("template name is required")
+	}
+	if template.Currency == "" {
+		return fmt.Errorf("template currency is required")
+	}
+	switch template.Frequency {
+	case Monthly, Quarterly, Yearly:
+	case "":
+		template.Frequency = Monthly
+	default:
+		return fmt.Errorf("unsupported frequency: %s", template.Frequency)
+	}
+	switch template.Proration {
+	case ProrationNone, ProrationDaily:
+	case "":
+		template.Proration = ProrationNone
+	default:
+		return fmt.Errorf("unsupported proration rule: %s", template.Proration)
+	}
+	if template.StartDate.IsZero() {
+		return fmt.Errorf("start date is required")
+	}
+	if template.EndDate != nil && template.EndDate.Before(template.StartDate) {
+		return fmt.Errorf("end date cannot be before start date")
+	}
+	if len(template.Lines) < 2 {
+		return fmt.Errorf("template must have at least two lines")
+	}
+
+	var debits, credits int64
+	for _, line := range template.Lines {
+		if line.Amount <= 0 {
+			return fmt.Errorf("line amounts must be positive")
+		}
+		if _, err := rs.storage.GetAccount(line.AccountID); err != nil {
+			return fmt.Errorf("invalid account %s: %w", line.AccountID, err)
+		}
+		switch line.Type {
+		case Debit:
+			debits += line.Amount
+		case Credit:
+			credits += line.Amount
+		default:
+			return fmt.Errorf("invalid entry type: %s", line.Type)
+		}
+	}
+	if debits != credits {
+		return classify(ErrUnbalanced, "template does not balance: debits=%d, credits=%d", debits, credits)
+	}
+
+	if err := rs.storage.assignID(&template.ID, "recurring template", BucketRecurringTemplates); err != nil {
+		return err
+	}
+	template.NextRunDate = template.StartDate
+	template.RunCount = 0
+	template.Status = RecurringActive
+	template.CreatedBy = userID
+	template.CreatedAt = time.Now()
+	template.UpdatedAt = template.CreatedAt
+
+	return rs.saveTemplate(template, EventCreateRecurringTemplate, userID)
+}
+
+// PauseTemplate stops a template from posting. Occurrences falling due while it
+// is paused are recorded as skipped rather than caught up on resumption.
+func (rs *RecurringTransactionService) PauseTemplate(templateID, userID string) (*RecurringTransactionTemplate, error) {
+	return rs.setStatus(templateID, RecurringActive, RecurringPaused, userID)
+}
+
+// ResumeTemplate resumes posting for a paused template
+func (rs *RecurringTransactionService) ResumeTemplate(templateID, userID string) (*RecurringTransactionTemplate, error) {
+	return rs.setStatus(templateID, RecurringPaused, RecurringActive, userID)
+}
+
+// RunDue processes every template occurrence falling due on or before the date and
+// returns the runs recorded. Occurrences already posted or skipped are not repeated,
+// so it is safe to call as often as needed. A failed occurrence stops its template
+// for this call and is retried the next time RunDue is called.
+func (rs *RecurringTransactionService) RunDue(asOfDate time.Time, userID string) ([]*RecurringRun, error) {
+	templates, err := rs.storage.GetAllRecurringTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring templates: %w", err)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].NextRunDate.Before(templates[j].NextRunDate)
+	})
+
+	var runs []*RecurringRun
+	for _, template := range templates {
+		if template.Status == RecurringCompleted {
+			continue
+		}
+
+		changed := false
+		for !template.NextRunDate.After(asOfDate) {
+			if template.EndDate != nil && template.NextRunDate.After(*template.EndDate) {
+				template.Status = RecurringCompleted
+				changed = true
+				break
+			}
+
+			run, err := rs.runOccurrence(template, userID)
+			if err != nil {
+				return runs, err
+			}
+			if run == nil {
+				// Recorded by an earlier call that did not get to advance the template
+				template.advance()
+				changed = true
+				continue
+			}
+			runs = append(runs, run)
+			if run.Status == RecurringRunFailed {
+				break
+			}
+			template.advance()
+			changed = true
+		}
+
+		if template.Status == RecurringActive && template.EndDate != nil && template.NextRunDate.After(*template.EndDate) {
+			template.Status = RecurringCompleted
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+		template.UpdatedAt = time.Now()
+		if err := rs.saveTemplate(template, EventUpdateRecurringTemplate, userID); err != nil {
+			return runs, err
+		}
+	}
+
+	return runs, nil
+}
+
+// GetRuns returns the recorded runs of a template in schedule order
+func (rs *RecurringTransactionService) GetRuns(templateID string) ([]*RecurringRun, error) {
+	all, err := rs.storage.GetAllRecurringRuns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring runs: %w", err)
+	}
+
+	var runs []*RecurringRun
+	for _, run := range all {
+		if run.TemplateID == templateID {
+			runs = append(runs, run)
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].ScheduledDate.Before(runs[j].ScheduledDate)
+	})
+	return runs, nil
+}
+
+// runOccurrence posts or skips the template's next occurrence and records the run.
+// It returns nil when the occurrence was already posted or skipped.
+func (rs *RecurringTransactionService) runOccurrence(template *RecurringTransactionTemplate, userID string) (*RecurringRun, error) {
+	scheduled := template.NextRunDate
+	run := &RecurringRun{
+		ID:            fmt.Sprintf("%s_%s", template.ID, scheduled.Format("2006-01-02")),
+		TemplateID:    template.ID,
+		ScheduledDate: scheduled,
+		Attempts:      1,
+		RunBy:         userID,
+		RunAt:         time.Now(),
+	}
+	if previous, err := rs.storage.GetRecurringRun(run.ID); err == nil {
+		if previous.Status != RecurringRunFailed {
+			return nil, nil
+		}
+		run.Attempts = previous.Attempts + 1
+	}
+
+	lines, err := template.occurrenceLines()
+	switch {
+	case err != nil:
+		run.Status = RecurringRunFailed
+		run.Reason = err.Error()
+	case template.Status == RecurringPaused:
+		run.Status = RecurringRunSkipped
+		run.Reason = "template paused"
+	default:
+		txn := template.buildTransaction(lines, userID)
+		for _, entry := range txn.Entries {
+			if entry.Type == Debit {
+				run.Amount += entry.Amount.Value
+			}
+		}
+		if run.Amount == 0 {
+			run.Status = RecurringRunSkipped
+			run.Reason = "prorated amount is zero"
+			break
+		}
+		if err := rs.postTransaction(txn, userID); err != nil {
+			run.Status = RecurringRunFailed
+			run.Reason = err.Error()
+			run.Amount = 0
+			break
+		}
+		run.Status = RecurringRunPosted
+		run.TransactionID = txn.ID
+	}
+
+	_, err = rs.eventStore.CreateEvent(EventRecordRecurringRun, run, scheduled, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recurring run event: %w", err)
+	}
+	if err := rs.storage.SaveRecurringRun(run); err != nil {
+		return nil, fmt.Errorf("failed to save recurring run: %w", err)
+	}
+	return run, nil
+}
+
+// advance moves the template to its next occurrence, counted from the start date
+// so month-end dates do not drift
+func (t *RecurringTransactionTemplate) advance() {
+	t.RunCount++
+	t.NextRunDate = t.occurrenceDate(t.RunCount)
+}
+
+// occurrenceDate returns the scheduled date of the n-th occurrence, starting at zero.
+// Days past the end of a shorter month fall on its last day rather than rolling over.
+func (t *RecurringTransactionTemplate) occurrenceDate(n int) time.Time {
+	months := n
+	switch t.Frequency {
+	case Quarterly:
+		months = 3 * n
+	case Yearly:
+		months = 12 * n
+	}
+
+	start := t.StartDate
+	firstOfMonth := time.Date(start.Year(), start.Month()+time.Month(months), 1,
+		start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := start.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// occurrenceLines returns the lines to post for the next occurrence. Under daily
+// proration an occurrence whose period runs past the end date is scaled by the
+// share of its days up to and including the end date; each side of the entry is
+// allocated from the same prorated total so the transaction still balances.
+func (t *RecurringTransactionTemplate) occurrenceLines() ([]RecurringLine, error) {
+	if t.Proration != ProrationDaily || t.EndDate == nil {
+		return t.Lines, nil
+	}
+	start := t.NextRunDate
+	next := t.occurrenceDate(t.RunCount + 1)
+	coveredEnd := t.EndDate.AddDate(0, 0, 1)
+	if !coveredEnd.Before(next) {
+		return t.Lines, nil
+	}
+
+	periodDays := int64(next.Sub(start).Hours()/24 + 0.5)
+	coveredDays := int64(coveredEnd.Sub(start).Hours()/24 + 0.5)
+	factor := big.NewRat(coveredDays, periodDays)
+
+	var total int64
+	for _, line := range t.Lines {
+		if line.Type == Debit {
+			total += line.Amount
+		}
+	}
+	prorated, err := roundRat(new(big.Rat).Mul(new(big.Rat).SetInt64(total), factor), RoundHalfUp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prorate occurrence: %w", err)
+	}
+
+	lines := make([]RecurringLine, len(t.Lines))
+	copy(lines, t.Lines)
+	for _, side := range []EntryType{Debit, Credit} {
+		remaining := prorated
+		last := -1
+		for i, line := range lines {
+			if line.Type != side {
+				continue
+			}
+			share, err := roundRat(new(big.Rat).Mul(big.NewRat(line.Amount, total), new(big.Rat).SetInt64(prorated)), RoundHalfUp)
+			if err != nil {
+				return nil, fmt.Errorf("failed to prorate line: %w", err)
+			}
+			lines[i].Amount = share
+			remaining -= share
+			last = i
+		}
+		if last >= 0 {
+			lines[last].Amount += remaining
+		}
+	}
+	return lines, nil
+}
+
+// buildTransaction creates the pending transaction for the next occurrence
+func (t *RecurringTransactionTemplate) buildTransaction(lines []RecurringLine, userID string) *Transaction {
+	description := t.Description
+	if description == "" {
+		description = t.Name
+	}
+	txn := &Transaction{
+		ID:              newID(),
+		Description:     fmt.Sprintf("%s (%s)", description, t.NextRunDate.Format("2006-01-02")),
+		ValidTime:       t.NextRunDate,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       fmt.Sprintf("RECURRING_%s_%s", t.ID, t.NextRunDate.Format("2006-01-02")),
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	for _, line := range lines {
+		if line.Amount == 0 {
+			continue
+		}
+		txn.Entries = append(txn.Entries, Entry{
+			ID:            newID(),
+			TransactionID: txn.ID,
+			AccountID:     line.AccountID,
+			Type:          line.Type,
+			Amount:        Amount{Value: line.Amount, Currency: t.Currency},
+			Dimensions:    line.Dimensions,
+		})
+	}
+	return txn
+}
+
+// setStatus moves a template between active and paused
+func (rs *RecurringTransactionService) setStatus(templateID string, from, to RecurringTemplateStatus, userID string) (*RecurringTransactionTemplate, error) {
+	template, err := rs.storage.GetRecurringTemplate(templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recurring template: %w", err)
+	}
+	if template.Status != from {
+		return nil, fmt.Errorf("template is %s, expected %s", template.Status, from)
+	}
+	template.Status = to
+	template.UpdatedAt = time.Now()
+	if err := rs.saveTemplate(template, EventUpdateRecurringTemplate, userID); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// postTransaction validates, records, saves and posts a generated transaction.
+// Validation happens first so a failed occurrence leaves no pending transaction behind.
+func (rs *RecurringTransactionService) postTransaction(txn *Transaction, userID string) error {
+	if err := rs.postingEngine.ValidateTransaction(txn).Err(); err != nil {
+		return err
+	}
+
+	_, err := rs.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := rs.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	if err := rs.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
+
+// saveTemplate records an event for the template and persists it
+func (rs *RecurringTransactionService) saveTemplate(template *RecurringTransactionTemplate, eventType, userID string) error {
+	_, err := rs.eventStore.CreateEvent(eventType, template, template.NextRunDate, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create recurring template event: %w", err)
+	}
+	if err := rs.storage.SaveRecurringTemplate(template); err != nil {
+		return fmt.Errorf("failed to save recurring template: %w", err)
+	}
+	return nil
+}
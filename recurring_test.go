@@ -0,0 +1,147 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurringTransactions(t *testing.T) {
+	// Setup
+	dbFile := "test_recurring.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "scheduler"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	date := func(month time.Month, day int) time.Time { return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC) }
+	runsFor := func(runs []*RecurringRun, templateID string) []*RecurringRun {
+		var filtered []*RecurringRun
+		for _, run := range runs {
+			if run.TemplateID == templateID {
+				filtered = append(filtered, run)
+			}
+		}
+		return filtered
+	}
+	leaseEnd := date(time.March, 15)
+
+	rent := &RecurringTransactionTemplate{
+		Name:      "Office rent",
+		Currency:  "USD",
+		StartDate: date(time.January, 1),
+		EndDate:   &leaseEnd,
+		Proration: ProrationDaily,
+		Lines: []RecurringLine{
+			{AccountID: "expenses", Type: Debit, Amount: 100000},
+			{AccountID: "cash", Type: Credit, Amount: 100000},
+		},
+	}
+	require.NoError(t, engine.CreateRecurringTemplate(rent, userID))
+	assert.Equal(t, Monthly, rent.Frequency)
+
+	t.Run("Posts Due Occurrences Once", func(t *testing.T) {
+		runs, err := engine.RunDue(date(time.February, 15), userID)
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		for _, run := range runs {
+			assert.Equal(t, RecurringRunPosted, run.Status)
+			assert.NotEmpty(t, run.TransactionID)
+		}
+
+		runs, err = engine.RunDue(date(time.February, 15), userID)
+		require.NoError(t, err)
+		assert.Empty(t, runs)
+
+		expenses, err := engine.GetAccountBalance("expenses", date(time.February, 29))
+		require.NoError(t, err)
+		assert.Equal(t, int64(200000), expenses.Balance.Value)
+	})
+
+	t.Run("Prorates Final Occurrence", func(t *testing.T) {
+		runs, err := engine.RunDue(date(time.June, 1), userID)
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		// 15 of March's 31 days
+		assert.Equal(t, int64(48387), runs[0].Amount)
+
+		template, err := engine.GetStorage().GetRecurringTemplate(rent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, RecurringCompleted, template.Status)
+		assert.Equal(t, 3, template.RunCount)
+	})
+
+	t.Run("Skips While Paused", func(t *testing.T) {
+		payroll := &RecurringTransactionTemplate{
+			Name:      "Payroll",
+			Currency:  "USD",
+			StartDate: date(time.January, 31),
+			Lines: []RecurringLine{
+				{AccountID: "expenses", Type: Debit, Amount: 500000},
+				{AccountID: "cash", Type: Credit, Amount: 500000},
+			},
+		}
+		require.NoError(t, engine.CreateRecurringTemplate(payroll, userID))
+		_, err := engine.PauseRecurringTemplate(payroll.ID, userID)
+		require.NoError(t, err)
+
+		runs, err := engine.RunDue(date(time.January, 31), userID)
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.Equal(t, RecurringRunSkipped, runs[0].Status)
+
+		_, err = engine.ResumeRecurringTemplate(payroll.ID, userID)
+		require.NoError(t, err)
+		runs, err = engine.RunDue(date(time.February, 29), userID)
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.Equal(t, RecurringRunPosted, runs[0].Status)
+		assert.Equal(t, date(time.February, 29), runs[0].ScheduledDate, "month-end dates do not drift")
+	})
+
+	t.Run("Retries Failed Occurrence", func(t *testing.T) {
+		insurance := &RecurringTransactionTemplate{
+			Name:      "Insurance",
+			Currency:  "USD",
+			Frequency: Quarterly,
+			StartDate: date(time.January, 1),
+			Lines: []RecurringLine{
+				{AccountID: "expenses", Type: Debit, Amount: 30000},
+				{AccountID: "cash", Type: Credit, Amount: 30000},
+			},
+		}
+		require.NoError(t, engine.CreateRecurringTemplate(insurance, userID))
+
+		// Corrupt the stored template so posting fails
+		insurance.Lines[1].AccountID = "missing_account"
+		require.NoError(t, engine.GetStorage().SaveRecurringTemplate(insurance))
+
+		runs, err := engine.RunDue(date(time.April, 1), userID)
+		require.NoError(t, err)
+		runs = runsFor(runs, insurance.ID)
+		require.Len(t, runs, 1, "a failed occurrence stops its template")
+		assert.Equal(t, RecurringRunFailed, runs[0].Status)
+		assert.NotEmpty(t, runs[0].Reason)
+
+		insurance.Lines[1].AccountID = "cash"
+		require.NoError(t, engine.GetStorage().SaveRecurringTemplate(insurance))
+
+		runs, err = engine.RunDue(date(time.April, 1), userID)
+		require.NoError(t, err)
+		runs = runsFor(runs, insurance.ID)
+		require.Len(t, runs, 2)
+		assert.Equal(t, RecurringRunPosted, runs[0].Status)
+		assert.Equal(t, 2, runs[0].Attempts)
+
+		history, err := engine.GetRecurringTransactionService().GetRuns(insurance.ID)
+		require.NoError(t, err)
+		assert.Len(t, history, 2)
+	})
+}
@@ -0,0 +1,681 @@
+package accounting
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Refund and Chargeback Structures
+// ----------------------------------------------------------------------------
+
+// SaleReversalType distinguishes a merchant-initiated refund from a chargeback
+// forced by the card issuer
+type SaleReversalType string
+
+const (
+	ReversalRefund     SaleReversalType = "REFUND"
+	ReversalChargeback SaleReversalType = "CHARGEBACK"
+)
+
+// SaleReversalStatus tracks a refund to completion and a chargeback through its dispute
+type SaleReversalStatus string
+
+const (
+	ReversalCompleted  SaleReversalStatus = "COMPLETED" // refund posted
+	ChargebackOpen     SaleReversalStatus = "OPEN"      // funds withdrawn, no response yet
+	ChargebackDisputed SaleReversalStatus = "DISPUTED"  // representment submitted to the issuer
+	ChargebackWon      SaleReversalStatus = "WON"       // issuer sided with the merchant, sale reinstated
+	ChargebackLost     SaleReversalStatus = "LOST"      // accepted or lost, reversal stands
+)
+
+// DefaultChargebackAlertRate is the chargeback-to-sales ratio above which a product
+// or channel is raised for fraud review, in line with common card scheme thresholds
+const DefaultChargebackAlertRate = 0.01
+
+// SaleReversalRequest describes a refund or chargeback against a posted sale. Amount is
+// the gross amount returned to the customer; every line of the sale, including revenue,
+// tax and fees, is reversed in proportion to it.
+type SaleReversalRequest struct {
+	SaleTransactionID string    `json:"sale_transaction_id"`
+	Amount            int64     `json:"amount"`
+	Date              time.Time `json:"date"`
+	Reason            string    `json:"reason"`
+	ReasonCode        string    `json:"reason_code,omitempty"` // issuer reason code on chargebacks
+	// Optional fee charged by the processor on a chargeback
+	FeeAmount           int64  `json:"fee_amount,omitempty"`
+	FeeAccountID        string `json:"fee_account_id,omitempty"`
+	SettlementAccountID string `json:"settlement_account_id,omitempty"` // account the fee is withdrawn from
+}
+
+// SaleReversal is a refund or chargeback linked to the sale it reverses
+type SaleReversal struct {
+	ID                         string             `json:"id"`
+	Type                       SaleReversalType   `json:"type"`
+	SaleTransactionID          string             `json:"sale_transaction_id"`
+	Amount                     *Amount            `json:"amount"`
+	SaleAmount                 *Amount            `json:"sale_amount"`
+	Product                    string             `json:"product,omitempty"`
+	Channel                    string             `json:"channel,omitempty"`
+	Reason                     string             `json:"reason"`
+	ReasonCode                 string             `json:"reason_code,omitempty"`
+	Status                     SaleReversalStatus `json:"status"`
+	ReversalDate               time.Time          `json:"reversal_date"`
+	TransactionID              string             `json:"transaction_id"`
+	FeeTransactionID           string             `json:"fee_transaction_id,omitempty"`
+	ReinstatementTransactionID string             `json:"reinstatement_transaction_id,omitempty"`
+	DisputeEvidence            string             `json:"dispute_evidence,omitempty"`
+	ResolvedBy                 string             `json:"resolved_by,omitempty"`
+	ResolvedAt                 *time.Time         `json:"resolved_at,omitempty"`
+	CreatedBy                  string             `json:"created_by"`
+	CreatedAt                  time.Time          `json:"created_at"`
+	UpdatedAt                  time.Time          `json:"updated_at"`
+}
+
+// ChargebackRate is the chargeback experience of one product and channel
+type ChargebackRate struct {
+	Product          string  `json:"product"`
+	Channel          string  `json:"channel"`
+	SaleCount        int     `json:"sale_count"`
+	SaleAmount       int64   `json:"sale_amount"`
+	ChargebackCount  int     `json:"chargeback_count"`
+	ChargebackAmount int64   `json:"chargeback_amount"`
+	CountRate        float64 `json:"count_rate"`  // chargebacks per sale
+	AmountRate       float64 `json:"amount_rate"` // chargeback amount per sale amount
+	Flagged          bool    `json:"flagged"`
+	AlertID          string  `json:"alert_id,omitempty"`
+}
+
+// ChargebackRateReport compares chargebacks to sales per product and channel for a period
+type ChargebackRateReport struct {
+	PeriodStart time.Time         `json:"period_start"`
+	PeriodEnd   time.Time         `json:"period_end"`
+	AlertRate   float64           `json:"alert_rate"`
+	Rates       []*ChargebackRate `json:"rates"`
+	GeneratedAt time.Time         `json:"generated_at"`
+}
+
+// ----------------------------------------------------------------------------
+// Refund and Chargeback Service
+// ----------------------------------------------------------------------------
+
+// RefundService posts refunds and chargebacks against sales and tracks chargeback disputes
+type RefundService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+	amlService    *AMLService
+	alertRate     float64
+	mutex         sync.RWMutex
+}
+
+// NewRefundService creates a new refund and chargeback service
+func NewRefundService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine, amlService *AMLService) *RefundService {
+	return &RefundService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+		amlService:    amlService,
+		alertRate:     DefaultChargebackAlertRate,
+	}
+}
+
+// SetChargebackAlertRate sets the chargeback-to-sales ratio that triggers a fraud alert
+func (rs *RefundService) SetChargebackAlertRate(rate float64) error {
+	if rate <= 0 || rate > 1 {
+		return fmt.Errorf("alert rate must be between 0 and 1")
+	}
+	rs.mutex.Lock()
+	defer rs.mutex.Unlock()
+	rs.alertRate = rate
+	return nil
+}
+
+// RefundSale posts a refund reversing the sale in proportion to the amount refunded
+func (rs *RefundService) RefundSale(request SaleReversalRequest, userID string) (*SaleReversal, error) {
+	if request.FeeAmount != 0 {
+		return nil, fmt.Errorf("fees apply to chargebacks only")
+	}
+	reversal, err := rs.reverse(ReversalRefund, request, userID)
+	if err != nil {
+		return nil, err
+	}
+	reversal.Status = ReversalCompleted
+	if err := rs.saveReversal(reversal, EventRefundSale, userID); err != nil {
+		return nil, err
+	}
+	return reversal, nil
+}
+
+// RecordChargeback posts a chargeback reversing the sale, plus any chargeback fee,
+// and opens it for dispute
+func (rs *RefundService) RecordChargeback(request SaleReversalRequest, userID string) (*SaleReversal, error) {
+	if request.FeeAmount < 0 {
+		return nil, fmt.Errorf("chargeback fee cannot be negative")
+	}
+	if request.FeeAmount > 0 {
+		for _, accountID := range []string{request.FeeAccountID, request.SettlementAccountID} {
+			if _, err := rs.storage.GetAccount(accountID); err != nil {
+				return nil, fmt.Errorf("invalid chargeback fee account %s: %w", accountID, err)
+			}
+		}
+	}
+
+	reversal, err := rs.reverse(ReversalChargeback, request, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.FeeAmount > 0 {
+		fee := Amount{Value: request.FeeAmount, Currency: reversal.Amount.Currency}
+		txn := &Transaction{
+			ID:              newID(),
+			Description:     fmt.Sprintf("Chargeback fee on sale %s", reversal.SaleTransactionID),
+			ValidTime:       reversal.ReversalDate,
+			TransactionTime: time.Now(),
+			Status:          Pending,
+			SourceRef:       fmt.Sprintf("CHARGEBACK_FEE_%s", reversal.ID),
+			UserID:          userID,
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+			Entries: []Entry{
+				{ID: newID(), AccountID: request.FeeAccountID, Type: Debit, Amount: fee},
+				{ID: newID(), AccountID: request.SettlementAccountID, Type: Credit, Amount: fee},
+			},
+		}
+		if err := rs.postTransaction(txn, userID); err != nil {
+			return nil, err
+		}
+		reversal.FeeTransactionID = txn.ID
+	}
+
+	reversal.Status = ChargebackOpen
+	if err := rs.saveReversal(reversal, EventRecordChargeback, userID); err != nil {
+		return nil, err
+	}
+	return reversal, nil
+}
+
+// SubmitDispute records the evidence submitted to the issuer to contest an open chargeback
+func (rs *RefundService) SubmitDispute(chargebackID, evidence, userID string) (*SaleReversal, error) {
+	reversal, err := rs.getChargeback(chargebackID)
+	if err != nil {
+		return nil, err
+	}
+	if reversal.Status != ChargebackOpen {
+		return nil, fmt.Errorf("chargeback is %s, only open chargebacks can be disputed", reversal.Status)
+	}
+	if evidence == "" {
+		return nil, fmt.Errorf("dispute evidence is required")
+	}
+
+	reversal.Status = ChargebackDisputed
+	reversal.DisputeEvidence = evidence
+	reversal.UpdatedAt = time.Now()
+	if err := rs.saveReversal(reversal, EventUpdateChargeback, userID); err != nil {
+		return nil, err
+	}
+	return reversal, nil
+}
+
+// AcceptChargeback closes an open chargeback without contesting it
+func (rs *RefundService) AcceptChargeback(chargebackID, userID string) (*SaleReversal, error) {
+	reversal, err := rs.getChargeback(chargebackID)
+	if err != nil {
+		return nil, err
+	}
+	if reversal.Status != ChargebackOpen {
+		return nil, fmt.Errorf("chargeback is %s, only open chargebacks can be accepted", reversal.Status)
+	}
+	return rs.resolve(reversal, ChargebackLost, userID)
+}
+
+// ResolveDispute records the issuer's decision on a disputed chargeback. A won
+// dispute reinstates the sale by posting the reverse of the chargeback; any
+// chargeback fee is not recovered.
+func (rs *RefundService) ResolveDispute(chargebackID string, won bool, resolvedAt time.Time, userID string) (*SaleReversal, error) {
+	reversal, err := rs.getChargeback(chargebackID)
+	if err != nil {
+		return nil, err
+	}
+	if reversal.Status != ChargebackDisputed {
+		return nil, fmt.Errorf("chargeback is %s, only disputed chargebacks can be resolved", reversal.Status)
+	}
+	if !won {
+		return rs.resolve(reversal, ChargebackLost, userID)
+	}
+
+	original, err := rs.storage.GetTransaction(reversal.TransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chargeback transaction: %w", err)
+	}
+	txn := &Transaction{
+		ID:              newID(),
+		Description:     fmt.Sprintf("Chargeback reversed in merchant's favour on sale %s", reversal.SaleTransactionID),
+		ValidTime:       resolvedAt,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       fmt.Sprintf("CHARGEBACK_WON_%s", reversal.ID),
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	for _, entry := range original.Entries {
+		entry.ID = newID()
+		entry.TransactionID = txn.ID
+		entry.Type = oppositeEntryType(entry.Type)
+		txn.Entries = append(txn.Entries, entry)
+	}
+	if err := rs.postTransaction(txn, userID); err != nil {
+		return nil, err
+	}
+	reversal.ReinstatementTransactionID = txn.ID
+
+	return rs.resolve(reversal, ChargebackWon, userID)
+}
+
+// GetReversals returns the refunds and chargebacks recorded against a sale
+func (rs *RefundService) GetReversals(saleTransactionID string) ([]*SaleReversal, error) {
+	all, err := rs.storage.GetAllSaleReversals()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sale reversals: %w", err)
+	}
+
+	var reversals []*SaleReversal
+	for _, reversal := range all {
+		if reversal.SaleTransactionID == saleTransactionID {
+			reversals = append(reversals, reversal)
+		}
+	}
+	sort.Slice(reversals, func(i, j int) bool {
+		return reversals[i].ReversalDate.Before(reversals[j].ReversalDate)
+	})
+	return reversals, nil
+}
+
+// GenerateChargebackRateReport compares chargebacks received in the period with sales
+// made in it, per product and channel. Sales are posted transactions crediting an
+// income account, grouped by the product and channel dimensions on that entry.
+func (rs *RefundService) GenerateChargebackRateReport(periodStart, periodEnd time.Time) (*ChargebackRateReport, error) {
+	rs.mutex.RLock()
+	alertRate := rs.alertRate
+	rs.mutex.RUnlock()
+
+	txns, err := rs.storage.GetTransactionsByDateRange("", periodStart, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	rates := make(map[string]*ChargebackRate)
+	rateFor := func(product, channel string) *ChargebackRate {
+		key := product + "|" + channel
+		if rates[key] == nil {
+			rates[key] = &ChargebackRate{Product: product, Channel: channel}
+		}
+		return rates[key]
+	}
+
+	accountTypes := make(map[string]AccountType)
+	for _, txn := range txns {
+		if txn.Status != Posted || isReversalSourceRef(txn.SourceRef) {
+			continue
+		}
+		isSale := false
+		for _, entry := range txn.Entries {
+			if entry.Type != Credit {
+				continue
+			}
+			accountType, ok := accountTypes[entry.AccountID]
+			if !ok {
+				account, err := rs.storage.GetAccount(entry.AccountID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get account %s: %w", entry.AccountID, err)
+				}
+				accountType = account.Type
+				accountTypes[entry.AccountID] = accountType
+			}
+			if accountType == Income {
+				isSale = true
+				break
+			}
+		}
+		if !isSale {
+			continue
+		}
+
+		product, channel := saleDimensions(txn)
+		rate := rateFor(product, channel)
+		rate.SaleCount++
+		rate.SaleAmount += saleGross(txn)
+	}
+
+	reversals, err := rs.storage.GetAllSaleReversals()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sale reversals: %w", err)
+	}
+	for _, reversal := range reversals {
+		if reversal.Type != ReversalChargeback ||
+			reversal.ReversalDate.Before(periodStart) || reversal.ReversalDate.After(periodEnd) {
+			continue
+		}
+		rate := rateFor(reversal.Product, reversal.Channel)
+		rate.ChargebackCount++
+		rate.ChargebackAmount += reversal.Amount.Value
+	}
+
+	report := &ChargebackRateReport{
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		AlertRate:   alertRate,
+		GeneratedAt: time.Now(),
+	}
+	for _, rate := range rates {
+		if rate.SaleCount > 0 {
+			rate.CountRate = float64(rate.ChargebackCount) / float64(rate.SaleCount)
+		}
+		if rate.SaleAmount > 0 {
+			rate.AmountRate = float64(rate.ChargebackAmount) / float64(rate.SaleAmount)
+		}
+		rate.Flagged = rate.ChargebackCount > 0 && (rate.SaleCount == 0 || rate.CountRate > alertRate)
+		report.Rates = append(report.Rates, rate)
+	}
+	sort.Slice(report.Rates, func(i, j int) bool {
+		if report.Rates[i].CountRate != report.Rates[j].CountRate {
+			return report.Rates[i].CountRate > report.Rates[j].CountRate
+		}
+		if report.Rates[i].Product != report.Rates[j].Product {
+			return report.Rates[i].Product < report.Rates[j].Product
+		}
+		return report.Rates[i].Channel < report.Rates[j].Channel
+	})
+
+	return report, nil
+}
+
+// MonitorChargebackRates generates the chargeback rate report and raises an AML alert
+// for every product and channel above the alert rate so it joins the fraud review queue
+func (rs *RefundService) MonitorChargebackRates(periodStart, periodEnd time.Time) (*ChargebackRateReport, error) {
+	report, err := rs.GenerateChargebackRateReport(periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rate := range report.Rates {
+		if !rate.Flagged {
+			continue
+		}
+		riskLevel := RiskMedium
+		if rate.CountRate > 2*report.AlertRate {
+			riskLevel = RiskHigh
+		}
+		alert := &AMLAlert{
+			ID:        newID(),
+			RuleType:  RuleChargebackRate,
+			RiskLevel: riskLevel,
+			Title:     fmt.Sprintf("Chargeback rate %.2f%% for %s / %s", rate.CountRate*100, rate.Product, rate.Channel),
+			Description: fmt.Sprintf("%d chargebacks against %d sales between %s and %s exceed the %.2f%% alert rate",
+				rate.ChargebackCount, rate.SaleCount, periodStart.Format("2006-01-02"), periodEnd.Format("2006-01-02"), report.AlertRate*100),
+			EntityID:   fmt.Sprintf("%s|%s", rate.Product, rate.Channel),
+			EntityType: "PRODUCT_CHANNEL",
+			DetectedAt: report.GeneratedAt,
+			Status:     "OPEN",
+			Evidence: []AMLEvidence{{
+				Type:        "PATTERN",
+				Description: "Chargeback to sales ratio",
+				Value:       rate.CountRate,
+				Source:      "chargeback_rate_report",
+				Confidence:  1.0,
+				CollectedAt: report.GeneratedAt,
+			}},
+			CreatedAt: report.GeneratedAt,
+			UpdatedAt: report.GeneratedAt,
+		}
+		if err := rs.amlService.RaiseAlert(alert); err != nil {
+			return nil, fmt.Errorf("failed to raise AML alert: %w", err)
+		}
+		rate.AlertID = alert.ID
+	}
+
+	return report, nil
+}
+
+// reverse validates a refund or chargeback against the sale and posts the
+// proportional reversal of its entries
+func (rs *RefundService) reverse(reversalType SaleReversalType, request SaleReversalRequest, userID string) (*SaleReversal, error) {
+	if request.Amount <= 0 {
+		return nil, fmt.Errorf("reversal amount must be positive")
+	}
+	if request.Date.IsZero() {
+		request.Date = time.Now()
+	}
+
+	sale, err := rs.storage.GetTransaction(request.SaleTransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sale: %w", err)
+	}
+	if sale.Status != Posted {
+		return nil, fmt.Errorf("sale %s is %s, only posted sales can be reversed", sale.ID, sale.Status)
+	}
+	if request.Date.Before(sale.ValidTime) {
+		return nil, fmt.Errorf("reversal cannot precede the sale")
+	}
+
+	gross := saleGross(sale)
+	previous, err := rs.GetReversals(sale.ID)
+	if err != nil {
+		return nil, err
+	}
+	var reversed int64
+	for _, p := range previous {
+		if p.Status != ChargebackWon {
+			reversed += p.Amount.Value
+		}
+	}
+	if reversed+request.Amount > gross {
+		return nil, fmt.Errorf("reversal of %d exceeds unreversed sale amount of %d", request.Amount, gross-reversed)
+	}
+
+	entries, err := prorateEntries(sale.Entries, request.Amount, gross)
+	if err != nil {
+		return nil, err
+	}
+
+	reversal := &SaleReversal{
+		ID:                newID(),
+		Type:              reversalType,
+		SaleTransactionID: sale.ID,
+		Amount:            &Amount{Value: request.Amount, Currency: sale.Entries[0].Amount.Currency},
+		SaleAmount:        &Amount{Value: gross, Currency: sale.Entries[0].Amount.Currency},
+		Reason:            request.Reason,
+		ReasonCode:        request.ReasonCode,
+		ReversalDate:      request.Date,
+		CreatedBy:         userID,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+	reversal.Product, reversal.Channel = saleDimensions(sale)
+
+	txn := &Transaction{
+		ID:              newID(),
+		Description:     fmt.Sprintf("%s of sale %s: %s", strings.ToLower(string(reversalType)), sale.ID, request.Reason),
+		ValidTime:       request.Date,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       fmt.Sprintf("%s_%s", reversalType, reversal.ID),
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	for _, entry := range entries {
+		if entry.Amount.Value == 0 {
+			continue
+		}
+		entry.ID = newID()
+		entry.TransactionID = txn.ID
+		entry.Type = oppositeEntryType(entry.Type)
+		txn.Entries = append(txn.Entries, entry)
+	}
+	if err := rs.postTransaction(txn, userID); err != nil {
+		return nil, err
+	}
+	reversal.TransactionID = txn.ID
+
+	return reversal, nil
+}
+
+// resolve closes a chargeback with its final status
+func (rs *RefundService) resolve(reversal *SaleReversal, status SaleReversalStatus, userID string) (*SaleReversal, error) {
+	now := time.Now()
+	reversal.Status = status
+	reversal.ResolvedBy = userID
+	reversal.ResolvedAt = &now
+	reversal.UpdatedAt = now
+	if err := rs.saveReversal(reversal, EventUpdateChargeback, userID); err != nil {
+		return nil, err
+	}
+	return reversal, nil
+}
+
+// getChargeback loads a reversal and checks that it is a chargeback
+func (rs *RefundService) getChargeback(chargebackID string) (*SaleReversal, error) {
+	reversal, err := rs.storage.GetSaleReversal(chargebackID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chargeback: %w", err)
+	}
+	if reversal.Type != ReversalChargeback {
+		return nil, fmt.Errorf("%s is a %s, not a chargeback", chargebackID, reversal.Type)
+	}
+	return reversal, nil
+}
+
+// postTransaction records, saves and posts a reversal transaction
+func (rs *RefundService) postTransaction(txn *Transaction, userID string) error {
+	_, err := rs.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := rs.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	if err := rs.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
+
+// saveReversal records an event for the reversal and persists it
+func (rs *RefundService) saveReversal(reversal *SaleReversal, eventType, userID string) error {
+	_, err := rs.eventStore.CreateEvent(eventType, reversal, reversal.ReversalDate, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create sale reversal event: %w", err)
+	}
+	if err := rs.storage.SaveSaleReversal(reversal); err != nil {
+		return fmt.Errorf("failed to save sale reversal: %w", err)
+	}
+	return nil
+}
+
+// saleGross is the total of the sale's debits, i.e. what the customer paid
+func saleGross(txn *Transaction) int64 {
+	var gross int64
+	for _, entry := range txn.Entries {
+		if entry.Type == Debit {
+			gross += entry.Amount.Value
+		}
+	}
+	return gross
+}
+
+// saleDimensions returns the product and channel tagged on the sale's entries
+func saleDimensions(txn *Transaction) (product, channel string) {
+	for _, entry := range txn.Entries {
+		for _, dim := range entry.Dimensions {
+			switch {
+			case dim.Key == DimProduct && product == "":
+				product = dim.Value
+			case dim.Key == DimChannel && channel == "":
+				channel = dim.Value
+			}
+		}
+	}
+	if product == "" {
+		product = "unassigned"
+	}
+	if channel == "" {
+		channel = "unassigned"
+	}
+	return product, channel
+}
+
+// isReversalSourceRef reports whether a transaction was generated by the refund service
+func isReversalSourceRef(sourceRef string) bool {
+	for _, prefix := range []string{string(ReversalRefund) + "_", string(ReversalChargeback) + "_"} {
+		if strings.HasPrefix(sourceRef, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// prorateEntries scales the entries by numerator/denominator. Each side is allocated
+// from the same rounded total, with the rounding difference on its last entry, so the
+// result still balances.
+func prorateEntries(entries []Entry, numerator, denominator int64) ([]Entry, error) {
+	fraction := big.NewRat(numerator, denominator)
+	prorated := make([]Entry, len(entries))
+	copy(prorated, entries)
+
+	for _, side := range []EntryType{Debit, Credit} {
+		var sideTotal, baseTotal int64
+		for _, entry := range entries {
+			if entry.Type == side {
+				sideTotal += entry.Amount.Value
+				baseTotal += entry.Amount.BaseValue
+			}
+		}
+		target, err := roundRat(new(big.Rat).Mul(new(big.Rat).SetInt64(sideTotal), fraction), RoundHalfUp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prorate entries: %w", err)
+		}
+		baseTarget, err := roundRat(new(big.Rat).Mul(new(big.Rat).SetInt64(baseTotal), fraction), RoundHalfUp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prorate entries: %w", err)
+		}
+
+		last := -1
+		for i, entry := range entries {
+			if entry.Type != side {
+				continue
+			}
+			value, err := roundRat(new(big.Rat).Mul(new(big.Rat).SetInt64(entry.Amount.Value), fraction), RoundHalfUp)
+			if err != nil {
+				return nil, fmt.Errorf("failed to prorate entry: %w", err)
+			}
+			baseValue, err := roundRat(new(big.Rat).Mul(new(big.Rat).SetInt64(entry.Amount.BaseValue), fraction), RoundHalfUp)
+			if err != nil {
+				return nil, fmt.Errorf("failed to prorate entry: %w", err)
+			}
+			prorated[i].Amount.Value = value
+			prorated[i].Amount.BaseValue = baseValue
+			target -= value
+			baseTarget -= baseValue
+			last = i
+		}
+		if last >= 0 {
+			prorated[last].Amount.Value += target
+			prorated[last].Amount.BaseValue += baseTarget
+		}
+	}
+	return prorated, nil
+}
@@ -0,0 +1,131 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefundsAndChargebacks(t *testing.T) {
+	// Setup
+	dbFile := "test_refunds.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "merchant_ops"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	accounts := []*Account{
+		{ID: "sales_tax_payable", Code: "2300", Name: "Sales Tax Payable", Type: Liability},
+		{ID: "processing_fees", Code: "5100", Name: "Card Processing Fees", Type: Expense},
+		{ID: "chargeback_fees", Code: "5110", Name: "Chargeback Fees", Type: Expense},
+	}
+	for _, account := range accounts {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 0, 0, 0, 0, time.UTC) }
+	usd := func(v int64) Amount { return Amount{Value: v, Currency: "USD"} }
+	sell := func(date time.Time, channel string) *Transaction {
+		tags := []Dimension{{Key: DimProduct, Value: "widget"}, {Key: DimChannel, Value: channel}}
+		txn := &Transaction{
+			Description: "Card sale",
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: usd(10670)},
+				{AccountID: "processing_fees", Type: Debit, Amount: usd(330)},
+				{AccountID: "revenue", Type: Credit, Amount: usd(10000), Dimensions: tags},
+				{AccountID: "sales_tax_payable", Type: Credit, Amount: usd(1000)},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	balance := func(accountID string) int64 {
+		result, err := engine.GetAccountBalance(accountID, day(31))
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+
+	refunded := sell(day(1), "web")
+	disputed := sell(day(2), "web")
+	sell(day(3), "mobile")
+
+	t.Run("Partial Refund", func(t *testing.T) {
+		refund, err := engine.RefundSale(SaleReversalRequest{
+			SaleTransactionID: refunded.ID,
+			Amount:            5500,
+			Date:              day(10),
+			Reason:            "Damaged in transit",
+		}, userID)
+		require.NoError(t, err)
+		assert.Equal(t, ReversalCompleted, refund.Status)
+		assert.Equal(t, "widget", refund.Product)
+		assert.Equal(t, "web", refund.Channel)
+
+		// Half of each line is reversed
+		assert.Equal(t, int64(30000-5000), balance("revenue"))
+		assert.Equal(t, int64(3000-500), balance("sales_tax_payable"))
+		assert.Equal(t, int64(990-165), balance("processing_fees"))
+
+		_, err = engine.RefundSale(SaleReversalRequest{SaleTransactionID: refunded.ID, Amount: 6000, Date: day(11)}, userID)
+		assert.Error(t, err, "cannot refund more than the unreversed sale amount")
+	})
+
+	t.Run("Chargeback Dispute Won", func(t *testing.T) {
+		chargeback, err := engine.RecordChargeback(SaleReversalRequest{
+			SaleTransactionID:   disputed.ID,
+			Amount:              11000,
+			Date:                day(15),
+			Reason:              "Cardholder does not recognize transaction",
+			ReasonCode:          "10.4",
+			FeeAmount:           1500,
+			FeeAccountID:        "chargeback_fees",
+			SettlementAccountID: "cash",
+		}, userID)
+		require.NoError(t, err)
+		assert.Equal(t, ChargebackOpen, chargeback.Status)
+		assert.NotEmpty(t, chargeback.FeeTransactionID)
+		assert.Equal(t, int64(25000-10000), balance("revenue"))
+		assert.Equal(t, int64(1500), balance("chargeback_fees"))
+
+		_, err = engine.ResolveChargebackDispute(chargeback.ID, true, day(20), userID)
+		assert.Error(t, err, "only disputed chargebacks can be resolved")
+
+		chargeback, err = engine.SubmitChargebackDispute(chargeback.ID, "Signed delivery receipt", userID)
+		require.NoError(t, err)
+		assert.Equal(t, ChargebackDisputed, chargeback.Status)
+
+		chargeback, err = engine.ResolveChargebackDispute(chargeback.ID, true, day(25), userID)
+		require.NoError(t, err)
+		assert.Equal(t, ChargebackWon, chargeback.Status)
+		assert.NotNil(t, chargeback.ResolvedAt)
+		assert.Equal(t, int64(25000), balance("revenue"))
+		assert.Equal(t, int64(1500), balance("chargeback_fees"), "fee is not recovered")
+	})
+
+	t.Run("Chargeback Rate Monitoring", func(t *testing.T) {
+		report, err := engine.MonitorChargebackRates(day(1), day(31))
+		require.NoError(t, err)
+		require.Len(t, report.Rates, 2)
+
+		web := report.Rates[0]
+		assert.Equal(t, "web", web.Channel)
+		assert.Equal(t, 2, web.SaleCount)
+		assert.Equal(t, 1, web.ChargebackCount)
+		assert.InDelta(t, 0.5, web.CountRate, 1e-9)
+		assert.True(t, web.Flagged)
+		assert.NotEmpty(t, web.AlertID)
+
+		mobile := report.Rates[1]
+		assert.Equal(t, "mobile", mobile.Channel)
+		assert.False(t, mobile.Flagged)
+		assert.Empty(t, mobile.AlertID)
+	})
+}
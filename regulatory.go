@@ -0,0 +1,403 @@
+package accounting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Regulatory Return Structures
+// ----------------------------------------------------------------------------
+
+// RegulatoryBasis is what a return line measures on its mapped accounts
+type RegulatoryBasis string
+
+const (
+	RegulatoryBalance  RegulatoryBasis = "BALANCE"  // closing balance at period end
+	RegulatoryActivity RegulatoryBasis = "ACTIVITY" // movement during the period
+)
+
+// RegulatoryMapping selects accounts for a return line by ID or by code prefix
+type RegulatoryMapping struct {
+	AccountID         string `json:"account_id,omitempty"`
+	AccountCodePrefix string `json:"account_code_prefix,omitempty"` // e.g. "11" for all cash and equivalents
+	Negate            bool   `json:"negate,omitempty"`              // subtract the accounts, e.g. contra balances
+}
+
+// RegulatoryLine is one line item of a regulatory return, such as a liquidity
+// coverage component. Weight applies a haircut or run-off factor to the mapped total.
+type RegulatoryLine struct {
+	Code        string              `json:"code"` // e.g. "HQLA_L1", "OUTFLOW_RETAIL_STABLE"
+	Description string              `json:"description"`
+	Basis       RegulatoryBasis     `json:"basis"`
+	Weight      float64             `json:"weight"` // e.g. 0.85 for Level 2A assets, 0.05 for stable deposits
+	Mappings    []RegulatoryMapping `json:"mappings"`
+}
+
+// RegulatoryRatio divides the weighted totals of two groups of lines, e.g. HQLA over
+// net cash outflows for the liquidity coverage ratio
+type RegulatoryRatio struct {
+	Code        string   `json:"code"`
+	Description string   `json:"description"`
+	Numerator   []string `json:"numerator"`   // line codes
+	Denominator []string `json:"denominator"` // line codes; prefix with "-" to subtract
+	Minimum     float64  `json:"minimum"`     // e.g. 1.0 for 100%
+}
+
+// RegulatoryReturn maps the chart of accounts to the line items of a regulatory return
+type RegulatoryReturn struct {
+	ID            string            `json:"id"` // e.g. "LCR"
+	Name          string            `json:"name"`
+	Regulator     string            `json:"regulator"`
+	Currency      Currency          `json:"currency"`
+	Lines         []RegulatoryLine  `json:"lines"`
+	Ratios        []RegulatoryRatio `json:"ratios,omitempty"`
+	CoverageTypes []AccountType     `json:"coverage_types,omitempty"` // account types every non-zero balance of must be mapped
+	CreatedBy     string            `json:"created_by"`
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// RegulatoryAccountValue is one account's contribution to a return line
+type RegulatoryAccountValue struct {
+	AccountID   string `json:"account_id"`
+	AccountCode string `json:"account_code"`
+	Amount      int64  `json:"amount"`
+}
+
+// RegulatoryLineValue is a return line's value for a period
+type RegulatoryLineValue struct {
+	Code           string                   `json:"code"`
+	Description    string                   `json:"description"`
+	Amount         int64                    `json:"amount"`
+	Weight         float64                  `json:"weight"`
+	WeightedAmount int64                    `json:"weighted_amount"`
+	Accounts       []RegulatoryAccountValue `json:"accounts"`
+}
+
+// RegulatoryRatioValue is a ratio's value for a period
+type RegulatoryRatioValue struct {
+	Code        string  `json:"code"`
+	Numerator   int64   `json:"numerator"`
+	Denominator int64   `json:"denominator"`
+	Value       float64 `json:"value"`
+	Minimum     float64 `json:"minimum"`
+	Breach      bool    `json:"breach"`
+}
+
+// RegulatoryReturnDataset is the generated return for one period, ready for a filing tool
+type RegulatoryReturnDataset struct {
+	ID               string                 `json:"id"` // return ID and period ID
+	ReturnID         string                 `json:"return_id"`
+	PeriodID         string                 `json:"period_id"`
+	PeriodStart      time.Time              `json:"period_start"`
+	PeriodEnd        time.Time              `json:"period_end"`
+	Currency         Currency               `json:"currency"`
+	Lines            []RegulatoryLineValue  `json:"lines"`
+	Ratios           []RegulatoryRatioValue `json:"ratios"`
+	UnmappedAccounts []string               `json:"unmapped_accounts,omitempty"` // in coverage with a balance but no line
+	GeneratedBy      string                 `json:"generated_by"`
+	GeneratedAt      time.Time              `json:"generated_at"`
+}
+
+// ----------------------------------------------------------------------------
+// Regulatory Reporting Service
+// ----------------------------------------------------------------------------
+
+// RegulatoryReportingService maintains regulatory return mappings and generates the
+// return datasets per period
+type RegulatoryReportingService struct {
+	storage    *Storage
+	eventStore *EventStore
+	queryAPI   *QueryAPI
+}
+
+// NewRegulatoryReportingService creates a new regulatory reporting service
+func NewRegulatoryReportingService(storage *Storage, eventStore *EventStore, queryAPI *QueryAPI) *RegulatoryReportingService {
+	return &RegulatoryReportingService{
+		storage:    storage,
+		eventStore: eventStore,
+		queryAPI:   queryAPI,
+	}
+}
+
+// SaveReturn validates and saves a regulatory return's mappings. Lines default to
+// the period-end balance at full weight.
+func (rrs *RegulatoryReportingService) SaveReturn(ret *RegulatoryReturn, userID string) error {
+	if ret.ID == "" {
+		return fmt.Errorf("regulatory return ID is required")
+	}
+	if len(ret.Lines) == 0 {
+		return fmt.Errorf("regulatory return needs at least one line")
+	}
+
+	codes := make(map[string]bool)
+	for i := range ret.Lines {
+		line := &ret.Lines[i]
+		if line.Code == "" {
+			return fmt.Errorf("line code is required")
+		}
+		if codes[line.Code] {
+			return fmt.Errorf("duplicate line code: %s", line.Code)
+		}
+		codes[line.Code] = true
+		if line.Basis == "" {
+			line.Basis = RegulatoryBalance
+		}
+		if line.Basis != RegulatoryBalance && line.Basis != RegulatoryActivity {
+			return fmt.Errorf("invalid basis for line %s: %s", line.Code, line.Basis)
+		}
+		if line.Weight == 0 {
+			line.Weight = 1
+		}
+		if line.Weight < 0 {
+			return fmt.Errorf("weight for line %s must be positive", line.Code)
+		}
+		for _, mapping := range line.Mappings {
+			if (mapping.AccountID == "") == (mapping.AccountCodePrefix == "") {
+				return fmt.Errorf("mappings for line %s need an account ID or a code prefix", line.Code)
+			}
+			if mapping.AccountID != "" {
+				if _, err := rrs.storage.GetAccount(mapping.AccountID); err != nil {
+					return fmt.Errorf("invalid mapping for line %s: %w", line.Code, err)
+				}
+			}
+		}
+	}
+	for _, ratio := range ret.Ratios {
+		if len(ratio.Numerator) == 0 || len(ratio.Denominator) == 0 {
+			return fmt.Errorf("ratio %s needs a numerator and a denominator", ratio.Code)
+		}
+		for _, code := range append(append([]string{}, ratio.Numerator...), ratio.Denominator...) {
+			if !codes[strings.TrimPrefix(code, "-")] {
+				return fmt.Errorf("ratio %s refers to unknown line %s", ratio.Code, code)
+			}
+		}
+	}
+
+	now := time.Now()
+	if existing, err := rrs.storage.GetRegulatoryReturn(ret.ID); err == nil {
+		ret.CreatedBy = existing.CreatedBy
+		ret.CreatedAt = existing.CreatedAt
+	} else {
+		ret.CreatedBy = userID
+		ret.CreatedAt = now
+	}
+	ret.UpdatedAt = now
+
+	_, err := rrs.eventStore.CreateEvent(EventSaveRegulatoryReturn, ret, now, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create regulatory return event: %w", err)
+	}
+	if err := rrs.storage.SaveRegulatoryReturn(ret); err != nil {
+		return fmt.Errorf("failed to save regulatory return: %w", err)
+	}
+	return nil
+}
+
+// GenerateReturn produces a return's dataset for a period: each line's mapped account
+// values, weighted totals and ratios, and any balances the mappings do not cover.
+// Regenerating a period replaces its dataset.
+func (rrs *RegulatoryReportingService) GenerateReturn(returnID, periodID, userID string) (*RegulatoryReturnDataset, error) {
+	ret, err := rrs.storage.GetRegulatoryReturn(returnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get regulatory return: %w", err)
+	}
+	period, err := rrs.storage.GetPeriod(periodID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get period: %w", err)
+	}
+	accounts, err := rrs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Code < accounts[j].Code
+	})
+
+	// Balances are cached per account and date across lines
+	balances := make(map[string]int64)
+	balanceAt := func(accountID string, asOf time.Time) (int64, error) {
+		key := accountID + "@" + asOf.Format(time.RFC3339Nano)
+		if value, ok := balances[key]; ok {
+			return value, nil
+		}
+		result, err := rrs.queryAPI.GetAccountBalance(accountID, asOf)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get balance for %s: %w", accountID, err)
+		}
+		balances[key] = result.Balance.Value
+		return result.Balance.Value, nil
+	}
+	openingDate := period.Start.Add(-time.Nanosecond)
+
+	dataset := &RegulatoryReturnDataset{
+		ID:          returnID + "_" + periodID,
+		ReturnID:    returnID,
+		PeriodID:    periodID,
+		PeriodStart: period.Start,
+		PeriodEnd:   period.End,
+		Currency:    ret.Currency,
+		GeneratedBy: userID,
+		GeneratedAt: time.Now(),
+	}
+	weighted := make(map[string]int64)
+	mapped := make(map[string]bool)
+	for _, line := range ret.Lines {
+		value := RegulatoryLineValue{Code: line.Code, Description: line.Description, Weight: line.Weight}
+		seen := make(map[string]bool)
+		for _, account := range accounts {
+			if ret.Currency != "" && account.Currency != "" && account.Currency != ret.Currency {
+				continue
+			}
+			sign, ok := regulatoryMappingSign(line.Mappings, account)
+			if !ok || seen[account.ID] {
+				continue
+			}
+			seen[account.ID] = true
+			mapped[account.ID] = true
+
+			amount, err := balanceAt(account.ID, period.End)
+			if err != nil {
+				return nil, err
+			}
+			if line.Basis == RegulatoryActivity {
+				opening, err := balanceAt(account.ID, openingDate)
+				if err != nil {
+					return nil, err
+				}
+				amount -= opening
+			}
+			amount *= sign
+			value.Amount += amount
+			value.Accounts = append(value.Accounts, RegulatoryAccountValue{AccountID: account.ID, AccountCode: account.Code, Amount: amount})
+		}
+		value.WeightedAmount, err = applyRate(value.Amount, line.Weight, RoundHalfUp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to weight line %s: %w", line.Code, err)
+		}
+		weighted[line.Code] = value.WeightedAmount
+		dataset.Lines = append(dataset.Lines, value)
+	}
+
+	for _, ratio := range ret.Ratios {
+		value := RegulatoryRatioValue{
+			Code:        ratio.Code,
+			Numerator:   sumRegulatoryLines(weighted, ratio.Numerator),
+			Denominator: sumRegulatoryLines(weighted, ratio.Denominator),
+			Minimum:     ratio.Minimum,
+		}
+		if value.Denominator != 0 {
+			value.Value = float64(value.Numerator) / float64(value.Denominator)
+			value.Breach = value.Value < ratio.Minimum
+		}
+		dataset.Ratios = append(dataset.Ratios, value)
+	}
+
+	for _, account := range accounts {
+		if mapped[account.ID] || !containsAccountType(ret.CoverageTypes, account.Type) {
+			continue
+		}
+		if ret.Currency != "" && account.Currency != "" && account.Currency != ret.Currency {
+			continue
+		}
+		amount, err := balanceAt(account.ID, period.End)
+		if err != nil {
+			return nil, err
+		}
+		if amount != 0 {
+			dataset.UnmappedAccounts = append(dataset.UnmappedAccounts, account.ID)
+		}
+	}
+
+	_, err = rrs.eventStore.CreateEvent(EventGenerateRegulatoryReturn, dataset, period.End, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create regulatory return event: %w", err)
+	}
+	if err := rrs.storage.SaveRegulatoryReturnDataset(dataset); err != nil {
+		return nil, fmt.Errorf("failed to save regulatory return dataset: %w", err)
+	}
+	return dataset, nil
+}
+
+// ExportDataset renders a generated dataset for a filing tool as "JSON", or as "CSV"
+// with one row per line item followed by one row per ratio
+func (rrs *RegulatoryReportingService) ExportDataset(datasetID, format string) ([]byte, error) {
+	dataset, err := rrs.storage.GetRegulatoryReturnDataset(datasetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get regulatory return dataset: %w", err)
+	}
+
+	switch format {
+	case "JSON":
+		return json.MarshalIndent(dataset, "", "  ")
+	case "CSV":
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		rows := [][]string{{"return_id", "period_id", "record", "code", "description", "amount", "weight", "weighted_amount"}}
+		for _, line := range dataset.Lines {
+			rows = append(rows, []string{
+				dataset.ReturnID, dataset.PeriodID, "LINE", line.Code, line.Description,
+				strconv.FormatInt(line.Amount, 10),
+				strconv.FormatFloat(line.Weight, 'f', -1, 64),
+				strconv.FormatInt(line.WeightedAmount, 10),
+			})
+		}
+		for _, ratio := range dataset.Ratios {
+			rows = append(rows, []string{
+				dataset.ReturnID, dataset.PeriodID, "RATIO", ratio.Code, "",
+				strconv.FormatInt(ratio.Numerator, 10) + "/" + strconv.FormatInt(ratio.Denominator, 10),
+				"",
+				strconv.FormatFloat(ratio.Value, 'f', 4, 64),
+			})
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return nil, fmt.Errorf("failed to write CSV: %w", err)
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// regulatoryMappingSign reports whether any mapping selects the account and with what sign
+func regulatoryMappingSign(mappings []RegulatoryMapping, account *Account) (int64, bool) {
+	for _, mapping := range mappings {
+		if mapping.AccountID == account.ID || (mapping.AccountCodePrefix != "" && strings.HasPrefix(account.Code, mapping.AccountCodePrefix)) {
+			if mapping.Negate {
+				return -1, true
+			}
+			return 1, true
+		}
+	}
+	return 0, false
+}
+
+// sumRegulatoryLines totals weighted line values, subtracting codes prefixed with "-"
+func sumRegulatoryLines(weighted map[string]int64, codes []string) int64 {
+	var total int64
+	for _, code := range codes {
+		if trimmed, ok := strings.CutPrefix(code, "-"); ok {
+			total -= weighted[trimmed]
+		} else {
+			total += weighted[code]
+		}
+	}
+	return total
+}
+
+// containsAccountType reports whether the account type is in the list
+func containsAccountType(types []AccountType, accountType AccountType) bool {
+	for _, t := range types {
+		if t == accountType {
+			return true
+		}
+	}
+	return false
+}
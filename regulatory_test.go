@@ -0,0 +1,159 @@
+package accounting
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegulatoryReturns(t *testing.T) {
+	// Setup
+	dbFile := "test_regulatory.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "regulatory_reporting"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	accounts := []*Account{
+		{ID: "govt_bonds", Code: "1010", Name: "Government Bonds", Type: Asset},
+		{ID: "corp_bonds", Code: "1050", Name: "Corporate Bonds AA", Type: Asset},
+		{ID: "loans", Code: "1400", Name: "Loans to Customers", Type: Asset},
+		{ID: "other_assets", Code: "1900", Name: "Other Assets", Type: Asset},
+		{ID: "retail_deposits", Code: "2300", Name: "Retail Deposits", Type: Liability},
+		{ID: "wholesale_deposits", Code: "2400", Name: "Wholesale Deposits", Type: Liability},
+	}
+	for _, account := range accounts {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	post := func(debit, credit string, value int64, when time.Time) {
+		txn := &Transaction{
+			Description: "Treasury",
+			ValidTime:   when,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	date := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+
+	post("cash", "revenue", 500, time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC))
+	post("cash", "retail_deposits", 100000, date(1, 5))
+	post("cash", "wholesale_deposits", 50000, date(1, 6))
+	post("govt_bonds", "cash", 30000, date(1, 10))
+	post("corp_bonds", "cash", 20000, date(1, 11))
+	post("loans", "cash", 40000, date(2, 1))
+	post("other_assets", "cash", 700, date(2, 2))
+	post("cash", "revenue", 1000, date(3, 31))
+
+	period := &Period{Name: "Q1 2025", Start: date(1, 1), End: time.Date(2025, 3, 31, 23, 59, 59, 0, time.UTC)}
+	require.NoError(t, engine.CreatePeriod(period, userID))
+
+	lcr := &RegulatoryReturn{
+		ID:        "LCR",
+		Name:      "Liquidity Coverage Ratio",
+		Regulator: "PRA",
+		Currency:  "USD",
+		Lines: []RegulatoryLine{
+			{Code: "HQLA_L1", Description: "Level 1 assets", Mappings: []RegulatoryMapping{{AccountID: "cash"}, {AccountID: "govt_bonds"}}},
+			{Code: "HQLA_L2A", Description: "Level 2A assets", Weight: 0.85, Mappings: []RegulatoryMapping{{AccountID: "corp_bonds"}}},
+			{Code: "OUT_RETAIL", Description: "Stable retail deposits", Weight: 0.05, Mappings: []RegulatoryMapping{{AccountCodePrefix: "23"}}},
+			{Code: "OUT_WHOLESALE", Description: "Operational wholesale deposits", Weight: 0.4, Mappings: []RegulatoryMapping{{AccountCodePrefix: "24"}}},
+			{Code: "IN_LOANS", Description: "Inflows from performing loans", Weight: 0.1, Mappings: []RegulatoryMapping{{AccountID: "loans"}}},
+			{Code: "NET_INCOME", Description: "Net income for the period", Basis: RegulatoryActivity, Mappings: []RegulatoryMapping{{AccountID: "revenue"}, {AccountID: "expenses", Negate: true}}},
+		},
+		Ratios: []RegulatoryRatio{
+			{Code: "LCR", Description: "HQLA over net cash outflows", Numerator: []string{"HQLA_L1", "HQLA_L2A"}, Denominator: []string{"OUT_RETAIL", "OUT_WHOLESALE", "-IN_LOANS"}, Minimum: 1},
+			{Code: "STRESS", Description: "Internal buffer", Numerator: []string{"HQLA_L1"}, Denominator: []string{"OUT_WHOLESALE"}, Minimum: 10},
+		},
+		CoverageTypes: []AccountType{Asset, Liability},
+	}
+
+	t.Run("Mapping Validation", func(t *testing.T) {
+		invalid := *lcr
+		invalid.Ratios = []RegulatoryRatio{{Code: "BAD", Numerator: []string{"HQLA_L3"}, Denominator: []string{"OUT_RETAIL"}}}
+		assert.Error(t, engine.SaveRegulatoryReturn(&invalid, userID), "ratios must refer to defined lines")
+
+		invalid = *lcr
+		invalid.Lines = []RegulatoryLine{{Code: "X", Mappings: []RegulatoryMapping{{}}}}
+		assert.Error(t, engine.SaveRegulatoryReturn(&invalid, userID), "mappings select accounts")
+
+		require.NoError(t, engine.SaveRegulatoryReturn(lcr, userID))
+	})
+
+	t.Run("Generate Dataset", func(t *testing.T) {
+		dataset, err := engine.GenerateRegulatoryReturn("LCR", period.ID, userID)
+		require.NoError(t, err)
+		require.Len(t, dataset.Lines, 6)
+
+		lines := make(map[string]RegulatoryLineValue)
+		for _, line := range dataset.Lines {
+			lines[line.Code] = line
+		}
+		assert.Equal(t, int64(90800), lines["HQLA_L1"].WeightedAmount)
+		require.Len(t, lines["HQLA_L1"].Accounts, 2)
+		assert.Equal(t, int64(20000), lines["HQLA_L2A"].Amount)
+		assert.Equal(t, int64(17000), lines["HQLA_L2A"].WeightedAmount)
+		assert.Equal(t, int64(5000), lines["OUT_RETAIL"].WeightedAmount)
+		assert.Equal(t, int64(20000), lines["OUT_WHOLESALE"].WeightedAmount)
+		assert.Equal(t, int64(4000), lines["IN_LOANS"].WeightedAmount)
+		assert.Equal(t, int64(1000), lines["NET_INCOME"].Amount, "activity excludes income before the period")
+
+		require.Len(t, dataset.Ratios, 2)
+		assert.Equal(t, int64(107800), dataset.Ratios[0].Numerator)
+		assert.Equal(t, int64(21000), dataset.Ratios[0].Denominator)
+		assert.InDelta(t, 5.1333, dataset.Ratios[0].Value, 0.0001)
+		assert.False(t, dataset.Ratios[0].Breach)
+		assert.True(t, dataset.Ratios[1].Breach)
+
+		assert.Equal(t, []string{"other_assets"}, dataset.UnmappedAccounts)
+	})
+
+	t.Run("Export For Filing", func(t *testing.T) {
+		data, err := engine.ExportRegulatoryReturn("LCR_"+period.ID, "CSV")
+		require.NoError(t, err)
+		rows := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, rows, 9)
+		assert.Equal(t, "LCR,"+period.ID+",LINE,HQLA_L2A,Level 2A assets,20000,0.85,17000", rows[2])
+		assert.True(t, strings.HasPrefix(rows[7], "LCR,"+period.ID+",RATIO,LCR,,107800/21000"))
+
+		data, err = engine.ExportRegulatoryReturn("LCR_"+period.ID, "JSON")
+		require.NoError(t, err)
+		var dataset RegulatoryReturnDataset
+		require.NoError(t, json.Unmarshal(data, &dataset))
+		assert.Equal(t, "LCR", dataset.ReturnID)
+
+		_, err = engine.ExportRegulatoryReturn("LCR_"+period.ID, "XBRL")
+		assert.Error(t, err)
+	})
+
+	t.Run("Half Points Round Up", func(t *testing.T) {
+		require.NoError(t, engine.CreateAccount(&Account{ID: "petty_cash", Code: "1020", Name: "Petty Cash", Type: Asset}, userID))
+		post("petty_cash", "cash", 10, date(3, 1))
+		require.NoError(t, engine.SaveRegulatoryReturn(&RegulatoryReturn{
+			ID:       "PETTY",
+			Name:     "Petty Cash Return",
+			Currency: "USD",
+			Lines: []RegulatoryLine{
+				{Code: "PETTY", Description: "Petty cash", Weight: 0.35, Mappings: []RegulatoryMapping{{AccountID: "petty_cash"}}},
+			},
+		}, userID))
+
+		// 35% of 10 is exactly 3.5, not the 3.4999... of 0.35's binary expansion
+		dataset, err := engine.GenerateRegulatoryReturn("PETTY", period.ID, userID)
+		require.NoError(t, err)
+		require.Len(t, dataset.Lines, 1)
+		assert.Equal(t, int64(4), dataset.Lines[0].WeightedAmount)
+	})
+}
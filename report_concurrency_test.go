@@ -0,0 +1,96 @@
+package accounting
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParallelReports(t *testing.T) {
+	t.Run("reports the lowest failing index", func(t *testing.T) {
+		for _, workers := range []int{1, 4, 64} {
+			err := forEachParallel(100, workers, func(i int) error {
+				if i == 7 || i == 30 {
+					return fmt.Errorf("failed at %d", i)
+				}
+				return nil
+			})
+			assert.EqualError(t, err, "failed at 7", "workers %d", workers)
+		}
+	})
+
+	dbFile := "test_parallel_reports.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	for i := 0; i < 30; i++ {
+		parent := "expenses"
+		if i%2 == 1 {
+			parent = "revenue"
+		}
+		accountType := Expense
+		if parent == "revenue" {
+			accountType = Income
+		}
+		id := fmt.Sprintf("%s_%02d", parent, i)
+		require.NoError(t, engine.CreateAccount(&Account{ID: id, Code: fmt.Sprintf("9%03d", i), Name: id, Type: accountType, ParentID: parent}, userID))
+
+		debit, credit := id, "cash"
+		if accountType == Income {
+			debit, credit = "cash", id
+		}
+		txn := &Transaction{
+			Description: "Activity",
+			ValidTime:   time.Date(2024, 6, 1+i%28, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: int64(1000 * (i + 1)), Currency: "USD"}},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: int64(1000 * (i + 1)), Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+
+	from := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	generate := func(workers int) ([]*BalanceResult, []*AccountRollupBalance, *FinancialStatement, *FinancialStatement) {
+		engine.SetReportConcurrency(workers)
+		trialBalance, err := engine.GetTrialBalance(to, nil)
+		require.NoError(t, err)
+		rollup, err := engine.GetRollupTrialBalance(to, nil)
+		require.NoError(t, err)
+		pl, err := engine.GenerateProfitAndLoss(from, to, "USD")
+		require.NoError(t, err)
+		bs, err := engine.GenerateBalanceSheet(to, "USD")
+		require.NoError(t, err)
+		return trialBalance, rollup, pl, bs
+	}
+
+	t.Run("matches serial output", func(t *testing.T) {
+		defer engine.SetReportConcurrency(0)
+		serialTB, serialRollup, serialPL, serialBS := generate(1)
+		for _, workers := range []int{0, 3, 16} {
+			tb, rollup, pl, bs := generate(workers)
+			assert.Equal(t, serialTB, tb, "workers %d", workers)
+			assert.Equal(t, serialRollup, rollup, "workers %d", workers)
+			assert.Equal(t, serialPL, pl, "workers %d", workers)
+			assert.Equal(t, serialBS, bs, "workers %d", workers)
+		}
+
+		require.Len(t, serialTB, 38)
+		for _, line := range serialRollup {
+			if line.AccountID == "revenue" {
+				assert.Equal(t, int64(1000*(2+4+6+8+10+12+14+16+18+20+22+24+26+28+30)), line.RolledUpAmount.Value)
+			}
+		}
+	})
+}
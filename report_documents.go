@@ -0,0 +1,243 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Report Documents
+// ----------------------------------------------------------------------------
+
+// amlReportCurrency is the currency AML monitoring amounts are kept in
+const amlReportCurrency Currency = "USD"
+
+// FinancialStatementDocument lays out a financial statement for export: its line
+// items indented by level with subtotals in bold, then its totals, and its notes on
+// a second sheet when it has footnotes
+func FinancialStatementDocument(statement *FinancialStatement) *ReportDocument {
+	currency := Currency(statement.Currency)
+	comparative := statement.ComparativeAsOf != nil
+	sheet := &ReportSheet{Name: statement.Name, Columns: []string{"Line", "Account", "Amount"}}
+	if comparative {
+		sheet.Columns = append(sheet.Columns, "Comparative")
+	}
+
+	amountCell := func(amount *Amount) ReportCell {
+		if amount == nil {
+			return TextCell("")
+		}
+		return AmountCell(amount.Value, currency)
+	}
+	var addLines func(items []*FinancialLineItem)
+	addLines = func(items []*FinancialLineItem) {
+		for _, item := range items {
+			row := []ReportCell{
+				TextCell(strings.Repeat("  ", item.Level) + item.AccountName),
+				TextCell(item.AccountID),
+				amountCell(item.Amount),
+			}
+			if comparative {
+				row = append(row, amountCell(item.Comparative))
+			}
+			if item.IsSubtotal {
+				for i := range row {
+					row[i] = row[i].Bolded()
+				}
+			}
+			sheet.Rows = append(sheet.Rows, row)
+			addLines(item.Children)
+		}
+	}
+	addLines(statement.LineItems)
+
+	for _, total := range []struct {
+		label  string
+		amount *Amount
+	}{
+		{"Total Assets", statement.TotalAssets},
+		{"Total Liabilities", statement.TotalLiabs},
+		{"Total Equity", statement.TotalEquity},
+		{"Net Income", statement.NetIncome},
+	} {
+		if total.amount == nil {
+			continue
+		}
+		sheet.Rows = append(sheet.Rows, []ReportCell{TextCell(total.label).Bolded(), TextCell(""), amountCell(total.amount).Bolded()})
+	}
+
+	subtitle := "As of " + statement.AsOfDate.Format(time.DateOnly)
+	if statement.FromDate != nil {
+		subtitle = statement.FromDate.Format(time.DateOnly) + " to " + statement.AsOfDate.Format(time.DateOnly)
+	}
+	doc := &ReportDocument{
+		Title:    statement.Name,
+		Subtitle: subtitle + " (" + statement.Currency + ")",
+		Sheets:   []*ReportSheet{sheet},
+	}
+	if len(statement.Footnotes) > 0 {
+		notes := &ReportSheet{Name: "Notes", Columns: []string{"Note", "Title", "Text"}}
+		for _, note := range statement.Footnotes {
+			notes.Rows = append(notes.Rows, []ReportCell{NumberCell(float64(note.Number), 0), TextCell(note.Title), TextCell(note.Text)})
+		}
+		doc.Sheets = append(doc.Sheets, notes)
+	}
+	return doc
+}
+
+// TrialBalanceDocument lays out a trial balance for export with each balance in its
+// debit or credit column and the column totals
+func TrialBalanceDocument(balances []*BalanceResult, asOfDate time.Time, currency string) *ReportDocument {
+	sheet := &ReportSheet{Name: "Trial Balance", Columns: []string{"Account ID", "Account", "Type", "Debit", "Credit"}}
+	reporting := Currency(currency)
+	var debits, credits int64
+	for _, balance := range balances {
+		value := balance.Balance.Value
+		if balance.AccountType != Asset && balance.AccountType != Expense {
+			value = -value
+		}
+		row := []ReportCell{TextCell(balance.AccountID), TextCell(balance.AccountName), TextCell(string(balance.AccountType)), TextCell(""), TextCell("")}
+		switch {
+		case value > 0:
+			row[3] = AmountCell(value, reporting)
+			debits += value
+		case value < 0:
+			row[4] = AmountCell(-value, reporting)
+			credits -= value
+		}
+		sheet.Rows = append(sheet.Rows, row)
+	}
+	sheet.Rows = append(sheet.Rows, []ReportCell{TextCell("Total").Bolded(), TextCell(""), TextCell(""), AmountCell(debits, reporting).Bolded(), AmountCell(credits, reporting).Bolded()})
+
+	return &ReportDocument{
+		Title:    "Trial Balance",
+		Subtitle: "As of " + asOfDate.Format(time.DateOnly) + " (" + currency + ")",
+		Sheets:   []*ReportSheet{sheet},
+	}
+}
+
+// AMLDashboardDocument lays out an AML dashboard for export, one sheet each for the
+// summary metrics, alerts by type and risk level, the daily trend, the riskiest
+// customers and the recommended actions
+func AMLDashboardDocument(dashboard *AMLDashboard) *ReportDocument {
+	metrics := dashboard.ComplianceMetrics
+	summary := &ReportSheet{Name: "Summary", Columns: []string{"Metric", "Value"}, Rows: [][]ReportCell{
+		{TextCell("Total alerts"), NumberCell(float64(dashboard.TotalAlerts), 0)},
+		{TextCell("High and critical risk alerts"), NumberCell(float64(dashboard.AlertsByRiskLevel[RiskHigh]+dashboard.AlertsByRiskLevel[RiskCritical]), 0)},
+		{TextCell("CTR filing rate (%)"), NumberCell(metrics.CTRFilingRate, 2)},
+		{TextCell("SAR filing rate (%)"), NumberCell(metrics.SARFilingRate, 2)},
+		{TextCell("False positive rate (%)"), NumberCell(metrics.FalsePositiveRate, 2)},
+		{TextCell("Average resolution time (hours)"), NumberCell(float64(metrics.AverageResolutionTime), 0)},
+		{TextCell("Compliance score"), NumberCell(float64(metrics.ComplianceScore), 0).Bolded()},
+	}}
+
+	byType := &ReportSheet{Name: "Alerts by Type", Columns: []string{"Rule type", "Alerts"}}
+	types := make([]AMLRuleType, 0, len(dashboard.AlertsByType))
+	for ruleType := range dashboard.AlertsByType {
+		types = append(types, ruleType)
+	}
+	slices.Sort(types)
+	for _, ruleType := range types {
+		byType.Rows = append(byType.Rows, []ReportCell{TextCell(string(ruleType)), NumberCell(float64(dashboard.AlertsByType[ruleType]), 0)})
+	}
+
+	byRisk := &ReportSheet{Name: "Alerts by Risk Level", Columns: []string{"Risk level", "Alerts"}}
+	for _, level := range []AMLRiskLevel{RiskLow, RiskMedium, RiskHigh, RiskCritical} {
+		byRisk.Rows = append(byRisk.Rows, []ReportCell{TextCell(string(level)), NumberCell(float64(dashboard.AlertsByRiskLevel[level]), 0)})
+	}
+
+	trend := &ReportSheet{Name: "Daily Trend", Columns: []string{"Date", "Alerts", "Volume"}}
+	for i, alerts := range dashboard.TrendAnalysis.AlertTrend30Days {
+		date := dashboard.PeriodEnd.AddDate(0, 0, i-len(dashboard.TrendAnalysis.AlertTrend30Days)+1)
+		var volume int64
+		if i < len(dashboard.TrendAnalysis.VolumeTrend30Days) {
+			volume = dashboard.TrendAnalysis.VolumeTrend30Days[i]
+		}
+		trend.Rows = append(trend.Rows, []ReportCell{TextCell(date.Format(time.DateOnly)), NumberCell(float64(alerts), 0), AmountCell(volume, amlReportCurrency)})
+	}
+
+	customers := &ReportSheet{Name: "Top Risky Customers", Columns: []string{"Customer ID", "Name", "Risk score", "Alerts", "Volume", "Last activity", "Risk factors"}}
+	for _, customer := range dashboard.TopRiskyCustomers {
+		customers.Rows = append(customers.Rows, []ReportCell{
+			TextCell(customer.CustomerID),
+			TextCell(customer.CustomerName),
+			NumberCell(float64(customer.RiskScore), 0),
+			NumberCell(float64(customer.AlertCount), 0),
+			AmountCell(customer.TotalVolume, amlReportCurrency),
+			TextCell(customer.LastActivity.Format(time.DateOnly)),
+			TextCell(strings.Join(customer.RiskFactors, "; ")),
+		})
+	}
+
+	actions := &ReportSheet{Name: "Recommendations", Columns: []string{"Priority", "Category", "Title", "Description", "Due"}}
+	for _, action := range dashboard.RecommendedActions {
+		actions.Rows = append(actions.Rows, []ReportCell{
+			TextCell(action.Priority),
+			TextCell(action.Category),
+			TextCell(action.Title),
+			TextCell(action.Description),
+			TextCell(action.DueDate.Format(time.DateOnly)),
+		})
+	}
+
+	return &ReportDocument{
+		Title:    "AML Compliance Dashboard",
+		Subtitle: dashboard.PeriodStart.Format(time.DateOnly) + " to " + dashboard.PeriodEnd.Format(time.DateOnly),
+		Sheets:   []*ReportSheet{summary, byType, byRisk, trend, customers, actions},
+	}
+}
+
+// BudgetVarianceDocument lays out a budget variance report for export, largest
+// overspends first, with the totals
+func BudgetVarianceDocument(report *BudgetVarianceReport) *ReportDocument {
+	sheet := &ReportSheet{Name: "Budget Variance", Columns: []string{"Account", "Description", "Budget", "Spent", "Variance", "Variance %"}}
+	items := slices.Clone(report.Items)
+	sort.SliceStable(items, func(i, j int) bool {
+		return varianceValue(items[i].Variance) < varianceValue(items[j].Variance)
+	})
+	amountCell := func(amount *Amount) ReportCell {
+		if amount == nil {
+			return TextCell("")
+		}
+		return AmountCell(amount.Value, amount.Currency)
+	}
+	for _, item := range items {
+		sheet.Rows = append(sheet.Rows, []ReportCell{
+			TextCell(item.AccountID),
+			TextCell(item.Description),
+			amountCell(item.BudgetAmount),
+			amountCell(item.SpentAmount),
+			amountCell(item.Variance),
+			NumberCell(item.VariancePercent, 1),
+		})
+	}
+	sheet.Rows = append(sheet.Rows, []ReportCell{
+		TextCell("Total").Bolded(),
+		TextCell(""),
+		amountCell(report.TotalBudget).Bolded(),
+		amountCell(report.TotalSpent).Bolded(),
+		amountCell(report.TotalVariance).Bolded(),
+		NumberCell(report.TotalVariancePercent, 1).Bolded(),
+	})
+
+	subtitle := fmt.Sprintf("Period %s", report.PeriodID)
+	if report.DepartmentID != "" {
+		subtitle += ", department " + report.DepartmentID
+	}
+	return &ReportDocument{
+		Title:    "Budget Variance Report",
+		Subtitle: subtitle,
+		Sheets:   []*ReportSheet{sheet},
+	}
+}
+
+// varianceValue orders variances, treating a missing one as zero
+func varianceValue(amount *Amount) int64 {
+	if amount == nil {
+		return 0
+	}
+	return amount.Value
+}
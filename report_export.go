@@ -0,0 +1,152 @@
+package accounting
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// ----------------------------------------------------------------------------
+// Report Documents and Exporters
+// ----------------------------------------------------------------------------
+
+// ReportCell is one value of a report table. Numeric cells carry their value so
+// spreadsheets can calculate with them; Text is what is shown.
+type ReportCell struct {
+	Text     string   `json:"text"`
+	Number   *float64 `json:"number,omitempty"`
+	Decimals int      `json:"decimals,omitempty"` // places Number is shown to
+	Bold     bool     `json:"bold,omitempty"`
+}
+
+// TextCell is a cell of text
+func TextCell(text string) ReportCell {
+	return ReportCell{Text: text}
+}
+
+// AmountCell is a cell holding an amount in minor units, shown to the currency's
+// decimal places. Spreadsheets store it as a double, exact to 15 significant digits.
+func AmountCell(value int64, currency Currency) ReportCell {
+	decimals := MinorUnits(currency)
+	number := float64(value) / math.Pow10(decimals)
+	return ReportCell{Text: FormatMinorUnits(value, currency), Number: &number, Decimals: decimals}
+}
+
+// NumberCell is a cell holding a number shown to the given decimal places
+func NumberCell(value float64, decimals int) ReportCell {
+	return ReportCell{Text: strconv.FormatFloat(value, 'f', decimals, 64), Number: &value, Decimals: decimals}
+}
+
+// Bolded returns the cell in bold, for headings and totals
+func (c ReportCell) Bolded() ReportCell {
+	c.Bold = true
+	return c
+}
+
+// ReportSheet is one table of a report: a worksheet in a workbook, or a section
+// starting on a new page of a PDF
+type ReportSheet struct {
+	Name    string         `json:"name"`
+	Columns []string       `json:"columns"`
+	Rows    [][]ReportCell `json:"rows"`
+}
+
+// ReportDocument is a report laid out for export
+type ReportDocument struct {
+	Title    string         `json:"title"`
+	Subtitle string         `json:"subtitle,omitempty"` // e.g. the period and currency
+	Sheets   []*ReportSheet `json:"sheets"`
+}
+
+// Exporter writes report documents in one file format
+type Exporter interface {
+	// Export writes the whole document to w
+	Export(w io.Writer, doc *ReportDocument) error
+	// ContentType is the MIME type of what Export writes
+	ContentType() string
+}
+
+// NewExporter returns the exporter for a format: CSV, JSON, XLSX or PDF
+func NewExporter(format ExportFormat) (Exporter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return CSVExporter{}, nil
+	case ExportFormatJSON:
+		return JSONExporter{}, nil
+	case ExportFormatXLSX:
+		return XLSXExporter{}, nil
+	case ExportFormatPDF:
+		return PDFExporter{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// ExportReport writes a report document in the given format
+func ExportReport(w io.Writer, format ExportFormat, doc *ReportDocument) error {
+	exporter, err := NewExporter(format)
+	if err != nil {
+		return err
+	}
+	return exporter.Export(w, doc)
+}
+
+// CSVExporter writes each sheet's header and rows, separating sheets with a blank
+// line and naming them when there are several
+type CSVExporter struct{}
+
+// ContentType is the CSV MIME type
+func (CSVExporter) ContentType() string { return "text/csv" }
+
+// Export writes the document as CSV
+func (CSVExporter) Export(w io.Writer, doc *ReportDocument) error {
+	writer := csv.NewWriter(w)
+	for i, sheet := range doc.Sheets {
+		if i > 0 {
+			if err := writer.Write([]string{""}); err != nil {
+				return fmt.Errorf("failed to write row: %w", err)
+			}
+		}
+		if len(doc.Sheets) > 1 {
+			if err := writer.Write([]string{sheet.Name}); err != nil {
+				return fmt.Errorf("failed to write row: %w", err)
+			}
+		}
+		if err := writer.Write(sheet.Columns); err != nil {
+			return fmt.Errorf("failed to write header: %w", err)
+		}
+		for _, row := range sheet.Rows {
+			record := make([]string, len(row))
+			for j, cell := range row {
+				record[j] = cell.Text
+			}
+			if err := writer.Write(record); err != nil {
+				return fmt.Errorf("failed to write row: %w", err)
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write rows: %w", err)
+	}
+	return nil
+}
+
+// JSONExporter writes the document as indented JSON
+type JSONExporter struct{}
+
+// ContentType is the JSON MIME type
+func (JSONExporter) ContentType() string { return "application/json" }
+
+// Export writes the document as JSON
+func (JSONExporter) Export(w io.Writer, doc *ReportDocument) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,329 @@
+package accounting
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// PDFExporter writes a paginated PDF in the standard Helvetica fonts, so no fonts
+// are embedded. Each sheet starts a new page with its name and the table header,
+// which repeats on every page the table runs onto; numeric columns are right
+// aligned. Pages turn landscape when a table is too wide for portrait, and columns
+// that still do not fit are shortened with an ellipsis.
+type PDFExporter struct{}
+
+// ContentType is the PDF MIME type
+func (PDFExporter) ContentType() string { return "application/pdf" }
+
+// PDF page layout, in points
+const (
+	pdfShortSide    = 595.28 // A4
+	pdfLongSide     = 841.89
+	pdfMargin       = 40.0
+	pdfFontSize     = 9.0
+	pdfLineHeight   = 13.0
+	pdfCellPadding  = 6.0
+	pdfTitleSize    = 14.0
+	pdfHeadingSize  = 11.0
+	pdfFooterOffset = 20.0
+)
+
+// helveticaWidths and helveticaBoldWidths are the Helvetica advance widths of the
+// printable ASCII characters from space, in thousandths of the font size
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = [95]int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
+
+// pdfTextWidth measures text set in Helvetica at a size
+func pdfTextWidth(text string, bold bool, size float64) float64 {
+	widths := &helveticaWidths
+	if bold {
+		widths = &helveticaBoldWidths
+	}
+	total := 0
+	for _, r := range text {
+		if r >= ' ' && r <= '~' {
+			total += widths[r-' ']
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * size / 1000
+}
+
+// pdfFitText shortens text with an ellipsis until it fits a width
+func pdfFitText(text string, bold bool, size, width float64) string {
+	if pdfTextWidth(text, bold, size) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdfTextWidth(string(runes)+"...", bold, size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// pdfString encodes text as a PDF literal string in WinAnsiEncoding, replacing
+// characters the encoding lacks with a question mark
+func pdfString(text string) string {
+	var out strings.Builder
+	out.WriteByte('(')
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			out.WriteByte('\\')
+			out.WriteRune(r)
+		case r >= ' ' && r <= '~':
+			out.WriteRune(r)
+		case r == '€':
+			out.WriteString(`\200`)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&out, `\%03o`, r)
+		default:
+			out.WriteByte('?')
+		}
+	}
+	out.WriteByte(')')
+	return out.String()
+}
+
+// pdfLayout lays a document out onto pages of content stream operators
+type pdfLayout struct {
+	width, height float64
+	pages         []*strings.Builder
+	y             float64
+}
+
+// newPage starts a page and returns to its top
+func (l *pdfLayout) newPage() {
+	l.pages = append(l.pages, &strings.Builder{})
+	l.y = l.height - pdfMargin
+}
+
+// text sets text with its left edge at x on the current line
+func (l *pdfLayout) text(x float64, text string, bold bool, size float64) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(l.pages[len(l.pages)-1], "BT /%s %.1f Tf %.2f %.2f Td %s Tj ET\n", font, size, x, l.y, pdfString(text))
+}
+
+// rule draws a horizontal line just below the current line
+func (l *pdfLayout) rule() {
+	y := l.y - 3
+	fmt.Fprintf(l.pages[len(l.pages)-1], "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, y, l.width-pdfMargin, y)
+}
+
+// Export writes the document as a PDF
+func (PDFExporter) Export(w io.Writer, doc *ReportDocument) error {
+	if len(doc.Sheets) == 0 {
+		return fmt.Errorf("report %s has no sheets", doc.Title)
+	}
+
+	// Size the columns of every sheet to their widest cell
+	columnWidths := make([][]float64, len(doc.Sheets))
+	widest := 0.0
+	for i, sheet := range doc.Sheets {
+		widths := make([]float64, len(sheet.Columns))
+		for col, column := range sheet.Columns {
+			widths[col] = pdfTextWidth(column, true, pdfFontSize) + pdfCellPadding
+		}
+		for _, row := range sheet.Rows {
+			for col, cell := range row {
+				if col < len(widths) {
+					widths[col] = max(widths[col], pdfTextWidth(cell.Text, cell.Bold, pdfFontSize)+pdfCellPadding)
+				}
+			}
+		}
+		total := 0.0
+		for _, width := range widths {
+			total += width
+		}
+		widest = max(widest, total)
+		columnWidths[i] = widths
+	}
+
+	layout := &pdfLayout{width: pdfShortSide, height: pdfLongSide}
+	if widest > pdfShortSide-2*pdfMargin {
+		layout.width, layout.height = pdfLongSide, pdfShortSide
+	}
+	available := layout.width - 2*pdfMargin
+	bottom := pdfMargin + pdfFooterOffset
+
+	for i, sheet := range doc.Sheets {
+		widths := columnWidths[i]
+		numeric := pdfNumericColumns(sheet)
+		total := 0.0
+		for _, width := range widths {
+			total += width
+		}
+		if total > available {
+			// Keep the numeric columns whole and shrink the rest to share what is left,
+			// or shrink everything when the numbers alone are too wide
+			fixed := 0.0
+			for col, width := range widths {
+				if numeric[col] {
+					fixed += width
+				}
+			}
+			shrinkAll := fixed >= available || fixed == total
+			scale := available / total
+			if !shrinkAll {
+				scale = (available - fixed) / (total - fixed)
+			}
+			for col := range widths {
+				if shrinkAll || !numeric[col] {
+					widths[col] *= scale
+				}
+			}
+		}
+
+		layout.newPage()
+		if i == 0 {
+			layout.text(pdfMargin, doc.Title, true, pdfTitleSize)
+			layout.y -= pdfTitleSize + 4
+			if doc.Subtitle != "" {
+				layout.text(pdfMargin, doc.Subtitle, false, pdfFontSize+1)
+				layout.y -= pdfLineHeight + 4
+			}
+		}
+		if len(doc.Sheets) > 1 || sheet.Name != doc.Title {
+			layout.text(pdfMargin, sheet.Name, true, pdfHeadingSize)
+			layout.y -= pdfLineHeight + 4
+		}
+
+		writeRow := func(cells []ReportCell, header bool) {
+			x := pdfMargin
+			for col, width := range widths {
+				if col >= len(cells) {
+					break
+				}
+				cell := cells[col]
+				bold := cell.Bold || header
+				text := pdfFitText(cell.Text, bold, pdfFontSize, width-pdfCellPadding)
+				if numeric[col] {
+					layout.text(x+width-pdfCellPadding-pdfTextWidth(text, bold, pdfFontSize), text, bold, pdfFontSize)
+				} else {
+					layout.text(x, text, bold, pdfFontSize)
+				}
+				x += width
+			}
+			if header {
+				layout.rule()
+				layout.y -= 4
+			}
+			layout.y -= pdfLineHeight
+		}
+		header := make([]ReportCell, len(sheet.Columns))
+		for col, column := range sheet.Columns {
+			header[col] = TextCell(column)
+		}
+		writeRow(header, true)
+		for _, row := range sheet.Rows {
+			if layout.y < bottom {
+				layout.newPage()
+				writeRow(header, true)
+			}
+			writeRow(row, false)
+		}
+	}
+
+	// Footers go on last, once the page count is known
+	generated := time.Now().UTC().Format("2006-01-02 15:04 UTC")
+	for n, page := range layout.pages {
+		footer := fmt.Sprintf("Page %d of %d", n+1, len(layout.pages))
+		fmt.Fprintf(page, "BT /F1 8.0 Tf %.2f %.2f Td %s Tj ET\n", pdfMargin, pdfMargin, pdfString(doc.Title+" - generated "+generated))
+		fmt.Fprintf(page, "BT /F1 8.0 Tf %.2f %.2f Td %s Tj ET\n", layout.width-pdfMargin-pdfTextWidth(footer, false, 8), pdfMargin, pdfString(footer))
+	}
+	return writePDF(w, doc.Title, layout)
+}
+
+// pdfNumericColumns reports the columns whose cells are all numeric or empty, which
+// are right aligned
+func pdfNumericColumns(sheet *ReportSheet) []bool {
+	numeric := make([]bool, len(sheet.Columns))
+	for col := range numeric {
+		numeric[col] = true
+		seen := false
+		for _, row := range sheet.Rows {
+			if col >= len(row) || (row[col].Number == nil && row[col].Text == "") {
+				continue
+			}
+			seen = true
+			if row[col].Number == nil {
+				numeric[col] = false
+				break
+			}
+		}
+		numeric[col] = numeric[col] && seen
+	}
+	return numeric
+}
+
+// writePDF assembles the objects of the laid out pages into a PDF file
+func writePDF(w io.Writer, title string, layout *pdfLayout) error {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	// Objects 1-5 are the catalog, page tree, fonts and document information; each
+	// page then takes a page object and a content stream
+	kids := make([]string, len(layout.pages))
+	for i := range layout.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(layout.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	object(fmt.Sprintf("<< /Title %s /Producer (accounting) /CreationDate (D:%s) >>", pdfString(title), time.Now().UTC().Format("20060102150405Z")))
+	for i, page := range layout.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			layout.width, layout.height, 7+2*i))
+
+		var content bytes.Buffer
+		compressor := zlib.NewWriter(&content)
+		if _, err := compressor.Write([]byte(page.String())); err != nil {
+			return fmt.Errorf("failed to compress page %d: %w", i+1, err)
+		}
+		if err := compressor.Close(); err != nil {
+			return fmt.Errorf("failed to compress page %d: %w", i+1, err)
+		}
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	if _, err := w.Write(out.Bytes()); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
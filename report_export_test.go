@@ -0,0 +1,211 @@
+package accounting
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/zlib"
+	"encoding/csv"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readXLSXParts unzips a workbook into its parts by name
+func readXLSXParts(t *testing.T, data []byte) map[string]string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		reader.Close()
+		parts[file.Name] = string(content)
+	}
+	return parts
+}
+
+// readPDFText inflates the content streams of a PDF
+func readPDFText(t *testing.T, data []byte) string {
+	var text strings.Builder
+	for _, match := range regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllSubmatch(data, -1) {
+		reader, err := zlib.NewReader(bytes.NewReader(match[1]))
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		text.Write(content)
+	}
+	return text.String()
+}
+
+func TestReportExport(t *testing.T) {
+	dbFile := "test_report_export.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	post := func(debit, credit string, amount int64) {
+		txn := &Transaction{
+			Description: "Activity",
+			ValidTime:   time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: amount, Currency: "USD"}},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: amount, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	post("cash", "revenue", 1234567)
+	post("expenses", "cash", 200050)
+	asOf := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("trial balance as an XLSX workbook with numeric cells", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, engine.ExportTrialBalance(&out, ExportFormatXLSX, asOf, "USD"))
+
+		parts := readXLSXParts(t, out.Bytes())
+		for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+			assert.Contains(t, parts, name)
+		}
+		assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Trial Balance"`)
+
+		sheet := parts["xl/worksheets/sheet1.xml"]
+		assert.Contains(t, sheet, `state="frozen"`)
+		assert.Contains(t, sheet, ">Trial Balance<")
+		assert.Contains(t, sheet, ">As of 2024-03-31 (USD)<")
+		// Cash debit 10345.17, revenue credit 12345.67, expenses debit 2000.50
+		assert.Contains(t, sheet, "<v>10345.17</v>")
+		assert.Contains(t, sheet, "<v>12345.67</v>")
+		assert.Contains(t, sheet, "<v>2000.5</v>")
+		// Revenue, then the equal debit and credit totals
+		assert.Equal(t, 3, strings.Count(sheet, "<v>12345.67</v>"))
+		assert.Contains(t, parts["xl/styles.xml"], `numFmtId="4"`)
+	})
+
+	t.Run("financial statement lays out line items and totals", func(t *testing.T) {
+		statement, err := engine.GenerateBalanceSheet(asOf, "USD")
+		require.NoError(t, err)
+		doc := FinancialStatementDocument(statement)
+
+		require.NotEmpty(t, doc.Sheets)
+		assert.Equal(t, statement.Name, doc.Title)
+		assert.Contains(t, doc.Subtitle, "2024-03-31")
+		sheet := doc.Sheets[0]
+		var labels []string
+		for _, row := range sheet.Rows {
+			labels = append(labels, strings.TrimSpace(row[0].Text))
+		}
+		assert.Contains(t, labels, "Total Assets")
+
+		var out bytes.Buffer
+		require.NoError(t, ExportReport(&out, ExportFormatPDF, doc))
+		assert.Contains(t, readPDFText(t, out.Bytes()), "(Total Assets)")
+	})
+
+	t.Run("PDF is well formed and paginates long tables", func(t *testing.T) {
+		sheet := &ReportSheet{Name: "Entries", Columns: []string{"Reference", "Amount"}}
+		for i := range 150 {
+			sheet.Rows = append(sheet.Rows, []ReportCell{TextCell("REF (" + strconv.Itoa(i) + ")"), AmountCell(int64(i*100), "USD")})
+		}
+		doc := &ReportDocument{Title: "Entry Listing", Subtitle: "March 2024", Sheets: []*ReportSheet{sheet}}
+
+		var out bytes.Buffer
+		require.NoError(t, ExportReport(&out, ExportFormatPDF, doc))
+		data := out.Bytes()
+
+		assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4")))
+		assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+		startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+		require.NotNil(t, startxref)
+		offset, err := strconv.Atoi(string(startxref[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(data[offset:], []byte("xref\n")))
+
+		pages := regexp.MustCompile(`/Count (\d+)`).FindSubmatch(data)
+		require.NotNil(t, pages)
+		count, _ := strconv.Atoi(string(pages[1]))
+		assert.Greater(t, count, 1)
+
+		text := readPDFText(t, data)
+		assert.Contains(t, text, `(REF \(149\))`)
+		assert.Contains(t, text, "(Page 1 of "+strconv.Itoa(count)+")")
+		// The header row repeats on every page
+		assert.Equal(t, count, strings.Count(text, "(Reference)"))
+	})
+
+	t.Run("AML dashboard exports a sheet per section", func(t *testing.T) {
+		dashboard := &AMLDashboard{
+			PeriodStart:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEnd:         time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+			TotalAlerts:       7,
+			AlertsByRiskLevel: map[AMLRiskLevel]int{RiskHigh: 2, RiskCritical: 1, RiskLow: 4},
+			AlertsByType:      map[AMLRuleType]int{RuleStructuring: 3, RuleCTR: 4},
+			TopRiskyCustomers: []CustomerRiskSummary{{CustomerID: "cust-1", CustomerName: "Acme", RiskScore: 85, AlertCount: 3, TotalVolume: 4500000}},
+			TrendAnalysis:     AMLTrendAnalysis{AlertTrend30Days: []int{1, 2, 4}, VolumeTrend30Days: []int64{100, 200, 400}},
+			RecommendedActions: []AMLRecommendation{
+				{Priority: "HIGH", Category: "INVESTIGATION", Title: "Review structuring", DueDate: time.Date(2024, 4, 7, 0, 0, 0, 0, time.UTC)},
+			},
+		}
+
+		data, err := engine.GetAMLService().ExportAMLReport(dashboard, "XLSX")
+		require.NoError(t, err)
+		parts := readXLSXParts(t, data)
+		for i, name := range []string{"Summary", "Alerts by Type", "Alerts by Risk Level", "Daily Trend", "Top Risky Customers", "Recommendations"} {
+			assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="`+name+`" sheetId="`+strconv.Itoa(i+1)+`"`)
+		}
+		assert.Contains(t, parts["xl/worksheets/sheet2.xml"], ">STRUCTURING<")
+		assert.Contains(t, parts["xl/worksheets/sheet4.xml"], ">2024-03-29<")
+		assert.Contains(t, parts["xl/worksheets/sheet5.xml"], "<v>45000</v>")
+
+		data, err = engine.GetAMLService().ExportAMLReport(dashboard, "PDF")
+		require.NoError(t, err)
+		assert.Contains(t, readPDFText(t, data), "(Top Risky Customers)")
+	})
+
+	t.Run("budget variance puts the largest overspend first", func(t *testing.T) {
+		usd := func(v int64) *Amount { return &Amount{Value: v, Currency: "USD"} }
+		report := &BudgetVarianceReport{
+			PeriodID:      "2024-Q1",
+			DepartmentID:  "marketing",
+			TotalBudget:   usd(300000),
+			TotalSpent:    usd(330000),
+			TotalVariance: usd(-30000),
+			Items: []BudgetVarianceItem{
+				{AccountID: "travel", BudgetAmount: usd(100000), SpentAmount: usd(90000), Variance: usd(10000), VariancePercent: 10},
+				{AccountID: "ads", BudgetAmount: usd(100000), SpentAmount: usd(150000), Variance: usd(-50000), VariancePercent: -50},
+				{AccountID: "events", BudgetAmount: usd(100000), SpentAmount: usd(90000), Variance: usd(10000), VariancePercent: 10},
+			},
+		}
+		doc := BudgetVarianceDocument(report)
+		assert.Equal(t, "Period 2024-Q1, department marketing", doc.Subtitle)
+
+		var out bytes.Buffer
+		require.NoError(t, ExportReport(&out, ExportFormatCSV, doc))
+		records, err := csv.NewReader(&out).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Account", "Description", "Budget", "Spent", "Variance", "Variance %"}, records[0])
+		assert.Equal(t, []string{"ads", "", "1000.00", "1500.00", "-500.00", "-50.0"}, records[1])
+		assert.Equal(t, "travel", records[2][0])
+		assert.Equal(t, []string{"Total", "", "3000.00", "3300.00", "-300.00", "0.0"}, records[4])
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := NewExporter(ExportFormat("DOCX"))
+		assert.Error(t, err)
+		assert.Error(t, ExportReport(io.Discard, ExportFormatPDF, &ReportDocument{Title: "Empty"}))
+	})
+}
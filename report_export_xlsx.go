@@ -0,0 +1,272 @@
+package accounting
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// XLSXExporter writes an Office Open XML workbook with one worksheet per sheet. Each
+// worksheet starts with the document title and subtitle, then a bold header row
+// frozen above the data; numeric cells are stored as numbers with a thousands
+// separator format to their decimal places.
+type XLSXExporter struct{}
+
+// ContentType is the XLSX MIME type
+func (XLSXExporter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+// xlsxStyle is a cell style: bold or not, and the decimals of a numeric cell or -1
+// for text
+type xlsxStyle struct {
+	bold     bool
+	decimals int
+}
+
+// xlsxStyles numbers the cell styles a workbook uses; style 0 is plain text
+type xlsxStyles struct {
+	styles []xlsxStyle
+	index  map[xlsxStyle]int
+}
+
+// id returns the index of a style, adding it on first use
+func (s *xlsxStyles) id(style xlsxStyle) int {
+	if s.index == nil {
+		s.styles = []xlsxStyle{{decimals: -1}}
+		s.index = map[xlsxStyle]int{{decimals: -1}: 0}
+	}
+	if id, ok := s.index[style]; ok {
+		return id
+	}
+	s.styles = append(s.styles, style)
+	s.index[style] = len(s.styles) - 1
+	return len(s.styles) - 1
+}
+
+// Export writes the document as an XLSX workbook
+func (XLSXExporter) Export(w io.Writer, doc *ReportDocument) error {
+	if len(doc.Sheets) == 0 {
+		return fmt.Errorf("report %s has no sheets", doc.Title)
+	}
+	archive := zip.NewWriter(w)
+	styles := &xlsxStyles{}
+	styles.id(xlsxStyle{decimals: -1})
+
+	names := xlsxSheetNames(doc.Sheets)
+	for i, sheet := range doc.Sheets {
+		if err := writeZipPart(archive, fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), xlsxWorksheet(doc, sheet, styles)); err != nil {
+			return err
+		}
+	}
+
+	var contentTypes, workbook, workbookRels strings.Builder
+	contentTypes.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i, name := range names {
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(name), i+1, i+1)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`, len(names)+1)
+
+	parts := []struct {
+		name, content string
+	}{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", xlsxStylesheet(styles)},
+	}
+	for _, part := range parts {
+		if err := writeZipPart(archive, part.name, part.content); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return nil
+}
+
+// xlsxWorksheet renders one sheet: title rows, header row and data rows
+func xlsxWorksheet(doc *ReportDocument, sheet *ReportSheet, styles *xlsxStyles) string {
+	widths := make([]int, len(sheet.Columns))
+	measure := func(col int, text string) {
+		for col >= len(widths) {
+			widths = append(widths, 0)
+		}
+		widths[col] = max(widths[col], utf8.RuneCountInString(text))
+	}
+
+	var rows strings.Builder
+	rowNumber := 0
+	writeRow := func(cells []ReportCell) {
+		rowNumber++
+		fmt.Fprintf(&rows, `<row r="%d">`, rowNumber)
+		for col, cell := range cells {
+			ref := xlsxColumn(col) + strconv.Itoa(rowNumber)
+			if cell.Number != nil {
+				style := styles.id(xlsxStyle{bold: cell.Bold, decimals: cell.Decimals})
+				fmt.Fprintf(&rows, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, strconv.FormatFloat(*cell.Number, 'f', -1, 64))
+				continue
+			}
+			if cell.Text == "" {
+				continue
+			}
+			style := styles.id(xlsxStyle{bold: cell.Bold, decimals: -1})
+			fmt.Fprintf(&rows, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xmlEscape(cell.Text))
+		}
+		rows.WriteString(`</row>`)
+	}
+
+	writeRow([]ReportCell{TextCell(doc.Title).Bolded()})
+	if doc.Subtitle != "" {
+		writeRow([]ReportCell{TextCell(doc.Subtitle)})
+	}
+	rowNumber++ // blank row before the table
+	header := make([]ReportCell, len(sheet.Columns))
+	for i, column := range sheet.Columns {
+		header[i] = TextCell(column).Bolded()
+		measure(i, column)
+	}
+	writeRow(header)
+	headerRow := rowNumber
+	for _, row := range sheet.Rows {
+		for col, cell := range row {
+			measure(col, cell.Text)
+		}
+		writeRow(row)
+	}
+
+	var out strings.Builder
+	out.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	fmt.Fprintf(&out, `<sheetViews><sheetView workbookViewId="0"><pane ySplit="%d" topLeftCell="A%d" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`, headerRow, headerRow+1)
+	if len(widths) > 0 {
+		out.WriteString(`<cols>`)
+		for i, width := range widths {
+			fmt.Fprintf(&out, `<col min="%d" max="%d" width="%d" customWidth="1"/>`, i+1, i+1, min(max(width, 8), 60)+2)
+		}
+		out.WriteString(`</cols>`)
+	}
+	out.WriteString(`<sheetData>` + rows.String() + `</sheetData></worksheet>`)
+	return out.String()
+}
+
+// xlsxStylesheet declares the fonts, number formats and cell styles in use
+func xlsxStylesheet(styles *xlsxStyles) string {
+	var numFmts, cellXfs strings.Builder
+	customFormats := 0
+	formatIDs := make(map[int]int)
+	for _, style := range styles.styles {
+		formatID := 0
+		switch {
+		case style.decimals < 0:
+		case style.decimals == 0:
+			formatID = 3 // built-in #,##0
+		case style.decimals == 2:
+			formatID = 4 // built-in #,##0.00
+		default:
+			id, ok := formatIDs[style.decimals]
+			if !ok {
+				id = 164 + customFormats
+				customFormats++
+				formatIDs[style.decimals] = id
+				fmt.Fprintf(&numFmts, `<numFmt numFmtId="%d" formatCode="#,##0.%s"/>`, id, strings.Repeat("0", style.decimals))
+			}
+			formatID = id
+		}
+		font := 0
+		if style.bold {
+			font = 1
+		}
+		fmt.Fprintf(&cellXfs, `<xf numFmtId="%d" fontId="%d" fillId="0" borderId="0" xfId="0" applyNumberFormat="1" applyFont="1"/>`, formatID, font)
+	}
+
+	var out strings.Builder
+	out.WriteString(xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if customFormats > 0 {
+		fmt.Fprintf(&out, `<numFmts count="%d">%s</numFmts>`, customFormats, numFmts.String())
+	}
+	out.WriteString(`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+		`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+		`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+		`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>`)
+	fmt.Fprintf(&out, `<cellXfs count="%d">%s</cellXfs>`, len(styles.styles), cellXfs.String())
+	out.WriteString(`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles></styleSheet>`)
+	return out.String()
+}
+
+// xlsxSheetNames makes sheet names valid worksheet names: at most 31 characters,
+// none of []:*?/\ and unique regardless of case
+func xlsxSheetNames(sheets []*ReportSheet) []string {
+	names := make([]string, len(sheets))
+	used := make(map[string]bool)
+	for i, sheet := range sheets {
+		name := strings.Map(func(r rune) rune {
+			if strings.ContainsRune(`[]:*?/\`, r) {
+				return '-'
+			}
+			return r
+		}, sheet.Name)
+		if strings.TrimSpace(name) == "" {
+			name = fmt.Sprintf("Sheet%d", i+1)
+		}
+		base := []rune(name)
+		if len(base) > 31 {
+			base = base[:31]
+		}
+		name = string(base)
+		for n := 2; used[strings.ToLower(name)]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			name = string(base[:min(len(base), 31-len(suffix))]) + suffix
+		}
+		used[strings.ToLower(name)] = true
+		names[i] = name
+	}
+	return names
+}
+
+// xlsxColumn returns the letters of a zero-based column, e.g. 27 is AB
+func xlsxColumn(col int) string {
+	var letters []byte
+	for col++; col > 0; col = (col - 1) / 26 {
+		letters = append([]byte{byte('A' + (col-1)%26)}, letters...)
+	}
+	return string(letters)
+}
+
+// writeZipPart adds a file to a zip archive
+func writeZipPart(archive *zip.Writer, name, content string) error {
+	part, err := archive.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", name, err)
+	}
+	if _, err := io.WriteString(part, content); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// xmlEscape escapes text for XML content and attributes, dropping characters XML
+// cannot carry
+func xmlEscape(text string) string {
+	var out strings.Builder
+	if err := xml.EscapeText(&out, []byte(text)); err != nil {
+		return ""
+	}
+	return out.String()
+}
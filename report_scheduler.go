@@ -0,0 +1,476 @@
+package accounting
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Scheduled Reports
+// ----------------------------------------------------------------------------
+
+// ScheduledReportType is a report the scheduler can generate
+type ScheduledReportType string
+
+const (
+	ScheduledTrialBalance      ScheduledReportType = "TRIAL_BALANCE"
+	ScheduledBalanceSheet      ScheduledReportType = "BALANCE_SHEET"
+	ScheduledProfitAndLoss     ScheduledReportType = "PROFIT_AND_LOSS"
+	ScheduledSegmentDisclosure ScheduledReportType = "SEGMENT_DISCLOSURE"
+)
+
+// ReportPeriod is the period a scheduled report covers, relative to the time it is
+// scheduled for. Point-in-time reports are as of the end of the period.
+type ReportPeriod string
+
+const (
+	ReportPeriodPreviousMonth   ReportPeriod = "PREVIOUS_MONTH" // the default, for month-end packs
+	ReportPeriodMonthToDate     ReportPeriod = "MONTH_TO_DATE"
+	ReportPeriodPreviousQuarter ReportPeriod = "PREVIOUS_QUARTER"
+	ReportPeriodYearToDate      ReportPeriod = "YEAR_TO_DATE"
+)
+
+// ReportParameters are what a scheduled report is generated with
+type ReportParameters struct {
+	Currency string       `json:"currency"`
+	Period   ReportPeriod `json:"period"`
+}
+
+// ReportSchedule generates a report on a cron schedule and delivers it to a
+// directory, a registered callback, or both
+type ReportSchedule struct {
+	ID         string              `json:"id"`
+	Name       string              `json:"name"`
+	ReportType ScheduledReportType `json:"report_type"`
+	Parameters ReportParameters    `json:"parameters"`
+	Format     ExportFormat        `json:"format"`
+	OutputDir  string              `json:"output_dir,omitempty"`
+	Callback   string              `json:"callback,omitempty"` // name of a callback registered with the scheduler
+	Cron       string              `json:"cron"`
+	Active     bool                `json:"active"`
+	NextRunAt  time.Time           `json:"next_run_at"`
+	LastRunAt  *time.Time          `json:"last_run_at,omitempty"`
+	CreatedBy  string              `json:"created_by"`
+	CreatedAt  time.Time           `json:"created_at"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+}
+
+// ReportRunStatus is the outcome of one scheduled report run
+type ReportRunStatus string
+
+const (
+	ReportRunSucceeded ReportRunStatus = "SUCCEEDED"
+	ReportRunFailed    ReportRunStatus = "FAILED"
+)
+
+// ReportRun records one generation of a scheduled report
+type ReportRun struct {
+	ID           string          `json:"id"`
+	ScheduleID   string          `json:"schedule_id"`
+	ScheduledFor time.Time       `json:"scheduled_for"`
+	PeriodStart  time.Time       `json:"period_start"`
+	PeriodEnd    time.Time       `json:"period_end"`
+	Status       ReportRunStatus `json:"status"`
+	OutputPath   string          `json:"output_path,omitempty"`
+	Size         int64           `json:"size"` // bytes generated
+	Error        string          `json:"error,omitempty"`
+	RunBy        string          `json:"run_by"`
+	StartedAt    time.Time       `json:"started_at"`
+	FinishedAt   time.Time       `json:"finished_at"`
+}
+
+// ReportCallback receives a generated report. Returning an error fails the run.
+type ReportCallback func(schedule *ReportSchedule, run *ReportRun, content []byte) error
+
+// schedulerUserID is who runs started by the background runner are recorded against
+const schedulerUserID = "report_scheduler"
+
+// ReportScheduler generates reports on their schedules, either when RunDue is called
+// or from a background runner started with Start
+type ReportScheduler struct {
+	storage    *Storage
+	eventStore *EventStore
+	reporting  *ReportingService
+	segments   *SegmentService
+	now        func() time.Time
+
+	mu        sync.Mutex // guards callbacks and the runner
+	callbacks map[string]ReportCallback
+	stop      chan struct{}
+	done      chan struct{}
+
+	runMu  sync.Mutex // one pass over the schedules at a time
+	logger *slog.Logger
+}
+
+// NewReportScheduler creates a new report scheduler
+func NewReportScheduler(storage *Storage, eventStore *EventStore, reporting *ReportingService, segments *SegmentService) *ReportScheduler {
+	return &ReportScheduler{
+		storage:    storage,
+		eventStore: eventStore,
+		reporting:  reporting,
+		segments:   segments,
+		now:        time.Now,
+		callbacks:  make(map[string]ReportCallback),
+		logger:     discardLogger,
+	}
+}
+
+// SetLogger sends the scheduler's logs to a host logger; nil discards them. Set it
+// before Start.
+func (rs *ReportScheduler) SetLogger(logger *slog.Logger) {
+	rs.logger = componentLogger(logger, "report_scheduler")
+}
+
+// RegisterCallback makes a callback available to schedules by name. Callbacks are
+// not persisted and must be registered again after a restart.
+func (rs *ReportScheduler) RegisterCallback(name string, callback ReportCallback) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	rs.callbacks[name] = callback
+}
+
+// SaveSchedule validates and saves a report schedule, working out its next run
+func (rs *ReportScheduler) SaveSchedule(schedule *ReportSchedule, userID string) error {
+	if schedule.Name == "" {
+		return fmt.Errorf("schedule name is required")
+	}
+	switch schedule.ReportType {
+	case ScheduledTrialBalance, ScheduledBalanceSheet, ScheduledProfitAndLoss, ScheduledSegmentDisclosure:
+	default:
+		return fmt.Errorf("unsupported report type: %s", schedule.ReportType)
+	}
+	if schedule.Parameters.Period == "" {
+		schedule.Parameters.Period = ReportPeriodPreviousMonth
+	}
+	if _, _, err := reportPeriodRange(schedule.Parameters.Period, time.Now()); err != nil {
+		return err
+	}
+	if schedule.Parameters.Currency == "" {
+		schedule.Parameters.Currency = "USD"
+	}
+	if _, err := NewExporter(schedule.Format); err != nil {
+		return err
+	}
+	if schedule.OutputDir == "" && schedule.Callback == "" {
+		return fmt.Errorf("schedule %s needs an output directory or a callback", schedule.Name)
+	}
+	cron, err := ParseCronSchedule(schedule.Cron)
+	if err != nil {
+		return err
+	}
+
+	now := rs.now()
+	if schedule.ID == "" {
+		if err := rs.storage.assignID(&schedule.ID, "report_schedule", BucketReportSchedules); err != nil {
+			return err
+		}
+		schedule.Active = true
+		schedule.CreatedBy = userID
+		schedule.CreatedAt = now
+	}
+	schedule.NextRunAt = cron.Next(now)
+	schedule.UpdatedAt = now
+	return rs.saveSchedule(schedule, userID)
+}
+
+// SetActive pauses or resumes a schedule. A resumed schedule next runs at its first
+// time after now rather than catching up.
+func (rs *ReportScheduler) SetActive(scheduleID string, active bool, userID string) (*ReportSchedule, error) {
+	schedule, err := rs.storage.GetReportSchedule(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	cron, err := ParseCronSchedule(schedule.Cron)
+	if err != nil {
+		return nil, err
+	}
+	schedule.Active = active
+	schedule.NextRunAt = cron.Next(rs.now())
+	schedule.UpdatedAt = rs.now()
+	if err := rs.saveSchedule(schedule, userID); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// RunDue runs every active schedule whose next run is at or before now. A schedule
+// that missed several runs, say while the engine was stopped, runs once for the
+// earliest of them and then moves on to its first time after now. Failed runs are
+// recorded and not retried; the error is only for runs that could not be recorded.
+func (rs *ReportScheduler) RunDue(now time.Time, userID string) ([]*ReportRun, error) {
+	rs.runMu.Lock()
+	defer rs.runMu.Unlock()
+
+	schedules, err := rs.storage.GetAllReportSchedules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report schedules: %w", err)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].NextRunAt.Before(schedules[j].NextRunAt)
+	})
+
+	var runs []*ReportRun
+	for _, schedule := range schedules {
+		if !schedule.Active || schedule.NextRunAt.IsZero() || schedule.NextRunAt.After(now) {
+			continue
+		}
+		cron, err := ParseCronSchedule(schedule.Cron)
+		if err != nil {
+			return runs, err
+		}
+		run, err := rs.run(schedule, schedule.NextRunAt, userID)
+		if err != nil {
+			return runs, err
+		}
+		runs = append(runs, run)
+
+		schedule.LastRunAt = &run.StartedAt
+		schedule.NextRunAt = cron.Next(now)
+		schedule.UpdatedAt = rs.now()
+		if err := rs.saveSchedule(schedule, userID); err != nil {
+			return runs, err
+		}
+	}
+	return runs, nil
+}
+
+// RunNow generates a scheduled report straight away without moving its schedule
+func (rs *ReportScheduler) RunNow(scheduleID, userID string) (*ReportRun, error) {
+	rs.runMu.Lock()
+	defer rs.runMu.Unlock()
+
+	schedule, err := rs.storage.GetReportSchedule(scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	return rs.run(schedule, rs.now(), userID)
+}
+
+// GetRuns returns the run history of a schedule, most recent first
+func (rs *ReportScheduler) GetRuns(scheduleID string) ([]*ReportRun, error) {
+	all, err := rs.storage.GetAllReportRuns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report runs: %w", err)
+	}
+	var runs []*ReportRun
+	for _, run := range all {
+		if run.ScheduleID == scheduleID {
+			runs = append(runs, run)
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].StartedAt.After(runs[j].StartedAt)
+	})
+	return runs, nil
+}
+
+// Start runs due schedules straight away and then every interval in the background
+// until Stop is called. Failed runs land in the run history; a pass that could not
+// record its runs is retried on the next tick.
+func (rs *ReportScheduler) Start(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("scheduler interval must be positive")
+	}
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	if rs.stop != nil {
+		return fmt.Errorf("report scheduler is already running")
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	rs.stop, rs.done = stop, done
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := rs.RunDue(rs.now(), schedulerUserID); err != nil {
+				rs.logger.Error("scheduled report pass failed, retrying next tick", LogKeyError, err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops the background runner, waiting for a pass in progress to finish. It
+// does nothing when the runner is not started.
+func (rs *ReportScheduler) Stop() {
+	rs.mu.Lock()
+	stop, done := rs.stop, rs.done
+	rs.stop, rs.done = nil, nil
+	rs.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+// Running reports whether the background runner is started
+func (rs *ReportScheduler) Running() bool {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+	return rs.stop != nil
+}
+
+// run generates a schedule's report for a time, delivers it and records the run
+func (rs *ReportScheduler) run(schedule *ReportSchedule, scheduledFor time.Time, userID string) (*ReportRun, error) {
+	run := &ReportRun{
+		ScheduleID:   schedule.ID,
+		ScheduledFor: scheduledFor,
+		RunBy:        userID,
+		StartedAt:    rs.now(),
+	}
+	if err := rs.storage.assignID(&run.ID, "report_run", BucketReportRuns); err != nil {
+		return nil, err
+	}
+
+	if err := rs.generate(schedule, run); err != nil {
+		run.Status = ReportRunFailed
+		run.Error = err.Error()
+		rs.logger.Warn("scheduled report failed", LogKeyScheduleID, schedule.ID, LogKeyRunID, run.ID, "report", schedule.ReportType, LogKeyError, err)
+	} else {
+		run.Status = ReportRunSucceeded
+	}
+	run.FinishedAt = rs.now()
+
+	_, err := rs.eventStore.CreateEvent(EventRecordReportRun, run, run.StartedAt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report run event: %w", err)
+	}
+	if err := rs.storage.SaveReportRun(run); err != nil {
+		return nil, fmt.Errorf("failed to save report run: %w", err)
+	}
+	return run, nil
+}
+
+// generate builds and exports the report, then writes it to the output directory
+// and hands it to the callback
+func (rs *ReportScheduler) generate(schedule *ReportSchedule, run *ReportRun) error {
+	from, to, err := reportPeriodRange(schedule.Parameters.Period, run.ScheduledFor)
+	if err != nil {
+		return err
+	}
+	run.PeriodStart, run.PeriodEnd = from, to
+
+	doc, err := rs.document(schedule.ReportType, from, to, schedule.Parameters.Currency)
+	if err != nil {
+		return err
+	}
+	doc.Title = schedule.Name + " - " + doc.Title
+	var content bytes.Buffer
+	if err := ExportReport(&content, schedule.Format, doc); err != nil {
+		return err
+	}
+	run.Size = int64(content.Len())
+
+	if schedule.OutputDir != "" {
+		if err := os.MkdirAll(schedule.OutputDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		path := filepath.Join(schedule.OutputDir, reportFileName(schedule, run.ScheduledFor))
+		if err := os.WriteFile(path, content.Bytes(), 0o644); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		run.OutputPath = path
+	}
+	if schedule.Callback != "" {
+		rs.mu.Lock()
+		callback, ok := rs.callbacks[schedule.Callback]
+		rs.mu.Unlock()
+		if !ok {
+			return fmt.Errorf("no report callback registered as %s", schedule.Callback)
+		}
+		if err := callback(schedule, run, content.Bytes()); err != nil {
+			return fmt.Errorf("report callback %s failed: %w", schedule.Callback, err)
+		}
+	}
+	return nil
+}
+
+// document generates a report for a period laid out for export
+func (rs *ReportScheduler) document(reportType ScheduledReportType, from, to time.Time, currency string) (*ReportDocument, error) {
+	switch reportType {
+	case ScheduledTrialBalance:
+		balances, err := rs.reporting.GenerateTrialBalance(to, currency)
+		if err != nil {
+			return nil, err
+		}
+		return TrialBalanceDocument(balances, to, currency), nil
+	case ScheduledBalanceSheet:
+		statement, err := rs.reporting.GenerateBalanceSheet(to, currency)
+		if err != nil {
+			return nil, err
+		}
+		return FinancialStatementDocument(statement), nil
+	case ScheduledProfitAndLoss:
+		statement, err := rs.reporting.GenerateProfitAndLoss(from, to, currency)
+		if err != nil {
+			return nil, err
+		}
+		return FinancialStatementDocument(statement), nil
+	case ScheduledSegmentDisclosure:
+		disclosure, err := rs.segments.GenerateSegmentDisclosure(from, to, currency)
+		if err != nil {
+			return nil, err
+		}
+		return SegmentDisclosureDocument(disclosure), nil
+	default:
+		return nil, fmt.Errorf("unsupported report type: %s", reportType)
+	}
+}
+
+// saveSchedule records and stores a schedule
+func (rs *ReportScheduler) saveSchedule(schedule *ReportSchedule, userID string) error {
+	_, err := rs.eventStore.CreateEvent(EventSaveReportSchedule, schedule, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create report schedule event: %w", err)
+	}
+	return rs.storage.SaveReportSchedule(schedule)
+}
+
+// reportPeriodRange is the first and last instant of a report period relative to a
+// time
+func reportPeriodRange(period ReportPeriod, at time.Time) (time.Time, time.Time, error) {
+	monthStart := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, at.Location())
+	switch period {
+	case ReportPeriodPreviousMonth:
+		return monthStart.AddDate(0, -1, 0), monthStart.Add(-time.Nanosecond), nil
+	case ReportPeriodMonthToDate:
+		return monthStart, at, nil
+	case ReportPeriodPreviousQuarter:
+		quarterStart := time.Date(at.Year(), at.Month()-(at.Month()-1)%3, 1, 0, 0, 0, 0, at.Location())
+		return quarterStart.AddDate(0, -3, 0), quarterStart.Add(-time.Nanosecond), nil
+	case ReportPeriodYearToDate:
+		return time.Date(at.Year(), 1, 1, 0, 0, 0, 0, at.Location()), at, nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("unsupported report period: %s", period)
+	}
+}
+
+// reportFileName names a run's output after the schedule and the time it was
+// scheduled for
+func reportFileName(schedule *ReportSchedule, scheduledFor time.Time) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, schedule.Name)
+	return fmt.Sprintf("%s_%s.%s", name, scheduledFor.Format("20060102_1504"), strings.ToLower(string(schedule.Format)))
+}
@@ -0,0 +1,205 @@
+package accounting
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportScheduler(t *testing.T) {
+	dbFile := "test_report_scheduler.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	txn := &Transaction{
+		Description: "April sales",
+		ValidTime:   time.Date(2024, 4, 12, 9, 0, 0, 0, time.UTC),
+		Entries: []Entry{
+			{AccountID: "cash", Type: Debit, Amount: Amount{Value: 125000, Currency: "USD"}},
+			{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 125000, Currency: "USD"}},
+		},
+	}
+	require.NoError(t, engine.CreateTransaction(txn, userID))
+	require.NoError(t, engine.PostTransaction(txn.ID, userID))
+
+	scheduler := engine.GetReportScheduler()
+	var clockMu sync.Mutex
+	clock := time.Date(2024, 4, 20, 10, 0, 0, 0, time.UTC)
+	scheduler.now = func() time.Time {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+		return clock
+	}
+	setClock := func(t time.Time) {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+		clock = t
+	}
+
+	t.Run("validates schedules", func(t *testing.T) {
+		base := ReportSchedule{Name: "Bad", ReportType: ScheduledTrialBalance, Format: ExportFormatCSV, OutputDir: t.TempDir(), Cron: "0 6 1 * *"}
+		for _, tc := range []struct {
+			change func(*ReportSchedule)
+			err    string
+		}{
+			{func(s *ReportSchedule) { s.Cron = "0 6 32 * *" }, "invalid day of month"},
+			{func(s *ReportSchedule) { s.OutputDir = "" }, "needs an output directory or a callback"},
+			{func(s *ReportSchedule) { s.Format = "DOCX" }, "unsupported export format"},
+			{func(s *ReportSchedule) { s.ReportType = "CASH_FORECAST" }, "unsupported report type"},
+			{func(s *ReportSchedule) { s.Parameters.Period = "FORTNIGHT" }, "unsupported report period"},
+		} {
+			schedule := base
+			tc.change(&schedule)
+			assert.ErrorContains(t, engine.SaveReportSchedule(&schedule, userID), tc.err)
+		}
+	})
+
+	outputDir := filepath.Join(t.TempDir(), "month-end")
+	monthEnd := &ReportSchedule{
+		Name:       "Month-end TB",
+		ReportType: ScheduledTrialBalance,
+		Format:     ExportFormatXLSX,
+		OutputDir:  outputDir,
+		Cron:       "0 6 1 * *",
+	}
+	require.NoError(t, engine.SaveReportSchedule(monthEnd, userID))
+
+	t.Run("schedule defaults and first run time", func(t *testing.T) {
+		assert.True(t, monthEnd.Active)
+		assert.Equal(t, ReportPeriodPreviousMonth, monthEnd.Parameters.Period)
+		assert.Equal(t, "USD", monthEnd.Parameters.Currency)
+		assert.Equal(t, time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC), monthEnd.NextRunAt)
+	})
+
+	t.Run("runs when due and writes the report", func(t *testing.T) {
+		runs, err := engine.RunDueReports(time.Date(2024, 4, 30, 23, 0, 0, 0, time.UTC), userID)
+		require.NoError(t, err)
+		assert.Empty(t, runs)
+
+		setClock(time.Date(2024, 5, 1, 6, 0, 30, 0, time.UTC))
+		runs, err = engine.RunDueReports(scheduler.now(), userID)
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		run := runs[0]
+		assert.Equal(t, ReportRunSucceeded, run.Status, run.Error)
+		assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), run.PeriodStart)
+		assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond), run.PeriodEnd)
+		assert.Equal(t, filepath.Join(outputDir, "Month-end_TB_20240501_0600.xlsx"), run.OutputPath)
+
+		content, err := os.ReadFile(run.OutputPath)
+		require.NoError(t, err)
+		assert.Equal(t, run.Size, int64(len(content)))
+		sheet := readXLSXParts(t, content)["xl/worksheets/sheet1.xml"]
+		assert.Contains(t, sheet, ">Month-end TB - Trial Balance<")
+		assert.Contains(t, sheet, "<v>1250</v>")
+
+		schedule, err := engine.storage.GetReportSchedule(monthEnd.ID)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 6, 1, 6, 0, 0, 0, time.UTC), schedule.NextRunAt)
+		require.NotNil(t, schedule.LastRunAt)
+
+		// Not due again until June
+		runs, err = engine.RunDueReports(scheduler.now(), userID)
+		require.NoError(t, err)
+		assert.Empty(t, runs)
+	})
+
+	t.Run("missed runs catch up once", func(t *testing.T) {
+		setClock(time.Date(2024, 8, 15, 12, 0, 0, 0, time.UTC))
+		runs, err := engine.RunDueReports(scheduler.now(), userID)
+		require.NoError(t, err)
+		require.Len(t, runs, 1)
+		assert.Equal(t, time.Date(2024, 6, 1, 6, 0, 0, 0, time.UTC), runs[0].ScheduledFor)
+
+		schedule, err := engine.storage.GetReportSchedule(monthEnd.ID)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 9, 1, 6, 0, 0, 0, time.UTC), schedule.NextRunAt)
+
+		history, err := engine.GetReportRuns(monthEnd.ID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+		assert.Equal(t, runs[0].ID, history[0].ID)
+	})
+
+	t.Run("paused schedules do not run", func(t *testing.T) {
+		paused, err := engine.SetReportScheduleActive(monthEnd.ID, false, userID)
+		require.NoError(t, err)
+		assert.False(t, paused.Active)
+		runs, err := engine.RunDueReports(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), userID)
+		require.NoError(t, err)
+		assert.Empty(t, runs)
+	})
+
+	t.Run("delivers to a callback and records failures", func(t *testing.T) {
+		var delivered bytes.Buffer
+		engine.RegisterReportCallback("archive", func(schedule *ReportSchedule, run *ReportRun, content []byte) error {
+			delivered.Write(content)
+			return nil
+		})
+		pl := &ReportSchedule{Name: "MTD P&L", ReportType: ScheduledProfitAndLoss, Format: ExportFormatPDF, Callback: "archive",
+			Cron: "@daily", Parameters: ReportParameters{Period: ReportPeriodMonthToDate}}
+		require.NoError(t, engine.SaveReportSchedule(pl, userID))
+
+		run, err := engine.RunScheduledReport(pl.ID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, ReportRunSucceeded, run.Status, run.Error)
+		assert.True(t, bytes.HasPrefix(delivered.Bytes(), []byte("%PDF-")))
+		assert.Empty(t, run.OutputPath)
+
+		engine.RegisterReportCallback("failing", func(*ReportSchedule, *ReportRun, []byte) error {
+			return fmt.Errorf("mailbox full")
+		})
+		pl.Callback = "failing"
+		require.NoError(t, engine.SaveReportSchedule(pl, userID))
+		run, err = engine.RunScheduledReport(pl.ID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, ReportRunFailed, run.Status)
+		assert.Equal(t, "report callback failing failed: mailbox full", run.Error)
+
+		pl.Callback = "unknown"
+		require.NoError(t, engine.SaveReportSchedule(pl, userID))
+		run, err = engine.RunScheduledReport(pl.ID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, ReportRunFailed, run.Status)
+		assert.Contains(t, run.Error, "no report callback registered as unknown")
+	})
+
+	t.Run("background runner", func(t *testing.T) {
+		delivered := make(chan time.Time, 10)
+		engine.RegisterReportCallback("notify", func(schedule *ReportSchedule, run *ReportRun, content []byte) error {
+			delivered <- run.ScheduledFor
+			return nil
+		})
+		hourly := &ReportSchedule{Name: "Hourly TB", ReportType: ScheduledTrialBalance, Format: ExportFormatCSV, Callback: "notify", Cron: "@hourly"}
+		require.NoError(t, engine.SaveReportSchedule(hourly, userID))
+
+		setClock(scheduler.now().Add(2 * time.Hour))
+		require.NoError(t, engine.StartReportScheduler(5*time.Millisecond))
+		assert.Error(t, engine.StartReportScheduler(5*time.Millisecond))
+
+		select {
+		case scheduledFor := <-delivered:
+			assert.Equal(t, hourly.NextRunAt, scheduledFor)
+		case <-time.After(5 * time.Second):
+			t.Fatal("background runner did not generate the report")
+		}
+		engine.StopReportScheduler()
+		assert.False(t, scheduler.Running())
+		engine.StopReportScheduler()
+
+		// Each due time runs once however often the runner ticks
+		assert.Empty(t, delivered)
+	})
+}
@@ -0,0 +1,419 @@
+package accounting
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ExportFormat is the layout of a streamed report or export
+type ExportFormat string
+
+const (
+	// ExportFormatCSV writes a header row and then one row per record
+	ExportFormatCSV ExportFormat = "CSV"
+	// ExportFormatJSONLines writes one JSON object per line
+	ExportFormatJSONLines ExportFormat = "JSONL"
+	// ExportFormatJSON writes a whole document as one JSON value
+	ExportFormatJSON ExportFormat = "JSON"
+	// ExportFormatText lays a document out for reading
+	ExportFormatText ExportFormat = "TEXT"
+	// ExportFormatXLSX writes an Office Open XML workbook, one worksheet per table
+	ExportFormatXLSX ExportFormat = "XLSX"
+	// ExportFormatPDF writes a paginated PDF document
+	ExportFormatPDF ExportFormat = "PDF"
+	// ExportFormatGraphML writes a transaction graph as GraphML XML
+	ExportFormatGraphML ExportFormat = "GRAPHML"
+	// ExportFormatDOT writes a transaction graph in the Graphviz DOT language
+	ExportFormatDOT ExportFormat = "DOT"
+	// ExportFormatNeo4jCSV writes a zip of node and relationship CSVs for neo4j-admin import
+	ExportFormatNeo4jCSV ExportFormat = "NEO4J_CSV"
+)
+
+// General ledger detail row types
+const (
+	LedgerRowOpening = "OPENING"
+	LedgerRowEntry   = "ENTRY"
+	LedgerRowClosing = "CLOSING"
+)
+
+// GeneralLedgerRow is one line of the general ledger detail: an account's opening
+// balance, one of its entries, or its closing balance. Amounts are in minor units.
+type GeneralLedgerRow struct {
+	RowType       string      `json:"row_type"`
+	AccountID     string      `json:"account_id"`
+	AccountCode   string      `json:"account_code"`
+	AccountName   string      `json:"account_name"`
+	Date          time.Time   `json:"date"`
+	TransactionID string      `json:"transaction_id,omitempty"`
+	EntryID       string      `json:"entry_id,omitempty"`
+	Description   string      `json:"description,omitempty"`
+	Debit         int64       `json:"debit"`
+	Credit        int64       `json:"credit"`
+	Currency      Currency    `json:"currency"`
+	Balance       int64       `json:"balance"` // running balance on the account's normal side
+	Dimensions    []Dimension `json:"dimensions,omitempty"`
+}
+
+var generalLedgerHeader = []string{"row_type", "account_id", "account_code", "account_name", "date", "transaction_id", "entry_id", "description", "debit", "credit", "currency", "balance", "dimensions"}
+
+func (r *GeneralLedgerRow) csvRecord() []string {
+	return []string{r.RowType, r.AccountID, r.AccountCode, r.AccountName, r.Date.Format(time.RFC3339Nano), r.TransactionID, r.EntryID, r.Description,
+		FormatMinorUnits(r.Debit, r.Currency), FormatMinorUnits(r.Credit, r.Currency), string(r.Currency), FormatMinorUnits(r.Balance, r.Currency), formatExportDimensions(r.Dimensions)}
+}
+
+// JournalRow is one entry of the journal listing, with its transaction's details
+type JournalRow struct {
+	Date          time.Time   `json:"date"`
+	TransactionID string      `json:"transaction_id"`
+	SourceRef     string      `json:"source_ref,omitempty"`
+	Description   string      `json:"description"`
+	EntryID       string      `json:"entry_id"`
+	AccountID     string      `json:"account_id"`
+	AccountName   string      `json:"account_name"`
+	Debit         int64       `json:"debit"`
+	Credit        int64       `json:"credit"`
+	Currency      Currency    `json:"currency"`
+	Dimensions    []Dimension `json:"dimensions,omitempty"`
+	UserID        string      `json:"user_id,omitempty"`
+}
+
+var journalHeader = []string{"date", "transaction_id", "source_ref", "description", "entry_id", "account_id", "account_name", "debit", "credit", "currency", "dimensions", "user_id"}
+
+func (r *JournalRow) csvRecord() []string {
+	return []string{r.Date.Format(time.RFC3339Nano), r.TransactionID, r.SourceRef, r.Description, r.EntryID, r.AccountID, r.AccountName,
+		FormatMinorUnits(r.Debit, r.Currency), FormatMinorUnits(r.Credit, r.Currency), string(r.Currency), formatExportDimensions(r.Dimensions), r.UserID}
+}
+
+// exportHeader names the transaction export columns, in the import file layout
+var exportHeader = []string{"id", "ref", "date", "description", "account_id", "type", "amount", "currency", "dimensions"}
+
+// reportStream writes report rows to a writer as CSV or JSON lines, buffering only a
+// few kilobytes at a time
+type reportStream struct {
+	buffer  *bufio.Writer
+	csv     *csv.Writer
+	encoder *json.Encoder
+}
+
+// newReportStream starts a stream, writing the CSV header straight away
+func newReportStream(w io.Writer, format ExportFormat, header []string) (*reportStream, error) {
+	stream := &reportStream{buffer: bufio.NewWriter(w)}
+	switch format {
+	case ExportFormatCSV:
+		stream.csv = csv.NewWriter(stream.buffer)
+		if err := stream.csv.Write(header); err != nil {
+			return nil, fmt.Errorf("failed to write header: %w", err)
+		}
+	case ExportFormatJSONLines:
+		stream.encoder = json.NewEncoder(stream.buffer)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+	return stream, nil
+}
+
+// write emits one row: its CSV record, or the JSON encoding of value
+func (rs *reportStream) write(record []string, value any) error {
+	if rs.csv != nil {
+		if err := rs.csv.Write(record); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+		return nil
+	}
+	if err := rs.encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to write row: %w", err)
+	}
+	return nil
+}
+
+// close flushes whatever is still buffered
+func (rs *reportStream) close() error {
+	if rs.csv != nil {
+		rs.csv.Flush()
+		if err := rs.csv.Error(); err != nil {
+			return fmt.Errorf("failed to write rows: %w", err)
+		}
+	}
+	if err := rs.buffer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush report: %w", err)
+	}
+	return nil
+}
+
+// WriteGeneralLedgerDetail streams the general ledger detail for the accounts, or all
+// accounts when none are given, in account code order. Each account with activity or
+// a balance gets an opening row, one row per entry between from and to with a running
+// balance, and a closing row. A zero from starts at inception. Entries are read in
+// batches from the posting index, so memory stays bounded however many there are.
+func (rs *ReportingService) WriteGeneralLedgerDetail(w io.Writer, format ExportFormat, accountIDs []string, from, to time.Time) error {
+	accounts, err := rs.ledgerAccounts(accountIDs)
+	if err != nil {
+		return err
+	}
+
+	stream, err := newReportStream(w, format, generalLedgerHeader)
+	if err != nil {
+		return err
+	}
+	for _, account := range accounts {
+		err := rs.walkAccountLedger(account, from, to, func(row *GeneralLedgerRow) error {
+			return stream.write(row.csvRecord(), row)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return stream.close()
+}
+
+// ledgerAccounts returns the accounts, or all accounts when none are given, in
+// account code order
+func (rs *ReportingService) ledgerAccounts(accountIDs []string) ([]*Account, error) {
+	accounts, err := rs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	if len(accountIDs) > 0 {
+		byID := make(map[string]*Account, len(accounts))
+		for _, account := range accounts {
+			byID[account.ID] = account
+		}
+		accounts = accounts[:0]
+		for _, id := range accountIDs {
+			account, ok := byID[id]
+			if !ok {
+				return nil, notFound("account", id)
+			}
+			accounts = append(accounts, account)
+		}
+	}
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return accounts[i].Code < accounts[j].Code
+	})
+	return accounts, nil
+}
+
+// walkAccountLedger calls fn with one account's opening row, entry rows and closing
+// row. Accounts with no entries and no balance produce no rows.
+func (rs *ReportingService) walkAccountLedger(account *Account, from, to time.Time, fn func(*GeneralLedgerRow) error) error {
+	var balance int64
+	if !from.IsZero() {
+		opening, err := rs.queryAPI.GetAccountBalance(account.ID, from.Add(-time.Nanosecond))
+		if err != nil {
+			return fmt.Errorf("failed to get opening balance for account %s: %w", account.ID, err)
+		}
+		balance = opening.Balance.Value
+	}
+
+	row := func(rowType string, date time.Time) *GeneralLedgerRow {
+		return &GeneralLedgerRow{
+			RowType:     rowType,
+			AccountID:   account.ID,
+			AccountCode: account.Code,
+			AccountName: account.Name,
+			Date:        date,
+			Currency:    account.Currency,
+			Balance:     balance,
+		}
+	}
+	// The opening row waits for the first entry, so quiet accounts with no balance
+	// are left out
+	opened := false
+	open := func() error {
+		if opened {
+			return nil
+		}
+		opened = true
+		return fn(row(LedgerRowOpening, from))
+	}
+
+	err := rs.storage.ForEachAccountPosting(account.ID, from, to, func(txn *Transaction) error {
+		if err := open(); err != nil {
+			return err
+		}
+		for _, entry := range txn.Entries {
+			if entry.AccountID != account.ID {
+				continue
+			}
+			signed := entry.Amount.Value * debitSign(account.Type)
+			line := row(LedgerRowEntry, txn.ValidTime)
+			if entry.Type == Debit {
+				line.Debit = entry.Amount.Value
+			} else {
+				line.Credit = entry.Amount.Value
+				signed = -signed
+			}
+			balance += signed
+			line.Balance = balance
+			line.TransactionID = txn.ID
+			line.EntryID = entry.ID
+			line.Description = txn.Description
+			line.Currency = entry.Amount.Currency
+			line.Dimensions = entry.Dimensions
+			if err := fn(line); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read ledger for account %s: %w", account.ID, err)
+	}
+
+	if !opened && balance == 0 {
+		return nil
+	}
+	if err := open(); err != nil {
+		return err
+	}
+	return fn(row(LedgerRowClosing, to))
+}
+
+// WriteJournalListing streams every entry of the transactions posted between from and
+// to, in valid-time order, one row per entry. A zero from starts at inception.
+func (rs *ReportingService) WriteJournalListing(w io.Writer, format ExportFormat, from, to time.Time) error {
+	names, err := rs.accountNames()
+	if err != nil {
+		return err
+	}
+	stream, err := newReportStream(w, format, journalHeader)
+	if err != nil {
+		return err
+	}
+
+	err = rs.storage.ForEachPostedTransaction(from, to, func(txn *Transaction) error {
+		for _, entry := range txn.Entries {
+			row := &JournalRow{
+				Date:          txn.ValidTime,
+				TransactionID: txn.ID,
+				SourceRef:     txn.SourceRef,
+				Description:   txn.Description,
+				EntryID:       entry.ID,
+				AccountID:     entry.AccountID,
+				AccountName:   names[entry.AccountID],
+				Currency:      entry.Amount.Currency,
+				Dimensions:    entry.Dimensions,
+				UserID:        txn.UserID,
+			}
+			if entry.Type == Debit {
+				row.Debit = entry.Amount.Value
+			} else {
+				row.Credit = entry.Amount.Value
+			}
+			if err := stream.write(row.csvRecord(), row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream journal: %w", err)
+	}
+	return stream.close()
+}
+
+// ExportTransactions streams the posted transactions matching the filter in the
+// transaction import layout, so the file can be loaded into another ledger with
+// ImportTransactions. The filter's valid-time range applies, and a transaction is
+// exported when any of its entries matches the filter's accounts, currencies and
+// dimensions. Each transaction's ID is written as both its id and ref.
+func (rs *ReportingService) ExportTransactions(w io.Writer, format ExportFormat, filter *QueryFilter) error {
+	if filter == nil {
+		filter = &QueryFilter{}
+	}
+	to := time.Now()
+	if filter.ValidTimeTo != nil {
+		to = *filter.ValidTimeTo
+	}
+	var from time.Time
+	if filter.ValidTimeFrom != nil {
+		from = *filter.ValidTimeFrom
+	}
+	accounts, err := rs.storage.GetAllAccounts()
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+	byID := make(map[string]*Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+
+	stream, err := newReportStream(w, format, exportHeader)
+	if err != nil {
+		return err
+	}
+	err = rs.storage.ForEachPostedTransaction(from, to, func(txn *Transaction) error {
+		matched := false
+		for i := range txn.Entries {
+			if account, ok := byID[txn.Entries[i].AccountID]; ok && matchesBalanceFilter(filter, &txn.Entries[i], account) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil
+		}
+
+		date := txn.ValidTime.Format(time.RFC3339Nano)
+		if stream.csv != nil {
+			for _, entry := range txn.Entries {
+				record := []string{txn.ID, txn.ID, date, txn.Description, entry.AccountID, string(entry.Type),
+					FormatMinorUnits(entry.Amount.Value, entry.Amount.Currency), string(entry.Amount.Currency), formatExportDimensions(entry.Dimensions)}
+				if err := stream.write(record, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		record := importRecord{ID: txn.ID, Ref: txn.ID, Date: date, Description: txn.Description}
+		for _, entry := range txn.Entries {
+			line := importLine{
+				AccountID: entry.AccountID,
+				Type:      string(entry.Type),
+				Amount:    json.Number(FormatMinorUnits(entry.Amount.Value, entry.Amount.Currency)),
+				Currency:  string(entry.Amount.Currency),
+			}
+			if len(entry.Dimensions) > 0 {
+				line.Dimensions = make(map[string]string, len(entry.Dimensions))
+				for _, dim := range entry.Dimensions {
+					line.Dimensions[string(dim.Key)] = dim.Value
+				}
+			}
+			record.Entries = append(record.Entries, line)
+		}
+		return stream.write(nil, record)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to stream transactions: %w", err)
+	}
+	return stream.close()
+}
+
+// accountNames maps account IDs to names
+func (rs *ReportingService) accountNames() (map[string]string, error) {
+	accounts, err := rs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	names := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		names[account.ID] = account.Name
+	}
+	return names, nil
+}
+
+// formatExportDimensions renders dimensions as key=value pairs separated by semicolons
+func formatExportDimensions(dimensions []Dimension) string {
+	pairs := make([]string, len(dimensions))
+	for i, dim := range dimensions {
+		pairs[i] = string(dim.Key) + "=" + dim.Value
+	}
+	return strings.Join(pairs, ";")
+}
@@ -0,0 +1,179 @@
+package accounting
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportStreams(t *testing.T) {
+	dbFile := "test_report_streams.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	post := func(debit, credit string, amount int64, validTime time.Time, dims ...Dimension) *Transaction {
+		txn := &Transaction{
+			Description: "Activity",
+			ValidTime:   validTime,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: dims},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: dims},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	day := func(d int) time.Time { return time.Date(2024, 5, d, 9, 0, 0, 0, time.UTC) }
+
+	post("cash", "revenue", 50000, day(20))
+	opening := post("cash", "revenue", 10000, day(1))
+	sale := post("accounts_receivable", "revenue", 25000, day(12), Dimension{Key: DimDepartment, Value: "sales"})
+	post("expenses", "cash", 7500, day(15))
+	reversed := post("cash", "revenue", 999, day(16))
+	_, err = engine.ReverseTransaction(reversed.ID, "Entered in error", userID)
+	require.NoError(t, err)
+
+	readCSV := func(data []byte) [][]string {
+		rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+		require.NoError(t, err)
+		return rows
+	}
+
+	t.Run("general ledger detail with running balances", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, engine.WriteGeneralLedgerDetail(&out, ExportFormatCSV, []string{"cash"}, day(2), day(31)))
+
+		rows := readCSV(out.Bytes())
+		require.Len(t, rows, 5)
+		assert.Equal(t, generalLedgerHeader, rows[0])
+		assert.Equal(t, []string{LedgerRowOpening, "100.00"}, []string{rows[1][0], rows[1][11]})
+		assert.Equal(t, []string{LedgerRowEntry, "0.00", "75.00", "25.00"}, []string{rows[2][0], rows[2][8], rows[2][9], rows[2][11]})
+		assert.Equal(t, []string{LedgerRowEntry, "500.00", "525.00"}, []string{rows[3][0], rows[3][8], rows[3][11]})
+		assert.Equal(t, []string{LedgerRowClosing, "525.00"}, []string{rows[4][0], rows[4][11]})
+	})
+
+	t.Run("general ledger skips quiet accounts", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, engine.WriteGeneralLedgerDetail(&out, ExportFormatJSONLines, nil, time.Time{}, day(31)))
+
+		accounts := make(map[string]bool)
+		scanner := bufio.NewScanner(&out)
+		for scanner.Scan() {
+			var row GeneralLedgerRow
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+			accounts[row.AccountID] = true
+		}
+		assert.Equal(t, map[string]bool{"cash": true, "accounts_receivable": true, "revenue": true, "expenses": true}, accounts)
+	})
+
+	t.Run("journal listing in valid-time order", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, engine.WriteJournalListing(&out, ExportFormatJSONLines, day(1), day(31)))
+
+		var rows []JournalRow
+		scanner := bufio.NewScanner(&out)
+		for scanner.Scan() {
+			var row JournalRow
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &row))
+			rows = append(rows, row)
+		}
+		require.Len(t, rows, 8, "the reversed transaction is left out")
+		assert.Equal(t, opening.ID, rows[0].TransactionID)
+		assert.Equal(t, sale.ID, rows[2].TransactionID)
+		assert.Equal(t, "Accounts Receivable", rows[2].AccountName)
+		assert.Equal(t, int64(25000), rows[2].Debit)
+		assert.True(t, sort.SliceIsSorted(rows, func(i, j int) bool { return rows[i].Date.Before(rows[j].Date) }))
+	})
+
+	t.Run("exports reload through the importer", func(t *testing.T) {
+		for _, format := range []ExportFormat{ExportFormatCSV, ExportFormatJSONLines} {
+			var out bytes.Buffer
+			require.NoError(t, engine.ExportTransactions(&out, format, &QueryFilter{Dimensions: []Dimension{{Key: DimDepartment, Value: "sales"}}}))
+
+			targetFile := "test_report_streams_target.db"
+			target, err := NewAccountingEngine(targetFile)
+			require.NoError(t, err)
+			require.NoError(t, target.CreateStandardAccounts(userID))
+
+			result, err := target.ImportTransactions(&out, ImportFormat(format), ImportOptions{AllOrNothing: true}, userID)
+			require.NoError(t, err)
+			require.Len(t, result.Accepted, 1, "format %s", format)
+			assert.Equal(t, sale.ID, result.Accepted[0].TransactionID)
+
+			imported, err := target.GetStorage().GetTransaction(sale.ID)
+			require.NoError(t, err)
+			assert.Equal(t, sale.Entries[0].Dimensions, imported.Entries[0].Dimensions)
+			assert.True(t, sale.ValidTime.Equal(imported.ValidTime))
+
+			target.Close()
+			os.Remove(targetFile)
+		}
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		var out bytes.Buffer
+		assert.Error(t, engine.WriteJournalListing(&out, "XML", day(1), day(31)))
+	})
+}
+
+func TestPostingIndexStreaming(t *testing.T) {
+	dbFile := "test_posting_index.db"
+	defer os.Remove(dbFile)
+
+	storage, err := NewStorageWithOptions(dbFile, StorageOptions{NoSync: true})
+	require.NoError(t, err)
+	defer storage.Close()
+
+	txns, _ := bulkTestTransactions(3 * postingStreamBatch / 2)
+	for i, txn := range txns {
+		txn.Status = Posted
+		// Spread the postings over time in the opposite order to their IDs
+		txn.ValidTime = txn.ValidTime.Add(time.Duration(len(txns)-i) * time.Minute)
+	}
+	require.NoError(t, storage.SaveTransactions(txns))
+
+	var seen []*Transaction
+	require.NoError(t, storage.ForEachPostedTransaction(time.Time{}, time.Now(), func(txn *Transaction) error {
+		seen = append(seen, txn)
+		return nil
+	}))
+	require.Len(t, seen, len(txns))
+	assert.Equal(t, txns[len(txns)-1].ID, seen[0].ID)
+	assert.True(t, sort.SliceIsSorted(seen, func(i, j int) bool { return seen[i].ValidTime.Before(seen[j].ValidTime) }))
+
+	t.Run("bounds are inclusive", func(t *testing.T) {
+		count := 0
+		require.NoError(t, storage.ForEachAccountPosting("cash", txns[10].ValidTime, txns[5].ValidTime, func(*Transaction) error {
+			count++
+			return nil
+		}))
+		assert.Equal(t, 6, count)
+	})
+
+	t.Run("drops postings that are no longer posted", func(t *testing.T) {
+		txns[0].Status = Reversed
+		require.NoError(t, storage.SaveTransaction(txns[0]))
+
+		count := 0
+		require.NoError(t, storage.ForEachAccountPosting("revenue", time.Time{}, time.Now(), func(*Transaction) error {
+			count++
+			return nil
+		}))
+		assert.Equal(t, len(txns)-1, count)
+	})
+}
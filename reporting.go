@@ -1,21 +1,33 @@
 package accounting
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
 // FinancialStatement represents a financial statement
 type FinancialStatement struct {
-	Name        string               `json:"name"`
-	AsOfDate    time.Time            `json:"as_of_date"`
-	FromDate    *time.Time           `json:"from_date,omitempty"` // For P&L and Cash Flow
-	Currency    string               `json:"currency"`
-	LineItems   []*FinancialLineItem `json:"line_items"`
-	TotalAssets *Amount              `json:"total_assets,omitempty"`
-	TotalLiabs  *Amount              `json:"total_liabilities,omitempty"`
-	TotalEquity *Amount              `json:"total_equity,omitempty"`
-	NetIncome   *Amount              `json:"net_income,omitempty"`
+	Name            string               `json:"name"`
+	TemplateID      string               `json:"template_id,omitempty"` // set when rendered from a statement template
+	AsOfDate        time.Time            `json:"as_of_date"`
+	FromDate        *time.Time           `json:"from_date,omitempty"` // For P&L and Cash Flow
+	ComparativeAsOf *time.Time           `json:"comparative_as_of,omitempty"`
+	ComparativeFrom *time.Time           `json:"comparative_from,omitempty"`
+	Currency        string               `json:"currency"`
+	LineItems       []*FinancialLineItem `json:"line_items"`
+	TotalAssets     *Amount              `json:"total_assets,omitempty"`
+	TotalLiabs      *Amount              `json:"total_liabilities,omitempty"`
+	TotalEquity     *Amount              `json:"total_equity,omitempty"`
+	NetIncome       *Amount              `json:"net_income,omitempty"`
+	Footnotes       []*StatementFootnote `json:"footnotes,omitempty"`
+
+	// Set on statements prepared under one framework from the shared ledger
+	Framework        ReportingStandard `json:"framework,omitempty"`
+	AdjustmentLayers []string          `json:"adjustment_layers,omitempty"`
 }
 
 // FinancialLineItem represents a line item in a financial statement
@@ -24,7 +36,8 @@ type FinancialLineItem struct {
 	AccountName string               `json:"account_name"`
 	AccountType AccountType          `json:"account_type"`
 	Amount      *Amount              `json:"amount"`
-	Level       int                  `json:"level"` // For hierarchy display
+	Comparative *Amount              `json:"comparative,omitempty"` // the comparative column, when the statement has one
+	Level       int                  `json:"level"`                 // For hierarchy display
 	IsSubtotal  bool                 `json:"is_subtotal"`
 	Children    []*FinancialLineItem `json:"children,omitempty"`
 }
@@ -48,6 +61,7 @@ const (
 // CashFlowStatement represents a cash flow statement
 type CashFlowStatement struct {
 	Name                string          `json:"name"`
+	Method              CashFlowMethod  `json:"method,omitempty"`
 	FromDate            time.Time       `json:"from_date"`
 	ToDate              time.Time       `json:"to_date"`
 	Currency            string          `json:"currency"`
@@ -57,30 +71,115 @@ type CashFlowStatement struct {
 	NetCashFlow         *Amount         `json:"net_cash_flow"`
 	BeginningCash       *Amount         `json:"beginning_cash"`
 	EndingCash          *Amount         `json:"ending_cash"`
+
+	// Indirect method: operating cash flow is net income plus the non-cash
+	// adjustments and working capital changes
+	NetIncome                *Amount         `json:"net_income,omitempty"`
+	NonCashAdjustments       []*CashFlowItem `json:"non_cash_adjustments,omitempty"`
+	WorkingCapitalChanges    []*CashFlowItem `json:"working_capital_changes,omitempty"`
+	OperatingCashFlow        *Amount         `json:"operating_cash_flow,omitempty"`
+	CashMovement             *Amount         `json:"cash_movement,omitempty"`             // change in the cash accounts' balances
+	ReconciliationDifference *Amount         `json:"reconciliation_difference,omitempty"` // cash movement less net cash flow
 }
 
 // ReportingService handles financial statement generation
 type ReportingService struct {
-	storage  *Storage
-	queryAPI *QueryAPI
+	storage       *Storage
+	queryAPI      *QueryAPI
+	exchangeRates *ExchangeRateService
+	metrics       *EngineMetrics // nil until instrumented
+	tracer        *engineTracer
 }
 
-// NewReportingService creates a new reporting service
-func NewReportingService(storage *Storage, queryAPI *QueryAPI) *ReportingService {
+// NewReportingService creates a new reporting service. Balances held in other
+// currencies are translated into the reporting currency using exchangeRates.
+func NewReportingService(storage *Storage, queryAPI *QueryAPI, exchangeRates *ExchangeRateService) *ReportingService {
 	return &ReportingService{
-		storage:  storage,
-		queryAPI: queryAPI,
+		storage:       storage,
+		queryAPI:      queryAPI,
+		exchangeRates: exchangeRates,
+		tracer:        noopTracer,
 	}
 }
 
-// GenerateBalanceSheet generates a balance sheet as of a specific date
-func (rs *ReportingService) GenerateBalanceSheet(asOfDate time.Time, currency string) (*FinancialStatement, error) {
-	// Get all account balances
+// Instrument records report generation times in the engine metrics. Call it while
+// the engine is being assembled.
+func (rs *ReportingService) Instrument(metrics *EngineMetrics) {
+	rs.metrics = metrics
+}
+
+// setTracer traces report generation through the engine's tracer. Call it while the
+// engine is being assembled.
+func (rs *ReportingService) setTracer(tracer *engineTracer) {
+	rs.tracer = tracer
+}
+
+// GenerateTrialBalance generates a trial balance translated into the reporting currency
+// at the rates in effect on the as-of date
+func (rs *ReportingService) GenerateTrialBalance(asOfDate time.Time, currency string) (_ []*BalanceResult, err error) {
+	defer rs.metrics.timeReport("trial_balance")(&err)
+	defer rs.tracer.report("trial_balance")(&err)
+
 	trialBalance, err := rs.queryAPI.GetTrialBalance(asOfDate, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get trial balance: %w", err)
 	}
 
+	return rs.translateTrialBalance(trialBalance, asOfDate, currency)
+}
+
+// translateTrialBalance converts every balance into the reporting currency
+func (rs *ReportingService) translateTrialBalance(trialBalance []*BalanceResult, rateDate time.Time, currency string) ([]*BalanceResult, error) {
+	translated := make([]*BalanceResult, len(trialBalance))
+	err := forEachParallel(len(trialBalance), rs.queryAPI.workers(), func(i int) error {
+		balance := trialBalance[i]
+		amount, err := rs.translateAmount(balance.Balance, currency, rateDate)
+		if err != nil {
+			return fmt.Errorf("failed to translate balance for account %s: %w", balance.AccountID, err)
+		}
+
+		translated[i] = &BalanceResult{
+			AccountID:   balance.AccountID,
+			AccountName: balance.AccountName,
+			AccountType: balance.AccountType,
+			Balance:     amount,
+			AsOfDate:    balance.AsOfDate,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return translated, nil
+}
+
+// translateAmount converts an amount into the reporting currency. Amounts without a
+// currency are assumed to already be in the reporting currency.
+func (rs *ReportingService) translateAmount(amount *Amount, currency string, rateDate time.Time) (*Amount, error) {
+	if amount.Currency == "" || amount.Currency == Currency(currency) {
+		return &Amount{Value: amount.Value, Currency: Currency(currency)}, nil
+	}
+	if rs.exchangeRates == nil {
+		return nil, fmt.Errorf("no exchange rate service configured to translate %s to %s", amount.Currency, currency)
+	}
+	return rs.exchangeRates.Convert(amount, Currency(currency), rateDate)
+}
+
+// GenerateBalanceSheet generates a balance sheet as of a specific date
+func (rs *ReportingService) GenerateBalanceSheet(asOfDate time.Time, currency string) (_ *FinancialStatement, err error) {
+	defer rs.metrics.timeReport("balance_sheet")(&err)
+	defer rs.tracer.report("balance_sheet")(&err)
+
+	// Get all account balances translated at the closing rate
+	trialBalance, err := rs.GenerateTrialBalance(asOfDate, currency)
+	if err != nil {
+		return nil, err
+	}
+	return rs.balanceSheet(trialBalance, asOfDate, currency)
+}
+
+// balanceSheet lays out a translated trial balance as a balance sheet
+func (rs *ReportingService) balanceSheet(trialBalance []*BalanceResult, asOfDate time.Time, currency string) (_ *FinancialStatement, err error) {
 	bs := &FinancialStatement{
 		Name:     "Balance Sheet",
 		AsOfDate: asOfDate,
@@ -118,6 +217,17 @@ func (rs *ReportingService) GenerateBalanceSheet(asOfDate time.Time, currency st
 		}
 	}
 
+	// Nest child accounts under their parents
+	if assets, err = rs.rollupLineItems(assets, currency); err != nil {
+		return nil, err
+	}
+	if liabilities, err = rs.rollupLineItems(liabilities, currency); err != nil {
+		return nil, err
+	}
+	if equity, err = rs.rollupLineItems(equity, currency); err != nil {
+		return nil, err
+	}
+
 	// Build line items with subtotals
 	bs.LineItems = append(bs.LineItems, &FinancialLineItem{
 		AccountName: "ASSETS",
@@ -148,7 +258,16 @@ func (rs *ReportingService) GenerateBalanceSheet(asOfDate time.Time, currency st
 }
 
 // GenerateProfitAndLoss generates a P&L statement for a period
-func (rs *ReportingService) GenerateProfitAndLoss(fromDate, toDate time.Time, currency string) (*FinancialStatement, error) {
+func (rs *ReportingService) GenerateProfitAndLoss(fromDate, toDate time.Time, currency string) (_ *FinancialStatement, err error) {
+	defer rs.metrics.timeReport("profit_and_loss")(&err)
+	defer rs.tracer.report("profit_and_loss")(&err)
+
+	return rs.profitAndLoss(fromDate, toDate, currency, nil)
+}
+
+// profitAndLoss builds a P&L from each account's movement over the period plus, when
+// a framework layer is given, its adjustments
+func (rs *ReportingService) profitAndLoss(fromDate, toDate time.Time, currency string, layer *frameworkLayer) (_ *FinancialStatement, err error) {
 	// Get all account balances for the period
 	trialBalance, err := rs.queryAPI.GetTrialBalance(toDate, []AccountType{Income, Expense})
 	if err != nil {
@@ -169,11 +288,30 @@ func (rs *ReportingService) GenerateProfitAndLoss(fromDate, toDate time.Time, cu
 	totalRevenue := &Amount{Value: 0, Currency: Currency(currency)}
 	totalExpenses := &Amount{Value: 0, Currency: Currency(currency)}
 
-	for _, balance := range trialBalance {
+	// Work out each account's movement for the period in parallel
+	periodBalances := make([]*Amount, len(trialBalance))
+	err = forEachParallel(len(trialBalance), rs.queryAPI.workers(), func(i int) error {
+		balance := trialBalance[i]
 		// Filter transactions by date range
 		periodBalance, err := rs.calculatePeriodBalance(balance.AccountID, fromDate, toDate)
 		if err != nil {
-			continue // Skip on error
+			return nil // Skip on error
+		}
+
+		periodBalances[i], err = rs.translateAmount(layer.add(balance.AccountID, periodBalance), currency, toDate)
+		if err != nil {
+			return fmt.Errorf("failed to translate balance for account %s: %w", balance.AccountID, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, balance := range trialBalance {
+		periodBalance := periodBalances[i]
+		if periodBalance == nil {
+			continue
 		}
 
 		lineItem := &FinancialLineItem{
@@ -200,6 +338,14 @@ func (rs *ReportingService) GenerateProfitAndLoss(fromDate, toDate time.Time, cu
 		Currency: Currency(currency),
 	}
 
+	// Nest child accounts under their parents
+	if revenue, err = rs.rollupLineItems(revenue, currency); err != nil {
+		return nil, err
+	}
+	if expenses, err = rs.rollupLineItems(expenses, currency); err != nil {
+		return nil, err
+	}
+
 	// Build line items
 	pl.LineItems = append(pl.LineItems, &FinancialLineItem{
 		AccountName: "REVENUE",
@@ -230,7 +376,10 @@ func (rs *ReportingService) GenerateProfitAndLoss(fromDate, toDate time.Time, cu
 }
 
 // GenerateCashFlowStatement generates a cash flow statement for a period
-func (rs *ReportingService) GenerateCashFlowStatement(fromDate, toDate time.Time, currency string) (*CashFlowStatement, error) {
+func (rs *ReportingService) GenerateCashFlowStatement(fromDate, toDate time.Time, currency string) (_ *CashFlowStatement, err error) {
+	defer rs.metrics.timeReport("cash_flow")(&err)
+	defer rs.tracer.report("cash_flow")(&err)
+
 	// Get cash account movements
 	cashEntries, err := rs.storage.GetEntriesByAccount("cash")
 	if err != nil {
@@ -239,6 +388,7 @@ func (rs *ReportingService) GenerateCashFlowStatement(fromDate, toDate time.Time
 
 	cf := &CashFlowStatement{
 		Name:     "Cash Flow Statement",
+		Method:   CashFlowDirect,
 		FromDate: fromDate,
 		ToDate:   toDate,
 		Currency: currency,
@@ -247,7 +397,10 @@ func (rs *ReportingService) GenerateCashFlowStatement(fromDate, toDate time.Time
 	// Get beginning cash balance
 	beginningCash, err := rs.queryAPI.GetAccountBalance("cash", fromDate)
 	if err == nil {
-		cf.BeginningCash = beginningCash.Balance
+		cf.BeginningCash, err = rs.translateAmount(beginningCash.Balance, currency, fromDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate beginning cash: %w", err)
+		}
 	} else {
 		cf.BeginningCash = &Amount{Value: 0, Currency: Currency(currency)}
 	}
@@ -272,14 +425,20 @@ func (rs *ReportingService) GenerateCashFlowStatement(fromDate, toDate time.Time
 		// Determine cash flow category based on contra accounts
 		category := rs.categorizeTransactionForCashFlow(txn, entry.AccountID)
 
-		amount := entry.Amount.Value
+		// Translate each movement at the rate on its transaction date
+		translated, err := rs.translateAmount(&entry.Amount, currency, txn.ValidTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate cash movement %s: %w", txn.ID, err)
+		}
+
+		amount := translated.Value
 		if entry.Type == Credit {
 			amount = -amount // Cash going out
 		}
 
 		item := &CashFlowItem{
 			Description: txn.Description,
-			Amount:      &Amount{Value: amount, Currency: entry.Amount.Currency},
+			Amount:      &Amount{Value: amount, Currency: Currency(currency)},
 			Category:    category,
 		}
 
@@ -399,28 +558,45 @@ func (rs *ReportingService) FormatFinancialStatement(statement *FinancialStateme
 	} else {
 		output += fmt.Sprintf("As of: %s\n", statement.AsOfDate.Format("2006-01-02"))
 	}
+	if statement.ComparativeFrom != nil {
+		output += fmt.Sprintf("Comparative: %s to %s\n",
+			statement.ComparativeFrom.Format("2006-01-02"),
+			statement.ComparativeAsOf.Format("2006-01-02"))
+	} else if statement.ComparativeAsOf != nil {
+		output += fmt.Sprintf("Comparative: as of %s\n", statement.ComparativeAsOf.Format("2006-01-02"))
+	}
 	output += fmt.Sprintf("Currency: %s\n", statement.Currency)
 	output += "==========================================\n"
 
+	symbol := currencySymbol(statement.Currency)
+	currency := Currency(statement.Currency)
 	for _, lineItem := range statement.LineItems {
-		output += rs.formatLineItem(lineItem, 0)
+		output += rs.formatLineItem(lineItem, lineItem.Level, symbol, currency)
 	}
 
 	if statement.NetIncome != nil {
-		output += fmt.Sprintf("\nNET INCOME: $%.2f\n", float64(statement.NetIncome.Value)/100)
+		output += fmt.Sprintf("\nNET INCOME: %s%s\n", symbol, FormatMinorUnits(statement.NetIncome.Value, currency))
 	}
 
 	if statement.TotalAssets != nil {
-		output += fmt.Sprintf("\nTOTAL ASSETS: $%.2f\n", float64(statement.TotalAssets.Value)/100)
-		output += fmt.Sprintf("TOTAL LIAB + EQUITY: $%.2f\n",
-			float64(statement.TotalLiabs.Value+statement.TotalEquity.Value)/100)
+		output += fmt.Sprintf("\nTOTAL ASSETS: %s%s\n", symbol, FormatMinorUnits(statement.TotalAssets.Value, currency))
+		output += fmt.Sprintf("TOTAL LIAB + EQUITY: %s%s\n",
+			symbol, FormatMinorUnits(statement.TotalLiabs.Value+statement.TotalEquity.Value, currency))
+	}
+
+	if len(statement.Footnotes) > 0 {
+		output += "\nNOTES TO THE FINANCIAL STATEMENTS\n"
+		output += "------------------------------------------\n"
+		for _, footnote := range statement.Footnotes {
+			output += fmt.Sprintf("%d. %s\n%s\n\n", footnote.Number, footnote.Title, footnote.Text)
+		}
 	}
 
 	return output
 }
 
 // formatLineItem formats a single line item
-func (rs *ReportingService) formatLineItem(item *FinancialLineItem, indent int) string {
+func (rs *ReportingService) formatLineItem(item *FinancialLineItem, indent int, symbol string, currency Currency) string {
 	var output string
 	indentStr := ""
 	for i := 0; i < indent; i++ {
@@ -433,25 +609,99 @@ func (rs *ReportingService) formatLineItem(item *FinancialLineItem, indent int)
 
 		// Show children
 		for _, child := range item.Children {
-			output += rs.formatLineItem(child, indent+1)
+			output += rs.formatLineItem(child, indent+1, symbol, currency)
 		}
 
 		if item.Amount != nil {
-			output += fmt.Sprintf("%s%s: $%.2f\n", indentStr, "TOTAL", float64(item.Amount.Value)/100)
+			output += fmt.Sprintf("%s%s: %s%s%s\n", indentStr, "TOTAL", symbol, FormatMinorUnits(item.Amount.Value, currency),
+				formatComparative(item.Comparative, symbol, currency))
 		}
 		output += "\n"
 	} else {
-		output += fmt.Sprintf("%s%-20s $%8.2f\n",
+		output += fmt.Sprintf("%s%-20s %s%8s%s\n",
 			indentStr,
 			item.AccountName,
-			float64(item.Amount.Value)/100)
+			symbol, FormatMinorUnits(item.Amount.Value, currency),
+			formatComparative(item.Comparative, symbol, currency))
 	}
 
 	return output
 }
 
+// formatComparative formats the comparative column of a line, or nothing when the
+// statement has none
+func formatComparative(comparative *Amount, symbol string, currency Currency) string {
+	if comparative == nil {
+		return ""
+	}
+	return fmt.Sprintf("   %s%8s", symbol, FormatMinorUnits(comparative.Value, currency))
+}
+
+// FormatFinancialStatementAs renders a financial statement as text, CSV or JSON.
+// CSV has one row per line item, parents before their children.
+func (rs *ReportingService) FormatFinancialStatementAs(statement *FinancialStatement, format ExportFormat) (string, error) {
+	switch format {
+	case ExportFormatText:
+		return rs.FormatFinancialStatement(statement), nil
+	case ExportFormatJSON:
+		data, err := json.MarshalIndent(statement, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal statement: %w", err)
+		}
+		return string(data), nil
+	case ExportFormatCSV:
+		var buffer strings.Builder
+		writer := csv.NewWriter(&buffer)
+		header := []string{"level", "line", "account_id", "amount"}
+		if statement.ComparativeAsOf != nil {
+			header = append(header, "comparative")
+		}
+		if err := writer.Write(header); err != nil {
+			return "", fmt.Errorf("failed to write header: %w", err)
+		}
+		currency := Currency(statement.Currency)
+		var write func(items []*FinancialLineItem) error
+		write = func(items []*FinancialLineItem) error {
+			for _, item := range items {
+				record := []string{strconv.Itoa(item.Level), item.AccountName, item.AccountID, formatOptionalMinorUnits(item.Amount, currency)}
+				if statement.ComparativeAsOf != nil {
+					record = append(record, formatOptionalMinorUnits(item.Comparative, currency))
+				}
+				if err := writer.Write(record); err != nil {
+					return fmt.Errorf("failed to write row: %w", err)
+				}
+				if err := write(item.Children); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := write(statement.LineItems); err != nil {
+			return "", err
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return "", fmt.Errorf("failed to write rows: %w", err)
+		}
+		return buffer.String(), nil
+	}
+	return "", fmt.Errorf("unsupported statement format: %s", format)
+}
+
+// formatOptionalMinorUnits formats an amount, or nothing for a line without one
+func formatOptionalMinorUnits(amount *Amount, currency Currency) string {
+	if amount == nil {
+		return ""
+	}
+	return FormatMinorUnits(amount.Value, currency)
+}
+
 // FormatCashFlowStatement formats a cash flow statement for display
 func (rs *ReportingService) FormatCashFlowStatement(cf *CashFlowStatement) string {
+	if cf.Method == CashFlowIndirect {
+		return rs.formatIndirectCashFlowStatement(cf)
+	}
+
 	var output string
 
 	output += fmt.Sprintf("\n%s\n", cf.Name)
@@ -461,38 +711,57 @@ func (rs *ReportingService) FormatCashFlowStatement(cf *CashFlowStatement) strin
 	output += fmt.Sprintf("Currency: %s\n", cf.Currency)
 	output += "==========================================\n"
 
+	symbol := currencySymbol(cf.Currency)
+	currency := Currency(cf.Currency)
+
 	// Operating Activities
 	output += "OPERATING ACTIVITIES:\n"
 	operatingTotal := int64(0)
 	for _, item := range cf.OperatingActivities {
-		output += fmt.Sprintf("  %-30s $%8.2f\n", item.Description, float64(item.Amount.Value)/100)
+		output += fmt.Sprintf("  %-30s %s%8s\n", item.Description, symbol, FormatMinorUnits(item.Amount.Value, currency))
 		operatingTotal += item.Amount.Value
 	}
-	output += fmt.Sprintf("  Net Cash from Operations: $%8.2f\n\n", float64(operatingTotal)/100)
+	output += fmt.Sprintf("  Net Cash from Operations: %s%8s\n\n", symbol, FormatMinorUnits(operatingTotal, currency))
 
 	// Investing Activities
 	output += "INVESTING ACTIVITIES:\n"
 	investingTotal := int64(0)
 	for _, item := range cf.InvestingActivities {
-		output += fmt.Sprintf("  %-30s $%8.2f\n", item.Description, float64(item.Amount.Value)/100)
+		output += fmt.Sprintf("  %-30s %s%8s\n", item.Description, symbol, FormatMinorUnits(item.Amount.Value, currency))
 		investingTotal += item.Amount.Value
 	}
-	output += fmt.Sprintf("  Net Cash from Investing: $%8.2f\n\n", float64(investingTotal)/100)
+	output += fmt.Sprintf("  Net Cash from Investing: %s%8s\n\n", symbol, FormatMinorUnits(investingTotal, currency))
 
 	// Financing Activities
 	output += "FINANCING ACTIVITIES:\n"
 	financingTotal := int64(0)
 	for _, item := range cf.FinancingActivities {
-		output += fmt.Sprintf("  %-30s $%8.2f\n", item.Description, float64(item.Amount.Value)/100)
+		output += fmt.Sprintf("  %-30s %s%8s\n", item.Description, symbol, FormatMinorUnits(item.Amount.Value, currency))
 		financingTotal += item.Amount.Value
 	}
-	output += fmt.Sprintf("  Net Cash from Financing: $%8.2f\n\n", float64(financingTotal)/100)
+	output += fmt.Sprintf("  Net Cash from Financing: %s%8s\n\n", symbol, FormatMinorUnits(financingTotal, currency))
 
 	// Summary
 	output += "CASH FLOW SUMMARY:\n"
-	output += fmt.Sprintf("  Beginning Cash:      $%8.2f\n", float64(cf.BeginningCash.Value)/100)
-	output += fmt.Sprintf("  Net Cash Flow:       $%8.2f\n", float64(cf.NetCashFlow.Value)/100)
-	output += fmt.Sprintf("  Ending Cash:         $%8.2f\n", float64(cf.EndingCash.Value)/100)
+	output += fmt.Sprintf("  Beginning Cash:      %s%8s\n", symbol, FormatMinorUnits(cf.BeginningCash.Value, currency))
+	output += fmt.Sprintf("  Net Cash Flow:       %s%8s\n", symbol, FormatMinorUnits(cf.NetCashFlow.Value, currency))
+	output += fmt.Sprintf("  Ending Cash:         %s%8s\n", symbol, FormatMinorUnits(cf.EndingCash.Value, currency))
 
 	return output
 }
+
+// currencySymbol returns the display prefix for a reporting currency
+func currencySymbol(currency string) string {
+	switch currency {
+	case "USD", "":
+		return "$"
+	case "EUR":
+		return "€"
+	case "GBP":
+		return "£"
+	case "JPY":
+		return "¥"
+	default:
+		return currency + " "
+	}
+}
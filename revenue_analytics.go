@@ -0,0 +1,493 @@
+package accounting
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Revenue Concentration and Recurring Revenue
+// ----------------------------------------------------------------------------
+
+// RevenueAnalyticsOptions tunes the revenue analytics. Nil uses the defaults.
+type RevenueAnalyticsOptions struct {
+	CustomerDimension DimensionKey `json:"customer_dimension"` // names the customer on revenue and billing transactions
+	TopN              int          `json:"top_n"`              // customers whose combined share is reported
+}
+
+// DefaultRevenueAnalyticsOptions reads customers from the counterparty dimension and
+// reports the top five
+func DefaultRevenueAnalyticsOptions() *RevenueAnalyticsOptions {
+	return &RevenueAnalyticsOptions{CustomerDimension: DimCounterparty, TopN: 5}
+}
+
+// CustomerRevenue is one customer's revenue in a concentration analysis
+type CustomerRevenue struct {
+	Customer        string  `json:"customer"`
+	Revenue         int64   `json:"revenue"` // minor units of the reporting currency
+	Share           float64 `json:"share"`
+	CumulativeShare float64 `json:"cumulative_share"`
+}
+
+// RevenueConcentration is how dependent revenue is on the largest customers
+type RevenueConcentration struct {
+	FromDate     time.Time          `json:"from_date"`
+	ToDate       time.Time          `json:"to_date"`
+	Currency     string             `json:"currency"`
+	TotalRevenue int64              `json:"total_revenue"`
+	Unattributed int64              `json:"unattributed"` // revenue with no customer, left out of the shares' ranking
+	Customers    []*CustomerRevenue `json:"customers"`    // largest first
+	TopN         int                `json:"top_n"`
+	TopNShare    float64            `json:"top_n_share"`
+	HHI          float64            `json:"hhi"` // Herfindahl-Hirschman index of customer shares, 0 to 10,000
+}
+
+// MRRMovement is the month's change in monthly recurring revenue. Opening plus new,
+// expansion and reactivation, less contraction and churn, gives closing.
+type MRRMovement struct {
+	Month        time.Time `json:"month"`
+	OpeningMRR   int64     `json:"opening_mrr"`
+	NewMRR       int64     `json:"new_mrr"`
+	ExpansionMRR int64     `json:"expansion_mrr"`
+	Reactivation int64     `json:"reactivation_mrr"`
+	Contraction  int64     `json:"contraction_mrr"`
+	ChurnedMRR   int64     `json:"churned_mrr"`
+	ClosingMRR   int64     `json:"closing_mrr"`
+	ClosingARR   int64     `json:"closing_arr"`
+
+	OpeningCustomers int `json:"opening_customers"`
+	NewCustomers     int `json:"new_customers"`
+	ChurnedCustomers int `json:"churned_customers"`
+	ClosingCustomers int `json:"closing_customers"`
+
+	CustomerChurnRate   *float64 `json:"customer_churn_rate,omitempty"`   // churned customers over opening customers
+	GrossRevenueChurn   *float64 `json:"gross_revenue_churn,omitempty"`   // contraction and churn over opening MRR
+	NetRevenueRetention *float64 `json:"net_revenue_retention,omitempty"` // opening customers' closing MRR over opening MRR
+}
+
+// CohortPeriod is a cohort's standing some months after it started
+type CohortPeriod struct {
+	Offset            int     `json:"offset"` // months since the cohort's first month
+	ActiveCustomers   int     `json:"active_customers"`
+	CustomerRetention float64 `json:"customer_retention"`
+	MRR               int64   `json:"mrr"`
+	RevenueRetention  float64 `json:"revenue_retention"` // MRR over the cohort's starting MRR, above 1 with expansion
+}
+
+// RevenueCohort is the customers whose recurring revenue started in the same month
+type RevenueCohort struct {
+	Month       time.Time       `json:"month"`
+	Customers   int             `json:"customers"`
+	StartingMRR int64           `json:"starting_mrr"`
+	Periods     []*CohortPeriod `json:"periods"`
+}
+
+// RecurringRevenueReport is MRR and ARR movements, churn and cohort retention over a
+// run of months, derived from the recognition schedules
+type RecurringRevenueReport struct {
+	FromMonth time.Time        `json:"from_month"`
+	ToMonth   time.Time        `json:"to_month"`
+	Currency  string           `json:"currency"`
+	Months    []*MRRMovement   `json:"months"`
+	Cohorts   []*RevenueCohort `json:"cohorts"`
+
+	// ChurnAdjustedARR is closing ARR run forward a year at the average monthly gross
+	// revenue churn over the months, before any new business or expansion
+	ChurnAdjustedARR int64 `json:"churn_adjusted_arr"`
+	// Unattributed is closing MRR from schedules whose billing has no customer
+	Unattributed int64 `json:"unattributed"`
+}
+
+// RevenueAnalyticsService analyses revenue by customer for concentration and, for
+// subscription businesses, recurring revenue movements and retention
+type RevenueAnalyticsService struct {
+	storage   *Storage
+	reporting *ReportingService
+}
+
+// NewRevenueAnalyticsService creates a new revenue analytics service
+func NewRevenueAnalyticsService(storage *Storage, reporting *ReportingService) *RevenueAnalyticsService {
+	return &RevenueAnalyticsService{
+		storage:   storage,
+		reporting: reporting,
+	}
+}
+
+// GenerateRevenueConcentration ranks customers by posted revenue between two dates
+// and reports the top customers' combined share and the HHI
+func (ra *RevenueAnalyticsService) GenerateRevenueConcentration(from, to time.Time, currency string, options *RevenueAnalyticsOptions) (*RevenueConcentration, error) {
+	if options == nil {
+		options = DefaultRevenueAnalyticsOptions()
+	}
+	byCustomer, err := customerRevenue(ra.storage, ra.reporting, from, to, currency, options.CustomerDimension)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RevenueConcentration{FromDate: from, ToDate: to, Currency: currency, TopN: options.TopN, Unattributed: byCustomer[""]}
+	attributed := int64(0)
+	for customer, revenue := range byCustomer {
+		report.TotalRevenue += revenue
+		if customer != "" && revenue > 0 {
+			report.Customers = append(report.Customers, &CustomerRevenue{Customer: customer, Revenue: revenue})
+			attributed += revenue
+		}
+	}
+	sort.Slice(report.Customers, func(i, j int) bool {
+		if report.Customers[i].Revenue != report.Customers[j].Revenue {
+			return report.Customers[i].Revenue > report.Customers[j].Revenue
+		}
+		return report.Customers[i].Customer < report.Customers[j].Customer
+	})
+
+	// Shares are of revenue attributed to customers, so unattributed revenue does not
+	// dilute the concentration
+	cumulative := 0.0
+	for i, customer := range report.Customers {
+		customer.Share = float64(customer.Revenue) / float64(attributed)
+		cumulative += customer.Share
+		customer.CumulativeShare = cumulative
+		report.HHI += math.Pow(customer.Share*100, 2)
+		if i < options.TopN {
+			report.TopNShare = cumulative
+		}
+	}
+	return report, nil
+}
+
+// GenerateRecurringRevenue computes MRR movements for each month from fromMonth to
+// toMonth, and retention for the cohorts starting in those months. Every recognition
+// schedule is a subscription for the customer on its billing transaction, worth its
+// per-period amount spread evenly over the months of each period.
+func (ra *RevenueAnalyticsService) GenerateRecurringRevenue(fromMonth, toMonth time.Time, currency string, options *RevenueAnalyticsOptions) (*RecurringRevenueReport, error) {
+	if options == nil {
+		options = DefaultRevenueAnalyticsOptions()
+	}
+	first := time.Date(fromMonth.Year(), fromMonth.Month(), 1, 0, 0, 0, 0, fromMonth.Location())
+	last := time.Date(toMonth.Year(), toMonth.Month(), 1, 0, 0, 0, 0, fromMonth.Location())
+	if last.Before(first) {
+		return nil, fmt.Errorf("recurring revenue months run backwards: %s to %s", first.Format("2006-01"), last.Format("2006-01"))
+	}
+
+	mrr, err := ra.monthlyRecurringRevenue(currency, options.CustomerDimension, first.Location())
+	if err != nil {
+		return nil, err
+	}
+	report := &RecurringRevenueReport{FromMonth: first, ToMonth: last, Currency: currency}
+
+	// A customer's first month with recurring revenue anywhere in the history
+	started := make(map[string]time.Time)
+	for month, byCustomer := range mrr {
+		for customer, amount := range byCustomer {
+			if customer == "" || amount <= 0 {
+				continue
+			}
+			if start, ok := started[customer]; !ok || month.Before(start) {
+				started[customer] = month
+			}
+		}
+	}
+
+	var churnRates []float64
+	for month := first; !month.After(last); month = month.AddDate(0, 1, 0) {
+		movement := mrrMovement(month, mrr[month.AddDate(0, -1, 0)], mrr[month], started)
+		if movement.GrossRevenueChurn != nil {
+			churnRates = append(churnRates, *movement.GrossRevenueChurn)
+		}
+		report.Months = append(report.Months, movement)
+	}
+	closing := report.Months[len(report.Months)-1]
+	report.Unattributed = mrr[last][""]
+
+	report.ChurnAdjustedARR = closing.ClosingARR
+	if len(churnRates) > 0 {
+		average := 0.0
+		for _, rate := range churnRates {
+			average += rate
+		}
+		average /= float64(len(churnRates))
+		report.ChurnAdjustedARR = int64(math.Round(float64(closing.ClosingARR) * math.Pow(1-math.Min(average, 1), 12)))
+	}
+
+	// Cohorts by first month, followed to the end of the range
+	cohorts := make(map[time.Time][]string)
+	for customer, start := range started {
+		if !start.Before(first) && !start.After(last) {
+			cohorts[start] = append(cohorts[start], customer)
+		}
+	}
+	for month := first; !month.After(last); month = month.AddDate(0, 1, 0) {
+		customers := cohorts[month]
+		if len(customers) == 0 {
+			continue
+		}
+		cohort := &RevenueCohort{Month: month, Customers: len(customers)}
+		for _, customer := range customers {
+			cohort.StartingMRR += mrr[month][customer]
+		}
+		for offset, at := 0, month; !at.After(last); offset, at = offset+1, at.AddDate(0, 1, 0) {
+			period := &CohortPeriod{Offset: offset}
+			for _, customer := range customers {
+				if amount := mrr[at][customer]; amount > 0 {
+					period.ActiveCustomers++
+					period.MRR += amount
+				}
+			}
+			period.CustomerRetention = float64(period.ActiveCustomers) / float64(cohort.Customers)
+			if cohort.StartingMRR > 0 {
+				period.RevenueRetention = float64(period.MRR) / float64(cohort.StartingMRR)
+			}
+			cohort.Periods = append(cohort.Periods, period)
+		}
+		report.Cohorts = append(report.Cohorts, cohort)
+	}
+	return report, nil
+}
+
+// monthlyRecurringRevenue spreads every recognition schedule over the months it
+// covers, giving MRR by month and customer. Amounts are translated at each
+// schedule's start so exchange rate moves do not show as expansion or contraction.
+func (ra *RevenueAnalyticsService) monthlyRecurringRevenue(currency string, key DimensionKey, loc *time.Location) (map[time.Time]map[string]int64, error) {
+	schedules, err := ra.storage.GetAllSchedules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recognition schedules: %w", err)
+	}
+
+	mrr := make(map[time.Time]map[string]int64)
+	for _, schedule := range schedules {
+		if schedule.Occurrences <= 0 {
+			continue
+		}
+		billing, err := ra.storage.GetTransaction(schedule.TransactionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get billing transaction for schedule %s: %w", schedule.ID, err)
+		}
+		total, err := ra.reporting.translateAmount(recognitionScheduleTotal(billing), currency, schedule.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate schedule %s: %w", schedule.ID, err)
+		}
+
+		monthsPerPeriod := 1
+		switch schedule.Frequency {
+		case Quarterly:
+			monthsPerPeriod = 3
+		case Yearly:
+			monthsPerPeriod = 12
+		}
+		months := schedule.Occurrences * monthsPerPeriod
+		amounts, err := total.Allocate(months)
+		if err != nil {
+			return nil, fmt.Errorf("failed to spread schedule %s over its months: %w", schedule.ID, err)
+		}
+
+		customer := transactionDimension(billing, key)
+		start := time.Date(schedule.StartTime.Year(), schedule.StartTime.Month(), 1, 0, 0, 0, 0, loc)
+		for i := 0; i < months; i++ {
+			month := start.AddDate(0, i, 0)
+			if mrr[month] == nil {
+				mrr[month] = make(map[string]int64)
+			}
+			mrr[month][customer] += amounts[i].Value
+		}
+	}
+	return mrr, nil
+}
+
+// mrrMovement classifies each customer's change in MRR between two months
+func mrrMovement(month time.Time, previous, current map[string]int64, started map[string]time.Time) *MRRMovement {
+	movement := &MRRMovement{Month: month}
+	customers := make(map[string]bool)
+	for customer := range previous {
+		customers[customer] = true
+	}
+	for customer := range current {
+		customers[customer] = true
+	}
+
+	retained := int64(0) // opening customers' closing MRR
+	for customer := range customers {
+		if customer == "" {
+			continue
+		}
+		before, after := max(previous[customer], 0), max(current[customer], 0)
+		if before > 0 {
+			movement.OpeningMRR += before
+			movement.OpeningCustomers++
+			retained += after
+		}
+		if after > 0 {
+			movement.ClosingMRR += after
+			movement.ClosingCustomers++
+		}
+		switch {
+		case before == 0 && after > 0 && started[customer].Equal(month):
+			movement.NewMRR += after
+			movement.NewCustomers++
+		case before == 0 && after > 0:
+			movement.Reactivation += after
+		case before > 0 && after == 0:
+			movement.ChurnedMRR += before
+			movement.ChurnedCustomers++
+		case after > before:
+			movement.ExpansionMRR += after - before
+		case after < before:
+			movement.Contraction += before - after
+		}
+	}
+	movement.ClosingARR = movement.ClosingMRR * 12
+
+	if movement.OpeningCustomers > 0 {
+		rate := float64(movement.ChurnedCustomers) / float64(movement.OpeningCustomers)
+		movement.CustomerChurnRate = &rate
+	}
+	if movement.OpeningMRR > 0 {
+		churn := float64(movement.Contraction+movement.ChurnedMRR) / float64(movement.OpeningMRR)
+		retention := float64(retained) / float64(movement.OpeningMRR)
+		movement.GrossRevenueChurn = &churn
+		movement.NetRevenueRetention = &retention
+	}
+	return movement
+}
+
+// recognitionScheduleTotal is the amount a schedule recognizes: the debits of its
+// billing transaction
+func recognitionScheduleTotal(billing *Transaction) *Amount {
+	total := &Amount{Currency: billing.Entries[0].Amount.Currency}
+	for _, entry := range billing.Entries {
+		if entry.Type == Debit {
+			total.Value += entry.Amount.Value
+		}
+	}
+	return total
+}
+
+// transactionDimension is the first value of a dimension across a transaction's entries
+func transactionDimension(txn *Transaction, key DimensionKey) string {
+	for i := range txn.Entries {
+		if value := entryDimension(&txn.Entries[i], key); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// customerRevenue totals posted revenue by customer between two dates, translated at
+// the end date. Revenue recognized by a schedule belongs to the customer on the
+// schedule's billing transaction; revenue with no customer is under "".
+func customerRevenue(storage *Storage, reporting *ReportingService, from, to time.Time, currency string, key DimensionKey) (map[string]int64, error) {
+	accounts, err := storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	types := accountTypes(accounts)
+	txns, err := storage.GetAllTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	byID := make(map[string]*Transaction, len(txns))
+	for _, txn := range txns {
+		byID[txn.ID] = txn
+	}
+	var billingBySchedule map[string]string // schedule ID to billing transaction ID, loaded when needed
+
+	byCustomer := make(map[string]int64)
+	for _, txn := range txns {
+		if txn.Status != Posted || txn.ValidTime.Before(from) || txn.ValidTime.After(to) {
+			continue
+		}
+		revenue := int64(0)
+		for i := range txn.Entries {
+			entry := &txn.Entries[i]
+			if types[entry.AccountID] != Income {
+				continue
+			}
+			translated, err := reporting.translateAmount(&entry.Amount, currency, to)
+			if err != nil {
+				return nil, fmt.Errorf("failed to translate revenue on %s: %w", entry.AccountID, err)
+			}
+			if entry.Type == Credit {
+				revenue += translated.Value
+			} else {
+				revenue -= translated.Value
+			}
+		}
+		if revenue == 0 {
+			continue
+		}
+
+		customer := transactionDimension(txn, key)
+		if scheduleID, ok := strings.CutPrefix(txn.SourceRef, recognitionSourcePrefix); ok && customer == "" {
+			if billingBySchedule == nil {
+				schedules, err := storage.GetAllSchedules()
+				if err != nil {
+					return nil, fmt.Errorf("failed to get recognition schedules: %w", err)
+				}
+				billingBySchedule = make(map[string]string, len(schedules))
+				for _, schedule := range schedules {
+					billingBySchedule[schedule.ID] = schedule.TransactionID
+				}
+			}
+			if billing, ok := byID[billingBySchedule[scheduleID]]; ok {
+				customer = transactionDimension(billing, key)
+			}
+		}
+		byCustomer[customer] += revenue
+	}
+	return byCustomer, nil
+}
+
+// RecurringRevenueDocument lays out a recurring revenue report for export
+func RecurringRevenueDocument(report *RecurringRevenueReport) *ReportDocument {
+	currency := Currency(report.Currency)
+	rate := func(value *float64) ReportCell {
+		if value == nil {
+			return TextCell("")
+		}
+		return NumberCell(*value, 4)
+	}
+
+	movements := &ReportSheet{Name: "MRR Movements", Columns: []string{"Month", "Opening MRR", "New", "Expansion", "Reactivation",
+		"Contraction", "Churn", "Closing MRR", "Closing ARR", "Customers", "Customer churn", "Gross revenue churn", "Net revenue retention"}}
+	for _, month := range report.Months {
+		movements.Rows = append(movements.Rows, []ReportCell{
+			TextCell(month.Month.Format("2006-01")),
+			AmountCell(month.OpeningMRR, currency),
+			AmountCell(month.NewMRR, currency),
+			AmountCell(month.ExpansionMRR, currency),
+			AmountCell(month.Reactivation, currency),
+			AmountCell(-month.Contraction, currency),
+			AmountCell(-month.ChurnedMRR, currency),
+			AmountCell(month.ClosingMRR, currency).Bolded(),
+			AmountCell(month.ClosingARR, currency),
+			NumberCell(float64(month.ClosingCustomers), 0),
+			rate(month.CustomerChurnRate),
+			rate(month.GrossRevenueChurn),
+			rate(month.NetRevenueRetention),
+		})
+	}
+
+	cohorts := &ReportSheet{Name: "Cohort Retention", Columns: []string{"Cohort", "Customers", "Starting MRR"}}
+	longest := 0
+	for _, cohort := range report.Cohorts {
+		longest = max(longest, len(cohort.Periods))
+	}
+	for offset := 0; offset < longest; offset++ {
+		cohorts.Columns = append(cohorts.Columns, fmt.Sprintf("Month %d", offset))
+	}
+	for _, cohort := range report.Cohorts {
+		row := []ReportCell{TextCell(cohort.Month.Format("2006-01")), NumberCell(float64(cohort.Customers), 0), AmountCell(cohort.StartingMRR, currency)}
+		for _, period := range cohort.Periods {
+			row = append(row, NumberCell(period.RevenueRetention, 4))
+		}
+		cohorts.Rows = append(cohorts.Rows, row)
+	}
+
+	return &ReportDocument{
+		Title:    "Recurring Revenue",
+		Subtitle: fmt.Sprintf("%s to %s (%s), churn-adjusted ARR %s", report.FromMonth.Format("2006-01"), report.ToMonth.Format("2006-01"), report.Currency, FormatMinorUnits(report.ChurnAdjustedARR, currency)),
+		Sheets:   []*ReportSheet{movements, cohorts},
+	}
+}
@@ -0,0 +1,147 @@
+package accounting
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevenueAnalytics(t *testing.T) {
+	dbFile := "test_revenue_analytics.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "revops"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	month := func(m time.Month) time.Time { return time.Date(2025, m, 1, 0, 0, 0, 0, time.UTC) }
+
+	// subscribe bills a customer up front and recognizes it over the schedule
+	subscribe := func(customer string, start time.Time, frequency ScheduleFrequency, occurrences int, amount int64) {
+		billing := &Transaction{
+			Description: "Subscription for " + customer,
+			ValidTime:   start,
+			Entries: []Entry{
+				{AccountID: "accounts_receivable", Type: Debit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: []Dimension{{Key: DimCounterparty, Value: customer}}},
+				{AccountID: "unearned_revenue", Type: Credit, Amount: Amount{Value: amount, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(billing, userID))
+		require.NoError(t, engine.PostTransaction(billing.ID, userID))
+		_, err := engine.CreateAccrualSchedule(billing.ID, &Amount{Value: amount, Currency: "USD"}, frequency, occurrences, start, nil, userID)
+		require.NoError(t, err)
+	}
+	subscribe("acme", month(1), Monthly, 12, 12000)    // 1,000 a month all year
+	subscribe("globex", month(1), Monthly, 3, 1500)    // 500 a month, lapses after March
+	subscribe("initech", month(2), Quarterly, 2, 1200) // 600 a quarter, 200 a month from February
+	subscribe("acme", month(3), Monthly, 2, 800)       // add-on for March and April
+	subscribe("globex", month(6), Monthly, 1, 300)     // comes back in June
+
+	t.Run("MRR movements", func(t *testing.T) {
+		report, err := engine.GenerateRecurringRevenue(month(1), time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC), "USD", nil)
+		require.NoError(t, err)
+		require.Len(t, report.Months, 6)
+
+		type row struct{ opening, newMRR, expansion, reactivation, contraction, churn, closing int64 }
+		var rows []row
+		for _, m := range report.Months {
+			rows = append(rows, row{m.OpeningMRR, m.NewMRR, m.ExpansionMRR, m.Reactivation, m.Contraction, m.ChurnedMRR, m.ClosingMRR})
+		}
+		assert.Equal(t, []row{
+			{0, 1500, 0, 0, 0, 0, 1500},
+			{1500, 200, 0, 0, 0, 0, 1700},
+			{1700, 0, 400, 0, 0, 0, 2100},
+			{2100, 0, 0, 0, 0, 500, 1600},
+			{1600, 0, 0, 0, 400, 0, 1200},
+			{1200, 0, 0, 300, 0, 0, 1500},
+		}, rows)
+
+		april := report.Months[3]
+		assert.Equal(t, 3, april.OpeningCustomers)
+		assert.Equal(t, 1, april.ChurnedCustomers)
+		assert.InDelta(t, 1.0/3, *april.CustomerChurnRate, 1e-9)
+		assert.InDelta(t, 500.0/2100, *april.GrossRevenueChurn, 1e-9)
+		assert.InDelta(t, 1600.0/2100, *april.NetRevenueRetention, 1e-9)
+		assert.Nil(t, report.Months[0].GrossRevenueChurn)
+		assert.Equal(t, int64(18000), report.Months[5].ClosingARR)
+
+		averageChurn := (500.0/2100 + 400.0/1600) / 5
+		assert.Equal(t, int64(math.Round(18000*math.Pow(1-averageChurn, 12))), report.ChurnAdjustedARR)
+	})
+
+	t.Run("cohort retention", func(t *testing.T) {
+		report, err := engine.GenerateRecurringRevenue(month(1), month(6), "USD", nil)
+		require.NoError(t, err)
+		require.Len(t, report.Cohorts, 2)
+
+		january := report.Cohorts[0]
+		assert.Equal(t, 2, january.Customers)
+		assert.Equal(t, int64(1500), january.StartingMRR)
+		require.Len(t, january.Periods, 6)
+		var mrr []int64
+		var active []int
+		for _, period := range january.Periods {
+			mrr = append(mrr, period.MRR)
+			active = append(active, period.ActiveCustomers)
+		}
+		assert.Equal(t, []int64{1500, 1500, 1900, 1400, 1000, 1300}, mrr)
+		assert.Equal(t, []int{2, 2, 2, 1, 1, 2}, active)
+		assert.InDelta(t, 1900.0/1500, january.Periods[2].RevenueRetention, 1e-9)
+		assert.InDelta(t, 0.5, january.Periods[3].CustomerRetention, 1e-9)
+
+		february := report.Cohorts[1]
+		assert.Equal(t, month(2), february.Month)
+		assert.Len(t, february.Periods, 5)
+		assert.Equal(t, 1.0, february.Periods[4].RevenueRetention)
+
+		var buf bytes.Buffer
+		require.NoError(t, ExportReport(&buf, ExportFormatCSV, RecurringRevenueDocument(report)))
+		assert.Contains(t, buf.String(), "2025-04,21.00,0.00,0.00,0.00,0.00,-5.00,16.00,192.00,2,0.3333,0.2381,0.7619")
+
+		_, err = engine.GenerateRecurringRevenue(month(6), month(1), "USD", nil)
+		assert.ErrorContains(t, err, "run backwards")
+	})
+
+	t.Run("customer concentration follows recognized revenue to the billed customer", func(t *testing.T) {
+		require.NoError(t, engine.ProcessAccruals(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), userID))
+		walkIn := &Transaction{
+			Description: "Walk-in sale",
+			ValidTime:   time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: 1000, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 1000, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(walkIn, userID))
+		require.NoError(t, engine.PostTransaction(walkIn.ID, userID))
+
+		options := DefaultRevenueAnalyticsOptions()
+		options.TopN = 2
+		report, err := engine.GenerateRevenueConcentration(month(1), time.Date(2025, 6, 30, 23, 59, 59, 0, time.UTC), "USD", options)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(10800), report.TotalRevenue)
+		assert.Equal(t, int64(1000), report.Unattributed)
+		require.Len(t, report.Customers, 3)
+		var names []string
+		var revenue []int64
+		for _, customer := range report.Customers {
+			names = append(names, customer.Customer)
+			revenue = append(revenue, customer.Revenue)
+		}
+		assert.Equal(t, []string{"acme", "globex", "initech"}, names)
+		assert.Equal(t, []int64{6800, 1800, 1200}, revenue)
+		assert.InDelta(t, 6800.0/9800, report.Customers[0].Share, 1e-9)
+		assert.InDelta(t, 8600.0/9800, report.TopNShare, 1e-9)
+		assert.InDelta(t, 1.0, report.Customers[2].CumulativeShare, 1e-9)
+		hhi := math.Pow(6800.0/98, 2) + math.Pow(1800.0/98, 2) + math.Pow(1200.0/98, 2)
+		assert.InDelta(t, hhi, report.HHI, 1e-6)
+	})
+}
@@ -0,0 +1,470 @@
+package accounting
+
+import (
+	"cmp"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Revenue Contract Structures
+// ----------------------------------------------------------------------------
+
+// DefaultDeferredRevenueAccountID holds contract billings until their obligations are satisfied
+const DefaultDeferredRevenueAccountID = "unearned_revenue"
+
+// RecognitionBasis says when a performance obligation's revenue is recognized
+type RecognitionBasis string
+
+const (
+	RecognizePointInTime RecognitionBasis = "POINT_IN_TIME" // when control transfers, e.g. on delivery
+	RecognizeOverTime    RecognitionBasis = "OVER_TIME"     // evenly across the service period
+)
+
+// PerformanceObligation is a distinct good or service promised in a contract
+// (ASC 606 step 2). Over-time obligations recognize from Start across the given
+// occurrences; point-in-time obligations recognize once satisfied.
+type PerformanceObligation struct {
+	ID                     string            `json:"id"` // defaults to PO-<n>
+	Description            string            `json:"description"`
+	StandaloneSellingPrice int64             `json:"standalone_selling_price"`
+	AllocatedPrice         int64             `json:"allocated_price"` // share of the transaction price, set on creation
+	Basis                  RecognitionBasis  `json:"basis"`
+	Start                  time.Time         `json:"start,omitempty"`
+	Frequency              ScheduleFrequency `json:"frequency,omitempty"` // defaults to monthly
+	Occurrences            int               `json:"occurrences,omitempty"`
+	SatisfiedAt            *time.Time        `json:"satisfied_at,omitempty"`
+	ScheduleID             string            `json:"schedule_id,omitempty"` // set once recognition is scheduled
+}
+
+// RevenueContract is a customer contract whose transaction price is billed up
+// front and recognized as its performance obligations are satisfied
+type RevenueContract struct {
+	ID                       string                   `json:"id"`
+	CustomerID               string                   `json:"customer_id"`
+	Reference                string                   `json:"reference"`
+	ContractDate             time.Time                `json:"contract_date"`
+	TransactionPrice         *Amount                  `json:"transaction_price"`
+	Obligations              []*PerformanceObligation `json:"obligations"`
+	ReceivableAccountID      string                   `json:"receivable_account_id"`
+	DeferredRevenueAccountID string                   `json:"deferred_revenue_account_id"`
+	RevenueAccountID         string                   `json:"revenue_account_id"`
+	BillingTransactionID     string                   `json:"billing_transaction_id"`
+	CreatedBy                string                   `json:"created_by"`
+	CreatedAt                time.Time                `json:"created_at"`
+	UpdatedAt                time.Time                `json:"updated_at"`
+}
+
+// Obligation finds a performance obligation by ID
+func (rc *RevenueContract) Obligation(id string) (*PerformanceObligation, error) {
+	for _, obligation := range rc.Obligations {
+		if obligation.ID == id {
+			return obligation, nil
+		}
+	}
+	return nil, notFound("performance obligation", id)
+}
+
+// RevenueContractRequest describes a contract to bill and schedule
+type RevenueContractRequest struct {
+	ContractID               string                   `json:"contract_id,omitempty"` // generated when empty
+	CustomerID               string                   `json:"customer_id"`
+	Reference                string                   `json:"reference"`
+	ContractDate             time.Time                `json:"contract_date"`
+	TransactionPrice         Amount                   `json:"transaction_price"`
+	Obligations              []*PerformanceObligation `json:"obligations"`
+	ReceivableAccountID      string                   `json:"receivable_account_id,omitempty"`
+	DeferredRevenueAccountID string                   `json:"deferred_revenue_account_id,omitempty"`
+	RevenueAccountID         string                   `json:"revenue_account_id,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// Revenue Contract Service
+// ----------------------------------------------------------------------------
+
+// ContractService bills customer contracts and schedules their revenue recognition
+type ContractService struct {
+	storage        *Storage
+	eventStore     *EventStore
+	postingEngine  *PostingEngine
+	accrualService *AccrualService
+	mu             sync.Mutex
+}
+
+// NewContractService creates a new revenue contract service
+func NewContractService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine, accrualService *AccrualService) *ContractService {
+	return &ContractService{
+		storage:        storage,
+		eventStore:     eventStore,
+		postingEngine:  postingEngine,
+		accrualService: accrualService,
+	}
+}
+
+// CreateContract allocates the transaction price to the performance obligations in
+// proportion to their standalone selling prices, posts the billing to deferred
+// revenue and schedules each obligation's recognition. Over-time obligations are
+// scheduled at once; point-in-time obligations when they are satisfied, which may
+// be given up front.
+func (cs *ContractService) CreateContract(request RevenueContractRequest, userID string) (*RevenueContract, error) {
+	if request.CustomerID == "" {
+		return nil, fmt.Errorf("customer is required")
+	}
+	if request.TransactionPrice.Value <= 0 {
+		return nil, fmt.Errorf("transaction price must be positive")
+	}
+	if len(request.Obligations) == 0 {
+		return nil, fmt.Errorf("contract must have at least one performance obligation")
+	}
+	if request.ContractDate.IsZero() {
+		return nil, fmt.Errorf("contract date is required")
+	}
+
+	seen := make(map[string]bool)
+	prices := make([]int64, len(request.Obligations))
+	for i, obligation := range request.Obligations {
+		if obligation.ID == "" {
+			obligation.ID = fmt.Sprintf("PO-%d", i+1)
+		}
+		if seen[obligation.ID] {
+			return nil, fmt.Errorf("duplicate performance obligation %s", obligation.ID)
+		}
+		seen[obligation.ID] = true
+		if err := obligation.validate(); err != nil {
+			return nil, fmt.Errorf("performance obligation %s: %w", obligation.ID, err)
+		}
+		prices[i] = obligation.StandaloneSellingPrice
+	}
+
+	if request.ReceivableAccountID == "" {
+		request.ReceivableAccountID = DefaultReceivableAccountID
+	}
+	if request.DeferredRevenueAccountID == "" {
+		request.DeferredRevenueAccountID = DefaultDeferredRevenueAccountID
+	}
+	if request.RevenueAccountID == "" {
+		request.RevenueAccountID = DefaultRevenueAccountID
+	}
+	for _, accountID := range []string{request.ReceivableAccountID, request.DeferredRevenueAccountID, request.RevenueAccountID} {
+		if _, err := cs.storage.GetAccount(accountID); err != nil {
+			return nil, fmt.Errorf("invalid account %s: %w", accountID, err)
+		}
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if err := cs.storage.assignID(&request.ContractID, "revenue contract", BucketRevenueContracts); err != nil {
+		return nil, err
+	}
+
+	// ASC 606 step 4: allocate on relative standalone selling prices
+	allocations, err := request.TransactionPrice.AllocateByWeights(prices)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate transaction price: %w", err)
+	}
+	for i, obligation := range request.Obligations {
+		obligation.AllocatedPrice = allocations[i].Value
+		obligation.ScheduleID = ""
+	}
+
+	now := time.Now()
+	contract := &RevenueContract{
+		ID:                       request.ContractID,
+		CustomerID:               request.CustomerID,
+		Reference:                request.Reference,
+		ContractDate:             request.ContractDate,
+		TransactionPrice:         &Amount{Value: request.TransactionPrice.Value, Currency: request.TransactionPrice.Currency},
+		Obligations:              request.Obligations,
+		ReceivableAccountID:      request.ReceivableAccountID,
+		DeferredRevenueAccountID: request.DeferredRevenueAccountID,
+		RevenueAccountID:         request.RevenueAccountID,
+		CreatedBy:                userID,
+		CreatedAt:                now,
+		UpdatedAt:                now,
+	}
+
+	billing := &Transaction{
+		ID:              newID(),
+		Description:     fmt.Sprintf("Contract billing %s", cmp.Or(contract.Reference, contract.ID)),
+		ValidTime:       contract.ContractDate,
+		TransactionTime: now,
+		Status:          Pending,
+		SourceRef:       "REVENUE_CONTRACT_" + contract.ID,
+		UserID:          userID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		Entries: []Entry{
+			{
+				ID:         newID(),
+				AccountID:  contract.ReceivableAccountID,
+				Type:       Debit,
+				Amount:     *contract.TransactionPrice,
+				Dimensions: []Dimension{{Key: DimCounterparty, Value: contract.CustomerID}},
+			},
+			{
+				ID:        newID(),
+				AccountID: contract.DeferredRevenueAccountID,
+				Type:      Credit,
+				Amount:    *contract.TransactionPrice,
+			},
+		},
+	}
+	if err := cs.postTransaction(billing, userID); err != nil {
+		return nil, err
+	}
+	contract.BillingTransactionID = billing.ID
+
+	for _, obligation := range contract.Obligations {
+		if obligation.Basis == RecognizePointInTime && obligation.SatisfiedAt == nil {
+			continue
+		}
+		if err := cs.scheduleObligation(contract, obligation, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = cs.eventStore.CreateEvent(EventCreateRevenueContract, contract, contract.ContractDate, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create revenue contract event: %w", err)
+	}
+	if err := cs.storage.SaveRevenueContract(contract); err != nil {
+		return nil, fmt.Errorf("failed to save revenue contract: %w", err)
+	}
+	return contract, nil
+}
+
+// SatisfyObligation records that control of a point-in-time obligation passed to
+// the customer and schedules its revenue for that date
+func (cs *ContractService) SatisfyObligation(contractID, obligationID string, satisfiedAt time.Time, userID string) (*RevenueContract, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	contract, err := cs.storage.GetRevenueContract(contractID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue contract: %w", err)
+	}
+	obligation, err := contract.Obligation(obligationID)
+	if err != nil {
+		return nil, err
+	}
+	if obligation.Basis != RecognizePointInTime {
+		return nil, fmt.Errorf("performance obligation %s is satisfied over time", obligationID)
+	}
+	if obligation.SatisfiedAt != nil {
+		return nil, fmt.Errorf("performance obligation %s was already satisfied on %s", obligationID, obligation.SatisfiedAt.Format("2006-01-02"))
+	}
+	if satisfiedAt.Before(contract.ContractDate) {
+		return nil, fmt.Errorf("performance obligation cannot be satisfied before the contract date")
+	}
+
+	obligation.SatisfiedAt = &satisfiedAt
+	if err := cs.scheduleObligation(contract, obligation, userID); err != nil {
+		return nil, err
+	}
+	contract.UpdatedAt = time.Now()
+
+	_, err = cs.eventStore.CreateEvent(EventSatisfyPerformanceObligation, contract, satisfiedAt, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create obligation satisfied event: %w", err)
+	}
+	if err := cs.storage.SaveRevenueContract(contract); err != nil {
+		return nil, fmt.Errorf("failed to save revenue contract: %w", err)
+	}
+	return contract, nil
+}
+
+// validate checks an obligation carries what its recognition basis needs
+func (po *PerformanceObligation) validate() error {
+	if po.Description == "" {
+		return fmt.Errorf("description is required")
+	}
+	if po.StandaloneSellingPrice <= 0 {
+		return fmt.Errorf("standalone selling price must be positive")
+	}
+	switch po.Basis {
+	case RecognizeOverTime:
+		if po.Start.IsZero() {
+			return fmt.Errorf("over-time obligation needs a start date")
+		}
+		if po.Occurrences <= 0 {
+			return fmt.Errorf("over-time obligation needs at least one occurrence")
+		}
+		if po.Frequency == "" {
+			po.Frequency = Monthly
+		}
+		if po.SatisfiedAt != nil {
+			return fmt.Errorf("over-time obligation is satisfied by its schedule")
+		}
+	case RecognizePointInTime:
+		if po.Occurrences != 0 {
+			return fmt.Errorf("point-in-time obligation cannot have occurrences")
+		}
+	default:
+		return fmt.Errorf("unknown recognition basis %q", po.Basis)
+	}
+	return nil
+}
+
+// scheduleObligation creates the recognition schedule releasing an obligation's
+// allocated price from deferred revenue: across its occurrences when satisfied
+// over time, in full on the satisfaction date otherwise
+func (cs *ContractService) scheduleObligation(contract *RevenueContract, obligation *PerformanceObligation, userID string) error {
+	start, frequency, occurrences := obligation.Start, obligation.Frequency, obligation.Occurrences
+	if obligation.Basis == RecognizePointInTime {
+		start, frequency, occurrences = *obligation.SatisfiedAt, Monthly, 1
+	}
+	template := &AccrualTemplate{
+		ID:                TemplateDeferredRevenue,
+		AccrualType:       DeferralRevenue,
+		DeferralAccountID: contract.DeferredRevenueAccountID,
+		RevenueAccountID:  contract.RevenueAccountID,
+	}
+	amount := &Amount{Value: obligation.AllocatedPrice, Currency: contract.TransactionPrice.Currency}
+	schedule, err := cs.accrualService.CreateRecognitionSchedule(contract.BillingTransactionID, amount, frequency, occurrences, start, template, userID)
+	if err != nil {
+		return fmt.Errorf("failed to schedule performance obligation %s: %w", obligation.ID, err)
+	}
+	obligation.ScheduleID = schedule.ID
+	return nil
+}
+
+// postTransaction records and posts a contract transaction
+func (cs *ContractService) postTransaction(txn *Transaction, userID string) error {
+	for i := range txn.Entries {
+		txn.Entries[i].TransactionID = txn.ID
+	}
+
+	_, err := cs.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+	if err := cs.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+	if err := cs.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Deferred Revenue Waterfall
+// ----------------------------------------------------------------------------
+
+// WaterfallLine lays out revenue deferred at the report date by when it will be recognized
+type WaterfallLine struct {
+	Overdue     int64   `json:"overdue"`     // due by the report date but not yet posted
+	Months      []int64 `json:"months"`      // recognized in each month shown
+	Later       int64   `json:"later"`       // recognized after the last month shown
+	Unscheduled int64   `json:"unscheduled"` // point-in-time obligations not yet satisfied
+	Total       int64   `json:"total"`
+}
+
+// add puts an amount in a bucket and the total
+func (wl *WaterfallLine) add(bucket *int64, value int64) {
+	*bucket += value
+	wl.Total += value
+}
+
+// WaterfallRow is one performance obligation's remaining deferred revenue
+type WaterfallRow struct {
+	ContractID   string   `json:"contract_id"`
+	CustomerID   string   `json:"customer_id"`
+	ObligationID string   `json:"obligation_id"`
+	Description  string   `json:"description"`
+	Currency     Currency `json:"currency"`
+	WaterfallLine
+}
+
+// DeferredRevenueWaterfall reports when contract revenue deferred at a date will
+// be recognized, month by month
+type DeferredRevenueWaterfall struct {
+	AsOf   time.Time                   `json:"as_of"`
+	Months []time.Time                 `json:"months"` // first day of each month shown
+	Rows   []*WaterfallRow             `json:"rows"`
+	Totals map[Currency]*WaterfallLine `json:"totals"`
+}
+
+// DeferredRevenueWaterfall builds the waterfall for the given number of months,
+// starting with the month of the report date. Recognitions dated after asOf are
+// bucketed by month; those dated on or before it count as overdue until posted.
+func (cs *ContractService) DeferredRevenueWaterfall(asOf time.Time, months int) (*DeferredRevenueWaterfall, error) {
+	if months <= 0 {
+		months = 12
+	}
+	contracts, err := cs.storage.GetAllRevenueContracts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue contracts: %w", err)
+	}
+
+	first := time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, asOf.Location())
+	report := &DeferredRevenueWaterfall{
+		AsOf:   asOf,
+		Totals: make(map[Currency]*WaterfallLine),
+	}
+	for i := 0; i < months; i++ {
+		report.Months = append(report.Months, first.AddDate(0, i, 0))
+	}
+
+	for _, contract := range contracts {
+		if contract.ContractDate.After(asOf) {
+			continue
+		}
+		currency := contract.TransactionPrice.Currency
+		for _, obligation := range contract.Obligations {
+			row := &WaterfallRow{
+				ContractID:    contract.ID,
+				CustomerID:    contract.CustomerID,
+				ObligationID:  obligation.ID,
+				Description:   obligation.Description,
+				Currency:      currency,
+				WaterfallLine: WaterfallLine{Months: make([]int64, months)},
+			}
+
+			if obligation.ScheduleID == "" {
+				row.add(&row.Unscheduled, obligation.AllocatedPrice)
+			} else {
+				entries, err := cs.storage.GetRecognitionEntries(obligation.ScheduleID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get recognition entries: %w", err)
+				}
+				for _, entry := range entries {
+					date := entry.RecognitionDate
+					switch offset := (date.Year()-first.Year())*12 + int(date.Month()) - int(first.Month()); {
+					case !date.After(asOf):
+						if entry.Status != RecognitionProcessed {
+							row.add(&row.Overdue, entry.Amount.Value)
+						}
+					case offset < months:
+						row.add(&row.Months[offset], entry.Amount.Value)
+					default:
+						row.add(&row.Later, entry.Amount.Value)
+					}
+				}
+			}
+			if row.Total == 0 {
+				continue
+			}
+
+			report.Rows = append(report.Rows, row)
+			total, ok := report.Totals[currency]
+			if !ok {
+				total = &WaterfallLine{Months: make([]int64, months)}
+				report.Totals[currency] = total
+			}
+			total.add(&total.Overdue, row.Overdue)
+			for i, value := range row.Months {
+				total.add(&total.Months[i], value)
+			}
+			total.add(&total.Later, row.Later)
+			total.add(&total.Unscheduled, row.Unscheduled)
+		}
+	}
+	return report, nil
+}
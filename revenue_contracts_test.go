@@ -0,0 +1,146 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevenueContracts(t *testing.T) {
+	// Setup
+	dbFile := "test_revenue_contracts.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	date := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC) }
+	balance := func(accountID string, asOf time.Time) int64 {
+		result, err := engine.GetAccountBalance(accountID, asOf)
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+
+	signed := date(1, 10)
+
+	// A software licence delivered at signing, a year of support and training on request
+	contract, err := engine.CreateRevenueContract(RevenueContractRequest{
+		CustomerID:       "CUST-7",
+		Reference:        "MSA-2025-01",
+		ContractDate:     signed,
+		TransactionPrice: Amount{Value: 1000, Currency: "USD"},
+		Obligations: []*PerformanceObligation{
+			{Description: "Licence", StandaloneSellingPrice: 700, Basis: RecognizePointInTime, SatisfiedAt: &signed},
+			{Description: "Support", StandaloneSellingPrice: 500, Basis: RecognizeOverTime, Start: date(1, 15), Occurrences: 12},
+			{Description: "Training", StandaloneSellingPrice: 100, Basis: RecognizePointInTime},
+		},
+	}, userID)
+	require.NoError(t, err)
+
+	t.Run("Allocates On Relative Standalone Selling Price", func(t *testing.T) {
+		var allocated []int64
+		for _, obligation := range contract.Obligations {
+			allocated = append(allocated, obligation.AllocatedPrice)
+		}
+		assert.Equal(t, []int64{538, 385, 77}, allocated)
+		assert.Equal(t, "PO-3", contract.Obligations[2].ID)
+
+		assert.NotEmpty(t, contract.Obligations[0].ScheduleID)
+		assert.NotEmpty(t, contract.Obligations[1].ScheduleID)
+		assert.Empty(t, contract.Obligations[2].ScheduleID, "training is scheduled once delivered")
+
+		assert.Equal(t, int64(1000), balance("accounts_receivable", date(1, 10)))
+		assert.Equal(t, int64(1000), balance("unearned_revenue", date(1, 10)))
+	})
+
+	t.Run("Waterfall At Signing", func(t *testing.T) {
+		waterfall, err := engine.DeferredRevenueWaterfall(date(1, 10), 6)
+		require.NoError(t, err)
+		require.Len(t, waterfall.Months, 6)
+		assert.Equal(t, date(1, 1), waterfall.Months[0])
+		require.Len(t, waterfall.Rows, 3)
+
+		// The licence is recognized on the signing date itself, so it is overdue until the run
+		licence := waterfall.Rows[0]
+		assert.Equal(t, int64(538), licence.Overdue)
+		support := waterfall.Rows[1]
+		assert.Equal(t, []int64{33, 32, 32, 32, 32, 32}, support.Months)
+		assert.Equal(t, int64(192), support.Later)
+		assert.Equal(t, int64(77), waterfall.Rows[2].Unscheduled)
+
+		total := waterfall.Totals["USD"]
+		assert.Equal(t, int64(1000), total.Total)
+		assert.Equal(t, balance("unearned_revenue", date(1, 10)), total.Total)
+	})
+
+	t.Run("Recognition Releases Deferred Revenue", func(t *testing.T) {
+		run, err := engine.RunRecognition(date(3, 31), userID)
+		require.NoError(t, err)
+		assert.Len(t, run.Posted, 4)
+		assert.Equal(t, int64(538+97), balance("revenue", date(3, 31)))
+		assert.Equal(t, int64(365), balance("unearned_revenue", date(3, 31)))
+
+		waterfall, err := engine.DeferredRevenueWaterfall(date(3, 31), 3)
+		require.NoError(t, err)
+		require.Len(t, waterfall.Rows, 2, "the licence is fully recognized")
+		assert.Equal(t, []int64{0, 32, 32}, waterfall.Rows[0].Months)
+		assert.Equal(t, int64(224), waterfall.Rows[0].Later)
+		assert.Equal(t, int64(365), waterfall.Totals["USD"].Total)
+	})
+
+	t.Run("Point In Time Satisfaction", func(t *testing.T) {
+		updated, err := engine.SatisfyPerformanceObligation(contract.ID, "PO-3", date(3, 20), userID)
+		require.NoError(t, err)
+		training, err := updated.Obligation("PO-3")
+		require.NoError(t, err)
+		assert.NotEmpty(t, training.ScheduleID)
+
+		waterfall, err := engine.DeferredRevenueWaterfall(date(3, 31), 3)
+		require.NoError(t, err)
+		assert.Equal(t, int64(77), waterfall.Totals["USD"].Overdue)
+		assert.Zero(t, waterfall.Totals["USD"].Unscheduled)
+
+		run, err := engine.RunRecognition(date(3, 31), userID)
+		require.NoError(t, err)
+		require.Len(t, run.Posted, 1)
+		assert.Equal(t, int64(77), run.Recognized["USD"])
+
+		_, err = engine.SatisfyPerformanceObligation(contract.ID, "PO-3", date(3, 21), userID)
+		assert.ErrorContains(t, err, "already satisfied")
+		_, err = engine.SatisfyPerformanceObligation(contract.ID, "PO-2", date(3, 21), userID)
+		assert.ErrorContains(t, err, "satisfied over time")
+		_, err = engine.SatisfyPerformanceObligation(contract.ID, "PO-9", date(3, 21), userID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		request := RevenueContractRequest{
+			CustomerID:       "CUST-7",
+			ContractDate:     date(4, 1),
+			TransactionPrice: Amount{Value: 500, Currency: "USD"},
+			Obligations:      []*PerformanceObligation{{Description: "Hosting", StandaloneSellingPrice: 500, Basis: RecognizeOverTime}},
+		}
+		_, err := engine.CreateRevenueContract(request, userID)
+		assert.ErrorContains(t, err, "needs a start date")
+
+		request.Obligations = []*PerformanceObligation{{Description: "Hosting", StandaloneSellingPrice: 500, Basis: "USAGE"}}
+		_, err = engine.CreateRevenueContract(request, userID)
+		assert.ErrorContains(t, err, "unknown recognition basis")
+	})
+}
+
+func TestAllocateByWeights(t *testing.T) {
+	amount := &Amount{Value: 100, Currency: "USD"}
+	parts, err := amount.AllocateByWeights([]int64{1, 1, 1})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{34, 33, 33}, []int64{parts[0].Value, parts[1].Value, parts[2].Value})
+
+	_, err = amount.AllocateByWeights([]int64{0, 0})
+	assert.Error(t, err)
+}
@@ -0,0 +1,523 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// ScriptHook is the point in the engine a script is bound to. Each hook calls a
+// function of a fixed name that the script must define.
+type ScriptHook string
+
+const (
+	HookValidateTransaction  ScriptHook = "VALIDATE_TRANSACTION"   // validate(txn) returns None, an error string or a list of them
+	HookEnrichAMLTransaction ScriptHook = "ENRICH_AML_TRANSACTION" // enrich(txn) returns None or a dict of fields to update
+	HookComputeKPI           ScriptHook = "COMPUTE_KPI"            // compute(as_of, balance) returns a number
+)
+
+// scriptHookFunctions is the function each hook calls
+var scriptHookFunctions = map[ScriptHook]string{
+	HookValidateTransaction:  "validate",
+	HookEnrichAMLTransaction: "enrich",
+	HookComputeKPI:           "compute",
+}
+
+const (
+	DefaultScriptMaxSteps uint64 = 100_000    // steps a script may take per run unless it sets its own limit
+	MaxScriptMaxSteps     uint64 = 10_000_000 // the highest limit a script may set
+	ScriptTimeout                = time.Second
+)
+
+// Script is an administrator-supplied Starlark program bound to a hook, letting an
+// organisation add its own rules without rebuilding the engine. Scripts run
+// sandboxed: they cannot load modules or reach files, the network or the clock, and
+// each run is cut off after MaxSteps steps or ScriptTimeout.
+type Script struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"` // unique; KPIs are computed by script name
+	Hook      ScriptHook `json:"hook"`
+	Source    string     `json:"source"`
+	Active    bool       `json:"active"`
+	MaxSteps  uint64     `json:"max_steps,omitempty"` // 0 uses DefaultScriptMaxSteps
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// compiledScript is a script with its top level already executed
+type compiledScript struct {
+	script *Script
+	fn     starlark.Callable
+}
+
+// ScriptService registers scripts and runs them at their hooks
+type ScriptService struct {
+	storage    *Storage
+	eventStore *EventStore
+	queryAPI   *QueryAPI
+
+	compiled map[string]*compiledScript // active scripts by ID, loaded on first use
+	mutex    sync.RWMutex
+}
+
+// NewScriptService creates a new script service
+func NewScriptService(storage *Storage, eventStore *EventStore, queryAPI *QueryAPI) *ScriptService {
+	return &ScriptService{
+		storage:    storage,
+		eventStore: eventStore,
+		queryAPI:   queryAPI,
+	}
+}
+
+// RegisterScript checks a script compiles and defines its hook's function, then
+// activates it
+func (ss *ScriptService) RegisterScript(script *Script, userID string) error {
+	if script.Name == "" {
+		return fmt.Errorf("script name is required")
+	}
+	scripts, err := ss.storage.GetAllScripts()
+	if err != nil {
+		return fmt.Errorf("failed to get scripts: %w", err)
+	}
+	for _, existing := range scripts {
+		if existing.Name == script.Name {
+			return fmt.Errorf("script %s already exists", script.Name)
+		}
+	}
+	compiled, err := compileScript(script)
+	if err != nil {
+		return err
+	}
+
+	if err := ss.storage.assignID(&script.ID, "script", BucketScripts); err != nil {
+		return err
+	}
+	script.Active = true
+	script.CreatedBy = userID
+	script.CreatedAt = time.Now()
+	script.UpdatedAt = script.CreatedAt
+
+	_, err = ss.eventStore.CreateEvent(EventRegisterScript, script, script.CreatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create script event: %w", err)
+	}
+	if err := ss.storage.SaveScript(script); err != nil {
+		return fmt.Errorf("failed to save script: %w", err)
+	}
+	ss.setCompiled(script.ID, compiled)
+	return nil
+}
+
+// UpdateScript replaces a script's source, which must compile
+func (ss *ScriptService) UpdateScript(scriptID, source, userID string) error {
+	script, err := ss.storage.GetScript(scriptID)
+	if err != nil {
+		return fmt.Errorf("failed to get script: %w", err)
+	}
+	script.Source = source
+	compiled, err := compileScript(script)
+	if err != nil {
+		return err
+	}
+	return ss.saveUpdate(script, compiled, userID)
+}
+
+// SetScriptActive switches a script on or off without removing it
+func (ss *ScriptService) SetScriptActive(scriptID string, active bool, userID string) error {
+	script, err := ss.storage.GetScript(scriptID)
+	if err != nil {
+		return fmt.Errorf("failed to get script: %w", err)
+	}
+	script.Active = active
+	var compiled *compiledScript
+	if active {
+		if compiled, err = compileScript(script); err != nil {
+			return err
+		}
+	}
+	return ss.saveUpdate(script, compiled, userID)
+}
+
+// GetScripts returns the scripts bound to a hook, by name
+func (ss *ScriptService) GetScripts(hook ScriptHook) ([]*Script, error) {
+	scripts, err := ss.storage.GetAllScripts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scripts: %w", err)
+	}
+	var bound []*Script
+	for _, script := range scripts {
+		if script.Hook == hook {
+			bound = append(bound, script)
+		}
+	}
+	sort.Slice(bound, func(i, j int) bool { return bound[i].Name < bound[j].Name })
+	return bound, nil
+}
+
+// ValidateTransaction runs the active validation scripts against a transaction. A
+// script that fails to run rejects the transaction rather than letting it through.
+func (ss *ScriptService) ValidateTransaction(txn *Transaction) error {
+	scripts, err := ss.activeScripts(HookValidateTransaction)
+	if err != nil {
+		return err
+	}
+	var problems []string
+	for _, compiled := range scripts {
+		result, err := runScript(compiled, transactionToStarlark(txn))
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		messages, err := scriptMessages(result)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("script %s: %v", compiled.script.Name, err))
+			continue
+		}
+		for _, message := range messages {
+			problems = append(problems, fmt.Sprintf("%s: %s", compiled.script.Name, message))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// EnrichAMLTransaction lets the active enrichment scripts fill in or adjust an AML
+// transaction before the rules see it. A returned dict may set risk_score, purpose,
+// channel, from_country and to_country, and add flags.
+func (ss *ScriptService) EnrichAMLTransaction(txn *AMLTransaction) error {
+	scripts, err := ss.activeScripts(HookEnrichAMLTransaction)
+	if err != nil {
+		return err
+	}
+	for _, compiled := range scripts {
+		result, err := runScript(compiled, amlTransactionToStarlark(txn))
+		if err != nil {
+			return err
+		}
+		if err := applyAMLEnrichment(txn, result); err != nil {
+			return fmt.Errorf("script %s: %w", compiled.script.Name, err)
+		}
+	}
+	return nil
+}
+
+// ComputeKPI runs the named KPI script for a date. The script is passed a
+// balance(account_id) function returning balances as of that date, in minor units.
+func (ss *ScriptService) ComputeKPI(name string, asOf time.Time) (float64, error) {
+	scripts, err := ss.activeScripts(HookComputeKPI)
+	if err != nil {
+		return 0, err
+	}
+	for _, compiled := range scripts {
+		if compiled.script.Name != name {
+			continue
+		}
+		balance := starlark.NewBuiltin("balance", func(_ *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			var accountID string
+			if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 1, &accountID); err != nil {
+				return nil, err
+			}
+			result, err := ss.queryAPI.GetAccountBalance(accountID, asOf)
+			if err != nil {
+				return nil, err
+			}
+			return starlark.MakeInt64(result.Balance.Value), nil
+		})
+		result, err := runScript(compiled, starlark.String(asOf.Format(time.DateOnly)), balance)
+		if err != nil {
+			return 0, err
+		}
+		value, ok := starlark.AsFloat(result)
+		if !ok {
+			return 0, fmt.Errorf("script %s returned %s, not a number", name, result.Type())
+		}
+		return value, nil
+	}
+	return 0, fmt.Errorf("no active KPI script named %s", name)
+}
+
+// saveUpdate records a change to an existing script and refreshes its compiled form
+func (ss *ScriptService) saveUpdate(script *Script, compiled *compiledScript, userID string) error {
+	script.UpdatedAt = time.Now()
+	_, err := ss.eventStore.CreateEvent(EventUpdateScript, script, script.UpdatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create script update event: %w", err)
+	}
+	if err := ss.storage.SaveScript(script); err != nil {
+		return fmt.Errorf("failed to save script: %w", err)
+	}
+	if !script.Active {
+		compiled = nil
+	}
+	ss.setCompiled(script.ID, compiled)
+	return nil
+}
+
+// setCompiled caches a script's compiled form, or drops it when compiled is nil
+func (ss *ScriptService) setCompiled(scriptID string, compiled *compiledScript) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	if ss.compiled == nil {
+		return // loaded from storage on first use
+	}
+	if compiled == nil {
+		delete(ss.compiled, scriptID)
+		return
+	}
+	ss.compiled[scriptID] = compiled
+}
+
+// activeScripts returns the compiled active scripts bound to a hook, by name,
+// compiling stored scripts on first use
+func (ss *ScriptService) activeScripts(hook ScriptHook) ([]*compiledScript, error) {
+	ss.mutex.RLock()
+	loaded := ss.compiled != nil
+	ss.mutex.RUnlock()
+	if !loaded {
+		if err := ss.loadScripts(); err != nil {
+			return nil, err
+		}
+	}
+
+	ss.mutex.RLock()
+	defer ss.mutex.RUnlock()
+	var scripts []*compiledScript
+	for _, compiled := range ss.compiled {
+		if compiled.script.Hook == hook {
+			scripts = append(scripts, compiled)
+		}
+	}
+	sort.Slice(scripts, func(i, j int) bool { return scripts[i].script.Name < scripts[j].script.Name })
+	return scripts, nil
+}
+
+// loadScripts compiles every active stored script
+func (ss *ScriptService) loadScripts() error {
+	scripts, err := ss.storage.GetAllScripts()
+	if err != nil {
+		return fmt.Errorf("failed to get scripts: %w", err)
+	}
+	compiled := make(map[string]*compiledScript)
+	for _, script := range scripts {
+		if !script.Active {
+			continue
+		}
+		if compiled[script.ID], err = compileScript(script); err != nil {
+			return err
+		}
+	}
+
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	if ss.compiled == nil {
+		ss.compiled = compiled
+	}
+	return nil
+}
+
+// newScriptThread creates the sandboxed thread a script runs on. load is left
+// unset so scripts cannot import anything, and print output is discarded.
+func newScriptThread(script *Script) (*starlark.Thread, func()) {
+	thread := &starlark.Thread{
+		Name:  script.Name,
+		Print: func(*starlark.Thread, string) {},
+	}
+	maxSteps := script.MaxSteps
+	if maxSteps == 0 {
+		maxSteps = DefaultScriptMaxSteps
+	}
+	thread.SetMaxExecutionSteps(maxSteps)
+	timer := time.AfterFunc(ScriptTimeout, func() { thread.Cancel("timed out") })
+	return thread, func() { timer.Stop() }
+}
+
+// compileScript executes a script's top level and finds its hook function
+func compileScript(script *Script) (*compiledScript, error) {
+	function, ok := scriptHookFunctions[script.Hook]
+	if !ok {
+		return nil, fmt.Errorf("unknown script hook %s", script.Hook)
+	}
+	if script.MaxSteps > MaxScriptMaxSteps {
+		return nil, fmt.Errorf("script %s step limit %d is over the maximum of %d", script.Name, script.MaxSteps, MaxScriptMaxSteps)
+	}
+
+	thread, stop := newScriptThread(script)
+	defer stop()
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, script.Name+".star", script.Source, starlark.StringDict{})
+	if err != nil {
+		return nil, fmt.Errorf("script %s does not compile: %w", script.Name, err)
+	}
+	globals.Freeze()
+	fn, ok := globals[function].(starlark.Callable)
+	if !ok {
+		return nil, fmt.Errorf("script %s must define %s() for hook %s", script.Name, function, script.Hook)
+	}
+	return &compiledScript{script: script, fn: fn}, nil
+}
+
+// runScript calls a compiled script's hook function under its execution limits
+func runScript(compiled *compiledScript, args ...starlark.Value) (starlark.Value, error) {
+	thread, stop := newScriptThread(compiled.script)
+	defer stop()
+
+	result, err := starlark.Call(thread, compiled.fn, starlark.Tuple(args), nil)
+	if err != nil {
+		return nil, fmt.Errorf("script %s failed: %w", compiled.script.Name, err)
+	}
+	return result, nil
+}
+
+// scriptMessages reads a validation result: None or "" passes, otherwise a string or
+// list of strings describes what is wrong
+func scriptMessages(result starlark.Value) ([]string, error) {
+	switch v := result.(type) {
+	case starlark.NoneType:
+		return nil, nil
+	case starlark.String:
+		if v == "" {
+			return nil, nil
+		}
+		return []string{string(v)}, nil
+	case *starlark.List:
+		messages := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			message, ok := starlark.AsString(v.Index(i))
+			if !ok {
+				return nil, fmt.Errorf("validation messages must be strings, got %s", v.Index(i).Type())
+			}
+			messages = append(messages, message)
+		}
+		return messages, nil
+	}
+	return nil, fmt.Errorf("validate() must return None, a string or a list of strings, got %s", result.Type())
+}
+
+// applyAMLEnrichment copies the fields an enrichment script returned onto the
+// transaction
+func applyAMLEnrichment(txn *AMLTransaction, result starlark.Value) error {
+	if result == starlark.None {
+		return nil
+	}
+	dict, ok := result.(*starlark.Dict)
+	if !ok {
+		return fmt.Errorf("enrich() must return None or a dict, got %s", result.Type())
+	}
+	for _, item := range dict.Items() {
+		key, ok := starlark.AsString(item[0])
+		if !ok {
+			return fmt.Errorf("enrichment keys must be strings, got %s", item[0].Type())
+		}
+		value := item[1]
+		switch key {
+		case "risk_score":
+			var score int
+			if err := starlark.AsInt(value, &score); err != nil {
+				return fmt.Errorf("risk_score: %w", err)
+			}
+			txn.RiskScore = score
+		case "flags":
+			list, ok := value.(*starlark.List)
+			if !ok {
+				return fmt.Errorf("flags must be a list, got %s", value.Type())
+			}
+			for i := 0; i < list.Len(); i++ {
+				flag, ok := starlark.AsString(list.Index(i))
+				if !ok {
+					return fmt.Errorf("flags must be strings, got %s", list.Index(i).Type())
+				}
+				txn.Flags = append(txn.Flags, flag)
+			}
+		case "purpose", "channel", "from_country", "to_country":
+			text, ok := starlark.AsString(value)
+			if !ok {
+				return fmt.Errorf("%s must be a string, got %s", key, value.Type())
+			}
+			switch key {
+			case "purpose":
+				txn.Purpose = text
+			case "channel":
+				txn.Channel = text
+			case "from_country":
+				txn.FromCountry = text
+			case "to_country":
+				txn.ToCountry = text
+			}
+		default:
+			return fmt.Errorf("enrichment cannot set %s", key)
+		}
+	}
+	return nil
+}
+
+// transactionToStarlark exposes a transaction to scripts as a frozen dict, with
+// amounts in minor units
+func transactionToStarlark(txn *Transaction) starlark.Value {
+	entries := make([]starlark.Value, len(txn.Entries))
+	for i, entry := range txn.Entries {
+		tags := make([]starlark.Value, len(entry.Tags))
+		for j, tag := range entry.Tags {
+			tags[j] = starlark.String(tag)
+		}
+		entries[i] = scriptDict(map[string]starlark.Value{
+			"account_id": starlark.String(entry.AccountID),
+			"type":       starlark.String(entry.Type),
+			"value":      starlark.MakeInt64(entry.Amount.Value),
+			"currency":   starlark.String(entry.Amount.Currency),
+			"memo":       starlark.String(entry.Memo),
+			"reference":  starlark.String(entry.Reference),
+			"tags":       starlark.NewList(tags),
+		})
+	}
+	return scriptDict(map[string]starlark.Value{
+		"id":          starlark.String(txn.ID),
+		"description": starlark.String(txn.Description),
+		"valid_time":  starlark.String(txn.ValidTime.Format(time.RFC3339)),
+		"source_ref":  starlark.String(txn.SourceRef),
+		"user_id":     starlark.String(txn.UserID),
+		"entries":     starlark.NewList(entries),
+	})
+}
+
+// amlTransactionToStarlark exposes an AML transaction to scripts as a frozen dict
+func amlTransactionToStarlark(txn *AMLTransaction) starlark.Value {
+	var amount int64
+	if txn.Amount != nil {
+		amount = txn.Amount.Value
+	}
+	flags := make([]starlark.Value, len(txn.Flags))
+	for i, flag := range txn.Flags {
+		flags[i] = starlark.String(flag)
+	}
+	return scriptDict(map[string]starlark.Value{
+		"transaction_id":   starlark.String(txn.TransactionID),
+		"amount":           starlark.MakeInt64(amount),
+		"currency":         starlark.String(txn.Currency),
+		"date":             starlark.String(txn.Date.Format(time.RFC3339)),
+		"from_customer_id": starlark.String(txn.FromCustomerID),
+		"to_customer_id":   starlark.String(txn.ToCustomerID),
+		"from_country":     starlark.String(txn.FromCountry),
+		"to_country":       starlark.String(txn.ToCountry),
+		"purpose":          starlark.String(txn.Purpose),
+		"channel":          starlark.String(txn.Channel),
+		"risk_score":       starlark.MakeInt(txn.RiskScore),
+		"flags":            starlark.NewList(flags),
+	})
+}
+
+// scriptDict builds a frozen dict so scripts cannot change what they are given
+func scriptDict(fields map[string]starlark.Value) *starlark.Dict {
+	dict := starlark.NewDict(len(fields))
+	for key, value := range fields {
+		_ = dict.SetKey(starlark.String(key), value)
+	}
+	dict.Freeze()
+	return dict
+}
@@ -0,0 +1,163 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptingHooks(t *testing.T) {
+	dbFile := "test_scripting.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	admin := "admin"
+	require.NoError(t, engine.CreateStandardAccounts(admin))
+
+	payment := func(value int64, memo string) *Transaction {
+		return &Transaction{
+			Description: "Supplier payment",
+			ValidTime:   time.Now(),
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}, Memo: memo},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+	}
+
+	t.Run("registration checks the script", func(t *testing.T) {
+		assert.Error(t, engine.RegisterScript(&Script{Name: "broken", Hook: HookValidateTransaction, Source: "def validate(txn)"}, admin))
+		assert.Error(t, engine.RegisterScript(&Script{Name: "wrong-fn", Hook: HookValidateTransaction, Source: "def check(txn):\n    return None\n"}, admin))
+		assert.Error(t, engine.RegisterScript(&Script{Name: "loader", Hook: HookValidateTransaction, Source: "load('os.star', 'system')\ndef validate(txn):\n    return None\n"}, admin), "scripts cannot load modules")
+		assert.Error(t, engine.RegisterScript(&Script{Name: "unknown", Hook: "ON_LOGIN", Source: "def validate(txn):\n    return None\n"}, admin))
+	})
+
+	memoRule := &Script{
+		Name: "large-payments-need-memo",
+		Hook: HookValidateTransaction,
+		Source: `
+def validate(txn):
+    problems = []
+    for entry in txn["entries"]:
+        if entry["type"] == "DEBIT" and entry["value"] >= 100000 and not entry["memo"]:
+            problems.append("debits of 1000.00 or more need a memo")
+    return problems
+`,
+	}
+	require.NoError(t, engine.RegisterScript(memoRule, admin))
+	assert.True(t, memoRule.Active)
+
+	t.Run("validation scripts block posting", func(t *testing.T) {
+		small := payment(5000, "")
+		require.NoError(t, engine.CreateTransaction(small, admin))
+		require.NoError(t, engine.PostTransaction(small.ID, admin))
+
+		large := payment(250000, "")
+		require.NoError(t, engine.CreateTransaction(large, admin))
+		err := engine.PostTransaction(large.ID, admin)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "need a memo")
+
+		documented := payment(250000, "Invoice 7781")
+		require.NoError(t, engine.CreateTransaction(documented, admin))
+		require.NoError(t, engine.PostTransaction(documented.ID, admin))
+
+		require.NoError(t, engine.SetScriptActive(memoRule.ID, false, admin))
+		require.NoError(t, engine.PostTransaction(large.ID, admin), "inactive scripts do not run")
+	})
+
+	t.Run("runaway scripts are stopped", func(t *testing.T) {
+		spin := &Script{
+			Name:     "spin",
+			Hook:     HookValidateTransaction,
+			MaxSteps: 1000,
+			Source: `
+def validate(txn):
+    total = 0
+    for i in range(1000000):
+        total += i
+    return None
+`,
+		}
+		require.NoError(t, engine.RegisterScript(spin, admin))
+		txn := payment(100, "")
+		require.NoError(t, engine.CreateTransaction(txn, admin))
+		err := engine.PostTransaction(txn.ID, admin)
+		require.Error(t, err, "a script that fails to run rejects the transaction")
+		assert.Contains(t, err.Error(), "too many steps")
+
+		require.NoError(t, engine.UpdateScript(spin.ID, "def validate(txn):\n    return None\n", admin))
+		require.NoError(t, engine.PostTransaction(txn.ID, admin))
+	})
+
+	t.Run("AML enrichment", func(t *testing.T) {
+		enrich := &Script{
+			Name: "wire-enrichment",
+			Hook: HookEnrichAMLTransaction,
+			Source: `
+def enrich(txn):
+    if txn["amount"] >= 500000:
+        return {"channel": "WIRE", "flags": ["LARGE_SCRIPTED"], "risk_score": 80}
+    return None
+`,
+		}
+		require.NoError(t, engine.RegisterScript(enrich, admin))
+
+		var enriched *AMLTransaction
+		aml := engine.GetAMLService()
+		aml.AddEnricher(func(txn *AMLTransaction) error {
+			enriched = txn
+			return nil
+		})
+		_, err := aml.MonitorTransaction(payment(600000, "Equipment"), nil)
+		require.NoError(t, err)
+		require.NotNil(t, enriched)
+		assert.Equal(t, "WIRE", enriched.Channel)
+		assert.Equal(t, 80, enriched.RiskScore)
+		assert.Contains(t, enriched.Flags, "LARGE_SCRIPTED")
+	})
+
+	t.Run("custom KPI", func(t *testing.T) {
+		kpi := &Script{
+			Name: "cash-to-expenses",
+			Hook: HookComputeKPI,
+			Source: `
+def compute(as_of, balance):
+    expenses = balance("expenses")
+    if expenses == 0:
+        return 0
+    return -balance("cash") / expenses
+`,
+		}
+		require.NoError(t, engine.RegisterScript(kpi, admin))
+
+		value, err := engine.ComputeKPI("cash-to-expenses", time.Now())
+		require.NoError(t, err)
+		assert.InDelta(t, 1.0, value, 0.0001)
+
+		_, err = engine.ComputeKPI("missing", time.Now())
+		assert.Error(t, err)
+	})
+
+	t.Run("scripts survive a restart", func(t *testing.T) {
+		restarted := NewScriptService(engine.GetStorage(), nil, nil)
+		scripts, err := restarted.activeScripts(HookValidateTransaction)
+		require.NoError(t, err)
+		require.Len(t, scripts, 1, "the memo rule is inactive")
+		assert.Equal(t, "spin", scripts[0].script.Name)
+	})
+
+	t.Run("registering scripts needs permission", func(t *testing.T) {
+		require.NoError(t, engine.CreateRole(&Role{ID: "admins", Name: "Administrators", Permissions: []Permission{PermissionManageAccess}}, admin))
+		require.NoError(t, engine.CreateUser(&User{ID: admin, RoleIDs: []string{"admins"}}, admin))
+		err := engine.RegisterScript(&Script{Name: "later", Hook: HookValidateTransaction, Source: "def validate(txn):\n    return None\n"}, admin)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
@@ -0,0 +1,325 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// DimensionBalance is an account's balance over the entries sharing one combination
+// of dimension values
+type DimensionBalance struct {
+	Dimensions  []Dimension `json:"dimensions"` // an empty value means the entries carry no such dimension
+	AccountID   string      `json:"account_id"`
+	AccountName string      `json:"account_name"`
+	AccountType AccountType `json:"account_type"`
+	Balance     *Amount     `json:"balance"` // positive on the account's normal side
+	EntryCount  int         `json:"entry_count"`
+}
+
+// DimensionSubtotal totals the balances of one account type within a dimension group
+type DimensionSubtotal struct {
+	Dimensions  []Dimension `json:"dimensions"`
+	AccountType AccountType `json:"account_type"`
+	Amount      *Amount     `json:"amount"`
+}
+
+// DimensionBalanceReport lists account balances grouped by dimension values
+type DimensionBalanceReport struct {
+	GroupBy   []DimensionKey       `json:"group_by"`
+	Balances  []*DimensionBalance  `json:"balances"`
+	Subtotals []*DimensionSubtotal `json:"subtotals"` // one per group, account type and currency
+}
+
+// SegmentStatement is the P&L of one value of the segmenting dimension
+type SegmentStatement struct {
+	Value     string              `json:"value"` // empty for entries without the dimension
+	Statement *FinancialStatement `json:"statement"`
+}
+
+// SegmentProfitAndLoss is a P&L split by the values of one dimension
+type SegmentProfitAndLoss struct {
+	DimensionKey DimensionKey        `json:"dimension_key"`
+	FromDate     time.Time           `json:"from_date"`
+	ToDate       time.Time           `json:"to_date"`
+	Currency     string              `json:"currency"`
+	Segments     []*SegmentStatement `json:"segments"`
+	NetIncome    *Amount             `json:"net_income"` // total across segments
+}
+
+// postedEntries returns the entries of posted transactions valid between from and to.
+// A zero from means since inception.
+func (rs *ReportingService) postedEntries(from, to time.Time) ([]*Entry, error) {
+	txns, err := rs.storage.GetAllTransactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	var entries []*Entry
+	for _, txn := range txns {
+		if txn.Status != Posted || txn.ValidTime.After(to) || (!from.IsZero() && txn.ValidTime.Before(from)) {
+			continue
+		}
+		for i := range txn.Entries {
+			entries = append(entries, &txn.Entries[i])
+		}
+	}
+	return entries, nil
+}
+
+// GetBalancesByDimensions aggregates posted entries matching the filter into account
+// balances per combination of the groupBy dimension values, with a subtotal per
+// account type in each group. Filter dimensions must all be present on an entry, and
+// ValidTimeTo defaults to now. Balances stay in the entry currency.
+func (rs *ReportingService) GetBalancesByDimensions(filter *QueryFilter, groupBy []DimensionKey) (*DimensionBalanceReport, error) {
+	if filter == nil {
+		filter = &QueryFilter{}
+	}
+	to := time.Now()
+	if filter.ValidTimeTo != nil {
+		to = *filter.ValidTimeTo
+	}
+	var from time.Time
+	if filter.ValidTimeFrom != nil {
+		from = *filter.ValidTimeFrom
+	}
+
+	entries, err := rs.postedEntries(from, to)
+	if err != nil {
+		return nil, err
+	}
+	accounts, err := rs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	byID := make(map[string]*Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+
+	report := &DimensionBalanceReport{GroupBy: groupBy}
+	balances := make(map[string]*DimensionBalance)
+	subtotals := make(map[string]*DimensionSubtotal)
+	for _, entry := range entries {
+		account, ok := byID[entry.AccountID]
+		if !ok || !matchesBalanceFilter(filter, entry, account) {
+			continue
+		}
+
+		dims := make([]Dimension, len(groupBy))
+		for i, key := range groupBy {
+			dims[i] = Dimension{Key: key, Value: entryDimension(entry, key)}
+		}
+		group := dimensionsKey(dims)
+		signed := entry.Amount.Value * debitSign(account.Type)
+		if entry.Type == Credit {
+			signed = -signed
+		}
+
+		balanceKey := group + "|" + account.ID + "|" + string(entry.Amount.Currency)
+		balance, ok := balances[balanceKey]
+		if !ok {
+			balance = &DimensionBalance{
+				Dimensions:  dims,
+				AccountID:   account.ID,
+				AccountName: account.Name,
+				AccountType: account.Type,
+				Balance:     &Amount{Currency: entry.Amount.Currency},
+			}
+			balances[balanceKey] = balance
+			report.Balances = append(report.Balances, balance)
+		}
+		balance.Balance.Value += signed
+		balance.EntryCount++
+
+		subtotalKey := group + "|" + string(account.Type) + "|" + string(entry.Amount.Currency)
+		subtotal, ok := subtotals[subtotalKey]
+		if !ok {
+			subtotal = &DimensionSubtotal{Dimensions: dims, AccountType: account.Type, Amount: &Amount{Currency: entry.Amount.Currency}}
+			subtotals[subtotalKey] = subtotal
+			report.Subtotals = append(report.Subtotals, subtotal)
+		}
+		subtotal.Amount.Value += signed
+	}
+
+	sort.Slice(report.Balances, func(i, j int) bool {
+		a, b := report.Balances[i], report.Balances[j]
+		if ka, kb := dimensionsKey(a.Dimensions), dimensionsKey(b.Dimensions); ka != kb {
+			return ka < kb
+		}
+		if ca, cb := byID[a.AccountID].Code, byID[b.AccountID].Code; ca != cb {
+			return ca < cb
+		}
+		return a.Balance.Currency < b.Balance.Currency
+	})
+	sort.Slice(report.Subtotals, func(i, j int) bool {
+		a, b := report.Subtotals[i], report.Subtotals[j]
+		if ka, kb := dimensionsKey(a.Dimensions), dimensionsKey(b.Dimensions); ka != kb {
+			return ka < kb
+		}
+		if a.AccountType != b.AccountType {
+			return a.AccountType < b.AccountType
+		}
+		return a.Amount.Currency < b.Amount.Currency
+	})
+	return report, nil
+}
+
+// matchesBalanceFilter reports whether an entry passes the account, currency and
+// dimension conditions of a filter
+func matchesBalanceFilter(filter *QueryFilter, entry *Entry, account *Account) bool {
+	if len(filter.AccountIDs) > 0 && !slices.Contains(filter.AccountIDs, account.ID) {
+		return false
+	}
+	if len(filter.AccountTypes) > 0 && !containsAccountType(filter.AccountTypes, account.Type) {
+		return false
+	}
+	if len(filter.Currencies) > 0 && !slices.Contains(filter.Currencies, entry.Amount.Currency) {
+		return false
+	}
+	return hasDimensions(entry.Dimensions, filter.Dimensions)
+}
+
+// GenerateProfitAndLossByDimension splits the P&L for a period by the values of a
+// dimension, such as department, project or cost center. Each segment is a full P&L
+// over the entries tagged with that value, and entries without the dimension form an
+// unassigned segment, so the segments add up to the overall P&L.
+func (rs *ReportingService) GenerateProfitAndLossByDimension(key DimensionKey, fromDate, toDate time.Time, currency string) (_ *SegmentProfitAndLoss, err error) {
+	defer rs.metrics.timeReport("profit_and_loss_by_dimension")(&err)
+	defer rs.tracer.report("profit_and_loss_by_dimension")(&err)
+
+	if key == "" {
+		return nil, fmt.Errorf("dimension key is required")
+	}
+	accounts, err := rs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Code < accounts[j].Code
+	})
+	byID := make(map[string]*Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+
+	entries, err := rs.postedEntries(fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// Segment value -> account -> entry currency -> signed amount
+	amounts := make(map[string]map[string]map[Currency]int64)
+	for _, entry := range entries {
+		account, ok := byID[entry.AccountID]
+		if !ok || (account.Type != Income && account.Type != Expense) {
+			continue
+		}
+		signed := entry.Amount.Value * debitSign(account.Type)
+		if entry.Type == Credit {
+			signed = -signed
+		}
+
+		value := entryDimension(entry, key)
+		if amounts[value] == nil {
+			amounts[value] = make(map[string]map[Currency]int64)
+		}
+		if amounts[value][account.ID] == nil {
+			amounts[value][account.ID] = make(map[Currency]int64)
+		}
+		amounts[value][account.ID][entry.Amount.Currency] += signed
+	}
+
+	values := make([]string, 0, len(amounts))
+	for value := range amounts {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		// The unassigned segment goes last
+		if values[i] == "" || values[j] == "" {
+			return values[j] == ""
+		}
+		return values[i] < values[j]
+	})
+
+	result := &SegmentProfitAndLoss{
+		DimensionKey: key,
+		FromDate:     fromDate,
+		ToDate:       toDate,
+		Currency:     currency,
+		NetIncome:    &Amount{Currency: Currency(currency)},
+	}
+	for _, value := range values {
+		statement, err := rs.segmentStatement(key, value, accounts, amounts[value], fromDate, toDate, currency)
+		if err != nil {
+			return nil, err
+		}
+		result.Segments = append(result.Segments, &SegmentStatement{Value: value, Statement: statement})
+		result.NetIncome.Value += statement.NetIncome.Value
+	}
+	return result, nil
+}
+
+// segmentStatement builds the P&L of one segment from its per-account amounts
+func (rs *ReportingService) segmentStatement(key DimensionKey, value string, accounts []*Account, amounts map[string]map[Currency]int64, fromDate, toDate time.Time, currency string) (*FinancialStatement, error) {
+	label := value
+	if label == "" {
+		label = "Unassigned"
+	}
+	statement := &FinancialStatement{
+		Name:     fmt.Sprintf("Profit & Loss Statement (%s: %s)", key, label),
+		AsOfDate: toDate,
+		FromDate: &fromDate,
+		Currency: currency,
+	}
+
+	var revenue, expenses []*FinancialLineItem
+	totalRevenue := &Amount{Currency: Currency(currency)}
+	totalExpenses := &Amount{Currency: Currency(currency)}
+	for _, account := range accounts {
+		if account.Type != Income && account.Type != Expense {
+			continue
+		}
+		total := &Amount{Currency: Currency(currency)}
+		for entryCurrency, amount := range amounts[account.ID] {
+			translated, err := rs.translateAmount(&Amount{Value: amount, Currency: entryCurrency}, currency, toDate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to translate balance for account %s: %w", account.ID, err)
+			}
+			total.Value += translated.Value
+		}
+
+		lineItem := &FinancialLineItem{
+			AccountID:   account.ID,
+			AccountName: account.Name,
+			AccountType: account.Type,
+			Amount:      total,
+			Level:       1,
+		}
+		if account.Type == Income {
+			revenue = append(revenue, lineItem)
+			totalRevenue.Value += total.Value
+		} else {
+			expenses = append(expenses, lineItem)
+			totalExpenses.Value += total.Value
+		}
+	}
+
+	var err error
+	if revenue, err = rs.rollupLineItems(revenue, currency); err != nil {
+		return nil, err
+	}
+	if expenses, err = rs.rollupLineItems(expenses, currency); err != nil {
+		return nil, err
+	}
+
+	netIncome := &Amount{Value: totalRevenue.Value - totalExpenses.Value, Currency: Currency(currency)}
+	statement.LineItems = []*FinancialLineItem{
+		{AccountName: "REVENUE", IsSubtotal: true, Amount: totalRevenue, Children: revenue},
+		{AccountName: "EXPENSES", IsSubtotal: true, Amount: totalExpenses, Children: expenses},
+		{AccountName: "NET INCOME", IsSubtotal: true, Amount: netIncome},
+	}
+	statement.NetIncome = netIncome
+	return statement, nil
+}
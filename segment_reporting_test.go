@@ -0,0 +1,111 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSegmentReporting(t *testing.T) {
+	dbFile := "test_segment_reporting.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "travel", Code: "5010", Name: "Travel", Type: Expense, ParentID: "expenses"}, userID))
+
+	march := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	post := func(debit, credit string, amount int64, dims ...Dimension) {
+		txn := &Transaction{
+			Description: "Segment activity",
+			ValidTime:   march,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: dims},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: amount, Currency: "USD"}, Dimensions: dims},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	sales := Dimension{Key: DimDepartment, Value: "sales"}
+	ops := Dimension{Key: DimDepartment, Value: "ops"}
+	alpha := Dimension{Key: DimProject, Value: "alpha"}
+
+	post("cash", "revenue", 100000, sales, alpha)
+	post("cash", "revenue", 40000, ops)
+	post("expenses", "cash", 30000, sales)
+	post("travel", "cash", 5000, sales, alpha)
+	post("expenses", "cash", 8000)
+
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("splits the P&L by department", func(t *testing.T) {
+		report, err := engine.GenerateProfitAndLossByDimension(DimDepartment, from, to, "USD")
+		require.NoError(t, err)
+		require.Len(t, report.Segments, 3)
+
+		assert.Equal(t, "ops", report.Segments[0].Value)
+		assert.Equal(t, int64(40000), report.Segments[0].Statement.NetIncome.Value)
+		assert.Equal(t, "sales", report.Segments[1].Value)
+		assert.Equal(t, int64(65000), report.Segments[1].Statement.NetIncome.Value)
+		assert.Equal(t, "", report.Segments[2].Value, "untagged entries come last")
+		assert.Equal(t, int64(-8000), report.Segments[2].Statement.NetIncome.Value)
+
+		expenses := report.Segments[1].Statement.LineItems[1]
+		assert.Equal(t, int64(35000), expenses.Amount.Value)
+		require.Len(t, expenses.Children, 1)
+		assert.Equal(t, int64(35000), expenses.Children[0].Amount.Value, "travel rolls up into operating expenses")
+
+		pl, err := engine.GenerateProfitAndLoss(from, to, "USD")
+		require.NoError(t, err)
+		assert.Equal(t, pl.NetIncome.Value, report.NetIncome.Value)
+	})
+
+	t.Run("groups balances by dimensions with subtotals", func(t *testing.T) {
+		report, err := engine.GetBalancesByDimensions(&QueryFilter{
+			ValidTimeTo:  &to,
+			AccountTypes: []AccountType{Income, Expense},
+		}, []DimensionKey{DimDepartment, DimProject})
+		require.NoError(t, err)
+
+		var salesAlpha []*DimensionBalance
+		for _, balance := range report.Balances {
+			if dimensionsKey(balance.Dimensions) == "department=sales,project=alpha" {
+				salesAlpha = append(salesAlpha, balance)
+			}
+		}
+		require.Len(t, salesAlpha, 2)
+		assert.Equal(t, "revenue", salesAlpha[0].AccountID)
+		assert.Equal(t, int64(100000), salesAlpha[0].Balance.Value)
+		assert.Equal(t, "travel", salesAlpha[1].AccountID)
+
+		subtotals := make(map[string]int64)
+		for _, subtotal := range report.Subtotals {
+			subtotals[dimensionsKey(subtotal.Dimensions)+" "+string(subtotal.AccountType)] = subtotal.Amount.Value
+		}
+		assert.Equal(t, int64(30000), subtotals["department=sales,project= EXPENSE"])
+		assert.Equal(t, int64(8000), subtotals["department=,project= EXPENSE"])
+	})
+
+	t.Run("filters on dimensions", func(t *testing.T) {
+		report, err := engine.GetBalancesByDimensions(&QueryFilter{
+			ValidTimeTo: &to,
+			Dimensions:  []Dimension{alpha},
+		}, nil)
+		require.NoError(t, err)
+
+		balances := make(map[string]int64)
+		for _, balance := range report.Balances {
+			balances[balance.AccountID] = balance.Balance.Value
+		}
+		assert.Equal(t, map[string]int64{"cash": 95000, "revenue": 100000, "travel": 5000}, balances)
+	})
+}
@@ -0,0 +1,588 @@
+package accounting
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// SOX Controls Catalog
+// ----------------------------------------------------------------------------
+
+// ControlFrequency is how often a control is performed and tested
+type ControlFrequency string
+
+const (
+	ControlDaily     ControlFrequency = "DAILY"
+	ControlWeekly    ControlFrequency = "WEEKLY" // weeks start on Monday
+	ControlMonthly   ControlFrequency = "MONTHLY"
+	ControlQuarterly ControlFrequency = "QUARTERLY"
+	ControlAnnually  ControlFrequency = "ANNUALLY"
+)
+
+// ControlTestType is how a control is tested. Manual controls are performed by
+// their owner, who records the result; the others are tested against the ledger.
+type ControlTestType string
+
+const (
+	ControlTestManual                 ControlTestType = "MANUAL"
+	ControlTestApprovalAboveThreshold ControlTestType = "APPROVAL_ABOVE_THRESHOLD" // postings at or above the threshold were approved
+	ControlTestSegregationOfDuties    ControlTestType = "SEGREGATION_OF_DUTIES"    // no one posted or approved their own entry
+)
+
+// ControlTestStatus is the outcome of a control test
+type ControlTestStatus string
+
+const (
+	ControlPassed ControlTestStatus = "PASSED"
+	ControlFailed ControlTestStatus = "FAILED" // exceptions were found
+	ControlError  ControlTestStatus = "ERROR"  // the test could not be completed
+)
+
+// soxControlUserID is who tests run by the background runner are recorded against
+const soxControlUserID = "sox_control_tester"
+
+// SOXControl is an internal control over financial reporting in the controls catalog
+type SOXControl struct {
+	ID          string            `json:"id"` // the control reference, e.g. "JE-01"
+	Objective   string            `json:"objective"`
+	Description string            `json:"description,omitempty"`
+	Frequency   ControlFrequency  `json:"frequency"`
+	Owner       string            `json:"owner"`
+	TestType    ControlTestType   `json:"test_type"`
+	Threshold   *Amount           `json:"threshold,omitempty"` // for approval tests; only postings in its currency are tested
+	Active      bool              `json:"active"`
+	NextRunAt   time.Time         `json:"next_run_at,omitempty"` // end of the period the next scheduled test covers
+	LastRunAt   *time.Time        `json:"last_run_at,omitempty"`
+	LastStatus  ControlTestStatus `json:"last_status,omitempty"`
+	CreatedBy   string            `json:"created_by"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// automated reports whether the control is tested against the ledger
+func (c *SOXControl) automated() bool {
+	return c.TestType != ControlTestManual
+}
+
+// ControlException is one failure of a control found by a test
+type ControlException struct {
+	TransactionID string  `json:"transaction_id,omitempty"`
+	Description   string  `json:"description"`
+	UserID        string  `json:"user_id,omitempty"` // who the exception is attributed to
+	Amount        *Amount `json:"amount,omitempty"`
+}
+
+// ControlTestResult records one test of a control and the evidence behind it. The
+// checksum covers the evidence so auditors can confirm it has not changed since the
+// test ran; documents attached afterwards are not part of it.
+type ControlTestResult struct {
+	ID                   string             `json:"id"`
+	ControlID            string             `json:"control_id"`
+	TestType             ControlTestType    `json:"test_type"`
+	PeriodStart          time.Time          `json:"period_start"`
+	PeriodEnd            time.Time          `json:"period_end"`
+	Status               ControlTestStatus  `json:"status"`
+	Population           int                `json:"population"` // transactions the test covered
+	TestedTransactionIDs []string           `json:"tested_transaction_ids,omitempty"`
+	Exceptions           []ControlException `json:"exceptions,omitempty"`
+	Notes                string             `json:"notes,omitempty"`
+	Error                string             `json:"error,omitempty"`
+	Checksum             string             `json:"checksum"` // SHA-256 of the evidence, hex encoded
+	Scheduled            bool               `json:"scheduled"`
+	Attachments          []string           `json:"attachments,omitempty"` // supporting document IDs
+	RunBy                string             `json:"run_by"`
+	StartedAt            time.Time          `json:"started_at"`
+	FinishedAt           time.Time          `json:"finished_at"`
+}
+
+// controlEvidence is the part of a test result its checksum covers
+type controlEvidence struct {
+	ControlID            string             `json:"control_id"`
+	TestType             ControlTestType    `json:"test_type"`
+	PeriodStart          time.Time          `json:"period_start"`
+	PeriodEnd            time.Time          `json:"period_end"`
+	Status               ControlTestStatus  `json:"status"`
+	Population           int                `json:"population"`
+	TestedTransactionIDs []string           `json:"tested_transaction_ids"`
+	Exceptions           []ControlException `json:"exceptions"`
+	Notes                string             `json:"notes"`
+}
+
+// checksum hashes the result's evidence
+func (r *ControlTestResult) checksum() (string, error) {
+	data, err := json.Marshal(controlEvidence{
+		ControlID:            r.ControlID,
+		TestType:             r.TestType,
+		PeriodStart:          r.PeriodStart.UTC(),
+		PeriodEnd:            r.PeriodEnd.UTC(),
+		Status:               r.Status,
+		Population:           r.Population,
+		TestedTransactionIDs: r.TestedTransactionIDs,
+		Exceptions:           r.Exceptions,
+		Notes:                r.Notes,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode control evidence: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// controlPeriodStart is the start of the control period containing a time
+func controlPeriodStart(frequency ControlFrequency, at time.Time) (time.Time, error) {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	switch frequency {
+	case ControlDaily:
+		return day, nil
+	case ControlWeekly:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7)), nil
+	case ControlMonthly:
+		return time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, at.Location()), nil
+	case ControlQuarterly:
+		return time.Date(at.Year(), at.Month()-(at.Month()-1)%3, 1, 0, 0, 0, 0, at.Location()), nil
+	case ControlAnnually:
+		return time.Date(at.Year(), 1, 1, 0, 0, 0, 0, at.Location()), nil
+	default:
+		return time.Time{}, classify(ErrValidation, "unsupported control frequency: %s", frequency)
+	}
+}
+
+// nextControlPeriod is the start of the control period after the one starting at
+// start
+func nextControlPeriod(frequency ControlFrequency, start time.Time) time.Time {
+	switch frequency {
+	case ControlDaily:
+		return start.AddDate(0, 0, 1)
+	case ControlWeekly:
+		return start.AddDate(0, 0, 7)
+	case ControlMonthly:
+		return start.AddDate(0, 1, 0)
+	case ControlQuarterly:
+		return start.AddDate(0, 3, 0)
+	default:
+		return start.AddDate(1, 0, 0)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// SOX Control Service
+// ----------------------------------------------------------------------------
+
+// SOXControlService keeps the controls catalog and tests automated controls against
+// posted transactions, on demand or when each control's period ends, either when
+// RunDue is called or from a background runner started with Start
+type SOXControlService struct {
+	storage    *Storage
+	eventStore *EventStore
+	compliance *ComplianceService
+	now        func() time.Time
+	logger     *slog.Logger
+
+	mu   sync.Mutex // guards the runner
+	stop chan struct{}
+	done chan struct{}
+
+	runMu sync.Mutex // one pass over the controls at a time
+}
+
+// NewSOXControlService creates a new SOX control service
+func NewSOXControlService(storage *Storage, eventStore *EventStore, compliance *ComplianceService) *SOXControlService {
+	return &SOXControlService{
+		storage:    storage,
+		eventStore: eventStore,
+		compliance: compliance,
+		now:        time.Now,
+		logger:     discardLogger,
+	}
+}
+
+// SetLogger sends the service's logs to a host logger; nil discards them. Set it
+// before Start.
+func (ss *SOXControlService) SetLogger(logger *slog.Logger) {
+	ss.logger = componentLogger(logger, "sox_controls")
+}
+
+// SaveControl adds a control to the catalog or updates it. Automated controls are
+// next tested when their current period ends.
+func (ss *SOXControlService) SaveControl(control *SOXControl, userID string) error {
+	if control.ID == "" {
+		return classify(ErrValidation, "control ID is required")
+	}
+	if control.Objective == "" {
+		return classify(ErrValidation, "control %s needs an objective", control.ID)
+	}
+	if control.Owner == "" {
+		return classify(ErrValidation, "control %s needs an owner", control.ID)
+	}
+	if control.TestType == "" {
+		control.TestType = ControlTestManual
+	}
+	switch control.TestType {
+	case ControlTestManual, ControlTestSegregationOfDuties:
+	case ControlTestApprovalAboveThreshold:
+		if control.Threshold == nil || control.Threshold.Value <= 0 || control.Threshold.Currency == "" {
+			return classify(ErrValidation, "control %s needs a positive threshold with a currency", control.ID)
+		}
+	default:
+		return classify(ErrValidation, "unsupported control test type: %s", control.TestType)
+	}
+	now := ss.now()
+	periodStart, err := controlPeriodStart(control.Frequency, now)
+	if err != nil {
+		return err
+	}
+
+	existing, err := ss.storage.GetSOXControl(control.ID)
+	switch {
+	case err == nil:
+		control.Active = existing.Active
+		control.LastRunAt = existing.LastRunAt
+		control.LastStatus = existing.LastStatus
+		control.CreatedBy = existing.CreatedBy
+		control.CreatedAt = existing.CreatedAt
+	case errors.Is(err, ErrNotFound):
+		control.Active = true
+		control.CreatedBy = userID
+		control.CreatedAt = now
+	default:
+		return fmt.Errorf("failed to get control: %w", err)
+	}
+	control.NextRunAt = time.Time{}
+	if control.automated() {
+		control.NextRunAt = nextControlPeriod(control.Frequency, periodStart)
+	}
+	control.UpdatedAt = now
+	return ss.saveControl(control, userID)
+}
+
+// SetActive takes a control out of scheduled testing or puts it back. A resumed
+// control is next tested when its current period ends rather than catching up.
+func (ss *SOXControlService) SetActive(controlID string, active bool, userID string) (*SOXControl, error) {
+	control, err := ss.storage.GetSOXControl(controlID)
+	if err != nil {
+		return nil, err
+	}
+	control.Active = active
+	if control.automated() {
+		periodStart, err := controlPeriodStart(control.Frequency, ss.now())
+		if err != nil {
+			return nil, err
+		}
+		control.NextRunAt = nextControlPeriod(control.Frequency, periodStart)
+	}
+	control.UpdatedAt = ss.now()
+	if err := ss.saveControl(control, userID); err != nil {
+		return nil, err
+	}
+	return control, nil
+}
+
+// GetControls returns the controls catalog ordered by control ID
+func (ss *SOXControlService) GetControls() ([]*SOXControl, error) {
+	controls, err := ss.storage.GetAllSOXControls()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get controls: %w", err)
+	}
+	sort.Slice(controls, func(i, j int) bool { return controls[i].ID < controls[j].ID })
+	return controls, nil
+}
+
+// TestControl tests an automated control over the transactions dated from
+// periodStart to periodEnd inclusive
+func (ss *SOXControlService) TestControl(controlID string, periodStart, periodEnd time.Time, userID string) (*ControlTestResult, error) {
+	ss.runMu.Lock()
+	defer ss.runMu.Unlock()
+
+	control, err := ss.storage.GetSOXControl(controlID)
+	if err != nil {
+		return nil, err
+	}
+	if !control.automated() {
+		return nil, classify(ErrValidation, "control %s is tested manually; record its result instead", controlID)
+	}
+	if periodEnd.Before(periodStart) {
+		return nil, classify(ErrValidation, "period end is before period start")
+	}
+	return ss.run(control, periodStart, periodEnd, false, userID)
+}
+
+// RunDue tests every active automated control whose period ended at or before now.
+// A control that missed several periods, say while the engine was stopped, is
+// tested for the earliest of them and then moves on to its current period. Tests
+// that fail or error are recorded; the error is only for tests that could not be
+// recorded.
+func (ss *SOXControlService) RunDue(now time.Time, userID string) ([]*ControlTestResult, error) {
+	ss.runMu.Lock()
+	defer ss.runMu.Unlock()
+
+	controls, err := ss.GetControls()
+	if err != nil {
+		return nil, err
+	}
+	var results []*ControlTestResult
+	for _, control := range controls {
+		if !control.Active || !control.automated() || control.NextRunAt.IsZero() || control.NextRunAt.After(now) {
+			continue
+		}
+		periodEnd := control.NextRunAt.Add(-time.Nanosecond)
+		periodStart, err := controlPeriodStart(control.Frequency, periodEnd)
+		if err != nil {
+			return results, err
+		}
+		current, err := controlPeriodStart(control.Frequency, now)
+		if err != nil {
+			return results, err
+		}
+		control.NextRunAt = nextControlPeriod(control.Frequency, current)
+		control.UpdatedAt = ss.now()
+
+		result, err := ss.run(control, periodStart, periodEnd, true, userID)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// RecordManualResult records the owner's test of a manual control: its period,
+// status, notes and any exceptions found
+func (ss *SOXControlService) RecordManualResult(result *ControlTestResult, userID string) error {
+	control, err := ss.storage.GetSOXControl(result.ControlID)
+	if err != nil {
+		return err
+	}
+	if control.automated() {
+		return classify(ErrValidation, "control %s is tested automatically", control.ID)
+	}
+	if result.Status != ControlPassed && result.Status != ControlFailed {
+		return classify(ErrValidation, "manual test results must pass or fail")
+	}
+	if result.Status == ControlFailed && len(result.Exceptions) == 0 && result.Notes == "" {
+		return classify(ErrValidation, "failed tests need exceptions or notes")
+	}
+	if result.PeriodEnd.Before(result.PeriodStart) {
+		return classify(ErrValidation, "period end is before period start")
+	}
+
+	now := ss.now()
+	result.ID = ""
+	result.TestType = ControlTestManual
+	result.Scheduled = false
+	result.Error = ""
+	result.RunBy = userID
+	result.StartedAt, result.FinishedAt = now, now
+	if err := ss.storage.assignID(&result.ID, "control test", BucketControlTestResults); err != nil {
+		return err
+	}
+	return ss.record(control, result, userID)
+}
+
+// GetResults returns a control's test history, most recent first
+func (ss *SOXControlService) GetResults(controlID string) ([]*ControlTestResult, error) {
+	all, err := ss.storage.GetAllControlTestResults()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get control test results: %w", err)
+	}
+	var results []*ControlTestResult
+	for _, result := range all {
+		if result.ControlID == controlID {
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].StartedAt.After(results[j].StartedAt) })
+	return results, nil
+}
+
+// VerifyResult reports whether a stored test result still matches the checksum
+// taken when it was recorded
+func (ss *SOXControlService) VerifyResult(resultID string) (bool, error) {
+	result, err := ss.storage.GetControlTestResult(resultID)
+	if err != nil {
+		return false, err
+	}
+	checksum, err := result.checksum()
+	if err != nil {
+		return false, err
+	}
+	return checksum == result.Checksum, nil
+}
+
+// Start tests due controls straight away and then every interval in the background
+// until Stop is called
+func (ss *SOXControlService) Start(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("control testing interval must be positive")
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.stop != nil {
+		return fmt.Errorf("control testing is already running")
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	ss.stop, ss.done = stop, done
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := ss.RunDue(ss.now(), soxControlUserID); err != nil {
+				ss.logger.Error("scheduled control testing failed, retrying next tick", LogKeyError, err)
+			}
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops the background runner, waiting for a pass in progress to finish. It
+// does nothing when the runner is not started.
+func (ss *SOXControlService) Stop() {
+	ss.mu.Lock()
+	stop, done := ss.stop, ss.done
+	ss.stop, ss.done = nil, nil
+	ss.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
+
+// Running reports whether the background runner is started
+func (ss *SOXControlService) Running() bool {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.stop != nil
+}
+
+// run tests a control over a period and records the result
+func (ss *SOXControlService) run(control *SOXControl, periodStart, periodEnd time.Time, scheduled bool, userID string) (*ControlTestResult, error) {
+	result := &ControlTestResult{
+		ControlID:   control.ID,
+		TestType:    control.TestType,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Scheduled:   scheduled,
+		RunBy:       userID,
+		StartedAt:   ss.now(),
+	}
+	if err := ss.storage.assignID(&result.ID, "control test", BucketControlTestResults); err != nil {
+		return nil, err
+	}
+
+	switch err := ss.test(control, result); {
+	case err != nil:
+		result.Status = ControlError
+		result.Error = err.Error()
+		ss.logger.Error("control test failed to run", "control_id", control.ID, LogKeyRunID, result.ID, LogKeyError, err)
+	case len(result.Exceptions) > 0:
+		result.Status = ControlFailed
+		ss.logger.Warn("control test found exceptions", "control_id", control.ID, LogKeyRunID, result.ID, "exceptions", len(result.Exceptions))
+	default:
+		result.Status = ControlPassed
+	}
+	result.FinishedAt = ss.now()
+
+	if err := ss.record(control, result, userID); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// test checks the posted transactions in the result's period against the control,
+// adding the population and exceptions to the result
+func (ss *SOXControlService) test(control *SOXControl, result *ControlTestResult) error {
+	txns, err := ss.storage.GetTransactionsByDateRange("", result.PeriodStart, result.PeriodEnd)
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+	sort.Slice(txns, func(i, j int) bool {
+		if !txns[i].ValidTime.Equal(txns[j].ValidTime) {
+			return txns[i].ValidTime.Before(txns[j].ValidTime)
+		}
+		return txns[i].ID < txns[j].ID
+	})
+
+	for _, txn := range txns {
+		if txn.Status != Posted && txn.Status != Reversed {
+			continue
+		}
+		switch control.TestType {
+		case ControlTestApprovalAboveThreshold:
+			var total int64
+			for _, entry := range txn.Entries {
+				if entry.Type == Debit && entry.Amount.Currency == control.Threshold.Currency {
+					total += entry.Amount.Value
+				}
+			}
+			if total < control.Threshold.Value {
+				continue
+			}
+			result.TestedTransactionIDs = append(result.TestedTransactionIDs, txn.ID)
+			if txn.ApprovedBy == "" {
+				result.Exceptions = append(result.Exceptions, ControlException{
+					TransactionID: txn.ID,
+					Description: fmt.Sprintf("Transaction of %s %s was posted without approval",
+						FormatMinorUnits(total, control.Threshold.Currency), control.Threshold.Currency),
+					UserID: txn.PostedBy,
+					Amount: &Amount{Value: total, Currency: control.Threshold.Currency},
+				})
+			}
+		case ControlTestSegregationOfDuties:
+			result.TestedTransactionIDs = append(result.TestedTransactionIDs, txn.ID)
+			violation := ss.compliance.checkSegregationOfDuties(*txn, ComplianceRule{ID: control.ID})
+			if violation != nil {
+				result.Exceptions = append(result.Exceptions, ControlException{
+					TransactionID: txn.ID,
+					Description:   violation.Description,
+					UserID:        transactionMaker(txn),
+				})
+			}
+		}
+	}
+	result.Population = len(result.TestedTransactionIDs)
+	return nil
+}
+
+// record seals a test result's evidence, stores it and notes the outcome on the
+// control
+func (ss *SOXControlService) record(control *SOXControl, result *ControlTestResult, userID string) error {
+	checksum, err := result.checksum()
+	if err != nil {
+		return err
+	}
+	result.Checksum = checksum
+
+	if _, err := ss.eventStore.CreateEvent(EventRecordControlTest, result, result.StartedAt, userID); err != nil {
+		return fmt.Errorf("failed to create control test event: %w", err)
+	}
+	if err := ss.storage.SaveControlTestResult(result); err != nil {
+		return fmt.Errorf("failed to save control test result: %w", err)
+	}
+
+	control.LastRunAt = &result.StartedAt
+	control.LastStatus = result.Status
+	return ss.saveControl(control, userID)
+}
+
+// saveControl records and stores a control
+func (ss *SOXControlService) saveControl(control *SOXControl, userID string) error {
+	if _, err := ss.eventStore.CreateEvent(EventSaveSOXControl, control, time.Now(), userID); err != nil {
+		return fmt.Errorf("failed to create control event: %w", err)
+	}
+	return ss.storage.SaveSOXControl(control)
+}
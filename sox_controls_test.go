@@ -0,0 +1,219 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSOXControls(t *testing.T) {
+	// Setup
+	dbFile := "test_sox_controls.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	clerk, controller := "ap_clerk", "controller"
+	require.NoError(t, engine.CreateStandardAccounts(controller))
+
+	approvals := &SOXControl{
+		ID: "JE-01", Objective: "Journal entries of $1,000 or more are approved before posting",
+		Frequency: ControlMonthly, Owner: controller, TestType: ControlTestApprovalAboveThreshold,
+		Threshold: &Amount{Value: 100000, Currency: "USD"},
+	}
+	duties := &SOXControl{
+		ID: "JE-02", Objective: "No one posts or approves a journal entry they prepared",
+		Frequency: ControlMonthly, Owner: controller, TestType: ControlTestSegregationOfDuties,
+	}
+	reconciliation := &SOXControl{
+		ID: "CASH-01", Objective: "Bank reconciliations are reviewed each month", Frequency: ControlMonthly, Owner: "treasurer",
+	}
+
+	date := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC) }
+	journal := func(value int64, on time.Time, postedBy string) *Transaction {
+		txn := &Transaction{
+			Description: "Accrued expenses",
+			ValidTime:   on,
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "accounts_payable", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, clerk))
+		require.NoError(t, engine.PostTransaction(txn.ID, postedBy))
+		return txn
+	}
+
+	t.Run("Controls Catalog", func(t *testing.T) {
+		for _, control := range []*SOXControl{approvals, duties, reconciliation} {
+			require.NoError(t, engine.SaveSOXControl(control, controller))
+		}
+		controls, err := engine.GetSOXControls()
+		require.NoError(t, err)
+		require.Len(t, controls, 3)
+		assert.Equal(t, "CASH-01", controls[0].ID)
+		assert.Equal(t, ControlTestManual, controls[0].TestType)
+		assert.True(t, controls[0].NextRunAt.IsZero(), "manual controls are not scheduled")
+
+		now := time.Now()
+		assert.True(t, controls[1].Active)
+		nextMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, 1, 0)
+		assert.True(t, nextMonth.Equal(controls[1].NextRunAt), "tested when the month ends")
+		assert.Equal(t, int64(100000), controls[1].Threshold.Value)
+	})
+
+	unapproved := journal(250000, date(1, 10), controller)
+	selfPosted := journal(50000, date(1, 15), clerk)
+	require.NoError(t, engine.ApplyCompanySettings(&CompanySettings{RequireApprovalOver: &Amount{Value: 100000, Currency: "USD"}}))
+	engine.SetJournalApprovers([]string{controller})
+	approved := journal(300000, date(1, 20), clerk)
+	_, err = engine.ApproveTransaction(approved.ID, "Agrees to the accrual schedule", controller)
+	require.NoError(t, err)
+
+	var approvalTest *ControlTestResult
+	t.Run("Approval Above Threshold", func(t *testing.T) {
+		approvalTest, err = engine.TestSOXControl("JE-01", date(1, 1), date(1, 31), controller)
+		require.NoError(t, err)
+		assert.Equal(t, ControlFailed, approvalTest.Status)
+		assert.Equal(t, 2, approvalTest.Population)
+		assert.Equal(t, []string{unapproved.ID, approved.ID}, approvalTest.TestedTransactionIDs)
+		require.Len(t, approvalTest.Exceptions, 1)
+		exception := approvalTest.Exceptions[0]
+		assert.Equal(t, unapproved.ID, exception.TransactionID)
+		assert.Equal(t, controller, exception.UserID)
+		assert.Equal(t, int64(250000), exception.Amount.Value)
+		assert.Equal(t, "Transaction of 2500.00 USD was posted without approval", exception.Description)
+		assert.False(t, approvalTest.Scheduled)
+		assert.NotEmpty(t, approvalTest.Checksum)
+	})
+
+	t.Run("Segregation Of Duties", func(t *testing.T) {
+		result, err := engine.TestSOXControl("JE-02", date(1, 1), date(1, 31), controller)
+		require.NoError(t, err)
+		assert.Equal(t, ControlFailed, result.Status)
+		assert.Equal(t, 3, result.Population)
+		require.Len(t, result.Exceptions, 1)
+		assert.Equal(t, selfPosted.ID, result.Exceptions[0].TransactionID)
+		assert.Equal(t, clerk, result.Exceptions[0].UserID)
+		assert.Contains(t, result.Exceptions[0].Description, "created and posted by the same user")
+
+		result, err = engine.TestSOXControl("JE-02", date(2, 1), date(2, 28), controller)
+		require.NoError(t, err)
+		assert.Equal(t, ControlPassed, result.Status)
+		assert.Zero(t, result.Population)
+
+		stored, err := engine.GetStorage().GetSOXControl("JE-02")
+		require.NoError(t, err)
+		assert.Equal(t, ControlPassed, stored.LastStatus)
+		require.NotNil(t, stored.LastRunAt)
+	})
+
+	t.Run("Scheduled Testing", func(t *testing.T) {
+		journal(20000, time.Now(), controller)
+		results, err := engine.RunDueSOXControls(time.Now(), controller)
+		require.NoError(t, err)
+		assert.Empty(t, results, "the current period has not ended")
+
+		_, err = engine.SetSOXControlActive("JE-01", false, controller)
+		require.NoError(t, err)
+		control, err := engine.GetStorage().GetSOXControl("JE-02")
+		require.NoError(t, err)
+		due := control.NextRunAt
+
+		results, err = engine.RunDueSOXControls(due, soxControlUserID)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		result := results[0]
+		assert.Equal(t, "JE-02", result.ControlID)
+		assert.True(t, result.Scheduled)
+		assert.Equal(t, ControlPassed, result.Status)
+		assert.Equal(t, 1, result.Population)
+		assert.Equal(t, due.AddDate(0, -1, 0), result.PeriodStart)
+		assert.Equal(t, due.Add(-time.Nanosecond), result.PeriodEnd)
+		assert.Equal(t, soxControlUserID, result.RunBy)
+
+		control, err = engine.GetStorage().GetSOXControl("JE-02")
+		require.NoError(t, err)
+		assert.Equal(t, due.AddDate(0, 1, 0), control.NextRunAt)
+
+		results, err = engine.RunDueSOXControls(due, soxControlUserID)
+		require.NoError(t, err)
+		assert.Empty(t, results, "each period is tested once")
+
+		history, err := engine.GetControlTestResults("JE-02")
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.Equal(t, result.ID, history[0].ID)
+	})
+
+	t.Run("Evidence For Auditors", func(t *testing.T) {
+		ok, err := engine.VerifyControlTestResult(approvalTest.ID)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		doc, err := engine.StoreDocument("je-01-walkthrough.pdf", "application/pdf", []byte("%PDF-1.7 walkthrough"), "Follow-up of the unapproved accrual", controller)
+		require.NoError(t, err)
+		require.NoError(t, engine.LinkDocument(doc.ID, DocumentLink{TargetType: DocumentTargetControlTest, TargetID: approvalTest.ID}, controller))
+		stored, err := engine.GetStorage().GetControlTestResult(approvalTest.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{doc.ID}, stored.Attachments)
+		ok, err = engine.VerifyControlTestResult(approvalTest.ID)
+		require.NoError(t, err)
+		assert.True(t, ok, "attachments are not part of the evidence")
+
+		stored.Exceptions = nil
+		require.NoError(t, engine.GetStorage().SaveControlTestResult(stored))
+		ok, err = engine.VerifyControlTestResult(approvalTest.ID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Manual Controls", func(t *testing.T) {
+		_, err := engine.TestSOXControl("CASH-01", date(1, 1), date(1, 31), controller)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		err = engine.RecordManualControlTest(&ControlTestResult{
+			ControlID: "CASH-01", PeriodStart: date(1, 1), PeriodEnd: date(1, 31), Status: ControlFailed,
+		}, "treasurer")
+		assert.ErrorIs(t, err, ErrValidation, "failures need exceptions or notes")
+
+		result := &ControlTestResult{
+			ControlID: "CASH-01", PeriodStart: date(1, 1), PeriodEnd: date(1, 31), Status: ControlPassed,
+			Notes: "Reviewed and signed off the January reconciliation",
+		}
+		require.NoError(t, engine.RecordManualControlTest(result, "treasurer"))
+		assert.Equal(t, ControlTestManual, result.TestType)
+		assert.Equal(t, "treasurer", result.RunBy)
+
+		history, err := engine.GetControlTestResults("CASH-01")
+		require.NoError(t, err)
+		require.Len(t, history, 1)
+		ok, err := engine.VerifyControlTestResult(history[0].ID)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		err = engine.RecordManualControlTest(&ControlTestResult{ControlID: "JE-02", Status: ControlPassed}, controller)
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		err := engine.SaveSOXControl(&SOXControl{ID: "X-1", Objective: "Test", Frequency: ControlMonthly}, controller)
+		assert.ErrorIs(t, err, ErrValidation, "owner is required")
+		err = engine.SaveSOXControl(&SOXControl{ID: "X-1", Objective: "Test", Owner: controller, Frequency: "HOURLY"}, controller)
+		assert.ErrorIs(t, err, ErrValidation)
+		err = engine.SaveSOXControl(&SOXControl{ID: "X-1", Objective: "Test", Owner: controller, Frequency: ControlWeekly, TestType: ControlTestApprovalAboveThreshold}, controller)
+		assert.ErrorIs(t, err, ErrValidation, "approval tests need a threshold")
+		err = engine.SaveSOXControl(&SOXControl{ID: "X-1", Objective: "Test", Owner: controller, Frequency: ControlWeekly, TestType: "RANDOM"}, controller)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		_, err = engine.TestSOXControl("JE-02", date(2, 1), date(1, 1), controller)
+		assert.ErrorIs(t, err, ErrValidation)
+		_, err = engine.TestSOXControl("MISSING", date(1, 1), date(1, 31), controller)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,290 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// StatementPoint is one side of a statement diff: the books on ValidAsOf as they
+// were known at KnowledgeAsOf. A zero KnowledgeAsOf means as known now.
+type StatementPoint struct {
+	ValidAsOf     time.Time `json:"valid_as_of"`
+	KnowledgeAsOf time.Time `json:"knowledge_as_of"`
+}
+
+// DiffCauseReason says why a transaction moved a statement line between two points
+type DiffCauseReason string
+
+const (
+	DiffCausePosted       DiffCauseReason = "POSTED"        // valid between the two dates
+	DiffCauseRecordedLate DiffCauseReason = "RECORDED_LATE" // backdated, recorded after the first point's knowledge time
+	DiffCauseReversed     DiffCauseReason = "REVERSED"      // reversed by the second point
+	DiffCauseReinstated   DiffCauseReason = "REINSTATED"    // reversed at the first point, not yet at the second
+	DiffCauseExcluded     DiffCauseReason = "EXCLUDED"      // not valid or not yet known at the second point
+)
+
+// StatementDiffCause is a transaction's contribution to a line's change
+type StatementDiffCause struct {
+	TransactionID string          `json:"transaction_id"`
+	Description   string          `json:"description"`
+	SourceRef     string          `json:"source_ref,omitempty"`
+	ValidTime     time.Time       `json:"valid_time"`
+	RecordedAt    time.Time       `json:"recorded_at"`
+	Reason        DiffCauseReason `json:"reason"`
+	Change        *Amount         `json:"change"` // translated at the second point's valid date
+}
+
+// StatementLineDiff is an account whose statement line differs between two points
+type StatementLineDiff struct {
+	AccountID   string                `json:"account_id"`
+	AccountCode string                `json:"account_code"`
+	AccountName string                `json:"account_name"`
+	AccountType AccountType           `json:"account_type"`
+	Before      *Amount               `json:"before"`
+	After       *Amount               `json:"after"`
+	Change      *Amount               `json:"change"`
+	Translation *Amount               `json:"translation,omitempty"` // change not explained by transactions, from exchange rates moving
+	Causes      []*StatementDiffCause `json:"causes,omitempty"`
+}
+
+// StatementTotalDiff compares a statement total between two points
+type StatementTotalDiff struct {
+	Name   string  `json:"name"`
+	Before *Amount `json:"before"`
+	After  *Amount `json:"after"`
+	Change *Amount `json:"change"`
+}
+
+// StatementDiff is the structured difference between the balance sheet and P&L at
+// two points. P&L lines compare cumulative income and expense, so between two dates
+// their change is the activity in between.
+type StatementDiff struct {
+	A             StatementPoint        `json:"a"`
+	B             StatementPoint        `json:"b"`
+	Currency      string                `json:"currency"`
+	BalanceSheet  []*StatementLineDiff  `json:"balance_sheet"`
+	ProfitAndLoss []*StatementLineDiff  `json:"profit_and_loss"`
+	Totals        []*StatementTotalDiff `json:"totals"`
+	GeneratedAt   time.Time             `json:"generated_at"`
+}
+
+// DiffStatements compares the balance sheet and P&L at point a with point b and
+// attributes each line's change to the transactions that caused it
+func (rs *ReportingService) DiffStatements(a, b StatementPoint, currency string) (_ *StatementDiff, err error) {
+	defer rs.metrics.timeReport("statement_diff")(&err)
+	defer rs.tracer.report("statement_diff")(&err)
+
+	now := time.Now()
+	for _, point := range []*StatementPoint{&a, &b} {
+		if point.KnowledgeAsOf.IsZero() {
+			point.KnowledgeAsOf = now
+		}
+	}
+
+	postingsA, err := rs.queryAPI.GetPostingsAsOf(a.ValidAsOf, a.KnowledgeAsOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get postings for first point: %w", err)
+	}
+	postingsB, err := rs.queryAPI.GetPostingsAsOf(b.ValidAsOf, b.KnowledgeAsOf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get postings for second point: %w", err)
+	}
+
+	accounts, err := rs.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].Code < accounts[j].Code
+	})
+	byID := make(map[string]*Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+
+	// Balances in each account's own currency, totalled as balancesAsOf does
+	balances := func(postings []*KnownPosting) map[string]int64 {
+		totals := make(map[string]int64)
+		for _, posting := range postings {
+			if posting.ReversedAt != nil {
+				continue
+			}
+			for accountID, value := range rs.postingEffect(posting, byID) {
+				totals[accountID] += value
+			}
+		}
+		return totals
+	}
+	before, after := balances(postingsA), balances(postingsB)
+
+	causes, err := rs.diffCauses(a, postingsA, postingsB, byID, currency, b.ValidAsOf)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &StatementDiff{
+		A:           a,
+		B:           b,
+		Currency:    currency,
+		GeneratedAt: now,
+	}
+	totals := make(map[AccountType]*StatementTotalDiff)
+	for _, accountType := range []AccountType{Asset, Liability, Equity, Income, Expense} {
+		totals[accountType] = &StatementTotalDiff{
+			Name:   fmt.Sprintf("Total %s", accountType),
+			Before: &Amount{Currency: Currency(currency)},
+			After:  &Amount{Currency: Currency(currency)},
+			Change: &Amount{Currency: Currency(currency)},
+		}
+	}
+
+	for _, account := range accounts {
+		beforeAmount, err := rs.translateAmount(&Amount{Value: before[account.ID], Currency: account.Currency}, currency, a.ValidAsOf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate balance for account %s: %w", account.ID, err)
+		}
+		afterAmount, err := rs.translateAmount(&Amount{Value: after[account.ID], Currency: account.Currency}, currency, b.ValidAsOf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to translate balance for account %s: %w", account.ID, err)
+		}
+		if total, ok := totals[account.Type]; ok {
+			total.Before.Value += beforeAmount.Value
+			total.After.Value += afterAmount.Value
+			total.Change.Value += afterAmount.Value - beforeAmount.Value
+		}
+
+		accountCauses := causes[account.ID]
+		change := afterAmount.Value - beforeAmount.Value
+		if change == 0 && len(accountCauses) == 0 {
+			continue
+		}
+
+		line := &StatementLineDiff{
+			AccountID:   account.ID,
+			AccountCode: account.Code,
+			AccountName: account.Name,
+			AccountType: account.Type,
+			Before:      beforeAmount,
+			After:       afterAmount,
+			Change:      &Amount{Value: change, Currency: Currency(currency)},
+			Causes:      accountCauses,
+		}
+		explained := int64(0)
+		for _, cause := range accountCauses {
+			explained += cause.Change.Value
+		}
+		if residual := change - explained; residual != 0 {
+			line.Translation = &Amount{Value: residual, Currency: Currency(currency)}
+		}
+
+		switch account.Type {
+		case Income, Expense:
+			diff.ProfitAndLoss = append(diff.ProfitAndLoss, line)
+		default:
+			diff.BalanceSheet = append(diff.BalanceSheet, line)
+		}
+	}
+
+	for _, accountType := range []AccountType{Asset, Liability, Equity, Income, Expense} {
+		diff.Totals = append(diff.Totals, totals[accountType])
+	}
+	netIncome := func(pick func(*StatementTotalDiff) *Amount) *Amount {
+		return &Amount{Value: pick(totals[Income]).Value - pick(totals[Expense]).Value, Currency: Currency(currency)}
+	}
+	diff.Totals = append(diff.Totals, &StatementTotalDiff{
+		Name:   "Net Income",
+		Before: netIncome(func(t *StatementTotalDiff) *Amount { return t.Before }),
+		After:  netIncome(func(t *StatementTotalDiff) *Amount { return t.After }),
+		Change: netIncome(func(t *StatementTotalDiff) *Amount { return t.Change }),
+	})
+	return diff, nil
+}
+
+// postingEffect is a posting's signed effect on each account's balance
+func (rs *ReportingService) postingEffect(posting *KnownPosting, accounts map[string]*Account) map[string]int64 {
+	effect := make(map[string]int64)
+	for _, entry := range posting.Entries {
+		account, ok := accounts[entry.AccountID]
+		if !ok {
+			continue
+		}
+		effect[entry.AccountID] += entry.Amount.Value * int64(rs.queryAPI.postingEngine.getBalanceMultiplier(account.Type, entry.Type))
+	}
+	return effect
+}
+
+// diffCauses finds the postings that count at one point but not the other and
+// attributes their effect to each account, keyed by account ID
+func (rs *ReportingService) diffCauses(a StatementPoint, postingsA, postingsB []*KnownPosting, accounts map[string]*Account, currency string, rateDate time.Time) (map[string][]*StatementDiffCause, error) {
+	indexA := make(map[string]*KnownPosting, len(postingsA))
+	for _, posting := range postingsA {
+		indexA[posting.TransactionID] = posting
+	}
+	indexB := make(map[string]*KnownPosting, len(postingsB))
+	for _, posting := range postingsB {
+		indexB[posting.TransactionID] = posting
+	}
+
+	type change struct {
+		posting *KnownPosting
+		reason  DiffCauseReason
+		sign    int64
+	}
+	var changes []change
+	for _, posting := range postingsB {
+		if posting.ReversedAt != nil {
+			continue
+		}
+		earlier, known := indexA[posting.TransactionID]
+		switch {
+		case !known && posting.ValidTime.After(a.ValidAsOf):
+			changes = append(changes, change{posting, DiffCausePosted, 1})
+		case !known:
+			changes = append(changes, change{posting, DiffCauseRecordedLate, 1})
+		case earlier.ReversedAt != nil:
+			changes = append(changes, change{posting, DiffCauseReinstated, 1})
+		}
+	}
+	for _, posting := range postingsA {
+		if posting.ReversedAt != nil {
+			continue
+		}
+		later, known := indexB[posting.TransactionID]
+		switch {
+		case !known:
+			changes = append(changes, change{posting, DiffCauseExcluded, -1})
+		case later.ReversedAt != nil:
+			changes = append(changes, change{posting, DiffCauseReversed, -1})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if !changes[i].posting.ValidTime.Equal(changes[j].posting.ValidTime) {
+			return changes[i].posting.ValidTime.Before(changes[j].posting.ValidTime)
+		}
+		return changes[i].posting.RecordedAt.Before(changes[j].posting.RecordedAt)
+	})
+
+	causes := make(map[string][]*StatementDiffCause)
+	for _, c := range changes {
+		for accountID, value := range rs.postingEffect(c.posting, accounts) {
+			if value == 0 {
+				continue
+			}
+			amount, err := rs.translateAmount(&Amount{Value: value * c.sign, Currency: accounts[accountID].Currency}, currency, rateDate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to translate change from transaction %s: %w", c.posting.TransactionID, err)
+			}
+			causes[accountID] = append(causes[accountID], &StatementDiffCause{
+				TransactionID: c.posting.TransactionID,
+				Description:   c.posting.Description,
+				SourceRef:     c.posting.SourceRef,
+				ValidTime:     c.posting.ValidTime,
+				RecordedAt:    c.posting.RecordedAt,
+				Reason:        c.reason,
+				Change:        amount,
+			})
+		}
+	}
+	return causes, nil
+}
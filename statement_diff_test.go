@@ -0,0 +1,124 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementDiff(t *testing.T) {
+	// Setup
+	dbFile := "test_statement_diff.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	date := func(m time.Month, d int) time.Time { return time.Date(2025, m, d, 0, 0, 0, 0, time.UTC) }
+	sell := func(description string, value int64, when time.Time) *Transaction {
+		txn := &Transaction{
+			Description: description,
+			ValidTime:   when,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	line := func(lines []*StatementLineDiff, accountID string) *StatementLineDiff {
+		for _, line := range lines {
+			if line.AccountID == accountID {
+				return line
+			}
+		}
+		return nil
+	}
+	total := func(diff *StatementDiff, name string) *StatementTotalDiff {
+		for _, total := range diff.Totals {
+			if total.Name == name {
+				return total
+			}
+		}
+		return nil
+	}
+
+	sell("March sales", 10000, date(3, 10))
+	closeKnown := time.Now()
+	late := sell("Late March invoice", 500, date(3, 31))
+	mistake := sell("Duplicate invoice", 2000, date(3, 20))
+	beforeReversal := time.Now()
+	_, err = engine.ReverseTransaction(mistake.ID, "Duplicate", userID)
+	require.NoError(t, err)
+	april := sell("April sales", 7000, date(4, 2))
+
+	t.Run("Between Dates", func(t *testing.T) {
+		diff, err := engine.DiffStatements(date(3, 31), date(4, 30), "USD")
+		require.NoError(t, err)
+
+		cash := line(diff.BalanceSheet, "cash")
+		require.NotNil(t, cash)
+		assert.Equal(t, int64(10500), cash.Before.Value)
+		assert.Equal(t, int64(17500), cash.After.Value)
+		assert.Equal(t, int64(7000), cash.Change.Value)
+		require.Len(t, cash.Causes, 1)
+		assert.Equal(t, april.ID, cash.Causes[0].TransactionID)
+		assert.Equal(t, DiffCausePosted, cash.Causes[0].Reason)
+		assert.Nil(t, cash.Translation)
+
+		revenue := line(diff.ProfitAndLoss, "revenue")
+		require.NotNil(t, revenue)
+		assert.Equal(t, int64(7000), revenue.Change.Value)
+		assert.Nil(t, line(diff.BalanceSheet, "revenue"), "income belongs on the P&L")
+		assert.Nil(t, line(diff.BalanceSheet, "accounts_payable"), "unchanged lines are left out")
+
+		assert.Equal(t, int64(7000), total(diff, "Net Income").Change.Value)
+		assert.Equal(t, int64(7000), total(diff, "Total ASSET").Change.Value)
+	})
+
+	t.Run("Between Knowledge Times", func(t *testing.T) {
+		diff, err := engine.DiffStatementsAsKnown(date(3, 31), closeKnown, time.Now(), "USD")
+		require.NoError(t, err)
+
+		cash := line(diff.BalanceSheet, "cash")
+		require.NotNil(t, cash)
+		assert.Equal(t, int64(10000), cash.Before.Value)
+		assert.Equal(t, int64(10500), cash.After.Value)
+		require.Len(t, cash.Causes, 1, "the reversed duplicate nets out")
+		assert.Equal(t, late.ID, cash.Causes[0].TransactionID)
+		assert.Equal(t, DiffCauseRecordedLate, cash.Causes[0].Reason)
+		assert.Equal(t, int64(500), cash.Causes[0].Change.Value)
+	})
+
+	t.Run("Reversal", func(t *testing.T) {
+		diff, err := engine.DiffStatementPoints(
+			StatementPoint{ValidAsOf: date(3, 31), KnowledgeAsOf: beforeReversal},
+			StatementPoint{ValidAsOf: date(3, 31)},
+			"USD",
+		)
+		require.NoError(t, err)
+		cash := line(diff.BalanceSheet, "cash")
+		require.NotNil(t, cash)
+		assert.Equal(t, int64(-2000), cash.Change.Value)
+		require.Len(t, cash.Causes, 1)
+		assert.Equal(t, mistake.ID, cash.Causes[0].TransactionID)
+		assert.Equal(t, DiffCauseReversed, cash.Causes[0].Reason)
+
+		reversed, err := engine.DiffStatements(date(4, 30), date(3, 31), "USD")
+		require.NoError(t, err)
+		cash = line(reversed.BalanceSheet, "cash")
+		require.NotNil(t, cash)
+		assert.Equal(t, int64(-7000), cash.Change.Value)
+		require.Len(t, cash.Causes, 1)
+		assert.Equal(t, DiffCauseExcluded, cash.Causes[0].Reason)
+	})
+}
@@ -0,0 +1,341 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// StatementBasis chooses what a template's lines measure
+type StatementBasis string
+
+const (
+	StatementBasisBalance  StatementBasis = "BALANCE"  // balances as of the end date, as on a balance sheet
+	StatementBasisActivity StatementBasis = "ACTIVITY" // movements between the dates, as on a P&L
+)
+
+// StatementComparison chooses the comparative column of a templated statement
+type StatementComparison string
+
+const (
+	CompareNone        StatementComparison = ""
+	ComparePriorPeriod StatementComparison = "PRIOR_PERIOD" // the period of the same length, or number of whole months, ending the day before
+	ComparePriorYear   StatementComparison = "PRIOR_YEAR"   // the same dates a year earlier
+)
+
+// StatementTemplateLine is one line of a statement layout. A line either selects
+// accounts, sums the lines named in its formula, or is a bare heading.
+//
+// Account selectors combine: an account must match every selector that is set, and
+// any one value within a list. Code ranges compare codes as text, so codes of equal
+// length sort as numbers do.
+type StatementTemplateLine struct {
+	Key          string        `json:"key"` // referenced by later formulas
+	Label        string        `json:"label"`
+	Level        int           `json:"level,omitempty"` // indentation
+	AccountTypes []AccountType `json:"account_types,omitempty"`
+	AccountIDs   []string      `json:"account_ids,omitempty"`
+	CodeFrom     string        `json:"code_from,omitempty"` // inclusive
+	CodeTo       string        `json:"code_to,omitempty"`   // inclusive
+	Tags         []Dimension   `json:"tags,omitempty"`      // account dimensions the account must carry
+	Formula      []string      `json:"formula,omitempty"`   // earlier line keys to add; a leading "-" subtracts
+	Negate       bool          `json:"negate,omitempty"`    // flip the sign, e.g. to show contra accounts as deductions
+	ShowAccounts bool          `json:"show_accounts,omitempty"`
+}
+
+// StatementTemplate is a user-defined financial statement layout
+type StatementTemplate struct {
+	ID        string                  `json:"id"`
+	Name      string                  `json:"name"`
+	Basis     StatementBasis          `json:"basis"`
+	Lines     []StatementTemplateLine `json:"lines"`
+	CreatedBy string                  `json:"created_by"`
+	CreatedAt time.Time               `json:"created_at"`
+	UpdatedAt time.Time               `json:"updated_at"`
+}
+
+// selectsAccounts reports whether a line has any account selector
+func (line *StatementTemplateLine) selectsAccounts() bool {
+	return len(line.AccountTypes) > 0 || len(line.AccountIDs) > 0 || line.CodeFrom != "" || line.CodeTo != "" || len(line.Tags) > 0
+}
+
+// matches reports whether an account falls on this line
+func (line *StatementTemplateLine) matches(account *Account) bool {
+	if !line.selectsAccounts() {
+		return false
+	}
+	if len(line.AccountTypes) > 0 && !slices.Contains(line.AccountTypes, account.Type) {
+		return false
+	}
+	if len(line.AccountIDs) > 0 && !slices.Contains(line.AccountIDs, account.ID) {
+		return false
+	}
+	if line.CodeFrom != "" && account.Code < line.CodeFrom {
+		return false
+	}
+	if line.CodeTo != "" && account.Code > line.CodeTo {
+		return false
+	}
+	for _, tag := range line.Tags {
+		if !slices.Contains(account.Dimensions, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks a template's lines can be evaluated top to bottom
+func (t *StatementTemplate) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("template name is required")
+	}
+	if t.Basis != StatementBasisBalance && t.Basis != StatementBasisActivity {
+		return fmt.Errorf("unknown statement basis %s", t.Basis)
+	}
+	if len(t.Lines) == 0 {
+		return fmt.Errorf("template %s has no lines", t.Name)
+	}
+	seen := make(map[string]bool, len(t.Lines))
+	for _, line := range t.Lines {
+		if line.Key == "" || line.Label == "" {
+			return fmt.Errorf("template %s: every line needs a key and a label", t.Name)
+		}
+		if seen[line.Key] {
+			return fmt.Errorf("template %s: duplicate line key %s", t.Name, line.Key)
+		}
+		if line.selectsAccounts() && len(line.Formula) > 0 {
+			return fmt.Errorf("template %s: line %s cannot both select accounts and have a formula", t.Name, line.Key)
+		}
+		if line.CodeFrom != "" && line.CodeTo != "" && line.CodeFrom > line.CodeTo {
+			return fmt.Errorf("template %s: line %s code range %s-%s is reversed", t.Name, line.Key, line.CodeFrom, line.CodeTo)
+		}
+		for _, term := range line.Formula {
+			if !seen[strings.TrimLeft(term, "+-")] {
+				return fmt.Errorf("template %s: line %s formula refers to %s, which is not an earlier line", t.Name, line.Key, term)
+			}
+		}
+		seen[line.Key] = true
+	}
+	return nil
+}
+
+// StatementTemplateService keeps statement layouts and renders statements from them
+type StatementTemplateService struct {
+	storage    *Storage
+	eventStore *EventStore
+	reporting  *ReportingService
+}
+
+// NewStatementTemplateService creates a new statement template service
+func NewStatementTemplateService(storage *Storage, eventStore *EventStore, reporting *ReportingService) *StatementTemplateService {
+	return &StatementTemplateService{
+		storage:    storage,
+		eventStore: eventStore,
+		reporting:  reporting,
+	}
+}
+
+// SaveTemplate creates a template, or replaces the layout of an existing one
+func (sts *StatementTemplateService) SaveTemplate(template *StatementTemplate, userID string) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if existing, err := sts.storage.GetStatementTemplate(template.ID); template.ID != "" && err == nil {
+		template.CreatedBy = existing.CreatedBy
+		template.CreatedAt = existing.CreatedAt
+	} else {
+		if err := sts.storage.assignID(&template.ID, "statement template", BucketStatementTemplates); err != nil {
+			return err
+		}
+		template.CreatedBy = userID
+		template.CreatedAt = now
+	}
+	template.UpdatedAt = now
+
+	_, err := sts.eventStore.CreateEvent(EventSaveStatementTemplate, template, now, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create statement template event: %w", err)
+	}
+	if err := sts.storage.SaveStatementTemplate(template); err != nil {
+		return fmt.Errorf("failed to save statement template: %w", err)
+	}
+	return nil
+}
+
+// GenerateStatement renders a template for a period in the reporting currency. On
+// a balance basis only toDate matters, except to place a prior-period comparative.
+func (sts *StatementTemplateService) GenerateStatement(templateID string, fromDate, toDate time.Time, currency string, comparison StatementComparison) (*FinancialStatement, error) {
+	template, err := sts.storage.GetStatementTemplate(templateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement template: %w", err)
+	}
+	accounts, err := sts.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	slices.SortFunc(accounts, func(a, b *Account) int { return strings.Compare(a.Code, b.Code) })
+
+	var selected []*Account
+	for _, account := range accounts {
+		if slices.ContainsFunc(template.Lines, func(line StatementTemplateLine) bool { return line.matches(account) }) {
+			selected = append(selected, account)
+		}
+	}
+
+	statement := &FinancialStatement{
+		Name:       template.Name,
+		TemplateID: template.ID,
+		AsOfDate:   toDate,
+		Currency:   currency,
+	}
+	if template.Basis == StatementBasisActivity {
+		statement.FromDate = &fromDate
+	}
+	current, err := sts.accountAmounts(template.Basis, selected, fromDate, toDate, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var prior map[string]int64
+	if comparison != CompareNone {
+		priorFrom, priorTo, err := comparativePeriod(comparison, fromDate, toDate)
+		if err != nil {
+			return nil, err
+		}
+		statement.ComparativeAsOf = &priorTo
+		if template.Basis == StatementBasisActivity {
+			statement.ComparativeFrom = &priorFrom
+		}
+		if prior, err = sts.accountAmounts(template.Basis, selected, priorFrom, priorTo, currency); err != nil {
+			return nil, err
+		}
+	}
+
+	statement.LineItems = buildTemplateLines(template, selected, current, prior, currency)
+	return statement, nil
+}
+
+// comparativePeriod works out the dates of the comparative column
+func comparativePeriod(comparison StatementComparison, fromDate, toDate time.Time) (time.Time, time.Time, error) {
+	switch comparison {
+	case ComparePriorYear:
+		return fromDate.AddDate(-1, 0, 0), toDate.AddDate(-1, 0, 0), nil
+	case ComparePriorPeriod:
+		priorTo := fromDate.AddDate(0, 0, -1)
+		if fromDate.Day() == 1 && toDate.AddDate(0, 0, 1).Day() == 1 {
+			// Whole calendar months compare with the same number of months before
+			months := (toDate.Year()-fromDate.Year())*12 + int(toDate.Month()-fromDate.Month()) + 1
+			return fromDate.AddDate(0, -months, 0), priorTo, nil
+		}
+		return priorTo.Add(-toDate.Sub(fromDate)), priorTo, nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("unknown statement comparison %s", comparison)
+}
+
+// accountAmounts measures each account on the template's basis, in minor units of
+// the reporting currency on the account's normal side
+func (sts *StatementTemplateService) accountAmounts(basis StatementBasis, accounts []*Account, fromDate, toDate time.Time, currency string) (map[string]int64, error) {
+	amounts := make([]int64, len(accounts))
+	err := forEachParallel(len(accounts), sts.reporting.queryAPI.workers(), func(i int) error {
+		account := accounts[i]
+		var amount *Amount
+		if basis == StatementBasisBalance {
+			balance, err := sts.reporting.queryAPI.GetAccountBalance(account.ID, toDate)
+			if err != nil {
+				return fmt.Errorf("failed to get balance for account %s: %w", account.ID, err)
+			}
+			amount = balance.Balance
+		} else {
+			movement, err := sts.reporting.calculatePeriodBalance(account.ID, fromDate, toDate)
+			if err != nil {
+				return fmt.Errorf("failed to get movement for account %s: %w", account.ID, err)
+			}
+			amount = movement
+		}
+		translated, err := sts.reporting.translateAmount(amount, currency, toDate)
+		if err != nil {
+			return fmt.Errorf("failed to translate balance for account %s: %w", account.ID, err)
+		}
+		amounts[i] = translated.Value
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byAccount := make(map[string]int64, len(accounts))
+	for i, account := range accounts {
+		byAccount[account.ID] = amounts[i]
+	}
+	return byAccount, nil
+}
+
+// buildTemplateLines evaluates the template top to bottom. prior is nil when there
+// is no comparative column.
+func buildTemplateLines(template *StatementTemplate, accounts []*Account, current, prior map[string]int64, currency string) []*FinancialLineItem {
+	amount := func(value int64) *Amount { return &Amount{Value: value, Currency: Currency(currency)} }
+	comparative := func(value int64) *Amount {
+		if prior == nil {
+			return nil
+		}
+		return amount(value)
+	}
+
+	totals := make(map[string]int64, len(template.Lines))
+	priorTotals := make(map[string]int64, len(template.Lines))
+	items := make([]*FinancialLineItem, 0, len(template.Lines))
+	for _, line := range template.Lines {
+		sign := int64(1)
+		if line.Negate {
+			sign = -1
+		}
+		item := &FinancialLineItem{AccountName: line.Label, Level: line.Level}
+
+		switch {
+		case line.selectsAccounts():
+			var total, priorTotal int64
+			for _, account := range accounts {
+				if !line.matches(account) {
+					continue
+				}
+				total += sign * current[account.ID]
+				priorTotal += sign * prior[account.ID]
+				if line.ShowAccounts {
+					item.Children = append(item.Children, &FinancialLineItem{
+						AccountID:   account.ID,
+						AccountName: account.Name,
+						AccountType: account.Type,
+						Amount:      amount(sign * current[account.ID]),
+						Comparative: comparative(sign * prior[account.ID]),
+						Level:       line.Level + 1,
+					})
+				}
+			}
+			totals[line.Key], priorTotals[line.Key] = total, priorTotal
+			item.Amount, item.Comparative = amount(total), comparative(priorTotal)
+			item.IsSubtotal = line.ShowAccounts
+		case len(line.Formula) > 0:
+			var total, priorTotal int64
+			for _, term := range line.Formula {
+				termSign := int64(1)
+				if strings.HasPrefix(term, "-") {
+					termSign = -1
+				}
+				key := strings.TrimLeft(term, "+-")
+				total += termSign * totals[key]
+				priorTotal += termSign * priorTotals[key]
+			}
+			total, priorTotal = sign*total, sign*priorTotal
+			totals[line.Key], priorTotals[line.Key] = total, priorTotal
+			item.Amount, item.Comparative = amount(total), comparative(priorTotal)
+			item.IsSubtotal = true
+		default:
+			item.IsSubtotal = true // heading
+		}
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,153 @@
+package accounting
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementTemplates(t *testing.T) {
+	dbFile := "test_statement_templates.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{
+		ID:         "advertising",
+		Code:       "5100",
+		Name:       "Advertising",
+		Type:       Expense,
+		Dimensions: []Dimension{{Key: "department", Value: "marketing"}},
+	}, userID))
+
+	post := func(date time.Time, debit, credit string, value int64) {
+		txn := &Transaction{
+			Description: "Activity",
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	may := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)
+	post(may, "cash", "revenue", 80000)
+	post(may, "expenses", "cash", 20000)
+	post(june, "cash", "revenue", 100000)
+	post(june, "expenses", "cash", 30000)
+	post(june, "advertising", "cash", 15000)
+
+	t.Run("templates are validated", func(t *testing.T) {
+		assert.Error(t, engine.SaveStatementTemplate(&StatementTemplate{Name: "Empty", Basis: StatementBasisActivity}, userID))
+		assert.Error(t, engine.SaveStatementTemplate(&StatementTemplate{Name: "Bad basis", Basis: "CASH", Lines: []StatementTemplateLine{{Key: "a", Label: "A"}}}, userID))
+		assert.Error(t, engine.SaveStatementTemplate(&StatementTemplate{
+			Name:  "Forward reference",
+			Basis: StatementBasisActivity,
+			Lines: []StatementTemplateLine{
+				{Key: "total", Label: "Total", Formula: []string{"revenue"}},
+				{Key: "revenue", Label: "Revenue", AccountTypes: []AccountType{Income}},
+			},
+		}, userID))
+	})
+
+	income := &StatementTemplate{
+		Name:  "Management P&L",
+		Basis: StatementBasisActivity,
+		Lines: []StatementTemplateLine{
+			{Key: "revenue", Label: "Revenue", AccountTypes: []AccountType{Income}},
+			{Key: "opex", Label: "Operating expenses", Level: 1, CodeFrom: "5000", CodeTo: "5099"},
+			{Key: "marketing", Label: "Marketing", Level: 1, AccountTypes: []AccountType{Expense}, Tags: []Dimension{{Key: "department", Value: "marketing"}}, ShowAccounts: true},
+			{Key: "expenses", Label: "Total expenses", Formula: []string{"opex", "marketing"}},
+			{Key: "net", Label: "Net income", Formula: []string{"revenue", "-expenses"}},
+		},
+	}
+	require.NoError(t, engine.SaveStatementTemplate(income, userID))
+	require.NotEmpty(t, income.ID)
+
+	statement, err := engine.GenerateTemplateStatement(income.ID,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC), "USD", ComparePriorPeriod)
+	require.NoError(t, err)
+	require.Len(t, statement.LineItems, 5)
+
+	lines := make(map[string]*FinancialLineItem)
+	for _, item := range statement.LineItems {
+		lines[item.AccountName] = item
+	}
+	assert.Equal(t, int64(100000), lines["Revenue"].Amount.Value)
+	assert.Equal(t, int64(80000), lines["Revenue"].Comparative.Value)
+	assert.Equal(t, int64(30000), lines["Operating expenses"].Amount.Value)
+	assert.Equal(t, int64(15000), lines["Marketing"].Amount.Value)
+	require.Len(t, lines["Marketing"].Children, 1)
+	assert.Equal(t, "advertising", lines["Marketing"].Children[0].AccountID)
+	assert.Equal(t, int64(45000), lines["Total expenses"].Amount.Value)
+	assert.Equal(t, int64(55000), lines["Net income"].Amount.Value)
+	assert.Equal(t, int64(60000), lines["Net income"].Comparative.Value)
+	require.NotNil(t, statement.ComparativeFrom)
+	assert.Equal(t, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), *statement.ComparativeFrom)
+	assert.Equal(t, time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC), *statement.ComparativeAsOf)
+
+	t.Run("text, CSV and JSON", func(t *testing.T) {
+		text, err := engine.FormatFinancialStatementAs(statement, ExportFormatText)
+		require.NoError(t, err)
+		assert.Contains(t, text, "Management P&L")
+		assert.Contains(t, text, "Comparative: 2026-05-01 to 2026-05-31")
+		assert.Contains(t, text, "550.00")
+
+		csvText, err := engine.FormatFinancialStatementAs(statement, ExportFormatCSV)
+		require.NoError(t, err)
+		rows := strings.Split(strings.TrimSpace(csvText), "\n")
+		assert.Equal(t, "level,line,account_id,amount,comparative", rows[0])
+		assert.Contains(t, rows, "0,Net income,,550.00,600.00")
+		assert.Contains(t, rows, "2,Advertising,advertising,150.00,0.00")
+
+		jsonText, err := engine.FormatFinancialStatementAs(statement, ExportFormatJSON)
+		require.NoError(t, err)
+		var decoded FinancialStatement
+		require.NoError(t, json.Unmarshal([]byte(jsonText), &decoded))
+		assert.Equal(t, income.ID, decoded.TemplateID)
+		assert.Len(t, decoded.LineItems, 5)
+
+		_, err = engine.FormatFinancialStatementAs(statement, ExportFormatJSONLines)
+		assert.Error(t, err)
+	})
+
+	t.Run("balance basis with prior year", func(t *testing.T) {
+		position := &StatementTemplate{
+			Name:  "Cash position",
+			Basis: StatementBasisBalance,
+			Lines: []StatementTemplateLine{
+				{Key: "cash", Label: "Cash and receivables", CodeFrom: "1000", CodeTo: "1999"},
+				{Key: "payables", Label: "Payables", AccountIDs: []string{"accounts_payable"}, Negate: true},
+				{Key: "net", Label: "Net position", Formula: []string{"cash", "payables"}},
+			},
+		}
+		require.NoError(t, engine.SaveStatementTemplate(position, userID))
+
+		bs, err := engine.GenerateTemplateStatement(position.ID, time.Time{}, time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC), "USD", ComparePriorYear)
+		require.NoError(t, err)
+		assert.Nil(t, bs.FromDate)
+		assert.Equal(t, int64(115000), bs.LineItems[0].Amount.Value)
+		assert.Equal(t, int64(0), bs.LineItems[0].Comparative.Value)
+		assert.Equal(t, int64(115000), bs.LineItems[2].Amount.Value)
+
+		position.Name = "Net cash position"
+		createdAt := position.CreatedAt
+		require.NoError(t, engine.SaveStatementTemplate(position, userID))
+		stored, err := engine.GetStorage().GetStatementTemplate(position.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Net cash position", stored.Name)
+		assert.True(t, createdAt.Equal(stored.CreatedAt), "replacing a layout keeps its creation time")
+	})
+}
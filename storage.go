@@ -5,11 +5,18 @@ package accounting
 //   (70% smaller, 4x faster than JSON)
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	pb "accounting/proto/accounting"
 	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -33,40 +40,387 @@ var (
 	BucketBudgetApprovals   = []byte("budget_approvals")
 	BucketBudgetAllocations = []byte("budget_allocations")
 	BucketBudgetTracking    = []byte("budget_tracking")
+	BucketBudgetForecasts   = []byte("budget_forecasts")
+	BucketDepartments       = []byte("departments")
 	// Compliance buckets
 	BucketComplianceRules      = []byte("compliance_rules")
 	BucketTaxRules             = []byte("tax_rules")
 	BucketComplianceViolations = []byte("compliance_violations")
 	BucketTaxReturns           = []byte("tax_returns")
 	// AML buckets
-	BucketAMLRules     = []byte("aml_rules")
-	BucketAMLAlerts    = []byte("aml_alerts")
-	BucketAMLCustomers = []byte("aml_customers")
+	BucketAMLRules      = []byte("aml_rules")
+	BucketAMLAlerts     = []byte("aml_alerts")
+	BucketAMLCustomers  = []byte("aml_customers")
+	BucketAMLAggregates = []byte("aml_aggregates")
+	// Period close buckets
+	BucketVarianceCommentary = []byte("variance_commentary")
+	BucketCloseSignOffs      = []byte("close_sign_offs")
+	BucketClosingBinders     = []byte("closing_binders")
+	// Currency buckets
+	BucketExchangeRates = []byte("exchange_rates")
+	// Disclosure buckets
+	BucketDisclosures      = []byte("disclosures")
+	BucketDisclosureValues = []byte("disclosure_values")
+
+	// Audit confirmation buckets
+	BucketConfirmationRequests = []byte("confirmation_requests")
+
+	// Materiality buckets
+	BucketMaterialityAssessments = []byte("materiality_assessments")
+
+	// Hyperinflation buckets
+	BucketPriceIndices = []byte("price_indices")
+
+	// Accounts payable buckets
+	BucketVendors     = []byte("vendors")
+	BucketVendorBills = []byte("vendor_bills")
+	BucketPaymentRuns = []byte("payment_runs")
+
+	// On-chain reconciliation buckets
+	BucketChainReconciliations = []byte("chain_reconciliations")
+
+	// Installment plan buckets
+	BucketInstallmentPlans = []byte("installment_plans")
+
+	// Recurring transaction buckets
+	BucketRecurringTemplates = []byte("recurring_templates")
+	BucketRecurringRuns      = []byte("recurring_runs")
+
+	// Refund and chargeback buckets
+	BucketSaleReversals = []byte("sale_reversals")
+
+	// Stored value
+	BucketStoredValuePrograms = []byte("stored_value_programs")
+	BucketGiftCards           = []byte("gift_cards")
+
+	// Journal approvals
+	BucketJournalApprovals = []byte("journal_approvals")
+
+	// Loyalty
+	BucketLoyaltyPrograms       = []byte("loyalty_programs")
+	BucketLoyaltyMemberAccounts = []byte("loyalty_member_accounts")
+
+	// Client money buckets
+	BucketClientMoneyAccounts = []byte("client_money_accounts")
+	BucketClientMoneyRecons   = []byte("client_money_reconciliations")
+
+	// Balance snapshot buckets
+	BucketBalanceSnapshots  = []byte("balance_snapshots")
+	BucketBalanceMovements  = []byte("balance_movements")
+	BucketPostingsByTime    = []byte("postings_by_time")
+	BucketPostingsByAccount = []byte("postings_by_account")
+
+	// PSP settlement buckets
+	BucketPSPProviders         = []byte("psp_providers")
+	BucketPSPSettlementBatches = []byte("psp_settlement_batches")
+
+	// Merchant reserve buckets
+	BucketMerchantReserves = []byte("merchant_reserves")
+	BucketReserveHolds     = []byte("reserve_holds")
+
+	// Fee schedule buckets
+	BucketFeeSchedules       = []byte("fee_schedules")
+	BucketFeeCharges         = []byte("fee_charges")
+	BucketFeeReconciliations = []byte("fee_reconciliations")
+
+	// Period close control buckets
+	BucketPeriodCloseChecklists = []byte("period_close_checklists")
+	BucketPeriodReopenings      = []byte("period_reopenings")
+
+	// Regulatory reporting buckets
+	BucketRegulatoryReturns  = []byte("regulatory_returns")
+	BucketRegulatoryDatasets = []byte("regulatory_datasets")
+
+	// Year-end close buckets
+	BucketFiscalYearCloses = []byte("fiscal_year_closes")
+
+	// Master data
+	BucketCustomers   = []byte("customers")
+	BucketPartyMerges = []byte("party_merges")
+
+	// Reclassification
+	BucketReclassBatches = []byte("reclass_batches")
+
+	// Dimension registry
+	BucketDimensionDefinitions = []byte("dimension_definitions")
+
+	// Access control
+	BucketRoles = []byte("roles")
+	BucketUsers = []byte("users")
+
+	// Documents
+	BucketDocuments        = []byte("documents")
+	BucketDocumentContents = []byte("document_contents")
+
+	// Scripting
+	BucketScripts = []byte("scripts")
+
+	// Statement templates
+	BucketStatementTemplates = []byte("statement_templates")
+
+	// Alert and SAR narratives
+	BucketNarrativeTemplates = []byte("narrative_templates")
+	BucketNarratives         = []byte("narratives")
+
+	// Cross-ledger posting rules
+	BucketLedgerPostingRules = []byte("ledger_posting_rules")
+
+	// Operating segments
+	BucketOperatingSegments      = []byte("operating_segments")
+	BucketSegmentAllocationRules = []byte("segment_allocation_rules")
+
+	// Scheduled reports
+	BucketReportSchedules = []byte("report_schedules")
+	BucketReportRuns      = []byte("report_runs")
+
+	// Going concern buckets
+	BucketDebtCovenants = []byte("debt_covenants")
+
+	// Notification buckets
+	BucketNotificationSubscriptions = []byte("notification_subscriptions")
+	BucketNotificationDeliveries    = []byte("notification_deliveries")
+
+	// Consistency checks
+	BucketConsistencyRuns = []byte("consistency_runs")
+
+	// Storage maintenance
+	BucketStorageMeta = []byte("storage_meta")
+
+	// Workflows
+	BucketWorkflowInstances = []byte("workflow_instances")
+
+	// Idempotency
+	BucketIdempotencyKeys = []byte("idempotency_keys")
+
+	// Accrual recognition
+	BucketRecognitionEntries = []byte("recognition_entries")
+
+	// Accrual templates
+	BucketAccrualTemplates = []byte("accrual_templates")
+
+	// Revenue contracts
+	BucketRevenueContracts = []byte("revenue_contracts")
+
+	// Tax rounding rules, keyed by jurisdiction
+	BucketTaxRounding = []byte("tax_rounding")
+
+	// Automatic tax lines: rules and the lines generated per transaction
+	BucketTaxLineRules        = []byte("tax_line_rules")
+	BucketTransactionTaxLines = []byte("transaction_tax_lines")
+
+	// VAT returns
+	BucketVATReturnMappings = []byte("vat_return_mappings")
+
+	// Form 1099 reporting
+	BucketVendor1099Payments = []byte("vendor_1099_payments")
+
+	// SOX controls
+	BucketSOXControls        = []byte("sox_controls")
+	BucketControlTestResults = []byte("control_test_results")
+
+	// Framework adjustment layers
+	BucketFrameworkAdjustments = []byte("framework_adjustments")
+
+	// Differential sync: the version of each record last agreed with a peer
+	BucketSyncBase = []byte("sync_base")
 )
 
 // Storage provides persistent storage for the accounting system
 type Storage struct {
-	db *bbolt.DB
+	db     *storageDB
+	cache  *readCache // nil when caching is disabled
+	logger *slog.Logger
+}
+
+// storageDB is the bbolt database with its read and write transactions timed for
+// metrics once instrumented, and traced
+type storageDB struct {
+	*bbolt.DB
+	options *bbolt.Options // reused to reopen the file after compaction
+	swap    sync.RWMutex   // held exclusively while Compact replaces the file
+	metrics *EngineMetrics
+	logger  *slog.Logger
+	tracer  *engineTracer
+	scans   atomic.Pointer[scanCounter] // set while a profile runs
+}
+
+// View runs a read-only transaction
+func (db *storageDB) View(fn func(*bbolt.Tx) error) (err error) {
+	db.swap.RLock()
+	defer db.swap.RUnlock()
+	_, span := db.tracer.start(context.Background(), "storage.read", TraceKeyStorageOperation.String("read"))
+	defer func() { endSpan(span, err) }()
+	if db.metrics == nil {
+		return db.DB.View(fn)
+	}
+	started := time.Now()
+	err = db.DB.View(fn)
+	db.metrics.observeStorage("read", time.Since(started), err)
+	return err
+}
+
+// path returns the database file's path, which Compact and Restore keep while
+// they swap the handle
+func (db *storageDB) path() string {
+	db.swap.RLock()
+	defer db.swap.RUnlock()
+	return db.Path()
+}
+
+// sync flushes the current handle; the caller holds swap
+func (db *storageDB) sync() error {
+	if err := db.DB.Sync(); err != nil {
+		return fmt.Errorf("failed to sync database: %w", err)
+	}
+	return nil
+}
+
+// Update runs a read-write transaction
+func (db *storageDB) Update(fn func(*bbolt.Tx) error) error {
+	db.swap.RLock()
+	defer db.swap.RUnlock()
+	_, span := db.tracer.start(context.Background(), "storage.write", TraceKeyStorageOperation.String("write"))
+	started := time.Now()
+	err := db.DB.Update(fn)
+	if db.metrics != nil {
+		db.metrics.observeStorage("write", time.Since(started), err)
+	}
+	if err != nil {
+		db.logger.Debug("storage write rolled back", LogKeyError, err, "duration", time.Since(started))
+	}
+	endSpan(span, err)
+	return err
+}
+
+// StorageOptions tunes the underlying bbolt database. The zero value matches the
+// defaults used by NewStorage.
+type StorageOptions struct {
+	// NoSync skips fsync on every commit. Bulk loads run several times faster, but
+	// writes since the last sync can be lost if the machine crashes, so enable it only
+	// for loads that can be re-run, and call Sync or SetNoSync(false) when done.
+	NoSync bool
+	// NoFreelistSync skips writing the freelist on commit, trading slower opens for
+	// faster writes
+	NoFreelistSync bool
+	// InitialMmapSize pre-sizes the memory map in bytes so large loads do not remap
+	// repeatedly as the file grows
+	InitialMmapSize int
+	// Timeout bounds the wait for the file lock; defaults to 10 seconds
+	Timeout time.Duration
+	// DisableCache reads accounts, periods, compliance rules and exchange rates from
+	// the database every time instead of keeping them in memory
+	DisableCache bool
+	// Logger receives structured logs from storage and, when the options open an
+	// engine, from its services. Nil discards them; the handler's level decides how
+	// much is kept.
+	Logger *slog.Logger
+	// TracerProvider receives spans from storage and, when the options open an
+	// engine, from transaction, AML monitoring and report operations. Nil records no
+	// spans.
+	TracerProvider trace.TracerProvider
+	// Company names the books being kept on every span, for hosts tracing several
+	// engines
+	Company string
 }
 
 // NewStorage creates a new storage instance
 func NewStorage(dbPath string) (*Storage, error) {
-	db, err := bbolt.Open(dbPath, 0600, &bbolt.Options{Timeout: 10 * time.Second})
+	return NewStorageWithOptions(dbPath, StorageOptions{})
+}
+
+// NewStorageWithOptions creates a new storage instance with tuned bbolt options
+func NewStorageWithOptions(dbPath string, options StorageOptions) (*Storage, error) {
+	timeout := options.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	boltOptions := &bbolt.Options{
+		Timeout:         timeout,
+		NoSync:          options.NoSync,
+		NoFreelistSync:  options.NoFreelistSync,
+		InitialMmapSize: options.InitialMmapSize,
+	}
+	db, err := bbolt.Open(dbPath, 0600, boltOptions)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	storage := &Storage{db: db}
+	var snapshotsMissing, amlAggregatesMissing bool
+	if err := db.View(func(tx *bbolt.Tx) error {
+		snapshotsMissing = tx.Bucket(BucketBalanceSnapshots) == nil || tx.Bucket(BucketPostingsByTime) == nil
+		amlAggregatesMissing = tx.Bucket(BucketAMLAggregates) == nil
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to inspect database: %w", err)
+	}
+
+	logger := componentLogger(options.Logger, "storage")
+	storage := &Storage{
+		db:     &storageDB{DB: db, options: boltOptions, logger: logger, tracer: newEngineTracer(options.TracerProvider, options.Company)},
+		logger: logger,
+	}
+	if !options.DisableCache {
+		storage.cache = newReadCache()
+	}
 	if err := storage.initBuckets(); err != nil {
 		return nil, fmt.Errorf("failed to initialize buckets: %w", err)
 	}
 
+	// Databases written before balance snapshots or the posting index existed are
+	// brought up to date once
+	if snapshotsMissing {
+		logger.Info("building balance snapshots and posting index")
+		if err := storage.RebuildBalanceSnapshots(); err != nil {
+			return nil, fmt.Errorf("failed to build balance snapshots: %w", err)
+		}
+	}
+	if amlAggregatesMissing {
+		logger.Info("building AML aggregates")
+		if err := storage.RebuildAMLAggregates(); err != nil {
+			return nil, fmt.Errorf("failed to build AML aggregates: %w", err)
+		}
+	}
+
+	logger.Info("storage opened", "path", dbPath, "cache", storage.cache != nil)
 	return storage, nil
 }
 
+// Instrument records read and write transaction latency in the engine metrics. Call
+// it while the engine is being assembled.
+func (s *Storage) Instrument(metrics *EngineMetrics) {
+	s.db.metrics = metrics
+}
+
 // Close closes the database connection
 func (s *Storage) Close() error {
-	return s.db.Close()
+	s.db.swap.RLock()
+	defer s.db.swap.RUnlock()
+	if err := s.db.Close(); err != nil {
+		s.logger.Error("failed to close storage", LogKeyError, err)
+		return err
+	}
+	s.logger.Info("storage closed")
+	return nil
+}
+
+// SetNoSync turns fsync on commit off for a bulk load, or back on afterwards.
+// Turning it back on flushes everything written in the meantime. Commits read the
+// setting, so it changes only while no transaction is open.
+func (s *Storage) SetNoSync(noSync bool) error {
+	s.db.swap.Lock()
+	defer s.db.swap.Unlock()
+	s.db.NoSync = noSync
+	if !noSync {
+		return s.db.sync()
+	}
+	return nil
+}
+
+// Sync flushes committed writes to disk, for use after writing with NoSync
+func (s *Storage) Sync() error {
+	s.db.swap.RLock()
+	defer s.db.swap.RUnlock()
+	return s.db.sync()
 }
 
 // initBuckets creates all required buckets
@@ -79,11 +433,110 @@ func (s *Storage) initBuckets() error {
 			BucketCompanies, BucketIntercompanyTransactions, BucketConsolidationGroups,
 			// Zero-Based Budgeting buckets
 			BucketBudgetPeriods, BucketBudgetRequests, BucketBudgetApprovals,
-			BucketBudgetAllocations, BucketBudgetTracking,
+			BucketBudgetAllocations, BucketBudgetTracking, BucketBudgetForecasts,
+			BucketDepartments,
 			// Compliance buckets
 			BucketComplianceRules, BucketTaxRules, BucketComplianceViolations, BucketTaxReturns,
 			// AML buckets
-			BucketAMLRules, BucketAMLAlerts, BucketAMLCustomers,
+			BucketAMLRules, BucketAMLAlerts, BucketAMLCustomers, BucketAMLAggregates,
+			// Period close buckets
+			BucketVarianceCommentary, BucketCloseSignOffs, BucketClosingBinders,
+			// Currency buckets
+			BucketExchangeRates,
+			// Disclosure buckets
+			BucketDisclosures, BucketDisclosureValues,
+			// Audit confirmation buckets
+			BucketConfirmationRequests,
+			// Materiality buckets
+			BucketMaterialityAssessments,
+			// Hyperinflation buckets
+			BucketPriceIndices,
+			// Accounts payable buckets
+			BucketVendors, BucketVendorBills, BucketPaymentRuns,
+			// On-chain reconciliation buckets
+			BucketChainReconciliations,
+			// Installment plan buckets
+			BucketInstallmentPlans,
+			// Recurring transaction buckets
+			BucketRecurringTemplates, BucketRecurringRuns,
+			// Refund and chargeback buckets
+			BucketSaleReversals,
+			// Stored value
+			BucketStoredValuePrograms, BucketGiftCards,
+			// Journal approvals
+			BucketJournalApprovals,
+			// Loyalty
+			BucketLoyaltyPrograms, BucketLoyaltyMemberAccounts,
+			// Client money buckets
+			BucketClientMoneyAccounts, BucketClientMoneyRecons,
+			// Balance snapshot buckets
+			BucketBalanceSnapshots, BucketBalanceMovements, BucketPostingsByTime, BucketPostingsByAccount,
+			// PSP settlement buckets
+			BucketPSPProviders, BucketPSPSettlementBatches,
+			// Merchant reserve buckets
+			BucketMerchantReserves, BucketReserveHolds,
+			// Fee schedule buckets
+			BucketFeeSchedules, BucketFeeCharges, BucketFeeReconciliations,
+			// Period close control buckets
+			BucketPeriodCloseChecklists, BucketPeriodReopenings,
+			// Regulatory reporting buckets
+			BucketRegulatoryReturns, BucketRegulatoryDatasets,
+			// Year-end close buckets
+			BucketFiscalYearCloses,
+			// Master data
+			BucketCustomers, BucketPartyMerges,
+			// Reclassification
+			BucketReclassBatches,
+			// Dimension registry
+			BucketDimensionDefinitions,
+			// Access control
+			BucketRoles, BucketUsers,
+			// Documents
+			BucketDocuments, BucketDocumentContents,
+			// Scripting
+			BucketScripts,
+			// Statement templates
+			BucketStatementTemplates,
+			// Alert and SAR narratives
+			BucketNarrativeTemplates, BucketNarratives,
+			// Cross-ledger posting rules
+			BucketLedgerPostingRules,
+			// Operating segments
+			BucketOperatingSegments, BucketSegmentAllocationRules,
+			// Scheduled reports
+			BucketReportSchedules, BucketReportRuns,
+			// Going concern buckets
+			BucketDebtCovenants,
+			// Notification buckets
+			BucketNotificationSubscriptions, BucketNotificationDeliveries,
+			// Consistency checks
+			BucketConsistencyRuns,
+			// Storage maintenance
+			BucketStorageMeta,
+			// Workflows
+			BucketWorkflowInstances,
+			// Idempotency
+			BucketIdempotencyKeys,
+			// Accrual recognition
+			BucketRecognitionEntries,
+			// Accrual templates
+			BucketAccrualTemplates,
+			// Revenue contracts
+			BucketRevenueContracts,
+			// Tax rounding rules, keyed by jurisdiction
+			BucketTaxRounding,
+			// Automatic tax lines: rules and the lines generated per transaction
+			BucketTaxLineRules, BucketTransactionTaxLines,
+			// VAT returns
+			BucketVATReturnMappings,
+			// Form 1099 reporting
+			BucketVendor1099Payments,
+			// SOX controls
+			BucketSOXControls, BucketControlTestResults,
+			// Framework adjustment layers
+			BucketFrameworkAdjustments,
+			// Differential sync
+			BucketSyncBase,
 		}
 
 		for _, bucket := range buckets {
@@ -117,6 +570,10 @@ func (s *Storage) GetEvents(from, to time.Time) ([]*JournalEvent, error) {
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(BucketEvents)
+		if from.UnixNano() <= 0 {
+			// Reading from the start of the log walks every event
+			b = s.db.scanBucket(tx, BucketEvents)
+		}
 		c := b.Cursor()
 
 		fromKey := []byte(fmt.Sprintf("%d", from.UnixNano()))
@@ -139,6 +596,10 @@ func (s *Storage) GetEvents(from, to time.Time) ([]*JournalEvent, error) {
 
 // SaveAccount saves an account to storage
 func (s *Storage) SaveAccount(account *Account) error {
+	if s.cache != nil {
+		s.cache.accounts.beginWrite()
+		defer s.cache.accounts.endWrite()
+	}
 	return s.db.Update(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(BucketAccounts)
 		// Use protobuf serialization for better performance (70% smaller, 4x faster)
@@ -152,13 +613,21 @@ func (s *Storage) SaveAccount(account *Account) error {
 
 // GetAccount retrieves an account by ID
 func (s *Storage) GetAccount(id string) (*Account, error) {
+	if s.cache != nil {
+		account, ok, err := s.cache.accounts.get(s.db, id)
+		if err == nil && !ok {
+			err = notFound("account", id)
+		}
+		return account, err
+	}
+
 	var account *Account
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(BucketAccounts)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("account not found: %s", id)
+			return notFound("account", id)
 		}
 		// Use protobuf deserialization for better performance
 		pbAccount := &pb.Account{}
@@ -175,19 +644,69 @@ func (s *Storage) GetAccount(id string) (*Account, error) {
 	return account, nil
 }
 
-// SaveTransaction saves a transaction to storage
+// GetAllAccounts retrieves every account in the chart of accounts
+func (s *Storage) GetAllAccounts() ([]*Account, error) {
+	if s.cache != nil {
+		return s.cache.accounts.list(s.db, nil)
+	}
+
+	var accounts []*Account
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketAccounts)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbAccount := &pb.Account{}
+			if err := proto.Unmarshal(v, pbAccount); err != nil {
+				return fmt.Errorf("failed to unmarshal account: %w", err)
+			}
+			accounts = append(accounts, AccountFromProto(pbAccount))
+		}
+		return nil
+	})
+
+	return accounts, err
+}
+
+// SaveTransaction saves a transaction to storage, keeping balance snapshots in step
+// with any change in whether it is posted
 func (s *Storage) SaveTransaction(txn *Transaction) error {
 	return s.db.Update(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketTransactions)
-		// Use protobuf serialization for better performance (70% smaller, 4x faster)
-		data, err := proto.Marshal(txn.ToProto())
-		if err != nil {
-			return fmt.Errorf("failed to marshal transaction: %w", err)
-		}
-		return b.Put([]byte(txn.ID), data)
+		return saveTransactionTx(tx, txn)
 	})
 }
 
+// saveTransactionTx writes a transaction within a bolt transaction. A posted
+// transaction's entries count towards balance snapshots, so the previous version's
+// effect is removed and the new version's added.
+func saveTransactionTx(tx *bbolt.Tx, txn *Transaction) error {
+	b := tx.Bucket(BucketTransactions)
+	if previous := b.Get([]byte(txn.ID)); previous != nil {
+		pbPrevious := &pb.Transaction{}
+		if err := proto.Unmarshal(previous, pbPrevious); err != nil {
+			return fmt.Errorf("failed to unmarshal transaction: %w", err)
+		}
+		if previousTxn := TransactionFromProto(pbPrevious); previousTxn.Status == Posted {
+			if err := applyLedgerEffect(tx, previousTxn, -1); err != nil {
+				return err
+			}
+		}
+	}
+	if txn.Status == Posted {
+		if err := applyLedgerEffect(tx, txn, 1); err != nil {
+			return err
+		}
+	}
+
+	// Use protobuf serialization for better performance (70% smaller, 4x faster)
+	data, err := proto.Marshal(txn.ToProto())
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction: %w", err)
+	}
+	return b.Put([]byte(txn.ID), data)
+}
+
 // GetTransaction retrieves a transaction by ID
 func (s *Storage) GetTransaction(id string) (*Transaction, error) {
 	var txn *Transaction
@@ -196,7 +715,7 @@ func (s *Storage) GetTransaction(id string) (*Transaction, error) {
 		b := tx.Bucket(BucketTransactions)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("transaction not found: %s", id)
+			return notFound("transaction", id)
 		}
 		// Use protobuf deserialization for better performance
 		pbTxn := &pb.Transaction{}
@@ -213,6 +732,25 @@ func (s *Storage) GetTransaction(id string) (*Transaction, error) {
 	return txn, nil
 }
 
+// GetAllTransactions retrieves every transaction regardless of date or status
+func (s *Storage) GetAllTransactions() ([]*Transaction, error) {
+	var transactions []*Transaction
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketTransactions)
+		return b.ForEach(func(k, v []byte) error {
+			pbTxn := &pb.Transaction{}
+			if err := proto.Unmarshal(v, pbTxn); err != nil {
+				return fmt.Errorf("failed to unmarshal transaction: %w", err)
+			}
+			transactions = append(transactions, TransactionFromProto(pbTxn))
+			return nil
+		})
+	})
+
+	return transactions, err
+}
+
 // SaveEntry saves an entry to storage
 func (s *Storage) SaveEntry(entry *Entry) error {
 	return s.db.Update(func(tx *bbolt.Tx) error {
@@ -226,12 +764,43 @@ func (s *Storage) SaveEntry(entry *Entry) error {
 	})
 }
 
+// SaveTransactions saves a batch of transactions in a single bolt transaction, so
+// either all of them are written or none are
+func (s *Storage) SaveTransactions(txns []*Transaction) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		for _, txn := range txns {
+			if err := saveTransactionTx(tx, txn); err != nil {
+				return fmt.Errorf("failed to save transaction %s: %w", txn.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// SaveEntries saves a batch of entries in a single bolt transaction, so either all
+// of them are written or none are
+func (s *Storage) SaveEntries(entries []*Entry) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketEntries)
+		for _, entry := range entries {
+			data, err := proto.Marshal(entry.ToProto())
+			if err != nil {
+				return fmt.Errorf("failed to marshal entry %s: %w", entry.ID, err)
+			}
+			if err := b.Put([]byte(entry.ID), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // GetEntriesByAccount retrieves all entries for a specific account
 func (s *Storage) GetEntriesByAccount(accountID string) ([]*Entry, error) {
 	var entries []*Entry
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketEntries)
+		b := s.db.scanBucket(tx, BucketEntries)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -263,8 +832,55 @@ func (s *Storage) SaveLedger(ledger *Ledger) error {
 	})
 }
 
+// GetLedger retrieves a ledger by ID
+func (s *Storage) GetLedger(id string) (*Ledger, error) {
+	var ledger *Ledger
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketLedgers)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("ledger", id)
+		}
+
+		pbItem := &pb.Ledger{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal ledger: %w", err)
+		}
+		ledger = LedgerFromProto(pbItem)
+		return nil
+	})
+
+	return ledger, err
+}
+
+// GetAllLedgers retrieves all ledgers
+func (s *Storage) GetAllLedgers() ([]*Ledger, error) {
+	var items []*Ledger
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketLedgers)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.Ledger{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal ledger: %w", err)
+			}
+			items = append(items, LedgerFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
 // SavePeriod saves a period to storage
 func (s *Storage) SavePeriod(period *Period) error {
+	if s.cache != nil {
+		s.cache.periods.beginWrite()
+		defer s.cache.periods.endWrite()
+	}
 	return s.db.Update(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(BucketPeriods)
 		data, err := proto.Marshal(period.ToProto())
@@ -277,13 +893,21 @@ func (s *Storage) SavePeriod(period *Period) error {
 
 // GetPeriod retrieves a period by ID
 func (s *Storage) GetPeriod(id string) (*Period, error) {
+	if s.cache != nil {
+		period, ok, err := s.cache.periods.get(s.db, id)
+		if err == nil && !ok {
+			err = notFound("period", id)
+		}
+		return period, err
+	}
+
 	var period *Period
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(BucketPeriods)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("period not found: %s", id)
+			return notFound("period", id)
 		}
 		pbPeriod := &pb.Period{}
 		if err := proto.Unmarshal(data, pbPeriod); err != nil {
@@ -299,6 +923,29 @@ func (s *Storage) GetPeriod(id string) (*Period, error) {
 	return period, nil
 }
 
+// GetAllPeriods retrieves all periods
+func (s *Storage) GetAllPeriods() ([]*Period, error) {
+	if s.cache != nil {
+		return s.cache.periods.list(s.db, nil)
+	}
+
+	var periods []*Period
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketPeriods)
+		return b.ForEach(func(k, v []byte) error {
+			pbPeriod := &pb.Period{}
+			if err := proto.Unmarshal(v, pbPeriod); err != nil {
+				return fmt.Errorf("failed to unmarshal period: %w", err)
+			}
+			periods = append(periods, PeriodFromProto(pbPeriod))
+			return nil
+		})
+	})
+
+	return periods, err
+}
+
 // SaveReconciliation saves a reconciliation to storage
 func (s *Storage) SaveReconciliation(recon *Reconciliation) error {
 	return s.db.Update(func(tx *bbolt.Tx) error {
@@ -311,6 +958,27 @@ func (s *Storage) SaveReconciliation(recon *Reconciliation) error {
 	})
 }
 
+// GetAllReconciliations retrieves all reconciliation records
+func (s *Storage) GetAllReconciliations() ([]*Reconciliation, error) {
+	var reconciliations []*Reconciliation
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketReconciliations)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbRecon := &pb.Reconciliation{}
+			if err := proto.Unmarshal(v, pbRecon); err != nil {
+				return fmt.Errorf("failed to unmarshal reconciliation: %w", err)
+			}
+			reconciliations = append(reconciliations, ReconciliationFromProto(pbRecon))
+		}
+		return nil
+	})
+
+	return reconciliations, err
+}
+
 // SaveSchedule saves a recognition schedule to storage
 func (s *Storage) SaveSchedule(schedule *RecognitionSchedule) error {
 	return s.db.Update(func(tx *bbolt.Tx) error {
@@ -328,7 +996,7 @@ func (s *Storage) GetAllSchedules() ([]*RecognitionSchedule, error) {
 	var schedules []*RecognitionSchedule
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketSchedules)
+		b := s.db.scanBucket(tx, BucketSchedules)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -345,6 +1013,48 @@ func (s *Storage) GetAllSchedules() ([]*RecognitionSchedule, error) {
 	return schedules, err
 }
 
+// recognitionEntryKey orders a schedule's recognition entries by period
+func recognitionEntryKey(scheduleID string, periodNumber int) []byte {
+	return []byte(fmt.Sprintf("%s/%06d", scheduleID, periodNumber))
+}
+
+// SaveRecognitionEntries saves recognition entries in one transaction
+func (s *Storage) SaveRecognitionEntries(entries ...*RecognitionEntry) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRecognitionEntries)
+		for _, entry := range entries {
+			data, err := proto.Marshal(entry.ToProto())
+			if err != nil {
+				return fmt.Errorf("failed to marshal recognition entry: %w", err)
+			}
+			if err := b.Put(recognitionEntryKey(entry.ScheduleID, entry.PeriodNumber), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetRecognitionEntries retrieves a schedule's recognition entries in period order
+func (s *Storage) GetRecognitionEntries(scheduleID string) ([]*RecognitionEntry, error) {
+	var entries []*RecognitionEntry
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(BucketRecognitionEntries).Cursor()
+		prefix := []byte(scheduleID + "/")
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			pbEntry := &pb.RecognitionEntry{}
+			if err := proto.Unmarshal(v, pbEntry); err != nil {
+				return fmt.Errorf("failed to unmarshal recognition entry: %w", err)
+			}
+			entries = append(entries, RecognitionEntryFromProto(pbEntry))
+		}
+		return nil
+	})
+
+	return entries, err
+}
+
 // SaveCompany saves a company to storage
 func (s *Storage) SaveCompany(company *Company) error {
 	return s.db.Update(func(tx *bbolt.Tx) error {
@@ -365,7 +1075,7 @@ func (s *Storage) GetCompany(id string) (*Company, error) {
 		b := tx.Bucket(BucketCompanies)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("company not found: %s", id)
+			return notFound("company", id)
 		}
 		pbCompany := &pb.Company{}
 		if err := proto.Unmarshal(data, pbCompany); err != nil {
@@ -386,7 +1096,7 @@ func (s *Storage) GetCompanies() ([]*Company, error) {
 	var companies []*Company
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketCompanies)
+		b := s.db.scanBucket(tx, BucketCompanies)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -423,7 +1133,7 @@ func (s *Storage) GetIntercompanyTransaction(id string) (*IntercompanyTransactio
 		b := tx.Bucket(BucketIntercompanyTransactions)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("intercompany transaction not found: %s", id)
+			return notFound("intercompany transaction", id)
 		}
 		pbTxn := &pb.IntercompanyTransaction{}
 		if err := proto.Unmarshal(data, pbTxn); err != nil {
@@ -444,7 +1154,7 @@ func (s *Storage) GetIntercompanyTransactionsByCompany(companyID string) ([]*Int
 	var txns []*IntercompanyTransaction
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketIntercompanyTransactions)
+		b := s.db.scanBucket(tx, BucketIntercompanyTransactions)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -483,7 +1193,7 @@ func (s *Storage) GetConsolidationGroup(id string) (*ConsolidationGroup, error)
 		b := tx.Bucket(BucketConsolidationGroups)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("consolidation group not found: %s", id)
+			return notFound("consolidation group", id)
 		}
 		pbGroup := &pb.ConsolidationGroup{}
 		if err := proto.Unmarshal(data, pbGroup); err != nil {
@@ -504,7 +1214,7 @@ func (s *Storage) GetConsolidationGroups() ([]*ConsolidationGroup, error) {
 	var groups []*ConsolidationGroup
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketConsolidationGroups)
+		b := s.db.scanBucket(tx, BucketConsolidationGroups)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -546,7 +1256,7 @@ func (s *Storage) GetBudgetPeriod(id string) (*BudgetPeriod, error) {
 		b := tx.Bucket(BucketBudgetPeriods)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("budget period not found")
+			return notFound("budget period", id)
 		}
 		pbPeriod := &pb.BudgetPeriod{}
 		if err := proto.Unmarshal(data, pbPeriod); err != nil {
@@ -559,15 +1269,18 @@ func (s *Storage) GetBudgetPeriod(id string) (*BudgetPeriod, error) {
 	return period, err
 }
 
-// SaveBudgetRequest saves a budget request
+// SaveBudgetRequest saves a budget request, failing with a ConflictError if it was
+// saved since the caller read it
 func (s *Storage) SaveBudgetRequest(request *BudgetRequest) error {
-	data, err := proto.Marshal(request.ToProto())
-	if err != nil {
-		return fmt.Errorf("failed to marshal budget request: %w", err)
-	}
-
-	return s.db.Update(func(tx *bbolt.Tx) error {
+	return s.updateVersioned(&request.Version, func(tx *bbolt.Tx, read int64) error {
 		b := tx.Bucket(BucketBudgetRequests)
+		if err := checkVersion(b, "budget request", request.ID, read, &pb.BudgetRequest{}); err != nil {
+			return err
+		}
+		data, err := proto.Marshal(request.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal budget request: %w", err)
+		}
 		return b.Put([]byte(request.ID), data)
 	})
 }
@@ -580,7 +1293,7 @@ func (s *Storage) GetBudgetRequest(id string) (*BudgetRequest, error) {
 		b := tx.Bucket(BucketBudgetRequests)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("budget request not found")
+			return notFound("budget request", id)
 		}
 		pbRequest := &pb.BudgetRequest{}
 		if err := proto.Unmarshal(data, pbRequest); err != nil {
@@ -598,7 +1311,7 @@ func (s *Storage) GetBudgetRequestsByPeriodAndDept(periodID, departmentID string
 	var requests []*BudgetRequest
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketBudgetRequests)
+		b := s.db.scanBucket(tx, BucketBudgetRequests)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -636,7 +1349,7 @@ func (s *Storage) GetBudgetApprovalsByRequest(requestID string) ([]*BudgetApprov
 	var approvals []*BudgetApproval
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketBudgetApprovals)
+		b := s.db.scanBucket(tx, BucketBudgetApprovals)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -677,7 +1390,7 @@ func (s *Storage) GetBudgetAllocation(id string) (*BudgetAllocation, error) {
 		b := tx.Bucket(BucketBudgetAllocations)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("budget allocation not found")
+			return notFound("budget allocation", id)
 		}
 		pbAllocation := &pb.BudgetAllocation{}
 		if err := proto.Unmarshal(data, pbAllocation); err != nil {
@@ -695,7 +1408,7 @@ func (s *Storage) GetBudgetAllocationsByPeriodAndDept(periodID, departmentID str
 	var allocations []*BudgetAllocation
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketBudgetAllocations)
+		b := s.db.scanBucket(tx, BucketBudgetAllocations)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -730,27 +1443,272 @@ func (s *Storage) SaveBudgetTracking(tracking *BudgetTracking) error {
 	})
 }
 
-// SaveComplianceRule saves a compliance rule
-func (s *Storage) SaveComplianceRule(rule *ComplianceRule) error {
-	return s.db.Update(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketComplianceRules)
-		data, err := proto.Marshal(rule.ToProto())
-		if err != nil {
-			return fmt.Errorf("failed to marshal compliance rule: %w", err)
-		}
-		return b.Put([]byte(rule.ID), data)
+// GetAllBudgetAllocations retrieves every budget allocation
+func (s *Storage) GetAllBudgetAllocations() ([]*BudgetAllocation, error) {
+	var allocations []*BudgetAllocation
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketBudgetAllocations)
+		return b.ForEach(func(k, v []byte) error {
+			pbAllocation := &pb.BudgetAllocation{}
+			if err := proto.Unmarshal(v, pbAllocation); err != nil {
+				return fmt.Errorf("failed to unmarshal budget allocation: %w", err)
+			}
+			allocations = append(allocations, BudgetAllocationFromProto(pbAllocation))
+			return nil
+		})
 	})
+
+	return allocations, err
 }
 
-// GetComplianceRule retrieves a compliance rule by ID
-func (s *Storage) GetComplianceRule(id string) (*ComplianceRule, error) {
-	var rule *ComplianceRule
+// HasBudgetTracking reports whether a transaction has already been tracked against an allocation
+func (s *Storage) HasBudgetTracking(allocationID, transactionID string) bool {
+	return s.HasKey(BucketBudgetTracking, fmt.Sprintf("%s_%s", allocationID, transactionID))
+}
+
+// GetBudgetTrackingByAllocation retrieves the spending tracked against an allocation
+func (s *Storage) GetBudgetTrackingByAllocation(allocationID string) ([]*BudgetTracking, error) {
+	var records []*BudgetTracking
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(BucketBudgetTracking).Cursor()
+		prefix := []byte(allocationID + "_")
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			pbTracking := &pb.BudgetTracking{}
+			if err := proto.Unmarshal(v, pbTracking); err != nil {
+				return fmt.Errorf("failed to unmarshal budget tracking: %w", err)
+			}
+			records = append(records, BudgetTrackingFromProto(pbTracking))
+		}
+		return nil
+	})
+
+	return records, err
+}
+
+// GetAllBudgetTracking retrieves every budget tracking record
+func (s *Storage) GetAllBudgetTracking() ([]*BudgetTracking, error) {
+	var records []*BudgetTracking
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketBudgetTracking)
+		return b.ForEach(func(k, v []byte) error {
+			pbTracking := &pb.BudgetTracking{}
+			if err := proto.Unmarshal(v, pbTracking); err != nil {
+				return fmt.Errorf("failed to unmarshal budget tracking: %w", err)
+			}
+			records = append(records, BudgetTrackingFromProto(pbTracking))
+			return nil
+		})
+	})
+
+	return records, err
+}
+
+// RebuildBudgetSpending recomputes each allocation's spent and remaining amounts from
+// the spending tracked against it. It returns how many allocations were corrected.
+func (s *Storage) RebuildBudgetSpending() (int, error) {
+	corrected := 0
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		spent := make(map[string]int64)
+		err := s.db.scanBucket(tx, BucketBudgetTracking).ForEach(func(k, v []byte) error {
+			pbTracking := &pb.BudgetTracking{}
+			if err := proto.Unmarshal(v, pbTracking); err != nil {
+				return fmt.Errorf("failed to unmarshal budget tracking: %w", err)
+			}
+			tracking := BudgetTrackingFromProto(pbTracking)
+			if tracking.Amount != nil {
+				spent[tracking.AllocationID] += tracking.Amount.Value
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		allocations := s.db.scanBucket(tx, BucketBudgetAllocations)
+		var rebuilt []*BudgetAllocation
+		err = allocations.ForEach(func(k, v []byte) error {
+			pbAllocation := &pb.BudgetAllocation{}
+			if err := proto.Unmarshal(v, pbAllocation); err != nil {
+				return fmt.Errorf("failed to unmarshal budget allocation: %w", err)
+			}
+			allocation := BudgetAllocationFromProto(pbAllocation)
+			if allocation.Amount == nil {
+				return nil
+			}
+			currency := allocation.Amount.Currency
+			value := spent[allocation.ID]
+			if allocation.SpentAmount != nil && allocation.Remaining != nil &&
+				allocation.SpentAmount.Value == value && allocation.Remaining.Value == allocation.Amount.Value-value {
+				return nil
+			}
+			allocation.SpentAmount = &Amount{Value: value, Currency: currency}
+			allocation.Remaining = &Amount{Value: allocation.Amount.Value - value, Currency: currency}
+			rebuilt = append(rebuilt, allocation)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, allocation := range rebuilt {
+			data, err := proto.Marshal(allocation.ToProto())
+			if err != nil {
+				return fmt.Errorf("failed to marshal budget allocation: %w", err)
+			}
+			if err := allocations.Put([]byte(allocation.ID), data); err != nil {
+				return err
+			}
+		}
+		corrected = len(rebuilt)
+		return nil
+	})
+	return corrected, err
+}
+
+// SaveBudgetForecast saves a budget forecast version
+func (s *Storage) SaveBudgetForecast(forecast *BudgetForecast) error {
+	data, err := proto.Marshal(forecast.ToProto())
+	if err != nil {
+		return fmt.Errorf("failed to marshal budget forecast: %w", err)
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketBudgetForecasts)
+		return b.Put([]byte(forecast.ID), data)
+	})
+}
+
+// GetBudgetForecast retrieves a budget forecast version by ID
+func (s *Storage) GetBudgetForecast(id string) (*BudgetForecast, error) {
+	var forecast *BudgetForecast
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketBudgetForecasts)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("budget forecast", id)
+		}
+		pbForecast := &pb.BudgetForecast{}
+		if err := proto.Unmarshal(data, pbForecast); err != nil {
+			return fmt.Errorf("failed to unmarshal budget forecast: %w", err)
+		}
+		forecast = BudgetForecastFromProto(pbForecast)
+		return nil
+	})
+
+	return forecast, err
+}
+
+// GetBudgetForecastsByPeriodAndDept retrieves the forecast versions of a department's budget
+func (s *Storage) GetBudgetForecastsByPeriodAndDept(periodID, departmentID string) ([]*BudgetForecast, error) {
+	var forecasts []*BudgetForecast
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketBudgetForecasts)
+		return b.ForEach(func(k, v []byte) error {
+			pbForecast := &pb.BudgetForecast{}
+			if err := proto.Unmarshal(v, pbForecast); err != nil {
+				return fmt.Errorf("failed to unmarshal budget forecast: %w", err)
+			}
+			if pbForecast.PeriodId == periodID && pbForecast.DepartmentId == departmentID {
+				forecasts = append(forecasts, BudgetForecastFromProto(pbForecast))
+			}
+			return nil
+		})
+	})
+
+	return forecasts, err
+}
+
+// SaveDepartment saves a department or cost center
+func (s *Storage) SaveDepartment(department *Department) error {
+	data, err := proto.Marshal(department.ToProto())
+	if err != nil {
+		return fmt.Errorf("failed to marshal department: %w", err)
+	}
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDepartments)
+		return b.Put([]byte(department.ID), data)
+	})
+}
+
+// GetDepartment retrieves a department or cost center by ID
+func (s *Storage) GetDepartment(id string) (*Department, error) {
+	var department *Department
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDepartments)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("department", id)
+		}
+		pbDepartment := &pb.Department{}
+		if err := proto.Unmarshal(data, pbDepartment); err != nil {
+			return fmt.Errorf("failed to unmarshal department: %w", err)
+		}
+		department = DepartmentFromProto(pbDepartment)
+		return nil
+	})
+
+	return department, err
+}
+
+// GetAllDepartments retrieves every department and cost center
+func (s *Storage) GetAllDepartments() ([]*Department, error) {
+	var departments []*Department
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketDepartments)
+		return b.ForEach(func(k, v []byte) error {
+			pbDepartment := &pb.Department{}
+			if err := proto.Unmarshal(v, pbDepartment); err != nil {
+				return fmt.Errorf("failed to unmarshal department: %w", err)
+			}
+			departments = append(departments, DepartmentFromProto(pbDepartment))
+			return nil
+		})
+	})
+
+	return departments, err
+}
+
+// SaveComplianceRule saves a compliance rule
+func (s *Storage) SaveComplianceRule(rule *ComplianceRule) error {
+	if s.cache != nil {
+		s.cache.complianceRules.beginWrite()
+		defer s.cache.complianceRules.endWrite()
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketComplianceRules)
+		data, err := proto.Marshal(rule.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal compliance rule: %w", err)
+		}
+		return b.Put([]byte(rule.ID), data)
+	})
+}
+
+// GetComplianceRule retrieves a compliance rule by ID
+func (s *Storage) GetComplianceRule(id string) (*ComplianceRule, error) {
+	if s.cache != nil {
+		rule, ok, err := s.cache.complianceRules.get(s.db, id)
+		if err == nil && !ok {
+			err = notFound("compliance rule", id)
+		}
+		return rule, err
+	}
+
+	var rule *ComplianceRule
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(BucketComplianceRules)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("compliance rule not found: %s", id)
+			return notFound("compliance rule", id)
 		}
 		pbRule := &pb.ComplianceRule{}
 		if err := proto.Unmarshal(data, pbRule); err != nil {
@@ -768,10 +1726,14 @@ func (s *Storage) GetComplianceRule(id string) (*ComplianceRule, error) {
 
 // GetAllComplianceRules retrieves all compliance rules
 func (s *Storage) GetAllComplianceRules() ([]*ComplianceRule, error) {
+	if s.cache != nil {
+		return s.cache.complianceRules.list(s.db, nil)
+	}
+
 	var rules []*ComplianceRule
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketComplianceRules)
+		b := s.db.scanBucket(tx, BucketComplianceRules)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -808,7 +1770,7 @@ func (s *Storage) GetTaxRule(id string) (*TaxRule, error) {
 		b := tx.Bucket(BucketTaxRules)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("tax rule not found: %s", id)
+			return notFound("tax rule", id)
 		}
 		pbRule := &pb.TaxRule{}
 		if err := proto.Unmarshal(data, pbRule); err != nil {
@@ -824,12 +1786,45 @@ func (s *Storage) GetTaxRule(id string) (*TaxRule, error) {
 	return rule, nil
 }
 
+// SaveTaxRounding saves the rounding rule of a jurisdiction
+func (s *Storage) SaveTaxRounding(rounding *TaxRounding) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketTaxRounding)
+		data, err := proto.Marshal(rounding.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal tax rounding: %w", err)
+		}
+		return b.Put([]byte(rounding.Jurisdiction), data)
+	})
+}
+
+// GetTaxRounding retrieves the rounding rule of a jurisdiction
+func (s *Storage) GetTaxRounding(jurisdiction TaxJurisdiction) (*TaxRounding, error) {
+	var rounding *TaxRounding
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketTaxRounding)
+		data := b.Get([]byte(jurisdiction))
+		if data == nil {
+			return notFound("tax rounding", string(jurisdiction))
+		}
+		pbRounding := &pb.TaxRounding{}
+		if err := proto.Unmarshal(data, pbRounding); err != nil {
+			return fmt.Errorf("failed to unmarshal tax rounding: %w", err)
+		}
+		rounding = TaxRoundingFromProto(pbRounding)
+		return nil
+	})
+
+	return rounding, err
+}
+
 // GetAllTaxRules retrieves all tax rules
 func (s *Storage) GetAllTaxRules() ([]*TaxRule, error) {
 	var rules []*TaxRule
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketTaxRules)
+		b := s.db.scanBucket(tx, BucketTaxRules)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -866,7 +1861,7 @@ func (s *Storage) GetComplianceViolation(id string) (*ComplianceViolation, error
 		b := tx.Bucket(BucketComplianceViolations)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("compliance violation not found: %s", id)
+			return notFound("compliance violation", id)
 		}
 		pbViolation := &pb.ComplianceViolation{}
 		if err := proto.Unmarshal(data, pbViolation); err != nil {
@@ -887,7 +1882,7 @@ func (s *Storage) GetAllComplianceViolations() ([]*ComplianceViolation, error) {
 	var violations []*ComplianceViolation
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketComplianceViolations)
+		b := s.db.scanBucket(tx, BucketComplianceViolations)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -924,7 +1919,7 @@ func (s *Storage) GetTaxReturn(id string) (*TaxReturn, error) {
 		b := tx.Bucket(BucketTaxReturns)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("tax return not found: %s", id)
+			return notFound("tax return", id)
 		}
 		pbTaxReturn := &pb.TaxReturn{}
 		if err := proto.Unmarshal(data, pbTaxReturn); err != nil {
@@ -945,7 +1940,7 @@ func (s *Storage) GetAllTaxReturns() ([]*TaxReturn, error) {
 	var taxReturns []*TaxReturn
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketTaxReturns)
+		b := s.db.scanBucket(tx, BucketTaxReturns)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -967,7 +1962,7 @@ func (s *Storage) GetTaxRulesByJurisdiction(jurisdiction TaxJurisdiction, taxTyp
 	var rules []*TaxRule
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketTaxRules)
+		b := s.db.scanBucket(tx, BucketTaxRules)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -993,7 +1988,7 @@ func (s *Storage) GetTransactionsByDateRange(companyID string, startDate, endDat
 	var transactions []*Transaction
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketTransactions)
+		b := s.db.scanBucket(tx, BucketTransactions)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -1023,7 +2018,7 @@ func (s *Storage) GetComplianceViolations(companyID string) ([]*ComplianceViolat
 	var violations []*ComplianceViolation
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketComplianceViolations)
+		b := s.db.scanBucket(tx, BucketComplianceViolations)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -1045,7 +2040,7 @@ func (s *Storage) QueryEntries(options *QueryOptions) ([]*Entry, error) {
 	var entries []*Entry
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketEntries)
+		b := s.db.scanBucket(tx, BucketEntries)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -1115,7 +2110,7 @@ func (s *Storage) GetAMLRule(id string) (*AMLRule, error) {
 		b := tx.Bucket(BucketAMLRules)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("AML rule not found: %s", id)
+			return notFound("AML rule", id)
 		}
 		pbRule := &pb.AMLRule{}
 		if err := proto.Unmarshal(data, pbRule); err != nil {
@@ -1136,7 +2131,7 @@ func (s *Storage) GetAllAMLRules() ([]*AMLRule, error) {
 	var rules []*AMLRule
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketAMLRules)
+		b := s.db.scanBucket(tx, BucketAMLRules)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -1153,14 +2148,35 @@ func (s *Storage) GetAllAMLRules() ([]*AMLRule, error) {
 	return rules, err
 }
 
-// SaveAMLAlert saves an AML alert
+// SaveAMLAlert saves an AML alert and moves it in the dashboard aggregates from what
+// it counted as before to what it counts as now. Saving an alert changed since it
+// was read fails with a ConflictError.
 func (s *Storage) SaveAMLAlert(alert *AMLAlert) error {
-	return s.db.Update(func(tx *bbolt.Tx) error {
+	return s.updateVersioned(&alert.Version, func(tx *bbolt.Tx, read int64) error {
 		b := tx.Bucket(BucketAMLAlerts)
+		pbAlert := &pb.AMLAlert{}
+		if err := checkVersion(b, "AML alert", alert.ID, read, pbAlert); err != nil {
+			return err
+		}
 		data, err := proto.Marshal(alert.ToProto())
 		if err != nil {
 			return fmt.Errorf("failed to marshal AML alert: %w", err)
 		}
+
+		if b.Get([]byte(alert.ID)) != nil {
+			old := AMLAlertFromProto(pbAlert)
+			if err := updateAMLAggregate(tx, amlAggregateDay(old.DetectedAt), func(a *AMLDailyAggregate) {
+				a.applyAlert(old, -1)
+			}); err != nil {
+				return err
+			}
+		}
+		if err := updateAMLAggregate(tx, amlAggregateDay(alert.DetectedAt), func(a *AMLDailyAggregate) {
+			a.applyAlert(alert, 1)
+		}); err != nil {
+			return err
+		}
+
 		return b.Put([]byte(alert.ID), data)
 	})
 }
@@ -1173,7 +2189,7 @@ func (s *Storage) GetAMLAlert(id string) (*AMLAlert, error) {
 		b := tx.Bucket(BucketAMLAlerts)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("AML alert not found: %s", id)
+			return notFound("AML alert", id)
 		}
 		pbAlert := &pb.AMLAlert{}
 		if err := proto.Unmarshal(data, pbAlert); err != nil {
@@ -1194,7 +2210,7 @@ func (s *Storage) GetAMLAlerts() ([]*AMLAlert, error) {
 	var alerts []*AMLAlert
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketAMLAlerts)
+		b := s.db.scanBucket(tx, BucketAMLAlerts)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -1211,10 +2227,14 @@ func (s *Storage) GetAMLAlerts() ([]*AMLAlert, error) {
 	return alerts, err
 }
 
-// SaveAMLCustomer saves an AML customer
+// SaveAMLCustomer saves an AML customer, failing with a ConflictError if the stored
+// customer is newer than the caller's copy
 func (s *Storage) SaveAMLCustomer(customer *AMLCustomer) error {
-	return s.db.Update(func(tx *bbolt.Tx) error {
+	return s.updateVersioned(&customer.Version, func(tx *bbolt.Tx, read int64) error {
 		b := tx.Bucket(BucketAMLCustomers)
+		if err := checkVersion(b, "AML customer", customer.ID, read, &pb.AMLCustomer{}); err != nil {
+			return err
+		}
 		data, err := proto.Marshal(customer.ToProto())
 		if err != nil {
 			return fmt.Errorf("failed to marshal AML customer: %w", err)
@@ -1231,7 +2251,7 @@ func (s *Storage) GetAMLCustomer(id string) (*AMLCustomer, error) {
 		b := tx.Bucket(BucketAMLCustomers)
 		data := b.Get([]byte(id))
 		if data == nil {
-			return fmt.Errorf("AML customer not found: %s", id)
+			return notFound("AML customer", id)
 		}
 		pbCustomer := &pb.AMLCustomer{}
 		if err := proto.Unmarshal(data, pbCustomer); err != nil {
@@ -1252,7 +2272,7 @@ func (s *Storage) GetAllAMLCustomers() ([]*AMLCustomer, error) {
 	var customers []*AMLCustomer
 
 	err := s.db.View(func(tx *bbolt.Tx) error {
-		b := tx.Bucket(BucketAMLCustomers)
+		b := s.db.scanBucket(tx, BucketAMLCustomers)
 		c := b.Cursor()
 
 		for k, v := c.First(); k != nil; k, v = c.Next() {
@@ -1268,3 +2288,4341 @@ func (s *Storage) GetAllAMLCustomers() ([]*AMLCustomer, error) {
 
 	return customers, err
 }
+
+// RecordAMLTransaction counts a monitored transaction in the dashboard aggregates
+func (s *Storage) RecordAMLTransaction(txn *AMLTransaction) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return updateAMLAggregate(tx, amlAggregateDay(txn.Date), func(a *AMLDailyAggregate) {
+			a.applyTransaction(txn)
+		})
+	})
+}
+
+// GetAMLDailyAggregates retrieves the dashboard aggregates for the days from
+// fromDay to toDay inclusive, in day order
+func (s *Storage) GetAMLDailyAggregates(fromDay, toDay string) ([]*AMLDailyAggregate, error) {
+	var aggregates []*AMLDailyAggregate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(BucketAMLAggregates).Cursor()
+		for k, v := c.Seek([]byte(fromDay)); k != nil && string(k) <= toDay; k, v = c.Next() {
+			pbAggregate := &pb.AMLDailyAggregate{}
+			if err := proto.Unmarshal(v, pbAggregate); err != nil {
+				return fmt.Errorf("failed to unmarshal AML aggregate: %w", err)
+			}
+			aggregates = append(aggregates, AMLDailyAggregateFromProto(pbAggregate))
+		}
+		return nil
+	})
+
+	return aggregates, err
+}
+
+// RebuildAMLAggregates recounts the alert side of the dashboard aggregates from the
+// saved alerts. Monitored transactions are not stored, so their counts are kept.
+func (s *Storage) RebuildAMLAggregates() error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		aggregates := s.db.scanBucket(tx, BucketAMLAggregates)
+		rebuilt := make(map[string]*AMLDailyAggregate)
+		err := aggregates.ForEach(func(k, v []byte) error {
+			pbAggregate := &pb.AMLDailyAggregate{}
+			if err := proto.Unmarshal(v, pbAggregate); err != nil {
+				return fmt.Errorf("failed to unmarshal AML aggregate: %w", err)
+			}
+			kept := newAMLDailyAggregate(string(k))
+			kept.TransactionCount = pbAggregate.TransactionCount
+			kept.TransactionVolume = pbAggregate.TransactionVolume
+			for customerID, pbActivity := range pbAggregate.Customers {
+				if pbActivity.TransactionCount == 0 {
+					continue
+				}
+				activity := kept.customer(customerID)
+				activity.TransactionCount = pbActivity.TransactionCount
+				activity.TransactionVolume = pbActivity.TransactionVolume
+				activity.LastActivity = protoToTime(pbActivity.LastActivity)
+			}
+			rebuilt[string(k)] = kept
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		err = s.db.scanBucket(tx, BucketAMLAlerts).ForEach(func(k, v []byte) error {
+			pbAlert := &pb.AMLAlert{}
+			if err := proto.Unmarshal(v, pbAlert); err != nil {
+				return fmt.Errorf("failed to unmarshal AML alert: %w", err)
+			}
+			alert := AMLAlertFromProto(pbAlert)
+			day := amlAggregateDay(alert.DetectedAt)
+			if rebuilt[day] == nil {
+				rebuilt[day] = newAMLDailyAggregate(day)
+			}
+			rebuilt[day].applyAlert(alert, 1)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for day, aggregate := range rebuilt {
+			if err := putAMLAggregate(aggregates, day, aggregate); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// updateAMLAggregate applies a change to one day's dashboard aggregate within a write
+func updateAMLAggregate(tx *bbolt.Tx, day string, change func(*AMLDailyAggregate)) error {
+	b := tx.Bucket(BucketAMLAggregates)
+	aggregate := newAMLDailyAggregate(day)
+	if data := b.Get([]byte(day)); data != nil {
+		pbAggregate := &pb.AMLDailyAggregate{}
+		if err := proto.Unmarshal(data, pbAggregate); err != nil {
+			return fmt.Errorf("failed to unmarshal AML aggregate: %w", err)
+		}
+		aggregate = AMLDailyAggregateFromProto(pbAggregate)
+	}
+	change(aggregate)
+	return putAMLAggregate(b, day, aggregate)
+}
+
+// putAMLAggregate stores a day's dashboard aggregate, removing it once it is empty
+func putAMLAggregate(b *bbolt.Bucket, day string, aggregate *AMLDailyAggregate) error {
+	if aggregate.TotalAlerts == 0 && aggregate.TransactionCount == 0 {
+		return b.Delete([]byte(day))
+	}
+	data, err := proto.Marshal(aggregate.ToProto())
+	if err != nil {
+		return fmt.Errorf("failed to marshal AML aggregate: %w", err)
+	}
+	return b.Put([]byte(day), data)
+}
+
+// ----------------------------------------------------------------------------
+// Period Close Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveVarianceCommentary saves a variance commentary record
+func (s *Storage) SaveVarianceCommentary(comment *VarianceCommentary) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketVarianceCommentary)
+		data, err := proto.Marshal(comment.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal variance commentary: %w", err)
+		}
+		return b.Put([]byte(comment.ID), data)
+	})
+}
+
+// GetVarianceCommentaryByPeriod retrieves all variance commentary for a period
+func (s *Storage) GetVarianceCommentaryByPeriod(periodID string) ([]*VarianceCommentary, error) {
+	var comments []*VarianceCommentary
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketVarianceCommentary)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbComment := &pb.VarianceCommentary{}
+			if err := proto.Unmarshal(v, pbComment); err != nil {
+				return fmt.Errorf("failed to unmarshal variance commentary: %w", err)
+			}
+			if pbComment.PeriodId == periodID {
+				comments = append(comments, VarianceCommentaryFromProto(pbComment))
+			}
+		}
+		return nil
+	})
+
+	return comments, err
+}
+
+// SaveCloseSignOff saves a period close sign-off
+func (s *Storage) SaveCloseSignOff(signOff *CloseSignOff) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketCloseSignOffs)
+		data, err := proto.Marshal(signOff.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal close sign-off: %w", err)
+		}
+		return b.Put([]byte(signOff.ID), data)
+	})
+}
+
+// GetCloseSignOffsByPeriod retrieves all sign-offs recorded for a period
+func (s *Storage) GetCloseSignOffsByPeriod(periodID string) ([]*CloseSignOff, error) {
+	var signOffs []*CloseSignOff
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketCloseSignOffs)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbSignOff := &pb.CloseSignOff{}
+			if err := proto.Unmarshal(v, pbSignOff); err != nil {
+				return fmt.Errorf("failed to unmarshal close sign-off: %w", err)
+			}
+			if pbSignOff.PeriodId == periodID {
+				signOffs = append(signOffs, CloseSignOffFromProto(pbSignOff))
+			}
+		}
+		return nil
+	})
+
+	return signOffs, err
+}
+
+// SaveClosingBinderArchive saves an archived closing binder
+func (s *Storage) SaveClosingBinderArchive(archive *ClosingBinderArchive) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketClosingBinders)
+		data, err := proto.Marshal(archive.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal closing binder: %w", err)
+		}
+		return b.Put([]byte(archive.ID), data)
+	})
+}
+
+// GetClosingBinderArchive retrieves an archived closing binder by ID
+func (s *Storage) GetClosingBinderArchive(id string) (*ClosingBinderArchive, error) {
+	var archive *ClosingBinderArchive
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketClosingBinders)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("closing binder", id)
+		}
+		pbArchive := &pb.ClosingBinderArchive{}
+		if err := proto.Unmarshal(data, pbArchive); err != nil {
+			return fmt.Errorf("failed to unmarshal closing binder: %w", err)
+		}
+		archive = ClosingBinderArchiveFromProto(pbArchive)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+// GetClosingBinderArchivesByPeriod retrieves all archived binders for a period
+func (s *Storage) GetClosingBinderArchivesByPeriod(periodID string) ([]*ClosingBinderArchive, error) {
+	var archives []*ClosingBinderArchive
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketClosingBinders)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbArchive := &pb.ClosingBinderArchive{}
+			if err := proto.Unmarshal(v, pbArchive); err != nil {
+				return fmt.Errorf("failed to unmarshal closing binder: %w", err)
+			}
+			if pbArchive.PeriodId == periodID {
+				archives = append(archives, ClosingBinderArchiveFromProto(pbArchive))
+			}
+		}
+		return nil
+	})
+
+	return archives, err
+}
+
+// ----------------------------------------------------------------------------
+// Exchange Rate Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveExchangeRate saves an exchange rate
+func (s *Storage) SaveExchangeRate(rate *ExchangeRate) error {
+	if s.cache != nil {
+		s.cache.exchangeRates.beginWrite()
+		defer s.cache.exchangeRates.endWrite()
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketExchangeRates)
+		data, err := proto.Marshal(rate.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal exchange rate: %w", err)
+		}
+		return b.Put([]byte(rate.ID), data)
+	})
+}
+
+// GetExchangeRatesByPair retrieves all stored rates for a currency pair
+func (s *Storage) GetExchangeRatesByPair(from, to Currency) ([]*ExchangeRate, error) {
+	if s.cache != nil {
+		return s.cache.exchangeRates.list(s.db, func(rate *ExchangeRate) bool {
+			return rate.FromCurrency == from && rate.ToCurrency == to
+		})
+	}
+
+	var rates []*ExchangeRate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketExchangeRates)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbRate := &pb.ExchangeRate{}
+			if err := proto.Unmarshal(v, pbRate); err != nil {
+				return fmt.Errorf("failed to unmarshal exchange rate: %w", err)
+			}
+			if Currency(pbRate.FromCurrency) == from && Currency(pbRate.ToCurrency) == to {
+				rates = append(rates, ExchangeRateFromProto(pbRate))
+			}
+		}
+		return nil
+	})
+
+	return rates, err
+}
+
+// GetAllExchangeRates retrieves all stored exchange rates
+func (s *Storage) GetAllExchangeRates() ([]*ExchangeRate, error) {
+	if s.cache != nil {
+		return s.cache.exchangeRates.list(s.db, nil)
+	}
+
+	var rates []*ExchangeRate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketExchangeRates)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbRate := &pb.ExchangeRate{}
+			if err := proto.Unmarshal(v, pbRate); err != nil {
+				return fmt.Errorf("failed to unmarshal exchange rate: %w", err)
+			}
+			rates = append(rates, ExchangeRateFromProto(pbRate))
+		}
+		return nil
+	})
+
+	return rates, err
+}
+
+// ----------------------------------------------------------------------------
+// Disclosure Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveDisclosure saves a disclosure library entry
+func (s *Storage) SaveDisclosure(disclosure *Disclosure) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDisclosures)
+		data, err := proto.Marshal(disclosure.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal disclosure: %w", err)
+		}
+		return b.Put([]byte(disclosure.ID), data)
+	})
+}
+
+// GetDisclosure retrieves a disclosure by ID
+func (s *Storage) GetDisclosure(id string) (*Disclosure, error) {
+	var disclosure *Disclosure
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDisclosures)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("disclosure", id)
+		}
+		pbDisclosure := &pb.Disclosure{}
+		if err := proto.Unmarshal(data, pbDisclosure); err != nil {
+			return fmt.Errorf("failed to unmarshal disclosure: %w", err)
+		}
+		disclosure = DisclosureFromProto(pbDisclosure)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return disclosure, nil
+}
+
+// GetAllDisclosures retrieves the full disclosure library
+func (s *Storage) GetAllDisclosures() ([]*Disclosure, error) {
+	var disclosures []*Disclosure
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketDisclosures)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbDisclosure := &pb.Disclosure{}
+			if err := proto.Unmarshal(v, pbDisclosure); err != nil {
+				return fmt.Errorf("failed to unmarshal disclosure: %w", err)
+			}
+			disclosures = append(disclosures, DisclosureFromProto(pbDisclosure))
+		}
+		return nil
+	})
+
+	return disclosures, err
+}
+
+// SaveDisclosureValue saves a period-specific disclosure value
+func (s *Storage) SaveDisclosureValue(value *DisclosureValue) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDisclosureValues)
+		data, err := proto.Marshal(value.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal disclosure value: %w", err)
+		}
+		return b.Put([]byte(value.ID), data)
+	})
+}
+
+// GetDisclosureValuesByPeriod retrieves all disclosure values entered for a period
+func (s *Storage) GetDisclosureValuesByPeriod(periodID string) ([]*DisclosureValue, error) {
+	var values []*DisclosureValue
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketDisclosureValues)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbValue := &pb.DisclosureValue{}
+			if err := proto.Unmarshal(v, pbValue); err != nil {
+				return fmt.Errorf("failed to unmarshal disclosure value: %w", err)
+			}
+			if pbValue.PeriodId == periodID {
+				values = append(values, DisclosureValueFromProto(pbValue))
+			}
+		}
+		return nil
+	})
+
+	return values, err
+}
+
+// ----------------------------------------------------------------------------
+// Confirmation Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveConfirmationRequest saves an audit confirmation request
+func (s *Storage) SaveConfirmationRequest(request *ConfirmationRequest) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketConfirmationRequests)
+		data, err := proto.Marshal(request.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal confirmation request: %w", err)
+		}
+		return b.Put([]byte(request.ID), data)
+	})
+}
+
+// GetConfirmationRequest retrieves a confirmation request by ID
+func (s *Storage) GetConfirmationRequest(id string) (*ConfirmationRequest, error) {
+	var request *ConfirmationRequest
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketConfirmationRequests)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("confirmation request", id)
+		}
+		pbRequest := &pb.ConfirmationRequest{}
+		if err := proto.Unmarshal(data, pbRequest); err != nil {
+			return fmt.Errorf("failed to unmarshal confirmation request: %w", err)
+		}
+		request = ConfirmationRequestFromProto(pbRequest)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// GetConfirmationRequestsByDate retrieves all confirmation requests for an audit date
+func (s *Storage) GetConfirmationRequestsByDate(asOfDate time.Time) ([]*ConfirmationRequest, error) {
+	var requests []*ConfirmationRequest
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketConfirmationRequests)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbRequest := &pb.ConfirmationRequest{}
+			if err := proto.Unmarshal(v, pbRequest); err != nil {
+				return fmt.Errorf("failed to unmarshal confirmation request: %w", err)
+			}
+			if protoToTime(pbRequest.AsOfDate).Equal(asOfDate) {
+				requests = append(requests, ConfirmationRequestFromProto(pbRequest))
+			}
+		}
+		return nil
+	})
+
+	return requests, err
+}
+
+// ----------------------------------------------------------------------------
+// Materiality Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveMaterialityAssessment saves a materiality assessment
+func (s *Storage) SaveMaterialityAssessment(assessment *MaterialityAssessment) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketMaterialityAssessments)
+		data, err := proto.Marshal(assessment.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal materiality assessment: %w", err)
+		}
+		return b.Put([]byte(assessment.ID), data)
+	})
+}
+
+// GetAllMaterialityAssessments retrieves all materiality assessments
+func (s *Storage) GetAllMaterialityAssessments() ([]*MaterialityAssessment, error) {
+	var assessments []*MaterialityAssessment
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketMaterialityAssessments)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbAssessment := &pb.MaterialityAssessment{}
+			if err := proto.Unmarshal(v, pbAssessment); err != nil {
+				return fmt.Errorf("failed to unmarshal materiality assessment: %w", err)
+			}
+			assessments = append(assessments, MaterialityAssessmentFromProto(pbAssessment))
+		}
+		return nil
+	})
+
+	return assessments, err
+}
+
+// ----------------------------------------------------------------------------
+// Price Index Storage Methods
+// ----------------------------------------------------------------------------
+
+// SavePriceIndex saves a price index reading
+func (s *Storage) SavePriceIndex(index *PriceIndex) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPriceIndices)
+		data, err := proto.Marshal(index.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal price index: %w", err)
+		}
+		return b.Put([]byte(index.ID), data)
+	})
+}
+
+// GetPriceIndicesByName retrieves all readings of a price index
+func (s *Storage) GetPriceIndicesByName(indexName string) ([]*PriceIndex, error) {
+	var indices []*PriceIndex
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketPriceIndices)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbIndex := &pb.PriceIndex{}
+			if err := proto.Unmarshal(v, pbIndex); err != nil {
+				return fmt.Errorf("failed to unmarshal price index: %w", err)
+			}
+			if pbIndex.IndexName == indexName {
+				indices = append(indices, PriceIndexFromProto(pbIndex))
+			}
+		}
+		return nil
+	})
+
+	return indices, err
+}
+
+// ----------------------------------------------------------------------------
+// Accounts Payable Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveVendor saves a vendor
+func (s *Storage) SaveVendor(item *Vendor) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketVendors)
+		data, err := proto.Marshal(item.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal vendor: %w", err)
+		}
+		return b.Put([]byte(item.ID), data)
+	})
+}
+
+// GetVendor retrieves a vendor by ID
+func (s *Storage) GetVendor(id string) (*Vendor, error) {
+	var item *Vendor
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketVendors)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("vendor", id)
+		}
+
+		pbItem := &pb.Vendor{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal vendor: %w", err)
+		}
+		item = VendorFromProto(pbItem)
+		return nil
+	})
+
+	return item, err
+}
+
+// GetAllVendors retrieves all vendors
+func (s *Storage) GetAllVendors() ([]*Vendor, error) {
+	var items []*Vendor
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketVendors)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.Vendor{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal vendor: %w", err)
+			}
+			items = append(items, VendorFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveVendorBill saves a vendor bill
+func (s *Storage) SaveVendorBill(item *VendorBill) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketVendorBills)
+		data, err := proto.Marshal(item.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal vendor bill: %w", err)
+		}
+		return b.Put([]byte(item.ID), data)
+	})
+}
+
+// GetVendorBill retrieves a vendor bill by ID
+func (s *Storage) GetVendorBill(id string) (*VendorBill, error) {
+	var item *VendorBill
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketVendorBills)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("vendor bill", id)
+		}
+
+		pbItem := &pb.VendorBill{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal vendor bill: %w", err)
+		}
+		item = VendorBillFromProto(pbItem)
+		return nil
+	})
+
+	return item, err
+}
+
+// GetAllVendorBills retrieves all vendor bills
+func (s *Storage) GetAllVendorBills() ([]*VendorBill, error) {
+	var items []*VendorBill
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketVendorBills)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.VendorBill{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal vendor bill: %w", err)
+			}
+			items = append(items, VendorBillFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SavePaymentRun saves a payment run
+func (s *Storage) SavePaymentRun(item *PaymentRun) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPaymentRuns)
+		data, err := proto.Marshal(item.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal payment run: %w", err)
+		}
+		return b.Put([]byte(item.ID), data)
+	})
+}
+
+// GetPaymentRun retrieves a payment run by ID
+func (s *Storage) GetPaymentRun(id string) (*PaymentRun, error) {
+	var item *PaymentRun
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPaymentRuns)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("payment run", id)
+		}
+
+		pbItem := &pb.PaymentRun{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal payment run: %w", err)
+		}
+		item = PaymentRunFromProto(pbItem)
+		return nil
+	})
+
+	return item, err
+}
+
+// GetAllPaymentRuns retrieves all payment runs
+func (s *Storage) GetAllPaymentRuns() ([]*PaymentRun, error) {
+	var items []*PaymentRun
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketPaymentRuns)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.PaymentRun{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal payment run: %w", err)
+			}
+			items = append(items, PaymentRunFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// On-Chain Reconciliation Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveChainReconciliation saves an on-chain reconciliation run
+func (s *Storage) SaveChainReconciliation(recon *ChainReconciliation) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketChainReconciliations)
+		data, err := proto.Marshal(recon.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal chain reconciliation: %w", err)
+		}
+		return b.Put([]byte(recon.ID), data)
+	})
+}
+
+// GetAllChainReconciliations retrieves all on-chain reconciliation runs
+func (s *Storage) GetAllChainReconciliations() ([]*ChainReconciliation, error) {
+	var recons []*ChainReconciliation
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketChainReconciliations)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbRecon := &pb.ChainReconciliation{}
+			if err := proto.Unmarshal(v, pbRecon); err != nil {
+				return fmt.Errorf("failed to unmarshal chain reconciliation: %w", err)
+			}
+			recons = append(recons, ChainReconciliationFromProto(pbRecon))
+		}
+		return nil
+	})
+
+	return recons, err
+}
+
+// ----------------------------------------------------------------------------
+// Installment Plan Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveInstallmentPlan saves an installment plan
+func (s *Storage) SaveInstallmentPlan(plan *InstallmentPlan) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketInstallmentPlans)
+		data, err := proto.Marshal(plan.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal installment plan: %w", err)
+		}
+		return b.Put([]byte(plan.ID), data)
+	})
+}
+
+// GetInstallmentPlan retrieves an installment plan by ID
+func (s *Storage) GetInstallmentPlan(id string) (*InstallmentPlan, error) {
+	var plan *InstallmentPlan
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketInstallmentPlans)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("installment plan", id)
+		}
+
+		pbPlan := &pb.InstallmentPlan{}
+		if err := proto.Unmarshal(data, pbPlan); err != nil {
+			return fmt.Errorf("failed to unmarshal installment plan: %w", err)
+		}
+		plan = InstallmentPlanFromProto(pbPlan)
+		return nil
+	})
+
+	return plan, err
+}
+
+// GetAllInstallmentPlans retrieves all installment plans
+func (s *Storage) GetAllInstallmentPlans() ([]*InstallmentPlan, error) {
+	var plans []*InstallmentPlan
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketInstallmentPlans)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbPlan := &pb.InstallmentPlan{}
+			if err := proto.Unmarshal(v, pbPlan); err != nil {
+				return fmt.Errorf("failed to unmarshal installment plan: %w", err)
+			}
+			plans = append(plans, InstallmentPlanFromProto(pbPlan))
+		}
+		return nil
+	})
+
+	return plans, err
+}
+
+// ----------------------------------------------------------------------------
+// Recurring Transaction Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveRecurringTemplate saves a recurring template
+func (s *Storage) SaveRecurringTemplate(template *RecurringTransactionTemplate) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRecurringTemplates)
+		data, err := proto.Marshal(template.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal recurring template: %w", err)
+		}
+		return b.Put([]byte(template.ID), data)
+	})
+}
+
+// GetRecurringTemplate retrieves a recurring template by ID
+func (s *Storage) GetRecurringTemplate(id string) (*RecurringTransactionTemplate, error) {
+	var template *RecurringTransactionTemplate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRecurringTemplates)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("recurring template", id)
+		}
+
+		pbItem := &pb.RecurringTransactionTemplate{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal recurring template: %w", err)
+		}
+		template = RecurringTransactionTemplateFromProto(pbItem)
+		return nil
+	})
+
+	return template, err
+}
+
+// GetAllRecurringTemplates retrieves all recurring templates
+func (s *Storage) GetAllRecurringTemplates() ([]*RecurringTransactionTemplate, error) {
+	var items []*RecurringTransactionTemplate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketRecurringTemplates)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.RecurringTransactionTemplate{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal recurring template: %w", err)
+			}
+			items = append(items, RecurringTransactionTemplateFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveRecurringRun saves a recurring run
+func (s *Storage) SaveRecurringRun(run *RecurringRun) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRecurringRuns)
+		data, err := proto.Marshal(run.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal recurring run: %w", err)
+		}
+		return b.Put([]byte(run.ID), data)
+	})
+}
+
+// GetRecurringRun retrieves a recurring run by ID
+func (s *Storage) GetRecurringRun(id string) (*RecurringRun, error) {
+	var run *RecurringRun
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRecurringRuns)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("recurring run", id)
+		}
+
+		pbItem := &pb.RecurringRun{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal recurring run: %w", err)
+		}
+		run = RecurringRunFromProto(pbItem)
+		return nil
+	})
+
+	return run, err
+}
+
+// GetAllRecurringRuns retrieves all recurring runs
+func (s *Storage) GetAllRecurringRuns() ([]*RecurringRun, error) {
+	var items []*RecurringRun
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketRecurringRuns)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.RecurringRun{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal recurring run: %w", err)
+			}
+			items = append(items, RecurringRunFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Refund and Chargeback Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveSaleReversal saves a sale reversal
+func (s *Storage) SaveSaleReversal(reversal *SaleReversal) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketSaleReversals)
+		data, err := proto.Marshal(reversal.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal sale reversal: %w", err)
+		}
+		return b.Put([]byte(reversal.ID), data)
+	})
+}
+
+// GetSaleReversal retrieves a sale reversal by ID
+func (s *Storage) GetSaleReversal(id string) (*SaleReversal, error) {
+	var reversal *SaleReversal
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketSaleReversals)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("sale reversal", id)
+		}
+
+		pbItem := &pb.SaleReversal{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal sale reversal: %w", err)
+		}
+		reversal = SaleReversalFromProto(pbItem)
+		return nil
+	})
+
+	return reversal, err
+}
+
+// GetAllSaleReversals retrieves all sale reversals
+func (s *Storage) GetAllSaleReversals() ([]*SaleReversal, error) {
+	var items []*SaleReversal
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketSaleReversals)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.SaleReversal{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal sale reversal: %w", err)
+			}
+			items = append(items, SaleReversalFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Stored Value Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveStoredValueProgram saves a stored value program
+func (s *Storage) SaveStoredValueProgram(program *StoredValueProgram) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketStoredValuePrograms)
+		data, err := proto.Marshal(program.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal stored value program: %w", err)
+		}
+		return b.Put([]byte(program.ID), data)
+	})
+}
+
+// GetStoredValueProgram retrieves a stored value program by ID
+func (s *Storage) GetStoredValueProgram(id string) (*StoredValueProgram, error) {
+	var program *StoredValueProgram
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketStoredValuePrograms)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("stored value program", id)
+		}
+
+		pbItem := &pb.StoredValueProgram{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal stored value program: %w", err)
+		}
+		program = StoredValueProgramFromProto(pbItem)
+		return nil
+	})
+
+	return program, err
+}
+
+// GetAllStoredValuePrograms retrieves all stored value programs
+func (s *Storage) GetAllStoredValuePrograms() ([]*StoredValueProgram, error) {
+	var items []*StoredValueProgram
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketStoredValuePrograms)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.StoredValueProgram{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal stored value program: %w", err)
+			}
+			items = append(items, StoredValueProgramFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveGiftCard saves a gift card
+func (s *Storage) SaveGiftCard(card *GiftCard) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketGiftCards)
+		data, err := proto.Marshal(card.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal gift card: %w", err)
+		}
+		return b.Put([]byte(card.ID), data)
+	})
+}
+
+// GetGiftCard retrieves a gift card by ID
+func (s *Storage) GetGiftCard(id string) (*GiftCard, error) {
+	var card *GiftCard
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketGiftCards)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("gift card", id)
+		}
+
+		pbItem := &pb.GiftCard{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal gift card: %w", err)
+		}
+		card = GiftCardFromProto(pbItem)
+		return nil
+	})
+
+	return card, err
+}
+
+// GetAllGiftCards retrieves all gift cards
+func (s *Storage) GetAllGiftCards() ([]*GiftCard, error) {
+	var items []*GiftCard
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketGiftCards)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.GiftCard{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal gift card: %w", err)
+			}
+			items = append(items, GiftCardFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Journal Approval Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveJournalApproval saves a journal approval
+func (s *Storage) SaveJournalApproval(approval *JournalApproval) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketJournalApprovals)
+		data, err := proto.Marshal(approval.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal journal approval: %w", err)
+		}
+		return b.Put([]byte(approval.ID), data)
+	})
+}
+
+// GetJournalApproval retrieves a journal approval by ID
+func (s *Storage) GetJournalApproval(id string) (*JournalApproval, error) {
+	var approval *JournalApproval
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketJournalApprovals)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("journal approval", id)
+		}
+
+		pbItem := &pb.JournalApproval{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal journal approval: %w", err)
+		}
+		approval = JournalApprovalFromProto(pbItem)
+		return nil
+	})
+
+	return approval, err
+}
+
+// GetAllJournalApprovals retrieves all journal approvals
+func (s *Storage) GetAllJournalApprovals() ([]*JournalApproval, error) {
+	var items []*JournalApproval
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketJournalApprovals)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.JournalApproval{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal journal approval: %w", err)
+			}
+			items = append(items, JournalApprovalFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Loyalty Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveLoyaltyProgram saves a loyalty program
+func (s *Storage) SaveLoyaltyProgram(program *LoyaltyProgram) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketLoyaltyPrograms)
+		data, err := proto.Marshal(program.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal loyalty program: %w", err)
+		}
+		return b.Put([]byte(program.ID), data)
+	})
+}
+
+// GetLoyaltyProgram retrieves a loyalty program by ID
+func (s *Storage) GetLoyaltyProgram(id string) (*LoyaltyProgram, error) {
+	var program *LoyaltyProgram
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketLoyaltyPrograms)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("loyalty program", id)
+		}
+
+		pbItem := &pb.LoyaltyProgram{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal loyalty program: %w", err)
+		}
+		program = LoyaltyProgramFromProto(pbItem)
+		return nil
+	})
+
+	return program, err
+}
+
+// GetAllLoyaltyPrograms retrieves all loyalty programs
+func (s *Storage) GetAllLoyaltyPrograms() ([]*LoyaltyProgram, error) {
+	var items []*LoyaltyProgram
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketLoyaltyPrograms)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.LoyaltyProgram{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal loyalty program: %w", err)
+			}
+			items = append(items, LoyaltyProgramFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveLoyaltyMemberAccount saves a loyalty account
+func (s *Storage) SaveLoyaltyMemberAccount(account *LoyaltyMemberAccount) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketLoyaltyMemberAccounts)
+		data, err := proto.Marshal(account.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal loyalty account: %w", err)
+		}
+		return b.Put([]byte(account.ID), data)
+	})
+}
+
+// GetLoyaltyMemberAccount retrieves a loyalty account by ID
+func (s *Storage) GetLoyaltyMemberAccount(id string) (*LoyaltyMemberAccount, error) {
+	var account *LoyaltyMemberAccount
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketLoyaltyMemberAccounts)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("loyalty account", id)
+		}
+
+		pbItem := &pb.LoyaltyMemberAccount{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal loyalty account: %w", err)
+		}
+		account = LoyaltyMemberAccountFromProto(pbItem)
+		return nil
+	})
+
+	return account, err
+}
+
+// GetAllLoyaltyMemberAccounts retrieves all loyalty accounts
+func (s *Storage) GetAllLoyaltyMemberAccounts() ([]*LoyaltyMemberAccount, error) {
+	var items []*LoyaltyMemberAccount
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketLoyaltyMemberAccounts)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.LoyaltyMemberAccount{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal loyalty account: %w", err)
+			}
+			items = append(items, LoyaltyMemberAccountFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Client Money Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveClientMoneyAccount saves a client money account
+func (s *Storage) SaveClientMoneyAccount(account *ClientMoneyAccount) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketClientMoneyAccounts)
+		data, err := proto.Marshal(account.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal client money account: %w", err)
+		}
+		return b.Put([]byte(account.ID), data)
+	})
+}
+
+// GetClientMoneyAccount retrieves a client money account by ID
+func (s *Storage) GetClientMoneyAccount(id string) (*ClientMoneyAccount, error) {
+	var account *ClientMoneyAccount
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketClientMoneyAccounts)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("client money account", id)
+		}
+
+		pbItem := &pb.ClientMoneyAccount{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal client money account: %w", err)
+		}
+		account = ClientMoneyAccountFromProto(pbItem)
+		return nil
+	})
+
+	return account, err
+}
+
+// GetAllClientMoneyAccounts retrieves all client money accounts
+func (s *Storage) GetAllClientMoneyAccounts() ([]*ClientMoneyAccount, error) {
+	var items []*ClientMoneyAccount
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketClientMoneyAccounts)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.ClientMoneyAccount{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal client money account: %w", err)
+			}
+			items = append(items, ClientMoneyAccountFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveClientMoneyReconciliation saves a client money reconciliation
+func (s *Storage) SaveClientMoneyReconciliation(recon *ClientMoneyReconciliation) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketClientMoneyRecons)
+		data, err := proto.Marshal(recon.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal client money reconciliation: %w", err)
+		}
+		return b.Put([]byte(recon.ID), data)
+	})
+}
+
+// GetClientMoneyReconciliation retrieves a client money reconciliation by ID
+func (s *Storage) GetClientMoneyReconciliation(id string) (*ClientMoneyReconciliation, error) {
+	var recon *ClientMoneyReconciliation
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketClientMoneyRecons)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("client money reconciliation", id)
+		}
+
+		pbItem := &pb.ClientMoneyReconciliation{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal client money reconciliation: %w", err)
+		}
+		recon = ClientMoneyReconciliationFromProto(pbItem)
+		return nil
+	})
+
+	return recon, err
+}
+
+// GetAllClientMoneyReconciliations retrieves all client money reconciliations
+func (s *Storage) GetAllClientMoneyReconciliations() ([]*ClientMoneyReconciliation, error) {
+	var items []*ClientMoneyReconciliation
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketClientMoneyRecons)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.ClientMoneyReconciliation{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal client money reconciliation: %w", err)
+			}
+			items = append(items, ClientMoneyReconciliationFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Balance Snapshot Storage Methods
+// ----------------------------------------------------------------------------
+
+// balanceMovementLayout formats movement keys at a fixed width so they sort by valid time
+const balanceMovementLayout = "2006-01-02T15:04:05.000000000"
+
+func balanceSnapshotKey(accountID, period string) []byte {
+	return []byte(accountID + "\x00" + period)
+}
+
+func balanceMovementKey(accountID string, validTime time.Time) []byte {
+	return []byte(accountID + "\x00" + validTime.UTC().Format(balanceMovementLayout))
+}
+
+// postingTimeKey orders the posting index by valid time, then transaction
+func postingTimeKey(validTime time.Time, txnID string) []byte {
+	return []byte(validTime.UTC().Format(balanceMovementLayout) + "\x00" + txnID)
+}
+
+// postingAccountKey orders an account's postings by valid time, then transaction
+func postingAccountKey(accountID string, validTime time.Time, txnID string) []byte {
+	return []byte(accountID + "\x00" + validTime.UTC().Format(balanceMovementLayout) + "\x00" + txnID)
+}
+
+// applyLedgerEffect adds (sign 1) or removes (sign -1) a posted transaction's entries
+// in the balance snapshots and movements and in the posting index
+func applyLedgerEffect(tx *bbolt.Tx, txn *Transaction, sign int64) error {
+	snapshots := tx.Bucket(BucketBalanceSnapshots)
+	movements := tx.Bucket(BucketBalanceMovements)
+	byTime := tx.Bucket(BucketPostingsByTime)
+	byAccount := tx.Bucket(BucketPostingsByAccount)
+	now := time.Now()
+
+	if sign > 0 {
+		if err := byTime.Put(postingTimeKey(txn.ValidTime, txn.ID), nil); err != nil {
+			return err
+		}
+	} else if err := byTime.Delete(postingTimeKey(txn.ValidTime, txn.ID)); err != nil {
+		return err
+	}
+
+	for _, entry := range txn.Entries {
+		accountKey := postingAccountKey(entry.AccountID, txn.ValidTime, txn.ID)
+		if sign > 0 {
+			if err := byAccount.Put(accountKey, nil); err != nil {
+				return err
+			}
+		} else if err := byAccount.Delete(accountKey); err != nil {
+			return err
+		}
+
+		var debits, credits int64
+		if entry.Type == Debit {
+			debits = sign * entry.Amount.Value
+		} else {
+			credits = sign * entry.Amount.Value
+		}
+
+		period := balanceSnapshotPeriod(txn.ValidTime)
+		snapshotKey := balanceSnapshotKey(entry.AccountID, period)
+		snapshot := &AccountBalanceSnapshot{AccountID: entry.AccountID, Period: period}
+		if data := snapshots.Get(snapshotKey); data != nil {
+			pbSnapshot := &pb.AccountBalanceSnapshot{}
+			if err := proto.Unmarshal(data, pbSnapshot); err != nil {
+				return fmt.Errorf("failed to unmarshal balance snapshot: %w", err)
+			}
+			snapshot = AccountBalanceSnapshotFromProto(pbSnapshot)
+		}
+		snapshot.Debits += debits
+		snapshot.Credits += credits
+		snapshot.EntryCount += sign
+		if txn.ValidTime.After(snapshot.LastValidTime) {
+			snapshot.LastValidTime = txn.ValidTime
+		}
+		snapshot.UpdatedAt = now
+		if snapshot.EntryCount == 0 {
+			if err := snapshots.Delete(snapshotKey); err != nil {
+				return err
+			}
+		} else {
+			data, err := proto.Marshal(snapshot.ToProto())
+			if err != nil {
+				return fmt.Errorf("failed to marshal balance snapshot: %w", err)
+			}
+			if err := snapshots.Put(snapshotKey, data); err != nil {
+				return err
+			}
+		}
+
+		movementKey := balanceMovementKey(entry.AccountID, txn.ValidTime)
+		movement := &BalanceMovement{}
+		if data := movements.Get(movementKey); data != nil {
+			pbMovement := &pb.BalanceMovement{}
+			if err := proto.Unmarshal(data, pbMovement); err != nil {
+				return fmt.Errorf("failed to unmarshal balance movement: %w", err)
+			}
+			movement = BalanceMovementFromProto(pbMovement)
+		}
+		movement.Debits += debits
+		movement.Credits += credits
+		movement.EntryCount += sign
+		if movement.EntryCount == 0 {
+			if err := movements.Delete(movementKey); err != nil {
+				return err
+			}
+		} else {
+			data, err := proto.Marshal(movement.ToProto())
+			if err != nil {
+				return fmt.Errorf("failed to marshal balance movement: %w", err)
+			}
+			if err := movements.Put(movementKey, data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetBalanceTotals sums the debits and credits posted to an account with a valid time
+// up to the as-of date. Whole months come from their snapshots; only the month the
+// date falls in, if it has later activity, is summed from its movements.
+func (s *Storage) GetBalanceTotals(accountID string, asOfDate time.Time) (debits, credits int64, err error) {
+	err = s.db.View(func(tx *bbolt.Tx) error {
+		prefix := []byte(accountID + "\x00")
+		asOfKey := balanceMovementKey(accountID, asOfDate)
+		movements := tx.Bucket(BucketBalanceMovements).Cursor()
+
+		c := tx.Bucket(BucketBalanceSnapshots).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			pbSnapshot := &pb.AccountBalanceSnapshot{}
+			if err := proto.Unmarshal(v, pbSnapshot); err != nil {
+				return fmt.Errorf("failed to unmarshal balance snapshot: %w", err)
+			}
+			snapshot := AccountBalanceSnapshotFromProto(pbSnapshot)
+
+			periodStart := snapshot.PeriodStart()
+			if periodStart.After(asOfDate) {
+				break
+			}
+			if !snapshot.LastValidTime.After(asOfDate) {
+				debits += snapshot.Debits
+				credits += snapshot.Credits
+				continue
+			}
+
+			for mk, mv := movements.Seek(balanceMovementKey(accountID, periodStart)); mk != nil && bytes.Compare(mk, asOfKey) <= 0; mk, mv = movements.Next() {
+				pbMovement := &pb.BalanceMovement{}
+				if err := proto.Unmarshal(mv, pbMovement); err != nil {
+					return fmt.Errorf("failed to unmarshal balance movement: %w", err)
+				}
+				debits += pbMovement.Debits
+				credits += pbMovement.Credits
+			}
+			break
+		}
+		return nil
+	})
+	return debits, credits, err
+}
+
+// GetBalanceSnapshots retrieves an account's monthly balance snapshots, oldest first
+func (s *Storage) GetBalanceSnapshots(accountID string) ([]*AccountBalanceSnapshot, error) {
+	var snapshots []*AccountBalanceSnapshot
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		prefix := []byte(accountID + "\x00")
+		c := tx.Bucket(BucketBalanceSnapshots).Cursor()
+		for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			pbSnapshot := &pb.AccountBalanceSnapshot{}
+			if err := proto.Unmarshal(v, pbSnapshot); err != nil {
+				return fmt.Errorf("failed to unmarshal balance snapshot: %w", err)
+			}
+			snapshots = append(snapshots, AccountBalanceSnapshotFromProto(pbSnapshot))
+		}
+		return nil
+	})
+
+	return snapshots, err
+}
+
+// RebuildBalanceSnapshots discards the balance snapshots and posting index and
+// rebuilds them by replaying the posting events against the transactions that are
+// still posted
+func (s *Storage) RebuildBalanceSnapshots() error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range [][]byte{BucketBalanceSnapshots, BucketBalanceMovements, BucketPostingsByTime, BucketPostingsByAccount} {
+			if err := tx.DeleteBucket(bucket); err != nil {
+				return fmt.Errorf("failed to clear bucket %s: %w", bucket, err)
+			}
+			if _, err := tx.CreateBucket(bucket); err != nil {
+				return fmt.Errorf("failed to create bucket %s: %w", bucket, err)
+			}
+		}
+
+		// The latest posting event for a transaction carries the entries it posted
+		posted := make(map[string][]Entry)
+		var order []string
+		c := tx.Bucket(BucketEvents).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbEvent := &pb.JournalEvent{}
+			if err := proto.Unmarshal(v, pbEvent); err != nil {
+				return fmt.Errorf("failed to unmarshal event: %w", err)
+			}
+			if pbEvent.EventType != EventPostTransaction {
+				continue
+			}
+			var payload TransactionPostedEvent
+			if err := json.Unmarshal(pbEvent.Payload, &payload); err != nil {
+				return fmt.Errorf("failed to unmarshal transaction posted event: %w", err)
+			}
+			if _, seen := posted[payload.TransactionID]; !seen {
+				order = append(order, payload.TransactionID)
+			}
+			posted[payload.TransactionID] = payload.Entries
+		}
+
+		transactions := tx.Bucket(BucketTransactions)
+		for _, txnID := range order {
+			data := transactions.Get([]byte(txnID))
+			if data == nil {
+				continue
+			}
+			pbTxn := &pb.Transaction{}
+			if err := proto.Unmarshal(data, pbTxn); err != nil {
+				return fmt.Errorf("failed to unmarshal transaction: %w", err)
+			}
+			txn := TransactionFromProto(pbTxn)
+			if txn.Status != Posted {
+				continue
+			}
+			effect := &Transaction{ID: txn.ID, ValidTime: txn.ValidTime, Entries: posted[txnID]}
+			if err := applyLedgerEffect(tx, effect, 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RebuildEntryIndex discards the entry index and rebuilds it from the entries carried
+// by the posting events, so every entry ever posted is indexed as last posted
+func (s *Storage) RebuildEntryIndex() error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket(BucketEntries); err != nil {
+			return fmt.Errorf("failed to clear bucket %s: %w", BucketEntries, err)
+		}
+		entries, err := tx.CreateBucket(BucketEntries)
+		if err != nil {
+			return fmt.Errorf("failed to create bucket %s: %w", BucketEntries, err)
+		}
+
+		c := tx.Bucket(BucketEvents).Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbEvent := &pb.JournalEvent{}
+			if err := proto.Unmarshal(v, pbEvent); err != nil {
+				return fmt.Errorf("failed to unmarshal event: %w", err)
+			}
+			if pbEvent.EventType != EventPostTransaction {
+				continue
+			}
+			var payload TransactionPostedEvent
+			if err := json.Unmarshal(pbEvent.Payload, &payload); err != nil {
+				return fmt.Errorf("failed to unmarshal transaction posted event: %w", err)
+			}
+			for _, entry := range payload.Entries {
+				data, err := proto.Marshal(entry.ToProto())
+				if err != nil {
+					return fmt.Errorf("failed to marshal entry %s: %w", entry.ID, err)
+				}
+				if err := entries.Put([]byte(entry.ID), data); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Posting Index Storage Methods
+// ----------------------------------------------------------------------------
+
+// postingStreamBatch is how many transactions a streaming read loads per bolt read
+// transaction
+const postingStreamBatch = 500
+
+// ForEachPostedTransaction calls fn for each posted transaction with a valid time from
+// from to to inclusive, in valid-time order. A zero from means since inception.
+// Transactions are read in batches and no read transaction is held while fn runs, so
+// memory stays bounded however large the ledger.
+func (s *Storage) ForEachPostedTransaction(from, to time.Time, fn func(*Transaction) error) error {
+	return s.streamPostings(BucketPostingsByTime, nil, from, to, fn)
+}
+
+// ForEachAccountPosting calls fn, in valid-time order, for each posted transaction
+// with an entry on the account and a valid time from from to to inclusive. It reads
+// in batches like ForEachPostedTransaction.
+func (s *Storage) ForEachAccountPosting(accountID string, from, to time.Time, fn func(*Transaction) error) error {
+	return s.streamPostings(BucketPostingsByAccount, []byte(accountID+"\x00"), from, to, fn)
+}
+
+// streamPostings walks a posting index between two valid times, loading the indexed
+// transactions a batch at a time
+func (s *Storage) streamPostings(bucket, prefix []byte, from, to time.Time, fn func(*Transaction) error) error {
+	seek := append(append([]byte{}, prefix...), from.UTC().Format(balanceMovementLayout)...)
+	// Keys continue with "\x00" after the valid time, so this bound keeps every key at to
+	end := append(append([]byte{}, prefix...), to.UTC().Format(balanceMovementLayout)+"\x01"...)
+	resume := false
+
+	for {
+		var batch []*Transaction
+		err := s.db.View(func(tx *bbolt.Tx) error {
+			transactions := tx.Bucket(BucketTransactions)
+			c := tx.Bucket(bucket).Cursor()
+			k, _ := c.Seek(seek)
+			if resume && bytes.Equal(k, seek) {
+				k, _ = c.Next()
+			}
+			for ; k != nil && bytes.Compare(k, end) < 0 && len(batch) < postingStreamBatch; k, _ = c.Next() {
+				seek = append(seek[:0], k...)
+				data := transactions.Get(k[bytes.LastIndexByte(k, 0)+1:])
+				if data == nil {
+					continue
+				}
+				pbTxn := &pb.Transaction{}
+				if err := proto.Unmarshal(data, pbTxn); err != nil {
+					return fmt.Errorf("failed to unmarshal transaction: %w", err)
+				}
+				batch = append(batch, TransactionFromProto(pbTxn))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, txn := range batch {
+			if err := fn(txn); err != nil {
+				return err
+			}
+		}
+		if len(batch) < postingStreamBatch {
+			return nil
+		}
+		resume = true
+	}
+}
+
+// ----------------------------------------------------------------------------
+// PSP Settlement Storage Methods
+// ----------------------------------------------------------------------------
+
+// SavePSPProvider saves a PSP provider
+func (s *Storage) SavePSPProvider(provider *PSPProvider) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPSPProviders)
+		data, err := proto.Marshal(provider.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal PSP provider: %w", err)
+		}
+		return b.Put([]byte(provider.ID), data)
+	})
+}
+
+// GetPSPProvider retrieves a PSP provider by ID
+func (s *Storage) GetPSPProvider(id string) (*PSPProvider, error) {
+	var provider *PSPProvider
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPSPProviders)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("PSP provider", id)
+		}
+
+		pbItem := &pb.PSPProvider{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal PSP provider: %w", err)
+		}
+		provider = PSPProviderFromProto(pbItem)
+		return nil
+	})
+
+	return provider, err
+}
+
+// GetAllPSPProviders retrieves all PSP providers
+func (s *Storage) GetAllPSPProviders() ([]*PSPProvider, error) {
+	var items []*PSPProvider
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketPSPProviders)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.PSPProvider{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal PSP provider: %w", err)
+			}
+			items = append(items, PSPProviderFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SavePSPSettlementBatch saves a PSP settlement batch
+func (s *Storage) SavePSPSettlementBatch(batch *PSPSettlementBatch) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPSPSettlementBatches)
+		data, err := proto.Marshal(batch.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal PSP settlement batch: %w", err)
+		}
+		return b.Put([]byte(batch.ID), data)
+	})
+}
+
+// GetPSPSettlementBatch retrieves a PSP settlement batch by ID
+func (s *Storage) GetPSPSettlementBatch(id string) (*PSPSettlementBatch, error) {
+	var batch *PSPSettlementBatch
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPSPSettlementBatches)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("PSP settlement batch", id)
+		}
+
+		pbItem := &pb.PSPSettlementBatch{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal PSP settlement batch: %w", err)
+		}
+		batch = PSPSettlementBatchFromProto(pbItem)
+		return nil
+	})
+
+	return batch, err
+}
+
+// GetAllPSPSettlementBatches retrieves all PSP settlement batchs
+func (s *Storage) GetAllPSPSettlementBatches() ([]*PSPSettlementBatch, error) {
+	var items []*PSPSettlementBatch
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketPSPSettlementBatches)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.PSPSettlementBatch{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal PSP settlement batch: %w", err)
+			}
+			items = append(items, PSPSettlementBatchFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Merchant Reserve Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveMerchantReserve saves a merchant reserve
+func (s *Storage) SaveMerchantReserve(reserve *MerchantReserve) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketMerchantReserves)
+		data, err := proto.Marshal(reserve.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal merchant reserve: %w", err)
+		}
+		return b.Put([]byte(reserve.ID), data)
+	})
+}
+
+// GetMerchantReserve retrieves a merchant reserve by ID
+func (s *Storage) GetMerchantReserve(id string) (*MerchantReserve, error) {
+	var reserve *MerchantReserve
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketMerchantReserves)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("merchant reserve", id)
+		}
+
+		pbItem := &pb.MerchantReserve{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal merchant reserve: %w", err)
+		}
+		reserve = MerchantReserveFromProto(pbItem)
+		return nil
+	})
+
+	return reserve, err
+}
+
+// GetAllMerchantReserves retrieves all merchant reserves
+func (s *Storage) GetAllMerchantReserves() ([]*MerchantReserve, error) {
+	var items []*MerchantReserve
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketMerchantReserves)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.MerchantReserve{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal merchant reserve: %w", err)
+			}
+			items = append(items, MerchantReserveFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveReserveHold saves a reserve hold
+func (s *Storage) SaveReserveHold(hold *ReserveHold) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketReserveHolds)
+		data, err := proto.Marshal(hold.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal reserve hold: %w", err)
+		}
+		return b.Put([]byte(hold.ID), data)
+	})
+}
+
+// GetReserveHold retrieves a reserve hold by ID
+func (s *Storage) GetReserveHold(id string) (*ReserveHold, error) {
+	var hold *ReserveHold
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketReserveHolds)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("reserve hold", id)
+		}
+
+		pbItem := &pb.ReserveHold{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal reserve hold: %w", err)
+		}
+		hold = ReserveHoldFromProto(pbItem)
+		return nil
+	})
+
+	return hold, err
+}
+
+// GetAllReserveHolds retrieves all reserve holds
+func (s *Storage) GetAllReserveHolds() ([]*ReserveHold, error) {
+	var items []*ReserveHold
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketReserveHolds)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.ReserveHold{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal reserve hold: %w", err)
+			}
+			items = append(items, ReserveHoldFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Fee Schedule Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveFeeSchedule saves a fee schedule
+func (s *Storage) SaveFeeSchedule(schedule *FeeSchedule) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketFeeSchedules)
+		data, err := proto.Marshal(schedule.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal fee schedule: %w", err)
+		}
+		return b.Put([]byte(schedule.ID), data)
+	})
+}
+
+// GetFeeSchedule retrieves a fee schedule by ID
+func (s *Storage) GetFeeSchedule(id string) (*FeeSchedule, error) {
+	var schedule *FeeSchedule
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketFeeSchedules)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("fee schedule", id)
+		}
+
+		pbItem := &pb.FeeSchedule{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal fee schedule: %w", err)
+		}
+		schedule = FeeScheduleFromProto(pbItem)
+		return nil
+	})
+
+	return schedule, err
+}
+
+// GetAllFeeSchedules retrieves all fee schedules
+func (s *Storage) GetAllFeeSchedules() ([]*FeeSchedule, error) {
+	var items []*FeeSchedule
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketFeeSchedules)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.FeeSchedule{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal fee schedule: %w", err)
+			}
+			items = append(items, FeeScheduleFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveFeeCharge saves a fee charge
+func (s *Storage) SaveFeeCharge(charge *FeeCharge) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketFeeCharges)
+		data, err := proto.Marshal(charge.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal fee charge: %w", err)
+		}
+		return b.Put([]byte(charge.ID), data)
+	})
+}
+
+// GetFeeCharge retrieves a fee charge by ID
+func (s *Storage) GetFeeCharge(id string) (*FeeCharge, error) {
+	var charge *FeeCharge
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketFeeCharges)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("fee charge", id)
+		}
+
+		pbItem := &pb.FeeCharge{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal fee charge: %w", err)
+		}
+		charge = FeeChargeFromProto(pbItem)
+		return nil
+	})
+
+	return charge, err
+}
+
+// GetAllFeeCharges retrieves all fee charges
+func (s *Storage) GetAllFeeCharges() ([]*FeeCharge, error) {
+	var items []*FeeCharge
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketFeeCharges)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.FeeCharge{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal fee charge: %w", err)
+			}
+			items = append(items, FeeChargeFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveFeeReconciliation saves a fee reconciliation
+func (s *Storage) SaveFeeReconciliation(recon *FeeReconciliation) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketFeeReconciliations)
+		data, err := proto.Marshal(recon.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal fee reconciliation: %w", err)
+		}
+		return b.Put([]byte(recon.ID), data)
+	})
+}
+
+// GetFeeReconciliation retrieves a fee reconciliation by ID
+func (s *Storage) GetFeeReconciliation(id string) (*FeeReconciliation, error) {
+	var recon *FeeReconciliation
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketFeeReconciliations)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("fee reconciliation", id)
+		}
+
+		pbItem := &pb.FeeReconciliation{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal fee reconciliation: %w", err)
+		}
+		recon = FeeReconciliationFromProto(pbItem)
+		return nil
+	})
+
+	return recon, err
+}
+
+// GetAllFeeReconciliations retrieves all fee reconciliations
+func (s *Storage) GetAllFeeReconciliations() ([]*FeeReconciliation, error) {
+	var items []*FeeReconciliation
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketFeeReconciliations)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.FeeReconciliation{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal fee reconciliation: %w", err)
+			}
+			items = append(items, FeeReconciliationFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Period Close Control Storage Methods
+// ----------------------------------------------------------------------------
+
+// SavePeriodCloseChecklist saves a period close checklist
+func (s *Storage) SavePeriodCloseChecklist(checklist *PeriodCloseChecklist) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPeriodCloseChecklists)
+		data, err := proto.Marshal(checklist.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal period close checklist: %w", err)
+		}
+		return b.Put([]byte(checklist.ID), data)
+	})
+}
+
+// GetPeriodCloseChecklist retrieves a period close checklist by ID
+func (s *Storage) GetPeriodCloseChecklist(id string) (*PeriodCloseChecklist, error) {
+	var checklist *PeriodCloseChecklist
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPeriodCloseChecklists)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("period close checklist", id)
+		}
+
+		pbItem := &pb.PeriodCloseChecklist{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal period close checklist: %w", err)
+		}
+		checklist = PeriodCloseChecklistFromProto(pbItem)
+		return nil
+	})
+
+	return checklist, err
+}
+
+// GetAllPeriodCloseChecklists retrieves all period close checklists
+func (s *Storage) GetAllPeriodCloseChecklists() ([]*PeriodCloseChecklist, error) {
+	var items []*PeriodCloseChecklist
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketPeriodCloseChecklists)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.PeriodCloseChecklist{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal period close checklist: %w", err)
+			}
+			items = append(items, PeriodCloseChecklistFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SavePeriodReopening saves a period reopening
+func (s *Storage) SavePeriodReopening(reopening *PeriodReopening) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPeriodReopenings)
+		data, err := proto.Marshal(reopening.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal period reopening: %w", err)
+		}
+		return b.Put([]byte(reopening.ID), data)
+	})
+}
+
+// GetPeriodReopening retrieves a period reopening by ID
+func (s *Storage) GetPeriodReopening(id string) (*PeriodReopening, error) {
+	var reopening *PeriodReopening
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPeriodReopenings)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("period reopening", id)
+		}
+
+		pbItem := &pb.PeriodReopening{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal period reopening: %w", err)
+		}
+		reopening = PeriodReopeningFromProto(pbItem)
+		return nil
+	})
+
+	return reopening, err
+}
+
+// GetAllPeriodReopenings retrieves all period reopenings
+func (s *Storage) GetAllPeriodReopenings() ([]*PeriodReopening, error) {
+	var items []*PeriodReopening
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketPeriodReopenings)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.PeriodReopening{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal period reopening: %w", err)
+			}
+			items = append(items, PeriodReopeningFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Regulatory Reporting Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveRegulatoryReturn saves a regulatory return
+func (s *Storage) SaveRegulatoryReturn(ret *RegulatoryReturn) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRegulatoryReturns)
+		data, err := proto.Marshal(ret.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal regulatory return: %w", err)
+		}
+		return b.Put([]byte(ret.ID), data)
+	})
+}
+
+// GetRegulatoryReturn retrieves a regulatory return by ID
+func (s *Storage) GetRegulatoryReturn(id string) (*RegulatoryReturn, error) {
+	var ret *RegulatoryReturn
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRegulatoryReturns)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("regulatory return", id)
+		}
+
+		pbItem := &pb.RegulatoryReturn{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal regulatory return: %w", err)
+		}
+		ret = RegulatoryReturnFromProto(pbItem)
+		return nil
+	})
+
+	return ret, err
+}
+
+// GetAllRegulatoryReturns retrieves all regulatory returns
+func (s *Storage) GetAllRegulatoryReturns() ([]*RegulatoryReturn, error) {
+	var items []*RegulatoryReturn
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketRegulatoryReturns)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.RegulatoryReturn{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal regulatory return: %w", err)
+			}
+			items = append(items, RegulatoryReturnFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveRegulatoryReturnDataset saves a regulatory return dataset
+func (s *Storage) SaveRegulatoryReturnDataset(dataset *RegulatoryReturnDataset) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRegulatoryDatasets)
+		data, err := proto.Marshal(dataset.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal regulatory return dataset: %w", err)
+		}
+		return b.Put([]byte(dataset.ID), data)
+	})
+}
+
+// GetRegulatoryReturnDataset retrieves a regulatory return dataset by ID
+func (s *Storage) GetRegulatoryReturnDataset(id string) (*RegulatoryReturnDataset, error) {
+	var dataset *RegulatoryReturnDataset
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRegulatoryDatasets)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("regulatory return dataset", id)
+		}
+
+		pbItem := &pb.RegulatoryReturnDataset{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal regulatory return dataset: %w", err)
+		}
+		dataset = RegulatoryReturnDatasetFromProto(pbItem)
+		return nil
+	})
+
+	return dataset, err
+}
+
+// GetAllRegulatoryReturnDatasets retrieves all regulatory return datasets
+func (s *Storage) GetAllRegulatoryReturnDatasets() ([]*RegulatoryReturnDataset, error) {
+	var items []*RegulatoryReturnDataset
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketRegulatoryDatasets)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.RegulatoryReturnDataset{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal regulatory return dataset: %w", err)
+			}
+			items = append(items, RegulatoryReturnDatasetFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Year-End Close Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveFiscalYearClose saves a fiscal year close
+func (s *Storage) SaveFiscalYearClose(fy *FiscalYearClose) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketFiscalYearCloses)
+		data, err := proto.Marshal(fy.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal fiscal year close: %w", err)
+		}
+		return b.Put([]byte(fy.ID), data)
+	})
+}
+
+// GetFiscalYearClose retrieves a fiscal year close by ID
+func (s *Storage) GetFiscalYearClose(id string) (*FiscalYearClose, error) {
+	var fy *FiscalYearClose
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketFiscalYearCloses)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("fiscal year close", id)
+		}
+
+		pbItem := &pb.FiscalYearClose{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal fiscal year close: %w", err)
+		}
+		fy = FiscalYearCloseFromProto(pbItem)
+		return nil
+	})
+
+	return fy, err
+}
+
+// GetAllFiscalYearCloses retrieves all fiscal year closes
+func (s *Storage) GetAllFiscalYearCloses() ([]*FiscalYearClose, error) {
+	var items []*FiscalYearClose
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketFiscalYearCloses)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.FiscalYearClose{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal fiscal year close: %w", err)
+			}
+			items = append(items, FiscalYearCloseFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Master Data Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveCustomer saves a customer
+func (s *Storage) SaveCustomer(customer *Customer) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketCustomers)
+		data, err := proto.Marshal(customer.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal customer: %w", err)
+		}
+		return b.Put([]byte(customer.ID), data)
+	})
+}
+
+// GetCustomer retrieves a customer by ID
+func (s *Storage) GetCustomer(id string) (*Customer, error) {
+	var customer *Customer
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketCustomers)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("customer", id)
+		}
+
+		pbItem := &pb.Customer{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal customer: %w", err)
+		}
+		customer = CustomerFromProto(pbItem)
+		return nil
+	})
+
+	return customer, err
+}
+
+// GetAllCustomers retrieves all customers
+func (s *Storage) GetAllCustomers() ([]*Customer, error) {
+	var items []*Customer
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketCustomers)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.Customer{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal customer: %w", err)
+			}
+			items = append(items, CustomerFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SavePartyMerge saves a party merge
+func (s *Storage) SavePartyMerge(merge *PartyMerge) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPartyMerges)
+		data, err := proto.Marshal(merge.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal party merge: %w", err)
+		}
+		return b.Put([]byte(merge.ID), data)
+	})
+}
+
+// CommitPartyMerge saves a merge together with every record it re-points in a
+// single bolt transaction. Posted entries of the re-pointed transactions are
+// re-pointed too, so entry reads see the survivor as the counterparty.
+func (s *Storage) CommitPartyMerge(merge *PartyMerge, writes *partyMergeWrites) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		put := func(bucket []byte, id string, message proto.Message) error {
+			data, err := proto.Marshal(message)
+			if err != nil {
+				return fmt.Errorf("failed to marshal %s %s: %w", bucket, id, err)
+			}
+			return tx.Bucket(bucket).Put([]byte(id), data)
+		}
+
+		for _, customer := range writes.customers {
+			if err := put(BucketCustomers, customer.ID, customer.ToProto()); err != nil {
+				return err
+			}
+		}
+		for _, vendor := range writes.vendors {
+			if err := put(BucketVendors, vendor.ID, vendor.ToProto()); err != nil {
+				return err
+			}
+		}
+		for _, plan := range writes.plans {
+			if err := put(BucketInstallmentPlans, plan.ID, plan.ToProto()); err != nil {
+				return err
+			}
+		}
+		for _, card := range writes.giftCards {
+			if err := put(BucketGiftCards, card.ID, card.ToProto()); err != nil {
+				return err
+			}
+		}
+		for _, bill := range writes.bills {
+			if err := put(BucketVendorBills, bill.ID, bill.ToProto()); err != nil {
+				return err
+			}
+		}
+
+		entries := tx.Bucket(BucketEntries)
+		for _, txn := range writes.transactions {
+			if err := saveTransactionTx(tx, txn); err != nil {
+				return fmt.Errorf("failed to save transaction %s: %w", txn.ID, err)
+			}
+			for _, entry := range txn.Entries {
+				data := entries.Get([]byte(entry.ID))
+				if data == nil {
+					continue
+				}
+				pbEntry := &pb.Entry{}
+				if err := proto.Unmarshal(data, pbEntry); err != nil {
+					return fmt.Errorf("failed to unmarshal entry: %w", err)
+				}
+				stored := []Entry{*EntryFromProto(pbEntry)}
+				if !repointCounterparty(stored, merge) {
+					continue
+				}
+				if err := put(BucketEntries, entry.ID, stored[0].ToProto()); err != nil {
+					return err
+				}
+			}
+		}
+
+		return put(BucketPartyMerges, merge.ID, merge.ToProto())
+	})
+}
+
+// GetPartyMerge retrieves a party merge by ID
+func (s *Storage) GetPartyMerge(id string) (*PartyMerge, error) {
+	var merge *PartyMerge
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketPartyMerges)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("party merge", id)
+		}
+
+		pbItem := &pb.PartyMerge{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal party merge: %w", err)
+		}
+		merge = PartyMergeFromProto(pbItem)
+		return nil
+	})
+
+	return merge, err
+}
+
+// GetAllPartyMerges retrieves all party merges
+func (s *Storage) GetAllPartyMerges() ([]*PartyMerge, error) {
+	var items []*PartyMerge
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketPartyMerges)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.PartyMerge{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal party merge: %w", err)
+			}
+			items = append(items, PartyMergeFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Reclassification Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveReclassBatch saves a reclassification batch
+func (s *Storage) SaveReclassBatch(batch *ReclassBatch) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketReclassBatches)
+		data, err := proto.Marshal(batch.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal reclassification batch: %w", err)
+		}
+		return b.Put([]byte(batch.ID), data)
+	})
+}
+
+// GetReclassBatch retrieves a reclassification batch by ID
+func (s *Storage) GetReclassBatch(id string) (*ReclassBatch, error) {
+	var batch *ReclassBatch
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketReclassBatches)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("reclassification batch", id)
+		}
+
+		pbItem := &pb.ReclassBatch{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal reclassification batch: %w", err)
+		}
+		batch = ReclassBatchFromProto(pbItem)
+		return nil
+	})
+
+	return batch, err
+}
+
+// GetAllReclassBatches retrieves all reclassification batchs
+func (s *Storage) GetAllReclassBatches() ([]*ReclassBatch, error) {
+	var items []*ReclassBatch
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketReclassBatches)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.ReclassBatch{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal reclassification batch: %w", err)
+			}
+			items = append(items, ReclassBatchFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Dimension Registry Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveDimensionDefinition saves a dimension definition
+func (s *Storage) SaveDimensionDefinition(def *DimensionDefinition) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDimensionDefinitions)
+		data, err := proto.Marshal(def.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal dimension definition: %w", err)
+		}
+		return b.Put([]byte(def.Key), data)
+	})
+}
+
+// GetDimensionDefinition retrieves a dimension definition by key
+func (s *Storage) GetDimensionDefinition(key string) (*DimensionDefinition, error) {
+	var def *DimensionDefinition
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDimensionDefinitions)
+		data := b.Get([]byte(key))
+		if data == nil {
+			return notFound("dimension definition", key)
+		}
+
+		pbItem := &pb.DimensionDefinition{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal dimension definition: %w", err)
+		}
+		def = DimensionDefinitionFromProto(pbItem)
+		return nil
+	})
+
+	return def, err
+}
+
+// GetAllDimensionDefinitions retrieves all dimension definitions
+func (s *Storage) GetAllDimensionDefinitions() ([]*DimensionDefinition, error) {
+	var items []*DimensionDefinition
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketDimensionDefinitions)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.DimensionDefinition{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal dimension definition: %w", err)
+			}
+			items = append(items, DimensionDefinitionFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Access Control Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveRole saves a role
+func (s *Storage) SaveRole(role *Role) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRoles)
+		data, err := proto.Marshal(role.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal role: %w", err)
+		}
+		return b.Put([]byte(role.ID), data)
+	})
+}
+
+// GetRole retrieves a role by ID
+func (s *Storage) GetRole(id string) (*Role, error) {
+	var role *Role
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRoles)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("role", id)
+		}
+
+		pbItem := &pb.Role{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal role: %w", err)
+		}
+		role = RoleFromProto(pbItem)
+		return nil
+	})
+
+	return role, err
+}
+
+// GetAllRoles retrieves all roles
+func (s *Storage) GetAllRoles() ([]*Role, error) {
+	var items []*Role
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketRoles)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.Role{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal role: %w", err)
+			}
+			items = append(items, RoleFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveUser saves a user
+func (s *Storage) SaveUser(user *User) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketUsers)
+		data, err := proto.Marshal(user.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal user: %w", err)
+		}
+		return b.Put([]byte(user.ID), data)
+	})
+}
+
+// GetUser retrieves a user by ID
+func (s *Storage) GetUser(id string) (*User, error) {
+	var user *User
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketUsers)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("user", id)
+		}
+
+		pbItem := &pb.User{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal user: %w", err)
+		}
+		user = UserFromProto(pbItem)
+		return nil
+	})
+
+	return user, err
+}
+
+// GetAllUsers retrieves all users
+func (s *Storage) GetAllUsers() ([]*User, error) {
+	var items []*User
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketUsers)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.User{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal user: %w", err)
+			}
+			items = append(items, UserFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Document Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveDocument saves a document
+func (s *Storage) SaveDocument(document *Document) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDocuments)
+		data, err := proto.Marshal(document.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal document: %w", err)
+		}
+		return b.Put([]byte(document.ID), data)
+	})
+}
+
+// GetDocument retrieves a document by ID
+func (s *Storage) GetDocument(id string) (*Document, error) {
+	var document *Document
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDocuments)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("document", id)
+		}
+
+		pbItem := &pb.Document{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal document: %w", err)
+		}
+		document = DocumentFromProto(pbItem)
+		return nil
+	})
+
+	return document, err
+}
+
+// GetAllDocuments retrieves all documents
+func (s *Storage) GetAllDocuments() ([]*Document, error) {
+	var items []*Document
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketDocuments)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.Document{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal document: %w", err)
+			}
+			items = append(items, DocumentFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveDocumentContent stores a document's bytes under their checksum. Content already
+// stored is left as it is, so identical files are kept once.
+func (s *Storage) SaveDocumentContent(checksum string, content []byte) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDocumentContents)
+		if b.Get([]byte(checksum)) != nil {
+			return nil
+		}
+		return b.Put([]byte(checksum), content)
+	})
+}
+
+// GetDocumentContent retrieves a document's bytes by checksum
+func (s *Storage) GetDocumentContent(checksum string) ([]byte, error) {
+	var content []byte
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(BucketDocumentContents).Get([]byte(checksum))
+		if data == nil {
+			return notFound("document content", checksum)
+		}
+		content = bytes.Clone(data)
+		return nil
+	})
+
+	return content, err
+}
+
+// ----------------------------------------------------------------------------
+// Script Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveScript saves a script
+func (s *Storage) SaveScript(script *Script) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketScripts)
+		data, err := proto.Marshal(script.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal script: %w", err)
+		}
+		return b.Put([]byte(script.ID), data)
+	})
+}
+
+// GetScript retrieves a script by ID
+func (s *Storage) GetScript(id string) (*Script, error) {
+	var script *Script
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketScripts)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("script", id)
+		}
+
+		pbItem := &pb.Script{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal script: %w", err)
+		}
+		script = ScriptFromProto(pbItem)
+		return nil
+	})
+
+	return script, err
+}
+
+// GetAllScripts retrieves all scripts
+func (s *Storage) GetAllScripts() ([]*Script, error) {
+	var items []*Script
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketScripts)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.Script{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal script: %w", err)
+			}
+			items = append(items, ScriptFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Statement Template Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveStatementTemplate saves a statement template
+func (s *Storage) SaveStatementTemplate(template *StatementTemplate) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketStatementTemplates)
+		data, err := proto.Marshal(template.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal statement template: %w", err)
+		}
+		return b.Put([]byte(template.ID), data)
+	})
+}
+
+// GetStatementTemplate retrieves a statement template by ID
+func (s *Storage) GetStatementTemplate(id string) (*StatementTemplate, error) {
+	var template *StatementTemplate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketStatementTemplates)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("statement template", id)
+		}
+
+		pbItem := &pb.StatementTemplate{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal statement template: %w", err)
+		}
+		template = StatementTemplateFromProto(pbItem)
+		return nil
+	})
+
+	return template, err
+}
+
+// GetAllStatementTemplates retrieves all statement templates
+func (s *Storage) GetAllStatementTemplates() ([]*StatementTemplate, error) {
+	var items []*StatementTemplate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketStatementTemplates)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.StatementTemplate{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal statement template: %w", err)
+			}
+			items = append(items, StatementTemplateFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Narrative Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveNarrativeTemplate saves a narrative template
+func (s *Storage) SaveNarrativeTemplate(tmpl *NarrativeTemplate) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketNarrativeTemplates)
+		data, err := proto.Marshal(tmpl.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal narrative template: %w", err)
+		}
+		return b.Put([]byte(tmpl.ID), data)
+	})
+}
+
+// GetNarrativeTemplate retrieves a narrative template by ID
+func (s *Storage) GetNarrativeTemplate(id string) (*NarrativeTemplate, error) {
+	var tmpl *NarrativeTemplate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketNarrativeTemplates)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("narrative template", id)
+		}
+
+		pbItem := &pb.NarrativeTemplate{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal narrative template: %w", err)
+		}
+		tmpl = NarrativeTemplateFromProto(pbItem)
+		return nil
+	})
+
+	return tmpl, err
+}
+
+// GetAllNarrativeTemplates retrieves all narrative templates
+func (s *Storage) GetAllNarrativeTemplates() ([]*NarrativeTemplate, error) {
+	var items []*NarrativeTemplate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketNarrativeTemplates)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.NarrativeTemplate{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal narrative template: %w", err)
+			}
+			items = append(items, NarrativeTemplateFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveNarrative saves a narrative
+func (s *Storage) SaveNarrative(narrative *Narrative) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketNarratives)
+		data, err := proto.Marshal(narrative.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal narrative: %w", err)
+		}
+		return b.Put([]byte(narrative.ID), data)
+	})
+}
+
+// GetNarrative retrieves a narrative by ID
+func (s *Storage) GetNarrative(id string) (*Narrative, error) {
+	var narrative *Narrative
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketNarratives)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("narrative", id)
+		}
+
+		pbItem := &pb.Narrative{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal narrative: %w", err)
+		}
+		narrative = NarrativeFromProto(pbItem)
+		return nil
+	})
+
+	return narrative, err
+}
+
+// GetAllNarratives retrieves all narratives
+func (s *Storage) GetAllNarratives() ([]*Narrative, error) {
+	var items []*Narrative
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketNarratives)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.Narrative{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal narrative: %w", err)
+			}
+			items = append(items, NarrativeFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Ledger Posting Rule Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveLedgerPostingRule saves a ledger posting rule
+func (s *Storage) SaveLedgerPostingRule(rule *LedgerPostingRule) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketLedgerPostingRules)
+		data, err := proto.Marshal(rule.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal ledger posting rule: %w", err)
+		}
+		return b.Put([]byte(rule.ID), data)
+	})
+}
+
+// GetLedgerPostingRule retrieves a ledger posting rule by ID
+func (s *Storage) GetLedgerPostingRule(id string) (*LedgerPostingRule, error) {
+	var rule *LedgerPostingRule
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketLedgerPostingRules)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("ledger posting rule", id)
+		}
+
+		pbItem := &pb.LedgerPostingRule{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal ledger posting rule: %w", err)
+		}
+		rule = LedgerPostingRuleFromProto(pbItem)
+		return nil
+	})
+
+	return rule, err
+}
+
+// GetAllLedgerPostingRules retrieves all ledger posting rules
+func (s *Storage) GetAllLedgerPostingRules() ([]*LedgerPostingRule, error) {
+	var items []*LedgerPostingRule
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketLedgerPostingRules)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.LedgerPostingRule{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal ledger posting rule: %w", err)
+			}
+			items = append(items, LedgerPostingRuleFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Operating Segment Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveOperatingSegment saves a operating segment
+func (s *Storage) SaveOperatingSegment(segment *OperatingSegment) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketOperatingSegments)
+		data, err := proto.Marshal(segment.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal operating segment: %w", err)
+		}
+		return b.Put([]byte(segment.ID), data)
+	})
+}
+
+// GetOperatingSegment retrieves a operating segment by ID
+func (s *Storage) GetOperatingSegment(id string) (*OperatingSegment, error) {
+	var segment *OperatingSegment
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketOperatingSegments)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("operating segment", id)
+		}
+
+		pbItem := &pb.OperatingSegment{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal operating segment: %w", err)
+		}
+		segment = OperatingSegmentFromProto(pbItem)
+		return nil
+	})
+
+	return segment, err
+}
+
+// GetAllOperatingSegments retrieves all operating segments
+func (s *Storage) GetAllOperatingSegments() ([]*OperatingSegment, error) {
+	var items []*OperatingSegment
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketOperatingSegments)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.OperatingSegment{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal operating segment: %w", err)
+			}
+			items = append(items, OperatingSegmentFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveSegmentAllocationRule saves a segment allocation rule
+func (s *Storage) SaveSegmentAllocationRule(rule *SegmentAllocationRule) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketSegmentAllocationRules)
+		data, err := proto.Marshal(rule.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal segment allocation rule: %w", err)
+		}
+		return b.Put([]byte(rule.ID), data)
+	})
+}
+
+// GetSegmentAllocationRule retrieves a segment allocation rule by ID
+func (s *Storage) GetSegmentAllocationRule(id string) (*SegmentAllocationRule, error) {
+	var rule *SegmentAllocationRule
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketSegmentAllocationRules)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("segment allocation rule", id)
+		}
+
+		pbItem := &pb.SegmentAllocationRule{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal segment allocation rule: %w", err)
+		}
+		rule = SegmentAllocationRuleFromProto(pbItem)
+		return nil
+	})
+
+	return rule, err
+}
+
+// GetAllSegmentAllocationRules retrieves all segment allocation rules
+func (s *Storage) GetAllSegmentAllocationRules() ([]*SegmentAllocationRule, error) {
+	var items []*SegmentAllocationRule
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketSegmentAllocationRules)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.SegmentAllocationRule{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal segment allocation rule: %w", err)
+			}
+			items = append(items, SegmentAllocationRuleFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Report Schedule Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveReportSchedule saves a report schedule
+func (s *Storage) SaveReportSchedule(schedule *ReportSchedule) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketReportSchedules)
+		data, err := proto.Marshal(schedule.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal report schedule: %w", err)
+		}
+		return b.Put([]byte(schedule.ID), data)
+	})
+}
+
+// GetReportSchedule retrieves a report schedule by ID
+func (s *Storage) GetReportSchedule(id string) (*ReportSchedule, error) {
+	var schedule *ReportSchedule
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketReportSchedules)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("report schedule", id)
+		}
+
+		pbItem := &pb.ReportSchedule{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal report schedule: %w", err)
+		}
+		schedule = ReportScheduleFromProto(pbItem)
+		return nil
+	})
+
+	return schedule, err
+}
+
+// GetAllReportSchedules retrieves all report schedules
+func (s *Storage) GetAllReportSchedules() ([]*ReportSchedule, error) {
+	var items []*ReportSchedule
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketReportSchedules)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.ReportSchedule{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal report schedule: %w", err)
+			}
+			items = append(items, ReportScheduleFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveReportRun saves a report run
+func (s *Storage) SaveReportRun(run *ReportRun) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketReportRuns)
+		data, err := proto.Marshal(run.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal report run: %w", err)
+		}
+		return b.Put([]byte(run.ID), data)
+	})
+}
+
+// GetReportRun retrieves a report run by ID
+func (s *Storage) GetReportRun(id string) (*ReportRun, error) {
+	var run *ReportRun
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketReportRuns)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("report run", id)
+		}
+
+		pbItem := &pb.ReportRun{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal report run: %w", err)
+		}
+		run = ReportRunFromProto(pbItem)
+		return nil
+	})
+
+	return run, err
+}
+
+// GetAllReportRuns retrieves all report runs
+func (s *Storage) GetAllReportRuns() ([]*ReportRun, error) {
+	var items []*ReportRun
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketReportRuns)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.ReportRun{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal report run: %w", err)
+			}
+			items = append(items, ReportRunFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Debt Covenant Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveDebtCovenant saves a debt covenant
+func (s *Storage) SaveDebtCovenant(covenant *DebtCovenant) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDebtCovenants)
+		data, err := proto.Marshal(covenant.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal debt covenant: %w", err)
+		}
+		return b.Put([]byte(covenant.ID), data)
+	})
+}
+
+// GetDebtCovenant retrieves a debt covenant by ID
+func (s *Storage) GetDebtCovenant(id string) (*DebtCovenant, error) {
+	var covenant *DebtCovenant
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketDebtCovenants)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("debt covenant", id)
+		}
+
+		pbItem := &pb.DebtCovenant{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal debt covenant: %w", err)
+		}
+		covenant = DebtCovenantFromProto(pbItem)
+		return nil
+	})
+
+	return covenant, err
+}
+
+// GetAllDebtCovenants retrieves all debt covenants
+func (s *Storage) GetAllDebtCovenants() ([]*DebtCovenant, error) {
+	var items []*DebtCovenant
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketDebtCovenants)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.DebtCovenant{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal debt covenant: %w", err)
+			}
+			items = append(items, DebtCovenantFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Notification Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveNotificationSubscription saves a notification subscription
+func (s *Storage) SaveNotificationSubscription(subscription *NotificationSubscription) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketNotificationSubscriptions)
+		data, err := proto.Marshal(subscription.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal notification subscription: %w", err)
+		}
+		return b.Put([]byte(subscription.ID), data)
+	})
+}
+
+// GetNotificationSubscription retrieves a notification subscription by ID
+func (s *Storage) GetNotificationSubscription(id string) (*NotificationSubscription, error) {
+	var subscription *NotificationSubscription
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketNotificationSubscriptions)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("notification subscription", id)
+		}
+
+		pbItem := &pb.NotificationSubscription{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal notification subscription: %w", err)
+		}
+		subscription = NotificationSubscriptionFromProto(pbItem)
+		return nil
+	})
+
+	return subscription, err
+}
+
+// GetAllNotificationSubscriptions retrieves all notification subscriptions
+func (s *Storage) GetAllNotificationSubscriptions() ([]*NotificationSubscription, error) {
+	var items []*NotificationSubscription
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketNotificationSubscriptions)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.NotificationSubscription{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal notification subscription: %w", err)
+			}
+			items = append(items, NotificationSubscriptionFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveNotificationDelivery saves a notification delivery
+func (s *Storage) SaveNotificationDelivery(delivery *NotificationDelivery) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketNotificationDeliveries)
+		data, err := proto.Marshal(delivery.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal notification delivery: %w", err)
+		}
+		return b.Put([]byte(delivery.ID), data)
+	})
+}
+
+// GetNotificationDelivery retrieves a notification delivery by ID
+func (s *Storage) GetNotificationDelivery(id string) (*NotificationDelivery, error) {
+	var delivery *NotificationDelivery
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketNotificationDeliveries)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("notification delivery", id)
+		}
+
+		pbItem := &pb.NotificationDelivery{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal notification delivery: %w", err)
+		}
+		delivery = NotificationDeliveryFromProto(pbItem)
+		return nil
+	})
+
+	return delivery, err
+}
+
+// GetAllNotificationDeliveries retrieves all notification deliverys
+func (s *Storage) GetAllNotificationDeliveries() ([]*NotificationDelivery, error) {
+	var items []*NotificationDelivery
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketNotificationDeliveries)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.NotificationDelivery{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal notification delivery: %w", err)
+			}
+			items = append(items, NotificationDeliveryFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Consistency Check Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveConsistencyRun saves a consistency run
+func (s *Storage) SaveConsistencyRun(run *ConsistencyRun) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketConsistencyRuns)
+		data, err := proto.Marshal(run.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal consistency run: %w", err)
+		}
+		return b.Put([]byte(run.ID), data)
+	})
+}
+
+// GetConsistencyRun retrieves a consistency run by ID
+func (s *Storage) GetConsistencyRun(id string) (*ConsistencyRun, error) {
+	var run *ConsistencyRun
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketConsistencyRuns)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("consistency run", id)
+		}
+
+		pbItem := &pb.ConsistencyRun{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal consistency run: %w", err)
+		}
+		run = ConsistencyRunFromProto(pbItem)
+		return nil
+	})
+
+	return run, err
+}
+
+// GetAllConsistencyRuns retrieves all consistency runs
+func (s *Storage) GetAllConsistencyRuns() ([]*ConsistencyRun, error) {
+	var items []*ConsistencyRun
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketConsistencyRuns)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.ConsistencyRun{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal consistency run: %w", err)
+			}
+			items = append(items, ConsistencyRunFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Workflow Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveWorkflowInstance saves a workflow instance; two approvers acting on the same
+// version cannot both save, the second gets a ConflictError
+func (s *Storage) SaveWorkflowInstance(instance *WorkflowInstance) error {
+	return s.updateVersioned(&instance.Version, func(tx *bbolt.Tx, read int64) error {
+		b := tx.Bucket(BucketWorkflowInstances)
+		if err := checkVersion(b, "workflow instance", instance.ID, read, &pb.WorkflowInstance{}); err != nil {
+			return err
+		}
+		data, err := proto.Marshal(instance.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal workflow instance: %w", err)
+		}
+		return b.Put([]byte(instance.ID), data)
+	})
+}
+
+// GetWorkflowInstance retrieves a workflow instance by ID
+func (s *Storage) GetWorkflowInstance(id string) (*WorkflowInstance, error) {
+	var instance *WorkflowInstance
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketWorkflowInstances)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("workflow instance", id)
+		}
+
+		pbItem := &pb.WorkflowInstance{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal workflow instance: %w", err)
+		}
+		instance = WorkflowInstanceFromProto(pbItem)
+		return nil
+	})
+
+	return instance, err
+}
+
+// GetAllWorkflowInstances retrieves all workflow instances
+func (s *Storage) GetAllWorkflowInstances() ([]*WorkflowInstance, error) {
+	var items []*WorkflowInstance
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketWorkflowInstances)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.WorkflowInstance{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal workflow instance: %w", err)
+			}
+			items = append(items, WorkflowInstanceFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Idempotency Key Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveIdempotencyRecord saves the record of a transaction created with an idempotency key
+func (s *Storage) SaveIdempotencyRecord(record *IdempotencyRecord) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return saveIdempotencyRecordTx(tx, record)
+	})
+}
+
+// SaveIdempotentTransaction saves a transaction created with an idempotency key
+// together with the key's record in a single bolt transaction, so a retry never finds
+// the transaction without its key
+func (s *Storage) SaveIdempotentTransaction(txn *Transaction, record *IdempotencyRecord) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := saveTransactionTx(tx, txn); err != nil {
+			return err
+		}
+		return saveIdempotencyRecordTx(tx, record)
+	})
+}
+
+// saveIdempotencyRecordTx writes an idempotency record within a bolt transaction
+func saveIdempotencyRecordTx(tx *bbolt.Tx, record *IdempotencyRecord) error {
+	data, err := proto.Marshal(record.ToProto())
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+	return tx.Bucket(BucketIdempotencyKeys).Put([]byte(record.Key), data)
+}
+
+// GetIdempotencyRecord retrieves the record for an idempotency key, or nil if the key
+// has not been used
+func (s *Storage) GetIdempotencyRecord(key string) (*IdempotencyRecord, error) {
+	var record *IdempotencyRecord
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(BucketIdempotencyKeys).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+
+		pbRecord := &pb.IdempotencyRecord{}
+		if err := proto.Unmarshal(data, pbRecord); err != nil {
+			return fmt.Errorf("failed to unmarshal idempotency record: %w", err)
+		}
+		record = IdempotencyRecordFromProto(pbRecord)
+		return nil
+	})
+
+	return record, err
+}
+
+// DeleteExpiredIdempotencyRecords removes the idempotency records that expired by
+// asOf, returning how many were removed
+func (s *Storage) DeleteExpiredIdempotencyRecords(asOf time.Time) (int, error) {
+	deleted := 0
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketIdempotencyKeys)
+		var expired [][]byte
+		err := b.ForEach(func(k, v []byte) error {
+			pbRecord := &pb.IdempotencyRecord{}
+			if err := proto.Unmarshal(v, pbRecord); err != nil {
+				return fmt.Errorf("failed to unmarshal idempotency record: %w", err)
+			}
+			if !protoToTime(pbRecord.ExpiresAt).After(asOf) {
+				expired = append(expired, append([]byte(nil), k...))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, key := range expired {
+			if err := b.Delete(key); err != nil {
+				return fmt.Errorf("failed to delete idempotency record: %w", err)
+			}
+		}
+		deleted = len(expired)
+		return nil
+	})
+	return deleted, err
+}
+
+// ----------------------------------------------------------------------------
+// Accrual Template Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveAccrualTemplate saves an accrual template
+func (s *Storage) SaveAccrualTemplate(template *AccrualTemplate) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketAccrualTemplates)
+		data, err := proto.Marshal(template.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal accrual template: %w", err)
+		}
+		return b.Put([]byte(template.ID), data)
+	})
+}
+
+// GetAccrualTemplate retrieves an accrual template by ID
+func (s *Storage) GetAccrualTemplate(id string) (*AccrualTemplate, error) {
+	var template *AccrualTemplate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketAccrualTemplates)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("accrual template", id)
+		}
+
+		pbItem := &pb.AccrualTemplate{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal accrual template: %w", err)
+		}
+		template = AccrualTemplateFromProto(pbItem)
+		return nil
+	})
+
+	return template, err
+}
+
+// GetAllAccrualTemplates retrieves all saved accrual templates
+func (s *Storage) GetAllAccrualTemplates() ([]*AccrualTemplate, error) {
+	var items []*AccrualTemplate
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketAccrualTemplates)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.AccrualTemplate{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal accrual template: %w", err)
+			}
+			items = append(items, AccrualTemplateFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Revenue Contract Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveRevenueContract saves a revenue contract
+func (s *Storage) SaveRevenueContract(contract *RevenueContract) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRevenueContracts)
+		data, err := proto.Marshal(contract.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal revenue contract: %w", err)
+		}
+		return b.Put([]byte(contract.ID), data)
+	})
+}
+
+// GetRevenueContract retrieves a revenue contract by ID
+func (s *Storage) GetRevenueContract(id string) (*RevenueContract, error) {
+	var contract *RevenueContract
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketRevenueContracts)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("revenue contract", id)
+		}
+
+		pbItem := &pb.RevenueContract{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal revenue contract: %w", err)
+		}
+		contract = RevenueContractFromProto(pbItem)
+		return nil
+	})
+
+	return contract, err
+}
+
+// GetAllRevenueContracts retrieves all revenue contracts
+func (s *Storage) GetAllRevenueContracts() ([]*RevenueContract, error) {
+	var items []*RevenueContract
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketRevenueContracts)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.RevenueContract{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal revenue contract: %w", err)
+			}
+			items = append(items, RevenueContractFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Tax Line Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveTaxLineRule saves a tax line rule
+func (s *Storage) SaveTaxLineRule(rule *TaxLineRule) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketTaxLineRules)
+		data, err := proto.Marshal(rule.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal tax line rule: %w", err)
+		}
+		return b.Put([]byte(rule.ID), data)
+	})
+}
+
+// GetTaxLineRule retrieves a tax line rule by ID
+func (s *Storage) GetTaxLineRule(id string) (*TaxLineRule, error) {
+	var rule *TaxLineRule
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketTaxLineRules)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("tax line rule", id)
+		}
+
+		pbItem := &pb.TaxLineRule{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal tax line rule: %w", err)
+		}
+		rule = TaxLineRuleFromProto(pbItem)
+		return nil
+	})
+
+	return rule, err
+}
+
+// GetAllTaxLineRules retrieves all tax line rules
+func (s *Storage) GetAllTaxLineRules() ([]*TaxLineRule, error) {
+	var items []*TaxLineRule
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketTaxLineRules)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.TaxLineRule{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal tax line rule: %w", err)
+			}
+			items = append(items, TaxLineRuleFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveTransactionTaxLines saves the tax lines generated for a transaction
+func (s *Storage) SaveTransactionTaxLines(lines *TransactionTaxLines) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketTransactionTaxLines)
+		data, err := proto.Marshal(lines.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal transaction tax lines: %w", err)
+		}
+		return b.Put([]byte(lines.TransactionID), data)
+	})
+}
+
+// GetTransactionTaxLines retrieves the tax lines generated for a transaction
+func (s *Storage) GetTransactionTaxLines(transactionID string) (*TransactionTaxLines, error) {
+	var lines *TransactionTaxLines
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketTransactionTaxLines)
+		data := b.Get([]byte(transactionID))
+		if data == nil {
+			return notFound("transaction tax lines", transactionID)
+		}
+
+		pbItem := &pb.TransactionTaxLines{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal transaction tax lines: %w", err)
+		}
+		lines = TransactionTaxLinesFromProto(pbItem)
+		return nil
+	})
+
+	return lines, err
+}
+
+// ----------------------------------------------------------------------------
+// VAT Return Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveVATReturnMapping saves a VAT return mapping
+func (s *Storage) SaveVATReturnMapping(mapping *VATReturnMapping) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketVATReturnMappings)
+		data, err := proto.Marshal(mapping.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal VAT return mapping: %w", err)
+		}
+		return b.Put([]byte(mapping.ID), data)
+	})
+}
+
+// GetVATReturnMapping retrieves a VAT return mapping by ID
+func (s *Storage) GetVATReturnMapping(id string) (*VATReturnMapping, error) {
+	var mapping *VATReturnMapping
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketVATReturnMappings)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("VAT return mapping", id)
+		}
+
+		pbItem := &pb.VATReturnMapping{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal VAT return mapping: %w", err)
+		}
+		mapping = VATReturnMappingFromProto(pbItem)
+		return nil
+	})
+
+	return mapping, err
+}
+
+// GetAllVATReturnMappings retrieves all VAT return mappings
+func (s *Storage) GetAllVATReturnMappings() ([]*VATReturnMapping, error) {
+	var items []*VATReturnMapping
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketVATReturnMappings)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.VATReturnMapping{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal VAT return mapping: %w", err)
+			}
+			items = append(items, VATReturnMappingFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Form 1099 Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveVendor1099Payment saves a 1099 payment
+func (s *Storage) SaveVendor1099Payment(payment *Vendor1099Payment) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketVendor1099Payments)
+		data, err := proto.Marshal(payment.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal 1099 payment: %w", err)
+		}
+		return b.Put([]byte(payment.ID), data)
+	})
+}
+
+// vendor1099PaymentKey keys a transaction's payment to one vendor in one currency,
+// so posting hooks that run again find what they recorded
+func vendor1099PaymentKey(transactionID, vendorID string, currency Currency) string {
+	return fmt.Sprintf("%s_%s_%s", transactionID, vendorID, currency)
+}
+
+// HasVendor1099Payment reports whether a transaction's payment to a vendor has already been recorded
+func (s *Storage) HasVendor1099Payment(transactionID, vendorID string, currency Currency) bool {
+	return s.HasKey(BucketVendor1099Payments, vendor1099PaymentKey(transactionID, vendorID, currency))
+}
+
+// GetVendor1099Payment retrieves a 1099 payment by ID
+func (s *Storage) GetVendor1099Payment(id string) (*Vendor1099Payment, error) {
+	var payment *Vendor1099Payment
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketVendor1099Payments)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("1099 payment", id)
+		}
+
+		pbItem := &pb.Vendor1099Payment{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal 1099 payment: %w", err)
+		}
+		payment = Vendor1099PaymentFromProto(pbItem)
+		return nil
+	})
+
+	return payment, err
+}
+
+// GetAllVendor1099Payments retrieves all 1099 payments
+func (s *Storage) GetAllVendor1099Payments() ([]*Vendor1099Payment, error) {
+	var items []*Vendor1099Payment
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketVendor1099Payments)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.Vendor1099Payment{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal 1099 payment: %w", err)
+			}
+			items = append(items, Vendor1099PaymentFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// SOX Control Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveSOXControl saves a SOX control
+func (s *Storage) SaveSOXControl(control *SOXControl) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketSOXControls)
+		data, err := proto.Marshal(control.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal SOX control: %w", err)
+		}
+		return b.Put([]byte(control.ID), data)
+	})
+}
+
+// GetSOXControl retrieves a SOX control by ID
+func (s *Storage) GetSOXControl(id string) (*SOXControl, error) {
+	var control *SOXControl
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketSOXControls)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("SOX control", id)
+		}
+
+		pbItem := &pb.SOXControl{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal SOX control: %w", err)
+		}
+		control = SOXControlFromProto(pbItem)
+		return nil
+	})
+
+	return control, err
+}
+
+// GetAllSOXControls retrieves all SOX controls
+func (s *Storage) GetAllSOXControls() ([]*SOXControl, error) {
+	var items []*SOXControl
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketSOXControls)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.SOXControl{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal SOX control: %w", err)
+			}
+			items = append(items, SOXControlFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// SaveControlTestResult saves a control test result
+func (s *Storage) SaveControlTestResult(result *ControlTestResult) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketControlTestResults)
+		data, err := proto.Marshal(result.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal control test result: %w", err)
+		}
+		return b.Put([]byte(result.ID), data)
+	})
+}
+
+// GetControlTestResult retrieves a control test result by ID
+func (s *Storage) GetControlTestResult(id string) (*ControlTestResult, error) {
+	var result *ControlTestResult
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketControlTestResults)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("control test result", id)
+		}
+
+		pbItem := &pb.ControlTestResult{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal control test result: %w", err)
+		}
+		result = ControlTestResultFromProto(pbItem)
+		return nil
+	})
+
+	return result, err
+}
+
+// GetAllControlTestResults retrieves all control test results
+func (s *Storage) GetAllControlTestResults() ([]*ControlTestResult, error) {
+	var items []*ControlTestResult
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketControlTestResults)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.ControlTestResult{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal control test result: %w", err)
+			}
+			items = append(items, ControlTestResultFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
+
+// ----------------------------------------------------------------------------
+// Framework Adjustment Storage Methods
+// ----------------------------------------------------------------------------
+
+// SaveFrameworkAdjustment saves a framework adjustment
+func (s *Storage) SaveFrameworkAdjustment(adjustment *FrameworkAdjustment) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketFrameworkAdjustments)
+		data, err := proto.Marshal(adjustment.ToProto())
+		if err != nil {
+			return fmt.Errorf("failed to marshal framework adjustment: %w", err)
+		}
+		return b.Put([]byte(adjustment.ID), data)
+	})
+}
+
+// GetFrameworkAdjustment retrieves a framework adjustment by ID
+func (s *Storage) GetFrameworkAdjustment(id string) (*FrameworkAdjustment, error) {
+	var adjustment *FrameworkAdjustment
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(BucketFrameworkAdjustments)
+		data := b.Get([]byte(id))
+		if data == nil {
+			return notFound("framework adjustment", id)
+		}
+
+		pbItem := &pb.FrameworkAdjustment{}
+		if err := proto.Unmarshal(data, pbItem); err != nil {
+			return fmt.Errorf("failed to unmarshal framework adjustment: %w", err)
+		}
+		adjustment = FrameworkAdjustmentFromProto(pbItem)
+		return nil
+	})
+
+	return adjustment, err
+}
+
+// GetAllFrameworkAdjustments retrieves all framework adjustments
+func (s *Storage) GetAllFrameworkAdjustments() ([]*FrameworkAdjustment, error) {
+	var items []*FrameworkAdjustment
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		b := s.db.scanBucket(tx, BucketFrameworkAdjustments)
+		c := b.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			pbItem := &pb.FrameworkAdjustment{}
+			if err := proto.Unmarshal(v, pbItem); err != nil {
+				return fmt.Errorf("failed to unmarshal framework adjustment: %w", err)
+			}
+			items = append(items, FrameworkAdjustmentFromProto(pbItem))
+		}
+		return nil
+	})
+
+	return items, err
+}
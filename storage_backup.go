@@ -0,0 +1,565 @@
+package accounting
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "accounting/proto/accounting"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+// ----------------------------------------------------------------------------
+// Backup and Restore
+// ----------------------------------------------------------------------------
+
+// BackupManifest describes a backup so a restore can prove it got the same database
+type BackupManifest struct {
+	CreatedAt      time.Time  `json:"created_at"`
+	SizeBytes      int64      `json:"size_bytes"`
+	Checksum       string     `json:"checksum"` // hex SHA-256 of the backup
+	Events         int        `json:"events"`
+	EventChainHash string     `json:"event_chain_hash"` // see eventChain
+	LastEventAt    *time.Time `json:"last_event_at,omitempty"`
+}
+
+// EventExport describes an incremental export of the event log
+type EventExport struct {
+	Since     time.Time `json:"since"`
+	Events    int       `json:"events"`
+	PrevHash  string    `json:"prev_hash"`  // chain hash of the events up to Since
+	ChainHash string    `json:"chain_hash"` // chain hash after the last exported event
+}
+
+// RestoreResult describes a restore
+type RestoreResult struct {
+	Events         int           `json:"events"`
+	Transactions   int           `json:"transactions"`
+	EventChainHash string        `json:"event_chain_hash"`
+	PreviousPath   string        `json:"previous_path"` // where the replaced database was kept
+	RestoredAt     time.Time     `json:"restored_at"`
+	Duration       time.Duration `json:"duration"`
+}
+
+// eventChain hashes the event log in key order, each link covering the previous
+// hash and the event's key and stored bytes, so any changed, dropped or reordered
+// event changes every later hash
+type eventChain struct {
+	head   []byte
+	events int
+}
+
+// add links an event onto the chain
+func (c *eventChain) add(key, data []byte) {
+	h := sha256.New()
+	h.Write(c.head)
+	h.Write(key)
+	h.Write(data)
+	c.head = h.Sum(nil)
+	c.events++
+}
+
+// Hash is the hex hash of the chain so far; empty before the first event
+func (c *eventChain) Hash() string {
+	return hex.EncodeToString(c.head)
+}
+
+// resumeEventChain continues a chain from a hash reported by a backup or export
+func resumeEventChain(prevHash string) (*eventChain, error) {
+	head, err := hex.DecodeString(prevHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chain hash %q: %w", prevHash, err)
+	}
+	if len(head) == 0 {
+		head = nil
+	}
+	return &eventChain{head: head}, nil
+}
+
+// eventKeyTime reads the transaction time an event is keyed by
+func eventKeyTime(key []byte) (time.Time, error) {
+	nanos, _, _ := strings.Cut(string(key), "_")
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid event key %q: %w", key, err)
+	}
+	return time.Unix(0, n), nil
+}
+
+// Backup writes a consistent copy of the database to w while reads and writes carry
+// on, returning a manifest to check the copy against when it is restored
+func (s *Storage) Backup(w io.Writer) (*BackupManifest, error) {
+	manifest := &BackupManifest{CreatedAt: time.Now()}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		chain := &eventChain{}
+		var last []byte
+		err := tx.Bucket(BucketEvents).ForEach(func(k, v []byte) error {
+			chain.add(k, v)
+			last = k
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		manifest.Events, manifest.EventChainHash = chain.events, chain.Hash()
+		if last != nil {
+			lastAt, err := eventKeyTime(last)
+			if err != nil {
+				return err
+			}
+			manifest.LastEventAt = &lastAt
+		}
+
+		checksum := sha256.New()
+		if manifest.SizeBytes, err = tx.WriteTo(io.MultiWriter(w, checksum)); err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+		manifest.Checksum = hex.EncodeToString(checksum.Sum(nil))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("storage backed up", "size", manifest.SizeBytes, "events", manifest.Events)
+	return manifest, nil
+}
+
+// eventExportHeader opens an event export
+type eventExportHeader struct {
+	Since    time.Time `json:"since"`
+	PrevHash string    `json:"prev_hash"`
+}
+
+// eventExportRecord is one exported event with the chain hash after it
+type eventExportRecord struct {
+	Key  string `json:"key"`
+	Data []byte `json:"data"` // the event as stored
+	Hash string `json:"hash"`
+}
+
+// ExportEvents writes the events recorded after since to w as JSON lines, each with
+// its link in the event chain; nothing is written when there are none. Exporting
+// from a backup's LastEventAt continues that backup's EventChainHash, so the export
+// can be verified against it.
+func (s *Storage) ExportEvents(w io.Writer, since time.Time) (*EventExport, error) {
+	export := &EventExport{Since: since}
+	chain := &eventChain{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		encoder := json.NewEncoder(w)
+		started := false
+		return tx.Bucket(BucketEvents).ForEach(func(k, v []byte) error {
+			at, err := eventKeyTime(k)
+			if err != nil {
+				return err
+			}
+			if !at.After(since) {
+				chain.add(k, v)
+				return nil
+			}
+			if !started {
+				export.PrevHash = chain.Hash()
+				if err := encoder.Encode(&eventExportHeader{Since: since, PrevHash: export.PrevHash}); err != nil {
+					return fmt.Errorf("failed to write event export: %w", err)
+				}
+				started = true
+			}
+			chain.add(k, v)
+			export.Events++
+			export.ChainHash = chain.Hash()
+			if err := encoder.Encode(&eventExportRecord{Key: string(k), Data: v, Hash: export.ChainHash}); err != nil {
+				return fmt.Errorf("failed to write event export: %w", err)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if export.Events == 0 {
+		// Nothing to write; the log still ends where it did at since
+		export.PrevHash, export.ChainHash = chain.Hash(), chain.Hash()
+	}
+	return export, nil
+}
+
+// ReadEventExport reads an export written by ExportEvents, checking every link of its
+// event chain. A non-empty prevHash must match where the export says it continues
+// from, such as the EventChainHash of the backup it follows.
+func ReadEventExport(r io.Reader, prevHash string) ([]*JournalEvent, *EventExport, error) {
+	records, export, err := readEventExport(r, prevHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	var events []*JournalEvent
+	for _, record := range records {
+		event, err := record.event()
+		if err != nil {
+			return nil, nil, err
+		}
+		events = append(events, event)
+	}
+	return events, export, nil
+}
+
+// readEventExport reads an export's records as stored, checking the chain
+func readEventExport(r io.Reader, prevHash string) ([]*eventExportRecord, *EventExport, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64<<20)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, nil, fmt.Errorf("failed to read event export: %w", err)
+		}
+		return nil, &EventExport{PrevHash: prevHash, ChainHash: prevHash}, nil
+	}
+	var header eventExportHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, nil, fmt.Errorf("failed to read event export header: %w", err)
+	}
+	if prevHash != "" && header.PrevHash != prevHash {
+		return nil, nil, fmt.Errorf("event export continues from chain hash %s, not %s", header.PrevHash, prevHash)
+	}
+	chain, err := resumeEventChain(header.PrevHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	export := &EventExport{Since: header.Since, PrevHash: header.PrevHash}
+	var records []*eventExportRecord
+	for scanner.Scan() {
+		record := &eventExportRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return nil, nil, fmt.Errorf("failed to read event export record %d: %w", export.Events+1, err)
+		}
+		chain.add([]byte(record.Key), record.Data)
+		if chain.Hash() != record.Hash {
+			return nil, nil, fmt.Errorf("event export is broken at event %s: chain hash does not match", record.Key)
+		}
+		records = append(records, record)
+		export.Events++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read event export: %w", err)
+	}
+	export.ChainHash = chain.Hash()
+	return records, export, nil
+}
+
+// event decodes an exported event
+func (record *eventExportRecord) event() (*JournalEvent, error) {
+	pbEvent := &pb.JournalEvent{}
+	if err := proto.Unmarshal(record.Data, pbEvent); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event %s: %w", record.Key, err)
+	}
+	return JournalEventFromProto(pbEvent), nil
+}
+
+// EventReplayResult describes events applied on top of a restored backup
+type EventReplayResult struct {
+	Events         int              `json:"events"`    // appended to the event log
+	Projected      int              `json:"projected"` // of those, replayed into accounts and transactions
+	Skipped        int              `json:"skipped"`   // recorded after the point in time
+	EventChainHash string           `json:"event_chain_hash"`
+	Until          time.Time        `json:"until"`
+	Rebuilt        []*RebuildResult `json:"rebuilt"`
+	Duration       time.Duration    `json:"duration"`
+}
+
+// replayedEvents are the events ApplyEventExport replays through the event processor;
+// the rest are appended to the log as the audit trail but project no records
+var replayedEvents = map[string]bool{
+	EventCreateAccount:      true,
+	EventUpdateAccount:      true,
+	EventCreateTransaction:  true,
+	EventPostTransaction:    true,
+	EventReverseTransaction: true,
+}
+
+// ApplyEventExport rolls a restored backup forward to a point in time. The database's
+// event log must still be the backup's, matching the manifest's EventChainHash, and
+// the export must continue that chain; both are checked before anything is written.
+// Events recorded up to until are appended to the log as exported, so the chain goes
+// on unchanged, and accounts, transactions, postings and reversals are replayed from
+// them. Derived data is then rebuilt. A zero until applies the whole export.
+func (s *Storage) ApplyEventExport(r io.Reader, manifest *BackupManifest, until time.Time) (*EventReplayResult, error) {
+	started := time.Now()
+	if manifest == nil {
+		return nil, classify(ErrValidation, "applying an event export needs the manifest of the backup it follows")
+	}
+	chain := &eventChain{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(BucketEvents).ForEach(func(k, v []byte) error {
+			chain.add(k, v)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if chain.events != manifest.Events || chain.Hash() != manifest.EventChainHash {
+		return nil, classify(ErrConflict, "event log has %d events with chain hash %s, not the backup's %d with %s",
+			chain.events, chain.Hash(), manifest.Events, manifest.EventChainHash)
+	}
+	records, _, err := readEventExport(r, manifest.EventChainHash)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &EventReplayResult{Until: until}
+	processor := NewEventProcessor(s)
+	for _, record := range records {
+		at, err := eventKeyTime([]byte(record.Key))
+		if err != nil {
+			return nil, err
+		}
+		if !until.IsZero() && at.After(until) {
+			result.Skipped++
+			continue
+		}
+		event, err := record.event()
+		if err != nil {
+			return nil, err
+		}
+		err = s.db.Update(func(tx *bbolt.Tx) error {
+			return tx.Bucket(BucketEvents).Put([]byte(record.Key), record.Data)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to append event %s: %w", record.Key, err)
+		}
+		chain.add([]byte(record.Key), record.Data)
+		result.Events++
+		if !replayedEvents[event.EventType] {
+			continue
+		}
+		if err := processor.ProcessEvent(event); err != nil {
+			return nil, fmt.Errorf("failed to replay event %s: %w", record.Key, err)
+		}
+		result.Projected++
+	}
+	result.EventChainHash = chain.Hash()
+
+	if result.Rebuilt, err = s.Rebuild(RebuildAll); err != nil {
+		return nil, err
+	}
+	result.Duration = time.Since(started)
+	s.logger.Info("event export applied", "events", result.Events, "projected", result.Projected, "skipped", result.Skipped)
+	return result, nil
+}
+
+// RestoreTo restores a backup and rolls it forward with an event export to the state
+// recorded at until: Restore, then ApplyEventExport
+func (s *Storage) RestoreTo(backup io.Reader, manifest *BackupManifest, export io.Reader, until time.Time) (*RestoreResult, *EventReplayResult, error) {
+	if manifest == nil {
+		return nil, nil, classify(ErrValidation, "a point-in-time restore needs the backup's manifest")
+	}
+	restored, err := s.Restore(backup, manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+	replayed, err := s.ApplyEventExport(export, manifest, until)
+	if err != nil {
+		return restored, nil, err
+	}
+	return restored, replayed, nil
+}
+
+// Restore replaces the database with a backup written by Backup. The backup is
+// written beside the database and checked before anything is replaced: against the
+// manifest's size, checksum and event chain when one is given, for bbolt page
+// consistency, and for every posted transaction and the balance snapshots balancing.
+// The replaced file is kept at the result's PreviousPath. Reads and writes wait while
+// the file is swapped; services keep some settings in memory, so reopen the engine
+// once the restore is done.
+func (s *Storage) Restore(r io.Reader, manifest *BackupManifest) (*RestoreResult, error) {
+	started := time.Now()
+	path := s.db.path()
+	restorePath := path + ".restore"
+	if err := writeRestoreFile(restorePath, r, manifest); err != nil {
+		os.Remove(restorePath)
+		return nil, err
+	}
+	result, err := verifyBackupFile(restorePath, s.db.options.Timeout, manifest)
+	if err != nil {
+		os.Remove(restorePath)
+		return nil, err
+	}
+
+	s.db.swap.Lock()
+	defer s.db.swap.Unlock()
+
+	options := *s.db.options
+	options.NoSync = s.db.NoSync
+	result.PreviousPath = path + ".pre-restore"
+	if err := s.db.DB.Close(); err != nil {
+		os.Remove(restorePath)
+		return nil, fmt.Errorf("failed to close database for restore: %w", err)
+	}
+	if err := os.Rename(path, result.PreviousPath); err != nil {
+		os.Remove(restorePath)
+		if reopenErr := s.reopen(path, &options); reopenErr != nil {
+			return nil, fmt.Errorf("failed to set aside database file: %w (and reopening it failed: %v)", err, reopenErr)
+		}
+		return nil, fmt.Errorf("failed to set aside database file: %w", err)
+	}
+	if err := os.Rename(restorePath, path); err != nil {
+		os.Remove(restorePath)
+		if rollbackErr := os.Rename(result.PreviousPath, path); rollbackErr != nil {
+			return nil, fmt.Errorf("failed to move restored database into place: %w (and putting the original back failed: %v)", err, rollbackErr)
+		}
+		if reopenErr := s.reopen(path, &options); reopenErr != nil {
+			return nil, fmt.Errorf("failed to move restored database into place: %w (and reopening the original failed: %v)", err, reopenErr)
+		}
+		return nil, fmt.Errorf("failed to move restored database into place: %w", err)
+	}
+	if err := s.reopen(path, &options); err != nil {
+		return nil, err
+	}
+	s.InvalidateCache()
+
+	result.RestoredAt = time.Now()
+	result.Duration = time.Since(started)
+	s.logger.Info("storage restored", "events", result.Events, "transactions", result.Transactions, "previous_path", result.PreviousPath)
+	return result, nil
+}
+
+// writeRestoreFile copies a backup to disk, checking its size and checksum
+func writeRestoreFile(restorePath string, r io.Reader, manifest *BackupManifest) error {
+	file, err := os.OpenFile(restorePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create restore file: %w", err)
+	}
+	checksum := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, checksum), r)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write restore file: %w", err)
+	}
+	if manifest == nil {
+		return nil
+	}
+	if size != manifest.SizeBytes {
+		return fmt.Errorf("backup is %d bytes, manifest says %d", size, manifest.SizeBytes)
+	}
+	if sum := hex.EncodeToString(checksum.Sum(nil)); sum != manifest.Checksum {
+		return fmt.Errorf("backup checksum %s does not match manifest checksum %s", sum, manifest.Checksum)
+	}
+	return nil
+}
+
+// verifyBackupFile checks a backup's pages, event chain and balances without
+// changing it
+func verifyBackupFile(path string, timeout time.Duration, manifest *BackupManifest) (*RestoreResult, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{ReadOnly: true, Timeout: timeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer db.Close()
+
+	result := &RestoreResult{}
+	err = db.View(func(tx *bbolt.Tx) error {
+		for err := range tx.Check() {
+			return fmt.Errorf("backup is corrupt: %w", err)
+		}
+
+		events := tx.Bucket(BucketEvents)
+		if events == nil {
+			return fmt.Errorf("backup has no event log")
+		}
+		chain := &eventChain{}
+		if err := events.ForEach(func(k, v []byte) error {
+			chain.add(k, v)
+			return nil
+		}); err != nil {
+			return err
+		}
+		result.Events, result.EventChainHash = chain.events, chain.Hash()
+		if manifest != nil && (chain.events != manifest.Events || chain.Hash() != manifest.EventChainHash) {
+			return fmt.Errorf("backup event log does not match the manifest: %d events with chain hash %s, expected %d with %s",
+				chain.events, chain.Hash(), manifest.Events, manifest.EventChainHash)
+		}
+
+		if result.Transactions, err = verifyPostedTransactions(tx); err != nil {
+			return err
+		}
+		return verifyBalanceSnapshots(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// verifyPostedTransactions checks that every posted transaction's debits equal its
+// credits in each currency, returning how many transactions there are
+func verifyPostedTransactions(tx *bbolt.Tx) (int, error) {
+	transactions := tx.Bucket(BucketTransactions)
+	if transactions == nil {
+		return 0, fmt.Errorf("backup has no transactions bucket")
+	}
+	count := 0
+	err := transactions.ForEach(func(k, v []byte) error {
+		count++
+		pbTxn := &pb.Transaction{}
+		if err := proto.Unmarshal(v, pbTxn); err != nil {
+			return fmt.Errorf("failed to unmarshal transaction %s: %w", k, err)
+		}
+		txn := TransactionFromProto(pbTxn)
+		if txn.Status != Posted {
+			return nil
+		}
+		net := make(map[Currency]int64)
+		for _, entry := range txn.Entries {
+			if entry.Type == Debit {
+				net[entry.Amount.Currency] += entry.Amount.Value
+			} else {
+				net[entry.Amount.Currency] -= entry.Amount.Value
+			}
+		}
+		for currency, difference := range net {
+			if difference != 0 {
+				return classify(ErrUnbalanced, "posted transaction %s is out of balance by %d %s", txn.ID, difference, currency)
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// verifyBalanceSnapshots checks that the balance snapshots' debits and credits agree
+// across the ledger
+func verifyBalanceSnapshots(tx *bbolt.Tx) error {
+	snapshots := tx.Bucket(BucketBalanceSnapshots)
+	if snapshots == nil {
+		return nil
+	}
+	var debits, credits int64
+	err := snapshots.ForEach(func(k, v []byte) error {
+		pbSnapshot := &pb.AccountBalanceSnapshot{}
+		if err := proto.Unmarshal(v, pbSnapshot); err != nil {
+			return fmt.Errorf("failed to unmarshal balance snapshot %s: %w", k, err)
+		}
+		debits += pbSnapshot.Debits
+		credits += pbSnapshot.Credits
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if debits != credits {
+		return fmt.Errorf("balance snapshots are out of balance: debits %d, credits %d", debits, credits)
+	}
+	return nil
+}
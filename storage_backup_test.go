@@ -0,0 +1,249 @@
+package accounting
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	dbFile := "test_backup.db"
+	defer os.Remove(dbFile)
+	defer os.Remove(dbFile + ".pre-restore")
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "operator"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	sale := func(value int64) *Transaction {
+		txn := &Transaction{
+			Description: "Sale",
+			ValidTime:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	first := sale(10000)
+
+	var backup bytes.Buffer
+	manifest, err := engine.BackupStorage(&backup)
+	require.NoError(t, err)
+	assert.Equal(t, int64(backup.Len()), manifest.SizeBytes)
+	assert.Positive(t, manifest.Events)
+	assert.NotEmpty(t, manifest.EventChainHash)
+	require.NotNil(t, manifest.LastEventAt)
+
+	second := sale(5000)
+
+	t.Run("Event Export Continues The Backup", func(t *testing.T) {
+		var export bytes.Buffer
+		summary, err := engine.ExportEvents(&export, *manifest.LastEventAt)
+		require.NoError(t, err)
+		assert.Equal(t, manifest.EventChainHash, summary.PrevHash)
+		assert.Equal(t, 2, summary.Events) // the second sale's creation and posting
+
+		events, read, err := ReadEventExport(bytes.NewReader(export.Bytes()), manifest.EventChainHash)
+		require.NoError(t, err)
+		assert.Equal(t, summary.ChainHash, read.ChainHash)
+		require.Len(t, events, 2)
+		assert.Equal(t, EventCreateTransaction, events[0].EventType)
+		assert.Equal(t, EventPostTransaction, events[1].EventType)
+
+		_, _, err = ReadEventExport(bytes.NewReader(export.Bytes()), strings.Repeat("0", 64))
+		assert.ErrorContains(t, err, "continues from chain hash")
+
+		lines := strings.SplitN(export.String(), "\n", 3)
+		tampered := lines[0] + "\n" + lines[2]
+		_, _, err = ReadEventExport(strings.NewReader(tampered), "")
+		assert.ErrorContains(t, err, "chain hash does not match")
+
+		later, err := engine.ExportEvents(&bytes.Buffer{}, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		assert.Zero(t, later.Events)
+		assert.Equal(t, summary.ChainHash, later.ChainHash)
+	})
+
+	t.Run("Restore Rejects A Damaged Backup", func(t *testing.T) {
+		damaged := append([]byte(nil), backup.Bytes()...)
+		damaged[len(damaged)/2] ^= 0xff
+		_, err := engine.RestoreStorage(bytes.NewReader(damaged), manifest)
+		assert.ErrorContains(t, err, "checksum")
+
+		_, err = engine.RestoreStorage(bytes.NewReader(backup.Bytes()), &BackupManifest{
+			SizeBytes: manifest.SizeBytes, Checksum: manifest.Checksum, Events: manifest.Events + 1, EventChainHash: manifest.EventChainHash,
+		})
+		assert.ErrorContains(t, err, "event log does not match")
+
+		// Nothing was replaced
+		_, err = engine.GetStorage().GetTransaction(second.ID)
+		require.NoError(t, err)
+		_, err = os.Stat(dbFile + ".restore")
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Restore Rejects An Unbalanced Ledger", func(t *testing.T) {
+		unbalancedFile := "test_backup_unbalanced.db"
+		defer os.Remove(unbalancedFile)
+		storage, err := NewStorage(unbalancedFile)
+		require.NoError(t, err)
+		require.NoError(t, storage.SaveTransaction(&Transaction{
+			ID: "broken", Status: Posted, ValidTime: time.Now(),
+			Entries: []Entry{{AccountID: "cash", Type: Debit, Amount: Amount{Value: 100, Currency: "USD"}}},
+		}))
+		var unbalanced bytes.Buffer
+		_, err = storage.Backup(&unbalanced)
+		require.NoError(t, err)
+		require.NoError(t, storage.Close())
+
+		_, err = engine.RestoreStorage(&unbalanced, nil)
+		assert.ErrorContains(t, err, "posted transaction broken is out of balance by 100 USD")
+	})
+
+	t.Run("Restore Swaps In The Backup", func(t *testing.T) {
+		result, err := engine.RestoreStorage(bytes.NewReader(backup.Bytes()), manifest)
+		require.NoError(t, err)
+		assert.Equal(t, manifest.Events, result.Events)
+		assert.Equal(t, manifest.EventChainHash, result.EventChainHash)
+		assert.Equal(t, 1, result.Transactions)
+		assert.Equal(t, dbFile+".pre-restore", result.PreviousPath)
+		_, err = os.Stat(result.PreviousPath)
+		require.NoError(t, err)
+
+		_, err = engine.GetStorage().GetTransaction(first.ID)
+		require.NoError(t, err)
+		_, err = engine.GetStorage().GetTransaction(second.ID)
+		assert.Error(t, err)
+		balance, err := engine.GetAccountBalance("cash", time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		assert.Equal(t, int64(10000), balance.Balance.Value)
+
+		// The restored database takes writes
+		sale(2500)
+		assert.Equal(t, HealthHealthy, engine.HealthCheck().Status)
+	})
+}
+
+func TestPointInTimeRestore(t *testing.T) {
+	dbFile := "test_backup_pitr.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "operator"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	sale := func(value int64) *Transaction {
+		txn := &Transaction{
+			Description: "Sale",
+			ValidTime:   time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	sale(10000)
+
+	var backup bytes.Buffer
+	manifest, err := engine.BackupStorage(&backup)
+	require.NoError(t, err)
+
+	kept := sale(5000)
+	mistake := sale(3000)
+	_, err = engine.ReverseTransaction(mistake.ID, "Entered twice", userID)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	pointInTime := time.Now()
+	time.Sleep(time.Millisecond)
+	late := sale(1000)
+
+	var export bytes.Buffer
+	_, err = engine.ExportEvents(&export, *manifest.LastEventAt)
+	require.NoError(t, err)
+	current, err := engine.BackupStorage(io.Discard)
+	require.NoError(t, err)
+
+	restore := func(t *testing.T, name string) *AccountingEngine {
+		t.Helper()
+		restoreFile := "test_backup_pitr_" + name + ".db"
+		t.Cleanup(func() {
+			os.Remove(restoreFile)
+			os.Remove(restoreFile + ".pre-restore")
+		})
+		restored, err := NewAccountingEngine(restoreFile)
+		require.NoError(t, err)
+		t.Cleanup(func() { restored.Close() })
+		return restored
+	}
+	march := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Restore To A Point In Time", func(t *testing.T) {
+		restored := restore(t, "point")
+		_, replay, err := restored.RestoreStorageTo(bytes.NewReader(backup.Bytes()), manifest, bytes.NewReader(export.Bytes()), pointInTime)
+		require.NoError(t, err)
+		assert.Equal(t, 2, replay.Skipped, "the late sale's creation and posting")
+		assert.Positive(t, replay.Projected)
+		require.NotEmpty(t, replay.Rebuilt)
+
+		txn, err := restored.GetStorage().GetTransaction(kept.ID)
+		require.NoError(t, err)
+		assert.Equal(t, Posted, txn.Status)
+		txn, err = restored.GetStorage().GetTransaction(mistake.ID)
+		require.NoError(t, err)
+		assert.Equal(t, Reversed, txn.Status)
+		_, err = restored.GetStorage().GetTransaction(late.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		balance, err := restored.GetAccountBalance("cash", march)
+		require.NoError(t, err)
+		assert.Equal(t, int64(15000), balance.Balance.Value)
+
+		rolled, err := restored.BackupStorage(io.Discard)
+		require.NoError(t, err)
+		assert.Equal(t, replay.EventChainHash, rolled.EventChainHash, "the log continues the exported chain")
+
+		_, err = restored.ApplyEventExport(bytes.NewReader(export.Bytes()), manifest, time.Time{})
+		assert.ErrorIs(t, err, ErrConflict, "the log has moved past the backup")
+	})
+
+	t.Run("Restore Replays The Whole Export", func(t *testing.T) {
+		restored := restore(t, "latest")
+		_, replay, err := restored.RestoreStorageTo(bytes.NewReader(backup.Bytes()), manifest, bytes.NewReader(export.Bytes()), time.Time{})
+		require.NoError(t, err)
+		assert.Zero(t, replay.Skipped)
+		assert.Equal(t, current.EventChainHash, replay.EventChainHash)
+
+		balance, err := restored.GetAccountBalance("cash", march)
+		require.NoError(t, err)
+		assert.Equal(t, int64(16000), balance.Balance.Value)
+	})
+
+	t.Run("Apply Rejects A Broken Export", func(t *testing.T) {
+		restored := restore(t, "broken")
+		_, err := restored.RestoreStorage(bytes.NewReader(backup.Bytes()), manifest)
+		require.NoError(t, err)
+
+		lines := strings.SplitN(export.String(), "\n", 3)
+		_, err = restored.ApplyEventExport(strings.NewReader(lines[0]+"\n"+lines[2]), manifest, time.Time{})
+		assert.ErrorContains(t, err, "chain hash does not match")
+		_, err = restored.GetStorage().GetTransaction(kept.ID)
+		assert.ErrorIs(t, err, ErrNotFound, "nothing is applied from a broken export")
+	})
+}
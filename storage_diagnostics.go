@@ -0,0 +1,206 @@
+package accounting
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// ----------------------------------------------------------------------------
+// Storage Diagnostics and Compaction
+// ----------------------------------------------------------------------------
+
+// Keys in the storage meta bucket
+var (
+	keyLastCompaction  = []byte("last_compaction")   // when the file was last compacted
+	keyLastHealthCheck = []byte("last_health_check") // written by health checks to prove writes work
+)
+
+// compactTxMaxSize bounds how many bytes Compact copies per write transaction
+const compactTxMaxSize = 64 << 20
+
+// BucketStats describes one top-level bucket
+type BucketStats struct {
+	Name string `json:"name"`
+	Keys int    `json:"keys"` // including keys of nested buckets
+}
+
+// StorageStats describes the database file for monitoring
+type StorageStats struct {
+	Path           string        `json:"path"`
+	SizeBytes      int64         `json:"size_bytes"`
+	PageSize       int           `json:"page_size"`
+	FreePages      int           `json:"free_pages"` // pages on the freelist, including those pending release
+	FreeBytes      int           `json:"free_bytes"` // space Compact could give back
+	Buckets        []BucketStats `json:"buckets"`    // ordered by name
+	Events         int           `json:"events"`
+	OpenReadTxs    int           `json:"open_read_txs"`
+	LastCompaction *time.Time    `json:"last_compaction,omitempty"`
+	Cache          []CacheStats  `json:"cache,omitempty"`
+}
+
+// FreeRatio is the share of the file taken by free pages
+func (s *StorageStats) FreeRatio() float64 {
+	if s.SizeBytes == 0 {
+		return 0
+	}
+	return float64(s.FreeBytes) / float64(s.SizeBytes)
+}
+
+// CompactionResult describes one compaction
+type CompactionResult struct {
+	SizeBefore  int64         `json:"size_before"`
+	SizeAfter   int64         `json:"size_after"`
+	Duration    time.Duration `json:"duration"`
+	CompactedAt time.Time     `json:"compacted_at"`
+}
+
+// Reclaimed is how many bytes the compaction gave back
+func (r *CompactionResult) Reclaimed() int64 {
+	return r.SizeBefore - r.SizeAfter
+}
+
+// Stats reports the database file's size, free space and per-bucket key counts
+func (s *Storage) Stats() (*StorageStats, error) {
+	s.db.swap.RLock()
+	defer s.db.swap.RUnlock()
+
+	path := s.db.Path()
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat database file: %w", err)
+	}
+	dbStats := s.db.DB.Stats()
+	stats := &StorageStats{
+		Path:        path,
+		SizeBytes:   info.Size(),
+		PageSize:    s.db.Info().PageSize,
+		FreePages:   dbStats.FreePageN + dbStats.PendingPageN,
+		FreeBytes:   dbStats.FreeAlloc,
+		OpenReadTxs: dbStats.OpenTxN,
+		Cache:       s.CacheStats(),
+	}
+
+	err = s.db.DB.View(func(tx *bbolt.Tx) error {
+		err := tx.ForEach(func(name []byte, b *bbolt.Bucket) error {
+			keys := b.Stats().KeyN
+			stats.Buckets = append(stats.Buckets, BucketStats{Name: string(name), Keys: keys})
+			if string(name) == string(BucketEvents) {
+				stats.Events = keys
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if data := tx.Bucket(BucketStorageMeta).Get(keyLastCompaction); data != nil {
+			compacted, err := time.Parse(time.RFC3339Nano, string(data))
+			if err != nil {
+				return fmt.Errorf("failed to parse last compaction time: %w", err)
+			}
+			stats.LastCompaction = &compacted
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bucket stats: %w", err)
+	}
+	sort.Slice(stats.Buckets, func(i, j int) bool { return stats.Buckets[i].Name < stats.Buckets[j].Name })
+	return stats, nil
+}
+
+// ping proves the database takes a write, for health checks
+func (s *Storage) ping() error {
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(BucketStorageMeta).Put(keyLastHealthCheck, []byte(time.Now().UTC().Format(time.RFC3339Nano)))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write to database: %w", err)
+	}
+	return nil
+}
+
+// Compact rewrites the database into a fresh file without free pages and swaps it in.
+// Reads and writes wait until it finishes; the old file is kept only until the new
+// one is in place.
+func (s *Storage) Compact() (*CompactionResult, error) {
+	s.db.swap.Lock()
+	defer s.db.swap.Unlock()
+
+	started := time.Now()
+	path := s.db.Path()
+	before, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat database file: %w", err)
+	}
+
+	compactPath := path + ".compact"
+	_ = os.Remove(compactPath)
+	dst, err := bbolt.Open(compactPath, 0600, &bbolt.Options{Timeout: s.db.options.Timeout, NoSync: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create compacted database: %w", err)
+	}
+	result := &CompactionResult{SizeBefore: before.Size(), CompactedAt: started}
+	err = bbolt.Compact(dst, s.db.DB, compactTxMaxSize)
+	if err == nil {
+		err = dst.Update(func(tx *bbolt.Tx) error {
+			meta, err := tx.CreateBucketIfNotExists(BucketStorageMeta)
+			if err != nil {
+				return err
+			}
+			return meta.Put(keyLastCompaction, []byte(started.UTC().Format(time.RFC3339Nano)))
+		})
+	}
+	if err == nil {
+		err = dst.Sync()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(compactPath)
+		return nil, fmt.Errorf("failed to compact database: %w", err)
+	}
+
+	// Reopen with the settings in force now, which SetNoSync may have changed
+	options := *s.db.options
+	options.NoSync = s.db.NoSync
+	if err := s.db.DB.Close(); err != nil {
+		os.Remove(compactPath)
+		return nil, fmt.Errorf("failed to close database for compaction: %w", err)
+	}
+	if err := os.Rename(compactPath, path); err != nil {
+		os.Remove(compactPath)
+		if reopenErr := s.reopen(path, &options); reopenErr != nil {
+			return nil, fmt.Errorf("failed to replace database file: %w (and reopening it failed: %v)", err, reopenErr)
+		}
+		return nil, fmt.Errorf("failed to replace database file: %w", err)
+	}
+	if err := s.reopen(path, &options); err != nil {
+		return nil, err
+	}
+
+	after, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat compacted database file: %w", err)
+	}
+	result.SizeAfter = after.Size()
+	result.Duration = time.Since(started)
+	s.logger.Info("storage compacted", "size_before", result.SizeBefore, "size_after", result.SizeAfter, "duration", result.Duration)
+	return result, nil
+}
+
+// reopen opens the database file again after Compact closed it
+func (s *Storage) reopen(path string, options *bbolt.Options) error {
+	db, err := bbolt.Open(path, 0600, options)
+	if err != nil {
+		s.logger.Error("failed to reopen storage after compaction", LogKeyError, err)
+		return fmt.Errorf("failed to reopen database: %w", err)
+	}
+	s.db.DB = db
+	s.db.options = options
+	return nil
+}
@@ -0,0 +1,345 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// ----------------------------------------------------------------------------
+// Storage Profiling
+// ----------------------------------------------------------------------------
+
+// Thresholds past which a profile suggests a change
+const (
+	// ProfileIndexMinKeys is the bucket size from which a full scan on every run of
+	// an operation is worth an index
+	ProfileIndexMinKeys = 1000
+	// ProfileCompactRatio is the share of the file in free pages above which a
+	// profile suggests compaction
+	ProfileCompactRatio = 0.25
+	// ProfileCompactMinBytes is the free space below which compaction is not worth
+	// suggesting, whatever the ratio
+	ProfileCompactMinBytes = 1 << 20
+)
+
+// ProfileSuggestionKind is what a profile suggests doing
+type ProfileSuggestionKind string
+
+const (
+	ProfileSuggestIndex   ProfileSuggestionKind = "INDEX"   // an operation scans a large bucket
+	ProfileSuggestCompact ProfileSuggestionKind = "COMPACT" // the file holds many free pages
+	ProfileSuggestCache   ProfileSuggestionKind = "CACHE"   // cacheable buckets are read from disk
+)
+
+// cachedBuckets are the buckets the read cache holds when it is enabled
+var cachedBuckets = [][]byte{BucketAccounts, BucketPeriods, BucketComplianceRules, BucketExchangeRates}
+
+// ProfileOptions chooses what a profile runs. Nil runs every workload five times.
+type ProfileOptions struct {
+	Iterations int      `json:"iterations,omitempty"` // runs per workload; defaults to 5
+	Operations []string `json:"operations,omitempty"` // workloads to run; empty runs all
+	Currency   string   `json:"currency,omitempty"`   // for the statements; defaults to USD
+}
+
+// BucketScan counts an operation's full scans of one bucket
+type BucketScan struct {
+	Bucket string  `json:"bucket"`
+	Scans  int     `json:"scans"` // across all runs
+	PerRun float64 `json:"per_run"`
+	Keys   int     `json:"keys"` // keys in the bucket, so each scan's size
+}
+
+// OperationProfile is the latency and storage work of one workload
+type OperationProfile struct {
+	Name       string        `json:"name"`
+	Runs       int           `json:"runs"`
+	Min        time.Duration `json:"min"`
+	Mean       time.Duration `json:"mean"`
+	P95        time.Duration `json:"p95"`
+	Max        time.Duration `json:"max"`
+	ReadTxs    int           `json:"read_txs"` // read transactions per run
+	Cursors    int           `json:"cursors"`  // bbolt cursors opened per run, a proxy for lookups
+	TotalScans int           `json:"total_scans"`
+	Scans      []*BucketScan `json:"scans,omitempty"` // heaviest first
+	Error      string        `json:"error,omitempty"` // the workload failed; its figures cover the runs before
+}
+
+// ProfileSuggestion is a change a profile recommends
+type ProfileSuggestion struct {
+	Kind      ProfileSuggestionKind `json:"kind"`
+	Operation string                `json:"operation,omitempty"`
+	Bucket    string                `json:"bucket,omitempty"`
+	Message   string                `json:"message"`
+}
+
+// StorageProfile is the outcome of running the profiling workloads against a database
+type StorageProfile struct {
+	Storage     *StorageStats        `json:"storage"`
+	Operations  []*OperationProfile  `json:"operations"`
+	Suggestions []*ProfileSuggestion `json:"suggestions,omitempty"`
+	StartedAt   time.Time            `json:"started_at"`
+	Duration    time.Duration        `json:"duration"`
+}
+
+// scanCounter counts full bucket scans by bucket while a profile runs
+type scanCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+// scanBucket returns a top-level bucket that is about to be walked from its first
+// key, counting the walk while a profile runs
+func (db *storageDB) scanBucket(tx *bbolt.Tx, name []byte) *bbolt.Bucket {
+	if counter := db.scans.Load(); counter != nil {
+		counter.mu.Lock()
+		counter.counts[string(name)]++
+		counter.mu.Unlock()
+	}
+	return tx.Bucket(name)
+}
+
+// boltStats reads the database's transaction statistics
+func (db *storageDB) boltStats() bbolt.Stats {
+	db.swap.RLock()
+	defer db.swap.RUnlock()
+	return db.DB.Stats()
+}
+
+// profileWorkload is a representative read-only operation
+type profileWorkload struct {
+	name string
+	run  func() error
+}
+
+// profileWorkloads are the operations a profile times: balance queries, AML
+// monitoring, bitemporal replay and statement generation
+func (ae *AccountingEngine) profileWorkloads(currency string) ([]profileWorkload, error) {
+	accounts, err := ae.storage.GetAllAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	now := time.Now()
+	yearStart := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+
+	return []profileWorkload{
+		{"account_balances", func() error {
+			for _, account := range accounts {
+				if _, err := ae.GetAccountBalance(account.ID, now); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"trial_balance", func() error {
+			_, err := ae.GetTrialBalance(now, nil)
+			return err
+		}},
+		{"aml_monitoring", func() error {
+			_, err := ae.amlService.evaluatePosted(now.AddDate(0, 0, -30), now)
+			return err
+		}},
+		{"postings_as_of", func() error {
+			_, err := ae.GetPostingsAsOf(now, now)
+			return err
+		}},
+		{"balance_sheet", func() error {
+			_, err := ae.GenerateBalanceSheet(now, currency)
+			return err
+		}},
+		{"profit_and_loss", func() error {
+			_, err := ae.GenerateProfitAndLoss(yearStart, now, currency)
+			return err
+		}},
+	}, nil
+}
+
+// evaluatePosted runs the enabled rules over the transactions posted in a period
+// without saving alerts, returning how many alerts they would raise
+func (aml *AMLService) evaluatePosted(fromDate, toDate time.Time) (int, error) {
+	customers, err := aml.storage.GetAllAMLCustomers()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get AML customers: %w", err)
+	}
+	customerInfo := make(map[string]*AMLCustomer, len(customers))
+	for _, customer := range customers {
+		customerInfo[customer.CustomerID] = customer
+	}
+
+	alerts := 0
+	err = aml.storage.ForEachPostedTransaction(fromDate, toDate, func(txn *Transaction) error {
+		amlTxn := aml.convertToAMLTransaction(txn, customerInfo)
+		for _, enrich := range aml.enrichers {
+			if err := enrich(amlTxn); err != nil {
+				return fmt.Errorf("failed to enrich AML transaction %s: %w", txn.ID, err)
+			}
+		}
+		for _, rule := range aml.rules {
+			if rule.Enabled && aml.evaluateRule(rule, amlTxn, customerInfo) != nil {
+				alerts++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to replay transactions: %w", err)
+	}
+	return alerts, nil
+}
+
+// ProfileStorage runs representative read-only workloads against the database and
+// reports each one's latency, read transactions, cursors and full bucket scans, with
+// suggested indexes, compaction or caching. Run it while the engine is otherwise
+// idle, since concurrent work is counted too.
+func (ae *AccountingEngine) ProfileStorage(options *ProfileOptions) (*StorageProfile, error) {
+	if options == nil {
+		options = &ProfileOptions{}
+	}
+	iterations := options.Iterations
+	if iterations <= 0 {
+		iterations = 5
+	}
+	currency := options.Currency
+	if currency == "" {
+		currency = "USD"
+	}
+
+	started := time.Now()
+	workloads, err := ae.profileWorkloads(currency)
+	if err != nil {
+		return nil, err
+	}
+	if len(options.Operations) > 0 {
+		for _, name := range options.Operations {
+			if !slices.ContainsFunc(workloads, func(w profileWorkload) bool { return w.name == name }) {
+				return nil, fmt.Errorf("unknown profile operation %q", name)
+			}
+		}
+		workloads = slices.DeleteFunc(workloads, func(w profileWorkload) bool {
+			return !slices.Contains(options.Operations, w.name)
+		})
+	}
+
+	stats, err := ae.storage.Stats()
+	if err != nil {
+		return nil, err
+	}
+	bucketKeys := make(map[string]int, len(stats.Buckets))
+	for _, bucket := range stats.Buckets {
+		bucketKeys[bucket.Name] = bucket.Keys
+	}
+
+	profile := &StorageProfile{Storage: stats, StartedAt: started}
+	db := ae.storage.db
+	defer db.scans.Store(nil)
+	for _, workload := range workloads {
+		op := &OperationProfile{Name: workload.name}
+		counter := &scanCounter{counts: make(map[string]int)}
+		db.scans.Store(counter)
+		before := db.boltStats()
+
+		var durations []time.Duration
+		for range iterations {
+			runStarted := time.Now()
+			if err := workload.run(); err != nil {
+				op.Error = err.Error()
+				break
+			}
+			durations = append(durations, time.Since(runStarted))
+		}
+		after := db.boltStats()
+		db.scans.Store(nil)
+
+		op.Runs = len(durations)
+		if op.Runs > 0 {
+			summarizeDurations(op, durations)
+			diff := after.Sub(&before)
+			op.ReadTxs = diff.TxN / op.Runs
+			op.Cursors = int(diff.TxStats.GetCursorCount()) / op.Runs
+			for bucket, scans := range counter.counts {
+				op.Scans = append(op.Scans, &BucketScan{
+					Bucket: bucket,
+					Scans:  scans,
+					PerRun: float64(scans) / float64(op.Runs),
+					Keys:   bucketKeys[bucket],
+				})
+				op.TotalScans += scans
+			}
+			sort.Slice(op.Scans, func(i, j int) bool {
+				if op.Scans[i].Scans*op.Scans[i].Keys != op.Scans[j].Scans*op.Scans[j].Keys {
+					return op.Scans[i].Scans*op.Scans[i].Keys > op.Scans[j].Scans*op.Scans[j].Keys
+				}
+				return op.Scans[i].Bucket < op.Scans[j].Bucket
+			})
+		}
+		profile.Operations = append(profile.Operations, op)
+	}
+
+	profile.Suggestions = profileSuggestions(profile)
+	profile.Duration = time.Since(started)
+	return profile, nil
+}
+
+// summarizeDurations fills in an operation's latency figures
+func summarizeDurations(op *OperationProfile, durations []time.Duration) {
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	op.Min = sorted[0]
+	op.Max = sorted[len(sorted)-1]
+	op.Mean = total / time.Duration(len(sorted))
+	// Nearest rank
+	rank := (95*len(sorted) + 99) / 100
+	op.P95 = sorted[rank-1]
+}
+
+// profileSuggestions recommends indexes for large buckets scanned on every run,
+// compaction when free pages take much of the file, and the read cache when it is
+// disabled but cacheable buckets are scanned
+func profileSuggestions(profile *StorageProfile) []*ProfileSuggestion {
+	var suggestions []*ProfileSuggestion
+	cacheDisabled := len(profile.Storage.Cache) == 0
+	uncached := make(map[string]bool)
+	for _, op := range profile.Operations {
+		for _, scan := range op.Scans {
+			cacheable := slices.ContainsFunc(cachedBuckets, func(b []byte) bool { return string(b) == scan.Bucket })
+			if cacheable && cacheDisabled {
+				uncached[scan.Bucket] = true
+				continue
+			}
+			if scan.Keys >= ProfileIndexMinKeys && scan.PerRun >= 1 {
+				suggestions = append(suggestions, &ProfileSuggestion{
+					Kind:      ProfileSuggestIndex,
+					Operation: op.Name,
+					Bucket:    scan.Bucket,
+					Message: fmt.Sprintf("%s walks all %d keys of %s %.0f times per run; an index on what it looks up would avoid the scan",
+						op.Name, scan.Keys, scan.Bucket, scan.PerRun),
+				})
+			}
+		}
+	}
+	for _, bucket := range cachedBuckets {
+		if uncached[string(bucket)] {
+			suggestions = append(suggestions, &ProfileSuggestion{
+				Kind:    ProfileSuggestCache,
+				Bucket:  string(bucket),
+				Message: fmt.Sprintf("the read cache is disabled, so %s is read from disk on every lookup; open storage without DisableCache", bucket),
+			})
+		}
+	}
+	if ratio := profile.Storage.FreeRatio(); ratio >= ProfileCompactRatio && profile.Storage.FreeBytes >= ProfileCompactMinBytes {
+		suggestions = append(suggestions, &ProfileSuggestion{
+			Kind: ProfileSuggestCompact,
+			Message: fmt.Sprintf("%.0f%% of the file (%d bytes) is free pages, which reads still page through; compacting would give it back",
+				ratio*100, profile.Storage.FreeBytes),
+		})
+	}
+	return suggestions
+}
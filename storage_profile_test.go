@@ -0,0 +1,99 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageProfile(t *testing.T) {
+	// Setup
+	dbFile := "test_storage_profile.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngineWithOptions(dbFile, StorageOptions{DisableCache: true})
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "operator"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	for i := range 5 {
+		txn := &Transaction{
+			Description: "Sale",
+			ValidTime:   time.Now().AddDate(0, 0, -i),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: 1000, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 1000, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+
+	t.Run("Workloads", func(t *testing.T) {
+		profile, err := engine.ProfileStorage(&ProfileOptions{Iterations: 3})
+		require.NoError(t, err)
+		require.Len(t, profile.Operations, 6)
+
+		byName := make(map[string]*OperationProfile)
+		for _, op := range profile.Operations {
+			assert.Empty(t, op.Error, op.Name)
+			assert.Equal(t, 3, op.Runs, op.Name)
+			assert.True(t, op.Min <= op.Mean && op.Mean <= op.Max, op.Name)
+			assert.True(t, op.P95 <= op.Max, op.Name)
+			assert.Positive(t, op.ReadTxs, op.Name)
+			byName[op.Name] = op
+		}
+
+		// Replaying the event log walks every event
+		replay := byName["postings_as_of"]
+		require.NotEmpty(t, replay.Scans)
+		assert.Equal(t, string(BucketEvents), replay.Scans[0].Bucket)
+		assert.Equal(t, 1.0, replay.Scans[0].PerRun)
+		assert.Equal(t, profile.Storage.Events, replay.Scans[0].Keys)
+
+		// With the cache off, account lookups come from disk
+		var cache *ProfileSuggestion
+		for _, suggestion := range profile.Suggestions {
+			if suggestion.Kind == ProfileSuggestCache && suggestion.Bucket == string(BucketAccounts) {
+				cache = suggestion
+			}
+		}
+		require.NotNil(t, cache)
+	})
+
+	t.Run("Selected Operations", func(t *testing.T) {
+		profile, err := engine.ProfileStorage(&ProfileOptions{Iterations: 1, Operations: []string{"trial_balance"}})
+		require.NoError(t, err)
+		require.Len(t, profile.Operations, 1)
+		assert.Equal(t, "trial_balance", profile.Operations[0].Name)
+
+		_, err = engine.ProfileStorage(&ProfileOptions{Operations: []string{"vacuum"}})
+		assert.ErrorContains(t, err, "unknown profile operation")
+	})
+
+	t.Run("Suggestions", func(t *testing.T) {
+		profile := &StorageProfile{
+			Storage: &StorageStats{SizeBytes: 10 << 20, FreeBytes: 4 << 20, Cache: []CacheStats{{Name: "accounts"}}},
+			Operations: []*OperationProfile{{
+				Name: "search",
+				Scans: []*BucketScan{
+					{Bucket: "entries", Scans: 4, PerRun: 2, Keys: 5000},
+					{Bucket: "ledgers", Scans: 2, PerRun: 1, Keys: 3},
+				},
+			}},
+		}
+		suggestions := profileSuggestions(profile)
+		require.Len(t, suggestions, 2)
+		assert.Equal(t, ProfileSuggestIndex, suggestions[0].Kind)
+		assert.Equal(t, "entries", suggestions[0].Bucket)
+		assert.Equal(t, ProfileSuggestCompact, suggestions[1].Kind)
+	})
+
+	t.Run("Counting Stops", func(t *testing.T) {
+		assert.Nil(t, engine.storage.db.scans.Load())
+	})
+}
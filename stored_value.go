@@ -0,0 +1,522 @@
+package accounting
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Stored Value Structures
+// ----------------------------------------------------------------------------
+
+// BreakageModel selects when value expected never to be redeemed is recognized
+// as revenue (IFRS 15 B44-B47 / ASC 606-10-55-46)
+type BreakageModel string
+
+const (
+	// BreakageProportional recognizes the estimated breakage in proportion to the
+	// pattern of redemptions, for programs with a reliable breakage estimate
+	BreakageProportional BreakageModel = "PROPORTIONAL"
+	// BreakageRemote recognizes the remaining balance once redemption becomes remote,
+	// i.e. after a period of inactivity, when no reliable estimate exists
+	BreakageRemote BreakageModel = "REMOTE"
+)
+
+// StoredValueProgram is a gift card or other stored-value scheme and its accounting policy
+type StoredValueProgram struct {
+	ID                      string        `json:"id"`
+	Name                    string        `json:"name"`
+	Currency                Currency      `json:"currency"`
+	LiabilityAccountID      string        `json:"liability_account_id"`
+	RevenueAccountID        string        `json:"revenue_account_id"`
+	BreakageIncomeAccountID string        `json:"breakage_income_account_id"`
+	BreakageModel           BreakageModel `json:"breakage_model"`
+	EstimatedBreakageRate   float64       `json:"estimated_breakage_rate,omitempty"` // share of value never redeemed, proportional model
+	RemoteAfterMonths       int           `json:"remote_after_months,omitempty"`     // inactivity before redemption is remote, remote model
+	ValidityMonths          int           `json:"validity_months,omitempty"`         // card expiry after issue, zero for no expiry
+	CreatedBy               string        `json:"created_by"`
+	CreatedAt               time.Time     `json:"created_at"`
+}
+
+// GiftCardStatus tracks whether a card can still be redeemed
+type GiftCardStatus string
+
+const (
+	GiftCardActive   GiftCardStatus = "ACTIVE"
+	GiftCardRedeemed GiftCardStatus = "REDEEMED"
+	GiftCardExpired  GiftCardStatus = "EXPIRED"
+)
+
+// StoredValueActivityType classifies a movement in the stored-value liability
+type StoredValueActivityType string
+
+const (
+	ActivityIssue      StoredValueActivityType = "ISSUE"
+	ActivityRedemption StoredValueActivityType = "REDEMPTION"
+	ActivityBreakage   StoredValueActivityType = "BREAKAGE" // negative when breakage is reversed
+)
+
+// StoredValueActivity is one movement on a card, in minor units of the program currency
+type StoredValueActivity struct {
+	Type          StoredValueActivityType `json:"type"`
+	Amount        int64                   `json:"amount"`
+	Date          time.Time               `json:"date"`
+	TransactionID string                  `json:"transaction_id"`
+}
+
+// GiftCard is a stored-value instrument issued under a program. Balance is what the
+// holder can still spend; the liability carried for the card is Balance less the
+// breakage recognized so far.
+type GiftCard struct {
+	ID                 string                `json:"id"`
+	ProgramID          string                `json:"program_id"`
+	Code               string                `json:"code"`
+	CustomerID         string                `json:"customer_id,omitempty"`
+	InitialValue       int64                 `json:"initial_value"`
+	Balance            int64                 `json:"balance"`
+	Redeemed           int64                 `json:"redeemed"`
+	BreakageRecognized int64                 `json:"breakage_recognized"`
+	IssuedAt           time.Time             `json:"issued_at"`
+	ExpiresAt          *time.Time            `json:"expires_at,omitempty"`
+	LastActivityAt     time.Time             `json:"last_activity_at"`
+	Status             GiftCardStatus        `json:"status"`
+	Activity           []StoredValueActivity `json:"activity"`
+	CreatedBy          string                `json:"created_by"`
+	UpdatedAt          time.Time             `json:"updated_at"`
+}
+
+// CarryingLiability returns the liability still recognized for the card
+func (gc *GiftCard) CarryingLiability() int64 {
+	return gc.Balance - gc.BreakageRecognized
+}
+
+// StoredValueRollForward reconciles a program's liability from the start to the end of a period
+type StoredValueRollForward struct {
+	ProgramID          string    `json:"program_id"`
+	Currency           Currency  `json:"currency"`
+	PeriodStart        time.Time `json:"period_start"`
+	PeriodEnd          time.Time `json:"period_end"`
+	OpeningLiability   int64     `json:"opening_liability"`
+	Issued             int64     `json:"issued"`
+	Redeemed           int64     `json:"redeemed"`
+	BreakageRecognized int64     `json:"breakage_recognized"`
+	ClosingLiability   int64     `json:"closing_liability"`
+	OutstandingBalance int64     `json:"outstanding_balance"` // face value still redeemable by holders
+	CardsOutstanding   int       `json:"cards_outstanding"`
+	GeneratedAt        time.Time `json:"generated_at"`
+}
+
+// ----------------------------------------------------------------------------
+// Stored Value Service
+// ----------------------------------------------------------------------------
+
+// StoredValueService manages gift card issuance, redemption and breakage
+type StoredValueService struct {
+	storage       *Storage
+	eventStore    *EventStore
+	postingEngine *PostingEngine
+}
+
+// NewStoredValueService creates a new stored value service
+func NewStoredValueService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine) *StoredValueService {
+	return &StoredValueService{
+		storage:       storage,
+		eventStore:    eventStore,
+		postingEngine: postingEngine,
+	}
+}
+
+// CreateProgram validates and saves a stored-value program
+func (ss *StoredValueService) CreateProgram(program *StoredValueProgram, userID string) error {
+	if program.Name == "" {
+		return fmt.Errorf("program name is required")
+	}
+	if program.Currency == "" {
+		return fmt.Errorf("program currency is required")
+	}
+	if program.RevenueAccountID == "" {
+		program.RevenueAccountID = DefaultRevenueAccountID
+	}
+	for _, accountID := range []string{program.LiabilityAccountID, program.RevenueAccountID, program.BreakageIncomeAccountID} {
+		if _, err := ss.storage.GetAccount(accountID); err != nil {
+			return fmt.Errorf("invalid account %q: %w", accountID, err)
+		}
+	}
+	switch program.BreakageModel {
+	case BreakageProportional:
+		if program.EstimatedBreakageRate <= 0 || program.EstimatedBreakageRate >= 1 {
+			return fmt.Errorf("estimated breakage rate must be between 0 and 1")
+		}
+	case BreakageRemote:
+		if program.RemoteAfterMonths <= 0 {
+			return fmt.Errorf("remote model requires a positive inactivity period")
+		}
+	default:
+		return fmt.Errorf("unsupported breakage model: %s", program.BreakageModel)
+	}
+	if program.ValidityMonths < 0 {
+		return fmt.Errorf("validity cannot be negative")
+	}
+
+	if err := ss.storage.assignID(&program.ID, "stored value program", BucketStoredValuePrograms); err != nil {
+		return err
+	}
+	program.CreatedBy = userID
+	program.CreatedAt = time.Now()
+
+	_, err := ss.eventStore.CreateEvent(EventCreateStoredValueProgram, program, program.CreatedAt, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create stored value program event: %w", err)
+	}
+	if err := ss.storage.SaveStoredValueProgram(program); err != nil {
+		return fmt.Errorf("failed to save stored value program: %w", err)
+	}
+	return nil
+}
+
+// IssueCard sells a card for cash, recognizing the full value as a liability
+func (ss *StoredValueService) IssueCard(programID string, value int64, customerID string, issuedAt time.Time, cashAccountID, userID string) (*GiftCard, error) {
+	program, err := ss.storage.GetStoredValueProgram(programID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored value program: %w", err)
+	}
+	if value <= 0 {
+		return nil, fmt.Errorf("card value must be positive")
+	}
+	if _, err := ss.storage.GetAccount(cashAccountID); err != nil {
+		return nil, fmt.Errorf("invalid cash account: %w", err)
+	}
+
+	card := &GiftCard{
+		ID:             newID(),
+		ProgramID:      program.ID,
+		CustomerID:     customerID,
+		InitialValue:   value,
+		Balance:        value,
+		IssuedAt:       issuedAt,
+		LastActivityAt: issuedAt,
+		Status:         GiftCardActive,
+		CreatedBy:      userID,
+		UpdatedAt:      time.Now(),
+	}
+	card.Code = strings.ToUpper(strings.ReplaceAll(card.ID, "-", "")[:16])
+	if program.ValidityMonths > 0 {
+		expiresAt := issuedAt.AddDate(0, program.ValidityMonths, 0)
+		card.ExpiresAt = &expiresAt
+	}
+
+	amount := Amount{Value: value, Currency: program.Currency}
+	txn := ss.newTransaction(fmt.Sprintf("Gift card %s issued", card.Code), issuedAt, fmt.Sprintf("GIFT_CARD_ISSUE_%s", card.ID), userID)
+	txn.Entries = []Entry{
+		{ID: newID(), TransactionID: txn.ID, AccountID: cashAccountID, Type: Debit, Amount: amount},
+		{ID: newID(), TransactionID: txn.ID, AccountID: program.LiabilityAccountID, Type: Credit, Amount: amount},
+	}
+	if err := ss.postTransaction(txn, userID); err != nil {
+		return nil, err
+	}
+	card.Activity = append(card.Activity, StoredValueActivity{Type: ActivityIssue, Amount: value, Date: issuedAt, TransactionID: txn.ID})
+
+	if err := ss.saveCard(card, EventIssueGiftCard, issuedAt, userID); err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// RedeemCard spends part of a card's balance, releasing the liability to revenue and
+// adjusting breakage to the new redemption pattern in the same transaction
+func (ss *StoredValueService) RedeemCard(cardID string, amount int64, redeemedAt time.Time, userID string) (*GiftCard, error) {
+	card, err := ss.storage.GetGiftCard(cardID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift card: %w", err)
+	}
+	program, err := ss.storage.GetStoredValueProgram(card.ProgramID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored value program: %w", err)
+	}
+	if card.Status != GiftCardActive {
+		return nil, fmt.Errorf("gift card is %s", card.Status)
+	}
+	if card.ExpiresAt != nil && redeemedAt.After(*card.ExpiresAt) {
+		return nil, fmt.Errorf("gift card expired on %s", card.ExpiresAt.Format("2006-01-02"))
+	}
+	if amount <= 0 {
+		return nil, fmt.Errorf("redemption amount must be positive")
+	}
+	if amount > card.Balance {
+		return nil, fmt.Errorf("redemption of %d exceeds card balance of %d", amount, card.Balance)
+	}
+
+	card.Balance -= amount
+	card.Redeemed += amount
+	card.LastActivityAt = redeemedAt
+	if card.Balance == 0 {
+		card.Status = GiftCardRedeemed
+	}
+
+	value := Amount{Value: amount, Currency: program.Currency}
+	txn := ss.newTransaction(fmt.Sprintf("Gift card %s redeemed", card.Code), redeemedAt, fmt.Sprintf("GIFT_CARD_REDEEM_%s", card.ID), userID)
+	txn.Entries = []Entry{
+		{ID: newID(), TransactionID: txn.ID, AccountID: program.LiabilityAccountID, Type: Debit, Amount: value},
+		{ID: newID(), TransactionID: txn.ID, AccountID: program.RevenueAccountID, Type: Credit, Amount: value},
+	}
+	delta, err := ss.breakageAdjustment(card, program, redeemedAt)
+	if err != nil {
+		return nil, err
+	}
+	txn.Entries = append(txn.Entries, breakageEntries(txn.ID, program, delta)...)
+	if err := ss.postTransaction(txn, userID); err != nil {
+		return nil, err
+	}
+
+	card.Activity = append(card.Activity, StoredValueActivity{Type: ActivityRedemption, Amount: amount, Date: redeemedAt, TransactionID: txn.ID})
+	if delta != 0 {
+		card.BreakageRecognized += delta
+		card.Activity = append(card.Activity, StoredValueActivity{Type: ActivityBreakage, Amount: delta, Date: redeemedAt, TransactionID: txn.ID})
+	}
+
+	if err := ss.saveCard(card, EventRedeemGiftCard, redeemedAt, userID); err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// RecognizeBreakage brings breakage on every active card up to date as of the date:
+// expired cards and, under the remote model, cards inactive beyond the program's
+// threshold have their remaining balance recognized. Safe to run at each period close.
+func (ss *StoredValueService) RecognizeBreakage(asOfDate time.Time, userID string) ([]*Transaction, error) {
+	cards, err := ss.storage.GetAllGiftCards()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift cards: %w", err)
+	}
+	programs := make(map[string]*StoredValueProgram)
+
+	var posted []*Transaction
+	for _, card := range cards {
+		if card.Status != GiftCardActive || card.IssuedAt.After(asOfDate) {
+			continue
+		}
+		program, ok := programs[card.ProgramID]
+		if !ok {
+			program, err = ss.storage.GetStoredValueProgram(card.ProgramID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get stored value program: %w", err)
+			}
+			programs[card.ProgramID] = program
+		}
+
+		delta, err := ss.breakageAdjustment(card, program, asOfDate)
+		if err != nil {
+			return nil, err
+		}
+		expired := card.ExpiresAt != nil && !card.ExpiresAt.After(asOfDate)
+		if delta == 0 && !expired {
+			continue
+		}
+
+		if delta != 0 {
+			txn := ss.newTransaction(fmt.Sprintf("Gift card %s breakage", card.Code), asOfDate, fmt.Sprintf("GIFT_CARD_BREAKAGE_%s", card.ID), userID)
+			txn.Entries = breakageEntries(txn.ID, program, delta)
+			if err := ss.postTransaction(txn, userID); err != nil {
+				return nil, err
+			}
+			card.BreakageRecognized += delta
+			card.Activity = append(card.Activity, StoredValueActivity{Type: ActivityBreakage, Amount: delta, Date: asOfDate, TransactionID: txn.ID})
+			posted = append(posted, txn)
+		}
+		if expired {
+			card.Status = GiftCardExpired
+		}
+		if err := ss.saveCard(card, EventRecognizeBreakage, asOfDate, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	return posted, nil
+}
+
+// GenerateRollForward reconciles a program's liability across a period from card activity
+func (ss *StoredValueService) GenerateRollForward(programID string, periodStart, periodEnd time.Time) (*StoredValueRollForward, error) {
+	program, err := ss.storage.GetStoredValueProgram(programID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored value program: %w", err)
+	}
+	cards, err := ss.storage.GetAllGiftCards()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift cards: %w", err)
+	}
+
+	report := &StoredValueRollForward{
+		ProgramID:   program.ID,
+		Currency:    program.Currency,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		GeneratedAt: time.Now(),
+	}
+	for _, card := range cards {
+		if card.ProgramID != program.ID {
+			continue
+		}
+		var balance int64
+		for _, activity := range card.Activity {
+			if activity.Date.After(periodEnd) {
+				continue
+			}
+			change := activity.Amount
+			if activity.Type != ActivityIssue {
+				change = -change
+			}
+			if activity.Date.Before(periodStart) {
+				report.OpeningLiability += change
+			} else {
+				switch activity.Type {
+				case ActivityIssue:
+					report.Issued += activity.Amount
+				case ActivityRedemption:
+					report.Redeemed += activity.Amount
+				case ActivityBreakage:
+					report.BreakageRecognized += activity.Amount
+				}
+			}
+			if activity.Type != ActivityBreakage {
+				balance += change
+			}
+		}
+		if balance > 0 && (card.ExpiresAt == nil || card.ExpiresAt.After(periodEnd)) {
+			report.OutstandingBalance += balance
+			report.CardsOutstanding++
+		}
+	}
+	report.ClosingLiability = report.OpeningLiability + report.Issued - report.Redeemed - report.BreakageRecognized
+
+	return report, nil
+}
+
+// GetCards returns a customer's gift cards, or all cards when customerID is empty
+func (ss *StoredValueService) GetCards(customerID string) ([]*GiftCard, error) {
+	cards, err := ss.storage.GetAllGiftCards()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gift cards: %w", err)
+	}
+
+	var result []*GiftCard
+	for _, card := range cards {
+		if customerID == "" || card.CustomerID == customerID {
+			result = append(result, card)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].IssuedAt.Before(result[j].IssuedAt)
+	})
+	return result, nil
+}
+
+// breakageAdjustment returns the change in recognized breakage needed to bring the
+// card to its target as of the date. The target is the whole balance once the card
+// has expired or, under the remote model, been inactive long enough; otherwise the
+// proportional model recognizes the estimated breakage in line with redemptions so
+// far. The target never exceeds the balance, so breakage recognized earlier is
+// reversed if the holder redeems more than expected.
+func (ss *StoredValueService) breakageAdjustment(card *GiftCard, program *StoredValueProgram, asOfDate time.Time) (int64, error) {
+	var target int64
+	switch {
+	case card.ExpiresAt != nil && !card.ExpiresAt.After(asOfDate):
+		target = card.Balance
+	case program.BreakageModel == BreakageRemote:
+		if !card.LastActivityAt.AddDate(0, program.RemoteAfterMonths, 0).After(asOfDate) {
+			target = card.Balance
+		}
+	case program.BreakageModel == BreakageProportional:
+		rate, err := decimalRat(program.EstimatedBreakageRate)
+		if err != nil {
+			return 0, fmt.Errorf("invalid breakage rate: %w", err)
+		}
+		initial := new(big.Rat).SetInt64(card.InitialValue)
+		expectedBreakage := new(big.Rat).Mul(initial, rate)
+		expectedRedemptions := new(big.Rat).Sub(initial, expectedBreakage)
+
+		progress := new(big.Rat).Quo(new(big.Rat).SetInt64(card.Redeemed), expectedRedemptions)
+		if progress.Cmp(big.NewRat(1, 1)) > 0 {
+			progress = big.NewRat(1, 1)
+		}
+		value, err := roundRat(new(big.Rat).Mul(expectedBreakage, progress), RoundHalfUp)
+		if err != nil {
+			return 0, fmt.Errorf("failed to estimate breakage: %w", err)
+		}
+		target = value
+	}
+	if target > card.Balance {
+		target = card.Balance
+	}
+	return target - card.BreakageRecognized, nil
+}
+
+// breakageEntries moves a breakage adjustment between the liability and breakage
+// income; a negative delta reinstates the liability
+func breakageEntries(txnID string, program *StoredValueProgram, delta int64) []Entry {
+	if delta == 0 {
+		return nil
+	}
+	debit, credit := program.LiabilityAccountID, program.BreakageIncomeAccountID
+	if delta < 0 {
+		debit, credit = credit, debit
+		delta = -delta
+	}
+	amount := Amount{Value: delta, Currency: program.Currency}
+	return []Entry{
+		{ID: newID(), TransactionID: txnID, AccountID: debit, Type: Debit, Amount: amount},
+		{ID: newID(), TransactionID: txnID, AccountID: credit, Type: Credit, Amount: amount},
+	}
+}
+
+// newTransaction creates a pending stored-value transaction without entries
+func (ss *StoredValueService) newTransaction(description string, validTime time.Time, sourceRef, userID string) *Transaction {
+	return &Transaction{
+		ID:              newID(),
+		Description:     description,
+		ValidTime:       validTime,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       sourceRef,
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// postTransaction records, saves and posts a stored-value transaction
+func (ss *StoredValueService) postTransaction(txn *Transaction, userID string) error {
+	_, err := ss.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := ss.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	if err := ss.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
+
+// saveCard records an event for the card and persists it
+func (ss *StoredValueService) saveCard(card *GiftCard, eventType string, validTime time.Time, userID string) error {
+	card.UpdatedAt = time.Now()
+	_, err := ss.eventStore.CreateEvent(eventType, card, validTime, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create gift card event: %w", err)
+	}
+	if err := ss.storage.SaveGiftCard(card); err != nil {
+		return fmt.Errorf("failed to save gift card: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoredValue(t *testing.T) {
+	// Setup
+	dbFile := "test_stored_value.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "treasury"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	accounts := []*Account{
+		{ID: "gift_card_liability", Code: "2400", Name: "Gift Card Liability", Type: Liability},
+		{ID: "remote_card_liability", Code: "2410", Name: "Prepaid Card Liability", Type: Liability},
+		{ID: "breakage_income", Code: "4300", Name: "Breakage Income", Type: Income},
+	}
+	for _, account := range accounts {
+		require.NoError(t, engine.CreateAccount(account, userID))
+	}
+
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	balance := func(accountID string) int64 {
+		result, err := engine.GetAccountBalance(accountID, date(2030, 1, 1))
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+
+	estimated := &StoredValueProgram{
+		Name:                    "Gift Cards",
+		Currency:                "USD",
+		LiabilityAccountID:      "gift_card_liability",
+		BreakageIncomeAccountID: "breakage_income",
+		BreakageModel:           BreakageProportional,
+		EstimatedBreakageRate:   0.2,
+		ValidityMonths:          12,
+	}
+	remote := &StoredValueProgram{
+		Name:                    "Prepaid Cards",
+		Currency:                "USD",
+		LiabilityAccountID:      "remote_card_liability",
+		BreakageIncomeAccountID: "breakage_income",
+		BreakageModel:           BreakageRemote,
+		RemoteAfterMonths:       24,
+	}
+
+	t.Run("Program Validation", func(t *testing.T) {
+		err := engine.CreateStoredValueProgram(&StoredValueProgram{
+			Name: "Bad", Currency: "USD", LiabilityAccountID: "gift_card_liability",
+			BreakageIncomeAccountID: "breakage_income", BreakageModel: BreakageProportional, EstimatedBreakageRate: 1.5,
+		}, userID)
+		assert.Error(t, err)
+
+		require.NoError(t, engine.CreateStoredValueProgram(estimated, userID))
+		require.NoError(t, engine.CreateStoredValueProgram(remote, userID))
+		assert.Equal(t, DefaultRevenueAccountID, estimated.RevenueAccountID)
+	})
+
+	t.Run("Proportional Breakage", func(t *testing.T) {
+		card, err := engine.IssueGiftCard(estimated.ID, 10000, "cust_1", date(2024, 1, 1), "cash", userID)
+		require.NoError(t, err)
+		assert.Len(t, card.Code, 16)
+		require.NotNil(t, card.ExpiresAt)
+		assert.Equal(t, int64(10000), balance("gift_card_liability"))
+
+		// Half the expected redemptions recognizes half the expected breakage
+		card, err = engine.RedeemGiftCard(card.ID, 4000, date(2024, 2, 1), userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), card.BreakageRecognized)
+		assert.Equal(t, int64(5000), balance("gift_card_liability"))
+		assert.Equal(t, int64(1000), balance("breakage_income"))
+
+		card, err = engine.RedeemGiftCard(card.ID, 4000, date(2024, 3, 1), userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2000), card.BreakageRecognized)
+		assert.Equal(t, int64(0), card.CarryingLiability())
+
+		// Redeeming beyond the estimate reverses breakage rather than overdrawing the liability
+		card, err = engine.RedeemGiftCard(card.ID, 1000, date(2024, 4, 1), userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1000), card.BreakageRecognized)
+		assert.Equal(t, int64(0), balance("gift_card_liability"))
+		assert.Equal(t, int64(1000), balance("breakage_income"))
+
+		_, err = engine.RedeemGiftCard(card.ID, 5000, date(2024, 5, 1), userID)
+		assert.Error(t, err)
+	})
+
+	t.Run("Expiry", func(t *testing.T) {
+		card, err := engine.IssueGiftCard(estimated.ID, 2000, "cust_2", date(2024, 1, 1), "cash", userID)
+		require.NoError(t, err)
+
+		_, err = engine.RedeemGiftCard(card.ID, 500, date(2025, 2, 1), userID)
+		assert.Error(t, err)
+
+		_, err = engine.RecognizeBreakage(date(2025, 1, 1), userID)
+		require.NoError(t, err)
+		cards, err := engine.GetStoredValueService().GetCards("cust_2")
+		require.NoError(t, err)
+		require.Len(t, cards, 1)
+		assert.Equal(t, GiftCardExpired, cards[0].Status)
+		assert.Equal(t, int64(2000), cards[0].BreakageRecognized)
+		assert.Equal(t, int64(3000), balance("breakage_income"))
+	})
+
+	t.Run("Remote Breakage", func(t *testing.T) {
+		card, err := engine.IssueGiftCard(remote.ID, 5000, "cust_3", date(2024, 1, 1), "cash", userID)
+		require.NoError(t, err)
+		_, err = engine.RedeemGiftCard(card.ID, 1000, date(2024, 3, 1), userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4000), balance("remote_card_liability"))
+
+		posted, err := engine.RecognizeBreakage(date(2026, 2, 1), userID)
+		require.NoError(t, err)
+		assert.Empty(t, posted)
+
+		posted, err = engine.RecognizeBreakage(date(2026, 3, 1), userID)
+		require.NoError(t, err)
+		assert.Len(t, posted, 1)
+		assert.Equal(t, int64(0), balance("remote_card_liability"))
+
+		// Running again is a no-op
+		posted, err = engine.RecognizeBreakage(date(2026, 3, 31), userID)
+		require.NoError(t, err)
+		assert.Empty(t, posted)
+
+		// A holder returning after breakage reinstates the liability
+		card, err = engine.RedeemGiftCard(card.ID, 1500, date(2026, 4, 1), userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), card.BreakageRecognized)
+		assert.Equal(t, int64(2500), balance("remote_card_liability"))
+	})
+
+	t.Run("Liability Roll-Forward", func(t *testing.T) {
+		report, err := engine.GenerateStoredValueRollForward(remote.ID, date(2026, 3, 1), date(2026, 4, 30))
+		require.NoError(t, err)
+		assert.Equal(t, int64(4000), report.OpeningLiability)
+		assert.Equal(t, int64(0), report.Issued)
+		assert.Equal(t, int64(1500), report.Redeemed)
+		assert.Equal(t, int64(0), report.BreakageRecognized)
+		assert.Equal(t, int64(2500), report.ClosingLiability)
+		assert.Equal(t, int64(2500), report.OutstandingBalance)
+		assert.Equal(t, balance("remote_card_liability"), report.ClosingLiability)
+
+		report, err = engine.GenerateStoredValueRollForward(estimated.ID, date(2024, 1, 1), date(2024, 12, 31))
+		require.NoError(t, err)
+		assert.Equal(t, int64(12000), report.Issued)
+		assert.Equal(t, int64(9000), report.Redeemed)
+		assert.Equal(t, int64(1000), report.BreakageRecognized)
+		assert.Equal(t, int64(2000), report.ClosingLiability)
+		assert.Equal(t, 2, report.CardsOutstanding)
+	})
+
+	t.Run("Half Points Round Up", func(t *testing.T) {
+		vouchers := &StoredValueProgram{
+			Name:                    "Vouchers",
+			Currency:                "USD",
+			LiabilityAccountID:      "gift_card_liability",
+			BreakageIncomeAccountID: "breakage_income",
+			BreakageModel:           BreakageProportional,
+			EstimatedBreakageRate:   0.6,
+		}
+		require.NoError(t, engine.CreateStoredValueProgram(vouchers, userID))
+		card, err := engine.IssueGiftCard(vouchers.ID, 10, "cust_4", date(2025, 1, 1), "cash", userID)
+		require.NoError(t, err)
+
+		// A quarter of the expected 4 redeemed recognizes a quarter of the 6 breakage,
+		// exactly 1.5 rather than the 1.4999... of 0.6's binary expansion
+		card, err = engine.RedeemGiftCard(card.ID, 1, date(2025, 2, 1), userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), card.BreakageRecognized)
+	})
+}
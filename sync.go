@@ -0,0 +1,258 @@
+package accounting
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// SyncConflictPolicy decides what happens to a record both databases hold in
+// different versions
+type SyncConflictPolicy string
+
+const (
+	SyncKeepLocal  SyncConflictPolicy = "KEEP_LOCAL"  // keep this database's version and report the conflict
+	SyncTakeRemote SyncConflictPolicy = "TAKE_REMOTE" // overwrite with the incoming version
+)
+
+// syncDerivedBuckets are regenerated from primary records after an import rather
+// than copied between databases
+var syncDerivedBuckets = [][]byte{
+	BucketBalanceSnapshots, BucketBalanceMovements, BucketPostingsByTime, BucketPostingsByAccount,
+	BucketEntries, BucketAMLAggregates,
+}
+
+// syncBaseKey keys a record's agreed version in the sync base bucket
+func syncBaseKey(bucket, key string) []byte {
+	return []byte(bucket + "/" + key)
+}
+
+// SyncManifest lists the version hash of every primary record in a database, by
+// bucket and key. The other side compares it with its own records to work out what
+// to send.
+type SyncManifest struct {
+	Buckets     map[string]map[string]string `json:"buckets"`
+	GeneratedAt time.Time                    `json:"generated_at"`
+}
+
+// SyncRecord is one record as stored, with its version hash and the version the
+// sending database last agreed on with a peer
+type SyncRecord struct {
+	Bucket   string `json:"bucket"`
+	Key      string `json:"key"`
+	Value    []byte `json:"value"`
+	Hash     string `json:"hash"`
+	BaseHash string `json:"base_hash,omitempty"`
+}
+
+// SyncPackage carries the records one database has that another lacks or holds in a
+// different version
+type SyncPackage struct {
+	Records     []SyncRecord `json:"records"`
+	GeneratedAt time.Time    `json:"generated_at"`
+}
+
+// SyncConflict is a record both databases changed independently
+type SyncConflict struct {
+	Bucket     string `json:"bucket"`
+	Key        string `json:"key"`
+	LocalHash  string `json:"local_hash"`
+	RemoteHash string `json:"remote_hash"`
+	TookRemote bool   `json:"took_remote"`
+}
+
+// SyncResult reports what an import applied
+type SyncResult struct {
+	Added     int            `json:"added"`
+	Updated   int            `json:"updated"`
+	Unchanged int            `json:"unchanged"`
+	Kept      int            `json:"kept"` // changed only here since the last sync, so the older remote version was ignored
+	Conflicts []SyncConflict `json:"conflicts,omitempty"`
+}
+
+// syncHash is the version hash of a stored record
+func syncHash(value []byte) string {
+	sum := sha256.Sum256(value)
+	return hex.EncodeToString(sum[:])
+}
+
+// isSyncDerived reports whether a bucket is rebuilt rather than synced
+func isSyncDerived(name []byte) bool {
+	for _, bucket := range syncDerivedBuckets {
+		if bytes.Equal(bucket, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSynced reports whether a bucket's records are sent between databases: derived
+// buckets are rebuilt instead, and the sync base belongs to this database alone
+func isSynced(name []byte) bool {
+	return !isSyncDerived(name) && !bytes.Equal(name, BucketSyncBase)
+}
+
+// forEachSyncRecord visits every primary record in the database
+func forEachSyncRecord(tx *bbolt.Tx, fn func(bucket string, key, value []byte) error) error {
+	return tx.ForEach(func(name []byte, b *bbolt.Bucket) error {
+		if !isSynced(name) {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			if v == nil {
+				return nil // nested bucket
+			}
+			return fn(string(name), k, v)
+		})
+	})
+}
+
+// SyncManifest hashes every primary record so another database can work out which
+// records it needs to send here
+func (s *Storage) SyncManifest() (*SyncManifest, error) {
+	manifest := &SyncManifest{Buckets: make(map[string]map[string]string), GeneratedAt: time.Now()}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return forEachSyncRecord(tx, func(bucket string, key, value []byte) error {
+			if manifest.Buckets[bucket] == nil {
+				manifest.Buckets[bucket] = make(map[string]string)
+			}
+			manifest.Buckets[bucket][string(key)] = syncHash(value)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build sync manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// ExportChanges packages the primary records the remote manifest lacks or holds in a
+// different version, in bucket and key order
+func (s *Storage) ExportChanges(remote *SyncManifest) (*SyncPackage, error) {
+	if remote == nil {
+		remote = &SyncManifest{}
+	}
+	pkg := &SyncPackage{GeneratedAt: time.Now()}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		base := tx.Bucket(BucketSyncBase)
+		return forEachSyncRecord(tx, func(bucket string, key, value []byte) error {
+			hash := syncHash(value)
+			if remote.Buckets[bucket][string(key)] == hash {
+				return nil
+			}
+			pkg.Records = append(pkg.Records, SyncRecord{
+				Bucket:   bucket,
+				Key:      string(key),
+				Value:    bytes.Clone(value),
+				Hash:     hash,
+				BaseHash: string(base.Get(syncBaseKey(bucket, string(key)))),
+			})
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export changes: %w", err)
+	}
+	return pkg, nil
+}
+
+// ImportChanges applies a package exported by another database in one write. Missing
+// records are added. A record held here in another version is compared with the
+// version the two sides last agreed on, recorded here at each sync and sent along by
+// the other side: changed only remotely, it is updated; changed only here, it is
+// kept; changed on both sides, or never agreed, the conflict policy settles it.
+// Derived stores are rebuilt afterwards so balances and indexes reflect the imported
+// records.
+func (s *Storage) ImportChanges(pkg *SyncPackage, policy SyncConflictPolicy) (*SyncResult, error) {
+	if policy == "" {
+		policy = SyncKeepLocal
+	}
+	if policy != SyncKeepLocal && policy != SyncTakeRemote {
+		return nil, fmt.Errorf("unknown sync conflict policy %s", policy)
+	}
+	for _, record := range pkg.Records {
+		if syncHash(record.Value) != record.Hash {
+			return nil, fmt.Errorf("sync record %s/%s does not match its hash", record.Bucket, record.Key)
+		}
+		if !isSynced([]byte(record.Bucket)) {
+			return nil, fmt.Errorf("sync record %s/%s is in unsynced bucket %s", record.Bucket, record.Key, record.Bucket)
+		}
+	}
+
+	result := &SyncResult{}
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		base := tx.Bucket(BucketSyncBase)
+		for _, record := range pkg.Records {
+			b, err := tx.CreateBucketIfNotExists([]byte(record.Bucket))
+			if err != nil {
+				return fmt.Errorf("failed to create bucket %s: %w", record.Bucket, err)
+			}
+			baseKey := syncBaseKey(record.Bucket, record.Key)
+			existing := b.Get([]byte(record.Key))
+			switch {
+			case existing == nil:
+				result.Added++
+			case bytes.Equal(existing, record.Value):
+				result.Unchanged++
+				if err := base.Put(baseKey, []byte(record.Hash)); err != nil {
+					return err
+				}
+				continue
+			default:
+				localHash := syncHash(existing)
+				agreed := func(hash string) bool {
+					return hash == string(base.Get(baseKey)) || hash == record.BaseHash
+				}
+				localChanged, remoteChanged := !agreed(localHash), !agreed(record.Hash)
+				if !localChanged && remoteChanged {
+					result.Updated++
+					break
+				}
+				if localChanged && !remoteChanged {
+					result.Kept++
+					continue
+				}
+				conflict := SyncConflict{
+					Bucket:     record.Bucket,
+					Key:        record.Key,
+					LocalHash:  localHash,
+					RemoteHash: record.Hash,
+					TookRemote: policy == SyncTakeRemote,
+				}
+				result.Conflicts = append(result.Conflicts, conflict)
+				if !conflict.TookRemote {
+					continue
+				}
+				result.Updated++
+			}
+			if err := b.Put([]byte(record.Key), record.Value); err != nil {
+				return err
+			}
+			if err := base.Put(baseKey, []byte(record.Hash)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to import changes: %w", err)
+	}
+
+	sort.Slice(result.Conflicts, func(i, j int) bool {
+		if result.Conflicts[i].Bucket != result.Conflicts[j].Bucket {
+			return result.Conflicts[i].Bucket < result.Conflicts[j].Bucket
+		}
+		return result.Conflicts[i].Key < result.Conflicts[j].Key
+	})
+	if result.Added+result.Updated > 0 {
+		if _, err := s.Rebuild(RebuildAll); err != nil {
+			return result, fmt.Errorf("imported changes but failed to rebuild derived data: %w", err)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,143 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDifferentialSync(t *testing.T) {
+	serverFile, laptopFile := "test_sync_server.db", "test_sync_laptop.db"
+	defer os.Remove(serverFile)
+	defer os.Remove(laptopFile)
+
+	server, err := NewAccountingEngine(serverFile)
+	require.NoError(t, err)
+	defer server.Close()
+	laptop, err := NewAccountingEngine(laptopFile)
+	require.NoError(t, err)
+	defer laptop.Close()
+
+	userID := "field_accountant"
+	require.NoError(t, server.CreateStandardAccounts(userID))
+
+	post := func(engine *AccountingEngine, value int64, description string) *Transaction {
+		txn := &Transaction{
+			Description: description,
+			ValidTime:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: value, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: value, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+	cash := func(engine *AccountingEngine) int64 {
+		balance, err := engine.GetAccountBalance("cash", time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+		return balance.Balance.Value
+	}
+	syncInto := func(from, to *AccountingEngine, policy SyncConflictPolicy) *SyncResult {
+		manifest, err := to.ExportSyncManifest()
+		require.NoError(t, err)
+		pkg, err := from.ExportChanges(manifest)
+		require.NoError(t, err)
+		result, err := to.ImportChanges(pkg, policy, userID)
+		require.NoError(t, err)
+		return result
+	}
+
+	post(server, 1000, "Counter sale")
+
+	t.Run("initial copy", func(t *testing.T) {
+		result := syncInto(server, laptop, SyncKeepLocal)
+		assert.Positive(t, result.Added)
+		assert.Empty(t, result.Conflicts)
+		assert.Equal(t, int64(1000), cash(laptop))
+	})
+
+	t.Run("offline work flows both ways", func(t *testing.T) {
+		field := post(laptop, 250, "Site visit invoice")
+		post(server, 400, "Online sale")
+
+		syncInto(laptop, server, SyncKeepLocal)
+		syncInto(server, laptop, SyncKeepLocal)
+		assert.Equal(t, int64(1650), cash(server))
+		assert.Equal(t, int64(1650), cash(laptop))
+
+		entries, err := server.GetStorage().GetEntriesByAccount("cash")
+		require.NoError(t, err)
+		assert.Len(t, entries, 3)
+		stored, err := server.GetStorage().GetTransaction(field.ID)
+		require.NoError(t, err)
+		assert.Equal(t, Posted, stored.Status)
+
+		manifest, err := server.ExportSyncManifest()
+		require.NoError(t, err)
+		pkg, err := server.ExportChanges(manifest)
+		require.NoError(t, err)
+		assert.Empty(t, pkg.Records, "a database has nothing to send itself")
+	})
+
+	rename := func(engine *AccountingEngine, name string) {
+		account, err := engine.GetStorage().GetAccount("cash")
+		require.NoError(t, err)
+		account.Name = name
+		require.NoError(t, engine.GetStorage().SaveAccount(account))
+	}
+	cashName := func(engine *AccountingEngine) string {
+		account, err := engine.GetStorage().GetAccount("cash")
+		require.NoError(t, err)
+		return account.Name
+	}
+
+	t.Run("changes made on one side are not conflicts", func(t *testing.T) {
+		rename(server, "Cash on hand")
+		result := syncInto(server, laptop, SyncKeepLocal)
+		assert.Empty(t, result.Conflicts)
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, "Cash on hand", cashName(laptop))
+
+		rename(laptop, "Petty cash")
+		result = syncInto(server, laptop, SyncKeepLocal)
+		assert.Empty(t, result.Conflicts)
+		assert.Equal(t, 1, result.Kept, "the server still holds the version the laptop changed")
+		assert.Equal(t, "Petty cash", cashName(laptop))
+
+		result = syncInto(laptop, server, SyncKeepLocal)
+		assert.Empty(t, result.Conflicts, "the laptop sends the version it changed from")
+		assert.Equal(t, 1, result.Updated)
+		assert.Equal(t, "Petty cash", cashName(server))
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		rename(server, "Cash at HQ")
+		rename(laptop, "Cash at site")
+
+		result := syncInto(server, laptop, SyncKeepLocal)
+		require.Len(t, result.Conflicts, 1)
+		assert.Equal(t, string(BucketAccounts), result.Conflicts[0].Bucket)
+		assert.Equal(t, "cash", result.Conflicts[0].Key)
+		assert.False(t, result.Conflicts[0].TookRemote)
+		account, err := laptop.GetStorage().GetAccount("cash")
+		require.NoError(t, err)
+		assert.Equal(t, "Cash at site", account.Name)
+
+		result = syncInto(server, laptop, SyncTakeRemote)
+		assert.Equal(t, 1, result.Updated)
+		account, err = laptop.GetStorage().GetAccount("cash")
+		require.NoError(t, err)
+		assert.Equal(t, "Cash at HQ", account.Name)
+	})
+
+	t.Run("rejects tampered records", func(t *testing.T) {
+		pkg := &SyncPackage{Records: []SyncRecord{{Bucket: string(BucketAccounts), Key: "cash", Value: []byte("x"), Hash: "00"}}}
+		_, err := laptop.ImportChanges(pkg, SyncTakeRemote, userID)
+		assert.ErrorContains(t, err, "does not match its hash")
+	})
+}
@@ -0,0 +1,212 @@
+package accounting
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Tax Line Structures
+// ----------------------------------------------------------------------------
+
+// TaxLineRule marks entries as taxable, by account or by dimension, and says where
+// the tax calculated on them is posted. Tax on a credit (a sale) is credited to the
+// output tax account and charged to the receivable; tax on a debit (a purchase) is
+// debited to the input tax account and added to the payable.
+type TaxLineRule struct {
+	ID                  string          `json:"id"`
+	Name                string          `json:"name"`
+	AccountID           string          `json:"account_id,omitempty"` // entries on this account are taxable
+	Dimension           *Dimension      `json:"dimension,omitempty"`  // or entries tagged with this dimension
+	Jurisdiction        TaxJurisdiction `json:"jurisdiction"`
+	TaxType             TaxType         `json:"tax_type"`
+	OutputTaxAccountID  string          `json:"output_tax_account_id"` // e.g. VAT payable
+	InputTaxAccountID   string          `json:"input_tax_account_id"`  // e.g. VAT receivable
+	ReceivableAccountID string          `json:"receivable_account_id,omitempty"`
+	PayableAccountID    string          `json:"payable_account_id,omitempty"`
+	Active              bool            `json:"active"`
+	CreatedBy           string          `json:"created_by"`
+	CreatedAt           time.Time       `json:"created_at"`
+}
+
+// matches reports whether an entry is taxable under the rule
+func (r *TaxLineRule) matches(entry *Entry) bool {
+	if entry.AccountID == r.OutputTaxAccountID || entry.AccountID == r.InputTaxAccountID {
+		return false
+	}
+	if r.AccountID != "" && entry.AccountID == r.AccountID {
+		return true
+	}
+	return r.Dimension != nil && slices.Contains(entry.Dimensions, *r.Dimension)
+}
+
+// TaxLine links a taxable entry to the tax entries generated for it and the
+// calculation behind them
+type TaxLine struct {
+	RuleID         string          `json:"rule_id"`
+	TaxableEntryID string          `json:"taxable_entry_id"`
+	TaxEntryID     string          `json:"tax_entry_id"`
+	OffsetEntryID  string          `json:"offset_entry_id"`
+	Calculation    *TaxCalculation `json:"calculation"`
+}
+
+// TransactionTaxLines are the tax lines generated for one transaction
+type TransactionTaxLines struct {
+	TransactionID string     `json:"transaction_id"`
+	Lines         []*TaxLine `json:"lines"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// appendedTaxLine is a tax line whose entries have been appended to a transaction
+// but not yet given IDs
+type appendedTaxLine struct {
+	line                      *TaxLine
+	taxable, taxEntry, offset int
+}
+
+// ----------------------------------------------------------------------------
+// Tax Line Service
+// ----------------------------------------------------------------------------
+
+// TaxLineService adds tax entries to transactions with taxable entries
+type TaxLineService struct {
+	storage    *Storage
+	eventStore *EventStore
+	compliance *ComplianceService
+}
+
+// NewTaxLineService creates a new tax line service
+func NewTaxLineService(storage *Storage, eventStore *EventStore, compliance *ComplianceService) *TaxLineService {
+	return &TaxLineService{
+		storage:    storage,
+		eventStore: eventStore,
+		compliance: compliance,
+	}
+}
+
+// SaveRule adds or replaces a tax line rule
+func (ts *TaxLineService) SaveRule(rule *TaxLineRule, userID string) error {
+	if rule.AccountID == "" && rule.Dimension == nil {
+		return classify(ErrValidation, "tax line rule needs an account or a dimension")
+	}
+	if rule.Jurisdiction == "" || rule.TaxType == "" {
+		return classify(ErrValidation, "tax line rule needs a jurisdiction and tax type")
+	}
+	if rule.OutputTaxAccountID == "" && rule.InputTaxAccountID == "" {
+		return classify(ErrValidation, "tax line rule needs an output or input tax account")
+	}
+	rule.ReceivableAccountID = cmp.Or(rule.ReceivableAccountID, DefaultReceivableAccountID)
+	rule.PayableAccountID = cmp.Or(rule.PayableAccountID, DefaultPayablesAccountID)
+
+	accountIDs := []string{rule.ReceivableAccountID, rule.PayableAccountID}
+	for _, accountID := range []string{rule.AccountID, rule.OutputTaxAccountID, rule.InputTaxAccountID} {
+		if accountID != "" {
+			accountIDs = append(accountIDs, accountID)
+		}
+	}
+	for _, accountID := range accountIDs {
+		if _, err := ts.storage.GetAccount(accountID); err != nil {
+			return fmt.Errorf("invalid account %s: %w", accountID, err)
+		}
+	}
+
+	if rule.ID == "" {
+		rule.ID = newID()
+		rule.Active = true
+		rule.CreatedBy = userID
+		rule.CreatedAt = time.Now()
+	}
+	_, err := ts.eventStore.CreateEvent(EventSaveTaxLineRule, rule, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to create tax line rule event: %w", err)
+	}
+	if err := ts.storage.SaveTaxLineRule(rule); err != nil {
+		return fmt.Errorf("failed to save tax line rule: %w", err)
+	}
+	return nil
+}
+
+// appendTaxLines calculates tax on each taxable entry of a new transaction and
+// appends a balanced pair of entries for it: the tax on the output or input tax
+// account, and its counterpart on the receivable or payable. Entries on a rule's
+// tax accounts are never taxed themselves; entry tags are claimed as exemptions.
+func (ts *TaxLineService) appendTaxLines(txn *Transaction) ([]appendedTaxLine, error) {
+	rules, err := ts.storage.GetAllTaxLineRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax line rules: %w", err)
+	}
+	rules = slices.DeleteFunc(rules, func(rule *TaxLineRule) bool { return !rule.Active })
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	slices.SortFunc(rules, func(a, b *TaxLineRule) int { return cmp.Compare(a.ID, b.ID) })
+
+	var appended []appendedTaxLine
+	for i, count := 0, len(txn.Entries); i < count; i++ {
+		entry := txn.Entries[i]
+		index := slices.IndexFunc(rules, func(rule *TaxLineRule) bool { return rule.matches(&entry) })
+		if index < 0 {
+			continue
+		}
+		rule := rules[index]
+
+		taxAccountID, offsetAccountID := rule.OutputTaxAccountID, rule.ReceivableAccountID
+		if entry.Type == Debit {
+			taxAccountID, offsetAccountID = rule.InputTaxAccountID, rule.PayableAccountID
+		}
+		if taxAccountID == "" {
+			continue
+		}
+
+		calc, tax, err := ts.compliance.calculateTaxOnAmount(&entry.Amount, rule.Jurisdiction, rule.TaxType, entry.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate tax on entry %d: %w", i+1, err)
+		}
+		if tax == 0 {
+			continue
+		}
+
+		amount := Amount{Value: tax, Currency: entry.Amount.Currency}
+		offsetType := Debit
+		if entry.Type == Debit {
+			offsetType = Credit
+		}
+		txn.Entries = append(txn.Entries,
+			Entry{AccountID: taxAccountID, Type: entry.Type, Amount: amount, Dimensions: slices.Clone(entry.Dimensions)},
+			Entry{AccountID: offsetAccountID, Type: offsetType, Amount: amount, Dimensions: slices.Clone(entry.Dimensions)},
+		)
+		appended = append(appended, appendedTaxLine{
+			line:     &TaxLine{RuleID: rule.ID, Calculation: calc},
+			taxable:  i,
+			taxEntry: len(txn.Entries) - 2,
+			offset:   len(txn.Entries) - 1,
+		})
+	}
+	return appended, nil
+}
+
+// recordTaxLines stores the link between a saved transaction's taxable entries and
+// the tax entries appended for them
+func (ts *TaxLineService) recordTaxLines(txn *Transaction, appended []appendedTaxLine, userID string) error {
+	if len(appended) == 0 {
+		return nil
+	}
+	record := &TransactionTaxLines{TransactionID: txn.ID, CreatedAt: time.Now()}
+	for _, applied := range appended {
+		applied.line.TaxableEntryID = txn.Entries[applied.taxable].ID
+		applied.line.TaxEntryID = txn.Entries[applied.taxEntry].ID
+		applied.line.OffsetEntryID = txn.Entries[applied.offset].ID
+		record.Lines = append(record.Lines, applied.line)
+	}
+
+	_, err := ts.eventStore.CreateEvent(EventRecordTaxLines, record, txn.ValidTime, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create tax lines event: %w", err)
+	}
+	if err := ts.storage.SaveTransactionTaxLines(record); err != nil {
+		return fmt.Errorf("failed to save tax lines: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxLines(t *testing.T) {
+	// Setup
+	dbFile := "test_tax_lines.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "bookkeeper"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "vat_payable", Code: "2450", Name: "VAT Payable", Type: Liability}, userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "vat_receivable", Code: "1450", Name: "VAT Receivable", Type: Asset}, userID))
+	require.NoError(t, engine.GetComplianceService().CreateTaxRule(TaxRule{
+		Jurisdiction: EU_VAT, TaxType: VAT, Name: "Standard VAT", Rate: 0.20,
+		EffectiveFrom: time.Now().AddDate(-1, 0, 0), Exemptions: []string{"zero_rated"},
+	}))
+
+	sales := &TaxLineRule{
+		Name: "Output VAT", AccountID: "revenue", Jurisdiction: EU_VAT, TaxType: VAT,
+		OutputTaxAccountID: "vat_payable",
+	}
+	require.NoError(t, engine.SaveTaxLineRule(sales, userID))
+	assert.True(t, sales.Active)
+	assert.Equal(t, DefaultReceivableAccountID, sales.ReceivableAccountID)
+
+	date := time.Date(2025, 5, 12, 0, 0, 0, 0, time.UTC)
+	usd := func(value int64) Amount { return Amount{Value: value, Currency: "USD"} }
+	find := func(txn *Transaction, id string) Entry {
+		for _, entry := range txn.Entries {
+			if entry.ID == id {
+				return entry
+			}
+		}
+		t.Fatalf("entry %s not found", id)
+		return Entry{}
+	}
+
+	t.Run("Output Tax On Sales", func(t *testing.T) {
+		sale := &Transaction{
+			Description: "Invoice 1001",
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: "accounts_receivable", Type: Debit, Amount: usd(10000)},
+				{AccountID: "revenue", Type: Credit, Amount: usd(10000)},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(sale, userID))
+		require.Len(t, sale.Entries, 4)
+		require.NoError(t, engine.PostTransaction(sale.ID, userID))
+
+		lines, err := engine.GetTransactionTaxLines(sale.ID)
+		require.NoError(t, err)
+		require.Len(t, lines.Lines, 1)
+		line := lines.Lines[0]
+		assert.Equal(t, sales.ID, line.RuleID)
+		assert.Equal(t, "revenue", find(sale, line.TaxableEntryID).AccountID)
+
+		tax := find(sale, line.TaxEntryID)
+		assert.Equal(t, "vat_payable", tax.AccountID)
+		assert.Equal(t, Credit, tax.Type)
+		assert.Equal(t, int64(2000), tax.Amount.Value)
+		offset := find(sale, line.OffsetEntryID)
+		assert.Equal(t, "accounts_receivable", offset.AccountID)
+		assert.Equal(t, Debit, offset.Type)
+
+		require.NotNil(t, line.Calculation)
+		assert.InDelta(t, 20.0, line.Calculation.TaxAmount, 1e-9)
+		assert.InDelta(t, 0.20, line.Calculation.TaxRate, 1e-9)
+
+		balance, err := engine.GetAccountBalance("vat_payable", date)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2000), balance.Balance.Value)
+	})
+
+	t.Run("Input Tax On Purchases By Dimension", func(t *testing.T) {
+		purchases := &TaxLineRule{
+			Name: "Input VAT", Dimension: &Dimension{Key: DimRegion, Value: "EU"}, Jurisdiction: EU_VAT, TaxType: VAT,
+			InputTaxAccountID: "vat_receivable",
+		}
+		require.NoError(t, engine.SaveTaxLineRule(purchases, userID))
+
+		purchase := &Transaction{
+			Description: "Supplier bill",
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: usd(5000), Dimensions: []Dimension{{Key: DimRegion, Value: "EU"}}},
+				{AccountID: "accounts_payable", Type: Credit, Amount: usd(5000)},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(purchase, userID))
+		require.Len(t, purchase.Entries, 4)
+
+		lines, err := engine.GetTransactionTaxLines(purchase.ID)
+		require.NoError(t, err)
+		require.Len(t, lines.Lines, 1)
+		tax := find(purchase, lines.Lines[0].TaxEntryID)
+		assert.Equal(t, "vat_receivable", tax.AccountID)
+		assert.Equal(t, Debit, tax.Type)
+		assert.Equal(t, int64(1000), tax.Amount.Value)
+		assert.Equal(t, []Dimension{{Key: DimRegion, Value: "EU"}}, tax.Dimensions)
+		offset := find(purchase, lines.Lines[0].OffsetEntryID)
+		assert.Equal(t, DefaultPayablesAccountID, offset.AccountID)
+		assert.Equal(t, Credit, offset.Type)
+
+		rules, err := engine.GetTaxLineRules()
+		require.NoError(t, err)
+		assert.Len(t, rules, 2)
+	})
+
+	t.Run("Exempt And Untaxed Entries", func(t *testing.T) {
+		exempt := &Transaction{
+			Description: "Zero-rated export",
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: "accounts_receivable", Type: Debit, Amount: usd(3000)},
+				{AccountID: "revenue", Type: Credit, Amount: usd(3000), Tags: []string{"zero_rated"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(exempt, userID))
+		assert.Len(t, exempt.Entries, 2)
+		_, err := engine.GetTransactionTaxLines(exempt.ID)
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		transfer := &Transaction{
+			Description: "Owner contribution",
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: usd(700)},
+				{AccountID: "accounts_payable", Type: Credit, Amount: usd(700)},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(transfer, userID))
+		assert.Len(t, transfer.Entries, 2)
+	})
+
+	t.Run("Rejected Transactions Keep Their Entries", func(t *testing.T) {
+		unbalanced := &Transaction{
+			Description: "Typo",
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: "accounts_receivable", Type: Debit, Amount: usd(100)},
+				{AccountID: "revenue", Type: Credit, Amount: usd(90)},
+			},
+		}
+		assert.Error(t, engine.CreateTransaction(unbalanced, userID))
+		assert.Len(t, unbalanced.Entries, 2)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		err := engine.SaveTaxLineRule(&TaxLineRule{Jurisdiction: EU_VAT, TaxType: VAT, OutputTaxAccountID: "vat_payable"}, userID)
+		assert.ErrorIs(t, err, ErrValidation)
+
+		err = engine.SaveTaxLineRule(&TaxLineRule{AccountID: "revenue", Jurisdiction: EU_VAT, TaxType: VAT, OutputTaxAccountID: "missing"}, userID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Tax Rounded Once Under The Jurisdiction Policy", func(t *testing.T) {
+		// 20% of 0.09 is 0.018, which the jurisdiction truncates to a cent
+		require.NoError(t, engine.GetComplianceService().SetTaxRounding(TaxRounding{Jurisdiction: EU_VAT, Policy: RoundTowardZero, Level: TaxRoundTotal}))
+		sale := &Transaction{
+			Description: "Invoice 1002",
+			ValidTime:   date,
+			Entries: []Entry{
+				{AccountID: "accounts_receivable", Type: Debit, Amount: usd(9)},
+				{AccountID: "revenue", Type: Credit, Amount: usd(9)},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(sale, userID))
+
+		lines, err := engine.GetTransactionTaxLines(sale.ID)
+		require.NoError(t, err)
+		require.Len(t, lines.Lines, 1)
+		assert.Equal(t, int64(1), find(sale, lines.Lines[0].TaxEntryID).Amount.Value)
+		assert.InDelta(t, 0.01, lines.Lines[0].Calculation.TaxAmount, 1e-9)
+	})
+}
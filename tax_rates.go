@@ -0,0 +1,146 @@
+package accounting
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Tiered Rates
+// ----------------------------------------------------------------------------
+
+// validate checks a rule's rate and brackets. Brackets must be in ascending order
+// without overlapping, and only the last may be open-ended.
+func (r *TaxRule) validate() error {
+	if r.Rate < 0 {
+		return fmt.Errorf("rate cannot be negative")
+	}
+	for i, bracket := range r.Brackets {
+		if bracket.Rate < 0 {
+			return fmt.Errorf("bracket %d rate cannot be negative", i+1)
+		}
+		if bracket.From < 0 {
+			return fmt.Errorf("bracket %d cannot start below zero", i+1)
+		}
+		if bracket.To == 0 && i < len(r.Brackets)-1 {
+			return fmt.Errorf("only the last bracket can be open-ended")
+		}
+		if bracket.To != 0 && bracket.To <= bracket.From {
+			return fmt.Errorf("bracket %d must end after it starts", i+1)
+		}
+		if i > 0 && bracket.From < r.Brackets[i-1].To {
+			return fmt.Errorf("bracket %d overlaps the one before it", i+1)
+		}
+	}
+	return nil
+}
+
+// taxOn returns the rule's exact tax on an amount: a flat rate, or the sum of each
+// bracket's rate on the slice of the amount falling within it. Rates and bounds are
+// taken at their decimal value, so the tax rounds where it should.
+func (r *TaxRule) taxOn(amount *big.Rat) (*big.Rat, error) {
+	if len(r.Brackets) == 0 {
+		rate, err := decimalRat(r.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate: %w", err)
+		}
+		return rate.Mul(rate, amount), nil
+	}
+	tax := new(big.Rat)
+	for i, bracket := range r.Brackets {
+		from, err := decimalRat(bracket.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bracket %d: %w", i+1, err)
+		}
+		if amount.Cmp(from) <= 0 {
+			break
+		}
+		upper := amount
+		if bracket.To != 0 {
+			to, err := decimalRat(bracket.To)
+			if err != nil {
+				return nil, fmt.Errorf("invalid bracket %d: %w", i+1, err)
+			}
+			if to.Cmp(upper) < 0 {
+				upper = to
+			}
+		}
+		rate, err := decimalRat(bracket.Rate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bracket %d rate: %w", i+1, err)
+		}
+		slice := new(big.Rat).Sub(upper, from)
+		tax.Add(tax, slice.Mul(slice, rate))
+	}
+	return tax, nil
+}
+
+// ----------------------------------------------------------------------------
+// Jurisdiction Rounding
+// ----------------------------------------------------------------------------
+
+// TaxRoundingLevel says where a jurisdiction rounds tax to the currency's minor units
+type TaxRoundingLevel string
+
+const (
+	TaxRoundTotal   TaxRoundingLevel = "TOTAL"    // once, on the combined tax
+	TaxRoundPerRule TaxRoundingLevel = "PER_RULE" // each rule's tax, before compound rules use it
+)
+
+// TaxRounding is the rounding rule of a jurisdiction. Jurisdictions without one
+// round the total half up.
+type TaxRounding struct {
+	Jurisdiction TaxJurisdiction  `json:"jurisdiction"`
+	Policy       RoundingPolicy   `json:"policy"`
+	Level        TaxRoundingLevel `json:"level"`
+}
+
+// describePolicy names the rounding policy for a calculation breakdown
+func (r *TaxRounding) describePolicy() string {
+	return strings.ToLower(strings.ReplaceAll(string(r.Policy), "_", " "))
+}
+
+// describeLevel says what was rounded for a calculation breakdown
+func (r *TaxRounding) describeLevel() string {
+	if r.Level == TaxRoundPerRule {
+		return "per rule"
+	}
+	return "on the total"
+}
+
+// SetTaxRounding stores the rounding rule of a jurisdiction
+func (cs *ComplianceService) SetTaxRounding(rounding TaxRounding) error {
+	if rounding.Jurisdiction == "" {
+		return classify(ErrValidation, "tax rounding needs a jurisdiction")
+	}
+	switch rounding.Policy {
+	case "":
+		rounding.Policy = RoundHalfUp
+	case RoundHalfUp, RoundHalfEven, RoundTowardZero:
+	default:
+		return classify(ErrValidation, "unknown rounding policy %s", rounding.Policy)
+	}
+	switch rounding.Level {
+	case "":
+		rounding.Level = TaxRoundTotal
+	case TaxRoundTotal, TaxRoundPerRule:
+	default:
+		return classify(ErrValidation, "unknown tax rounding level %s", rounding.Level)
+	}
+	return cs.storage.SaveTaxRounding(&rounding)
+}
+
+// GetTaxRounding returns the rounding rule of a jurisdiction, or the default of
+// rounding the total half up
+func (cs *ComplianceService) GetTaxRounding(jurisdiction TaxJurisdiction) (*TaxRounding, error) {
+	rounding, err := cs.storage.GetTaxRounding(jurisdiction)
+	if errors.Is(err, ErrNotFound) {
+		return &TaxRounding{Jurisdiction: jurisdiction, Policy: RoundHalfUp, Level: TaxRoundTotal}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax rounding: %w", err)
+	}
+	return rounding, nil
+}
@@ -0,0 +1,160 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompoundAndTieredTax(t *testing.T) {
+	// Setup
+	dbFile := "test_tax_rates.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	compliance := engine.GetComplianceService()
+	lastYear := time.Now().AddDate(-1, 0, 0)
+
+	t.Run("Compound Tax On Tax", func(t *testing.T) {
+		// GST, then a provincial sales tax charged on the GST-inclusive price
+		require.NoError(t, compliance.CreateTaxRule(TaxRule{
+			Jurisdiction: CANADA_GST, TaxType: SALES_TAX, Name: "PST", Rate: 0.10,
+			Compound: true, Priority: 2, EffectiveFrom: lastYear,
+		}))
+		require.NoError(t, compliance.CreateTaxRule(TaxRule{
+			Jurisdiction: CANADA_GST, TaxType: SALES_TAX, Name: "GST", Rate: 0.05,
+			Priority: 1, EffectiveFrom: lastYear,
+		}))
+
+		calc, err := compliance.CalculateTaxOnAmount(&Amount{Value: 10000, Currency: "CAD"}, CANADA_GST, SALES_TAX, nil)
+		require.NoError(t, err)
+		require.Len(t, calc.Components, 2)
+		assert.Equal(t, "GST", calc.Components[0].Name)
+		assert.InDelta(t, 5.0, calc.Components[0].TaxAmount, 1e-9)
+		assert.True(t, calc.Components[1].Compound)
+		assert.InDelta(t, 105.0, calc.Components[1].TaxableAmount, 1e-9)
+		assert.InDelta(t, 10.5, calc.Components[1].TaxAmount, 1e-9)
+		assert.InDelta(t, 15.5, calc.TaxAmount, 1e-9)
+		assert.InDelta(t, 0.15, calc.TaxRate, 1e-9)
+
+		assert.Equal(t, "GST", calc.Breakdown.Steps[1].Name)
+		assert.Equal(t, "PST", calc.Breakdown.Steps[2].Name)
+		assert.Contains(t, calc.Breakdown.Steps[2].Description, "compound")
+	})
+
+	t.Run("Jurisdiction Rounding", func(t *testing.T) {
+		amount := &Amount{Value: 107, Currency: "CAD"}
+
+		// Rounding the total once: 0.0535 + 0.11235 = 0.16585
+		calc, err := compliance.CalculateTaxOnAmount(amount, CANADA_GST, SALES_TAX, nil)
+		require.NoError(t, err)
+		assert.InDelta(t, 0.17, calc.TaxAmount, 1e-9)
+
+		// Rounding each tax: GST 0.05, then PST on 1.12 is 0.112
+		require.NoError(t, compliance.SetTaxRounding(TaxRounding{Jurisdiction: CANADA_GST, Level: TaxRoundPerRule}))
+		rounding, err := compliance.GetTaxRounding(CANADA_GST)
+		require.NoError(t, err)
+		assert.Equal(t, RoundHalfUp, rounding.Policy)
+
+		calc, err = compliance.CalculateTaxOnAmount(amount, CANADA_GST, SALES_TAX, nil)
+		require.NoError(t, err)
+		assert.InDelta(t, 0.05, calc.Components[0].TaxAmount, 1e-9)
+		assert.InDelta(t, 0.11, calc.Components[1].TaxAmount, 1e-9)
+		assert.InDelta(t, 0.16, calc.TaxAmount, 1e-9)
+		last := calc.Breakdown.Steps[len(calc.Breakdown.Steps)-1]
+		assert.Contains(t, last.Description, "per rule")
+
+		err = compliance.SetTaxRounding(TaxRounding{Jurisdiction: CANADA_GST, Level: "SOMETIMES"})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("Tiered Rates", func(t *testing.T) {
+		require.NoError(t, compliance.CreateTaxRule(TaxRule{
+			Jurisdiction: US_FEDERAL, TaxType: INCOME_TAX, Name: "Graduated", EffectiveFrom: lastYear,
+			Brackets: []TaxBracket{
+				{From: 0, To: 1000, Rate: 0.10},
+				{From: 1000, To: 5000, Rate: 0.20},
+				{From: 5000, Rate: 0.30},
+			},
+		}))
+
+		calc, err := compliance.CalculateTax(7000, US_FEDERAL, INCOME_TAX, nil)
+		require.NoError(t, err)
+		assert.InDelta(t, 100+800+600, calc.TaxAmount, 1e-9)
+		assert.InDelta(t, 1500.0/7000, calc.TaxRate, 1e-9)
+
+		calc, err = compliance.CalculateTax(400, US_FEDERAL, INCOME_TAX, nil)
+		require.NoError(t, err)
+		assert.InDelta(t, 40, calc.TaxAmount, 1e-9)
+
+		err = compliance.CreateTaxRule(TaxRule{
+			Jurisdiction: US_FEDERAL, TaxType: INCOME_TAX, Name: "Overlapping",
+			Brackets: []TaxBracket{{From: 0, To: 1000, Rate: 0.1}, {From: 500, Rate: 0.2}},
+		})
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("Simultaneous Rules And Exemptions", func(t *testing.T) {
+		require.NoError(t, compliance.CreateTaxRule(TaxRule{
+			Jurisdiction: US_STATE, TaxType: SALES_TAX, Name: "State", Rate: 0.06, EffectiveFrom: lastYear,
+		}))
+		require.NoError(t, compliance.CreateTaxRule(TaxRule{
+			Jurisdiction: US_STATE, TaxType: SALES_TAX, Name: "County", Rate: 0.015, Priority: 1, EffectiveFrom: lastYear,
+			Exemptions: []string{"groceries"},
+		}))
+
+		calc, err := compliance.CalculateTax(200, US_STATE, SALES_TAX, nil)
+		require.NoError(t, err)
+		assert.Len(t, calc.Components, 2)
+		assert.InDelta(t, 15, calc.TaxAmount, 1e-9)
+		assert.InDelta(t, 0.075, calc.TaxRate, 1e-9)
+
+		// Only the county exempts groceries
+		calc, err = compliance.CalculateTax(200, US_STATE, SALES_TAX, []string{"GROCERIES"})
+		require.NoError(t, err)
+		assert.InDelta(t, 12, calc.TaxAmount, 1e-9)
+		assert.InDelta(t, 200, calc.TaxableAmount, 1e-9)
+		assert.Equal(t, []string{"GROCERIES"}, calc.Exemptions)
+		assert.Equal(t, []string{"GROCERIES"}, calc.Components[1].Exemptions)
+	})
+
+	t.Run("Half Points", func(t *testing.T) {
+		require.NoError(t, compliance.CreateTaxRule(TaxRule{
+			Jurisdiction: UK_VAT, TaxType: VAT, Name: "Flat", Rate: 0.10, EffectiveFrom: lastYear,
+		}))
+		require.NoError(t, compliance.CreateTaxRule(TaxRule{
+			Jurisdiction: AUSTRALIA, TaxType: SALES_TAX, Name: "Tiered", EffectiveFrom: lastYear,
+			Brackets: []TaxBracket{{From: 0, To: 1, Rate: 0.10}, {From: 1, Rate: 0.35}},
+		}))
+
+		// 10% of 1.05 and 1.15 is 0.105 and 0.115; 0.10 + 35% of 0.10 is 0.135
+		cases := []struct {
+			jurisdiction TaxJurisdiction
+			taxType      TaxType
+			value        int64
+			halfUp       float64
+			halfEven     float64
+			towardZero   float64
+		}{
+			{UK_VAT, VAT, 105, 0.11, 0.10, 0.10},
+			{UK_VAT, VAT, 115, 0.12, 0.12, 0.11},
+			{UK_VAT, VAT, 125, 0.13, 0.12, 0.12},
+			{AUSTRALIA, SALES_TAX, 110, 0.14, 0.14, 0.13},
+		}
+		policies := []RoundingPolicy{RoundHalfUp, RoundHalfEven, RoundTowardZero}
+		for _, c := range cases {
+			for i, policy := range policies {
+				require.NoError(t, compliance.SetTaxRounding(TaxRounding{Jurisdiction: c.jurisdiction, Policy: policy, Level: TaxRoundTotal}))
+				calc, err := compliance.CalculateTaxOnAmount(&Amount{Value: c.value, Currency: "USD"}, c.jurisdiction, c.taxType, nil)
+				require.NoError(t, err)
+				assert.Equal(t, []float64{c.halfUp, c.halfEven, c.towardZero}[i], calc.TaxAmount, "%d under %s", c.value, policy)
+			}
+		}
+	})
+}
@@ -0,0 +1,87 @@
+package accounting
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// ----------------------------------------------------------------------------
+// Tracing
+// ----------------------------------------------------------------------------
+
+// TracerName is the instrumentation scope the engine's spans are recorded under
+const TracerName = "accounting"
+
+// Attribute keys set on the engine's spans
+const (
+	TraceKeyCompany          = attribute.Key("accounting.company")
+	TraceKeyTransactionID    = attribute.Key("accounting.transaction_id")
+	TraceKeyUserID           = attribute.Key("accounting.user_id")
+	TraceKeyEntryCount       = attribute.Key("accounting.entry_count")
+	TraceKeyAccountCount     = attribute.Key("accounting.account_count")
+	TraceKeyHeld             = attribute.Key("accounting.held_for_approval")
+	TraceKeyRuleCount        = attribute.Key("accounting.aml.rule_count")
+	TraceKeyAlertCount       = attribute.Key("accounting.aml.alert_count")
+	TraceKeyReport           = attribute.Key("accounting.report")
+	TraceKeyStorageOperation = attribute.Key("accounting.storage.operation")
+)
+
+// engineTracer starts the engine's spans, tagging each with the company the books
+// are kept for
+type engineTracer struct {
+	tracer  trace.Tracer
+	company string
+}
+
+// noopTracer records nothing; services use it until given a tracer
+var noopTracer = newEngineTracer(nil, "")
+
+// newEngineTracer takes the engine's tracer from a host's provider, or records
+// nothing when the host gave none
+func newEngineTracer(provider trace.TracerProvider, company string) *engineTracer {
+	if provider == nil {
+		provider = noop.NewTracerProvider()
+	}
+	return &engineTracer{tracer: provider.Tracer(TracerName), company: company}
+}
+
+// start starts a span as a child of any span in ctx
+func (t *engineTracer) start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if t.company != "" {
+		attrs = append(attrs, TraceKeyCompany.String(t.company))
+	}
+	return t.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// report starts a span for generating a report. Defer the result with the address
+// of the report's error result so failures are recorded too.
+func (t *engineTracer) report(report string) func(err *error) {
+	_, span := t.start(context.Background(), "report."+report, TraceKeyReport.String(report))
+	return func(err *error) { endSpan(span, *err) }
+}
+
+// endSpan ends a span, marking it failed when err is set
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// transactionAttributes describes a transaction on a span
+func transactionAttributes(txn *Transaction) []attribute.KeyValue {
+	accounts := make(map[string]bool, len(txn.Entries))
+	for _, entry := range txn.Entries {
+		accounts[entry.AccountID] = true
+	}
+	return []attribute.KeyValue{
+		TraceKeyTransactionID.String(txn.ID),
+		TraceKeyEntryCount.Int(len(txn.Entries)),
+		TraceKeyAccountCount.Int(len(accounts)),
+	}
+}
@@ -0,0 +1,124 @@
+package accounting
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// findSpan returns the first ended span with a name, or nil
+func findSpan(spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	for _, span := range spans {
+		if span.Name() == name {
+			return span
+		}
+	}
+	return nil
+}
+
+// spanAttributes indexes a span's attributes by key
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracing(t *testing.T) {
+	dbFile := "test_tracing.db"
+	defer os.Remove(dbFile)
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	engine, err := NewAccountingEngineWithOptions(dbFile, StorageOptions{TracerProvider: provider, Company: "acme"})
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "teller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	aml := engine.GetAMLService()
+	require.NoError(t, aml.SetupStandardAMLRules(BSA_Framework))
+
+	ctx, parent := provider.Tracer("host").Start(context.Background(), "http.request")
+	deposit := &Transaction{
+		Description: "Cash deposit",
+		ValidTime:   time.Date(2025, 5, 6, 10, 0, 0, 0, time.UTC),
+		Entries: []Entry{
+			{AccountID: "cash", Type: Debit, Amount: Amount{Value: 1500000, Currency: "USD"}},
+			{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 1000000, Currency: "USD"}},
+			{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 500000, Currency: "USD"}},
+		},
+	}
+	require.NoError(t, engine.CreateTransactionContext(ctx, deposit, userID))
+	require.NoError(t, engine.PostTransactionContext(ctx, deposit.ID, userID))
+	alerts, err := aml.MonitorTransactionContext(ctx, deposit, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, alerts)
+	assert.Error(t, engine.PostTransaction("missing", userID))
+	_, err = engine.GenerateTrialBalance(deposit.ValidTime, "USD")
+	require.NoError(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+
+	created := findSpan(spans, "engine.CreateTransaction")
+	require.NotNil(t, created)
+	assert.Equal(t, parent.SpanContext().TraceID(), created.SpanContext().TraceID())
+	assert.Equal(t, parent.SpanContext().SpanID(), created.Parent().SpanID())
+	attrs := spanAttributes(created)
+	assert.Equal(t, "acme", attrs[TraceKeyCompany].AsString())
+	assert.Equal(t, deposit.ID, attrs[TraceKeyTransactionID].AsString())
+	assert.Equal(t, userID, attrs[TraceKeyUserID].AsString())
+	assert.Equal(t, int64(3), attrs[TraceKeyEntryCount].AsInt64())
+	assert.Equal(t, int64(2), attrs[TraceKeyAccountCount].AsInt64())
+
+	posted := findSpan(spans, "engine.PostTransaction")
+	require.NotNil(t, posted)
+	assert.Equal(t, parent.SpanContext().SpanID(), posted.Parent().SpanID())
+	assert.Equal(t, int64(2), spanAttributes(posted)[TraceKeyAccountCount].AsInt64())
+	assert.False(t, spanAttributes(posted)[TraceKeyHeld].AsBool())
+	assert.Equal(t, codes.Unset, posted.Status().Code)
+
+	monitored := findSpan(spans, "aml.MonitorTransaction")
+	require.NotNil(t, monitored)
+	assert.Equal(t, parent.SpanContext().SpanID(), monitored.Parent().SpanID())
+	attrs = spanAttributes(monitored)
+	assert.Positive(t, attrs[TraceKeyRuleCount].AsInt64())
+	assert.Equal(t, int64(len(alerts)), attrs[TraceKeyAlertCount].AsInt64())
+
+	var failed sdktrace.ReadOnlySpan
+	for _, span := range spans {
+		if span.Name() == "engine.PostTransaction" && spanAttributes(span)[TraceKeyTransactionID].AsString() == "missing" {
+			failed = span
+		}
+	}
+	require.NotNil(t, failed)
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	assert.Contains(t, failed.Status().Description, "failed to get transaction")
+	assert.False(t, failed.Parent().IsValid(), "calls without a context start their own trace")
+
+	report := findSpan(spans, "report.trial_balance")
+	require.NotNil(t, report)
+	assert.Equal(t, "trial_balance", spanAttributes(report)[TraceKeyReport].AsString())
+
+	write := findSpan(spans, "storage.write")
+	require.NotNil(t, write)
+	assert.Equal(t, "write", spanAttributes(write)[TraceKeyStorageOperation].AsString())
+	assert.Equal(t, "acme", spanAttributes(write)[TraceKeyCompany].AsString())
+	assert.NotNil(t, findSpan(spans, "storage.read"))
+
+	t.Run("no provider records nothing", func(t *testing.T) {
+		_, span := noopTracer.start(context.Background(), "engine.CreateTransaction")
+		assert.False(t, span.IsRecording())
+		span.End()
+	})
+}
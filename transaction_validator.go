@@ -0,0 +1,102 @@
+package accounting
+
+import "fmt"
+
+// Codes of the violations reported by transaction validation
+const (
+	ViolationUnbalanced       = "UNBALANCED_TRANSACTION"
+	ViolationInvalidAccount   = "INVALID_ACCOUNT"
+	ViolationPeriodClosed     = "PERIOD_CLOSED"
+	ViolationPostingDate      = "POSTING_DATE_OUT_OF_WINDOW"
+	ViolationZeroAmount       = "ZERO_AMOUNT"
+	ViolationCurrencyMismatch = "CURRENCY_MISMATCH"
+	ViolationInvalidDimension = "INVALID_DIMENSION"
+)
+
+// Validator checks a transaction before it is stored, collecting every rule it
+// breaks instead of stopping at the first
+type Validator struct {
+	storage       *Storage
+	postingEngine *PostingEngine
+	dimensions    *DimensionService
+}
+
+// NewValidator creates a new transaction validator
+func NewValidator(storage *Storage, postingEngine *PostingEngine, dimensions *DimensionService) *Validator {
+	return &Validator{
+		storage:       storage,
+		postingEngine: postingEngine,
+		dimensions:    dimensions,
+	}
+}
+
+// Validate checks a transaction created by userID. Entries must have non-zero
+// amounts and balance in each currency, post to existing open accounts in the
+// currency of the account's ledger and carry valid dimensions; the valid time must
+// fall in a period open to userID and within the posting date window. The error is
+// only for failures to run the checks.
+func (v *Validator) Validate(txn *Transaction, userID string) (*ValidationResult, error) {
+	result := &ValidationResult{Valid: true}
+	report := func(code string, err error) {
+		result.Valid = false
+		result.Errors = append(result.Errors, PostingError{Code: code, Message: err.Error()})
+	}
+
+	ledgers, err := v.ledgerCurrencies()
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range txn.Entries {
+		if entry.Amount.Value == 0 {
+			report(ViolationZeroAmount, fmt.Errorf("entry %d: amount is zero", i+1))
+		}
+
+		account, err := v.storage.GetAccount(entry.AccountID)
+		if err != nil {
+			report(ViolationInvalidAccount, fmt.Errorf("entry %d: account %s does not exist", i+1, entry.AccountID))
+			continue
+		}
+		if account.ClosedAt != nil {
+			report(ViolationInvalidAccount, fmt.Errorf("entry %d: account %s is inactive", i+1, entry.AccountID))
+		}
+		// A foreign-currency entry is consistent when projected into the ledger's currency
+		if currency := ledgers[account.LedgerID]; currency != "" &&
+			entry.Amount.Currency != currency && entry.Amount.BaseCurrency != currency {
+			report(ViolationCurrencyMismatch, fmt.Errorf("entry %d: %s amount posted to account %s in %s ledger %s",
+				i+1, entry.Amount.Currency, entry.AccountID, currency, account.LedgerID))
+		}
+	}
+
+	for _, err := range balanceErrors(txn) {
+		report(ViolationUnbalanced, err)
+	}
+
+	dimensionErrors, err := v.dimensions.entryErrors(txn)
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range dimensionErrors {
+		report(ViolationInvalidDimension, err)
+	}
+
+	if err := v.postingEngine.validatePeriod(txn.ValidTime, userID); err != nil {
+		report(ViolationPeriodClosed, err)
+	}
+	if err := v.postingEngine.validatePostingDate(txn.ValidTime, userID); err != nil {
+		report(ViolationPostingDate, err)
+	}
+	return result, nil
+}
+
+// ledgerCurrencies maps the ledgers kept in a single currency to it
+func (v *Validator) ledgerCurrencies() (map[string]Currency, error) {
+	ledgers, err := v.storage.GetAllLedgers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledgers: %w", err)
+	}
+	currencies := make(map[string]Currency, len(ledgers))
+	for _, ledger := range ledgers {
+		currencies[ledger.ID] = ledger.Currency
+	}
+	return currencies, nil
+}
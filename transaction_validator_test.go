@@ -0,0 +1,119 @@
+package accounting
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionValidator(t *testing.T) {
+	// Setup
+	dbFile := "test_transaction_validator.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "bookkeeper"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+
+	receivables := &Ledger{Name: "Receivables", Type: AccountsReceivable, Currency: "EUR"}
+	require.NoError(t, engine.CreateLedger(receivables, userID))
+	_, err = engine.AssignAccountLedger("accounts_receivable", receivables.ID, userID)
+	require.NoError(t, err)
+	require.NoError(t, engine.DefineDimension(&DimensionDefinition{Key: DimProject, Name: "Project", Open: true}, userID))
+
+	closedAt := time.Now()
+	require.NoError(t, engine.CreatePeriod(&Period{
+		Name:         "2024",
+		Start:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:          time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+		HardClosedAt: &closedAt,
+	}, userID))
+
+	count := func() int {
+		txns, err := engine.GetStorage().GetAllTransactions()
+		require.NoError(t, err)
+		return len(txns)
+	}
+	codes := func(err error) []string {
+		var invalid *ValidationError
+		require.True(t, errors.As(err, &invalid), "got %v", err)
+		var codes []string
+		for _, failure := range invalid.Errors {
+			codes = append(codes, failure.Code)
+		}
+		return codes
+	}
+
+	t.Run("Reports Every Violation", func(t *testing.T) {
+		before := count()
+		txn := &Transaction{
+			Description: "Everything wrong",
+			ValidTime:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: 0, Currency: "USD"}},
+				{AccountID: "no-such-account", Type: Credit, Amount: Amount{Value: 500, Currency: "USD"}},
+				{AccountID: "accounts_receivable", Type: Debit, Amount: Amount{Value: 500, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 300, Currency: "EUR"},
+					Dimensions: []Dimension{{Key: "colour", Value: "red"}}},
+			},
+		}
+		err := engine.CreateTransaction(txn, userID)
+		require.ErrorIs(t, err, ErrValidation)
+		assert.ErrorIs(t, err, ErrUnbalanced)
+		assert.ErrorIs(t, err, ErrPeriodClosed)
+		assert.Equal(t, []string{
+			ViolationZeroAmount,
+			ViolationInvalidAccount,
+			ViolationCurrencyMismatch,
+			ViolationUnbalanced,
+			ViolationInvalidDimension,
+			ViolationPeriodClosed,
+		}, codes(err))
+		assert.ErrorContains(t, err, "entry 2: account no-such-account does not exist")
+		assert.ErrorContains(t, err, "does not balance in EUR: debits=0, credits=300")
+		assert.Equal(t, before, count(), "nothing is stored")
+	})
+
+	t.Run("Balances Per Currency", func(t *testing.T) {
+		txn := &Transaction{
+			Description: "Mixed currencies",
+			ValidTime:   time.Now(),
+			Entries: []Entry{
+				{AccountID: "cash", Type: Debit, Amount: Amount{Value: 100, Currency: "USD"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 100, Currency: "EUR"}},
+			},
+		}
+		err := engine.CreateTransaction(txn, userID)
+		assert.Equal(t, []string{ViolationUnbalanced, ViolationUnbalanced}, codes(err))
+
+		// Projected into a base currency, the transaction balances there
+		txn.Entries[1].Amount.BaseCurrency = "USD"
+		txn.Entries[1].Amount.BaseValue = 100
+		assert.NoError(t, engine.CreateTransaction(txn, userID))
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		txn := &Transaction{
+			Description: "Invoice",
+			ValidTime:   time.Now(),
+			Entries: []Entry{
+				{AccountID: "accounts_receivable", Type: Debit, Amount: Amount{Value: 800, Currency: "EUR"}},
+				{AccountID: "revenue", Type: Credit, Amount: Amount{Value: 800, Currency: "EUR"}},
+			},
+		}
+		before := count()
+		result, err := engine.ValidateTransaction(txn, userID)
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.NoError(t, result.Err())
+		assert.Empty(t, txn.ID)
+		assert.Equal(t, before, count())
+	})
+}
@@ -0,0 +1,578 @@
+package accounting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// VAT Return Layouts
+// ----------------------------------------------------------------------------
+
+// VATReturnFormat is the box layout of a VAT return
+type VATReturnFormat string
+
+const (
+	VATReturnUK100 VATReturnFormat = "UK_VAT100" // HMRC VAT100, boxes 1 to 9
+	VATReturnEU    VATReturnFormat = "EU"        // the common layout of EU member state returns
+)
+
+// VATSide says whether a box collects tax charged on supplies or tax paid on purchases
+type VATSide string
+
+const (
+	VATOutput VATSide = "OUTPUT"
+	VATInput  VATSide = "INPUT"
+)
+
+// VATBoxBasis says whether a box reports tax or the value it was charged on
+type VATBoxBasis string
+
+const (
+	VATBoxTax VATBoxBasis = "TAX"
+	VATBoxNet VATBoxBasis = "NET" // value excluding VAT
+)
+
+// VATBoxDefinition is one box of a return layout. Derived boxes total other boxes
+// and take no mappings.
+type VATBoxDefinition struct {
+	Box         string      `json:"box"`
+	Description string      `json:"description"`
+	Side        VATSide     `json:"side,omitempty"`
+	Basis       VATBoxBasis `json:"basis,omitempty"`
+	Derived     []string    `json:"derived,omitempty"`     // boxes summed; prefix with "-" to subtract
+	WholeUnits  bool        `json:"whole_units,omitempty"` // reported without minor units, rounded down
+}
+
+// VATReturnLayout is the set of boxes of a return format
+type VATReturnLayout struct {
+	Format       VATReturnFormat    `json:"format"`
+	Jurisdiction TaxJurisdiction    `json:"jurisdiction"`
+	Boxes        []VATBoxDefinition `json:"boxes"`
+	NetBox       string             `json:"net_box"`    // the box holding the amount to pay or reclaim
+	DueMonths    int                `json:"due_months"` // filing deadline after the period end
+	DueDays      int                `json:"due_days"`
+}
+
+// box returns the definition of a box in the layout
+func (l *VATReturnLayout) box(code string) (*VATBoxDefinition, bool) {
+	for i := range l.Boxes {
+		if l.Boxes[i].Box == code {
+			return &l.Boxes[i], true
+		}
+	}
+	return nil, false
+}
+
+var vatReturnLayouts = map[VATReturnFormat]*VATReturnLayout{
+	VATReturnUK100: {
+		Format:       VATReturnUK100,
+		Jurisdiction: UK_VAT,
+		Boxes: []VATBoxDefinition{
+			{Box: "1", Description: "VAT due on sales and other outputs", Side: VATOutput, Basis: VATBoxTax},
+			{Box: "2", Description: "VAT due on acquisitions of goods made in Northern Ireland from EU Member States", Side: VATOutput, Basis: VATBoxTax},
+			{Box: "3", Description: "Total VAT due", Derived: []string{"1", "2"}},
+			{Box: "4", Description: "VAT reclaimed on purchases and other inputs", Side: VATInput, Basis: VATBoxTax},
+			{Box: "5", Description: "Net VAT to pay to HMRC or reclaim", Derived: []string{"3", "-4"}},
+			{Box: "6", Description: "Total value of sales and all other outputs excluding any VAT", Side: VATOutput, Basis: VATBoxNet, WholeUnits: true},
+			{Box: "7", Description: "Total value of purchases and all other inputs excluding any VAT", Side: VATInput, Basis: VATBoxNet, WholeUnits: true},
+			{Box: "8", Description: "Total value of dispatches of goods and related costs excluding any VAT, from Northern Ireland to EU Member States", Side: VATOutput, Basis: VATBoxNet, WholeUnits: true},
+			{Box: "9", Description: "Total value of acquisitions of goods and related costs excluding any VAT, made in Northern Ireland from EU Member States", Side: VATInput, Basis: VATBoxNet, WholeUnits: true},
+		},
+		NetBox:    "5",
+		DueMonths: 1,
+		DueDays:   7,
+	},
+	VATReturnEU: {
+		Format:       VATReturnEU,
+		Jurisdiction: EU_VAT,
+		Boxes: []VATBoxDefinition{
+			{Box: "OUTPUT_VAT", Description: "Output VAT on domestic supplies", Side: VATOutput, Basis: VATBoxTax},
+			{Box: "REVERSE_CHARGE_VAT", Description: "VAT due under the reverse charge", Side: VATOutput, Basis: VATBoxTax},
+			{Box: "TOTAL_OUTPUT_VAT", Description: "Total output VAT", Derived: []string{"OUTPUT_VAT", "REVERSE_CHARGE_VAT"}},
+			{Box: "INPUT_VAT", Description: "Deductible input VAT", Side: VATInput, Basis: VATBoxTax},
+			{Box: "NET_VAT", Description: "VAT payable or refundable", Derived: []string{"TOTAL_OUTPUT_VAT", "-INPUT_VAT"}},
+			{Box: "TAXABLE_SUPPLIES", Description: "Taxable supplies excluding VAT", Side: VATOutput, Basis: VATBoxNet},
+			{Box: "INTRA_EU_SUPPLIES", Description: "Intra-community supplies of goods", Side: VATOutput, Basis: VATBoxNet},
+			{Box: "TAXABLE_PURCHASES", Description: "Purchases excluding VAT", Side: VATInput, Basis: VATBoxNet},
+			{Box: "INTRA_EU_ACQUISITIONS", Description: "Intra-community acquisitions of goods", Side: VATInput, Basis: VATBoxNet},
+		},
+		NetBox:    "NET_VAT",
+		DueMonths: 1,
+	},
+}
+
+// GetVATReturnLayout returns the boxes of a VAT return format
+func GetVATReturnLayout(format VATReturnFormat) (*VATReturnLayout, error) {
+	layout, ok := vatReturnLayouts[format]
+	if !ok {
+		return nil, classify(ErrValidation, "unknown VAT return format %s", format)
+	}
+	return layout, nil
+}
+
+// ----------------------------------------------------------------------------
+// VAT Return Structures
+// ----------------------------------------------------------------------------
+
+// VATBoxMapping fills a box from the posted movement on an account, or from the tax
+// lines generated by a tax line rule, which serves as the tax code. A rule mapped to
+// a tax box contributes its tax; mapped to a net box, the value the tax was charged on.
+type VATBoxMapping struct {
+	Box           string `json:"box"`
+	AccountID     string `json:"account_id,omitempty"`
+	TaxLineRuleID string `json:"tax_line_rule_id,omitempty"`
+	Negate        bool   `json:"negate,omitempty"`
+}
+
+// VATReturnMapping maps accounts and tax codes to the boxes of a VAT return format
+type VATReturnMapping struct {
+	ID           string          `json:"id"` // e.g. "UK-MAIN"
+	Name         string          `json:"name"`
+	Format       VATReturnFormat `json:"format"`
+	Jurisdiction TaxJurisdiction `json:"jurisdiction"`
+	Currency     Currency        `json:"currency"`
+	Boxes        []VATBoxMapping `json:"boxes"`
+	CreatedBy    string          `json:"created_by"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// VATBoxValue is a box's value on a prepared return, in minor units
+type VATBoxValue struct {
+	Box         string `json:"box"`
+	Description string `json:"description"`
+	Amount      int64  `json:"amount"`
+}
+
+// VATReturnRequest asks for a VAT return to be prepared for a period
+type VATReturnRequest struct {
+	MappingID   string    `json:"mapping_id"`
+	CompanyID   string    `json:"company_id,omitempty"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	PeriodKey   string    `json:"period_key,omitempty"` // e.g. the HMRC period key "25A1"
+}
+
+// VAT return positions on a filing document
+const (
+	VATPayable     = "PAYABLE"
+	VATReclaimable = "RECLAIMABLE"
+	VATNil         = "NIL"
+)
+
+// VATFilingBox is a box on a filing document, with the amount in major units
+type VATFilingBox struct {
+	Box         string      `json:"box"`
+	Description string      `json:"description"`
+	Amount      json.Number `json:"amount"`
+}
+
+// VATFilingDocument is a prepared VAT return in the form submitted to the tax authority
+type VATFilingDocument struct {
+	ReturnID     string          `json:"return_id"`
+	Format       VATReturnFormat `json:"format"`
+	Jurisdiction TaxJurisdiction `json:"jurisdiction"`
+	CompanyID    string          `json:"company_id,omitempty"`
+	PeriodKey    string          `json:"period_key,omitempty"`
+	PeriodStart  string          `json:"period_start"`
+	PeriodEnd    string          `json:"period_end"`
+	DueDate      string          `json:"due_date"`
+	Currency     Currency        `json:"currency"`
+	Boxes        []VATFilingBox  `json:"boxes"`
+	OutputVAT    json.Number     `json:"output_vat"`
+	InputVAT     json.Number     `json:"input_vat"`
+	NetVAT       json.Number     `json:"net_vat"`
+	Position     string          `json:"position"`
+}
+
+// ----------------------------------------------------------------------------
+// VAT Return Service
+// ----------------------------------------------------------------------------
+
+// VATReturnService maps accounts and tax codes to VAT return boxes and prepares the
+// returns from posted entries
+type VATReturnService struct {
+	storage    *Storage
+	eventStore *EventStore
+}
+
+// NewVATReturnService creates a new VAT return service
+func NewVATReturnService(storage *Storage, eventStore *EventStore) *VATReturnService {
+	return &VATReturnService{
+		storage:    storage,
+		eventStore: eventStore,
+	}
+}
+
+// SaveMapping validates and saves a VAT return mapping. The jurisdiction defaults to
+// the format's; UK returns default to GBP.
+func (vs *VATReturnService) SaveMapping(mapping *VATReturnMapping, userID string) error {
+	if mapping.ID == "" {
+		return classify(ErrValidation, "VAT return mapping ID is required")
+	}
+	layout, err := GetVATReturnLayout(mapping.Format)
+	if err != nil {
+		return err
+	}
+	if mapping.Jurisdiction == "" {
+		mapping.Jurisdiction = layout.Jurisdiction
+	}
+	if mapping.Currency == "" && mapping.Format == VATReturnUK100 {
+		mapping.Currency = "GBP"
+	}
+	if mapping.Currency == "" {
+		return classify(ErrValidation, "VAT return mapping %s needs a currency", mapping.ID)
+	}
+	if len(mapping.Boxes) == 0 {
+		return classify(ErrValidation, "VAT return mapping %s maps no boxes", mapping.ID)
+	}
+
+	for _, boxMapping := range mapping.Boxes {
+		box, ok := layout.box(boxMapping.Box)
+		if !ok {
+			return classify(ErrValidation, "%s has no box %s", mapping.Format, boxMapping.Box)
+		}
+		if len(box.Derived) > 0 {
+			return classify(ErrValidation, "box %s is calculated from other boxes and cannot be mapped", box.Box)
+		}
+		if (boxMapping.AccountID == "") == (boxMapping.TaxLineRuleID == "") {
+			return classify(ErrValidation, "mappings for box %s need an account or a tax line rule", box.Box)
+		}
+		if boxMapping.AccountID != "" {
+			if _, err := vs.storage.GetAccount(boxMapping.AccountID); err != nil {
+				return fmt.Errorf("invalid mapping for box %s: %w", box.Box, err)
+			}
+		}
+		if boxMapping.TaxLineRuleID != "" {
+			if _, err := vs.storage.GetTaxLineRule(boxMapping.TaxLineRuleID); err != nil {
+				return fmt.Errorf("invalid mapping for box %s: %w", box.Box, err)
+			}
+		}
+	}
+
+	now := time.Now()
+	if existing, err := vs.storage.GetVATReturnMapping(mapping.ID); err == nil {
+		mapping.CreatedBy = existing.CreatedBy
+		mapping.CreatedAt = existing.CreatedAt
+	} else {
+		mapping.CreatedBy = userID
+		mapping.CreatedAt = now
+	}
+	mapping.UpdatedAt = now
+
+	_, err = vs.eventStore.CreateEvent(EventSaveVATReturnMapping, mapping, now, userID)
+	if err != nil {
+		return fmt.Errorf("failed to create VAT return mapping event: %w", err)
+	}
+	if err := vs.storage.SaveVATReturnMapping(mapping); err != nil {
+		return fmt.Errorf("failed to save VAT return mapping: %w", err)
+	}
+	return nil
+}
+
+// PrepareReturn aggregates the posted output and input VAT of a period into the boxes
+// of a draft tax return. Account mappings take the movement on the account, credits
+// counting towards output boxes and debits towards input boxes; tax code mappings take
+// the tax lines of posted transactions, less those of their reversals. TotalTax is the
+// output VAT, TaxPaid the input VAT and TaxOwed the difference, negative when VAT is
+// reclaimable.
+func (vs *VATReturnService) PrepareReturn(req VATReturnRequest, userID string) (*TaxReturn, error) {
+	mapping, err := vs.storage.GetVATReturnMapping(req.MappingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get VAT return mapping: %w", err)
+	}
+	layout, err := GetVATReturnLayout(mapping.Format)
+	if err != nil {
+		return nil, err
+	}
+	if req.PeriodStart.IsZero() || !req.PeriodEnd.After(req.PeriodStart) {
+		return nil, classify(ErrValidation, "VAT return period must end after it starts")
+	}
+
+	transactions, err := vs.storage.GetTransactionsByDateRange(req.CompanyID, req.PeriodStart, req.PeriodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	amounts := make(map[string]int64)
+	for _, txn := range transactions {
+		if txn.Status != Posted && txn.Status != Reversed {
+			continue
+		}
+		if err := vs.addAccountMovements(amounts, layout, mapping, txn); err != nil {
+			return nil, err
+		}
+		if err := vs.addTaxLines(amounts, layout, mapping, txn); err != nil {
+			return nil, err
+		}
+	}
+
+	taxReturn := &TaxReturn{
+		ID:           newID(),
+		CompanyID:    req.CompanyID,
+		Jurisdiction: mapping.Jurisdiction,
+		TaxType:      VAT,
+		PeriodStart:  req.PeriodStart,
+		PeriodEnd:    req.PeriodEnd,
+		FilingStatus: "DRAFT",
+		DueDate:      vatDueDate(req.PeriodEnd, layout),
+		Attachments:  []string{},
+		VATFormat:    mapping.Format,
+		VATMappingID: mapping.ID,
+		Currency:     mapping.Currency,
+		PeriodKey:    req.PeriodKey,
+	}
+	var output, input, sales int64
+	for _, box := range layout.Boxes {
+		amount := amounts[box.Box]
+		if len(box.Derived) > 0 {
+			amount = sumRegulatoryLines(amounts, box.Derived)
+			amounts[box.Box] = amount
+		}
+		if box.WholeUnits {
+			amount -= amount % int64(math.Pow10(MinorUnits(mapping.Currency)))
+		}
+		switch {
+		case box.Side == VATOutput && box.Basis == VATBoxTax:
+			output += amount
+		case box.Side == VATInput && box.Basis == VATBoxTax:
+			input += amount
+		case box.Side == VATOutput && box.Basis == VATBoxNet:
+			sales += amount
+		}
+		taxReturn.VATBoxes = append(taxReturn.VATBoxes, VATBoxValue{Box: box.Box, Description: box.Description, Amount: amount})
+	}
+	taxReturn.GrossRevenue = ToMajorUnits(sales, mapping.Currency)
+	taxReturn.TaxableRevenue = taxReturn.GrossRevenue
+	taxReturn.TotalTax = ToMajorUnits(output, mapping.Currency)
+	taxReturn.TaxPaid = ToMajorUnits(input, mapping.Currency)
+	taxReturn.TaxOwed = ToMajorUnits(output-input, mapping.Currency)
+	taxReturn.CreatedAt = time.Now()
+	taxReturn.UpdatedAt = taxReturn.CreatedAt
+
+	_, err = vs.eventStore.CreateEvent(EventPrepareVATReturn, taxReturn, req.PeriodEnd, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create VAT return event: %w", err)
+	}
+	if err := vs.storage.SaveTaxReturn(taxReturn); err != nil {
+		return nil, fmt.Errorf("failed to save VAT return: %w", err)
+	}
+	return taxReturn, nil
+}
+
+// vatDueDate counts the filing deadline's months from the day after the period end,
+// so a quarter ending 31 March with a month and seven days is due on 7 May
+func vatDueDate(periodEnd time.Time, layout *VATReturnLayout) time.Time {
+	return periodEnd.AddDate(0, 0, 1).AddDate(0, layout.DueMonths, layout.DueDays-1)
+}
+
+// addAccountMovements adds the transaction's entries on mapped accounts to their boxes
+func (vs *VATReturnService) addAccountMovements(amounts map[string]int64, layout *VATReturnLayout, mapping *VATReturnMapping, txn *Transaction) error {
+	for _, boxMapping := range mapping.Boxes {
+		if boxMapping.AccountID == "" {
+			continue
+		}
+		box, _ := layout.box(boxMapping.Box)
+		for _, entry := range txn.Entries {
+			if entry.AccountID != boxMapping.AccountID {
+				continue
+			}
+			if entry.Amount.Currency != mapping.Currency {
+				return classify(ErrValidation, "transaction %s has %s on account %s, the %s return is in %s",
+					txn.ID, entry.Amount.Currency, entry.AccountID, mapping.ID, mapping.Currency)
+			}
+			amount := entry.Amount.Value
+			if (box.Side == VATOutput) != (entry.Type == Credit) {
+				amount = -amount
+			}
+			if boxMapping.Negate {
+				amount = -amount
+			}
+			amounts[box.Box] += amount
+		}
+	}
+	return nil
+}
+
+// addTaxLines adds the tax lines of mapped tax codes to their boxes. A reversal takes
+// the tax lines of the transaction it reverses, negated.
+func (vs *VATReturnService) addTaxLines(amounts map[string]int64, layout *VATReturnLayout, mapping *VATReturnMapping, txn *Transaction) error {
+	if !slices.ContainsFunc(mapping.Boxes, func(m VATBoxMapping) bool { return m.TaxLineRuleID != "" }) {
+		return nil
+	}
+	source, sign := txn, int64(1)
+	if originalID, ok := strings.CutPrefix(txn.SourceRef, "REVERSAL_"); ok {
+		original, err := vs.storage.GetTransaction(originalID)
+		if err != nil {
+			return fmt.Errorf("failed to get reversed transaction %s: %w", originalID, err)
+		}
+		source, sign = original, -1
+	}
+	record, err := vs.storage.GetTransactionTaxLines(source.ID)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get tax lines of %s: %w", source.ID, err)
+	}
+	entries := make(map[string]Entry, len(source.Entries))
+	for _, entry := range source.Entries {
+		entries[entry.ID] = entry
+	}
+
+	for _, line := range record.Lines {
+		taxEntry, taxable := entries[line.TaxEntryID], entries[line.TaxableEntryID]
+		side := VATInput
+		if taxEntry.Type == Credit {
+			side = VATOutput
+		}
+		for _, boxMapping := range mapping.Boxes {
+			if boxMapping.TaxLineRuleID != line.RuleID {
+				continue
+			}
+			box, _ := layout.box(boxMapping.Box)
+			if box.Side != side {
+				continue
+			}
+			entry := taxEntry
+			if box.Basis == VATBoxNet {
+				entry = taxable
+			}
+			if entry.Amount.Currency != mapping.Currency {
+				return classify(ErrValidation, "transaction %s has %s tax lines, the %s return is in %s",
+					source.ID, entry.Amount.Currency, mapping.ID, mapping.Currency)
+			}
+			amount := entry.Amount.Value * sign
+			if boxMapping.Negate {
+				amount = -amount
+			}
+			amounts[box.Box] += amount
+		}
+	}
+	return nil
+}
+
+// FilingDocument renders a prepared VAT return with its amounts in major units
+func (vs *VATReturnService) FilingDocument(returnID string) (*VATFilingDocument, error) {
+	taxReturn, err := vs.storage.GetTaxReturn(returnID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tax return: %w", err)
+	}
+	if taxReturn.VATFormat == "" {
+		return nil, classify(ErrValidation, "tax return %s is not a VAT return", returnID)
+	}
+	layout, err := GetVATReturnLayout(taxReturn.VATFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	currency := taxReturn.Currency
+	doc := &VATFilingDocument{
+		ReturnID:     taxReturn.ID,
+		Format:       taxReturn.VATFormat,
+		Jurisdiction: taxReturn.Jurisdiction,
+		CompanyID:    taxReturn.CompanyID,
+		PeriodKey:    taxReturn.PeriodKey,
+		PeriodStart:  taxReturn.PeriodStart.Format(time.DateOnly),
+		PeriodEnd:    taxReturn.PeriodEnd.Format(time.DateOnly),
+		DueDate:      taxReturn.DueDate.Format(time.DateOnly),
+		Currency:     currency,
+		Position:     VATNil,
+	}
+	var output, input, net int64
+	for _, box := range taxReturn.VATBoxes {
+		doc.Boxes = append(doc.Boxes, VATFilingBox{Box: box.Box, Description: box.Description, Amount: json.Number(FormatMinorUnits(box.Amount, currency))})
+		definition, _ := layout.box(box.Box)
+		if definition != nil && definition.Basis == VATBoxTax {
+			if definition.Side == VATOutput {
+				output += box.Amount
+			} else {
+				input += box.Amount
+			}
+		}
+		if box.Box == layout.NetBox {
+			net = box.Amount
+		}
+	}
+	doc.OutputVAT = json.Number(FormatMinorUnits(output, currency))
+	doc.InputVAT = json.Number(FormatMinorUnits(input, currency))
+	doc.NetVAT = json.Number(FormatMinorUnits(net, currency))
+	switch {
+	case net > 0:
+		doc.Position = VATPayable
+	case net < 0:
+		doc.Position = VATReclaimable
+	}
+	return doc, nil
+}
+
+// ExportReturn renders a prepared VAT return as "JSON" (the filing document), "CSV"
+// with one row per box, or "HMRC_MTD", the body of a Making Tax Digital VAT return
+// submission, for UK returns
+func (vs *VATReturnService) ExportReturn(returnID, format string) ([]byte, error) {
+	doc, err := vs.FilingDocument(returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case "JSON":
+		return json.MarshalIndent(doc, "", "  ")
+	case "CSV":
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		rows := [][]string{{"return_id", "period_key", "period_start", "period_end", "box", "description", "amount", "currency"}}
+		for _, box := range doc.Boxes {
+			rows = append(rows, []string{doc.ReturnID, doc.PeriodKey, doc.PeriodStart, doc.PeriodEnd, box.Box, box.Description, box.Amount.String(), string(doc.Currency)})
+		}
+		if err := writer.WriteAll(rows); err != nil {
+			return nil, fmt.Errorf("failed to write CSV: %w", err)
+		}
+		return buf.Bytes(), nil
+	case "HMRC_MTD":
+		return hmrcVATReturnBody(doc)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// hmrcVATReturnBody maps VAT100 boxes to the fields of an MTD submission. HMRC takes
+// box 5 as a positive amount and boxes 6 to 9 in whole pounds.
+func hmrcVATReturnBody(doc *VATFilingDocument) ([]byte, error) {
+	if doc.Format != VATReturnUK100 {
+		return nil, classify(ErrValidation, "only %s returns can be submitted to HMRC, not %s", VATReturnUK100, doc.Format)
+	}
+	if doc.PeriodKey == "" {
+		return nil, classify(ErrValidation, "an HMRC submission needs the period key")
+	}
+	fields := map[string]string{
+		"1": "vatDueSales",
+		"2": "vatDueAcquisitions",
+		"3": "totalVatDue",
+		"4": "vatReclaimedCurrPeriod",
+		"5": "netVatDue",
+		"6": "totalValueSalesExVAT",
+		"7": "totalValuePurchasesExVAT",
+		"8": "totalValueGoodsSuppliedExVAT",
+		"9": "totalAcquisitionsExVAT",
+	}
+	body := map[string]any{"periodKey": doc.PeriodKey, "finalised": true}
+	for _, box := range doc.Boxes {
+		amount := box.Amount.String()
+		switch box.Box {
+		case "5":
+			amount = strings.TrimPrefix(amount, "-")
+		case "6", "7", "8", "9":
+			amount, _, _ = strings.Cut(amount, ".")
+		}
+		body[fields[box.Box]] = json.Number(amount)
+	}
+	return json.MarshalIndent(body, "", "  ")
+}
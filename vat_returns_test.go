@@ -0,0 +1,217 @@
+package accounting
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVATReturns(t *testing.T) {
+	// Setup
+	dbFile := "test_vat_returns.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "tax_manager"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "vat_payable", Code: "2450", Name: "VAT Payable", Type: Liability}, userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "vat_receivable", Code: "1450", Name: "VAT Receivable", Type: Asset}, userID))
+	require.NoError(t, engine.GetComplianceService().CreateTaxRule(TaxRule{
+		Jurisdiction: UK_VAT, TaxType: VAT, Name: "Standard rate", Rate: 0.20, EffectiveFrom: time.Now().AddDate(-2, 0, 0),
+	}))
+
+	sales := &TaxLineRule{Name: "Standard-rated sales", AccountID: "revenue", Jurisdiction: UK_VAT, TaxType: VAT, OutputTaxAccountID: "vat_payable"}
+	require.NoError(t, engine.SaveTaxLineRule(sales, userID))
+	purchases := &TaxLineRule{Name: "Standard-rated purchases", AccountID: "expenses", Jurisdiction: UK_VAT, TaxType: VAT, InputTaxAccountID: "vat_receivable"}
+	require.NoError(t, engine.SaveTaxLineRule(purchases, userID))
+
+	date := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC) }
+	gbp := func(value int64) Amount { return Amount{Value: value, Currency: "GBP"} }
+	post := func(debit, credit string, value int64, on time.Time) *Transaction {
+		txn := &Transaction{
+			Description: debit + " / " + credit,
+			ValidTime:   on,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: gbp(value)},
+				{AccountID: credit, Type: Credit, Amount: gbp(value)},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+		return txn
+	}
+
+	post("accounts_receivable", "revenue", 100050, date(1, 10)) // VAT 200.10
+	post("expenses", "accounts_payable", 40000, date(2, 3))     // VAT 80.00
+	post("accounts_receivable", "revenue", 50000, date(4, 2))   // next quarter
+
+	require.NoError(t, engine.SaveVATReturnMapping(&VATReturnMapping{
+		ID:     "UK-MAIN",
+		Name:   "Main UK VAT registration",
+		Format: VATReturnUK100,
+		Boxes: []VATBoxMapping{
+			{Box: "1", TaxLineRuleID: sales.ID},
+			{Box: "4", TaxLineRuleID: purchases.ID},
+			{Box: "6", AccountID: "revenue"},
+			{Box: "7", AccountID: "expenses"},
+		},
+	}, userID))
+
+	boxes := func(taxReturn *TaxReturn) map[string]int64 {
+		values := make(map[string]int64)
+		for _, box := range taxReturn.VATBoxes {
+			values[box.Box] = box.Amount
+		}
+		return values
+	}
+
+	var prepared *TaxReturn
+	t.Run("Prepares UK VAT100 From Tax Codes And Accounts", func(t *testing.T) {
+		prepared, err = engine.PrepareVATReturn(VATReturnRequest{
+			MappingID: "UK-MAIN", PeriodStart: date(1, 1), PeriodEnd: date(3, 31), PeriodKey: "25A1",
+		}, userID)
+		require.NoError(t, err)
+		require.Len(t, prepared.VATBoxes, 9)
+
+		values := boxes(prepared)
+		assert.Equal(t, int64(20010), values["1"])
+		assert.Equal(t, int64(20010), values["3"])
+		assert.Equal(t, int64(8000), values["4"])
+		assert.Equal(t, int64(12010), values["5"])
+		assert.Equal(t, int64(100000), values["6"], "box 6 is in whole pounds")
+		assert.Equal(t, int64(40000), values["7"])
+
+		assert.Equal(t, UK_VAT, prepared.Jurisdiction)
+		assert.Equal(t, Currency("GBP"), prepared.Currency)
+		assert.InDelta(t, 200.10, prepared.TotalTax, 1e-9)
+		assert.InDelta(t, 80.00, prepared.TaxPaid, 1e-9)
+		assert.InDelta(t, 120.10, prepared.TaxOwed, 1e-9)
+		assert.Equal(t, date(5, 7), prepared.DueDate)
+
+		stored, err := engine.GetStorage().GetTaxReturn(prepared.ID)
+		require.NoError(t, err)
+		assert.Equal(t, prepared.VATBoxes, stored.VATBoxes)
+		assert.Equal(t, "DRAFT", stored.FilingStatus)
+		assert.Equal(t, "25A1", stored.PeriodKey)
+		assert.Equal(t, date(3, 31), stored.PeriodEnd)
+	})
+
+	t.Run("Exports Filing Documents", func(t *testing.T) {
+		data, err := engine.ExportVATReturn(prepared.ID, "JSON")
+		require.NoError(t, err)
+		var doc VATFilingDocument
+		require.NoError(t, json.Unmarshal(data, &doc))
+		assert.Equal(t, VATPayable, doc.Position)
+		assert.Equal(t, json.Number("120.10"), doc.NetVAT)
+		assert.Equal(t, "2025-05-07", doc.DueDate)
+
+		data, err = engine.ExportVATReturn(prepared.ID, "HMRC_MTD")
+		require.NoError(t, err)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(data, &body))
+		assert.Equal(t, "25A1", body["periodKey"])
+		assert.Equal(t, 200.1, body["vatDueSales"])
+		assert.Equal(t, 120.1, body["netVatDue"])
+		assert.Equal(t, 1000.0, body["totalValueSalesExVAT"])
+		assert.Equal(t, true, body["finalised"])
+		assert.Contains(t, string(data), `"totalValuePurchasesExVAT": 400,`)
+
+		data, err = engine.ExportVATReturn(prepared.ID, "CSV")
+		require.NoError(t, err)
+		rows := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, rows, 10)
+		assert.True(t, strings.HasPrefix(rows[5], prepared.ID+",25A1,2025-01-01,2025-03-31,5,"))
+		assert.Contains(t, rows[5], ",120.10,GBP")
+	})
+
+	t.Run("Reclaimable Returns Map Accounts Directly", func(t *testing.T) {
+		require.NoError(t, engine.SaveVATReturnMapping(&VATReturnMapping{
+			ID:     "UK-ACCOUNTS",
+			Format: VATReturnUK100,
+			Boxes: []VATBoxMapping{
+				{Box: "1", AccountID: "vat_payable"},
+				{Box: "4", AccountID: "vat_receivable"},
+			},
+		}, userID))
+		post("expenses", "accounts_payable", 90000, date(5, 20)) // VAT 180.00
+
+		taxReturn, err := engine.PrepareVATReturn(VATReturnRequest{
+			MappingID: "UK-ACCOUNTS", PeriodStart: date(4, 1), PeriodEnd: date(6, 30), PeriodKey: "25A2",
+		}, userID)
+		require.NoError(t, err)
+		values := boxes(taxReturn)
+		assert.Equal(t, int64(10000), values["1"])
+		assert.Equal(t, int64(18000), values["4"])
+		assert.Equal(t, int64(-8000), values["5"])
+		assert.InDelta(t, -80.0, taxReturn.TaxOwed, 1e-9)
+
+		data, err := engine.ExportVATReturn(taxReturn.ID, "HMRC_MTD")
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"netVatDue": 80.00`)
+		doc, err := engine.GetVATReturnService().FilingDocument(taxReturn.ID)
+		require.NoError(t, err)
+		assert.Equal(t, VATReclaimable, doc.Position)
+	})
+
+	t.Run("Credit Notes Cancel Their Sales", func(t *testing.T) {
+		// Reversals are dated when they are made
+		refunded := post("accounts_receivable", "revenue", 20000, time.Now())
+		_, err := engine.ReverseTransaction(refunded.ID, "Credit note", userID)
+		require.NoError(t, err)
+
+		taxReturn, err := engine.PrepareVATReturn(VATReturnRequest{
+			MappingID: "UK-MAIN", PeriodStart: time.Now().AddDate(0, 0, -1), PeriodEnd: time.Now().AddDate(0, 0, 1),
+		}, userID)
+		require.NoError(t, err)
+		values := boxes(taxReturn)
+		assert.Zero(t, values["1"])
+		assert.Zero(t, values["6"])
+		assert.Zero(t, taxReturn.TaxOwed)
+	})
+
+	t.Run("EU Returns", func(t *testing.T) {
+		require.NoError(t, engine.SaveVATReturnMapping(&VATReturnMapping{
+			ID:       "EU-DE",
+			Format:   VATReturnEU,
+			Currency: "GBP",
+			Boxes: []VATBoxMapping{
+				{Box: "OUTPUT_VAT", AccountID: "vat_payable"},
+				{Box: "INPUT_VAT", AccountID: "vat_receivable"},
+				{Box: "TAXABLE_SUPPLIES", TaxLineRuleID: sales.ID},
+			},
+		}, userID))
+		taxReturn, err := engine.PrepareVATReturn(VATReturnRequest{MappingID: "EU-DE", PeriodStart: date(1, 1), PeriodEnd: date(3, 31)}, userID)
+		require.NoError(t, err)
+		values := boxes(taxReturn)
+		assert.Equal(t, EU_VAT, taxReturn.Jurisdiction)
+		assert.Equal(t, int64(12010), values["NET_VAT"])
+		assert.Equal(t, int64(100050), values["TAXABLE_SUPPLIES"])
+
+		_, err = engine.ExportVATReturn(taxReturn.ID, "HMRC_MTD")
+		assert.ErrorIs(t, err, ErrValidation)
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		err := engine.SaveVATReturnMapping(&VATReturnMapping{ID: "BAD", Format: VATReturnUK100, Boxes: []VATBoxMapping{{Box: "5", AccountID: "vat_payable"}}}, userID)
+		assert.ErrorIs(t, err, ErrValidation)
+		err = engine.SaveVATReturnMapping(&VATReturnMapping{ID: "BAD", Format: VATReturnUK100, Boxes: []VATBoxMapping{{Box: "10", AccountID: "vat_payable"}}}, userID)
+		assert.ErrorIs(t, err, ErrValidation)
+		err = engine.SaveVATReturnMapping(&VATReturnMapping{ID: "BAD", Format: "US_SALES"}, userID)
+		assert.ErrorIs(t, err, ErrValidation)
+		err = engine.SaveVATReturnMapping(&VATReturnMapping{ID: "BAD", Format: VATReturnEU, Boxes: []VATBoxMapping{{Box: "INPUT_VAT", AccountID: "vat_receivable"}}}, userID)
+		assert.ErrorContains(t, err, "needs a currency")
+
+		_, err = engine.PrepareVATReturn(VATReturnRequest{MappingID: "UK-MAIN", PeriodStart: date(3, 31), PeriodEnd: date(1, 1)}, userID)
+		assert.ErrorIs(t, err, ErrValidation)
+		_, err = engine.PrepareVATReturn(VATReturnRequest{MappingID: "MISSING", PeriodStart: date(1, 1), PeriodEnd: date(3, 31)}, userID)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
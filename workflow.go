@@ -0,0 +1,468 @@
+package accounting
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Workflow Structures
+// ----------------------------------------------------------------------------
+
+// WorkflowStateNew is the state of a subject no transition has been fired for yet
+const WorkflowStateNew = ""
+
+// workflowTimerUserID is who transitions fired by timers are recorded against
+const workflowTimerUserID = "workflow_timer"
+
+// WorkflowRequest asks for an action to be taken on a subject
+type WorkflowRequest struct {
+	Action    string
+	Actor     string
+	Comment   string
+	Assignees []string // replaces the instance's assignees when not nil
+	Data      any      // module-specific detail for guards and OnTransition, not persisted
+}
+
+// WorkflowGuard decides whether a transition may be taken. The instance is in the
+// state being left.
+type WorkflowGuard func(instance *WorkflowInstance, request *WorkflowRequest) error
+
+// WorkflowTransition moves a subject between states when an action is taken
+type WorkflowTransition struct {
+	Action         string
+	From           []string // WorkflowStateNew allows the action on subjects not yet in the workflow
+	To             string
+	Guard          WorkflowGuard // nil lets anyone take the action
+	RequireComment bool
+}
+
+// WorkflowTimer takes an action on a subject left in a state for too long
+type WorkflowTimer struct {
+	State  string
+	After  time.Duration
+	Action string
+}
+
+// WorkflowDefinition describes a workflow. Modules register one per kind of record
+// whose status they move through review or approval.
+type WorkflowDefinition struct {
+	Name        string
+	Transitions []WorkflowTransition
+	Timers      []WorkflowTimer
+
+	// State reads the subject's current state from the module's own record, so the
+	// record stays the source of truth; nil keeps the state on the instance only
+	State func(subjectID string) (string, error)
+	// NotAllowed explains why an action cannot be taken in the instance's state; nil
+	// gives a generic error
+	NotAllowed func(instance *WorkflowInstance, request *WorkflowRequest) error
+	// OnTransition applies a transition to the module's record. The instance is
+	// already in the new state; an error abandons the transition.
+	OnTransition func(instance *WorkflowInstance, request *WorkflowRequest, from string) error
+}
+
+// transition finds the transition an action takes from a state
+func (d *WorkflowDefinition) transition(action, state string) (*WorkflowTransition, bool) {
+	known := false
+	for i := range d.Transitions {
+		transition := &d.Transitions[i]
+		if transition.Action != action {
+			continue
+		}
+		known = true
+		if slices.Contains(transition.From, state) {
+			return transition, true
+		}
+	}
+	return nil, known
+}
+
+// Actions lists the actions that can be taken from a state
+func (d *WorkflowDefinition) Actions(state string) []string {
+	var actions []string
+	for _, transition := range d.Transitions {
+		if slices.Contains(transition.From, state) && !slices.Contains(actions, transition.Action) {
+			actions = append(actions, transition.Action)
+		}
+	}
+	return actions
+}
+
+// timer finds the timer on a state
+func (d *WorkflowDefinition) timer(state string) *WorkflowTimer {
+	for i := range d.Timers {
+		if d.Timers[i].State == state {
+			return &d.Timers[i]
+		}
+	}
+	return nil
+}
+
+// validate checks that the definition is complete and its timers take real actions
+func (d *WorkflowDefinition) validate() error {
+	if d.Name == "" {
+		return fmt.Errorf("workflow name is required")
+	}
+	if len(d.Transitions) == 0 {
+		return fmt.Errorf("workflow %s has no transitions", d.Name)
+	}
+	for _, transition := range d.Transitions {
+		if transition.Action == "" || transition.To == "" || len(transition.From) == 0 {
+			return fmt.Errorf("workflow %s has a transition without an action, source or target state", d.Name)
+		}
+	}
+	for _, timer := range d.Timers {
+		if timer.After <= 0 {
+			return fmt.Errorf("workflow %s timer on %s must wait a positive duration", d.Name, timer.State)
+		}
+		if transition, _ := d.transition(timer.Action, timer.State); transition == nil {
+			return fmt.Errorf("workflow %s timer on %s takes action %s, which is not allowed there", d.Name, timer.State, timer.Action)
+		}
+	}
+	return nil
+}
+
+// WorkflowHistoryEntry is one transition taken
+type WorkflowHistoryEntry struct {
+	Action  string    `json:"action"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Actor   string    `json:"actor"`
+	Comment string    `json:"comment,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// WorkflowInstance is a subject's progress through a workflow. It is keyed by the
+// workflow and subject ID.
+type WorkflowInstance struct {
+	ID        string                 `json:"id"`
+	Workflow  string                 `json:"workflow"`
+	SubjectID string                 `json:"subject_id"`
+	State     string                 `json:"state"`
+	Assignees []string               `json:"assignees,omitempty"` // who is expected to act next
+	EnteredAt time.Time              `json:"entered_at"`
+	DueAt     *time.Time             `json:"due_at,omitempty"` // when the state's timer fires
+	DueAction string                 `json:"due_action,omitempty"`
+	History   []WorkflowHistoryEntry `json:"history"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+	Version   int64                  `json:"version"` // of the instance this copy was read at
+}
+
+// workflowInstanceID keys an instance by its workflow and subject
+func workflowInstanceID(workflow, subjectID string) string {
+	return workflow + "/" + subjectID
+}
+
+// IsAssigned reports whether the user is among the instance's assignees
+func (wi *WorkflowInstance) IsAssigned(userID string) bool {
+	return slices.Contains(wi.Assignees, userID)
+}
+
+// ----------------------------------------------------------------------------
+// Workflow Service
+// ----------------------------------------------------------------------------
+
+// WorkflowService runs registered workflows: it checks each action against the
+// subject's state and the transition's guard, keeps assignees and history, and fires
+// timers on subjects left waiting
+type WorkflowService struct {
+	storage     *Storage
+	mu          sync.Mutex // guards the fields below
+	definitions map[string]*WorkflowDefinition
+	subjects    map[string]*subjectLock
+	now         func() time.Time
+}
+
+// NewWorkflowService creates a new workflow service
+func NewWorkflowService(storage *Storage) *WorkflowService {
+	return &WorkflowService{
+		storage:     storage,
+		definitions: make(map[string]*WorkflowDefinition),
+		subjects:    make(map[string]*subjectLock),
+		now:         time.Now,
+	}
+}
+
+// Register adds a workflow, replacing any registered under the same name
+func (ws *WorkflowService) Register(definition *WorkflowDefinition) error {
+	if err := definition.validate(); err != nil {
+		return err
+	}
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.definitions[definition.Name] = definition
+	return nil
+}
+
+// mustRegister registers a workflow built into a module, whose definition is fixed
+func (ws *WorkflowService) mustRegister(definition *WorkflowDefinition) {
+	if err := ws.Register(definition); err != nil {
+		panic(fmt.Sprintf("invalid built-in workflow: %v", err))
+	}
+}
+
+// Definition returns a registered workflow
+func (ws *WorkflowService) Definition(workflow string) (*WorkflowDefinition, error) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	definition, ok := ws.definitions[workflow]
+	if !ok {
+		return nil, fmt.Errorf("workflow not registered: %s", workflow)
+	}
+	return definition, nil
+}
+
+// subjectLock serializes transitions on one instance
+type subjectLock struct {
+	sync.Mutex
+	holders int // goroutines holding or waiting for it; dropped from the map at zero
+}
+
+// lockSubject serializes transitions on one instance, returning the unlock. Other
+// subjects, including ones an OnTransition moves in turn, are not blocked.
+func (ws *WorkflowService) lockSubject(workflow, subjectID string) func() {
+	id := workflowInstanceID(workflow, subjectID)
+	ws.mu.Lock()
+	lock, ok := ws.subjects[id]
+	if !ok {
+		lock = &subjectLock{}
+		ws.subjects[id] = lock
+	}
+	lock.holders++
+	ws.mu.Unlock()
+
+	lock.Lock()
+	return func() {
+		lock.Unlock()
+		ws.mu.Lock()
+		if lock.holders--; lock.holders == 0 {
+			delete(ws.subjects, id)
+		}
+		ws.mu.Unlock()
+	}
+}
+
+// Start enters a subject into a workflow in its current state without a transition,
+// so the state's timer runs, as for records created already in their first state
+func (ws *WorkflowService) Start(workflow, subjectID string) (*WorkflowInstance, error) {
+	definition, err := ws.Definition(workflow)
+	if err != nil {
+		return nil, err
+	}
+	defer ws.lockSubject(workflow, subjectID)()
+	instance, state, err := ws.load(definition, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	now := ws.now()
+	if instance.State != state || instance.EnteredAt.IsZero() {
+		instance.EnteredAt = now
+	}
+	instance.State = state
+	instance.UpdatedAt = now
+	ws.setTimer(definition, instance)
+	if err := ws.storage.SaveWorkflowInstance(instance); err != nil {
+		return nil, fmt.Errorf("failed to save workflow instance: %w", err)
+	}
+	return instance, nil
+}
+
+// Fire takes an action on a subject, returning the instance in its new state
+func (ws *WorkflowService) Fire(workflow, subjectID string, request *WorkflowRequest) (*WorkflowInstance, error) {
+	definition, err := ws.Definition(workflow)
+	if err != nil {
+		return nil, err
+	}
+	defer ws.lockSubject(workflow, subjectID)()
+	return ws.fire(definition, subjectID, request)
+}
+
+// fire takes an action; callers hold the subject's lock
+func (ws *WorkflowService) fire(definition *WorkflowDefinition, subjectID string, request *WorkflowRequest) (*WorkflowInstance, error) {
+	instance, from, err := ws.load(definition, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	instance.State = from
+
+	transition, known := definition.transition(request.Action, from)
+	if !known {
+		return nil, fmt.Errorf("workflow %s has no action %s", definition.Name, request.Action)
+	}
+	for _, candidate := range definition.Transitions {
+		if candidate.Action == request.Action && candidate.RequireComment && request.Comment == "" {
+			return nil, fmt.Errorf("a comment is required to %s", request.Action)
+		}
+	}
+	if transition == nil {
+		if definition.NotAllowed != nil {
+			if err := definition.NotAllowed(instance, request); err != nil {
+				return nil, err
+			}
+		}
+		return nil, fmt.Errorf("cannot %s %s %s in state %s", request.Action, definition.Name, subjectID, from)
+	}
+	if transition.Guard != nil {
+		if err := transition.Guard(instance, request); err != nil {
+			return nil, err
+		}
+	}
+
+	now := ws.now()
+	instance.State = transition.To
+	instance.EnteredAt = now
+	instance.UpdatedAt = now
+	if request.Assignees != nil {
+		instance.Assignees = append([]string(nil), request.Assignees...)
+	}
+	instance.History = append(instance.History, WorkflowHistoryEntry{
+		Action: request.Action, From: from, To: transition.To, Actor: request.Actor, Comment: request.Comment, At: now,
+	})
+	ws.setTimer(definition, instance)
+
+	if definition.OnTransition != nil {
+		if err := definition.OnTransition(instance, request, from); err != nil {
+			return nil, err
+		}
+	}
+	if err := ws.storage.SaveWorkflowInstance(instance); err != nil {
+		return nil, fmt.Errorf("failed to save workflow instance: %w", err)
+	}
+	return instance, nil
+}
+
+// load reads a subject's instance, or starts a new one, with the subject's current state
+func (ws *WorkflowService) load(definition *WorkflowDefinition, subjectID string) (*WorkflowInstance, string, error) {
+	instance, err := ws.storage.GetWorkflowInstance(workflowInstanceID(definition.Name, subjectID))
+	if err != nil {
+		now := ws.now()
+		instance = &WorkflowInstance{
+			ID:        workflowInstanceID(definition.Name, subjectID),
+			Workflow:  definition.Name,
+			SubjectID: subjectID,
+			CreatedAt: now,
+		}
+	}
+	state := instance.State
+	if definition.State != nil {
+		if state, err = definition.State(subjectID); err != nil {
+			return nil, "", err
+		}
+	}
+	return instance, state, nil
+}
+
+// setTimer schedules the timer on the instance's state, if it has one
+func (ws *WorkflowService) setTimer(definition *WorkflowDefinition, instance *WorkflowInstance) {
+	instance.DueAt, instance.DueAction = nil, ""
+	if timer := definition.timer(instance.State); timer != nil {
+		due := instance.EnteredAt.Add(timer.After)
+		instance.DueAt, instance.DueAction = &due, timer.Action
+	}
+}
+
+// FireTimers takes the timed action on every subject whose timer is due. Subjects
+// that moved on outside the workflow have their timer cleared instead.
+func (ws *WorkflowService) FireTimers(asOf time.Time) ([]*WorkflowInstance, error) {
+	instances, err := ws.storage.GetAllWorkflowInstances()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow instances: %w", err)
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].ID < instances[j].ID })
+
+	var fired []*WorkflowInstance
+	for _, instance := range instances {
+		if instance.DueAt == nil || instance.DueAt.After(asOf) {
+			continue
+		}
+		definition, err := ws.Definition(instance.Workflow)
+		if err != nil {
+			continue
+		}
+		advanced, err := ws.fireTimer(definition, instance.SubjectID, asOf)
+		if err != nil {
+			return fired, fmt.Errorf("failed to fire %s timer for %s: %w", instance.Workflow, instance.SubjectID, err)
+		}
+		if advanced != nil {
+			fired = append(fired, advanced)
+		}
+	}
+	return fired, nil
+}
+
+// fireTimer takes a subject's timed action if it is still due, returning nil when
+// there was nothing to do
+func (ws *WorkflowService) fireTimer(definition *WorkflowDefinition, subjectID string, asOf time.Time) (*WorkflowInstance, error) {
+	defer ws.lockSubject(definition.Name, subjectID)()
+	instance, state, err := ws.load(definition, subjectID)
+	if err != nil {
+		return nil, err
+	}
+	if instance.DueAt == nil || instance.DueAt.After(asOf) {
+		return nil, nil
+	}
+	if state != instance.State {
+		instance.State = state
+		instance.EnteredAt = asOf
+		ws.setTimer(definition, instance)
+		if err := ws.storage.SaveWorkflowInstance(instance); err != nil {
+			return nil, fmt.Errorf("failed to save workflow instance: %w", err)
+		}
+		return nil, nil
+	}
+	return ws.fire(definition, subjectID, &WorkflowRequest{
+		Action:  instance.DueAction,
+		Actor:   workflowTimerUserID,
+		Comment: fmt.Sprintf("no action for %s in %s", asOf.Sub(instance.EnteredAt).Round(time.Hour), instance.State),
+	})
+}
+
+// GetInstance returns a subject's progress through a workflow
+func (ws *WorkflowService) GetInstance(workflow, subjectID string) (*WorkflowInstance, error) {
+	return ws.storage.GetWorkflowInstance(workflowInstanceID(workflow, subjectID))
+}
+
+// GetAssigned returns the instances waiting on a user, across workflows, oldest first
+func (ws *WorkflowService) GetAssigned(userID string) ([]*WorkflowInstance, error) {
+	instances, err := ws.storage.GetAllWorkflowInstances()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow instances: %w", err)
+	}
+	var assigned []*WorkflowInstance
+	for _, instance := range instances {
+		if instance.IsAssigned(userID) {
+			assigned = append(assigned, instance)
+		}
+	}
+	sort.Slice(assigned, func(i, j int) bool { return assigned[i].EnteredAt.Before(assigned[j].EnteredAt) })
+	return assigned, nil
+}
+
+// ----------------------------------------------------------------------------
+// Workflow Guards
+// ----------------------------------------------------------------------------
+
+// AllGuards passes when every guard passes, checking them in order
+func AllGuards(guards ...WorkflowGuard) WorkflowGuard {
+	return func(instance *WorkflowInstance, request *WorkflowRequest) error {
+		for _, guard := range guards {
+			if err := guard(instance, request); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// AssigneesOnly lets only the instance's assignees act, or anyone while it has none
+func AssigneesOnly(instance *WorkflowInstance, request *WorkflowRequest) error {
+	if len(instance.Assignees) > 0 && !instance.IsAssigned(request.Actor) {
+		return fmt.Errorf("user %s is not assigned to %s %s", request.Actor, instance.Workflow, instance.SubjectID)
+	}
+	return nil
+}
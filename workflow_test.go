@@ -0,0 +1,209 @@
+package accounting
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowEngine(t *testing.T) {
+	// Setup
+	dbFile := "test_workflow.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	workflows := engine.GetWorkflowService()
+
+	t.Run("Custom Workflow", func(t *testing.T) {
+		vendorReview := &WorkflowDefinition{
+			Name: "vendor_onboarding",
+			Transitions: []WorkflowTransition{
+				{Action: "submit", From: []string{WorkflowStateNew}, To: "REVIEW"},
+				{Action: "approve", From: []string{"REVIEW"}, To: "ACTIVE", Guard: AssigneesOnly},
+				{Action: "reject", From: []string{"REVIEW"}, To: "REJECTED", Guard: AssigneesOnly, RequireComment: true},
+				{Action: "expire", From: []string{"REVIEW"}, To: "REJECTED"},
+			},
+			Timers: []WorkflowTimer{{State: "REVIEW", After: 7 * 24 * time.Hour, Action: "expire"}},
+		}
+		assert.ErrorContains(t, engine.RegisterWorkflow(&WorkflowDefinition{Name: WorkflowAMLAlert, Transitions: vendorReview.Transitions}), "built in")
+		assert.ErrorContains(t, engine.RegisterWorkflow(&WorkflowDefinition{
+			Name:        "broken",
+			Transitions: vendorReview.Transitions,
+			Timers:      []WorkflowTimer{{State: "ACTIVE", After: time.Hour, Action: "expire"}},
+		}), "not allowed there")
+		require.NoError(t, engine.RegisterWorkflow(vendorReview))
+
+		instance, err := workflows.Fire("vendor_onboarding", "vendor-1", &WorkflowRequest{Action: "submit", Actor: "buyer", Assignees: []string{"procurement"}})
+		require.NoError(t, err)
+		assert.Equal(t, "REVIEW", instance.State)
+		require.NotNil(t, instance.DueAt)
+		assert.Equal(t, "expire", instance.DueAction)
+
+		assigned, err := engine.GetAssignedWork("procurement")
+		require.NoError(t, err)
+		require.Len(t, assigned, 1)
+		assert.Equal(t, "vendor-1", assigned[0].SubjectID)
+
+		_, err = engine.FireWorkflow("vendor_onboarding", "vendor-1", "approve", "", "buyer")
+		assert.ErrorContains(t, err, "not assigned")
+		_, err = engine.FireWorkflow("vendor_onboarding", "vendor-1", "reject", "", "procurement")
+		assert.ErrorContains(t, err, "comment is required")
+		_, err = engine.FireWorkflow("vendor_onboarding", "vendor-1", "submit", "", "buyer")
+		assert.ErrorContains(t, err, "in state REVIEW")
+		_, err = engine.FireWorkflow("vendor_onboarding", "vendor-1", "suspend", "", "buyer")
+		assert.ErrorContains(t, err, "no action suspend")
+
+		// The timer leaves a subject that is still waiting for a decision
+		_, err = workflows.Fire("vendor_onboarding", "vendor-2", &WorkflowRequest{Action: "submit", Actor: "buyer"})
+		require.NoError(t, err)
+		instance, err = engine.FireWorkflow("vendor_onboarding", "vendor-1", "approve", "Checked bank details", "procurement")
+		require.NoError(t, err)
+		assert.Equal(t, "ACTIVE", instance.State)
+		assert.Nil(t, instance.DueAt)
+
+		fired, err := engine.FireWorkflowTimers(time.Now().Add(8 * 24 * time.Hour))
+		require.NoError(t, err)
+		require.Len(t, fired, 1)
+		assert.Equal(t, "vendor-2", fired[0].SubjectID)
+		assert.Equal(t, "REJECTED", fired[0].State)
+
+		stored, err := engine.GetWorkflowInstance("vendor_onboarding", "vendor-1")
+		require.NoError(t, err)
+		require.Len(t, stored.History, 2)
+		assert.Equal(t, WorkflowHistoryEntry{Action: "approve", From: "REVIEW", To: "ACTIVE", Actor: "procurement", Comment: "Checked bank details", At: stored.History[1].At}, stored.History[1])
+	})
+
+	t.Run("Built-In Workflows Keep Their Permission Checks", func(t *testing.T) {
+		_, err := engine.FireWorkflow(WorkflowBudgetRequest, "req-1", "approve", "", userID)
+		assert.ErrorContains(t, err, "driven through its module's methods")
+	})
+
+	t.Run("Budget Requests", func(t *testing.T) {
+		request := &BudgetRequest{
+			Title:     "Laptops",
+			LineItems: []BudgetLineItem{{Description: "Laptops", Amount: &Amount{Value: 500000, Currency: "USD"}}},
+		}
+		require.NoError(t, engine.CreateBudgetRequest(request, "manager"))
+		assert.ErrorContains(t, engine.SubmitBudgetRequest(request.ID, "manager"), "missing justification")
+		assert.ErrorContains(t, engine.ApproveBudgetRequest(request.ID, userID, nil, ""), "can only approve submitted requests")
+
+		stored, err := engine.GetStorage().GetBudgetRequest(request.ID)
+		require.NoError(t, err)
+		stored.LineItems[0].Justification = "Replacement cycle"
+		require.NoError(t, engine.GetStorage().SaveBudgetRequest(stored))
+
+		require.NoError(t, engine.SubmitBudgetRequest(request.ID, "manager"))
+		assert.ErrorContains(t, engine.RequestBudgetRevision(request.ID, userID, ""), "comment is required")
+		require.NoError(t, engine.RequestBudgetRevision(request.ID, userID, "Quote two vendors"))
+		require.NoError(t, engine.SubmitBudgetRequest(request.ID, "manager"))
+		require.NoError(t, engine.ReviewBudgetRequest(request.ID, userID))
+		require.NoError(t, engine.ApproveBudgetRequest(request.ID, userID, &Amount{Value: 450000, Currency: "USD"}, "Approved at the lower quote"))
+
+		stored, err = engine.GetStorage().GetBudgetRequest(request.ID)
+		require.NoError(t, err)
+		assert.Equal(t, BudgetRequestApproved, stored.Status)
+		assert.Equal(t, userID, stored.ApprovedBy)
+
+		instance, err := engine.GetWorkflowInstance(WorkflowBudgetRequest, request.ID)
+		require.NoError(t, err)
+		var actions []string
+		for _, entry := range instance.History {
+			actions = append(actions, entry.Action)
+		}
+		assert.Equal(t, []string{"submit", "request_revision", "submit", "review", "approve"}, actions)
+		assert.Empty(t, instance.Assignees)
+	})
+
+	t.Run("AML Alerts Escalate When Left Open", func(t *testing.T) {
+		stale := &AMLAlert{Title: "Structuring", RiskLevel: RiskHigh}
+		handled := &AMLAlert{Title: "Rapid movement", RiskLevel: RiskMedium}
+		require.NoError(t, engine.GetAMLService().RaiseAlert(stale))
+		require.NoError(t, engine.GetAMLService().RaiseAlert(handled))
+
+		_, err := engine.CreateAMLInvestigation(handled.ID, "analyst")
+		require.NoError(t, err)
+		assigned, err := engine.GetAssignedWork("analyst")
+		require.NoError(t, err)
+		require.Len(t, assigned, 1)
+		assert.Equal(t, handled.ID, assigned[0].SubjectID)
+
+		fired, err := engine.FireWorkflowTimers(time.Now().Add(AMLAlertEscalationAge + time.Hour))
+		require.NoError(t, err)
+		require.Len(t, fired, 1)
+		assert.Equal(t, stale.ID, fired[0].SubjectID)
+
+		alert, err := engine.GetStorage().GetAMLAlert(stale.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ESCALATED", alert.Status)
+
+		require.NoError(t, engine.UpdateAMLAlertStatus(handled.ID, "CLOSED", userID))
+		alert, err = engine.GetStorage().GetAMLAlert(handled.ID)
+		require.NoError(t, err)
+		require.Len(t, alert.Dispositions, 1)
+		assert.Equal(t, "NO_ACTION", alert.Dispositions[0].Type)
+		assert.ErrorContains(t, engine.UpdateAMLAlertStatus(handled.ID, "ARCHIVED", userID), "unknown AML alert status")
+		assert.ErrorContains(t, engine.UpdateAMLAlertStatus(handled.ID, "UNDER_REVIEW", userID), "alert is CLOSED")
+	})
+
+	t.Run("Journal Approvals", func(t *testing.T) {
+		require.NoError(t, engine.ApplyCompanySettings(&CompanySettings{RequireApprovalOver: &Amount{Value: 100000, Currency: "USD"}}))
+		engine.SetJournalApprovers([]string{"cfo"})
+
+		txn := &Transaction{
+			Description: "Year-end accrual",
+			ValidTime:   time.Now(),
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 250000, Currency: "USD"}},
+				{AccountID: "accounts_payable", Type: Credit, Amount: Amount{Value: 250000, Currency: "USD"}},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, "accountant"))
+		require.NoError(t, engine.PostTransaction(txn.ID, "accountant"))
+
+		instance, err := engine.GetWorkflowInstance(WorkflowJournalApproval, txn.ID)
+		require.NoError(t, err)
+		assert.Equal(t, string(JournalApprovalPending), instance.State)
+		assert.Equal(t, []string{"cfo"}, instance.Assignees)
+
+		_, err = engine.ApproveTransaction(txn.ID, "", "accountant")
+		assert.ErrorContains(t, err, "someone other than its preparer")
+		_, err = engine.ApproveTransaction(txn.ID, "Agreed to invoice", "cfo")
+		require.NoError(t, err)
+
+		instance, err = engine.GetWorkflowInstance(WorkflowJournalApproval, txn.ID)
+		require.NoError(t, err)
+		assert.Equal(t, string(JournalApprovalApproved), instance.State)
+		assert.Empty(t, instance.Assignees)
+		require.Len(t, instance.History, 2)
+		assert.Equal(t, "cfo", instance.History[1].Actor)
+	})
+
+	t.Run("Period Close", func(t *testing.T) {
+		engine.SetPeriodClosePermissions(PeriodClosePermissions{Controllers: []string{userID}, HardClosers: []string{"cfo"}})
+		period := &Period{Name: "May 2025", Start: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC)}
+		require.NoError(t, engine.CreatePeriod(period, userID))
+
+		_, err := engine.ReopenPeriod(period.ID, "Late invoice", userID)
+		assert.ErrorContains(t, err, fmt.Sprintf("period %s is not closed", period.ID))
+		require.NoError(t, engine.ClosePeriod(period.ID, true, userID))
+		assert.ErrorContains(t, engine.ClosePeriod(period.ID, true, userID), "already soft-closed")
+		assert.ErrorContains(t, engine.ClosePeriod(period.ID, false, "cfo"), "checklist steps outstanding")
+		_, err = engine.ReopenPeriod(period.ID, "Late invoice", userID)
+		require.NoError(t, err)
+
+		instance, err := engine.GetWorkflowInstance(WorkflowPeriodClose, period.ID)
+		require.NoError(t, err)
+		assert.Equal(t, PeriodStateOpen, instance.State)
+		require.Len(t, instance.History, 2)
+		assert.Equal(t, WorkflowHistoryEntry{Action: "reopen", From: PeriodStateSoftClosed, To: PeriodStateOpen, Actor: userID, Comment: "Late invoice", At: instance.History[1].At}, instance.History[1])
+	})
+}
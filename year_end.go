@@ -0,0 +1,425 @@
+package accounting
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Year-End Close Structures
+// ----------------------------------------------------------------------------
+
+// FiscalYearStatus is the state of a fiscal year close
+type FiscalYearStatus string
+
+const (
+	FiscalYearLocked   FiscalYearStatus = "LOCKED"
+	FiscalYearReopened FiscalYearStatus = "REOPENED"
+)
+
+// FiscalYearCloseLine is one income or expense balance closed to retained earnings
+type FiscalYearCloseLine struct {
+	AccountID   string      `json:"account_id"`
+	AccountName string      `json:"account_name"`
+	AccountType AccountType `json:"account_type"`
+	Dimensions  []Dimension `json:"dimensions,omitempty"`
+	Currency    Currency    `json:"currency"`
+	Amount      int64       `json:"amount"` // balance closed, positive on the account's normal side
+}
+
+// FiscalYearCloseTotal summarizes the result closed for one currency
+type FiscalYearCloseTotal struct {
+	Currency  Currency `json:"currency"`
+	Income    int64    `json:"income"`
+	Expenses  int64    `json:"expenses"`
+	NetIncome int64    `json:"net_income"`
+}
+
+// FiscalYearClose closes a fiscal year's income and expense accounts into retained
+// earnings and locks the year. It doubles as the closing report.
+type FiscalYearClose struct {
+	ID                        string         `json:"id"` // e.g. "FY2025"; also the ID of the locked period
+	Name                      string         `json:"name"`
+	Start                     time.Time      `json:"start"`
+	End                       time.Time      `json:"end"`
+	RetainedEarningsAccountID string         `json:"retained_earnings_account_id"`
+	DimensionKeys             []DimensionKey `json:"dimension_keys,omitempty"` // dimensions kept on closing entries; empty keeps all
+
+	// Filled in by the close
+	Status                 FiscalYearStatus       `json:"status"`
+	Lines                  []FiscalYearCloseLine  `json:"lines"`
+	Totals                 []FiscalYearCloseTotal `json:"totals"`
+	TransactionIDs         []string               `json:"transaction_ids"`
+	ReversalTransactionIDs []string               `json:"reversal_transaction_ids,omitempty"`
+	ClosedBy               string                 `json:"closed_by"`
+	ClosedAt               time.Time              `json:"closed_at"`
+	ReopenedBy             string                 `json:"reopened_by,omitempty"`
+	ReopenedAt             *time.Time             `json:"reopened_at,omitempty"`
+	ReopenReason           string                 `json:"reopen_reason,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+// Year-End Close Service
+// ----------------------------------------------------------------------------
+
+// YearEndCloseService generates year-end closing entries and locks fiscal years
+type YearEndCloseService struct {
+	storage            *Storage
+	eventStore         *EventStore
+	postingEngine      *PostingEngine
+	periodCloseService *PeriodCloseService
+}
+
+// NewYearEndCloseService creates a new year-end close service
+func NewYearEndCloseService(storage *Storage, eventStore *EventStore, postingEngine *PostingEngine, periodCloseService *PeriodCloseService) *YearEndCloseService {
+	return &YearEndCloseService{
+		storage:            storage,
+		eventStore:         eventStore,
+		postingEngine:      postingEngine,
+		periodCloseService: periodCloseService,
+	}
+}
+
+// CloseFiscalYear zeroes the year's income and expense activity into retained earnings,
+// one closing transaction per currency dated at year end. Each account is closed per
+// combination of its entries' dimensions, and retained earnings carries the same
+// dimensions. The year is then locked as a hard-closed period.
+func (yes *YearEndCloseService) CloseFiscalYear(fy *FiscalYearClose, userID string) (*FiscalYearClose, error) {
+	if fy.ID == "" {
+		return nil, fmt.Errorf("fiscal year ID is required")
+	}
+	if !fy.End.After(fy.Start) {
+		return nil, fmt.Errorf("fiscal year must end after it starts")
+	}
+	retained, err := yes.storage.GetAccount(fy.RetainedEarningsAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retained earnings account: %w", err)
+	}
+	if retained.Type != Equity {
+		return nil, fmt.Errorf("retained earnings account %s must be an equity account", retained.ID)
+	}
+	if existing, err := yes.storage.GetFiscalYearClose(fy.ID); err == nil && existing.Status == FiscalYearLocked {
+		return nil, classify(ErrPeriodClosed, "fiscal year %s is already closed", fy.ID)
+	}
+	if err := yes.postingEngine.validatePeriod(fy.End, userID); err != nil {
+		return nil, fmt.Errorf("cannot post closing entries: %w", err)
+	}
+
+	lines, err := yes.closingLines(fy)
+	if err != nil {
+		return nil, err
+	}
+
+	fy.Status = FiscalYearLocked
+	fy.Lines = lines
+	fy.Totals = nil
+	fy.TransactionIDs = nil
+	fy.ReversalTransactionIDs = nil
+	fy.ClosedBy = userID
+	fy.ClosedAt = time.Now()
+	fy.ReopenedBy, fy.ReopenedAt, fy.ReopenReason = "", nil, ""
+
+	byCurrency := make(map[Currency][]FiscalYearCloseLine)
+	var currencies []string
+	for _, line := range lines {
+		if _, seen := byCurrency[line.Currency]; !seen {
+			currencies = append(currencies, string(line.Currency))
+		}
+		byCurrency[line.Currency] = append(byCurrency[line.Currency], line)
+	}
+	sort.Strings(currencies)
+
+	for _, code := range currencies {
+		currency := Currency(code)
+		total := FiscalYearCloseTotal{Currency: currency}
+		for _, line := range byCurrency[currency] {
+			if line.AccountType == Income {
+				total.Income += line.Amount
+			} else {
+				total.Expenses += line.Amount
+			}
+		}
+		total.NetIncome = total.Income - total.Expenses
+		fy.Totals = append(fy.Totals, total)
+
+		txn := yes.closingTransaction(fy, currency, byCurrency[currency], userID)
+		if err := yes.postTransaction(txn, userID); err != nil {
+			return nil, err
+		}
+		fy.TransactionIDs = append(fy.TransactionIDs, txn.ID)
+	}
+
+	if err := yes.lockPeriod(fy, userID); err != nil {
+		return nil, err
+	}
+
+	_, err = yes.eventStore.CreateEvent(EventCloseFiscalYear, fy, fy.End, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fiscal year close event: %w", err)
+	}
+	if err := yes.storage.SaveFiscalYearClose(fy); err != nil {
+		return nil, fmt.Errorf("failed to save fiscal year close: %w", err)
+	}
+	return fy, nil
+}
+
+// ReopenFiscalYear unlocks a closed fiscal year and reverses its closing entries with
+// contra transactions dated at year end, restoring the income and expense balances
+func (yes *YearEndCloseService) ReopenFiscalYear(fiscalYearID, reason, userID string) (*FiscalYearClose, error) {
+	fy, err := yes.storage.GetFiscalYearClose(fiscalYearID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fiscal year close: %w", err)
+	}
+	if fy.Status != FiscalYearLocked {
+		return nil, fmt.Errorf("fiscal year %s is not closed", fiscalYearID)
+	}
+	if _, err := yes.periodCloseService.ReopenPeriod(fy.ID, reason, userID); err != nil {
+		return nil, fmt.Errorf("failed to reopen fiscal year period: %w", err)
+	}
+
+	for _, txnID := range fy.TransactionIDs {
+		closing, err := yes.storage.GetTransaction(txnID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get closing transaction: %w", err)
+		}
+		reversal := yes.newTransaction(fmt.Sprintf("Reversal of %s", closing.Description), fy.End, "REVERSAL_"+closing.ID, userID)
+		for _, entry := range closing.Entries {
+			reversal.Entries = append(reversal.Entries, Entry{
+				ID:            newID(),
+				TransactionID: reversal.ID,
+				AccountID:     entry.AccountID,
+				Type:          oppositeEntryType(entry.Type),
+				Amount:        entry.Amount,
+				Dimensions:    entry.Dimensions,
+			})
+		}
+		if err := yes.postTransaction(reversal, userID); err != nil {
+			return nil, err
+		}
+		fy.ReversalTransactionIDs = append(fy.ReversalTransactionIDs, reversal.ID)
+	}
+
+	now := time.Now()
+	fy.Status = FiscalYearReopened
+	fy.ReopenedBy = userID
+	fy.ReopenedAt = &now
+	fy.ReopenReason = reason
+
+	_, err = yes.eventStore.CreateEvent(EventReopenFiscalYear, fy, fy.End, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fiscal year reopen event: %w", err)
+	}
+	if err := yes.storage.SaveFiscalYearClose(fy); err != nil {
+		return nil, fmt.Errorf("failed to save fiscal year close: %w", err)
+	}
+	return fy, nil
+}
+
+// closingLines nets the year's posted income and expense entries by account, currency
+// and kept dimensions
+func (yes *YearEndCloseService) closingLines(fy *FiscalYearClose) ([]FiscalYearCloseLine, error) {
+	txns, err := yes.storage.GetTransactionsByDateRange("", fy.Start, fy.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	accounts := make(map[string]*Account)
+
+	netDebits := make(map[string]int64)
+	groups := make(map[string]*FiscalYearCloseLine)
+	var keys []string
+	for _, txn := range txns {
+		if txn.Status != Posted {
+			continue
+		}
+		for _, entry := range txn.Entries {
+			account, ok := accounts[entry.AccountID]
+			if !ok {
+				account, err = yes.storage.GetAccount(entry.AccountID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get account: %w", err)
+				}
+				accounts[entry.AccountID] = account
+			}
+			if account.Type != Income && account.Type != Expense {
+				continue
+			}
+
+			dims := keptDimensions(entry.Dimensions, fy.DimensionKeys)
+			key := entry.AccountID + "|" + string(entry.Amount.Currency) + "|" + dimensionsKey(dims)
+			if _, ok := groups[key]; !ok {
+				groups[key] = &FiscalYearCloseLine{
+					AccountID:   account.ID,
+					AccountName: account.Name,
+					AccountType: account.Type,
+					Dimensions:  dims,
+					Currency:    entry.Amount.Currency,
+				}
+				keys = append(keys, key)
+			}
+			if entry.Type == Debit {
+				netDebits[key] += entry.Amount.Value
+			} else {
+				netDebits[key] -= entry.Amount.Value
+			}
+		}
+	}
+	sort.Strings(keys)
+
+	var lines []FiscalYearCloseLine
+	for _, key := range keys {
+		if netDebits[key] == 0 {
+			continue
+		}
+		line := groups[key]
+		line.Amount = netDebits[key]
+		if line.AccountType == Income {
+			line.Amount = -line.Amount
+		}
+		lines = append(lines, *line)
+	}
+	return lines, nil
+}
+
+// closingTransaction reverses each line's balance and books the net to retained
+// earnings under the same dimensions
+func (yes *YearEndCloseService) closingTransaction(fy *FiscalYearClose, currency Currency, lines []FiscalYearCloseLine, userID string) *Transaction {
+	txn := yes.newTransaction(fmt.Sprintf("Year-end close %s", fy.Name), fy.End, fmt.Sprintf("YEAR_END_CLOSE_%s_%s", fy.ID, currency), userID)
+
+	retainedDebits := make(map[string]int64)
+	retainedDims := make(map[string][]Dimension)
+	var retainedKeys []string
+	for _, line := range lines {
+		// Net debit on the account is closed by the opposite entry
+		netDebit := line.Amount
+		if line.AccountType == Income {
+			netDebit = -line.Amount
+		}
+		entryType, value := Credit, netDebit
+		if netDebit < 0 {
+			entryType, value = Debit, -netDebit
+		}
+		txn.Entries = append(txn.Entries, Entry{
+			ID:            newID(),
+			TransactionID: txn.ID,
+			AccountID:     line.AccountID,
+			Type:          entryType,
+			Amount:        Amount{Value: value, Currency: currency},
+			Dimensions:    line.Dimensions,
+		})
+
+		key := dimensionsKey(line.Dimensions)
+		if _, ok := retainedDims[key]; !ok {
+			retainedDims[key] = line.Dimensions
+			retainedKeys = append(retainedKeys, key)
+		}
+		retainedDebits[key] += netDebit
+	}
+
+	sort.Strings(retainedKeys)
+	for _, key := range retainedKeys {
+		netDebit := retainedDebits[key]
+		if netDebit == 0 {
+			continue
+		}
+		entryType, value := Debit, netDebit
+		if netDebit < 0 {
+			entryType, value = Credit, -netDebit
+		}
+		txn.Entries = append(txn.Entries, Entry{
+			ID:            newID(),
+			TransactionID: txn.ID,
+			AccountID:     fy.RetainedEarningsAccountID,
+			Type:          entryType,
+			Amount:        Amount{Value: value, Currency: currency},
+			Dimensions:    retainedDims[key],
+		})
+	}
+	return txn
+}
+
+// lockPeriod hard-closes the period covering the fiscal year, creating it if needed
+func (yes *YearEndCloseService) lockPeriod(fy *FiscalYearClose, userID string) error {
+	period, err := yes.storage.GetPeriod(fy.ID)
+	if err != nil {
+		period = &Period{ID: fy.ID, Name: fy.Name, Start: fy.Start, End: fy.End}
+		_, err := yes.eventStore.CreateEvent(EventCreatePeriod, period, time.Now(), userID)
+		if err != nil {
+			return fmt.Errorf("failed to create period event: %w", err)
+		}
+	}
+	now := time.Now()
+	period.HardClosedAt = &now
+	if err := yes.storage.SavePeriod(period); err != nil {
+		return fmt.Errorf("failed to lock fiscal year period: %w", err)
+	}
+	return nil
+}
+
+// newTransaction creates a pending year-end transaction without entries
+func (yes *YearEndCloseService) newTransaction(description string, validTime time.Time, sourceRef, userID string) *Transaction {
+	return &Transaction{
+		ID:              newID(),
+		Description:     description,
+		ValidTime:       validTime,
+		TransactionTime: time.Now(),
+		Status:          Pending,
+		SourceRef:       sourceRef,
+		UserID:          userID,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// postTransaction records, saves and posts a year-end transaction
+func (yes *YearEndCloseService) postTransaction(txn *Transaction, userID string) error {
+	_, err := yes.eventStore.CreateEvent(
+		EventCreateTransaction,
+		TransactionCreatedEvent{Transaction: txn},
+		txn.ValidTime,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create transaction event: %w", err)
+	}
+
+	if err := yes.storage.SaveTransaction(txn); err != nil {
+		return fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	if err := yes.postingEngine.PostTransaction(txn, userID); err != nil {
+		return fmt.Errorf("failed to post transaction: %w", err)
+	}
+	return nil
+}
+
+// keptDimensions returns the entry dimensions to carry onto closing entries, sorted by key
+func keptDimensions(dims []Dimension, keys []DimensionKey) []Dimension {
+	var kept []Dimension
+	for _, dim := range dims {
+		if len(keys) == 0 {
+			kept = append(kept, dim)
+			continue
+		}
+		for _, key := range keys {
+			if dim.Key == key {
+				kept = append(kept, dim)
+				break
+			}
+		}
+	}
+	sort.Slice(kept, func(i, j int) bool {
+		return kept[i].Key < kept[j].Key
+	})
+	return kept
+}
+
+// dimensionsKey renders sorted dimensions as a grouping key
+func dimensionsKey(dims []Dimension) string {
+	parts := make([]string, len(dims))
+	for i, dim := range dims {
+		parts[i] = string(dim.Key) + "=" + dim.Value
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,144 @@
+package accounting
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloseFiscalYear(t *testing.T) {
+	// Setup
+	dbFile := "test_year_end.db"
+	defer os.Remove(dbFile)
+
+	engine, err := NewAccountingEngine(dbFile)
+	require.NoError(t, err)
+	defer engine.Close()
+
+	userID := "controller"
+	require.NoError(t, engine.CreateStandardAccounts(userID))
+	require.NoError(t, engine.CreateAccount(&Account{ID: "retained_earnings", Code: "3100", Name: "Retained Earnings", Type: Equity}, userID))
+
+	date := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	yearEnd := time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC)
+	balance := func(accountID string) int64 {
+		result, err := engine.GetAccountBalance(accountID, yearEnd)
+		require.NoError(t, err)
+		return result.Balance.Value
+	}
+	post := func(debit, credit string, value int64, when time.Time, dims ...Dimension) {
+		txn := &Transaction{
+			Description: "Trading",
+			ValidTime:   when,
+			Entries: []Entry{
+				{AccountID: debit, Type: Debit, Amount: Amount{Value: value, Currency: "USD"}, Dimensions: dims},
+				{AccountID: credit, Type: Credit, Amount: Amount{Value: value, Currency: "USD"}, Dimensions: dims},
+			},
+		}
+		require.NoError(t, engine.CreateTransaction(txn, userID))
+		require.NoError(t, engine.PostTransaction(txn.ID, userID))
+	}
+	sales := Dimension{Key: DimDepartment, Value: "sales"}
+	ops := Dimension{Key: DimDepartment, Value: "ops"}
+
+	post("cash", "revenue", 999, date(2024, 11, 1))
+	post("cash", "revenue", 10000, date(2025, 2, 1), sales)
+	post("revenue", "cash", 500, date(2025, 3, 1), sales)
+	post("cash", "revenue", 4000, date(2025, 5, 1), ops)
+	post("expenses", "cash", 3000, date(2025, 6, 1), ops)
+	post("expenses", "cash", 1000, date(2025, 7, 1))
+
+	fy := &FiscalYearClose{
+		ID:                        "FY2025",
+		Name:                      "Fiscal Year 2025",
+		Start:                     date(2025, 1, 1),
+		End:                       yearEnd,
+		RetainedEarningsAccountID: "retained_earnings",
+	}
+
+	t.Run("Closing Entries", func(t *testing.T) {
+		invalid := *fy
+		invalid.RetainedEarningsAccountID = "cash"
+		_, err := engine.CloseFiscalYear(&invalid, userID)
+		assert.Error(t, err, "retained earnings must be equity")
+
+		closed, err := engine.CloseFiscalYear(fy, userID)
+		require.NoError(t, err)
+		assert.Equal(t, FiscalYearLocked, closed.Status)
+
+		require.Len(t, closed.Lines, 4)
+		require.Len(t, closed.Totals, 1)
+		assert.Equal(t, int64(13500), closed.Totals[0].Income)
+		assert.Equal(t, int64(4000), closed.Totals[0].Expenses)
+		assert.Equal(t, int64(9500), closed.Totals[0].NetIncome)
+
+		assert.Equal(t, int64(0), balance("expenses"))
+		assert.Equal(t, int64(999), balance("revenue"), "prior year activity is not closed")
+		assert.Equal(t, int64(9500), balance("retained_earnings"))
+
+		require.Len(t, closed.TransactionIDs, 1)
+		closing, err := engine.GetStorage().GetTransaction(closed.TransactionIDs[0])
+		require.NoError(t, err)
+		retained := make(map[string]int64)
+		for _, entry := range closing.Entries {
+			if entry.AccountID != "retained_earnings" {
+				continue
+			}
+			value := entry.Amount.Value
+			if entry.Type == Debit {
+				value = -value
+			}
+			retained[entryDimension(&entry, DimDepartment)] = value
+		}
+		assert.Equal(t, map[string]int64{"sales": 9500, "ops": 1000, "": -1000}, retained, "retained earnings keeps departments")
+	})
+
+	t.Run("Year Locked", func(t *testing.T) {
+		txn := &Transaction{
+			Description: "Late adjustment",
+			ValidTime:   date(2025, 8, 1),
+			Entries: []Entry{
+				{AccountID: "expenses", Type: Debit, Amount: Amount{Value: 100, Currency: "USD"}},
+				{AccountID: "cash", Type: Credit, Amount: Amount{Value: 100, Currency: "USD"}},
+			},
+		}
+		assert.Error(t, engine.CreateTransaction(txn, userID))
+
+		_, err := engine.CloseFiscalYear(fy, userID)
+		assert.Error(t, err, "a locked year cannot be closed again")
+	})
+
+	t.Run("Reopen And Reclose", func(t *testing.T) {
+		reopened, err := engine.ReopenFiscalYear("FY2025", "Audit adjustment", userID)
+		require.NoError(t, err)
+		assert.Equal(t, FiscalYearReopened, reopened.Status)
+		require.Len(t, reopened.ReversalTransactionIDs, 1)
+
+		assert.Equal(t, int64(0), balance("retained_earnings"))
+		assert.Equal(t, int64(14499), balance("revenue"))
+		assert.Equal(t, int64(4000), balance("expenses"))
+
+		post("expenses", "cash", 100, date(2025, 8, 1))
+
+		closed, err := engine.CloseFiscalYear(&FiscalYearClose{
+			ID:                        "FY2025",
+			Name:                      "Fiscal Year 2025",
+			Start:                     date(2025, 1, 1),
+			End:                       yearEnd,
+			RetainedEarningsAccountID: "retained_earnings",
+			DimensionKeys:             []DimensionKey{DimProject},
+		}, userID)
+		require.NoError(t, err)
+		assert.Len(t, closed.Lines, 2, "departments are not kept")
+		assert.Equal(t, int64(9400), closed.Totals[0].NetIncome)
+		assert.Equal(t, int64(9400), balance("retained_earnings"))
+
+		stored, err := engine.GetFiscalYearClose("FY2025")
+		require.NoError(t, err)
+		assert.Equal(t, FiscalYearLocked, stored.Status)
+		assert.Empty(t, stored.ReopenReason)
+	})
+}
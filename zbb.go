@@ -2,9 +2,9 @@ package accounting
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 	"time"
-
-	"github.com/google/uuid"
 )
 
 // ----------------------------------------------------------------------------
@@ -49,6 +49,7 @@ type BudgetRequest struct {
 	SubmittedAt    *time.Time          `json:"submitted_at,omitempty"`
 	ApprovedAt     *time.Time          `json:"approved_at,omitempty"`
 	ApprovedBy     string              `json:"approved_by,omitempty"`
+	Version        int64               `json:"version"` // incremented on each save
 }
 
 type BudgetRequestStatus string
@@ -97,7 +98,7 @@ type Justification struct {
 	RiskOfNotFunding string                `json:"risk_of_not_funding"` // What happens if not funded
 	Alternatives     []Alternative         `json:"alternatives,omitempty"`
 	Metrics          []JustificationMetric `json:"metrics,omitempty"`
-	SupportingDocs   []string              `json:"supporting_docs,omitempty"`
+	SupportingDocs   []string              `json:"supporting_docs,omitempty"` // document IDs, see DocumentService
 	CreatedAt        time.Time             `json:"created_at"`
 	CreatedBy        string                `json:"created_by"`
 }
@@ -175,26 +176,46 @@ type BudgetTracking struct {
 	Description     string    `json:"description"`
 	TrackedAt       time.Time `json:"tracked_at"`
 	RemainingBudget *Amount   `json:"remaining_budget"`
+	OverBudget      bool      `json:"over_budget"` // the allocation was over budget after this spending
 }
 
 // ----------------------------------------------------------------------------
 // Zero-Based Budgeting Service
 // ----------------------------------------------------------------------------
 
+// WorkflowBudgetRequest is the review of zero-based budget requests; its subjects
+// are budget request IDs
+const WorkflowBudgetRequest = "budget_request"
+
 type ZBBService struct {
-	storage *Storage
+	storage       *Storage
+	eventStore    *EventStore
+	workflows     *WorkflowService
+	budgetControl BudgetControlPolicy
+	onWarning     func(*BudgetWarning)
+	mutex         sync.Mutex
 }
 
-func NewZBBService(storage *Storage) *ZBBService {
-	return &ZBBService{
-		storage: storage,
+func NewZBBService(storage *Storage, eventStore *EventStore) *ZBBService {
+	zbb := &ZBBService{
+		storage:    storage,
+		eventStore: eventStore,
 	}
+	zbb.UseWorkflows(NewWorkflowService(storage))
+	return zbb
+}
+
+// UseWorkflows runs budget request review on a shared workflow service. Call it
+// while the engine is being assembled.
+func (zbb *ZBBService) UseWorkflows(workflows *WorkflowService) {
+	workflows.mustRegister(zbb.workflow())
+	zbb.workflows = workflows
 }
 
 // CreateBudgetPeriod creates a new budget period
 func (zbb *ZBBService) CreateBudgetPeriod(period *BudgetPeriod, userID string) error {
-	if period.ID == "" {
-		period.ID = uuid.New().String()
+	if err := zbb.storage.assignID(&period.ID, "budget period", BucketBudgetPeriods); err != nil {
+		return err
 	}
 	period.CreatedAt = time.Now()
 	period.CreatedBy = userID
@@ -203,10 +224,19 @@ func (zbb *ZBBService) CreateBudgetPeriod(period *BudgetPeriod, userID string) e
 	return zbb.storage.SaveBudgetPeriod(period)
 }
 
-// CreateBudgetRequest creates a new zero-based budget request
+// CreateBudgetRequest creates a new zero-based budget request. Once departments are
+// registered the request's department must be active in the budget period, and its
+// default dimensions are added to the line items.
 func (zbb *ZBBService) CreateBudgetRequest(request *BudgetRequest, userID string) error {
-	if request.ID == "" {
-		request.ID = uuid.New().String()
+	department, err := zbb.budgetDepartment(request.DepartmentID, request.PeriodID)
+	if err != nil {
+		return err
+	}
+	if err := zbb.storage.assignID(&request.ID, "budget request", BucketBudgetRequests); err != nil {
+		return err
+	}
+	if department != nil {
+		applyDepartmentDefaults(department, request.LineItems)
 	}
 
 	request.CreatedAt = time.Now()
@@ -234,7 +264,7 @@ func (zbb *ZBBService) AddJustification(requestID string, justification *Justifi
 	}
 
 	if justification.ID == "" {
-		justification.ID = uuid.New().String()
+		justification.ID = newID()
 	}
 	justification.CreatedAt = time.Now()
 	justification.CreatedBy = userID
@@ -247,66 +277,119 @@ func (zbb *ZBBService) AddJustification(requestID string, justification *Justifi
 
 // SubmitBudgetRequest submits request for approval
 func (zbb *ZBBService) SubmitBudgetRequest(requestID string, userID string) error {
-	request, err := zbb.storage.GetBudgetRequest(requestID)
-	if err != nil {
-		return fmt.Errorf("failed to get budget request: %w", err)
-	}
+	return zbb.fireRequest(requestID, &WorkflowRequest{Action: "submit", Actor: userID})
+}
 
-	if request.Status != BudgetRequestDraft {
-		return fmt.Errorf("can only submit draft requests")
+// ReviewBudgetRequest marks a submitted request as under review by the reviewer
+func (zbb *ZBBService) ReviewBudgetRequest(requestID string, reviewerID string) error {
+	return zbb.fireRequest(requestID, &WorkflowRequest{Action: "review", Actor: reviewerID, Assignees: []string{reviewerID}})
+}
+
+// ApproveBudgetRequest approves a budget request
+func (zbb *ZBBService) ApproveBudgetRequest(requestID string, approverID string, approvedAmount *Amount, comments string) error {
+	return zbb.fireRequest(requestID, &WorkflowRequest{Action: "approve", Actor: approverID, Comment: comments, Assignees: []string{}, Data: approvedAmount})
+}
+
+// RejectBudgetRequest rejects a budget request; the reason is required
+func (zbb *ZBBService) RejectBudgetRequest(requestID string, approverID string, reason string) error {
+	return zbb.fireRequest(requestID, &WorkflowRequest{Action: "reject", Actor: approverID, Comment: reason, Assignees: []string{}})
+}
+
+// RequestBudgetRevision sends a budget request back to its requestor for changes;
+// the comment is required
+func (zbb *ZBBService) RequestBudgetRevision(requestID string, approverID string, comment string) error {
+	return zbb.fireRequest(requestID, &WorkflowRequest{Action: "request_revision", Actor: approverID, Comment: comment, Assignees: []string{}})
+}
+
+// fireRequest takes a review action on a budget request
+func (zbb *ZBBService) fireRequest(requestID string, request *WorkflowRequest) error {
+	_, err := zbb.workflows.Fire(WorkflowBudgetRequest, requestID, request)
+	return err
+}
+
+// workflow defines how budget requests move from draft to approval
+func (zbb *ZBBService) workflow() *WorkflowDefinition {
+	draft, submitted := string(BudgetRequestDraft), string(BudgetRequestSubmitted)
+	underReview, revision := string(BudgetRequestUnderReview), string(BudgetRequestRevisionRequired)
+	return &WorkflowDefinition{
+		Name: WorkflowBudgetRequest,
+		Transitions: []WorkflowTransition{
+			{Action: "submit", From: []string{draft, revision}, To: submitted, Guard: zbb.justified},
+			{Action: "review", From: []string{submitted}, To: underReview},
+			{Action: "approve", From: []string{submitted, underReview}, To: string(BudgetRequestApproved)},
+			{Action: "reject", From: []string{submitted, underReview}, To: string(BudgetRequestRejected), RequireComment: true},
+			{Action: "request_revision", From: []string{submitted, underReview}, To: revision, RequireComment: true},
+		},
+		State: func(requestID string) (string, error) {
+			request, err := zbb.storage.GetBudgetRequest(requestID)
+			if err != nil {
+				return "", fmt.Errorf("failed to get budget request: %w", err)
+			}
+			return string(request.Status), nil
+		},
+		NotAllowed: func(instance *WorkflowInstance, request *WorkflowRequest) error {
+			switch request.Action {
+			case "submit":
+				return fmt.Errorf("can only submit draft requests")
+			case "approve":
+				return fmt.Errorf("can only approve submitted requests")
+			}
+			return fmt.Errorf("can only %s submitted requests", strings.ReplaceAll(request.Action, "_", " "))
+		},
+		OnTransition: zbb.applyTransition,
 	}
+}
 
-	// Validate that all line items have justifications
+// justified checks that every line item has a justification
+func (zbb *ZBBService) justified(instance *WorkflowInstance, _ *WorkflowRequest) error {
+	request, err := zbb.storage.GetBudgetRequest(instance.SubjectID)
+	if err != nil {
+		return fmt.Errorf("failed to get budget request: %w", err)
+	}
 	for _, item := range request.LineItems {
 		if item.Justification == "" {
 			return fmt.Errorf("line item '%s' missing justification", item.Description)
 		}
 	}
-
-	request.Status = BudgetRequestSubmitted
-	now := time.Now()
-	request.SubmittedAt = &now
-	request.UpdatedAt = now
-
-	return zbb.storage.SaveBudgetRequest(request)
+	return nil
 }
 
-// ApproveBudgetRequest approves a budget request
-func (zbb *ZBBService) ApproveBudgetRequest(requestID string, approverID string, approvedAmount *Amount, comments string) error {
-	request, err := zbb.storage.GetBudgetRequest(requestID)
+// applyTransition records a review step on the budget request
+func (zbb *ZBBService) applyTransition(instance *WorkflowInstance, wr *WorkflowRequest, _ string) error {
+	request, err := zbb.storage.GetBudgetRequest(instance.SubjectID)
 	if err != nil {
 		return fmt.Errorf("failed to get budget request: %w", err)
 	}
+	now := instance.EnteredAt
+	request.Status = BudgetRequestStatus(instance.State)
+	request.UpdatedAt = now
 
-	if request.Status != BudgetRequestSubmitted && request.Status != BudgetRequestUnderReview {
-		return fmt.Errorf("can only approve submitted requests")
-	}
-
-	// Create approval record
-	approval := &BudgetApproval{
-		ID:             uuid.New().String(),
-		RequestID:      requestID,
-		ApproverID:     approverID,
-		ApproverLevel:  1, // Simplified for demo
-		Status:         ApprovalApproved,
-		ApprovedAmount: approvedAmount,
-		Comments:       comments,
-		CreatedAt:      time.Now(),
-		ApprovedAt:     &[]time.Time{time.Now()}[0],
-	}
-
-	err = zbb.storage.SaveBudgetApproval(approval)
-	if err != nil {
-		return fmt.Errorf("failed to save approval: %w", err)
+	switch wr.Action {
+	case "submit":
+		request.SubmittedAt = &now
+	case "approve", "reject":
+		approval := &BudgetApproval{
+			ID:            newID(),
+			RequestID:     request.ID,
+			ApproverID:    wr.Actor,
+			ApproverLevel: 1, // Simplified for demo
+			Status:        ApprovalRejected,
+			Comments:      wr.Comment,
+			CreatedAt:     now,
+		}
+		if wr.Action == "approve" {
+			approvedAmount, _ := wr.Data.(*Amount)
+			approval.Status = ApprovalApproved
+			approval.ApprovedAmount = approvedAmount
+			approval.ApprovedAt = &now
+			request.ApprovedAt = &now
+			request.ApprovedBy = wr.Actor
+		}
+		if err := zbb.storage.SaveBudgetApproval(approval); err != nil {
+			return fmt.Errorf("failed to save approval: %w", err)
+		}
 	}
 
-	// Update request status
-	request.Status = BudgetRequestApproved
-	now := time.Now()
-	request.ApprovedAt = &now
-	request.ApprovedBy = approverID
-	request.UpdatedAt = now
-
 	return zbb.storage.SaveBudgetRequest(request)
 }
 
@@ -320,11 +403,14 @@ func (zbb *ZBBService) CreateBudgetAllocation(requestID string, userID string) e
 	if request.Status != BudgetRequestApproved {
 		return fmt.Errorf("can only allocate approved requests")
 	}
+	if _, err := zbb.budgetDepartment(request.DepartmentID, request.PeriodID); err != nil {
+		return err
+	}
 
 	// Create allocations for each line item
 	for _, item := range request.LineItems {
 		allocation := &BudgetAllocation{
-			ID:           uuid.New().String(),
+			ID:           newID(),
 			PeriodID:     request.PeriodID,
 			RequestID:    requestID,
 			DepartmentID: request.DepartmentID,
@@ -347,7 +433,9 @@ func (zbb *ZBBService) CreateBudgetAllocation(requestID string, userID string) e
 	return nil
 }
 
-// TrackBudgetSpending tracks actual spending against budget allocations
+// TrackBudgetSpending tracks actual spending against budget allocations. Posted
+// transactions are tracked automatically; this is for allocations whose spending
+// should be recorded by hand. A transaction is tracked against an allocation once.
 func (zbb *ZBBService) TrackBudgetSpending(transactionID string, allocationID string) error {
 	// Get transaction to validate amount
 	txn, err := zbb.storage.GetTransaction(transactionID)
@@ -355,10 +443,16 @@ func (zbb *ZBBService) TrackBudgetSpending(transactionID string, allocationID st
 		return fmt.Errorf("failed to get transaction: %w", err)
 	}
 
+	zbb.mutex.Lock()
+	defer zbb.mutex.Unlock()
+
 	allocation, err := zbb.storage.GetBudgetAllocation(allocationID)
 	if err != nil {
 		return fmt.Errorf("failed to get allocation: %w", err)
 	}
+	if zbb.storage.HasBudgetTracking(allocationID, transactionID) {
+		return fmt.Errorf("transaction %s is already tracked against allocation %s", transactionID, allocationID)
+	}
 
 	// Calculate spending amount from transaction entries
 	var spendAmount int64
@@ -372,27 +466,8 @@ func (zbb *ZBBService) TrackBudgetSpending(transactionID string, allocationID st
 		return fmt.Errorf("no valid spending found for account %s", allocation.AccountID)
 	}
 
-	// Update allocation
-	allocation.SpentAmount.Value += spendAmount
-	allocation.Remaining.Value -= spendAmount
-	allocation.UpdatedAt = time.Now()
-
-	err = zbb.storage.SaveBudgetAllocation(allocation)
-	if err != nil {
-		return fmt.Errorf("failed to update allocation: %w", err)
-	}
-
-	// Create tracking record
-	tracking := &BudgetTracking{
-		AllocationID:    allocationID,
-		TransactionID:   transactionID,
-		Amount:          &Amount{Value: spendAmount, Currency: allocation.Amount.Currency},
-		Description:     txn.Description,
-		TrackedAt:       time.Now(),
-		RemainingBudget: &Amount{Value: allocation.Remaining.Value, Currency: allocation.Amount.Currency},
-	}
-
-	return zbb.storage.SaveBudgetTracking(tracking)
+	_, err = zbb.consumeAllocation(allocation, txn, spendAmount)
+	return err
 }
 
 // GetBudgetVariance calculates variance between budget and actual